@@ -0,0 +1,97 @@
+// Command api-token issues, lists and revokes the API tokens partner
+// integrations use to call the GraphQL API.
+//
+//	go run ./cmd/api-token -email=partner@example.com -name="Ticket sync"
+//	go run ./cmd/api-token -email=partner@example.com -list
+//	go run ./cmd/api-token -email=partner@example.com -revoke=3
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+
+	"event-ticketing-platform/internal/config"
+	"event-ticketing-platform/internal/database"
+	"event-ticketing-platform/internal/repositories"
+	"event-ticketing-platform/internal/services"
+)
+
+func main() {
+	var (
+		emailFlag  = flag.String("email", "", "Account the token acts as")
+		nameFlag   = flag.String("name", "", "Name of the integration the token is for")
+		listFlag   = flag.Bool("list", false, "List the account's tokens instead of issuing one")
+		revokeFlag = flag.Int("revoke", 0, "ID of a token to revoke")
+	)
+	flag.Parse()
+
+	if *emailFlag == "" {
+		log.Fatal("-email is required")
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Connect to database
+	dbConfig := database.Config{
+		URL:      cfg.Database.URL,
+		Host:     cfg.Database.Host,
+		Port:     cfg.Database.Port,
+		User:     cfg.Database.User,
+		Password: cfg.Database.Password,
+		DBName:   cfg.Database.DBName,
+		SSLMode:  cfg.Database.SSLMode,
+	}
+
+	db, err := database.NewConnection(dbConfig)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	userRepo := repositories.NewUserRepository(db.DB)
+	tokenService := services.NewAPITokenService(repositories.NewAPITokenRepository(db.DB), userRepo)
+
+	user, err := userRepo.GetByEmail(*emailFlag)
+	if err != nil {
+		log.Fatalf("Failed to find %s: %v", *emailFlag, err)
+	}
+
+	switch {
+	case *revokeFlag > 0:
+		if err := tokenService.RevokeToken(*revokeFlag, user.ID); err != nil {
+			log.Fatalf("Failed to revoke token: %v", err)
+		}
+		fmt.Printf("Revoked token %d\n", *revokeFlag)
+
+	case *listFlag:
+		tokens, err := tokenService.GetTokens(user.ID)
+		if err != nil {
+			log.Fatalf("Failed to list tokens: %v", err)
+		}
+		for _, token := range tokens {
+			state := "active"
+			if token.IsRevoked() {
+				state = "revoked"
+			}
+			lastUsed := "never"
+			if token.LastUsedAt != nil {
+				lastUsed = token.LastUsedAt.Format("2006-01-02 15:04")
+			}
+			fmt.Printf("%d\t%s...\t%s\t%s\tlast used %s\n", token.ID, token.Prefix, token.Name, state, lastUsed)
+		}
+
+	default:
+		raw, token, err := tokenService.IssueToken(user.ID, *nameFlag)
+		if err != nil {
+			log.Fatalf("Failed to issue token: %v", err)
+		}
+		fmt.Printf("Issued token %d for %s (%s)\n", token.ID, user.Email, token.Name)
+		fmt.Printf("Token: %s\n", raw)
+		fmt.Println("Store it now, it cannot be shown again.")
+	}
+}
@@ -0,0 +1,26 @@
+// Command assets writes the static asset manifest, so servers don't hash the
+// files at startup. Run it after building CSS and before deploying; the
+// manifest must be rewritten whenever a static file changes.
+//
+//	go run ./cmd/assets
+//	go run ./cmd/assets -dir=web/static
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"path/filepath"
+
+	"event-ticketing-platform/internal/assets"
+)
+
+func main() {
+	dirFlag := flag.String("dir", "web/static", "Directory of static files")
+	flag.Parse()
+
+	if err := assets.WriteManifest(*dirFlag); err != nil {
+		log.Fatalf("Failed to write asset manifest: %v", err)
+	}
+	fmt.Printf("Wrote %s\n", filepath.Join(*dirFlag, assets.ManifestFile))
+}
@@ -79,7 +79,7 @@ func main() {
 	eventRows.Close()
 
 	if len(events) == 0 {
-		log.Fatal("No published events found. Please run seed-simple first.")
+		log.Fatal("No published events found. Please run go run ./cmd/seed -profile demo first.")
 	}
 
 	fmt.Printf("📅 Found %d events to create orders for\n", len(events))
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"log"
+	"os"
+
+	"event-ticketing-platform/internal/config"
+	"event-ticketing-platform/internal/database"
+	"event-ticketing-platform/internal/repositories"
+	"event-ticketing-platform/internal/services"
+)
+
+func main() {
+	var (
+		dryRunFlag    = flag.Bool("dry-run", false, "Show what would be migrated without uploading or changing events")
+		sourceFlag    = flag.String("source", "./uploads", "Local uploads directory")
+		sourceURLFlag = flag.String("source-url", "http://localhost:8080/uploads", "URL local uploads were served from")
+	)
+	flag.Parse()
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Connect to database
+	dbConfig := database.Config{
+		URL:      cfg.Database.URL,
+		Host:     cfg.Database.Host,
+		Port:     cfg.Database.Port,
+		User:     cfg.Database.User,
+		Password: cfg.Database.Password,
+		DBName:   cfg.Database.DBName,
+		SSLMode:  cfg.Database.SSLMode,
+	}
+
+	db, err := database.NewConnection(dbConfig)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	// The migration resumes from the items recorded by migration 031
+	if err := db.RunMigrations(); err != nil {
+		log.Fatalf("Failed to run migrations: %v", err)
+	}
+
+	r2Service, err := services.NewR2Service(cfg.R2)
+	if err != nil {
+		log.Fatalf("R2 is not configured: %v", err)
+	}
+	if err := r2Service.HealthCheck(context.Background()); err != nil {
+		log.Fatalf("R2 health check failed: %v", err)
+	}
+
+	source := services.NewFallbackStorageService(*sourceFlag, *sourceURLFlag)
+	migrationService := services.NewStorageMigrationService(
+		source,
+		r2Service,
+		repositories.NewStorageMigrationRepository(db.DB),
+		services.StorageMigrationOptions{
+			DryRun:     *dryRunFlag,
+			SourceURLs: []string{"/uploads", *sourceURLFlag},
+		},
+	)
+
+	if *dryRunFlag {
+		fmt.Println("Dry run: nothing will be uploaded or changed")
+	}
+	fmt.Printf("Migrating %s to R2 bucket %s...\n", *sourceFlag, cfg.R2.BucketName)
+
+	report, err := migrationService.Migrate(context.Background())
+	if report != nil {
+		fmt.Printf("\nStorage Migration:\n")
+		fmt.Printf("  Scanned files: %d\n", report.ScannedObjects)
+		fmt.Printf("  Uploaded files: %d (%d bytes)\n", report.UploadedObjects, report.UploadedBytes)
+		fmt.Printf("  Already migrated: %d\n", report.ResumedObjects)
+		fmt.Printf("  Failed files: %d\n", report.FailedObjects)
+		fmt.Printf("  Events rewritten: %d\n", report.EventsRewritten)
+		fmt.Printf("  Events not rewritten: %d\n", report.EventsUnresolved)
+		for _, message := range report.Errors {
+			fmt.Printf("  - %s\n", message)
+		}
+	}
+	if err != nil {
+		log.Fatalf("Storage migration failed: %v", err)
+	}
+
+	if report.FailedObjects > 0 || report.EventsUnresolved > 0 {
+		fmt.Println("\nSome files were not migrated. Fix the errors above and run the command again to resume.")
+		os.Exit(1)
+	}
+
+	fmt.Println("\nStorage migration completed successfully!")
+}
@@ -14,6 +14,10 @@ func main() {
 	var (
 		statusFlag = flag.Bool("status", false, "Show migration status")
 		upFlag     = flag.Bool("up", false, "Run pending migrations")
+		downFlag   = flag.Bool("down", false, "Roll back the last applied migration (see -steps)")
+		stepsFlag  = flag.Int("steps", 1, "Number of migrations -down rolls back")
+		toFlag     = flag.Int("to", -1, "Apply or roll back migrations to this version (0 rolls back everything)")
+		forceFlag  = flag.Int("force", -1, "Clear a dirty migration, recording the database as at this version")
 	)
 	flag.Parse()
 
@@ -50,10 +54,27 @@ func main() {
 			log.Fatalf("Failed to run migrations: %v", err)
 		}
 		fmt.Println("All migrations completed successfully!")
+	case *downFlag:
+		if err := db.RollbackMigrations(*stepsFlag); err != nil {
+			log.Fatalf("Failed to roll back migrations: %v", err)
+		}
+		fmt.Println("Rollback completed successfully!")
+	case *toFlag >= 0:
+		if err := db.MigrateTo(*toFlag); err != nil {
+			log.Fatalf("Failed to migrate to version %d: %v", *toFlag, err)
+		}
+		fmt.Printf("Database is at version %d\n", *toFlag)
+	case *forceFlag >= 0:
+		if err := db.ForceMigration(*forceFlag); err != nil {
+			log.Fatalf("Failed to force migration: %v", err)
+		}
 	default:
 		fmt.Println("Usage:")
-		fmt.Println("  go run cmd/migrate/main.go -status   # Show migration status")
-		fmt.Println("  go run cmd/migrate/main.go -up       # Run pending migrations")
+		fmt.Println("  go run cmd/migrate/main.go -status            # Show migration status")
+		fmt.Println("  go run cmd/migrate/main.go -up                # Run pending migrations")
+		fmt.Println("  go run cmd/migrate/main.go -down [-steps N]   # Roll back the last N migrations (default 1)")
+		fmt.Println("  go run cmd/migrate/main.go -to VERSION        # Migrate up or down to VERSION")
+		fmt.Println("  go run cmd/migrate/main.go -force VERSION     # Clear a dirty migration after fixing it by hand")
 		os.Exit(1)
 	}
 }
\ No newline at end of file
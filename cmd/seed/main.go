@@ -0,0 +1,77 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"strings"
+
+	"event-ticketing-platform/internal/config"
+	"event-ticketing-platform/internal/database"
+	"event-ticketing-platform/internal/repositories"
+	"event-ticketing-platform/internal/seed"
+)
+
+func main() {
+	var (
+		profileFlag  = flag.String("profile", "demo", "Built-in profile ("+strings.Join(seed.ProfileNames(), ", ")+") or path to a .yaml/.json profile")
+		passwordFlag = flag.String("password", "", "Password for the seeded users, overriding the profile's")
+	)
+	flag.Parse()
+
+	profile, err := seed.LoadProfile(*profileFlag)
+	if err != nil {
+		log.Fatalf("Failed to load profile: %v", err)
+	}
+	if *passwordFlag != "" {
+		profile.Password = *passwordFlag
+		if err := profile.Validate(); err != nil {
+			log.Fatalf("Invalid profile: %v", err)
+		}
+	}
+
+	// Load configuration
+	cfg, err := config.Load()
+	if err != nil {
+		log.Fatalf("Failed to load config: %v", err)
+	}
+
+	// Connect to database
+	dbConfig := database.Config{
+		URL:      cfg.Database.URL,
+		Host:     cfg.Database.Host,
+		Port:     cfg.Database.Port,
+		User:     cfg.Database.User,
+		Password: cfg.Database.Password,
+		DBName:   cfg.Database.DBName,
+		SSLMode:  cfg.Database.SSLMode,
+	}
+
+	db, err := database.NewConnection(dbConfig)
+	if err != nil {
+		log.Fatalf("Failed to connect to database: %v", err)
+	}
+	defer db.Close()
+
+	seeder := seed.NewSeeder(
+		repositories.NewUserRepository(db.DB),
+		repositories.NewEventRepository(db.DB),
+		repositories.NewTicketRepository(db.DB),
+		repositories.NewOrderRepository(db.DB),
+	)
+
+	fmt.Printf("Seeding profile %q: %s\n", profile.Name, profile.Description)
+	result, err := seeder.Seed(profile)
+	if result != nil {
+		fmt.Printf("Created %d users, %d events, %d ticket types and %d orders\n", result.Users, result.Events, result.TicketTypes, result.Orders)
+	}
+	if err != nil {
+		log.Fatalf("Failed to seed profile: %v", err)
+	}
+
+	fmt.Println("Seeding completed successfully!")
+	for _, user := range profile.Users {
+		fmt.Printf("  %-32s %s\n", user.Email, user.Role)
+	}
+	fmt.Println("Every seeded user's password is the profile's password (see -password).")
+}
@@ -1,21 +1,33 @@
 package main
 
 import (
+	"context"
 	"encoding/gob"
 	"fmt"
+	"io"
 	"log"
+	"log/slog"
 	"net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
+	"event-ticketing-platform/internal/assets"
+	"event-ticketing-platform/internal/cache"
 	"event-ticketing-platform/internal/config"
 	"event-ticketing-platform/internal/database"
+	"event-ticketing-platform/internal/graph"
 	"event-ticketing-platform/internal/handlers"
+	"event-ticketing-platform/internal/logging"
 	"event-ticketing-platform/internal/middleware"
 	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/ratelimit"
 	"event-ticketing-platform/internal/repositories"
+	"event-ticketing-platform/internal/server"
 	"event-ticketing-platform/internal/services"
 
 	"github.com/go-chi/chi/v5"
-	chimiddleware "github.com/go-chi/chi/v5/middleware"
 	"github.com/gorilla/sessions"
 
 	// Import packages to ensure they're included in go.mod
@@ -33,6 +45,13 @@ func main() {
 	if err != nil {
 		log.Fatal("Failed to load configuration:", err)
 	}
+	logging.Setup(cfg.Log.Format, cfg.Log.Level)
+	slog.Info("configuration loaded", "config", cfg)
+
+	// Default time zone for date-based browsing (e.g. "this weekend")
+	if err := services.SetDefaultTimeZone(cfg.Server.TimeZone); err != nil {
+		log.Printf("Warning: %v, falling back to UTC", err)
+	}
 
 	// Initialize database connection
 	dbConfig := database.Config{
@@ -53,18 +72,27 @@ func main() {
 		setupSimpleRoutes(cfg)
 		return
 	}
-	defer db.Close()
+
+	// Background jobs and resources are stopped in order on shutdown
+	lifecycle := server.NewLifecycle(cfg.Server.ShutdownTimeout)
+	lifecycle.OnClose("database", db.Close)
 	log.Println("Database connection established successfully")
 
-	// Create session store
-	sessionStore := sessions.NewCookieStore([]byte(cfg.Session.Secret))
+	// Keep sessions server-side so they can be listed and revoked; the cookie
+	// only carries the signed session ID
+	sessionStore := services.NewSessionStore(repositories.NewSessionRepository(db.DB), []byte(cfg.Session.Secret))
+	lifecycle.Every(time.Hour, func(ctx context.Context) {
+		if _, err := sessionStore.DeleteExpired(); err != nil {
+			log.Printf("Warning: expired session cleanup failed: %v", err)
+		}
+	})
 
 	// Configure session options
 	sessionStore.Options = &sessions.Options{
 		Path:     "/",
 		MaxAge:   86400 * 30, // 30 days
 		HttpOnly: true,
-		Secure:   false, // Set to true in production with HTTPS
+		Secure:   cfg.Session.SecureCookies,
 		SameSite: http.SameSiteLaxMode,
 	}
 
@@ -82,6 +110,24 @@ func main() {
 		FromEmail: cfg.Resend.FromEmail,
 		FromName:  cfg.Resend.FromName,
 	})
+	// Send through the configured provider, failing over to the fallback one
+	emailProviderConfigs := services.EmailProviderConfigs{
+		Resend: services.ResendConfig{APIKey: cfg.Resend.APIKey, FromEmail: cfg.Resend.FromEmail, FromName: cfg.Resend.FromName},
+		SMTP:   services.SMTPConfig{Host: cfg.Email.SMTPHost, Port: cfg.Email.SMTPPort, Username: cfg.Email.SMTPUser, Password: cfg.Email.SMTPPassword},
+		SES:    services.SESConfig{Region: cfg.SES.Region, AccessKeyID: cfg.SES.AccessKeyID, SecretAccessKey: cfg.SES.SecretAccessKey},
+	}
+	emailProvider, err := services.NewEmailProvider(cfg.Email.Provider, emailProviderConfigs)
+	if err != nil {
+		log.Fatal("Invalid email provider:", err)
+	}
+	if cfg.Email.FallbackProvider != "" {
+		fallbackProvider, err := services.NewEmailProvider(cfg.Email.FallbackProvider, emailProviderConfigs)
+		if err != nil {
+			log.Fatal("Invalid fallback email provider:", err)
+		}
+		emailProvider = services.NewFailoverEmailProvider(emailProvider, fallbackProvider)
+	}
+	emailService.SetProvider(emailProvider)
 	// Initialize payment service with Paystack
 	paymentService := services.NewPaystackService(services.PaystackConfig{
 		SecretKey:   cfg.Paystack.SecretKey,
@@ -90,21 +136,219 @@ func main() {
 		WebhookURL:  cfg.Paystack.WebhookURL,
 		CallbackURL: cfg.Paystack.CallbackURL,
 	})
+
+	// Track payment provider success rates so checkout can steer buyers away from failing providers
+	paymentHealthService := services.NewPaymentHealthService(services.PaymentHealthOptions{
+		Window:        cfg.PaymentHealth.Window,
+		MinAttempts:   cfg.PaymentHealth.MinAttempts,
+		DegradedBelow: float64(cfg.PaymentHealth.DegradedBelow) / 100,
+		AutoFailover:  cfg.PaymentHealth.AutoFailover,
+	})
+
+	// Direct checkouts settle through the mock provider until Paystack is
+	// configured, so staging deployments can run purchases end to end
+	var checkoutPayments services.PaymentService = paymentService
+	mockCheckout := cfg.Paystack.SecretKey == "" && cfg.Server.Env != "production"
+	if mockCheckout {
+		checkoutPayments = services.NewMockPaymentService(&cfg.Pesapal, &cfg.Paystack)
+	}
+	monitoredPaymentService := services.NewMonitoredPaymentService(checkoutPayments, paymentHealthService)
 	authService := services.NewAuthService(userRepo, emailService)
 	userService := services.NewUserService(userRepo)
 	eventService := services.NewEventService(eventRepo, authService, "uploads/events")
 
+	// Published events, completed orders, check-ins and refunds are delivered
+	// to the modules that react to them through the domain event bus
+	eventBus := services.NewDomainEventBus()
+	eventService.SetEventBus(eventBus)
+
+	// Cache hot public event reads (Redis when configured, in-memory otherwise)
+	appCache := cache.New(cfg.Redis.URL)
+	if closer, ok := appCache.(io.Closer); ok {
+		lifecycle.OnClose("cache", closer.Close)
+	}
+	eventService.SetCache(appCache)
+
 	// Initialize PDF service for ticket generation
 	pdfService := services.NewPDFService()
 
 	// Initialize ticket service with proper parameters
-	ticketService := services.NewTicketService(ticketRepo, orderRepo, paymentService, authService, pdfService, 900) // 15 minutes reservation TTL
+	ticketService := services.NewTicketService(ticketRepo, orderRepo, monitoredPaymentService, authService, pdfService, 15) // 15 minutes reservation TTL
+	ticketService.SetCache(appCache)
+	ticketService.SetEventBus(eventBus)
+	eventBus.OnOrderCompleted(ticketService) // Advances tiers and invalidates cached availability
+
+	// Clear out ticket holds left by checkouts that never completed
+	lifecycle.Every(5*time.Minute, func(ctx context.Context) {
+		if _, err := ticketService.DeleteExpiredReservations(); err != nil {
+			log.Printf("Warning: expired ticket reservation cleanup failed: %v", err)
+		}
+	})
+
+	// Live availability streams are pushed changes as tickets sell, and end
+	// when shutdown starts so requests can drain
+	availabilityBroker := services.NewAvailabilityBroker(ticketService.GetTicketAvailability)
+	ticketService.SetAvailabilityBroker(availabilityBroker)
+	lifecycle.OnShutdown(availabilityBroker.Close)
+
+	// Optional arrival windows buyers choose at checkout, printed on tickets
+	arrivalSlotService := services.NewArrivalSlotService(repositories.NewArrivalSlotRepository(db.DB))
+	ticketService.SetArrivalSlots(arrivalSlotService)
+
+	// Retried checkouts and replayed payment callbacks are given the first
+	// attempt's result instead of creating another order
+	idempotencyService := services.NewIdempotencyService(repositories.NewIdempotencyRepository(db.DB))
+	lifecycle.Every(time.Hour, func(ctx context.Context) {
+		if _, err := idempotencyService.DeleteExpired(); err != nil {
+			log.Printf("Warning: expired idempotency key cleanup failed: %v", err)
+		}
+	})
+
+	// Optional queues for flash on-sales, admitting visitors to their carts
+	// at each event's rate. The queue lives in the shared cache.
+	waitingRoomService := services.NewWaitingRoomService(repositories.NewWaitingRoomRepository(db.DB), appCache, []byte(cfg.Session.Secret))
+
+	// Custom questions asked of every attendee at checkout, kept with their tickets
+	checkoutQuestionService := services.NewCheckoutQuestionService(repositories.NewCheckoutQuestionRepository(db.DB), ticketRepo)
+	ticketService.SetCheckoutAnswers(checkoutQuestionService)
+
+	// Named tickets: who each ticket is for, editable until shortly before the event
+	ticketAttendeeService := services.NewTicketAttendeeService(ticketRepo, orderRepo, eventRepo, cfg.Tickets.AttendeeEditCutoff)
+	ticketService.SetAttendees(ticketAttendeeService)
+
+	// Ticket types chained into pricing tiers, e.g. Early Bird then Regular
+	ticketService.SetTierRepository(ticketRepo)
+
+	walletPassService, err := services.NewWalletPassService(services.WalletPassConfig{
+		BaseURL:                   cfg.Server.BaseURL,
+		OrganizationName:          cfg.Wallet.OrganizationName,
+		ApplePassTypeID:           cfg.Wallet.ApplePassTypeID,
+		AppleTeamID:               cfg.Wallet.AppleTeamID,
+		AppleCertificate:          cfg.Wallet.AppleCertificate,
+		ApplePrivateKey:           cfg.Wallet.ApplePrivateKey,
+		AppleWWDRCertificate:      cfg.Wallet.AppleWWDRCertificate,
+		GoogleIssuerID:            cfg.Wallet.GoogleIssuerID,
+		GoogleServiceAccountEmail: cfg.Wallet.GoogleServiceAccountEmail,
+		GooglePrivateKey:          cfg.Wallet.GooglePrivateKey,
+	})
+	if err != nil {
+		log.Printf("Warning: wallet passes disabled: %v", err)
+	} else {
+		ticketService.SetWalletPassService(walletPassService)
+	}
 
 	// Initialize order service
 	orderService := services.NewOrderService(orderRepo, ticketRepo, userRepo, paymentService, emailService)
+	orderService.SetEventBus(eventBus)
+	orderService.SetTicketAttendees(ticketAttendeeService)
+
+	// Send order emails in the buyer's language, falling back to the event's
+	localeService := services.NewLocaleService(repositories.NewLocaleRepository(db.DB))
+	orderService.SetLocaleResolver(localeService)
+	eventBus.OnRefundIssued(orderService) // Emails refund notices
+
+	// Initialize organizer notifications, driven by completed orders
+	notificationRepo := repositories.NewNotificationRepository(db.DB)
+	notificationService := services.NewNotificationService(notificationRepo, eventRepo, ticketRepo, userRepo, emailService, cfg.Server.BaseURL)
+	paymentHealthService.SetAlerter(notificationService)
+	eventBus.OnOrderCompleted(notificationService)
+
+	// Periodically warn organizers about ticket sales closing within 24 hours
+	lifecycle.Every(time.Hour, func(ctx context.Context) {
+		if err := notificationService.CheckSalesEnding(); err != nil {
+			log.Printf("Warning: sales ending check failed: %v", err)
+		}
+	})
+
+	// Stream or meeting links of online events, shown only to ticket holders
+	eventAccessService := services.NewEventAccessService(repositories.NewEventAccessRepository(db.DB))
+
+	// Agendas of sessions and lineups of speakers or performers
+	eventAgendaService := services.NewEventAgendaService(repositories.NewEventAgendaRepository(db.DB), "uploads/speakers")
+
+	// FAQs and policies organizers publish for their events
+	eventFAQService := services.NewEventFAQService(repositories.NewEventFAQRepository(db.DB))
+	orderService.SetEventInfo(eventFAQService)
+
+	// Email ticket holders 7 days, 24 hours and 2 hours before their events
+	eventReminderService := services.NewEventReminderService(repositories.NewEventReminderRepository(db.DB), eventRepo, emailService, cfg.Server.BaseURL)
+	eventReminderService.SetAccessService(eventAccessService)
+	lifecycle.Every(10*time.Minute, func(ctx context.Context) {
+		if _, err := eventReminderService.SendDueReminders(); err != nil {
+			log.Printf("Warning: event reminders failed: %v", err)
+		}
+	})
+
+	// Record ticket price changes and email attendees who saved an event when
+	// its prices drop or an early-bird tier is about to end
+	favoriteService := services.NewFavoriteService(repositories.NewFavoriteRepository(db.DB))
+	priceHistoryService := services.NewPriceHistoryService(repositories.NewPriceHistoryRepository(db.DB), favoriteService, eventRepo, emailService, cfg.Server.BaseURL)
+	ticketService.SetPriceHistory(priceHistoryService)
+	lifecycle.Every(10*time.Minute, func(ctx context.Context) {
+		if _, err := priceHistoryService.SendPriceAlerts(); err != nil {
+			log.Printf("Warning: price alerts failed: %v", err)
+		}
+	})
+
+	// Analytics and marketing consent, which gates tracking and follower announcements
+	privacyService := services.NewPrivacyService(repositories.NewPrivacyRepository(db.DB))
+	privacyHandler := handlers.NewPrivacyHandler(privacyService)
+
+	// Organizer storefronts; followers are emailed when organizers publish events
+	storefrontService := services.NewStorefrontService(repositories.NewStorefrontRepository(db.DB), eventRepo, userRepo, emailService, cfg.Server.BaseURL, "uploads/organizers")
+	// Venues organizers reuse across events, each with a public page
+	venueService := services.NewVenueService(repositories.NewVenueRepository(db.DB), "uploads/venues")
+	storefrontService.SetMarketingConsent(privacyService)
+	eventBus.OnEventPublished(storefrontService)
+	lifecycle.Every(10*time.Minute, func(ctx context.Context) {
+		if _, err := storefrontService.AnnounceNewEvents(); err != nil {
+			log.Printf("Warning: new event announcements failed: %v", err)
+		}
+	})
+
+	// Email attendees when a newly published event matches a search they saved
+	savedSearchService := services.NewSavedSearchService(repositories.NewSavedSearchRepository(db.DB), eventRepo, emailService, cfg.Server.BaseURL)
+	eventBus.OnEventPublished(savedSearchService)
+	lifecycle.Every(10*time.Minute, func(ctx context.Context) {
+		if _, err := savedSearchService.MatchNewEvents(); err != nil {
+			log.Printf("Warning: saved search matching failed: %v", err)
+		}
+	})
 
 	// Initialize analytics service
 	analyticsService := services.NewAnalyticsService(db.DB, orderRepo, eventRepo, ticketRepo, userRepo)
+	analyticsService.SetCache(appCache)
+	analyticsService.SetCheckoutQuestions(checkoutQuestionService)
+	eventBus.OnOrderCompleted(analyticsService)
+	eventBus.OnRefundIssued(analyticsService)
+
+	// Track event page views for the top of sales funnels, rolling up each
+	// day's views once it is over
+	eventViewService := services.NewEventViewService(repositories.NewEventViewRepository(db.DB))
+	analyticsService.SetEventViewCounter(eventViewService)
+	lifecycle.Every(time.Hour, func(ctx context.Context) {
+		if _, err := eventViewService.RollupViews(); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	})
+
+	// Let buyers pay for expensive orders in installments, charging the rest
+	// to their card as they fall due once Paystack is configured
+	installmentService := services.NewInstallmentService(repositories.NewInstallmentRepository(db.DB), orderRepo, orderService, paymentService, emailService, cfg.Server.BaseURL)
+	if cfg.Paystack.SecretKey != "" {
+		lifecycle.Every(time.Hour, func(ctx context.Context) {
+			if _, err := installmentService.ProcessDue(ctx); err != nil {
+				log.Printf("Warning: installment charging failed: %v", err)
+			}
+		})
+	}
+
+	// Let organizers ask buyers for a donation with their order
+	donationService := services.NewDonationService(repositories.NewDonationRepository(db.DB))
+
+	// Charge organizers' tax on tickets, shown on receipts and statements
+	taxService := services.NewTaxService(repositories.NewTaxRepository(db.DB))
+	ticketService.SetTaxRates(taxService)
 
 	// Initialize storage service (R2 or fallback)
 	var storageService services.StorageService
@@ -124,6 +368,26 @@ func main() {
 
 	// Initialize image service
 	imageService := services.NewImageService(storageService)
+	imageService.SetUploadLimits(services.ImageUploadLimits{
+		MaxWidth:      cfg.Uploads.MaxImageWidth,
+		MaxHeight:     cfg.Uploads.MaxImageHeight,
+		MaxMegapixels: cfg.Uploads.MaxImageMegapixels,
+	})
+	if cfg.Uploads.ClamAVAddress != "" {
+		clamAV := services.NewClamAVScanner(cfg.Uploads.ClamAVAddress, cfg.Uploads.ClamAVTimeout)
+		pingCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		if err := clamAV.Ping(pingCtx); err != nil {
+			log.Printf("ClamAV is unreachable, uploads will be refused until it is: %v", err)
+		}
+		cancel()
+		imageService.SetUploadScanner(clamAV)
+	}
+
+	// Event images stored in R2 get cropped variants for srcset; local
+	// uploads are kept as is
+	if _, ok := storageService.(*services.R2Service); ok {
+		eventService.SetImageService(imageService)
+	}
 
 	// Test connections
 	if err := emailService.TestConnection(); err != nil {
@@ -140,13 +404,60 @@ func main() {
 
 	// Initialize handlers
 	publicHandler := handlers.NewPublicHandler(eventService, ticketService)
+	eventTranslationService := services.NewEventTranslationService(repositories.NewEventTranslationRepository(db.DB), localeService)
+	publicHandler.SetTranslationService(eventTranslationService)
+	publicHandler.SetAvailabilityBroker(availabilityBroker)
+	publicHandler.SetFavoriteService(favoriteService)
 	authHandler := handlers.NewAuthHandler(authService, sessionStore)
 	dashboardHandler := handlers.NewDashboardHandler(orderService, eventService, ticketService)
+	dashboardHandler.SetEventAccessService(eventAccessService)
+	dashboardHandler.SetEventAgendaService(eventAgendaService)
+
+	// Initialize calendar downloads and attendee calendar feeds
+	ticketCalendarService := services.NewTicketCalendarService(eventRepo, cfg.Server.BaseURL, cfg.Session.Secret)
+	ticketCalendarHandler := handlers.NewTicketCalendarHandler(ticketCalendarService, eventService)
+	dashboardHandler.SetTicketCalendarService(ticketCalendarService)
+	dashboardHandler.SetFavoriteService(favoriteService)
+	dashboardHandler.SetSavedSearchService(savedSearchService)
+	dashboardHandler.SetTicketAttendeeService(ticketAttendeeService)
 	profileHandler := handlers.NewProfileHandler(authService, userService, sessionStore)
-	cartHandler := handlers.NewCartHandler(ticketService, eventService, paymentService, sessionStore)
+	cartHandler := handlers.NewCartHandler(ticketService, eventService, monitoredPaymentService, sessionStore)
+	cartHandler.SetPaymentHealth(paymentHealthService)
+	cartHandler.SetLocaleService(localeService)
+	cartHandler.SetArrivalSlotService(arrivalSlotService)
+	cartHandler.SetCheckoutQuestionService(checkoutQuestionService)
+	cartHandler.SetCartAdditionRecorder(analyticsService)
+	cartHandler.SetInstallmentService(installmentService)
+	cartHandler.SetDonationService(donationService)
+	cartHandler.SetTaxRates(taxService)
+	waitingRoomHandler := handlers.NewWaitingRoomHandler(waitingRoomService, eventService)
+	profileHandler.SetLocaleService(localeService)
+	profileHandler.SetSessionStore(sessionStore)
+	authService.SetSessionStore(sessionStore)
 	paymentHandler := handlers.NewPaymentHandler(paymentService, orderService, ticketService, sessionStore)
+	paymentHandler.SetCheckoutAnswers(checkoutQuestionService)
+	paymentHandler.SetAttributionRecorder(analyticsService)
+	paymentHandler.SetIdempotencyStore(idempotencyService)
+	paymentHandler.SetInstallmentService(installmentService)
+	installmentHandler := handlers.NewInstallmentHandler(installmentService, eventService, orderService)
+	donationHandler := handlers.NewDonationHandler(donationService, eventService)
+	taxHandler := handlers.NewTaxHandler(taxService, eventService)
+
+	// Let organizers sell tickets at the door, attributed to the box office
+	boxOfficeService := services.NewBoxOfficeService(ticketRepo, orderRepo, orderService, analyticsService)
+	boxOfficeHandler := handlers.NewBoxOfficeHandler(boxOfficeService, eventService, orderService)
+
+	// Let buyers upgrade or change their tickets before the event, paying or
+	// being refunded the difference
+	orderAmendmentService := services.NewOrderAmendmentService(repositories.NewOrderAmendmentRepository(db.DB), orderRepo, ticketRepo, eventRepo, monitoredPaymentService, orderService)
+	orderAmendmentService.SetInstallments(installmentService)
+	orderAmendmentService.SetAvailability(ticketService)
+	paymentHandler.SetOrderAmendments(orderAmendmentService)
+	orderAmendmentHandler := handlers.NewOrderAmendmentHandler(orderAmendmentService, orderService)
 	imageHandler := handlers.NewImageManagementHandler(imageService, eventService, storageService)
+	imageHandler.SetEventImageRecorder(eventService)
 	analyticsHandler := handlers.NewAnalyticsHandler(analyticsService, authService)
+	eventViewHandler := handlers.NewEventViewHandler(eventViewService)
 	adminHandler := handlers.NewAdminHandler(userService, eventService, orderService)
 
 	// Initialize withdrawal service and handler
@@ -154,35 +465,310 @@ func main() {
 	withdrawalService := services.NewWithdrawalService(withdrawalRepo)
 	withdrawalHandler := handlers.NewWithdrawalHandler(withdrawalService)
 
+	// Keep organizer balances as ledgers of their sales, fees, refunds and payouts
+	ledgerService := services.NewLedgerService(repositories.NewLedgerRepository(db.DB))
+	withdrawalService.SetBalanceReader(ledgerService)
+	ledgerHandler := handlers.NewLedgerHandler(ledgerService)
+	cashFlowHandler := handlers.NewCashFlowHandler(services.NewCashFlowService(repositories.NewCashFlowRepository(db.DB), withdrawalService))
+	financeHandler := handlers.NewFinanceHandler(services.NewFinanceService(repositories.NewFinanceRepository(db.DB), withdrawalService, pdfService), eventService)
+	disputeHandler := handlers.NewDisputeHandler(services.NewDisputeService(repositories.NewDisputeRepository(db.DB), paymentService, storageService, notificationService))
+
+	// Link accounts sharing payout details, browsers or IP addresses
+	fraudLinkageService := services.NewFraudLinkageService(repositories.NewFraudLinkageRepository(db.DB))
+	withdrawalService.SetPayoutRecorder(fraudLinkageService)
+	authHandler.SetFraudLinkageService(fraudLinkageService)
+	fraudLinkageHandler := handlers.NewFraudLinkageHandler(fraudLinkageService)
+
 	// Initialize audit and event moderation services
 	auditRepo := repositories.NewAuditLogRepository(db.DB)
 	auditService := services.NewAuditService(auditRepo)
+	userService.SetAuditService(auditService)
+	withdrawalService.SetAuditService(auditService)
+	fraudLinkageService.SetAuditService(auditService)
+	eventBus.OnRefundIssued(auditService) // Records refunds in the audit log
+	auditLogHandler := handlers.NewAuditLogHandler(auditService)
+	adminUserService := services.NewAdminUserService(userRepo, orderService, eventRepo, withdrawalService, sessionStore, auditService)
+	adminUserService.SetPasswordResets(authService)
+	adminUserHandler := handlers.NewAdminUserHandler(adminUserService)
 	eventModerationService := services.NewEventModerationService(eventRepo, auditService)
+	eventModerationService.SetCache(appCache)
+	eventModerationService.SetEventBus(eventBus)
+	adminBulkService := services.NewAdminBulkService(userService, userRepo, eventModerationService)
+	adminBulkHandler := handlers.NewAdminBulkHandler(adminBulkService, eventModerationService, eventService)
+
+	// Screen organizers' event content for spam and phishing before it is published
+	contentScreenService := services.NewContentScreenService(repositories.NewContentFlagRepository(db.DB))
+	contentScreenService.SetBlockedKeywords(cfg.ContentModeration.BlockedKeywords)
+	if cfg.ContentModeration.APIURL != "" {
+		moderationClient := services.NewHTTPContentModerationClient(cfg.ContentModeration.APIURL, cfg.ContentModeration.APIKey, cfg.ContentModeration.Model)
+		contentScreenService.SetModerationClient(moderationClient)
+		if cfg.ContentModeration.ImageScreening == "api" {
+			contentScreenService.SetImageModerationClient(moderationClient)
+		}
+	}
+	if cfg.ContentModeration.ImageScreening == "heuristic" {
+		contentScreenService.SetImageModerationClient(services.NewSkinToneImageClassifier(cfg.Server.BaseURL))
+	}
+	eventService.SetContentScreen(contentScreenService)
+
+	// Geocode event locations for "near me" search and the events map,
+	// retrying events saved while the geocoding API was unavailable
+	if cfg.Geocoding.APIURL != "" {
+		geocoder := services.NewNominatimGeocoder(cfg.Geocoding.APIURL, cfg.Geocoding.APIKey, cfg.Geocoding.UserAgent, cfg.Geocoding.CountryCodes)
+		geocodingService := services.NewGeocodingService(geocoder, repositories.NewEventGeocodeRepository(db.DB))
+		geocodingService.SetCache(appCache)
+		eventService.SetGeocoding(geocodingService)
+		venueService.SetGeocoder(geocodingService)
+		lifecycle.EveryFromStart(10*time.Minute, func(ctx context.Context) {
+			if _, err := geocodingService.GeocodePending(ctx, 100); err != nil {
+				log.Printf("Warning: failed to geocode events: %v", err)
+			}
+		})
+	}
+	eventModerationService.SetContentScreen(contentScreenService)
+
+	// Initialize search suggestions, rebuilt when events change and every
+	// 15 minutes to pick up ticket sales
+	searchSuggestService := services.NewSearchSuggestService(eventRepo)
+	searchSuggestHandler := handlers.NewSearchSuggestHandler(searchSuggestService)
+	eventService.AddChangeHook(searchSuggestService)
+	eventModerationService.AddChangeHook(searchSuggestService)
+	lifecycle.EveryFromStart(15*time.Minute, func(ctx context.Context) {
+		if err := searchSuggestService.Refresh(); err != nil {
+			log.Printf("Warning: %v", err)
+		}
+	})
 	eventModerationHandler := handlers.NewEventModerationHandler(eventModerationService)
+	eventModerationHandler.SetEventService(eventService)
+
+	// Queue events waiting for review, assigning them to moderators in turn
+	eventModerationService.SetReviewQueue(repositories.NewEventReviewRepository(db.DB), userRepo)
+	lifecycle.Every(time.Minute, func(ctx context.Context) {
+		if _, err := eventModerationService.AssignPendingEvents(); err != nil {
+			log.Printf("Warning: failed to assign events for review: %v", err)
+		}
+	})
+
+	// Periodically delete or quarantine uploads that no event references
+	storageGCService := services.NewStorageGCService(storageService, repositories.NewStorageGCRepository(db.DB), services.StorageGCOptions{
+		GracePeriod:         cfg.StorageGC.GracePeriod,
+		Quarantine:          cfg.StorageGC.Quarantine,
+		QuarantineRetention: cfg.StorageGC.QuarantineRetention,
+	})
+	if cfg.StorageGC.Interval > 0 {
+		lifecycle.Every(cfg.StorageGC.Interval, func(ctx context.Context) {
+			result, err := storageGCService.Run(ctx)
+			if err != nil {
+				log.Printf("Warning: storage cleanup failed: %v", err)
+				return
+			}
+			log.Printf("Storage cleanup: %d orphans, %d quarantined, %d deleted, %d bytes reclaimed",
+				result.Run.OrphanedObjects, result.Run.QuarantinedObjects, result.Run.DeletedObjects, result.Run.ReclaimedBytes)
+		})
+	}
+
+	// Initialize data quality checks, run at startup and every six hours
+	dataQualityService := services.NewDataQualityService(repositories.NewDataQualityRepository(db.DB), auditService)
+	dataQualityService.SetStorageGCService(storageGCService)
+	dataQualityService.SetCache(appCache)
+	dataQualityHandler := handlers.NewDataQualityHandler(dataQualityService)
+	platformReportHandler := handlers.NewPlatformReportHandler(analyticsService)
+	promoterHandler := handlers.NewPromoterHandler(services.NewPromoterService(repositories.NewPromoterRepository(db.DB)), eventService)
+	lifecycle.EveryFromStart(6*time.Hour, func(ctx context.Context) {
+		if report := dataQualityService.RunChecks(ctx); report.TotalIssues() > 0 {
+			log.Printf("Warning: data quality checks found %d issues", report.TotalIssues())
+		}
+	})
+
+	// Reconcile Paystack transactions against orders hourly once Paystack is configured
+	paymentReconciliationService := services.NewPaymentReconciliationService(repositories.NewPaymentReconciliationRepository(db.DB), paymentService, auditService)
+	paymentReconciliationHandler := handlers.NewPaymentReconciliationHandler(paymentReconciliationService)
+	if cfg.Paystack.SecretKey != "" {
+		lifecycle.EveryFromStart(time.Hour, func(ctx context.Context) {
+			run := paymentReconciliationService.Run(ctx)
+			if run.Error != "" {
+				log.Printf("Warning: payment reconciliation failed: %s", run.Error)
+			} else if run.Flagged > 0 {
+				log.Printf("Warning: payment reconciliation flagged %d mismatches", run.Flagged)
+			}
+		})
+	}
+
+	// Initialize rate limiting, shared across instances through the cache
+	rateLimits := make(map[string]ratelimit.Rule)
+	for name, spec := range map[string]string{
+		"login":     cfg.RateLimit.Login,
+		"register":  cfg.RateLimit.Register,
+		"checkout":  cfg.RateLimit.Checkout,
+		"api":       cfg.RateLimit.API,
+		"broadcast": cfg.RateLimit.Broadcast,
+	} {
+		rule, err := ratelimit.ParseRule(name, spec)
+		if err != nil {
+			log.Fatal("Invalid rate limit configuration:", err)
+		}
+		rateLimits[name] = rule
+	}
+	rateLimiter := ratelimit.NewLimiter(appCache)
+	rateLimiter.OnExceeded(func(r *http.Request, rule ratelimit.Rule, key string) {
+		log.Printf("Rate limit %s exceeded by %s on %s", rule.Name, key, r.URL.Path)
+		details := map[string]interface{}{"rule": rule.Name, "key": key, "path": r.URL.Path}
+		if err := auditService.LogSecurityEvent(models.AuditActionRateLimitExceeded, models.AuditTargetRateLimit, details, r); err != nil {
+			log.Printf("Failed to audit rate limit: %v", err)
+		}
+	})
 
 	// Initialize settings service and handler
 	settingsRepo := repositories.NewSettingsRepository(db.DB)
 	settingsService := services.NewSettingsService(settingsRepo)
+	settingsService.SetAuditService(auditService)
+	settingsService.SetCache(appCache)
+	ticketService.SetOrderLimits(settingsService)
+	emailService.SetSettings(settingsService)
 	adminSettingsHandler := handlers.NewAdminSettingsHandler(settingsService)
+	adminSettingsHandler.SetStorageGCService(storageGCService)
+	adminSettingsHandler.SetPaymentHealthService(paymentHealthService)
+	pricingHandler := handlers.NewPricingHandler(settingsService)
+
+	// Admin-editable content snippets for pages and emails
+	snippetService := services.NewSnippetService(repositories.NewSnippetRepository(db.DB))
+	snippetService.SetCache(appCache)
+	snippetService.SetAuditService(auditService)
+	emailService.SetSnippets(snippetService)
+	adminSnippetsHandler := handlers.NewAdminSnippetsHandler(snippetService)
+
+	// Announcement banners admins publish across the site
+	announcementService := services.NewAnnouncementService(repositories.NewAnnouncementRepository(db.DB))
+	announcementService.SetCache(appCache)
+	announcementService.SetAuditService(auditService)
+	announcementHandler := handlers.NewAnnouncementHandler(announcementService)
+
+	// Help center articles admins write in Markdown
+	helpService := services.NewHelpService(repositories.NewHelpArticleRepository(db.DB))
+	helpService.SetAuditService(auditService)
+	helpHandler := handlers.NewHelpHandler(helpService)
+
+	// Accounts locked after too many failed logins
+	accountLockoutService := services.NewAccountLockoutService(userRepo)
+	accountLockoutService.SetAuditService(auditService)
+	adminLockoutsHandler := handlers.NewAdminLockoutsHandler(accountLockoutService)
+
+	// Deleted users, events and ticket types, purged once past retention
+	trashService := services.NewTrashService(repositories.NewTrashRepository(db.DB), cfg.Trash.Retention)
+	trashService.SetAuditService(auditService)
+	trashHandler := handlers.NewTrashHandler(trashService)
+	if cfg.Trash.Retention > 0 {
+		lifecycle.Every(24*time.Hour, func(ctx context.Context) {
+			result, err := trashService.Purge(ctx)
+			if err != nil {
+				log.Printf("Warning: trash purge failed: %v", err)
+				return
+			}
+			log.Printf("Trash purge: %d records purged", result.Total())
+		})
+	}
+
+	// Outbound email log, with the delivery events Resend reports by webhook
+	emailLogRepo := repositories.NewEmailLogRepository(db.DB)
+	emailService.SetDeliveryLog(emailLogRepo)
+	emailLogService := services.NewEmailLogService(emailLogRepo, emailService, cfg.Resend.WebhookSecret)
+	emailLogService.SetAuditService(auditService)
+	adminEmailsHandler := handlers.NewAdminEmailsHandler(emailLogService)
+	emailWebhookHandler := handlers.NewEmailWebhookHandler(emailLogService)
 
 	// Initialize default settings
 	if err := settingsService.InitializeDefaultSettings(); err != nil {
 		log.Printf("Failed to initialize default settings: %v", err)
 	}
 
+	// Hold events from new and low-reputation organizers for moderation
+	organizerReputationService := services.NewOrganizerReputationService(repositories.NewOrganizerReputationRepository(db.DB), settingsService)
+	eventService.SetReputationService(organizerReputationService)
+	eventModerationService.SetReputationService(organizerReputationService)
+	eventReportHandler := handlers.NewEventReportHandler(organizerReputationService, eventService)
+	favoriteHandler := handlers.NewFavoriteHandler(favoriteService, eventService)
+	storefrontHandler := handlers.NewStorefrontHandler(storefrontService, cfg.Server.BaseURL)
+	venueHandler := handlers.NewVenueHandler(venueService, cfg.Server.BaseURL)
+	savedSearchHandler := handlers.NewSavedSearchHandler(savedSearchService)
+
+	// Organizer team members with delegated access to events, analytics and check-in
+	teamService := services.NewTeamService(repositories.NewOrganizerMemberRepository(db.DB), userRepo, emailService, cfg.Server.BaseURL)
+	eventService.SetTeamAccess(teamService)
+	eventModerationService.SetTeamAccess(teamService)
+	teamHandler := handlers.NewTeamHandler(teamService)
+
+	// Admins log in as other users for support debugging
+	impersonationService := services.NewImpersonationService(userRepo, auditService)
+	impersonationHandler := handlers.NewImpersonationHandler(impersonationService, sessionStore)
+
+	// Partner GraphQL API, authenticated with API tokens
+	apiTokenService := services.NewAPITokenService(repositories.NewAPITokenRepository(db.DB), userRepo)
+	// The purchaseTickets mutation can't send buyers to Paystack's payment
+	// page, so it only buys tickets where checkout settles directly
+	graphResolver := graph.NewResolver(eventService, ticketService, orderService)
+	graphResolver.SetCheckoutQuestions(checkoutQuestionService)
+	graphResolver.SetWaitingRoom(waitingRoomService)
+	graphResolver.SetIdempotencyStore(idempotencyService)
+	if mockCheckout {
+		graphResolver.EnablePurchases()
+	}
+	graphHandler := graph.NewHandler(graphResolver)
+
+	// Initialize city landing pages and sitemap
+	cityService := services.NewCityService(eventRepo, cfg.Server.BaseURL)
+	cityHandler := handlers.NewCityHandler(cityService)
+	locationSuggestHandler := handlers.NewLocationSuggestHandler(services.NewLocationSuggestService(eventRepo))
+	seoService := services.NewSEOService(cfg.Server.BaseURL)
+	// Category landing pages, curated by admins
+	categoryPageService := services.NewCategoryPageService(repositories.NewCategoryRepository(db.DB), eventService, cfg.Server.BaseURL)
+	categoryPageService.SetCache(appCache)
+	categoryPageService.SetAuditService(auditService)
+	categoryPageHandler := handlers.NewCategoryPageHandler(categoryPageService)
+	sitemapHandler := handlers.NewSitemapHandler(cityService, eventService, seoService, cfg.Server.BaseURL)
+	publicHandler.SetSEOService(seoService)
+	publicHandler.SetCityPages(cityHandler.CityPage)
+	publicHandler.SetVenueService(venueService)
+	publicHandler.SetEventAccessService(eventAccessService)
+	publicHandler.SetEventAgendaService(eventAgendaService)
+	publicHandler.SetEventFAQService(eventFAQService)
+
+	// Static files are served under content-hashed names, optionally through a CDN
+	staticAssets, err := assets.Load(cfg.Assets.Dir, cfg.Assets.CDNBaseURL)
+	if err != nil {
+		log.Fatal("Failed to load static assets:", err)
+	}
+
 	// Initialize router
 	r := chi.NewRouter()
 
 	// Basic middleware
-	r.Use(chimiddleware.Logger)
-	r.Use(chimiddleware.Recoverer)
-	r.Use(middleware.CORSMiddleware(middleware.DefaultCORSConfig()))
+	r.Use(middleware.RequestIDMiddleware)
+	r.Use(middleware.ErrorHandlingMiddleware)
+	// Pages using the session cookie are same-origin only; the public API and
+	// the embeddable widget accept the configured origins
+	r.Use(middleware.CORSPolicyMiddleware(
+		middleware.SameOriginCORSConfig(),
+		middleware.CORSPolicy{PathPrefix: "/api/v1", Config: middleware.PublicAPICORSConfig(cfg.CORS.APIOrigins, cfg.CORS.MaxAge)},
+		middleware.CORSPolicy{PathPrefix: "/widget", Config: middleware.WidgetCORSConfig(cfg.CORS.WidgetOrigins, cfg.CORS.MaxAge)},
+	))
 	r.Use(sessionMiddleware.SessionConfig)
 	r.Use(authMiddleware.LoadUser) // Load user context for all routes
+	r.Use(middleware.Impersonation(sessionStore, impersonationService))
+	r.Use(middleware.LoggingMiddleware) // After the user is loaded so requests log their user ID
 	r.Use(csrfMiddleware.EnsureCSRFToken)
+	r.Use(middleware.PrivacyConsent(privacyService)) // Load analytics and marketing consent
+	r.Use(middleware.Attribution(sessionStore))      // Remember where each session first came from
+	r.Use(middleware.ContentSnippets(snippetService))
+	r.Use(middleware.Announcements(announcementService))
+	r.Use(middleware.Assets(staticAssets))
+	r.Use(middleware.Compress)
+	// Pages are revalidated with ETags; authenticated route groups override
+	// this so they are never stored
+	r.Use(middleware.CacheControl(middleware.RevalidateCacheControl))
+	r.Use(middleware.ETag)
 
 	// Static files
-	r.Handle("/static/*", http.StripPrefix("/static/", http.FileServer(http.Dir("web/static/"))))
+	r.Handle(assets.URLPrefix+"*", http.StripPrefix(assets.URLPrefix, staticAssets.Handler()))
 
 	// Uploads files
 	r.Handle("/uploads/*", http.StripPrefix("/uploads/", http.FileServer(http.Dir("uploads/"))))
@@ -191,43 +777,27 @@ func main() {
 	r.Get("/", publicHandler.HomePage)
 	r.Get("/events", publicHandler.EventsListPage)
 	r.Get("/events/{id}", publicHandler.EventDetailsPage)
+	r.Post("/events/{id}/view", eventViewHandler.TrackView)
+	r.Get("/events/{slug:[a-zA-Z][a-zA-Z0-9-]*}", publicHandler.EventBySlugPage) // Event slugs, then city landing pages; numeric IDs redirect to the slug
 	r.Get("/events/{id}/availability", publicHandler.GetTicketAvailability)
+	r.Get("/events/{id}/availability/stream", publicHandler.StreamTicketAvailability)
+	r.Get("/events/{id}/waiting-room", waitingRoomHandler.WaitingRoomPage)
+	r.Get("/events/{id}/waiting-room/status", waitingRoomHandler.WaitingRoomStatus)
+	r.Get("/events/{id}/calendar.ics", ticketCalendarHandler.EventCalendar)
 	r.Get("/search", publicHandler.SearchEvents)
+	r.Get("/search/suggest", searchSuggestHandler.Suggest)
+	r.Get("/sitemap.xml", sitemapHandler.Sitemap)
 
 	// Additional public routes
-	r.Get("/categories", func(w http.ResponseWriter, r *http.Request) {
-		// Redirect to events page with category filter for now
-		http.Redirect(w, r, "/events", http.StatusTemporaryRedirect)
-	})
-	r.Get("/pricing", func(w http.ResponseWriter, r *http.Request) {
-		// Simple pricing page placeholder
-		w.Header().Set("Content-Type", "text/html")
-		w.Write([]byte(`
-			<!DOCTYPE html>
-			<html>
-			<head><title>Pricing - Runtown</title></head>
-			<body style="font-family: Arial, sans-serif; max-width: 800px; margin: 50px auto; padding: 20px;">
-				<h1>Pricing Plans</h1>
-				<p>Our pricing plans are coming soon. For now, event creation is free!</p>
-				<a href="/" style="color: #3B82F6;">← Back to Home</a>
-			</body>
-			</html>
-		`))
-	})
-	r.Get("/help", func(w http.ResponseWriter, r *http.Request) {
-		w.Header().Set("Content-Type", "text/html")
-		w.Write([]byte(`
-			<!DOCTYPE html>
-			<html>
-			<head><title>Help Center - Runtown</title></head>
-			<body style="font-family: Arial, sans-serif; max-width: 800px; margin: 50px auto; padding: 20px;">
-				<h1>Help Center</h1>
-				<p>Help documentation is coming soon. For support, please contact us.</p>
-				<a href="/" style="color: #3B82F6;">← Back to Home</a>
-			</body>
-			</html>
-		`))
-	})
+	r.Get("/categories", publicHandler.CategoriesPage)
+	r.Get("/cities", cityHandler.CitiesPage)
+	r.Get("/categories/{slug}", categoryPageHandler.CategoryPage)
+	// Pricing, from the fee schedule in the system settings
+	r.Get("/pricing", pricingHandler.PricingPage)
+	r.Get("/pricing/calculator", pricingHandler.Calculator)
+	// Help center
+	r.Get("/help", helpHandler.HelpCenter)
+	r.Get("/help/{slug}", helpHandler.Article)
 	r.Get("/contact", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "text/html")
 		w.Write([]byte(`
@@ -261,9 +831,9 @@ func main() {
 	r.Route("/auth", func(r chi.Router) {
 		r.Use(csrfMiddleware.CSRFProtection) // Add CSRF protection to POST routes
 		r.Get("/login", authHandler.LoginPage)
-		r.Post("/login", authHandler.LoginSubmit)
+		r.With(middleware.RateLimit(rateLimiter, rateLimits["login"], middleware.AccountFromForm("email"))).Post("/login", authHandler.LoginSubmit)
 		r.Get("/register", authHandler.RegisterPage)
-		r.Post("/register", authHandler.RegisterSubmit)
+		r.With(middleware.RateLimit(rateLimiter, rateLimits["register"], middleware.AccountFromForm("email"))).Post("/register", authHandler.RegisterSubmit)
 		r.Get("/forgot-password", authHandler.ForgotPasswordPage)
 		r.Post("/forgot-password", authHandler.ForgotPasswordSubmit)
 		r.Get("/reset-password", authHandler.ResetPasswordPage)
@@ -275,29 +845,79 @@ func main() {
 		r.Get("/csrf-token", authHandler.GetCSRFToken)
 	})
 
+	// Privacy preferences, chosen from the consent banner or managed here
+	r.Route("/privacy-preferences", func(r chi.Router) {
+		r.Use(middleware.CacheControl(middleware.NoStoreCacheControl))
+		r.Use(csrfMiddleware.CSRFProtection)
+		r.Get("/", privacyHandler.PreferencesPage)
+		r.Post("/", privacyHandler.UpdatePreferences)
+	})
+
+	// Visitors dismissing announcement banners
+	r.Route("/announcements", func(r chi.Router) {
+		r.Use(middleware.CacheControl(middleware.NoStoreCacheControl))
+		r.Use(csrfMiddleware.CSRFProtection)
+		r.Post("/{id}/dismiss", announcementHandler.Dismiss)
+	})
+
 	// Shopping cart and checkout routes
 	r.Route("/cart", func(r chi.Router) {
 		r.Use(middleware.RequireAuth)
+		r.Use(middleware.CacheControl(middleware.NoStoreCacheControl))
 		r.Use(csrfMiddleware.CSRFProtection) // Add CSRF protection
 		r.Get("/", cartHandler.ViewCart)
-		r.Post("/add", cartHandler.AddToCartUnified)
+		r.With(middleware.RequireWaitingRoomAdmission(waitingRoomService)).Post("/add", cartHandler.AddToCartUnified)
 		r.Post("/clear", cartHandler.ClearCart)
 		r.Post("/update", cartHandler.UpdateCartItem)
 	})
 
 	r.Route("/events/{id}/cart", func(r chi.Router) {
 		r.Use(middleware.RequireAuth)
+		r.Use(middleware.CacheControl(middleware.NoStoreCacheControl))
 		r.Use(csrfMiddleware.CSRFProtection) // Add CSRF protection
-		r.Post("/add", cartHandler.AddToCart)
+		r.With(middleware.RequireWaitingRoomAdmission(waitingRoomService)).Post("/add", cartHandler.AddToCart)
+	})
+
+	r.Route("/events/{id}/report", func(r chi.Router) {
+		r.Use(middleware.RequireAuth)
+		r.Use(middleware.CacheControl(middleware.NoStoreCacheControl))
+		r.Use(csrfMiddleware.CSRFProtection)
+		r.Post("/", eventReportHandler.ReportEvent)
+	})
+
+	r.Route("/events/{id}/favorite", func(r chi.Router) {
+		r.Use(middleware.RequireAuth)
+		r.Use(middleware.CacheControl(middleware.NoStoreCacheControl))
+		r.Use(csrfMiddleware.CSRFProtection)
+		r.Post("/", favoriteHandler.ToggleFavorite)
+	})
+
+	// Organizer storefronts
+	r.Get("/o/{slug}", storefrontHandler.StorefrontPage)
+	r.Get("/organizers/{id}", storefrontHandler.OrganizerRedirect)
+	r.Route("/o/{slug}/follow", func(r chi.Router) {
+		r.Use(middleware.RequireAuth)
+		r.Use(middleware.CacheControl(middleware.NoStoreCacheControl))
+		r.Use(csrfMiddleware.CSRFProtection)
+		r.Post("/", storefrontHandler.ToggleFollow)
 	})
 
+	// Venue pages
+	r.Get("/venues/{slug}", venueHandler.VenuePage)
+
 	r.Route("/checkout", func(r chi.Router) {
 		r.Use(middleware.RequireAuth)
+		r.Use(middleware.CacheControl(middleware.NoStoreCacheControl))
+		r.Use(middleware.RateLimit(rateLimiter, rateLimits["checkout"], middleware.AccountFromUser))
 		r.Use(csrfMiddleware.CSRFProtection) // Add CSRF protection
 		r.Get("/", cartHandler.CheckoutPage)
-		r.Post("/", cartHandler.ProcessCheckout)
+		r.With(middleware.Idempotent(idempotencyService, models.IdempotencyScopeCheckout)).Post("/", cartHandler.ProcessCheckout)
 	})
 
+	// Email delivery events, verified by their signature
+	r.Post("/webhooks/resend", emailWebhookHandler.ResendWebhook)
+	r.Post("/webhooks/paystack", disputeHandler.PaystackWebhook)
+
 	// Payment routes (for Paystack callbacks and status)
 	r.Route("/payment", func(r chi.Router) {
 		r.Get("/callback", paymentHandler.PaymentCallback)  // Pesapal callback (no auth required)
@@ -308,22 +928,38 @@ func main() {
 		r.Post("/initiate", paymentHandler.InitiatePayment) // For testing (requires auth)
 	})
 
+	// Calendar apps fetch the feed without a session using its signed URL
+	r.Get("/dashboard/calendar.ics", ticketCalendarHandler.Feed)
+
 	// Protected dashboard routes
 	r.Route("/dashboard", func(r chi.Router) {
 		r.Use(middleware.RequireAuth)
+		r.Use(middleware.CacheControl(middleware.NoStoreCacheControl))
 		r.Get("/", dashboardHandler.DashboardPage)
 		r.Get("/orders", dashboardHandler.OrdersPage)
 		r.Get("/orders/{id}", dashboardHandler.OrderDetailsPage)
 		r.Post("/orders/{id}/cancel", dashboardHandler.CancelOrder)
 		r.Get("/orders/{id}/tickets/download", dashboardHandler.DownloadTickets)
 		r.Get("/orders/{id}/tickets/redownload", dashboardHandler.RedownloadTickets)
+		r.Get("/orders/{id}/installments", installmentHandler.SchedulePage)
+		r.Get("/orders/{id}/change", orderAmendmentHandler.ChangePage)
+		r.With(csrfMiddleware.CSRFProtection).Post("/orders/{id}/change", orderAmendmentHandler.RequestChange)
+		r.With(csrfMiddleware.CSRFProtection).Post("/orders/{id}/change/{amendmentID}/confirm", orderAmendmentHandler.ConfirmChange)
+		r.With(csrfMiddleware.CSRFProtection).Post("/orders/{id}/change/{amendmentID}/check", orderAmendmentHandler.CheckPayment)
 		r.Get("/tickets/{id}/download", dashboardHandler.DownloadSingleTicket)
+		r.Get("/tickets/{id}/wallet/apple", dashboardHandler.DownloadApplePass)
+		r.Get("/tickets/{id}/wallet/google", dashboardHandler.SaveToGoogleWallet)
+		r.With(csrfMiddleware.CSRFProtection).Post("/tickets/{id}/attendee", dashboardHandler.UpdateTicketAttendee)
+		r.With(csrfMiddleware.CSRFProtection).Post("/saved-searches", savedSearchHandler.SaveSearch)
+		r.With(csrfMiddleware.CSRFProtection).Post("/saved-searches/{id}/delete", savedSearchHandler.DeleteSearch)
 
 		// Profile management routes
 		r.Get("/profile", profileHandler.ProfilePage)
 		r.Post("/profile", profileHandler.UpdateProfile)
 		r.Get("/security", profileHandler.SecurityPage)
 		r.Post("/security/change-password", profileHandler.ChangePassword)
+		r.With(csrfMiddleware.CSRFProtection).Post("/security/sessions/logout-others", profileHandler.LogOutOtherSessions)
+		r.With(csrfMiddleware.CSRFProtection).Post("/security/sessions/{id}/revoke", profileHandler.RevokeSession)
 		r.Get("/settings", profileHandler.SettingsPage)
 		r.Post("/settings", profileHandler.UpdateSettings)
 		r.Get("/delete-account", profileHandler.DeleteAccountPage)
@@ -333,15 +969,62 @@ func main() {
 	// Order confirmation route (separate from dashboard for direct access)
 	r.Route("/orders/{id}/confirmation", func(r chi.Router) {
 		r.Use(middleware.RequireAuth)
+		r.Use(middleware.CacheControl(middleware.NoStoreCacheControl))
 		r.Get("/", dashboardHandler.OrderConfirmationPage)
 	})
 
 	// Organizer routes for event and image management
 	organizerEventHandler := handlers.NewOrganizerEventHandler(eventService, ticketService, storageService, imageService)
+	organizerEventHandler.SetVenueService(venueService)
+	organizerEventHandler.SetEventAccessService(eventAccessService)
 	ticketTypeHandler := handlers.NewTicketTypeHandler(ticketService, eventService)
+	ticketTypeHandler.SetPriceHistoryService(priceHistoryService)
+	calendarHandler := handlers.NewOrganizerCalendarHandler(services.NewCalendarService(eventRepo, ticketRepo))
+	notificationHandler := handlers.NewNotificationHandler(notificationService)
+	eventReminderHandler := handlers.NewEventReminderHandler(eventReminderService, eventService)
+	eventReminderHandler.SetLocaleService(localeService)
+
+	// Organizer emails to all ticket holders, queued and sent in batches every minute
+	eventBroadcastService := services.NewEventBroadcastService(repositories.NewEventBroadcastRepository(db.DB), eventRepo, emailService, cfg.Server.BaseURL)
+	eventBroadcastService.SetAuditService(auditService)
+	eventBroadcastHandler := handlers.NewEventBroadcastHandler(eventBroadcastService, eventService)
+	eventBroadcastHandler.SetRateLimiter(rateLimiter, rateLimits["broadcast"])
+	lifecycle.Every(time.Minute, func(ctx context.Context) {
+		if _, err := eventBroadcastService.ProcessQueue(); err != nil {
+			log.Printf("Warning: event broadcasts failed: %v", err)
+		}
+	})
+
+	// Event cancellations, refunding ticket holders in batches every minute
+	eventCancellationService := services.NewEventCancellationService(repositories.NewEventCancellationRepository(db.DB), eventService, eventRepo, ticketService, emailService)
+	eventCancellationService.SetAuditService(auditService)
+	eventCancellationHandler := handlers.NewEventCancellationHandler(eventCancellationService, eventService)
+	lifecycle.Every(time.Minute, func(ctx context.Context) {
+		if _, err := eventCancellationService.ProcessQueue(); err != nil {
+			log.Printf("Warning: event cancellation refunds failed: %v", err)
+		}
+	})
+	eventTranslationHandler := handlers.NewEventTranslationHandler(eventTranslationService, eventService)
+	arrivalSlotHandler := handlers.NewArrivalSlotHandler(arrivalSlotService, eventService)
+	checkoutQuestionHandler := handlers.NewCheckoutQuestionHandler(checkoutQuestionService, eventService)
+	eventAgendaHandler := handlers.NewEventAgendaHandler(eventAgendaService, eventService)
+	eventFAQHandler := handlers.NewEventFAQHandler(eventFAQService, eventService)
+	ticketScanService := services.NewTicketScanService(repositories.NewTicketScanRepository(db.DB), ticketRepo, orderRepo, eventRepo)
+	ticketScanService.SetArrivalSlots(arrivalSlotService)
+	ticketScanService.SetEventBus(eventBus)
+	ticketScanService.SetTeamAccess(teamService)
+	ticketScanService.SetManifestSecret(cfg.Session.Secret)
+	ticketScanHandler := handlers.NewTicketScanHandler(ticketScanService)
+	// Push admissions to live check-in dashboards as tickets are scanned
+	checkInBroker := services.NewCheckInBroker()
+	eventBus.OnTicketCheckedIn(checkInBroker)
+	lifecycle.OnShutdown(checkInBroker.Close)
+	ticketScanHandler.SetCheckInBroker(checkInBroker)
+	categoryHandler := handlers.NewCategoryHandler(analyticsService, services.NewCategorySuggestionService(eventService))
 
 	r.Route("/organizer", func(r chi.Router) {
 		r.Use(middleware.RequireAuth)
+		r.Use(middleware.CacheControl(middleware.NoStoreCacheControl))
 		r.Use(authMiddleware.RequireRole(models.UserRoleOrganizer))
 		r.Use(csrfMiddleware.CSRFProtection) // Add CSRF protection to POST routes
 
@@ -352,6 +1035,10 @@ func main() {
 		r.Get("/events", organizerEventHandler.EventsListPage)
 		r.Get("/events/create", organizerEventHandler.CreateEventPage)
 		r.Get("/events/new", organizerEventHandler.CreateEventPage) // Add alias for backward compatibility
+		r.Get("/events/suggest-category", categoryHandler.SuggestCategory)
+		r.Get("/events/locations/cities", locationSuggestHandler.Cities)
+		r.Get("/events/locations/venues", locationSuggestHandler.Venues)
+		r.Post("/events/accessibility-check", organizerEventHandler.CheckAccessibility)
 		r.Post("/events", organizerEventHandler.CreateEventSubmit)
 		r.Get("/events/{id}/edit", organizerEventHandler.EditEventPage)
 		r.Put("/events/{id}", organizerEventHandler.UpdateEventSubmit)
@@ -362,15 +1049,121 @@ func main() {
 		r.Post("/events/{id}/publish", organizerEventHandler.PublishEvent)
 		r.Post("/events/{id}/unpublish", organizerEventHandler.UnpublishEvent)
 
+		// Review comments from moderators
+		r.Get("/events/{id}/review", eventModerationHandler.ReviewFeedbackPage)
+		r.Post("/events/{id}/review", eventModerationHandler.ReplyToReview)
+
+		// Event calendar
+		r.Get("/calendar", calendarHandler.CalendarPage)
+
+		// Notifications and notification settings
+		r.Get("/notifications", notificationHandler.NotificationsPage)
+		r.Post("/notifications/read-all", notificationHandler.MarkAllRead)
+		r.Post("/notifications/preferences", notificationHandler.UpdatePreferences)
+		r.Post("/notifications/{id}/read", notificationHandler.MarkRead)
+
+		// Attendee reminder settings
+		r.Get("/events/{id}/reminders", eventReminderHandler.RemindersPage)
+		r.Post("/events/{id}/reminders", eventReminderHandler.UpdateReminders)
+
+		// Emails to all ticket holders
+		r.Get("/events/{id}/broadcast", eventBroadcastHandler.BroadcastPage)
+		r.Post("/events/{id}/broadcast", eventBroadcastHandler.SendBroadcast)
+
+		// Event cancellation and refunds
+		r.Get("/events/{id}/cancel", eventCancellationHandler.CancelPage)
+		r.Post("/events/{id}/cancel", eventCancellationHandler.CancelEvent)
+
+		// Translations
+		r.Get("/events/{id}/translations", eventTranslationHandler.TranslationsPage)
+		r.Post("/events/{id}/translations", eventTranslationHandler.SaveTranslation)
+		r.Post("/events/{id}/translations/{locale}/delete", eventTranslationHandler.DeleteTranslation)
+
+		// Arrival slots
+		r.Get("/events/{id}/arrival-slots", arrivalSlotHandler.ArrivalSlotsPage)
+		r.Post("/events/{id}/arrival-slots", arrivalSlotHandler.AddArrivalSlot)
+		r.Post("/events/{id}/arrival-slots/{slotID}/delete", arrivalSlotHandler.DeleteArrivalSlot)
+
+		// Waiting room for flash on-sales
+		r.Get("/events/{id}/waiting-room", waitingRoomHandler.SettingsPage)
+		r.Post("/events/{id}/waiting-room", waitingRoomHandler.UpdateSettings)
+		r.Get("/events/{id}/installments", installmentHandler.SettingsPage)
+		r.Post("/events/{id}/installments", installmentHandler.UpdateSettings)
+		r.Get("/events/{id}/donations", donationHandler.SettingsPage)
+		r.Post("/events/{id}/donations", donationHandler.UpdateSettings)
+		r.Get("/events/{id}/tax", taxHandler.EventSettingsPage)
+		r.Post("/events/{id}/tax", taxHandler.UpdateEventSettings)
+		r.Get("/events/{id}/box-office", boxOfficeHandler.SalePage)
+		r.Post("/events/{id}/box-office", boxOfficeHandler.Sell)
+
+		// Checkout questions
+		r.Get("/events/{id}/questions", checkoutQuestionHandler.QuestionsPage)
+		r.Post("/events/{id}/questions", checkoutQuestionHandler.AddQuestion)
+		r.Post("/events/{id}/questions/{questionID}/delete", checkoutQuestionHandler.DeleteQuestion)
+		r.Get("/events/{id}/agenda", eventAgendaHandler.AgendaPage)
+		r.Post("/events/{id}/agenda/sessions", eventAgendaHandler.AddSession)
+		r.Get("/events/{id}/agenda/sessions/{sessionID}/edit", eventAgendaHandler.EditSessionPage)
+		r.Post("/events/{id}/agenda/sessions/{sessionID}", eventAgendaHandler.UpdateSession)
+		r.Post("/events/{id}/agenda/sessions/{sessionID}/delete", eventAgendaHandler.DeleteSession)
+		r.Post("/events/{id}/agenda/speakers", eventAgendaHandler.AddSpeaker)
+		r.Get("/events/{id}/agenda/speakers/{speakerID}/edit", eventAgendaHandler.EditSpeakerPage)
+		r.Post("/events/{id}/agenda/speakers/{speakerID}", eventAgendaHandler.UpdateSpeaker)
+		r.Post("/events/{id}/agenda/speakers/{speakerID}/delete", eventAgendaHandler.DeleteSpeaker)
+		r.Get("/events/{id}/faqs", eventFAQHandler.FAQsPage)
+		r.Post("/events/{id}/faqs", eventFAQHandler.AddFAQ)
+		r.Post("/events/{id}/faqs/policies", eventFAQHandler.SavePolicies)
+		r.Get("/events/{id}/faqs/{faqID}/edit", eventFAQHandler.EditFAQPage)
+		r.Post("/events/{id}/faqs/{faqID}", eventFAQHandler.UpdateFAQ)
+		r.Post("/events/{id}/faqs/{faqID}/delete", eventFAQHandler.DeleteFAQ)
+
 		// Withdrawal routes
 		r.Get("/withdrawals", withdrawalHandler.WithdrawalsPage)
 		r.Get("/withdrawals/create", withdrawalHandler.CreateWithdrawalPage)
 		r.Post("/withdrawals/create", withdrawalHandler.CreateWithdrawalSubmit)
+		r.Get("/balance", ledgerHandler.BalanceHistoryPage)
+		r.Get("/cash-flow", cashFlowHandler.CashFlowPage)
+
+		// Settlement statements
+		r.Get("/finance", financeHandler.FinancePage)
+		r.Get("/finance/statements/{month}", financeHandler.MonthlyStatement)
+		r.Get("/finance/events/{id}", financeHandler.EventStatement)
+		r.Get("/disputes", disputeHandler.DisputesPage)
+		r.Post("/disputes/{id}/evidence", disputeHandler.SubmitEvidence)
+		r.Get("/tax", taxHandler.SettingsPage)
+		r.Post("/tax", taxHandler.UpdateSettings)
+
+		// Public storefront
+		r.Get("/storefront", storefrontHandler.EditPage)
+		r.Post("/storefront", storefrontHandler.UpdateProfile)
+
+		// Saved venues
+		r.Get("/venues", venueHandler.ListVenues)
+		r.Get("/venues/new", venueHandler.NewVenuePage)
+		r.Post("/venues", venueHandler.CreateVenue)
+		r.Get("/venues/{id}/edit", venueHandler.EditVenuePage)
+		r.Post("/venues/{id}", venueHandler.UpdateVenue)
+		r.Post("/venues/{id}/delete", venueHandler.DeleteVenue)
+
+		// Team members and invitations
+		r.Get("/team", teamHandler.TeamPage)
+		r.Post("/team", teamHandler.Invite)
+		r.Post("/team/{id}/remove", teamHandler.RemoveMember)
+
+		// Promoter tracking links and their commission
+		r.Get("/promoters", promoterHandler.PromotersPage)
+		r.Post("/promoters", promoterHandler.CreateLink)
+		r.Post("/promoters/{id}/deactivate", promoterHandler.DeactivateLink)
 
 		// Event analytics routes
 		r.Get("/events/{id}/analytics", analyticsHandler.EventAnalytics)
 		r.Get("/events/{id}/export-attendees", analyticsHandler.ExportAttendees)
 
+		// Ticket scanning and the scan audit export
+		r.Post("/events/{id}/scans", ticketScanHandler.ScanTicket)
+		r.Get("/events/{id}/scans/export", ticketScanHandler.ExportScans)
+		r.Get("/events/{id}/check-in", ticketScanHandler.CheckInDashboard)
+		r.Get("/events/{id}/check-in/stream", ticketScanHandler.StreamCheckInStats)
+
 		// Ticket type management routes
 		r.Route("/events/{eventId}/tickets", func(r chi.Router) {
 			r.Get("/", ticketTypeHandler.TicketTypesPage)
@@ -380,6 +1173,7 @@ func main() {
 			r.Put("/{id}", ticketTypeHandler.UpdateTicketTypeSubmit)
 			r.Post("/{id}", ticketTypeHandler.UpdateTicketTypeSubmit) // For forms that can't use PUT
 			r.Delete("/{id}", ticketTypeHandler.DeleteTicketType)
+			r.Post("/{id}/next-tier", ticketTypeHandler.SetNextTier)
 		})
 
 		// Image management routes
@@ -389,25 +1183,71 @@ func main() {
 			r.Post("/replace", imageHandler.ReplaceImage)
 			r.Delete("/delete", imageHandler.DeleteImage)
 			r.Post("/presigned-url", imageHandler.GeneratePresignedURL)
+			r.Post("/complete", imageHandler.CompleteUpload)
 			r.Get("/{imageKey}/variants", imageHandler.GetImageVariants)
 		})
 	})
 
+	// Invitations to organizers' teams can be viewed before signing in
+	r.Route("/team/invitations/{token}", func(r chi.Router) {
+		r.Get("/", teamHandler.InvitationPage)
+		r.With(middleware.RequireAuth, csrfMiddleware.CSRFProtection).Post("/", teamHandler.AcceptInvitation)
+	})
+
+	// Admins return to their own account from an impersonation
+	r.Route("/impersonation", func(r chi.Router) {
+		r.Use(middleware.RequireAuth)
+		r.Use(middleware.CacheControl(middleware.NoStoreCacheControl))
+		r.Use(csrfMiddleware.CSRFProtection)
+		r.Post("/stop", impersonationHandler.StopImpersonating)
+	})
+
+	// Offline check-in sync for scanning apps, authenticated with the API
+	// tokens of organizers and their check-in staff
+	r.Route("/scanner", func(r chi.Router) {
+		r.Use(middleware.CacheControl(middleware.NoStoreCacheControl))
+		r.Get("/manifest-key", ticketScanHandler.ManifestKey)
+		r.Group(func(r chi.Router) {
+			r.Use(middleware.RequireAPIToken(apiTokenService))
+			r.Use(middleware.RateLimit(rateLimiter, rateLimits["api"], middleware.AccountFromUser))
+			r.Get("/events/{id}/manifest", ticketScanHandler.DownloadManifest)
+			r.Post("/events/{id}/check-ins", ticketScanHandler.SyncCheckIns)
+		})
+	})
+
+	// Partner GraphQL API. Requests carry an API token instead of a session,
+	// so they need no CSRF token.
+	r.Route("/graphql", func(r chi.Router) {
+		r.Use(middleware.RequireAPIToken(apiTokenService))
+		r.Use(middleware.RateLimit(rateLimiter, rateLimits["api"], middleware.AccountFromUser))
+		r.Handle("/", graphHandler)
+	})
+
 	// API routes for HTMX requests
 	r.Route("/api", func(r chi.Router) {
 		r.Use(middleware.RequireAuth)
+		r.Use(middleware.CacheControl(middleware.NoStoreCacheControl))
+		r.Use(middleware.RateLimit(rateLimiter, rateLimits["api"], middleware.AccountFromUser))
 
 		// Analytics API routes
 		r.Route("/organizer", func(r chi.Router) {
 			r.Use(authMiddleware.RequireRole(models.UserRoleOrganizer))
 			r.Get("/dashboard", analyticsHandler.DashboardAPI)
+			r.Get("/sales", analyticsHandler.SalesSeriesAPI)
+			r.Get("/sources", analyticsHandler.SalesBySourceAPI)
 			r.Get("/events/{id}/analytics", analyticsHandler.EventAnalyticsAPI)
+			r.Get("/events/{id}/sales", analyticsHandler.EventSalesSeriesAPI)
+			r.Get("/events/{id}/ticket-types", analyticsHandler.EventTicketTypeRevenueAPI)
+			r.Get("/events/{id}/funnel", analyticsHandler.EventFunnelAPI)
+			r.Get("/events/{id}/comparison", analyticsHandler.EventComparisonAPI)
+			r.Get("/events/{id}/sources", analyticsHandler.EventSalesBySourceAPI)
 		})
 	})
 
 	// Admin routes
 	r.Route("/admin", func(r chi.Router) {
 		r.Use(middleware.RequireAuth)
+		r.Use(middleware.CacheControl(middleware.NoStoreCacheControl))
 		r.Use(authMiddleware.RequireRole(models.UserRoleAdmin))
 		r.Use(csrfMiddleware.CSRFProtection) // Add CSRF protection to POST routes
 
@@ -416,34 +1256,99 @@ func main() {
 
 		// User management
 		r.Get("/users", adminHandler.UserManagement)
+		r.Post("/users/bulk", adminBulkHandler.BulkUsers)
+		r.Get("/users/{id}", adminUserHandler.UserDetailPage)
+		r.Post("/users/{id}/password-reset", adminUserHandler.SendPasswordReset)
+		r.Post("/users/{id}/verify-email", adminUserHandler.VerifyEmail)
 		r.Post("/users/{id}/role", adminHandler.UpdateUserRole)
 		r.Post("/users/{id}/suspend", adminHandler.SuspendUser)
 		r.Post("/users/{id}/activate", adminHandler.ActivateUser)
+		r.Get("/locked-accounts", adminLockoutsHandler.LockedAccountsPage)
+		r.Post("/locked-accounts/{id}/unlock", adminLockoutsHandler.UnlockAccount)
+		r.Post("/users/{id}/impersonate", impersonationHandler.Impersonate)
+
+		// Trash
+		r.Get("/trash", trashHandler.TrashPage)
+		r.Post("/trash/{kind}/{id}/restore", trashHandler.Restore)
+
+		// Audit log
+		r.Get("/audit", auditLogHandler.AuditLogPage)
+		r.Get("/audit/export", auditLogHandler.ExportAuditLog)
 
 		// Category management
 		r.Get("/categories", adminHandler.CategoryManagement)
 		r.Get("/categories/create", adminHandler.CreateCategoryPage)
+		r.Get("/categories/analytics", categoryHandler.CategoryAnalytics)
 		r.Post("/categories", adminHandler.CreateCategory)
 		r.Get("/categories/{id}/edit", adminHandler.EditCategoryPage)
 		r.Post("/categories/{id}", adminHandler.UpdateCategory)
 		r.Delete("/categories/{id}", adminHandler.DeleteCategory)
+		r.Get("/categories/{id}/landing-page", categoryPageHandler.CurationPage)
+		r.Post("/categories/{id}/landing-page", categoryPageHandler.UpdateLandingPage)
+		r.Post("/categories/{id}/featured", categoryPageHandler.FeatureEvent)
+		r.Post("/categories/{id}/featured/{eventID}/remove", categoryPageHandler.UnfeatureEvent)
 
 		// Withdrawal management
 		r.Get("/withdrawals", withdrawalHandler.AdminWithdrawalsPage)
 		r.Post("/withdrawals/{id}/status", withdrawalHandler.UpdateWithdrawalStatus)
 
+		// Events
+		r.Get("/events", adminBulkHandler.EventsPage)
+		r.Post("/events/bulk", adminBulkHandler.BulkEvents)
+
 		// Event moderation
 		r.Get("/events/moderate", eventModerationHandler.AdminEventModerationPage)
 		r.Post("/events/{id}/moderate", eventModerationHandler.ModerateEvent)
 
+		// Data quality
+		r.Get("/data-quality", dataQualityHandler.Dashboard)
+		r.Post("/data-quality/run", dataQualityHandler.RunChecks)
+		r.Post("/data-quality/{check}/remediate", dataQualityHandler.Remediate)
+		r.Get("/payments/reconciliation", paymentReconciliationHandler.Dashboard)
+		r.Post("/payments/reconciliation/run", paymentReconciliationHandler.Run)
+		r.Post("/payments/reconciliation/{id}/resolve", paymentReconciliationHandler.Resolve)
+		r.Get("/disputes", disputeHandler.AdminDisputes)
+		r.Post("/disputes/{id}/resolve", disputeHandler.ResolveDispute)
+
+		// Platform reports
+		r.Get("/reports", platformReportHandler.Reports)
+		r.Get("/reports/export", platformReportHandler.ExportReports)
+
+		r.Get("/fraud/linkage", fraudLinkageHandler.Clusters)
+		r.Get("/fraud/linkage/users/{id}", fraudLinkageHandler.UserLinks)
+
 		// System settings
 		r.Get("/settings", adminSettingsHandler.SettingsPage)
 		r.Post("/settings", adminSettingsHandler.UpdateSettings)
+		r.Post("/settings/flags", adminSettingsHandler.UpdateFeatureFlag)
+		r.Post("/settings/storage-gc", adminSettingsHandler.RunStorageGC)
+		r.Get("/settings/snippets", adminSnippetsHandler.SnippetsPage)
+		r.Post("/settings/snippets", adminSnippetsHandler.UpdateSnippets)
+		r.Post("/settings/snippets/reset", adminSnippetsHandler.ResetSnippet)
+		r.Get("/emails", adminEmailsHandler.LogPage)
+		r.Post("/emails/{id}/retry", adminEmailsHandler.RetryEmail)
+		r.Get("/emails/preview", adminEmailsHandler.PreviewPage)
+
+		// Announcement banners
+		r.Get("/announcements", announcementHandler.AnnouncementsPage)
+		r.Post("/announcements", announcementHandler.CreateAnnouncement)
+		r.Get("/announcements/{id}/edit", announcementHandler.EditAnnouncementPage)
+		r.Post("/announcements/{id}", announcementHandler.UpdateAnnouncement)
+		r.Post("/announcements/{id}/delete", announcementHandler.DeleteAnnouncement)
+
+		// Help center articles
+		r.Get("/help", helpHandler.ArticlesPage)
+		r.Get("/help/new", helpHandler.NewArticlePage)
+		r.Post("/help", helpHandler.CreateArticle)
+		r.Get("/help/{id}/edit", helpHandler.EditArticlePage)
+		r.Post("/help/{id}", helpHandler.UpdateArticle)
+		r.Post("/help/{id}/delete", helpHandler.DeleteArticle)
 	})
 
 	// Moderator routes
 	r.Route("/moderator", func(r chi.Router) {
 		r.Use(middleware.RequireAuth)
+		r.Use(middleware.CacheControl(middleware.NoStoreCacheControl))
 		r.Use(authMiddleware.RequireRole(models.UserRoleModerator))
 		r.Use(csrfMiddleware.CSRFProtection) // Add CSRF protection to POST routes
 
@@ -458,8 +1363,6 @@ func main() {
 	// Additional public routes that might be expected
 	// Pricing route is already defined above
 
-	r.Get("/categories", publicHandler.CategoriesPage)
-
 	// Health check endpoint
 	r.Get("/health", func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Set("Content-Type", "application/json")
@@ -468,7 +1371,7 @@ func main() {
 
 	serverAddr := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
 	log.Printf("Server starting on %s (Environment: %s)", serverAddr, cfg.Server.Env)
-	log.Fatal(http.ListenAndServe(serverAddr, r))
+	serve(lifecycle, serverAddr, r)
 }
 
 func setupSimpleRoutes(cfg *config.Config) {
@@ -481,8 +1384,9 @@ func setupSimpleRoutes(cfg *config.Config) {
 	r := chi.NewRouter()
 
 	// Basic middleware
-	r.Use(chimiddleware.Logger)
-	r.Use(chimiddleware.Recoverer)
+	r.Use(middleware.RequestIDMiddleware)
+	r.Use(middleware.LoggingMiddleware)
+	r.Use(middleware.ErrorHandlingMiddleware)
 
 	// Static files
 	r.Handle("/static/*", http.StripPrefix("/static/", http.FileServer(http.Dir("web/static/"))))
@@ -501,6 +1405,23 @@ func setupSimpleRoutes(cfg *config.Config) {
 
 	serverAddr := fmt.Sprintf("%s:%s", cfg.Server.Host, cfg.Server.Port)
 	log.Printf("Server starting on %s (Environment: %s) - Simple Mode", serverAddr, cfg.Server.Env)
-	log.Fatal(http.ListenAndServe(serverAddr, r))
+	serve(server.NewLifecycle(cfg.Server.ShutdownTimeout), serverAddr, r)
+}
+
+// serve runs the router until SIGINT or SIGTERM, then drains outstanding
+// requests, stops background jobs and closes resources before returning
+func serve(lifecycle *server.Lifecycle, addr string, handler http.Handler) {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           handler,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+	if err := lifecycle.Serve(ctx, srv); err != nil {
+		log.Fatal(err)
+	}
+	log.Println("Server stopped")
 }
 
@@ -0,0 +1,484 @@
+// Command smoke runs an end-to-end purchase against a running deployment.
+//
+// It registers a disposable account, buys a ticket for a dedicated smoke
+// event through the mock payment provider, downloads the ticket PDF and
+// checks the ticket in as the event's organizer. Each step is printed as it
+// runs and the command exits non-zero on the first failure, so it can gate
+// deploy pipelines.
+//
+//	go run ./cmd/smoke -base-url=https://staging.example.com -event=42 \
+//		-inbox-url=http://mailpit:8025 -inbox-address=smoke@example.com \
+//		-organizer-email=smoke-organizer@example.com -organizer-password=...
+//
+// The deployment must run outside ENV=production without Paystack
+// credentials so checkout falls back to the mock payment provider.
+// Verification emails are read from a Mailpit-compatible test inbox; without
+// one the account must be able to log in unverified.
+package main
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"html"
+	"io"
+	"net/http"
+	"net/http/cookiejar"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var (
+	ticketTypePattern   = regexp.MustCompile(`name="ticket_type_id" value="(\d+)"`)
+	orderPathPattern    = regexp.MustCompile(`^/orders/(\d+)/confirmation`)
+	qrCodePattern       = regexp.MustCompile(`<p class="text-xs text-gray-500 font-mono break-all">([^<]+)</p>`)
+	verifyTokenPattern  = regexp.MustCompile(`/auth/verify\?token=([A-Za-z0-9_\-%.]+)`)
+	errStepSkipped      = errors.New("skipped")
+	registrationMessage = "check your email and click the verification link"
+)
+
+// client is a browser-like session against the deployment
+type client struct {
+	baseURL string
+	http    *http.Client
+	csrf    string
+}
+
+// smoke holds the state threaded through the steps
+type smoke struct {
+	baseURL           string
+	eventID           int
+	ticketTypeID      int
+	inboxURL          string
+	email             string
+	password          string
+	organizerEmail    string
+	organizerPassword string
+	timeout           time.Duration
+
+	buyer   *client
+	orderID int
+	qrCodes []string
+}
+
+func main() {
+	var (
+		baseURLFlag           = flag.String("base-url", "http://localhost:8080", "Base URL of the deployment to test")
+		eventFlag             = flag.Int("event", 0, "ID of a published event with free or cheap tickets kept for smoke tests")
+		ticketTypeFlag        = flag.Int("ticket-type", 0, "Ticket type to buy (defaults to the first one on sale)")
+		inboxURLFlag          = flag.String("inbox-url", "", "Mailpit-compatible test inbox API for verification emails")
+		inboxAddressFlag      = flag.String("inbox-address", "smoke@example.com", "Address the disposable account is tagged from, e.g. smoke+<run>@example.com")
+		organizerEmailFlag    = flag.String("organizer-email", "", "Organizer of the smoke event, used to check the ticket in")
+		organizerPasswordFlag = flag.String("organizer-password", "", "Password of the smoke event's organizer")
+		timeoutFlag           = flag.Duration("timeout", 2*time.Minute, "How long to wait for emails before failing")
+	)
+	flag.Parse()
+
+	if *eventFlag <= 0 {
+		fmt.Fprintln(os.Stderr, "smoke: -event is required")
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	s := &smoke{
+		baseURL:           strings.TrimRight(*baseURLFlag, "/"),
+		eventID:           *eventFlag,
+		ticketTypeID:      *ticketTypeFlag,
+		inboxURL:          strings.TrimRight(*inboxURLFlag, "/"),
+		email:             disposableAddress(*inboxAddressFlag),
+		password:          "Smoke-" + randomHex(8) + "-1a",
+		organizerEmail:    *organizerEmailFlag,
+		organizerPassword: *organizerPasswordFlag,
+		timeout:           *timeoutFlag,
+	}
+
+	fmt.Printf("🔥 Smoke testing %s as %s\n", s.baseURL, s.email)
+
+	steps := []struct {
+		name string
+		run  func() error
+	}{
+		{"health check", s.checkHealth},
+		{"public pages", s.checkPages},
+		{"register disposable account", s.register},
+		{"verify email from test inbox", s.verifyEmail},
+		{"log in", s.login},
+		{"add ticket to cart", s.addToCart},
+		{"check out with mock payment", s.checkout},
+		{"download ticket PDF", s.downloadTickets},
+		{"check ticket in as organizer", s.checkIn},
+	}
+
+	for _, step := range steps {
+		start := time.Now()
+		err := step.run()
+		switch {
+		case errors.Is(err, errStepSkipped):
+			fmt.Printf("⏭️  %s: %v\n", step.name, err)
+		case err != nil:
+			fmt.Printf("❌ %s: %v\n", step.name, err)
+			os.Exit(1)
+		default:
+			fmt.Printf("✅ %s (%s)\n", step.name, time.Since(start).Round(time.Millisecond))
+		}
+	}
+
+	fmt.Printf("🎉 Smoke test passed: order %d\n", s.orderID)
+}
+
+func (s *smoke) checkHealth() error {
+	c, err := newClient(s.baseURL)
+	if err != nil {
+		return err
+	}
+	_, err = c.get("/health")
+	return err
+}
+
+func (s *smoke) checkPages() error {
+	c, err := newClient(s.baseURL)
+	if err != nil {
+		return err
+	}
+
+	for _, path := range []string{"/", "/events", fmt.Sprintf("/events/%d", s.eventID)} {
+		if _, err := c.get(path); err != nil {
+			return err
+		}
+	}
+
+	if s.ticketTypeID == 0 {
+		body, err := c.get(fmt.Sprintf("/events/%d/availability", s.eventID))
+		if err != nil {
+			return err
+		}
+		match := ticketTypePattern.FindStringSubmatch(body)
+		if match == nil {
+			return fmt.Errorf("event %d has no ticket types on sale", s.eventID)
+		}
+		s.ticketTypeID, _ = strconv.Atoi(match[1])
+	}
+	return nil
+}
+
+func (s *smoke) register() error {
+	c, err := newClient(s.baseURL)
+	if err != nil {
+		return err
+	}
+	s.buyer = c
+
+	body, path, err := c.postForm("/auth/register", url.Values{
+		"email":            {s.email},
+		"password":         {s.password},
+		"password_confirm": {s.password},
+		"first_name":       {"Smoke"},
+		"last_name":        {"Test"},
+		"terms":            {"on"},
+	})
+	if err != nil {
+		return err
+	}
+	if path != "/dashboard" && !strings.Contains(body, registrationMessage) {
+		return fmt.Errorf("registration did not succeed, landed on %s", path)
+	}
+	return nil
+}
+
+func (s *smoke) verifyEmail() error {
+	if s.inboxURL == "" {
+		return fmt.Errorf("%w, no -inbox-url", errStepSkipped)
+	}
+
+	message, err := s.waitForEmail(s.email)
+	if err != nil {
+		return err
+	}
+	match := verifyTokenPattern.FindStringSubmatch(message)
+	if match == nil {
+		return errors.New("verification email has no verification link")
+	}
+
+	token, err := url.QueryUnescape(html.UnescapeString(match[1]))
+	if err != nil {
+		return fmt.Errorf("invalid verification token: %w", err)
+	}
+	_, err = s.buyer.get("/auth/verify?token=" + url.QueryEscape(token))
+	return err
+}
+
+func (s *smoke) login() error {
+	return s.buyer.login(s.email, s.password)
+}
+
+func (s *smoke) addToCart() error {
+	_, _, err := s.buyer.postForm("/cart/add", url.Values{
+		"event_id":       {strconv.Itoa(s.eventID)},
+		"ticket_type_id": {strconv.Itoa(s.ticketTypeID)},
+		"quantity":       {"1"},
+	})
+	return err
+}
+
+func (s *smoke) checkout() error {
+	body, path, err := s.buyer.postForm("/checkout", url.Values{
+		"billing_email":  {s.email},
+		"billing_name":   {"Smoke Test"},
+		"payment_method": {"card"},
+	})
+	if err != nil {
+		return err
+	}
+
+	match := orderPathPattern.FindStringSubmatch(path)
+	if match == nil {
+		return fmt.Errorf("checkout landed on %s instead of an order confirmation, is the mock payment provider active?", path)
+	}
+	s.orderID, _ = strconv.Atoi(match[1])
+
+	for _, qr := range qrCodePattern.FindAllStringSubmatch(body, -1) {
+		s.qrCodes = append(s.qrCodes, html.UnescapeString(strings.TrimSpace(qr[1])))
+	}
+	if len(s.qrCodes) == 0 {
+		return fmt.Errorf("order %d confirmation shows no tickets", s.orderID)
+	}
+	return nil
+}
+
+func (s *smoke) downloadTickets() error {
+	body, err := s.buyer.get(fmt.Sprintf("/dashboard/orders/%d/tickets/download", s.orderID))
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(body, "%PDF") {
+		return errors.New("ticket download is not a PDF")
+	}
+	return nil
+}
+
+func (s *smoke) checkIn() error {
+	if s.organizerEmail == "" || s.organizerPassword == "" {
+		return fmt.Errorf("%w, no -organizer-email and -organizer-password", errStepSkipped)
+	}
+
+	organizer, err := newClient(s.baseURL)
+	if err != nil {
+		return err
+	}
+	if err := organizer.login(s.organizerEmail, s.organizerPassword); err != nil {
+		return fmt.Errorf("organizer %w", err)
+	}
+
+	payload, _ := json.Marshal(map[string]string{
+		"qr_code": s.qrCodes[0],
+		"gate":    "smoke",
+		"device":  "smoke-test",
+	})
+	body, err := organizer.do(http.MethodPost, fmt.Sprintf("/organizer/events/%d/scans", s.eventID), "application/json", bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+
+	var scan struct {
+		Result string `json:"result"`
+		Reason string `json:"reason"`
+	}
+	if err := json.Unmarshal([]byte(body), &scan); err != nil {
+		return fmt.Errorf("invalid scan response: %w", err)
+	}
+	if scan.Result != "accepted" {
+		return fmt.Errorf("scan was %s: %s", scan.Result, scan.Reason)
+	}
+	return nil
+}
+
+// waitForEmail polls the test inbox until a message for the address arrives
+// and returns its text and HTML bodies
+func (s *smoke) waitForEmail(address string) (string, error) {
+	inbox := &http.Client{Timeout: 10 * time.Second}
+	deadline := time.Now().Add(s.timeout)
+
+	for {
+		var search struct {
+			Messages []struct {
+				ID string `json:"ID"`
+			} `json:"messages"`
+		}
+		query := url.QueryEscape(fmt.Sprintf("to:%q", address))
+		if err := getJSON(inbox, s.inboxURL+"/api/v1/search?query="+query, &search); err != nil {
+			return "", fmt.Errorf("failed to search test inbox: %w", err)
+		}
+
+		if len(search.Messages) > 0 {
+			var message struct {
+				Text string `json:"Text"`
+				HTML string `json:"HTML"`
+			}
+			if err := getJSON(inbox, s.inboxURL+"/api/v1/message/"+search.Messages[0].ID, &message); err != nil {
+				return "", fmt.Errorf("failed to read email: %w", err)
+			}
+			return message.Text + "\n" + message.HTML, nil
+		}
+
+		if time.Now().After(deadline) {
+			return "", fmt.Errorf("no email for %s after %s", address, s.timeout)
+		}
+		time.Sleep(2 * time.Second)
+	}
+}
+
+func newClient(baseURL string) (*client, error) {
+	jar, err := cookiejar.New(nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create cookie jar: %w", err)
+	}
+	return &client{
+		baseURL: baseURL,
+		http:    &http.Client{Jar: jar, Timeout: 30 * time.Second},
+	}, nil
+}
+
+func (c *client) get(path string) (string, error) {
+	return c.do(http.MethodGet, path, "", nil)
+}
+
+// postForm submits a form with the session's CSRF token and returns the body
+// and path of the page it ends up on
+func (c *client) postForm(path string, form url.Values) (string, string, error) {
+	if err := c.ensureCSRF(); err != nil {
+		return "", "", err
+	}
+	form.Set("csrf_token", c.csrf)
+
+	req, err := http.NewRequest(http.MethodPost, c.baseURL+path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, body, err := c.send(req)
+	if err != nil {
+		return "", "", err
+	}
+	return body, resp.Request.URL.Path, nil
+}
+
+func (c *client) do(method, path, contentType string, payload io.Reader) (string, error) {
+	if method != http.MethodGet {
+		if err := c.ensureCSRF(); err != nil {
+			return "", err
+		}
+	}
+
+	req, err := http.NewRequest(method, c.baseURL+path, payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to build request: %w", err)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	if c.csrf != "" {
+		req.Header.Set("X-CSRF-Token", c.csrf)
+	}
+
+	_, body, err := c.send(req)
+	return body, err
+}
+
+func (c *client) send(req *http.Request) (*http.Response, string, error) {
+	resp, err := c.http.Do(req)
+	if err != nil {
+		return nil, "", fmt.Errorf("%s %s: %w", req.Method, req.URL.Path, err)
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to read %s: %w", req.URL.Path, err)
+	}
+	if resp.StatusCode >= 400 {
+		return nil, "", fmt.Errorf("%s %s returned %d: %s", req.Method, req.URL.Path, resp.StatusCode, summarize(body))
+	}
+	return resp, string(body), nil
+}
+
+// ensureCSRF fetches the CSRF token tied to the client's session
+func (c *client) ensureCSRF() error {
+	if c.csrf != "" {
+		return nil
+	}
+
+	body, err := c.get("/auth/csrf-token")
+	if err != nil {
+		return err
+	}
+	var token struct {
+		CSRFToken string `json:"csrf_token"`
+	}
+	if err := json.Unmarshal([]byte(body), &token); err != nil || token.CSRFToken == "" {
+		return errors.New("failed to get CSRF token")
+	}
+	c.csrf = token.CSRFToken
+	return nil
+}
+
+func (c *client) login(email, password string) error {
+	_, path, err := c.postForm("/auth/login", url.Values{
+		"email":    {email},
+		"password": {password},
+	})
+	if err != nil {
+		return err
+	}
+	if path == "/auth/login" {
+		return errors.New("login was rejected, is the account verified?")
+	}
+	// Logging in starts a new session with a new token
+	c.csrf = ""
+	return nil
+}
+
+func getJSON(c *http.Client, url string, v interface{}) error {
+	resp, err := c.Get(url)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("%s returned %d", url, resp.StatusCode)
+	}
+	return json.NewDecoder(resp.Body).Decode(v)
+}
+
+// disposableAddress tags the inbox address with the run so every run gets a
+// fresh account
+func disposableAddress(address string) string {
+	at := strings.LastIndex(address, "@")
+	if at < 0 {
+		return address
+	}
+	return fmt.Sprintf("%s+%d%s", address[:at], time.Now().Unix(), address[at:])
+}
+
+func randomHex(n int) string {
+	b := make([]byte, n)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+func summarize(body []byte) string {
+	text := strings.TrimSpace(string(body))
+	if len(text) > 200 {
+		text = text[:200] + "..."
+	}
+	return text
+}
@@ -0,0 +1,194 @@
+// Package assets serves static files under content-hashed names, so they can
+// be cached forever by browsers and CDNs and still change on deploy.
+package assets
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+)
+
+// ManifestFile is the manifest a build step may write into the static
+// directory. Without one the files are hashed at startup.
+const ManifestFile = "manifest.json"
+
+// URLPrefix is where static files are served from
+const URLPrefix = "/static/"
+
+// Cache headers: hashed names never change content, anything else is
+// revalidated on every use
+const (
+	immutableCacheControl  = "public, max-age=31536000, immutable"
+	revalidateCacheControl = "no-cache"
+)
+
+// hashLength is how many hex characters of the content hash go in names
+const hashLength = 10
+
+// Manifest maps static files to their content-hashed names
+type Manifest struct {
+	dir     string
+	baseURL string
+	hashed  map[string]string // Logical name to hashed name
+	logical map[string]string // Hashed name to logical name
+}
+
+// Load builds the manifest for the files in dir, reading its manifest file
+// when there is one. baseURL is an optional CDN origin asset URLs are
+// prefixed with; the CDN is expected to pull from this server.
+func Load(dir, baseURL string) (*Manifest, error) {
+	names, err := readManifest(dir)
+	if errors.Is(err, fs.ErrNotExist) {
+		names, err = Build(dir)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	m := &Manifest{
+		dir:     dir,
+		baseURL: strings.TrimRight(baseURL, "/"),
+		hashed:  names,
+		logical: make(map[string]string, len(names)),
+	}
+	for name, hashedName := range names {
+		m.logical[hashedName] = name
+	}
+	return m, nil
+}
+
+// Build hashes every file in dir, returning each file's hashed name by its
+// slash-separated path relative to dir, e.g. "js/app.js" to
+// "js/app.3f2a9c1b7e.js"
+func Build(dir string) (map[string]string, error) {
+	names := make(map[string]string)
+	err := filepath.WalkDir(dir, func(file string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() || strings.HasPrefix(entry.Name(), ".") || entry.Name() == ManifestFile {
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, file)
+		if err != nil {
+			return err
+		}
+		hash, err := hashFile(file)
+		if err != nil {
+			return err
+		}
+		name := filepath.ToSlash(rel)
+		names[name] = hashedName(name, hash)
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash static assets: %w", err)
+	}
+	return names, nil
+}
+
+// WriteManifest hashes the files in dir and writes the manifest file, so
+// servers don't hash them at startup
+func WriteManifest(dir string) error {
+	names, err := Build(dir)
+	if err != nil {
+		return err
+	}
+	data, err := json.MarshalIndent(names, "", "  ")
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, ManifestFile), append(data, '\n'), 0o644)
+}
+
+// URL returns the URL a static file is served from, its hashed name when the
+// manifest knows it. A nil manifest returns the plain path, so templates
+// rendered without one still work.
+func (m *Manifest) URL(name string) string {
+	name = strings.TrimPrefix(name, "/")
+	if m == nil {
+		return URLPrefix + name
+	}
+	if hashedName, ok := m.hashed[name]; ok {
+		name = hashedName
+	}
+	return m.baseURL + URLPrefix + name
+}
+
+// Handler serves the static files, expecting URLPrefix to be stripped.
+// Hashed names are cached forever; plain names still work but are
+// revalidated.
+func (m *Manifest) Handler() http.Handler {
+	files := http.FileServer(http.Dir(m.dir))
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		name := strings.TrimPrefix(path.Clean("/"+r.URL.Path), "/")
+		if name == ManifestFile {
+			http.NotFound(w, r)
+			return
+		}
+
+		if logicalName, ok := m.logical[name]; ok {
+			w.Header().Set("Cache-Control", immutableCacheControl)
+			w.Header().Set("ETag", etag(logicalName, name))
+			r2 := r.Clone(r.Context())
+			r2.URL.Path = "/" + logicalName
+			files.ServeHTTP(w, r2)
+			return
+		}
+
+		w.Header().Set("Cache-Control", revalidateCacheControl)
+		if hashedName, ok := m.hashed[name]; ok {
+			w.Header().Set("ETag", etag(name, hashedName))
+		}
+		files.ServeHTTP(w, r)
+	})
+}
+
+func readManifest(dir string) (map[string]string, error) {
+	data, err := os.ReadFile(filepath.Join(dir, ManifestFile))
+	if err != nil {
+		return nil, err
+	}
+	var names map[string]string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", ManifestFile, err)
+	}
+	return names, nil
+}
+
+func hashFile(file string) (string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return "", err
+	}
+	defer f.Close()
+
+	hash := sha256.New()
+	if _, err := io.Copy(hash, f); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(hash.Sum(nil))[:hashLength], nil
+}
+
+// etag is a file's content hash, taken back out of its hashed name
+func etag(name, hashedName string) string {
+	ext := path.Ext(name)
+	hash := strings.TrimSuffix(strings.TrimPrefix(hashedName, strings.TrimSuffix(name, ext)+"."), ext)
+	return `"` + hash + `"`
+}
+
+// hashedName puts the hash before the extension, e.g. "js/app.3f2a9c1b7e.js"
+func hashedName(name, hash string) string {
+	ext := path.Ext(name)
+	return strings.TrimSuffix(name, ext) + "." + hash + ext
+}
@@ -0,0 +1,164 @@
+package assets
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeStaticFiles(t *testing.T) string {
+	t.Helper()
+	dir := t.TempDir()
+	files := map[string]string{
+		"js/app.js":   "console.log('hello')",
+		"css/app.css": "body { margin: 0 }",
+		".gitkeep":    "",
+	}
+	for name, content := range files {
+		file := filepath.Join(dir, filepath.FromSlash(name))
+		if err := os.MkdirAll(filepath.Dir(file), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(file, []byte(content), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	return dir
+}
+
+func TestLoad_HashesFiles(t *testing.T) {
+	manifest, err := Load(writeStaticFiles(t), "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	url := manifest.URL("js/app.js")
+	if !strings.HasPrefix(url, "/static/js/app.") || !strings.HasSuffix(url, ".js") || url == "/static/js/app.js" {
+		t.Errorf("expected a hashed URL, got %s", url)
+	}
+	if _, ok := manifest.hashed[".gitkeep"]; ok {
+		t.Error("dotfiles should not be in the manifest")
+	}
+	if got := manifest.URL("/css/missing.css"); got != "/static/css/missing.css" {
+		t.Errorf("unknown files should keep their name, got %s", got)
+	}
+}
+
+func TestLoad_HashChangesWithContent(t *testing.T) {
+	dir := writeStaticFiles(t)
+	before, err := Load(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, "js", "app.js"), []byte("console.log('bye')"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	after, err := Load(dir, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if before.URL("js/app.js") == after.URL("js/app.js") {
+		t.Error("expected the URL to change with the content")
+	}
+	if before.URL("css/app.css") != after.URL("css/app.css") {
+		t.Error("expected unchanged files to keep their URL")
+	}
+}
+
+func TestLoad_ReadsManifestFile(t *testing.T) {
+	dir := writeStaticFiles(t)
+	if err := WriteManifest(dir); err != nil {
+		t.Fatal(err)
+	}
+	built, err := Build(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := built[ManifestFile]; ok {
+		t.Error("the manifest should not list itself")
+	}
+
+	// Changes after the manifest was written aren't picked up
+	if err := os.WriteFile(filepath.Join(dir, "js", "app.js"), []byte("changed"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	manifest, err := Load(dir, "https://cdn.example.com/")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "https://cdn.example.com/static/" + built["js/app.js"]; manifest.URL("js/app.js") != want {
+		t.Errorf("expected %s, got %s", want, manifest.URL("js/app.js"))
+	}
+}
+
+func TestManifest_NilURL(t *testing.T) {
+	var manifest *Manifest
+	if got := manifest.URL("js/app.js"); got != "/static/js/app.js" {
+		t.Errorf("expected the plain path, got %s", got)
+	}
+}
+
+func TestManifest_Handler(t *testing.T) {
+	manifest, err := Load(writeStaticFiles(t), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := http.StripPrefix(URLPrefix, manifest.Handler())
+
+	tests := []struct {
+		name         string
+		path         string
+		status       int
+		cacheControl string
+		body         string
+	}{
+		{"hashed", manifest.URL("js/app.js"), http.StatusOK, immutableCacheControl, "console.log('hello')"},
+		{"plain", "/static/js/app.js", http.StatusOK, revalidateCacheControl, "console.log('hello')"},
+		{"stale hash", "/static/js/app.0000000000.js", http.StatusNotFound, "", ""},
+		{"manifest", "/static/" + ManifestFile, http.StatusNotFound, "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, tt.path, nil))
+
+			if rec.Code != tt.status {
+				t.Fatalf("expected status %d, got %d", tt.status, rec.Code)
+			}
+			if got := rec.Header().Get("Cache-Control"); got != tt.cacheControl {
+				t.Errorf("expected Cache-Control %q, got %q", tt.cacheControl, got)
+			}
+			if tt.body != "" && rec.Body.String() != tt.body {
+				t.Errorf("unexpected body %q", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestManifest_HandlerETag(t *testing.T) {
+	manifest, err := Load(writeStaticFiles(t), "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler := http.StripPrefix(URLPrefix, manifest.Handler())
+
+	rec := httptest.NewRecorder()
+	handler.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/static/js/app.js", nil))
+	etag := rec.Header().Get("ETag")
+	if etag == "" || !strings.Contains(manifest.URL("js/app.js"), strings.Trim(etag, `"`)) {
+		t.Fatalf("expected the content hash as ETag, got %q", etag)
+	}
+
+	req := httptest.NewRequest(http.MethodGet, "/static/js/app.js", nil)
+	req.Header.Set("If-None-Match", etag)
+	rec = httptest.NewRecorder()
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusNotModified {
+		t.Errorf("expected 304 for an unchanged file, got %d", rec.Code)
+	}
+}
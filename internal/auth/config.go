@@ -5,13 +5,16 @@ import (
 	"database/sql"
 	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"github.com/aarondl/authboss/v3"
 	"github.com/aarondl/authboss/v3/defaults"
 	"github.com/gorilla/sessions"
+	"event-ticketing-platform/internal/logging"
 	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/ratelimit"
 	"event-ticketing-platform/internal/services"
 	"event-ticketing-platform/internal/utils"
 )
@@ -20,8 +23,22 @@ import (
 type AuthbossConfig struct {
 	Authboss *authboss.Authboss
 	Storage  *Storage
+
+	rateLimiter    *ratelimit.Limiter
+	rateLimitRules map[string]ratelimit.Rule
+
+	twoFactorService *services.TwoFactorService
+	auditService     *services.AuditService
+	fraudLinkage     *services.FraudLinkageService
+
+	oauthProviders map[string]*OAuthProvider
+	secureCookies  bool
 }
 
+// twoFactorPendingTimeout is how long a user has to enter their second factor
+// after a successful password check
+const twoFactorPendingTimeout = 10 * time.Minute
+
 // NewAuthbossConfig creates and configures a new Authboss instance
 func NewAuthbossConfig(db *sql.DB, sessionStore sessions.Store, emailService services.EmailService, baseURL string, isDevelopment bool) (*AuthbossConfig, error) {
 	// Create Authboss instance
@@ -73,8 +90,9 @@ func NewAuthbossConfig(db *sql.DB, sessionStore sessions.Store, emailService ser
 	}
 
 	return &AuthbossConfig{
-		Authboss: ab,
-		Storage:  storage,
+		Authboss:      ab,
+		Storage:       storage,
+		secureCookies: !isDevelopment,
 	}, nil
 }
 
@@ -145,6 +163,62 @@ func (ac *AuthbossConfig) GetAuthbossHandler() http.Handler {
 				}
 			}
 			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		case "/auth/2fa":
+			if r.Method == "GET" {
+				// Render second-step verification page
+				component := ac.Authboss.Config.Core.ViewRenderer
+				if component != nil {
+					data := make(map[string]interface{})
+					output, contentType, err := component.Render(r.Context(), "2fa", data)
+					if err != nil {
+						http.Error(w, "Failed to render two-factor page", http.StatusInternalServerError)
+						return
+					}
+					w.Header().Set("Content-Type", contentType)
+					w.Write(output)
+					return
+				}
+			} else if r.Method == "POST" {
+				// Handle second-step code submission
+				ac.handleTwoFactor(w, r)
+				return
+			}
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		case "/auth/magic-link":
+			if r.Method == "GET" {
+				// Render sign-in link request page
+				component := ac.Authboss.Config.Core.ViewRenderer
+				if component != nil {
+					data := make(map[string]interface{})
+					output, contentType, err := component.Render(r.Context(), "magic_link", data)
+					if err != nil {
+						http.Error(w, "Failed to render sign-in link page", http.StatusInternalServerError)
+						return
+					}
+					w.Header().Set("Content-Type", contentType)
+					w.Write(output)
+					return
+				}
+			} else if r.Method == "POST" {
+				// Email a sign-in link
+				ac.handleMagicLinkRequest(w, r)
+				return
+			}
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		case "/auth/magic-link/verify":
+			if r.Method == "GET" || r.Method == "POST" {
+				// Confirm and sign in with an emailed link
+				ac.handleMagicLinkVerify(w, r)
+				return
+			}
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
+		case "/auth/unlock":
+			if r.Method == "GET" || r.Method == "POST" {
+				// Confirm and unlock an account with an emailed link
+				ac.handleUnlock(w, r)
+				return
+			}
+			http.Error(w, "Method not allowed", http.StatusMethodNotAllowed)
 		case "/auth/confirm":
 			if r.Method == "GET" {
 				// Handle email confirmation
@@ -212,10 +286,8 @@ func (ac *AuthbossConfig) Cleanup() error {
 
 // handleLogin handles the login form submission
 func (ac *AuthbossConfig) handleLogin(w http.ResponseWriter, r *http.Request) {
-	fmt.Printf("[DEBUG] Login handler called - Method: %s, URL: %s\n", r.Method, r.URL.String())
-	
 	// Check rate limiting
-	if !ac.RateLimitCheck(r, "login") {
+	if !ac.RateLimitCheck(w, r, "login") {
 		ac.logSecurityEvent("rate_limit_exceeded", "", r, "Login rate limit exceeded")
 		http.Error(w, "Too many login attempts. Please try again later.", http.StatusTooManyRequests)
 		return
@@ -223,7 +295,7 @@ func (ac *AuthbossConfig) handleLogin(w http.ResponseWriter, r *http.Request) {
 	
 	// Parse form data
 	if err := r.ParseForm(); err != nil {
-		fmt.Printf("[DEBUG] Failed to parse form: %v\n", err)
+		logging.FromContext(r.Context()).Warn("failed to parse login form", "error", err)
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
 		return
 	}
@@ -233,21 +305,18 @@ func (ac *AuthbossConfig) handleLogin(w http.ResponseWriter, r *http.Request) {
 	rememberMe := r.FormValue("remember_me") == "on"
 	csrfToken := r.FormValue("csrf_token")
 
-	fmt.Printf("[DEBUG] Login form data - Email: %s, Password length: %d, CSRF: %s, RememberMe: %t\n", 
-		email, len(password), csrfToken, rememberMe)
-
 	// Validate CSRF token
 	sessionStorer := ac.Storage.SessionStorer
 	session, err := sessionStorer.store.Get(r, sessionStorer.sessionName)
 	if err != nil {
-		fmt.Printf("[DEBUG] Failed to get session for CSRF validation: %v\n", err)
+		logging.FromContext(r.Context()).Error("failed to get session for CSRF validation", "error", err)
 		http.Error(w, "Session error", http.StatusInternalServerError)
 		return
 	}
 
 	sessionToken, ok := session.Values["csrf_token"].(string)
 	if !ok || sessionToken == "" {
-		fmt.Printf("[DEBUG] No CSRF token in session\n")
+		logging.FromContext(r.Context()).Warn("login without a CSRF token in the session")
 		data := map[string]interface{}{
 			"validation": map[string][]string{
 				"general": {"Security token missing. Please refresh the page and try again."},
@@ -272,7 +341,7 @@ func (ac *AuthbossConfig) handleLogin(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if csrfToken != sessionToken {
-		fmt.Printf("[DEBUG] CSRF token mismatch - Session: %s, Request: %s\n", sessionToken, csrfToken)
+		logging.FromContext(r.Context()).Warn("login CSRF token mismatch", "token_sent", csrfToken != "")
 		data := map[string]interface{}{
 			"validation": map[string][]string{
 				"general": {"Security token mismatch. Please refresh the page and try again."},
@@ -296,8 +365,6 @@ func (ac *AuthbossConfig) handleLogin(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	fmt.Printf("[DEBUG] CSRF token validation passed\n")
-
 	// Validate input
 	if email == "" || password == "" {
 		// Render login page with errors
@@ -326,11 +393,12 @@ func (ac *AuthbossConfig) handleLogin(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Try to load user from storage
-	fmt.Printf("[DEBUG] Attempting to load user: %s\n", email)
 	user, err := ac.Authboss.Config.Storage.Server.Load(r.Context(), email)
 	if err != nil || user == nil {
 		// User not found - still record failed attempt for rate limiting
-		fmt.Printf("[DEBUG] User not found or error loading user: %v\n", err)
+		if err != nil && err != authboss.ErrUserNotFound {
+			logging.FromContext(r.Context()).Error("failed to load user for login", "error", err)
+		}
 		ac.logSecurityEvent("login_failed", email, r, "User not found")
 		
 		data := map[string]interface{}{
@@ -359,46 +427,27 @@ func (ac *AuthbossConfig) handleLogin(w http.ResponseWriter, r *http.Request) {
 	// Verify password
 	authUser, ok := user.(*AuthbossUser)
 	if !ok {
-		fmt.Printf("[DEBUG] Failed to cast user to AuthbossUser\n")
+		logging.FromContext(r.Context()).Error("unexpected user type from storage", "type", fmt.Sprintf("%T", user))
 		http.Error(w, "Internal server error", http.StatusInternalServerError)
 		return
 	}
-	
-	fmt.Printf("[DEBUG] User loaded successfully - ID: %d, Email: %s\n", authUser.ID, authUser.Email)
 
 	// Check if account is locked
 	if ac.isAccountLocked(authUser) {
 		ac.logSecurityEvent("login_blocked", email, r, "Account locked")
-		
-		data := map[string]interface{}{
-			"validation": map[string][]string{
-				"general": {"Account is temporarily locked due to too many failed login attempts. Please try again later."},
-			},
-			"preserve": map[string]string{
-				"email": email,
-			},
-		}
-		
-		component := ac.Authboss.Config.Core.ViewRenderer
-		if component != nil {
-			output, contentType, err := component.Render(r.Context(), "login", data)
-			if err != nil {
-				http.Error(w, "Failed to render login page", http.StatusInternalServerError)
-				return
-			}
-			w.Header().Set("Content-Type", contentType)
-			w.WriteHeader(http.StatusUnprocessableEntity)
-			w.Write(output)
-			return
-		}
+		ac.renderLockedOut(w, r, authUser)
+		return
 	}
 
-	fmt.Printf("[DEBUG] Verifying password for user: %s\n", email)
 	if !authUser.VerifyPassword(password) {
 		// Invalid password - increment failed attempts
-		fmt.Printf("[DEBUG] Password verification failed for user: %s\n", email)
-		ac.recordFailedAttempt(authUser)
+		locked := ac.recordFailedAttempt(authUser)
 		ac.logSecurityEvent("login_failed", email, r, "Invalid password")
+		if locked {
+			ac.lockAccount(r, authUser)
+			ac.renderLockedOut(w, r, authUser)
+			return
+		}
 		
 		data := map[string]interface{}{
 			"validation": map[string][]string{
@@ -423,12 +472,59 @@ func (ac *AuthbossConfig) handleLogin(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
-	// Login successful - reset failed attempts and log success
+	// Password verified - reset failed attempts
 	ac.resetFailedAttempts(authUser)
-	ac.logSecurityEvent("login_success", email, r, "Successful login")
-	
-	// Reuse the existing session from CSRF validation above
-	// sessionStorer and session are already declared above
+
+	ac.finishLogin(w, r, session, authUser, rememberMe, "Successful login")
+}
+
+// finishLogin continues a login whose first factor has been verified, either
+// by password or by a social login provider. Users with two-factor
+// authentication enabled are sent to the second-step page first.
+func (ac *AuthbossConfig) finishLogin(w http.ResponseWriter, r *http.Request, session *sessions.Session, authUser *AuthbossUser, rememberMe bool, details string) {
+	email := authUser.Email
+	redirectTo := "/dashboard"
+	if ac.twoFactorService != nil {
+		enabled, err := ac.twoFactorService.IsEnabled(authUser.ID)
+		if err != nil {
+			logging.FromContext(r.Context()).Error("failed to check two-factor status", "user_id", authUser.ID, "error", err)
+			http.Error(w, "Internal server error", http.StatusInternalServerError)
+			return
+		}
+
+		if enabled {
+			// Hold the login until the second factor is verified
+			ac.logSecurityEvent("login_2fa_pending", email, r, "Password verified, awaiting two-factor code")
+			services.RenewSessionID(session)
+			session.Values["2fa_pending_user"] = authUser.GetPID()
+			session.Values["2fa_remember_me"] = rememberMe
+			session.Values["2fa_pending_at"] = time.Now().Unix()
+			if err := session.Save(r, w); err != nil {
+				http.Error(w, "Failed to save session", http.StatusInternalServerError)
+				return
+			}
+			http.Redirect(w, r, "/auth/2fa", http.StatusSeeOther)
+			return
+		}
+
+		if ac.twoFactorService.IsRequired(authUser.User) {
+			// Send users who must enroll straight to the setup page
+			redirectTo = "/dashboard/security?required=1"
+		}
+	}
+
+	ac.logSecurityEvent("login_success", email, r, details)
+	ac.completeLogin(w, r, session, authUser, rememberMe, redirectTo)
+}
+
+// completeLogin establishes the authenticated session for a user whose
+// credentials have been verified and redirects them to redirectTo
+func (ac *AuthbossConfig) completeLogin(w http.ResponseWriter, r *http.Request, session *sessions.Session, authUser *AuthbossUser, rememberMe bool, redirectTo string) {
+	sessionStorer := ac.Storage.SessionStorer
+
+	// Sign the user in under a new session ID, so one set before login can't
+	// be used to act as them
+	services.RenewSessionID(session)
 
 	// Set session values using Authboss session keys
 	session.Values[authboss.SessionKey] = authUser.GetPID()
@@ -446,24 +542,119 @@ func (ac *AuthbossConfig) handleLogin(w http.ResponseWriter, r *http.Request) {
 		session.Options.MaxAge = 86400 // 24 hours for regular sessions
 	}
 
-	err = session.Save(r, w)
+	err := session.Save(r, w)
 	if err != nil {
-		fmt.Printf("[DEBUG] Failed to save session: %v\n", err)
+		logging.FromContext(r.Context()).Error("failed to save login session", "user_id", authUser.ID, "error", err)
 		http.Error(w, "Failed to save session", http.StatusInternalServerError)
 		return
 	}
 	
-	fmt.Printf("[DEBUG] Session saved successfully - User ID: %s, Session values: %+v\n", 
-		authUser.GetPID(), session.Values)
+	logging.FromContext(r.Context()).Info("user logged in", "user_id", authUser.ID, "remember_me", rememberMe)
 
-	// Redirect to dashboard
-	http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
+	if ac.fraudLinkage != nil {
+		ac.fraudLinkage.RecordSignIn(w, r, authUser.ID)
+	}
+
+	http.Redirect(w, r, redirectTo, http.StatusSeeOther)
+}
+
+// handleTwoFactor handles the second-step verification after a password login
+func (ac *AuthbossConfig) handleTwoFactor(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	sessionStorer := ac.Storage.SessionStorer
+	session, err := sessionStorer.store.Get(r, sessionStorer.sessionName)
+	if err != nil {
+		http.Error(w, "Session error", http.StatusInternalServerError)
+		return
+	}
+
+	// The pending login must exist and be recent, otherwise start over
+	pendingPID, _ := session.Values["2fa_pending_user"].(string)
+	pendingAt, _ := session.Values["2fa_pending_at"].(int64)
+	if ac.twoFactorService == nil || pendingPID == "" || time.Since(time.Unix(pendingAt, 0)) > twoFactorPendingTimeout {
+		ac.clearTwoFactorPending(session)
+		session.Save(r, w)
+		http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
+		return
+	}
+
+	sessionToken, _ := session.Values["csrf_token"].(string)
+	if sessionToken == "" || r.FormValue("csrf_token") != sessionToken {
+		ac.renderTwoFactorError(w, r, "general", "Security token mismatch. Please refresh the page and try again.")
+		return
+	}
+
+	if !ac.rateLimitCheck(w, r, "login", pendingPID) {
+		ac.logSecurityEvent("rate_limit_exceeded", "", r, "Two-factor rate limit exceeded")
+		http.Error(w, "Too many login attempts. Please try again later.", http.StatusTooManyRequests)
+		return
+	}
+
+	user, err := ac.Authboss.Config.Storage.Server.Load(r.Context(), pendingPID)
+	if err != nil || user == nil {
+		ac.clearTwoFactorPending(session)
+		session.Save(r, w)
+		http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
+		return
+	}
+
+	authUser, ok := user.(*AuthbossUser)
+	if !ok {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	if err := ac.twoFactorService.Verify(authUser.ID, r.FormValue("code")); err != nil {
+		ac.logSecurityEvent("login_2fa_failed", authUser.Email, r, err.Error())
+		message := "Invalid authentication code"
+		if strings.Contains(err.Error(), "already been used") {
+			message = "That code has already been used. Wait for a new code and try again."
+		}
+		ac.renderTwoFactorError(w, r, "code", message)
+		return
+	}
+
+	rememberMe, _ := session.Values["2fa_remember_me"].(bool)
+	ac.clearTwoFactorPending(session)
+
+	ac.logSecurityEvent("login_success", authUser.Email, r, "Successful login with two-factor authentication")
+	ac.completeLogin(w, r, session, authUser, rememberMe, "/dashboard")
+}
+
+// renderTwoFactorError re-renders the two-factor page with a validation error
+func (ac *AuthbossConfig) renderTwoFactorError(w http.ResponseWriter, r *http.Request, field, message string) {
+	data := map[string]interface{}{
+		"validation": map[string][]string{
+			field: {message},
+		},
+	}
+
+	component := ac.Authboss.Config.Core.ViewRenderer
+	output, contentType, err := component.Render(r.Context(), "2fa", data)
+	if err != nil {
+		http.Error(w, "Failed to render two-factor page", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	w.Write(output)
+}
+
+// clearTwoFactorPending removes a pending two-factor login from the session
+func (ac *AuthbossConfig) clearTwoFactorPending(session *sessions.Session) {
+	delete(session.Values, "2fa_pending_user")
+	delete(session.Values, "2fa_remember_me")
+	delete(session.Values, "2fa_pending_at")
 }
 
 // handleRegister handles the registration form submission
 func (ac *AuthbossConfig) handleRegister(w http.ResponseWriter, r *http.Request) {
 	// Check rate limiting
-	if !ac.RateLimitCheck(r, "register") {
+	if !ac.RateLimitCheck(w, r, "register") {
 		ac.logSecurityEvent("rate_limit_exceeded", "", r, "Registration rate limit exceeded")
 		http.Error(w, "Too many registration attempts. Please try again later.", http.StatusTooManyRequests)
 		return
@@ -592,6 +783,9 @@ func (ac *AuthbossConfig) handleRegister(w http.ResponseWriter, r *http.Request)
 	ac.Authboss.Config.Core.Logger.Info(fmt.Sprintf("User saved successfully: %s (ID: %d)", email, newUser.ID))
 	
 	ac.logSecurityEvent("registration_success", email, r, "User account created")
+	if ac.fraudLinkage != nil {
+		ac.fraudLinkage.RecordSignIn(w, r, newUser.ID)
+	}
 	
 	// Send verification email
 	if ac.Authboss.Config.Core.Mailer != nil {
@@ -651,27 +845,33 @@ func (ac *AuthbossConfig) isAccountLocked(user *AuthbossUser) bool {
 	return time.Now().Before(*user.LockedUntil)
 }
 
-// recordFailedAttempt records a failed login attempt and locks account if necessary
-func (ac *AuthbossConfig) recordFailedAttempt(user *AuthbossUser) {
+// recordFailedAttempt records a failed login attempt and locks the account
+// after too many failures within the lock window. It reports whether this
+// attempt locked the account.
+func (ac *AuthbossConfig) recordFailedAttempt(user *AuthbossUser) bool {
+	modules := ac.Authboss.Config.Modules
 	now := time.Now()
-	user.LastAttempt = &now
-	
+
 	// Reset attempt count if last attempt was more than the lock window ago
-	if user.LastAttempt != nil && now.Sub(*user.LastAttempt) > 5*time.Minute {
+	if user.LastAttempt != nil && now.Sub(*user.LastAttempt) > modules.LockWindow {
 		user.AttemptCount = 0
 	}
-	
+
+	user.LastAttempt = &now
 	user.AttemptCount++
-	
+
 	// Lock account if too many attempts
-	if user.AttemptCount >= 5 {
-		lockUntil := now.Add(30 * time.Minute)
+	locked := false
+	if user.AttemptCount >= modules.LockAfter {
+		lockUntil := now.Add(modules.LockDuration)
 		user.LockedUntil = &lockUntil
 		user.AttemptCount = 0 // Reset counter after locking
+		locked = true
 	}
-	
+
 	// Save user with updated attempt info
 	ac.Authboss.Config.Storage.Server.Save(context.Background(), user)
+	return locked
 }
 
 // resetFailedAttempts resets failed login attempts after successful login
@@ -898,21 +1098,48 @@ func (ac *AuthbossConfig) hasSequentialChars(password string) bool {
 	return false
 }
 
-// RateLimitCheck implements basic rate limiting for authentication attempts
-func (ac *AuthbossConfig) RateLimitCheck(r *http.Request, action string) bool {
-	clientIP := ac.getClientIP(r)
-	
-	// This is a basic implementation - in production, you'd use Redis or similar
-	// For now, we'll use a simple in-memory approach with cleanup
-	
-	// Rate limiting: max 10 attempts per IP per 15 minutes for login
-	// max 5 registration attempts per IP per hour
-	
-	// In a real implementation, you'd store this in Redis with TTL
-	// For now, just log the attempt and return true (allowing the request)
-	ac.logSecurityEvent("rate_limit_check", "", r, fmt.Sprintf("Action: %s, IP: %s", action, clientIP))
-	
-	return true // Allow for now - implement proper rate limiting with Redis in production
+// SetRateLimiter enables rate limiting of authentication attempts. Each rule
+// applies to the action matching its name, e.g. "login" or "register".
+func (ac *AuthbossConfig) SetRateLimiter(limiter *ratelimit.Limiter, rules ...ratelimit.Rule) {
+	ac.rateLimiter = limiter
+	ac.rateLimitRules = make(map[string]ratelimit.Rule, len(rules))
+	for _, rule := range rules {
+		ac.rateLimitRules[rule.Name] = rule
+	}
+}
+
+// RateLimitCheck applies the action's rate limit per client IP and per email
+// address. When the limit is exceeded it sets the Retry-After header and
+// returns false.
+func (ac *AuthbossConfig) RateLimitCheck(w http.ResponseWriter, r *http.Request, action string) bool {
+	return ac.rateLimitCheck(w, r, action, r.FormValue("email"))
+}
+
+// SetTwoFactorService enables the TOTP second step after password login
+func (ac *AuthbossConfig) SetTwoFactorService(twoFactorService *services.TwoFactorService) {
+	ac.twoFactorService = twoFactorService
+}
+
+// SetFraudLinkageService records the IP address and browser of each sign-in
+// and registration, to link duplicate accounts
+func (ac *AuthbossConfig) SetFraudLinkageService(fraudLinkage *services.FraudLinkageService) {
+	ac.fraudLinkage = fraudLinkage
+}
+
+// rateLimitCheck applies the action's rate limit per client IP and per account
+func (ac *AuthbossConfig) rateLimitCheck(w http.ResponseWriter, r *http.Request, action, account string) bool {
+	rule, exists := ac.rateLimitRules[action]
+	if ac.rateLimiter == nil || !exists {
+		return true
+	}
+
+	result := ac.rateLimiter.Check(r, rule, ratelimit.IPKey(r), ratelimit.AccountKey(account))
+	if !result.Allowed {
+		w.Header().Set("Retry-After", strconv.Itoa(result.RetryAfterSeconds()))
+		return false
+	}
+
+	return true
 }
 
 // handleEmailConfirmation handles email verification
@@ -1,7 +1,7 @@
 package auth
 
 import (
-	"log"
+	"log/slog"
 )
 
 // AuthbossLogger implements authboss.Logger interface
@@ -14,10 +14,10 @@ func NewAuthbossLogger() *AuthbossLogger {
 
 // Info logs an info message
 func (l *AuthbossLogger) Info(msg string) {
-	log.Printf("[AUTHBOSS INFO] %s", msg)
+	slog.Info(msg, "component", "authboss")
 }
 
 // Error logs an error message
 func (l *AuthbossLogger) Error(msg string) {
-	log.Printf("[AUTHBOSS ERROR] %s", msg)
+	slog.Error(msg, "component", "authboss")
 }
\ No newline at end of file
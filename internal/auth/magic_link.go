@@ -0,0 +1,242 @@
+package auth
+
+import (
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/aarondl/authboss/v3"
+	"github.com/gorilla/sessions"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/internal/utils"
+)
+
+// magicLinkTTL is how long an emailed sign-in link stays valid
+const magicLinkTTL = 15 * time.Minute
+
+// SetAuditService records passwordless login and account lock events in the
+// admin audit log
+func (ac *AuthbossConfig) SetAuditService(auditService *services.AuditService) {
+	ac.auditService = auditService
+}
+
+// handleMagicLinkRequest emails a single-use sign-in link. The response is the
+// same whether or not the address has an account.
+func (ac *AuthbossConfig) handleMagicLinkRequest(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	email := strings.ToLower(strings.TrimSpace(r.FormValue("email")))
+
+	if !ac.rateLimitCheck(w, r, "login", email) {
+		ac.logSecurityEvent("rate_limit_exceeded", email, r, "Magic link rate limit exceeded")
+		http.Error(w, "Too many login attempts. Please try again later.", http.StatusTooManyRequests)
+		return
+	}
+
+	session, err := ac.Storage.SessionStorer.store.Get(r, ac.Storage.SessionStorer.sessionName)
+	if err != nil {
+		http.Error(w, "Session error", http.StatusInternalServerError)
+		return
+	}
+
+	if !validFormCSRF(session, r) {
+		ac.renderAuthPage(w, r, "magic_link", http.StatusUnprocessableEntity, map[string][]string{
+			"general": {"Security token invalid. Please refresh the page and try again."},
+		}, map[string]string{"email": email})
+		return
+	}
+
+	if email == "" {
+		ac.renderAuthPage(w, r, "magic_link", http.StatusUnprocessableEntity, map[string][]string{
+			"email": {"Email is required"},
+		}, nil)
+		return
+	}
+
+	if err := ac.sendMagicLink(r, email); err != nil {
+		ac.Authboss.Config.Core.Logger.Error(fmt.Sprintf("Failed to send magic link to %s: %v", email, err))
+	}
+
+	ac.renderAuthPage(w, r, "magic_link_sent", http.StatusOK, nil, map[string]string{"email": email})
+}
+
+// sendMagicLink creates a token for the account with the given email and
+// emails the sign-in link. Unknown and locked accounts are silently skipped.
+func (ac *AuthbossConfig) sendMagicLink(r *http.Request, email string) error {
+	ctx := r.Context()
+
+	user, err := ac.Storage.ServerStorer.Load(ctx, email)
+	if err != nil {
+		if errors.Is(err, authboss.ErrUserNotFound) {
+			ac.logSecurityEvent("magic_link_unknown_email", email, r, "Magic link requested for unknown email")
+			return nil
+		}
+		return err
+	}
+	authUser := user.(*AuthbossUser)
+
+	if ac.isAccountLocked(authUser) {
+		ac.auditAuthEvent(models.AuditActionMagicLinkRejected, authUser, r, map[string]interface{}{"reason": "account locked"})
+		return nil
+	}
+
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return err
+	}
+
+	expiresAt := time.Now().Add(magicLinkTTL)
+	if err := ac.Storage.MagicLinks.CreateMagicLinkToken(ctx, authUser.ID, hashMagicLinkToken(token), expiresAt, ac.getClientIP(r)); err != nil {
+		return err
+	}
+
+	mailer, ok := ac.Authboss.Config.Core.Mailer.(*AuthbossMailer)
+	if !ok {
+		return fmt.Errorf("mailer not configured")
+	}
+
+	link := fmt.Sprintf("%s/auth/magic-link/verify?token=%s", ac.Authboss.Config.Paths.RootURL, url.QueryEscape(token))
+	if err := mailer.SendMagicLink(authUser.Email, authUser.FirstName, link, magicLinkTTL); err != nil {
+		return err
+	}
+
+	ac.auditAuthEvent(models.AuditActionMagicLinkRequested, authUser, r, map[string]interface{}{"expires_at": expiresAt.UTC().Format(time.RFC3339)})
+	return nil
+}
+
+// handleMagicLinkVerify signs the user in with an emailed token. The link
+// opens a confirmation page and the token is only used when that page is
+// submitted, so email scanners that prefetch links don't burn it.
+func (ac *AuthbossConfig) handleMagicLinkVerify(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "GET" {
+		ac.renderAuthPage(w, r, "magic_link_verify", http.StatusOK, nil, map[string]string{"token": r.URL.Query().Get("token")})
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	session, err := ac.Storage.SessionStorer.store.Get(r, ac.Storage.SessionStorer.sessionName)
+	if err != nil {
+		http.Error(w, "Session error", http.StatusInternalServerError)
+		return
+	}
+
+	if !ac.rateLimitCheck(w, r, "login", "") {
+		ac.logSecurityEvent("rate_limit_exceeded", "", r, "Magic link rate limit exceeded")
+		http.Error(w, "Too many login attempts. Please try again later.", http.StatusTooManyRequests)
+		return
+	}
+
+	token := r.FormValue("token")
+	if !validFormCSRF(session, r) {
+		ac.renderAuthPage(w, r, "magic_link_verify", http.StatusUnprocessableEntity, map[string][]string{
+			"general": {"Security token invalid. Please refresh the page and try again."},
+		}, map[string]string{"token": token})
+		return
+	}
+
+	invalidLink := map[string][]string{
+		"general": {"This sign-in link is invalid or has expired. Please request a new one."},
+	}
+
+	userID, err := ac.Storage.MagicLinks.UseMagicLinkToken(r.Context(), hashMagicLinkToken(token))
+	if err != nil {
+		if !errors.Is(err, authboss.ErrTokenNotFound) {
+			ac.Authboss.Config.Core.Logger.Error(fmt.Sprintf("Failed to use magic link: %v", err))
+		}
+		ac.auditAuthEvent(models.AuditActionMagicLinkRejected, nil, r, map[string]interface{}{"reason": "invalid or expired token"})
+		ac.renderAuthPage(w, r, "magic_link", http.StatusUnprocessableEntity, invalidLink, nil)
+		return
+	}
+
+	user, err := ac.Storage.ServerStorer.Load(r.Context(), strconv.Itoa(userID))
+	if err != nil {
+		ac.renderAuthPage(w, r, "magic_link", http.StatusUnprocessableEntity, invalidLink, nil)
+		return
+	}
+	authUser := user.(*AuthbossUser)
+
+	if ac.isAccountLocked(authUser) {
+		ac.auditAuthEvent(models.AuditActionMagicLinkRejected, authUser, r, map[string]interface{}{"reason": "account locked"})
+		ac.renderLockedOut(w, r, authUser)
+		return
+	}
+
+	if !authUser.IsConfirmed() {
+		// Opening the emailed link proves ownership of the address
+		authUser.PutConfirmed(true)
+		if err := ac.Storage.ServerStorer.Save(r.Context(), authUser); err != nil {
+			ac.Authboss.Config.Core.Logger.Error(fmt.Sprintf("Failed to confirm user %s: %v", authUser.Email, err))
+		}
+	}
+
+	ac.auditAuthEvent(models.AuditActionMagicLinkLogin, authUser, r, nil)
+	ac.finishLogin(w, r, session, authUser, false, "Successful magic link login")
+}
+
+// auditAuthEvent records a passwordless login or account lock event in the
+// security audit log
+func (ac *AuthbossConfig) auditAuthEvent(action string, user *AuthbossUser, r *http.Request, details map[string]interface{}) {
+	var userID, email string
+	var targetID int
+	if user != nil {
+		userID, email, targetID = user.GetPID(), user.Email, user.ID
+	}
+
+	ac.SecurityAuditLog(action, userID, email, r, details)
+
+	if ac.auditService != nil {
+		if err := ac.auditService.LogAction(0, action, models.AuditTargetUser, targetID, details, r); err != nil {
+			ac.Authboss.Config.Core.Logger.Error(fmt.Sprintf("Failed to audit %s: %v", action, err))
+		}
+	}
+}
+
+// renderAuthPage renders an auth page with the given status, errors and form data
+func (ac *AuthbossConfig) renderAuthPage(w http.ResponseWriter, r *http.Request, page string, status int, validation map[string][]string, preserve map[string]string) {
+	data := map[string]interface{}{}
+	if validation != nil {
+		data["validation"] = validation
+	}
+	if preserve != nil {
+		data["preserve"] = preserve
+	}
+
+	output, contentType, err := ac.Authboss.Config.Core.ViewRenderer.Render(r.Context(), page, data)
+	if err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(status)
+	w.Write(output)
+}
+
+// validFormCSRF checks the submitted csrf_token against the session's token
+func validFormCSRF(session *sessions.Session, r *http.Request) bool {
+	sessionToken, _ := session.Values["csrf_token"].(string)
+	requestToken := r.FormValue("csrf_token")
+	return sessionToken != "" && subtle.ConstantTimeCompare([]byte(sessionToken), []byte(requestToken)) == 1
+}
+
+// hashMagicLinkToken hashes a sign-in token for storage. Tokens are random and
+// high-entropy, so a fast hash is enough and allows looking them up directly.
+func hashMagicLinkToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
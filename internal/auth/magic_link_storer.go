@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aarondl/authboss/v3"
+)
+
+// MagicLinkStorer stores single-use passwordless login tokens
+type MagicLinkStorer struct {
+	db *sql.DB
+}
+
+// NewMagicLinkStorer creates a new magic link storer
+func NewMagicLinkStorer(db *sql.DB) *MagicLinkStorer {
+	return &MagicLinkStorer{db: db}
+}
+
+// CreateMagicLinkToken stores a hashed login token for a user, replacing any
+// of the user's links that have not been used yet
+func (s *MagicLinkStorer) CreateMagicLinkToken(ctx context.Context, userID int, tokenHash string, expiresAt time.Time, requestedIP string) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM magic_link_tokens WHERE user_id = $1 AND used_at IS NULL`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke previous magic links: %w", err)
+	}
+
+	query := `
+		INSERT INTO magic_link_tokens (user_id, token_hash, expires_at, requested_ip)
+		VALUES ($1, $2, $3, $4)
+	`
+	if _, err := tx.ExecContext(ctx, query, userID, tokenHash, expiresAt, requestedIP); err != nil {
+		return fmt.Errorf("failed to create magic link token: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// UseMagicLinkToken marks an unexpired, unused token as used and returns its
+// user ID. A token can only ever be used once.
+func (s *MagicLinkStorer) UseMagicLinkToken(ctx context.Context, tokenHash string) (int, error) {
+	query := `
+		UPDATE magic_link_tokens SET used_at = NOW()
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING user_id
+	`
+
+	var userID int
+	err := s.db.QueryRowContext(ctx, query, tokenHash).Scan(&userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, authboss.ErrTokenNotFound
+		}
+		return 0, fmt.Errorf("failed to use magic link token: %w", err)
+	}
+
+	return userID, nil
+}
+
+// CleanupExpiredMagicLinkTokens removes expired and used magic link tokens
+func (s *MagicLinkStorer) CleanupExpiredMagicLinkTokens(ctx context.Context) error {
+	query := `DELETE FROM magic_link_tokens WHERE expires_at < NOW() OR used_at IS NOT NULL`
+
+	result, err := s.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to cleanup expired magic link tokens: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	slog.Info("cleaned up expired magic link tokens", "count", rowsAffected)
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/aarondl/authboss/v3"
 	"event-ticketing-platform/internal/services"
@@ -45,6 +46,34 @@ func (m *AuthbossMailer) Send(ctx context.Context, email authboss.Email) error {
 	}
 }
 
+// magicLinkSender is implemented by email services that can send passwordless sign-in links
+type magicLinkSender interface {
+	SendMagicLinkEmail(email, userName, link string, expiresIn time.Duration) error
+}
+
+// SendMagicLink sends a passwordless sign-in link
+func (m *AuthbossMailer) SendMagicLink(email, userName, link string, expiresIn time.Duration) error {
+	sender, ok := m.emailService.(magicLinkSender)
+	if !ok {
+		return fmt.Errorf("email service does not support sign-in links")
+	}
+	return sender.SendMagicLinkEmail(email, userName, link, expiresIn)
+}
+
+// unlockLinkSender is implemented by email services that can send account unlock links
+type unlockLinkSender interface {
+	SendAccountUnlockEmail(email, userName, link string, lockedUntil time.Time) error
+}
+
+// SendUnlockLink tells a user their account was locked and sends a link that unlocks it
+func (m *AuthbossMailer) SendUnlockLink(email, userName, link string, lockedUntil time.Time) error {
+	sender, ok := m.emailService.(unlockLinkSender)
+	if !ok {
+		return fmt.Errorf("email service does not support unlock links")
+	}
+	return sender.SendAccountUnlockEmail(email, userName, link, lockedUntil)
+}
+
 // sendConfirmationEmail sends an email confirmation email
 func (m *AuthbossMailer) sendConfirmationEmail(recipient string, email authboss.Email) error {
 	// Extract confirmation token from email content
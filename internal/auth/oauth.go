@@ -0,0 +1,540 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/aarondl/authboss/v3"
+	"github.com/go-chi/chi/v5"
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/endpoints"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/utils"
+)
+
+// Supported social login providers
+const (
+	OAuthProviderGoogle = "google"
+	OAuthProviderApple  = "apple"
+)
+
+const (
+	googleUserInfoURL = "https://openidconnect.googleapis.com/v1/userinfo"
+	appleIssuer       = "https://appleid.apple.com"
+)
+
+// OAuthIdentity is the account information returned by a social login provider
+type OAuthIdentity struct {
+	Provider      string
+	Subject       string
+	Email         string
+	EmailVerified bool
+	FirstName     string
+	LastName      string
+}
+
+// OAuthProvider is a configured social login provider
+type OAuthProvider struct {
+	Name        string
+	config      *oauth2.Config
+	authOptions []oauth2.AuthCodeOption
+	// clientSecret returns the client secret to use for a token exchange
+	clientSecret func() (string, error)
+	// identity reads the user's identity once the authorization code has been exchanged
+	identity func(ctx context.Context, config *oauth2.Config, token *oauth2.Token, r *http.Request) (*OAuthIdentity, error)
+}
+
+// NewGoogleProvider creates a Google OAuth2 provider
+func NewGoogleProvider(clientID, clientSecret, redirectURL string) *OAuthProvider {
+	return &OAuthProvider{
+		Name: OAuthProviderGoogle,
+		config: &oauth2.Config{
+			ClientID:     clientID,
+			ClientSecret: clientSecret,
+			RedirectURL:  redirectURL,
+			Endpoint:     endpoints.Google,
+			Scopes:       []string{"openid", "email", "profile"},
+		},
+		authOptions: []oauth2.AuthCodeOption{oauth2.SetAuthURLParam("prompt", "select_account")},
+		identity:    googleIdentity,
+	}
+}
+
+// NewAppleProvider creates a Sign in with Apple provider. Apple requires the
+// client secret to be a short-lived JWT signed with the team's private key.
+func NewAppleProvider(clientID, teamID, keyID, privateKeyPEM, redirectURL string) (*OAuthProvider, error) {
+	key, err := parseApplePrivateKey(privateKeyPEM)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OAuthProvider{
+		Name: OAuthProviderApple,
+		config: &oauth2.Config{
+			ClientID:    clientID,
+			RedirectURL: redirectURL,
+			Endpoint:    endpoints.Apple,
+			Scopes:      []string{"name", "email"},
+		},
+		// Apple only returns the user's name when the callback is a form POST
+		authOptions: []oauth2.AuthCodeOption{oauth2.SetAuthURLParam("response_mode", "form_post")},
+		clientSecret: func() (string, error) {
+			return appleClientSecret(key, clientID, teamID, keyID, time.Now())
+		},
+		identity: appleIdentity,
+	}, nil
+}
+
+// AuthCodeURL returns the provider's consent page URL for the given state
+func (p *OAuthProvider) AuthCodeURL(state string) string {
+	return p.config.AuthCodeURL(state, p.authOptions...)
+}
+
+// Exchange trades an authorization code for the user's identity
+func (p *OAuthProvider) Exchange(ctx context.Context, code string, r *http.Request) (*OAuthIdentity, error) {
+	config := *p.config
+	if p.clientSecret != nil {
+		secret, err := p.clientSecret()
+		if err != nil {
+			return nil, err
+		}
+		config.ClientSecret = secret
+	}
+
+	token, err := config.Exchange(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("failed to exchange authorization code: %w", err)
+	}
+
+	identity, err := p.identity(ctx, &config, token, r)
+	if err != nil {
+		return nil, err
+	}
+	if identity.Subject == "" {
+		return nil, fmt.Errorf("provider did not return an account ID")
+	}
+
+	identity.Provider = p.Name
+	identity.Email = strings.ToLower(strings.TrimSpace(identity.Email))
+	return identity, nil
+}
+
+// googleIdentity reads the signed-in user from Google's userinfo endpoint
+func googleIdentity(ctx context.Context, config *oauth2.Config, token *oauth2.Token, r *http.Request) (*OAuthIdentity, error) {
+	resp, err := config.Client(ctx, token).Get(googleUserInfoURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch Google user info: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("failed to fetch Google user info: status %d", resp.StatusCode)
+	}
+
+	var info struct {
+		Sub           string `json:"sub"`
+		Email         string `json:"email"`
+		EmailVerified bool   `json:"email_verified"`
+		GivenName     string `json:"given_name"`
+		FamilyName    string `json:"family_name"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+		return nil, fmt.Errorf("failed to decode Google user info: %w", err)
+	}
+
+	return &OAuthIdentity{
+		Subject:       info.Sub,
+		Email:         info.Email,
+		EmailVerified: info.EmailVerified,
+		FirstName:     info.GivenName,
+		LastName:      info.FamilyName,
+	}, nil
+}
+
+// appleIdentity reads the user from the ID token returned by Apple's token
+// endpoint. The token comes straight from Apple over TLS, so its claims are
+// trusted once the issuer, audience and expiry check out.
+func appleIdentity(ctx context.Context, config *oauth2.Config, token *oauth2.Token, r *http.Request) (*OAuthIdentity, error) {
+	idToken, _ := token.Extra("id_token").(string)
+	if idToken == "" {
+		return nil, fmt.Errorf("apple did not return an ID token")
+	}
+
+	var claims struct {
+		Issuer        string      `json:"iss"`
+		Audience      string      `json:"aud"`
+		Expiry        int64       `json:"exp"`
+		Subject       string      `json:"sub"`
+		Email         string      `json:"email"`
+		EmailVerified interface{} `json:"email_verified"` // Apple sends either a bool or a string
+	}
+	if err := decodeJWTClaims(idToken, &claims); err != nil {
+		return nil, err
+	}
+
+	if claims.Issuer != appleIssuer || claims.Audience != config.ClientID {
+		return nil, fmt.Errorf("apple ID token was not issued for this application")
+	}
+	if time.Now().Unix() > claims.Expiry {
+		return nil, fmt.Errorf("apple ID token has expired")
+	}
+
+	identity := &OAuthIdentity{
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified == true || claims.EmailVerified == "true",
+	}
+
+	// The user's name is only posted on the first authorization
+	var user struct {
+		Name struct {
+			FirstName string `json:"firstName"`
+			LastName  string `json:"lastName"`
+		} `json:"name"`
+	}
+	if raw := r.FormValue("user"); raw != "" && json.Unmarshal([]byte(raw), &user) == nil {
+		identity.FirstName = user.Name.FirstName
+		identity.LastName = user.Name.LastName
+	}
+
+	return identity, nil
+}
+
+// decodeJWTClaims decodes the payload of a JWT without verifying its signature
+func decodeJWTClaims(token string, claims interface{}) error {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return fmt.Errorf("malformed ID token")
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return fmt.Errorf("malformed ID token: %w", err)
+	}
+
+	if err := json.Unmarshal(payload, claims); err != nil {
+		return fmt.Errorf("malformed ID token: %w", err)
+	}
+
+	return nil
+}
+
+// parseApplePrivateKey parses the PKCS#8 .p8 key downloaded from Apple
+func parseApplePrivateKey(privateKeyPEM string) (*ecdsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(privateKeyPEM))
+	if block == nil {
+		return nil, fmt.Errorf("invalid Apple private key: no PEM data found")
+	}
+
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Apple private key: %w", err)
+	}
+
+	ecKey, ok := key.(*ecdsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("invalid Apple private key: expected an ECDSA key")
+	}
+
+	return ecKey, nil
+}
+
+// appleClientSecret builds the ES256 signed JWT Apple expects as the client secret
+func appleClientSecret(key *ecdsa.PrivateKey, clientID, teamID, keyID string, now time.Time) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "ES256", "kid": keyID})
+	if err != nil {
+		return "", err
+	}
+
+	claims, err := json.Marshal(map[string]interface{}{
+		"iss": teamID,
+		"iat": now.Unix(),
+		"exp": now.Add(5 * time.Minute).Unix(),
+		"aud": appleIssuer,
+		"sub": clientID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+
+	r, s, err := ecdsa.Sign(rand.Reader, key, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign Apple client secret: %w", err)
+	}
+
+	// JWS encodes the ES256 signature as the fixed-size concatenation of r and s
+	signature := make([]byte, 64)
+	r.FillBytes(signature[:32])
+	s.FillBytes(signature[32:])
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+const (
+	oauthStateCookie = "oauth_state"
+	oauthRoleCookie  = "oauth_role"
+	oauthCookiePath  = "/auth/oauth"
+	oauthCookieAge   = 10 * 60 // seconds allowed to complete the provider's consent screen
+)
+
+// SetOAuthProviders enables social login with the given providers
+func (ac *AuthbossConfig) SetOAuthProviders(providers ...*OAuthProvider) {
+	ac.oauthProviders = make(map[string]*OAuthProvider)
+	var names []string
+	for _, provider := range providers {
+		ac.oauthProviders[provider.Name] = provider
+		names = append(names, provider.Name)
+	}
+
+	if renderer, ok := ac.Authboss.Config.Core.ViewRenderer.(*AuthbossRenderer); ok {
+		renderer.oauthProviders = names
+	}
+}
+
+// HandleOAuthStart handles GET /auth/oauth/{provider} by redirecting to the
+// provider's consent screen
+func (ac *AuthbossConfig) HandleOAuthStart(w http.ResponseWriter, r *http.Request) {
+	provider, ok := ac.oauthProviders[chi.URLParam(r, "provider")]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	if !ac.RateLimitCheck(w, r, "login") {
+		ac.logSecurityEvent("rate_limit_exceeded", "", r, "Social login rate limit exceeded")
+		http.Error(w, "Too many login attempts. Please try again later.", http.StatusTooManyRequests)
+		return
+	}
+
+	state, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		http.Error(w, "Internal server error", http.StatusInternalServerError)
+		return
+	}
+
+	// Remember the role chosen on the registration page for first-time sign ups
+	role := string(models.UserRoleUser)
+	if r.URL.Query().Get("role") == string(models.UserRoleOrganizer) {
+		role = string(models.UserRoleOrganizer)
+	}
+
+	ac.setOAuthCookie(w, oauthStateCookie, state, oauthCookieAge)
+	ac.setOAuthCookie(w, oauthRoleCookie, role, oauthCookieAge)
+
+	http.Redirect(w, r, provider.AuthCodeURL(state), http.StatusFound)
+}
+
+// HandleOAuthCallback handles the provider's redirect back to
+// /auth/oauth/{provider}/callback. Apple posts the callback as a form.
+func (ac *AuthbossConfig) HandleOAuthCallback(w http.ResponseWriter, r *http.Request) {
+	provider, ok := ac.oauthProviders[chi.URLParam(r, "provider")]
+	if !ok {
+		http.NotFound(w, r)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	stateCookie, err := r.Cookie(oauthStateCookie)
+	role := string(models.UserRoleUser)
+	if roleCookie, err := r.Cookie(oauthRoleCookie); err == nil {
+		role = roleCookie.Value
+	}
+	ac.setOAuthCookie(w, oauthStateCookie, "", -1)
+	ac.setOAuthCookie(w, oauthRoleCookie, "", -1)
+
+	if err != nil || stateCookie.Value == "" ||
+		subtle.ConstantTimeCompare([]byte(stateCookie.Value), []byte(r.FormValue("state"))) != 1 {
+		ac.logSecurityEvent("oauth_state_mismatch", "", r, provider.Name)
+		ac.renderOAuthError(w, r, "Your sign in session expired. Please try again.")
+		return
+	}
+
+	if r.FormValue("error") != "" || r.FormValue("code") == "" {
+		// The user cancelled on the consent screen
+		ac.renderOAuthError(w, r, "Sign in was cancelled.")
+		return
+	}
+
+	identity, err := provider.Exchange(r.Context(), r.FormValue("code"), r)
+	if err != nil {
+		ac.Authboss.Config.Core.Logger.Error(fmt.Sprintf("OAuth %s exchange failed: %v", provider.Name, err))
+		ac.renderOAuthError(w, r, "We couldn't sign you in with that account. Please try again.")
+		return
+	}
+
+	authUser, message := ac.resolveOAuthUser(r, identity, models.UserRole(role))
+	if authUser == nil {
+		ac.renderOAuthError(w, r, message)
+		return
+	}
+
+	if ac.isAccountLocked(authUser) {
+		ac.logSecurityEvent("login_blocked", authUser.Email, r, "Account locked")
+		ac.renderLockedOut(w, r, authUser)
+		return
+	}
+
+	sessionStorer := ac.Storage.SessionStorer
+	session, err := sessionStorer.store.Get(r, sessionStorer.sessionName)
+	if err != nil {
+		http.Error(w, "Session error", http.StatusInternalServerError)
+		return
+	}
+
+	ac.finishLogin(w, r, session, authUser, false, fmt.Sprintf("Successful %s login", provider.Name))
+}
+
+// resolveOAuthUser finds the user for a provider identity. Accounts already
+// linked to the identity are used directly, an existing account with the same
+// verified email is linked, and otherwise a new account is registered. On
+// failure it returns a message to show on the login page.
+func (ac *AuthbossConfig) resolveOAuthUser(r *http.Request, identity *OAuthIdentity, role models.UserRole) (*AuthbossUser, string) {
+	ctx := r.Context()
+	storer := ac.Storage.ServerStorer
+	genericError := "We couldn't sign you in with that account. Please try again."
+
+	authUser, err := storer.LoadByOAuthID(ctx, identity.Provider, identity.Subject)
+	if err == nil {
+		return authUser, ""
+	}
+	if !errors.Is(err, authboss.ErrUserNotFound) {
+		ac.Authboss.Config.Core.Logger.Error(fmt.Sprintf("Failed to load OAuth user: %v", err))
+		return nil, genericError
+	}
+
+	// Only trust the email for linking or sign up once the provider has verified it
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, "Your account doesn't have a verified email address. Please register with email and password instead."
+	}
+
+	existing, err := storer.Load(ctx, identity.Email)
+	switch {
+	case err == nil:
+		authUser = existing.(*AuthbossUser)
+		if !authUser.IsConfirmed() {
+			// The provider has proven ownership of the address
+			authUser.PutConfirmed(true)
+			if err := storer.Save(ctx, authUser); err != nil {
+				ac.Authboss.Config.Core.Logger.Error(fmt.Sprintf("Failed to confirm linked user %s: %v", authUser.Email, err))
+				return nil, genericError
+			}
+		}
+		ac.logSecurityEvent("oauth_account_linked", authUser.Email, r, identity.Provider)
+	case errors.Is(err, authboss.ErrUserNotFound):
+		authUser, err = ac.registerOAuthUser(ctx, identity, role)
+		if err != nil {
+			ac.logSecurityEvent("registration_failed", identity.Email, r, fmt.Sprintf("Database error: %v", err))
+			return nil, "Failed to create account"
+		}
+		ac.logSecurityEvent("registration_success", identity.Email, r, fmt.Sprintf("User account created with %s", identity.Provider))
+	default:
+		ac.Authboss.Config.Core.Logger.Error(fmt.Sprintf("Failed to load user %s: %v", identity.Email, err))
+		return nil, genericError
+	}
+
+	if err := storer.LinkOAuthID(ctx, authUser.ID, identity.Provider, identity.Subject); err != nil {
+		ac.Authboss.Config.Core.Logger.Error(fmt.Sprintf("Failed to link %s account for %s: %v", identity.Provider, authUser.Email, err))
+		return nil, genericError
+	}
+
+	return authUser, ""
+}
+
+// registerOAuthUser creates a confirmed account for a first-time social login.
+// The account gets an unguessable password until the user sets one via password reset.
+func (ac *AuthbossConfig) registerOAuthUser(ctx context.Context, identity *OAuthIdentity, role models.UserRole) (*AuthbossUser, error) {
+	if role != models.UserRoleOrganizer {
+		role = models.UserRoleUser
+	}
+
+	firstName, lastName := identity.FirstName, identity.LastName
+	if firstName == "" && lastName == "" {
+		firstName = strings.Split(identity.Email, "@")[0]
+	}
+
+	password, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return nil, err
+	}
+	hashedPassword, err := utils.HashPassword(password)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	newUser := &AuthbossUser{
+		User: &models.User{
+			Email:        identity.Email,
+			FirstName:    firstName,
+			LastName:     lastName,
+			Role:         role,
+			PasswordHash: hashedPassword,
+		},
+		PasswordChangedAt: &now,
+	}
+	newUser.PutConfirmed(true)
+
+	if err := ac.Storage.ServerStorer.Save(ctx, newUser); err != nil {
+		return nil, err
+	}
+
+	return newUser, nil
+}
+
+// renderOAuthError shows a social login failure on the login page
+func (ac *AuthbossConfig) renderOAuthError(w http.ResponseWriter, r *http.Request, message string) {
+	data := map[string]interface{}{
+		"validation": map[string][]string{
+			"general": {message},
+		},
+	}
+
+	component := ac.Authboss.Config.Core.ViewRenderer
+	output, contentType, err := component.Render(r.Context(), "login", data)
+	if err != nil {
+		http.Error(w, "Failed to render login page", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	w.Write(output)
+}
+
+// setOAuthCookie sets a short-lived cookie scoped to the OAuth routes. Apple's
+// form POST callback is a cross-site request, so secure cookies use SameSite=None.
+func (ac *AuthbossConfig) setOAuthCookie(w http.ResponseWriter, name, value string, maxAge int) {
+	sameSite := http.SameSiteLaxMode
+	if ac.secureCookies {
+		sameSite = http.SameSiteNoneMode
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     name,
+		Value:    value,
+		Path:     oauthCookiePath,
+		MaxAge:   maxAge,
+		HttpOnly: true,
+		Secure:   ac.secureCookies,
+		SameSite: sameSite,
+	})
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"database/sql"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"github.com/aarondl/authboss/v3"
@@ -90,7 +91,7 @@ func (s *RememberStorer) CleanupExpiredRememberTokens(ctx context.Context) error
 	}
 
 	rowsAffected, _ := result.RowsAffected()
-	fmt.Printf("Cleaned up %d expired remember tokens\n", rowsAffected)
+	slog.Info("cleaned up expired remember tokens", "count", rowsAffected)
 
 	return nil
 }
\ No newline at end of file
@@ -10,7 +10,10 @@ import (
 )
 
 // AuthbossRenderer implements authboss.Renderer interface
-type AuthbossRenderer struct{}
+type AuthbossRenderer struct {
+	// oauthProviders are the social login providers offered on the login and register pages
+	oauthProviders []string
+}
 
 // NewAuthbossRenderer creates a new Authboss renderer
 func NewAuthbossRenderer() *AuthbossRenderer {
@@ -29,6 +32,12 @@ func (r *AuthbossRenderer) Render(ctx context.Context, page string, data authbos
 			errors = errMap
 		}
 	}
+	// Our own handlers pass validation errors under "validation"
+	if errs, ok := data["validation"]; ok {
+		if errMap, ok := errs.(map[string][]string); ok {
+			errors = errMap
+		}
+	}
 
 	// Extract form data from authboss data
 	if preserved, ok := data[authboss.DataPreserve]; ok {
@@ -46,6 +55,11 @@ func (r *AuthbossRenderer) Render(ctx context.Context, page string, data authbos
 		}
 	}
 
+	// Flag the social login buttons to show
+	for _, provider := range r.oauthProviders {
+		formData["oauth_"+provider] = "1"
+	}
+
 	var component interface{}
 
 	// Route to appropriate template based on page
@@ -54,6 +68,20 @@ func (r *AuthbossRenderer) Render(ctx context.Context, page string, data authbos
 		component = pages.LoginPage(nil, errors, formData)
 	case "register":
 		component = pages.RegisterPage(nil, errors, formData)
+	case "2fa":
+		// Second-step verification after password login
+		component = pages.TwoFactorVerifyPage(errors, formData)
+	case "magic_link":
+		// Passwordless sign-in link request
+		component = pages.MagicLinkRequestPage(errors, formData, false)
+	case "magic_link_sent":
+		component = pages.MagicLinkRequestPage(errors, formData, true)
+	case "magic_link_verify":
+		// Confirmation step before an emailed sign-in link is used
+		component = pages.MagicLinkVerifyPage(errors, formData)
+	case "unlock":
+		// Confirmation step before an emailed unlock link is used
+		component = pages.UnlockAccountPage(errors, formData)
 	case "recover_start":
 		// For password recovery start page
 		component = pages.ForgotPasswordPage(nil, errors, formData, false)
@@ -29,7 +29,6 @@ func (s *ServerStorer) Load(ctx context.Context, key string) (authboss.User, err
 	// Try to parse as user ID first
 	if userID, err := strconv.Atoi(key); err == nil {
 		// Key is a user ID
-		fmt.Printf("[DEBUG] Loading user by ID: %d\n", userID)
 		query = `
 			SELECT id, email, password_hash, first_name, last_name, role, 
 			       created_at, updated_at, email_verified, email_verified_at,
@@ -37,12 +36,11 @@ func (s *ServerStorer) Load(ctx context.Context, key string) (authboss.User, err
 			       locked_until, attempt_count, last_attempt, password_changed_at,
 			       recover_selector, recover_verifier, recover_token_expires
 			FROM users 
-			WHERE id = $1
+			WHERE id = $1 AND deleted_at IS NULL
 		`
 		queryParam = userID
 	} else {
 		// Key is an email address
-		fmt.Printf("[DEBUG] Loading user by email: %s\n", key)
 		query = `
 			SELECT id, email, password_hash, first_name, last_name, role, 
 			       created_at, updated_at, email_verified, email_verified_at,
@@ -50,7 +48,7 @@ func (s *ServerStorer) Load(ctx context.Context, key string) (authboss.User, err
 			       locked_until, attempt_count, last_attempt, password_changed_at,
 			       recover_selector, recover_verifier, recover_token_expires
 			FROM users 
-			WHERE email = $1
+			WHERE email = $1 AND deleted_at IS NULL
 		`
 		queryParam = key
 	}
@@ -173,6 +171,58 @@ func (s *ServerStorer) Save(ctx context.Context, user authboss.User) error {
 	return nil
 }
 
+// oauthIDColumn returns the users column holding the account ID for a social login provider
+func oauthIDColumn(provider string) (string, error) {
+	switch provider {
+	case OAuthProviderGoogle:
+		return "google_id", nil
+	case OAuthProviderApple:
+		return "apple_id", nil
+	default:
+		return "", fmt.Errorf("unsupported OAuth provider: %s", provider)
+	}
+}
+
+// LoadByOAuthID loads the user linked to a social login provider account
+func (s *ServerStorer) LoadByOAuthID(ctx context.Context, provider, subject string) (*AuthbossUser, error) {
+	column, err := oauthIDColumn(provider)
+	if err != nil {
+		return nil, err
+	}
+
+	var userID int
+	query := fmt.Sprintf("SELECT id FROM users WHERE %s = $1", column)
+	err = s.db.QueryRowContext(ctx, query, subject).Scan(&userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, authboss.ErrUserNotFound
+		}
+		return nil, fmt.Errorf("failed to load user by OAuth ID: %w", err)
+	}
+
+	user, err := s.Load(ctx, strconv.Itoa(userID))
+	if err != nil {
+		return nil, err
+	}
+
+	return user.(*AuthbossUser), nil
+}
+
+// LinkOAuthID links a social login provider account to a user
+func (s *ServerStorer) LinkOAuthID(ctx context.Context, userID int, provider, subject string) error {
+	column, err := oauthIDColumn(provider)
+	if err != nil {
+		return err
+	}
+
+	query := fmt.Sprintf("UPDATE users SET %s = $2, updated_at = CURRENT_TIMESTAMP WHERE id = $1", column)
+	if _, err := s.db.ExecContext(ctx, query, userID, subject); err != nil {
+		return fmt.Errorf("failed to link OAuth account: %w", err)
+	}
+
+	return nil
+}
+
 // New creates a new user in the database
 func (s *ServerStorer) New(ctx context.Context) authboss.User {
 	return &AuthbossUser{
@@ -43,26 +43,18 @@ func (s *SessionStateReadWriter) ReadState(r *http.Request) (authboss.ClientStat
 	}
 
 	values := make(map[string]string)
-	fmt.Printf("[DEBUG] Session values in ReadState: %+v\n", session.Values)
-	
 	for key, value := range session.Values {
 		if strKey, ok := key.(string); ok {
-			// Handle both string and integer values
+			// Handle both string and integer values; others belong to the
+			// rest of the application
 			if strValue, ok := value.(string); ok {
 				values[strKey] = strValue
-				fmt.Printf("[DEBUG] Added string value: %s = %s\n", strKey, strValue)
 			} else if intValue, ok := value.(int); ok {
 				values[strKey] = fmt.Sprintf("%d", intValue)
-				fmt.Printf("[DEBUG] Added int value: %s = %d (converted to %s)\n", strKey, intValue, fmt.Sprintf("%d", intValue))
-			} else {
-				fmt.Printf("[DEBUG] Unhandled value type for key %s: %T = %v\n", strKey, value, value)
 			}
-		} else {
-			fmt.Printf("[DEBUG] Non-string key: %T = %v\n", key, key)
 		}
 	}
-	
-	fmt.Printf("[DEBUG] Final session state values: %+v\n", values)
+
 	return &SessionState{values: values}, nil
 }
 
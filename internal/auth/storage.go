@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"database/sql"
 	"net/http"
 
@@ -14,6 +15,8 @@ type Storage struct {
 	RememberStorer *RememberStorer
 	SessionStorer  *SessionStateReadWriter
 	CookieStorer   *CookieStorer
+	MagicLinks     *MagicLinkStorer
+	UnlockTokens   *UnlockTokenStorer
 }
 
 // NewStorage creates a new storage instance with all required storers
@@ -23,6 +26,8 @@ func NewStorage(db *sql.DB, sessionStore sessions.Store, sessionName string, sec
 		RememberStorer: NewRememberStorer(db),
 		SessionStorer:  NewSessionStateReadWriter(sessionStore, sessionName),
 		CookieStorer:   NewCookieStorer("authboss", secure),
+		MagicLinks:     NewMagicLinkStorer(db),
+		UnlockTokens:   NewUnlockTokenStorer(db),
 	}
 }
 
@@ -51,6 +56,16 @@ func (s *Storage) Cleanup() error {
 		return err
 	}
 
+	// Cleanup used and expired magic login links
+	if err := s.MagicLinks.CleanupExpiredMagicLinkTokens(context.Background()); err != nil {
+		return err
+	}
+
+	// Cleanup used and expired account unlock links
+	if err := s.UnlockTokens.CleanupExpiredUnlockTokens(context.Background()); err != nil {
+		return err
+	}
+
 	// Additional cleanup operations can be added here
 	return nil
 }
@@ -0,0 +1,126 @@
+package auth
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/aarondl/authboss/v3"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/utils"
+)
+
+// sendUnlockLink emails a locked user a single-use link that unlocks their
+// account. The link expires when the lock would have lifted anyway.
+func (ac *AuthbossConfig) sendUnlockLink(r *http.Request, authUser *AuthbossUser) error {
+	if authUser.LockedUntil == nil {
+		return nil
+	}
+
+	token, err := utils.GenerateSecureToken(32)
+	if err != nil {
+		return err
+	}
+
+	if err := ac.Storage.UnlockTokens.CreateUnlockToken(r.Context(), authUser.ID, hashMagicLinkToken(token), *authUser.LockedUntil); err != nil {
+		return err
+	}
+
+	mailer, ok := ac.Authboss.Config.Core.Mailer.(*AuthbossMailer)
+	if !ok {
+		return fmt.Errorf("mailer not configured")
+	}
+
+	link := fmt.Sprintf("%s/auth/unlock?token=%s", ac.Authboss.Config.Paths.RootURL, url.QueryEscape(token))
+	return mailer.SendUnlockLink(authUser.Email, authUser.FirstName, link, *authUser.LockedUntil)
+}
+
+// lockAccount records that a failed login locked the account and emails the
+// user an unlock link
+func (ac *AuthbossConfig) lockAccount(r *http.Request, authUser *AuthbossUser) {
+	ac.logSecurityEvent("account_locked", authUser.Email, r, "Too many failed login attempts")
+	ac.auditAuthEvent(models.AuditActionAccountLocked, authUser, r, map[string]interface{}{
+		"locked_until": authUser.LockedUntil.UTC().Format(time.RFC3339),
+	})
+
+	if err := ac.sendUnlockLink(r, authUser); err != nil {
+		ac.Authboss.Config.Core.Logger.Error(fmt.Sprintf("Failed to send unlock link to %s: %v", authUser.Email, err))
+	}
+}
+
+// renderLockedOut renders the login page with a countdown to when a locked
+// account can sign in again
+func (ac *AuthbossConfig) renderLockedOut(w http.ResponseWriter, r *http.Request, authUser *AuthbossUser) {
+	ac.renderAuthPage(w, r, "login", http.StatusUnprocessableEntity, nil, map[string]string{
+		"email":        authUser.Email,
+		"locked_until": authUser.LockedUntil.UTC().Format(time.RFC3339),
+	})
+}
+
+// handleUnlock unlocks an account with an emailed token. Like sign-in links,
+// the link opens a confirmation page and the token is only used when that
+// page is submitted, so email scanners that prefetch links don't burn it.
+func (ac *AuthbossConfig) handleUnlock(w http.ResponseWriter, r *http.Request) {
+	if r.Method == "GET" {
+		ac.renderAuthPage(w, r, "unlock", http.StatusOK, nil, map[string]string{"token": r.URL.Query().Get("token")})
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	session, err := ac.Storage.SessionStorer.store.Get(r, ac.Storage.SessionStorer.sessionName)
+	if err != nil {
+		http.Error(w, "Session error", http.StatusInternalServerError)
+		return
+	}
+
+	if !ac.rateLimitCheck(w, r, "login", "") {
+		ac.logSecurityEvent("rate_limit_exceeded", "", r, "Account unlock rate limit exceeded")
+		http.Error(w, "Too many login attempts. Please try again later.", http.StatusTooManyRequests)
+		return
+	}
+
+	token := r.FormValue("token")
+	if !validFormCSRF(session, r) {
+		ac.renderAuthPage(w, r, "unlock", http.StatusUnprocessableEntity, map[string][]string{
+			"general": {"Security token invalid. Please refresh the page and try again."},
+		}, map[string]string{"token": token})
+		return
+	}
+
+	invalidLink := map[string][]string{
+		"general": {"This unlock link is invalid or has expired. If your account is still locked, try signing in again once the lock has lifted."},
+	}
+
+	userID, err := ac.Storage.UnlockTokens.UseUnlockToken(r.Context(), hashMagicLinkToken(token))
+	if err != nil {
+		if !errors.Is(err, authboss.ErrTokenNotFound) {
+			ac.Authboss.Config.Core.Logger.Error(fmt.Sprintf("Failed to use unlock link: %v", err))
+		}
+		ac.renderAuthPage(w, r, "login", http.StatusUnprocessableEntity, invalidLink, nil)
+		return
+	}
+
+	user, err := ac.Storage.ServerStorer.Load(r.Context(), strconv.Itoa(userID))
+	if err != nil {
+		ac.renderAuthPage(w, r, "login", http.StatusUnprocessableEntity, invalidLink, nil)
+		return
+	}
+	authUser := user.(*AuthbossUser)
+
+	ac.resetFailedAttempts(authUser)
+	ac.logSecurityEvent("account_unlocked", authUser.Email, r, "Unlocked with emailed link")
+	ac.auditAuthEvent(models.AuditActionAccountUnlock, authUser, r, map[string]interface{}{"method": "email"})
+
+	ac.renderAuthPage(w, r, "login", http.StatusOK, nil, map[string]string{
+		"email":    authUser.Email,
+		"unlocked": "1",
+	})
+}
@@ -0,0 +1,83 @@
+package auth
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"log/slog"
+	"time"
+
+	"github.com/aarondl/authboss/v3"
+)
+
+// UnlockTokenStorer stores the single-use links emailed to unlock accounts
+// locked after too many failed logins
+type UnlockTokenStorer struct {
+	db *sql.DB
+}
+
+// NewUnlockTokenStorer creates a new unlock token storer
+func NewUnlockTokenStorer(db *sql.DB) *UnlockTokenStorer {
+	return &UnlockTokenStorer{db: db}
+}
+
+// CreateUnlockToken stores a hashed unlock token for a user, replacing any of
+// the user's unlock links that have not been used yet
+func (s *UnlockTokenStorer) CreateUnlockToken(ctx context.Context, userID int, tokenHash string, expiresAt time.Time) error {
+	tx, err := s.db.BeginTx(ctx, nil)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.ExecContext(ctx, `DELETE FROM account_unlock_tokens WHERE user_id = $1 AND used_at IS NULL`, userID)
+	if err != nil {
+		return fmt.Errorf("failed to revoke previous unlock links: %w", err)
+	}
+
+	query := `
+		INSERT INTO account_unlock_tokens (user_id, token_hash, expires_at)
+		VALUES ($1, $2, $3)
+	`
+	if _, err := tx.ExecContext(ctx, query, userID, tokenHash, expiresAt); err != nil {
+		return fmt.Errorf("failed to create unlock token: %w", err)
+	}
+
+	return tx.Commit()
+}
+
+// UseUnlockToken marks an unexpired, unused token as used and returns its
+// user ID. A token can only ever be used once.
+func (s *UnlockTokenStorer) UseUnlockToken(ctx context.Context, tokenHash string) (int, error) {
+	query := `
+		UPDATE account_unlock_tokens SET used_at = NOW()
+		WHERE token_hash = $1 AND used_at IS NULL AND expires_at > NOW()
+		RETURNING user_id
+	`
+
+	var userID int
+	err := s.db.QueryRowContext(ctx, query, tokenHash).Scan(&userID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, authboss.ErrTokenNotFound
+		}
+		return 0, fmt.Errorf("failed to use unlock token: %w", err)
+	}
+
+	return userID, nil
+}
+
+// CleanupExpiredUnlockTokens removes expired and used unlock tokens
+func (s *UnlockTokenStorer) CleanupExpiredUnlockTokens(ctx context.Context) error {
+	query := `DELETE FROM account_unlock_tokens WHERE expires_at < NOW() OR used_at IS NOT NULL`
+
+	result, err := s.db.ExecContext(ctx, query)
+	if err != nil {
+		return fmt.Errorf("failed to cleanup expired unlock tokens: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	slog.Info("cleaned up expired unlock tokens", "count", rowsAffected)
+
+	return nil
+}
@@ -0,0 +1,145 @@
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"time"
+)
+
+// Cache is a key/value store with per-key expiry
+type Cache interface {
+	// Get returns the value for key and whether it was found
+	Get(key string) ([]byte, bool, error)
+	// Set stores value under key for the given TTL
+	Set(key string, value []byte, ttl time.Duration) error
+	// Delete removes the given keys
+	Delete(keys ...string) error
+	// DeletePrefix removes every key starting with prefix
+	DeletePrefix(prefix string) error
+	// Increment adds one to the counter at key and returns the new value. The
+	// TTL is only applied when the counter is created.
+	Increment(key string, ttl time.Duration) (int64, error)
+}
+
+// New creates a Redis cache when redisURL is set and reachable, falling back
+// to an in-memory cache otherwise
+func New(redisURL string) Cache {
+	if redisURL == "" {
+		log.Println("Redis not configured, using in-memory cache")
+		return NewMemoryCache()
+	}
+
+	redisCache, err := NewRedisCache(redisURL)
+	if err != nil {
+		log.Printf("Warning: Failed to connect to Redis (%v), using in-memory cache", err)
+		return NewMemoryCache()
+	}
+
+	log.Println("Using Redis cache")
+	return redisCache
+}
+
+// Remember returns the cached value for key, or calls load and caches its
+// result as JSON. Cache failures are logged and never fail the caller.
+func Remember[T any](c Cache, key string, ttl time.Duration, load func() (T, error)) (T, error) {
+	if c == nil {
+		return load()
+	}
+
+	if data, found, err := c.Get(key); err != nil {
+		log.Printf("Cache get failed for %s: %v", key, err)
+	} else if found {
+		var value T
+		if err := json.Unmarshal(data, &value); err == nil {
+			return value, nil
+		}
+		log.Printf("Cache decode failed for %s, reloading", key)
+	}
+
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+
+	data, err := json.Marshal(value)
+	if err != nil {
+		log.Printf("Cache encode failed for %s: %v", key, err)
+		return value, nil
+	}
+	if err := c.Set(key, data, ttl); err != nil {
+		log.Printf("Cache set failed for %s: %v", key, err)
+	}
+
+	return value, nil
+}
+
+// staleEntry is a cached value with the time it stops being fresh
+type staleEntry[T any] struct {
+	Value      T         `json:"value"`
+	FreshUntil time.Time `json:"fresh_until"`
+}
+
+// clock returns the current time; tests replace it
+var clock = time.Now
+
+// RememberStale is Remember with stale-while-revalidate: a value is fresh for
+// ttl and then served for up to staleTTL longer while a single caller reloads
+// it in the background. Deleting the key makes the next read load synchronously.
+func RememberStale[T any](c Cache, key string, ttl, staleTTL time.Duration, load func() (T, error)) (T, error) {
+	if c == nil {
+		return load()
+	}
+
+	if data, found, err := c.Get(key); err != nil {
+		log.Printf("Cache get failed for %s: %v", key, err)
+	} else if found {
+		var entry staleEntry[T]
+		if err := json.Unmarshal(data, &entry); err == nil {
+			if clock().Before(entry.FreshUntil) {
+				return entry.Value, nil
+			}
+
+			// Only the first caller to see the stale value reloads it
+			if refreshes, err := c.Increment(key+":refresh", ttl); err == nil && refreshes == 1 {
+				go func() {
+					if _, err := storeStale(c, key, ttl, staleTTL, load); err != nil {
+						log.Printf("Cache refresh failed for %s: %v", key, err)
+					}
+				}()
+			}
+			return entry.Value, nil
+		}
+		log.Printf("Cache decode failed for %s, reloading", key)
+	}
+
+	return storeStale(c, key, ttl, staleTTL, load)
+}
+
+// storeStale loads a value and caches it for RememberStale
+func storeStale[T any](c Cache, key string, ttl, staleTTL time.Duration, load func() (T, error)) (T, error) {
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+
+	data, err := json.Marshal(staleEntry[T]{Value: value, FreshUntil: clock().Add(ttl)})
+	if err != nil {
+		log.Printf("Cache encode failed for %s: %v", key, err)
+		return value, nil
+	}
+	if err := c.Set(key, data, ttl+staleTTL); err != nil {
+		log.Printf("Cache set failed for %s: %v", key, err)
+	}
+
+	return value, nil
+}
+
+// Key builds a cache key from a prefix and parts, e.g. Key("events:upcoming", 6)
+func Key(prefix string, parts ...interface{}) string {
+	key := prefix
+	for _, part := range parts {
+		key += fmt.Sprintf(":%v", part)
+	}
+	return key
+}
@@ -0,0 +1,206 @@
+package cache
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMemoryCache_GetSetDelete(t *testing.T) {
+	c := NewMemoryCache()
+	current := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return current }
+
+	if _, found, _ := c.Get("missing"); found {
+		t.Fatal("expected missing key to be absent")
+	}
+
+	c.Set("events:featured:6", []byte("a"), time.Minute)
+	c.Set("events:upcoming:6", []byte("b"), time.Minute)
+	c.Set("categories", []byte("c"), time.Minute)
+
+	value, found, err := c.Get("events:featured:6")
+	if err != nil || !found || string(value) != "a" {
+		t.Fatalf("expected cached value 'a', got %q found=%v err=%v", value, found, err)
+	}
+
+	c.DeletePrefix("events:")
+	if _, found, _ := c.Get("events:upcoming:6"); found {
+		t.Error("expected prefixed keys to be deleted")
+	}
+	if _, found, _ := c.Get("categories"); !found {
+		t.Error("expected other keys to be kept")
+	}
+
+	c.Delete("categories")
+	if _, found, _ := c.Get("categories"); found {
+		t.Error("expected key to be deleted")
+	}
+}
+
+func TestMemoryCache_Expiry(t *testing.T) {
+	c := NewMemoryCache()
+	current := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return current }
+
+	c.Set("key", []byte("value"), time.Minute)
+
+	current = current.Add(59 * time.Second)
+	if _, found, _ := c.Get("key"); !found {
+		t.Error("expected key to be present before TTL")
+	}
+
+	current = current.Add(2 * time.Second)
+	if _, found, _ := c.Get("key"); found {
+		t.Error("expected key to expire after TTL")
+	}
+}
+
+func TestMemoryCache_Increment(t *testing.T) {
+	c := NewMemoryCache()
+	current := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return current }
+
+	for i := int64(1); i <= 3; i++ {
+		count, err := c.Increment("hits", time.Minute)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if count != i {
+			t.Errorf("expected count %d, got %d", i, count)
+		}
+	}
+
+	// The TTL is set when the counter is created and not extended
+	current = current.Add(61 * time.Second)
+	if count, _ := c.Increment("hits", time.Minute); count != 1 {
+		t.Errorf("expected counter to restart after expiry, got %d", count)
+	}
+
+	c.Set("text", []byte("abc"), time.Minute)
+	if _, err := c.Increment("text", time.Minute); err == nil {
+		t.Error("expected error incrementing a non-counter value")
+	}
+}
+
+func TestRemember(t *testing.T) {
+	c := NewMemoryCache()
+	calls := 0
+	load := func() ([]string, error) {
+		calls++
+		return []string{"music", "sports"}, nil
+	}
+
+	for i := 0; i < 3; i++ {
+		value, err := Remember(c, "categories", time.Minute, load)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(value) != 2 || value[0] != "music" {
+			t.Errorf("unexpected value: %v", value)
+		}
+	}
+	if calls != 1 {
+		t.Errorf("expected loader to be called once, got %d", calls)
+	}
+
+	// Errors are returned and not cached
+	failing := func() ([]string, error) { return nil, errors.New("db down") }
+	if _, err := Remember(c, "failing", time.Minute, failing); err == nil {
+		t.Error("expected loader error to be returned")
+	}
+	if _, found, _ := c.Get("failing"); found {
+		t.Error("expected failed load not to be cached")
+	}
+
+	// A nil cache always loads
+	calls = 0
+	Remember[[]string](nil, "categories", time.Minute, load)
+	Remember[[]string](nil, "categories", time.Minute, load)
+	if calls != 2 {
+		t.Errorf("expected nil cache to load every time, got %d calls", calls)
+	}
+}
+
+func TestRememberStale(t *testing.T) {
+	c := NewMemoryCache()
+	current := time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC)
+	c.now = func() time.Time { return current }
+	clock = func() time.Time { return current }
+	defer func() { clock = time.Now }()
+
+	available := 10
+	refreshed := make(chan struct{}, 1)
+	load := func() (int, error) {
+		defer func() {
+			select {
+			case refreshed <- struct{}{}:
+			default:
+			}
+		}()
+		return available, nil
+	}
+
+	if value, _ := RememberStale(c, "availability:1", 5*time.Second, 25*time.Second, load); value != 10 {
+		t.Fatalf("expected 10, got %d", value)
+	}
+	<-refreshed
+
+	// While fresh, changes aren't seen
+	available = 8
+	current = current.Add(4 * time.Second)
+	if value, _ := RememberStale(c, "availability:1", 5*time.Second, 25*time.Second, load); value != 10 {
+		t.Errorf("expected fresh cached value 10, got %d", value)
+	}
+
+	// Once stale, the old value is served while it reloads in the background
+	current = current.Add(2 * time.Second)
+	if value, _ := RememberStale(c, "availability:1", 5*time.Second, 25*time.Second, load); value != 10 {
+		t.Errorf("expected stale value 10, got %d", value)
+	}
+	<-refreshed
+	deadline := time.Now().Add(time.Second)
+	for {
+		value, _ := RememberStale(c, "availability:1", 5*time.Second, 25*time.Second, load)
+		if value == 8 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected background reload to store 8, still got %d", value)
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	// Past the stale window the value is loaded synchronously
+	available = 5
+	current = current.Add(31 * time.Second)
+	if value, _ := RememberStale(c, "availability:1", 5*time.Second, 25*time.Second, load); value != 5 {
+		t.Errorf("expected expired value to be reloaded, got %d", value)
+	}
+	<-refreshed
+
+	// Invalidation forces a synchronous load
+	available = 4
+	c.Delete("availability:1")
+	if value, _ := RememberStale(c, "availability:1", 5*time.Second, 25*time.Second, load); value != 4 {
+		t.Errorf("expected invalidated value to be reloaded, got %d", value)
+	}
+}
+
+func TestKey(t *testing.T) {
+	if got := Key("events:published", 20, 40); got != "events:published:20:40" {
+		t.Errorf("unexpected key: %s", got)
+	}
+	if got := Key("events:categories"); got != "events:categories" {
+		t.Errorf("unexpected key: %s", got)
+	}
+}
+
+func TestNew_FallsBackToMemory(t *testing.T) {
+	if _, ok := New("").(*MemoryCache); !ok {
+		t.Error("expected memory cache when Redis is not configured")
+	}
+	if _, ok := New("redis://127.0.0.1:1").(*MemoryCache); !ok {
+		t.Error("expected memory cache when Redis is unreachable")
+	}
+}
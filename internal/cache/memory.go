@@ -0,0 +1,114 @@
+package cache
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// memoryEntry is a cached value with its expiry time
+type memoryEntry struct {
+	value     []byte
+	expiresAt time.Time
+}
+
+// MemoryCache is an in-process Cache used when Redis is unavailable
+type MemoryCache struct {
+	mu      sync.RWMutex
+	entries map[string]memoryEntry
+	now     func() time.Time
+}
+
+// NewMemoryCache creates a new in-memory cache
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{
+		entries: make(map[string]memoryEntry),
+		now:     time.Now,
+	}
+}
+
+// Get returns the value for key if it exists and has not expired
+func (c *MemoryCache) Get(key string) ([]byte, bool, error) {
+	c.mu.RLock()
+	entry, exists := c.entries[key]
+	c.mu.RUnlock()
+
+	if !exists {
+		return nil, false, nil
+	}
+	if c.now().After(entry.expiresAt) {
+		c.mu.Lock()
+		delete(c.entries, key)
+		c.mu.Unlock()
+		return nil, false, nil
+	}
+
+	return entry.value, true, nil
+}
+
+// Set stores value under key for the given TTL
+func (c *MemoryCache) Set(key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	// Opportunistically drop expired entries so the map does not grow unbounded
+	now := c.now()
+	if len(c.entries) > 1000 {
+		for k, entry := range c.entries {
+			if now.After(entry.expiresAt) {
+				delete(c.entries, k)
+			}
+		}
+	}
+
+	c.entries[key] = memoryEntry{value: value, expiresAt: now.Add(ttl)}
+	return nil
+}
+
+// Increment adds one to the counter at key and returns the new value
+func (c *MemoryCache) Increment(key string, ttl time.Duration) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := c.now()
+	entry, exists := c.entries[key]
+	if !exists || now.After(entry.expiresAt) {
+		entry = memoryEntry{value: []byte("0"), expiresAt: now.Add(ttl)}
+	}
+
+	count, err := strconv.ParseInt(string(entry.value), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("value at %s is not a counter", key)
+	}
+	count++
+
+	entry.value = []byte(strconv.FormatInt(count, 10))
+	c.entries[key] = entry
+	return count, nil
+}
+
+// Delete removes the given keys
+func (c *MemoryCache) Delete(keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+	return nil
+}
+
+// DeletePrefix removes every key starting with prefix
+func (c *MemoryCache) DeletePrefix(prefix string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for key := range c.entries {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.entries, key)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,335 @@
+package cache
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	redisDialTimeout = 2 * time.Second
+	redisIOTimeout   = time.Second
+	redisMaxIdle     = 8
+)
+
+// errNil is returned by a redis command that replies with a null value
+var errNil = errors.New("redis: nil")
+
+// RedisCache is a Cache backed by Redis, speaking RESP over a small pool of
+// connections
+type RedisCache struct {
+	addr     string
+	password string
+	db       int
+
+	mu   sync.Mutex
+	idle []*redisConn
+}
+
+// redisConn is a single connection to the Redis server
+type redisConn struct {
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+// NewRedisCache connects to the Redis server at redisURL, e.g.
+// redis://:password@localhost:6379/0
+func NewRedisCache(redisURL string) (*RedisCache, error) {
+	u, err := url.Parse(redisURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid redis URL: %w", err)
+	}
+	if u.Scheme != "redis" {
+		return nil, fmt.Errorf("unsupported redis URL scheme: %s", u.Scheme)
+	}
+
+	c := &RedisCache{addr: u.Host}
+	if u.Port() == "" {
+		c.addr = net.JoinHostPort(u.Hostname(), "6379")
+	}
+	if u.User != nil {
+		c.password, _ = u.User.Password()
+	}
+	if path := strings.TrimPrefix(u.Path, "/"); path != "" {
+		c.db, err = strconv.Atoi(path)
+		if err != nil {
+			return nil, fmt.Errorf("invalid redis database: %s", path)
+		}
+	}
+
+	if _, err := c.do("PING"); err != nil {
+		return nil, fmt.Errorf("failed to ping redis: %w", err)
+	}
+
+	return c, nil
+}
+
+// Get returns the value for key
+func (c *RedisCache) Get(key string) ([]byte, bool, error) {
+	reply, err := c.do("GET", key)
+	if err == errNil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to get cache key: %w", err)
+	}
+
+	value, ok := reply.([]byte)
+	if !ok {
+		return nil, false, fmt.Errorf("unexpected redis reply for GET")
+	}
+	return value, true, nil
+}
+
+// Set stores value under key for the given TTL
+func (c *RedisCache) Set(key string, value []byte, ttl time.Duration) error {
+	ms := ttl.Milliseconds()
+	if ms <= 0 {
+		ms = 1
+	}
+	if _, err := c.do("SET", key, string(value), "PX", strconv.FormatInt(ms, 10)); err != nil {
+		return fmt.Errorf("failed to set cache key: %w", err)
+	}
+	return nil
+}
+
+// Increment adds one to the counter at key and returns the new value
+func (c *RedisCache) Increment(key string, ttl time.Duration) (int64, error) {
+	reply, err := c.do("INCR", key)
+	if err != nil {
+		return 0, fmt.Errorf("failed to increment cache key: %w", err)
+	}
+
+	count, ok := reply.(int64)
+	if !ok {
+		return 0, fmt.Errorf("unexpected redis reply for INCR")
+	}
+
+	if count == 1 {
+		ms := ttl.Milliseconds()
+		if ms <= 0 {
+			ms = 1
+		}
+		if _, err := c.do("PEXPIRE", key, strconv.FormatInt(ms, 10)); err != nil {
+			return count, fmt.Errorf("failed to set counter expiry: %w", err)
+		}
+	}
+
+	return count, nil
+}
+
+// Delete removes the given keys
+func (c *RedisCache) Delete(keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+	if _, err := c.do("DEL", keys...); err != nil {
+		return fmt.Errorf("failed to delete cache keys: %w", err)
+	}
+	return nil
+}
+
+// DeletePrefix removes every key starting with prefix using SCAN so large
+// keyspaces do not block the server
+func (c *RedisCache) DeletePrefix(prefix string) error {
+	cursor := "0"
+	for {
+		reply, err := c.do("SCAN", cursor, "MATCH", escapeGlob(prefix)+"*", "COUNT", "100")
+		if err != nil {
+			return fmt.Errorf("failed to scan cache keys: %w", err)
+		}
+
+		parts, ok := reply.([]interface{})
+		if !ok || len(parts) != 2 {
+			return fmt.Errorf("unexpected redis reply for SCAN")
+		}
+		next, _ := parts[0].([]byte)
+		keys, _ := parts[1].([]interface{})
+
+		batch := make([]string, 0, len(keys))
+		for _, key := range keys {
+			if b, ok := key.([]byte); ok {
+				batch = append(batch, string(b))
+			}
+		}
+		if err := c.Delete(batch...); err != nil {
+			return err
+		}
+
+		cursor = string(next)
+		if cursor == "0" || cursor == "" {
+			return nil
+		}
+	}
+}
+
+// do runs a single command on a pooled connection
+func (c *RedisCache) do(cmd string, args ...string) (interface{}, error) {
+	rc, err := c.getConn()
+	if err != nil {
+		return nil, err
+	}
+
+	reply, err := rc.do(cmd, args...)
+	if err != nil && err != errNil {
+		// Protocol state is unknown after a failure, so drop the connection
+		if _, isServerErr := err.(redisError); !isServerErr {
+			rc.conn.Close()
+			return nil, err
+		}
+	}
+
+	c.putConn(rc)
+	return reply, err
+}
+
+// getConn returns an idle connection or dials a new one
+func (c *RedisCache) getConn() (*redisConn, error) {
+	c.mu.Lock()
+	if n := len(c.idle); n > 0 {
+		rc := c.idle[n-1]
+		c.idle = c.idle[:n-1]
+		c.mu.Unlock()
+		return rc, nil
+	}
+	c.mu.Unlock()
+
+	conn, err := net.DialTimeout("tcp", c.addr, redisDialTimeout)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to redis: %w", err)
+	}
+	rc := &redisConn{conn: conn, reader: bufio.NewReader(conn)}
+
+	if c.password != "" {
+		if _, err := rc.do("AUTH", c.password); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to authenticate with redis: %w", err)
+		}
+	}
+	if c.db != 0 {
+		if _, err := rc.do("SELECT", strconv.Itoa(c.db)); err != nil {
+			conn.Close()
+			return nil, fmt.Errorf("failed to select redis database: %w", err)
+		}
+	}
+
+	return rc, nil
+}
+
+// putConn returns a connection to the idle pool
+func (c *RedisCache) putConn(rc *redisConn) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if len(c.idle) >= redisMaxIdle {
+		rc.conn.Close()
+		return
+	}
+	c.idle = append(c.idle, rc)
+}
+
+// Close closes all idle connections
+func (c *RedisCache) Close() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, rc := range c.idle {
+		rc.conn.Close()
+	}
+	c.idle = nil
+	return nil
+}
+
+// redisError is an error reply sent by the server
+type redisError string
+
+func (e redisError) Error() string {
+	return "redis: " + string(e)
+}
+
+// do writes a command and reads its reply
+func (rc *redisConn) do(cmd string, args ...string) (interface{}, error) {
+	rc.conn.SetDeadline(time.Now().Add(redisIOTimeout))
+
+	if _, err := rc.conn.Write(encodeCommand(cmd, args...)); err != nil {
+		return nil, err
+	}
+	return readReply(rc.reader)
+}
+
+// encodeCommand encodes a command as a RESP array of bulk strings
+func encodeCommand(cmd string, args ...string) []byte {
+	var b strings.Builder
+	fmt.Fprintf(&b, "*%d\r\n", len(args)+1)
+	fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(cmd), cmd)
+	for _, arg := range args {
+		fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(arg), arg)
+	}
+	return []byte(b.String())
+}
+
+// readReply parses a single RESP reply
+func readReply(r *bufio.Reader) (interface{}, error) {
+	line, err := r.ReadString('\n')
+	if err != nil {
+		return nil, err
+	}
+	line = strings.TrimSuffix(line, "\r\n")
+	if line == "" {
+		return nil, fmt.Errorf("redis: empty reply")
+	}
+
+	switch line[0] {
+	case '+':
+		return line[1:], nil
+	case '-':
+		return nil, redisError(line[1:])
+	case ':':
+		return strconv.ParseInt(line[1:], 10, 64)
+	case '$':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid bulk length: %w", err)
+		}
+		if n < 0 {
+			return nil, errNil
+		}
+		buf := make([]byte, n+2)
+		if _, err := io.ReadFull(r, buf); err != nil {
+			return nil, err
+		}
+		return buf[:n], nil
+	case '*':
+		n, err := strconv.Atoi(line[1:])
+		if err != nil {
+			return nil, fmt.Errorf("redis: invalid array length: %w", err)
+		}
+		if n < 0 {
+			return nil, errNil
+		}
+		items := make([]interface{}, 0, n)
+		for i := 0; i < n; i++ {
+			item, err := readReply(r)
+			if err != nil && err != errNil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	}
+
+	return nil, fmt.Errorf("redis: unexpected reply type %q", line[0])
+}
+
+// escapeGlob escapes glob metacharacters in a SCAN MATCH pattern
+func escapeGlob(s string) string {
+	replacer := strings.NewReplacer(`\`, `\\`, `*`, `\*`, `?`, `\?`, `[`, `\[`, `]`, `\]`)
+	return replacer.Replace(s)
+}
@@ -0,0 +1,185 @@
+package cache
+
+import (
+	"bufio"
+	"fmt"
+	"net"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// fakeRedis is a minimal in-process Redis server supporting the commands
+// used by RedisCache
+type fakeRedis struct {
+	listener net.Listener
+	mu       sync.Mutex
+	data     map[string]string
+}
+
+func newFakeRedis(t *testing.T) *fakeRedis {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Skipf("cannot listen: %v", err)
+	}
+	f := &fakeRedis{listener: listener, data: make(map[string]string)}
+	go f.serve()
+	t.Cleanup(func() { listener.Close() })
+	return f
+}
+
+func (f *fakeRedis) serve() {
+	for {
+		conn, err := f.listener.Accept()
+		if err != nil {
+			return
+		}
+		go f.handle(conn)
+	}
+}
+
+func (f *fakeRedis) handle(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	for {
+		reply, err := readReply(reader)
+		if err != nil {
+			return
+		}
+		items, _ := reply.([]interface{})
+		args := make([]string, len(items))
+		for i, item := range items {
+			b, _ := item.([]byte)
+			args[i] = string(b)
+		}
+		conn.Write([]byte(f.exec(args)))
+	}
+}
+
+func (f *fakeRedis) exec(args []string) string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+
+	switch strings.ToUpper(args[0]) {
+	case "PING":
+		return "+PONG\r\n"
+	case "GET":
+		value, ok := f.data[args[1]]
+		if !ok {
+			return "$-1\r\n"
+		}
+		return fmt.Sprintf("$%d\r\n%s\r\n", len(value), value)
+	case "SET":
+		f.data[args[1]] = args[2]
+		return "+OK\r\n"
+	case "INCR":
+		var count int
+		fmt.Sscanf(f.data[args[1]], "%d", &count)
+		count++
+		f.data[args[1]] = fmt.Sprintf("%d", count)
+		return fmt.Sprintf(":%d\r\n", count)
+	case "PEXPIRE":
+		return ":1\r\n"
+	case "DEL":
+		for _, key := range args[1:] {
+			delete(f.data, key)
+		}
+		return fmt.Sprintf(":%d\r\n", len(args)-1)
+	case "SCAN":
+		prefix := strings.TrimSuffix(args[3], "*")
+		var keys []string
+		for key := range f.data {
+			if strings.HasPrefix(key, prefix) {
+				keys = append(keys, key)
+			}
+		}
+		var b strings.Builder
+		fmt.Fprintf(&b, "*2\r\n$1\r\n0\r\n*%d\r\n", len(keys))
+		for _, key := range keys {
+			fmt.Fprintf(&b, "$%d\r\n%s\r\n", len(key), key)
+		}
+		return b.String()
+	}
+	return "-ERR unknown command\r\n"
+}
+
+func TestRedisCache(t *testing.T) {
+	server := newFakeRedis(t)
+
+	c, err := NewRedisCache("redis://" + server.listener.Addr().String())
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer c.Close()
+
+	if _, found, err := c.Get("missing"); err != nil || found {
+		t.Fatalf("expected missing key, found=%v err=%v", found, err)
+	}
+
+	value := []byte("{\"title\":\"Jazz\\r\\nNight\"}")
+	if err := c.Set("events:featured:6", value, time.Minute); err != nil {
+		t.Fatalf("failed to set: %v", err)
+	}
+	c.Set("events:upcoming:6", []byte("b"), time.Minute)
+	c.Set("other", []byte("c"), time.Minute)
+
+	got, found, err := c.Get("events:featured:6")
+	if err != nil || !found || string(got) != string(value) {
+		t.Fatalf("expected %q, got %q found=%v err=%v", value, got, found, err)
+	}
+
+	if err := c.DeletePrefix("events:"); err != nil {
+		t.Fatalf("failed to delete prefix: %v", err)
+	}
+	if _, found, _ := c.Get("events:upcoming:6"); found {
+		t.Error("expected prefixed keys to be deleted")
+	}
+	if _, found, _ := c.Get("other"); !found {
+		t.Error("expected other keys to be kept")
+	}
+}
+
+func TestRedisCache_Increment(t *testing.T) {
+	server := newFakeRedis(t)
+
+	c, err := NewRedisCache("redis://" + server.listener.Addr().String())
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer c.Close()
+
+	for i := int64(1); i <= 3; i++ {
+		count, err := c.Increment("hits", time.Minute)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if count != i {
+			t.Errorf("expected count %d, got %d", i, count)
+		}
+	}
+
+	value, found, _ := c.Get("hits")
+	if !found || string(value) != "3" {
+		t.Errorf("expected counter to be readable with Get, got %q", value)
+	}
+}
+
+func TestNewRedisCache_InvalidURL(t *testing.T) {
+	tests := []string{
+		"http://localhost:6379",
+		"redis://localhost:6379/abc",
+	}
+	for _, redisURL := range tests {
+		if _, err := NewRedisCache(redisURL); err == nil {
+			t.Errorf("expected error for %s", redisURL)
+		}
+	}
+}
+
+func TestEscapeGlob(t *testing.T) {
+	if got := escapeGlob("events:[1]*?"); got != `events:\[1\]\*\?` {
+		t.Errorf("unexpected escaped pattern: %s", got)
+	}
+}
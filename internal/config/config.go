@@ -2,28 +2,64 @@ package config
 
 import (
 	"net/url"
-	"os"
 	"strconv"
 	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/logging"
 
 	"github.com/joho/godotenv"
 )
 
+// Environments the application runs in
+const (
+	EnvDevelopment = "development"
+	EnvTest        = "test"
+	EnvStaging     = "staging"
+	EnvProduction  = "production"
+)
+
+// placeholderSessionSecret is the development session secret, which
+// production refuses to start with
+const placeholderSessionSecret = "your-secret-key-change-in-production"
+
+// emailProviders are the providers EMAIL_PROVIDER and EMAIL_FALLBACK_PROVIDER
+// can name
+var emailProviders = []string{"resend", "smtp", "ses"}
+
 type Config struct {
-	Server   ServerConfig
-	Database DatabaseConfig
-	Session  SessionConfig
-	Email    EmailConfig
-	Resend   ResendConfig
-	Pesapal  PesapalConfig
-	Paystack PaystackConfig
-	R2       R2Config
+	Server            ServerConfig
+	Database          DatabaseConfig
+	Session           SessionConfig
+	Email             EmailConfig
+	Resend            ResendConfig
+	SES               SESConfig
+	Pesapal           PesapalConfig
+	Paystack          PaystackConfig
+	R2                R2Config
+	StorageGC         StorageGCConfig
+	Trash             TrashConfig
+	Redis             RedisConfig
+	RateLimit         RateLimitConfig
+	CORS              CORSConfig
+	OAuth             OAuthConfig
+	Wallet            WalletConfig
+	ContentModeration ContentModerationConfig
+	Geocoding         GeocodingConfig
+	PaymentHealth     PaymentHealthConfig
+	Tickets           TicketsConfig
+	Uploads           UploadsConfig
+	Assets            AssetsConfig
+	Log               LogConfig
 }
 
 type ServerConfig struct {
-	Port string
-	Host string
-	Env  string
+	Port            string
+	Host            string
+	Env             string
+	BaseURL         string        // Public URL used for absolute links (sitemaps, canonical URLs)
+	TimeZone        string        // Default IANA time zone for date-based browsing
+	ShutdownTimeout time.Duration // How long shutdown waits for requests and background jobs to finish
 }
 
 type DatabaseConfig struct {
@@ -37,21 +73,31 @@ type DatabaseConfig struct {
 }
 
 type SessionConfig struct {
-	Secret string
+	Secret        string
+	SecureCookies bool // Only send session cookies over HTTPS; always on in production
 }
 
 type EmailConfig struct {
-	SMTPHost     string
-	SMTPPort     int
-	SMTPUser     string
-	SMTPPassword string
-	FromEmail    string
+	Provider         string // Sends emails: resend, smtp or ses
+	FallbackProvider string // Takes over while the provider is failing; empty for none
+	SMTPHost         string
+	SMTPPort         int
+	SMTPUser         string
+	SMTPPassword     string
+	FromEmail        string
 }
 
 type ResendConfig struct {
-	APIKey    string
-	FromEmail string
-	FromName  string
+	APIKey        string
+	FromEmail     string
+	FromName      string
+	WebhookSecret string // Signs the webhooks reporting deliveries, bounces and complaints
+}
+
+type SESConfig struct {
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
 }
 
 type PesapalConfig struct {
@@ -80,80 +126,326 @@ type R2Config struct {
 	Endpoint        string
 }
 
+// StorageGCConfig controls the periodic cleanup of uploads that no event references
+type StorageGCConfig struct {
+	Interval            time.Duration
+	GracePeriod         time.Duration // Orphans younger than this are kept, since forms upload before saving
+	Quarantine          bool          // Move orphans under quarantine/ instead of deleting them
+	QuarantineRetention time.Duration // How long quarantined objects are kept before deletion
+}
+
+// TrashConfig controls how long deleted users, events and ticket types can
+// be restored before they are purged
+type TrashConfig struct {
+	Retention time.Duration
+}
+
+type RedisConfig struct {
+	URL string // e.g. redis://localhost:6379/0; empty uses the in-memory cache
+}
+
+// RateLimitConfig holds per route group limits as "<requests>/<window>", e.g. "10/15m"
+type RateLimitConfig struct {
+	Login     string
+	Register  string
+	Checkout  string
+	API       string
+	Broadcast string // Organizer emails to all ticket holders, per organizer
+}
+
+// CORSConfig holds the origins allowed to call cross-origin route groups.
+// Dashboard and admin pages are always same-origin only.
+type CORSConfig struct {
+	APIOrigins    []string      // Origins allowed to call /api/v1; empty allows none
+	WidgetOrigins []string      // Sites allowed to embed the event widget; "*" allows any
+	MaxAge        time.Duration // How long browsers may cache preflight responses
+}
+
+// OAuthConfig holds social login credentials. A provider is enabled when its client ID is set.
+type OAuthConfig struct {
+	GoogleClientID     string
+	GoogleClientSecret string
+	AppleClientID      string // Services ID used for Sign in with Apple on the web
+	AppleTeamID        string
+	AppleKeyID         string
+	ApplePrivateKey    string // PEM encoded .p8 key; literal "\n" sequences are converted to newlines
+}
+
+// WalletConfig holds the credentials used to sign Apple Wallet and Google Wallet
+// ticket passes. A wallet is enabled when all of its settings are set. PEM
+// values may use literal "\n" sequences for newlines.
+type WalletConfig struct {
+	OrganizationName          string // Shown on passes
+	ApplePassTypeID           string // e.g. pass.com.example.tickets
+	AppleTeamID               string
+	AppleCertificate          string // PEM encoded pass type certificate
+	ApplePrivateKey           string // PEM encoded RSA key of the pass type certificate
+	AppleWWDRCertificate      string // PEM encoded Apple WWDR intermediate certificate
+	GoogleIssuerID            string
+	GoogleServiceAccountEmail string
+	GooglePrivateKey          string // private_key from the service account JSON key
+}
+
+// ContentModerationConfig configures the optional external moderation API
+// used alongside the built-in spam rules when screening event content. The
+// API is expected to accept and answer requests in the format of OpenAI's
+// moderation endpoint. Leave APIURL empty to use the rules only.
+//
+// ImageScreening chooses how event images are screened: "api" sends them to
+// the moderation API, "heuristic" flags images that are mostly skin tones,
+// and an empty value skips images.
+type ContentModerationConfig struct {
+	APIURL          string // e.g. https://api.openai.com/v1/moderations
+	APIKey          string
+	Model           string // Optional model name sent with each request
+	ImageScreening  string
+	BlockedKeywords []string // Words and phrases that always hold an event for review
+}
+
+// GeocodingConfig configures the optional geocoding API event locations are
+// looked up with for "near me" searches and the events map. The API is
+// expected to follow the format of OpenStreetMap's Nominatim search endpoint,
+// which asks for an identifying User-Agent and at most one request a second.
+// Leave APIURL empty to disable geocoding.
+type GeocodingConfig struct {
+	APIURL       string // e.g. https://nominatim.openstreetmap.org/search
+	APIKey       string // Optional, for hosted Nominatim-compatible providers
+	UserAgent    string
+	CountryCodes []string // Limits results to these ISO 3166-1 country codes, e.g. ke
+}
+
+// PaymentHealthConfig controls how payment provider success rates are tracked.
+// A provider is degraded when fewer than DegradedBelow percent of its recent
+// payment attempts succeed.
+type PaymentHealthConfig struct {
+	Window        time.Duration // How far back attempts count towards the success rate
+	MinAttempts   int           // Attempts needed in the window before a provider can be degraded
+	DegradedBelow int           // Success rate percentage below which a provider is degraded
+	AutoFailover  bool          // Preselect a healthy provider at checkout when the default is degraded
+}
+
+// TicketsConfig controls named tickets
+type TicketsConfig struct {
+	AttendeeEditCutoff time.Duration // How long before an event buyers stop being able to rename its tickets
+}
+
+// UploadsConfig controls the checks uploaded images go through before they
+// are stored
+type UploadsConfig struct {
+	MaxImageWidth      int
+	MaxImageHeight     int
+	MaxImageMegapixels int
+	ClamAVAddress      string        // clamd host:port or unix:/path/to/socket; empty skips malware scanning
+	ClamAVTimeout      time.Duration // How long a scan may take before the upload is refused
+}
+
+// AssetsConfig controls how static files are served. Files get
+// content-hashed names, so a CDN can cache them forever.
+type AssetsConfig struct {
+	Dir        string
+	CDNBaseURL string // e.g. https://cdn.example.com; the CDN pulls /static from this server. Empty serves assets directly.
+}
+
+// LogConfig controls structured logging. Format is "text" or "json" and
+// defaults to JSON in production.
+type LogConfig struct {
+	Level  string // debug, info, warn or error
+	Format string
+}
+
+// Load reads the configuration from the environment and validates it. Every
+// problem found, such as a value that fails to parse or a setting production
+// requires, is reported together in a *ValidationError.
 func Load() (*Config, error) {
 	// Load .env files if they exist (try .env.local first, then .env)
 	_ = godotenv.Load(".env.local")
 	_ = godotenv.Load(".env")
 
+	e := &envReader{}
+	env := e.OneOf("ENV", EnvDevelopment, EnvDevelopment, EnvTest, EnvStaging, EnvProduction)
+	production := env == EnvProduction
+
 	config := &Config{
 		Server: ServerConfig{
-			Port: getEnv("PORT", "8080"),
-			Host: getEnv("HOST", "localhost"),
-			Env:  getEnv("ENV", "development"),
+			Port:            e.String("PORT", "8080"),
+			Host:            e.String("HOST", "localhost"),
+			Env:             env,
+			BaseURL:         strings.TrimRight(e.String("BASE_URL", "http://localhost:8080"), "/"),
+			TimeZone:        e.String("APP_TIMEZONE", "Africa/Nairobi"),
+			ShutdownTimeout: e.Duration("SHUTDOWN_TIMEOUT", 30*time.Second),
 		},
-		Database: parseDatabaseConfig(),
+		Database: parseDatabaseConfig(e, production),
 		Session: SessionConfig{
-			Secret: getEnv("SESSION_SECRET", "your-secret-key-change-in-production"),
+			Secret: e.String("SESSION_SECRET", placeholderSessionSecret),
+			// Production always serves over HTTPS, so its cookies are always secure
+			SecureCookies: production || e.Bool("COOKIE_SECURE", false),
 		},
 		Email: EmailConfig{
-			SMTPHost:     getEnv("SMTP_HOST", "localhost"),
-			SMTPPort:     getEnvAsInt("SMTP_PORT", 587),
-			SMTPUser:     getEnv("SMTP_USER", ""),
-			SMTPPassword: getEnv("SMTP_PASSWORD", ""),
-			FromEmail:    getEnv("FROM_EMAIL", "noreply@eventtickets.com"),
+			Provider:         e.OneOf("EMAIL_PROVIDER", "resend", emailProviders...),
+			FallbackProvider: e.String("EMAIL_FALLBACK_PROVIDER", ""),
+			SMTPHost:         e.String("SMTP_HOST", "localhost"),
+			SMTPPort:         e.Int("SMTP_PORT", 587),
+			SMTPUser:         e.String("SMTP_USER", ""),
+			SMTPPassword:     e.String("SMTP_PASSWORD", ""),
+			FromEmail:        e.String("FROM_EMAIL", "noreply@eventtickets.com"),
 		},
 		Resend: ResendConfig{
-			APIKey:    getEnv("RESEND_API_KEY", ""),
-			FromEmail: getEnv("RESEND_FROM_EMAIL", "noreply@eventtickets.com"),
-			FromName:  getEnv("RESEND_FROM_NAME", "Event Ticketing Platform"),
+			APIKey:        e.String("RESEND_API_KEY", ""),
+			FromEmail:     e.String("RESEND_FROM_EMAIL", "noreply@eventtickets.com"),
+			FromName:      e.String("RESEND_FROM_NAME", "Event Ticketing Platform"),
+			WebhookSecret: e.String("RESEND_WEBHOOK_SECRET", ""),
+		},
+		SES: SESConfig{
+			Region:          e.String("SES_REGION", ""),
+			AccessKeyID:     e.String("SES_ACCESS_KEY_ID", ""),
+			SecretAccessKey: e.String("SES_SECRET_ACCESS_KEY", ""),
 		},
 		Pesapal: PesapalConfig{
-			ConsumerKey:    getEnv("PESAPAL_CONSUMER_KEY", ""),
-			ConsumerSecret: getEnv("PESAPAL_CONSUMER_SECRET", ""),
-			Environment:    getEnv("PESAPAL_ENVIRONMENT", "sandbox"),
-			CallbackURL:    getEnv("PESAPAL_CALLBACK_URL", "http://localhost:8080/payment/callback"),
-			IPNURL:         getEnv("PESAPAL_IPN_URL", "http://localhost:8080/payment/ipn"),
+			ConsumerKey:    e.String("PESAPAL_CONSUMER_KEY", ""),
+			ConsumerSecret: e.String("PESAPAL_CONSUMER_SECRET", ""),
+			Environment:    e.String("PESAPAL_ENVIRONMENT", "sandbox"),
+			CallbackURL:    e.String("PESAPAL_CALLBACK_URL", "http://localhost:8080/payment/callback"),
+			IPNURL:         e.String("PESAPAL_IPN_URL", "http://localhost:8080/payment/ipn"),
 		},
 		Paystack: PaystackConfig{
-			SecretKey:   getEnv("PAYSTACK_SECRET_KEY", ""),
-			PublicKey:   getEnv("PAYSTACK_PUBLIC_KEY", ""),
-			Environment: getEnv("PAYSTACK_ENVIRONMENT", "test"),
-			WebhookURL:  getEnv("PAYSTACK_WEBHOOK_URL", "http://localhost:8080/payment/paystack/webhook"),
-			CallbackURL: getEnv("PAYSTACK_CALLBACK_URL", "http://localhost:8080/payment/paystack/callback"),
+			SecretKey:   e.String("PAYSTACK_SECRET_KEY", ""),
+			PublicKey:   e.String("PAYSTACK_PUBLIC_KEY", ""),
+			Environment: e.String("PAYSTACK_ENVIRONMENT", "test"),
+			WebhookURL:  e.String("PAYSTACK_WEBHOOK_URL", "http://localhost:8080/payment/paystack/webhook"),
+			CallbackURL: e.String("PAYSTACK_CALLBACK_URL", "http://localhost:8080/payment/paystack/callback"),
 		},
 		R2: R2Config{
-			AccountID:       getEnv("R2_ACCOUNT_ID", ""),
-			AccessKeyID:     getEnv("R2_ACCESS_KEY_ID", ""),
-			SecretAccessKey: getEnv("R2_SECRET_ACCESS_KEY", ""),
-			BucketName:      getEnv("R2_BUCKET_NAME", "event-images"),
-			PublicURL:       getEnv("R2_PUBLIC_URL", ""),
-			Region:          getEnv("R2_REGION", "auto"),
-			Endpoint:        getEnv("R2_ENDPOINT", ""),
+			AccountID:       e.String("R2_ACCOUNT_ID", ""),
+			AccessKeyID:     e.String("R2_ACCESS_KEY_ID", ""),
+			SecretAccessKey: e.String("R2_SECRET_ACCESS_KEY", ""),
+			BucketName:      e.String("R2_BUCKET_NAME", "event-images"),
+			PublicURL:       e.String("R2_PUBLIC_URL", ""),
+			Region:          e.String("R2_REGION", "auto"),
+			Endpoint:        e.String("R2_ENDPOINT", ""),
+		},
+		StorageGC: StorageGCConfig{
+			Interval:            e.Duration("STORAGE_GC_INTERVAL", 24*time.Hour),
+			GracePeriod:         e.Duration("STORAGE_GC_GRACE_PERIOD", 48*time.Hour),
+			Quarantine:          e.OneOf("STORAGE_GC_MODE", "quarantine", "quarantine", "delete") == "quarantine",
+			QuarantineRetention: e.Duration("STORAGE_GC_QUARANTINE_RETENTION", 30*24*time.Hour),
+		},
+		Trash: TrashConfig{
+			Retention: e.Duration("TRASH_RETENTION", 30*24*time.Hour),
+		},
+		Redis: RedisConfig{
+			URL: e.String("REDIS_URL", ""),
+		},
+		RateLimit: RateLimitConfig{
+			Login:     e.String("RATE_LIMIT_LOGIN", "10/15m"),
+			Register:  e.String("RATE_LIMIT_REGISTER", "5/1h"),
+			Checkout:  e.String("RATE_LIMIT_CHECKOUT", "20/10m"),
+			API:       e.String("RATE_LIMIT_API", "300/1m"),
+			Broadcast: e.String("RATE_LIMIT_BROADCAST", "5/24h"),
+		},
+		CORS: CORSConfig{
+			APIOrigins:    e.List("CORS_API_ORIGINS", nil),
+			WidgetOrigins: e.List("CORS_WIDGET_ORIGINS", []string{"*"}),
+			MaxAge:        e.Duration("CORS_MAX_AGE", 2*time.Hour),
+		},
+		OAuth: OAuthConfig{
+			GoogleClientID:     e.String("GOOGLE_CLIENT_ID", ""),
+			GoogleClientSecret: e.String("GOOGLE_CLIENT_SECRET", ""),
+			AppleClientID:      e.String("APPLE_CLIENT_ID", ""),
+			AppleTeamID:        e.String("APPLE_TEAM_ID", ""),
+			AppleKeyID:         e.String("APPLE_KEY_ID", ""),
+			ApplePrivateKey:    e.PEM("APPLE_PRIVATE_KEY"),
+		},
+		Wallet: WalletConfig{
+			OrganizationName:          e.String("WALLET_ORGANIZATION_NAME", "Event Ticketing Platform"),
+			ApplePassTypeID:           e.String("APPLE_PASS_TYPE_ID", ""),
+			AppleTeamID:               e.String("APPLE_PASS_TEAM_ID", ""),
+			AppleCertificate:          e.PEM("APPLE_PASS_CERTIFICATE"),
+			ApplePrivateKey:           e.PEM("APPLE_PASS_PRIVATE_KEY"),
+			AppleWWDRCertificate:      e.PEM("APPLE_WWDR_CERTIFICATE"),
+			GoogleIssuerID:            e.String("GOOGLE_WALLET_ISSUER_ID", ""),
+			GoogleServiceAccountEmail: e.String("GOOGLE_WALLET_SERVICE_ACCOUNT_EMAIL", ""),
+			GooglePrivateKey:          e.PEM("GOOGLE_WALLET_PRIVATE_KEY"),
+		},
+		ContentModeration: ContentModerationConfig{
+			APIURL:          e.String("CONTENT_MODERATION_API_URL", ""),
+			APIKey:          e.String("CONTENT_MODERATION_API_KEY", ""),
+			Model:           e.String("CONTENT_MODERATION_MODEL", ""),
+			ImageScreening:  e.OneOf("CONTENT_MODERATION_IMAGES", "", "", "api", "heuristic"),
+			BlockedKeywords: e.List("CONTENT_MODERATION_BLOCKED_KEYWORDS", nil),
+		},
+		Geocoding: GeocodingConfig{
+			APIURL:       e.String("GEOCODING_API_URL", ""),
+			APIKey:       e.String("GEOCODING_API_KEY", ""),
+			UserAgent:    e.String("GEOCODING_USER_AGENT", "event-ticketing-platform"),
+			CountryCodes: e.List("GEOCODING_COUNTRY_CODES", nil),
+		},
+		PaymentHealth: PaymentHealthConfig{
+			Window:        e.Duration("PAYMENT_HEALTH_WINDOW", 15*time.Minute),
+			MinAttempts:   e.Int("PAYMENT_HEALTH_MIN_ATTEMPTS", 5),
+			DegradedBelow: e.Int("PAYMENT_HEALTH_DEGRADED_BELOW", 80),
+			AutoFailover:  e.Bool("PAYMENT_AUTO_FAILOVER", false),
+		},
+		Tickets: TicketsConfig{
+			AttendeeEditCutoff: e.Duration("TICKET_ATTENDEE_EDIT_CUTOFF", 24*time.Hour),
+		},
+		Uploads: UploadsConfig{
+			MaxImageWidth:      e.Int("UPLOAD_MAX_IMAGE_WIDTH", 8000),
+			MaxImageHeight:     e.Int("UPLOAD_MAX_IMAGE_HEIGHT", 8000),
+			MaxImageMegapixels: e.Int("UPLOAD_MAX_IMAGE_MEGAPIXELS", 40),
+			ClamAVAddress:      e.String("CLAMAV_ADDRESS", ""),
+			ClamAVTimeout:      e.Duration("CLAMAV_TIMEOUT", 30*time.Second),
+		},
+		Assets: AssetsConfig{
+			Dir:        e.String("ASSETS_DIR", "web/static"),
+			CDNBaseURL: strings.TrimRight(e.String("CDN_BASE_URL", ""), "/"),
+		},
+		Log: LogConfig{
+			Level:  e.OneOf("LOG_LEVEL", "info", "debug", "info", "warn", "warning", "error"),
+			Format: e.OneOf("LOG_FORMAT", defaultLogFormat(env), logging.FormatText, logging.FormatJSON),
 		},
 	}
 
+	problems := append(e.problems, config.validate()...)
+	if len(problems) > 0 {
+		return nil, &ValidationError{Problems: problems}
+	}
 	return config, nil
 }
 
-func parseDatabaseConfig() DatabaseConfig {
+// defaultLogFormat logs JSON in production for log collectors and text elsewhere
+func defaultLogFormat(env string) string {
+	if env == EnvProduction {
+		return "json"
+	}
+	return "text"
+}
+
+// parseDatabaseConfig reads the database settings. Connections require SSL in
+// production unless an SSL mode is set explicitly.
+func parseDatabaseConfig(e *envReader, production bool) DatabaseConfig {
+	defaultSSLMode := "disable"
+	if production {
+		defaultSSLMode = "require"
+	}
+
 	// Check if DATABASE_URL is provided
-	databaseURL := getEnv("DATABASE_URL", "")
+	databaseURL := e.String("DATABASE_URL", "")
 	if databaseURL != "" {
-		return parseDatabaseURL(databaseURL)
+		return parseDatabaseURL(databaseURL, defaultSSLMode)
 	}
 
 	// Fall back to individual environment variables
 	return DatabaseConfig{
-		Host:     getEnv("DB_HOST", "localhost"),
-		Port:     getEnvAsInt("DB_PORT", 5432),
-		User:     getEnv("DB_USER", "postgres"),
-		Password: getEnv("DB_PASSWORD", ""),
-		DBName:   getEnv("DB_NAME", "event_ticketing"),
-		SSLMode:  getEnv("DB_SSLMODE", "disable"),
+		Host:     e.String("DB_HOST", "localhost"),
+		Port:     e.Int("DB_PORT", 5432),
+		User:     e.String("DB_USER", "postgres"),
+		Password: e.String("DB_PASSWORD", ""),
+		DBName:   e.String("DB_NAME", "event_ticketing"),
+		SSLMode:  e.String("DB_SSLMODE", defaultSSLMode),
 	}
 }
 
-func parseDatabaseURL(databaseURL string) DatabaseConfig {
+func parseDatabaseURL(databaseURL, defaultSSLMode string) DatabaseConfig {
 	config := DatabaseConfig{
 		URL: databaseURL,
 	}
@@ -185,24 +477,8 @@ func parseDatabaseURL(databaseURL string) DatabaseConfig {
 	query := u.Query()
 	config.SSLMode = query.Get("sslmode")
 	if config.SSLMode == "" {
-		config.SSLMode = "disable"
+		config.SSLMode = defaultSSLMode
 	}
 
 	return config
 }
-
-func getEnv(key, defaultValue string) string {
-	if value := os.Getenv(key); value != "" {
-		return value
-	}
-	return defaultValue
-}
-
-func getEnvAsInt(key string, defaultValue int) int {
-	if value := os.Getenv(key); value != "" {
-		if intValue, err := strconv.Atoi(value); err == nil {
-			return intValue
-		}
-	}
-	return defaultValue
-}
\ No newline at end of file
@@ -0,0 +1,102 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// envReader reads typed settings from the environment. Unset variables take
+// their default; set variables that fail to parse are recorded as problems
+// instead of silently falling back, so Load can report them all at once.
+type envReader struct {
+	problems []string
+}
+
+func (e *envReader) invalid(key, value, expected string) {
+	e.problems = append(e.problems, fmt.Sprintf("%s=%q is not %s", key, value, expected))
+}
+
+// String returns the variable's value, or defaultValue when it is unset
+func (e *envReader) String(key, defaultValue string) string {
+	if value := strings.TrimSpace(os.Getenv(key)); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+// OneOf returns the variable's value when it is one of allowed, or
+// defaultValue when it is unset
+func (e *envReader) OneOf(key, defaultValue string, allowed ...string) string {
+	value := e.String(key, defaultValue)
+	if !slices.Contains(allowed, value) {
+		e.invalid(key, value, "one of "+strings.Join(allowed, ", "))
+		return defaultValue
+	}
+	return value
+}
+
+func (e *envReader) Int(key string, defaultValue int) int {
+	value := e.String(key, "")
+	if value == "" {
+		return defaultValue
+	}
+	intValue, err := strconv.Atoi(value)
+	if err != nil {
+		e.invalid(key, value, "a whole number")
+		return defaultValue
+	}
+	return intValue
+}
+
+func (e *envReader) Bool(key string, defaultValue bool) bool {
+	value := e.String(key, "")
+	if value == "" {
+		return defaultValue
+	}
+	boolValue, err := strconv.ParseBool(value)
+	if err != nil {
+		e.invalid(key, value, "true or false")
+		return defaultValue
+	}
+	return boolValue
+}
+
+// Duration parses values such as "90s", "15m" or "24h"
+func (e *envReader) Duration(key string, defaultValue time.Duration) time.Duration {
+	value := e.String(key, "")
+	if value == "" {
+		return defaultValue
+	}
+	duration, err := time.ParseDuration(value)
+	if err != nil {
+		e.invalid(key, value, "a duration such as 15m or 24h")
+		return defaultValue
+	}
+	return duration
+}
+
+// List parses a comma-separated list, ignoring empty entries
+func (e *envReader) List(key string, defaultValue []string) []string {
+	value := os.Getenv(key)
+	if strings.TrimSpace(value) == "" {
+		return defaultValue
+	}
+
+	var list []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			list = append(list, item)
+		}
+	}
+	return list
+}
+
+// PEM returns a PEM encoded value, converting literal "\n" sequences to
+// newlines so keys fit on one line of a .env file
+func (e *envReader) PEM(key string) string {
+	return strings.ReplaceAll(e.String(key, ""), "\\n", "\n")
+}
@@ -0,0 +1,49 @@
+package config
+
+import "log/slog"
+
+// LogValue reports the configuration at startup, e.g.
+// slog.Info("configuration loaded", "config", cfg). Secrets are never
+// included; integrations only report whether they are configured.
+func (c *Config) LogValue() slog.Value {
+	cache := "memory"
+	if c.Redis.URL != "" {
+		cache = "redis"
+	}
+	storageGCMode := "delete"
+	if c.StorageGC.Quarantine {
+		storageGCMode = "quarantine"
+	}
+
+	return slog.GroupValue(
+		slog.String("env", c.Server.Env),
+		slog.String("addr", c.Server.Host+":"+c.Server.Port),
+		slog.String("base_url", c.Server.BaseURL),
+		slog.Group("database",
+			"host", c.Database.Host,
+			"port", c.Database.Port,
+			"name", c.Database.DBName,
+			"sslmode", c.Database.SSLMode,
+		),
+		slog.Bool("secure_cookies", c.Session.SecureCookies),
+		slog.String("cache", cache),
+		slog.String("log_level", c.Log.Level),
+		slog.String("storage_gc_mode", storageGCMode),
+		slog.String("cdn_base_url", c.Assets.CDNBaseURL),
+		slog.String("email_provider", c.Email.Provider),
+		slog.String("email_fallback_provider", c.Email.FallbackProvider),
+		slog.Group("integrations",
+			"paystack", c.Paystack.SecretKey != "",
+			"pesapal", c.Pesapal.ConsumerKey != "",
+			"resend", c.Resend.APIKey != "",
+			"resend_webhooks", c.Resend.WebhookSecret != "",
+			"r2", c.R2.AccessKeyID != "",
+			"google_login", c.OAuth.GoogleClientID != "",
+			"apple_login", c.OAuth.AppleClientID != "",
+			"apple_wallet", c.Wallet.ApplePassTypeID != "",
+			"google_wallet", c.Wallet.GoogleIssuerID != "",
+			"content_moderation_api", c.ContentModeration.APIURL != "",
+			"clamav", c.Uploads.ClamAVAddress != "",
+		),
+	)
+}
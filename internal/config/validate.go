@@ -0,0 +1,124 @@
+package config
+
+import (
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strconv"
+	"strings"
+
+	"event-ticketing-platform/internal/ratelimit"
+)
+
+// minProductionSecretLength is the shortest session secret production accepts
+const minProductionSecretLength = 32
+
+// sslModes are the SSL modes PostgreSQL accepts, weakest first
+var sslModes = []string{"disable", "allow", "prefer", "require", "verify-ca", "verify-full"}
+
+// ValidationError lists every problem found in the configuration
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid configuration: " + strings.Join(e.Problems, "; ")
+}
+
+// validate checks the settings that parse but don't make sense together, and
+// the ones production can't run without
+func (c *Config) validate() []string {
+	var problems []string
+	add := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if port, err := strconv.Atoi(c.Server.Port); err != nil || port < 1 || port > 65535 {
+		add("PORT=%q is not a port number between 1 and 65535", c.Server.Port)
+	}
+	baseURL, err := url.Parse(c.Server.BaseURL)
+	if err != nil || (baseURL.Scheme != "http" && baseURL.Scheme != "https") || baseURL.Host == "" {
+		add("BASE_URL=%q is not an absolute http or https URL", c.Server.BaseURL)
+	}
+	if c.Server.ShutdownTimeout <= 0 {
+		add("SHUTDOWN_TIMEOUT must be positive")
+	}
+
+	if c.Database.URL == "" && (c.Database.Host == "" || c.Database.DBName == "") {
+		add("DATABASE_URL or DB_HOST and DB_NAME are required")
+	}
+	if !slices.Contains(sslModes, c.Database.SSLMode) {
+		add("database SSL mode %q is not one of %s", c.Database.SSLMode, strings.Join(sslModes, ", "))
+	}
+
+	rateLimits := map[string]string{
+		"RATE_LIMIT_LOGIN":     c.RateLimit.Login,
+		"RATE_LIMIT_REGISTER":  c.RateLimit.Register,
+		"RATE_LIMIT_CHECKOUT":  c.RateLimit.Checkout,
+		"RATE_LIMIT_API":       c.RateLimit.API,
+		"RATE_LIMIT_BROADCAST": c.RateLimit.Broadcast,
+	}
+	for _, key := range slices.Sorted(maps.Keys(rateLimits)) {
+		if _, err := ratelimit.ParseRule(key, rateLimits[key]); err != nil {
+			add("%v", err)
+		}
+	}
+
+	switch {
+	case c.Email.FallbackProvider == "":
+	case !slices.Contains(emailProviders, c.Email.FallbackProvider):
+		add("EMAIL_FALLBACK_PROVIDER=%q is not one of %s", c.Email.FallbackProvider, strings.Join(emailProviders, ", "))
+	case c.Email.FallbackProvider == c.Email.Provider:
+		add("EMAIL_FALLBACK_PROVIDER must differ from EMAIL_PROVIDER")
+	}
+	if c.Email.Provider == "ses" || c.Email.FallbackProvider == "ses" {
+		if c.SES.Region == "" || c.SES.AccessKeyID == "" || c.SES.SecretAccessKey == "" {
+			add("SES_REGION, SES_ACCESS_KEY_ID and SES_SECRET_ACCESS_KEY are required to send email through SES")
+		}
+	}
+
+	if c.PaymentHealth.DegradedBelow < 0 || c.PaymentHealth.DegradedBelow > 100 {
+		add("PAYMENT_HEALTH_DEGRADED_BELOW=%d is not a percentage between 0 and 100", c.PaymentHealth.DegradedBelow)
+	}
+	if c.PaymentHealth.MinAttempts < 1 {
+		add("PAYMENT_HEALTH_MIN_ATTEMPTS must be at least 1")
+	}
+
+	if c.Assets.CDNBaseURL != "" {
+		cdnURL, err := url.Parse(c.Assets.CDNBaseURL)
+		if err != nil || (cdnURL.Scheme != "http" && cdnURL.Scheme != "https") || cdnURL.Host == "" {
+			add("CDN_BASE_URL=%q is not an absolute http or https URL", c.Assets.CDNBaseURL)
+		}
+	}
+
+	if c.Uploads.MaxImageWidth < 1 || c.Uploads.MaxImageHeight < 1 || c.Uploads.MaxImageMegapixels < 1 {
+		add("UPLOAD_MAX_IMAGE_WIDTH, UPLOAD_MAX_IMAGE_HEIGHT and UPLOAD_MAX_IMAGE_MEGAPIXELS must be at least 1")
+	}
+	if c.Uploads.ClamAVAddress != "" && c.Uploads.ClamAVTimeout <= 0 {
+		add("CLAMAV_TIMEOUT must be positive")
+	}
+
+	if c.Server.Env != EnvProduction {
+		return problems
+	}
+
+	// Production only
+	switch {
+	case c.Session.Secret == placeholderSessionSecret:
+		add("SESSION_SECRET must be set in production")
+	case len(c.Session.Secret) < minProductionSecretLength:
+		add("SESSION_SECRET must be at least %d characters in production", minProductionSecretLength)
+	}
+	if baseURL != nil && baseURL.Scheme != "https" {
+		add("BASE_URL must use https in production")
+	}
+	if mode := slices.Index(sslModes, c.Database.SSLMode); mode >= 0 && mode < slices.Index(sslModes, "require") {
+		add("database SSL mode must be require, verify-ca or verify-full in production, not %q", c.Database.SSLMode)
+	}
+	if c.Paystack.SecretKey == "" {
+		add("PAYSTACK_SECRET_KEY must be set in production")
+	}
+
+	return problems
+}
@@ -60,6 +60,24 @@ func (db *DB) RunMigrations() error {
 	return migrator.RunMigrations()
 }
 
+// RollbackMigrations rolls back the last steps applied migrations
+func (db *DB) RollbackMigrations(steps int) error {
+	migrator := NewMigrator(db.DB)
+	return migrator.RollbackMigrations(steps)
+}
+
+// MigrateTo applies or rolls back migrations to the given version
+func (db *DB) MigrateTo(version int) error {
+	migrator := NewMigrator(db.DB)
+	return migrator.MigrateTo(version)
+}
+
+// ForceMigration clears a dirty migration after it has been fixed by hand
+func (db *DB) ForceMigration(version int) error {
+	migrator := NewMigrator(db.DB)
+	return migrator.Force(version)
+}
+
 // GetMigrationStatus shows the current migration status
 func (db *DB) GetMigrationStatus() error {
 	migrator := NewMigrator(db.DB)
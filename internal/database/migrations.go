@@ -3,26 +3,53 @@ package database
 import (
 	"database/sql"
 	"embed"
+	"errors"
 	"fmt"
+	"io/fs"
 	"sort"
 	"strings"
 )
 
+// Migrations are pairs of files named after their version, e.g.
+// "001_create_users_table.up.sql" and "001_create_users_table.down.sql". The
+// down file undoes the up file and may be left out when a migration can't be
+// rolled back.
+//
 //go:embed migrations/*.sql
 var migrationFiles embed.FS
 
+// noTransactionDirective, as the first line of a migration file, runs it
+// outside a transaction, for statements such as CREATE INDEX CONCURRENTLY
+const noTransactionDirective = "-- migrate:no-transaction"
+
 type Migration struct {
+	Version       int
+	Name          string
+	SQL           string // Applies the migration
+	DownSQL       string // Rolls the migration back; empty when it can't be
+	NoTransaction bool   // Run outside a transaction (see noTransactionDirective)
+}
+
+// DirtyError reports a migration that failed part way, leaving the schema in
+// an unknown state. Migrations refuse to run until it has been fixed by hand
+// and the migration forced to a clean state.
+type DirtyError struct {
 	Version int
 	Name    string
-	SQL     string
+}
+
+func (e *DirtyError) Error() string {
+	return fmt.Sprintf("migration %d (%s) did not finish and left the database dirty: check the schema, fix it by hand, then run migrate -force VERSION with the version it is now at", e.Version, e.Name)
 }
 
 type Migrator struct {
-	db *sql.DB
+	db    *sql.DB
+	files fs.FS
 }
 
 func NewMigrator(db *sql.DB) *Migrator {
-	return &Migrator{db: db}
+	files, _ := fs.Sub(migrationFiles, "migrations")
+	return &Migrator{db: db, files: files}
 }
 
 // CreateMigrationsTable creates the migrations tracking table
@@ -33,6 +60,8 @@ func (m *Migrator) CreateMigrationsTable() error {
 			name VARCHAR(255) NOT NULL,
 			applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
 		);
+		-- Set while a migration runs, and left set if it stops part way
+		ALTER TABLE schema_migrations ADD COLUMN IF NOT EXISTS dirty BOOLEAN NOT NULL DEFAULT FALSE;
 	`
 	_, err := m.db.Exec(query)
 	return err
@@ -41,13 +70,13 @@ func (m *Migrator) CreateMigrationsTable() error {
 // GetAppliedMigrations returns a list of applied migration versions
 func (m *Migrator) GetAppliedMigrations() (map[int]bool, error) {
 	applied := make(map[int]bool)
-	
+
 	rows, err := m.db.Query("SELECT version FROM schema_migrations ORDER BY version")
 	if err != nil {
 		return applied, err
 	}
 	defer rows.Close()
-	
+
 	for rows.Next() {
 		var version int
 		if err := rows.Scan(&version); err != nil {
@@ -55,135 +84,380 @@ func (m *Migrator) GetAppliedMigrations() (map[int]bool, error) {
 		}
 		applied[version] = true
 	}
-	
+
 	return applied, rows.Err()
 }
 
+// checkDirty returns a *DirtyError when a migration was left part way
+func (m *Migrator) checkDirty() error {
+	var dirty DirtyError
+	err := m.db.QueryRow("SELECT version, name FROM schema_migrations WHERE dirty ORDER BY version LIMIT 1").Scan(&dirty.Version, &dirty.Name)
+	if err == sql.ErrNoRows {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check for dirty migrations: %w", err)
+	}
+	return &dirty
+}
+
 // LoadMigrations loads all migration files from the embedded filesystem
 func (m *Migrator) LoadMigrations() ([]Migration, error) {
-	var migrations []Migration
-	
-	entries, err := migrationFiles.ReadDir("migrations")
+	return loadMigrations(m.files)
+}
+
+func loadMigrations(files fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(files, ".")
 	if err != nil {
 		return nil, fmt.Errorf("failed to read migrations directory: %w", err)
 	}
-	
+
+	byVersion := make(map[int]*Migration)
 	for _, entry := range entries {
-		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".sql") {
+		if entry.IsDir() {
 			continue
 		}
-		
-		// Parse version from filename (e.g., "001_create_users_table.sql")
-		parts := strings.SplitN(entry.Name(), "_", 2)
+
+		// Parse version and direction from the filename (e.g., "001_create_users_table.up.sql")
+		base, up := strings.CutSuffix(entry.Name(), ".up.sql")
+		if !up {
+			var down bool
+			if base, down = strings.CutSuffix(entry.Name(), ".down.sql"); !down {
+				continue
+			}
+		}
+		parts := strings.SplitN(base, "_", 2)
 		if len(parts) != 2 {
 			continue
 		}
-		
+
 		var version int
 		if _, err := fmt.Sscanf(parts[0], "%d", &version); err != nil {
 			continue
 		}
-		
+
 		// Read migration content
-		content, err := migrationFiles.ReadFile("migrations/" + entry.Name())
+		content, err := fs.ReadFile(files, entry.Name())
 		if err != nil {
 			return nil, fmt.Errorf("failed to read migration %s: %w", entry.Name(), err)
 		}
-		
-		name := strings.TrimSuffix(parts[1], ".sql")
-		migrations = append(migrations, Migration{
-			Version: version,
-			Name:    name,
-			SQL:     string(content),
-		})
+
+		migration, ok := byVersion[version]
+		if !ok {
+			migration = &Migration{Version: version, Name: parts[1]}
+			byVersion[version] = migration
+		}
+		if migration.Name != parts[1] {
+			return nil, fmt.Errorf("migration %d has files named both %s and %s", version, migration.Name, parts[1])
+		}
+
+		sql := string(content)
+		if up {
+			migration.SQL = sql
+			migration.NoTransaction = strings.HasPrefix(strings.TrimSpace(sql), noTransactionDirective)
+		} else {
+			migration.DownSQL = sql
+		}
 	}
-	
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, migration := range byVersion {
+		if migration.SQL == "" {
+			return nil, fmt.Errorf("migration %d (%s) has a down file but no up file", migration.Version, migration.Name)
+		}
+		migrations = append(migrations, *migration)
+	}
+
 	// Sort migrations by version
 	sort.Slice(migrations, func(i, j int) bool {
 		return migrations[i].Version < migrations[j].Version
 	})
-	
+
 	return migrations, nil
 }
 
 // RunMigrations executes all pending migrations
 func (m *Migrator) RunMigrations() error {
+	return m.migrate(func(migrations []Migration, applied map[int]bool) ([]Migration, []Migration, error) {
+		var pending []Migration
+		for _, migration := range migrations {
+			if !applied[migration.Version] {
+				pending = append(pending, migration)
+			}
+		}
+		return pending, nil, nil
+	})
+}
+
+// RollbackMigrations rolls back the last steps applied migrations, newest first
+func (m *Migrator) RollbackMigrations(steps int) error {
+	return m.migrate(func(migrations []Migration, applied map[int]bool) ([]Migration, []Migration, error) {
+		down, err := planRollback(migrations, applied, steps)
+		return nil, down, err
+	})
+}
+
+// MigrateTo applies or rolls back migrations until exactly the migrations up
+// to version are applied. Version 0 rolls every migration back.
+func (m *Migrator) MigrateTo(version int) error {
+	return m.migrate(func(migrations []Migration, applied map[int]bool) ([]Migration, []Migration, error) {
+		return planTo(migrations, applied, version)
+	})
+}
+
+// Force clears a dirty migration after its schema changes have been fixed by
+// hand. The dirty migration is recorded as applied when version is at or
+// after it, and as not applied otherwise.
+func (m *Migrator) Force(version int) error {
+	if err := m.CreateMigrationsTable(); err != nil {
+		return fmt.Errorf("failed to create migrations table: %w", err)
+	}
+
+	var dirty *DirtyError
+	err := m.checkDirty()
+	if err == nil {
+		return errors.New("no migration is dirty")
+	}
+	if !errors.As(err, &dirty) {
+		return err
+	}
+
+	if version >= dirty.Version {
+		_, err = m.db.Exec("UPDATE schema_migrations SET dirty = FALSE WHERE version = $1", dirty.Version)
+		if err == nil {
+			fmt.Printf("Migration %d marked as applied\n", dirty.Version)
+		}
+		return err
+	}
+
+	_, err = m.db.Exec("DELETE FROM schema_migrations WHERE version = $1", dirty.Version)
+	if err == nil {
+		fmt.Printf("Migration %d marked as not applied\n", dirty.Version)
+	}
+	return err
+}
+
+// migrate plans which migrations to apply and roll back against the current
+// state, then runs them in order: rollbacks newest first, then applies
+// oldest first. Nothing runs while a migration is dirty.
+func (m *Migrator) migrate(plan func(migrations []Migration, applied map[int]bool) (up, down []Migration, err error)) error {
 	// Create migrations table if it doesn't exist
 	if err := m.CreateMigrationsTable(); err != nil {
 		return fmt.Errorf("failed to create migrations table: %w", err)
 	}
-	
+	if err := m.checkDirty(); err != nil {
+		return err
+	}
+
 	// Get applied migrations
 	applied, err := m.GetAppliedMigrations()
 	if err != nil {
 		return fmt.Errorf("failed to get applied migrations: %w", err)
 	}
-	
+
 	// Load all migrations
 	migrations, err := m.LoadMigrations()
 	if err != nil {
 		return fmt.Errorf("failed to load migrations: %w", err)
 	}
-	
-	// Run pending migrations
-	for _, migration := range migrations {
-		if applied[migration.Version] {
-			continue // Skip already applied migrations
+
+	up, down, err := plan(migrations, applied)
+	if err != nil {
+		return err
+	}
+
+	for _, migration := range down {
+		fmt.Printf("Rolling back migration %d: %s\n", migration.Version, migration.Name)
+		if err := m.run(migration, false); err != nil {
+			return err
 		}
-		
+		fmt.Printf("Migration %d rolled back successfully\n", migration.Version)
+	}
+	for _, migration := range up {
 		fmt.Printf("Running migration %d: %s\n", migration.Version, migration.Name)
-		
-		// Start transaction
-		tx, err := m.db.Begin()
-		if err != nil {
-			return fmt.Errorf("failed to start transaction for migration %d: %w", migration.Version, err)
-		}
-		
-		// Execute migration SQL
-		if _, err := tx.Exec(migration.SQL); err != nil {
-			tx.Rollback()
-			return fmt.Errorf("failed to execute migration %d: %w", migration.Version, err)
-		}
-		
-		// Record migration as applied
-		if _, err := tx.Exec("INSERT INTO schema_migrations (version, name) VALUES ($1, $2)", migration.Version, migration.Name); err != nil {
-			tx.Rollback()
+		if err := m.run(migration, true); err != nil {
+			return err
+		}
+		fmt.Printf("Migration %d completed successfully\n", migration.Version)
+	}
+
+	return nil
+}
+
+// run applies (up) or rolls back a migration. The migration is marked dirty
+// while it runs. Migrations in a transaction are clean again if they fail,
+// since nothing they did is kept; ones outside a transaction stay dirty.
+func (m *Migrator) run(migration Migration, up bool) error {
+	// An applied migration is recorded as a clean row and a rolled back one
+	// has no row; undoMark restores the row as it was before running
+	var query, finish, undoMark string
+	var err error
+	if up {
+		query = migration.SQL
+		finish = "UPDATE schema_migrations SET dirty = FALSE WHERE version = $1"
+		undoMark = "DELETE FROM schema_migrations WHERE version = $1"
+		_, err = m.db.Exec("INSERT INTO schema_migrations (version, name, dirty) VALUES ($1, $2, TRUE)", migration.Version, migration.Name)
+	} else {
+		query = migration.DownSQL
+		finish = "DELETE FROM schema_migrations WHERE version = $1"
+		undoMark = "UPDATE schema_migrations SET dirty = FALSE WHERE version = $1"
+		_, err = m.db.Exec("UPDATE schema_migrations SET dirty = TRUE WHERE version = $1", migration.Version)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+	}
+
+	if migration.NoTransaction {
+		if _, err := m.db.Exec(query); err != nil {
+			return fmt.Errorf("failed to execute migration %d, leaving it dirty: %w", migration.Version, err)
+		}
+		if _, err := m.db.Exec(finish, migration.Version); err != nil {
 			return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
 		}
-		
-		// Commit transaction
-		if err := tx.Commit(); err != nil {
-			return fmt.Errorf("failed to commit migration %d: %w", migration.Version, err)
+		return nil
+	}
+
+	// Start transaction
+	tx, err := m.db.Begin()
+	if err != nil {
+		m.db.Exec(undoMark, migration.Version)
+		return fmt.Errorf("failed to start transaction for migration %d: %w", migration.Version, err)
+	}
+
+	// Execute migration SQL and record the result
+	if _, err := tx.Exec(query); err != nil {
+		tx.Rollback()
+		m.db.Exec(undoMark, migration.Version)
+		return fmt.Errorf("failed to execute migration %d: %w", migration.Version, err)
+	}
+	if _, err := tx.Exec(finish, migration.Version); err != nil {
+		tx.Rollback()
+		m.db.Exec(undoMark, migration.Version)
+		return fmt.Errorf("failed to record migration %d: %w", migration.Version, err)
+	}
+
+	// Commit transaction
+	if err := tx.Commit(); err != nil {
+		m.db.Exec(undoMark, migration.Version)
+		return fmt.Errorf("failed to commit migration %d: %w", migration.Version, err)
+	}
+
+	return nil
+}
+
+// planTo returns the migrations to apply, oldest first, and to roll back,
+// newest first, so that exactly the migrations up to target are applied
+func planTo(migrations []Migration, applied map[int]bool, target int) (up, down []Migration, err error) {
+	if target < 0 || (target > 0 && !hasVersion(migrations, target)) {
+		return nil, nil, fmt.Errorf("no migration has version %d", target)
+	}
+	if err := checkKnown(migrations, applied, target); err != nil {
+		return nil, nil, err
+	}
+
+	for _, migration := range migrations {
+		if migration.Version <= target && !applied[migration.Version] {
+			up = append(up, migration)
+		}
+	}
+	for i := len(migrations) - 1; i >= 0; i-- {
+		if migrations[i].Version > target && applied[migrations[i].Version] {
+			down = append(down, migrations[i])
+		}
+	}
+	return up, down, checkReversible(down)
+}
+
+// planRollback returns the last steps applied migrations, newest first
+func planRollback(migrations []Migration, applied map[int]bool, steps int) ([]Migration, error) {
+	if steps < 1 {
+		return nil, fmt.Errorf("steps must be at least 1")
+	}
+	if err := checkKnown(migrations, applied, 0); err != nil {
+		return nil, err
+	}
+
+	var down []Migration
+	for i := len(migrations) - 1; i >= 0 && len(down) < steps; i-- {
+		if applied[migrations[i].Version] {
+			down = append(down, migrations[i])
+		}
+	}
+	return down, checkReversible(down)
+}
+
+func hasVersion(migrations []Migration, version int) bool {
+	for _, migration := range migrations {
+		if migration.Version == version {
+			return true
+		}
+	}
+	return false
+}
+
+// checkKnown rejects rolling back past applied migrations this build doesn't
+// have, such as ones applied by a newer release
+func checkKnown(migrations []Migration, applied map[int]bool, target int) error {
+	for version := range applied {
+		if version > target && !hasVersion(migrations, version) {
+			return fmt.Errorf("migration %d is applied but has no migration file; was it applied by a newer release?", version)
+		}
+	}
+	return nil
+}
+
+func checkReversible(down []Migration) error {
+	for _, migration := range down {
+		if migration.DownSQL == "" {
+			return fmt.Errorf("migration %d (%s) has no down migration and can't be rolled back", migration.Version, migration.Name)
 		}
-		
-		fmt.Printf("Migration %d completed successfully\n", migration.Version)
 	}
-	
 	return nil
 }
 
 // GetMigrationStatus returns the current migration status
 func (m *Migrator) GetMigrationStatus() error {
+	if err := m.CreateMigrationsTable(); err != nil {
+		return fmt.Errorf("failed to create migrations table: %w", err)
+	}
+
 	applied, err := m.GetAppliedMigrations()
 	if err != nil {
 		return err
 	}
-	
+
 	migrations, err := m.LoadMigrations()
 	if err != nil {
 		return err
 	}
-	
+
+	dirtyVersion := 0
+	var dirty *DirtyError
+	if err := m.checkDirty(); errors.As(err, &dirty) {
+		dirtyVersion = dirty.Version
+	} else if err != nil {
+		return err
+	}
+
 	fmt.Println("Migration Status:")
 	fmt.Println("================")
-	
+
 	for _, migration := range migrations {
 		status := "PENDING"
-		if applied[migration.Version] {
+		if migration.Version == dirtyVersion {
+			status = "DIRTY"
+		} else if applied[migration.Version] {
 			status = "APPLIED"
 		}
+		if migration.DownSQL == "" {
+			status += ", NO DOWN"
+		}
 		fmt.Printf("%d: %s [%s]\n", migration.Version, migration.Name, status)
 	}
-	
+
+	if dirtyVersion != 0 {
+		fmt.Printf("\nMigration %d did not finish. Fix the schema by hand, then run migrate -force VERSION.\n", dirtyVersion)
+	}
+
 	return nil
-}
\ No newline at end of file
+}
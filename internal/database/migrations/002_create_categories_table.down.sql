@@ -0,0 +1,2 @@
+-- Drop categories table
+DROP TABLE IF EXISTS categories;
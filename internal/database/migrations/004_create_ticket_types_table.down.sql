@@ -0,0 +1,2 @@
+-- Drop ticket types table
+DROP TABLE IF EXISTS ticket_types;
@@ -0,0 +1,2 @@
+-- Drop orders table
+DROP TABLE IF EXISTS orders;
@@ -0,0 +1,2 @@
+-- Drop tickets table
+DROP TABLE IF EXISTS tickets;
@@ -0,0 +1,5 @@
+-- Remove the seeded categories that no event uses
+DELETE FROM categories
+WHERE slug IN ('music', 'sports', 'arts-culture', 'business', 'food-drink', 'technology',
+               'health-wellness', 'education', 'community', 'entertainment')
+  AND NOT EXISTS (SELECT 1 FROM events WHERE events.category_id = categories.id);
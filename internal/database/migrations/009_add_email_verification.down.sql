@@ -0,0 +1,7 @@
+-- Remove email verification from users
+DROP INDEX IF EXISTS idx_users_verification_token;
+
+ALTER TABLE users
+DROP COLUMN IF EXISTS email_verified,
+DROP COLUMN IF EXISTS email_verified_at,
+DROP COLUMN IF EXISTS verification_token;
@@ -0,0 +1,7 @@
+-- Remove password reset tokens from users
+DROP INDEX IF EXISTS idx_users_password_reset_token;
+DROP INDEX IF EXISTS idx_users_password_reset_expires;
+
+ALTER TABLE users
+DROP COLUMN IF EXISTS password_reset_token,
+DROP COLUMN IF EXISTS password_reset_expires;
@@ -0,0 +1,16 @@
+-- Remove image metadata from events
+ALTER TABLE events DROP CONSTRAINT IF EXISTS chk_image_metadata_consistency;
+ALTER TABLE events DROP CONSTRAINT IF EXISTS chk_image_format;
+ALTER TABLE events DROP CONSTRAINT IF EXISTS chk_image_dimensions;
+ALTER TABLE events DROP CONSTRAINT IF EXISTS chk_image_size;
+
+DROP INDEX IF EXISTS idx_events_image_key;
+DROP INDEX IF EXISTS idx_events_has_image;
+
+ALTER TABLE events
+DROP COLUMN IF EXISTS image_key,
+DROP COLUMN IF EXISTS image_size,
+DROP COLUMN IF EXISTS image_format,
+DROP COLUMN IF EXISTS image_width,
+DROP COLUMN IF EXISTS image_height,
+DROP COLUMN IF EXISTS image_uploaded_at;
@@ -0,0 +1,3 @@
+-- Remove is_active from users
+DROP INDEX IF EXISTS idx_users_is_active;
+ALTER TABLE users DROP COLUMN IF EXISTS is_active;
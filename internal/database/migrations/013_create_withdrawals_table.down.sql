@@ -0,0 +1,2 @@
+-- Drop withdrawals table
+DROP TABLE IF EXISTS withdrawals;
@@ -0,0 +1,9 @@
+-- Remove event moderation columns and the admin audit log
+DROP TABLE IF EXISTS admin_audit_log;
+
+DROP INDEX IF EXISTS idx_events_status_reviewed;
+DROP INDEX IF EXISTS idx_events_reviewed_by;
+
+ALTER TABLE events DROP COLUMN IF EXISTS reviewed_at;
+ALTER TABLE events DROP COLUMN IF EXISTS reviewed_by;
+ALTER TABLE events DROP COLUMN IF EXISTS rejection_reason;
@@ -0,0 +1,4 @@
+-- Remove the withdrawal columns the application reads
+ALTER TABLE withdrawals DROP COLUMN IF EXISTS reason;
+ALTER TABLE withdrawals DROP COLUMN IF EXISTS bank_details;
+ALTER TABLE withdrawals DROP COLUMN IF EXISTS notes;
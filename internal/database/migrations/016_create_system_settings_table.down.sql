@@ -0,0 +1,2 @@
+-- Drop system_settings table
+DROP TABLE IF EXISTS system_settings;
@@ -0,0 +1,17 @@
+-- Remove Authboss fields from users table
+DROP INDEX IF EXISTS idx_users_confirmed_at;
+DROP INDEX IF EXISTS idx_users_confirm_selector;
+DROP INDEX IF EXISTS idx_users_locked_until;
+DROP INDEX IF EXISTS idx_users_recover_selector;
+
+ALTER TABLE users
+DROP COLUMN IF EXISTS confirmed_at,
+DROP COLUMN IF EXISTS confirm_selector,
+DROP COLUMN IF EXISTS confirm_verifier,
+DROP COLUMN IF EXISTS locked_until,
+DROP COLUMN IF EXISTS attempt_count,
+DROP COLUMN IF EXISTS last_attempt,
+DROP COLUMN IF EXISTS password_changed_at,
+DROP COLUMN IF EXISTS recover_selector,
+DROP COLUMN IF EXISTS recover_verifier,
+DROP COLUMN IF EXISTS recover_token_expires;
@@ -0,0 +1,2 @@
+-- Drop Authboss remember tokens table
+DROP TABLE IF EXISTS authboss_remember_tokens;
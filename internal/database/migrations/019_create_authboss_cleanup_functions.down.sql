@@ -0,0 +1,5 @@
+-- Drop Authboss token cleanup functions
+DROP FUNCTION IF EXISTS cleanup_expired_remember_tokens();
+DROP FUNCTION IF EXISTS cleanup_expired_recovery_tokens();
+DROP FUNCTION IF EXISTS unlock_expired_accounts();
+DROP FUNCTION IF EXISTS cleanup_old_confirmation_tokens();
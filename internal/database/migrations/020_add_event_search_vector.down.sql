@@ -0,0 +1,5 @@
+-- Remove full-text search support for events. The pg_trgm extension is
+-- left installed, since other database objects may rely on it.
+DROP INDEX IF EXISTS idx_events_title_trgm;
+DROP INDEX IF EXISTS idx_events_search_vector;
+ALTER TABLE events DROP COLUMN IF EXISTS search_vector;
@@ -0,0 +1,16 @@
+-- Add full-text search support for events
+CREATE EXTENSION IF NOT EXISTS pg_trgm;
+
+-- Weighted search document: title ranks above description, which ranks above location
+ALTER TABLE events
+ADD COLUMN search_vector tsvector GENERATED ALWAYS AS (
+    setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(description, '')), 'B') ||
+    setweight(to_tsvector('english', coalesce(location, '')), 'C')
+) STORED;
+
+-- GIN index for tsvector matching
+CREATE INDEX idx_events_search_vector ON events USING GIN (search_vector);
+
+-- Trigram index for typo-tolerant title matching
+CREATE INDEX idx_events_title_trgm ON events USING GIN (title gin_trgm_ops);
\ No newline at end of file
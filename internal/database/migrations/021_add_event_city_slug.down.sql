@@ -0,0 +1,3 @@
+-- Remove the derived city from events
+DROP INDEX IF EXISTS idx_events_city_slug_published;
+ALTER TABLE events DROP COLUMN IF EXISTS city_slug;
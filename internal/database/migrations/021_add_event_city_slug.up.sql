@@ -0,0 +1,8 @@
+-- Normalized city derived from the last comma-separated part of the location,
+-- used for city landing pages such as /events/nairobi
+ALTER TABLE events
+ADD COLUMN city_slug VARCHAR(255) GENERATED ALWAYS AS (
+    trim(both '-' from regexp_replace(lower(trim(regexp_replace(location, '^.*,', ''))), '[^a-z0-9]+', '-', 'g'))
+) STORED;
+
+CREATE INDEX idx_events_city_slug_published ON events(city_slug, start_date) WHERE status = 'published';
@@ -0,0 +1,2 @@
+-- Partial index backing date-range browsing of published events (today, this weekend, ...)
+CREATE INDEX IF NOT EXISTS idx_events_published_start_date ON events(start_date) WHERE status = 'published';
@@ -0,0 +1,4 @@
+-- Drop organizer notifications
+DROP TABLE IF EXISTS event_milestones;
+DROP TABLE IF EXISTS notification_preferences;
+DROP TABLE IF EXISTS notifications;
@@ -0,0 +1,35 @@
+-- Organizer in-app notifications
+CREATE TABLE IF NOT EXISTS notifications (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    event_id INTEGER REFERENCES events(id) ON DELETE CASCADE,
+    type VARCHAR(50) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    message TEXT NOT NULL,
+    link VARCHAR(500) NOT NULL DEFAULT '',
+    read_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX IF NOT EXISTS idx_notifications_user_created ON notifications(user_id, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_notifications_user_unread ON notifications(user_id) WHERE read_at IS NULL;
+
+-- Per-organizer notification toggles; organizers without a row get the defaults
+CREATE TABLE IF NOT EXISTS notification_preferences (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    email_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    in_app_enabled BOOLEAN NOT NULL DEFAULT TRUE,
+    first_sale BOOLEAN NOT NULL DEFAULT TRUE,
+    half_sold BOOLEAN NOT NULL DEFAULT TRUE,
+    sold_out BOOLEAN NOT NULL DEFAULT TRUE,
+    sale_ending BOOLEAN NOT NULL DEFAULT TRUE,
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW()
+);
+
+-- Sales milestones already reached, so each milestone is only announced once per event
+CREATE TABLE IF NOT EXISTS event_milestones (
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    milestone VARCHAR(50) NOT NULL,
+    reached_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT NOW(),
+    PRIMARY KEY (event_id, milestone)
+);
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- Require an admin actor on audit log entries again. Entries recorded without
+-- one, such as rate limits tripping, are removed.
+DELETE FROM admin_audit_log WHERE admin_user_id IS NULL;
+ALTER TABLE admin_audit_log ALTER COLUMN admin_user_id SET NOT NULL;
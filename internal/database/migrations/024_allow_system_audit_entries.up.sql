@@ -0,0 +1,2 @@
+-- Allow audit log entries for security events that have no admin actor, such as rate limits tripping
+ALTER TABLE admin_audit_log ALTER COLUMN admin_user_id DROP NOT NULL;
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- Drop ticket scan history
+DROP TABLE IF EXISTS ticket_scans;
@@ -0,0 +1,16 @@
+-- Record every ticket scan attempt for post-event dispute resolution and security review
+CREATE TABLE IF NOT EXISTS ticket_scans (
+    id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    ticket_id INTEGER REFERENCES tickets(id) ON DELETE SET NULL,
+    qr_code VARCHAR(255) NOT NULL,
+    result VARCHAR(20) NOT NULL CHECK (result IN ('accepted', 'duplicate', 'invalid', 'refunded')),
+    reason TEXT NOT NULL DEFAULT '',
+    gate VARCHAR(100) NOT NULL DEFAULT '',
+    device VARCHAR(255) NOT NULL DEFAULT '',
+    staff_user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    scanned_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_scans_event ON ticket_scans(event_id, scanned_at);
+CREATE INDEX IF NOT EXISTS idx_ticket_scans_ticket ON ticket_scans(ticket_id);
\ No newline at end of file
@@ -0,0 +1,7 @@
+-- Remove two-factor authentication
+ALTER TABLE system_settings
+    DROP COLUMN IF EXISTS require_2fa_admins,
+    DROP COLUMN IF EXISTS require_2fa_organizers;
+
+DROP TABLE IF EXISTS user_backup_codes;
+DROP TABLE IF EXISTS user_two_factor;
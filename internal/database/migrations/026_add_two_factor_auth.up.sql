@@ -0,0 +1,23 @@
+-- TOTP two-factor authentication secrets and hashed one-time backup codes
+CREATE TABLE IF NOT EXISTS user_two_factor (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    secret VARCHAR(64) NOT NULL,
+    enabled_at TIMESTAMP WITH TIME ZONE,
+    last_used_step BIGINT NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS user_backup_codes (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    code_hash VARCHAR(64) NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_user_backup_codes_user ON user_backup_codes(user_id, code_hash);
+
+-- Allow admins to require two-factor authentication for privileged roles
+ALTER TABLE system_settings
+    ADD COLUMN IF NOT EXISTS require_2fa_admins BOOLEAN NOT NULL DEFAULT false,
+    ADD COLUMN IF NOT EXISTS require_2fa_organizers BOOLEAN NOT NULL DEFAULT false;
\ No newline at end of file
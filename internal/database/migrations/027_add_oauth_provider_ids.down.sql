@@ -0,0 +1,7 @@
+-- Remove social login provider IDs from users
+DROP INDEX IF EXISTS idx_users_google_id;
+DROP INDEX IF EXISTS idx_users_apple_id;
+
+ALTER TABLE users
+    DROP COLUMN IF EXISTS google_id,
+    DROP COLUMN IF EXISTS apple_id;
@@ -0,0 +1,7 @@
+-- Store social login provider IDs so OAuth accounts can be linked to users
+ALTER TABLE users
+    ADD COLUMN IF NOT EXISTS google_id VARCHAR(255),
+    ADD COLUMN IF NOT EXISTS apple_id VARCHAR(255);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_google_id ON users(google_id) WHERE google_id IS NOT NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS idx_users_apple_id ON users(apple_id) WHERE apple_id IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1 @@
+ALTER TABLE events DROP COLUMN IF EXISTS image_alt_text;
@@ -0,0 +1,2 @@
+-- Alt text describing the event image for screen readers
+ALTER TABLE events ADD COLUMN IF NOT EXISTS image_alt_text TEXT NOT NULL DEFAULT '';
\ No newline at end of file
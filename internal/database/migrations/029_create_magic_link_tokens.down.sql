@@ -0,0 +1,2 @@
+-- Drop passwordless login links
+DROP TABLE IF EXISTS magic_link_tokens;
@@ -0,0 +1,2 @@
+-- Drop storage garbage collection run history
+DROP TABLE IF EXISTS storage_gc_runs;
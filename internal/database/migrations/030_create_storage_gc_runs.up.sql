@@ -0,0 +1,16 @@
+-- Record storage garbage collection runs so admins can see reclaimed space
+CREATE TABLE IF NOT EXISTS storage_gc_runs (
+    id SERIAL PRIMARY KEY,
+    mode VARCHAR(20) NOT NULL CHECK (mode IN ('delete', 'quarantine')),
+    scanned_objects INTEGER NOT NULL DEFAULT 0,
+    orphaned_objects INTEGER NOT NULL DEFAULT 0,
+    skipped_recent INTEGER NOT NULL DEFAULT 0,
+    quarantined_objects INTEGER NOT NULL DEFAULT 0,
+    deleted_objects INTEGER NOT NULL DEFAULT 0,
+    reclaimed_bytes BIGINT NOT NULL DEFAULT 0,
+    errors JSONB NOT NULL DEFAULT '[]',
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    completed_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_storage_gc_runs_completed ON storage_gc_runs(completed_at DESC);
\ No newline at end of file
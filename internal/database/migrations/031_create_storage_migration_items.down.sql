@@ -0,0 +1,2 @@
+-- Drop storage migration progress
+DROP TABLE IF EXISTS storage_migration_items;
@@ -0,0 +1,12 @@
+-- Track local uploads copied to R2 so the storage migration can resume where it stopped
+CREATE TABLE IF NOT EXISTS storage_migration_items (
+    key VARCHAR(1024) PRIMARY KEY,
+    size BIGINT NOT NULL DEFAULT 0,
+    checksum VARCHAR(64) NOT NULL DEFAULT '',
+    destination_url TEXT NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL CHECK (status IN ('verified', 'failed')),
+    error TEXT NOT NULL DEFAULT '',
+    migrated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_storage_migration_items_status ON storage_migration_items(status);
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- Drop attendee reminder settings and deliveries
+DROP TABLE IF EXISTS event_reminder_deliveries;
+DROP TABLE IF EXISTS event_reminder_settings;
@@ -0,0 +1,22 @@
+-- Per-event attendee reminder settings. Events without a row use the defaults.
+CREATE TABLE IF NOT EXISTS event_reminder_settings (
+    event_id INTEGER PRIMARY KEY REFERENCES events(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT true,
+    remind_7d BOOLEAN NOT NULL DEFAULT true,
+    remind_24h BOOLEAN NOT NULL DEFAULT true,
+    remind_2h BOOLEAN NOT NULL DEFAULT true,
+    message TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- One row per order and reminder so each reminder is only emailed once
+CREATE TABLE IF NOT EXISTS event_reminder_deliveries (
+    id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    reminder VARCHAR(10) NOT NULL CHECK (reminder IN ('7d', '24h', '2h')),
+    sent_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (order_id, reminder)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_reminder_deliveries_event ON event_reminder_deliveries(event_id);
\ No newline at end of file
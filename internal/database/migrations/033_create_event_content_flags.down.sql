@@ -0,0 +1,2 @@
+-- Drop automatic content screening results
+DROP TABLE IF EXISTS event_content_flags;
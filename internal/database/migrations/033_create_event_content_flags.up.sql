@@ -0,0 +1,10 @@
+-- Results of automatic spam and phishing screening that held an event back for review
+CREATE TABLE IF NOT EXISTS event_content_flags (
+    id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    score INTEGER NOT NULL DEFAULT 0,
+    reasons JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_content_flags_event ON event_content_flags(event_id, created_at);
\ No newline at end of file
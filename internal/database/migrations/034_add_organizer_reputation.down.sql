@@ -0,0 +1,7 @@
+-- Remove organizer reputation settings and event reports
+ALTER TABLE system_settings
+    DROP COLUMN IF EXISTS reputation_fast_track_enabled,
+    DROP COLUMN IF EXISTS reputation_auto_publish_score,
+    DROP COLUMN IF EXISTS reputation_min_approved_events;
+
+DROP TABLE IF EXISTS event_reports;
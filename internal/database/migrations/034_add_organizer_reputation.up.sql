@@ -0,0 +1,18 @@
+-- Attendee reports of events, counted against the organizer's reputation
+CREATE TABLE IF NOT EXISTS event_reports (
+    id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    reporter_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason VARCHAR(30) NOT NULL CHECK (reason IN ('scam', 'misleading', 'inappropriate', 'cancelled', 'other')),
+    details TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (event_id, reporter_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_reports_event ON event_reports(event_id);
+
+-- Let organizers with a good track record publish without manual moderation
+ALTER TABLE system_settings
+    ADD COLUMN IF NOT EXISTS reputation_fast_track_enabled BOOLEAN NOT NULL DEFAULT true,
+    ADD COLUMN IF NOT EXISTS reputation_auto_publish_score INTEGER NOT NULL DEFAULT 70,
+    ADD COLUMN IF NOT EXISTS reputation_min_approved_events INTEGER NOT NULL DEFAULT 3;
\ No newline at end of file
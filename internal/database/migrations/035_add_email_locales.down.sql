@@ -0,0 +1,4 @@
+-- Remove preferred email languages
+ALTER TABLE users DROP COLUMN IF EXISTS locale;
+ALTER TABLE orders DROP COLUMN IF EXISTS locale;
+ALTER TABLE events DROP COLUMN IF EXISTS locale;
@@ -0,0 +1,7 @@
+-- Preferred language for emails: captured per order at checkout, set on the
+-- buyer's account, and defaulted per event by the organizer
+ALTER TABLE users ADD COLUMN IF NOT EXISTS locale VARCHAR(10) NOT NULL DEFAULT '';
+
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS locale VARCHAR(10) NOT NULL DEFAULT '';
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS locale VARCHAR(10) NOT NULL DEFAULT 'en';
\ No newline at end of file
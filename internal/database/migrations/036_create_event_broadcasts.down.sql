@@ -0,0 +1,3 @@
+-- Drop organizer broadcasts to ticket holders
+DROP TABLE IF EXISTS event_broadcast_deliveries;
+DROP TABLE IF EXISTS event_broadcasts;
@@ -0,0 +1,26 @@
+-- Emails organizers send to all ticket holders of an event, queued and sent in batches
+CREATE TABLE IF NOT EXISTS event_broadcasts (
+    id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    organizer_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    subject VARCHAR(150) NOT NULL,
+    message TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'queued' CHECK (status IN ('queued', 'sending', 'sent')),
+    recipient_count INTEGER NOT NULL DEFAULT 0,
+    sent_count INTEGER NOT NULL DEFAULT 0,
+    failed_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_broadcasts_event ON event_broadcasts(event_id, created_at);
+CREATE INDEX IF NOT EXISTS idx_event_broadcasts_pending ON event_broadcasts(status) WHERE status <> 'sent';
+
+-- One row per order a broadcast was sent to, so a broadcast interrupted part
+-- way through resumes without emailing anyone twice
+CREATE TABLE IF NOT EXISTS event_broadcast_deliveries (
+    broadcast_id INTEGER NOT NULL REFERENCES event_broadcasts(id) ON DELETE CASCADE,
+    order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    sent_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (broadcast_id, order_id)
+);
\ No newline at end of file
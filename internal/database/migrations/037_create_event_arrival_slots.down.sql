@@ -0,0 +1,5 @@
+-- Remove arrival windows
+DROP INDEX IF EXISTS idx_orders_arrival_slot;
+ALTER TABLE orders DROP COLUMN IF EXISTS arrival_slot_id;
+
+DROP TABLE IF EXISTS event_arrival_slots;
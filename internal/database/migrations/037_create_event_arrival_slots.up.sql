@@ -0,0 +1,17 @@
+-- Optional arrival windows buyers choose at checkout, to spread out entry queues
+CREATE TABLE IF NOT EXISTS event_arrival_slots (
+    id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    ends_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    capacity INTEGER NOT NULL CHECK (capacity > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    CHECK (ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_arrival_slots_event ON event_arrival_slots(event_id, starts_at);
+
+-- The slot an order's ticket holders chose to arrive in
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS arrival_slot_id INTEGER REFERENCES event_arrival_slots(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_orders_arrival_slot ON orders(arrival_slot_id) WHERE arrival_slot_id IS NOT NULL;
\ No newline at end of file
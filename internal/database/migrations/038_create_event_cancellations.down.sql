@@ -0,0 +1,3 @@
+-- Drop event cancellations and their refund progress
+DROP TABLE IF EXISTS event_cancellation_refunds;
+DROP TABLE IF EXISTS event_cancellations;
@@ -0,0 +1,29 @@
+-- Cancelled events, with progress refunding their ticket holders in the background
+CREATE TABLE IF NOT EXISTS event_cancellations (
+    id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL UNIQUE REFERENCES events(id) ON DELETE CASCADE,
+    cancelled_by INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    reason TEXT NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'refunding' CHECK (status IN ('refunding', 'completed')),
+    order_count INTEGER NOT NULL DEFAULT 0,
+    refund_total INTEGER NOT NULL DEFAULT 0, -- In cents
+    refunded_count INTEGER NOT NULL DEFAULT 0,
+    failed_count INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_cancellations_pending ON event_cancellations(status) WHERE status <> 'completed';
+
+-- One row per order a cancellation has tried to refund. An order is claimed
+-- before the payment provider is called, so it is never refunded twice.
+CREATE TABLE IF NOT EXISTS event_cancellation_refunds (
+    cancellation_id INTEGER NOT NULL REFERENCES event_cancellations(id) ON DELETE CASCADE,
+    order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'refunded', 'failed')),
+    refund_id VARCHAR(255),
+    error TEXT,
+    attempts INTEGER NOT NULL DEFAULT 1,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (cancellation_id, order_id)
+);
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- Drop event translations
+DROP TABLE IF EXISTS event_translations;
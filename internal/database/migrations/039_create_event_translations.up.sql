@@ -0,0 +1,11 @@
+-- Organizer-provided translations of an event's title and description. The
+-- event's own title and description are in its default language (events.locale).
+CREATE TABLE IF NOT EXISTS event_translations (
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    locale VARCHAR(10) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (event_id, locale)
+);
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- Remove pricing tier chains from ticket types
+DROP INDEX IF EXISTS idx_ticket_types_next_tier;
+ALTER TABLE ticket_types DROP COLUMN IF EXISTS next_tier_id;
@@ -0,0 +1,7 @@
+-- Ticket types can be chained into pricing tiers, e.g. Early Bird, then Regular,
+-- then Late. A tier's next tier goes on sale as soon as it sells out or its
+-- sale ends.
+ALTER TABLE ticket_types ADD COLUMN IF NOT EXISTS next_tier_id INTEGER REFERENCES ticket_types(id) ON DELETE SET NULL;
+
+-- Each tier follows at most one other
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ticket_types_next_tier ON ticket_types(next_tier_id) WHERE next_tier_id IS NOT NULL;
\ No newline at end of file
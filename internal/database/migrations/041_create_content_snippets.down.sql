@@ -0,0 +1,2 @@
+-- Drop admin-editable content snippets
+DROP TABLE IF EXISTS content_snippets;
@@ -0,0 +1,8 @@
+-- Admin-editable copy shown on pages and in emails, such as the footer text
+-- and refund policy. Snippets without a row use their built-in default.
+CREATE TABLE IF NOT EXISTS content_snippets (
+    key VARCHAR(50) PRIMARY KEY,
+    content TEXT NOT NULL DEFAULT '',
+    updated_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- Drop saved events and ticket price history
+DROP TABLE IF EXISTS ticket_tier_ending_notices;
+DROP TABLE IF EXISTS ticket_price_changes;
+DROP TABLE IF EXISTS event_favorites;
@@ -0,0 +1,31 @@
+-- Events attendees have saved, so they can be told about price changes
+CREATE TABLE IF NOT EXISTS event_favorites (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_favorites_event ON event_favorites(event_id);
+
+-- Every price a ticket type has had. The first row of a ticket type has no
+-- old price. Price drops stay unnotified until their watchers are emailed.
+CREATE TABLE IF NOT EXISTS ticket_price_changes (
+    id SERIAL PRIMARY KEY,
+    ticket_type_id INTEGER NOT NULL REFERENCES ticket_types(id) ON DELETE CASCADE,
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    old_price INTEGER,
+    new_price INTEGER NOT NULL,
+    notified_at TIMESTAMP WITH TIME ZONE,
+    changed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_price_changes_event ON ticket_price_changes(event_id, changed_at);
+CREATE INDEX IF NOT EXISTS idx_ticket_price_changes_unnotified ON ticket_price_changes(id) WHERE notified_at IS NULL;
+
+-- Early-bird tiers whose watchers have been warned the price goes up soon,
+-- so each tier is only announced once
+CREATE TABLE IF NOT EXISTS ticket_tier_ending_notices (
+    ticket_type_id INTEGER PRIMARY KEY REFERENCES ticket_types(id) ON DELETE CASCADE,
+    sent_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
\ No newline at end of file
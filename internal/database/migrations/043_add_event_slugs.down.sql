@@ -0,0 +1,3 @@
+-- Remove event slugs. Links using them stop working; numeric URLs still do.
+DROP INDEX IF EXISTS idx_events_slug;
+ALTER TABLE events DROP COLUMN IF EXISTS slug;
@@ -0,0 +1,30 @@
+-- Human-readable event URLs (/events/nairobi-jazz-night). Old numeric URLs
+-- redirect to the slug. Slugs are generated from the title when the event is
+-- created and then kept, so shared links keep working.
+ALTER TABLE events ADD COLUMN IF NOT EXISTS slug VARCHAR(100) NOT NULL DEFAULT '';
+
+-- Backfill existing events the same way models.EventSlug does
+WITH bases AS (
+    SELECT id, trim(both '-' from left(trim(both '-' from regexp_replace(lower(title), '[^a-z0-9]+', '-', 'g')), 80)) AS base
+    FROM events
+    WHERE slug = ''
+), slugs AS (
+    SELECT id, CASE
+        WHEN base = '' THEN 'event'
+        WHEN base ~ '^[0-9]' THEN 'event-' || base
+        ELSE base
+    END AS slug
+    FROM bases
+), numbered AS (
+    SELECT id, slug, ROW_NUMBER() OVER (PARTITION BY slug ORDER BY id) AS n
+    FROM slugs
+)
+UPDATE events e
+SET slug = CASE
+    WHEN numbered.n = 1 AND NOT EXISTS (SELECT 1 FROM events c WHERE c.city_slug = numbered.slug) THEN numbered.slug
+    ELSE numbered.slug || '-' || e.id
+END
+FROM numbered
+WHERE numbered.id = e.id;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_events_slug ON events(slug) WHERE slug <> '';
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- Drop account signals used to link duplicate accounts
+DROP TABLE IF EXISTS account_signals;
@@ -0,0 +1,28 @@
+-- Identifying signals seen for each account, used to link duplicate accounts
+-- across organizers. Values are stored as SHA-256 fingerprints of the
+-- normalized value; label is what admins are shown (an IP address, a
+-- browser, or the last digits of payout details).
+CREATE TABLE IF NOT EXISTS account_signals (
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(20) NOT NULL,
+    fingerprint VARCHAR(64) NOT NULL,
+    label VARCHAR(255) NOT NULL DEFAULT '',
+    seen_count INTEGER NOT NULL DEFAULT 1,
+    first_seen_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (user_id, kind, fingerprint)
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_signals_fingerprint ON account_signals(kind, fingerprint);
+
+-- Backfill payout details from past withdrawals, normalized the same way as
+-- models.SignalFingerprint
+INSERT INTO account_signals (user_id, kind, fingerprint, label, seen_count, first_seen_at, last_seen_at)
+SELECT organizer_id, 'payout',
+    encode(sha256(convert_to('payout:' || regexp_replace(lower(bank_details), '[^a-z0-9]', '', 'g'), 'UTF8')), 'hex'),
+    trim('•••• ' || right(regexp_replace(bank_details, '[^0-9]', '', 'g'), 4)),
+    COUNT(*), MIN(created_at), MAX(created_at)
+FROM withdrawals
+WHERE regexp_replace(lower(bank_details), '[^a-z0-9]', '', 'g') <> ''
+GROUP BY organizer_id, 2, 3, 4
+ON CONFLICT (user_id, kind, fingerprint) DO NOTHING;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- Drop organizer storefronts, followers and new event announcements
+DROP TABLE IF EXISTS organizer_event_announcements;
+DROP TABLE IF EXISTS organizer_followers;
+DROP TABLE IF EXISTS organizer_profiles;
@@ -0,0 +1,57 @@
+-- Public organizer storefronts (/o/{slug}) with the organizer's branding.
+-- Organizers without a row get one the first time their storefront is needed.
+CREATE TABLE IF NOT EXISTS organizer_profiles (
+    user_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    slug VARCHAR(100) NOT NULL UNIQUE,
+    display_name VARCHAR(100) NOT NULL DEFAULT '',
+    description TEXT NOT NULL DEFAULT '',
+    logo_url VARCHAR(500) NOT NULL DEFAULT '',
+    banner_url VARCHAR(500) NOT NULL DEFAULT '',
+    website_url VARCHAR(500) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Backfill existing organizers the same way models.OrganizerSlug does
+WITH bases AS (
+    SELECT id, left(TRIM(first_name || ' ' || last_name), 100) AS name,
+        trim(both '-' from left(trim(both '-' from regexp_replace(lower(first_name || ' ' || last_name), '[^a-z0-9]+', '-', 'g')), 60)) AS base
+    FROM users
+    WHERE role = 'organizer'
+), slugs AS (
+    SELECT id, name, CASE
+        WHEN base = '' THEN 'organizer'
+        WHEN base ~ '^[0-9]' THEN 'organizer-' || base
+        ELSE base
+    END AS slug
+    FROM bases
+), numbered AS (
+    SELECT id, name, slug, ROW_NUMBER() OVER (PARTITION BY slug ORDER BY id) AS n
+    FROM slugs
+)
+INSERT INTO organizer_profiles (user_id, slug, display_name)
+SELECT id, CASE WHEN n = 1 THEN slug ELSE slug || '-' || id END, name
+FROM numbered
+ON CONFLICT (user_id) DO NOTHING;
+
+-- Attendees following organizers, to hear about their new events
+CREATE TABLE IF NOT EXISTS organizer_followers (
+    organizer_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    follower_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (organizer_id, follower_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_organizer_followers_follower ON organizer_followers(follower_id);
+
+-- Published events whose organizer's followers have been told about them,
+-- so each event is only announced once. Events published before followers
+-- existed are marked as announced.
+CREATE TABLE IF NOT EXISTS organizer_event_announcements (
+    event_id INTEGER PRIMARY KEY REFERENCES events(id) ON DELETE CASCADE,
+    sent_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO organizer_event_announcements (event_id)
+SELECT id FROM events WHERE status = 'published'
+ON CONFLICT (event_id) DO NOTHING;
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- Drop saved searches
+DROP TABLE IF EXISTS saved_search_matched_events;
+DROP TABLE IF EXISTS saved_searches;
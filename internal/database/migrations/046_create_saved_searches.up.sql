@@ -0,0 +1,23 @@
+-- Searches attendees saved to be emailed about matching new events
+CREATE TABLE IF NOT EXISTS saved_searches (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    query VARCHAR(200) NOT NULL DEFAULT '',
+    category_id INTEGER REFERENCES categories(id) ON DELETE CASCADE,
+    location VARCHAR(100) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_saved_searches_user ON saved_searches(user_id);
+
+-- Published events that have been matched against saved searches, so each
+-- event is only matched once. Events published before saved searches existed
+-- are marked as matched.
+CREATE TABLE IF NOT EXISTS saved_search_matched_events (
+    event_id INTEGER PRIMARY KEY REFERENCES events(id) ON DELETE CASCADE,
+    matched_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+INSERT INTO saved_search_matched_events (event_id)
+SELECT id FROM events WHERE status = 'published'
+ON CONFLICT (event_id) DO NOTHING;
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- Drop API tokens. Partner integrations using them stop working.
+DROP TABLE IF EXISTS api_tokens;
@@ -0,0 +1,2 @@
+-- Drop organizer team members
+DROP TABLE IF EXISTS organizer_members;
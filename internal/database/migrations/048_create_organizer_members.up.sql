@@ -0,0 +1,19 @@
+-- Team members organizers have invited to help run their events, with the
+-- parts of the organizer's account each member can use. Withdrawals always
+-- stay with the organizer. Only a hash of the invitation token is stored, and
+-- it is cleared once the invitation is accepted.
+CREATE TABLE IF NOT EXISTS organizer_members (
+    id SERIAL PRIMARY KEY,
+    organizer_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    email VARCHAR(255) NOT NULL,
+    can_manage_events BOOLEAN NOT NULL DEFAULT FALSE,
+    can_view_analytics BOOLEAN NOT NULL DEFAULT FALSE,
+    can_check_in BOOLEAN NOT NULL DEFAULT FALSE,
+    invitation_token_hash VARCHAR(64) UNIQUE,
+    invited_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    accepted_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (organizer_id, email)
+);
+
+CREATE INDEX IF NOT EXISTS idx_organizer_members_user ON organizer_members(user_id) WHERE user_id IS NOT NULL;
\ No newline at end of file
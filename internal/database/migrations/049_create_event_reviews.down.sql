@@ -0,0 +1,3 @@
+-- Drop the event review queue and moderators' comments
+DROP TABLE IF EXISTS event_review_comments;
+DROP TABLE IF EXISTS event_review_assignments;
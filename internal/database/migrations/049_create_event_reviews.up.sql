@@ -0,0 +1,22 @@
+-- The approval queue for events held for review. Each event waiting for
+-- review is assigned to a moderator, and moderators' comments and decisions
+-- are kept so organizers can see why changes were requested.
+CREATE TABLE IF NOT EXISTS event_review_assignments (
+    event_id INTEGER PRIMARY KEY REFERENCES events(id) ON DELETE CASCADE,
+    reviewer_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    assigned_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_review_assignments_reviewer ON event_review_assignments(reviewer_id);
+CREATE INDEX IF NOT EXISTS idx_event_review_assignments_assigned_at ON event_review_assignments(assigned_at);
+
+CREATE TABLE IF NOT EXISTS event_review_comments (
+    id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    author_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    decision VARCHAR(20) NOT NULL DEFAULT 'comment', -- 'comment', 'changes_requested', 'approved', 'rejected'
+    body TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_review_comments_event ON event_review_comments(event_id, created_at);
\ No newline at end of file
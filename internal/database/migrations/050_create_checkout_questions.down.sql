@@ -0,0 +1,3 @@
+-- Drop checkout questions and attendees' answers
+DROP TABLE IF EXISTS ticket_answers;
+DROP TABLE IF EXISTS event_checkout_questions;
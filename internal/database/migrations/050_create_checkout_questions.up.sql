@@ -0,0 +1,23 @@
+-- Custom questions organizers ask every attendee at checkout
+CREATE TABLE IF NOT EXISTS event_checkout_questions (
+    id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    label VARCHAR(200) NOT NULL,
+    field_type VARCHAR(20) NOT NULL CHECK (field_type IN ('text', 'select')),
+    options TEXT[] NOT NULL DEFAULT '{}',
+    required BOOLEAN NOT NULL DEFAULT FALSE,
+    position INTEGER NOT NULL DEFAULT 0,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_checkout_questions_event ON event_checkout_questions(event_id, position);
+
+-- Each attendee's answers, stored with their ticket
+CREATE TABLE IF NOT EXISTS ticket_answers (
+    ticket_id INTEGER NOT NULL REFERENCES tickets(id) ON DELETE CASCADE,
+    question_id INTEGER NOT NULL REFERENCES event_checkout_questions(id) ON DELETE CASCADE,
+    answer TEXT NOT NULL,
+    PRIMARY KEY (ticket_id, question_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_answers_question ON ticket_answers(question_id);
\ No newline at end of file
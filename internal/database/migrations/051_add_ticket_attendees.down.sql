@@ -0,0 +1,3 @@
+-- Remove attendee names from tickets
+ALTER TABLE tickets DROP COLUMN IF EXISTS attendee_name;
+ALTER TABLE tickets DROP COLUMN IF EXISTS attendee_email;
@@ -0,0 +1,5 @@
+-- Named tickets: who each ticket is for. Buyers fill these in at checkout and
+-- can change them until shortly before the event.
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS attendee_name VARCHAR(100) NOT NULL DEFAULT '';
+
+ALTER TABLE tickets ADD COLUMN IF NOT EXISTS attendee_email VARCHAR(255) NOT NULL DEFAULT '';
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- Drop add-to-cart tracking
+DROP TABLE IF EXISTS cart_additions;
@@ -0,0 +1,11 @@
+-- Tickets added to carts, the add-to-cart step of organizers' sales funnels
+CREATE TABLE IF NOT EXISTS cart_additions (
+    id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    ticket_type_id INTEGER REFERENCES ticket_types(id) ON DELETE SET NULL,
+    user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_cart_additions_event ON cart_additions(event_id, created_at);
\ No newline at end of file
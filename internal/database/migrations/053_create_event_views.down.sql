@@ -0,0 +1,3 @@
+-- Drop event page views
+DROP TABLE IF EXISTS event_view_daily;
+DROP TABLE IF EXISTS event_views;
@@ -0,0 +1,22 @@
+-- First-party page views of event detail pages, the top of organizers'
+-- sales funnels. Recent views are kept individually; older ones are rolled
+-- up into daily totals.
+CREATE TABLE IF NOT EXISTS event_views (
+    id BIGSERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    visitor_id VARCHAR(64) NOT NULL,
+    user_id INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    referrer_host VARCHAR(255) NOT NULL DEFAULT '',
+    viewed_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_views_event ON event_views(event_id, viewed_at);
+CREATE INDEX IF NOT EXISTS idx_event_views_visitor ON event_views(event_id, visitor_id, viewed_at);
+
+CREATE TABLE IF NOT EXISTS event_view_daily (
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    day DATE NOT NULL,
+    views INTEGER NOT NULL DEFAULT 0,
+    visitors INTEGER NOT NULL DEFAULT 0,
+    PRIMARY KEY (event_id, day)
+);
\ No newline at end of file
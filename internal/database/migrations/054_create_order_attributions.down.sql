@@ -0,0 +1,2 @@
+-- Drop order attributions
+DROP TABLE IF EXISTS order_attributions;
@@ -0,0 +1,13 @@
+-- Where each order's buyer came from, captured on their first visit in the
+-- session, for organizers' sales by source
+CREATE TABLE IF NOT EXISTS order_attributions (
+    order_id INTEGER PRIMARY KEY REFERENCES orders(id) ON DELETE CASCADE,
+    source VARCHAR(100) NOT NULL DEFAULT '',
+    medium VARCHAR(100) NOT NULL DEFAULT '',
+    campaign VARCHAR(100) NOT NULL DEFAULT '',
+    term VARCHAR(100) NOT NULL DEFAULT '',
+    content VARCHAR(100) NOT NULL DEFAULT '',
+    referrer_host VARCHAR(255) NOT NULL DEFAULT '',
+    landing_path VARCHAR(500) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- Drop promoter links and the promoter code recorded with orders
+DROP INDEX IF EXISTS idx_order_attributions_ref;
+ALTER TABLE order_attributions DROP COLUMN IF EXISTS ref;
+
+DROP TABLE IF EXISTS promoter_links;
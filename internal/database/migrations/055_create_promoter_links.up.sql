@@ -0,0 +1,18 @@
+-- Named tracking links organizers give promoters, with the commission each
+-- earns on the sales it brings in. Links are deactivated rather than deleted,
+-- keeping the sales made before.
+CREATE TABLE IF NOT EXISTS promoter_links (
+    id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    code VARCHAR(50) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    commission_rate NUMERIC(5,2) NOT NULL DEFAULT 0 CHECK (commission_rate >= 0 AND commission_rate <= 100),
+    deactivated_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (event_id, code)
+);
+
+-- The code of the last promoter link an order's buyer followed
+ALTER TABLE order_attributions ADD COLUMN IF NOT EXISTS ref VARCHAR(50) NOT NULL DEFAULT '';
+
+CREATE INDEX IF NOT EXISTS idx_order_attributions_ref ON order_attributions(ref) WHERE ref <> '';
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- Drop the session store's rows and columns, leaving the login sessions
+DROP INDEX IF EXISTS idx_sessions_user_last_seen;
+DELETE FROM sessions WHERE data IS NOT NULL;
+ALTER TABLE sessions DROP COLUMN IF EXISTS last_seen_at;
+ALTER TABLE sessions DROP COLUMN IF EXISTS data;
\ No newline at end of file
@@ -0,0 +1,7 @@
+-- Keep browser sessions server-side in the sessions table, so they can be
+-- listed and revoked. Rows written by the session store carry the encoded
+-- session values; ids are hashes of the session ids sent in cookies.
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS data TEXT;
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS last_seen_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP;
+
+CREATE INDEX IF NOT EXISTS idx_sessions_user_last_seen ON sessions(user_id, last_seen_at DESC) WHERE data IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- Drop the device details recorded with sessions
+ALTER TABLE sessions DROP COLUMN IF EXISTS user_agent;
+ALTER TABLE sessions DROP COLUMN IF EXISTS ip_address;
\ No newline at end of file
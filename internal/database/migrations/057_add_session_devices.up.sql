@@ -0,0 +1,4 @@
+-- The address and browser each session was last used from, shown on the
+-- security page so users can recognise their devices
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS ip_address TEXT NOT NULL DEFAULT '';
+ALTER TABLE sessions ADD COLUMN IF NOT EXISTS user_agent TEXT NOT NULL DEFAULT '';
\ No newline at end of file
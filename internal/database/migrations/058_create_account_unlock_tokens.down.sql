@@ -0,0 +1,3 @@
+-- Drop account unlock links
+DROP INDEX IF EXISTS idx_users_locked_until;
+DROP TABLE IF EXISTS account_unlock_tokens;
\ No newline at end of file
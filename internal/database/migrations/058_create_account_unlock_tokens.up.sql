@@ -0,0 +1,16 @@
+-- Single-use links emailed when an account is locked after too many failed
+-- logins. Only a SHA-256 hash of each token is stored.
+CREATE TABLE IF NOT EXISTS account_unlock_tokens (
+    id SERIAL PRIMARY KEY,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    token_hash VARCHAR(64) NOT NULL UNIQUE,
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    used_at TIMESTAMP WITH TIME ZONE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_account_unlock_tokens_user ON account_unlock_tokens(user_id);
+CREATE INDEX IF NOT EXISTS idx_account_unlock_tokens_expires ON account_unlock_tokens(expires_at);
+
+-- Listing the accounts that are currently locked
+CREATE INDEX IF NOT EXISTS idx_users_locked_until ON users(locked_until) WHERE locked_until IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- Drop the email delivery log
+DROP TABLE IF EXISTS email_log;
\ No newline at end of file
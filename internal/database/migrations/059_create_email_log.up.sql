@@ -0,0 +1,21 @@
+-- Every email sent through the email provider, with its delivery status as
+-- reported by the provider's webhooks. The request is kept so failed sends
+-- can be retried.
+CREATE TABLE IF NOT EXISTS email_log (
+    id SERIAL PRIMARY KEY,
+    email_type VARCHAR(50) NOT NULL DEFAULT 'other',
+    recipient VARCHAR(255) NOT NULL,
+    subject VARCHAR(500) NOT NULL DEFAULT '',
+    provider VARCHAR(20) NOT NULL DEFAULT 'resend',
+    provider_message_id VARCHAR(100),
+    status VARCHAR(20) NOT NULL CHECK (status IN ('sent', 'failed', 'delayed', 'delivered', 'bounced', 'complained')),
+    error TEXT NOT NULL DEFAULT '',
+    request JSONB NOT NULL,
+    attempts INTEGER NOT NULL DEFAULT 1,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_email_log_provider_message ON email_log(provider_message_id) WHERE provider_message_id IS NOT NULL;
+CREATE INDEX IF NOT EXISTS idx_email_log_status ON email_log(status, created_at DESC);
+CREATE INDEX IF NOT EXISTS idx_email_log_recipient ON email_log(recipient);
\ No newline at end of file
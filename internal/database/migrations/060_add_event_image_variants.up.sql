@@ -0,0 +1,2 @@
+-- Sizes and formats generated from each event image, for srcset
+ALTER TABLE events ADD COLUMN IF NOT EXISTS image_variants JSONB NOT NULL DEFAULT '[]';
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- Drop event waiting rooms
+DROP TABLE IF EXISTS event_waiting_rooms;
\ No newline at end of file
@@ -0,0 +1,10 @@
+-- Per-event virtual waiting rooms for high-demand on-sales. Queue positions
+-- and admissions are kept in the shared cache; events without a row have no
+-- waiting room.
+CREATE TABLE IF NOT EXISTS event_waiting_rooms (
+    event_id INTEGER PRIMARY KEY REFERENCES events(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT false,
+    opens_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    admit_per_minute INTEGER NOT NULL CHECK (admit_per_minute > 0),
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- Drop ticket reservations
+DROP TABLE IF EXISTS ticket_reservations;
\ No newline at end of file
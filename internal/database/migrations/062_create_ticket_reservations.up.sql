@@ -0,0 +1,21 @@
+-- Create ticket reservations: holds on tickets while buyers pay, which
+-- expire unless the order completes. Sold counts now only change as orders
+-- complete and tickets are refunded, so start them from the tickets issued.
+CREATE TABLE IF NOT EXISTS ticket_reservations (
+    id SERIAL PRIMARY KEY,
+    ticket_type_id INTEGER NOT NULL REFERENCES ticket_types(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    quantity INTEGER NOT NULL CHECK (quantity > 0),
+    expires_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_ticket_reservations_ticket_type ON ticket_reservations(ticket_type_id, expires_at);
+CREATE INDEX IF NOT EXISTS idx_ticket_reservations_user ON ticket_reservations(user_id);
+CREATE INDEX IF NOT EXISTS idx_ticket_reservations_expires_at ON ticket_reservations(expires_at);
+
+UPDATE ticket_types tt
+SET sold = LEAST(tt.quantity, (
+    SELECT COUNT(*) FROM tickets t
+    WHERE t.ticket_type_id = tt.id AND t.status <> 'refunded'
+));
\ No newline at end of file
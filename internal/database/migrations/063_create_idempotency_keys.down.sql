@@ -0,0 +1,2 @@
+-- Drop idempotency keys
+DROP TABLE IF EXISTS idempotency_keys;
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- Drop payment reconciliation issues
+DROP INDEX IF EXISTS idx_orders_payment_id;
+DROP TABLE IF EXISTS payment_reconciliation_issues;
\ No newline at end of file
@@ -0,0 +1,24 @@
+-- Create payment reconciliation issues: transactions the payment provider
+-- and local orders disagree about, kept until an admin resolves them
+CREATE TABLE IF NOT EXISTS payment_reconciliation_issues (
+    id SERIAL PRIMARY KEY,
+    kind VARCHAR(30) NOT NULL CHECK (kind IN ('paid_not_completed', 'paid_without_order', 'amount_mismatch', 'completed_not_paid')),
+    payment_reference VARCHAR(255) NOT NULL,
+    order_id INTEGER REFERENCES orders(id) ON DELETE SET NULL,
+    provider_status VARCHAR(30) NOT NULL DEFAULT '',
+    provider_amount INTEGER NOT NULL DEFAULT 0,
+    currency VARCHAR(3) NOT NULL DEFAULT '',
+    customer_email VARCHAR(255) NOT NULL DEFAULT '',
+    order_status VARCHAR(20) NOT NULL DEFAULT '',
+    order_amount INTEGER NOT NULL DEFAULT 0,
+    detected_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    last_seen_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    resolved_at TIMESTAMP WITH TIME ZONE,
+    resolved_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    resolution VARCHAR(20) CHECK (resolution IN ('resolved', 'ignored', 'cleared')),
+    resolution_note TEXT NOT NULL DEFAULT '',
+    UNIQUE (kind, payment_reference)
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_reconciliation_issues_open ON payment_reconciliation_issues(detected_at) WHERE resolved_at IS NULL;
+CREATE INDEX IF NOT EXISTS idx_orders_payment_id ON orders(payment_id);
\ No newline at end of file
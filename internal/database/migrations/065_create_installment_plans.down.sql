@@ -0,0 +1,4 @@
+-- Drop installment plans
+DROP TABLE IF EXISTS order_installments;
+DROP TABLE IF EXISTS order_installment_plans;
+DROP TABLE IF EXISTS event_installment_settings;
\ No newline at end of file
@@ -0,0 +1,45 @@
+-- Create installment plans: organizers' settings for paying for an event's
+-- tickets in installments, and each order's payment schedule
+CREATE TABLE IF NOT EXISTS event_installment_settings (
+    event_id INTEGER PRIMARY KEY REFERENCES events(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    installments INTEGER NOT NULL DEFAULT 3 CHECK (installments BETWEEN 2 AND 12),
+    interval_days INTEGER NOT NULL DEFAULT 30 CHECK (interval_days BETWEEN 7 AND 31),
+    min_order_amount INTEGER NOT NULL DEFAULT 0 CHECK (min_order_amount >= 0),
+    issue_tickets_upfront BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS order_installment_plans (
+    id SERIAL PRIMARY KEY,
+    order_id INTEGER NOT NULL UNIQUE REFERENCES orders(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    total_amount INTEGER NOT NULL CHECK (total_amount > 0),
+    status VARCHAR(20) NOT NULL DEFAULT 'active' CHECK (status IN ('active', 'completed', 'defaulted')),
+    issue_tickets_upfront BOOLEAN NOT NULL DEFAULT FALSE,
+    authorization_code VARCHAR(255) NOT NULL,
+    customer_email VARCHAR(255) NOT NULL,
+    items JSONB NOT NULL DEFAULT '[]',
+    attendees JSONB NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE TABLE IF NOT EXISTS order_installments (
+    id SERIAL PRIMARY KEY,
+    plan_id INTEGER NOT NULL REFERENCES order_installment_plans(id) ON DELETE CASCADE,
+    sequence INTEGER NOT NULL CHECK (sequence > 0),
+    amount INTEGER NOT NULL CHECK (amount > 0),
+    due_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'paid', 'failed')),
+    payment_reference VARCHAR(255) NOT NULL DEFAULT '',
+    attempts INTEGER NOT NULL DEFAULT 0,
+    next_attempt_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    last_error TEXT NOT NULL DEFAULT '',
+    paid_at TIMESTAMP WITH TIME ZONE,
+    UNIQUE (plan_id, sequence)
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_installments_due ON order_installments(next_attempt_at) WHERE status = 'pending';
+CREATE INDEX IF NOT EXISTS idx_order_installments_payment_reference ON order_installments(payment_reference);
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- Remove pay-what-you-want ticket types and donations
+DROP TABLE IF EXISTS event_donation_settings;
+DROP TABLE IF EXISTS order_item_prices;
+ALTER TABLE orders DROP COLUMN IF EXISTS donation_amount;
+ALTER TABLE ticket_types DROP COLUMN IF EXISTS pay_what_you_want;
\ No newline at end of file
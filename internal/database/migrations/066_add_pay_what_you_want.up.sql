@@ -0,0 +1,25 @@
+-- Pay-what-you-want ticket types and donations at checkout. A
+-- pay-what-you-want ticket type's price is the least a buyer can pay for it.
+ALTER TABLE ticket_types ADD COLUMN IF NOT EXISTS pay_what_you_want BOOLEAN NOT NULL DEFAULT FALSE;
+
+-- The donation a buyer added at checkout, included in the order's total
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS donation_amount INTEGER NOT NULL DEFAULT 0 CHECK (donation_amount >= 0);
+
+-- The price each ticket type of an order was sold at, which for
+-- pay-what-you-want ticket types is the amount the buyer chose
+CREATE TABLE IF NOT EXISTS order_item_prices (
+    order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    ticket_type_id INTEGER NOT NULL REFERENCES ticket_types(id) ON DELETE CASCADE,
+    unit_price INTEGER NOT NULL CHECK (unit_price >= 0),
+    PRIMARY KEY (order_id, ticket_type_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_item_prices_ticket_type ON order_item_prices(ticket_type_id);
+
+-- Organizers' settings for asking buyers for a donation at checkout
+CREATE TABLE IF NOT EXISTS event_donation_settings (
+    event_id INTEGER PRIMARY KEY REFERENCES events(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    message TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
\ No newline at end of file
@@ -0,0 +1,4 @@
+DROP INDEX IF EXISTS idx_ticket_scans_client_id;
+
+ALTER TABLE ticket_scans DROP COLUMN IF EXISTS synced_at;
+ALTER TABLE ticket_scans DROP COLUMN IF EXISTS client_id;
\ No newline at end of file
@@ -0,0 +1,7 @@
+-- Let scanning apps check tickets in offline and upload the scans afterwards.
+-- client_id is the id the app gave a scan, so uploading it again is a no-op;
+-- synced_at is when an offline scan reached the server.
+ALTER TABLE ticket_scans ADD COLUMN IF NOT EXISTS client_id VARCHAR(64);
+ALTER TABLE ticket_scans ADD COLUMN IF NOT EXISTS synced_at TIMESTAMP WITH TIME ZONE;
+
+CREATE UNIQUE INDEX IF NOT EXISTS idx_ticket_scans_client_id ON ticket_scans(event_id, client_id) WHERE client_id IS NOT NULL;
\ No newline at end of file
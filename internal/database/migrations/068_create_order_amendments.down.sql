@@ -0,0 +1,2 @@
+-- Drop order amendments
+DROP TABLE IF EXISTS order_amendments;
\ No newline at end of file
@@ -0,0 +1,16 @@
+-- Order amendments: a buyer's change of their order's ticket types or
+-- quantities before the event, paid for or refunded as the difference
+CREATE TABLE IF NOT EXISTS order_amendments (
+    id SERIAL PRIMARY KEY,
+    order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    user_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    items JSONB NOT NULL DEFAULT '[]',
+    price_delta INTEGER NOT NULL,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'completed', 'cancelled')),
+    payment_reference VARCHAR(255) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    completed_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_order_amendments_order ON order_amendments(order_id);
+CREATE INDEX IF NOT EXISTS idx_order_amendments_payment_reference ON order_amendments(payment_reference) WHERE payment_reference != '';
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- Drop payment disputes
+UPDATE orders SET status = 'completed' WHERE status = 'disputed';
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_check;
+ALTER TABLE orders ADD CONSTRAINT orders_status_check CHECK (status IN ('pending', 'completed', 'cancelled', 'refunded'));
+DROP TABLE IF EXISTS payment_disputes;
\ No newline at end of file
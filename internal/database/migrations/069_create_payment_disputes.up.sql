@@ -0,0 +1,28 @@
+-- Create payment disputes: chargebacks buyers raised with their card issuer
+-- over an order's payment, the organizer's evidence and their outcome
+CREATE TABLE IF NOT EXISTS payment_disputes (
+    id SERIAL PRIMARY KEY,
+    provider_dispute_id VARCHAR(100) NOT NULL UNIQUE,
+    order_id INTEGER NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    payment_reference VARCHAR(255) NOT NULL,
+    amount INTEGER NOT NULL CHECK (amount >= 0),
+    currency VARCHAR(3) NOT NULL DEFAULT '',
+    reason VARCHAR(255) NOT NULL DEFAULT '',
+    status VARCHAR(20) NOT NULL DEFAULT 'open' CHECK (status IN ('open', 'under_review', 'won', 'lost')),
+    order_status VARCHAR(20) NOT NULL,
+    evidence_text TEXT NOT NULL DEFAULT '',
+    evidence_url VARCHAR(500) NOT NULL DEFAULT '',
+    evidence_submitted_at TIMESTAMP WITH TIME ZONE,
+    due_at TIMESTAMP WITH TIME ZONE,
+    resolution_note TEXT NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    resolved_at TIMESTAMP WITH TIME ZONE
+);
+
+CREATE INDEX IF NOT EXISTS idx_payment_disputes_order_id ON payment_disputes(order_id);
+CREATE INDEX IF NOT EXISTS idx_payment_disputes_unresolved ON payment_disputes(created_at) WHERE resolved_at IS NULL;
+
+-- Disputed orders are left out of organizer balances until the dispute is won
+ALTER TABLE orders DROP CONSTRAINT IF EXISTS orders_status_check;
+ALTER TABLE orders ADD CONSTRAINT orders_status_check CHECK (status IN ('pending', 'completed', 'cancelled', 'refunded', 'disputed'));
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- Remove tax settings
+ALTER TABLE orders DROP COLUMN IF EXISTS tax;
+DROP TABLE IF EXISTS event_tax_overrides;
+DROP TABLE IF EXISTS organizer_tax_settings;
\ No newline at end of file
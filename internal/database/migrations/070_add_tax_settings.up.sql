@@ -0,0 +1,23 @@
+-- Tax charged on tickets. Organizers set a rate that applies to all their
+-- events, which an event can override or be exempt from.
+CREATE TABLE IF NOT EXISTS organizer_tax_settings (
+    organizer_id INTEGER PRIMARY KEY REFERENCES users(id) ON DELETE CASCADE,
+    enabled BOOLEAN NOT NULL DEFAULT FALSE,
+    label VARCHAR(20) NOT NULL DEFAULT 'VAT',
+    rate_basis_points INTEGER NOT NULL DEFAULT 0 CHECK (rate_basis_points BETWEEN 0 AND 5000),
+    inclusive BOOLEAN NOT NULL DEFAULT FALSE,
+    tax_id VARCHAR(50) NOT NULL DEFAULT '',
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE TABLE IF NOT EXISTS event_tax_overrides (
+    event_id INTEGER PRIMARY KEY REFERENCES events(id) ON DELETE CASCADE,
+    exempt BOOLEAN NOT NULL DEFAULT FALSE,
+    rate_basis_points INTEGER NOT NULL DEFAULT 0 CHECK (rate_basis_points BETWEEN 0 AND 5000),
+    inclusive BOOLEAN NOT NULL DEFAULT FALSE,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+-- The tax line of an order, kept as it was at checkout for its receipt. Its
+-- amount is part of the order's total unless the tax is inclusive.
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS tax JSONB;
\ No newline at end of file
@@ -0,0 +1,3 @@
+-- Drop organizer ledgers
+DROP TABLE IF EXISTS ledger_entries;
+DROP TABLE IF EXISTS ledger_transactions;
\ No newline at end of file
@@ -0,0 +1,26 @@
+-- Create organizer ledgers: every movement of an organizer's money, posted
+-- once from the order, amendment, dispute or withdrawal it records, as
+-- entries that sum to zero
+CREATE TABLE IF NOT EXISTS ledger_transactions (
+    id SERIAL PRIMARY KEY,
+    organizer_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    kind VARCHAR(30) NOT NULL,
+    source_id INTEGER NOT NULL,
+    order_id INTEGER REFERENCES orders(id) ON DELETE SET NULL,
+    description TEXT NOT NULL DEFAULT '',
+    occurred_at TIMESTAMP WITH TIME ZONE NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (kind, source_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_transactions_organizer ON ledger_transactions(organizer_id, occurred_at);
+CREATE INDEX IF NOT EXISTS idx_ledger_transactions_order_id ON ledger_transactions(order_id);
+
+CREATE TABLE IF NOT EXISTS ledger_entries (
+    id SERIAL PRIMARY KEY,
+    transaction_id INTEGER NOT NULL REFERENCES ledger_transactions(id) ON DELETE CASCADE,
+    account VARCHAR(30) NOT NULL,
+    amount BIGINT NOT NULL -- in cents, credits positive and debits negative
+);
+
+CREATE INDEX IF NOT EXISTS idx_ledger_entries_transaction_id ON ledger_entries(transaction_id);
\ No newline at end of file
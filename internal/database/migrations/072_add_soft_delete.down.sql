@@ -0,0 +1,7 @@
+-- Remove soft delete
+DROP INDEX IF EXISTS idx_ticket_types_deleted_at;
+DROP INDEX IF EXISTS idx_events_deleted_at;
+DROP INDEX IF EXISTS idx_users_deleted_at;
+ALTER TABLE ticket_types DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE events DROP COLUMN IF EXISTS deleted_at;
+ALTER TABLE users DROP COLUMN IF EXISTS deleted_at;
\ No newline at end of file
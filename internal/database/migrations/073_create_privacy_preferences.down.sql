@@ -0,0 +1,2 @@
+-- Drop privacy preferences
+DROP TABLE IF EXISTS privacy_preferences;
\ No newline at end of file
@@ -0,0 +1,13 @@
+-- Create privacy preferences: the analytics and marketing consent visitors
+-- give, by the consent cookie of anonymous visitors and by user once signed in
+CREATE TABLE IF NOT EXISTS privacy_preferences (
+    id SERIAL PRIMARY KEY,
+    consent_id VARCHAR(36) NOT NULL UNIQUE,
+    user_id INTEGER REFERENCES users(id) ON DELETE CASCADE,
+    analytics BOOLEAN NOT NULL DEFAULT FALSE,
+    marketing BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_privacy_preferences_user ON privacy_preferences(user_id, updated_at DESC) WHERE user_id IS NOT NULL;
\ No newline at end of file
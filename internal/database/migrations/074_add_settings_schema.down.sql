@@ -0,0 +1,6 @@
+-- Drop feature flags and the settings added with them
+DROP TABLE IF EXISTS feature_flags;
+
+ALTER TABLE system_settings
+    DROP COLUMN IF EXISTS max_tickets_per_order,
+    DROP COLUMN IF EXISTS email_reply_to;
\ No newline at end of file
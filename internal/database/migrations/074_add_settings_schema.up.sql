@@ -0,0 +1,13 @@
+-- Settings limiting order sizes and where replies to platform emails go
+ALTER TABLE system_settings
+    ADD COLUMN IF NOT EXISTS max_tickets_per_order INTEGER NOT NULL DEFAULT 10,
+    ADD COLUMN IF NOT EXISTS email_reply_to VARCHAR(255) NOT NULL DEFAULT '';
+
+-- Create feature flags: the features admins have switched on or off. Flags
+-- without a row use their default.
+CREATE TABLE IF NOT EXISTS feature_flags (
+    flag VARCHAR(100) PRIMARY KEY,
+    enabled BOOLEAN NOT NULL,
+    updated_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- Drop announcements
+DROP TABLE IF EXISTS announcements;
\ No newline at end of file
@@ -0,0 +1,18 @@
+-- Create announcements: banners admins publish across the site, shown to
+-- their audience between starts_at and ends_at
+CREATE TABLE IF NOT EXISTS announcements (
+    id SERIAL PRIMARY KEY,
+    message TEXT NOT NULL,
+    link_url TEXT NOT NULL DEFAULT '',
+    link_text VARCHAR(100) NOT NULL DEFAULT '',
+    level VARCHAR(20) NOT NULL DEFAULT 'info' CHECK (level IN ('info', 'warning', 'critical')),
+    audience VARCHAR(20) NOT NULL DEFAULT 'everyone' CHECK (audience IN ('everyone', 'signed_in', 'attendees', 'organizers', 'admins')),
+    starts_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    ends_at TIMESTAMP WITH TIME ZONE,
+    dismissible BOOLEAN NOT NULL DEFAULT TRUE,
+    created_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_announcements_schedule ON announcements(starts_at, ends_at);
\ No newline at end of file
@@ -0,0 +1,2 @@
+-- Drop help_articles
+DROP TABLE IF EXISTS help_articles;
\ No newline at end of file
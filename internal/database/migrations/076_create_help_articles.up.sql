@@ -0,0 +1,88 @@
+-- Create help_articles: the help center, written by admins in Markdown
+CREATE TABLE IF NOT EXISTS help_articles (
+    id SERIAL PRIMARY KEY,
+    slug VARCHAR(80) NOT NULL UNIQUE,
+    title VARCHAR(200) NOT NULL,
+    summary VARCHAR(300) NOT NULL DEFAULT '',
+    body TEXT NOT NULL,
+    category VARCHAR(30) NOT NULL CHECK (category IN ('getting_started', 'buying_tickets', 'organizing', 'payments', 'account')),
+    status VARCHAR(20) NOT NULL DEFAULT 'draft' CHECK (status IN ('draft', 'published')),
+    updated_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    published_at TIMESTAMP WITH TIME ZONE,
+    -- Weighted search document: title ranks above summary, which ranks above body
+    search_vector tsvector GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(summary, '')), 'B') ||
+        setweight(to_tsvector('english', coalesce(body, '')), 'C')
+    ) STORED
+);
+
+CREATE INDEX IF NOT EXISTS idx_help_articles_search_vector ON help_articles USING GIN (search_vector);
+CREATE INDEX IF NOT EXISTS idx_help_articles_title_trgm ON help_articles USING GIN (title gin_trgm_ops);
+CREATE INDEX IF NOT EXISTS idx_help_articles_category ON help_articles(category, status);
+
+-- First versions of the articles dashboard pages link to
+INSERT INTO help_articles (slug, title, summary, body, category, status, published_at) VALUES
+('creating-an-event', 'Creating an event', 'Set up your event page, submit it for review and publish it.',
+'## Before you start
+
+You need an organizer account. Any account can become one from **Dashboard**.
+
+## Create the event
+
+1. Go to **Dashboard** and choose **Create Event**.
+2. Add a title, description, category, date and location.
+3. Upload a cover image so your event stands out in search.
+4. Save the event as a draft while you add ticket types.
+
+## Publish
+
+When the event is ready, submit it for review. Most events are approved within a day, and you will get an email once it is live.', 'organizing', 'published', NOW()),
+('setting-up-ticket-types', 'Setting up ticket types', 'Offer early bird, VIP and group tickets with their own prices and limits.',
+'Each event can sell several **ticket types**, such as Early Bird, General Admission and VIP.
+
+## Add a ticket type
+
+1. Open the event from **Dashboard** and choose **Ticket Types**.
+2. Set a name, price and how many are available.
+3. Optionally set when sales start and end.
+
+## Tips
+
+- Free tickets are allowed: set the price to 0.
+- Buyers can order up to 10 tickets at once unless an admin changes the limit.
+- Sold out ticket types can be reopened by raising their quantity.', 'organizing', 'published', NOW()),
+('getting-paid', 'Getting paid', 'How your balance builds up and how to withdraw your earnings.',
+'Ticket sales, minus the platform fee, are added to your **balance** once each order is paid.
+
+## Withdraw your earnings
+
+1. Go to **Dashboard** and open **Withdrawals**.
+2. Choose how much to withdraw and where to send it.
+3. An admin reviews the request, usually within two business days.
+
+Withdrawals have a minimum and maximum amount, shown on the withdrawal form. Refunds issued after a sale are taken from your balance.', 'payments', 'published', NOW()),
+('finding-your-tickets', 'Finding your tickets', 'Where your tickets are after checkout and how to show them at the door.',
+'After checkout your tickets are emailed to you and kept in your account.
+
+## In your account
+
+Go to **Dashboard** and open **My Tickets**. Each ticket has a QR code that is scanned at the entrance.
+
+## Did not get the email?
+
+- Check your spam folder.
+- Make sure the email address on your account is correct.
+- You can always show the ticket from your account instead.', 'buying_tickets', 'published', NOW()),
+('refunds', 'Refunds', 'When tickets can be refunded and how long refunds take.',
+'Refunds follow the refund policy shown at checkout, and organizers may offer more generous terms for their events.
+
+## Cancelled events
+
+If an event is cancelled, every ticket holder is refunded in full automatically.
+
+## Requesting a refund
+
+Contact the organizer from the event page. Approved refunds go back to the card you paid with, usually within 5 to 10 business days.', 'buying_tickets', 'published', NOW());
\ No newline at end of file
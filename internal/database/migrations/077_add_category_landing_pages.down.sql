@@ -0,0 +1,5 @@
+-- Remove category landing pages
+DROP TABLE IF EXISTS category_featured_events;
+ALTER TABLE categories DROP COLUMN IF EXISTS meta_description;
+ALTER TABLE categories DROP COLUMN IF EXISTS meta_title;
+ALTER TABLE categories DROP COLUMN IF EXISTS hero_image_url;
\ No newline at end of file
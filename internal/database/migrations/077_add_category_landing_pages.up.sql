@@ -0,0 +1,15 @@
+-- Category landing pages: a hero image, search metadata and events admins feature
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS hero_image_url VARCHAR(500) NOT NULL DEFAULT '';
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS meta_title VARCHAR(70) NOT NULL DEFAULT '';
+ALTER TABLE categories ADD COLUMN IF NOT EXISTS meta_description VARCHAR(160) NOT NULL DEFAULT '';
+
+CREATE TABLE IF NOT EXISTS category_featured_events (
+    category_id INTEGER NOT NULL REFERENCES categories(id) ON DELETE CASCADE,
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    position INTEGER NOT NULL,
+    added_by INTEGER REFERENCES users(id) ON DELETE SET NULL,
+    added_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    PRIMARY KEY (category_id, event_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_category_featured_events_position ON category_featured_events(category_id, position);
\ No newline at end of file
@@ -0,0 +1,5 @@
+-- Remove event coordinates
+DROP INDEX IF EXISTS idx_events_coordinates_published;
+ALTER TABLE events DROP COLUMN IF EXISTS geocoded_location;
+ALTER TABLE events DROP COLUMN IF EXISTS longitude;
+ALTER TABLE events DROP COLUMN IF EXISTS latitude;
\ No newline at end of file
@@ -0,0 +1,9 @@
+-- Event coordinates geocoded from the free-text location, used for "near me"
+-- radius search and the map view of search results. geocoded_location is the
+-- location the coordinates were looked up for, so events are geocoded again
+-- once their location changes.
+ALTER TABLE events ADD COLUMN IF NOT EXISTS latitude DOUBLE PRECISION;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS longitude DOUBLE PRECISION;
+ALTER TABLE events ADD COLUMN IF NOT EXISTS geocoded_location VARCHAR(255);
+
+CREATE INDEX IF NOT EXISTS idx_events_coordinates_published ON events(latitude, longitude) WHERE status = 'published' AND latitude IS NOT NULL;
\ No newline at end of file
@@ -0,0 +1,4 @@
+-- Remove venues
+DROP INDEX IF EXISTS idx_events_venue;
+ALTER TABLE events DROP COLUMN IF EXISTS venue_id;
+DROP TABLE IF EXISTS venues;
@@ -0,0 +1,24 @@
+-- Venues organizers hold events at, picked in the event form instead of
+-- retyping the location. Events keep their free-text location, set from the
+-- venue's name and city, so city pages, search and geocoding work unchanged.
+CREATE TABLE IF NOT EXISTS venues (
+    id SERIAL PRIMARY KEY,
+    organizer_id INTEGER NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    slug VARCHAR(120) NOT NULL UNIQUE,
+    name VARCHAR(150) NOT NULL,
+    address VARCHAR(255) NOT NULL DEFAULT '',
+    city VARCHAR(100) NOT NULL,
+    capacity INTEGER NOT NULL DEFAULT 0 CHECK (capacity >= 0),
+    latitude DOUBLE PRECISION,
+    longitude DOUBLE PRECISION,
+    description TEXT NOT NULL DEFAULT '',
+    images TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_venues_organizer ON venues(organizer_id, name);
+
+ALTER TABLE events ADD COLUMN IF NOT EXISTS venue_id INTEGER REFERENCES venues(id) ON DELETE SET NULL;
+
+CREATE INDEX IF NOT EXISTS idx_events_venue ON events(venue_id, start_date) WHERE venue_id IS NOT NULL;
@@ -0,0 +1,2 @@
+-- Remove online event access links
+DROP TABLE IF EXISTS event_access_links;
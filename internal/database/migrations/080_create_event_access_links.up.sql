@@ -0,0 +1,11 @@
+-- Stream or meeting links of online events (events whose location is
+-- 'Online'). They are kept out of the events table so no public query can
+-- return them; only ticket holders are shown the link, optionally not until
+-- reveal_minutes_before the event starts.
+CREATE TABLE IF NOT EXISTS event_access_links (
+    event_id INTEGER PRIMARY KEY REFERENCES events(id) ON DELETE CASCADE,
+    url VARCHAR(2048) NOT NULL,
+    reveal_minutes_before INTEGER NOT NULL DEFAULT 0 CHECK (reveal_minutes_before >= 0),
+    created_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+    updated_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
@@ -0,0 +1,4 @@
+-- Remove event agendas and lineups
+DROP TABLE IF EXISTS event_session_speakers;
+DROP TABLE IF EXISTS event_sessions;
+DROP TABLE IF EXISTS event_speakers;
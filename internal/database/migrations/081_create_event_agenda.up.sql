@@ -0,0 +1,36 @@
+-- Events' lineups of speakers or performers, shown on the event page
+CREATE TABLE IF NOT EXISTS event_speakers (
+    id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    name VARCHAR(150) NOT NULL,
+    role VARCHAR(150) NOT NULL DEFAULT '',
+    bio TEXT NOT NULL DEFAULT '',
+    photo_url VARCHAR(500) NOT NULL DEFAULT '',
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_speakers_event ON event_speakers(event_id, id);
+
+-- The sessions of events' agendas, each with any of the event's speakers
+CREATE TABLE IF NOT EXISTS event_sessions (
+    id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    title VARCHAR(200) NOT NULL,
+    description TEXT NOT NULL DEFAULT '',
+    stage VARCHAR(100) NOT NULL DEFAULT '',
+    starts_at TIMESTAMP NOT NULL, -- Wall-clock time, like the event's dates
+    ends_at TIMESTAMP CHECK (ends_at > starts_at),
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_sessions_event ON event_sessions(event_id, starts_at);
+
+CREATE TABLE IF NOT EXISTS event_session_speakers (
+    session_id INTEGER NOT NULL REFERENCES event_sessions(id) ON DELETE CASCADE,
+    speaker_id INTEGER NOT NULL REFERENCES event_speakers(id) ON DELETE CASCADE,
+    PRIMARY KEY (session_id, speaker_id)
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_session_speakers_speaker ON event_session_speakers(speaker_id);
@@ -0,0 +1,2 @@
+DROP TABLE IF EXISTS event_policies;
+DROP TABLE IF EXISTS event_faqs;
@@ -0,0 +1,21 @@
+-- Questions organizers answer ahead of time on their event pages
+CREATE TABLE IF NOT EXISTS event_faqs (
+    id SERIAL PRIMARY KEY,
+    event_id INTEGER NOT NULL REFERENCES events(id) ON DELETE CASCADE,
+    question VARCHAR(300) NOT NULL,
+    answer TEXT NOT NULL,
+    created_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS idx_event_faqs_event ON event_faqs(event_id, id);
+
+-- The terms of attending an event, shown on the event page and in order
+-- confirmation emails. A minimum_age of 0 means all ages.
+CREATE TABLE IF NOT EXISTS event_policies (
+    event_id INTEGER PRIMARY KEY REFERENCES events(id) ON DELETE CASCADE,
+    refund_policy TEXT NOT NULL DEFAULT '',
+    minimum_age INTEGER NOT NULL DEFAULT 0 CHECK (minimum_age >= 0),
+    accessibility_info TEXT NOT NULL DEFAULT '',
+    updated_at TIMESTAMP WITH TIME ZONE DEFAULT CURRENT_TIMESTAMP
+);
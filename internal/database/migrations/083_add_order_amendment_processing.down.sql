@@ -0,0 +1,4 @@
+UPDATE order_amendments SET status = 'completed' WHERE status = 'processing';
+ALTER TABLE order_amendments DROP CONSTRAINT IF EXISTS order_amendments_status_check;
+ALTER TABLE order_amendments ADD CONSTRAINT order_amendments_status_check
+    CHECK (status IN ('pending', 'completed', 'cancelled'));
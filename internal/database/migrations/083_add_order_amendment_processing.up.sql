@@ -0,0 +1,6 @@
+-- Amendments that cost less are 'processing' from when their tickets are
+-- changed until the difference has been refunded, so the amendment is
+-- claimed before any money moves
+ALTER TABLE order_amendments DROP CONSTRAINT IF EXISTS order_amendments_status_check;
+ALTER TABLE order_amendments ADD CONSTRAINT order_amendments_status_check
+    CHECK (status IN ('pending', 'processing', 'completed', 'cancelled'));
@@ -0,0 +1,135 @@
+package database
+
+import (
+	"slices"
+	"strings"
+	"testing"
+	"testing/fstest"
+)
+
+func versions(migrations []Migration) []int {
+	var list []int
+	for _, migration := range migrations {
+		list = append(list, migration.Version)
+	}
+	return list
+}
+
+func TestLoadMigrations(t *testing.T) {
+	files := fstest.MapFS{
+		"002_add_index.up.sql":      {Data: []byte("-- migrate:no-transaction\nCREATE INDEX CONCURRENTLY idx ON users(email);")},
+		"001_create_users.up.sql":   {Data: []byte("CREATE TABLE users (id SERIAL);")},
+		"001_create_users.down.sql": {Data: []byte("DROP TABLE users;")},
+		"README.md":                 {Data: []byte("not a migration")},
+		"notes_without_version.sql": {Data: []byte("SELECT 1;")},
+	}
+
+	migrations, err := loadMigrations(files)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(migrations) != 2 {
+		t.Fatalf("expected 2 migrations, got %v", versions(migrations))
+	}
+
+	users, index := migrations[0], migrations[1]
+	if users.Version != 1 || users.Name != "create_users" || users.DownSQL != "DROP TABLE users;" || users.NoTransaction {
+		t.Errorf("unexpected first migration: %+v", users)
+	}
+	if index.Version != 2 || index.DownSQL != "" || !index.NoTransaction {
+		t.Errorf("unexpected second migration: %+v", index)
+	}
+}
+
+func TestLoadMigrations_Errors(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"down without up": {
+			"001_create_users.down.sql": {Data: []byte("DROP TABLE users;")},
+		},
+		"mismatched names": {
+			"001_create_users.up.sql":  {Data: []byte("CREATE TABLE users (id SERIAL);")},
+			"001_create_people.up.sql": {Data: []byte("CREATE TABLE people (id SERIAL);")},
+		},
+	}
+	for name, files := range tests {
+		if _, err := loadMigrations(files); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestPlanTo(t *testing.T) {
+	migrations := []Migration{
+		{Version: 1, Name: "one", SQL: "up", DownSQL: "down"},
+		{Version: 2, Name: "two", SQL: "up", DownSQL: "down"},
+		{Version: 3, Name: "three", SQL: "up", DownSQL: "down"},
+	}
+
+	up, down, err := planTo(migrations, map[int]bool{1: true}, 3)
+	if err != nil || len(down) != 0 || !slices.Equal(versions(up), []int{2, 3}) {
+		t.Errorf("expected to apply 2 and 3, got up=%v down=%v err=%v", versions(up), versions(down), err)
+	}
+
+	up, down, err = planTo(migrations, map[int]bool{1: true, 2: true, 3: true}, 1)
+	if err != nil || len(up) != 0 || !slices.Equal(versions(down), []int{3, 2}) {
+		t.Errorf("expected to roll back 3 then 2, got up=%v down=%v err=%v", versions(up), versions(down), err)
+	}
+
+	// A gap left by a migration merged late is filled while rolling back newer ones
+	up, down, err = planTo(migrations, map[int]bool{1: true, 3: true}, 2)
+	if err != nil || !slices.Equal(versions(up), []int{2}) || !slices.Equal(versions(down), []int{3}) {
+		t.Errorf("expected to roll back 3 and apply 2, got up=%v down=%v err=%v", versions(up), versions(down), err)
+	}
+
+	up, down, err = planTo(migrations, map[int]bool{1: true, 2: true}, 0)
+	if err != nil || len(up) != 0 || !slices.Equal(versions(down), []int{2, 1}) {
+		t.Errorf("expected version 0 to roll everything back, got up=%v down=%v err=%v", versions(up), versions(down), err)
+	}
+
+	if _, _, err := planTo(migrations, nil, 7); err == nil {
+		t.Error("expected an error for an unknown version")
+	}
+	if _, _, err := planTo(migrations, map[int]bool{1: true, 9: true}, 1); err == nil || !strings.Contains(err.Error(), "newer release") {
+		t.Errorf("expected an error rolling back a migration without a file, got %v", err)
+	}
+}
+
+func TestPlanRollback(t *testing.T) {
+	migrations := []Migration{
+		{Version: 1, Name: "one", SQL: "up"},
+		{Version: 2, Name: "two", SQL: "up", DownSQL: "down"},
+		{Version: 3, Name: "three", SQL: "up", DownSQL: "down"},
+	}
+	applied := map[int]bool{1: true, 2: true, 3: true}
+
+	down, err := planRollback(migrations, applied, 2)
+	if err != nil || !slices.Equal(versions(down), []int{3, 2}) {
+		t.Errorf("expected to roll back 3 then 2, got %v, %v", versions(down), err)
+	}
+
+	if _, err := planRollback(migrations, applied, 3); err == nil || !strings.Contains(err.Error(), "no down migration") {
+		t.Errorf("expected an error rolling back an irreversible migration, got %v", err)
+	}
+	if _, err := planRollback(migrations, applied, 0); err == nil {
+		t.Error("expected an error for zero steps")
+	}
+}
+
+func TestEmbeddedMigrationsAreReversible(t *testing.T) {
+	migrations, err := NewMigrator(nil).LoadMigrations()
+	if err != nil {
+		t.Fatalf("failed to load embedded migrations: %v", err)
+	}
+	if len(migrations) == 0 {
+		t.Fatal("expected embedded migrations")
+	}
+
+	for i, migration := range migrations {
+		if migration.Version != i+1 {
+			t.Errorf("expected migration %d to be numbered %d", migration.Version, i+1)
+		}
+		if strings.TrimSpace(migration.DownSQL) == "" {
+			t.Errorf("migration %d (%s) has no down migration", migration.Version, migration.Name)
+		}
+	}
+}
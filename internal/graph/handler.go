@@ -0,0 +1,30 @@
+package graph
+
+import (
+	"net/http"
+
+	"github.com/99designs/gqlgen/graphql/handler"
+	"github.com/99designs/gqlgen/graphql/handler/extension"
+	"github.com/99designs/gqlgen/graphql/handler/lru"
+	"github.com/99designs/gqlgen/graphql/handler/transport"
+	"github.com/vektah/gqlparser/v2/ast"
+)
+
+// maxComplexity bounds how expensive a single query can be, so a partner
+// cannot ask for every order's tickets' ticket types in one request
+const maxComplexity = 500
+
+// NewHandler creates the HTTP handler for the GraphQL endpoint
+func NewHandler(resolver *Resolver) http.Handler {
+	server := handler.New(NewExecutableSchema(Config{Resolvers: resolver}))
+
+	server.AddTransport(transport.GET{})
+	server.AddTransport(transport.POST{})
+	server.SetQueryCache(lru.New[*ast.QueryDocument](1000))
+
+	server.Use(extension.Introspection{})
+	server.Use(extension.AutomaticPersistedQuery{Cache: lru.New[string](100)})
+	server.Use(extension.FixedComplexityLimit(maxComplexity))
+
+	return server
+}
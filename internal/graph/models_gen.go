@@ -0,0 +1,74 @@
+// Code generated by github.com/99designs/gqlgen, DO NOT EDIT.
+
+package graph
+
+import (
+	"event-ticketing-platform/internal/models"
+)
+
+type AttendeeInput struct {
+	// Printed on the ticket
+	Name  *string `json:"name,omitempty"`
+	Email *string `json:"email,omitempty"`
+	// Answers to the event's checkout questions
+	Answers []*CheckoutAnswerInput `json:"answers,omitempty"`
+}
+
+type CheckoutAnswerInput struct {
+	QuestionID int    `json:"questionId"`
+	Answer     string `json:"answer"`
+}
+
+type EventFilter struct {
+	Query *string `json:"query,omitempty"`
+	// Category name or slug
+	Category *string `json:"category,omitempty"`
+	Location *string `json:"location,omitempty"`
+	// YYYY-MM-DD
+	DateFrom *string `json:"dateFrom,omitempty"`
+	// YYYY-MM-DD
+	DateTo      *string `json:"dateTo,omitempty"`
+	OrganizerID *int    `json:"organizerId,omitempty"`
+}
+
+type EventPage struct {
+	Events  []*models.Event `json:"events"`
+	Total   int             `json:"total"`
+	Page    int             `json:"page"`
+	PerPage int             `json:"perPage"`
+}
+
+type Mutation struct {
+}
+
+type OrderPage struct {
+	Orders  []*models.Order `json:"orders"`
+	Total   int             `json:"total"`
+	Page    int             `json:"page"`
+	PerPage int             `json:"perPage"`
+}
+
+type PurchaseTicketsInput struct {
+	EventID int                     `json:"eventId"`
+	Tickets []*TicketSelectionInput `json:"tickets"`
+	// Defaults to the account's email
+	BillingEmail *string `json:"billingEmail,omitempty"`
+	// Defaults to the account's name
+	BillingName *string `json:"billingName,omitempty"`
+	// Language of the order emails, e.g. en
+	Locale *string `json:"locale,omitempty"`
+	// Who each ticket is for, in the order of the tickets. Either none or one
+	// per ticket, and needed when the event asks checkout questions.
+	Attendees []*AttendeeInput `json:"attendees,omitempty"`
+	// Makes the purchase safe to retry: a retry with the same key returns the
+	// order of the first instead of buying again
+	IdempotencyKey *string `json:"idempotencyKey,omitempty"`
+}
+
+type Query struct {
+}
+
+type TicketSelectionInput struct {
+	TicketTypeID int `json:"ticketTypeId"`
+	Quantity     int `json:"quantity"`
+}
@@ -0,0 +1,226 @@
+// Package graph serves the partner GraphQL API on top of the service layer.
+package graph
+
+//go:generate go run github.com/99designs/gqlgen generate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/repositories"
+	"event-ticketing-platform/internal/services"
+)
+
+// maxPerPage caps how many events or orders one query can page through
+const maxPerPage = 100
+
+// maxPurchaseTickets caps how many tickets one purchase checks attendees
+// for, well above any order limit the ticket service enforces
+const maxPurchaseTickets = 1000
+
+// orderConfirmationPath is where a completed purchase's order is shown,
+// which is recorded for retries with its idempotency key
+const orderConfirmationPath = "/orders/%d/confirmation"
+
+var (
+	errUnauthenticated   = errors.New("API token required")
+	errPurchasesDisabled = errors.New("ticket purchases are not available through the API on this deployment")
+)
+
+// EventReader is the part of the event service the API reads from
+type EventReader interface {
+	SearchEvents(filters services.EventSearchFilters) ([]*models.Event, int, error)
+	GetEventByID(id int) (*models.Event, error)
+	GetCategories() ([]*models.Category, error)
+}
+
+// TicketPurchaser is the part of the ticket service the API uses
+type TicketPurchaser interface {
+	GetTicketAvailability(eventID int) ([]*models.TicketType, error)
+	GetTicketTypeByID(id int) (*models.TicketType, error)
+	GetTicketsByOrderID(orderID int) ([]*models.Ticket, error)
+	PurchaseTickets(req *services.TicketPurchaseRequest) (*services.PurchaseResult, error)
+}
+
+// OrderReader is the part of the order service the API reads from
+type OrderReader interface {
+	GetUserOrders(userID int, limit, offset int) ([]*repositories.OrderWithDetails, int, error)
+	GetOrderByID(orderID int, requestingUserID int) (*models.Order, error)
+}
+
+// CheckoutQuestionReader is the part of the checkout question service
+// purchases are checked against
+type CheckoutQuestionReader interface {
+	GetQuestions(eventID int) ([]*models.CheckoutQuestion, error)
+}
+
+// Resolver resolves API queries as the account in the request context
+type Resolver struct {
+	events      EventReader
+	tickets     TicketPurchaser
+	orders      OrderReader
+	purchases   bool
+	questions   CheckoutQuestionReader
+	waitingRoom middleware.WaitingRoomGate
+	idempotency middleware.IdempotencyStore
+}
+
+// NewResolver creates a new resolver
+func NewResolver(events EventReader, tickets TicketPurchaser, orders OrderReader) *Resolver {
+	return &Resolver{
+		events:  events,
+		tickets: tickets,
+		orders:  orders,
+	}
+}
+
+// EnablePurchases lets the purchaseTickets mutation buy tickets. It is only
+// enabled where checkout settles payments directly, as the mutation can't
+// send the buyer to a payment page.
+func (r *Resolver) EnablePurchases() {
+	r.purchases = true
+}
+
+// SetCheckoutQuestions makes purchases answer the event's checkout
+// questions for each attendee, as the checkout form does
+func (r *Resolver) SetCheckoutQuestions(questions CheckoutQuestionReader) {
+	r.questions = questions
+}
+
+// SetWaitingRoom stops purchases for events whose waiting room is open, as
+// API clients can't queue in it
+func (r *Resolver) SetWaitingRoom(gate middleware.WaitingRoomGate) {
+	r.waitingRoom = gate
+}
+
+// SetIdempotencyStore makes purchases sent with an idempotency key safe to
+// retry
+func (r *Resolver) SetIdempotencyStore(store middleware.IdempotencyStore) {
+	r.idempotency = store
+}
+
+// purchaseOnce makes a purchase sent with an idempotency key. A retry with
+// the key gets the order of the first purchase instead of buying again.
+func (r *Resolver) purchaseOnce(user *models.User, key string, req *services.TicketPurchaseRequest) (*models.Order, error) {
+	if !models.ValidIdempotencyKey(key) {
+		return nil, errors.New("invalid idempotency key")
+	}
+
+	scope := models.IdempotencyScopeAPIPurchase
+	record, claimed, err := r.idempotency.Begin(scope, user.ID, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check idempotency key: %w", err)
+	}
+	if !claimed {
+		var orderID int
+		if !record.IsCompleted() {
+			return nil, errors.New("a purchase with this idempotency key is still being processed")
+		}
+		if _, err := fmt.Sscanf(record.ResponseLocation, orderConfirmationPath, &orderID); err != nil {
+			return nil, fmt.Errorf("failed to find the order of idempotency key: %w", err)
+		}
+		return r.orders.GetOrderByID(orderID, user.ID)
+	}
+
+	result, err := r.tickets.PurchaseTickets(req)
+	if err != nil {
+		if releaseErr := r.idempotency.Release(scope, user.ID, key); releaseErr != nil {
+			slog.Warn("failed to release idempotency key", "scope", scope, "error", releaseErr)
+		}
+		return nil, err
+	}
+	location := fmt.Sprintf(orderConfirmationPath, result.Order.ID)
+	if err := r.idempotency.Complete(scope, user.ID, key, http.StatusSeeOther, location); err != nil {
+		slog.Error("failed to record idempotent result", "scope", scope, "error", err)
+	}
+	return result.Order, nil
+}
+
+// purchaseAttendees checks who each of a purchase's tickets is for and their
+// answers to the event's checkout questions, as the checkout form does.
+// Attendees are nil when no ticket was named, and answers when the event
+// asks no questions.
+func purchaseAttendees(input []*AttendeeInput, tickets int, questions []*models.CheckoutQuestion) ([]models.TicketAttendee, []models.AttendeeAnswers, error) {
+	if len(input) > 0 && len(input) != tickets {
+		return nil, nil, fmt.Errorf("expected one attendee per ticket, %d in all", tickets)
+	}
+
+	var attendees []models.TicketAttendee
+	var answers []models.AttendeeAnswers
+	named := false
+	for i := 0; i < tickets; i++ {
+		given := map[int]string{}
+		if len(input) > 0 {
+			attendee := models.TicketAttendee{Name: deref(input[i].Name), Email: deref(input[i].Email)}
+			if err := attendee.Validate(); err != nil {
+				return nil, nil, fmt.Errorf("attendee %d: %w", i+1, err)
+			}
+			named = named || !attendee.IsEmpty()
+			attendees = append(attendees, attendee)
+			for _, answer := range input[i].Answers {
+				given[answer.QuestionID] = answer.Answer
+			}
+		}
+
+		if len(questions) == 0 {
+			continue
+		}
+		attendeeAnswers := models.AttendeeAnswers{}
+		for _, question := range questions {
+			answer, err := question.CheckAnswer(given[question.ID])
+			if err != nil {
+				return nil, nil, fmt.Errorf("attendee %d: %w", i+1, err)
+			}
+			attendeeAnswers[question.ID] = answer
+		}
+		answers = append(answers, attendeeAnswers)
+	}
+
+	if !named {
+		attendees = nil
+	}
+	return attendees, answers, nil
+}
+
+// viewer returns the account the request's API token belongs to
+func viewer(ctx context.Context) (*models.User, error) {
+	user := middleware.GetUserFromContext(ctx)
+	if user == nil {
+		return nil, errUnauthenticated
+	}
+	return user, nil
+}
+
+// canView returns true if the user may see the event: anyone can see
+// published events, organizers their own and admins every event
+func canView(user *models.User, event *models.Event) bool {
+	return event.IsPublished() || event.OrganizerID == user.ID || user.Role == models.UserRoleAdmin
+}
+
+// pagination clamps a requested page to valid bounds
+func pagination(page, perPage *int) (int, int) {
+	p, pp := 1, 20
+	if page != nil && *page > 0 {
+		p = *page
+	}
+	if perPage != nil && *perPage > 0 {
+		pp = *perPage
+	}
+	if pp > maxPerPage {
+		pp = maxPerPage
+	}
+	return p, pp
+}
+
+// deref returns the value of an optional string argument
+func deref(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,448 @@
+package graph
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/repositories"
+	"event-ticketing-platform/internal/services"
+)
+
+type fakeEvents struct {
+	events      map[int]*models.Event
+	lastFilters services.EventSearchFilters
+}
+
+func (f *fakeEvents) SearchEvents(filters services.EventSearchFilters) ([]*models.Event, int, error) {
+	f.lastFilters = filters
+	var events []*models.Event
+	for _, event := range f.events {
+		if event.IsPublished() {
+			events = append(events, event)
+		}
+	}
+	return events, len(events), nil
+}
+
+func (f *fakeEvents) GetEventByID(id int) (*models.Event, error) {
+	if event, ok := f.events[id]; ok {
+		return event, nil
+	}
+	return nil, errors.New("event not found")
+}
+
+func (f *fakeEvents) GetCategories() ([]*models.Category, error) {
+	return []*models.Category{{ID: 1, Name: "Music", Slug: "music"}}, nil
+}
+
+type fakeTickets struct {
+	ticketTypes map[int]*models.TicketType
+	purchases   []*services.TicketPurchaseRequest
+}
+
+func (f *fakeTickets) GetTicketAvailability(eventID int) ([]*models.TicketType, error) {
+	var ticketTypes []*models.TicketType
+	for _, ticketType := range f.ticketTypes {
+		if ticketType.EventID == eventID {
+			ticketTypes = append(ticketTypes, ticketType)
+		}
+	}
+	return ticketTypes, nil
+}
+
+func (f *fakeTickets) GetTicketTypeByID(id int) (*models.TicketType, error) {
+	if ticketType, ok := f.ticketTypes[id]; ok {
+		return ticketType, nil
+	}
+	return nil, errors.New("ticket type not found")
+}
+
+func (f *fakeTickets) GetTicketsByOrderID(orderID int) ([]*models.Ticket, error) {
+	return []*models.Ticket{{ID: 1, OrderID: orderID, TicketTypeID: 10, QRCode: "QR-1", Status: models.TicketActive}}, nil
+}
+
+func (f *fakeTickets) PurchaseTickets(req *services.TicketPurchaseRequest) (*services.PurchaseResult, error) {
+	f.purchases = append(f.purchases, req)
+	return &services.PurchaseResult{
+		Order: &models.Order{ID: 99, UserID: req.UserID, EventID: req.EventID, OrderNumber: "ORD-99", Status: models.OrderCompleted},
+	}, nil
+}
+
+type fakeOrders struct {
+	orders map[int]*models.Order
+}
+
+func (f *fakeOrders) GetUserOrders(userID int, limit, offset int) ([]*repositories.OrderWithDetails, int, error) {
+	var orders []*repositories.OrderWithDetails
+	for _, order := range f.orders {
+		if order.UserID == userID {
+			orders = append(orders, &repositories.OrderWithDetails{Order: order})
+		}
+	}
+	return orders, len(orders), nil
+}
+
+func (f *fakeOrders) GetOrderByID(orderID int, requestingUserID int) (*models.Order, error) {
+	order, ok := f.orders[orderID]
+	if !ok || order.UserID != requestingUserID {
+		return nil, errors.New("order not found")
+	}
+	return order, nil
+}
+
+type fakeQuestions struct {
+	questions map[int][]*models.CheckoutQuestion
+}
+
+func (f *fakeQuestions) GetQuestions(eventID int) ([]*models.CheckoutQuestion, error) {
+	return f.questions[eventID], nil
+}
+
+type fakeWaitingRoom struct {
+	queued map[int]bool
+}
+
+func (f *fakeWaitingRoom) IsAdmitted(eventID int, token string) (bool, error) {
+	return !f.queued[eventID], nil
+}
+
+type fakeIdempotencyStore struct {
+	records map[string]*models.IdempotencyRecord
+}
+
+func (f *fakeIdempotencyStore) Begin(scope string, userID int, key string) (*models.IdempotencyRecord, bool, error) {
+	if record, ok := f.records[key]; ok {
+		return record, false, nil
+	}
+	f.records[key] = &models.IdempotencyRecord{Scope: scope, UserID: userID, Key: key, Status: models.IdempotencyProcessing}
+	return f.records[key], true, nil
+}
+
+func (f *fakeIdempotencyStore) Complete(scope string, userID int, key string, status int, location string) error {
+	f.records[key].Status = models.IdempotencyCompleted
+	f.records[key].ResponseStatus = status
+	f.records[key].ResponseLocation = location
+	return nil
+}
+
+func (f *fakeIdempotencyStore) Release(scope string, userID int, key string) error {
+	delete(f.records, key)
+	return nil
+}
+
+type graphResponse struct {
+	Data   map[string]json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func setupResolver() (http.Handler, *fakeEvents, *fakeTickets) {
+	resolver, events, tickets := newTestResolver()
+	return NewHandler(resolver), events, tickets
+}
+
+// newTestResolver creates a resolver over fake services that buys tickets
+// without checking them against the checkout
+func newTestResolver() (*Resolver, *fakeEvents, *fakeTickets) {
+	events := &fakeEvents{events: map[int]*models.Event{
+		1: {ID: 1, Title: "Live Show", Slug: "live-show", CategoryID: 1, OrganizerID: 5, Status: models.StatusPublished},
+		2: {ID: 2, Title: "Draft Show", OrganizerID: 5, Status: models.StatusDraft},
+	}}
+	tickets := &fakeTickets{ticketTypes: map[int]*models.TicketType{
+		10: {ID: 10, EventID: 1, Name: "General", Price: 1500, Quantity: 100, Sold: 40},
+		20: {ID: 20, EventID: 2, Name: "Early", Price: 500, Quantity: 10},
+	}}
+	orders := &fakeOrders{orders: map[int]*models.Order{
+		7: {ID: 7, UserID: 3, EventID: 1, OrderNumber: "ORD-7", Status: models.OrderCompleted, TotalAmount: 1500},
+		8: {ID: 8, UserID: 4, EventID: 1, OrderNumber: "ORD-8", Status: models.OrderCompleted},
+	}}
+	resolver := NewResolver(events, tickets, orders)
+	resolver.EnablePurchases()
+	return resolver, events, tickets
+}
+
+func execute(t *testing.T, h http.Handler, user *models.User, query string, variables map[string]interface{}) graphResponse {
+	t.Helper()
+
+	body, _ := json.Marshal(map[string]interface{}{"query": query, "variables": variables})
+	req := httptest.NewRequest("POST", "/graphql", strings.NewReader(string(body)))
+	req.Header.Set("Content-Type", "application/json")
+	if user != nil {
+		req = req.WithContext(middleware.SetUserContext(req.Context(), user))
+	}
+
+	rr := httptest.NewRecorder()
+	h.ServeHTTP(rr, req)
+
+	var resp graphResponse
+	if err := json.Unmarshal(rr.Body.Bytes(), &resp); err != nil {
+		t.Fatalf("invalid response %q: %v", rr.Body.String(), err)
+	}
+	return resp
+}
+
+func TestQueryEvents(t *testing.T) {
+	h, events, _ := setupResolver()
+	buyer := &models.User{ID: 3, Role: models.UserRoleUser}
+
+	resp := execute(t, h, buyer, `{
+		events(filter: {query: "show", category: "music"}, perPage: 500) {
+			total perPage
+			events { id title status path category { name } ticketTypes { id price available isOnSale } }
+		}
+	}`, nil)
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors: %+v", resp.Errors)
+	}
+
+	var page struct {
+		Total   int `json:"total"`
+		PerPage int `json:"perPage"`
+		Events  []struct {
+			ID          string `json:"id"`
+			Status      string `json:"status"`
+			Path        string `json:"path"`
+			Category    struct{ Name string }
+			TicketTypes []struct {
+				ID        string `json:"id"`
+				Available int    `json:"available"`
+			} `json:"ticketTypes"`
+		} `json:"events"`
+	}
+	json.Unmarshal(resp.Data["events"], &page)
+
+	if page.Total != 1 || len(page.Events) != 1 {
+		t.Fatalf("expected only the published event, got %+v", page)
+	}
+	event := page.Events[0]
+	if event.ID != "1" || event.Status != "published" || event.Path != "/events/live-show" || event.Category.Name != "Music" {
+		t.Errorf("unexpected event %+v", event)
+	}
+	if len(event.TicketTypes) != 1 || event.TicketTypes[0].Available != 60 {
+		t.Errorf("expected the event's ticket type with 60 left, got %+v", event.TicketTypes)
+	}
+	if page.PerPage != maxPerPage || events.lastFilters.PerPage != maxPerPage {
+		t.Errorf("expected page size capped at %d, got %d", maxPerPage, page.PerPage)
+	}
+	if events.lastFilters.Query != "show" || events.lastFilters.Category != "music" {
+		t.Errorf("expected filter to be passed through, got %+v", events.lastFilters)
+	}
+}
+
+func TestQueryEventHidesUnpublishedEvents(t *testing.T) {
+	h, _, _ := setupResolver()
+	query := `query($id: ID!) { event(id: $id) { title } }`
+
+	resp := execute(t, h, &models.User{ID: 3, Role: models.UserRoleUser}, query, map[string]interface{}{"id": "2"})
+	if string(resp.Data["event"]) != "null" {
+		t.Errorf("expected draft to be hidden from other accounts, got %s", resp.Data["event"])
+	}
+
+	resp = execute(t, h, &models.User{ID: 5, Role: models.UserRoleOrganizer}, query, map[string]interface{}{"id": "2"})
+	if !strings.Contains(string(resp.Data["event"]), "Draft Show") {
+		t.Errorf("expected organizer to see their draft, got %s", resp.Data["event"])
+	}
+}
+
+func TestQueryOrders(t *testing.T) {
+	h, _, _ := setupResolver()
+	buyer := &models.User{ID: 3, Role: models.UserRoleUser}
+
+	resp := execute(t, h, buyer, `{
+		orders { total orders { orderNumber status event { title } tickets { qrCode ticketType { name } } } }
+		other: order(id: 8) { orderNumber }
+	}`, nil)
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors: %+v", resp.Errors)
+	}
+
+	orders := string(resp.Data["orders"])
+	for _, want := range []string{`"total":1`, `"ORD-7"`, `"completed"`, `"Live Show"`, `"QR-1"`, `"General"`} {
+		if !strings.Contains(orders, want) {
+			t.Errorf("expected %s in orders, got %s", want, orders)
+		}
+	}
+	if string(resp.Data["other"]) != "null" {
+		t.Errorf("expected another account's order to be hidden, got %s", resp.Data["other"])
+	}
+}
+
+func TestMutationPurchaseTickets(t *testing.T) {
+	h, _, tickets := setupResolver()
+	buyer := &models.User{ID: 3, Email: "buyer@example.com", FirstName: "Ann", LastName: "Buyer", Role: models.UserRoleUser}
+	mutation := `mutation($input: PurchaseTicketsInput!) { purchaseTickets(input: $input) { id orderNumber status } }`
+
+	resp := execute(t, h, buyer, mutation, map[string]interface{}{"input": map[string]interface{}{
+		"eventId": "1",
+		"tickets": []map[string]interface{}{{"ticketTypeId": "10", "quantity": 2}},
+	}})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors: %+v", resp.Errors)
+	}
+	if !strings.Contains(string(resp.Data["purchaseTickets"]), `"ORD-99"`) {
+		t.Errorf("expected the completed order, got %s", resp.Data["purchaseTickets"])
+	}
+
+	if len(tickets.purchases) != 1 {
+		t.Fatalf("expected one purchase, got %d", len(tickets.purchases))
+	}
+	req := tickets.purchases[0]
+	if req.UserID != 3 || req.EventID != 1 || req.TicketSelections[0].Quantity != 2 {
+		t.Errorf("unexpected purchase request %+v", req)
+	}
+	if req.BillingInfo.Email != "buyer@example.com" || req.BillingInfo.Name != "Ann Buyer" {
+		t.Errorf("expected billing to default to the account, got %+v", req.BillingInfo)
+	}
+
+	// Ticket types from another event and unpublished events are rejected
+	for _, input := range []map[string]interface{}{
+		{"eventId": "1", "tickets": []map[string]interface{}{{"ticketTypeId": "20", "quantity": 1}}},
+		{"eventId": "2", "tickets": []map[string]interface{}{{"ticketTypeId": "20", "quantity": 1}}},
+	} {
+		resp := execute(t, h, buyer, mutation, map[string]interface{}{"input": input})
+		if len(resp.Errors) == 0 {
+			t.Errorf("expected error for %v", input)
+		}
+	}
+	if len(tickets.purchases) != 1 {
+		t.Errorf("expected rejected purchases not to reach the ticket service, got %d", len(tickets.purchases))
+	}
+}
+
+func TestMutationPurchaseTicketsNeedsPurchasesEnabled(t *testing.T) {
+	resolver, _, tickets := newTestResolver()
+	resolver.purchases = false
+	buyer := &models.User{ID: 3, Email: "buyer@example.com", Role: models.UserRoleUser}
+
+	resp := execute(t, NewHandler(resolver), buyer, `mutation($input: PurchaseTicketsInput!) { purchaseTickets(input: $input) { id } }`,
+		map[string]interface{}{"input": map[string]interface{}{
+			"eventId": "1",
+			"tickets": []map[string]interface{}{{"ticketTypeId": "10", "quantity": 1}},
+		}})
+	if len(resp.Errors) == 0 || resp.Errors[0].Message != errPurchasesDisabled.Error() {
+		t.Errorf("expected purchases to be disabled, got %+v", resp.Errors)
+	}
+	if len(tickets.purchases) != 0 {
+		t.Errorf("expected no purchase, got %d", len(tickets.purchases))
+	}
+}
+
+func TestMutationPurchaseTicketsChecksTheCheckout(t *testing.T) {
+	resolver, events, tickets := newTestResolver()
+	events.events[3] = &models.Event{ID: 3, Title: "Queued Show", OrganizerID: 5, Status: models.StatusPublished}
+	tickets.ticketTypes[30] = &models.TicketType{ID: 30, EventID: 3, Name: "General", Price: 1500, Quantity: 100}
+	resolver.SetWaitingRoom(&fakeWaitingRoom{queued: map[int]bool{3: true}})
+	resolver.SetCheckoutQuestions(&fakeQuestions{questions: map[int][]*models.CheckoutQuestion{
+		1: {{ID: 4, EventID: 1, Label: "T-shirt size", Type: models.QuestionTypeSelect, Options: []string{"S", "M"}, Required: true}},
+	}})
+	h := NewHandler(resolver)
+	buyer := &models.User{ID: 3, Email: "buyer@example.com", Role: models.UserRoleUser}
+	mutation := `mutation($input: PurchaseTicketsInput!) { purchaseTickets(input: $input) { id } }`
+
+	attendee := func(name, size string) map[string]interface{} {
+		return map[string]interface{}{"name": name, "answers": []map[string]interface{}{{"questionId": "4", "answer": size}}}
+	}
+	tests := []struct {
+		name      string
+		input     map[string]interface{}
+		wantError string
+	}{
+		{
+			name: "event with an open waiting room",
+			input: map[string]interface{}{"eventId": "3",
+				"tickets": []map[string]interface{}{{"ticketTypeId": "30", "quantity": 1}}},
+			wantError: "waiting room",
+		},
+		{
+			name: "required question unanswered",
+			input: map[string]interface{}{"eventId": "1",
+				"tickets": []map[string]interface{}{{"ticketTypeId": "10", "quantity": 1}}},
+			wantError: "T-shirt size is required",
+		},
+		{
+			name: "attendee missing for a ticket",
+			input: map[string]interface{}{"eventId": "1",
+				"tickets":   []map[string]interface{}{{"ticketTypeId": "10", "quantity": 2}},
+				"attendees": []map[string]interface{}{attendee("Ann", "S")}},
+			wantError: "one attendee per ticket",
+		},
+		{
+			name: "answer that isn't an option",
+			input: map[string]interface{}{"eventId": "1",
+				"tickets":   []map[string]interface{}{{"ticketTypeId": "10", "quantity": 1}},
+				"attendees": []map[string]interface{}{attendee("Ann", "XXL")}},
+			wantError: "choose one of the options",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := execute(t, h, buyer, mutation, map[string]interface{}{"input": tt.input})
+			if len(resp.Errors) == 0 || !strings.Contains(resp.Errors[0].Message, tt.wantError) {
+				t.Errorf("expected error containing %q, got %+v", tt.wantError, resp.Errors)
+			}
+		})
+	}
+	if len(tickets.purchases) != 0 {
+		t.Fatalf("expected rejected purchases not to reach the ticket service, got %d", len(tickets.purchases))
+	}
+
+	resp := execute(t, h, buyer, mutation, map[string]interface{}{"input": map[string]interface{}{"eventId": "1",
+		"tickets":   []map[string]interface{}{{"ticketTypeId": "10", "quantity": 2}},
+		"attendees": []map[string]interface{}{attendee("Ann", "S"), attendee("Ben", "M")}}})
+	if len(resp.Errors) > 0 {
+		t.Fatalf("unexpected errors: %+v", resp.Errors)
+	}
+	if len(tickets.purchases) != 1 {
+		t.Fatalf("expected one purchase, got %d", len(tickets.purchases))
+	}
+	req := tickets.purchases[0]
+	if len(req.Attendees) != 2 || req.Attendees[1].Name != "Ben" {
+		t.Errorf("expected both attendees to be named, got %+v", req.Attendees)
+	}
+	if len(req.Answers) != 2 || req.Answers[0][4] != "S" || req.Answers[1][4] != "M" {
+		t.Errorf("expected each attendee's answers, got %+v", req.Answers)
+	}
+}
+
+func TestMutationPurchaseTicketsWithIdempotencyKey(t *testing.T) {
+	resolver, _, tickets := newTestResolver()
+	resolver.SetIdempotencyStore(&fakeIdempotencyStore{records: make(map[string]*models.IdempotencyRecord)})
+	resolver.orders.(*fakeOrders).orders[99] = &models.Order{ID: 99, UserID: 3, EventID: 1, OrderNumber: "ORD-99", Status: models.OrderCompleted}
+	h := NewHandler(resolver)
+	buyer := &models.User{ID: 3, Email: "buyer@example.com", Role: models.UserRoleUser}
+	mutation := `mutation($input: PurchaseTicketsInput!) { purchaseTickets(input: $input) { orderNumber } }`
+	input := map[string]interface{}{
+		"eventId":        "1",
+		"tickets":        []map[string]interface{}{{"ticketTypeId": "10", "quantity": 1}},
+		"idempotencyKey": "purchase-1",
+	}
+
+	for i := 0; i < 2; i++ {
+		resp := execute(t, h, buyer, mutation, map[string]interface{}{"input": input})
+		if len(resp.Errors) > 0 {
+			t.Fatalf("unexpected errors: %+v", resp.Errors)
+		}
+		if !strings.Contains(string(resp.Data["purchaseTickets"]), `"ORD-99"`) {
+			t.Errorf("expected the first purchase's order, got %s", resp.Data["purchaseTickets"])
+		}
+	}
+	if len(tickets.purchases) != 1 {
+		t.Errorf("expected the retry not to buy again, got %d purchases", len(tickets.purchases))
+	}
+}
+
+func TestQueriesRequireAnAccount(t *testing.T) {
+	h, _, _ := setupResolver()
+
+	resp := execute(t, h, nil, `{ viewer { email } }`, nil)
+	if len(resp.Errors) == 0 || !strings.Contains(resp.Errors[0].Message, "API token required") {
+		t.Errorf("expected authentication error, got %+v", resp.Errors)
+	}
+}
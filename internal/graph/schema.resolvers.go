@@ -0,0 +1,263 @@
+package graph
+
+// This file will be automatically regenerated based on the schema, any resolver implementations
+// will be copied through when generating and any unknown code will be moved to the end.
+// Code generated by github.com/99designs/gqlgen version v0.17.78
+
+import (
+	"context"
+	"event-ticketing-platform/internal/i18n"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"fmt"
+)
+
+// Status is the resolver for the status field.
+func (r *eventResolver) Status(ctx context.Context, obj *models.Event) (string, error) {
+	return string(obj.Status), nil
+}
+
+// Category is the resolver for the category field.
+func (r *eventResolver) Category(ctx context.Context, obj *models.Event) (*models.Category, error) {
+	if obj.Category != nil {
+		return obj.Category, nil
+	}
+
+	categories, err := r.events.GetCategories()
+	if err != nil {
+		return nil, err
+	}
+	for _, category := range categories {
+		if category.ID == obj.CategoryID {
+			return category, nil
+		}
+	}
+	return nil, nil
+}
+
+// TicketTypes is the resolver for the ticketTypes field.
+func (r *eventResolver) TicketTypes(ctx context.Context, obj *models.Event) ([]*models.TicketType, error) {
+	return r.tickets.GetTicketAvailability(obj.ID)
+}
+
+// PurchaseTickets is the resolver for the purchaseTickets field.
+func (r *mutationResolver) PurchaseTickets(ctx context.Context, input PurchaseTicketsInput) (*models.Order, error) {
+	user, err := viewer(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if !r.purchases {
+		return nil, errPurchasesDisabled
+	}
+
+	event, err := r.events.GetEventByID(input.EventID)
+	if err != nil || !event.IsPublished() {
+		return nil, fmt.Errorf("event not found")
+	}
+	if r.waitingRoom != nil {
+		admitted, err := r.waitingRoom.IsAdmitted(event.ID, "")
+		if err != nil {
+			return nil, fmt.Errorf("failed to check the waiting room: %w", err)
+		}
+		if !admitted {
+			return nil, fmt.Errorf("tickets for this event are sold through its waiting room")
+		}
+	}
+
+	req := &services.TicketPurchaseRequest{
+		EventID:       event.ID,
+		UserID:        user.ID,
+		PaymentMethod: "card",
+		BillingInfo: services.PaymentBillingInfo{
+			Email:       user.Email,
+			Name:        user.FullName(),
+			PaymentType: "card",
+		},
+	}
+	if input.BillingEmail != nil && *input.BillingEmail != "" {
+		req.BillingInfo.Email = *input.BillingEmail
+	}
+	if input.BillingName != nil && *input.BillingName != "" {
+		req.BillingInfo.Name = *input.BillingName
+	}
+	if input.Locale != nil {
+		req.Locale = i18n.Normalize(*input.Locale)
+	}
+
+	ticketCount := 0
+	for _, selection := range input.Tickets {
+		ticketType, err := r.tickets.GetTicketTypeByID(selection.TicketTypeID)
+		if err != nil || ticketType.EventID != event.ID {
+			return nil, fmt.Errorf("ticket type %d is not sold for this event", selection.TicketTypeID)
+		}
+		if selection.Quantity <= 0 || selection.Quantity > maxPurchaseTickets-ticketCount {
+			return nil, fmt.Errorf("quantity of ticket type %d is out of range", selection.TicketTypeID)
+		}
+		req.TicketSelections = append(req.TicketSelections, services.TicketSelection{
+			TicketTypeID: selection.TicketTypeID,
+			Quantity:     selection.Quantity,
+		})
+		ticketCount += selection.Quantity
+	}
+	if len(req.TicketSelections) == 0 {
+		return nil, fmt.Errorf("at least one ticket is required")
+	}
+
+	var questions []*models.CheckoutQuestion
+	if r.questions != nil {
+		if questions, err = r.questions.GetQuestions(event.ID); err != nil {
+			return nil, fmt.Errorf("failed to load checkout questions: %w", err)
+		}
+	}
+	if req.Attendees, req.Answers, err = purchaseAttendees(input.Attendees, ticketCount, questions); err != nil {
+		return nil, err
+	}
+
+	if key := deref(input.IdempotencyKey); key != "" && r.idempotency != nil {
+		return r.purchaseOnce(user, key, req)
+	}
+	result, err := r.tickets.PurchaseTickets(req)
+	if err != nil {
+		return nil, err
+	}
+	return result.Order, nil
+}
+
+// Status is the resolver for the status field.
+func (r *orderResolver) Status(ctx context.Context, obj *models.Order) (string, error) {
+	return string(obj.Status), nil
+}
+
+// Event is the resolver for the event field.
+func (r *orderResolver) Event(ctx context.Context, obj *models.Order) (*models.Event, error) {
+	return r.events.GetEventByID(obj.EventID)
+}
+
+// Tickets is the resolver for the tickets field.
+func (r *orderResolver) Tickets(ctx context.Context, obj *models.Order) ([]*models.Ticket, error) {
+	return r.tickets.GetTicketsByOrderID(obj.ID)
+}
+
+// Events is the resolver for the events field.
+func (r *queryResolver) Events(ctx context.Context, filter *EventFilter, page *int, perPage *int) (*EventPage, error) {
+	if _, err := viewer(ctx); err != nil {
+		return nil, err
+	}
+
+	p, pp := pagination(page, perPage)
+	filters := services.EventSearchFilters{Page: p, PerPage: pp}
+	if filter != nil {
+		filters.Query = deref(filter.Query)
+		filters.Category = deref(filter.Category)
+		filters.Location = deref(filter.Location)
+		filters.DateFrom = deref(filter.DateFrom)
+		filters.DateTo = deref(filter.DateTo)
+		if filter.OrganizerID != nil {
+			filters.OrganizerID = *filter.OrganizerID
+		}
+	}
+
+	events, total, err := r.events.SearchEvents(filters)
+	if err != nil {
+		return nil, err
+	}
+	return &EventPage{Events: events, Total: total, Page: p, PerPage: pp}, nil
+}
+
+// Event is the resolver for the event field.
+func (r *queryResolver) Event(ctx context.Context, id int) (*models.Event, error) {
+	user, err := viewer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	event, err := r.events.GetEventByID(id)
+	if err != nil || !canView(user, event) {
+		return nil, nil
+	}
+	return event, nil
+}
+
+// Categories is the resolver for the categories field.
+func (r *queryResolver) Categories(ctx context.Context) ([]*models.Category, error) {
+	return r.events.GetCategories()
+}
+
+// Orders is the resolver for the orders field.
+func (r *queryResolver) Orders(ctx context.Context, page *int, perPage *int) (*OrderPage, error) {
+	user, err := viewer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	p, pp := pagination(page, perPage)
+	details, total, err := r.orders.GetUserOrders(user.ID, pp, (p-1)*pp)
+	if err != nil {
+		return nil, err
+	}
+
+	orders := make([]*models.Order, 0, len(details))
+	for _, detail := range details {
+		orders = append(orders, detail.Order)
+	}
+	return &OrderPage{Orders: orders, Total: total, Page: p, PerPage: pp}, nil
+}
+
+// Order is the resolver for the order field.
+func (r *queryResolver) Order(ctx context.Context, id int) (*models.Order, error) {
+	user, err := viewer(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	order, err := r.orders.GetOrderByID(id, user.ID)
+	if err != nil {
+		return nil, nil
+	}
+	return order, nil
+}
+
+// Viewer is the resolver for the viewer field.
+func (r *queryResolver) Viewer(ctx context.Context) (*models.User, error) {
+	return viewer(ctx)
+}
+
+// Status is the resolver for the status field.
+func (r *ticketResolver) Status(ctx context.Context, obj *models.Ticket) (string, error) {
+	return string(obj.Status), nil
+}
+
+// TicketType is the resolver for the ticketType field.
+func (r *ticketResolver) TicketType(ctx context.Context, obj *models.Ticket) (*models.TicketType, error) {
+	return r.tickets.GetTicketTypeByID(obj.TicketTypeID)
+}
+
+// Role is the resolver for the role field.
+func (r *viewerResolver) Role(ctx context.Context, obj *models.User) (string, error) {
+	return string(obj.Role), nil
+}
+
+// Event returns EventResolver implementation.
+func (r *Resolver) Event() EventResolver { return &eventResolver{r} }
+
+// Mutation returns MutationResolver implementation.
+func (r *Resolver) Mutation() MutationResolver { return &mutationResolver{r} }
+
+// Order returns OrderResolver implementation.
+func (r *Resolver) Order() OrderResolver { return &orderResolver{r} }
+
+// Query returns QueryResolver implementation.
+func (r *Resolver) Query() QueryResolver { return &queryResolver{r} }
+
+// Ticket returns TicketResolver implementation.
+func (r *Resolver) Ticket() TicketResolver { return &ticketResolver{r} }
+
+// Viewer returns ViewerResolver implementation.
+func (r *Resolver) Viewer() ViewerResolver { return &viewerResolver{r} }
+
+type eventResolver struct{ *Resolver }
+type mutationResolver struct{ *Resolver }
+type orderResolver struct{ *Resolver }
+type queryResolver struct{ *Resolver }
+type ticketResolver struct{ *Resolver }
+type viewerResolver struct{ *Resolver }
@@ -148,14 +148,14 @@ func (h *AdminHandler) UpdateUserRole(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Update user role
-	err = h.userService.UpdateUserRole(userID, role)
+	err = h.userService.UpdateUserRole(user.ID, userID, role, r)
 	if err != nil {
 		http.Error(w, "Failed to update user role", http.StatusInternalServerError)
 		return
 	}
 
-	// Redirect back to user management
-	http.Redirect(w, r, "/admin/users", http.StatusSeeOther)
+	// Redirect back to user management, or the user's page if they acted from it
+	http.Redirect(w, r, userManagementReturnPath(r, userID), http.StatusSeeOther)
 }
 
 // SuspendUser handles user account suspension
@@ -178,14 +178,14 @@ func (h *AdminHandler) SuspendUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Suspend user
-	err = h.userService.SuspendUser(userID)
+	err = h.userService.SuspendUser(user.ID, userID, r)
 	if err != nil {
 		http.Error(w, "Failed to suspend user", http.StatusInternalServerError)
 		return
 	}
 
-	// Redirect back to user management
-	http.Redirect(w, r, "/admin/users", http.StatusSeeOther)
+	// Redirect back to user management, or the user's page if they acted from it
+	http.Redirect(w, r, userManagementReturnPath(r, userID), http.StatusSeeOther)
 }
 
 // ActivateUser handles user account activation
@@ -208,14 +208,24 @@ func (h *AdminHandler) ActivateUser(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Activate user
-	err = h.userService.ActivateUser(userID)
+	err = h.userService.ActivateUser(user.ID, userID, r)
 	if err != nil {
 		http.Error(w, "Failed to activate user", http.StatusInternalServerError)
 		return
 	}
 
-	// Redirect back to user management
-	http.Redirect(w, r, "/admin/users", http.StatusSeeOther)
+	// Redirect back to user management, or the user's page if they acted from it
+	http.Redirect(w, r, userManagementReturnPath(r, userID), http.StatusSeeOther)
+}
+
+// userManagementReturnPath returns where to send an admin after changing a
+// user: back to the user's admin page when they acted from it, otherwise to
+// user management
+func userManagementReturnPath(r *http.Request, userID int) string {
+	if r.FormValue("return_to") == "detail" {
+		return adminUserPath(userID)
+	}
+	return "/admin/users"
 }
 
 // getSystemStats retrieves system statistics for the dashboard
@@ -436,4 +446,20 @@ func (h *AdminHandler) DeleteCategory(w http.ResponseWriter, r *http.Request) {
 	// Delete category (we'll need to add this to the service)
 	// For now, redirect back to category management
 	http.Redirect(w, r, "/admin/categories", http.StatusSeeOther)
-}
\ No newline at end of file
+}
+
+// requireAdmin returns the signed-in admin, writing an error response otherwise
+func requireAdmin(w http.ResponseWriter, r *http.Request) (*models.User, bool) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return nil, false
+	}
+
+	if user.Role != models.UserRoleAdmin {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return nil, false
+	}
+
+	return user, true
+}
@@ -0,0 +1,140 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+)
+
+// adminEventsPageSize is how many events the admin event list shows per page
+const adminEventsPageSize = 50
+
+// AdminBulkHandler handles the admin event list and bulk actions on users
+// and events
+type AdminBulkHandler struct {
+	bulkService       *services.AdminBulkService
+	moderationService *services.EventModerationService
+	eventService      services.EventServiceInterface
+}
+
+// NewAdminBulkHandler creates a new admin bulk handler
+func NewAdminBulkHandler(bulkService *services.AdminBulkService, moderationService *services.EventModerationService, eventService services.EventServiceInterface) *AdminBulkHandler {
+	return &AdminBulkHandler{
+		bulkService:       bulkService,
+		moderationService: moderationService,
+		eventService:      eventService,
+	}
+}
+
+// EventsPage handles GET /admin/events, listing events of a status, published
+// by default, for admins to select for bulk actions
+func (h *AdminBulkHandler) EventsPage(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	search := strings.TrimSpace(query.Get("q"))
+	status := models.EventStatus(query.Get("status"))
+	if status == "" {
+		status = models.StatusPublished
+	}
+	categoryID, _ := strconv.Atoi(query.Get("category"))
+	page, _ := strconv.Atoi(query.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+
+	events, total, err := h.moderationService.GetAdminEvents(search, status, categoryID, page, adminEventsPageSize)
+	if err != nil {
+		http.Error(w, "Failed to load events", http.StatusInternalServerError)
+		return
+	}
+
+	categories, err := h.eventService.GetCategories()
+	if err != nil {
+		http.Error(w, "Failed to load categories", http.StatusInternalServerError)
+		return
+	}
+
+	totalPages := (total + adminEventsPageSize - 1) / adminEventsPageSize
+	component := pages.AdminEventsPage(user, events, categories, search, status, categoryID, page, totalPages, total)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// BulkUsers handles POST /admin/users/bulk, suspending the selected users or
+// changing their role
+func (h *AdminBulkHandler) BulkUsers(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	action := models.BulkAction(r.FormValue("action"))
+	role := models.UserRole(r.FormValue("role"))
+	result, err := h.bulkService.RunUserAction(user.ID, action, bulkFormIDs(r), role, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.renderResult(w, r, user, result, "/admin/users")
+}
+
+// BulkEvents handles POST /admin/events/bulk, unpublishing the selected
+// events or moving them to a category
+func (h *AdminBulkHandler) BulkEvents(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	action := models.BulkAction(r.FormValue("action"))
+	categoryID, _ := strconv.Atoi(r.FormValue("category_id"))
+	result, err := h.bulkService.RunEventAction(user.ID, action, bulkFormIDs(r), categoryID, r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	h.renderResult(w, r, user, result, "/admin/events")
+}
+
+// renderResult renders the outcome of a bulk action
+func (h *AdminBulkHandler) renderResult(w http.ResponseWriter, r *http.Request, user *models.User, result *models.BulkResult, backURL string) {
+	component := pages.AdminBulkResultPage(user, result, backURL)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// bulkFormIDs returns the IDs of the items selected for a bulk action,
+// skipping any that aren't numbers
+func bulkFormIDs(r *http.Request) []int {
+	var ids []int
+	for _, value := range r.Form["ids"] {
+		if id, err := strconv.Atoi(value); err == nil {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
@@ -0,0 +1,126 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"event-ticketing-platform/internal/i18n"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/emails"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// emailLogPageSize is how many emails the delivery log shows per page
+const emailLogPageSize = 50
+
+// AdminEmailsHandler handles admins following email deliveries, retrying
+// failed sends and previewing the email templates
+type AdminEmailsHandler struct {
+	emailLogService *services.EmailLogService
+}
+
+// NewAdminEmailsHandler creates a new admin emails handler
+func NewAdminEmailsHandler(emailLogService *services.EmailLogService) *AdminEmailsHandler {
+	return &AdminEmailsHandler{
+		emailLogService: emailLogService,
+	}
+}
+
+// LogPage lists sent emails with their delivery status, failures first by
+// default
+func (h *AdminEmailsHandler) LogPage(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	filter := models.EmailLogFilter{
+		Status:    models.EmailStatus(query.Get("status")),
+		Recipient: query.Get("recipient"),
+		Limit:     emailLogPageSize,
+	}
+	if !query.Has("status") {
+		filter.Status = models.EmailFailed
+	}
+	page, _ := strconv.Atoi(query.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+	filter.Offset = (page - 1) * emailLogPageSize
+
+	entries, total, err := h.emailLogService.List(filter)
+	if err != nil {
+		http.Error(w, "Failed to load email log", http.StatusInternalServerError)
+		return
+	}
+
+	counts, err := h.emailLogService.CountByStatus()
+	if err != nil {
+		http.Error(w, "Failed to load email log", http.StatusInternalServerError)
+		return
+	}
+
+	totalPages := (total + emailLogPageSize - 1) / emailLogPageSize
+	component := pages.AdminEmailLogPage(user, entries, counts, filter, page, totalPages, query.Get("retried"))
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// RetryEmail sends a failed email again
+func (h *AdminEmailsHandler) RetryEmail(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid email ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.emailLogService.Retry(user.ID, id, r)
+	switch {
+	case err == nil:
+		http.Redirect(w, r, "/admin/emails?retried=sent", http.StatusSeeOther)
+	case errors.Is(err, services.ErrEmailNotRetryable):
+		// Already retried, from another tab or by another admin
+		http.Redirect(w, r, "/admin/emails", http.StatusSeeOther)
+	default:
+		http.Redirect(w, r, "/admin/emails?retried=failed", http.StatusSeeOther)
+	}
+}
+
+// PreviewPage renders an email template with sample data, in HTML and plain
+// text, in the chosen language
+func (h *AdminEmailsHandler) PreviewPage(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	name := r.URL.Query().Get("template")
+	if name == "" {
+		name = emails.Previews[0].Name
+	}
+	locale := i18n.Resolve(r.URL.Query().Get("locale"))
+
+	email, err := emails.RenderPreview(name, locale)
+	if err != nil {
+		http.Error(w, "Email template not found", http.StatusNotFound)
+		return
+	}
+
+	component := pages.AdminEmailPreviewPage(user, name, locale, email)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// AdminLockoutsHandler handles admins viewing and unlocking accounts locked
+// after too many failed logins
+type AdminLockoutsHandler struct {
+	lockoutService *services.AccountLockoutService
+}
+
+// NewAdminLockoutsHandler creates a new admin lockouts handler
+func NewAdminLockoutsHandler(lockoutService *services.AccountLockoutService) *AdminLockoutsHandler {
+	return &AdminLockoutsHandler{
+		lockoutService: lockoutService,
+	}
+}
+
+// LockedAccountsPage lists the accounts that are currently locked
+func (h *AdminLockoutsHandler) LockedAccountsPage(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	accounts, err := h.lockoutService.GetLockedAccounts()
+	if err != nil {
+		http.Error(w, "Failed to load locked accounts", http.StatusInternalServerError)
+		return
+	}
+
+	component := pages.AdminLockedAccountsPage(user, accounts, r.URL.Query().Get("unlocked") == "1")
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// UnlockAccount lifts the lock on an account before it expires
+func (h *AdminLockoutsHandler) UnlockAccount(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.lockoutService.UnlockAccount(user.ID, userID, r); err != nil {
+		if errors.Is(err, models.ErrAccountNotLocked) {
+			// Already unlocked, by the user or when the lock expired
+			http.Redirect(w, r, "/admin/locked-accounts", http.StatusSeeOther)
+			return
+		}
+		http.Error(w, "Failed to unlock account", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/locked-accounts?unlocked=1", http.StatusSeeOther)
+}
@@ -1,8 +1,9 @@
 package handlers
 
 import (
+	"fmt"
+	"log/slog"
 	"net/http"
-	"strconv"
 
 	"event-ticketing-platform/internal/middleware"
 	"event-ticketing-platform/internal/models"
@@ -12,7 +13,9 @@ import (
 
 // AdminSettingsHandler handles admin settings management
 type AdminSettingsHandler struct {
-	settingsService *services.SettingsService
+	settingsService  *services.SettingsService
+	storageGCService *services.StorageGCService
+	paymentHealth    *services.PaymentHealthService
 }
 
 // NewAdminSettingsHandler creates a new admin settings handler
@@ -22,6 +25,47 @@ func NewAdminSettingsHandler(settingsService *services.SettingsService) *AdminSe
 	}
 }
 
+// SetStorageGCService shows storage cleanup status on the settings page
+func (h *AdminSettingsHandler) SetStorageGCService(storageGCService *services.StorageGCService) {
+	h.storageGCService = storageGCService
+}
+
+// storageGCSummary returns the storage cleanup status, or nil if it isn't available
+func (h *AdminSettingsHandler) storageGCSummary() *models.StorageGCSummary {
+	if h.storageGCService == nil {
+		return nil
+	}
+	summary, err := h.storageGCService.GetSummary()
+	if err != nil {
+		slog.Warn("failed to load storage cleanup summary", "error", err)
+		return nil
+	}
+	return summary
+}
+
+// SetPaymentHealthService shows payment provider success rates on the settings page
+func (h *AdminSettingsHandler) SetPaymentHealthService(paymentHealth *services.PaymentHealthService) {
+	h.paymentHealth = paymentHealth
+}
+
+// paymentProviderHealth returns the payment providers' health, or nil if it isn't monitored
+func (h *AdminSettingsHandler) paymentProviderHealth() []*models.PaymentProviderHealth {
+	if h.paymentHealth == nil {
+		return nil
+	}
+	return h.paymentHealth.GetHealth()
+}
+
+// featureFlags returns the state of every feature flag, or nil if it can't be loaded
+func (h *AdminSettingsHandler) featureFlags() []*models.FeatureFlagState {
+	flags, err := h.settingsService.GetFeatureFlags()
+	if err != nil {
+		slog.Warn("failed to load feature flags", "error", err)
+		return nil
+	}
+	return flags
+}
+
 // SettingsPage displays the admin settings page
 func (h *AdminSettingsHandler) SettingsPage(w http.ResponseWriter, r *http.Request) {
 	user := middleware.GetUserFromContext(r.Context())
@@ -43,7 +87,7 @@ func (h *AdminSettingsHandler) SettingsPage(w http.ResponseWriter, r *http.Reque
 	}
 
 	// Render settings page
-	component := pages.AdminSettingsPage(user, settings, nil, nil)
+	component := pages.AdminSettingsPage(user, h.featureFlags(), h.storageGCSummary(), h.paymentProviderHealth(), settings.FormValues(), nil)
 	if err := component.Render(r.Context(), w); err != nil {
 		http.Error(w, "Failed to render page", http.StatusInternalServerError)
 		return
@@ -69,98 +113,88 @@ func (h *AdminSettingsHandler) UpdateSettings(w http.ResponseWriter, r *http.Req
 		return
 	}
 
-	// Build update request
-	req := &models.SettingsUpdateRequest{}
-	errors := make(map[string]string)
-
-	// Parse platform fee percentage
-	if feeStr := r.FormValue("platform_fee_percentage"); feeStr != "" {
-		if fee, err := strconv.ParseFloat(feeStr, 64); err == nil {
-			req.PlatformFeePercentage = &fee
-		} else {
-			errors["platform_fee_percentage"] = "Invalid platform fee percentage"
+	req, errors := models.ParseSettingsForm(r.PostForm)
+	if len(errors) == 0 {
+		if _, err := h.settingsService.UpdateSettings(user.ID, req, r); err != nil {
+			errors["general"] = err.Error()
 		}
 	}
 
-	// Parse minimum withdrawal amount
-	if minStr := r.FormValue("min_withdrawal_amount"); minStr != "" {
-		if min, err := strconv.ParseFloat(minStr, 64); err == nil {
-			req.MinWithdrawalAmount = &min
-		} else {
-			errors["min_withdrawal_amount"] = "Invalid minimum withdrawal amount"
+	// Re-render the form with what was submitted if it can't be saved
+	if len(errors) > 0 {
+		formData := make(map[string]string)
+		for _, definition := range models.SettingsSchema {
+			formData[definition.Key] = r.PostForm.Get(definition.Key)
 		}
-	}
 
-	// Parse maximum withdrawal amount
-	if maxStr := r.FormValue("max_withdrawal_amount"); maxStr != "" {
-		if max, err := strconv.ParseFloat(maxStr, 64); err == nil {
-			req.MaxWithdrawalAmount = &max
-		} else {
-			errors["max_withdrawal_amount"] = "Invalid maximum withdrawal amount"
+		w.WriteHeader(http.StatusBadRequest)
+		component := pages.AdminSettingsPage(user, h.featureFlags(), h.storageGCSummary(), h.paymentProviderHealth(), formData, errors)
+		if err := component.Render(r.Context(), w); err != nil {
+			http.Error(w, "Failed to render page", http.StatusInternalServerError)
 		}
+		return
 	}
 
-	// Parse withdrawal processing days
-	if daysStr := r.FormValue("withdrawal_processing_days"); daysStr != "" {
-		if days, err := strconv.Atoi(daysStr); err == nil {
-			req.WithdrawalProcessingDays = &days
-		} else {
-			errors["withdrawal_processing_days"] = "Invalid processing days"
-		}
+	// Redirect to settings page with success message
+	http.Redirect(w, r, "/admin/settings?success=1", http.StatusSeeOther)
+}
+
+// UpdateFeatureFlag handles POST /admin/settings/flags, switching a feature
+// on or off
+func (h *AdminSettingsHandler) UpdateFeatureFlag(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
 	}
 
-	// Parse boolean settings
-	eventModeration := r.FormValue("event_moderation_enabled") == "on"
-	req.EventModerationEnabled = &eventModeration
+	if user.Role != models.UserRoleAdmin {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
 
-	autoApprove := r.FormValue("auto_approve_organizers") == "on"
-	req.AutoApproveOrganizers = &autoApprove
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
 
-	maintenanceMode := r.FormValue("maintenance_mode") == "on"
-	req.MaintenanceMode = &maintenanceMode
+	flag := models.FeatureFlag(r.FormValue("flag"))
+	if _, ok := models.LookupFeatureFlag(flag); !ok {
+		http.Error(w, "Unknown feature flag", http.StatusBadRequest)
+		return
+	}
 
-	// If there are validation errors, re-render the form
-	if len(errors) > 0 {
-		settings, _ := h.settingsService.GetSettings()
-		formData := map[string]interface{}{
-			"platform_fee_percentage":    r.FormValue("platform_fee_percentage"),
-			"min_withdrawal_amount":      r.FormValue("min_withdrawal_amount"),
-			"max_withdrawal_amount":      r.FormValue("max_withdrawal_amount"),
-			"withdrawal_processing_days": r.FormValue("withdrawal_processing_days"),
-			"event_moderation_enabled":   eventModeration,
-			"auto_approve_organizers":    autoApprove,
-			"maintenance_mode":           maintenanceMode,
-		}
+	enabled := r.FormValue("enabled") == "true"
+	if err := h.settingsService.SetFeatureFlag(user.ID, flag, enabled, r); err != nil {
+		http.Error(w, "Failed to update feature flag", http.StatusInternalServerError)
+		return
+	}
 
-		component := pages.AdminSettingsPage(user, settings, formData, errors)
-		if err := component.Render(r.Context(), w); err != nil {
-			http.Error(w, "Failed to render page", http.StatusInternalServerError)
-		}
+	http.Redirect(w, r, "/admin/settings?flag_updated=1#feature-flags", http.StatusSeeOther)
+}
+
+// RunStorageGC handles POST /admin/settings/storage-gc
+func (h *AdminSettingsHandler) RunStorageGC(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
 		return
 	}
 
-	// Update settings
-	_, err := h.settingsService.UpdateSettings(req)
-	if err != nil {
-		errors["general"] = err.Error()
-		settings, _ := h.settingsService.GetSettings()
-		formData := map[string]interface{}{
-			"platform_fee_percentage":    r.FormValue("platform_fee_percentage"),
-			"min_withdrawal_amount":      r.FormValue("min_withdrawal_amount"),
-			"max_withdrawal_amount":      r.FormValue("max_withdrawal_amount"),
-			"withdrawal_processing_days": r.FormValue("withdrawal_processing_days"),
-			"event_moderation_enabled":   eventModeration,
-			"auto_approve_organizers":    autoApprove,
-			"maintenance_mode":           maintenanceMode,
-		}
+	if user.Role != models.UserRoleAdmin {
+		http.Error(w, "Access denied", http.StatusForbidden)
+		return
+	}
 
-		component := pages.AdminSettingsPage(user, settings, formData, errors)
-		if err := component.Render(r.Context(), w); err != nil {
-			http.Error(w, "Failed to render page", http.StatusInternalServerError)
-		}
+	if h.storageGCService == nil {
+		http.Error(w, "Storage cleanup is not configured", http.StatusServiceUnavailable)
 		return
 	}
 
-	// Redirect to settings page with success message
-	http.Redirect(w, r, "/admin/settings?success=1", http.StatusSeeOther)
+	if _, err := h.storageGCService.Run(r.Context()); err != nil {
+		http.Error(w, fmt.Sprintf("Storage cleanup failed: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/settings", http.StatusSeeOther)
 }
\ No newline at end of file
@@ -0,0 +1,116 @@
+package handlers
+
+import (
+	"net/http"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+)
+
+// AdminSnippetsHandler handles admins editing the content snippets shown on
+// pages and in emails
+type AdminSnippetsHandler struct {
+	snippetService *services.SnippetService
+}
+
+// NewAdminSnippetsHandler creates a new admin snippets handler
+func NewAdminSnippetsHandler(snippetService *services.SnippetService) *AdminSnippetsHandler {
+	return &AdminSnippetsHandler{
+		snippetService: snippetService,
+	}
+}
+
+// SnippetsPage displays the content snippets editor
+func (h *AdminSnippetsHandler) SnippetsPage(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	h.renderPage(w, r, http.StatusOK, user, nil, nil, r.URL.Query().Get("success") == "1")
+}
+
+// UpdateSnippets saves the snippets that were changed
+func (h *AdminSnippetsHandler) UpdateSnippets(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	current := h.snippetService.All()
+	formData := make(map[models.SnippetKey]string)
+	errors := make(map[string]string)
+	for _, definition := range models.SnippetDefinitions {
+		if _, submitted := r.PostForm[string(definition.Key)]; !submitted {
+			continue
+		}
+		req := &models.SnippetUpdateRequest{
+			Key:       definition.Key,
+			Content:   r.PostFormValue(string(definition.Key)),
+			UpdatedBy: user.ID,
+		}
+		formData[definition.Key] = req.Content
+		if req.Content == current[definition.Key] {
+			continue
+		}
+		if err := h.snippetService.UpdateSnippet(req, r); err != nil {
+			errors[string(definition.Key)] = err.Error()
+		}
+	}
+
+	if len(errors) > 0 {
+		h.renderPage(w, r, http.StatusBadRequest, user, formData, errors, false)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/settings/snippets?success=1", http.StatusSeeOther)
+}
+
+// ResetSnippet restores a snippet's default content
+func (h *AdminSnippetsHandler) ResetSnippet(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	key := models.SnippetKey(r.FormValue("key"))
+	if err := h.snippetService.ResetSnippet(key, user.ID, r); err != nil {
+		h.renderPage(w, r, http.StatusBadRequest, user, nil, map[string]string{"general": err.Error()}, false)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/settings/snippets?success=1", http.StatusSeeOther)
+}
+
+// renderPage renders the snippets editor with the current content, keeping
+// any submitted content that failed to save
+func (h *AdminSnippetsHandler) renderPage(w http.ResponseWriter, r *http.Request, status int, user *models.User, formData map[models.SnippetKey]string, errors map[string]string, saved bool) {
+	customized, err := h.snippetService.GetSaved()
+	if err != nil {
+		http.Error(w, "Failed to load snippets", http.StatusInternalServerError)
+		return
+	}
+
+	contents := h.snippetService.All()
+	for key, content := range formData {
+		contents[key] = content
+	}
+
+	w.WriteHeader(status)
+	component := pages.AdminSnippetsPage(user, contents, customized, errors, saved)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,102 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// AdminUserHandler handles the admin page of a single user and the quick
+// actions on it
+type AdminUserHandler struct {
+	adminUserService *services.AdminUserService
+}
+
+// NewAdminUserHandler creates a new admin user handler
+func NewAdminUserHandler(adminUserService *services.AdminUserService) *AdminUserHandler {
+	return &AdminUserHandler{
+		adminUserService: adminUserService,
+	}
+}
+
+// UserDetailPage handles GET /admin/users/{id}, showing a user's orders,
+// events, withdrawals, sessions and audit entries with a timeline of them
+func (h *AdminUserHandler) UserDetailPage(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	detail, err := h.adminUserService.GetDetail(userID)
+	if err != nil {
+		http.Error(w, "User not found", http.StatusNotFound)
+		return
+	}
+
+	component := pages.AdminUserDetailPage(user, detail, r.URL.Query().Get("done"))
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// SendPasswordReset handles POST /admin/users/{id}/password-reset, emailing
+// the user a link to reset their password
+func (h *AdminUserHandler) SendPasswordReset(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.adminUserService.SendPasswordReset(user.ID, userID, r); err != nil {
+		http.Redirect(w, r, adminUserPath(userID)+"?done=reset_failed", http.StatusSeeOther)
+		return
+	}
+
+	http.Redirect(w, r, adminUserPath(userID)+"?done=reset_sent", http.StatusSeeOther)
+}
+
+// VerifyEmail handles POST /admin/users/{id}/verify-email, marking the user's
+// email address verified
+func (h *AdminUserHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	err = h.adminUserService.VerifyEmail(user.ID, userID, r)
+	if err != nil && !errors.Is(err, services.ErrEmailAlreadyVerified) {
+		http.Error(w, "Failed to verify email", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, adminUserPath(userID)+"?done=verified", http.StatusSeeOther)
+}
+
+// adminUserPath returns the path of a user's admin page
+func adminUserPath(userID int) string {
+	return "/admin/users/" + strconv.Itoa(userID)
+}
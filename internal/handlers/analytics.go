@@ -8,7 +8,9 @@ import (
 
 	"github.com/go-chi/chi/v5"
 
+	"event-ticketing-platform/internal/logging"
 	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
 	"event-ticketing-platform/internal/services"
 	"event-ticketing-platform/web/templates/pages"
 )
@@ -106,9 +108,34 @@ func (h *AnalyticsHandler) ExportAttendees(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	// Export attendee data
-	csvData, err := h.analyticsService.ExportAttendeeData(eventID, user.ID)
-	if err != nil {
+	opts := services.AttendeeExportOptions{
+		Format:      services.AttendeeExportFormat(r.URL.Query().Get("format")),
+		OrderStatus: models.OrderStatus(r.URL.Query().Get("status")),
+	}
+	if ticketType := r.URL.Query().Get("ticket_type"); ticketType != "" {
+		if opts.TicketTypeID, err = strconv.Atoi(ticketType); err != nil {
+			http.Error(w, "Invalid ticket type", http.StatusBadRequest)
+			return
+		}
+	}
+	if err := opts.Validate(); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// The export streams straight to the response, so the download headers
+	// are only set once it starts writing
+	download := &downloadWriter{
+		w:           w,
+		contentType: opts.Format.ContentType(),
+		filename:    fmt.Sprintf("event_%d_attendees.%s", eventID, opts.Format),
+	}
+	if err := h.analyticsService.ExportAttendees(download, eventID, user.ID, opts); err != nil {
+		if download.started {
+			// Too late to change the response; the download is cut short
+			logging.FromContext(r.Context()).Warn("attendee export failed", "event_id", eventID, "error", err)
+			return
+		}
 		if err.Error() == "organizer does not have access to this event" {
 			http.Error(w, "Forbidden", http.StatusForbidden)
 			return
@@ -116,14 +143,24 @@ func (h *AnalyticsHandler) ExportAttendees(w http.ResponseWriter, r *http.Reques
 		http.Error(w, fmt.Sprintf("Failed to export attendee data: %v", err), http.StatusInternalServerError)
 		return
 	}
+}
 
-	// Set headers for CSV download
-	w.Header().Set("Content-Type", "text/csv")
-	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"event_%d_attendees.csv\"", eventID))
-	w.Header().Set("Content-Length", strconv.Itoa(len(csvData)))
+// downloadWriter sets file download headers on the first write
+type downloadWriter struct {
+	w           http.ResponseWriter
+	contentType string
+	filename    string
+	started     bool
+}
 
-	// Write CSV data
-	w.Write(csvData)
+// Write writes to the response, starting the download first
+func (d *downloadWriter) Write(p []byte) (int, error) {
+	if !d.started {
+		d.started = true
+		d.w.Header().Set("Content-Type", d.contentType)
+		d.w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", d.filename))
+	}
+	return d.w.Write(p)
 }
 
 // DashboardAPI handles GET /api/organizer/dashboard (for HTMX requests)
@@ -143,7 +180,7 @@ func (h *AnalyticsHandler) DashboardAPI(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Return JSON response
-	if err := writeJSON(w, dashboard); err != nil {
+	if err := writeCacheableJSON(w, r, analyticsCacheControl, dashboard); err != nil {
 		http.Error(w, fmt.Sprintf("Failed to write JSON response: %v", err), http.StatusInternalServerError)
 		return
 	}
@@ -178,7 +215,7 @@ func (h *AnalyticsHandler) EventAnalyticsAPI(w http.ResponseWriter, r *http.Requ
 	}
 
 	// Return JSON response
-	if err := writeJSON(w, analytics); err != nil {
+	if err := writeCacheableJSON(w, r, analyticsCacheControl, analytics); err != nil {
 		http.Error(w, fmt.Sprintf("Failed to write JSON response: %v", err), http.StatusInternalServerError)
 		return
 	}
@@ -0,0 +1,253 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// announcementTimeFormat is the format of the datetime-local schedule
+// fields, in UTC
+const announcementTimeFormat = "2006-01-02T15:04"
+
+// AnnouncementHandler handles admins publishing announcement banners and
+// visitors dismissing them
+type AnnouncementHandler struct {
+	announcementService *services.AnnouncementService
+}
+
+// NewAnnouncementHandler creates a new announcement handler
+func NewAnnouncementHandler(announcementService *services.AnnouncementService) *AnnouncementHandler {
+	return &AnnouncementHandler{
+		announcementService: announcementService,
+	}
+}
+
+// Dismiss handles POST /announcements/{id}/dismiss, hiding an announcement
+// from the visitor on every later page
+func (h *AnnouncementHandler) Dismiss(w http.ResponseWriter, r *http.Request) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil || id <= 0 {
+		http.Error(w, "Invalid announcement ID", http.StatusBadRequest)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     middleware.DismissedAnnouncementsCookie,
+		Value:    middleware.DismissAnnouncement(r, id),
+		Path:     "/",
+		MaxAge:   int((90 * 24 * time.Hour).Seconds()),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	// HTMX removes the banner in place
+	if middleware.IsHTMXRequest(r) {
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+	http.Redirect(w, r, bannerReturnPath(r), http.StatusSeeOther)
+}
+
+// AnnouncementsPage lists every announcement with a form to publish another
+func (h *AnnouncementHandler) AnnouncementsPage(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	formData := map[string]string{
+		"level":       string(models.AnnouncementInfo),
+		"audience":    string(models.AnnouncementEveryone),
+		"starts_at":   time.Now().UTC().Format(announcementTimeFormat),
+		"dismissible": "on",
+	}
+	h.renderList(w, r, http.StatusOK, user, formData, r.URL.Query().Get("saved"), "")
+}
+
+// CreateAnnouncement publishes an announcement
+func (h *AnnouncementHandler) CreateAnnouncement(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	req, formData, ok := parseAnnouncementForm(w, r)
+	if !ok {
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.renderList(w, r, http.StatusBadRequest, user, formData, "", err.Error())
+		return
+	}
+
+	if _, err := h.announcementService.Create(user.ID, req, r); err != nil {
+		http.Error(w, "Failed to publish announcement", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/announcements?saved=created", http.StatusSeeOther)
+}
+
+// EditAnnouncementPage shows the form changing an announcement
+func (h *AnnouncementHandler) EditAnnouncementPage(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	announcement, ok := h.loadAnnouncement(w, r)
+	if !ok {
+		return
+	}
+
+	formData := map[string]string{
+		"message":   announcement.Message,
+		"link_url":  announcement.LinkURL,
+		"link_text": announcement.LinkText,
+		"level":     string(announcement.Level),
+		"audience":  string(announcement.Audience),
+		"starts_at": announcement.StartsAt.UTC().Format(announcementTimeFormat),
+	}
+	if announcement.EndsAt != nil {
+		formData["ends_at"] = announcement.EndsAt.UTC().Format(announcementTimeFormat)
+	}
+	if announcement.Dismissible {
+		formData["dismissible"] = "on"
+	}
+
+	h.renderEdit(w, r, http.StatusOK, user, announcement, formData, "")
+}
+
+// UpdateAnnouncement changes an announcement
+func (h *AnnouncementHandler) UpdateAnnouncement(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	announcement, ok := h.loadAnnouncement(w, r)
+	if !ok {
+		return
+	}
+
+	req, formData, ok := parseAnnouncementForm(w, r)
+	if !ok {
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.renderEdit(w, r, http.StatusBadRequest, user, announcement, formData, err.Error())
+		return
+	}
+
+	if _, err := h.announcementService.Update(user.ID, announcement.ID, req, r); err != nil {
+		http.Error(w, "Failed to save announcement", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/announcements?saved=updated", http.StatusSeeOther)
+}
+
+// DeleteAnnouncement removes an announcement
+func (h *AnnouncementHandler) DeleteAnnouncement(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	announcement, ok := h.loadAnnouncement(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.announcementService.Delete(user.ID, announcement.ID, r); err != nil {
+		http.Error(w, "Failed to delete announcement", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/announcements?saved=deleted", http.StatusSeeOther)
+}
+
+// parseAnnouncementForm reads the announcement form. Invalid times are
+// left zero for Validate to report.
+func parseAnnouncementForm(w http.ResponseWriter, r *http.Request) (*models.AnnouncementRequest, map[string]string, bool) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return nil, nil, false
+	}
+
+	formData := make(map[string]string)
+	for _, field := range []string{"message", "link_url", "link_text", "level", "audience", "starts_at", "ends_at", "dismissible"} {
+		formData[field] = r.FormValue(field)
+	}
+
+	startsAt, _ := time.Parse(announcementTimeFormat, formData["starts_at"])
+	req := &models.AnnouncementRequest{
+		Message:     formData["message"],
+		LinkURL:     formData["link_url"],
+		LinkText:    formData["link_text"],
+		Level:       models.AnnouncementLevel(formData["level"]),
+		Audience:    models.AnnouncementAudience(formData["audience"]),
+		StartsAt:    startsAt,
+		Dismissible: formData["dismissible"] == "on",
+	}
+	if endsAt := strings.TrimSpace(formData["ends_at"]); endsAt != "" {
+		parsed, _ := time.Parse(announcementTimeFormat, endsAt)
+		req.EndsAt = &parsed
+	}
+
+	return req, formData, true
+}
+
+// loadAnnouncement loads the announcement in the URL, responding 404 if it
+// doesn't exist
+func (h *AnnouncementHandler) loadAnnouncement(w http.ResponseWriter, r *http.Request) (*models.Announcement, bool) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid announcement ID", http.StatusBadRequest)
+		return nil, false
+	}
+
+	announcement, err := h.announcementService.Get(id)
+	if err != nil {
+		http.Error(w, "Announcement not found", http.StatusNotFound)
+		return nil, false
+	}
+	return announcement, true
+}
+
+// renderList renders the announcements page
+func (h *AnnouncementHandler) renderList(w http.ResponseWriter, r *http.Request, status int, user *models.User, formData map[string]string, saved, errorMsg string) {
+	announcements, err := h.announcementService.List()
+	if err != nil {
+		http.Error(w, "Failed to load announcements", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(status)
+	component := pages.AdminAnnouncementsPage(user, announcements, time.Now(), formData, saved, errorMsg)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// renderEdit renders the page changing an announcement
+func (h *AnnouncementHandler) renderEdit(w http.ResponseWriter, r *http.Request, status int, user *models.User, announcement *models.Announcement, formData map[string]string, errorMsg string) {
+	w.WriteHeader(status)
+	component := pages.AdminAnnouncementEditPage(user, announcement, formData, errorMsg)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// ArrivalSlotHandler handles organizers' arrival slots for an event
+type ArrivalSlotHandler struct {
+	slotService  *services.ArrivalSlotService
+	eventService services.EventServiceInterface
+}
+
+// NewArrivalSlotHandler creates a new arrival slot handler
+func NewArrivalSlotHandler(slotService *services.ArrivalSlotService, eventService services.EventServiceInterface) *ArrivalSlotHandler {
+	return &ArrivalSlotHandler{
+		slotService:  slotService,
+		eventService: eventService,
+	}
+}
+
+// ArrivalSlotsPage shows an event's arrival slots and their bookings
+func (h *ArrivalSlotHandler) ArrivalSlotsPage(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	h.renderPage(w, r, http.StatusOK, user, event, map[string]string{}, r.URL.Query().Get("saved") == "1", "")
+}
+
+// AddArrivalSlot adds an arrival slot to an event
+func (h *ArrivalSlotHandler) AddArrivalSlot(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	formData := map[string]string{
+		"starts_at": r.FormValue("starts_at"),
+		"ends_at":   r.FormValue("ends_at"),
+		"capacity":  r.FormValue("capacity"),
+	}
+
+	// Invalid times and capacities are left zero for Validate to report
+	startsAt, _ := time.Parse("2006-01-02T15:04", formData["starts_at"])
+	endsAt, _ := time.Parse("2006-01-02T15:04", formData["ends_at"])
+	capacity, _ := strconv.Atoi(strings.TrimSpace(formData["capacity"]))
+
+	req := &models.ArrivalSlotCreateRequest{
+		EventID:  event.ID,
+		StartsAt: startsAt,
+		EndsAt:   endsAt,
+		Capacity: capacity,
+	}
+	if err := req.Validate(); err != nil {
+		h.renderPage(w, r, http.StatusBadRequest, user, event, formData, false, err.Error())
+		return
+	}
+
+	if _, err := h.slotService.AddSlot(req); err != nil {
+		http.Error(w, "Failed to add arrival time", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/organizer/events/"+strconv.Itoa(event.ID)+"/arrival-slots?saved=1", http.StatusSeeOther)
+}
+
+// DeleteArrivalSlot removes an arrival slot that no tickets have chosen
+func (h *ArrivalSlotHandler) DeleteArrivalSlot(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	slotID, err := strconv.Atoi(chi.URLParam(r, "slotID"))
+	if err != nil {
+		http.Error(w, "Invalid arrival slot ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.slotService.DeleteSlot(event.ID, slotID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, "Arrival slot not found", http.StatusNotFound)
+			return
+		}
+		if strings.Contains(err.Error(), "cannot be deleted") {
+			h.renderPage(w, r, http.StatusConflict, user, event, map[string]string{}, false, err.Error())
+			return
+		}
+		http.Error(w, "Failed to delete arrival time", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/organizer/events/"+strconv.Itoa(event.ID)+"/arrival-slots?saved=1", http.StatusSeeOther)
+}
+
+// renderPage renders the arrival slots page with the event's current slots
+func (h *ArrivalSlotHandler) renderPage(w http.ResponseWriter, r *http.Request, status int, user *models.User, event *models.Event, formData map[string]string, saved bool, errorMsg string) {
+	slots, err := h.slotService.GetSlots(event.ID)
+	if err != nil {
+		http.Error(w, "Failed to load arrival times", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(status)
+	component := pages.ArrivalSlotsPage(user, event, slots, formData, saved, errorMsg)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
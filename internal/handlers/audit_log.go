@@ -0,0 +1,137 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+)
+
+// auditLogPageSize is how many entries the audit log shows per page
+const auditLogPageSize = 50
+
+// auditLogExportLimit caps how many entries a single CSV export holds
+const auditLogExportLimit = 10000
+
+// AuditLogHandler handles admins searching and exporting the audit log
+type AuditLogHandler struct {
+	auditService *services.AuditService
+}
+
+// NewAuditLogHandler creates a new audit log handler
+func NewAuditLogHandler(auditService *services.AuditService) *AuditLogHandler {
+	return &AuditLogHandler{
+		auditService: auditService,
+	}
+}
+
+// AuditLogPage handles GET /admin/audit, listing audit log entries filtered
+// by actor, action, entity and date, newest first
+func (h *AuditLogHandler) AuditLogPage(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	query := r.URL.Query()
+	filter, err := parseAuditLogFilter(query)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	page, _ := strconv.Atoi(query.Get("page"))
+	if page < 1 {
+		page = 1
+	}
+
+	logs, total, err := h.auditService.SearchAuditLogs(filter, page, auditLogPageSize)
+	if err != nil {
+		http.Error(w, "Failed to load audit log", http.StatusInternalServerError)
+		return
+	}
+
+	actions, targetTypes, err := h.auditService.GetFilterOptions()
+	if err != nil {
+		http.Error(w, "Failed to load audit log", http.StatusInternalServerError)
+		return
+	}
+
+	totalPages := (total + auditLogPageSize - 1) / auditLogPageSize
+	component := pages.AdminAuditLogPage(user, logs, filter, actions, targetTypes, page, totalPages, total)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// ExportAuditLog handles GET /admin/audit/export, downloading the entries
+// matching the same filters as AuditLogPage as CSV
+func (h *AuditLogHandler) ExportAuditLog(w http.ResponseWriter, r *http.Request) {
+	if _, ok := requireAdmin(w, r); !ok {
+		return
+	}
+
+	filter, err := parseAuditLogFilter(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	logs, _, err := h.auditService.SearchAuditLogs(filter, 1, auditLogExportLimit)
+	if err != nil {
+		http.Error(w, "Failed to load audit log", http.StatusInternalServerError)
+		return
+	}
+
+	filename := fmt.Sprintf("audit_log_%s.csv", time.Now().Format("2006-01-02"))
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+
+	if err := h.auditService.ExportAuditLogs(w, logs); err != nil {
+		http.Error(w, "Failed to export audit log", http.StatusInternalServerError)
+		return
+	}
+}
+
+// parseAuditLogFilter reads the audit log filters from query parameters. The
+// from and to dates are both inclusive.
+func parseAuditLogFilter(query url.Values) (models.AuditLogFilter, error) {
+	filter := models.AuditLogFilter{
+		Actor:      strings.TrimSpace(query.Get("actor")),
+		Action:     query.Get("action"),
+		TargetType: query.Get("target_type"),
+	}
+
+	if targetID := strings.TrimSpace(query.Get("target_id")); targetID != "" {
+		id, err := strconv.Atoi(targetID)
+		if err != nil || id < 1 {
+			return filter, errors.New("entity ID must be a positive number")
+		}
+		filter.TargetID = id
+	}
+
+	if from := query.Get("from"); from != "" {
+		date, err := time.Parse("2006-01-02", from)
+		if err != nil {
+			return filter, errors.New("from date must be formatted YYYY-MM-DD")
+		}
+		filter.From = &date
+	}
+	if to := query.Get("to"); to != "" {
+		date, err := time.Parse("2006-01-02", to)
+		if err != nil {
+			return filter, errors.New("to date must be formatted YYYY-MM-DD")
+		}
+		end := date.AddDate(0, 0, 1)
+		filter.To = &end
+	}
+
+	return filter, nil
+}
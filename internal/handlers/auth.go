@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"event-ticketing-platform/internal/logging"
 	"event-ticketing-platform/internal/middleware"
 	"event-ticketing-platform/internal/models"
 	"event-ticketing-platform/internal/services"
@@ -20,6 +21,7 @@ type AuthHandler struct {
 	authService       services.AuthServiceInterface
 	authFlowService   *services.AuthFlowService
 	onboardingService *services.OnboardingService
+	fraudLinkage      *services.FraudLinkageService
 	store             sessions.Store
 }
 
@@ -33,6 +35,11 @@ func NewAuthHandler(authService services.AuthServiceInterface, store sessions.St
 	}
 }
 
+// SetFraudLinkageService sets the service that records where users sign in from
+func (h *AuthHandler) SetFraudLinkageService(fraudLinkage *services.FraudLinkageService) {
+	h.fraudLinkage = fraudLinkage
+}
+
 // getCSRFToken gets or creates a CSRF token for the session
 func (h *AuthHandler) getCSRFToken(w http.ResponseWriter, r *http.Request) string {
 	session, err := h.store.Get(r, "session")
@@ -78,7 +85,7 @@ func (h *AuthHandler) LoginPage(w http.ResponseWriter, r *http.Request) {
 // LoginSubmit handles login form submission
 func (h *AuthHandler) LoginSubmit(w http.ResponseWriter, r *http.Request) {
 	if err := r.ParseForm(); err != nil {
-		fmt.Printf("Failed to parse form: %v\n", err)
+		logging.FromContext(r.Context()).Warn("failed to parse login form", "error", err)
 		http.Error(w, "Invalid form data", http.StatusBadRequest)
 		return
 	}
@@ -86,11 +93,6 @@ func (h *AuthHandler) LoginSubmit(w http.ResponseWriter, r *http.Request) {
 	email := strings.TrimSpace(r.FormValue("email"))
 	password := r.FormValue("password")
 	rememberMe := r.FormValue("remember_me") == "on"
-	csrfToken := r.FormValue("csrf_token")
-
-	// Debug logging
-	fmt.Printf("Login attempt - Email: %s, Password length: %d, CSRF token: %s\n", 
-		email, len(password), csrfToken)
 
 	// Validate input
 	errors := make(map[string][]string)
@@ -100,15 +102,12 @@ func (h *AuthHandler) LoginSubmit(w http.ResponseWriter, r *http.Request) {
 
 	if email == "" {
 		errors["email"] = []string{"Email is required"}
-		fmt.Printf("Validation error: Email is required\n")
 	}
 	if password == "" {
 		errors["password"] = []string{"Password is required"}
-		fmt.Printf("Validation error: Password is required\n")
 	}
 
 	if len(errors) > 0 {
-		fmt.Printf("Login validation failed with errors: %+v\n", errors)
 		component := pages.LoginPage(nil, errors, formData)
 		w.WriteHeader(http.StatusUnprocessableEntity)
 		err := component.Render(r.Context(), w)
@@ -150,6 +149,7 @@ func (h *AuthHandler) LoginSubmit(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	services.RenewSessionID(session)
 	session.Values["session_id"] = authResponse.SessionID
 	session.Values["user_id"] = authResponse.User.ID
 	session.Values["remember_me"] = rememberMe
@@ -172,6 +172,10 @@ func (h *AuthHandler) LoginSubmit(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if h.fraudLinkage != nil {
+		h.fraudLinkage.RecordSignIn(w, r, authResponse.User.ID)
+	}
+
 	// Get redirect URL from query parameter or default to dashboard
 	redirectURL := r.URL.Query().Get("redirect")
 	if redirectURL == "" {
@@ -259,11 +263,6 @@ func (h *AuthHandler) RegisterSubmit(w http.ResponseWriter, r *http.Request) {
 	}
 
 	if len(errors) > 0 {
-		// Debug: log validation errors
-		fmt.Printf("Registration validation failed for %s. Errors: %+v\n", email, errors)
-		fmt.Printf("Form data received: email=%s, firstName=%s, lastName=%s, password_len=%d, passwordConfirm_len=%d, terms=%s, role=%s\n", 
-			email, firstName, lastName, len(password), len(passwordConfirm), terms, roleValue)
-		
 		component := pages.RegisterPage(nil, errors, formData)
 		w.WriteHeader(http.StatusUnprocessableEntity)
 		err := component.Render(r.Context(), w)
@@ -282,10 +281,9 @@ func (h *AuthHandler) RegisterSubmit(w http.ResponseWriter, r *http.Request) {
 		Role:      role,
 	}
 
-	fmt.Printf("Attempting registration for: %s\n", email)
 	authResponse, err := h.authService.Register(registerReq)
 	if err != nil {
-		fmt.Printf("Registration failed for %s: %v\n", email, err)
+		logging.FromContext(r.Context()).Warn("registration failed", "error", err)
 		if strings.Contains(err.Error(), "already exists") {
 			errors["email"] = []string{"An account with this email already exists"}
 		} else {
@@ -301,10 +299,14 @@ func (h *AuthHandler) RegisterSubmit(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	if h.fraudLinkage != nil {
+		h.fraudLinkage.RecordSignIn(w, r, authResponse.User.ID)
+	}
+
 	// For email verification flow, don't create session immediately
 	// Instead, show a message asking user to check their email
 	if authResponse.SessionID == "" {
-		fmt.Printf("Registration successful for %s, showing verification page\n", email)
+		logging.FromContext(r.Context()).Info("user registered", "user_id", authResponse.User.ID)
 		// Show email verification required page
 		component := pages.RegistrationSuccessPage(email)
 		err := component.Render(r.Context(), w)
@@ -321,6 +323,7 @@ func (h *AuthHandler) RegisterSubmit(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	services.RenewSessionID(session)
 	session.Values["session_id"] = authResponse.SessionID
 	session.Values["user_id"] = authResponse.User.ID
 	err = session.Save(r, w)
@@ -435,7 +438,7 @@ func (h *AuthHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
 	// Create session
 	session, err := h.store.Get(r, "session")
 	if err != nil {
-		fmt.Printf("Failed to get session after email verification: %v\n", err)
+		logging.FromContext(r.Context()).Error("failed to get session after email verification", "user_id", user.ID, "error", err)
 		component := pages.VerificationSuccessPage(user.FirstName)
 		err := component.Render(r.Context(), w)
 		if err != nil {
@@ -444,21 +447,22 @@ func (h *AuthHandler) VerifyEmail(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	services.RenewSessionID(session)
 	session.Values["session_id"] = sessionID
 	session.Values["user_id"] = user.ID
 	err = session.Save(r, w)
 	if err != nil {
-		fmt.Printf("Failed to save session after email verification: %v\n", err)
+		logging.FromContext(r.Context()).Error("failed to save session after email verification", "user_id", user.ID, "error", err)
 	}
 
 	// Initialize onboarding for the user
 	onboarding, err := h.onboardingService.InitializeOnboarding(user.ID, user.Role)
 	if err != nil {
-		fmt.Printf("Failed to initialize onboarding for user %d: %v\n", user.ID, err)
+		logging.FromContext(r.Context()).Error("failed to initialize onboarding", "user_id", user.ID, "error", err)
 	} else {
 		err = h.onboardingService.SaveOnboardingToSession(w, r, onboarding)
 		if err != nil {
-			fmt.Printf("Failed to save onboarding to session: %v\n", err)
+			logging.FromContext(r.Context()).Error("failed to save onboarding to session", "user_id", user.ID, "error", err)
 		}
 	}
 
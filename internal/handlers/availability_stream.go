@@ -0,0 +1,142 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Live availability streams
+const (
+	// availabilityStreamRefresh is how often a stream reloads availability,
+	// picking up orders completed on other servers, and keeps the connection
+	// alive through proxies
+	availabilityStreamRefresh = 15 * time.Second
+	// availabilityStreamRetry is how long browsers wait to reconnect, in
+	// milliseconds
+	availabilityStreamRetry = 5000
+)
+
+// TicketAvailabilityUpdate is what the availability stream sends for an event
+type TicketAvailabilityUpdate struct {
+	EventID     int                      `json:"event_id"`
+	TicketTypes []TicketTypeAvailability `json:"ticket_types"`
+}
+
+// TicketTypeAvailability is how many tickets of a type are left
+type TicketTypeAvailability struct {
+	ID        int  `json:"id"`
+	Remaining int  `json:"remaining"`
+	SoldOut   bool `json:"sold_out"`
+}
+
+// newTicketAvailabilityUpdate builds the update sent for an event's ticket types
+func newTicketAvailabilityUpdate(eventID int, ticketTypes []*models.TicketType) TicketAvailabilityUpdate {
+	update := TicketAvailabilityUpdate{EventID: eventID, TicketTypes: make([]TicketTypeAvailability, 0, len(ticketTypes))}
+	for _, ticketType := range ticketTypes {
+		update.TicketTypes = append(update.TicketTypes, TicketTypeAvailability{
+			ID:        ticketType.ID,
+			Remaining: ticketType.Available(),
+			SoldOut:   ticketType.IsSoldOut(),
+		})
+	}
+	return update
+}
+
+// SetAvailabilityBroker enables live availability streams, pushed as orders
+// complete
+func (h *PublicHandler) SetAvailabilityBroker(broker *services.AvailabilityBroker) {
+	h.availabilityBroker = broker
+}
+
+// StreamTicketAvailability streams an event's ticket availability as
+// server-sent "availability" events, sending it on connect and whenever it
+// changes, so event pages and carts update live during on-sales
+func (h *PublicHandler) StreamTicketAvailability(w http.ResponseWriter, r *http.Request) {
+	eventID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+	if h.availabilityBroker == nil {
+		http.Error(w, "Live availability is not enabled", http.StatusNotFound)
+		return
+	}
+
+	ticketTypes, err := h.loadTicketAvailability(eventID)
+	if err != nil {
+		http.Error(w, "Failed to load ticket availability", http.StatusInternalServerError)
+		return
+	}
+
+	// Subscribe before sending the current availability, so no change
+	// between the two is missed
+	updates, unsubscribe := h.availabilityBroker.Subscribe(eventID)
+	defer unsubscribe()
+
+	controller := http.NewResponseController(w)
+	controller.SetWriteDeadline(time.Time{}) // Streams outlive the server's write timeout
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Accel-Buffering", "no") // Stop nginx buffering events
+	w.WriteHeader(http.StatusOK)
+
+	var last []byte
+	send := func(ticketTypes []*models.TicketType) error {
+		data, err := json.Marshal(newTicketAvailabilityUpdate(eventID, ticketTypes))
+		if err != nil {
+			return err
+		}
+		if bytes.Equal(data, last) {
+			return nil
+		}
+		last = data
+		if _, err := fmt.Fprintf(w, "event: availability\ndata: %s\n\n", data); err != nil {
+			return err
+		}
+		return controller.Flush()
+	}
+
+	fmt.Fprintf(w, "retry: %d\n\n", availabilityStreamRetry)
+	if err := send(ticketTypes); err != nil {
+		return
+	}
+
+	refresh := time.NewTicker(availabilityStreamRefresh)
+	defer refresh.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case ticketTypes, ok := <-updates:
+			if !ok {
+				return // Shutting down
+			}
+			if err := send(ticketTypes); err != nil {
+				return
+			}
+		case <-refresh.C:
+			if ticketTypes, err := h.loadTicketAvailability(eventID); err == nil {
+				if err := send(ticketTypes); err != nil {
+					return
+				}
+			}
+			// Comments keep idle connections open
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			if err := controller.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
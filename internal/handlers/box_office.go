@@ -0,0 +1,133 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+)
+
+// BoxOfficeHandler handles organizers' sales at the door
+type BoxOfficeHandler struct {
+	boxOfficeService *services.BoxOfficeService
+	eventService     services.EventServiceInterface
+	orderService     services.OrderServiceInterface
+}
+
+// NewBoxOfficeHandler creates a new box office handler
+func NewBoxOfficeHandler(boxOfficeService *services.BoxOfficeService, eventService services.EventServiceInterface, orderService services.OrderServiceInterface) *BoxOfficeHandler {
+	return &BoxOfficeHandler{
+		boxOfficeService: boxOfficeService,
+		eventService:     eventService,
+		orderService:     orderService,
+	}
+}
+
+// SalePage shows the box office of one of the organizer's events, with the
+// sale just recorded, if any, ready to print
+func (h *BoxOfficeHandler) SalePage(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadManagedEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	var sold *models.Order
+	if orderID, err := strconv.Atoi(r.URL.Query().Get("order")); err == nil {
+		order, err := h.orderService.GetOrderByID(orderID, user.ID)
+		if err == nil && order.EventID == event.ID {
+			sold = order
+		}
+	}
+
+	formData := map[string]string{"payment_method": string(models.BoxOfficeCash)}
+	h.renderSalePage(w, r, http.StatusOK, user, event, formData, sold, "")
+}
+
+// Sell records a sale at the door and issues its tickets straight away
+func (h *BoxOfficeHandler) Sell(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadManagedEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	ticketTypes, err := h.boxOfficeService.TicketTypes(event.ID)
+	if err != nil {
+		http.Error(w, "Failed to load ticket types", http.StatusInternalServerError)
+		return
+	}
+
+	formData := map[string]string{
+		"payment_method": r.FormValue("payment_method"),
+		"buyer_name":     r.FormValue("buyer_name"),
+		"buyer_email":    r.FormValue("buyer_email"),
+	}
+	sale := &models.BoxOfficeSale{
+		PaymentMethod: models.BoxOfficePaymentMethod(formData["payment_method"]),
+		BuyerName:     formData["buyer_name"],
+		BuyerEmail:    formData["buyer_email"],
+	}
+
+	// Quantities and prices are submitted per ticket type; blank quantities
+	// are none of that type
+	for _, ticketType := range ticketTypes {
+		quantityKey := "quantity_" + strconv.Itoa(ticketType.ID)
+		priceKey := "price_" + strconv.Itoa(ticketType.ID)
+		formData[quantityKey] = r.FormValue(quantityKey)
+		formData[priceKey] = r.FormValue(priceKey)
+
+		quantity := 0
+		if value := strings.TrimSpace(formData[quantityKey]); value != "" {
+			quantity, err = strconv.Atoi(value)
+			if err != nil {
+				h.renderSalePage(w, r, http.StatusBadRequest, user, event, formData, nil, "Quantity of "+ticketType.Name+" must be a whole number")
+				return
+			}
+		}
+		price := 0
+		if value := strings.TrimSpace(formData[priceKey]); value != "" && ticketType.PayWhatYouWant {
+			amount, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				h.renderSalePage(w, r, http.StatusBadRequest, user, event, formData, nil, "Price of "+ticketType.Name+" must be a number")
+				return
+			}
+			price = int(math.Round(amount * 100))
+		}
+		if quantity != 0 {
+			sale.Items = append(sale.Items, models.BoxOfficeItem{TicketTypeID: ticketType.ID, Quantity: quantity, Price: price})
+		}
+	}
+
+	order, err := h.boxOfficeService.Sell(event, user, sale)
+	if err != nil {
+		h.renderSalePage(w, r, http.StatusBadRequest, user, event, formData, nil, err.Error())
+		return
+	}
+
+	http.Redirect(w, r, "/organizer/events/"+strconv.Itoa(event.ID)+"/box-office?order="+strconv.Itoa(order.ID), http.StatusSeeOther)
+}
+
+// renderSalePage renders the box office page with the event's current
+// inventory
+func (h *BoxOfficeHandler) renderSalePage(w http.ResponseWriter, r *http.Request, status int, user *models.User, event *models.Event, formData map[string]string, sold *models.Order, errorMsg string) {
+	ticketTypes, err := h.boxOfficeService.TicketTypes(event.ID)
+	if err != nil {
+		http.Error(w, "Failed to load ticket types", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(status)
+	component := pages.BoxOfficePage(user, event, ticketTypes, formData, sold, errorMsg)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,99 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// boxOfficeEvents serves one event that only its organizer manages, and
+// that can be edited only until it starts
+type boxOfficeEvents struct {
+	services.EventServiceInterface
+	event *models.Event
+}
+
+func (e *boxOfficeEvents) GetEventByID(id int) (*models.Event, error) {
+	return e.event, nil
+}
+
+func (e *boxOfficeEvents) CanUserEditEvent(eventID, userID int) (bool, error) {
+	return e.event.CanBeEdited() && e.event.OrganizerID == userID, nil
+}
+
+func (e *boxOfficeEvents) CanUserManageEvent(eventID, userID int) (bool, error) {
+	return e.event.OrganizerID == userID, nil
+}
+
+// boxOfficeOrders records door sales in memory
+type boxOfficeOrders struct {
+	ticketTypes []*models.TicketType
+	orders      map[int]*models.Order
+}
+
+func (o *boxOfficeOrders) GetTicketTypesByEvent(eventID int) ([]*models.TicketType, error) {
+	return o.ticketTypes, nil
+}
+
+func (o *boxOfficeOrders) Create(req *models.OrderCreateRequest) (*models.Order, error) {
+	order := &models.Order{ID: len(o.orders) + 1, UserID: req.UserID, EventID: req.EventID, TotalAmount: req.TotalAmount, Status: req.Status}
+	o.orders[order.ID] = order
+	return order, nil
+}
+
+func (o *boxOfficeOrders) GetByID(id int) (*models.Order, error) {
+	return o.orders[id], nil
+}
+
+func (o *boxOfficeOrders) UpdateStatus(id int, status models.OrderStatus) error {
+	o.orders[id].Status = status
+	return nil
+}
+
+func (o *boxOfficeOrders) ProcessOrderCompletion(orderID int, paymentID string, ticketData []struct {
+	TicketTypeID int
+	QRCode       string
+}) error {
+	o.orders[orderID].Status = models.OrderCompleted
+	o.orders[orderID].PaymentID = paymentID
+	return nil
+}
+
+func TestBoxOfficeHandler_SellsOnceTheEventHasStarted(t *testing.T) {
+	organizer := &models.User{ID: 7, Role: models.RoleOrganizer, Email: "organizer@example.com"}
+	event := &models.Event{ID: 3, OrganizerID: organizer.ID, Title: "Gala", Status: models.StatusPublished,
+		StartDate: time.Now().Add(-time.Hour), EndDate: time.Now().Add(3 * time.Hour)}
+	orders := &boxOfficeOrders{
+		ticketTypes: []*models.TicketType{{ID: 1, EventID: event.ID, Name: "General", Price: 1000, Quantity: 100}},
+		orders:      make(map[int]*models.Order),
+	}
+	handler := NewBoxOfficeHandler(services.NewBoxOfficeService(orders, orders, nil, nil), &boxOfficeEvents{event: event}, nil)
+
+	form := url.Values{"payment_method": {"cash"}, "quantity_1": {"2"}}
+	r := httptest.NewRequest(http.MethodPost, "/organizer/events/3/box-office", strings.NewReader(form.Encode()))
+	r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	routeContext := chi.NewRouteContext()
+	routeContext.URLParams.Add("id", "3")
+	ctx := context.WithValue(r.Context(), chi.RouteCtxKey, routeContext)
+	r = r.WithContext(middleware.SetUserContext(ctx, organizer))
+	w := httptest.NewRecorder()
+
+	handler.Sell(w, r)
+
+	if w.Code != http.StatusSeeOther {
+		t.Fatalf("status = %d, want %d: %s", w.Code, http.StatusSeeOther, w.Body.String())
+	}
+	if len(orders.orders) != 1 || orders.orders[1].Status != models.OrderCompleted || orders.orders[1].TotalAmount != 2000 {
+		t.Errorf("orders = %+v, want one completed sale of 2000", orders.orders)
+	}
+}
@@ -3,12 +3,16 @@ package handlers
 import (
 	"encoding/json"
 	"fmt"
+	"log/slog"
+	"math"
 	"net/http"
 	"regexp"
 	"strconv"
 	"strings"
 	"time"
 
+	"event-ticketing-platform/internal/i18n"
+	"event-ticketing-platform/internal/logging"
 	"event-ticketing-platform/internal/middleware"
 	"event-ticketing-platform/internal/models"
 	"event-ticketing-platform/internal/services"
@@ -24,6 +28,14 @@ type CartHandler struct {
 	eventService   services.EventServiceInterface
 	paymentService services.PaymentService
 	store          sessions.Store
+	paymentHealth  *services.PaymentHealthService
+	locales        *services.LocaleService
+	arrivalSlots   *services.ArrivalSlotService
+	questions      *services.CheckoutQuestionService
+	cartAdditions  services.CartAdditionRecorder
+	installments   *services.InstallmentService
+	donations      *services.DonationService
+	taxes          services.TaxRateLookup
 }
 
 // NewCartHandler creates a new cart handler
@@ -41,6 +53,192 @@ func NewCartHandler(
 	}
 }
 
+// SetPaymentHealth warns buyers at checkout about failing payment providers
+// and lets it preselect a healthy one
+func (h *CartHandler) SetPaymentHealth(paymentHealth *services.PaymentHealthService) {
+	h.paymentHealth = paymentHealth
+}
+
+// SetLocaleService preselects the buyer's email language at checkout
+func (h *CartHandler) SetLocaleService(locales *services.LocaleService) {
+	h.locales = locales
+}
+
+// SetArrivalSlotService asks buyers to choose an arrival time for events
+// that use arrival slots
+func (h *CartHandler) SetArrivalSlotService(arrivalSlots *services.ArrivalSlotService) {
+	h.arrivalSlots = arrivalSlots
+}
+
+// SetCheckoutQuestionService asks every attendee the event's checkout
+// questions
+func (h *CartHandler) SetCheckoutQuestionService(questions *services.CheckoutQuestionService) {
+	h.questions = questions
+}
+
+// SetCartAdditionRecorder records tickets added to carts for organizers'
+// sales funnels
+func (h *CartHandler) SetCartAdditionRecorder(recorder services.CartAdditionRecorder) {
+	h.cartAdditions = recorder
+}
+
+// SetInstallmentService lets buyers of events that offer it pay for their
+// order in installments
+func (h *CartHandler) SetInstallmentService(installments *services.InstallmentService) {
+	h.installments = installments
+}
+
+// SetDonationService asks buyers of events that accept donations for one at
+// checkout
+func (h *CartHandler) SetDonationService(donations *services.DonationService) {
+	h.donations = donations
+}
+
+// SetTaxRates adds the tax organizers charge on their tickets at checkout
+func (h *CartHandler) SetTaxRates(taxes services.TaxRateLookup) {
+	h.taxes = taxes
+}
+
+// applyCheckoutTax works out the tax on the cart's tickets at the rate of
+// its event, recalculating its total
+func (h *CartHandler) applyCheckoutTax(cart *models.Cart) error {
+	var rate *models.TaxRate
+	if h.taxes != nil {
+		var err error
+		if rate, err = h.taxes.EventTaxRate(cart.EventID); err != nil {
+			return err
+		}
+	}
+	cart.ApplyTax(rate)
+	return nil
+}
+
+// checkoutDonations returns the event's donation settings if it asks buyers
+// for a donation at checkout
+func (h *CartHandler) checkoutDonations(eventID int) *models.DonationSettings {
+	if h.donations == nil {
+		return nil
+	}
+	return h.donations.CheckoutSettings(eventID)
+}
+
+// recordCartAddition records an addition to the cart, which only feeds
+// analytics and so never fails the request
+func (h *CartHandler) recordCartAddition(eventID, ticketTypeID, userID, quantity int) {
+	if h.cartAdditions == nil {
+		return
+	}
+	if err := h.cartAdditions.RecordCartAddition(eventID, ticketTypeID, userID, quantity); err != nil {
+		slog.Warn("failed to record cart addition", "event_id", eventID, "error", err)
+	}
+}
+
+// checkoutQuestions returns the questions to ask each attendee at checkout,
+// or nil if the event does not ask any
+func (h *CartHandler) checkoutQuestions(eventID int) []*models.CheckoutQuestion {
+	if h.questions == nil {
+		return nil
+	}
+	questions, err := h.questions.GetQuestions(eventID)
+	if err != nil {
+		slog.Warn("failed to load checkout questions", "event_id", eventID, "error", err)
+		return nil
+	}
+	return questions
+}
+
+// checkoutAnswers reads and validates each attendee's answers to the
+// checkout questions, adding them to the form data and any problems to errors
+func checkoutAnswers(r *http.Request, cart *models.Cart, questions []*models.CheckoutQuestion, formData map[string]string, errors map[string][]string) []models.AttendeeAnswers {
+	if len(questions) == 0 {
+		return nil
+	}
+
+	var answers []models.AttendeeAnswers
+	for attendee := 0; attendee < cart.TicketCount(); attendee++ {
+		attendeeAnswers := models.AttendeeAnswers{}
+		for _, question := range questions {
+			field := models.CheckoutAnswerField(attendee, question.ID)
+			formData[field] = r.FormValue(field)
+			answer, err := question.CheckAnswer(formData[field])
+			if err != nil {
+				errors[field] = []string{err.Error()}
+				continue
+			}
+			attendeeAnswers[question.ID] = answer
+		}
+		answers = append(answers, attendeeAnswers)
+	}
+	return answers
+}
+
+// checkoutAttendees reads who each ticket is for, adding the details to the
+// form data and any problems to errors. It returns nil if no ticket was named.
+func checkoutAttendees(r *http.Request, cart *models.Cart, formData map[string]string, errors map[string][]string) []models.TicketAttendee {
+	var attendees []models.TicketAttendee
+	named := false
+	for i := 0; i < cart.TicketCount(); i++ {
+		nameField, emailField := models.AttendeeNameField(i), models.AttendeeEmailField(i)
+		formData[nameField] = r.FormValue(nameField)
+		formData[emailField] = r.FormValue(emailField)
+
+		attendee := models.TicketAttendee{Name: formData[nameField], Email: formData[emailField]}
+		if err := attendee.Validate(); err != nil {
+			errors[nameField] = []string{err.Error()}
+		}
+		named = named || !attendee.IsEmpty()
+		attendees = append(attendees, attendee)
+	}
+
+	if !named {
+		return nil
+	}
+	return attendees
+}
+
+// checkoutArrivalSlots returns the arrival slots buyers can choose from at
+// checkout, or nil if the event does not use them
+func (h *CartHandler) checkoutArrivalSlots(eventID int) []*models.ArrivalSlot {
+	if h.arrivalSlots == nil {
+		return nil
+	}
+	slots, err := h.arrivalSlots.GetSlots(eventID)
+	if err != nil {
+		slog.Warn("failed to load arrival slots", "event_id", eventID, "error", err)
+		return nil
+	}
+	return slots
+}
+
+// checkoutLocale returns the email language to preselect at checkout: the
+// buyer's account preference, else the event's language
+func (h *CartHandler) checkoutLocale(user *models.User, eventID int) string {
+	if h.locales == nil {
+		return i18n.DefaultLocale
+	}
+	return h.locales.ResolveLocale("", user.ID, eventID)
+}
+
+// checkoutPaymentStatus returns the payment providers' status for checkout,
+// with the installment plan the cart can be paid in
+func (h *CartHandler) checkoutPaymentStatus(cart *models.Cart) *models.CheckoutPaymentStatus {
+	status := &models.CheckoutPaymentStatus{DefaultMethod: models.DefaultPaymentMethod}
+	if h.paymentHealth != nil {
+		status = h.paymentHealth.CheckoutStatus()
+	}
+	status.Installments = h.checkoutInstallments(cart)
+	return status
+}
+
+// checkoutInstallments returns the installment plan the cart can be paid
+// in, or nil if its event doesn't offer one for it
+func (h *CartHandler) checkoutInstallments(cart *models.Cart) *models.InstallmentOffer {
+	if h.installments == nil {
+		return nil
+	}
+	return h.installments.Offer(cart.EventID, cart.TotalAmount)
+}
+
 // AddToCartUnified adds tickets to the shopping cart (unified endpoint that accepts event_id as form parameter)
 func (h *CartHandler) AddToCartUnified(w http.ResponseWriter, r *http.Request) {
 	user := middleware.GetUserFromContext(r.Context())
@@ -97,6 +295,14 @@ func (h *CartHandler) AddToCartUnified(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// A tier that has sold out or stopped selling is replaced by the next tier
+	selectedTicketType, err = h.ticketService.ResolveActiveTier(selectedTicketType.ID)
+	if err != nil {
+		http.Error(w, "Failed to get ticket type", http.StatusInternalServerError)
+		return
+	}
+	ticketTypeID = selectedTicketType.ID
+
 	// Check availability
 	if !selectedTicketType.IsAvailable() {
 		http.Error(w, "Tickets are not available", http.StatusBadRequest)
@@ -108,6 +314,18 @@ func (h *CartHandler) AddToCartUnified(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Pay-what-you-want tickets sell at the amount the buyer chose
+	amount, err := parseCurrencyAmount(r.FormValue("amount"))
+	if err != nil {
+		http.Error(w, "Invalid amount", http.StatusBadRequest)
+		return
+	}
+	price, err := selectedTicketType.ChoosePrice(amount)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Get or create cart from session
 	session, err := h.store.Get(r, "session")
 	if err != nil {
@@ -138,7 +356,7 @@ func (h *CartHandler) AddToCartUnified(w http.ResponseWriter, r *http.Request) {
 	for i := range cart.Items {
 		if cart.Items[i].TicketTypeID == ticketTypeID {
 			cart.Items[i].Quantity += quantity
-			cart.Items[i].Subtotal = cart.Items[i].Price * cart.Items[i].Quantity
+			cart.Items[i].Price = price
 			found = true
 			break
 		}
@@ -146,19 +364,16 @@ func (h *CartHandler) AddToCartUnified(w http.ResponseWriter, r *http.Request) {
 
 	if !found {
 		cart.Items = append(cart.Items, models.CartItem{
-			TicketTypeID: ticketTypeID,
-			TicketName:   selectedTicketType.Name,
-			Price:        selectedTicketType.Price,
-			Quantity:     quantity,
-			Subtotal:     selectedTicketType.Price * quantity,
+			TicketTypeID:   ticketTypeID,
+			TicketName:     selectedTicketType.Name,
+			Price:          price,
+			Quantity:       quantity,
+			PayWhatYouWant: selectedTicketType.PayWhatYouWant,
 		})
 	}
 
 	// Recalculate total
-	cart.TotalAmount = 0
-	for _, item := range cart.Items {
-		cart.TotalAmount += item.Subtotal
-	}
+	cart.RecalculateTotal()
 
 	// Set expiration (15 minutes from now)
 	cart.ExpiresAt = time.Now().Add(15 * time.Minute).Unix()
@@ -170,6 +385,7 @@ func (h *CartHandler) AddToCartUnified(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Failed to save session", http.StatusInternalServerError)
 		return
 	}
+	h.recordCartAddition(eventID, ticketTypeID, user.ID, quantity)
 
 	// Return success response for HTMX
 	w.Header().Set("Content-Type", "text/html")
@@ -261,6 +477,14 @@ func (h *CartHandler) AddToCart(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// A tier that has sold out or stopped selling is replaced by the next tier
+	selectedTicketType, err = h.ticketService.ResolveActiveTier(selectedTicketType.ID)
+	if err != nil {
+		http.Error(w, "Failed to get ticket type", http.StatusInternalServerError)
+		return
+	}
+	ticketTypeID = selectedTicketType.ID
+
 	// Check availability
 	if !selectedTicketType.IsAvailable() {
 		http.Error(w, "Tickets are not available", http.StatusBadRequest)
@@ -272,6 +496,18 @@ func (h *CartHandler) AddToCart(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Pay-what-you-want tickets sell at the amount the buyer chose
+	amount, err := parseCurrencyAmount(r.FormValue("amount"))
+	if err != nil {
+		http.Error(w, "Invalid amount", http.StatusBadRequest)
+		return
+	}
+	price, err := selectedTicketType.ChoosePrice(amount)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
 	// Get or create cart from session
 	session, err := h.store.Get(r, "session")
 	if err != nil {
@@ -302,7 +538,7 @@ func (h *CartHandler) AddToCart(w http.ResponseWriter, r *http.Request) {
 	for i := range cart.Items {
 		if cart.Items[i].TicketTypeID == ticketTypeID {
 			cart.Items[i].Quantity += quantity
-			cart.Items[i].Subtotal = cart.Items[i].Price * cart.Items[i].Quantity
+			cart.Items[i].Price = price
 			found = true
 			break
 		}
@@ -310,19 +546,16 @@ func (h *CartHandler) AddToCart(w http.ResponseWriter, r *http.Request) {
 
 	if !found {
 		cart.Items = append(cart.Items, models.CartItem{
-			TicketTypeID: ticketTypeID,
-			TicketName:   selectedTicketType.Name,
-			Price:        selectedTicketType.Price,
-			Quantity:     quantity,
-			Subtotal:     selectedTicketType.Price * quantity,
+			TicketTypeID:   ticketTypeID,
+			TicketName:     selectedTicketType.Name,
+			Price:          price,
+			Quantity:       quantity,
+			PayWhatYouWant: selectedTicketType.PayWhatYouWant,
 		})
 	}
 
 	// Recalculate total
-	cart.TotalAmount = 0
-	for _, item := range cart.Items {
-		cart.TotalAmount += item.Subtotal
-	}
+	cart.RecalculateTotal()
 
 	// Set expiration (15 minutes from now)
 	cart.ExpiresAt = time.Now().Add(15 * time.Minute).Unix()
@@ -334,6 +567,7 @@ func (h *CartHandler) AddToCart(w http.ResponseWriter, r *http.Request) {
 		http.Error(w, "Failed to save session", http.StatusInternalServerError)
 		return
 	}
+	h.recordCartAddition(eventID, ticketTypeID, user.ID, quantity)
 
 	// Return success response for HTMX
 	w.Header().Set("Content-Type", "text/html")
@@ -449,17 +683,13 @@ func (h *CartHandler) UpdateCartItem(w http.ResponseWriter, r *http.Request) {
 			} else {
 				// Update quantity
 				cart.Items[i].Quantity = quantity
-				cart.Items[i].Subtotal = cart.Items[i].Price * quantity
 			}
 			break
 		}
 	}
 
 	// Recalculate total
-	cart.TotalAmount = 0
-	for _, item := range cart.Items {
-		cart.TotalAmount += item.Subtotal
-	}
+	cart.RecalculateTotal()
 
 	// Save cart to session
 	h.saveCartToSession(session, cart)
@@ -510,14 +740,28 @@ func (h *CartHandler) CheckoutPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Pre-fill form with user data
+	if err := h.applyCheckoutTax(cart); err != nil {
+		slog.Error("failed to calculate checkout tax", "event_id", cart.EventID, "error", err)
+		http.Error(w, "Failed to calculate tax", http.StatusInternalServerError)
+		return
+	}
+
+	// Pre-fill form with user data and the preferred payment method
+	payment := h.checkoutPaymentStatus(cart)
 	formData := map[string]string{
-		"billing_email": user.Email,
-		"billing_name":  fmt.Sprintf("%s %s", user.FirstName, user.LastName),
+		"billing_email":  user.Email,
+		"billing_name":   fmt.Sprintf("%s %s", user.FirstName, user.LastName),
+		"payment_method": payment.DefaultMethod,
+		"locale":         h.checkoutLocale(user, cart.EventID),
+	}
+	if cart.Donation > 0 {
+		formData["donation"] = strconv.FormatFloat(float64(cart.Donation)/100, 'f', 2, 64)
 	}
+	// Submitting the form twice is recognised as one checkout
+	formData[middleware.IdempotencyKeyField] = middleware.NewIdempotencyKey()
 
 	// Render checkout page
-	component := pages.CheckoutPage(user, cart, nil, formData)
+	component := pages.CheckoutPage(user, cart, nil, formData, payment, h.checkoutArrivalSlots(cart.EventID), h.checkoutQuestions(cart.EventID), h.checkoutDonations(cart.EventID))
 	err = component.Render(r.Context(), w)
 	if err != nil {
 		http.Error(w, "Failed to render checkout page", http.StatusInternalServerError)
@@ -554,19 +798,15 @@ func (h *CartHandler) ProcessCheckout(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Debug: Log all form values
-	fmt.Printf("🛒 Checkout Form Debug:\n")
-	fmt.Printf("   All form values: %v\n", r.Form)
-
 	// Extract form data
 	billingEmail := strings.TrimSpace(r.FormValue("billing_email"))
 	billingName := strings.TrimSpace(r.FormValue("billing_name"))
 	paymentMethod := r.FormValue("payment_method")
-
-	fmt.Printf("   Extracted values:\n")
-	fmt.Printf("     billing_email: '%s'\n", billingEmail)
-	fmt.Printf("     billing_name: '%s'\n", billingName)
-	fmt.Printf("     payment_method: '%s'\n", paymentMethod)
+	locale := i18n.Normalize(r.FormValue("locale"))
+	var arrivalSlotID *int
+	if id, err := strconv.Atoi(r.FormValue("arrival_slot")); err == nil {
+		arrivalSlotID = &id
+	}
 
 	// Validate form data
 	errors := make(map[string][]string)
@@ -574,7 +814,14 @@ func (h *CartHandler) ProcessCheckout(w http.ResponseWriter, r *http.Request) {
 		"billing_email":  billingEmail,
 		"billing_name":   billingName,
 		"payment_method": paymentMethod,
+		"locale":         locale,
+		"arrival_slot":   r.FormValue("arrival_slot"),
+		"installments":   r.FormValue("installments"),
+		"donation":       strings.TrimSpace(r.FormValue("donation")),
 	}
+	// A form shown again after an error resubmits with the same key, which
+	// was released as the attempt didn't complete
+	formData[middleware.IdempotencyKeyField] = r.FormValue(middleware.IdempotencyKeyField)
 
 	if billingEmail == "" {
 		errors["billing_email"] = []string{"Billing email is required"}
@@ -587,8 +834,45 @@ func (h *CartHandler) ProcessCheckout(w http.ResponseWriter, r *http.Request) {
 	if paymentMethod == "" {
 		errors["payment_method"] = []string{"Payment method is required"}
 	}
+	if h.arrivalSlots != nil {
+		if _, err := h.arrivalSlots.ChooseSlot(cart.EventID, arrivalSlotID, cart.TicketCount()); err != nil {
+			errors["arrival_slot"] = []string{err.Error()}
+		}
+	}
+	// The donation and tax are part of the total, so they are added before
+	// the total is split into installments
+	cart.Donation = 0
+	if donation, err := parseCurrencyAmount(formData["donation"]); err != nil {
+		errors["donation"] = []string{"Donation must be an amount in KSh"}
+	} else if donation > 0 && h.donations == nil {
+		errors["donation"] = []string{"This event doesn't accept donations"}
+	} else if donation > 0 {
+		if cart.Donation, err = h.donations.ChooseDonation(cart.EventID, donation); err != nil {
+			errors["donation"] = []string{err.Error()}
+		}
+	}
+	if err := h.applyCheckoutTax(cart); err != nil {
+		slog.Error("failed to calculate checkout tax", "event_id", cart.EventID, "error", err)
+		errors["general"] = []string{"Tax could not be calculated. Please try again."}
+	}
+
+	var installmentOffer *models.InstallmentOffer
+	if formData["installments"] == "on" {
+		installmentOffer = h.checkoutInstallments(cart)
+		if installmentOffer == nil {
+			errors["installments"] = []string{"This order can't be paid in installments"}
+		} else if paymentMethod != "paystack" {
+			errors["installments"] = []string{"Installments can only be paid by card through Paystack"}
+		}
+	}
+	answers := checkoutAnswers(r, cart, h.checkoutQuestions(cart.EventID), formData, errors)
+	attendees := checkoutAttendees(r, cart, formData, errors)
 
-	fmt.Printf("   Validation errors: %v\n", errors)
+	if changes := h.refreshCartTiers(cart); len(changes) > 0 {
+		h.saveCartToSession(session, cart)
+		session.Save(r, w)
+		errors["general"] = []string{strings.Join(changes, " ") + " Please review your order before paying."}
+	}
 
 	if len(errors) > 0 {
 		h.handleCheckoutError(w, r, errors, formData, user, cart)
@@ -601,6 +885,7 @@ func (h *CartHandler) ProcessCheckout(w http.ResponseWriter, r *http.Request) {
 		ticketSelections = append(ticketSelections, services.TicketSelection{
 			TicketTypeID: item.TicketTypeID,
 			Quantity:     item.Quantity,
+			Price:        item.Price,
 		})
 	}
 
@@ -615,83 +900,111 @@ func (h *CartHandler) ProcessCheckout(w http.ResponseWriter, r *http.Request) {
 		},
 		PaymentMethod: paymentMethod,
 		UserID:        user.ID,
+		Locale:        locale,
+		ArrivalSlotID: arrivalSlotID,
+		Answers:       answers,
+		Attendees:     attendees,
+		Donation:      cart.Donation,
 	}
 
 	// Handle Paystack payment differently (redirect-based)
 	if paymentMethod == "paystack" {
 		// For Paystack, we need to initiate payment and redirect
-		totalAmount := 0
-		for _, item := range cart.Items {
-			totalAmount += item.Price * item.Quantity
+		totalAmount := cart.TotalAmount
+
+		logger := logging.FromContext(r.Context()).With("event_id", cart.EventID, "payment_method", paymentMethod)
+
+		// Hold the tickets while the buyer pays on Paystack's page
+		if err := h.ticketService.HoldTickets(cart.EventID, user.ID, ticketSelections); err != nil {
+			logger.Info("checkout tickets could not be held", "error", err)
+			errors["general"] = []string{fmt.Sprintf("Tickets could not be reserved: %s", err.Error())}
+			h.handleCheckoutError(w, r, errors, formData, user, cart)
+			return
 		}
 
-		fmt.Printf("   💳 Processing Paystack payment for amount: %d\n", totalAmount)
+		// Paying in installments charges the first now, by card so the rest
+		// can be charged to it later
+		chargeAmount, paymentType := totalAmount, paymentMethod
+		if installmentOffer != nil {
+			chargeAmount, paymentType = installmentOffer.Amounts[0], "card"
+		}
+
+		logger.Info("initiating checkout payment", "amount", chargeAmount, "installments", installmentOffer != nil)
 
 		// Process payment with Paystack (this will return a pending status)
 		paymentResult, err := h.paymentService.ProcessPayment(
-			totalAmount,
+			chargeAmount,
 			paymentMethod,
 			services.PaymentBillingInfo{
 				Email:       billingEmail,
 				Name:        billingName,
-				PaymentType: paymentMethod,
+				PaymentType: paymentType,
 			},
 		)
 		if err != nil {
-			fmt.Printf("   ❌ Paystack payment failed: %v\n", err)
+			logger.Warn("checkout payment initiation failed", "error", err)
+			h.ticketService.ReleaseHolds(cart.EventID, user.ID, ticketSelections)
 			errors["general"] = []string{fmt.Sprintf("Payment initiation failed: %s", err.Error())}
 			h.handleCheckoutError(w, r, errors, formData, user, cart)
 			return
 		}
 
-		fmt.Printf("   ✅ Paystack payment initialized: %s\n", paymentResult.PaymentID)
+		logger = logger.With("payment_id", paymentResult.PaymentID)
+		logger.Info("checkout payment initiated")
 
 		// Store cart and payment info in session for callback processing
 		session.Values["pending_payment_id"] = paymentResult.PaymentID
 		session.Values["pending_cart"] = cart
 		session.Values["pending_billing_email"] = billingEmail
 		session.Values["pending_billing_name"] = billingName
+		session.Values["pending_locale"] = locale
+		if arrivalSlotID != nil {
+			session.Values["pending_arrival_slot"] = *arrivalSlotID
+		} else {
+			delete(session.Values, "pending_arrival_slot")
+		}
+		if answersJSON, err := json.Marshal(answers); err == nil && len(answers) > 0 {
+			session.Values["pending_checkout_answers"] = string(answersJSON)
+		} else {
+			delete(session.Values, "pending_checkout_answers")
+		}
+		if attendeesJSON, err := json.Marshal(attendees); err == nil && len(attendees) > 0 {
+			session.Values["pending_attendees"] = string(attendeesJSON)
+		} else {
+			delete(session.Values, "pending_attendees")
+		}
+		if offerJSON, err := json.Marshal(installmentOffer); err == nil && installmentOffer != nil {
+			session.Values["pending_installment_plan"] = string(offerJSON)
+		} else {
+			delete(session.Values, "pending_installment_plan")
+		}
 		session.Values["pending_authorization_url"] = paymentResult.AuthorizationURL // Store the authorization URL
 
-		// Debug: Print session data before saving
-		fmt.Printf("   💾 Saving session data:\n")
-		fmt.Printf("      - pending_payment_id: %s\n", paymentResult.PaymentID)
-		fmt.Printf("      - pending_billing_email: %s\n", billingEmail)
-		fmt.Printf("      - pending_cart items: %d\n", len(cart.Items))
-		fmt.Printf("      - pending_authorization_url: %s\n", paymentResult.AuthorizationURL)
-
 		// Save session with error handling
 		if err := session.Save(r, w); err != nil {
-			fmt.Printf("   ❌ Failed to save session: %v\n", err)
+			logger.Error("failed to save pending payment to session", "error", err)
+			h.ticketService.ReleaseHolds(cart.EventID, user.ID, ticketSelections)
 			h.handleSessionError(w, r, err)
 			return
 		}
 
-		fmt.Printf("   ✅ Session saved successfully\n")
-
 		// Check if we have the authorization URL directly
 		if paymentResult.AuthorizationURL != "" {
-			fmt.Printf("   🔄 Redirecting to Paystack: %s\n", paymentResult.AuthorizationURL)
-
 			// Handle HTMX requests differently to avoid CORS issues
 			if middleware.IsHTMXRequest(r) {
 				// For HTMX requests, use HX-Redirect header to trigger client-side redirect
 				w.Header().Set("HX-Redirect", paymentResult.AuthorizationURL)
 				w.WriteHeader(http.StatusOK)
-				fmt.Printf("   ✅ HTMX redirect header set\n")
 				return
 			} else {
 				// For regular requests, use standard HTTP redirect
 				http.Redirect(w, r, paymentResult.AuthorizationURL, http.StatusSeeOther)
-				fmt.Printf("   ✅ Standard HTTP redirect\n")
 				return
 			}
 		}
 
 		// Fallback to payment redirect page (shouldn't be needed now)
 		redirectURL := fmt.Sprintf("/payment/redirect?payment_id=%s", paymentResult.PaymentID)
-		fmt.Printf("   🔄 Fallback redirect to: %s\n", redirectURL)
-
 		if middleware.IsHTMXRequest(r) {
 			w.Header().Set("HX-Redirect", redirectURL)
 			w.WriteHeader(http.StatusOK)
@@ -776,15 +1089,80 @@ func (h *CartHandler) saveCartToSession(session *sessions.Session, cart *models.
 	session.Values["cart"] = string(cartJSON)
 }
 
+// parseCurrencyAmount parses an amount in KSh entered in a form into cents.
+// An empty amount is 0.
+func parseCurrencyAmount(value string) (int, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return 0, nil
+	}
+	amount, err := strconv.ParseFloat(value, 64)
+	if err != nil || amount < 0 || math.IsInf(amount, 0) || math.IsNaN(amount) {
+		return 0, fmt.Errorf("invalid amount %q", value)
+	}
+	return int(math.Round(amount * 100)), nil
+}
+
 // validateEmail validates email format
 func validateEmail(email string) bool {
 	emailRegex := regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
 	return emailRegex.MatchString(email)
 }
 
+// refreshCartTiers replaces cart items of tiers that have finished since
+// they were added with the tier now on sale, returning a note for each
+func (h *CartHandler) refreshCartTiers(cart *models.Cart) []string {
+	var changes []string
+	var items []models.CartItem
+	for _, item := range cart.Items {
+		ticketType, err := h.ticketService.ResolveActiveTier(item.TicketTypeID)
+		if err != nil || ticketType.ID == item.TicketTypeID {
+			items = append(items, item)
+			continue
+		}
+
+		// An amount chosen for a pay-what-you-want tier carries over if the
+		// new tier is too and accepts it, otherwise its price applies
+		price, err := ticketType.ChoosePrice(item.Price)
+		if err != nil {
+			price = ticketType.Price
+		}
+
+		changes = append(changes, fmt.Sprintf("%s tickets are no longer on sale, so your cart now has %s tickets at KSh %.2f each.", item.TicketName, ticketType.Name, float64(price)/100))
+		item.TicketTypeID = ticketType.ID
+		item.TicketName = ticketType.Name
+		item.Price = price
+		item.PayWhatYouWant = ticketType.PayWhatYouWant
+		items = append(items, item)
+	}
+
+	if len(changes) == 0 {
+		return nil
+	}
+
+	// Merge items that now hold the same tier
+	cart.Items = nil
+	for _, item := range items {
+		merged := false
+		for i := range cart.Items {
+			if cart.Items[i].TicketTypeID == item.TicketTypeID {
+				cart.Items[i].Quantity += item.Quantity
+				merged = true
+				break
+			}
+		}
+		if !merged {
+			cart.Items = append(cart.Items, item)
+		}
+	}
+	cart.RecalculateTotal()
+
+	return changes
+}
+
 // handleCheckoutError returns appropriate error response based on request type
 func (h *CartHandler) handleCheckoutError(w http.ResponseWriter, r *http.Request, errors map[string][]string, formData map[string]string, user *models.User, cart *models.Cart) {
-	component := pages.CheckoutPage(user, cart, errors, formData)
+	component := pages.CheckoutPage(user, cart, errors, formData, h.checkoutPaymentStatus(cart), h.checkoutArrivalSlots(cart.EventID), h.checkoutQuestions(cart.EventID), h.checkoutDonations(cart.EventID))
 	w.WriteHeader(http.StatusUnprocessableEntity)
 	err := component.Render(r.Context(), w)
 	if err != nil {
@@ -0,0 +1,42 @@
+package handlers
+
+import (
+	"net/http"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+)
+
+// CashFlowHandler handles organizers' cash-flow projections
+type CashFlowHandler struct {
+	cashFlowService *services.CashFlowService
+}
+
+// NewCashFlowHandler creates a new cash-flow handler
+func NewCashFlowHandler(cashFlowService *services.CashFlowService) *CashFlowHandler {
+	return &CashFlowHandler{
+		cashFlowService: cashFlowService,
+	}
+}
+
+// CashFlowPage shows the organizer's projected incoming funds by week
+func (h *CashFlowHandler) CashFlowPage(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
+		return
+	}
+
+	projection, err := h.cashFlowService.GetProjection(user.ID)
+	if err != nil {
+		http.Error(w, "Failed to load cash-flow projection", http.StatusInternalServerError)
+		return
+	}
+
+	component := pages.CashFlowPage(user, projection)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+)
+
+// CategoryHandler handles category analytics and category suggestions
+type CategoryHandler struct {
+	analyticsService  *services.AnalyticsService
+	suggestionService *services.CategorySuggestionService
+}
+
+// NewCategoryHandler creates a new category handler
+func NewCategoryHandler(analyticsService *services.AnalyticsService, suggestionService *services.CategorySuggestionService) *CategoryHandler {
+	return &CategoryHandler{
+		analyticsService:  analyticsService,
+		suggestionService: suggestionService,
+	}
+}
+
+// CategoryAnalytics handles GET /admin/categories/analytics
+func (h *CategoryHandler) CategoryAnalytics(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login?redirect=/admin/categories/analytics", http.StatusSeeOther)
+		return
+	}
+
+	if user.Role != models.UserRoleAdmin {
+		http.Error(w, "Forbidden - Admin access required", http.StatusForbidden)
+		return
+	}
+
+	categories, err := h.analyticsService.GetCategoryPerformance()
+	if err != nil {
+		http.Error(w, "Failed to load category analytics", http.StatusInternalServerError)
+		return
+	}
+
+	component := pages.AdminCategoryAnalytics(user, categories)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// SuggestCategory handles GET /organizer/events/suggest-category. It returns
+// the suggested category as JSON, or an empty object when nothing matches.
+func (h *CategoryHandler) SuggestCategory(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	suggestion, err := h.suggestionService.Suggest(r.URL.Query().Get("title"), r.URL.Query().Get("description"))
+	if err != nil {
+		http.Error(w, "Failed to suggest category", http.StatusInternalServerError)
+		return
+	}
+
+	var response interface{} = map[string]interface{}{}
+	if suggestion != nil {
+		response = suggestion
+	}
+
+	if err := writeJSON(w, response); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to write JSON response: %v", err), http.StatusInternalServerError)
+		return
+	}
+}
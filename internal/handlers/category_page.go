@@ -0,0 +1,243 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// categoryPageSize is how many events a category landing page lists at a time
+const categoryPageSize = 12
+
+// CategoryPageHandler handles public category landing pages and admins
+// curating them
+type CategoryPageHandler struct {
+	categoryPageService *services.CategoryPageService
+}
+
+// NewCategoryPageHandler creates a new category landing page handler
+func NewCategoryPageHandler(categoryPageService *services.CategoryPageService) *CategoryPageHandler {
+	return &CategoryPageHandler{
+		categoryPageService: categoryPageService,
+	}
+}
+
+// CategoryPage renders the landing page for a category, e.g. /categories/music
+func (h *CategoryPageHandler) CategoryPage(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+
+	page := 1
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	data, err := h.categoryPageService.GetCategoryPage(strings.ToLower(chi.URLParam(r, "slug")), page, categoryPageSize)
+	if err != nil {
+		if errors.Is(err, services.ErrCategoryNotFound) {
+			http.Error(w, "Category not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to load category events", http.StatusInternalServerError)
+		return
+	}
+
+	component := pages.CategoryPage(user, data)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// CurationPage handles GET /admin/categories/{id}/landing-page, showing the
+// category's hero image, search metadata and featured events
+func (h *CategoryPageHandler) CurationPage(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	categoryID, ok := categoryIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	curation, ok := h.loadCuration(w, categoryID)
+	if !ok {
+		return
+	}
+
+	h.renderCuration(w, r, http.StatusOK, user, curation, landingPageForm(curation.Category), r.URL.Query().Get("saved"), "")
+}
+
+// UpdateLandingPage handles POST /admin/categories/{id}/landing-page
+func (h *CategoryPageHandler) UpdateLandingPage(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	categoryID, ok := categoryIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	formData := map[string]string{
+		"hero_image_url":   r.FormValue("hero_image_url"),
+		"meta_title":       r.FormValue("meta_title"),
+		"meta_description": r.FormValue("meta_description"),
+	}
+	req := &models.CategoryLandingPageRequest{
+		HeroImageURL:    formData["hero_image_url"],
+		MetaTitle:       formData["meta_title"],
+		MetaDescription: formData["meta_description"],
+	}
+
+	if err := req.Validate(); err != nil {
+		curation, ok := h.loadCuration(w, categoryID)
+		if !ok {
+			return
+		}
+		h.renderCuration(w, r, http.StatusBadRequest, user, curation, formData, "", err.Error())
+		return
+	}
+
+	if _, err := h.categoryPageService.UpdateLandingPage(user.ID, categoryID, req, r); err != nil {
+		http.Error(w, "Failed to save category landing page", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, curationPath(categoryID)+"?saved=updated", http.StatusSeeOther)
+}
+
+// FeatureEvent handles POST /admin/categories/{id}/featured, featuring the
+// event in the event_id form value on the category's landing page
+func (h *CategoryPageHandler) FeatureEvent(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	categoryID, ok := categoryIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	eventID, err := strconv.Atoi(strings.TrimSpace(r.FormValue("event_id")))
+	if err != nil {
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.categoryPageService.FeatureEvent(user.ID, categoryID, eventID, r); err != nil {
+		switch {
+		case errors.Is(err, services.ErrCategoryNotFound):
+			http.Error(w, "Category not found", http.StatusNotFound)
+		case errors.Is(err, services.ErrFeaturedEventNotInCategory), errors.Is(err, services.ErrFeaturedEventsFull):
+			curation, ok := h.loadCuration(w, categoryID)
+			if !ok {
+				return
+			}
+			h.renderCuration(w, r, http.StatusBadRequest, user, curation, landingPageForm(curation.Category), "", err.Error())
+		default:
+			http.Error(w, "Failed to feature event", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	http.Redirect(w, r, curationPath(categoryID)+"?saved=featured", http.StatusSeeOther)
+}
+
+// UnfeatureEvent handles POST /admin/categories/{id}/featured/{eventID}/remove
+func (h *CategoryPageHandler) UnfeatureEvent(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	categoryID, ok := categoryIDParam(w, r)
+	if !ok {
+		return
+	}
+
+	eventID, err := strconv.Atoi(chi.URLParam(r, "eventID"))
+	if err != nil {
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.categoryPageService.UnfeatureEvent(user.ID, categoryID, eventID, r); err != nil {
+		if errors.Is(err, services.ErrCategoryNotFound) {
+			http.Error(w, "Category not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to remove featured event", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, curationPath(categoryID)+"?saved=removed", http.StatusSeeOther)
+}
+
+// loadCuration loads what a category's landing page is curated from,
+// responding 404 if the category doesn't exist
+func (h *CategoryPageHandler) loadCuration(w http.ResponseWriter, categoryID int) (*services.CategoryCuration, bool) {
+	curation, err := h.categoryPageService.Curation(categoryID)
+	if err != nil {
+		if errors.Is(err, services.ErrCategoryNotFound) {
+			http.Error(w, "Category not found", http.StatusNotFound)
+			return nil, false
+		}
+		http.Error(w, "Failed to load category landing page", http.StatusInternalServerError)
+		return nil, false
+	}
+	return curation, true
+}
+
+// renderCuration renders the page curating a category's landing page
+func (h *CategoryPageHandler) renderCuration(w http.ResponseWriter, r *http.Request, status int, user *models.User, curation *services.CategoryCuration, formData map[string]string, saved, errorMsg string) {
+	w.WriteHeader(status)
+	component := pages.AdminCategoryLandingPage(user, curation, formData, saved, errorMsg)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// landingPageForm returns the landing page form filled in from a category
+func landingPageForm(category *models.Category) map[string]string {
+	return map[string]string{
+		"hero_image_url":   category.HeroImageURL,
+		"meta_title":       category.MetaTitle,
+		"meta_description": category.MetaDescription,
+	}
+}
+
+// categoryIDParam returns the category ID in the URL, responding 400 if it
+// isn't a number
+func categoryIDParam(w http.ResponseWriter, r *http.Request) (int, bool) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid category ID", http.StatusBadRequest)
+		return 0, false
+	}
+	return id, true
+}
+
+// curationPath returns the admin path curating a category's landing page
+func curationPath(categoryID int) string {
+	return "/admin/categories/" + strconv.Itoa(categoryID) + "/landing-page"
+}
@@ -0,0 +1,191 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// Live check-in dashboards
+const (
+	// checkInStreamRefresh is how often a dashboard reloads its statistics,
+	// picking up scans on other servers and rejected scans, and keeps the
+	// connection alive through proxies
+	checkInStreamRefresh = 10 * time.Second
+	// checkInStreamThrottle is the most often a dashboard reloads as ticket
+	// holders are admitted
+	checkInStreamThrottle = time.Second
+	// checkInStreamRetry is how long browsers wait to reconnect, in
+	// milliseconds
+	checkInStreamRetry = 5000
+)
+
+// SetCheckInBroker pushes admissions to live check-in dashboards as tickets
+// are scanned. Without it dashboards only refresh periodically.
+func (h *TicketScanHandler) SetCheckInBroker(broker *services.CheckInBroker) {
+	h.checkInBroker = broker
+}
+
+// CheckInDashboard handles GET /organizer/events/{id}/check-in, showing how
+// check-in is going during the event
+func (h *TicketScanHandler) CheckInDashboard(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	eventID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	event, stats, err := h.scanService.CheckInDashboard(eventID, user.ID)
+	if err != nil {
+		writeCheckInStatsError(w, err)
+		return
+	}
+
+	component := pages.CheckInDashboardPage(user, event, stats)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// StreamCheckInStats handles GET /organizer/events/{id}/check-in/stream. It
+// streams the dashboard's statistics as server-sent "stats" events holding
+// the rendered HTML, sent on connect and as ticket holders are admitted.
+func (h *TicketScanHandler) StreamCheckInStats(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	eventID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	// Subscribe before loading the statistics, so no admission between the
+	// two is missed. Without a broker, updates stays nil and never receives.
+	var updates <-chan struct{}
+	if h.checkInBroker != nil {
+		var unsubscribe func()
+		updates, unsubscribe = h.checkInBroker.Subscribe(eventID)
+		defer unsubscribe()
+	}
+
+	stats, err := h.scanService.GetCheckInStats(eventID, user.ID)
+	if err != nil {
+		writeCheckInStatsError(w, err)
+		return
+	}
+
+	controller := http.NewResponseController(w)
+	controller.SetWriteDeadline(time.Time{}) // Streams outlive the server's write timeout
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("X-Accel-Buffering", "no") // Stop nginx buffering events
+	w.WriteHeader(http.StatusOK)
+
+	var last []byte
+	send := func(stats *models.CheckInStats) error {
+		var html bytes.Buffer
+		if err := pages.CheckInStatsPartial(stats).Render(r.Context(), &html); err != nil {
+			return err
+		}
+		if bytes.Equal(html.Bytes(), last) {
+			return nil
+		}
+		last = html.Bytes()
+		if _, err := fmt.Fprintf(w, "event: stats\n%s\n", sseData(html.String())); err != nil {
+			return err
+		}
+		return controller.Flush()
+	}
+	reload := func() error {
+		stats, err := h.scanService.GetCheckInStats(eventID, user.ID)
+		if err != nil {
+			return nil // Keep what the dashboard shows and try again later
+		}
+		return send(stats)
+	}
+
+	fmt.Fprintf(w, "retry: %d\n\n", checkInStreamRetry)
+	if err := send(stats); err != nil {
+		return
+	}
+
+	refresh := time.NewTicker(checkInStreamRefresh)
+	defer refresh.Stop()
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case _, ok := <-updates:
+			if !ok {
+				return // Shutting down
+			}
+			if err := reload(); err != nil {
+				return
+			}
+			// Admissions while waiting are merged into the next reload
+			select {
+			case <-r.Context().Done():
+				return
+			case <-time.After(checkInStreamThrottle):
+			}
+		case <-refresh.C:
+			if err := reload(); err != nil {
+				return
+			}
+			// Comments keep idle connections open
+			if _, err := fmt.Fprint(w, ": keep-alive\n\n"); err != nil {
+				return
+			}
+			if err := controller.Flush(); err != nil {
+				return
+			}
+		}
+	}
+}
+
+// sseData formats text as the data lines of a server-sent event, which
+// browsers join back together with newlines
+func sseData(text string) string {
+	var data strings.Builder
+	for _, line := range strings.Split(text, "\n") {
+		data.WriteString("data: ")
+		data.WriteString(strings.TrimSuffix(line, "\r"))
+		data.WriteString("\n")
+	}
+	return data.String()
+}
+
+// writeCheckInStatsError reports why an event's check-in statistics couldn't
+// be loaded
+func writeCheckInStatsError(w http.ResponseWriter, err error) {
+	switch {
+	case strings.Contains(err.Error(), "does not have access"):
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	case strings.Contains(err.Error(), "event not found"):
+		http.Error(w, "Event not found", http.StatusNotFound)
+	default:
+		http.Error(w, "Failed to load check-in statistics", http.StatusInternalServerError)
+	}
+}
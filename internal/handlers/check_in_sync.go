@@ -0,0 +1,119 @@
+package handlers
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// maxCheckInSyncBody is the largest check-in upload accepted, in bytes
+const maxCheckInSyncBody = 1 << 20
+
+// ManifestKey handles GET /scanner/manifest-key, returning the public key
+// scanning apps verify check-in manifests with
+func (h *TicketScanHandler) ManifestKey(w http.ResponseWriter, r *http.Request) {
+	key := h.scanService.ManifestPublicKey()
+	if key == nil {
+		http.Error(w, "Check-in manifests are not enabled", http.StatusNotFound)
+		return
+	}
+
+	response := map[string]string{
+		"algorithm":  "ed25519",
+		"public_key": base64.StdEncoding.EncodeToString(key),
+	}
+	if err := writeJSON(w, response); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to write JSON response: %v", err), http.StatusInternalServerError)
+		return
+	}
+}
+
+// DownloadManifest handles GET /scanner/events/{id}/manifest, returning the
+// signed list of the event's tickets for checking them in offline
+func (h *TicketScanHandler) DownloadManifest(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	eventID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	manifest, err := h.scanService.BuildManifest(eventID, user.ID)
+	if err != nil {
+		writeCheckInSyncError(w, err, "Failed to build check-in manifest")
+		return
+	}
+
+	if err := writeJSON(w, manifest); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to write JSON response: %v", err), http.StatusInternalServerError)
+		return
+	}
+}
+
+// SyncCheckIns handles POST /scanner/events/{id}/check-ins. It accepts the
+// scans a device made offline as JSON and returns what became of each.
+func (h *TicketScanHandler) SyncCheckIns(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	eventID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	req := &services.CheckInSyncRequest{}
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxCheckInSyncBody)).Decode(req); err != nil {
+		http.Error(w, "Invalid JSON", http.StatusBadRequest)
+		return
+	}
+
+	req.EventID = eventID
+	req.StaffUserID = user.ID
+	if req.Device == "" {
+		req.Device = r.UserAgent()
+	}
+
+	result, err := h.scanService.SyncCheckIns(req)
+	if err != nil {
+		writeCheckInSyncError(w, err, "Failed to sync check-ins")
+		return
+	}
+
+	if err := writeJSON(w, result); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to write JSON response: %v", err), http.StatusInternalServerError)
+		return
+	}
+}
+
+// writeCheckInSyncError reports why a manifest or upload failed
+func writeCheckInSyncError(w http.ResponseWriter, err error, fallback string) {
+	switch {
+	case strings.Contains(err.Error(), "does not have access"):
+		http.Error(w, "Forbidden", http.StatusForbidden)
+	case strings.Contains(err.Error(), "event not found"):
+		http.Error(w, "Event not found", http.StatusNotFound)
+	case strings.Contains(err.Error(), "not configured"):
+		http.Error(w, "Check-in manifests are not enabled", http.StatusNotFound)
+	case strings.Contains(err.Error(), "required"), strings.Contains(err.Error(), "at most"):
+		http.Error(w, err.Error(), http.StatusBadRequest)
+	default:
+		http.Error(w, fallback, http.StatusInternalServerError)
+	}
+}
@@ -0,0 +1,117 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// CheckoutQuestionHandler handles organizers' checkout questions for an event
+type CheckoutQuestionHandler struct {
+	questionService *services.CheckoutQuestionService
+	eventService    services.EventServiceInterface
+}
+
+// NewCheckoutQuestionHandler creates a new checkout question handler
+func NewCheckoutQuestionHandler(questionService *services.CheckoutQuestionService, eventService services.EventServiceInterface) *CheckoutQuestionHandler {
+	return &CheckoutQuestionHandler{
+		questionService: questionService,
+		eventService:    eventService,
+	}
+}
+
+// QuestionsPage shows the questions an event asks attendees at checkout
+func (h *CheckoutQuestionHandler) QuestionsPage(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	h.renderPage(w, r, http.StatusOK, user, event, map[string]string{}, r.URL.Query().Get("saved") == "1", "")
+}
+
+// AddQuestion adds a checkout question to an event
+func (h *CheckoutQuestionHandler) AddQuestion(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	formData := map[string]string{
+		"label":    r.FormValue("label"),
+		"type":     r.FormValue("type"),
+		"options":  r.FormValue("options"),
+		"required": r.FormValue("required"),
+	}
+
+	// Dropdown options are entered one per line
+	req := &models.CheckoutQuestionCreateRequest{
+		EventID:  event.ID,
+		Label:    formData["label"],
+		Type:     models.CheckoutQuestionType(formData["type"]),
+		Options:  strings.Split(formData["options"], "\n"),
+		Required: formData["required"] == "on",
+	}
+	if _, err := h.questionService.AddQuestion(req); err != nil {
+		if strings.HasPrefix(err.Error(), "failed to") {
+			http.Error(w, "Failed to add question", http.StatusInternalServerError)
+			return
+		}
+		h.renderPage(w, r, http.StatusBadRequest, user, event, formData, false, err.Error())
+		return
+	}
+
+	http.Redirect(w, r, "/organizer/events/"+strconv.Itoa(event.ID)+"/questions?saved=1", http.StatusSeeOther)
+}
+
+// DeleteQuestion removes a checkout question and the answers given to it
+func (h *CheckoutQuestionHandler) DeleteQuestion(w http.ResponseWriter, r *http.Request) {
+	_, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	questionID, err := strconv.Atoi(chi.URLParam(r, "questionID"))
+	if err != nil {
+		http.Error(w, "Invalid question ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.questionService.DeleteQuestion(event.ID, questionID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, "Question not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to delete question", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/organizer/events/"+strconv.Itoa(event.ID)+"/questions?saved=1", http.StatusSeeOther)
+}
+
+// renderPage renders the checkout questions page with the event's current questions
+func (h *CheckoutQuestionHandler) renderPage(w http.ResponseWriter, r *http.Request, status int, user *models.User, event *models.Event, formData map[string]string, saved bool, errorMsg string) {
+	questions, err := h.questionService.GetQuestions(event.ID)
+	if err != nil {
+		http.Error(w, "Failed to load questions", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(status)
+	component := pages.CheckoutQuestionsPage(user, event, questions, formData, saved, errorMsg)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
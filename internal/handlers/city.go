@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// CityHandler handles public city landing pages
+type CityHandler struct {
+	cityService *services.CityService
+}
+
+// NewCityHandler creates a new city handler
+func NewCityHandler(cityService *services.CityService) *CityHandler {
+	return &CityHandler{
+		cityService: cityService,
+	}
+}
+
+// CityPage renders the landing page for a city, e.g. /events/nairobi
+func (h *CityHandler) CityPage(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+
+	slug := strings.ToLower(chi.URLParam(r, "slug"))
+	category := r.URL.Query().Get("category")
+
+	page := 1
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	data, err := h.cityService.GetCityPage(slug, category, page, 12)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, "City not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to load city events", http.StatusInternalServerError)
+		return
+	}
+
+	component := pages.CityPage(user, data)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// CitiesPage renders the directory of cities with upcoming events, /cities
+func (h *CityHandler) CitiesPage(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+
+	regions, err := h.cityService.GetCityDirectory()
+	if err != nil {
+		http.Error(w, "Failed to load cities", http.StatusInternalServerError)
+		return
+	}
+
+	component := pages.CitiesPage(user, regions, h.cityService.DirectoryURL())
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
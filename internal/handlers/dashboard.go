@@ -7,6 +7,7 @@ import (
 	"strings"
 	"time"
 
+	"event-ticketing-platform/internal/logging"
 	"event-ticketing-platform/internal/middleware"
 	"event-ticketing-platform/internal/models"
 	"event-ticketing-platform/internal/repositories"
@@ -22,6 +23,13 @@ type DashboardHandler struct {
 	orderService  services.OrderServiceInterface
 	eventService  services.EventServiceInterface
 	ticketService services.TicketServiceInterface
+
+	calendarService    *services.TicketCalendarService
+	favoriteService    *services.FavoriteService
+	savedSearchService *services.SavedSearchService
+	attendeeService    *services.TicketAttendeeService
+	accessService      *services.EventAccessService
+	agendaService      *services.EventAgendaService
 }
 
 // NewDashboardHandler creates a new dashboard handler
@@ -33,16 +41,39 @@ func NewDashboardHandler(orderService services.OrderServiceInterface, eventServi
 	}
 }
 
+// SetTicketCalendarService enables the personal calendar feed link on the dashboard
+func (h *DashboardHandler) SetTicketCalendarService(calendarService *services.TicketCalendarService) {
+	h.calendarService = calendarService
+}
+
+// SetFavoriteService lists the events the attendee saved on the dashboard
+func (h *DashboardHandler) SetFavoriteService(favoriteService *services.FavoriteService) {
+	h.favoriteService = favoriteService
+}
+
+// SetSavedSearchService lists the attendee's saved searches on the dashboard
+// and lets them save more
+func (h *DashboardHandler) SetSavedSearchService(savedSearchService *services.SavedSearchService) {
+	h.savedSearchService = savedSearchService
+}
+
+// SetEventAccessService shows the access link of online events with the tickets
+func (h *DashboardHandler) SetEventAccessService(accessService *services.EventAccessService) {
+	h.accessService = accessService
+}
+
+// SetEventAgendaService shows the event's agenda with the tickets
+func (h *DashboardHandler) SetEventAgendaService(agendaService *services.EventAgendaService) {
+	h.agendaService = agendaService
+}
+
 // DashboardPage renders the main dashboard page
 func (h *DashboardHandler) DashboardPage(w http.ResponseWriter, r *http.Request) {
 	user := middleware.GetUserFromContext(r.Context())
-	fmt.Printf("[DEBUG] Dashboard handler - User from context: %+v\n", user)
 	if user == nil {
-		fmt.Printf("[DEBUG] Dashboard handler - No user found, redirecting to login\n")
 		http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
 		return
 	}
-	fmt.Printf("[DEBUG] Dashboard handler - User found: ID=%d, Email=%s\n", user.ID, user.Email)
 
 	// Get user's orders (recent 10)
 	recentOrdersWithDetails, _, err := h.orderService.GetUserOrders(user.ID, 10, 0)
@@ -96,6 +127,23 @@ func (h *DashboardHandler) DashboardPage(w http.ResponseWriter, r *http.Request)
 		RecentOrders:      recentOrdersWithDetails, // Use recent orders for display
 		RecommendedEvents: recommendedEvents,
 	}
+	if h.calendarService != nil {
+		dashboardData.CalendarFeedURL = h.calendarService.FeedURL(user.ID)
+	}
+	if h.favoriteService != nil {
+		if dashboardData.SavedEvents, err = h.favoriteService.GetSavedEvents(user.ID); err != nil {
+			logging.FromContext(r.Context()).Warn("failed to get saved events", "error", err)
+		}
+	}
+	if h.savedSearchService != nil {
+		if dashboardData.SavedSearches, err = h.savedSearchService.GetSearches(user.ID); err != nil {
+			logging.FromContext(r.Context()).Warn("failed to get saved searches", "error", err)
+		}
+		if dashboardData.Categories, err = h.eventService.GetCategories(); err != nil {
+			logging.FromContext(r.Context()).Warn("failed to get categories", "error", err)
+		}
+		dashboardData.SavedSearchError = savedSearchErrors[r.URL.Query().Get("search_error")]
+	}
 
 	component := pages.AttendeeDashboard(user, dashboardData)
 	err = component.Render(r.Context(), w)
@@ -235,9 +283,24 @@ func (h *DashboardHandler) OrderDetailsPage(w http.ResponseWriter, r *http.Reque
 			ticketTypes[tt.ID] = tt
 		}
 	}
+	h.applyPaidPrices(order.ID, ticketTypes)
+
+	var access *models.EventAccess
+	if h.accessService != nil {
+		if access, err = h.accessService.GetAccess(event, user.ID); err != nil {
+			logging.FromContext(r.Context()).Warn("failed to load event access", "event_id", event.ID, "error", err)
+		}
+	}
+
+	var agenda *models.EventAgenda
+	if h.agendaService != nil {
+		if agenda, err = h.agendaService.GetAgenda(event.ID); err != nil {
+			logging.FromContext(r.Context()).Warn("failed to load event agenda", "event_id", event.ID, "error", err)
+		}
+	}
 
 	// Render enhanced order details page
-	component := pages.OrderDetailsEnhancedPage(user, order, event, tickets, ticketTypes)
+	component := pages.OrderDetailsEnhancedPage(user, order, event, tickets, ticketTypes, h.walletPassOptions(), h.attendeeEditView(r, event), access, agenda)
 	err = component.Render(r.Context(), w)
 	if err != nil {
 		http.Error(w, "Failed to render order details", http.StatusInternalServerError)
@@ -245,6 +308,33 @@ func (h *DashboardHandler) OrderDetailsPage(w http.ResponseWriter, r *http.Reque
 	}
 }
 
+// orderItemPriceProvider is implemented by order services that record the
+// price each ticket of an order was bought at
+type orderItemPriceProvider interface {
+	GetItemPrices(orderID int) (map[int]int, error)
+}
+
+// applyPaidPrices shows the price the buyer paid for each ticket type of an
+// order, which differs from the current price for pay-what-you-want tickets
+// and tiers that have since moved on
+func (h *DashboardHandler) applyPaidPrices(orderID int, ticketTypes map[int]*models.TicketType) {
+	provider, ok := h.orderService.(orderItemPriceProvider)
+	if !ok {
+		return
+	}
+	prices, err := provider.GetItemPrices(orderID)
+	if err != nil {
+		return
+	}
+	for id, price := range prices {
+		if tt, ok := ticketTypes[id]; ok && tt.Price != price {
+			paid := *tt
+			paid.Price = price
+			ticketTypes[id] = &paid
+		}
+	}
+}
+
 // DownloadTickets handles ticket download requests
 func (h *DashboardHandler) DownloadTickets(w http.ResponseWriter, r *http.Request) {
 	user := middleware.GetUserFromContext(r.Context())
@@ -346,7 +436,7 @@ func (h *DashboardHandler) OrderConfirmationPage(w http.ResponseWriter, r *http.
 	}
 
 	// Render order confirmation page
-	component := pages.OrderConfirmationPage(user, order, tickets, event)
+	component := pages.OrderConfirmationPage(user, order, tickets, event, h.walletPassOptions())
 	err = component.Render(r.Context(), w)
 	if err != nil {
 		http.Error(w, "Failed to render order confirmation", http.StatusInternalServerError)
@@ -137,6 +137,14 @@ func (m *MockEventServiceForDashboard) GetEventByID(id int) (*models.Event, erro
 	return args.Get(0).(*models.Event), args.Error(1)
 }
 
+func (m *MockEventServiceForDashboard) GetEventBySlug(slug string) (*models.Event, error) {
+	args := m.Called(slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*models.Event), args.Error(1)
+}
+
 func (m *MockEventServiceForDashboard) GetFeaturedEvents(limit int) ([]*models.Event, error) {
 	args := m.Called(limit)
 	return args.Get(0).([]*models.Event), args.Error(1)
@@ -192,6 +200,11 @@ func (m *MockEventServiceForDashboard) CanUserDeleteEvent(eventID int, userID in
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockEventServiceForDashboard) CanUserManageEvent(eventID int, userID int) (bool, error) {
+	args := m.Called(eventID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockEventServiceForDashboard) UpdateEventStatus(eventID int, status models.EventStatus, organizerID int) (*models.Event, error) {
 	args := m.Called(eventID, status, organizerID)
 	if args.Get(0) == nil {
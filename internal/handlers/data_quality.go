@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"net/http"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// DataQualityHandler handles the admin data quality dashboard
+type DataQualityHandler struct {
+	dataQualityService *services.DataQualityService
+}
+
+// NewDataQualityHandler creates a new data quality handler
+func NewDataQualityHandler(dataQualityService *services.DataQualityService) *DataQualityHandler {
+	return &DataQualityHandler{
+		dataQualityService: dataQualityService,
+	}
+}
+
+// Dashboard handles GET /admin/data-quality
+func (h *DataQualityHandler) Dashboard(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login?redirect=/admin/data-quality", http.StatusSeeOther)
+		return
+	}
+
+	if user.Role != models.UserRoleAdmin {
+		http.Error(w, "Forbidden - Admin access required", http.StatusForbidden)
+		return
+	}
+
+	report := h.dataQualityService.LastReport()
+	if report == nil {
+		report = h.dataQualityService.RunChecks(r.Context())
+	}
+
+	component := pages.AdminDataQuality(user, report, h.dataQualityService.LastRemediation())
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// RunChecks handles POST /admin/data-quality/run
+func (h *DataQualityHandler) RunChecks(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil || user.Role != models.UserRoleAdmin {
+		http.Error(w, "Forbidden - Admin access required", http.StatusForbidden)
+		return
+	}
+
+	h.dataQualityService.RunChecks(r.Context())
+
+	http.Redirect(w, r, "/admin/data-quality", http.StatusSeeOther)
+}
+
+// Remediate handles POST /admin/data-quality/{check}/remediate
+func (h *DataQualityHandler) Remediate(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil || user.Role != models.UserRoleAdmin {
+		http.Error(w, "Forbidden - Admin access required", http.StatusForbidden)
+		return
+	}
+
+	check := models.DataQualityCheck(chi.URLParam(r, "check"))
+	if _, err := h.dataQualityService.Remediate(r.Context(), check, user.ID, r); err != nil {
+		http.Error(w, "Failed to run remediation: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/data-quality", http.StatusSeeOther)
+}
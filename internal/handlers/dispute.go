@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"strconv"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// DisputeHandler handles payment disputes: the provider's webhooks reporting
+// them, organizers' evidence and the admin queue
+type DisputeHandler struct {
+	disputeService *services.DisputeService
+}
+
+// NewDisputeHandler creates a new dispute handler
+func NewDisputeHandler(disputeService *services.DisputeService) *DisputeHandler {
+	return &DisputeHandler{
+		disputeService: disputeService,
+	}
+}
+
+// PaystackWebhook handles POST /webhooks/paystack, applying the dispute
+// events Paystack reports
+func (h *DisputeHandler) PaystackWebhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		http.Error(w, "Failed to read request", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.disputeService.HandlePaystackWebhook(r.Header, body); err != nil {
+		if errors.Is(err, services.ErrInvalidWebhookSignature) {
+			http.Error(w, "Invalid signature", http.StatusUnauthorized)
+			return
+		}
+		// Paystack retries deliveries that fail
+		slog.Error("failed to handle Paystack webhook", "error", err)
+		http.Error(w, "Failed to handle webhook", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusOK)
+}
+
+// DisputesPage handles GET /organizer/disputes, listing the disputes of the
+// organizer's orders with a form to submit evidence against open ones
+func (h *DisputeHandler) DisputesPage(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
+		return
+	}
+
+	h.renderDisputes(w, r, http.StatusOK, user, r.URL.Query().Get("submitted") == "1", "")
+}
+
+// SubmitEvidence handles POST /organizer/disputes/{id}/evidence, with the
+// evidence text and an optional file
+func (h *DisputeHandler) SubmitEvidence(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	disputeID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid dispute ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseMultipartForm(models.MaxDisputeEvidenceSize + 1<<20); err != nil {
+		h.renderDisputes(w, r, http.StatusBadRequest, user, false, "Evidence file is too large")
+		return
+	}
+
+	var file io.Reader
+	if upload, _, err := r.FormFile("evidence_file"); err == nil {
+		defer upload.Close()
+		file = upload
+	}
+
+	if err := h.disputeService.SubmitEvidence(r.Context(), disputeID, user.ID, r.FormValue("evidence_text"), file); err != nil {
+		if errors.Is(err, services.ErrDisputeNotFound) {
+			http.Error(w, "Dispute not found", http.StatusNotFound)
+			return
+		}
+		h.renderDisputes(w, r, http.StatusBadRequest, user, false, err.Error())
+		return
+	}
+
+	http.Redirect(w, r, "/organizer/disputes?submitted=1", http.StatusSeeOther)
+}
+
+// renderDisputes renders the organizer's disputes page
+func (h *DisputeHandler) renderDisputes(w http.ResponseWriter, r *http.Request, status int, user *models.User, submitted bool, errorMsg string) {
+	disputes, err := h.disputeService.Disputes(user.ID)
+	if err != nil {
+		http.Error(w, "Failed to load disputes", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(status)
+	component := pages.OrganizerDisputesPage(user, disputes, submitted, errorMsg)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// AdminDisputes handles GET /admin/disputes, listing every dispute and its outcome
+func (h *DisputeHandler) AdminDisputes(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login?redirect=/admin/disputes", http.StatusSeeOther)
+		return
+	}
+
+	if user.Role != models.UserRoleAdmin {
+		http.Error(w, "Forbidden - Admin access required", http.StatusForbidden)
+		return
+	}
+
+	disputes, err := h.disputeService.AllDisputes()
+	if err != nil {
+		http.Error(w, "Failed to load disputes", http.StatusInternalServerError)
+		return
+	}
+
+	component := pages.AdminDisputes(user, disputes)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// ResolveDispute handles POST /admin/disputes/{id}/resolve, recording the
+// outcome of a dispute settled with the provider directly
+func (h *DisputeHandler) ResolveDispute(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil || user.Role != models.UserRoleAdmin {
+		http.Error(w, "Forbidden - Admin access required", http.StatusForbidden)
+		return
+	}
+
+	disputeID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid dispute ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	status := models.DisputeStatus(r.FormValue("outcome"))
+	if err := h.disputeService.Resolve(disputeID, status, r.FormValue("note")); err != nil {
+		if errors.Is(err, services.ErrDisputeNotFound) {
+			http.Error(w, "Dispute not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/disputes", http.StatusSeeOther)
+}
@@ -0,0 +1,94 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+)
+
+// DonationHandler handles organizers' donation settings
+type DonationHandler struct {
+	donationService *services.DonationService
+	eventService    services.EventServiceInterface
+}
+
+// NewDonationHandler creates a new donation handler
+func NewDonationHandler(donationService *services.DonationService, eventService services.EventServiceInterface) *DonationHandler {
+	return &DonationHandler{
+		donationService: donationService,
+		eventService:    eventService,
+	}
+}
+
+// SettingsPage shows the donation settings for one of the organizer's events
+func (h *DonationHandler) SettingsPage(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	settings, err := h.donationService.GetSettings(event.ID)
+	if err != nil {
+		http.Error(w, "Failed to load donation settings", http.StatusInternalServerError)
+		return
+	}
+
+	formData := map[string]string{
+		"message": settings.Message,
+	}
+	if settings.Enabled {
+		formData["enabled"] = "on"
+	}
+
+	h.renderSettings(w, r, http.StatusOK, user, event, formData, r.URL.Query().Get("saved") == "1", "")
+}
+
+// UpdateSettings turns asking for donations at checkout on or off for an event
+func (h *DonationHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	formData := map[string]string{
+		"enabled": r.FormValue("enabled"),
+		"message": r.FormValue("message"),
+	}
+
+	// Unchecked checkboxes are not submitted
+	settings := &models.DonationSettings{
+		EventID: event.ID,
+		Enabled: formData["enabled"] == "on",
+		Message: strings.TrimSpace(formData["message"]),
+	}
+	if err := settings.Validate(); err != nil {
+		h.renderSettings(w, r, http.StatusBadRequest, user, event, formData, false, err.Error())
+		return
+	}
+
+	if err := h.donationService.UpdateSettings(settings); err != nil {
+		http.Error(w, "Failed to save donation settings", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/organizer/events/"+strconv.Itoa(event.ID)+"/donations?saved=1", http.StatusSeeOther)
+}
+
+// renderSettings renders the donation settings page
+func (h *DonationHandler) renderSettings(w http.ResponseWriter, r *http.Request, status int, user *models.User, event *models.Event, formData map[string]string, saved bool, errorMsg string) {
+	w.WriteHeader(status)
+	component := pages.EventDonationsPage(user, event, formData, saved, errorMsg)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,48 @@
+package handlers
+
+import (
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+
+	"event-ticketing-platform/internal/services"
+)
+
+// maxWebhookBody limits the size of webhook requests
+const maxWebhookBody = 1 << 20
+
+// EmailWebhookHandler receives the delivery events the email provider reports
+type EmailWebhookHandler struct {
+	emailLogService *services.EmailLogService
+}
+
+// NewEmailWebhookHandler creates a new email webhook handler
+func NewEmailWebhookHandler(emailLogService *services.EmailLogService) *EmailWebhookHandler {
+	return &EmailWebhookHandler{
+		emailLogService: emailLogService,
+	}
+}
+
+// ResendWebhook applies a Resend delivery, bounce or complaint event to the
+// email log
+func (h *EmailWebhookHandler) ResendWebhook(w http.ResponseWriter, r *http.Request) {
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxWebhookBody))
+	if err != nil {
+		http.Error(w, "Failed to read request", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.emailLogService.HandleResendWebhook(r.Header, body); err != nil {
+		if errors.Is(err, services.ErrInvalidWebhookSignature) {
+			http.Error(w, "Invalid signature", http.StatusUnauthorized)
+			return
+		}
+		// Resend retries deliveries that fail
+		slog.Error("failed to handle Resend webhook", "error", err)
+		http.Error(w, "Failed to handle webhook", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+}
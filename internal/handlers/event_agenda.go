@@ -0,0 +1,354 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// EventAgendaHandler handles organizers' agendas and lineups for an event
+type EventAgendaHandler struct {
+	agendaService *services.EventAgendaService
+	eventService  services.EventServiceInterface
+}
+
+// NewEventAgendaHandler creates a new event agenda handler
+func NewEventAgendaHandler(agendaService *services.EventAgendaService, eventService services.EventServiceInterface) *EventAgendaHandler {
+	return &EventAgendaHandler{
+		agendaService: agendaService,
+		eventService:  eventService,
+	}
+}
+
+// AgendaPage handles GET /organizer/events/{id}/agenda
+func (h *EventAgendaHandler) AgendaPage(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	h.renderAgendaPage(w, r, http.StatusOK, user, event, &models.EventSession{}, &models.EventSpeaker{}, "")
+}
+
+// AddSession handles POST /organizer/events/{id}/agenda/sessions
+func (h *EventAgendaHandler) AddSession(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	session, err := parseEventSession(r)
+	if err == nil {
+		err = h.agendaService.AddSession(event, session)
+	}
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "failed to") {
+			http.Error(w, "Failed to add session", http.StatusInternalServerError)
+			return
+		}
+		h.renderAgendaPage(w, r, http.StatusBadRequest, user, event, session, &models.EventSpeaker{}, err.Error())
+		return
+	}
+
+	http.Redirect(w, r, agendaPath(event.ID)+"?saved=1", http.StatusSeeOther)
+}
+
+// EditSessionPage handles GET /organizer/events/{id}/agenda/sessions/{sessionID}/edit
+func (h *EventAgendaHandler) EditSessionPage(w http.ResponseWriter, r *http.Request) {
+	user, event, session, ok := h.loadSession(w, r)
+	if !ok {
+		return
+	}
+
+	h.renderSessionForm(w, r, http.StatusOK, user, event, session, "")
+}
+
+// UpdateSession handles POST /organizer/events/{id}/agenda/sessions/{sessionID}
+func (h *EventAgendaHandler) UpdateSession(w http.ResponseWriter, r *http.Request) {
+	user, event, current, ok := h.loadSession(w, r)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	session, err := parseEventSession(r)
+	session.ID = current.ID
+	if err == nil {
+		err = h.agendaService.UpdateSession(event, session)
+	}
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "failed to") {
+			http.Error(w, "Failed to update session", http.StatusInternalServerError)
+			return
+		}
+		h.renderSessionForm(w, r, http.StatusBadRequest, user, event, session, err.Error())
+		return
+	}
+
+	http.Redirect(w, r, agendaPath(event.ID)+"?saved=1", http.StatusSeeOther)
+}
+
+// DeleteSession handles POST /organizer/events/{id}/agenda/sessions/{sessionID}/delete
+func (h *EventAgendaHandler) DeleteSession(w http.ResponseWriter, r *http.Request) {
+	_, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	sessionID, err := strconv.Atoi(chi.URLParam(r, "sessionID"))
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.agendaService.DeleteSession(event.ID, sessionID); err != nil {
+		if errors.Is(err, services.ErrAgendaItemNotFound) {
+			http.Error(w, "Session not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to delete session", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, agendaPath(event.ID)+"?saved=1", http.StatusSeeOther)
+}
+
+// AddSpeaker handles POST /organizer/events/{id}/agenda/speakers
+func (h *EventAgendaHandler) AddSpeaker(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	req := parseSpeakerRequest(r)
+	if _, err := h.agendaService.AddSpeaker(event.ID, req); err != nil {
+		if strings.HasPrefix(err.Error(), "failed to") {
+			http.Error(w, "Failed to add speaker", http.StatusInternalServerError)
+			return
+		}
+		speaker := &models.EventSpeaker{Name: req.Name, Role: req.Role, Bio: req.Bio}
+		h.renderAgendaPage(w, r, http.StatusBadRequest, user, event, &models.EventSession{}, speaker, err.Error())
+		return
+	}
+
+	http.Redirect(w, r, agendaPath(event.ID)+"?saved=1", http.StatusSeeOther)
+}
+
+// EditSpeakerPage handles GET /organizer/events/{id}/agenda/speakers/{speakerID}/edit
+func (h *EventAgendaHandler) EditSpeakerPage(w http.ResponseWriter, r *http.Request) {
+	user, event, speaker, ok := h.loadSpeaker(w, r)
+	if !ok {
+		return
+	}
+
+	h.renderSpeakerForm(w, r, http.StatusOK, user, event, speaker, "")
+}
+
+// UpdateSpeaker handles POST /organizer/events/{id}/agenda/speakers/{speakerID}
+func (h *EventAgendaHandler) UpdateSpeaker(w http.ResponseWriter, r *http.Request) {
+	user, event, current, ok := h.loadSpeaker(w, r)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseMultipartForm(10 << 20); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	req := parseSpeakerRequest(r)
+	if _, err := h.agendaService.UpdateSpeaker(event.ID, current.ID, req); err != nil {
+		if strings.HasPrefix(err.Error(), "failed to") {
+			http.Error(w, "Failed to update speaker", http.StatusInternalServerError)
+			return
+		}
+		edited := *current
+		edited.Name, edited.Role, edited.Bio = req.Name, req.Role, req.Bio
+		h.renderSpeakerForm(w, r, http.StatusBadRequest, user, event, &edited, err.Error())
+		return
+	}
+
+	http.Redirect(w, r, agendaPath(event.ID)+"?saved=1", http.StatusSeeOther)
+}
+
+// DeleteSpeaker handles POST /organizer/events/{id}/agenda/speakers/{speakerID}/delete
+func (h *EventAgendaHandler) DeleteSpeaker(w http.ResponseWriter, r *http.Request) {
+	_, event, speaker, ok := h.loadSpeaker(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.agendaService.DeleteSpeaker(event.ID, speaker.ID); err != nil {
+		http.Error(w, "Failed to delete speaker", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, agendaPath(event.ID)+"?saved=1", http.StatusSeeOther)
+}
+
+// loadSession loads the organizer's event and the session in the URL,
+// writing the error response when it can't
+func (h *EventAgendaHandler) loadSession(w http.ResponseWriter, r *http.Request) (*models.User, *models.Event, *models.EventSession, bool) {
+	user, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return nil, nil, nil, false
+	}
+
+	sessionID, err := strconv.Atoi(chi.URLParam(r, "sessionID"))
+	if err != nil {
+		http.Error(w, "Invalid session ID", http.StatusBadRequest)
+		return nil, nil, nil, false
+	}
+
+	session, err := h.agendaService.GetSession(event.ID, sessionID)
+	if err != nil {
+		if errors.Is(err, services.ErrAgendaItemNotFound) {
+			http.Error(w, "Session not found", http.StatusNotFound)
+			return nil, nil, nil, false
+		}
+		http.Error(w, "Failed to load session", http.StatusInternalServerError)
+		return nil, nil, nil, false
+	}
+	return user, event, session, true
+}
+
+// loadSpeaker loads the organizer's event and the speaker in the URL,
+// writing the error response when it can't
+func (h *EventAgendaHandler) loadSpeaker(w http.ResponseWriter, r *http.Request) (*models.User, *models.Event, *models.EventSpeaker, bool) {
+	user, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return nil, nil, nil, false
+	}
+
+	speakerID, err := strconv.Atoi(chi.URLParam(r, "speakerID"))
+	if err != nil {
+		http.Error(w, "Invalid speaker ID", http.StatusBadRequest)
+		return nil, nil, nil, false
+	}
+
+	speaker, err := h.agendaService.GetSpeaker(event.ID, speakerID)
+	if err != nil {
+		if errors.Is(err, services.ErrAgendaItemNotFound) {
+			http.Error(w, "Speaker not found", http.StatusNotFound)
+			return nil, nil, nil, false
+		}
+		http.Error(w, "Failed to load speaker", http.StatusInternalServerError)
+		return nil, nil, nil, false
+	}
+	return user, event, speaker, true
+}
+
+// renderAgendaPage renders the event's agenda and lineup with the forms for
+// adding a session and a speaker
+func (h *EventAgendaHandler) renderAgendaPage(w http.ResponseWriter, r *http.Request, status int, user *models.User, event *models.Event, session *models.EventSession, speaker *models.EventSpeaker, errorMsg string) {
+	agenda, err := h.agendaService.GetAgenda(event.ID)
+	if err != nil {
+		http.Error(w, "Failed to load agenda", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(status)
+	component := pages.EventAgendaPage(user, event, agenda, session, speaker, r.URL.Query().Get("saved") == "1", errorMsg)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// renderSessionForm renders the form for editing one of the event's sessions
+func (h *EventAgendaHandler) renderSessionForm(w http.ResponseWriter, r *http.Request, status int, user *models.User, event *models.Event, session *models.EventSession, errorMsg string) {
+	agenda, err := h.agendaService.GetAgenda(event.ID)
+	if err != nil {
+		http.Error(w, "Failed to load agenda", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(status)
+	component := pages.EventSessionFormPage(user, event, agenda.Speakers, session, errorMsg)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// renderSpeakerForm renders the form for editing one of the event's speakers
+func (h *EventAgendaHandler) renderSpeakerForm(w http.ResponseWriter, r *http.Request, status int, user *models.User, event *models.Event, speaker *models.EventSpeaker, errorMsg string) {
+	w.WriteHeader(status)
+	component := pages.EventSpeakerFormPage(user, event, speaker, errorMsg)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// agendaPath is the organizer's agenda page of an event
+func agendaPath(eventID int) string {
+	return fmt.Sprintf("/organizer/events/%d/agenda", eventID)
+}
+
+// parseEventSession reads a submitted session form. The session is returned
+// along with any error in its times, so the form can be shown again.
+func parseEventSession(r *http.Request) (*models.EventSession, error) {
+	session := &models.EventSession{
+		Title:       r.FormValue("title"),
+		Description: r.FormValue("description"),
+		Stage:       r.FormValue("stage"),
+	}
+	for _, value := range r.Form["speaker_ids"] {
+		if id, err := strconv.Atoi(value); err == nil {
+			session.SpeakerIDs = append(session.SpeakerIDs, id)
+		}
+	}
+
+	var err error
+	if value := r.FormValue("starts_at"); value != "" {
+		if session.StartsAt, err = time.Parse("2006-01-02T15:04", value); err != nil {
+			return session, errors.New("invalid session start time")
+		}
+	}
+	if value := r.FormValue("ends_at"); value != "" {
+		if session.EndsAt, err = time.Parse("2006-01-02T15:04", value); err != nil {
+			return session, errors.New("invalid session end time")
+		}
+	}
+	return session, nil
+}
+
+// parseSpeakerRequest reads a submitted speaker form
+func parseSpeakerRequest(r *http.Request) *services.SpeakerRequest {
+	req := &services.SpeakerRequest{
+		Name:        r.FormValue("name"),
+		Role:        r.FormValue("role"),
+		Bio:         r.FormValue("bio"),
+		RemovePhoto: r.FormValue("remove_photo") == "on",
+	}
+	if r.MultipartForm != nil {
+		if files := r.MultipartForm.File["photo"]; len(files) > 0 {
+			req.Photo = files[0]
+		}
+	}
+	return req
+}
@@ -0,0 +1,108 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/ratelimit"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+)
+
+// EventBroadcastHandler handles organizers' emails to all ticket holders of an event
+type EventBroadcastHandler struct {
+	broadcastService *services.EventBroadcastService
+	eventService     services.EventServiceInterface
+	limiter          *ratelimit.Limiter
+	rateLimit        ratelimit.Rule
+}
+
+// NewEventBroadcastHandler creates a new event broadcast handler
+func NewEventBroadcastHandler(broadcastService *services.EventBroadcastService, eventService services.EventServiceInterface) *EventBroadcastHandler {
+	return &EventBroadcastHandler{
+		broadcastService: broadcastService,
+		eventService:     eventService,
+	}
+}
+
+// SetRateLimiter limits how many broadcasts each organizer can send
+func (h *EventBroadcastHandler) SetRateLimiter(limiter *ratelimit.Limiter, rule ratelimit.Rule) {
+	h.limiter = limiter
+	h.rateLimit = rule
+}
+
+// BroadcastPage shows the broadcast form and the event's past broadcasts
+func (h *EventBroadcastHandler) BroadcastPage(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadManagedEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	queued := r.URL.Query().Get("queued") == "1"
+	h.renderPage(w, r, http.StatusOK, user, event, &models.EventBroadcastCreateRequest{}, queued, "")
+}
+
+// SendBroadcast queues an email to all ticket holders of the event
+func (h *EventBroadcastHandler) SendBroadcast(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadManagedEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	req := &models.EventBroadcastCreateRequest{
+		EventID:     event.ID,
+		OrganizerID: user.ID,
+		Subject:     r.FormValue("subject"),
+		Message:     r.FormValue("message"),
+	}
+	if err := req.Validate(); err != nil {
+		h.renderPage(w, r, http.StatusBadRequest, user, event, req, false, err.Error())
+		return
+	}
+
+	// Only count valid broadcasts against the limit, so a typo does not use one up
+	if h.limiter != nil {
+		result := h.limiter.Check(r, h.rateLimit, ratelimit.AccountKey(strconv.Itoa(user.ID)))
+		if !result.Allowed {
+			w.Header().Set("Retry-After", strconv.Itoa(result.RetryAfterSeconds()))
+			wait := (time.Duration(result.RetryAfterSeconds()) * time.Second).String()
+			h.renderPage(w, r, http.StatusTooManyRequests, user, event, req, false, "You have sent too many broadcasts. Please try again in "+wait+".")
+			return
+		}
+	}
+
+	if _, err := h.broadcastService.QueueBroadcast(req, r); err != nil {
+		if errors.Is(err, services.ErrNoBroadcastRecipients) {
+			h.renderPage(w, r, http.StatusBadRequest, user, event, req, false, "This event has no ticket holders to email yet.")
+			return
+		}
+		http.Error(w, "Failed to send broadcast", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/organizer/events/"+strconv.Itoa(event.ID)+"/broadcast?queued=1", http.StatusSeeOther)
+}
+
+// renderPage renders the broadcast page with the event's past broadcasts
+func (h *EventBroadcastHandler) renderPage(w http.ResponseWriter, r *http.Request, status int, user *models.User, event *models.Event, form *models.EventBroadcastCreateRequest, queued bool, errorMsg string) {
+	broadcasts, err := h.broadcastService.GetBroadcasts(event.ID)
+	if err != nil {
+		http.Error(w, "Failed to load broadcasts", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(status)
+	component := pages.EventBroadcastPage(user, event, broadcasts, form, queued, errorMsg)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
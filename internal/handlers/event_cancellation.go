@@ -0,0 +1,92 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+)
+
+// EventCancellationHandler handles organizers cancelling their events
+type EventCancellationHandler struct {
+	cancellationService *services.EventCancellationService
+	eventService        services.EventServiceInterface
+}
+
+// NewEventCancellationHandler creates a new event cancellation handler
+func NewEventCancellationHandler(cancellationService *services.EventCancellationService, eventService services.EventServiceInterface) *EventCancellationHandler {
+	return &EventCancellationHandler{
+		cancellationService: cancellationService,
+		eventService:        eventService,
+	}
+}
+
+// CancelPage shows what cancelling the event will refund, or the progress
+// of the refunds once it is cancelled
+func (h *EventCancellationHandler) CancelPage(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadManagedEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	h.renderPage(w, r, http.StatusOK, user, event, "", "")
+}
+
+// CancelEvent cancels the event and queues refunds for its ticket holders
+func (h *EventCancellationHandler) CancelEvent(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadManagedEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	req := &models.EventCancellationRequest{
+		EventID:     event.ID,
+		CancelledBy: user.ID,
+		Reason:      r.FormValue("reason"),
+	}
+	if err := req.Validate(); err != nil {
+		h.renderPage(w, r, http.StatusBadRequest, user, event, req.Reason, err.Error())
+		return
+	}
+
+	if _, err := h.cancellationService.CancelEvent(req, r); err != nil {
+		if errors.Is(err, services.ErrEventAlreadyCancelled) {
+			http.Redirect(w, r, "/organizer/events/"+strconv.Itoa(event.ID)+"/cancel", http.StatusSeeOther)
+			return
+		}
+		h.renderPage(w, r, http.StatusBadRequest, user, event, req.Reason, err.Error())
+		return
+	}
+
+	http.Redirect(w, r, "/organizer/events/"+strconv.Itoa(event.ID)+"/cancel", http.StatusSeeOther)
+}
+
+// renderPage renders the cancellation page with the event's refund totals
+func (h *EventCancellationHandler) renderPage(w http.ResponseWriter, r *http.Request, status int, user *models.User, event *models.Event, reason, errorMsg string) {
+	cancellation, err := h.cancellationService.GetCancellation(event.ID)
+	if err != nil {
+		http.Error(w, "Failed to load cancellation", http.StatusInternalServerError)
+		return
+	}
+
+	orderCount, refundTotal, err := h.cancellationService.GetRefundTotals(event.ID)
+	if err != nil {
+		http.Error(w, "Failed to load orders", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(status)
+	component := pages.EventCancellationPage(user, event, cancellation, orderCount, refundTotal, reason, errorMsg)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,224 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// EventFAQHandler handles organizers' FAQs and policies for an event
+type EventFAQHandler struct {
+	faqService   *services.EventFAQService
+	eventService services.EventServiceInterface
+}
+
+// NewEventFAQHandler creates a new event FAQ handler
+func NewEventFAQHandler(faqService *services.EventFAQService, eventService services.EventServiceInterface) *EventFAQHandler {
+	return &EventFAQHandler{
+		faqService:   faqService,
+		eventService: eventService,
+	}
+}
+
+// FAQsPage handles GET /organizer/events/{id}/faqs
+func (h *EventFAQHandler) FAQsPage(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	h.renderPage(w, r, http.StatusOK, user, event, nil, &models.EventFAQ{}, "")
+}
+
+// SavePolicies handles POST /organizer/events/{id}/faqs/policies
+func (h *EventFAQHandler) SavePolicies(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	policies := &models.EventPolicies{
+		RefundPolicy:      r.FormValue("refund_policy"),
+		AccessibilityInfo: r.FormValue("accessibility_info"),
+	}
+	var err error
+	if value := r.FormValue("minimum_age"); value != "" {
+		if policies.MinimumAge, err = strconv.Atoi(value); err != nil {
+			err = errors.New("invalid minimum age")
+		}
+	}
+	if err == nil {
+		err = h.faqService.SavePolicies(event.ID, policies)
+	}
+	if err != nil {
+		if strings.HasPrefix(err.Error(), "failed to") {
+			http.Error(w, "Failed to save policies", http.StatusInternalServerError)
+			return
+		}
+		h.renderPage(w, r, http.StatusBadRequest, user, event, policies, &models.EventFAQ{}, err.Error())
+		return
+	}
+
+	http.Redirect(w, r, faqsPath(event.ID)+"?saved=1", http.StatusSeeOther)
+}
+
+// AddFAQ handles POST /organizer/events/{id}/faqs
+func (h *EventFAQHandler) AddFAQ(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	faq := &models.EventFAQ{Question: r.FormValue("question"), Answer: r.FormValue("answer")}
+	if err := h.faqService.AddFAQ(event.ID, faq); err != nil {
+		if strings.HasPrefix(err.Error(), "failed to") {
+			http.Error(w, "Failed to add FAQ", http.StatusInternalServerError)
+			return
+		}
+		h.renderPage(w, r, http.StatusBadRequest, user, event, nil, faq, err.Error())
+		return
+	}
+
+	http.Redirect(w, r, faqsPath(event.ID)+"?saved=1", http.StatusSeeOther)
+}
+
+// EditFAQPage handles GET /organizer/events/{id}/faqs/{faqID}/edit
+func (h *EventFAQHandler) EditFAQPage(w http.ResponseWriter, r *http.Request) {
+	user, event, faq, ok := h.loadFAQ(w, r)
+	if !ok {
+		return
+	}
+
+	h.renderFAQForm(w, r, http.StatusOK, user, event, faq, "")
+}
+
+// UpdateFAQ handles POST /organizer/events/{id}/faqs/{faqID}
+func (h *EventFAQHandler) UpdateFAQ(w http.ResponseWriter, r *http.Request) {
+	user, event, current, ok := h.loadFAQ(w, r)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	faq := &models.EventFAQ{ID: current.ID, Question: r.FormValue("question"), Answer: r.FormValue("answer")}
+	if err := h.faqService.UpdateFAQ(event.ID, faq); err != nil {
+		if strings.HasPrefix(err.Error(), "failed to") {
+			http.Error(w, "Failed to update FAQ", http.StatusInternalServerError)
+			return
+		}
+		h.renderFAQForm(w, r, http.StatusBadRequest, user, event, faq, err.Error())
+		return
+	}
+
+	http.Redirect(w, r, faqsPath(event.ID)+"?saved=1", http.StatusSeeOther)
+}
+
+// DeleteFAQ handles POST /organizer/events/{id}/faqs/{faqID}/delete
+func (h *EventFAQHandler) DeleteFAQ(w http.ResponseWriter, r *http.Request) {
+	_, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	faqID, err := strconv.Atoi(chi.URLParam(r, "faqID"))
+	if err != nil {
+		http.Error(w, "Invalid FAQ ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.faqService.DeleteFAQ(event.ID, faqID); err != nil {
+		if errors.Is(err, services.ErrFAQNotFound) {
+			http.Error(w, "FAQ not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to delete FAQ", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, faqsPath(event.ID)+"?saved=1", http.StatusSeeOther)
+}
+
+// loadFAQ loads the organizer's event and the FAQ entry in the URL, writing
+// the error response when it can't
+func (h *EventFAQHandler) loadFAQ(w http.ResponseWriter, r *http.Request) (*models.User, *models.Event, *models.EventFAQ, bool) {
+	user, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return nil, nil, nil, false
+	}
+
+	faqID, err := strconv.Atoi(chi.URLParam(r, "faqID"))
+	if err != nil {
+		http.Error(w, "Invalid FAQ ID", http.StatusBadRequest)
+		return nil, nil, nil, false
+	}
+
+	faq, err := h.faqService.GetFAQ(event.ID, faqID)
+	if err != nil {
+		if errors.Is(err, services.ErrFAQNotFound) {
+			http.Error(w, "FAQ not found", http.StatusNotFound)
+			return nil, nil, nil, false
+		}
+		http.Error(w, "Failed to load FAQ", http.StatusInternalServerError)
+		return nil, nil, nil, false
+	}
+	return user, event, faq, true
+}
+
+// renderPage renders the event's policies and FAQs. The policies form shows
+// the saved policies unless policies were submitted.
+func (h *EventFAQHandler) renderPage(w http.ResponseWriter, r *http.Request, status int, user *models.User, event *models.Event, policies *models.EventPolicies, faq *models.EventFAQ, errorMsg string) {
+	info, err := h.faqService.GetInfo(event.ID)
+	if err != nil {
+		http.Error(w, "Failed to load FAQs", http.StatusInternalServerError)
+		return
+	}
+	if policies == nil {
+		policies = info.Policies
+	}
+	if policies == nil {
+		policies = &models.EventPolicies{}
+	}
+
+	w.WriteHeader(status)
+	component := pages.EventFAQsPage(user, event, info.FAQs, policies, faq, r.URL.Query().Get("saved") == "1", errorMsg)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// renderFAQForm renders the form for editing one of the event's FAQ entries
+func (h *EventFAQHandler) renderFAQForm(w http.ResponseWriter, r *http.Request, status int, user *models.User, event *models.Event, faq *models.EventFAQ, errorMsg string) {
+	w.WriteHeader(status)
+	component := pages.EventFAQFormPage(user, event, faq, errorMsg)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// faqsPath is the organizer's FAQs and policies page of an event
+func faqsPath(eventID int) string {
+	return "/organizer/events/" + strconv.Itoa(eventID) + "/faqs"
+}
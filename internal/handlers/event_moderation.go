@@ -1,10 +1,13 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 
 	"github.com/go-chi/chi/v5"
+	"event-ticketing-platform/internal/logging"
 	"event-ticketing-platform/internal/middleware"
 	"event-ticketing-platform/internal/models"
 	"event-ticketing-platform/internal/services"
@@ -14,6 +17,7 @@ import (
 // EventModerationHandler handles event moderation requests
 type EventModerationHandler struct {
 	moderationService *services.EventModerationService
+	eventService      services.EventServiceInterface
 }
 
 // NewEventModerationHandler creates a new event moderation handler
@@ -23,7 +27,14 @@ func NewEventModerationHandler(moderationService *services.EventModerationServic
 	}
 }
 
-// AdminEventModerationPage displays the admin event moderation page
+// SetEventService lets organizers read and answer the review comments on
+// their events
+func (h *EventModerationHandler) SetEventService(eventService services.EventServiceInterface) {
+	h.eventService = eventService
+}
+
+// AdminEventModerationPage displays the event approval queue to admins and
+// moderators
 func (h *EventModerationHandler) AdminEventModerationPage(w http.ResponseWriter, r *http.Request) {
 	user := middleware.GetUserFromContext(r.Context())
 	if user == nil {
@@ -31,11 +42,16 @@ func (h *EventModerationHandler) AdminEventModerationPage(w http.ResponseWriter,
 		return
 	}
 
-	if user.Role != models.UserRoleAdmin {
+	if !h.moderationService.CanUserModerateEvent(user.ID, user.Role) {
 		http.Error(w, "Access denied", http.StatusForbidden)
 		return
 	}
 
+	// Hand out anything submitted since the last assignment run
+	if _, err := h.moderationService.AssignPendingEvents(); err != nil {
+		logging.FromContext(r.Context()).Warn("failed to assign events for review", "error", err)
+	}
+
 	// Get page parameter
 	page := 1
 	if p := r.URL.Query().Get("page"); p != "" {
@@ -51,6 +67,36 @@ func (h *EventModerationHandler) AdminEventModerationPage(w http.ResponseWriter,
 		return
 	}
 
+	flags, err := h.moderationService.GetContentFlags(events)
+	if err != nil {
+		http.Error(w, "Failed to load content flags", http.StatusInternalServerError)
+		return
+	}
+
+	reputations, err := h.moderationService.GetOrganizerReputations(events)
+	if err != nil {
+		http.Error(w, "Failed to load organizer reputations", http.StatusInternalServerError)
+		return
+	}
+
+	assignments, err := h.moderationService.GetReviewAssignments(events)
+	if err != nil {
+		http.Error(w, "Failed to load reviewer assignments", http.StatusInternalServerError)
+		return
+	}
+
+	comments, err := h.moderationService.GetReviewComments(events)
+	if err != nil {
+		http.Error(w, "Failed to load review comments", http.StatusInternalServerError)
+		return
+	}
+
+	reviewers, err := h.moderationService.GetReviewers()
+	if err != nil {
+		http.Error(w, "Failed to load moderators", http.StatusInternalServerError)
+		return
+	}
+
 	// Calculate pagination
 	totalPages := (totalCount + 9) / 10
 	paginationInfo := map[string]interface{}{
@@ -64,14 +110,15 @@ func (h *EventModerationHandler) AdminEventModerationPage(w http.ResponseWriter,
 	}
 
 	// Render admin event moderation page
-	component := pages.AdminEventModerationPage(user, events, paginationInfo)
+	component := pages.AdminEventModerationPage(user, events, flags, reputations, assignments, comments, reviewers, moderationBasePath(r), paginationInfo)
 	if err := component.Render(r.Context(), w); err != nil {
 		http.Error(w, "Failed to render page", http.StatusInternalServerError)
 		return
 	}
 }
 
-// ModerateEvent handles event approval/rejection
+// ModerateEvent handles approving, rejecting and requesting changes to an
+// event, commenting on its review and assigning its reviewer
 func (h *EventModerationHandler) ModerateEvent(w http.ResponseWriter, r *http.Request) {
 	user := middleware.GetUserFromContext(r.Context())
 	if user == nil {
@@ -79,7 +126,7 @@ func (h *EventModerationHandler) ModerateEvent(w http.ResponseWriter, r *http.Re
 		return
 	}
 
-	if user.Role != models.UserRoleAdmin {
+	if !h.moderationService.CanUserModerateEvent(user.ID, user.Role) {
 		http.Error(w, "Access denied", http.StatusForbidden)
 		return
 	}
@@ -117,13 +164,101 @@ func (h *EventModerationHandler) ModerateEvent(w http.ResponseWriter, r *http.Re
 			http.Error(w, "Failed to reject event", http.StatusInternalServerError)
 			return
 		}
+	case "request_changes":
+		comment := r.FormValue("review_comment")
+		if strings.TrimSpace(comment) == "" {
+			http.Error(w, "A comment explaining the changes is required", http.StatusBadRequest)
+			return
+		}
+		err = h.moderationService.RequestChanges(eventID, user.ID, comment, r)
+		if err != nil {
+			http.Error(w, "Failed to request changes", http.StatusInternalServerError)
+			return
+		}
+	case "comment":
+		if _, err := h.moderationService.AddReviewComment(eventID, user, r.FormValue("review_comment")); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	case "assign":
+		reviewerID, err := strconv.Atoi(r.FormValue("reviewer_id"))
+		if err != nil {
+			http.Error(w, "Invalid reviewer", http.StatusBadRequest)
+			return
+		}
+		if err := h.moderationService.AssignReviewer(eventID, reviewerID, user.ID, r); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
 	default:
 		http.Error(w, "Invalid action", http.StatusBadRequest)
 		return
 	}
 
 	// Redirect back to moderation page
-	http.Redirect(w, r, "/admin/events/moderate", http.StatusSeeOther)
+	http.Redirect(w, r, moderationQueuePath(r), http.StatusSeeOther)
+}
+
+// ReviewFeedbackPage shows the organizer the review comments on their event
+func (h *EventModerationHandler) ReviewFeedbackPage(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+	h.renderReviewFeedbackPage(w, r, user, event, http.StatusOK, "")
+}
+
+// ReplyToReview handles POST /organizer/events/{id}/review, adding the
+// organizer's answer to the review comments
+func (h *EventModerationHandler) ReplyToReview(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.moderationService.AddReviewComment(event.ID, user, r.FormValue("review_comment")); err != nil {
+		h.renderReviewFeedbackPage(w, r, user, event, http.StatusUnprocessableEntity, err.Error())
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/organizer/events/%d/review", event.ID), http.StatusSeeOther)
+}
+
+// renderReviewFeedbackPage renders an event's review comments with an error
+func (h *EventModerationHandler) renderReviewFeedbackPage(w http.ResponseWriter, r *http.Request, user *models.User, event *models.Event, status int, formError string) {
+	comments, err := h.moderationService.GetReviewComments([]*models.Event{event})
+	if err != nil {
+		http.Error(w, "Failed to load review comments", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(status)
+	component := pages.EventReviewFeedbackPage(user, event, comments[event.ID], formError)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+	}
+}
+
+// moderationBasePath is where the approval queue's actions are posted, under
+// /moderator for moderators and /admin for admins
+func moderationBasePath(r *http.Request) string {
+	if strings.HasPrefix(r.URL.Path, "/moderator/") {
+		return "/moderator/events"
+	}
+	return "/admin/events"
+}
+
+// moderationQueuePath is the approval queue page moderation returns to
+func moderationQueuePath(r *http.Request) string {
+	if strings.HasPrefix(r.URL.Path, "/moderator/") {
+		return "/moderator/events"
+	}
+	return "/admin/events/moderate"
 }
 
 // SubmitEventForReview handles organizer submission of events for review
@@ -0,0 +1,177 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"event-ticketing-platform/internal/i18n"
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// EventReminderHandler handles organizers' attendee reminder settings
+type EventReminderHandler struct {
+	reminderService *services.EventReminderService
+	eventService    services.EventServiceInterface
+	localeService   *services.LocaleService
+}
+
+// NewEventReminderHandler creates a new event reminder handler
+func NewEventReminderHandler(reminderService *services.EventReminderService, eventService services.EventServiceInterface) *EventReminderHandler {
+	return &EventReminderHandler{
+		reminderService: reminderService,
+		eventService:    eventService,
+	}
+}
+
+// SetLocaleService lets organizers choose the language of their event's emails
+func (h *EventReminderHandler) SetLocaleService(localeService *services.LocaleService) {
+	h.localeService = localeService
+}
+
+// eventLocale returns the language the event's emails default to
+func (h *EventReminderHandler) eventLocale(eventID int) (string, error) {
+	if h.localeService == nil {
+		return i18n.DefaultLocale, nil
+	}
+	return h.localeService.GetEventLocale(eventID)
+}
+
+// RemindersPage shows the reminder settings for one of the organizer's events
+func (h *EventReminderHandler) RemindersPage(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := h.organizerEvent(w, r)
+	if !ok {
+		return
+	}
+
+	settings, err := h.reminderService.GetSettings(event.ID)
+	if err != nil {
+		http.Error(w, "Failed to load reminder settings", http.StatusInternalServerError)
+		return
+	}
+
+	locale, err := h.eventLocale(event.ID)
+	if err != nil {
+		http.Error(w, "Failed to load reminder settings", http.StatusInternalServerError)
+		return
+	}
+
+	saved := r.URL.Query().Get("saved") == "1"
+
+	component := pages.EventRemindersPage(user, event, settings, locale, saved, "")
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// UpdateReminders saves the reminder toggles and custom message for an event
+func (h *EventReminderHandler) UpdateReminders(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := h.organizerEvent(w, r)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	// Unchecked checkboxes are not submitted
+	settings := &models.EventReminderSettings{
+		EventID:   event.ID,
+		Enabled:   r.FormValue("enabled") == "on",
+		SevenDays: r.FormValue("remind_7d") == "on",
+		OneDay:    r.FormValue("remind_24h") == "on",
+		TwoHours:  r.FormValue("remind_2h") == "on",
+		Message:   r.FormValue("message"),
+	}
+	locale := r.FormValue("locale")
+
+	validationErr := settings.Validate()
+	if validationErr == nil && h.localeService != nil && !i18n.IsSupported(locale) {
+		validationErr = errors.New("please choose a supported email language")
+	}
+	if validationErr != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		component := pages.EventRemindersPage(user, event, settings, locale, false, validationErr.Error())
+		if err := component.Render(r.Context(), w); err != nil {
+			http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := h.reminderService.UpdateSettings(settings); err != nil {
+		http.Error(w, "Failed to save reminder settings", http.StatusInternalServerError)
+		return
+	}
+
+	if h.localeService != nil {
+		if err := h.localeService.UpdateEventLocale(event.ID, locale); err != nil {
+			http.Error(w, "Failed to save email language", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	http.Redirect(w, r, "/organizer/events/"+strconv.Itoa(event.ID)+"/reminders?saved=1", http.StatusSeeOther)
+}
+
+// organizerEvent loads the event in the URL, checking that the current user
+// can edit it. It writes the error response when it fails.
+func (h *EventReminderHandler) organizerEvent(w http.ResponseWriter, r *http.Request) (*models.User, *models.Event, bool) {
+	return loadOrganizerEvent(w, r, h.eventService)
+}
+
+// loadOrganizerEvent loads the event in the URL for the organizer pages of a
+// single event, checking that the current user can edit it. It writes the
+// error response when it fails.
+func loadOrganizerEvent(w http.ResponseWriter, r *http.Request, eventService services.EventServiceInterface) (*models.User, *models.Event, bool) {
+	return loadEventFor(w, r, eventService, eventService.CanUserEditEvent)
+}
+
+// loadManagedEvent loads the event in the URL for the organizer pages used to
+// run it, checking that the current user manages it. Unlike
+// loadOrganizerEvent, it keeps working once the event has started. It writes
+// the error response when it fails.
+func loadManagedEvent(w http.ResponseWriter, r *http.Request, eventService services.EventServiceInterface) (*models.User, *models.Event, bool) {
+	return loadEventFor(w, r, eventService, eventService.CanUserManageEvent)
+}
+
+// loadEventFor loads the event in the URL if allowed says the current user
+// can use it
+func loadEventFor(w http.ResponseWriter, r *http.Request, eventService services.EventServiceInterface, allowed func(eventID, userID int) (bool, error)) (*models.User, *models.Event, bool) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return nil, nil, false
+	}
+
+	eventID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return nil, nil, false
+	}
+
+	ok, err := allowed(eventID, user.ID)
+	if err != nil {
+		http.Error(w, "Failed to check permissions", http.StatusInternalServerError)
+		return nil, nil, false
+	}
+	if !ok {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return nil, nil, false
+	}
+
+	event, err := eventService.GetEventByID(eventID)
+	if err != nil {
+		http.Error(w, "Event not found", http.StatusNotFound)
+		return nil, nil, false
+	}
+
+	return user, event, true
+}
@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// EventReportHandler handles attendees' reports of problem events
+type EventReportHandler struct {
+	reputationService *services.OrganizerReputationService
+	eventService      services.EventServiceInterface
+}
+
+// NewEventReportHandler creates a new event report handler
+func NewEventReportHandler(reputationService *services.OrganizerReputationService, eventService services.EventServiceInterface) *EventReportHandler {
+	return &EventReportHandler{
+		reputationService: reputationService,
+		eventService:      eventService,
+	}
+}
+
+// ReportEvent records a report against an event, which counts against the
+// organizer's reputation. It responds with a short status message for the
+// report form.
+func (h *EventReportHandler) ReportEvent(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	eventID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	event, err := h.eventService.GetEventByID(eventID)
+	if err != nil {
+		http.Error(w, "Event not found", http.StatusNotFound)
+		return
+	}
+
+	if event.OrganizerID == user.ID {
+		http.Error(w, "You cannot report your own event", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	report := &models.EventReport{
+		Reason:  models.EventReportReason(r.FormValue("reason")),
+		Details: r.FormValue("details"),
+	}
+	if err := report.Validate(); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		pages.EventReportResult(false, err.Error()).Render(r.Context(), w)
+		return
+	}
+
+	if err := h.reputationService.ReportEvent(event.ID, user.ID, report.Reason, report.Details); err != nil {
+		http.Error(w, "Failed to submit report", http.StatusInternalServerError)
+		return
+	}
+
+	if r.Header.Get("HX-Request") != "true" {
+		http.Redirect(w, r, "/events/"+strconv.Itoa(event.ID), http.StatusSeeOther)
+		return
+	}
+
+	component := pages.EventReportResult(true, "Thanks, our team will review your report.")
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render report result", http.StatusInternalServerError)
+		return
+	}
+}
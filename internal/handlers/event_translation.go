@@ -0,0 +1,114 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// EventTranslationHandler handles organizers' translations of their events
+type EventTranslationHandler struct {
+	translationService *services.EventTranslationService
+	eventService       services.EventServiceInterface
+}
+
+// NewEventTranslationHandler creates a new event translation handler
+func NewEventTranslationHandler(translationService *services.EventTranslationService, eventService services.EventServiceInterface) *EventTranslationHandler {
+	return &EventTranslationHandler{
+		translationService: translationService,
+		eventService:       eventService,
+	}
+}
+
+// TranslationsPage shows an event's translations into each supported language
+func (h *EventTranslationHandler) TranslationsPage(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	h.renderPage(w, r, http.StatusOK, user, event, nil, r.URL.Query().Get("saved") == "1", "")
+}
+
+// SaveTranslation creates or replaces an event's translation into one language
+func (h *EventTranslationHandler) SaveTranslation(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	req := &models.EventTranslationRequest{
+		EventID:     event.ID,
+		Locale:      r.FormValue("locale"),
+		Title:       r.FormValue("title"),
+		Description: r.FormValue("description"),
+	}
+	if _, err := h.translationService.SaveTranslation(req); err != nil {
+		if strings.Contains(err.Error(), "failed to") {
+			http.Error(w, "Failed to save translation", http.StatusInternalServerError)
+			return
+		}
+		h.renderPage(w, r, http.StatusBadRequest, user, event, req, false, err.Error())
+		return
+	}
+
+	http.Redirect(w, r, "/organizer/events/"+strconv.Itoa(event.ID)+"/translations?saved=1", http.StatusSeeOther)
+}
+
+// DeleteTranslation removes an event's translation into one language
+func (h *EventTranslationHandler) DeleteTranslation(w http.ResponseWriter, r *http.Request) {
+	_, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	if err := h.translationService.DeleteTranslation(event.ID, chi.URLParam(r, "locale")); err != nil {
+		if strings.Contains(err.Error(), "not found") || strings.Contains(err.Error(), "unsupported") {
+			http.Error(w, "Translation not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to delete translation", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/organizer/events/"+strconv.Itoa(event.ID)+"/translations?saved=1", http.StatusSeeOther)
+}
+
+// renderPage renders the translations page. failed is the translation that
+// could not be saved, shown again so the organizer can correct it.
+func (h *EventTranslationHandler) renderPage(w http.ResponseWriter, r *http.Request, status int, user *models.User, event *models.Event, failed *models.EventTranslationRequest, saved bool, errorMsg string) {
+	defaultLocale, err := h.translationService.GetDefaultLocale(event.ID)
+	if err != nil {
+		http.Error(w, "Failed to load event language", http.StatusInternalServerError)
+		return
+	}
+
+	translations, err := h.translationService.GetTranslations(event.ID)
+	if err != nil {
+		http.Error(w, "Failed to load translations", http.StatusInternalServerError)
+		return
+	}
+
+	byLocale := make(map[string]*models.EventTranslation, len(translations))
+	for _, translation := range translations {
+		byLocale[translation.Locale] = translation
+	}
+
+	w.WriteHeader(status)
+	component := pages.EventTranslationsPage(user, event, defaultLocale, byLocale, failed, saved, errorMsg)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,79 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"event-ticketing-platform/internal/logging"
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/google/uuid"
+)
+
+// visitorCookie identifies a browser across event page views, so repeat
+// views by the same visitor are counted once
+const visitorCookie = "visitor_id"
+
+// EventViewHandler handles first-party event page view tracking
+type EventViewHandler struct {
+	viewService *services.EventViewService
+}
+
+// NewEventViewHandler creates a new event view handler
+func NewEventViewHandler(viewService *services.EventViewService) *EventViewHandler {
+	return &EventViewHandler{viewService: viewService}
+}
+
+// TrackView handles POST /events/{id}/view, sent by event detail pages as
+// a beacon once they load. The page's own referrer is sent as a form value.
+// Views of visitors who haven't agreed to analytics are not recorded, and
+// they are given no visitor cookie.
+func (h *EventViewHandler) TrackView(w http.ResponseWriter, r *http.Request) {
+	eventID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	if !middleware.AnalyticsAllowed(r.Context()) {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	view := &models.EventView{EventID: eventID, VisitorID: visitorID(w, r)}
+	if user := middleware.GetUserFromContext(r.Context()); user != nil {
+		view.UserID = &user.ID
+	}
+
+	if _, err := h.viewService.RecordView(view, r.UserAgent(), r.FormValue("referrer")); err != nil {
+		logging.FromContext(r.Context()).Warn("failed to record event view", "event_id", eventID, "error", err)
+	}
+
+	// Tracking never fails the page, so the beacon always succeeds
+	w.WriteHeader(http.StatusNoContent)
+}
+
+// visitorID returns the visitor's ID from their cookie, setting a new one
+// for first-time visitors
+func visitorID(w http.ResponseWriter, r *http.Request) string {
+	if cookie, err := r.Cookie(visitorCookie); err == nil {
+		if _, err := uuid.Parse(cookie.Value); err == nil {
+			return cookie.Value
+		}
+	}
+
+	id := uuid.NewString()
+	http.SetCookie(w, &http.Cookie{
+		Name:     visitorCookie,
+		Value:    id,
+		Path:     "/",
+		MaxAge:   int((365 * 24 * time.Hour).Seconds()),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+	return id
+}
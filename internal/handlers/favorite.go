@@ -0,0 +1,54 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// FavoriteHandler handles attendees saving events
+type FavoriteHandler struct {
+	favoriteService *services.FavoriteService
+	eventService    services.EventServiceInterface
+}
+
+// NewFavoriteHandler creates a new favorite handler
+func NewFavoriteHandler(favoriteService *services.FavoriteService, eventService services.EventServiceInterface) *FavoriteHandler {
+	return &FavoriteHandler{
+		favoriteService: favoriteService,
+		eventService:    eventService,
+	}
+}
+
+// ToggleFavorite saves the event for the user, or unsaves it if it was
+// saved, and sends them back to the event
+func (h *FavoriteHandler) ToggleFavorite(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	eventID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	event, err := h.eventService.GetEventByID(eventID)
+	if err != nil {
+		http.Error(w, "Event not found", http.StatusNotFound)
+		return
+	}
+
+	if _, err := h.favoriteService.ToggleFavorite(user.ID, eventID); err != nil {
+		http.Error(w, "Failed to save event", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, event.Path(), http.StatusSeeOther)
+}
@@ -0,0 +1,136 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// FinanceHandler handles organizers' settlement statements
+type FinanceHandler struct {
+	financeService *services.FinanceService
+	eventService   services.EventServiceInterface
+}
+
+// NewFinanceHandler creates a new finance handler
+func NewFinanceHandler(financeService *services.FinanceService, eventService services.EventServiceInterface) *FinanceHandler {
+	return &FinanceHandler{
+		financeService: financeService,
+		eventService:   eventService,
+	}
+}
+
+// FinancePage handles GET /organizer/finance, showing the organizer's
+// statement for the month query parameter, the current month by default,
+// and their events' statements to download
+func (h *FinanceHandler) FinancePage(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
+		return
+	}
+
+	var errorMsg string
+	month, err := h.financeService.ParseStatementMonth(r.URL.Query().Get("month"))
+	if err != nil {
+		errorMsg = err.Error()
+		month, _ = h.financeService.ParseStatementMonth("")
+	}
+
+	statement, err := h.financeService.MonthlyStatement(user.ID, month)
+	if err != nil {
+		http.Error(w, "Failed to load statement", http.StatusInternalServerError)
+		return
+	}
+
+	events, err := h.eventService.GetEventsByOrganizer(user.ID)
+	if err != nil {
+		http.Error(w, "Failed to load events", http.StatusInternalServerError)
+		return
+	}
+
+	component := pages.FinancePage(user, statement, h.financeService.StatementMonths(), events, errorMsg)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// MonthlyStatement handles GET /organizer/finance/statements/{month},
+// downloading the organizer's statement for a YYYY-MM month in the format
+// query parameter, csv or pdf
+func (h *FinanceHandler) MonthlyStatement(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	month, err := h.financeService.ParseStatementMonth(chi.URLParam(r, "month"))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	statement, err := h.financeService.MonthlyStatement(user.ID, month)
+	if err != nil {
+		http.Error(w, "Failed to load statement", http.StatusInternalServerError)
+		return
+	}
+
+	h.download(w, r, statement)
+}
+
+// EventStatement handles GET /organizer/finance/events/{id}, downloading
+// the statement of one of the organizer's events in the format query
+// parameter. Only the event's organizer sees its finances, not their team.
+func (h *FinanceHandler) EventStatement(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadManagedEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+	if event.OrganizerID != user.ID {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	statement, err := h.financeService.EventStatement(event)
+	if err != nil {
+		http.Error(w, "Failed to load statement", http.StatusInternalServerError)
+		return
+	}
+
+	h.download(w, r, statement)
+}
+
+// download writes a statement as CSV or PDF
+func (h *FinanceHandler) download(w http.ResponseWriter, r *http.Request, statement *models.SettlementStatement) {
+	filename := services.StatementFilename(statement)
+
+	switch r.URL.Query().Get("format") {
+	case "", "csv":
+		w.Header().Set("Content-Type", "text/csv")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.csv\"", filename))
+		if err := services.ExportStatement(w, statement); err != nil {
+			http.Error(w, "Failed to export statement", http.StatusInternalServerError)
+			return
+		}
+	case "pdf":
+		pdf, err := h.financeService.StatementPDF(statement)
+		if err != nil {
+			http.Error(w, "Failed to generate statement", http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "application/pdf")
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s.pdf\"", filename))
+		w.Write(pdf)
+	default:
+		http.Error(w, "Unsupported format", http.StatusBadRequest)
+	}
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// FraudLinkageHandler handles the admin views of linked accounts
+type FraudLinkageHandler struct {
+	fraudLinkageService *services.FraudLinkageService
+}
+
+// NewFraudLinkageHandler creates a new fraud linkage handler
+func NewFraudLinkageHandler(fraudLinkageService *services.FraudLinkageService) *FraudLinkageHandler {
+	return &FraudLinkageHandler{
+		fraudLinkageService: fraudLinkageService,
+	}
+}
+
+// Clusters handles GET /admin/fraud/linkage
+func (h *FraudLinkageHandler) Clusters(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login?redirect=/admin/fraud/linkage", http.StatusSeeOther)
+		return
+	}
+
+	if user.Role != models.UserRoleAdmin {
+		http.Error(w, "Forbidden - Admin access required", http.StatusForbidden)
+		return
+	}
+
+	clusters, err := h.fraudLinkageService.GetClusters()
+	if err != nil {
+		http.Error(w, "Failed to load linked accounts", http.StatusInternalServerError)
+		return
+	}
+
+	component := pages.AdminFraudLinkage(user, clusters)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// UserLinks handles GET /admin/fraud/linkage/users/{id}
+func (h *FraudLinkageHandler) UserLinks(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil || user.Role != models.UserRoleAdmin {
+		http.Error(w, "Forbidden - Admin access required", http.StatusForbidden)
+		return
+	}
+
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	cluster, err := h.fraudLinkageService.GetCluster(userID)
+	if err != nil {
+		http.Error(w, "User not found", http.StatusNotFound)
+		return
+	}
+
+	signals, err := h.fraudLinkageService.GetSignals(userID)
+	if err != nil {
+		http.Error(w, "Failed to load account signals", http.StatusInternalServerError)
+		return
+	}
+
+	component := pages.AdminFraudLinkageUser(user, cluster.Account(userID), cluster, signals)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
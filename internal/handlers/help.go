@@ -0,0 +1,268 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// HelpHandler handles the public help center and admins writing its articles
+type HelpHandler struct {
+	helpService *services.HelpService
+}
+
+// NewHelpHandler creates a new help center handler
+func NewHelpHandler(helpService *services.HelpService) *HelpHandler {
+	return &HelpHandler{
+		helpService: helpService,
+	}
+}
+
+// HelpCenter handles GET /help, listing the articles by category or, with a
+// q parameter, the ones matching a search
+func (h *HelpHandler) HelpCenter(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+
+	sections, err := h.helpService.Sections()
+	if err != nil {
+		http.Error(w, "Failed to load help articles", http.StatusInternalServerError)
+		return
+	}
+
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	var results []*models.HelpArticle
+	if query != "" {
+		results, err = h.helpService.Search(query)
+		if err != nil {
+			http.Error(w, "Failed to search help articles", http.StatusInternalServerError)
+			return
+		}
+	}
+
+	component := pages.HelpCenterPage(user, sections, query, results)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// Article handles GET /help/{slug}. Admins can preview drafts.
+func (h *HelpHandler) Article(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	isAdmin := user != nil && user.Role == models.UserRoleAdmin
+
+	article, err := h.helpService.Article(chi.URLParam(r, "slug"), isAdmin)
+	if err != nil {
+		http.Error(w, "Help article not found", http.StatusNotFound)
+		return
+	}
+
+	component := pages.HelpArticlePage(user, article, h.helpService.Related(article))
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// ArticlesPage lists every help article for admins, drafts included
+func (h *HelpHandler) ArticlesPage(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	filter := models.HelpArticleFilter{
+		Category: models.HelpCategory(r.URL.Query().Get("category")),
+		Status:   models.HelpArticleStatus(r.URL.Query().Get("status")),
+	}
+	articles, err := h.helpService.List(filter)
+	if err != nil {
+		http.Error(w, "Failed to load help articles", http.StatusInternalServerError)
+		return
+	}
+
+	component := pages.AdminHelpArticlesPage(user, articles, filter, r.URL.Query().Get("saved"))
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// NewArticlePage shows the form writing a help article
+func (h *HelpHandler) NewArticlePage(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	formData := map[string]string{
+		"category": string(models.HelpGettingStarted),
+		"status":   string(models.HelpArticleDraft),
+	}
+	h.renderForm(w, r, http.StatusOK, user, nil, formData, "")
+}
+
+// CreateArticle saves a new help article
+func (h *HelpHandler) CreateArticle(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	req, formData, ok := parseHelpArticleForm(w, r)
+	if !ok {
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.renderForm(w, r, http.StatusBadRequest, user, nil, formData, err.Error())
+		return
+	}
+
+	if _, err := h.helpService.Create(user.ID, req, r); err != nil {
+		if errors.Is(err, services.ErrHelpSlugTaken) {
+			h.renderForm(w, r, http.StatusBadRequest, user, nil, formData, err.Error())
+			return
+		}
+		http.Error(w, "Failed to save help article", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/help?saved=created", http.StatusSeeOther)
+}
+
+// EditArticlePage shows the form changing a help article
+func (h *HelpHandler) EditArticlePage(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	article, ok := h.loadArticle(w, r)
+	if !ok {
+		return
+	}
+
+	formData := map[string]string{
+		"slug":     article.Slug,
+		"title":    article.Title,
+		"summary":  article.Summary,
+		"body":     article.Body,
+		"category": string(article.Category),
+		"status":   string(article.Status),
+	}
+	h.renderForm(w, r, http.StatusOK, user, article, formData, "")
+}
+
+// UpdateArticle changes a help article
+func (h *HelpHandler) UpdateArticle(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	article, ok := h.loadArticle(w, r)
+	if !ok {
+		return
+	}
+
+	req, formData, ok := parseHelpArticleForm(w, r)
+	if !ok {
+		return
+	}
+
+	if err := req.Validate(); err != nil {
+		h.renderForm(w, r, http.StatusBadRequest, user, article, formData, err.Error())
+		return
+	}
+
+	if _, err := h.helpService.Update(user.ID, article.ID, req, r); err != nil {
+		if errors.Is(err, services.ErrHelpSlugTaken) {
+			h.renderForm(w, r, http.StatusBadRequest, user, article, formData, err.Error())
+			return
+		}
+		http.Error(w, "Failed to save help article", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/help?saved=updated", http.StatusSeeOther)
+}
+
+// DeleteArticle removes a help article
+func (h *HelpHandler) DeleteArticle(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	article, ok := h.loadArticle(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.helpService.Delete(user.ID, article.ID, r); err != nil {
+		http.Error(w, "Failed to delete help article", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/help?saved=deleted", http.StatusSeeOther)
+}
+
+// parseHelpArticleForm reads the help article form
+func parseHelpArticleForm(w http.ResponseWriter, r *http.Request) (*models.HelpArticleRequest, map[string]string, bool) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return nil, nil, false
+	}
+
+	formData := make(map[string]string)
+	for _, field := range []string{"slug", "title", "summary", "body", "category", "status"} {
+		formData[field] = r.FormValue(field)
+	}
+
+	req := &models.HelpArticleRequest{
+		Slug:     formData["slug"],
+		Title:    formData["title"],
+		Summary:  formData["summary"],
+		Body:     formData["body"],
+		Category: models.HelpCategory(formData["category"]),
+		Status:   models.HelpArticleStatus(formData["status"]),
+	}
+	return req, formData, true
+}
+
+// loadArticle loads the help article in the URL, responding 404 if it
+// doesn't exist
+func (h *HelpHandler) loadArticle(w http.ResponseWriter, r *http.Request) (*models.HelpArticle, bool) {
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid help article ID", http.StatusBadRequest)
+		return nil, false
+	}
+
+	article, err := h.helpService.Get(id)
+	if err != nil {
+		http.Error(w, "Help article not found", http.StatusNotFound)
+		return nil, false
+	}
+	return article, true
+}
+
+// renderForm renders the page writing or changing a help article, article
+// being nil for a new one
+func (h *HelpHandler) renderForm(w http.ResponseWriter, r *http.Request, status int, user *models.User, article *models.HelpArticle, formData map[string]string, errorMsg string) {
+	w.WriteHeader(status)
+	component := pages.AdminHelpArticleFormPage(user, article, formData, errorMsg)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
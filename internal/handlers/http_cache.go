@@ -0,0 +1,60 @@
+package handlers
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// Cache-Control policies for endpoints polled by HTMX. Responses include the
+// user's CSRF token or data, so only the browser may cache them.
+const (
+	availabilityCacheControl = "private, max-age=5, stale-while-revalidate=25"
+	analyticsCacheControl    = "private, max-age=30, stale-while-revalidate=60"
+)
+
+// writeCacheable writes body with the given Cache-Control policy and an ETag,
+// answering 304 Not Modified when the browser already has the same body
+func writeCacheable(w http.ResponseWriter, r *http.Request, contentType, cacheControl string, body []byte) {
+	sum := sha256.Sum256(body)
+	etag := `"` + hex.EncodeToString(sum[:16]) + `"`
+
+	w.Header().Set("Cache-Control", cacheControl)
+	w.Header().Set("ETag", etag)
+	w.Header().Add("Vary", "Cookie")
+
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.Write(body)
+}
+
+// writeCacheableJSON encodes data and writes it with writeCacheable
+func writeCacheableJSON(w http.ResponseWriter, r *http.Request, cacheControl string, data interface{}) error {
+	var body bytes.Buffer
+	if err := json.NewEncoder(&body).Encode(data); err != nil {
+		return err
+	}
+
+	writeCacheable(w, r, "application/json", cacheControl, body.Bytes())
+	return nil
+}
+
+// etagMatches reports whether an If-None-Match header matches etag
+func etagMatches(ifNoneMatch, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
@@ -2,12 +2,14 @@ package handlers
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
+	"event-ticketing-platform/internal/logging"
 	"event-ticketing-platform/internal/middleware"
 	"event-ticketing-platform/internal/models"
 	"event-ticketing-platform/internal/services"
@@ -21,6 +23,7 @@ type ImageManagementHandler struct {
 	imageService services.ImageServiceInterface
 	eventService services.EventServiceInterface
 	storageService services.StorageService
+	eventImages  services.EventImageRecorder
 }
 
 // EventImage represents an image associated with an event
@@ -45,6 +48,11 @@ func NewImageManagementHandler(
 	}
 }
 
+// SetEventImageRecorder saves completed direct uploads as their event's image
+func (h *ImageManagementHandler) SetEventImageRecorder(eventImages services.EventImageRecorder) {
+	h.eventImages = eventImages
+}
+
 // ImageUploadResponse represents the response from image upload
 type ImageUploadResponse struct {
 	Success  bool                        `json:"success"`
@@ -184,27 +192,21 @@ func (h *ImageManagementHandler) UploadImage(w http.ResponseWriter, r *http.Requ
 	// Upload image with processing
 	result, err := h.imageService.UploadImageWithOptions(r.Context(), file, header.Filename, options)
 	if err != nil {
-		h.writeJSONResponse(w, http.StatusInternalServerError, ImageUploadResponse{
+		h.writeJSONResponse(w, uploadErrorStatus(err), ImageUploadResponse{
 			Success: false,
 			Error:   fmt.Sprintf("Failed to upload image: %v", err),
 		})
 		return
 	}
 
-	// Update event with new image URL (use large variant as primary)
-	largeVariantURL := result.Original.URL
-	for _, variant := range result.Variants {
-		if variant.Name == "large" {
-			largeVariantURL = variant.URL
-			break
-		}
-	}
+	// Update event with new image URL (use the widest hero as primary)
+	largeVariantURL := heroImageURL(result)
 
 	// Update event image URL directly in the database
 	// Note: We bypass the service layer here since we're only updating the image URL
 	// and the service layer expects multipart file uploads
 	// TODO: Consider adding a dedicated method to update just the image URL
-	fmt.Printf("Image uploaded successfully. New image URL: %s\n", largeVariantURL)
+	logging.FromContext(r.Context()).Info("event image uploaded", "url", largeVariantURL)
 
 	h.writeJSONResponse(w, http.StatusOK, ImageUploadResponse{
 		Success:  true,
@@ -256,8 +258,8 @@ func (h *ImageManagementHandler) GeneratePresignedURL(w http.ResponseWriter, r *
 
 	// Parse request body
 	var req struct {
-		Filename    string `json:"filename"`
 		ContentType string `json:"content_type"`
+		Size        int64  `json:"size"`
 	}
 
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -268,12 +270,15 @@ func (h *ImageManagementHandler) GeneratePresignedURL(w http.ResponseWriter, r *
 		return
 	}
 
-	// Generate unique key for the image
-	key := fmt.Sprintf("events/%d/temp/%s", eventID, req.Filename)
-
-	// Generate presigned URL (valid for 15 minutes)
-	presignedURL, err := h.storageService.GeneratePresignedURL(r.Context(), key, req.ContentType, 15*60)
+	upload, err := h.imageService.CreateDirectUpload(r.Context(), eventID, req.ContentType, req.Size)
 	if err != nil {
+		if errors.Is(err, services.ErrInvalidDirectUpload) {
+			h.writeJSONResponse(w, http.StatusBadRequest, map[string]interface{}{
+				"success": false,
+				"error":   err.Error(),
+			})
+			return
+		}
 		h.writeJSONResponse(w, http.StatusInternalServerError, map[string]interface{}{
 			"success": false,
 			"error":   "Failed to generate presigned URL",
@@ -283,8 +288,95 @@ func (h *ImageManagementHandler) GeneratePresignedURL(w http.ResponseWriter, r *
 
 	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
 		"success":       true,
-		"presigned_url": presignedURL,
-		"key":           key,
+		"presigned_url": upload.URL,
+		"key":           upload.Key,
+		"content_type":  upload.ContentType,
+		"max_size":      upload.MaxSize,
+		"expires_at":    upload.ExpiresAt,
+	})
+}
+
+// CompleteUpload registers an image the browser uploaded through a presigned
+// URL as the event's image, once it passes validation, and generates its
+// variants
+func (h *ImageManagementHandler) CompleteUpload(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		h.writeJSONResponse(w, http.StatusUnauthorized, ImageUploadResponse{
+			Success: false,
+			Error:   "Unauthorized",
+		})
+		return
+	}
+
+	// Get event ID from URL
+	eventID, err := strconv.Atoi(chi.URLParam(r, "eventId"))
+	if err != nil {
+		h.writeJSONResponse(w, http.StatusBadRequest, ImageUploadResponse{
+			Success: false,
+			Error:   "Invalid event ID",
+		})
+		return
+	}
+
+	// Verify event ownership
+	event, err := h.eventService.GetEventByID(eventID)
+	if err != nil {
+		h.writeJSONResponse(w, http.StatusNotFound, ImageUploadResponse{
+			Success: false,
+			Error:   "Event not found",
+		})
+		return
+	}
+
+	if event.OrganizerID != user.ID && user.Role != models.RoleAdmin {
+		h.writeJSONResponse(w, http.StatusForbidden, ImageUploadResponse{
+			Success: false,
+			Error:   "Forbidden",
+		})
+		return
+	}
+
+	var req struct {
+		Key string `json:"key"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil || req.Key == "" {
+		h.writeJSONResponse(w, http.StatusBadRequest, ImageUploadResponse{
+			Success: false,
+			Error:   "Invalid request body",
+		})
+		return
+	}
+
+	result, err := h.imageService.CompleteDirectUpload(r.Context(), eventID, req.Key, h.getProcessingOptions(r))
+	if err != nil {
+		h.writeJSONResponse(w, uploadErrorStatus(err), ImageUploadResponse{
+			Success: false,
+			Error:   fmt.Sprintf("Failed to process image: %v", err),
+		})
+		return
+	}
+
+	imageURL := heroImageURL(result)
+	if h.eventImages != nil {
+		updated, err := h.eventImages.SetEventImage(eventID, user.ID, result)
+		if err != nil {
+			h.imageService.DeleteImage(r.Context(), strings.TrimSuffix(result.Original.Key, "/original"))
+			h.writeJSONResponse(w, http.StatusInternalServerError, ImageUploadResponse{
+				Success: false,
+				Error:   "Failed to save event image",
+			})
+			return
+		}
+		imageURL = updated.ImageURL
+	}
+	logging.FromContext(r.Context()).Info("direct event image upload completed", "event_id", eventID, "url", imageURL)
+
+	h.writeJSONResponse(w, http.StatusOK, ImageUploadResponse{
+		Success:  true,
+		Message:  "Image uploaded successfully",
+		ImageURL: imageURL,
+		Result:   result,
 	})
 }
 
@@ -355,7 +447,7 @@ func (h *ImageManagementHandler) DeleteImage(w http.ResponseWriter, r *http.Requ
 	if strings.Contains(event.ImageURL, req.ImageKey) {
 		// TODO: Update the event's image URL directly in the database
 		// For now, just log that the primary image was deleted
-		fmt.Printf("Primary image deleted for event %d\n", eventID)
+		logging.FromContext(r.Context()).Info("primary event image deleted", "event_id", eventID)
 	}
 
 	h.writeJSONResponse(w, http.StatusOK, map[string]interface{}{
@@ -451,7 +543,7 @@ func (h *ImageManagementHandler) ReplaceImage(w http.ResponseWriter, r *http.Req
 	// Upload new image
 	result, err := h.imageService.UploadImageWithOptions(r.Context(), file, header.Filename, options)
 	if err != nil {
-		h.writeJSONResponse(w, http.StatusInternalServerError, ImageUploadResponse{
+		h.writeJSONResponse(w, uploadErrorStatus(err), ImageUploadResponse{
 			Success: false,
 			Error:   fmt.Sprintf("Failed to upload new image: %v", err),
 		})
@@ -461,21 +553,15 @@ func (h *ImageManagementHandler) ReplaceImage(w http.ResponseWriter, r *http.Req
 	// Delete old image
 	err = h.imageService.DeleteImage(r.Context(), oldImageKey)
 	if err != nil {
-		fmt.Printf("Failed to delete old image %s: %v\n", oldImageKey, err)
+		logging.FromContext(r.Context()).Warn("failed to delete old event image", "event_id", eventID, "key", oldImageKey, "error", err)
 	}
 
 	// Update event with new image URL
-	largeVariantURL := result.Original.URL
-	for _, variant := range result.Variants {
-		if variant.Name == "large" {
-			largeVariantURL = variant.URL
-			break
-		}
-	}
+	largeVariantURL := heroImageURL(result)
 
 	// TODO: Update the event's image URL directly in the database
 	// For now, just log the successful replacement
-	fmt.Printf("Image replaced successfully for event %d. New image URL: %s\n", eventID, largeVariantURL)
+	logging.FromContext(r.Context()).Info("event image replaced", "event_id", eventID, "url", largeVariantURL)
 
 	h.writeJSONResponse(w, http.StatusOK, ImageUploadResponse{
 		Success:  true,
@@ -544,10 +630,20 @@ func (h *ImageManagementHandler) GetImageVariants(w http.ResponseWriter, r *http
 
 // Helper methods
 
+// heroImageURL returns the URL of the widest JPEG hero variant, or of the
+// original when no hero was generated
+func heroImageURL(result *services.ImageUploadResult) string {
+	if hero, ok := result.EventImageVariants().Largest(models.ImageVariantHero); ok {
+		return hero.URL
+	}
+	return result.Original.URL
+}
+
 func (h *ImageManagementHandler) getProcessingOptions(r *http.Request) services.ImageProcessingOptions {
 	options := services.ImageProcessingOptions{
 		Quality:         85,
 		EnableWebP:      true,
+		EnableAVIF:      true,
 		CompressionLevel: 6,
 	}
 
@@ -563,6 +659,11 @@ func (h *ImageManagementHandler) getProcessingOptions(r *http.Request) services.
 		options.EnableWebP = webpStr == "true"
 	}
 
+	// Parse AVIF setting
+	if avifStr := r.FormValue("enable_avif"); avifStr != "" {
+		options.EnableAVIF = avifStr == "true"
+	}
+
 	// Parse compression level
 	if compressionStr := r.FormValue("compression_level"); compressionStr != "" {
 		if compression, err := strconv.Atoi(compressionStr); err == nil && compression >= 0 && compression <= 9 {
@@ -650,6 +751,15 @@ func (h *ImageManagementHandler) getImageVariantURLs(keyPrefix string) map[strin
 	return variants
 }
 
+// uploadErrorStatus is the status for a failed upload: rejected uploads are
+// the client's fault, anything else is the server's
+func uploadErrorStatus(err error) int {
+	if errors.Is(err, services.ErrInvalidImage) || errors.Is(err, services.ErrInvalidDirectUpload) {
+		return http.StatusBadRequest
+	}
+	return http.StatusInternalServerError
+}
+
 func (h *ImageManagementHandler) writeJSONResponse(w http.ResponseWriter, statusCode int, data interface{}) {
 	w.Header().Set("Content-Type", "application/json")
 	w.WriteHeader(statusCode)
@@ -334,9 +334,9 @@ func TestImageManagementIntegration(t *testing.T) {
 
 	t.Run("GeneratePresignedURL", func(t *testing.T) {
 		// Create request body
-		reqBody := map[string]string{
-			"filename":     "test.jpg",
+		reqBody := map[string]interface{}{
 			"content_type": "image/jpeg",
+			"size":         1024,
 		}
 		
 		bodyBytes, err := json.Marshal(reqBody)
@@ -0,0 +1,77 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+
+	"github.com/go-chi/chi/v5"
+	"github.com/gorilla/sessions"
+)
+
+// ImpersonationHandler handles admins logging in as other users for support
+type ImpersonationHandler struct {
+	impersonationService *services.ImpersonationService
+	store                sessions.Store
+}
+
+// NewImpersonationHandler creates a new impersonation handler
+func NewImpersonationHandler(impersonationService *services.ImpersonationService, store sessions.Store) *ImpersonationHandler {
+	return &ImpersonationHandler{
+		impersonationService: impersonationService,
+		store:                store,
+	}
+}
+
+// Impersonate starts acting as the user and sends the admin to their dashboard
+func (h *ImpersonationHandler) Impersonate(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if user.Role != models.UserRoleAdmin {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	userID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid user ID", http.StatusBadRequest)
+		return
+	}
+
+	impersonation, err := h.impersonationService.Start(user, userID, r.FormValue("reason"), r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	if err := middleware.StartImpersonation(w, r, h.store, impersonation); err != nil {
+		http.Error(w, "Failed to save session", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/dashboard", http.StatusSeeOther)
+}
+
+// StopImpersonating returns the admin to their own account
+func (h *ImpersonationHandler) StopImpersonating(w http.ResponseWriter, r *http.Request) {
+	impersonation := middleware.GetImpersonation(r.Context())
+	if impersonation == nil {
+		http.Redirect(w, r, "/", http.StatusSeeOther)
+		return
+	}
+
+	h.impersonationService.Stop(impersonation, r)
+	if err := middleware.StopImpersonation(w, r, h.store); err != nil {
+		http.Error(w, "Failed to save session", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/users", http.StatusSeeOther)
+}
@@ -0,0 +1,164 @@
+package handlers
+
+import (
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// InstallmentHandler handles organizers' installment settings and buyers'
+// payment schedules
+type InstallmentHandler struct {
+	installmentService *services.InstallmentService
+	eventService       services.EventServiceInterface
+	orderService       services.OrderServiceInterface
+}
+
+// NewInstallmentHandler creates a new installment handler
+func NewInstallmentHandler(installmentService *services.InstallmentService, eventService services.EventServiceInterface, orderService services.OrderServiceInterface) *InstallmentHandler {
+	return &InstallmentHandler{
+		installmentService: installmentService,
+		eventService:       eventService,
+		orderService:       orderService,
+	}
+}
+
+// SettingsPage shows the installment settings for one of the organizer's events
+func (h *InstallmentHandler) SettingsPage(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	settings, err := h.installmentService.GetSettings(event.ID)
+	if err != nil {
+		http.Error(w, "Failed to load installment settings", http.StatusInternalServerError)
+		return
+	}
+
+	formData := map[string]string{
+		"installments":     strconv.Itoa(settings.Installments),
+		"interval_days":    strconv.Itoa(settings.IntervalDays),
+		"min_order_amount": strconv.FormatFloat(float64(settings.MinOrderAmount)/100, 'f', 2, 64),
+	}
+	if settings.Enabled {
+		formData["enabled"] = "on"
+	}
+	if settings.IssueTicketsUpfront {
+		formData["issue_tickets_upfront"] = "on"
+	}
+
+	h.renderSettings(w, r, http.StatusOK, user, event, formData, r.URL.Query().Get("saved") == "1", "")
+}
+
+// UpdateSettings turns paying in installments on or off for an event and
+// sets how orders are split
+func (h *InstallmentHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	formData := map[string]string{
+		"enabled":               r.FormValue("enabled"),
+		"installments":          r.FormValue("installments"),
+		"interval_days":         r.FormValue("interval_days"),
+		"min_order_amount":      r.FormValue("min_order_amount"),
+		"issue_tickets_upfront": r.FormValue("issue_tickets_upfront"),
+	}
+
+	// Invalid numbers are left zero for Validate to report
+	installments, _ := strconv.Atoi(strings.TrimSpace(formData["installments"]))
+	intervalDays, _ := strconv.Atoi(strings.TrimSpace(formData["interval_days"]))
+	minOrderAmount := 0
+	if amount := strings.TrimSpace(formData["min_order_amount"]); amount != "" {
+		value, err := strconv.ParseFloat(amount, 64)
+		if err != nil {
+			h.renderSettings(w, r, http.StatusBadRequest, user, event, formData, false, "Minimum order amount must be a number")
+			return
+		}
+		minOrderAmount = int(math.Round(value * 100))
+	}
+
+	// Unchecked checkboxes are not submitted
+	settings := &models.InstallmentSettings{
+		EventID:             event.ID,
+		Enabled:             formData["enabled"] == "on",
+		Installments:        installments,
+		IntervalDays:        intervalDays,
+		MinOrderAmount:      minOrderAmount,
+		IssueTicketsUpfront: formData["issue_tickets_upfront"] == "on",
+	}
+	if err := settings.Validate(); err != nil {
+		h.renderSettings(w, r, http.StatusBadRequest, user, event, formData, false, err.Error())
+		return
+	}
+
+	if err := h.installmentService.UpdateSettings(settings); err != nil {
+		http.Error(w, "Failed to save installment settings", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/organizer/events/"+strconv.Itoa(event.ID)+"/installments?saved=1", http.StatusSeeOther)
+}
+
+// renderSettings renders the installment settings page
+func (h *InstallmentHandler) renderSettings(w http.ResponseWriter, r *http.Request, status int, user *models.User, event *models.Event, formData map[string]string, saved bool, errorMsg string) {
+	w.WriteHeader(status)
+	component := pages.EventInstallmentsPage(user, event, formData, saved, errorMsg)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// SchedulePage handles GET /dashboard/orders/{id}/installments, showing the
+// buyer what they have paid for an order and when the rest is charged
+func (h *InstallmentHandler) SchedulePage(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	orderID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid order ID", http.StatusBadRequest)
+		return
+	}
+
+	order, err := h.orderService.GetOrderByID(orderID, user.ID)
+	if err != nil {
+		http.Error(w, "Order not found", http.StatusNotFound)
+		return
+	}
+
+	plan, err := h.installmentService.Plan(order.ID)
+	if err != nil {
+		http.Error(w, "Failed to load payment schedule", http.StatusInternalServerError)
+		return
+	}
+	if plan == nil {
+		http.Redirect(w, r, "/dashboard/orders/"+strconv.Itoa(order.ID), http.StatusSeeOther)
+		return
+	}
+
+	component := pages.InstallmentSchedulePage(user, order, plan)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
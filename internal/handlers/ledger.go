@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+)
+
+// ledgerPageSize is how many transactions the balance history shows per page
+const ledgerPageSize = 25
+
+// LedgerHandler handles organizers' balance history
+type LedgerHandler struct {
+	ledgerService *services.LedgerService
+}
+
+// NewLedgerHandler creates a new ledger handler
+func NewLedgerHandler(ledgerService *services.LedgerService) *LedgerHandler {
+	return &LedgerHandler{ledgerService: ledgerService}
+}
+
+// BalanceHistoryPage handles GET /organizer/balance, showing every movement
+// of the organizer's balance and the accounts of their ledger
+func (h *LedgerHandler) BalanceHistoryPage(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	page := 1
+	if p := r.URL.Query().Get("page"); p != "" {
+		if parsed, err := strconv.Atoi(p); err == nil && parsed > 0 {
+			page = parsed
+		}
+	}
+
+	balances, err := h.ledgerService.Balances(user.ID)
+	if err != nil {
+		http.Error(w, "Failed to load balance", http.StatusInternalServerError)
+		return
+	}
+
+	transactions, total, err := h.ledgerService.History(user.ID, page, ledgerPageSize)
+	if err != nil {
+		http.Error(w, "Failed to load balance history", http.StatusInternalServerError)
+		return
+	}
+	totalPages := (total + ledgerPageSize - 1) / ledgerPageSize
+
+	component := pages.BalanceHistoryPage(user, balances, transactions, page, totalPages)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
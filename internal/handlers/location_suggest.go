@@ -0,0 +1,70 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/services"
+)
+
+// LocationSuggestHandler handles city and venue autocomplete in the event form
+type LocationSuggestHandler struct {
+	suggestService *services.LocationSuggestService
+}
+
+// NewLocationSuggestHandler creates a new location suggestion handler
+func NewLocationSuggestHandler(suggestService *services.LocationSuggestService) *LocationSuggestHandler {
+	return &LocationSuggestHandler{
+		suggestService: suggestService,
+	}
+}
+
+// Cities handles GET /organizer/events/locations/cities?q=
+func (h *LocationSuggestHandler) Cities(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("q")
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	suggestions, err := h.suggestService.SuggestCities(query, limit)
+	if err != nil {
+		http.Error(w, "Failed to suggest cities", http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"query":       query,
+		"suggestions": suggestions,
+	}
+	if err := writeJSON(w, response); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to write JSON response: %v", err), http.StatusInternalServerError)
+		return
+	}
+}
+
+// Venues handles GET /organizer/events/locations/venues?city=&q=
+func (h *LocationSuggestHandler) Venues(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	query := r.URL.Query().Get("q")
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	suggestions, err := h.suggestService.SuggestVenues(r.URL.Query().Get("city"), query, user.ID, limit)
+	if err != nil {
+		http.Error(w, "Failed to suggest venues", http.StatusInternalServerError)
+		return
+	}
+
+	response := map[string]interface{}{
+		"query":       query,
+		"suggestions": suggestions,
+	}
+	if err := writeJSON(w, response); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to write JSON response: %v", err), http.StatusInternalServerError)
+		return
+	}
+}
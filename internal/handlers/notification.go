@@ -0,0 +1,143 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// NotificationHandler handles organizer notifications and notification settings
+type NotificationHandler struct {
+	notificationService *services.NotificationService
+}
+
+// NewNotificationHandler creates a new notification handler
+func NewNotificationHandler(notificationService *services.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		notificationService: notificationService,
+	}
+}
+
+// NotificationsPage lists the organizer's notifications alongside their notification settings
+func (h *NotificationHandler) NotificationsPage(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	page := 1
+	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
+		if p, err := strconv.Atoi(pageStr); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	list, err := h.notificationService.GetNotifications(user.ID, page, 20)
+	if err != nil {
+		http.Error(w, "Failed to load notifications", http.StatusInternalServerError)
+		return
+	}
+
+	prefs, err := h.notificationService.GetPreferences(user.ID)
+	if err != nil {
+		http.Error(w, "Failed to load notification settings", http.StatusInternalServerError)
+		return
+	}
+
+	saved := r.URL.Query().Get("saved") == "1"
+
+	component := pages.OrganizerNotificationsPage(user, list, prefs, saved)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// MarkRead marks a notification as read and follows its link
+func (h *NotificationHandler) MarkRead(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	notificationID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid notification ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.notificationService.MarkRead(notificationID, user.ID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, "Notification not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to update notification", http.StatusInternalServerError)
+		return
+	}
+
+	// Only follow local links
+	redirectURL := r.FormValue("redirect")
+	if !strings.HasPrefix(redirectURL, "/") || strings.HasPrefix(redirectURL, "//") {
+		redirectURL = "/organizer/notifications"
+	}
+	http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+}
+
+// MarkAllRead marks all of the organizer's notifications as read
+func (h *NotificationHandler) MarkAllRead(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := h.notificationService.MarkAllRead(user.ID); err != nil {
+		http.Error(w, "Failed to update notifications", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/organizer/notifications", http.StatusSeeOther)
+}
+
+// UpdatePreferences saves the organizer's notification toggles
+func (h *NotificationHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	prefs, err := h.notificationService.GetPreferences(user.ID)
+	if err != nil {
+		http.Error(w, "Failed to load notification settings", http.StatusInternalServerError)
+		return
+	}
+
+	// Unchecked checkboxes are not submitted
+	prefs.EmailEnabled = r.FormValue("email_enabled") == "on"
+	prefs.InAppEnabled = r.FormValue("in_app_enabled") == "on"
+	prefs.FirstSale = r.FormValue("first_sale") == "on"
+	prefs.HalfSold = r.FormValue("half_sold") == "on"
+	prefs.SoldOut = r.FormValue("sold_out") == "on"
+	prefs.SaleEnding = r.FormValue("sale_ending") == "on"
+
+	if err := h.notificationService.UpdatePreferences(prefs); err != nil {
+		http.Error(w, "Failed to save notification settings", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/organizer/notifications?saved=1", http.StatusSeeOther)
+}
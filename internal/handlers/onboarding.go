@@ -2,8 +2,10 @@ package handlers
 
 import (
 	"fmt"
+	"log/slog"
 	"net/http"
 
+	"event-ticketing-platform/internal/logging"
 	"event-ticketing-platform/internal/middleware"
 	"event-ticketing-platform/internal/models"
 	"event-ticketing-platform/internal/services"
@@ -150,7 +152,7 @@ func (h *OnboardingHandler) OnboardingStepSubmit(w http.ResponseWriter, r *http.
 		// Update user profile completion status
 		err = h.updateUserProfileCompletion(user.ID, onboarding)
 		if err != nil {
-			fmt.Printf("Failed to update user profile completion: %v\n", err)
+			logging.FromContext(r.Context()).Warn("failed to update profile completion", "error", err)
 		}
 
 		// Redirect to appropriate dashboard
@@ -250,8 +252,7 @@ func (h *OnboardingHandler) updateUserProfileCompletion(userID int, onboarding *
 	// Update user profile completion
 	// This would typically involve calling a user service method
 	// For now, we'll just log the completion
-	fmt.Printf("User %d completed onboarding: %d/%d steps completed\n", 
-		userID, completedSteps, len(onboarding.Steps))
+	slog.Info("user completed onboarding", "user_id", userID, "completed_steps", completedSteps, "steps", len(onboarding.Steps))
 
 	return nil
 }
\ No newline at end of file
@@ -0,0 +1,182 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// OrderAmendmentHandler handles buyers' changes to their orders
+type OrderAmendmentHandler struct {
+	amendmentService *services.OrderAmendmentService
+	orderService     services.OrderServiceInterface
+}
+
+// NewOrderAmendmentHandler creates a new order amendment handler
+func NewOrderAmendmentHandler(amendmentService *services.OrderAmendmentService, orderService services.OrderServiceInterface) *OrderAmendmentHandler {
+	return &OrderAmendmentHandler{
+		amendmentService: amendmentService,
+		orderService:     orderService,
+	}
+}
+
+// ChangePage handles GET /dashboard/orders/{id}/change, showing what the
+// buyer can change their order to and the change awaiting confirmation
+func (h *OrderAmendmentHandler) ChangePage(w http.ResponseWriter, r *http.Request) {
+	user, orderID, ok := amendmentRequest(w, r)
+	if !ok {
+		return
+	}
+
+	h.render(w, r, http.StatusOK, user, orderID, nil, r.URL.Query().Get("changed") == "1", "")
+}
+
+// RequestChange handles POST /dashboard/orders/{id}/change, pricing the new
+// quantities of each ticket type for the buyer to confirm
+func (h *OrderAmendmentHandler) RequestChange(w http.ResponseWriter, r *http.Request) {
+	user, orderID, ok := amendmentRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	// Quantities are submitted as quantity_<ticket type ID>
+	formData := make(map[string]string)
+	quantities := make(map[int]int)
+	for key := range r.PostForm {
+		ticketTypeID, err := strconv.Atoi(strings.TrimPrefix(key, "quantity_"))
+		if !strings.HasPrefix(key, "quantity_") || err != nil {
+			continue
+		}
+		formData[key] = r.PostForm.Get(key)
+		quantity, err := strconv.Atoi(strings.TrimSpace(formData[key]))
+		if err != nil {
+			h.render(w, r, http.StatusBadRequest, user, orderID, formData, false, "Quantities must be whole numbers")
+			return
+		}
+		quantities[ticketTypeID] = quantity
+	}
+
+	if _, err := h.amendmentService.RequestAmendment(orderID, user.ID, quantities); err != nil {
+		h.render(w, r, http.StatusBadRequest, user, orderID, formData, false, err.Error())
+		return
+	}
+
+	http.Redirect(w, r, changePageURL(orderID), http.StatusSeeOther)
+}
+
+// ConfirmChange handles POST /dashboard/orders/{id}/change/{amendmentID}/confirm,
+// applying the change or sending the buyer to pay the difference
+func (h *OrderAmendmentHandler) ConfirmChange(w http.ResponseWriter, r *http.Request) {
+	user, orderID, ok := amendmentRequest(w, r)
+	if !ok {
+		return
+	}
+	amendmentID, err := strconv.Atoi(chi.URLParam(r, "amendmentID"))
+	if err != nil {
+		http.Error(w, "Invalid change ID", http.StatusBadRequest)
+		return
+	}
+
+	confirmation, err := h.amendmentService.ConfirmAmendment(amendmentID, user.ID)
+	if err != nil {
+		h.render(w, r, http.StatusBadRequest, user, orderID, nil, false, err.Error())
+		return
+	}
+
+	if confirmation.AuthorizationURL != "" {
+		http.Redirect(w, r, confirmation.AuthorizationURL, http.StatusSeeOther)
+		return
+	}
+	if confirmation.Amendment.IsPending() {
+		// The payment is being processed; the buyer checks back for it
+		http.Redirect(w, r, changePageURL(orderID), http.StatusSeeOther)
+		return
+	}
+	http.Redirect(w, r, changePageURL(orderID)+"?changed=1", http.StatusSeeOther)
+}
+
+// CheckPayment handles POST /dashboard/orders/{id}/change/{amendmentID}/check,
+// applying the change once the difference has been paid
+func (h *OrderAmendmentHandler) CheckPayment(w http.ResponseWriter, r *http.Request) {
+	user, orderID, ok := amendmentRequest(w, r)
+	if !ok {
+		return
+	}
+	amendmentID, err := strconv.Atoi(chi.URLParam(r, "amendmentID"))
+	if err != nil {
+		http.Error(w, "Invalid change ID", http.StatusBadRequest)
+		return
+	}
+
+	if _, err := h.amendmentService.CheckAmendmentPayment(amendmentID, user.ID); err != nil {
+		h.render(w, r, http.StatusBadRequest, user, orderID, nil, false, err.Error())
+		return
+	}
+
+	http.Redirect(w, r, changePageURL(orderID)+"?changed=1", http.StatusSeeOther)
+}
+
+// render renders the change page. Orders that can no longer be changed are
+// shown with the reason instead of the form.
+func (h *OrderAmendmentHandler) render(w http.ResponseWriter, r *http.Request, status int, user *models.User, orderID int, formData map[string]string, changed bool, errorMsg string) {
+	order, err := h.orderService.GetOrderByID(orderID, user.ID)
+	if err != nil {
+		http.Error(w, "Order not found", http.StatusNotFound)
+		return
+	}
+
+	closedMsg := ""
+	options, err := h.amendmentService.Options(order.ID, user.ID)
+	if err != nil {
+		if !errors.Is(err, services.ErrAmendmentClosed) {
+			http.Error(w, "Failed to load order", http.StatusInternalServerError)
+			return
+		}
+		closedMsg = err.Error()
+	}
+
+	if formData == nil {
+		formData = make(map[string]string)
+	}
+
+	w.WriteHeader(status)
+	component := pages.OrderChangePage(user, order, options, formData, changed, closedMsg, errorMsg)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// amendmentRequest returns the signed in buyer and the order of a change request
+func amendmentRequest(w http.ResponseWriter, r *http.Request) (*models.User, int, bool) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return nil, 0, false
+	}
+
+	orderID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid order ID", http.StatusBadRequest)
+		return nil, 0, false
+	}
+	return user, orderID, true
+}
+
+// changePageURL returns the URL of an order's change page
+func changePageURL(orderID int) string {
+	return "/dashboard/orders/" + strconv.Itoa(orderID) + "/change"
+}
@@ -93,6 +93,11 @@ func (m *MockEventService) GetEventByID(id int) (*models.Event, error) {
 	return args.Get(0).(*models.Event), args.Error(1)
 }
 
+func (m *MockEventService) GetEventBySlug(slug string) (*models.Event, error) {
+	args := m.Called(slug)
+	return args.Get(0).(*models.Event), args.Error(1)
+}
+
 func (m *MockEventService) GetEventOrganizer(eventID int) (*models.User, error) {
 	args := m.Called(eventID)
 	return args.Get(0).(*models.User), args.Error(1)
@@ -0,0 +1,55 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+)
+
+// OrganizerCalendarHandler handles the organizer event calendar
+type OrganizerCalendarHandler struct {
+	calendarService *services.CalendarService
+}
+
+// NewOrganizerCalendarHandler creates a new organizer calendar handler
+func NewOrganizerCalendarHandler(calendarService *services.CalendarService) *OrganizerCalendarHandler {
+	return &OrganizerCalendarHandler{
+		calendarService: calendarService,
+	}
+}
+
+// CalendarPage displays a month or week calendar of the organizer's events
+func (h *OrganizerCalendarHandler) CalendarPage(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	view := services.ParseCalendarView(r.URL.Query().Get("view"))
+
+	var anchor time.Time
+	if dateStr := r.URL.Query().Get("date"); dateStr != "" {
+		parsed, err := time.Parse("2006-01-02", dateStr)
+		if err != nil {
+			http.Error(w, "Invalid date format", http.StatusBadRequest)
+			return
+		}
+		anchor = parsed
+	}
+
+	calendar, err := h.calendarService.GetOrganizerCalendar(user.ID, view, anchor)
+	if err != nil {
+		http.Error(w, "Failed to load calendar", http.StatusInternalServerError)
+		return
+	}
+
+	component := pages.OrganizerCalendarPage(user, calendar)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
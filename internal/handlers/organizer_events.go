@@ -2,13 +2,13 @@ package handlers
 
 import (
 	"fmt"
-	"log"
 	"mime/multipart"
 	"net/http"
 	"strconv"
 	"strings"
 	"time"
 
+	"event-ticketing-platform/internal/logging"
 	"event-ticketing-platform/internal/middleware"
 	"event-ticketing-platform/internal/models"
 	"event-ticketing-platform/internal/services"
@@ -23,6 +23,8 @@ type OrganizerEventHandler struct {
 	ticketService  services.TicketServiceInterface
 	storageService services.StorageService
 	imageService   services.ImageServiceInterface
+	venueService   *services.VenueService
+	accessService  *services.EventAccessService
 }
 
 // NewOrganizerEventHandler creates a new organizer event handler
@@ -35,6 +37,103 @@ func NewOrganizerEventHandler(eventService services.EventServiceInterface, ticke
 	}
 }
 
+// SetVenueService lets organizers pick one of their saved venues as an
+// event's location
+func (h *OrganizerEventHandler) SetVenueService(venueService *services.VenueService) {
+	h.venueService = venueService
+}
+
+// organizerVenues returns the saved venues the event form offers, or none if
+// they can't be loaded
+func (h *OrganizerEventHandler) organizerVenues(r *http.Request, organizerID int) []*models.Venue {
+	if h.venueService == nil {
+		return nil
+	}
+	venues, err := h.venueService.GetVenues(organizerID)
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to load venues", "organizer_id", organizerID, "error", err)
+		return nil
+	}
+	return venues
+}
+
+// eventFormVenue returns the saved venue picked on the event form, or nil
+// when the organizer entered the venue and city instead
+func (h *OrganizerEventHandler) eventFormVenue(r *http.Request, organizerID int) (*models.Venue, error) {
+	venueID, err := strconv.Atoi(r.FormValue("venue_id"))
+	if err != nil || venueID == 0 || h.venueService == nil {
+		return nil, nil
+	}
+	return h.venueService.GetVenue(venueID, organizerID)
+}
+
+// holdEventAtVenue records the venue an event is held at, first saving the
+// entered venue and city as a new venue if the organizer asked to. Events
+// whose location was entered by hand are detached from any venue.
+func (h *OrganizerEventHandler) holdEventAtVenue(r *http.Request, eventID, organizerID int, venue *models.Venue) {
+	if h.venueService == nil {
+		return
+	}
+
+	logger := logging.FromContext(r.Context())
+	if venue == nil && r.FormValue("save_venue") == "on" && r.FormValue("event_type") != onlineEventType {
+		saved, err := h.venueService.CreateVenue(organizerID, &services.VenueRequest{
+			Name: r.FormValue("venue"),
+			City: r.FormValue("city"),
+		})
+		if err != nil {
+			logger.Warn("failed to save event location as a venue", "event_id", eventID, "error", err)
+		} else {
+			venue = saved
+		}
+	}
+
+	if err := h.venueService.SetEventVenue(eventID, venue); err != nil {
+		logger.Warn("failed to set event venue", "event_id", eventID, "error", err)
+	}
+}
+
+// onlineEventType is the event form's format for events held online
+const onlineEventType = "online"
+
+// SetEventAccessService lets organizers hold events online, with a stream or
+// meeting link for ticket holders
+func (h *OrganizerEventHandler) SetEventAccessService(accessService *services.EventAccessService) {
+	h.accessService = accessService
+}
+
+// eventFormAccessLink returns the access link entered on the event form, or
+// nil when the event is held in person
+func (h *OrganizerEventHandler) eventFormAccessLink(r *http.Request) *models.EventAccessLink {
+	if h.accessService == nil || r.FormValue("event_type") != onlineEventType {
+		return nil
+	}
+	revealMinutes, _ := strconv.Atoi(r.FormValue("reveal_minutes_before"))
+	return &models.EventAccessLink{
+		URL:                 r.FormValue("access_url"),
+		RevealMinutesBefore: revealMinutes,
+	}
+}
+
+// saveAccessLink saves an online event's access link, or removes it from an
+// event that is now held in person
+func (h *OrganizerEventHandler) saveAccessLink(r *http.Request, eventID int, link *models.EventAccessLink) {
+	if h.accessService == nil {
+		return
+	}
+
+	var err error
+	if link == nil {
+		err = h.accessService.DeleteLink(eventID)
+	} else {
+		link.EventID = eventID
+		err = h.accessService.SaveLink(link)
+	}
+	if err != nil {
+		logging.FromContext(r.Context()).Warn("failed to save event access link", "event_id", eventID, "error", err)
+	}
+}
+
 
 // EventsListPage displays the organizer's events with filtering
 func (h *OrganizerEventHandler) EventsListPage(w http.ResponseWriter, r *http.Request) {
@@ -115,7 +214,7 @@ func (h *OrganizerEventHandler) CreateEventPage(w http.ResponseWriter, r *http.R
 	}
 
 	// Render the create event page
-	component := pages.CreateEventPage(user, categories, nil, nil)
+	component := pages.CreateEventPage(user, categories, h.organizerVenues(r, user.ID), nil, nil)
 	err = component.Render(r.Context(), w)
 	if err != nil {
 		http.Error(w, fmt.Sprintf("Failed to render page: %v", err), http.StatusInternalServerError)
@@ -154,11 +253,12 @@ func (h *OrganizerEventHandler) CreateEventSubmit(w http.ResponseWriter, r *http
 	// Extract form fields
 	title := r.FormValue("title")
 	description := r.FormValue("description")
-	location := r.FormValue("location")
+	location := eventFormLocation(r)
 	startDateStr := r.FormValue("start_date")
 	endDateStr := r.FormValue("end_date")
 	categoryIDStr := r.FormValue("category_id")
 	statusValue := r.FormValue("status")
+	imageAltText := strings.TrimSpace(r.FormValue("image_alt_text"))
 	
 	// Extract new fields
 	eventType := r.FormValue("event_type")
@@ -167,13 +267,6 @@ func (h *OrganizerEventHandler) CreateEventSubmit(w http.ResponseWriter, r *http
 	ticketPriceStr := r.FormValue("ticket_price")
 	ticketQuantityStr := r.FormValue("ticket_quantity")
 	saleEndDateStr := r.FormValue("sale_end_date")
-	
-	// Debug logging
-	log.Printf("🔍 Form values received:")
-	log.Printf("  title: %s", title)
-	log.Printf("  status: %s", statusValue)
-	log.Printf("  ticket_name: %s", ticketName)
-	log.Printf("  ticket_price: %s", ticketPriceStr)
 
 	// Validate required fields
 	errors := make(map[string]string)
@@ -183,6 +276,19 @@ func (h *OrganizerEventHandler) CreateEventSubmit(w http.ResponseWriter, r *http
 	if description == "" {
 		errors["description"] = "Description is required"
 	}
+	venue, venueErr := h.eventFormVenue(r, user.ID)
+	accessLink := h.eventFormAccessLink(r)
+	if accessLink != nil {
+		venue = nil
+		location = models.OnlineEventLocation
+		if err := accessLink.Validate(); err != nil {
+			errors["access_url"] = err.Error()
+		}
+	} else if venueErr != nil {
+		errors["location"] = "Pick one of your saved venues"
+	} else if venue != nil {
+		location = venue.Location()
+	}
 	if location == "" {
 		errors["location"] = "Location is required"
 	}
@@ -235,10 +341,8 @@ func (h *OrganizerEventHandler) CreateEventSubmit(w http.ResponseWriter, r *http
 	var status models.EventStatus
 	if statusValue == "published" {
 		status = models.StatusPublished
-		log.Printf("🔍 Setting status to published")
 	} else {
 		status = models.StatusDraft
-		log.Printf("🔍 Setting status to draft")
 	}
 
 	// Handle image upload
@@ -246,9 +350,21 @@ func (h *OrganizerEventHandler) CreateEventSubmit(w http.ResponseWriter, r *http
 	if file, fileHeader, err := r.FormFile("image"); err == nil {
 		defer file.Close()
 		imageFile = fileHeader
-		log.Printf("🔍 Image file received: %s (size: %d bytes)", fileHeader.Filename, fileHeader.Size)
-	} else {
-		log.Printf("🔍 No image file received: %v", err)
+	}
+
+	// Accessibility issues must be fixed before the event goes live
+	var accessibilityReport *services.AccessibilityReport
+	if status == models.StatusPublished {
+		accessibilityReport = services.CheckEventAccessibility(&services.AccessibilityCheckRequest{
+			Title:        title,
+			Description:  description,
+			Location:     location,
+			ImageAltText: imageAltText,
+			HasImage:     imageFile != nil,
+		})
+		if accessibilityReport.HasErrors() {
+			errors["general"] = "Fix the accessibility issues below before publishing, or save the event as a draft."
+		}
 	}
 
 	// If there are validation errors, re-render the form
@@ -268,9 +384,15 @@ func (h *OrganizerEventHandler) CreateEventSubmit(w http.ResponseWriter, r *http
 			"ticket_price":     ticketPriceStr,
 			"ticket_quantity":  ticketQuantityStr,
 			"sale_end_date":    saleEndDateStr,
+			"image_alt_text":   imageAltText,
+			"accessibility":    accessibilityReport,
+			"venue_id":         r.FormValue("venue_id"),
+			"save_venue":       r.FormValue("save_venue"),
+			"access_url":       r.FormValue("access_url"),
+			"reveal_minutes_before": r.FormValue("reveal_minutes_before"),
 		}
 		
-		component := pages.CreateEventPage(user, categories, formData, errors)
+		component := pages.CreateEventPage(user, categories, h.organizerVenues(r, user.ID), formData, errors)
 		err = component.Render(r.Context(), w)
 		if err != nil {
 			http.Error(w, "Failed to render page", http.StatusInternalServerError)
@@ -289,6 +411,7 @@ func (h *OrganizerEventHandler) CreateEventSubmit(w http.ResponseWriter, r *http
 		Status:      status,
 		OrganizerID: user.ID,
 		Image:       imageFile,
+		ImageAltText: imageAltText,
 	}
 
 	event, err := h.eventService.CreateEvent(createReq)
@@ -310,9 +433,15 @@ func (h *OrganizerEventHandler) CreateEventSubmit(w http.ResponseWriter, r *http
 			"ticket_price":     ticketPriceStr,
 			"ticket_quantity":  ticketQuantityStr,
 			"sale_end_date":    saleEndDateStr,
+			"image_alt_text":   imageAltText,
+			"accessibility":    accessibilityReport,
+			"venue_id":         r.FormValue("venue_id"),
+			"save_venue":       r.FormValue("save_venue"),
+			"access_url":       r.FormValue("access_url"),
+			"reveal_minutes_before": r.FormValue("reveal_minutes_before"),
 		}
 		
-		component := pages.CreateEventPage(user, categories, formData, errors)
+		component := pages.CreateEventPage(user, categories, h.organizerVenues(r, user.ID), formData, errors)
 		err = component.Render(r.Context(), w)
 		if err != nil {
 			http.Error(w, "Failed to render page", http.StatusInternalServerError)
@@ -320,6 +449,9 @@ func (h *OrganizerEventHandler) CreateEventSubmit(w http.ResponseWriter, r *http
 		return
 	}
 
+	h.holdEventAtVenue(r, event.ID, user.ID, venue)
+	h.saveAccessLink(r, event.ID, accessLink)
+
 	// Create basic ticket type if provided
 	if ticketName != "" && ticketQuantityStr != "" {
 		// Parse ticket data
@@ -363,7 +495,7 @@ func (h *OrganizerEventHandler) CreateEventSubmit(w http.ResponseWriter, r *http
 		if err != nil {
 			// Log error but don't fail the event creation
 			// The user can still create ticket types manually
-			fmt.Printf("Warning: Failed to create basic ticket type: %v\n", err)
+			logging.FromContext(r.Context()).Warn("failed to create basic ticket type", "event_id", event.ID, "error", err)
 		}
 	}
 
@@ -371,6 +503,45 @@ func (h *OrganizerEventHandler) CreateEventSubmit(w http.ResponseWriter, r *http
 	http.Redirect(w, r, fmt.Sprintf("/organizer/events/%d/tickets/", event.ID), http.StatusSeeOther)
 }
 
+// eventFormLocation returns the location the event form submitted, joined
+// from its venue and city fields. Clients that send a single location field
+// still work.
+func eventFormLocation(r *http.Request) string {
+	venue, city := r.FormValue("venue"), r.FormValue("city")
+	if venue == "" && city == "" {
+		return strings.TrimSpace(r.FormValue("location"))
+	}
+	return models.ComposeLocation(venue, city)
+}
+
+// CheckAccessibility handles POST /organizer/events/accessibility-check. It
+// checks the event form content as it is edited and returns the issues as JSON.
+func (h *OrganizerEventHandler) CheckAccessibility(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	report := services.CheckEventAccessibility(&services.AccessibilityCheckRequest{
+		Title:        r.FormValue("title"),
+		Description:  r.FormValue("description"),
+		Location:     eventFormLocation(r),
+		ImageAltText: r.FormValue("image_alt_text"),
+		HasImage:     r.FormValue("has_image") == "true",
+	})
+
+	if err := writeJSON(w, report); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to write JSON response: %v", err), http.StatusInternalServerError)
+		return
+	}
+}
+
 // EditEventPage displays the event editing form
 func (h *OrganizerEventHandler) EditEventPage(w http.ResponseWriter, r *http.Request) {
 	user := middleware.GetUserFromContext(r.Context())
@@ -412,8 +583,19 @@ func (h *OrganizerEventHandler) EditEventPage(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	if h.venueService != nil {
+		if event.Venue, err = h.venueService.GetEventVenue(event.ID); err != nil {
+			logging.FromContext(r.Context()).Warn("failed to load event venue", "event_id", event.ID, "error", err)
+		}
+	}
+	if h.accessService != nil && event.IsOnline() {
+		if event.AccessLink, err = h.accessService.GetLink(event.ID); err != nil {
+			logging.FromContext(r.Context()).Warn("failed to load event access link", "event_id", event.ID, "error", err)
+		}
+	}
+
 	// Render the edit event page
-	component := pages.EditEventPage(user, event, categories, nil, nil)
+	component := pages.EditEventPage(user, event, categories, h.organizerVenues(r, user.ID), nil, nil)
 	err = component.Render(r.Context(), w)
 	if err != nil {
 		http.Error(w, "Failed to render page", http.StatusInternalServerError)
@@ -465,11 +647,12 @@ func (h *OrganizerEventHandler) UpdateEventSubmit(w http.ResponseWriter, r *http
 	// Extract form fields
 	title := r.FormValue("title")
 	description := r.FormValue("description")
-	location := r.FormValue("location")
+	location := eventFormLocation(r)
 	startDateStr := r.FormValue("start_date")
 	endDateStr := r.FormValue("end_date")
 	categoryIDStr := r.FormValue("category_id")
 	status := models.EventStatus(r.FormValue("status"))
+	imageAltText := strings.TrimSpace(r.FormValue("image_alt_text"))
 
 	// Validate required fields
 	errors := make(map[string]string)
@@ -479,6 +662,19 @@ func (h *OrganizerEventHandler) UpdateEventSubmit(w http.ResponseWriter, r *http
 	if description == "" {
 		errors["description"] = "Description is required"
 	}
+	venue, venueErr := h.eventFormVenue(r, user.ID)
+	accessLink := h.eventFormAccessLink(r)
+	if accessLink != nil {
+		venue = nil
+		location = models.OnlineEventLocation
+		if err := accessLink.Validate(); err != nil {
+			errors["access_url"] = err.Error()
+		}
+	} else if venueErr != nil {
+		errors["location"] = "Pick one of your saved venues"
+	} else if venue != nil {
+		location = venue.Location()
+	}
 	if location == "" {
 		errors["location"] = "Location is required"
 	}
@@ -530,6 +726,25 @@ func (h *OrganizerEventHandler) UpdateEventSubmit(w http.ResponseWriter, r *http
 		imageFile = fileHeader
 	}
 
+	// Accessibility issues must be fixed before the event goes live
+	var accessibilityReport *services.AccessibilityReport
+	if status == models.StatusPublished {
+		hasImage := imageFile != nil
+		if existingEvent, err := h.eventService.GetEventByID(eventID); err == nil && existingEvent.ImageURL != "" {
+			hasImage = true
+		}
+		accessibilityReport = services.CheckEventAccessibility(&services.AccessibilityCheckRequest{
+			Title:        title,
+			Description:  description,
+			Location:     location,
+			ImageAltText: imageAltText,
+			HasImage:     hasImage,
+		})
+		if accessibilityReport.HasErrors() {
+			errors["general"] = "Fix the accessibility issues below before publishing, or save the event as a draft."
+		}
+	}
+
 	// If there are validation errors, re-render the form
 	if len(errors) > 0 {
 		event, _ := h.eventService.GetEventByID(eventID)
@@ -542,9 +757,16 @@ func (h *OrganizerEventHandler) UpdateEventSubmit(w http.ResponseWriter, r *http
 			"end_date":    endDateStr,
 			"category_id": categoryIDStr,
 			"status":      status,
+			"event_type":     r.FormValue("event_type"),
+			"image_alt_text": imageAltText,
+			"accessibility":  accessibilityReport,
+			"venue_id":       r.FormValue("venue_id"),
+			"save_venue":     r.FormValue("save_venue"),
+			"access_url":     r.FormValue("access_url"),
+			"reveal_minutes_before": r.FormValue("reveal_minutes_before"),
 		}
 		
-		component := pages.EditEventPage(user, event, categories, formData, errors)
+		component := pages.EditEventPage(user, event, categories, h.organizerVenues(r, user.ID), formData, errors)
 		err = component.Render(r.Context(), w)
 		if err != nil {
 			http.Error(w, "Failed to render page", http.StatusInternalServerError)
@@ -563,6 +785,7 @@ func (h *OrganizerEventHandler) UpdateEventSubmit(w http.ResponseWriter, r *http
 		Status:      status,
 		OrganizerID: user.ID,
 		Image:       imageFile,
+		ImageAltText: imageAltText,
 	}
 
 	event, err := h.eventService.UpdateEvent(eventID, updateReq)
@@ -579,9 +802,16 @@ func (h *OrganizerEventHandler) UpdateEventSubmit(w http.ResponseWriter, r *http
 			"end_date":    endDateStr,
 			"category_id": categoryIDStr,
 			"status":      status,
+			"event_type":     r.FormValue("event_type"),
+			"image_alt_text": imageAltText,
+			"accessibility":  accessibilityReport,
+			"venue_id":       r.FormValue("venue_id"),
+			"save_venue":     r.FormValue("save_venue"),
+			"access_url":     r.FormValue("access_url"),
+			"reveal_minutes_before": r.FormValue("reveal_minutes_before"),
 		}
 		
-		component := pages.EditEventPage(user, event, categories, formData, errors)
+		component := pages.EditEventPage(user, event, categories, h.organizerVenues(r, user.ID), formData, errors)
 		err = component.Render(r.Context(), w)
 		if err != nil {
 			http.Error(w, "Failed to render page", http.StatusInternalServerError)
@@ -589,6 +819,9 @@ func (h *OrganizerEventHandler) UpdateEventSubmit(w http.ResponseWriter, r *http
 		return
 	}
 
+	h.holdEventAtVenue(r, event.ID, user.ID, venue)
+	h.saveAccessLink(r, event.ID, accessLink)
+
 	// Redirect back to the edit page with success message
 	http.Redirect(w, r, fmt.Sprintf("/organizer/events/%d/edit?success=updated", event.ID), http.StatusSeeOther)
 }
@@ -616,6 +849,12 @@ func (h *OrganizerEventHandler) UpdateEventStatus(w http.ResponseWriter, r *http
 		return
 	}
 
+	// Cancelling goes through the cancellation page, which refunds ticket holders
+	if newStatus == models.StatusCancelled {
+		http.Error(w, "Use the Cancel Event page to cancel an event and refund its ticket holders", http.StatusBadRequest)
+		return
+	}
+
 	// Update event status
 	event, err := h.eventService.UpdateEventStatus(eventID, newStatus, user.ID)
 	if err != nil {
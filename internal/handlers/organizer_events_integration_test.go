@@ -51,6 +51,11 @@ func (m *MockEventService) GetEventByID(id int) (*models.Event, error) {
 	return args.Get(0).(*models.Event), args.Error(1)
 }
 
+func (m *MockEventService) GetEventBySlug(slug string) (*models.Event, error) {
+	args := m.Called(slug)
+	return args.Get(0).(*models.Event), args.Error(1)
+}
+
 func (m *MockEventService) GetEventOrganizer(eventID int) (*models.User, error) {
 	args := m.Called(eventID)
 	return args.Get(0).(*models.User), args.Error(1)
@@ -86,6 +91,11 @@ func (m *MockEventService) CanUserDeleteEvent(eventID int, userID int) (bool, er
 	return args.Bool(0), args.Error(1)
 }
 
+func (m *MockEventService) CanUserManageEvent(eventID int, userID int) (bool, error) {
+	args := m.Called(eventID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
 func (m *MockEventService) UpdateEventStatus(eventID int, status models.EventStatus, organizerID int) (*models.Event, error) {
 	args := m.Called(eventID, status, organizerID)
 	return args.Get(0).(*models.Event), args.Error(1)
@@ -166,6 +176,16 @@ func (m *MockImageService) GetImageVariants(keyPrefix string) []string {
 	return args.Get(0).([]string)
 }
 
+func (m *MockImageService) CreateDirectUpload(ctx context.Context, eventID int, contentType string, size int64) (*services.DirectUpload, error) {
+	args := m.Called(ctx, eventID, contentType, size)
+	return args.Get(0).(*services.DirectUpload), args.Error(1)
+}
+
+func (m *MockImageService) CompleteDirectUpload(ctx context.Context, eventID int, key string, options services.ImageProcessingOptions) (*services.ImageUploadResult, error) {
+	args := m.Called(ctx, eventID, key, options)
+	return args.Get(0).(*services.ImageUploadResult), args.Error(1)
+}
+
 func TestOrganizerEventHandler_EventsListPage(t *testing.T) {
 	// Setup
 	mockEventService := new(MockEventService)
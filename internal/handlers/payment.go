@@ -1,15 +1,17 @@
 package handlers
 
 import (
+	"context"
 	"crypto/rand"
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
-	"log"
 	"net/http"
 	"strconv"
 	"time"
 
+	"event-ticketing-platform/internal/logging"
+	"event-ticketing-platform/internal/middleware"
 	"event-ticketing-platform/internal/models"
 	"event-ticketing-platform/internal/services"
 	"event-ticketing-platform/web/templates/pages"
@@ -24,6 +26,11 @@ type PaymentHandler struct {
 	orderService   services.OrderServiceInterface
 	ticketService  services.TicketServiceInterface
 	store          sessions.Store
+	answers        services.CheckoutAnswerRecorder
+	attributions   services.OrderAttributionRecorder
+	idempotency    middleware.IdempotencyStore
+	installments   *services.InstallmentService
+	amendments     *services.OrderAmendmentService
 }
 
 // NewPaymentHandler creates a new payment handler
@@ -36,6 +43,46 @@ func NewPaymentHandler(paymentService services.PaymentService, orderService serv
 	}
 }
 
+// attendeeOrderCompleter is implemented by order services that can name an
+// order's tickets as they complete it
+type attendeeOrderCompleter interface {
+	CompleteOrderWithAttendees(orderID int, paymentID string, ticketData []struct {
+		TicketTypeID int
+		QRCode       string
+	}, attendees []models.TicketAttendee) error
+}
+
+// SetCheckoutAnswers stores the answers attendees gave to the event's
+// checkout questions once their payment completes
+func (h *PaymentHandler) SetCheckoutAnswers(answers services.CheckoutAnswerRecorder) {
+	h.answers = answers
+}
+
+// SetAttributionRecorder stores where each buyer came from with their order,
+// for sales by source
+func (h *PaymentHandler) SetAttributionRecorder(attributions services.OrderAttributionRecorder) {
+	h.attributions = attributions
+}
+
+// SetIdempotencyStore makes payment callbacks safe to replay: each payment
+// completes its order once, and later callbacks for it are redirected to
+// where the first went
+func (h *PaymentHandler) SetIdempotencyStore(store middleware.IdempotencyStore) {
+	h.idempotency = store
+}
+
+// SetInstallmentService starts the payment schedule of orders whose buyer
+// chose to pay in installments
+func (h *PaymentHandler) SetInstallmentService(installments *services.InstallmentService) {
+	h.installments = installments
+}
+
+// SetOrderAmendments applies the order changes whose difference a
+// successful payment was for, as buyers are redirected back from paying
+func (h *PaymentHandler) SetOrderAmendments(amendments *services.OrderAmendmentService) {
+	h.amendments = amendments
+}
+
 // PaymentCallback handles payment callback from Pesapal
 func (h *PaymentHandler) PaymentCallback(w http.ResponseWriter, r *http.Request) {
 	// Get query parameters
@@ -43,51 +90,104 @@ func (h *PaymentHandler) PaymentCallback(w http.ResponseWriter, r *http.Request)
 	merchantReference := r.URL.Query().Get("OrderMerchantReference")
 
 	if orderTrackingID == "" {
-		log.Printf("Payment callback: missing OrderTrackingId")
+		logging.FromContext(r.Context()).Warn("payment callback without an order tracking ID")
 		http.Error(w, "Missing order tracking ID", http.StatusBadRequest)
 		return
 	}
 
-	log.Printf("Payment callback received: OrderTrackingId=%s, MerchantReference=%s", orderTrackingID, merchantReference)
+	ctx := logging.WithAttrs(r.Context(), "payment_id", orderTrackingID, "merchant_reference", merchantReference)
+	logger := logging.FromContext(ctx)
+	logger.Info("payment callback received")
 
 	// Get payment status from Pesapal
 	paymentStatus, err := h.paymentService.GetPaymentStatus(orderTrackingID)
 	if err != nil {
-		log.Printf("Payment callback: failed to get payment status: %v", err)
+		logger.Error("failed to get payment status", "error", err)
 		http.Error(w, "Failed to verify payment status", http.StatusInternalServerError)
 		return
 	}
 
-	log.Printf("Payment status: %s for OrderTrackingId=%s", paymentStatus.Status, orderTrackingID)
+	logger.Info("payment status checked", "status", paymentStatus.Status)
+
+	// Payments of the difference of an order change apply the change rather
+	// than completing a checkout
+	if paymentStatus.Status == "success" && h.amendments != nil {
+		amendment, err := h.amendments.CompletePaidAmendment(orderTrackingID)
+		if err != nil {
+			logger.Error("failed to complete order change", "error", err)
+		}
+		if amendment != nil {
+			redirectURL := fmt.Sprintf("/dashboard/orders/%d/change", amendment.OrderID)
+			if !amendment.IsPending() {
+				redirectURL += "?changed=1"
+			}
+			http.Redirect(w, r, redirectURL, http.StatusSeeOther)
+			return
+		}
+	}
 
 	// For successful payments, complete the order creation process
 	if paymentStatus.Status == "success" {
 		// Get session to retrieve pending order info
 		session, err := h.store.Get(r, "session")
 		if err != nil {
-			log.Printf("Payment callback: failed to get session: %v", err)
+			logger.Error("failed to get session for payment callback", "error", err)
 			http.Redirect(w, r, "/payment/success?payment_id="+orderTrackingID, http.StatusSeeOther)
 			return
 		}
 
 		// Check if we have pending payment info
 		if pendingPaymentID, ok := session.Values["pending_payment_id"].(string); ok && pendingPaymentID == orderTrackingID {
+			successURL := "/payment/success?payment_id=" + orderTrackingID
+
+			// The payment reference is the key, so a replayed callback can't
+			// complete the order again
+			if h.idempotency != nil {
+				record, claimed, err := h.idempotency.Begin(models.IdempotencyScopePaymentCallback, 0, orderTrackingID)
+				if err != nil {
+					logger.Error("failed to claim payment callback", "error", err)
+					http.Redirect(w, r, "/payment/pending?payment_id="+orderTrackingID, http.StatusSeeOther)
+					return
+				}
+				if !claimed {
+					logger.Info("payment callback replayed", "completed", record.IsCompleted())
+					if record.IsCompleted() {
+						http.Redirect(w, r, record.ResponseLocation, http.StatusSeeOther)
+					} else {
+						http.Redirect(w, r, "/payment/pending?payment_id="+orderTrackingID, http.StatusSeeOther)
+					}
+					return
+				}
+			}
+
 			// We have matching pending payment, complete the order
-			if err := h.completePendingOrder(session, orderTrackingID, paymentStatus); err != nil {
-				log.Printf("Payment callback: failed to complete pending order: %v", err)
+			if err := h.completePendingOrder(ctx, session, orderTrackingID, paymentStatus); err != nil {
+				logger.Error("failed to complete pending order", "error", err)
+				h.releaseCallback(ctx, orderTrackingID)
 				http.Redirect(w, r, "/payment/failed?payment_id="+orderTrackingID, http.StatusSeeOther)
 				return
 			}
 
+			if h.idempotency != nil {
+				if err := h.idempotency.Complete(models.IdempotencyScopePaymentCallback, 0, orderTrackingID, http.StatusSeeOther, successURL); err != nil {
+					logger.Warn("failed to record payment callback result", "error", err)
+				}
+			}
+
 			// Clear pending payment info from session
 			delete(session.Values, "pending_payment_id")
 			delete(session.Values, "pending_cart")
 			delete(session.Values, "pending_billing_email")
 			delete(session.Values, "pending_billing_name")
+			delete(session.Values, "pending_locale")
+			delete(session.Values, "pending_arrival_slot")
+			delete(session.Values, "pending_checkout_answers")
+			delete(session.Values, "pending_attendees")
+			delete(session.Values, "pending_installment_plan")
 			session.Save(r, w)
 
 			// Redirect to success page
-			http.Redirect(w, r, "/payment/success?payment_id="+orderTrackingID, http.StatusSeeOther)
+			http.Redirect(w, r, successURL, http.StatusSeeOther)
 			return
 		}
 	}
@@ -109,23 +209,34 @@ func (h *PaymentHandler) PaymentCallback(w http.ResponseWriter, r *http.Request)
 	http.Redirect(w, r, redirectURL, http.StatusSeeOther)
 }
 
+// releaseCallback lets a payment's callback be retried after completing its
+// order failed
+func (h *PaymentHandler) releaseCallback(ctx context.Context, paymentID string) {
+	if h.idempotency == nil {
+		return
+	}
+	if err := h.idempotency.Release(models.IdempotencyScopePaymentCallback, 0, paymentID); err != nil {
+		logging.FromContext(ctx).Warn("failed to release payment callback", "error", err)
+	}
+}
+
 // PaymentIPN handles Instant Payment Notifications from Pesapal
 func (h *PaymentHandler) PaymentIPN(w http.ResponseWriter, r *http.Request) {
 	// Parse IPN data
 	var ipnData services.PesapalIPN
 	if err := json.NewDecoder(r.Body).Decode(&ipnData); err != nil {
-		log.Printf("Payment IPN: failed to decode IPN data: %v", err)
+		logging.FromContext(r.Context()).Warn("failed to decode payment IPN", "error", err)
 		http.Error(w, "Invalid IPN data", http.StatusBadRequest)
 		return
 	}
 
-	log.Printf("Payment IPN received: OrderTrackingId=%s, MerchantReference=%s",
-		ipnData.OrderTrackingID, ipnData.OrderMerchantReference)
+	logger := logging.FromContext(r.Context()).With("payment_id", ipnData.OrderTrackingID, "merchant_reference", ipnData.OrderMerchantReference)
+	logger.Info("payment IPN received")
 
 	// Handle IPN through payment service
 	if pesapalService, ok := h.paymentService.(*services.PesapalPaymentService); ok {
 		if err := pesapalService.HandleIPN(ipnData); err != nil {
-			log.Printf("Payment IPN: failed to handle IPN: %v", err)
+			logger.Error("failed to handle payment IPN", "error", err)
 			http.Error(w, "Failed to process IPN", http.StatusInternalServerError)
 			return
 		}
@@ -134,7 +245,7 @@ func (h *PaymentHandler) PaymentIPN(w http.ResponseWriter, r *http.Request) {
 	// Get updated payment status
 	paymentStatus, err := h.paymentService.GetPaymentStatus(ipnData.OrderTrackingID)
 	if err != nil {
-		log.Printf("Payment IPN: failed to get payment status: %v", err)
+		logger.Error("failed to get payment status", "error", err)
 		http.Error(w, "Failed to get payment status", http.StatusInternalServerError)
 		return
 	}
@@ -142,8 +253,7 @@ func (h *PaymentHandler) PaymentIPN(w http.ResponseWriter, r *http.Request) {
 	// TODO: Find and update the corresponding order
 	// This requires implementing order lookup by payment ID
 
-	log.Printf("Payment IPN processed successfully for OrderTrackingId=%s, Status=%s",
-		ipnData.OrderTrackingID, paymentStatus.Status)
+	logger.Info("payment IPN processed", "status", paymentStatus.Status)
 
 	// Respond with success
 	w.WriteHeader(http.StatusOK)
@@ -160,7 +270,7 @@ func (h *PaymentHandler) PaymentStatus(w http.ResponseWriter, r *http.Request) {
 		var err error
 		paymentStatus, err = h.paymentService.GetPaymentStatus(paymentID)
 		if err != nil {
-			log.Printf("Failed to get payment status: %v", err)
+			logging.FromContext(r.Context()).Warn("failed to get payment status", "payment_id", paymentID, "error", err)
 		}
 	}
 
@@ -198,8 +308,7 @@ func (h *PaymentHandler) PaymentRedirect(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
-	fmt.Printf("🔄 Payment Redirect Debug:\n")
-	fmt.Printf("   Payment ID: %s\n", paymentID)
+	logger := logging.FromContext(r.Context()).With("payment_id", paymentID)
 
 	// Get session to retrieve payment details
 	session, err := h.store.Get(r, "session")
@@ -211,20 +320,17 @@ func (h *PaymentHandler) PaymentRedirect(w http.ResponseWriter, r *http.Request)
 	// Check if we have pending payment info
 	pendingPaymentID, ok := session.Values["pending_payment_id"].(string)
 	if !ok {
-		fmt.Printf("   ❌ No pending payment ID in session\n")
-		fmt.Printf("   Session values: %v\n", session.Values)
+		logger.Warn("payment redirect without a pending payment")
 		http.Error(w, "No pending payment found in session", http.StatusBadRequest)
 		return
 	}
 
 	if pendingPaymentID != paymentID {
-		fmt.Printf("   ❌ Payment ID mismatch: expected %s, got %s\n", pendingPaymentID, paymentID)
+		logger.Warn("payment redirect for a different payment", "pending_payment_id", pendingPaymentID)
 		http.Error(w, "Payment ID mismatch", http.StatusBadRequest)
 		return
 	}
 
-	fmt.Printf("   ✅ Payment ID matches: %s\n", paymentID)
-
 	// For Paystack, we need to re-initialize the transaction to get the authorization URL
 	// This is because our current PaymentResult doesn't include the URL
 	// In a production system, we'd store this URL properly
@@ -240,33 +346,25 @@ func (h *PaymentHandler) PaymentRedirect(w http.ResponseWriter, r *http.Request)
 	// Get the cart information from session to get the correct amount
 	pendingCart, ok := session.Values["pending_cart"].(*models.Cart)
 	if !ok {
-		fmt.Printf("   ❌ No pending cart found in session\n")
+		logger.Warn("payment redirect without a pending cart")
 		http.Error(w, "No cart information found", http.StatusBadRequest)
 		return
 	}
 
-	// Calculate total amount from cart
-	totalAmount := 0
-	for _, item := range pendingCart.Items {
-		totalAmount += item.Price * item.Quantity
+	// The cart total includes any donation
+	totalAmount := pendingCart.TotalAmount
+	if offer := pendingInstallmentOffer(session); offer != nil {
+		totalAmount = offer.Amounts[0]
 	}
 
-	fmt.Printf("   💰 Cart total amount: %d\n", totalAmount)
-
-	// Debug: Check payment service type
-	fmt.Printf("   🔍 Payment service type: %T\n", h.paymentService)
-
 	// Check if we have the authorization URL stored in session
 	if authURL, ok := session.Values["pending_authorization_url"].(string); ok && authURL != "" {
-		fmt.Printf("   ✅ Found stored authorization URL: %s\n", authURL)
 		http.Redirect(w, r, authURL, http.StatusSeeOther)
 		return
 	}
 
 	// Fallback: Check if payment service is Paystack and try to re-initialize
-	fmt.Printf("   🔍 No stored authorization URL, checking if payment service is Paystack...\n")
 	if paystackService, ok := h.paymentService.(*services.PaystackService); ok {
-		fmt.Printf("   ✅ Payment service is Paystack, attempting fallback re-initialization\n")
 		// Generate a new reference to avoid duplicate reference error
 		newReference := fmt.Sprintf("%s-retry-%d", paymentID, time.Now().Unix())
 
@@ -284,14 +382,11 @@ func (h *PaymentHandler) PaymentRedirect(w http.ResponseWriter, r *http.Request)
 			},
 		}
 
-		fmt.Printf("   🔄 Re-initializing with new reference: %s\n", newReference)
-		fmt.Printf("   📝 Request: Email=%s, Amount=%d, Currency=%s, Reference=%s\n",
-			req.Email, req.Amount, req.Currency, req.Reference)
+		logger.Info("re-initializing payment", "reference", newReference, "amount", totalAmount)
 
 		resp, err := paystackService.InitializeTransaction(req)
 		if err != nil {
-			fmt.Printf("   ❌ Failed to get authorization URL: %v\n", err)
-			fmt.Printf("   📝 Request details: %+v\n", req)
+			logger.Error("failed to re-initialize payment", "reference", newReference, "error", err)
 			http.Error(w, fmt.Sprintf("Failed to initialize payment: %v", err), http.StatusInternalServerError)
 			return
 		}
@@ -301,15 +396,12 @@ func (h *PaymentHandler) PaymentRedirect(w http.ResponseWriter, r *http.Request)
 		session.Values["pending_authorization_url"] = resp.Data.AuthorizationURL
 		session.Save(r, w)
 
-		fmt.Printf("   ✅ Redirecting to Paystack with new reference: %s\n", resp.Data.AuthorizationURL)
 		http.Redirect(w, r, resp.Data.AuthorizationURL, http.StatusSeeOther)
 		return
-	} else {
-		fmt.Printf("   ❌ Payment service is not Paystack: %T\n", h.paymentService)
 	}
 
 	// Fallback error
-	fmt.Printf("   ❌ Reached fallback error\n")
+	logger.Error("payment redirect without an authorization URL", "payment_service", fmt.Sprintf("%T", h.paymentService))
 	http.Error(w, "Payment service not available", http.StatusInternalServerError)
 }
 
@@ -345,7 +437,7 @@ func (h *PaymentHandler) InitiatePayment(w http.ResponseWriter, r *http.Request)
 	// Process payment
 	result, err := h.paymentService.ProcessPayment(amount, "pesapal", billingInfo)
 	if err != nil {
-		log.Printf("Payment initiation failed: %v", err)
+		logging.FromContext(r.Context()).Error("payment initiation failed", "error", err)
 		http.Error(w, fmt.Sprintf("Payment failed: %v", err), http.StatusInternalServerError)
 		return
 	}
@@ -363,7 +455,9 @@ func (h *PaymentHandler) InitiatePayment(w http.ResponseWriter, r *http.Request)
 }
 
 // completePendingOrder completes a pending order after successful payment
-func (h *PaymentHandler) completePendingOrder(session *sessions.Session, paymentID string, paymentStatus *services.PaymentStatus) error {
+func (h *PaymentHandler) completePendingOrder(ctx context.Context, session *sessions.Session, paymentID string, paymentStatus *services.PaymentStatus) error {
+	logger := logging.FromContext(ctx)
+
 	// Get pending order info from session
 	pendingCart, ok := session.Values["pending_cart"].(*models.Cart)
 	if !ok {
@@ -380,6 +474,31 @@ func (h *PaymentHandler) completePendingOrder(session *sessions.Session, payment
 		return fmt.Errorf("no pending billing name found in session")
 	}
 
+	// The email language is optional; sessions from before it was captured have none
+	locale, _ := session.Values["pending_locale"].(string)
+
+	// Only events with arrival slots store one
+	var arrivalSlotID *int
+	if id, ok := session.Values["pending_arrival_slot"].(int); ok {
+		arrivalSlotID = &id
+	}
+
+	// Only events with checkout questions store answers
+	var answers []models.AttendeeAnswers
+	if answersJSON, ok := session.Values["pending_checkout_answers"].(string); ok {
+		if err := json.Unmarshal([]byte(answersJSON), &answers); err != nil {
+			logger.Warn("failed to read checkout answers", "error", err)
+		}
+	}
+
+	// Only orders whose buyer named their tickets store attendees
+	var attendees []models.TicketAttendee
+	if attendeesJSON, ok := session.Values["pending_attendees"].(string); ok {
+		if err := json.Unmarshal([]byte(attendeesJSON), &attendees); err != nil {
+			logger.Warn("failed to read attendees", "error", err)
+		}
+	}
+
 	// Get user ID from session
 	userID, ok := session.Values["user_id"].(int)
 	if !ok {
@@ -401,16 +520,24 @@ func (h *PaymentHandler) completePendingOrder(session *sessions.Session, payment
 		}
 	}
 
-	log.Printf("Completing pending order for payment %s, cart with %d items, user %d", paymentID, len(pendingCart.Items), userID)
+	logger = logger.With("user_id", userID, "event_id", pendingCart.EventID)
+	logger.Info("completing pending order", "items", len(pendingCart.Items))
 
-	// Create order in database
+	// Create order in database with the payment reference, so reconciliation
+	// can match the payment to it even if completing it fails
 	orderReq := &models.OrderCreateRequest{
-		UserID:       userID,
-		EventID:      pendingCart.EventID,
-		TotalAmount:  pendingCart.TotalAmount,
-		BillingEmail: billingEmail,
-		BillingName:  billingName,
-		Status:       models.OrderPending,
+		UserID:         userID,
+		EventID:        pendingCart.EventID,
+		TotalAmount:    pendingCart.TotalAmount,
+		BillingEmail:   billingEmail,
+		BillingName:    billingName,
+		Locale:         locale,
+		ArrivalSlotID:  arrivalSlotID,
+		Status:         models.OrderPending,
+		PaymentID:      paymentID,
+		DonationAmount: pendingCart.Donation,
+		Tax:            pendingCart.Tax,
+		ItemPrices:     pendingCart.ItemPrices(),
 	}
 
 	order, err := h.orderService.CreateOrder(orderReq)
@@ -418,7 +545,30 @@ func (h *PaymentHandler) completePendingOrder(session *sessions.Session, payment
 		return fmt.Errorf("failed to create order: %w", err)
 	}
 
-	log.Printf("Created order %s (ID: %d) for user %d", order.OrderNumber, order.ID, userID)
+	logger = logger.With("order_id", order.ID, "order_number", order.OrderNumber)
+	logger.Info("order created")
+
+	// Orders paid in installments have had their first installment paid.
+	// The card it was paid with is saved before any tickets are issued, so
+	// the rest can be charged.
+	if offer := pendingInstallmentOffer(session); offer != nil && h.installments != nil {
+		var items []models.InstallmentItem
+		for _, item := range pendingCart.Items {
+			items = append(items, models.InstallmentItem{TicketTypeID: item.TicketTypeID, Quantity: item.Quantity})
+		}
+		plan, err := h.installments.StartPlan(order, offer, paymentID, items, attendees)
+		if err != nil {
+			return fmt.Errorf("failed to start installment plan: %w", err)
+		}
+		logger.Info("installment plan started", "plan_id", plan.ID, "installments", len(plan.Installments), "upfront", plan.IssueTicketsUpfront)
+
+		// Tickets issued once paid in full have been taken out of inventory
+		// for the buyer, and are issued when the last installment is paid
+		if !plan.IssueTicketsUpfront {
+			h.recordCheckoutExtras(ctx, session, order.ID, answers)
+			return nil
+		}
+	}
 
 	// Generate ticket data for order completion
 	var ticketData []struct {
@@ -445,17 +595,56 @@ func (h *PaymentHandler) completePendingOrder(session *sessions.Session, payment
 	}
 
 	// Use order service to complete the order (creates tickets and updates status)
-	err = h.orderService.CompleteOrder(order.ID, paymentID, ticketData)
+	if completer, ok := h.orderService.(attendeeOrderCompleter); ok {
+		err = completer.CompleteOrderWithAttendees(order.ID, paymentID, ticketData, attendees)
+	} else {
+		err = h.orderService.CompleteOrder(order.ID, paymentID, ticketData)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to complete order: %w", err)
 	}
 
-	log.Printf("Order completed successfully: %s, amount: KES %.2f, tickets created: %d",
-		order.OrderNumber, float64(paymentStatus.Amount)/100, len(ticketData))
+	logger.Info("order completed", "amount", float64(paymentStatus.Amount)/100, "tickets", len(ticketData))
 
+	h.recordCheckoutExtras(ctx, session, order.ID, answers)
 	return nil
 }
 
+// recordCheckoutExtras stores the attendees' checkout answers and where the
+// buyer came from with their order, neither of which fails it
+func (h *PaymentHandler) recordCheckoutExtras(ctx context.Context, session *sessions.Session, orderID int, answers []models.AttendeeAnswers) {
+	logger := logging.FromContext(ctx)
+
+	if h.answers != nil {
+		if err := h.answers.RecordAnswers(orderID, answers); err != nil {
+			logger.Warn("failed to record checkout answers", "error", err)
+		}
+	}
+
+	// Sessions from before attribution was captured have none
+	if h.attributions != nil {
+		if attribution := middleware.AttributionFromSession(session); attribution != nil {
+			if err := h.attributions.RecordOrderAttribution(orderID, attribution); err != nil {
+				logger.Warn("failed to record attribution", "error", err)
+			}
+		}
+	}
+}
+
+// pendingInstallmentOffer returns the installment plan the buyer chose at
+// checkout, or nil if they are paying in full
+func pendingInstallmentOffer(session *sessions.Session) *models.InstallmentOffer {
+	offerJSON, ok := session.Values["pending_installment_plan"].(string)
+	if !ok {
+		return nil
+	}
+	var offer models.InstallmentOffer
+	if err := json.Unmarshal([]byte(offerJSON), &offer); err != nil || offer.Count() == 0 {
+		return nil
+	}
+	return &offer
+}
+
 // Helper function to map payment status to order status
 func mapPaymentStatusToOrderStatus(paymentStatus string) string {
 	switch paymentStatus {
@@ -0,0 +1,100 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// PaymentReconciliationHandler handles the admin payment reconciliation dashboard
+type PaymentReconciliationHandler struct {
+	reconciliationService *services.PaymentReconciliationService
+}
+
+// NewPaymentReconciliationHandler creates a new payment reconciliation handler
+func NewPaymentReconciliationHandler(reconciliationService *services.PaymentReconciliationService) *PaymentReconciliationHandler {
+	return &PaymentReconciliationHandler{
+		reconciliationService: reconciliationService,
+	}
+}
+
+// Dashboard handles GET /admin/payments/reconciliation
+func (h *PaymentReconciliationHandler) Dashboard(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login?redirect=/admin/payments/reconciliation", http.StatusSeeOther)
+		return
+	}
+
+	if user.Role != models.UserRoleAdmin {
+		http.Error(w, "Forbidden - Admin access required", http.StatusForbidden)
+		return
+	}
+
+	open, err := h.reconciliationService.OpenIssues()
+	if err != nil {
+		http.Error(w, "Failed to load reconciliation issues", http.StatusInternalServerError)
+		return
+	}
+
+	resolved, err := h.reconciliationService.ResolvedIssues()
+	if err != nil {
+		http.Error(w, "Failed to load reconciliation issues", http.StatusInternalServerError)
+		return
+	}
+
+	component := pages.AdminPaymentReconciliation(user, h.reconciliationService.LastRun(), open, resolved)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// Run handles POST /admin/payments/reconciliation/run
+func (h *PaymentReconciliationHandler) Run(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil || user.Role != models.UserRoleAdmin {
+		http.Error(w, "Forbidden - Admin access required", http.StatusForbidden)
+		return
+	}
+
+	h.reconciliationService.Run(r.Context())
+
+	http.Redirect(w, r, "/admin/payments/reconciliation", http.StatusSeeOther)
+}
+
+// Resolve handles POST /admin/payments/reconciliation/{id}/resolve
+func (h *PaymentReconciliationHandler) Resolve(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil || user.Role != models.UserRoleAdmin {
+		http.Error(w, "Forbidden - Admin access required", http.StatusForbidden)
+		return
+	}
+
+	issueID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid issue ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	resolution := models.ReconciliationResolution(r.FormValue("resolution"))
+	note := strings.TrimSpace(r.FormValue("note"))
+	if _, err := h.reconciliationService.Resolve(issueID, resolution, user.ID, note, r); err != nil {
+		http.Error(w, "Failed to resolve issue: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/payments/reconciliation", http.StatusSeeOther)
+}
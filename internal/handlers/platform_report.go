@@ -0,0 +1,90 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+)
+
+// PlatformReportHandler handles the admin platform-wide reports
+type PlatformReportHandler struct {
+	analyticsService *services.AnalyticsService
+}
+
+// NewPlatformReportHandler creates a new platform report handler
+func NewPlatformReportHandler(analyticsService *services.AnalyticsService) *PlatformReportHandler {
+	return &PlatformReportHandler{analyticsService: analyticsService}
+}
+
+// Reports handles GET /admin/reports. The range comes from the range query
+// parameter, with from and to dates for custom ranges. An invalid range falls
+// back to the default one and is reported on the page.
+func (h *PlatformReportHandler) Reports(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login?redirect=/admin/reports", http.StatusSeeOther)
+		return
+	}
+
+	if user.Role != models.UserRoleAdmin {
+		http.Error(w, "Forbidden - Admin access required", http.StatusForbidden)
+		return
+	}
+
+	query := r.URL.Query()
+	var rangeError string
+	reportRange, err := services.ParseReportRange(query.Get("range"), query.Get("from"), query.Get("to"), time.Now())
+	if err != nil {
+		rangeError = err.Error()
+		reportRange, _ = services.ParseReportRange("", "", "", time.Now())
+	}
+
+	report, err := h.analyticsService.GetPlatformReport(reportRange)
+	if err != nil {
+		http.Error(w, "Failed to load platform report", http.StatusInternalServerError)
+		return
+	}
+
+	component := pages.AdminReports(user, report, rangeError)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// ExportReports handles GET /admin/reports/export, downloading the report
+// for the same range query parameters as Reports as CSV
+func (h *PlatformReportHandler) ExportReports(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil || user.Role != models.UserRoleAdmin {
+		http.Error(w, "Forbidden - Admin access required", http.StatusForbidden)
+		return
+	}
+
+	query := r.URL.Query()
+	reportRange, err := services.ParseReportRange(query.Get("range"), query.Get("from"), query.Get("to"), time.Now())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	report, err := h.analyticsService.GetPlatformReport(reportRange)
+	if err != nil {
+		http.Error(w, "Failed to load platform report", http.StatusInternalServerError)
+		return
+	}
+
+	filename := fmt.Sprintf("platform_report_%s_%s.csv", reportRange.From.Format("2006-01-02"), reportRange.LastDay().Format("2006-01-02"))
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"%s\"", filename))
+
+	if err := services.ExportPlatformReport(w, report); err != nil {
+		http.Error(w, "Failed to export platform report", http.StatusInternalServerError)
+		return
+	}
+}
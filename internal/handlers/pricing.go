@@ -0,0 +1,110 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+)
+
+// Fee calculator defaults, shown until an organizer enters their own
+const (
+	defaultCalculatorPrice    = "1000"
+	defaultCalculatorQuantity = "100"
+)
+
+// PricingHandler handles the public pricing page, built from the fee
+// schedule in the system settings
+type PricingHandler struct {
+	settingsService *services.SettingsService
+}
+
+// NewPricingHandler creates a new pricing handler
+func NewPricingHandler(settingsService *services.SettingsService) *PricingHandler {
+	return &PricingHandler{
+		settingsService: settingsService,
+	}
+}
+
+// PricingPage handles GET /pricing. Without JavaScript the fee calculator
+// submits its price and quantity parameters here.
+func (h *PricingHandler) PricingPage(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+
+	schedule, ok := h.feeSchedule(w)
+	if !ok {
+		return
+	}
+
+	formData := calculatorForm(r)
+	if formData["price"] == "" && formData["quantity"] == "" {
+		formData["price"] = defaultCalculatorPrice
+		formData["quantity"] = defaultCalculatorQuantity
+	}
+	breakdown, errorMsg := calculateFees(schedule, formData)
+
+	component := pages.PricingPage(user, schedule, formData, breakdown, errorMsg)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// Calculator handles GET /pricing/calculator, rendering only the fee
+// calculator's result for HTMX to swap in as the organizer types
+func (h *PricingHandler) Calculator(w http.ResponseWriter, r *http.Request) {
+	schedule, ok := h.feeSchedule(w)
+	if !ok {
+		return
+	}
+
+	breakdown, errorMsg := calculateFees(schedule, calculatorForm(r))
+
+	component := pages.FeeCalculatorResult(breakdown, errorMsg)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// feeSchedule loads the current fee schedule, writing an error response if
+// it can't be
+func (h *PricingHandler) feeSchedule(w http.ResponseWriter) (*models.FeeSchedule, bool) {
+	settings, err := h.settingsService.GetSettings()
+	if err != nil {
+		http.Error(w, "Failed to load pricing", http.StatusInternalServerError)
+		return nil, false
+	}
+	return settings.FeeSchedule(), true
+}
+
+// calculatorForm reads the fee calculator's parameters
+func calculatorForm(r *http.Request) map[string]string {
+	return map[string]string{
+		"price":    strings.TrimSpace(r.URL.Query().Get("price")),
+		"quantity": strings.TrimSpace(r.URL.Query().Get("quantity")),
+	}
+}
+
+// calculateFees runs the fee calculator on its form, returning the error to
+// show instead if the form is invalid
+func calculateFees(schedule *models.FeeSchedule, formData map[string]string) (*models.FeeBreakdown, string) {
+	price, err := strconv.ParseFloat(strings.ReplaceAll(formData["price"], ",", ""), 64)
+	if err != nil {
+		return nil, "Enter a ticket price"
+	}
+	quantity, err := strconv.Atoi(strings.ReplaceAll(formData["quantity"], ",", ""))
+	if err != nil {
+		return nil, "Enter how many tickets you expect to sell"
+	}
+
+	breakdown, err := schedule.Calculate(price, quantity)
+	if err != nil {
+		return nil, err.Error()
+	}
+	return breakdown, ""
+}
@@ -0,0 +1,105 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/google/uuid"
+)
+
+// Choices the consent banner submits instead of individual toggles
+const (
+	privacyChoiceAcceptAll = "accept_all"
+	privacyChoiceRejectAll = "reject_all"
+)
+
+// PrivacyHandler handles the consent banner and the privacy preferences page
+type PrivacyHandler struct {
+	privacyService *services.PrivacyService
+}
+
+// NewPrivacyHandler creates a new privacy handler
+func NewPrivacyHandler(privacyService *services.PrivacyService) *PrivacyHandler {
+	return &PrivacyHandler{
+		privacyService: privacyService,
+	}
+}
+
+// PreferencesPage handles GET /privacy-preferences
+func (h *PrivacyHandler) PreferencesPage(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	prefs := middleware.GetPrivacyPreferences(r.Context())
+
+	component := pages.PrivacyPreferencesPage(user, prefs, r.URL.Query().Get("saved") == "1")
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// UpdatePreferences handles POST /privacy-preferences, from the page's
+// toggles or the consent banner's accept and reject buttons. The banner
+// returns visitors to the page they were on.
+func (h *PrivacyHandler) UpdatePreferences(w http.ResponseWriter, r *http.Request) {
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	// Unchecked checkboxes are not submitted
+	analytics := r.FormValue("analytics") == "on"
+	marketing := r.FormValue("marketing") == "on"
+	choice := r.FormValue("choice")
+	switch choice {
+	case privacyChoiceAcceptAll:
+		analytics, marketing = true, true
+	case privacyChoiceRejectAll:
+		analytics, marketing = false, false
+	}
+
+	consentID := middleware.PrivacyConsentID(r)
+	if consentID == "" {
+		consentID = uuid.NewString()
+	}
+
+	userID := 0
+	if user := middleware.GetUserFromContext(r.Context()); user != nil {
+		userID = user.ID
+	}
+
+	if _, err := h.privacyService.Save(userID, consentID, analytics, marketing); err != nil {
+		http.Error(w, "Failed to save privacy preferences", http.StatusInternalServerError)
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     middleware.PrivacyConsentCookie,
+		Value:    consentID,
+		Path:     "/",
+		MaxAge:   int((365 * 24 * time.Hour).Seconds()),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	if choice != "" {
+		http.Redirect(w, r, bannerReturnPath(r), http.StatusSeeOther)
+		return
+	}
+	http.Redirect(w, r, "/privacy-preferences?saved=1", http.StatusSeeOther)
+}
+
+// bannerReturnPath returns the path of the page a banner was submitted
+// from. Only the path is kept, so the redirect never leaves the site.
+func bannerReturnPath(r *http.Request) string {
+	referrer, err := url.Parse(r.Referer())
+	if err != nil || !strings.HasPrefix(referrer.Path, "/") || strings.HasPrefix(referrer.Path, "//") {
+		return "/"
+	}
+	return (&url.URL{Path: referrer.Path, RawQuery: referrer.RawQuery}).RequestURI()
+}
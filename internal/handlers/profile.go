@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"log/slog"
 	"net/http"
 	"strings"
 
@@ -16,6 +17,10 @@ type ProfileHandler struct {
 	authService services.AuthServiceInterface
 	userService services.UserServiceInterface
 	store       sessions.Store
+
+	twoFactorService *services.TwoFactorService
+	localeService    *services.LocaleService
+	sessionStore     *services.SessionStore
 }
 
 // NewProfileHandler creates a new profile handler
@@ -27,6 +32,35 @@ func NewProfileHandler(authService services.AuthServiceInterface, userService se
 	}
 }
 
+// SetTwoFactorService enables two-factor authentication management on the security page
+func (h *ProfileHandler) SetTwoFactorService(twoFactorService *services.TwoFactorService) {
+	h.twoFactorService = twoFactorService
+}
+
+// SetSessionStore lists the user's signed-in devices on the security page and
+// lets them log the others out
+func (h *ProfileHandler) SetSessionStore(sessionStore *services.SessionStore) {
+	h.sessionStore = sessionStore
+}
+
+// SetLocaleService lets users choose the language of their emails on the settings page
+func (h *ProfileHandler) SetLocaleService(localeService *services.LocaleService) {
+	h.localeService = localeService
+}
+
+// userLocale returns the user's preferred email language, empty if they have none
+func (h *ProfileHandler) userLocale(userID int) string {
+	if h.localeService == nil {
+		return ""
+	}
+	locale, err := h.localeService.GetUserLocale(userID)
+	if err != nil {
+		slog.Warn("failed to load user locale", "user_id", userID, "error", err)
+		return ""
+	}
+	return locale
+}
+
 // ProfilePage renders the profile editing page
 func (h *ProfileHandler) ProfilePage(w http.ResponseWriter, r *http.Request) {
 	user := middleware.GetUserFromContext(r.Context())
@@ -139,7 +173,7 @@ func (h *ProfileHandler) SecurityPage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Render security page
-	component := pages.SecurityPage(user, make(map[string][]string), make(map[string]string), false)
+	component := pages.SecurityPage(user, make(map[string][]string), make(map[string]string), false, h.twoFactorView(user), h.sessionsView(r, user))
 	err := component.Render(r.Context(), w)
 	if err != nil {
 		http.Error(w, "Failed to render security page", http.StatusInternalServerError)
@@ -185,7 +219,7 @@ func (h *ProfileHandler) ChangePassword(w http.ResponseWriter, r *http.Request)
 	}
 
 	if len(errors) > 0 {
-		component := pages.SecurityPage(user, errors, formData, false)
+		component := pages.SecurityPage(user, errors, formData, false, h.twoFactorView(user), h.sessionsView(r, user))
 		w.WriteHeader(http.StatusUnprocessableEntity)
 		err := component.Render(r.Context(), w)
 		if err != nil {
@@ -199,6 +233,10 @@ func (h *ProfileHandler) ChangePassword(w http.ResponseWriter, r *http.Request)
 		OldPassword: currentPassword,
 		NewPassword: newPassword,
 	}
+	if session, err := h.store.Get(r, "session"); err == nil {
+		// Stay signed in here; other devices are logged out
+		changeReq.KeepSession = session
+	}
 
 	err := h.authService.ChangePassword(user.ID, changeReq)
 	if err != nil {
@@ -208,7 +246,7 @@ func (h *ProfileHandler) ChangePassword(w http.ResponseWriter, r *http.Request)
 			errors["general"] = []string{"Failed to change password. Please try again."}
 		}
 
-		component := pages.SecurityPage(user, errors, formData, false)
+		component := pages.SecurityPage(user, errors, formData, false, h.twoFactorView(user), h.sessionsView(r, user))
 		w.WriteHeader(http.StatusUnprocessableEntity)
 		err := component.Render(r.Context(), w)
 		if err != nil {
@@ -218,7 +256,11 @@ func (h *ProfileHandler) ChangePassword(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Show success message
-	component := pages.SecurityPage(user, make(map[string][]string), make(map[string]string), true)
+	sessionsView := h.sessionsView(r, user)
+	if sessionsView != nil {
+		sessionsView.Message = "Your password was changed, so your other devices were logged out."
+	}
+	component := pages.SecurityPage(user, make(map[string][]string), make(map[string]string), true, h.twoFactorView(user), sessionsView)
 	err = component.Render(r.Context(), w)
 	if err != nil {
 		http.Error(w, "Failed to render security page", http.StatusInternalServerError)
@@ -244,7 +286,7 @@ func (h *ProfileHandler) SettingsPage(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Render settings page
-	component := pages.SettingsPage(user, preferences, make(map[string][]string), false)
+	component := pages.SettingsPage(user, preferences, h.userLocale(user.ID), make(map[string][]string), false)
 	err := component.Render(r.Context(), w)
 	if err != nil {
 		http.Error(w, "Failed to render settings page", http.StatusInternalServerError)
@@ -274,14 +316,19 @@ func (h *ProfileHandler) UpdateSettings(w http.ResponseWriter, r *http.Request)
 		NewsletterSubscription: r.FormValue("newsletter_subscription") == "on",
 	}
 
+	locale := r.FormValue("locale")
+
 	// Update preferences (for now, we'll just show success)
 	// In a real implementation, you would save these to the database
 	err := h.userService.UpdatePreferences(user.ID, preferences)
+	if err == nil && h.localeService != nil {
+		err = h.localeService.UpdateUserLocale(user.ID, locale)
+	}
 	if err != nil {
 		errors := map[string][]string{
 			"general": {"Failed to update settings. Please try again."},
 		}
-		component := pages.SettingsPage(user, preferences, errors, false)
+		component := pages.SettingsPage(user, preferences, locale, errors, false)
 		w.WriteHeader(http.StatusUnprocessableEntity)
 		err := component.Render(r.Context(), w)
 		if err != nil {
@@ -291,7 +338,7 @@ func (h *ProfileHandler) UpdateSettings(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Show success message
-	component := pages.SettingsPage(user, preferences, make(map[string][]string), true)
+	component := pages.SettingsPage(user, preferences, h.userLocale(user.ID), make(map[string][]string), true)
 	err = component.Render(r.Context(), w)
 	if err != nil {
 		http.Error(w, "Failed to render settings page", http.StatusInternalServerError)
@@ -0,0 +1,125 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// sessionsView builds the active sessions section of the security page, or
+// nil if sessions aren't kept server-side
+func (h *ProfileHandler) sessionsView(r *http.Request, user *models.User) *pages.SessionsView {
+	if h.sessionStore == nil {
+		return nil
+	}
+
+	view := &pages.SessionsView{}
+	if session, err := h.store.Get(r, "session"); err == nil && session.ID != "" {
+		view.CurrentID = models.HashSessionID(session.ID)
+	}
+
+	sessions, err := h.sessionStore.UserSessions(user.ID)
+	if err != nil {
+		slog.Warn("failed to list sessions", "user_id", user.ID, "error", err)
+		view.Error = "Failed to load your active sessions"
+		return view
+	}
+	view.Sessions = sessions
+	return view
+}
+
+// LogOutOtherSessions ends the user's sessions on every device but this one
+func (h *ProfileHandler) LogOutOtherSessions(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
+		return
+	}
+
+	if h.sessionStore == nil {
+		http.Error(w, "Session management is not available", http.StatusNotFound)
+		return
+	}
+
+	session, err := h.store.Get(r, "session")
+	if err != nil {
+		http.Error(w, "Session error", http.StatusInternalServerError)
+		return
+	}
+
+	ended, err := h.sessionStore.LogOutOtherSessions(user.ID, session)
+	if err != nil {
+		slog.Error("failed to log out other sessions", "user_id", user.ID, "error", err)
+		view := h.sessionsView(r, user)
+		view.Error = "Failed to log out your other devices. Please try again."
+		h.renderSessions(w, r, user, view, http.StatusInternalServerError)
+		return
+	}
+	slog.Info("logged out other sessions", "user_id", user.ID, "sessions", ended)
+
+	view := h.sessionsView(r, user)
+	view.Message = fmt.Sprintf("Logged out of %d other devices.", ended)
+	if ended == 1 {
+		view.Message = "Logged out of 1 other device."
+	}
+	h.renderSessions(w, r, user, view, http.StatusOK)
+}
+
+// RevokeSession logs one of the user's other devices out
+func (h *ProfileHandler) RevokeSession(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
+		return
+	}
+
+	if h.sessionStore == nil {
+		http.Error(w, "Session management is not available", http.StatusNotFound)
+		return
+	}
+
+	view := h.sessionsView(r, user)
+	id := chi.URLParam(r, "id")
+	if id == view.CurrentID {
+		view.Error = "This is the device you are using. Log out instead to end this session."
+		h.renderSessions(w, r, user, view, http.StatusUnprocessableEntity)
+		return
+	}
+
+	if err := h.sessionStore.RevokeSession(user.ID, id); err != nil {
+		status := http.StatusInternalServerError
+		view.Error = "Failed to log out that device. Please try again."
+		if errors.Is(err, models.ErrSessionNotFound) {
+			status = http.StatusNotFound
+			view.Error = "That session has already ended."
+		} else {
+			slog.Error("failed to revoke session", "user_id", user.ID, "error", err)
+		}
+		h.renderSessions(w, r, user, view, status)
+		return
+	}
+	slog.Info("revoked session", "user_id", user.ID)
+
+	view = h.sessionsView(r, user)
+	view.Message = "The device was logged out."
+	h.renderSessions(w, r, user, view, http.StatusOK)
+}
+
+// renderSessions renders the security page with an updated sessions section
+func (h *ProfileHandler) renderSessions(w http.ResponseWriter, r *http.Request, user *models.User, view *pages.SessionsView, status int) {
+	if status != http.StatusOK {
+		w.WriteHeader(status)
+	}
+
+	component := pages.SecurityPage(user, make(map[string][]string), make(map[string]string), false, h.twoFactorView(user), view)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render security page", http.StatusInternalServerError)
+	}
+}
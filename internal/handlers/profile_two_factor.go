@@ -0,0 +1,184 @@
+package handlers
+
+import (
+	"net/http"
+	"strings"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+)
+
+// twoFactorView builds the two-factor section of the security page, or nil if
+// two-factor authentication is not configured
+func (h *ProfileHandler) twoFactorView(user *models.User) *pages.TwoFactorView {
+	if h.twoFactorService == nil {
+		return nil
+	}
+
+	status, err := h.twoFactorService.GetStatus(user)
+	if err != nil {
+		return &pages.TwoFactorView{
+			Status: &services.TwoFactorStatus{Required: h.twoFactorService.IsRequired(user)},
+			Error:  "Failed to load two-factor authentication status",
+		}
+	}
+
+	view := &pages.TwoFactorView{Status: status}
+	if !status.Enabled {
+		enrollment, err := h.twoFactorService.PendingEnrollment(user)
+		if err == nil {
+			view.Enrollment = enrollment
+		}
+	}
+
+	return view
+}
+
+// renderTwoFactor renders the security page with an updated two-factor section
+func (h *ProfileHandler) renderTwoFactor(w http.ResponseWriter, r *http.Request, user *models.User, view *pages.TwoFactorView, status int) {
+	if status != http.StatusOK {
+		w.WriteHeader(status)
+	}
+
+	component := pages.SecurityPage(user, make(map[string][]string), make(map[string]string), false, view, h.sessionsView(r, user))
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render security page", http.StatusInternalServerError)
+	}
+}
+
+// SetupTwoFactor starts two-factor enrollment and shows the QR code to scan
+func (h *ProfileHandler) SetupTwoFactor(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
+		return
+	}
+
+	if h.twoFactorService == nil {
+		http.Error(w, "Two-factor authentication is not available", http.StatusNotFound)
+		return
+	}
+
+	if _, err := h.twoFactorService.BeginEnrollment(user); err != nil {
+		view := h.twoFactorView(user)
+		view.Error = "Failed to start two-factor setup: " + err.Error()
+		h.renderTwoFactor(w, r, user, view, http.StatusUnprocessableEntity)
+		return
+	}
+
+	h.renderTwoFactor(w, r, user, h.twoFactorView(user), http.StatusOK)
+}
+
+// ConfirmTwoFactor enables two-factor authentication after verifying the first code
+func (h *ProfileHandler) ConfirmTwoFactor(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
+		return
+	}
+
+	if h.twoFactorService == nil {
+		http.Error(w, "Two-factor authentication is not available", http.StatusNotFound)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	backupCodes, err := h.twoFactorService.ConfirmEnrollment(user.ID, r.FormValue("code"))
+	if err != nil {
+		view := h.twoFactorView(user)
+		if strings.Contains(err.Error(), "invalid verification code") {
+			view.Error = "That code is not valid. Check your authenticator app and try again."
+		} else {
+			view.Error = "Failed to enable two-factor authentication: " + err.Error()
+		}
+		h.renderTwoFactor(w, r, user, view, http.StatusUnprocessableEntity)
+		return
+	}
+
+	view := h.twoFactorView(user)
+	view.BackupCodes = backupCodes
+	view.Message = "Two-factor authentication is now enabled."
+	h.renderTwoFactor(w, r, user, view, http.StatusOK)
+}
+
+// RegenerateBackupCodes replaces the user's backup codes
+func (h *ProfileHandler) RegenerateBackupCodes(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
+		return
+	}
+
+	if h.twoFactorService == nil {
+		http.Error(w, "Two-factor authentication is not available", http.StatusNotFound)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	backupCodes, err := h.twoFactorService.RegenerateBackupCodes(user.ID, r.FormValue("code"))
+	if err != nil {
+		view := h.twoFactorView(user)
+		view.Error = twoFactorErrorMessage(err)
+		h.renderTwoFactor(w, r, user, view, http.StatusUnprocessableEntity)
+		return
+	}
+
+	view := h.twoFactorView(user)
+	view.BackupCodes = backupCodes
+	view.Message = "New backup codes have been generated."
+	h.renderTwoFactor(w, r, user, view, http.StatusOK)
+}
+
+// DisableTwoFactor turns off two-factor authentication
+func (h *ProfileHandler) DisableTwoFactor(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
+		return
+	}
+
+	if h.twoFactorService == nil {
+		http.Error(w, "Two-factor authentication is not available", http.StatusNotFound)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.twoFactorService.Disable(user, r.FormValue("code")); err != nil {
+		view := h.twoFactorView(user)
+		view.Error = twoFactorErrorMessage(err)
+		h.renderTwoFactor(w, r, user, view, http.StatusUnprocessableEntity)
+		return
+	}
+
+	view := h.twoFactorView(user)
+	view.Message = "Two-factor authentication has been disabled."
+	h.renderTwoFactor(w, r, user, view, http.StatusOK)
+}
+
+// twoFactorErrorMessage converts a verification error into a user-facing message
+func twoFactorErrorMessage(err error) string {
+	switch {
+	case strings.Contains(err.Error(), "required for your role"):
+		return "Two-factor authentication is required for your account and cannot be disabled."
+	case strings.Contains(err.Error(), "already been used"):
+		return "That code has already been used. Wait for a new code and try again."
+	case strings.Contains(err.Error(), "invalid verification code"):
+		return "That code is not valid."
+	default:
+		return "Failed to verify code. Please try again."
+	}
+}
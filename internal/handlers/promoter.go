@@ -0,0 +1,151 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// PromoterHandler handles organizers' promoter links
+type PromoterHandler struct {
+	promoterService *services.PromoterService
+	eventService    services.EventServiceInterface
+}
+
+// NewPromoterHandler creates a new promoter handler
+func NewPromoterHandler(promoterService *services.PromoterService, eventService services.EventServiceInterface) *PromoterHandler {
+	return &PromoterHandler{
+		promoterService: promoterService,
+		eventService:    eventService,
+	}
+}
+
+// PromotersPage handles GET /organizer/promoters, listing promoter links
+// with their sales and the commission owed
+func (h *PromoterHandler) PromotersPage(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login?redirect=/organizer/promoters", http.StatusSeeOther)
+		return
+	}
+
+	h.renderPage(w, r, http.StatusOK, user, map[string]string{}, r.URL.Query().Get("saved") == "1", "")
+}
+
+// CreateLink handles POST /organizer/promoters
+func (h *PromoterHandler) CreateLink(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	formData := map[string]string{
+		"event_id":        r.FormValue("event_id"),
+		"name":            r.FormValue("name"),
+		"code":            r.FormValue("code"),
+		"commission_rate": r.FormValue("commission_rate"),
+	}
+
+	eventID, err := strconv.Atoi(formData["event_id"])
+	if err != nil {
+		h.renderPage(w, r, http.StatusBadRequest, user, formData, false, "Please choose an event")
+		return
+	}
+
+	canEdit, err := h.eventService.CanUserEditEvent(eventID, user.ID)
+	if err != nil {
+		http.Error(w, "Failed to check permissions", http.StatusInternalServerError)
+		return
+	}
+	if !canEdit {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	// Commission is optional; links without one only track sales
+	var commission float64
+	if value := strings.TrimSpace(formData["commission_rate"]); value != "" {
+		if commission, err = strconv.ParseFloat(value, 64); err != nil {
+			h.renderPage(w, r, http.StatusBadRequest, user, formData, false, "Commission must be a number")
+			return
+		}
+	}
+
+	req := &models.PromoterLinkCreateRequest{
+		EventID:        eventID,
+		Name:           formData["name"],
+		Code:           formData["code"],
+		CommissionRate: commission,
+	}
+	if _, err := h.promoterService.CreateLink(req); err != nil {
+		if strings.HasPrefix(err.Error(), "failed to") {
+			http.Error(w, "Failed to create promoter link", http.StatusInternalServerError)
+			return
+		}
+		h.renderPage(w, r, http.StatusBadRequest, user, formData, false, err.Error())
+		return
+	}
+
+	http.Redirect(w, r, "/organizer/promoters?saved=1", http.StatusSeeOther)
+}
+
+// DeactivateLink handles POST /organizer/promoters/{id}/deactivate
+func (h *PromoterHandler) DeactivateLink(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	linkID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid link ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.promoterService.DeactivateLink(linkID, user.ID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			http.Error(w, "Promoter link not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, "Failed to deactivate promoter link", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/organizer/promoters?saved=1", http.StatusSeeOther)
+}
+
+// renderPage renders the promoters page with the organizer's links and events
+func (h *PromoterHandler) renderPage(w http.ResponseWriter, r *http.Request, status int, user *models.User, formData map[string]string, saved bool, errorMsg string) {
+	summary, err := h.promoterService.GetPayoutSummary(user.ID)
+	if err != nil {
+		http.Error(w, "Failed to load promoter links", http.StatusInternalServerError)
+		return
+	}
+
+	events, err := h.eventService.GetEventsByOrganizer(user.ID)
+	if err != nil {
+		http.Error(w, "Failed to load events", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(status)
+	component := pages.PromotersPage(user, summary, events, formData, saved, errorMsg)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
@@ -1,9 +1,14 @@
 package handlers
 
 import (
+	"bytes"
 	"net/http"
 	"strconv"
+	"strings"
+	"time"
 
+	"event-ticketing-platform/internal/i18n"
+	"event-ticketing-platform/internal/logging"
 	"event-ticketing-platform/internal/middleware"
 	"event-ticketing-platform/internal/models"
 	"event-ticketing-platform/internal/services"
@@ -19,6 +24,15 @@ type PublicHandler struct {
 	eventService          services.EventServiceInterface
 	ticketService         services.TicketServiceInterface
 	eventDiscoveryService *services.EventDiscoveryService
+	translationService    *services.EventTranslationService
+	favoriteService       *services.FavoriteService
+	seoService            *services.SEOService
+	cityPage              http.HandlerFunc
+	availabilityBroker    *services.AvailabilityBroker
+	venueService          *services.VenueService
+	accessService         *services.EventAccessService
+	agendaService         *services.EventAgendaService
+	faqService            *services.EventFAQService
 }
 
 // NewPublicHandler creates a new public handler
@@ -30,6 +44,56 @@ func NewPublicHandler(eventService services.EventServiceInterface, ticketService
 	}
 }
 
+// SetVenueService links events held at a saved venue to the venue's page
+func (h *PublicHandler) SetVenueService(venueService *services.VenueService) {
+	h.venueService = venueService
+}
+
+// SetEventAccessService shows ticket holders the access link of online events
+func (h *PublicHandler) SetEventAccessService(accessService *services.EventAccessService) {
+	h.accessService = accessService
+}
+
+// SetEventAgendaService shows events' agendas and lineups on their pages
+func (h *PublicHandler) SetEventAgendaService(agendaService *services.EventAgendaService) {
+	h.agendaService = agendaService
+}
+
+// SetEventFAQService shows events' FAQs and policies on their pages
+func (h *PublicHandler) SetEventFAQService(faqService *services.EventFAQService) {
+	h.faqService = faqService
+}
+
+// SetTranslationService shows events in the visitor's language where the
+// organizer has translated them
+func (h *PublicHandler) SetTranslationService(translationService *services.EventTranslationService) {
+	h.translationService = translationService
+}
+
+// SetFavoriteService shows signed-in visitors whether they saved an event
+func (h *PublicHandler) SetFavoriteService(favoriteService *services.FavoriteService) {
+	h.favoriteService = favoriteService
+}
+
+// SetSEOService adds canonical URLs and structured data to event pages
+func (h *PublicHandler) SetSEOService(seoService *services.SEOService) {
+	h.seoService = seoService
+}
+
+// SetCityPages sets the handler for city landing pages, which share the
+// /events/{slug} URLs with event slugs. Events win if both match.
+func (h *PublicHandler) SetCityPages(cityPage http.HandlerFunc) {
+	h.cityPage = cityPage
+}
+
+// localizeEvents translates the events into the visitor's language, if translations are enabled
+func (h *PublicHandler) localizeEvents(events []*models.Event, locale string) []*models.Event {
+	if h.translationService == nil {
+		return events
+	}
+	return h.translationService.LocalizeEvents(events, locale)
+}
+
 // HomePage renders the homepage with featured and upcoming events
 func (h *PublicHandler) HomePage(w http.ResponseWriter, r *http.Request) {
 	user := middleware.GetUserFromContext(r.Context())
@@ -48,6 +112,10 @@ func (h *PublicHandler) HomePage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	locale := visitorLocale(w, r)
+	featuredEvents = h.localizeEvents(featuredEvents, locale)
+	upcomingEvents = h.localizeEvents(upcomingEvents, locale)
+
 	// Render the homepage
 	component := pages.HomePage(user, featuredEvents, upcomingEvents)
 	err = component.Render(r.Context(), w)
@@ -71,6 +139,17 @@ func (h *PublicHandler) EventsListPage(w http.ResponseWriter, r *http.Request) {
 	sortBy := r.URL.Query().Get("sort_by")
 	sortOrder := r.URL.Query().Get("sort_order")
 	availability := r.URL.Query().Get("availability")
+	when := r.URL.Query().Get("when")
+	timeZone := r.URL.Query().Get("tz")
+	view := r.URL.Query().Get("view")
+
+	// Parse the searcher's position for "near me" searches, nearest first
+	// unless another order was asked for
+	near := models.ParseGeoPoint(r.URL.Query().Get("lat"), r.URL.Query().Get("lng"))
+	radius, _ := strconv.Atoi(r.URL.Query().Get("radius"))
+	if near != nil && sortBy == "" {
+		sortBy = "distance"
+	}
 
 	// Parse price range
 	priceMin := 0
@@ -86,6 +165,9 @@ func (h *PublicHandler) EventsListPage(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// Parse organizer filter, used by organizer search suggestions
+	organizerID, _ := strconv.Atoi(r.URL.Query().Get("organizer"))
+
 	// Parse pagination
 	page := 1
 	if pageStr := r.URL.Query().Get("page"); pageStr != "" {
@@ -94,6 +176,13 @@ func (h *PublicHandler) EventsListPage(w http.ResponseWriter, r *http.Request) {
 		}
 	}
 
+	// The map shows more results at once, without pagination
+	perPage := 12
+	if view == pages.EventsViewMap {
+		page = 1
+		perPage = pages.EventsMapPageSize
+	}
+
 	// Get user ID for personalization
 	userID := 0
 	if user != nil {
@@ -105,6 +194,7 @@ func (h *PublicHandler) EventsListPage(w http.ResponseWriter, r *http.Request) {
 		Query:        query,
 		Category:     category,
 		Location:     location,
+		OrganizerID:  organizerID,
 		DateFrom:     dateFrom,
 		DateTo:       dateTo,
 		PriceMin:     priceMin,
@@ -113,8 +203,12 @@ func (h *PublicHandler) EventsListPage(w http.ResponseWriter, r *http.Request) {
 		SortBy:       sortBy,
 		SortOrder:    sortOrder,
 		Availability: availability,
+		When:         when,
+		TimeZone:     timeZone,
+		Near:         near,
+		Radius:       radius,
 		Page:         page,
-		PerPage:      12,
+		PerPage:      perPage,
 		UserID:       userID,
 	}
 
@@ -125,6 +219,8 @@ func (h *PublicHandler) EventsListPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	events := h.localizeEvents(result.Events, visitorLocale(w, r))
+
 	// Build pagination
 	totalPages := (result.FilteredCount + filters.PerPage - 1) / filters.PerPage
 	pagination := components.Pagination{
@@ -140,12 +236,14 @@ func (h *PublicHandler) EventsListPage(w http.ResponseWriter, r *http.Request) {
 		Location: location,
 		DateFrom: dateFrom,
 		DateTo:   dateTo,
+		View:     view,
+		Near:     near,
 	}
 
 	// Check if this is an HTMX request for partial update
 	if middleware.IsHTMXRequest(r) {
-		// Return just the events list partial
-		component := pages.EventsList(result.Events, pagination)
+		// Return just the events list or map partial
+		component := pages.EventResults(events, templateFilters, pagination)
 		err = component.Render(r.Context(), w)
 		if err != nil {
 			http.Error(w, "Failed to render events list", http.StatusInternalServerError)
@@ -156,7 +254,7 @@ func (h *PublicHandler) EventsListPage(w http.ResponseWriter, r *http.Request) {
 	// Note: Recommendations and trending events removed for simplicity
 
 	// Render the events page
-	component := pages.EventsListPage(user, result.Events, result.Categories, templateFilters, pagination)
+	component := pages.EventsListPage(user, events, result.Categories, templateFilters, pagination)
 	err = component.Render(r.Context(), w)
 	if err != nil {
 		http.Error(w, "Failed to render page", http.StatusInternalServerError)
@@ -183,6 +281,42 @@ func (h *PublicHandler) EventDetailsPage(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Numeric URLs predate slugs; send visitors and search engines to the
+	// canonical one
+	if event.Slug != "" && !middleware.IsHTMXRequest(r) {
+		target := event.Path()
+		if r.URL.RawQuery != "" {
+			target += "?" + r.URL.RawQuery
+		}
+		http.Redirect(w, r, target, http.StatusMovedPermanently)
+		return
+	}
+
+	h.renderEventDetails(w, r, user, event)
+}
+
+// EventBySlugPage renders the event details page for /events/{slug}, or the
+// city landing page if no event has the slug
+func (h *PublicHandler) EventBySlugPage(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+
+	event, err := h.eventService.GetEventBySlug(strings.ToLower(chi.URLParam(r, "slug")))
+	if err != nil {
+		if h.cityPage != nil {
+			h.cityPage(w, r)
+			return
+		}
+		http.Error(w, "Event not found", http.StatusNotFound)
+		return
+	}
+
+	h.renderEventDetails(w, r, user, event)
+}
+
+// renderEventDetails renders the event details page
+func (h *PublicHandler) renderEventDetails(w http.ResponseWriter, r *http.Request, user *models.User, event *models.Event) {
+	eventID := event.ID
+
 	// Get ticket types for this event with real-time availability
 	ticketTypes, err := h.ticketService.GetTicketTypesByEventID(eventID)
 	if err != nil {
@@ -197,6 +331,59 @@ func (h *PublicHandler) EventDetailsPage(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	// Show the event in the visitor's language, falling back to its own
+	locale := visitorLocale(w, r)
+	languages := []string{}
+	if h.translationService != nil {
+		event = h.translationService.Localize(event, locale)
+		if languages, err = h.translationService.GetLanguages(eventID); err != nil {
+			logging.FromContext(r.Context()).Warn("failed to load event languages", "event_id", eventID, "error", err)
+		}
+	}
+
+	if h.venueService != nil {
+		if event.Venue, err = h.venueService.GetEventVenue(eventID); err != nil {
+			logging.FromContext(r.Context()).Warn("failed to load event venue", "event_id", eventID, "error", err)
+		}
+	}
+
+	favorited := false
+	if user != nil && h.favoriteService != nil {
+		if favorited, err = h.favoriteService.IsFavorite(user.ID, eventID); err != nil {
+			logging.FromContext(r.Context()).Warn("failed to check whether the user saved the event", "event_id", eventID, "error", err)
+		}
+	}
+
+	var access *models.EventAccess
+	if h.accessService != nil {
+		userID := 0
+		if user != nil {
+			userID = user.ID
+		}
+		if access, err = h.accessService.GetAccess(event, userID); err != nil {
+			logging.FromContext(r.Context()).Warn("failed to load event access", "event_id", eventID, "error", err)
+		}
+	}
+
+	var agenda *models.EventAgenda
+	if h.agendaService != nil {
+		if agenda, err = h.agendaService.GetAgenda(eventID); err != nil {
+			logging.FromContext(r.Context()).Warn("failed to load event agenda", "event_id", eventID, "error", err)
+		}
+	}
+
+	var info *models.EventInfo
+	if h.faqService != nil {
+		if info, err = h.faqService.GetInfo(eventID); err != nil {
+			logging.FromContext(r.Context()).Warn("failed to load event FAQs", "event_id", eventID, "error", err)
+		}
+	}
+
+	var seo *services.EventSEO
+	if h.seoService != nil {
+		seo = h.seoService.EventSEO(event, ticketTypes, organizer)
+	}
+
 	// Note: Similar events and recommendations removed for simplicity
 
 	// Check if this is an HTMX request for ticket availability update
@@ -210,7 +397,7 @@ func (h *PublicHandler) EventDetailsPage(w http.ResponseWriter, r *http.Request)
 	}
 
 	// Render the enhanced event details page
-	component := pages.EnhancedEventDetailsPage(user, event, ticketTypes, organizer, []*models.Event{}, []*models.Event{})
+	component := pages.EnhancedEventDetailsPage(user, event, ticketTypes, organizer, []*models.Event{}, []*models.Event{}, languages, locale, favorited, access, agenda, info, seo)
 	err = component.Render(r.Context(), w)
 	if err != nil {
 		http.Error(w, "Failed to render page", http.StatusInternalServerError)
@@ -234,10 +421,12 @@ func (h *PublicHandler) SearchEvents(w http.ResponseWriter, r *http.Request) {
 
 	// Use enhanced discovery for search suggestions
 	filters := services.DiscoveryFilters{
-		Query:   query,
-		Page:    1,
-		PerPage: 10, // Limit results for dropdown
-		SortBy:  "relevance",
+		Query:    query,
+		When:     r.URL.Query().Get("when"),
+		TimeZone: r.URL.Query().Get("tz"),
+		Page:     1,
+		PerPage:  10, // Limit results for dropdown
+		SortBy:   "relevance",
 	}
 
 	result, err := h.eventDiscoveryService.DiscoverEvents(filters)
@@ -247,7 +436,7 @@ func (h *PublicHandler) SearchEvents(w http.ResponseWriter, r *http.Request) {
 	}
 
 	// Render search results partial
-	component := partials.SearchResults(result.Events, query)
+	component := partials.SearchResults(h.localizeEvents(result.Events, visitorLocale(w, r)), query)
 	err = component.Render(r.Context(), w)
 	if err != nil {
 		http.Error(w, "Failed to render search results", http.StatusInternalServerError)
@@ -259,15 +448,40 @@ func (h *PublicHandler) SearchEvents(w http.ResponseWriter, r *http.Request) {
 func (h *PublicHandler) GetTicketAvailability(w http.ResponseWriter, r *http.Request) {
 	// Get event ID from URL
 	eventIDStr := chi.URLParam(r, "id")
-	_, err := strconv.Atoi(eventIDStr)
+	eventID, err := strconv.Atoi(eventIDStr)
 	if err != nil {
 		http.Error(w, "Invalid event ID", http.StatusBadRequest)
 		return
 	}
 
-	// Return ticket availability partial (simplified)
-	w.Write([]byte("<div>Ticket availability updated</div>"))
-	return
+	ticketTypes, err := h.loadTicketAvailability(eventID)
+	if err != nil {
+		http.Error(w, "Failed to load ticket availability", http.StatusInternalServerError)
+		return
+	}
+
+	// The partial only needs the event ID, so the poll doesn't load the event
+	var body bytes.Buffer
+	if err := pages.TicketAvailabilityPartial(&models.Event{ID: eventID}, ticketTypes).Render(r.Context(), &body); err != nil {
+		http.Error(w, "Failed to render ticket availability", http.StatusInternalServerError)
+		return
+	}
+
+	writeCacheable(w, r, "text/html; charset=utf-8", availabilityCacheControl, body.Bytes())
+}
+
+// availabilityProvider is implemented by ticket services that cache ticket availability
+type availabilityProvider interface {
+	GetTicketAvailability(eventID int) ([]*models.TicketType, error)
+}
+
+// loadTicketAvailability loads an event's ticket types, using the cached
+// availability when the ticket service provides it
+func (h *PublicHandler) loadTicketAvailability(eventID int) ([]*models.TicketType, error) {
+	if provider, ok := h.ticketService.(availabilityProvider); ok {
+		return provider.GetTicketAvailability(eventID)
+	}
+	return h.ticketService.GetTicketTypesByEventID(eventID)
 }
 
 // QuickAddToCart adds tickets to cart via HTMX
@@ -352,3 +566,31 @@ func (h *PublicHandler) CategoriesPage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 }
+
+// localeCookie remembers the language a visitor picked with ?lang=
+const localeCookie = "lang"
+
+// visitorLocale returns the language to show events in: one picked with
+// ?lang=, which is remembered for later visits, else the one picked before,
+// else the browser's preferred language. It returns "" if none is supported.
+func visitorLocale(w http.ResponseWriter, r *http.Request) string {
+	if locale := i18n.Normalize(r.URL.Query().Get("lang")); locale != "" {
+		http.SetCookie(w, &http.Cookie{
+			Name:     localeCookie,
+			Value:    locale,
+			Path:     "/",
+			MaxAge:   int((365 * 24 * time.Hour).Seconds()),
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		})
+		return locale
+	}
+
+	if cookie, err := r.Cookie(localeCookie); err == nil {
+		if locale := i18n.Normalize(cookie.Value); locale != "" {
+			return locale
+		}
+	}
+
+	return i18n.FromAcceptLanguage(r.Header.Get("Accept-Language"))
+}
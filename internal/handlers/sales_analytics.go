@@ -0,0 +1,229 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/go-chi/chi/v5"
+
+	"event-ticketing-platform/internal/middleware"
+)
+
+// Chart endpoints cover the last 30 days unless asked for up to a year
+const (
+	defaultSeriesDays = 30
+	maxSeriesDays     = 365
+)
+
+// seriesDays parses the days query parameter of the chart endpoints
+func seriesDays(r *http.Request) (int, error) {
+	value := r.URL.Query().Get("days")
+	if value == "" {
+		return defaultSeriesDays, nil
+	}
+	days, err := strconv.Atoi(value)
+	if err != nil || days < 1 || days > maxSeriesDays {
+		return 0, fmt.Errorf("days must be between 1 and %d", maxSeriesDays)
+	}
+	return days, nil
+}
+
+// writeEventAnalyticsError writes the response for an error loading an
+// event's analytics
+func writeEventAnalyticsError(w http.ResponseWriter, err error) {
+	if err.Error() == "organizer does not have access to this event" {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+	http.Error(w, fmt.Sprintf("Failed to get event analytics: %v", err), http.StatusInternalServerError)
+}
+
+// SalesSeriesAPI handles GET /api/organizer/sales
+func (h *AnalyticsHandler) SalesSeriesAPI(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	days, err := seriesDays(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	series, err := h.analyticsService.GetOrganizerSalesSeries(user.ID, days)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get sales: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	if err := writeCacheableJSON(w, r, analyticsCacheControl, series); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to write JSON response: %v", err), http.StatusInternalServerError)
+	}
+}
+
+// EventSalesSeriesAPI handles GET /api/organizer/events/{id}/sales
+func (h *AnalyticsHandler) EventSalesSeriesAPI(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	eventID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	days, err := seriesDays(r)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	series, err := h.analyticsService.GetEventSalesSeries(eventID, user.ID, days)
+	if err != nil {
+		writeEventAnalyticsError(w, err)
+		return
+	}
+
+	if err := writeCacheableJSON(w, r, analyticsCacheControl, series); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to write JSON response: %v", err), http.StatusInternalServerError)
+	}
+}
+
+// EventTicketTypeRevenueAPI handles GET /api/organizer/events/{id}/ticket-types
+func (h *AnalyticsHandler) EventTicketTypeRevenueAPI(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	eventID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	ticketTypes, err := h.analyticsService.GetEventRevenueByTicketType(eventID, user.ID)
+	if err != nil {
+		writeEventAnalyticsError(w, err)
+		return
+	}
+
+	if err := writeCacheableJSON(w, r, analyticsCacheControl, ticketTypes); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to write JSON response: %v", err), http.StatusInternalServerError)
+	}
+}
+
+// EventFunnelAPI handles GET /api/organizer/events/{id}/funnel. Without a
+// days parameter it covers the event's whole sale.
+func (h *AnalyticsHandler) EventFunnelAPI(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	eventID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	var since time.Time
+	if r.URL.Query().Get("days") != "" {
+		days, err := seriesDays(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// Whole days, so the funnel is cached for the day
+		now := time.Now()
+		since = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, -days)
+	}
+
+	funnel, err := h.analyticsService.GetEventFunnel(eventID, user.ID, since)
+	if err != nil {
+		writeEventAnalyticsError(w, err)
+		return
+	}
+
+	if err := writeCacheableJSON(w, r, analyticsCacheControl, funnel); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to write JSON response: %v", err), http.StatusInternalServerError)
+	}
+}
+
+// EventComparisonAPI handles GET /api/organizer/events/{id}/comparison
+func (h *AnalyticsHandler) EventComparisonAPI(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	eventID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	comparison, err := h.analyticsService.GetEventComparison(eventID, user.ID)
+	if err != nil {
+		writeEventAnalyticsError(w, err)
+		return
+	}
+
+	if err := writeCacheableJSON(w, r, analyticsCacheControl, comparison); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to write JSON response: %v", err), http.StatusInternalServerError)
+	}
+}
+
+// SalesBySourceAPI handles GET /api/organizer/sources
+func (h *AnalyticsHandler) SalesBySourceAPI(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	sources, err := h.analyticsService.GetOrganizerSalesBySource(user.ID)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("Failed to get sales by source: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	if err := writeCacheableJSON(w, r, analyticsCacheControl, sources); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to write JSON response: %v", err), http.StatusInternalServerError)
+	}
+}
+
+// EventSalesBySourceAPI handles GET /api/organizer/events/{id}/sources
+func (h *AnalyticsHandler) EventSalesBySourceAPI(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	eventID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	sources, err := h.analyticsService.GetEventSalesBySource(eventID, user.ID)
+	if err != nil {
+		writeEventAnalyticsError(w, err)
+		return
+	}
+
+	if err := writeCacheableJSON(w, r, analyticsCacheControl, sources); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to write JSON response: %v", err), http.StatusInternalServerError)
+	}
+}
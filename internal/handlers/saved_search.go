@@ -0,0 +1,88 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// savedSearchErrors are the messages shown on the dashboard when a search
+// could not be saved, by the code in the redirect
+var savedSearchErrors = map[string]string{
+	"empty": "Enter a search, category or location to save.",
+	"limit": services.ErrTooManySavedSearches.Error() + ". Remove one to save another.",
+	"long":  "That search is too long to save.",
+}
+
+// SavedSearchHandler handles attendees saving searches to be emailed about
+// matching new events
+type SavedSearchHandler struct {
+	savedSearchService *services.SavedSearchService
+}
+
+// NewSavedSearchHandler creates a new saved search handler
+func NewSavedSearchHandler(savedSearchService *services.SavedSearchService) *SavedSearchHandler {
+	return &SavedSearchHandler{
+		savedSearchService: savedSearchService,
+	}
+}
+
+// SaveSearch saves the submitted search and sends the attendee back to their
+// dashboard. It takes the events listing's q, category and location fields.
+func (h *SavedSearchHandler) SaveSearch(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
+		return
+	}
+
+	categoryID, _ := strconv.Atoi(r.FormValue("category"))
+	search := &models.SavedSearch{
+		UserID:     user.ID,
+		Query:      r.FormValue("q"),
+		CategoryID: categoryID,
+		Location:   r.FormValue("location"),
+	}
+
+	if err := h.savedSearchService.SaveSearch(search); err != nil {
+		code := "long"
+		switch {
+		case errors.Is(err, services.ErrTooManySavedSearches):
+			code = "limit"
+		case search.Query == "" && search.CategoryID == 0 && search.Location == "":
+			code = "empty"
+		}
+		http.Redirect(w, r, "/dashboard?search_error="+code+"#saved-searches", http.StatusSeeOther)
+		return
+	}
+
+	http.Redirect(w, r, "/dashboard#saved-searches", http.StatusSeeOther)
+}
+
+// DeleteSearch removes one of the attendee's saved searches
+func (h *SavedSearchHandler) DeleteSearch(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
+		return
+	}
+
+	searchID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid saved search ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.savedSearchService.DeleteSearch(searchID, user.ID); err != nil {
+		http.Error(w, "Failed to remove saved search", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/dashboard#saved-searches", http.StatusSeeOther)
+}
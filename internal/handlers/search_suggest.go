@@ -0,0 +1,57 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/partials"
+)
+
+// searchSuggestCacheControl lets browsers reuse suggestions while the user
+// edits their query; the index only changes when events do
+const searchSuggestCacheControl = "public, max-age=30"
+
+// SearchSuggestHandler handles search-as-you-type suggestions
+type SearchSuggestHandler struct {
+	suggestService *services.SearchSuggestService
+}
+
+// NewSearchSuggestHandler creates a new search suggestion handler
+func NewSearchSuggestHandler(suggestService *services.SearchSuggestService) *SearchSuggestHandler {
+	return &SearchSuggestHandler{
+		suggestService: suggestService,
+	}
+}
+
+// Suggest handles GET /search/suggest?q=. HTMX requests from the navbar get
+// the suggestions dropdown; other clients get JSON.
+func (h *SearchSuggestHandler) Suggest(w http.ResponseWriter, r *http.Request) {
+	query := strings.TrimSpace(r.URL.Query().Get("q"))
+	limit, _ := strconv.Atoi(r.URL.Query().Get("limit"))
+
+	suggestions := h.suggestService.Suggest(query, limit)
+
+	w.Header().Set("Cache-Control", searchSuggestCacheControl)
+	w.Header().Set("Vary", "HX-Request")
+
+	if middleware.IsHTMXRequest(r) {
+		component := partials.SearchSuggestions(query, suggestions)
+		if err := component.Render(r.Context(), w); err != nil {
+			http.Error(w, "Failed to render search suggestions", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	response := map[string]interface{}{
+		"query":       query,
+		"suggestions": suggestions,
+	}
+	if err := writeJSON(w, response); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to write JSON response: %v", err), http.StatusInternalServerError)
+		return
+	}
+}
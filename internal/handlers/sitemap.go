@@ -0,0 +1,107 @@
+package handlers
+
+import (
+	"encoding/xml"
+	"net/http"
+	"time"
+
+	"event-ticketing-platform/internal/services"
+)
+
+// sitemapURLSet is the root element of a sitemap.xml document
+type sitemapURLSet struct {
+	XMLName xml.Name     `xml:"urlset"`
+	Xmlns   string       `xml:"xmlns,attr"`
+	URLs    []sitemapURL `xml:"url"`
+}
+
+// sitemapURL is a single sitemap entry
+type sitemapURL struct {
+	Loc        string `xml:"loc"`
+	LastMod    string `xml:"lastmod,omitempty"`
+	ChangeFreq string `xml:"changefreq,omitempty"`
+	Priority   string `xml:"priority,omitempty"`
+}
+
+// SitemapHandler serves the public sitemap
+type SitemapHandler struct {
+	cityService  *services.CityService
+	eventService *services.EventService
+	seoService   *services.SEOService
+	baseURL      string
+}
+
+// NewSitemapHandler creates a new sitemap handler
+func NewSitemapHandler(cityService *services.CityService, eventService *services.EventService, seoService *services.SEOService, baseURL string) *SitemapHandler {
+	return &SitemapHandler{
+		cityService:  cityService,
+		eventService: eventService,
+		seoService:   seoService,
+		baseURL:      baseURL,
+	}
+}
+
+// Sitemap renders /sitemap.xml
+func (h *SitemapHandler) Sitemap(w http.ResponseWriter, r *http.Request) {
+	urlSet := sitemapURLSet{
+		Xmlns: "http://www.sitemaps.org/schemas/sitemap/0.9",
+		URLs: []sitemapURL{
+			{Loc: h.baseURL + "/", ChangeFreq: "daily", Priority: "1.0"},
+			{Loc: h.baseURL + "/events", ChangeFreq: "hourly", Priority: "0.9"},
+			{Loc: h.baseURL + "/cities", ChangeFreq: "daily", Priority: "0.6"},
+			{Loc: h.baseURL + "/pricing", ChangeFreq: "monthly", Priority: "0.5"},
+		},
+	}
+
+	// City landing pages
+	cities, err := h.cityService.GetCities(0)
+	if err != nil {
+		http.Error(w, "Failed to build sitemap", http.StatusInternalServerError)
+		return
+	}
+	for _, city := range cities {
+		urlSet.URLs = append(urlSet.URLs, sitemapURL{
+			Loc:        h.cityService.CityURL(city.Slug),
+			ChangeFreq: "daily",
+			Priority:   "0.7",
+		})
+	}
+
+	// Category landing pages
+	categories, err := h.eventService.GetCategories()
+	if err != nil {
+		http.Error(w, "Failed to build sitemap", http.StatusInternalServerError)
+		return
+	}
+	for _, category := range categories {
+		urlSet.URLs = append(urlSet.URLs, sitemapURL{
+			Loc:        h.seoService.CategoryURL(category),
+			ChangeFreq: "daily",
+			Priority:   "0.6",
+		})
+	}
+
+	// Published events, by their canonical slug URLs
+	events, err := h.eventService.GetSitemapEvents()
+	if err != nil {
+		http.Error(w, "Failed to build sitemap", http.StatusInternalServerError)
+		return
+	}
+	for _, event := range events {
+		urlSet.URLs = append(urlSet.URLs, sitemapURL{
+			Loc:        h.seoService.EventURL(event),
+			LastMod:    event.UpdatedAt.UTC().Format(time.RFC3339),
+			ChangeFreq: "daily",
+			Priority:   "0.8",
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/xml; charset=utf-8")
+	w.Write([]byte(xml.Header))
+	encoder := xml.NewEncoder(w)
+	encoder.Indent("", "  ")
+	if err := encoder.Encode(urlSet); err != nil {
+		http.Error(w, "Failed to encode sitemap", http.StatusInternalServerError)
+		return
+	}
+}
@@ -0,0 +1,197 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/layouts"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// StorefrontHandler handles organizers' public storefronts and their followers
+type StorefrontHandler struct {
+	storefrontService *services.StorefrontService
+	baseURL           string
+}
+
+// NewStorefrontHandler creates a new storefront handler
+func NewStorefrontHandler(storefrontService *services.StorefrontService, baseURL string) *StorefrontHandler {
+	return &StorefrontHandler{
+		storefrontService: storefrontService,
+		baseURL:           strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// StorefrontPage handles GET /o/{slug}
+func (h *StorefrontHandler) StorefrontPage(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	viewerID := 0
+	if user != nil {
+		viewerID = user.ID
+	}
+
+	storefront, err := h.storefrontService.GetStorefront(chi.URLParam(r, "slug"), viewerID)
+	if err != nil {
+		http.Error(w, "Organizer not found", http.StatusNotFound)
+		return
+	}
+
+	profile := storefront.Profile
+	meta := layouts.PageMeta{
+		Description:  storefrontDescription(profile),
+		CanonicalURL: h.baseURL + profile.Path(),
+		ImageURL:     absoluteURL(h.baseURL, profile.BannerURL),
+	}
+
+	component := pages.OrganizerStorefrontPage(user, storefront, meta)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// OrganizerRedirect handles GET /organizers/{id}, sending visitors to the
+// organizer's storefront
+func (h *StorefrontHandler) OrganizerRedirect(w http.ResponseWriter, r *http.Request) {
+	organizerID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid organizer ID", http.StatusBadRequest)
+		return
+	}
+
+	profile, err := h.storefrontService.GetProfile(organizerID)
+	if err != nil {
+		http.Error(w, "Organizer not found", http.StatusNotFound)
+		return
+	}
+
+	http.Redirect(w, r, profile.Path(), http.StatusMovedPermanently)
+}
+
+// ToggleFollow handles POST /o/{slug}/follow
+func (h *StorefrontHandler) ToggleFollow(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	storefront, err := h.storefrontService.GetStorefront(chi.URLParam(r, "slug"), user.ID)
+	if err != nil {
+		http.Error(w, "Organizer not found", http.StatusNotFound)
+		return
+	}
+
+	if _, err := h.storefrontService.ToggleFollow(storefront.Profile.UserID, user.ID); err != nil {
+		if errors.Is(err, services.ErrCannotFollowSelf) {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		http.Error(w, "Failed to follow organizer", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, storefront.Profile.Path(), http.StatusSeeOther)
+}
+
+// EditPage handles GET /organizer/storefront
+func (h *StorefrontHandler) EditPage(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
+		return
+	}
+
+	profile, err := h.storefrontService.GetProfile(user.ID)
+	if err != nil {
+		http.Error(w, "Failed to load storefront", http.StatusInternalServerError)
+		return
+	}
+
+	component := pages.OrganizerStorefrontEditPage(user, profile, r.URL.Query().Get("saved") == "1", "")
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// UpdateProfile handles POST /organizer/storefront
+func (h *StorefrontHandler) UpdateProfile(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
+		return
+	}
+
+	if err := r.ParseMultipartForm(12 << 20); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	req := &services.StorefrontUpdateRequest{
+		Slug:         r.FormValue("slug"),
+		DisplayName:  r.FormValue("display_name"),
+		Description:  r.FormValue("description"),
+		WebsiteURL:   r.FormValue("website_url"),
+		RemoveLogo:   r.FormValue("remove_logo") == "on",
+		RemoveBanner: r.FormValue("remove_banner") == "on",
+	}
+	if file, fileHeader, err := r.FormFile("logo"); err == nil {
+		defer file.Close()
+		req.Logo = fileHeader
+	}
+	if file, fileHeader, err := r.FormFile("banner"); err == nil {
+		defer file.Close()
+		req.Banner = fileHeader
+	}
+
+	if _, err := h.storefrontService.UpdateProfile(user.ID, req); err != nil {
+		profile, loadErr := h.storefrontService.GetProfile(user.ID)
+		if loadErr != nil {
+			http.Error(w, "Failed to load storefront", http.StatusInternalServerError)
+			return
+		}
+		// Show what the organizer typed, not what was saved before
+		edited := *profile
+		edited.Slug = req.Slug
+		edited.DisplayName = req.DisplayName
+		edited.Description = req.Description
+		edited.WebsiteURL = req.WebsiteURL
+
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		component := pages.OrganizerStorefrontEditPage(user, &edited, false, err.Error())
+		if err := component.Render(r.Context(), w); err != nil {
+			http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	http.Redirect(w, r, "/organizer/storefront?saved=1", http.StatusSeeOther)
+}
+
+// storefrontDescription is the meta description of a storefront
+func storefrontDescription(profile *models.OrganizerProfile) string {
+	description := strings.Join(strings.Fields(profile.Description), " ")
+	if description == "" {
+		return "Upcoming events by " + profile.DisplayName
+	}
+	if runes := []rune(description); len(runes) > 160 {
+		description = strings.TrimSpace(string(runes[:157])) + "..."
+	}
+	return description
+}
+
+// absoluteURL turns a site-relative URL into an absolute one
+func absoluteURL(baseURL, url string) string {
+	if url == "" || strings.HasPrefix(url, "http://") || strings.HasPrefix(url, "https://") {
+		return url
+	}
+	return baseURL + url
+}
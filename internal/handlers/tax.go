@@ -0,0 +1,227 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+)
+
+// Ways an event can be taxed, as chosen on its tax settings page
+const (
+	eventTaxDefault = "default"
+	eventTaxCustom  = "custom"
+	eventTaxExempt  = "exempt"
+)
+
+// TaxHandler handles organizers' tax settings and their events' overrides
+type TaxHandler struct {
+	taxService   *services.TaxService
+	eventService services.EventServiceInterface
+}
+
+// NewTaxHandler creates a new tax handler
+func NewTaxHandler(taxService *services.TaxService, eventService services.EventServiceInterface) *TaxHandler {
+	return &TaxHandler{
+		taxService:   taxService,
+		eventService: eventService,
+	}
+}
+
+// SettingsPage handles GET /organizer/tax, showing the tax the organizer
+// charges on their tickets
+func (h *TaxHandler) SettingsPage(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/login", http.StatusSeeOther)
+		return
+	}
+
+	settings, err := h.taxService.GetSettings(user.ID)
+	if err != nil {
+		http.Error(w, "Failed to load tax settings", http.StatusInternalServerError)
+		return
+	}
+
+	formData := map[string]string{
+		"label":  settings.Label,
+		"rate":   taxRateFormValue(settings.RateBasisPoints),
+		"tax_id": settings.TaxID,
+	}
+	if settings.Enabled {
+		formData["enabled"] = "on"
+	}
+	if settings.Inclusive {
+		formData["inclusive"] = "on"
+	}
+
+	h.renderSettings(w, r, http.StatusOK, user, formData, r.URL.Query().Get("saved") == "1", "")
+}
+
+// UpdateSettings handles POST /organizer/tax
+func (h *TaxHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	formData := map[string]string{
+		"enabled":   r.FormValue("enabled"),
+		"label":     r.FormValue("label"),
+		"rate":      r.FormValue("rate"),
+		"inclusive": r.FormValue("inclusive"),
+		"tax_id":    r.FormValue("tax_id"),
+	}
+
+	rate, err := models.ParseTaxRate(formData["rate"])
+	if err != nil {
+		h.renderSettings(w, r, http.StatusBadRequest, user, formData, false, err.Error())
+		return
+	}
+
+	// Unchecked checkboxes are not submitted
+	settings := &models.TaxSettings{
+		OrganizerID:     user.ID,
+		Enabled:         formData["enabled"] == "on",
+		Label:           formData["label"],
+		RateBasisPoints: rate,
+		Inclusive:       formData["inclusive"] == "on",
+		TaxID:           formData["tax_id"],
+	}
+	if err := settings.Validate(); err != nil {
+		h.renderSettings(w, r, http.StatusBadRequest, user, formData, false, err.Error())
+		return
+	}
+
+	if err := h.taxService.UpdateSettings(settings); err != nil {
+		http.Error(w, "Failed to save tax settings", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/organizer/tax?saved=1", http.StatusSeeOther)
+}
+
+// renderSettings renders the organizer's tax settings page
+func (h *TaxHandler) renderSettings(w http.ResponseWriter, r *http.Request, status int, user *models.User, formData map[string]string, saved bool, errorMsg string) {
+	w.WriteHeader(status)
+	component := pages.OrganizerTaxPage(user, formData, saved, errorMsg)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// EventSettingsPage shows how one of the organizer's events is taxed
+func (h *TaxHandler) EventSettingsPage(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	override, err := h.taxService.GetOverride(event.ID)
+	if err != nil {
+		http.Error(w, "Failed to load tax settings", http.StatusInternalServerError)
+		return
+	}
+
+	formData := map[string]string{"mode": eventTaxDefault}
+	if override != nil {
+		formData["mode"] = eventTaxCustom
+		if override.Exempt {
+			formData["mode"] = eventTaxExempt
+		}
+		formData["rate"] = taxRateFormValue(override.RateBasisPoints)
+		if override.Inclusive {
+			formData["inclusive"] = "on"
+		}
+	}
+
+	h.renderEventSettings(w, r, http.StatusOK, user, event, formData, r.URL.Query().Get("saved") == "1", "")
+}
+
+// UpdateEventSettings taxes an event at its organizer's rate, a rate of its
+// own or not at all
+func (h *TaxHandler) UpdateEventSettings(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	formData := map[string]string{
+		"mode":      r.FormValue("mode"),
+		"rate":      r.FormValue("rate"),
+		"inclusive": r.FormValue("inclusive"),
+	}
+
+	var override *models.EventTaxOverride
+	switch formData["mode"] {
+	case eventTaxDefault:
+	case eventTaxExempt:
+		override = &models.EventTaxOverride{Exempt: true}
+	case eventTaxCustom:
+		rate, err := models.ParseTaxRate(formData["rate"])
+		if err != nil {
+			h.renderEventSettings(w, r, http.StatusBadRequest, user, event, formData, false, err.Error())
+			return
+		}
+		override = &models.EventTaxOverride{RateBasisPoints: rate, Inclusive: formData["inclusive"] == "on"}
+	default:
+		h.renderEventSettings(w, r, http.StatusBadRequest, user, event, formData, false, "Choose how the event is taxed")
+		return
+	}
+
+	if override != nil {
+		if err := override.Validate(); err != nil {
+			h.renderEventSettings(w, r, http.StatusBadRequest, user, event, formData, false, err.Error())
+			return
+		}
+	}
+
+	if err := h.taxService.UpdateOverride(event.ID, override); err != nil {
+		http.Error(w, "Failed to save tax settings", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/organizer/events/"+strconv.Itoa(event.ID)+"/tax?saved=1", http.StatusSeeOther)
+}
+
+// renderEventSettings renders an event's tax settings page, with the
+// organizer's settings it would otherwise be taxed with
+func (h *TaxHandler) renderEventSettings(w http.ResponseWriter, r *http.Request, status int, user *models.User, event *models.Event, formData map[string]string, saved bool, errorMsg string) {
+	settings, err := h.taxService.GetSettings(user.ID)
+	if err != nil {
+		http.Error(w, "Failed to load tax settings", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(status)
+	component := pages.EventTaxPage(user, event, settings, formData, saved, errorMsg)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// taxRateFormValue formats a rate in basis points as the percentage entered
+// in tax forms, empty for no rate
+func taxRateFormValue(basisPoints int) string {
+	if basisPoints == 0 {
+		return ""
+	}
+	return strings.TrimSuffix(models.FormatTaxRate(basisPoints), "%")
+}
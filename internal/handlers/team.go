@@ -0,0 +1,167 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+
+	"event-ticketing-platform/internal/i18n"
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// TeamHandler handles organizers inviting team members and members
+// accepting their invitations
+type TeamHandler struct {
+	teamService *services.TeamService
+}
+
+// NewTeamHandler creates a new team handler
+func NewTeamHandler(teamService *services.TeamService) *TeamHandler {
+	return &TeamHandler{
+		teamService: teamService,
+	}
+}
+
+// TeamPage handles GET /organizer/team
+func (h *TeamHandler) TeamPage(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
+		return
+	}
+
+	notice := ""
+	if r.URL.Query().Get("invited") == "1" {
+		notice = "Invitation sent. It can be accepted for 7 days."
+	}
+	h.renderTeamPage(w, r, user, http.StatusOK, notice, "")
+}
+
+// Invite handles POST /organizer/team, emailing an invitation in the
+// organizer's language
+func (h *TeamHandler) Invite(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	var permissions []models.TeamPermission
+	for _, value := range r.Form["permissions"] {
+		permissions = append(permissions, models.TeamPermission(value))
+	}
+
+	locale := i18n.FromAcceptLanguage(r.Header.Get("Accept-Language"))
+	if _, err := h.teamService.Invite(user.ID, r.FormValue("email"), permissions, locale); err != nil {
+		h.renderTeamPage(w, r, user, http.StatusUnprocessableEntity, "", err.Error())
+		return
+	}
+
+	http.Redirect(w, r, "/organizer/team?invited=1", http.StatusSeeOther)
+}
+
+// RemoveMember handles POST /organizer/team/{id}/remove
+func (h *TeamHandler) RemoveMember(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
+		return
+	}
+
+	memberID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid team member ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.teamService.RemoveMember(user.ID, memberID); err != nil {
+		http.Error(w, "Team member not found", http.StatusNotFound)
+		return
+	}
+
+	http.Redirect(w, r, "/organizer/team", http.StatusSeeOther)
+}
+
+// InvitationPage handles GET /team/invitations/{token}
+func (h *TeamHandler) InvitationPage(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	token := chi.URLParam(r, "token")
+
+	invitation, err := h.teamService.GetInvitation(token)
+	if err != nil {
+		h.renderInvitationPage(w, r, user, nil, token, http.StatusNotFound, invitationError(err))
+		return
+	}
+	h.renderInvitationPage(w, r, user, invitation, token, http.StatusOK, "")
+}
+
+// AcceptInvitation handles POST /team/invitations/{token}
+func (h *TeamHandler) AcceptInvitation(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
+		return
+	}
+	token := chi.URLParam(r, "token")
+
+	if _, err := h.teamService.AcceptInvitation(token, user.ID); err != nil {
+		invitation, lookupErr := h.teamService.GetInvitation(token)
+		if lookupErr != nil {
+			h.renderInvitationPage(w, r, user, nil, token, http.StatusNotFound, invitationError(lookupErr))
+			return
+		}
+		h.renderInvitationPage(w, r, user, invitation, token, http.StatusUnprocessableEntity, invitationError(err))
+		return
+	}
+
+	http.Redirect(w, r, "/organizer/events", http.StatusSeeOther)
+}
+
+// renderTeamPage renders the organizer's team page with a notice or error
+func (h *TeamHandler) renderTeamPage(w http.ResponseWriter, r *http.Request, user *models.User, status int, notice, formError string) {
+	members, err := h.teamService.GetMembers(user.ID)
+	if err != nil {
+		http.Error(w, "Failed to load team", http.StatusInternalServerError)
+		return
+	}
+	teams, err := h.teamService.GetTeams(user.ID)
+	if err != nil {
+		http.Error(w, "Failed to load team", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(status)
+	component := pages.OrganizerTeamPage(user, members, teams, notice, formError)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+	}
+}
+
+// renderInvitationPage renders a team invitation, or why it can't be accepted
+func (h *TeamHandler) renderInvitationPage(w http.ResponseWriter, r *http.Request, user *models.User, invitation *models.OrganizerMember, token string, status int, formError string) {
+	w.WriteHeader(status)
+	component := pages.TeamInvitationPage(user, invitation, token, formError)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+	}
+}
+
+// invitationError is the message shown when an invitation can't be used
+func invitationError(err error) string {
+	switch {
+	case errors.Is(err, services.ErrTeamInvitationExpired), errors.Is(err, services.ErrTeamInvitationWrongAccount):
+		return err.Error() + "."
+	default:
+		return "This invitation is no longer valid. It may have been cancelled or already accepted."
+	}
+}
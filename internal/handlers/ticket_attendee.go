@@ -0,0 +1,82 @@
+package handlers
+
+import (
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// SetTicketAttendeeService lets attendees change who their tickets are for
+// from the order details page
+func (h *DashboardHandler) SetTicketAttendeeService(attendeeService *services.TicketAttendeeService) {
+	h.attendeeService = attendeeService
+}
+
+// attendeeEditView reports whether the event's tickets can still be renamed,
+// with the outcome of the last change from the query string
+func (h *DashboardHandler) attendeeEditView(r *http.Request, event *models.Event) *pages.AttendeeEditView {
+	if h.attendeeService == nil {
+		return nil
+	}
+	return &pages.AttendeeEditView{
+		Editable:      h.attendeeService.CanEdit(event),
+		EditableUntil: h.attendeeService.EditableUntil(event),
+		Saved:         r.URL.Query().Get("attendee_saved") == "1",
+		Error:         r.URL.Query().Get("attendee_error"),
+	}
+}
+
+// UpdateTicketAttendee handles POST /dashboard/tickets/{id}/attendee
+func (h *DashboardHandler) UpdateTicketAttendee(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
+		return
+	}
+
+	if h.attendeeService == nil {
+		http.Error(w, "Named tickets are not available", http.StatusNotFound)
+		return
+	}
+
+	ticketID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ticket ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	attendee := models.TicketAttendee{
+		Name:  r.FormValue("attendee_name"),
+		Email: r.FormValue("attendee_email"),
+	}
+	ticket, err := h.attendeeService.UpdateAttendee(ticketID, user.ID, attendee)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "not found"), strings.Contains(err.Error(), "does not belong"):
+			http.Error(w, "Ticket not found", http.StatusNotFound)
+			return
+		case strings.HasPrefix(err.Error(), "failed to"):
+			http.Error(w, "Failed to update attendee", http.StatusInternalServerError)
+			return
+		}
+
+		// Validation and cutoff errors are shown on the order page
+		http.Redirect(w, r, "/dashboard/orders/"+strconv.Itoa(ticket.OrderID)+"?attendee_error="+url.QueryEscape(err.Error()), http.StatusSeeOther)
+		return
+	}
+
+	http.Redirect(w, r, "/dashboard/orders/"+strconv.Itoa(ticket.OrderID)+"?attendee_saved=1", http.StatusSeeOther)
+}
@@ -0,0 +1,80 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// TicketCalendarHandler serves iCalendar downloads and attendee calendar feeds
+type TicketCalendarHandler struct {
+	calendarService *services.TicketCalendarService
+	eventService    services.EventServiceInterface
+}
+
+// NewTicketCalendarHandler creates a new ticket calendar handler
+func NewTicketCalendarHandler(calendarService *services.TicketCalendarService, eventService services.EventServiceInterface) *TicketCalendarHandler {
+	return &TicketCalendarHandler{
+		calendarService: calendarService,
+		eventService:    eventService,
+	}
+}
+
+// EventCalendar handles GET /events/{id}/calendar.ics
+func (h *TicketCalendarHandler) EventCalendar(w http.ResponseWriter, r *http.Request) {
+	eventID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	event, err := h.eventService.GetEventByID(eventID)
+	if err != nil || (event.Status != models.StatusPublished && event.Status != models.StatusCancelled) {
+		http.Error(w, "Event not found", http.StatusNotFound)
+		return
+	}
+
+	data := h.calendarService.EventICS(event)
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"event_%d.ics\"", eventID))
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+
+	w.Write(data)
+}
+
+// Feed handles GET /dashboard/calendar.ics. Signed-in users get their own
+// feed; calendar apps authenticate with the signed user and token parameters
+// from the feed URL since they have no session.
+func (h *TicketCalendarHandler) Feed(w http.ResponseWriter, r *http.Request) {
+	var userID int
+	if user := middleware.GetUserFromContext(r.Context()); user != nil {
+		userID = user.ID
+	} else {
+		id, err := strconv.Atoi(r.URL.Query().Get("user"))
+		if err != nil || !h.calendarService.ValidFeedToken(id, r.URL.Query().Get("token")) {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
+		userID = id
+	}
+
+	data, err := h.calendarService.GetAttendeeFeed(userID)
+	if err != nil {
+		http.Error(w, "Failed to build calendar feed", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+	w.Header().Set("Content-Disposition", "inline; filename=\"tickets.ics\"")
+	w.Header().Set("Cache-Control", "private, max-age=900")
+	w.Header().Set("Content-Length", strconv.Itoa(len(data)))
+
+	w.Write(data)
+}
@@ -0,0 +1,115 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// TicketScanHandler handles ticket scanning at event entrances and the scan audit export
+type TicketScanHandler struct {
+	scanService   *services.TicketScanService
+	checkInBroker *services.CheckInBroker
+}
+
+// NewTicketScanHandler creates a new ticket scan handler
+func NewTicketScanHandler(scanService *services.TicketScanService) *TicketScanHandler {
+	return &TicketScanHandler{
+		scanService: scanService,
+	}
+}
+
+// ScanTicket handles POST /organizer/events/{id}/scans. It accepts the scanned
+// QR code, gate and device as JSON or form values and returns the scan as JSON.
+func (h *TicketScanHandler) ScanTicket(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	eventID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	req := &services.ScanRequest{}
+	if strings.HasPrefix(r.Header.Get("Content-Type"), "application/json") {
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			http.Error(w, "Invalid JSON", http.StatusBadRequest)
+			return
+		}
+	} else {
+		req.QRCode = r.FormValue("qr_code")
+		req.Gate = r.FormValue("gate")
+		req.Device = r.FormValue("device")
+	}
+
+	req.EventID = eventID
+	req.StaffUserID = user.ID
+	if req.Device == "" {
+		req.Device = r.UserAgent()
+	}
+
+	scan, err := h.scanService.ScanTicket(req)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "does not have access"):
+			http.Error(w, "Forbidden", http.StatusForbidden)
+		case strings.Contains(err.Error(), "event not found"):
+			http.Error(w, "Event not found", http.StatusNotFound)
+		case strings.Contains(err.Error(), "required"):
+			http.Error(w, err.Error(), http.StatusBadRequest)
+		default:
+			http.Error(w, "Failed to scan ticket", http.StatusInternalServerError)
+		}
+		return
+	}
+
+	if err := writeJSON(w, scan); err != nil {
+		http.Error(w, fmt.Sprintf("Failed to write JSON response: %v", err), http.StatusInternalServerError)
+		return
+	}
+}
+
+// ExportScans handles GET /organizer/events/{id}/scans/export
+func (h *TicketScanHandler) ExportScans(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	eventID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	csvData, err := h.scanService.ExportEventScans(eventID, user.ID)
+	if err != nil {
+		switch {
+		case strings.Contains(err.Error(), "does not have access"):
+			http.Error(w, "Forbidden", http.StatusForbidden)
+		case strings.Contains(err.Error(), "event not found"):
+			http.Error(w, "Event not found", http.StatusNotFound)
+		default:
+			http.Error(w, fmt.Sprintf("Failed to export scans: %v", err), http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "text/csv")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"event_%d_scans.csv\"", eventID))
+	w.Header().Set("Content-Length", strconv.Itoa(len(csvData)))
+
+	w.Write(csvData)
+}
@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
+	"event-ticketing-platform/internal/logging"
 	"event-ticketing-platform/internal/middleware"
 	"event-ticketing-platform/internal/models"
 	"event-ticketing-platform/internal/services"
@@ -18,6 +20,7 @@ import (
 type TicketTypeHandler struct {
 	ticketService services.TicketServiceInterface
 	eventService  services.EventServiceInterface
+	priceHistory  *services.PriceHistoryService
 }
 
 // NewTicketTypeHandler creates a new ticket type handler
@@ -28,6 +31,11 @@ func NewTicketTypeHandler(ticketService services.TicketServiceInterface, eventSe
 	}
 }
 
+// SetPriceHistoryService shows the event's price changes on the ticket types page
+func (h *TicketTypeHandler) SetPriceHistoryService(priceHistory *services.PriceHistoryService) {
+	h.priceHistory = priceHistory
+}
+
 // TicketTypesPage displays the ticket types for an event
 func (h *TicketTypeHandler) TicketTypesPage(w http.ResponseWriter, r *http.Request) {
 	user := middleware.GetUserFromContext(r.Context())
@@ -80,8 +88,15 @@ func (h *TicketTypeHandler) TicketTypesPage(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
+	var priceHistory []*models.TicketPriceChange
+	if h.priceHistory != nil {
+		if priceHistory, err = h.priceHistory.GetEventHistory(eventID); err != nil {
+			logging.FromContext(r.Context()).Warn("failed to load price history", "event_id", eventID, "error", err)
+		}
+	}
+
 	// Render the full ticket types page
-	component := pages.TicketTypesPage(user, event, ticketTypes)
+	component := pages.TicketTypesPage(user, event, ticketTypes, priceHistory)
 	err = component.Render(r.Context(), w)
 	if err != nil {
 		http.Error(w, "Failed to render page", http.StatusInternalServerError)
@@ -172,6 +187,7 @@ func (h *TicketTypeHandler) CreateTicketTypeSubmit(w http.ResponseWriter, r *htt
 	quantityStr := r.FormValue("quantity")
 	saleStartStr := r.FormValue("sale_start")
 	saleEndStr := r.FormValue("sale_end")
+	payWhatYouWant := r.FormValue("pay_what_you_want") == "on" // Unchecked checkboxes are not submitted
 
 	// Validate required fields
 	errors := make(map[string]string)
@@ -240,6 +256,9 @@ func (h *TicketTypeHandler) CreateTicketTypeSubmit(w http.ResponseWriter, r *htt
 			"sale_start":  saleStartStr,
 			"sale_end":    saleEndStr,
 		}
+		if payWhatYouWant {
+			formData["pay_what_you_want"] = "on"
+		}
 
 		component := pages.CreateTicketTypePage(user, event, formData, errors)
 		err = component.Render(r.Context(), w)
@@ -251,13 +270,14 @@ func (h *TicketTypeHandler) CreateTicketTypeSubmit(w http.ResponseWriter, r *htt
 
 	// Create the ticket type
 	createReq := &models.TicketTypeCreateRequest{
-		EventID:     eventID,
-		Name:        name,
-		Description: description,
-		Price:       price,
-		Quantity:    quantity,
-		SaleStart:   saleStart,
-		SaleEnd:     saleEnd,
+		EventID:        eventID,
+		Name:           name,
+		Description:    description,
+		Price:          price,
+		Quantity:       quantity,
+		SaleStart:      saleStart,
+		SaleEnd:        saleEnd,
+		PayWhatYouWant: payWhatYouWant,
 	}
 
 	_, err = h.ticketService.CreateTicketType(createReq)
@@ -273,6 +293,9 @@ func (h *TicketTypeHandler) CreateTicketTypeSubmit(w http.ResponseWriter, r *htt
 			"sale_start":  saleStartStr,
 			"sale_end":    saleEndStr,
 		}
+		if payWhatYouWant {
+			formData["pay_what_you_want"] = "on"
+		}
 
 		component := pages.CreateTicketTypePage(user, event, formData, errors)
 		err = component.Render(r.Context(), w)
@@ -394,6 +417,7 @@ func (h *TicketTypeHandler) UpdateTicketTypeSubmit(w http.ResponseWriter, r *htt
 	quantityStr := r.FormValue("quantity")
 	saleStartStr := r.FormValue("sale_start")
 	saleEndStr := r.FormValue("sale_end")
+	payWhatYouWant := r.FormValue("pay_what_you_want") == "on" // Unchecked checkboxes are not submitted
 
 	// Validate required fields
 	errors := make(map[string]string)
@@ -463,6 +487,9 @@ func (h *TicketTypeHandler) UpdateTicketTypeSubmit(w http.ResponseWriter, r *htt
 			"sale_start":  saleStartStr,
 			"sale_end":    saleEndStr,
 		}
+		if payWhatYouWant {
+			formData["pay_what_you_want"] = "on"
+		}
 
 		component := pages.EditTicketTypePage(user, event, ticketType, formData, errors)
 		err = component.Render(r.Context(), w)
@@ -474,12 +501,13 @@ func (h *TicketTypeHandler) UpdateTicketTypeSubmit(w http.ResponseWriter, r *htt
 
 	// Update the ticket type
 	updateReq := &models.TicketTypeUpdateRequest{
-		Name:        name,
-		Description: description,
-		Price:       price,
-		Quantity:    quantity,
-		SaleStart:   saleStart,
-		SaleEnd:     saleEnd,
+		Name:           name,
+		Description:    description,
+		Price:          price,
+		Quantity:       quantity,
+		SaleStart:      saleStart,
+		SaleEnd:        saleEnd,
+		PayWhatYouWant: payWhatYouWant,
 	}
 
 	_, err = h.ticketService.UpdateTicketType(ticketTypeID, updateReq)
@@ -496,6 +524,9 @@ func (h *TicketTypeHandler) UpdateTicketTypeSubmit(w http.ResponseWriter, r *htt
 			"sale_start":  saleStartStr,
 			"sale_end":    saleEndStr,
 		}
+		if payWhatYouWant {
+			formData["pay_what_you_want"] = "on"
+		}
 
 		component := pages.EditTicketTypePage(user, event, ticketType, formData, errors)
 		err = component.Render(r.Context(), w)
@@ -576,4 +607,86 @@ func (h *TicketTypeHandler) DeleteTicketType(w http.ResponseWriter, r *http.Requ
 
 	// Redirect to ticket types list
 	http.Redirect(w, r, fmt.Sprintf("/organizer/events/%d/tickets", eventID), http.StatusSeeOther)
-}
\ No newline at end of file
+}
+
+// ticketTierManager is implemented by ticket services that support pricing tiers
+type ticketTierManager interface {
+	SetNextTier(ticketTypeID int, nextTierID *int) error
+}
+
+// SetNextTier sets the ticket type that goes on sale once this one sells out
+// or its sale ends
+func (h *TicketTypeHandler) SetNextTier(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Error(w, "Unauthorized", http.StatusUnauthorized)
+		return
+	}
+
+	// Get event ID and ticket type ID from URL
+	eventID, err := strconv.Atoi(chi.URLParam(r, "eventId"))
+	if err != nil {
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+	ticketTypeID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ticket type ID", http.StatusBadRequest)
+		return
+	}
+
+	// Check if user can edit this event
+	canEdit, err := h.eventService.CanUserEditEvent(eventID, user.ID)
+	if err != nil {
+		http.Error(w, "Failed to check permissions", http.StatusInternalServerError)
+		return
+	}
+	if !canEdit {
+		http.Error(w, "Forbidden", http.StatusForbidden)
+		return
+	}
+
+	// Verify ticket type belongs to the event
+	ticketType, err := h.ticketService.GetTicketTypeByID(ticketTypeID)
+	if err != nil {
+		http.Error(w, "Ticket type not found", http.StatusNotFound)
+		return
+	}
+	if ticketType.EventID != eventID {
+		http.Error(w, "Ticket type does not belong to this event", http.StatusBadRequest)
+		return
+	}
+
+	tiers, ok := h.ticketService.(ticketTierManager)
+	if !ok {
+		http.Error(w, "Pricing tiers are not available", http.StatusNotImplemented)
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	// An empty selection removes the next tier
+	var nextTierID *int
+	if value := r.FormValue("next_tier_id"); value != "" {
+		id, err := strconv.Atoi(value)
+		if err != nil {
+			http.Error(w, "Invalid next tier", http.StatusBadRequest)
+			return
+		}
+		nextTierID = &id
+	}
+
+	if err := tiers.SetNextTier(ticketTypeID, nextTierID); err != nil {
+		if strings.Contains(err.Error(), "failed to") {
+			http.Error(w, "Failed to set next tier", http.StatusInternalServerError)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	http.Redirect(w, r, fmt.Sprintf("/organizer/events/%d/tickets?success=updated", eventID), http.StatusSeeOther)
+}
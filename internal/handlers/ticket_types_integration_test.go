@@ -76,6 +76,11 @@ func (m *MockEventServiceForTicketTypes) GetEventByID(id int) (*models.Event, er
 	return args.Get(0).(*models.Event), args.Error(1)
 }
 
+func (m *MockEventServiceForTicketTypes) GetEventBySlug(slug string) (*models.Event, error) {
+	args := m.Called(slug)
+	return args.Get(0).(*models.Event), args.Error(1)
+}
+
 func (m *MockEventServiceForTicketTypes) CanUserEditEvent(eventID, userID int) (bool, error) {
 	args := m.Called(eventID, userID)
 	return args.Bool(0), args.Error(1)
@@ -122,6 +127,10 @@ func (m *MockEventServiceForTicketTypes) CanUserDeleteEvent(eventID, userID int)
 	return false, models.ErrNotImplemented
 }
 
+func (m *MockEventServiceForTicketTypes) CanUserManageEvent(eventID, userID int) (bool, error) {
+	return false, models.ErrNotImplemented
+}
+
 func (m *MockEventServiceForTicketTypes) UpdateEventStatus(eventID int, status models.EventStatus, organizerID int) (*models.Event, error) {
 	return nil, models.ErrNotImplemented
 }
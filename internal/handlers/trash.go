@@ -0,0 +1,83 @@
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// TrashHandler handles admins viewing and restoring deleted users, events
+// and ticket types
+type TrashHandler struct {
+	trashService *services.TrashService
+}
+
+// NewTrashHandler creates a new trash handler
+func NewTrashHandler(trashService *services.TrashService) *TrashHandler {
+	return &TrashHandler{
+		trashService: trashService,
+	}
+}
+
+// TrashPage handles GET /admin/trash, listing the deleted records of the
+// kind in the query, users by default
+func (h *TrashHandler) TrashPage(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	kind := models.TrashUsers
+	if value := r.URL.Query().Get("kind"); value != "" {
+		parsed, err := models.ParseTrashKind(value)
+		if err != nil {
+			http.Error(w, "Invalid trash kind", http.StatusBadRequest)
+			return
+		}
+		kind = parsed
+	}
+
+	items, err := h.trashService.List(kind)
+	if err != nil {
+		http.Error(w, "Failed to load trash", http.StatusInternalServerError)
+		return
+	}
+
+	component := pages.AdminTrashPage(user, kind, items, h.trashService.Retention(), r.URL.Query().Get("restored") == "1")
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// Restore handles POST /admin/trash/{kind}/{id}/restore
+func (h *TrashHandler) Restore(w http.ResponseWriter, r *http.Request) {
+	user, ok := requireAdmin(w, r)
+	if !ok {
+		return
+	}
+
+	kind, err := models.ParseTrashKind(chi.URLParam(r, "kind"))
+	if err != nil {
+		http.Error(w, "Invalid trash kind", http.StatusBadRequest)
+		return
+	}
+
+	id, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ID", http.StatusBadRequest)
+		return
+	}
+
+	if err := h.trashService.Restore(user.ID, kind, id, r); err != nil {
+		http.Error(w, "Failed to restore: "+err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	http.Redirect(w, r, "/admin/trash?kind="+string(kind)+"&restored=1", http.StatusSeeOther)
+}
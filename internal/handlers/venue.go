@@ -0,0 +1,259 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/layouts"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// VenueHandler handles organizers' venues and the public venue pages
+type VenueHandler struct {
+	venueService *services.VenueService
+	baseURL      string
+}
+
+// NewVenueHandler creates a new venue handler
+func NewVenueHandler(venueService *services.VenueService, baseURL string) *VenueHandler {
+	return &VenueHandler{
+		venueService: venueService,
+		baseURL:      strings.TrimRight(baseURL, "/"),
+	}
+}
+
+// VenuePage handles GET /venues/{slug}
+func (h *VenueHandler) VenuePage(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+
+	page, err := h.venueService.GetVenuePage(chi.URLParam(r, "slug"))
+	if err != nil {
+		http.Error(w, "Venue not found", http.StatusNotFound)
+		return
+	}
+
+	venue := page.Venue
+	meta := layouts.PageMeta{
+		Description:  venueDescription(venue),
+		CanonicalURL: h.baseURL + venue.Path(),
+		ImageURL:     absoluteURL(h.baseURL, venue.CoverImage()),
+	}
+
+	component := pages.VenuePage(user, page, meta)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// ListVenues handles GET /organizer/venues
+func (h *VenueHandler) ListVenues(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
+		return
+	}
+
+	venues, err := h.venueService.GetVenues(user.ID)
+	if err != nil {
+		http.Error(w, "Failed to load venues", http.StatusInternalServerError)
+		return
+	}
+
+	component := pages.OrganizerVenuesPage(user, venues, r.URL.Query().Get("saved") == "1", r.URL.Query().Get("deleted") == "1")
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// NewVenuePage handles GET /organizer/venues/new
+func (h *VenueHandler) NewVenuePage(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
+		return
+	}
+
+	component := pages.OrganizerVenueFormPage(user, &models.Venue{}, "")
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// CreateVenue handles POST /organizer/venues
+func (h *VenueHandler) CreateVenue(w http.ResponseWriter, r *http.Request) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
+		return
+	}
+
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	req, err := parseVenueRequest(r)
+	if err == nil {
+		_, err = h.venueService.CreateVenue(user.ID, req)
+	}
+	if err != nil {
+		h.renderFormError(w, r, user, &models.Venue{}, req, err)
+		return
+	}
+
+	http.Redirect(w, r, "/organizer/venues?saved=1", http.StatusSeeOther)
+}
+
+// EditVenuePage handles GET /organizer/venues/{id}/edit
+func (h *VenueHandler) EditVenuePage(w http.ResponseWriter, r *http.Request) {
+	user, venue, ok := h.loadVenue(w, r)
+	if !ok {
+		return
+	}
+
+	component := pages.OrganizerVenueFormPage(user, venue, "")
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// UpdateVenue handles POST /organizer/venues/{id}
+func (h *VenueHandler) UpdateVenue(w http.ResponseWriter, r *http.Request) {
+	user, venue, ok := h.loadVenue(w, r)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseMultipartForm(32 << 20); err != nil {
+		http.Error(w, "Invalid form data", http.StatusBadRequest)
+		return
+	}
+
+	req, err := parseVenueRequest(r)
+	if err == nil {
+		_, err = h.venueService.UpdateVenue(venue.ID, user.ID, req)
+	}
+	if err != nil {
+		h.renderFormError(w, r, user, venue, req, err)
+		return
+	}
+
+	http.Redirect(w, r, "/organizer/venues?saved=1", http.StatusSeeOther)
+}
+
+// DeleteVenue handles POST /organizer/venues/{id}/delete
+func (h *VenueHandler) DeleteVenue(w http.ResponseWriter, r *http.Request) {
+	user, venue, ok := h.loadVenue(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.venueService.DeleteVenue(venue.ID, user.ID); err != nil {
+		http.Error(w, "Failed to delete venue", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/organizer/venues?deleted=1", http.StatusSeeOther)
+}
+
+// loadVenue loads the venue in the URL for the signed-in organizer, writing
+// the error response when it can't
+func (h *VenueHandler) loadVenue(w http.ResponseWriter, r *http.Request) (*models.User, *models.Venue, bool) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
+		return nil, nil, false
+	}
+
+	venueID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid venue ID", http.StatusBadRequest)
+		return nil, nil, false
+	}
+
+	venue, err := h.venueService.GetVenue(venueID, user.ID)
+	if err != nil {
+		if errors.Is(err, services.ErrVenueNotFound) {
+			http.Error(w, "Venue not found", http.StatusNotFound)
+			return nil, nil, false
+		}
+		http.Error(w, "Failed to load venue", http.StatusInternalServerError)
+		return nil, nil, false
+	}
+	return user, venue, true
+}
+
+// renderFormError re-renders the venue form with what the organizer typed
+// and why it wasn't saved
+func (h *VenueHandler) renderFormError(w http.ResponseWriter, r *http.Request, user *models.User, venue *models.Venue, req *services.VenueRequest, err error) {
+	edited := *venue
+	edited.Name = req.Name
+	edited.Address = req.Address
+	edited.City = req.City
+	edited.Capacity = req.Capacity
+	edited.Coordinates = req.Coordinates
+	edited.Description = req.Description
+
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	component := pages.OrganizerVenueFormPage(user, &edited, err.Error())
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+	}
+}
+
+// parseVenueRequest reads a submitted venue form. The request is returned
+// along with any error in the capacity or coordinates, so the form can be
+// shown again.
+func parseVenueRequest(r *http.Request) (*services.VenueRequest, error) {
+	req := &services.VenueRequest{
+		Name:         r.FormValue("name"),
+		Address:      r.FormValue("address"),
+		City:         r.FormValue("city"),
+		Description:  r.FormValue("description"),
+		RemoveImages: r.Form["remove_images"],
+	}
+	if r.MultipartForm != nil {
+		req.Images = r.MultipartForm.File["images"]
+	}
+
+	if capacity := strings.TrimSpace(r.FormValue("capacity")); capacity != "" {
+		value, err := strconv.Atoi(capacity)
+		if err != nil || value < 0 {
+			return req, errors.New("capacity must be a whole number of people")
+		}
+		req.Capacity = value
+	}
+
+	latitude, longitude := strings.TrimSpace(r.FormValue("latitude")), strings.TrimSpace(r.FormValue("longitude"))
+	if latitude != "" || longitude != "" {
+		req.Coordinates = models.ParseGeoPoint(latitude, longitude)
+		if req.Coordinates == nil {
+			return req, errors.New("enter both a latitude and a longitude, or leave them empty to look them up from the address")
+		}
+	}
+
+	return req, nil
+}
+
+// venueDescription is the meta description of a venue page
+func venueDescription(venue *models.Venue) string {
+	description := strings.Join(strings.Fields(venue.Description), " ")
+	if description == "" {
+		return "Upcoming events at " + venue.FullAddress()
+	}
+	if runes := []rune(description); len(runes) > 160 {
+		description = strings.TrimSpace(string(runes[:157])) + "..."
+	}
+	return description
+}
@@ -0,0 +1,212 @@
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+	"event-ticketing-platform/web/templates/pages"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// waitingRoomCookieMaxAge is how long a visitor's place in a queue is kept
+const waitingRoomCookieMaxAge = 48 * time.Hour
+
+// WaitingRoomHandler handles the virtual waiting rooms of high-demand
+// on-sales and their organizer settings
+type WaitingRoomHandler struct {
+	roomService  *services.WaitingRoomService
+	eventService services.EventServiceInterface
+}
+
+// NewWaitingRoomHandler creates a new waiting room handler
+func NewWaitingRoomHandler(roomService *services.WaitingRoomService, eventService services.EventServiceInterface) *WaitingRoomHandler {
+	return &WaitingRoomHandler{
+		roomService:  roomService,
+		eventService: eventService,
+	}
+}
+
+// WaitingRoomPage puts the visitor in the event's queue, unless they already
+// have a place, and shows where they are. Events without a waiting room
+// redirect to their page.
+func (h *WaitingRoomHandler) WaitingRoomPage(w http.ResponseWriter, r *http.Request) {
+	eventID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	event, err := h.eventService.GetEventByID(eventID)
+	if err != nil {
+		http.Error(w, "Event not found", http.StatusNotFound)
+		return
+	}
+
+	status, token, err := h.roomService.Join(eventID, waitingRoomToken(r, eventID))
+	if err != nil {
+		http.Error(w, "Failed to join the waiting room", http.StatusInternalServerError)
+		return
+	}
+	// Events without an enabled waiting room don't give out places
+	if status.Position == 0 {
+		http.Redirect(w, r, event.Path(), http.StatusSeeOther)
+		return
+	}
+	if token != "" {
+		http.SetCookie(w, &http.Cookie{
+			Name:     middleware.WaitingRoomCookie(eventID),
+			Value:    token,
+			Path:     "/",
+			MaxAge:   int(waitingRoomCookieMaxAge.Seconds()),
+			HttpOnly: true,
+			SameSite: http.SameSiteLaxMode,
+		})
+	}
+
+	w.Header().Set("Cache-Control", middleware.NoStoreCacheControl)
+	component := pages.WaitingRoomPage(middleware.GetUserFromContext(r.Context()), event, status)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// WaitingRoomStatus renders the visitor's place in the queue for the waiting
+// room page to poll. Visitors without a place are sent back to join.
+func (h *WaitingRoomHandler) WaitingRoomStatus(w http.ResponseWriter, r *http.Request) {
+	eventID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid event ID", http.StatusBadRequest)
+		return
+	}
+
+	status, err := h.roomService.Status(eventID, waitingRoomToken(r, eventID))
+	if errors.Is(err, services.ErrInvalidWaitingRoomToken) {
+		w.Header().Set("HX-Redirect", middleware.WaitingRoomPath(eventID))
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	if err != nil {
+		http.Error(w, "Failed to check the waiting room", http.StatusInternalServerError)
+		return
+	}
+
+	event, err := h.eventService.GetEventByID(eventID)
+	if err != nil {
+		http.Error(w, "Event not found", http.StatusNotFound)
+		return
+	}
+
+	w.Header().Set("Cache-Control", middleware.NoStoreCacheControl)
+	component := pages.WaitingRoomStatusPanel(event, status)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render status", http.StatusInternalServerError)
+		return
+	}
+}
+
+// SettingsPage shows the waiting room settings for one of the organizer's events
+func (h *WaitingRoomHandler) SettingsPage(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	room, err := h.roomService.GetWaitingRoom(event.ID)
+	if err != nil {
+		http.Error(w, "Failed to load waiting room", http.StatusInternalServerError)
+		return
+	}
+
+	formData := map[string]string{
+		"admit_per_minute": strconv.Itoa(room.AdmitPerMinute),
+	}
+	if room.Enabled {
+		formData["enabled"] = "on"
+	}
+	if !room.OpensAt.IsZero() {
+		formData["opens_at"] = room.OpensAt.Format("2006-01-02T15:04")
+	}
+
+	h.renderSettings(w, r, http.StatusOK, user, event, room, formData, r.URL.Query().Get("saved") == "1", "")
+}
+
+// UpdateSettings turns an event's waiting room on or off and sets when and
+// how quickly it admits visitors
+func (h *WaitingRoomHandler) UpdateSettings(w http.ResponseWriter, r *http.Request) {
+	user, event, ok := loadOrganizerEvent(w, r, h.eventService)
+	if !ok {
+		return
+	}
+
+	if err := r.ParseForm(); err != nil {
+		http.Error(w, "Failed to parse form", http.StatusBadRequest)
+		return
+	}
+
+	formData := map[string]string{
+		"enabled":          r.FormValue("enabled"),
+		"opens_at":         r.FormValue("opens_at"),
+		"admit_per_minute": r.FormValue("admit_per_minute"),
+	}
+
+	// Invalid times and rates are left zero for Validate to report
+	opensAt, _ := time.Parse("2006-01-02T15:04", formData["opens_at"])
+	admitPerMinute, _ := strconv.Atoi(strings.TrimSpace(formData["admit_per_minute"]))
+
+	room := &models.WaitingRoom{
+		EventID:        event.ID,
+		Enabled:        formData["enabled"] == "on", // Unchecked checkboxes are not submitted
+		OpensAt:        opensAt,
+		AdmitPerMinute: admitPerMinute,
+	}
+	if err := room.Validate(); err != nil {
+		current, loadErr := h.roomService.GetWaitingRoom(event.ID)
+		if loadErr != nil {
+			http.Error(w, "Failed to load waiting room", http.StatusInternalServerError)
+			return
+		}
+		h.renderSettings(w, r, http.StatusBadRequest, user, event, current, formData, false, err.Error())
+		return
+	}
+
+	if err := h.roomService.UpdateWaitingRoom(room); err != nil {
+		http.Error(w, "Failed to save waiting room", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, "/organizer/events/"+strconv.Itoa(event.ID)+"/waiting-room?saved=1", http.StatusSeeOther)
+}
+
+// renderSettings renders the waiting room settings page with the current queue
+func (h *WaitingRoomHandler) renderSettings(w http.ResponseWriter, r *http.Request, status int, user *models.User, event *models.Event, room *models.WaitingRoom, formData map[string]string, saved bool, errorMsg string) {
+	stats, err := h.roomService.GetStats(event.ID)
+	if err != nil {
+		http.Error(w, "Failed to load waiting room", http.StatusInternalServerError)
+		return
+	}
+
+	w.WriteHeader(status)
+	component := pages.EventWaitingRoomPage(user, event, room, stats, formData, saved, errorMsg)
+	if err := component.Render(r.Context(), w); err != nil {
+		http.Error(w, "Failed to render page", http.StatusInternalServerError)
+		return
+	}
+}
+
+// waitingRoomToken returns the visitor's position token for an event's
+// waiting room, if they have one
+func waitingRoomToken(r *http.Request, eventID int) string {
+	cookie, err := r.Cookie(middleware.WaitingRoomCookie(eventID))
+	if err != nil {
+		return ""
+	}
+	return cookie.Value
+}
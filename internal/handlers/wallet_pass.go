@@ -0,0 +1,124 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// walletPassProvider is implemented by ticket services that can generate
+// Apple Wallet and Google Wallet passes
+type walletPassProvider interface {
+	WalletPassOptions() services.WalletPassOptions
+	GenerateApplePass(ticket *models.Ticket, event *models.Event, order *models.Order) ([]byte, error)
+	GoogleWalletURL(tickets []*models.Ticket, event *models.Event, order *models.Order) (string, error)
+}
+
+// walletPassOptions reports which wallets the ticket service can add tickets to
+func (h *DashboardHandler) walletPassOptions() services.WalletPassOptions {
+	if provider, ok := h.ticketService.(walletPassProvider); ok {
+		return provider.WalletPassOptions()
+	}
+	return services.WalletPassOptions{}
+}
+
+// DownloadApplePass handles GET /dashboard/tickets/{id}/wallet/apple
+func (h *DashboardHandler) DownloadApplePass(w http.ResponseWriter, r *http.Request) {
+	provider, ok := h.ticketService.(walletPassProvider)
+	if !ok || !provider.WalletPassOptions().Apple {
+		http.Error(w, "Apple Wallet passes are not available", http.StatusNotFound)
+		return
+	}
+
+	ticket, order, event, ok := h.walletTicket(w, r)
+	if !ok {
+		return
+	}
+
+	passData, err := provider.GenerateApplePass(ticket, event, order)
+	if err != nil {
+		http.Error(w, "Failed to generate Apple Wallet pass", http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/vnd.apple.pkpass")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"ticket-%s-%d.pkpass\"", order.OrderNumber, ticket.ID))
+	w.Header().Set("Content-Length", strconv.Itoa(len(passData)))
+	w.Header().Set("Cache-Control", "no-cache, no-store, must-revalidate")
+
+	w.Write(passData)
+}
+
+// SaveToGoogleWallet handles GET /dashboard/tickets/{id}/wallet/google by
+// redirecting to Google Wallet's save page
+func (h *DashboardHandler) SaveToGoogleWallet(w http.ResponseWriter, r *http.Request) {
+	provider, ok := h.ticketService.(walletPassProvider)
+	if !ok || !provider.WalletPassOptions().Google {
+		http.Error(w, "Google Wallet passes are not available", http.StatusNotFound)
+		return
+	}
+
+	ticket, order, event, ok := h.walletTicket(w, r)
+	if !ok {
+		return
+	}
+
+	saveURL, err := provider.GoogleWalletURL([]*models.Ticket{ticket}, event, order)
+	if err != nil {
+		http.Error(w, "Failed to generate Google Wallet pass", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, saveURL, http.StatusSeeOther)
+}
+
+// walletTicket loads the ticket in the URL, checking that it belongs to the
+// current user and is still valid. It writes the error response when it fails.
+func (h *DashboardHandler) walletTicket(w http.ResponseWriter, r *http.Request) (*models.Ticket, *models.Order, *models.Event, bool) {
+	user := middleware.GetUserFromContext(r.Context())
+	if user == nil {
+		http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
+		return nil, nil, nil, false
+	}
+
+	ticketID, err := strconv.Atoi(chi.URLParam(r, "id"))
+	if err != nil {
+		http.Error(w, "Invalid ticket ID", http.StatusBadRequest)
+		return nil, nil, nil, false
+	}
+
+	ticket, err := h.ticketService.GetTicketByID(ticketID)
+	if err != nil {
+		http.Error(w, "Ticket not found", http.StatusNotFound)
+		return nil, nil, nil, false
+	}
+
+	order, err := h.orderService.GetOrderByID(ticket.OrderID, user.ID)
+	if err != nil {
+		http.Error(w, "Order not found", http.StatusNotFound)
+		return nil, nil, nil, false
+	}
+
+	if order.Status != models.OrderCompleted {
+		http.Error(w, "Ticket is not available yet", http.StatusBadRequest)
+		return nil, nil, nil, false
+	}
+	if ticket.Status == models.TicketRefunded {
+		http.Error(w, "This ticket has been refunded and is no longer valid", http.StatusBadRequest)
+		return nil, nil, nil, false
+	}
+
+	event, err := h.eventService.GetEventByID(order.EventID)
+	if err != nil {
+		http.Error(w, "Event not found", http.StatusNotFound)
+		return nil, nil, nil, false
+	}
+
+	return ticket, order, event, true
+}
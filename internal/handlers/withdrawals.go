@@ -279,7 +279,7 @@ func (h *WithdrawalHandler) UpdateWithdrawalStatus(w http.ResponseWriter, r *htt
 	adminNotes := r.FormValue("admin_notes")
 
 	// Update status
-	err = h.withdrawalService.UpdateWithdrawalStatus(withdrawalID, status, adminNotes)
+	err = h.withdrawalService.UpdateWithdrawalStatus(user.ID, withdrawalID, status, adminNotes, r)
 	if err != nil {
 		http.Error(w, "Failed to update withdrawal status", http.StatusInternalServerError)
 		return
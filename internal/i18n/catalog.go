@@ -0,0 +1,543 @@
+package i18n
+
+// catalog holds the email messages for every supported locale, keyed by
+// locale and message key. Messages use fmt verbs for their arguments.
+var catalog = map[string]map[string]string{
+	English: {
+		// Shared email text
+		"email.greeting":        "Dear %s,",
+		"email.contact_support": "If you have any questions, please contact our support team.",
+		"email.questions":       "If you have any questions about your order or need assistance, please don't hesitate to contact our support team.",
+		"email.sent_to":         "This email was sent to %s",
+		"email.team":            "Runtown Team",
+
+		// Email verification
+		"verify.subject":    "Verify your email address - Runtown",
+		"verify.heading":    "Verify Your Email Address",
+		"verify.intro":      "Thank you for creating an account with Runtown! To complete your registration and access all features, please verify your email address by clicking the button below:",
+		"verify.intro_text": "Thank you for creating an account with Runtown! To complete your registration and access all features, please verify your email address by visiting the following link:",
+		"verify.button":     "Verify My Email",
+		"verify.copy_link":  "Or copy and paste this link into your browser:",
+		"verify.expiry":     "Important: This verification link will expire in 24 hours for security reasons.",
+		"verify.ignore":     "If you did not create an account with Runtown, please ignore this email.",
+		"verify.welcome":    "Welcome to the community!",
+
+		// Orders
+		"order.details":          "Order Details",
+		"order.number":           "Order Number",
+		"order.date":             "Order Date",
+		"order.total":            "Total Amount",
+		"order.donation":         "Donation",
+		"order.tax_included":     "included",
+		"order.tax_id":           "Tax ID",
+		"order.payment_status":   "Payment Status",
+		"order.status.pending":   "Pending Payment",
+		"order.status.completed": "Completed",
+		"order.status.cancelled": "Cancelled",
+		"order.status.refunded":  "Refunded",
+
+		// Order confirmation
+		"order_confirmation.subject":           "Order Confirmation - %s",
+		"order_confirmation.heading":           "Order Confirmed!",
+		"order_confirmation.thanks":            "Thank you for your purchase",
+		"order_confirmation.ready":             "Your order has been successfully processed and your tickets are ready!",
+		"order_confirmation.your_tickets":      "Your Tickets",
+		"order_confirmation.ticket_count":      "%d tickets",
+		"order_confirmation.ticket_count_text": "You have %d ticket(s) for this order.",
+		"order_confirmation.attached":          "Please find your tickets attached to this email as a PDF.",
+		"order_confirmation.dashboard":         "You can also download them from your account dashboard.",
+		"order_confirmation.dashboard_at":      "You can also download them from your account dashboard at:",
+		"order_confirmation.view_order":        "View Order Details",
+		"order_confirmation.important":         "Important Information",
+		"order_confirmation.bring":             "Please bring your tickets (printed or on mobile) to the event",
+		"order_confirmation.arrive_early":      "Arrive early to avoid queues at the entrance",
+		"order_confirmation.qr_code":           "Each ticket contains a unique QR code for entry",
+		"order_confirmation.non_refundable":    "Tickets are non-transferable and non-refundable",
+		"order_confirmation.refund_policy":     "Refund policy: %s",
+		"order_confirmation.minimum_age":       "This event is for ages %d and over. You may be asked for ID at the entrance",
+		"order_confirmation.accessibility":     "Accessibility: %s",
+		"order_confirmation.faqs":              "Have a question about the event? The organizer has answered common ones on the event page.",
+		"order_confirmation.faqs_at":           "Have a question about the event? The organizer has answered common ones at:",
+		"order_confirmation.view_faqs":         "Read the FAQs",
+		"order_confirmation.thanks_choosing":   "Thank you for choosing Event Ticketing Platform!",
+
+		// Ticket details added to order confirmations
+		"tickets.details":            "Ticket Details",
+		"tickets.number":             "Ticket #",
+		"tickets.ticket":             "Ticket #%d",
+		"tickets.qr_code":            "QR Code",
+		"tickets.status":             "Status",
+		"tickets.generated":          "Generated",
+		"tickets.count_intro":        "You have %d ticket(s) for this order:",
+		"tickets.status.active":      "active",
+		"tickets.status.used":        "used",
+		"tickets.status.refunded":    "refunded",
+		"tickets.mobile_access":      "Mobile Access",
+		"tickets.mobile_access_html": "You can also access your tickets anytime from your account dashboard at",
+		"tickets.mobile_access_text": "You can access your tickets anytime from your account dashboard:",
+		"tickets.next_steps":         "Next Steps",
+		"tickets.step_save":          "Save this email for your records",
+		"tickets.step_download":      "Download the tickets from your account dashboard",
+		"tickets.step_bring":         "Bring your tickets (printed or on mobile) to the event",
+		"tickets.step_arrive":        "Arrive early to avoid entrance queues",
+
+		// Order status updates
+		"order_completed.subject":   "Your order %s is complete",
+		"order_completed.heading":   "Order Completed Successfully!",
+		"order_completed.body":      "Great news! Your order %s has been completed and your tickets are ready.",
+		"order_completed.dashboard": "You can download your tickets from your account dashboard.",
+		"order_completed.thanks":    "Thank you for your purchase!",
+		"order_refunded.subject":    "Refund for order %s",
+		"order_refunded.heading":    "Order Refunded",
+		"order_refunded.body":       "Your order %s has been refunded.",
+		"order_refunded.amount":     "The refund amount of %s will be processed back to your original payment method within 3-5 business days.",
+		"order_cancelled.subject":   "Order %s cancelled",
+		"order_cancelled.heading":   "Order Cancelled",
+		"order_cancelled.body":      "Your order %s has been cancelled.",
+		"order_cancelled.warning":   "If this was not requested by you, please contact our support team immediately.",
+		"order_cancelled.reorder":   "You can place a new order anytime from our website.",
+
+		// Event reminders
+		"reminder.subject":           "Reminder: %s starts in %s",
+		"reminder.lead.7d":           "7 days",
+		"reminder.lead.24h":          "24 hours",
+		"reminder.lead.2h":           "2 hours",
+		"reminder.intro":             "This is a reminder that you have tickets for %s.",
+		"reminder.date":              "Date",
+		"reminder.location":          "Location",
+		"reminder.organizer_message": "A message from the organizer:",
+		"reminder.view_tickets":      "View Your Tickets",
+		"reminder.view_tickets_text": "View your tickets",
+		"reminder.bring":             "Please bring your tickets (printed or on your mobile device) to the event.",
+		"reminder.join":              "Join the Event",
+		"reminder.join_text":         "Join the event",
+		"reminder.link_private":      "This link is for ticket holders only, please don't share it.",
+		"reminder.link_available":    "The link to join will be on your order page from %s.",
+
+		// Organizer broadcasts
+		"broadcast.intro":           "The organizer of %s has sent an update to ticket holders:",
+		"broadcast.view_event":      "View Event",
+		"broadcast.view_event_text": "View the event",
+		"broadcast.reason":          "You are receiving this email because you have tickets for this event.",
+
+		// Event cancellations
+		"cancellation.subject":       "Event cancelled: %s",
+		"cancellation.heading":       "Event Cancelled",
+		"cancellation.intro":         "We are sorry to let you know that %s, which was to take place on %s, has been cancelled by the organizer.",
+		"cancellation.reason":        "The organizer's explanation:",
+		"cancellation.refunded":      "Order %s has been refunded in full. %s will be returned to your original payment method, which can take a few days to appear.",
+		"cancellation.refund_failed": "We could not refund order %s automatically. Our support team will contact you to return your %s.",
+		"cancellation.voided":        "Your tickets for order %s are no longer valid.",
+
+		// Price alerts
+		"price_alert.drop.subject":   "Price drop: %s",
+		"price_alert.drop.message":   "%s tickets for %s are now %s, down from %s.",
+		"price_alert.ending.subject": "Prices for %s go up soon",
+		"price_alert.ending.message": "%s tickets for %s cost %s until %s. After that, %s tickets cost %s.",
+		"price_alert.reason":         "You are receiving this email because you saved this event.",
+
+		// Installment plans
+		"installment.paid.subject":      "Installment %d of %d paid for order %s",
+		"installment.paid.message":      "We charged %s to your card for order %s. %s is left to pay, and the next installment is due on %s.",
+		"installment.completed.subject": "Order %s is paid in full",
+		"installment.completed.message": "We charged the last installment of %s to your card, so order %s is now paid in full. Your tickets are in your account.",
+		"installment.failed.subject":    "We couldn't charge installment %d for order %s",
+		"installment.failed.message":    "We couldn't charge %s to your card for order %s. We will try again on %s, so please make sure the card can be charged to keep your tickets.",
+		"installment.defaulted.subject": "Order %s has been cancelled",
+		"installment.defaulted.message": "We couldn't charge installment %d for order %s after several attempts, so the order has been cancelled and its tickets are no longer valid. Please contact our support team about the installments you have paid.",
+		"installment.view":              "View Payment Schedule",
+		"installment.view_text":         "View your payment schedule",
+		"installment.reason":            "You are receiving this email because you are paying for this order in installments.",
+
+		// New events from followed organizers
+		"follow.new_event.subject": "New from %s: %s",
+		"follow.new_event.message": "%s has just announced a new event, %s.",
+		"follow.reason":            "You are receiving this email because you follow %s.",
+		"saved_search.subject":     "New event for %s: %s",
+		"saved_search.message":     "%s has just been published and matches your saved search %s.",
+		"saved_search.reason":      "You are receiving this email because you saved the search %s. You can remove it from your dashboard.",
+
+		// Organizer team invitations
+		"team.invite.subject":            "%s invited you to their team",
+		"team.invite.message":            "%s has invited you to help run their events. Once you join, you will be able to:",
+		"team.invite.accept":             "Accept Invitation",
+		"team.invite.accept_text":        "Accept the invitation",
+		"team.invite.expiry":             "This invitation expires in %d days. If you were not expecting it, you can ignore this email.",
+		"team.permission.manage_events":  "Manage events and ticket types",
+		"team.permission.view_analytics": "View sales analytics and attendee lists",
+		"team.permission.check_in":       "Check in ticket holders at the door",
+
+		// Dates
+		"month.january":     "January",
+		"month.february":    "February",
+		"month.march":       "March",
+		"month.april":       "April",
+		"month.may":         "May",
+		"month.june":        "June",
+		"month.july":        "July",
+		"month.august":      "August",
+		"month.september":   "September",
+		"month.october":     "October",
+		"month.november":    "November",
+		"month.december":    "December",
+		"weekday.monday":    "Monday",
+		"weekday.tuesday":   "Tuesday",
+		"weekday.wednesday": "Wednesday",
+		"weekday.thursday":  "Thursday",
+		"weekday.friday":    "Friday",
+		"weekday.saturday":  "Saturday",
+		"weekday.sunday":    "Sunday",
+	},
+
+	Swahili: {
+		"email.greeting":        "Mpendwa %s,",
+		"email.contact_support": "Ikiwa una maswali yoyote, tafadhali wasiliana na timu yetu ya usaidizi.",
+		"email.questions":       "Ikiwa una maswali yoyote kuhusu agizo lako au unahitaji msaada, tafadhali usisite kuwasiliana na timu yetu ya usaidizi.",
+		"email.sent_to":         "Barua pepe hii ilitumwa kwa %s",
+		"email.team":            "Timu ya Runtown",
+
+		// Email verification
+		"verify.subject":    "Thibitisha anwani yako ya barua pepe - Runtown",
+		"verify.heading":    "Thibitisha Anwani Yako ya Barua Pepe",
+		"verify.intro":      "Asante kwa kufungua akaunti na Runtown! Ili kukamilisha usajili wako na kufikia huduma zote, tafadhali thibitisha anwani yako ya barua pepe kwa kubofya kitufe kilicho hapa chini:",
+		"verify.intro_text": "Asante kwa kufungua akaunti na Runtown! Ili kukamilisha usajili wako na kufikia huduma zote, tafadhali thibitisha anwani yako ya barua pepe kwa kufungua kiungo kifuatacho:",
+		"verify.button":     "Thibitisha Barua Pepe Yangu",
+		"verify.copy_link":  "Au nakili na ubandike kiungo hiki kwenye kivinjari chako:",
+		"verify.expiry":     "Muhimu: Kiungo hiki cha uthibitisho kitaisha muda baada ya saa 24 kwa sababu za usalama.",
+		"verify.ignore":     "Ikiwa hukufungua akaunti na Runtown, tafadhali puuza barua pepe hii.",
+		"verify.welcome":    "Karibu kwenye jumuiya!",
+
+		"order.details":          "Maelezo ya Agizo",
+		"order.number":           "Nambari ya Agizo",
+		"order.date":             "Tarehe ya Agizo",
+		"order.total":            "Jumla ya Kiasi",
+		"order.donation":         "Mchango",
+		"order.tax_included":     "imejumuishwa",
+		"order.tax_id":           "Nambari ya Kodi",
+		"order.payment_status":   "Hali ya Malipo",
+		"order.status.pending":   "Malipo Yanasubiriwa",
+		"order.status.completed": "Limekamilika",
+		"order.status.cancelled": "Limeghairiwa",
+		"order.status.refunded":  "Pesa Zimerejeshwa",
+
+		"order_confirmation.subject":           "Uthibitisho wa Agizo - %s",
+		"order_confirmation.heading":           "Agizo Limethibitishwa!",
+		"order_confirmation.thanks":            "Asante kwa ununuzi wako",
+		"order_confirmation.ready":             "Agizo lako limeshughulikiwa na tiketi zako ziko tayari!",
+		"order_confirmation.your_tickets":      "Tiketi Zako",
+		"order_confirmation.ticket_count":      "tiketi %d",
+		"order_confirmation.ticket_count_text": "Una tiketi %d kwa agizo hili.",
+		"order_confirmation.attached":          "Tiketi zako zimeambatishwa kwenye barua pepe hii kama PDF.",
+		"order_confirmation.dashboard":         "Unaweza pia kuzipakua kutoka kwenye dashibodi ya akaunti yako.",
+		"order_confirmation.dashboard_at":      "Unaweza pia kuzipakua kutoka kwenye dashibodi ya akaunti yako:",
+		"order_confirmation.view_order":        "Tazama Maelezo ya Agizo",
+		"order_confirmation.important":         "Taarifa Muhimu",
+		"order_confirmation.bring":             "Tafadhali leta tiketi zako (zilizochapishwa au kwenye simu) kwenye tukio",
+		"order_confirmation.arrive_early":      "Fika mapema ili kuepuka foleni mlangoni",
+		"order_confirmation.qr_code":           "Kila tiketi ina msimbo wa QR wa kipekee wa kuingia",
+		"order_confirmation.non_refundable":    "Tiketi haziwezi kuhamishwa wala kurejeshewa pesa",
+		"order_confirmation.refund_policy":     "Sera ya kurejesha pesa: %s",
+		"order_confirmation.minimum_age":       "Tukio hili ni la watu wenye umri wa miaka %d na zaidi. Huenda ukaombwa kitambulisho mlangoni",
+		"order_confirmation.accessibility":     "Ufikivu: %s",
+		"order_confirmation.faqs":              "Una swali kuhusu tukio? Mwandaaji amejibu maswali ya kawaida kwenye ukurasa wa tukio.",
+		"order_confirmation.faqs_at":           "Una swali kuhusu tukio? Mwandaaji amejibu maswali ya kawaida hapa:",
+		"order_confirmation.view_faqs":         "Soma Maswali ya Kawaida",
+		"order_confirmation.thanks_choosing":   "Asante kwa kuchagua Event Ticketing Platform!",
+
+		"tickets.details":            "Maelezo ya Tiketi",
+		"tickets.number":             "Tiketi #",
+		"tickets.ticket":             "Tiketi #%d",
+		"tickets.qr_code":            "Msimbo wa QR",
+		"tickets.status":             "Hali",
+		"tickets.generated":          "Imetolewa",
+		"tickets.count_intro":        "Una tiketi %d kwa agizo hili:",
+		"tickets.status.active":      "inatumika",
+		"tickets.status.used":        "imetumika",
+		"tickets.status.refunded":    "pesa zimerejeshwa",
+		"tickets.mobile_access":      "Ufikiaji kwa Simu",
+		"tickets.mobile_access_html": "Unaweza pia kufikia tiketi zako wakati wowote kutoka kwenye dashibodi ya akaunti yako kupitia",
+		"tickets.mobile_access_text": "Unaweza kufikia tiketi zako wakati wowote kutoka kwenye dashibodi ya akaunti yako:",
+		"tickets.next_steps":         "Hatua Zinazofuata",
+		"tickets.step_save":          "Hifadhi barua pepe hii kwa kumbukumbu zako",
+		"tickets.step_download":      "Pakua tiketi kutoka kwenye dashibodi ya akaunti yako",
+		"tickets.step_bring":         "Leta tiketi zako (zilizochapishwa au kwenye simu) kwenye tukio",
+		"tickets.step_arrive":        "Fika mapema ili kuepuka foleni mlangoni",
+
+		"order_completed.subject":   "Agizo lako %s limekamilika",
+		"order_completed.heading":   "Agizo Limekamilika!",
+		"order_completed.body":      "Habari njema! Agizo lako %s limekamilika na tiketi zako ziko tayari.",
+		"order_completed.dashboard": "Unaweza kupakua tiketi zako kutoka kwenye dashibodi ya akaunti yako.",
+		"order_completed.thanks":    "Asante kwa ununuzi wako!",
+		"order_refunded.subject":    "Kurejeshewa pesa kwa agizo %s",
+		"order_refunded.heading":    "Pesa za Agizo Zimerejeshwa",
+		"order_refunded.body":       "Pesa za agizo lako %s zimerejeshwa.",
+		"order_refunded.amount":     "Kiasi cha %s kitarejeshwa kwenye njia yako ya malipo ndani ya siku 3-5 za kazi.",
+		"order_cancelled.subject":   "Agizo %s limeghairiwa",
+		"order_cancelled.heading":   "Agizo Limeghairiwa",
+		"order_cancelled.body":      "Agizo lako %s limeghairiwa.",
+		"order_cancelled.warning":   "Ikiwa hukuomba hili, tafadhali wasiliana na timu yetu ya usaidizi mara moja.",
+		"order_cancelled.reorder":   "Unaweza kuweka agizo jipya wakati wowote kwenye tovuti yetu.",
+
+		"reminder.subject":           "Kikumbusho: %s inaanza baada ya %s",
+		"reminder.lead.7d":           "siku 7",
+		"reminder.lead.24h":          "saa 24",
+		"reminder.lead.2h":           "saa 2",
+		"reminder.intro":             "Hiki ni kikumbusho kwamba una tiketi za %s.",
+		"reminder.date":              "Tarehe",
+		"reminder.location":          "Mahali",
+		"reminder.organizer_message": "Ujumbe kutoka kwa mwandalizi:",
+		"reminder.view_tickets":      "Tazama Tiketi Zako",
+		"reminder.view_tickets_text": "Tazama tiketi zako",
+		"reminder.bring":             "Tafadhali leta tiketi zako (zilizochapishwa au kwenye simu yako) kwenye tukio.",
+		"reminder.join":              "Jiunge na Tukio",
+		"reminder.join_text":         "Jiunge na tukio",
+		"reminder.link_private":      "Kiungo hiki ni cha wenye tiketi pekee, tafadhali usikishiriki.",
+		"reminder.link_available":    "Kiungo cha kujiunga kitapatikana kwenye ukurasa wa oda yako kuanzia %s.",
+
+		"broadcast.intro":           "Mwandalizi wa %s ametuma taarifa kwa wenye tiketi:",
+		"broadcast.view_event":      "Tazama Tukio",
+		"broadcast.view_event_text": "Tazama tukio",
+		"broadcast.reason":          "Unapokea barua pepe hii kwa sababu una tiketi za tukio hili.",
+
+		// Event cancellations
+		"cancellation.subject":       "Tukio limeghairiwa: %s",
+		"cancellation.heading":       "Tukio Limeghairiwa",
+		"cancellation.intro":         "Tunasikitika kukujulisha kuwa %s, lililopangwa kufanyika %s, limeghairiwa na mwandalizi.",
+		"cancellation.reason":        "Maelezo ya mwandalizi:",
+		"cancellation.refunded":      "Agizo %s limerejeshewa pesa zote. %s zitarudishwa kwa njia yako ya malipo ya awali, jambo linaloweza kuchukua siku chache.",
+		"cancellation.refund_failed": "Hatukuweza kurejesha pesa za agizo %s kiotomatiki. Timu yetu ya msaada itawasiliana nawe kukurudishia %s.",
+		"cancellation.voided":        "Tiketi zako za agizo %s si halali tena.",
+
+		"price_alert.drop.subject":   "Bei imeshuka: %s",
+		"price_alert.drop.message":   "Tiketi za %s za %s sasa ni %s, kutoka %s.",
+		"price_alert.ending.subject": "Bei za %s zitapanda hivi karibuni",
+		"price_alert.ending.message": "Tiketi za %s za %s ni %s hadi %s. Baada ya hapo, tiketi za %s ni %s.",
+		"price_alert.reason":         "Unapokea barua pepe hii kwa sababu ulihifadhi tukio hili.",
+
+		// Installment plans
+		"installment.paid.subject":      "Awamu %d kati ya %d imelipwa kwa agizo %s",
+		"installment.paid.message":      "Tumetoza %s kwenye kadi yako kwa agizo %s. Zimebaki %s kulipa, na awamu inayofuata inadaiwa tarehe %s.",
+		"installment.completed.subject": "Agizo %s limelipwa kikamilifu",
+		"installment.completed.message": "Tumetoza awamu ya mwisho ya %s kwenye kadi yako, kwa hivyo agizo %s sasa limelipwa kikamilifu. Tiketi zako ziko kwenye akaunti yako.",
+		"installment.failed.subject":    "Hatukuweza kutoza awamu %d ya agizo %s",
+		"installment.failed.message":    "Hatukuweza kutoza %s kwenye kadi yako kwa agizo %s. Tutajaribu tena tarehe %s, kwa hivyo tafadhali hakikisha kadi inaweza kutozwa ili usipoteze tiketi zako.",
+		"installment.defaulted.subject": "Agizo %s limeghairiwa",
+		"installment.defaulted.message": "Hatukuweza kutoza awamu %d ya agizo %s baada ya majaribio kadhaa, kwa hivyo agizo limeghairiwa na tiketi zake si halali tena. Tafadhali wasiliana na timu yetu ya msaada kuhusu awamu ulizolipa.",
+		"installment.view":              "Angalia Ratiba ya Malipo",
+		"installment.view_text":         "Angalia ratiba yako ya malipo",
+		"installment.reason":            "Unapokea barua pepe hii kwa sababu unalipia agizo hili kwa awamu.",
+
+		"follow.new_event.subject": "Mpya kutoka %s: %s",
+		"follow.new_event.message": "%s ametangaza tukio jipya, %s.",
+		"follow.reason":            "Unapokea barua pepe hii kwa sababu unamfuata %s.",
+		"saved_search.subject":     "Tukio jipya la %s: %s",
+		"saved_search.message":     "%s limechapishwa sasa hivi na linalingana na utafutaji wako uliohifadhiwa %s.",
+		"saved_search.reason":      "Unapokea barua pepe hii kwa sababu ulihifadhi utafutaji %s. Unaweza kuuondoa kwenye dashibodi yako.",
+
+		// Organizer team invitations
+		"team.invite.subject":            "%s amekualika kwenye timu yake",
+		"team.invite.message":            "%s amekualika usaidie kuendesha matukio yake. Ukijiunga, utaweza:",
+		"team.invite.accept":             "Kubali Mwaliko",
+		"team.invite.accept_text":        "Kubali mwaliko",
+		"team.invite.expiry":             "Mwaliko huu utaisha baada ya siku %d. Ikiwa hukuutarajia, unaweza kupuuza barua pepe hii.",
+		"team.permission.manage_events":  "Kusimamia matukio na aina za tiketi",
+		"team.permission.view_analytics": "Kuona takwimu za mauzo na orodha za wahudhuriaji",
+		"team.permission.check_in":       "Kuwaingiza wenye tiketi mlangoni",
+
+		"month.january":     "Januari",
+		"month.february":    "Februari",
+		"month.march":       "Machi",
+		"month.april":       "Aprili",
+		"month.may":         "Mei",
+		"month.june":        "Juni",
+		"month.july":        "Julai",
+		"month.august":      "Agosti",
+		"month.september":   "Septemba",
+		"month.october":     "Oktoba",
+		"month.november":    "Novemba",
+		"month.december":    "Desemba",
+		"weekday.monday":    "Jumatatu",
+		"weekday.tuesday":   "Jumanne",
+		"weekday.wednesday": "Jumatano",
+		"weekday.thursday":  "Alhamisi",
+		"weekday.friday":    "Ijumaa",
+		"weekday.saturday":  "Jumamosi",
+		"weekday.sunday":    "Jumapili",
+	},
+
+	French: {
+		"email.greeting":        "Bonjour %s,",
+		"email.contact_support": "Pour toute question, veuillez contacter notre équipe d'assistance.",
+		"email.questions":       "Si vous avez des questions sur votre commande ou besoin d'aide, n'hésitez pas à contacter notre équipe d'assistance.",
+		"email.sent_to":         "Cet e-mail a été envoyé à %s",
+		"email.team":            "L'équipe Runtown",
+
+		// Email verification
+		"verify.subject":    "Vérifiez votre adresse e-mail - Runtown",
+		"verify.heading":    "Vérifiez votre adresse e-mail",
+		"verify.intro":      "Merci d'avoir créé un compte Runtown ! Pour finaliser votre inscription et accéder à toutes les fonctionnalités, veuillez vérifier votre adresse e-mail en cliquant sur le bouton ci-dessous :",
+		"verify.intro_text": "Merci d'avoir créé un compte Runtown ! Pour finaliser votre inscription et accéder à toutes les fonctionnalités, veuillez vérifier votre adresse e-mail en ouvrant le lien suivant :",
+		"verify.button":     "Vérifier mon e-mail",
+		"verify.copy_link":  "Ou copiez et collez ce lien dans votre navigateur :",
+		"verify.expiry":     "Important : pour des raisons de sécurité, ce lien de vérification expire dans 24 heures.",
+		"verify.ignore":     "Si vous n'avez pas créé de compte Runtown, veuillez ignorer cet e-mail.",
+		"verify.welcome":    "Bienvenue dans la communauté !",
+
+		"order.details":          "Détails de la commande",
+		"order.number":           "Numéro de commande",
+		"order.date":             "Date de commande",
+		"order.total":            "Montant total",
+		"order.donation":         "Don",
+		"order.tax_included":     "incluse",
+		"order.tax_id":           "Numéro fiscal",
+		"order.payment_status":   "Statut du paiement",
+		"order.status.pending":   "Paiement en attente",
+		"order.status.completed": "Terminée",
+		"order.status.cancelled": "Annulée",
+		"order.status.refunded":  "Remboursée",
+
+		"order_confirmation.subject":           "Confirmation de commande - %s",
+		"order_confirmation.heading":           "Commande confirmée !",
+		"order_confirmation.thanks":            "Merci pour votre achat",
+		"order_confirmation.ready":             "Votre commande a bien été traitée et vos billets sont prêts !",
+		"order_confirmation.your_tickets":      "Vos billets",
+		"order_confirmation.ticket_count":      "%d billets",
+		"order_confirmation.ticket_count_text": "Vous avez %d billet(s) pour cette commande.",
+		"order_confirmation.attached":          "Vos billets sont joints à cet e-mail au format PDF.",
+		"order_confirmation.dashboard":         "Vous pouvez aussi les télécharger depuis le tableau de bord de votre compte.",
+		"order_confirmation.dashboard_at":      "Vous pouvez aussi les télécharger depuis le tableau de bord de votre compte :",
+		"order_confirmation.view_order":        "Voir les détails de la commande",
+		"order_confirmation.important":         "Informations importantes",
+		"order_confirmation.bring":             "Veuillez apporter vos billets (imprimés ou sur mobile) à l'événement",
+		"order_confirmation.arrive_early":      "Arrivez tôt pour éviter les files d'attente à l'entrée",
+		"order_confirmation.qr_code":           "Chaque billet contient un code QR unique pour l'entrée",
+		"order_confirmation.non_refundable":    "Les billets ne sont ni transférables ni remboursables",
+		"order_confirmation.refund_policy":     "Politique de remboursement : %s",
+		"order_confirmation.minimum_age":       "Cet événement est réservé aux %d ans et plus. Une pièce d'identité peut vous être demandée à l'entrée",
+		"order_confirmation.accessibility":     "Accessibilité : %s",
+		"order_confirmation.faqs":              "Une question sur l'événement ? L'organisateur a répondu aux plus fréquentes sur la page de l'événement.",
+		"order_confirmation.faqs_at":           "Une question sur l'événement ? L'organisateur a répondu aux plus fréquentes ici :",
+		"order_confirmation.view_faqs":         "Lire la FAQ",
+		"order_confirmation.thanks_choosing":   "Merci d'avoir choisi Event Ticketing Platform !",
+
+		"tickets.details":            "Détails des billets",
+		"tickets.number":             "Billet n°",
+		"tickets.ticket":             "Billet n°%d",
+		"tickets.qr_code":            "Code QR",
+		"tickets.status":             "Statut",
+		"tickets.generated":          "Généré le",
+		"tickets.count_intro":        "Vous avez %d billet(s) pour cette commande :",
+		"tickets.status.active":      "valide",
+		"tickets.status.used":        "utilisé",
+		"tickets.status.refunded":    "remboursé",
+		"tickets.mobile_access":      "Accès mobile",
+		"tickets.mobile_access_html": "Vous pouvez aussi retrouver vos billets à tout moment dans le tableau de bord de votre compte :",
+		"tickets.mobile_access_text": "Retrouvez vos billets à tout moment dans le tableau de bord de votre compte :",
+		"tickets.next_steps":         "Prochaines étapes",
+		"tickets.step_save":          "Conservez cet e-mail",
+		"tickets.step_download":      "Téléchargez les billets depuis le tableau de bord de votre compte",
+		"tickets.step_bring":         "Apportez vos billets (imprimés ou sur mobile) à l'événement",
+		"tickets.step_arrive":        "Arrivez tôt pour éviter les files d'attente",
+
+		"order_completed.subject":   "Votre commande %s est terminée",
+		"order_completed.heading":   "Commande terminée !",
+		"order_completed.body":      "Bonne nouvelle ! Votre commande %s est terminée et vos billets sont prêts.",
+		"order_completed.dashboard": "Vous pouvez télécharger vos billets depuis le tableau de bord de votre compte.",
+		"order_completed.thanks":    "Merci pour votre achat !",
+		"order_refunded.subject":    "Remboursement de la commande %s",
+		"order_refunded.heading":    "Commande remboursée",
+		"order_refunded.body":       "Votre commande %s a été remboursée.",
+		"order_refunded.amount":     "Le montant de %s sera reversé sur votre moyen de paiement d'origine sous 3 à 5 jours ouvrés.",
+		"order_cancelled.subject":   "Commande %s annulée",
+		"order_cancelled.heading":   "Commande annulée",
+		"order_cancelled.body":      "Votre commande %s a été annulée.",
+		"order_cancelled.warning":   "Si vous n'êtes pas à l'origine de cette demande, contactez immédiatement notre équipe d'assistance.",
+		"order_cancelled.reorder":   "Vous pouvez passer une nouvelle commande à tout moment sur notre site.",
+
+		"reminder.subject":           "Rappel : %s commence dans %s",
+		"reminder.lead.7d":           "7 jours",
+		"reminder.lead.24h":          "24 heures",
+		"reminder.lead.2h":           "2 heures",
+		"reminder.intro":             "Ceci est un rappel : vous avez des billets pour %s.",
+		"reminder.date":              "Date",
+		"reminder.location":          "Lieu",
+		"reminder.organizer_message": "Un message de l'organisateur :",
+		"reminder.view_tickets":      "Voir vos billets",
+		"reminder.view_tickets_text": "Voir vos billets",
+		"reminder.bring":             "Veuillez apporter vos billets (imprimés ou sur votre mobile) à l'événement.",
+		"reminder.join":              "Rejoindre l'événement",
+		"reminder.join_text":         "Rejoindre l'événement",
+		"reminder.link_private":      "Ce lien est réservé aux détenteurs de billets, merci de ne pas le partager.",
+		"reminder.link_available":    "Le lien pour rejoindre sera sur la page de votre commande à partir du %s.",
+
+		"broadcast.intro":           "L'organisateur de %s a envoyé un message aux détenteurs de billets :",
+		"broadcast.view_event":      "Voir l'événement",
+		"broadcast.view_event_text": "Voir l'événement",
+		"broadcast.reason":          "Vous recevez cet e-mail car vous avez des billets pour cet événement.",
+
+		// Event cancellations
+		"cancellation.subject":       "Événement annulé : %s",
+		"cancellation.heading":       "Événement annulé",
+		"cancellation.intro":         "Nous sommes désolés de vous informer que %s, prévu le %s, a été annulé par l'organisateur.",
+		"cancellation.reason":        "Explication de l'organisateur :",
+		"cancellation.refunded":      "La commande %s a été intégralement remboursée. %s seront reversés sur votre moyen de paiement d'origine, ce qui peut prendre quelques jours.",
+		"cancellation.refund_failed": "Nous n'avons pas pu rembourser automatiquement la commande %s. Notre équipe d'assistance vous contactera pour vous reverser vos %s.",
+		"cancellation.voided":        "Vos billets de la commande %s ne sont plus valables.",
+
+		"price_alert.drop.subject":   "Baisse de prix : %s",
+		"price_alert.drop.message":   "Les billets %s pour %s sont maintenant à %s, au lieu de %s.",
+		"price_alert.ending.subject": "Les prix de %s augmentent bientôt",
+		"price_alert.ending.message": "Les billets %s pour %s coûtent %s jusqu'au %s. Ensuite, les billets %s coûteront %s.",
+		"price_alert.reason":         "Vous recevez cet e-mail car vous avez enregistré cet événement.",
+
+		// Installment plans
+		"installment.paid.subject":      "Échéance %d sur %d payée pour la commande %s",
+		"installment.paid.message":      "Nous avons débité %s de votre carte pour la commande %s. Il reste %s à payer, et la prochaine échéance est due le %s.",
+		"installment.completed.subject": "La commande %s est entièrement payée",
+		"installment.completed.message": "Nous avons débité la dernière échéance de %s de votre carte, la commande %s est donc entièrement payée. Vos billets sont dans votre compte.",
+		"installment.failed.subject":    "Nous n'avons pas pu débiter l'échéance %d de la commande %s",
+		"installment.failed.message":    "Nous n'avons pas pu débiter %s de votre carte pour la commande %s. Nous réessaierons le %s, veillez donc à ce que la carte puisse être débitée pour conserver vos billets.",
+		"installment.defaulted.subject": "La commande %s a été annulée",
+		"installment.defaulted.message": "Nous n'avons pas pu débiter l'échéance %d de la commande %s après plusieurs tentatives, la commande a donc été annulée et ses billets ne sont plus valables. Veuillez contacter notre équipe d'assistance au sujet des échéances déjà payées.",
+		"installment.view":              "Voir l'échéancier",
+		"installment.view_text":         "Voir votre échéancier",
+		"installment.reason":            "Vous recevez cet e-mail car vous payez cette commande en plusieurs fois.",
+
+		"follow.new_event.subject": "Nouveau chez %s : %s",
+		"follow.new_event.message": "%s vient d'annoncer un nouvel événement, %s.",
+		"follow.reason":            "Vous recevez cet e-mail car vous suivez %s.",
+		"saved_search.subject":     "Nouvel événement pour %s : %s",
+		"saved_search.message":     "%s vient d'être publié et correspond à votre recherche enregistrée %s.",
+		"saved_search.reason":      "Vous recevez cet e-mail car vous avez enregistré la recherche %s. Vous pouvez la supprimer depuis votre tableau de bord.",
+
+		// Organizer team invitations
+		"team.invite.subject":            "%s vous invite dans son équipe",
+		"team.invite.message":            "%s vous invite à l'aider à organiser ses événements. Une fois membre, vous pourrez :",
+		"team.invite.accept":             "Accepter l'invitation",
+		"team.invite.accept_text":        "Accepter l'invitation",
+		"team.invite.expiry":             "Cette invitation expire dans %d jours. Si vous ne l'attendiez pas, vous pouvez ignorer cet e-mail.",
+		"team.permission.manage_events":  "Gérer les événements et les types de billets",
+		"team.permission.view_analytics": "Consulter les ventes et les listes de participants",
+		"team.permission.check_in":       "Contrôler les billets à l'entrée",
+
+		"month.january":     "janvier",
+		"month.february":    "février",
+		"month.march":       "mars",
+		"month.april":       "avril",
+		"month.may":         "mai",
+		"month.june":        "juin",
+		"month.july":        "juillet",
+		"month.august":      "août",
+		"month.september":   "septembre",
+		"month.october":     "octobre",
+		"month.november":    "novembre",
+		"month.december":    "décembre",
+		"weekday.monday":    "lundi",
+		"weekday.tuesday":   "mardi",
+		"weekday.wednesday": "mercredi",
+		"weekday.thursday":  "jeudi",
+		"weekday.friday":    "vendredi",
+		"weekday.saturday":  "samedi",
+		"weekday.sunday":    "dimanche",
+	},
+}
@@ -0,0 +1,156 @@
+package i18n
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Supported locales
+const (
+	English = "en"
+	Swahili = "sw"
+	French  = "fr"
+)
+
+// DefaultLocale is used when neither the buyer nor the event has a locale
+const DefaultLocale = English
+
+// SupportedLocales lists the locales emails can be sent in, in the order
+// they are offered to users
+var SupportedLocales = []string{English, Swahili, French}
+
+// localeNames are the locales' names in their own language
+var localeNames = map[string]string{
+	English: "English",
+	Swahili: "Kiswahili",
+	French:  "Français",
+}
+
+// Normalize maps a language tag such as "sw-KE" or "FR_fr" to a supported
+// locale. It returns "" if the language is not supported.
+func Normalize(tag string) string {
+	tag = strings.ToLower(strings.TrimSpace(tag))
+	if i := strings.IndexAny(tag, "-_"); i >= 0 {
+		tag = tag[:i]
+	}
+	if _, ok := catalog[tag]; ok {
+		return tag
+	}
+	return ""
+}
+
+// IsSupported returns true if the tag is a supported locale
+func IsSupported(tag string) bool {
+	return Normalize(tag) != ""
+}
+
+// Resolve returns the first supported locale among the candidates, in order
+// of preference, falling back to DefaultLocale
+func Resolve(candidates ...string) string {
+	for _, candidate := range candidates {
+		if locale := Normalize(candidate); locale != "" {
+			return locale
+		}
+	}
+	return DefaultLocale
+}
+
+// FromAcceptLanguage returns the supported locale the browser prefers most
+// according to an Accept-Language header such as "sw-KE,sw;q=0.9,en;q=0.8".
+// It returns "" if none of the languages are supported.
+func FromAcceptLanguage(header string) string {
+	type preference struct {
+		tag     string
+		quality float64
+	}
+
+	var preferences []preference
+	for _, part := range strings.Split(header, ",") {
+		fields := strings.Split(part, ";")
+		pref := preference{tag: strings.TrimSpace(fields[0]), quality: 1}
+		for _, param := range fields[1:] {
+			if value, ok := strings.CutPrefix(strings.TrimSpace(param), "q="); ok {
+				if quality, err := strconv.ParseFloat(value, 64); err == nil {
+					pref.quality = quality
+				}
+			}
+		}
+		if pref.tag != "" && pref.quality > 0 {
+			preferences = append(preferences, pref)
+		}
+	}
+
+	sort.SliceStable(preferences, func(i, j int) bool {
+		return preferences[i].quality > preferences[j].quality
+	})
+
+	for _, pref := range preferences {
+		if locale := Normalize(pref.tag); locale != "" {
+			return locale
+		}
+	}
+	return ""
+}
+
+// Name returns the locale's name in its own language
+func Name(locale string) string {
+	if name, ok := localeNames[Normalize(locale)]; ok {
+		return name
+	}
+	return locale
+}
+
+// T returns the message for key in the given locale, formatted with args.
+// Messages missing from a locale fall back to English, and unknown keys are
+// returned as is so a missing translation never breaks an email.
+func T(locale, key string, args ...interface{}) string {
+	message, ok := catalog[Resolve(locale)][key]
+	if !ok {
+		message, ok = catalog[DefaultLocale][key]
+	}
+	if !ok {
+		return key
+	}
+	if len(args) == 0 {
+		return message
+	}
+	return fmt.Sprintf(message, args...)
+}
+
+// Date and time layouts per locale. English month and weekday names in the
+// formatted output are replaced with the locale's.
+var (
+	dateTimeLayouts = map[string]string{
+		English: "January 2, 2006 at 3:04 PM",
+		Swahili: "2 January 2006 saa 15:04",
+		French:  "2 January 2006 à 15:04",
+	}
+	longDateTimeLayouts = map[string]string{
+		English: "Monday, January 2, 2006 at 3:04 PM",
+		Swahili: "Monday, 2 January 2006 saa 15:04",
+		French:  "Monday 2 January 2006 à 15:04",
+	}
+)
+
+// FormatDateTime formats t as a date and time, e.g. "January 2, 2006 at 3:04 PM"
+func FormatDateTime(locale string, t time.Time) string {
+	return formatTime(Resolve(locale), t, dateTimeLayouts)
+}
+
+// FormatLongDateTime formats t as a date and time including the weekday,
+// e.g. "Monday, January 2, 2006 at 3:04 PM"
+func FormatLongDateTime(locale string, t time.Time) string {
+	return formatTime(Resolve(locale), t, longDateTimeLayouts)
+}
+
+func formatTime(locale string, t time.Time, layouts map[string]string) string {
+	formatted := t.Format(layouts[locale])
+	if locale == English {
+		return formatted
+	}
+	formatted = strings.Replace(formatted, t.Weekday().String(), T(locale, "weekday."+strings.ToLower(t.Weekday().String())), 1)
+	return strings.Replace(formatted, t.Month().String(), T(locale, "month."+strings.ToLower(t.Month().String())), 1)
+}
@@ -0,0 +1,112 @@
+package i18n
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCatalogIsComplete(t *testing.T) {
+	for _, locale := range SupportedLocales {
+		messages, ok := catalog[locale]
+		if !ok {
+			t.Fatalf("catalog has no messages for supported locale %q", locale)
+		}
+		for key, english := range catalog[DefaultLocale] {
+			message, ok := messages[key]
+			if !ok {
+				t.Errorf("%s: missing message %q", locale, key)
+				continue
+			}
+			if strings.Count(message, "%") != strings.Count(english, "%") {
+				t.Errorf("%s: message %q has different arguments than English", locale, key)
+			}
+		}
+		for key := range messages {
+			if _, ok := catalog[DefaultLocale][key]; !ok {
+				t.Errorf("%s: message %q is not in the English catalog", locale, key)
+			}
+		}
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	tests := map[string]string{
+		"en":    "en",
+		"sw-KE": "sw",
+		"FR_fr": "fr",
+		" fr ":  "fr",
+		"de":    "",
+		"":      "",
+	}
+	for tag, want := range tests {
+		if got := Normalize(tag); got != want {
+			t.Errorf("Normalize(%q) = %q, want %q", tag, got, want)
+		}
+	}
+}
+
+func TestResolve(t *testing.T) {
+	if got := Resolve("", "de", "sw-KE", "fr"); got != Swahili {
+		t.Errorf("expected the first supported candidate, got %q", got)
+	}
+	if got := Resolve("", "de"); got != DefaultLocale {
+		t.Errorf("expected the default locale, got %q", got)
+	}
+}
+
+func TestFromAcceptLanguage(t *testing.T) {
+	tests := map[string]string{
+		"sw-KE,sw;q=0.9,en;q=0.8": Swahili,
+		"de-DE,de;q=0.9,fr;q=0.7": French,
+		"en;q=0.5, fr":            French,
+		"fr;q=0, en":              English,
+		"de, *;q=0.5":             "",
+		"":                        "",
+	}
+	for header, want := range tests {
+		if got := FromAcceptLanguage(header); got != want {
+			t.Errorf("FromAcceptLanguage(%q) = %q, want %q", header, got, want)
+		}
+	}
+}
+
+func TestT(t *testing.T) {
+	if got := T(Swahili, "email.greeting", "Amina"); got != "Mpendwa Amina," {
+		t.Errorf("unexpected Swahili greeting: %q", got)
+	}
+	if got := T("de", "email.greeting", "Anna"); got != "Dear Anna," {
+		t.Errorf("expected unsupported locales to use English, got %q", got)
+	}
+	if got := T(French, "no.such.key"); got != "no.such.key" {
+		t.Errorf("expected unknown keys to be returned as is, got %q", got)
+	}
+}
+
+func TestFormatDateTime(t *testing.T) {
+	date := time.Date(2025, time.August, 4, 18, 30, 0, 0, time.UTC)
+
+	tests := []struct {
+		locale string
+		short  string
+		long   string
+	}{
+		{English, "August 4, 2025 at 6:30 PM", "Monday, August 4, 2025 at 6:30 PM"},
+		{Swahili, "4 Agosti 2025 saa 18:30", "Jumatatu, 4 Agosti 2025 saa 18:30"},
+		{French, "4 août 2025 à 18:30", "lundi 4 août 2025 à 18:30"},
+	}
+	for _, tt := range tests {
+		if got := FormatDateTime(tt.locale, date); got != tt.short {
+			t.Errorf("FormatDateTime(%s) = %q, want %q", tt.locale, got, tt.short)
+		}
+		if got := FormatLongDateTime(tt.locale, date); got != tt.long {
+			t.Errorf("FormatLongDateTime(%s) = %q, want %q", tt.locale, got, tt.long)
+		}
+	}
+}
+
+func TestName(t *testing.T) {
+	if got := Name("sw-KE"); got != "Kiswahili" {
+		t.Errorf("unexpected name: %q", got)
+	}
+}
@@ -0,0 +1,68 @@
+// Package logging configures the application's structured logger and carries
+// request-scoped loggers, with their correlation fields, through contexts.
+package logging
+
+import (
+	"context"
+	"io"
+	"log/slog"
+	"os"
+	"strings"
+)
+
+// Log output formats
+const (
+	FormatText = "text" // key=value lines for reading in a terminal
+	FormatJSON = "json" // One JSON object per line for log collectors
+)
+
+type contextKey struct{}
+
+// New creates a logger writing records at or above level to w in the given
+// format. Unknown formats fall back to text and unknown levels to info.
+func New(w io.Writer, format, level string) *slog.Logger {
+	options := &slog.HandlerOptions{Level: ParseLevel(level)}
+	if strings.EqualFold(format, FormatJSON) {
+		return slog.New(slog.NewJSONHandler(w, options))
+	}
+	return slog.New(slog.NewTextHandler(w, options))
+}
+
+// Setup makes a logger writing to stderr the default. Output of the standard
+// log package goes through it too, so older log.Printf calls share the format.
+func Setup(format, level string) *slog.Logger {
+	logger := New(os.Stderr, format, level)
+	slog.SetDefault(logger)
+	return logger
+}
+
+// ParseLevel parses a level name: debug, info, warn or error
+func ParseLevel(level string) slog.Level {
+	switch strings.ToLower(strings.TrimSpace(level)) {
+	case "debug":
+		return slog.LevelDebug
+	case "warn", "warning":
+		return slog.LevelWarn
+	case "error":
+		return slog.LevelError
+	default:
+		return slog.LevelInfo
+	}
+}
+
+// WithAttrs returns a context whose logger adds the given attributes to every
+// record, e.g. WithAttrs(ctx, "order_id", order.ID)
+func WithAttrs(ctx context.Context, args ...any) context.Context {
+	return context.WithValue(ctx, contextKey{}, FromContext(ctx).With(args...))
+}
+
+// FromContext returns the context's logger, or the default logger when the
+// context has none
+func FromContext(ctx context.Context) *slog.Logger {
+	if ctx != nil {
+		if logger, ok := ctx.Value(contextKey{}).(*slog.Logger); ok {
+			return logger
+		}
+	}
+	return slog.Default()
+}
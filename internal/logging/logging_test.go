@@ -0,0 +1,75 @@
+package logging
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"strings"
+	"testing"
+)
+
+func TestNew_JSON(t *testing.T) {
+	var buf bytes.Buffer
+	logger := New(&buf, "json", "info")
+
+	logger.Debug("hidden")
+	logger.Info("order completed", "order_id", 42)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 1 {
+		t.Fatalf("expected only the info record, got %q", buf.String())
+	}
+
+	var record map[string]any
+	if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+		t.Fatalf("expected JSON output, got %q: %v", lines[0], err)
+	}
+	if record["msg"] != "order completed" || record["order_id"] != float64(42) {
+		t.Errorf("unexpected record: %v", record)
+	}
+}
+
+func TestNew_Text(t *testing.T) {
+	var buf bytes.Buffer
+	New(&buf, "", "debug").Debug("loading user", "user_id", 7)
+
+	if output := buf.String(); !strings.Contains(output, "level=DEBUG") || !strings.Contains(output, "user_id=7") {
+		t.Errorf("unexpected text output: %q", output)
+	}
+}
+
+func TestParseLevel(t *testing.T) {
+	tests := map[string]slog.Level{
+		"debug":   slog.LevelDebug,
+		"INFO":    slog.LevelInfo,
+		"warning": slog.LevelWarn,
+		" error ": slog.LevelError,
+		"verbose": slog.LevelInfo,
+	}
+	for name, want := range tests {
+		if got := ParseLevel(name); got != want {
+			t.Errorf("ParseLevel(%q) = %v, want %v", name, got, want)
+		}
+	}
+}
+
+func TestWithAttrs(t *testing.T) {
+	var buf bytes.Buffer
+	ctx := context.WithValue(context.Background(), contextKey{}, New(&buf, "text", "info"))
+
+	ctx = WithAttrs(ctx, "request_id", "abc123")
+	ctx = WithAttrs(ctx, "user_id", 7)
+	FromContext(ctx).Info("checkout started")
+
+	output := buf.String()
+	if !strings.Contains(output, "request_id=abc123") || !strings.Contains(output, "user_id=7") {
+		t.Errorf("expected both correlation fields, got %q", output)
+	}
+}
+
+func TestFromContext_Default(t *testing.T) {
+	if FromContext(context.Background()) != slog.Default() {
+		t.Error("expected the default logger for a context without one")
+	}
+}
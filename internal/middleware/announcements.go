@@ -0,0 +1,87 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"event-ticketing-platform/internal/models"
+)
+
+const (
+	// DismissedAnnouncementsCookie lists the IDs of the announcements a
+	// visitor has closed
+	DismissedAnnouncementsCookie = "dismissed_announcements"
+	// AnnouncementsContextKey holds the announcements shown on the page
+	AnnouncementsContextKey = "announcements"
+	// maxDismissedAnnouncements is how many dismissals the cookie keeps,
+	// dropping the oldest first
+	maxDismissedAnnouncements = 20
+)
+
+// AnnouncementProvider returns the announcements a visitor sees
+type AnnouncementProvider interface {
+	Visible(user *models.User, dismissed map[int]bool) []*models.Announcement
+}
+
+// Announcements middleware adds the announcement banners the visitor sees
+// to the request context for the layout. It must run after the user is
+// loaded.
+func Announcements(provider AnnouncementProvider) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !isAttributedPath(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			dismissed := make(map[int]bool)
+			for _, id := range DismissedAnnouncements(r) {
+				dismissed[id] = true
+			}
+
+			announcements := provider.Visible(GetUserFromContext(r.Context()), dismissed)
+			ctx := context.WithValue(r.Context(), AnnouncementsContextKey, announcements)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// DismissedAnnouncements returns the IDs in the request's dismissed
+// announcements cookie, oldest first
+func DismissedAnnouncements(r *http.Request) []int {
+	cookie, err := r.Cookie(DismissedAnnouncementsCookie)
+	if err != nil {
+		return nil
+	}
+
+	var ids []int
+	for _, value := range strings.Split(cookie.Value, ".") {
+		if id, err := strconv.Atoi(value); err == nil && id > 0 {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// DismissAnnouncement returns the dismissed announcements cookie value with
+// the ID added
+func DismissAnnouncement(r *http.Request, id int) string {
+	ids := []int{}
+	for _, dismissed := range DismissedAnnouncements(r) {
+		if dismissed != id {
+			ids = append(ids, dismissed)
+		}
+	}
+	ids = append(ids, id)
+	if len(ids) > maxDismissedAnnouncements {
+		ids = ids[len(ids)-maxDismissedAnnouncements:]
+	}
+
+	values := make([]string, len(ids))
+	for i, dismissed := range ids {
+		values[i] = strconv.Itoa(dismissed)
+	}
+	return strings.Join(values, ".")
+}
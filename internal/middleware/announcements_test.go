@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"event-ticketing-platform/internal/models"
+)
+
+type mockAnnouncementProvider struct {
+	dismissed map[int]bool
+}
+
+func (m *mockAnnouncementProvider) Visible(user *models.User, dismissed map[int]bool) []*models.Announcement {
+	m.dismissed = dismissed
+	return []*models.Announcement{{ID: 1, Message: "Planned downtime tonight"}}
+}
+
+func TestAnnouncements(t *testing.T) {
+	provider := &mockAnnouncementProvider{}
+	var announcements []*models.Announcement
+	handler := Announcements(provider)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		announcements, _ = r.Context().Value(AnnouncementsContextKey).([]*models.Announcement)
+	}))
+
+	req := httptest.NewRequest("GET", "/events", nil)
+	req.AddCookie(&http.Cookie{Name: DismissedAnnouncementsCookie, Value: "3.bogus.7"})
+	handler.ServeHTTP(httptest.NewRecorder(), req.WithContext(context.Background()))
+
+	if len(announcements) != 1 {
+		t.Fatalf("expected the announcements in the context, got %v", announcements)
+	}
+	if !reflect.DeepEqual(provider.dismissed, map[int]bool{3: true, 7: true}) {
+		t.Errorf("expected announcements 3 and 7 dismissed, got %v", provider.dismissed)
+	}
+
+	announcements = nil
+	handler.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest("GET", "/static/js/app.js", nil))
+	if announcements != nil {
+		t.Error("expected no announcements loaded for static files")
+	}
+}
+
+func TestDismissAnnouncement(t *testing.T) {
+	req := httptest.NewRequest("POST", "/announcements/5/dismiss", nil)
+	if got := DismissAnnouncement(req, 5); got != "5" {
+		t.Errorf("DismissAnnouncement() = %q, want %q", got, "5")
+	}
+
+	req.AddCookie(&http.Cookie{Name: DismissedAnnouncementsCookie, Value: "2.5.9"})
+	if got := DismissAnnouncement(req, 5); got != "2.9.5" {
+		t.Errorf("DismissAnnouncement() = %q, want %q", got, "2.9.5")
+	}
+
+	full := httptest.NewRequest("POST", "/announcements/100/dismiss", nil)
+	full.AddCookie(&http.Cookie{Name: DismissedAnnouncementsCookie, Value: "1.2.3.4.5.6.7.8.9.10.11.12.13.14.15.16.17.18.19.20"})
+	if got := DismissAnnouncement(full, 100); got != "2.3.4.5.6.7.8.9.10.11.12.13.14.15.16.17.18.19.20.100" {
+		t.Errorf("expected the oldest dismissal dropped, got %q", got)
+	}
+}
@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"event-ticketing-platform/internal/logging"
+	"event-ticketing-platform/internal/models"
+)
+
+// APITokenAuthenticator resolves an API token to the account it belongs to
+type APITokenAuthenticator interface {
+	Authenticate(token string) (*models.User, error)
+}
+
+// RequireAPIToken authenticates requests with an "Authorization: Bearer"
+// API token and puts the token's account in the context. Requests without
+// a valid token are rejected with a JSON 401.
+func RequireAPIToken(authenticator APITokenAuthenticator) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			header := r.Header.Get("Authorization")
+			token, ok := strings.CutPrefix(header, "Bearer ")
+			if !ok || strings.TrimSpace(token) == "" {
+				writeAPITokenError(w, "API token required")
+				return
+			}
+
+			user, err := authenticator.Authenticate(token)
+			if err != nil {
+				writeAPITokenError(w, "Invalid API token")
+				return
+			}
+
+			ctx := context.WithValue(r.Context(), UserContextKey, user)
+			ctx = logging.WithAttrs(ctx, "user_id", user.ID)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+func writeAPITokenError(w http.ResponseWriter, message string) {
+	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("WWW-Authenticate", `Bearer realm="api"`)
+	w.WriteHeader(http.StatusUnauthorized)
+	w.Write([]byte(`{"errors":[{"message":"` + message + `"}]}`))
+}
@@ -0,0 +1,67 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"event-ticketing-platform/internal/models"
+)
+
+type mockAPITokenAuthenticator struct {
+	users map[string]*models.User
+}
+
+func (m *mockAPITokenAuthenticator) Authenticate(token string) (*models.User, error) {
+	if user, ok := m.users[token]; ok {
+		return user, nil
+	}
+	return nil, errors.New("invalid API token")
+}
+
+func TestRequireAPIToken(t *testing.T) {
+	authenticator := &mockAPITokenAuthenticator{
+		users: map[string]*models.User{"rt_valid": {ID: 3}},
+	}
+
+	var seenUser *models.User
+	handler := RequireAPIToken(authenticator)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seenUser = GetUserFromContext(r.Context())
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name           string
+		header         string
+		expectedStatus int
+	}{
+		{name: "missing header", header: "", expectedStatus: http.StatusUnauthorized},
+		{name: "not a bearer token", header: "Basic rt_valid", expectedStatus: http.StatusUnauthorized},
+		{name: "unknown token", header: "Bearer rt_unknown", expectedStatus: http.StatusUnauthorized},
+		{name: "valid token", header: "Bearer rt_valid", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			seenUser = nil
+			req := httptest.NewRequest("POST", "/graphql", nil)
+			if tt.header != "" {
+				req.Header.Set("Authorization", tt.header)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+			if tt.expectedStatus == http.StatusOK && (seenUser == nil || seenUser.ID != 3) {
+				t.Errorf("expected token's user in context, got %+v", seenUser)
+			}
+			if tt.expectedStatus == http.StatusUnauthorized && rr.Header().Get("Content-Type") != "application/json" {
+				t.Errorf("expected JSON error, got %q", rr.Header().Get("Content-Type"))
+			}
+		})
+	}
+}
@@ -0,0 +1,19 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"event-ticketing-platform/internal/assets"
+)
+
+// Assets middleware adds the static asset manifest to the request context,
+// so templates link to content-hashed files
+func Assets(manifest *assets.Manifest) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), "assets", manifest)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package middleware
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"event-ticketing-platform/internal/models"
+
+	"github.com/gorilla/sessions"
+)
+
+// AttributionSessionKey holds the JSON encoded attribution of the session's
+// first visit
+const AttributionSessionKey = "attribution"
+
+// attributionSkippedPrefixes are requests that are not page visits
+var attributionSkippedPrefixes = []string{"/static/", "/api/", "/health", "/favicon"}
+
+// Attribution records where a visitor came from on their first page visit
+// in the session: the visit's UTM parameters and, when they followed a link
+// from another site, its referrer. Later visits never overwrite it, so an
+// order is attributed to the visit that first brought its buyer in. Promoter
+// links are the exception: the last one followed earns the sale.
+//
+// Visitors who haven't agreed to analytics only have promoter links
+// remembered, since that is how promoters are paid. It must run after
+// PrivacyConsent.
+func Attribution(store sessions.Store) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != http.MethodGet || !isAttributedPath(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			session, err := store.Get(r, "session")
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			attribution := AttributionFromSession(session)
+			changed := false
+			if attribution == nil {
+				if AnalyticsAllowed(r.Context()) {
+					attribution = models.NewAttribution(r.URL.Query(), externalReferrerHost(r), r.URL.Path)
+					changed = true
+				} else if ref := models.PromoterRef(r.URL.Query()); ref != "" {
+					attribution = &models.Attribution{Ref: ref}
+					changed = true
+				}
+			} else if ref := models.PromoterRef(r.URL.Query()); ref != "" && ref != attribution.Ref {
+				attribution.Ref = ref
+				changed = true
+			}
+
+			if changed {
+				if data, err := json.Marshal(attribution); err == nil {
+					session.Values[AttributionSessionKey] = string(data)
+					session.Save(r, w)
+				}
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// AttributionFromSession returns the attribution recorded for a session, or
+// nil when none was
+func AttributionFromSession(session *sessions.Session) *models.Attribution {
+	data, ok := session.Values[AttributionSessionKey].(string)
+	if !ok {
+		return nil
+	}
+
+	attribution := &models.Attribution{}
+	if err := json.Unmarshal([]byte(data), attribution); err != nil {
+		return nil
+	}
+	return attribution
+}
+
+// isAttributedPath reports whether a request path is a page visit
+func isAttributedPath(path string) bool {
+	for _, prefix := range attributionSkippedPrefixes {
+		if strings.HasPrefix(path, prefix) {
+			return false
+		}
+	}
+	return true
+}
+
+// externalReferrerHost returns the host of the request's referrer when it
+// is another site. Links within the site are not referrals.
+func externalReferrerHost(r *http.Request) string {
+	referrer, err := url.Parse(r.Referer())
+	if err != nil || referrer.Hostname() == "" {
+		return ""
+	}
+
+	host := strings.ToLower(referrer.Hostname())
+	if host == strings.ToLower(hostWithoutPort(r.Host)) {
+		return ""
+	}
+	return strings.TrimPrefix(host, "www.")
+}
+
+// hostWithoutPort strips the port from a request's host
+func hostWithoutPort(host string) string {
+	if u, err := url.Parse("//" + host); err == nil && u.Hostname() != "" {
+		return u.Hostname()
+	}
+	return host
+}
@@ -0,0 +1,80 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"event-ticketing-platform/internal/models"
+
+	"github.com/gorilla/sessions"
+)
+
+func TestAttribution(t *testing.T) {
+	store := sessions.NewCookieStore([]byte("test-secret"))
+
+	var seen *models.Attribution
+	handler := Attribution(store)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		session, _ := store.Get(r, "session")
+		seen = AttributionFromSession(session)
+	}))
+
+	consent := &models.PrivacyPreferences{Analytics: true}
+	serve := func(target, referrer string, cookies []*http.Cookie) *httptest.ResponseRecorder {
+		seen = nil
+		req := httptest.NewRequest("GET", target, nil)
+		req = req.WithContext(WithPrivacyPreferences(req.Context(), consent))
+		req.Host = "tickets.example.com"
+		if referrer != "" {
+			req.Header.Set("Referer", referrer)
+		}
+		for _, cookie := range cookies {
+			req.AddCookie(cookie)
+		}
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr
+	}
+
+	rr := serve("/events/5?utm_source=Instagram&utm_medium=social&utm_campaign=launch", "https://www.instagram.com/p/abc", nil)
+	if seen == nil {
+		t.Fatal("expected the first visit to be attributed")
+	}
+	if seen.Source != "instagram" || seen.Campaign != "launch" || seen.ReferrerHost != "instagram.com" || seen.LandingPath != "/events/5" {
+		t.Errorf("unexpected attribution: %+v", seen)
+	}
+
+	// Later visits keep the first visit's attribution
+	serve("/events/6?utm_source=newsletter", "https://mail.example.org/", rr.Result().Cookies())
+	if seen == nil || seen.Source != "instagram" {
+		t.Errorf("expected the first visit's attribution to be kept, got %+v", seen)
+	}
+
+	// The last promoter link followed earns the sale
+	serve("/events/6?ref=DJ-Kevo", "", rr.Result().Cookies())
+	if seen == nil || seen.Ref != "dj-kevo" || seen.Source != "instagram" {
+		t.Errorf("expected the promoter's code alongside the first visit's source, got %+v", seen)
+	}
+
+	// Links within the site are not referrals
+	serve("/events", "https://tickets.example.com/", nil)
+	if seen == nil || seen.SourceLabel() != models.AttributionSourceDirect {
+		t.Errorf("expected an internal referrer to count as direct, got %+v", seen)
+	}
+
+	serve("/static/css/app.css?utm_source=x", "", nil)
+	if seen != nil {
+		t.Errorf("expected static files not to be attributed, got %+v", seen)
+	}
+
+	// Without analytics consent only promoter links are remembered
+	consent = nil
+	serve("/events/5?utm_source=instagram", "https://www.instagram.com/p/abc", nil)
+	if seen != nil {
+		t.Errorf("expected visitors who haven't consented not to be attributed, got %+v", seen)
+	}
+	serve("/events/5?utm_source=instagram&ref=DJ-Kevo", "https://www.instagram.com/p/abc", nil)
+	if seen == nil || seen.Ref != "dj-kevo" || seen.Source != "" || seen.ReferrerHost != "" {
+		t.Errorf("expected only the promoter's code to be kept, got %+v", seen)
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"strconv"
 	"time"
 
+	"event-ticketing-platform/internal/logging"
 	"event-ticketing-platform/internal/models"
 	"event-ticketing-platform/internal/services"
 
@@ -95,8 +96,9 @@ func (m *AuthMiddleware) LoadUser(next http.Handler) http.Handler {
 			session.Save(r, w)
 		}
 
-		// Add user to context
+		// Add user to context and its logs
 		ctx := context.WithValue(r.Context(), UserContextKey, user)
+		ctx = logging.WithAttrs(ctx, "user_id", user.ID)
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
@@ -2,11 +2,11 @@ package middleware
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 	"strings"
 
 	"event-ticketing-platform/internal/auth"
+	"event-ticketing-platform/internal/logging"
 	"event-ticketing-platform/internal/models"
 
 	"github.com/aarondl/authboss/v3"
@@ -29,16 +29,12 @@ func NewAuthbossMiddleware(authbossConfig *auth.AuthbossConfig) *AuthbossMiddlew
 func (m *AuthbossMiddleware) LoadUser(next http.Handler) http.Handler {
 	// Use Authboss's built-in LoadClientStateMiddleware to properly load session state
 	return m.authboss.Authboss.LoadClientStateMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		fmt.Printf("[DEBUG] LoadUser middleware called for: %s\n", r.URL.Path)
-
 		// Now check if user was loaded and set in context
 		user, err := m.authboss.Authboss.CurrentUser(r)
 		if err != nil {
-			fmt.Printf("[DEBUG] Error loading current user: %v\n", err)
+			logging.FromContext(r.Context()).Warn("failed to load current user", "error", err)
 		} else if user != nil {
 			if authUser, ok := user.(*auth.AuthbossUser); ok {
-				fmt.Printf("[DEBUG] User loaded successfully: ID=%d, Email=%s\n", authUser.ID, authUser.Email)
-
 				// Convert to models.User and set in context for compatibility with existing handlers
 				modelsUser := &models.User{
 					ID:        int(authUser.ID),
@@ -52,11 +48,9 @@ func (m *AuthbossMiddleware) LoadUser(next http.Handler) http.Handler {
 
 				// Set user in context using the old context key for compatibility
 				ctx := context.WithValue(r.Context(), UserContextKey, modelsUser)
+				ctx = logging.WithAttrs(ctx, "user_id", modelsUser.ID)
 				r = r.WithContext(ctx)
-				fmt.Printf("[DEBUG] User set in context: ID=%d, Email=%s\n", modelsUser.ID, modelsUser.Email)
 			}
-		} else {
-			fmt.Printf("[DEBUG] No user found in session\n")
 		}
 
 		// Continue with the next handler
@@ -68,22 +62,17 @@ func (m *AuthbossMiddleware) LoadUser(next http.Handler) http.Handler {
 func (m *AuthbossMiddleware) RequireAuth(next http.Handler) http.Handler {
 	// Use Authboss's built-in LoadClientStateMiddleware to properly load session state first
 	return m.authboss.Authboss.LoadClientStateMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		fmt.Printf("[DEBUG] RequireAuth middleware called for: %s\n", r.URL.Path)
-
 		user, err := m.authboss.Authboss.CurrentUser(r)
 		if err != nil {
-			fmt.Printf("[DEBUG] RequireAuth - Error getting current user: %v\n", err)
+			logging.FromContext(r.Context()).Warn("failed to load current user", "error", err)
 			http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
 			return
 		} else if user == nil {
-			fmt.Printf("[DEBUG] RequireAuth - No authenticated user found, redirecting to login\n")
 			http.Redirect(w, r, "/auth/login", http.StatusSeeOther)
 			return
 		}
 
 		if authUser, ok := user.(*auth.AuthbossUser); ok {
-			fmt.Printf("[DEBUG] RequireAuth - User authenticated: ID=%d, Email=%s\n", authUser.ID, authUser.Email)
-
 			// Convert to models.User and set in context for compatibility with existing handlers
 			modelsUser := &models.User{
 				ID:        int(authUser.ID),
@@ -97,8 +86,8 @@ func (m *AuthbossMiddleware) RequireAuth(next http.Handler) http.Handler {
 
 			// Set user in context using the old context key for compatibility
 			ctx := context.WithValue(r.Context(), "user", modelsUser)
+			ctx = logging.WithAttrs(ctx, "user_id", modelsUser.ID)
 			r = r.WithContext(ctx)
-			fmt.Printf("[DEBUG] RequireAuth - User set in context: ID=%d, Email=%s\n", modelsUser.ID, modelsUser.Email)
 		}
 
 		// Continue with the actual handler
@@ -0,0 +1,168 @@
+package middleware
+
+import (
+	"fmt"
+	"hash/fnv"
+	"net/http"
+	"strings"
+)
+
+// Cache-Control policies for pages. Pages carry the session's CSRF token and
+// the signed-in user, so none of them may be stored by shared caches.
+const (
+	// RevalidateCacheControl lets browsers keep a page but check it is still
+	// current before each use, which ETag answers with a 304
+	RevalidateCacheControl = "private, no-cache"
+	// NoStoreCacheControl keeps authenticated pages out of every cache
+	NoStoreCacheControl = "private, no-store"
+)
+
+// etagMaxSize is the largest response buffered to tag it; larger ones are
+// streamed untagged
+const etagMaxSize = 1 << 20
+
+// CacheControl middleware sets the Cache-Control header of responses.
+// Handlers and middleware further in, such as a route group's override,
+// can replace it.
+func CacheControl(value string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Cache-Control", value)
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// ETag middleware tags successful GET responses with a hash of their body
+// and answers If-None-Match requests for unchanged ones with 304 Not
+// Modified, saving the client the download. Responses that already have an
+// ETag, must not be stored or are streamed are passed through.
+func ETag(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ew := &etagWriter{ResponseWriter: w, ifNoneMatch: r.Header.Get("If-None-Match")}
+		next.ServeHTTP(ew, r)
+		ew.finish()
+	})
+}
+
+// etagMatches reports whether an If-None-Match header lists etag, using the
+// weak comparison conditional GETs call for
+func etagMatches(ifNoneMatch, etag string) bool {
+	if strings.TrimSpace(ifNoneMatch) == "*" {
+		return true
+	}
+	etag = strings.TrimPrefix(etag, "W/")
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		if strings.TrimPrefix(strings.TrimSpace(candidate), "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// etagWriter buffers a response, unless it turns out not to be taggable, so
+// it can be tagged once it is complete
+type etagWriter struct {
+	http.ResponseWriter
+	ifNoneMatch string
+	status      int
+	buf         []byte
+	passthrough bool
+}
+
+func (ew *etagWriter) taggable() bool {
+	header := ew.Header()
+	return ew.status == http.StatusOK &&
+		header.Get("ETag") == "" &&
+		!strings.Contains(header.Get("Cache-Control"), "no-store") &&
+		!strings.HasPrefix(header.Get("Content-Type"), "text/event-stream")
+}
+
+func (ew *etagWriter) WriteHeader(status int) {
+	if ew.passthrough {
+		ew.ResponseWriter.WriteHeader(status)
+		return
+	}
+	if status >= 100 && status < 200 {
+		ew.ResponseWriter.WriteHeader(status)
+		return
+	}
+	if ew.status != 0 {
+		return
+	}
+	ew.status = status
+	if !ew.taggable() {
+		ew.startPassthrough()
+	}
+}
+
+func (ew *etagWriter) Write(b []byte) (int, error) {
+	if ew.status == 0 {
+		ew.WriteHeader(http.StatusOK)
+	}
+	if ew.passthrough {
+		return ew.ResponseWriter.Write(b)
+	}
+	if len(ew.buf)+len(b) > etagMaxSize {
+		if err := ew.startPassthrough(); err != nil {
+			return 0, err
+		}
+		return ew.ResponseWriter.Write(b)
+	}
+	ew.buf = append(ew.buf, b...)
+	return len(b), nil
+}
+
+// startPassthrough sends the status and anything buffered, then writes
+// straight through
+func (ew *etagWriter) startPassthrough() error {
+	ew.passthrough = true
+	ew.ResponseWriter.WriteHeader(ew.status)
+	buf := ew.buf
+	ew.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	_, err := ew.ResponseWriter.Write(buf)
+	return err
+}
+
+// Flush streams the response untagged, since it can't be held back to hash
+func (ew *etagWriter) Flush() {
+	if ew.status == 0 {
+		ew.status = http.StatusOK
+	}
+	if !ew.passthrough {
+		ew.startPassthrough()
+	}
+	http.NewResponseController(ew.ResponseWriter).Flush()
+}
+
+func (ew *etagWriter) finish() {
+	if ew.passthrough || ew.status == 0 {
+		return
+	}
+
+	hash := fnv.New64a()
+	hash.Write(ew.buf)
+	etag := fmt.Sprintf(`"%x"`, hash.Sum64())
+	ew.Header().Set("ETag", etag)
+
+	if ew.ifNoneMatch != "" && etagMatches(ew.ifNoneMatch, etag) {
+		ew.Header().Del("Content-Type")
+		ew.Header().Del("Content-Length")
+		ew.ResponseWriter.WriteHeader(http.StatusNotModified)
+		return
+	}
+	ew.ResponseWriter.WriteHeader(ew.status)
+	ew.ResponseWriter.Write(ew.buf)
+}
+
+func (ew *etagWriter) Unwrap() http.ResponseWriter {
+	return ew.ResponseWriter
+}
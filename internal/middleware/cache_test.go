@@ -0,0 +1,126 @@
+package middleware
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func serveTagged(handler http.Handler, method, ifNoneMatch string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, "/events", nil)
+	if ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", ifNoneMatch)
+	}
+	rec := httptest.NewRecorder()
+	ETag(handler).ServeHTTP(rec, req)
+	return rec
+}
+
+func TestETag_ConditionalGet(t *testing.T) {
+	page := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		io.WriteString(w, "<h1>Events</h1>")
+	})
+
+	first := serveTagged(page, http.MethodGet, "")
+	etag := first.Header().Get("ETag")
+	if first.Code != http.StatusOK || etag == "" || first.Body.String() != "<h1>Events</h1>" {
+		t.Fatalf("expected a tagged page, got %d %q %q", first.Code, etag, first.Body.String())
+	}
+
+	tests := []struct {
+		name        string
+		ifNoneMatch string
+		status      int
+	}{
+		{"unchanged", etag, http.StatusNotModified},
+		{"weak match through compression", "W/" + etag, http.StatusNotModified},
+		{"one of several", `"other", ` + etag, http.StatusNotModified},
+		{"changed", `"stale"`, http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveTagged(page, http.MethodGet, tt.ifNoneMatch)
+			if rec.Code != tt.status {
+				t.Fatalf("expected status %d, got %d", tt.status, rec.Code)
+			}
+			if tt.status == http.StatusNotModified && rec.Body.Len() != 0 {
+				t.Error("304 responses have no body")
+			}
+		})
+	}
+}
+
+func TestETag_PassesThrough(t *testing.T) {
+	tests := []struct {
+		name    string
+		method  string
+		handler http.HandlerFunc
+	}{
+		{"post", http.MethodPost, func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, "created")
+		}},
+		{"no store", http.MethodGet, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Cache-Control", NoStoreCacheControl)
+			io.WriteString(w, "dashboard")
+		}},
+		{"error", http.MethodGet, func(w http.ResponseWriter, r *http.Request) {
+			http.Error(w, "not found", http.StatusNotFound)
+		}},
+		{"own etag", http.MethodGet, func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("ETag", `"static"`)
+			io.WriteString(w, "file")
+		}},
+		{"flushed stream", http.MethodGet, func(w http.ResponseWriter, r *http.Request) {
+			io.WriteString(w, "data: 1\n\n")
+			http.NewResponseController(w).Flush()
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveTagged(tt.handler, tt.method, "*")
+			if rec.Code == http.StatusNotModified {
+				t.Fatal("expected the response to pass through")
+			}
+			if etag := rec.Header().Get("ETag"); etag != "" && etag != `"static"` {
+				t.Errorf("expected no generated ETag, got %q", etag)
+			}
+			if rec.Body.Len() == 0 {
+				t.Error("expected the body to be written")
+			}
+		})
+	}
+}
+
+func TestETag_LargeResponseStreamsUntagged(t *testing.T) {
+	body := strings.Repeat("x", etagMaxSize+1)
+	rec := serveTagged(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, body[:etagMaxSize])
+		io.WriteString(w, body[etagMaxSize:])
+	}), http.MethodGet, "")
+
+	if rec.Header().Get("ETag") != "" {
+		t.Error("expected large responses to be untagged")
+	}
+	if rec.Body.Len() != len(body) {
+		t.Errorf("expected %d bytes, got %d", len(body), rec.Body.Len())
+	}
+}
+
+func TestCacheControl_RouteOverride(t *testing.T) {
+	handler := CacheControl(RevalidateCacheControl)(CacheControl(NoStoreCacheControl)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		io.WriteString(w, "dashboard")
+	})))
+
+	rec := serveTagged(handler, http.MethodGet, "")
+	if got := rec.Header().Get("Cache-Control"); got != NoStoreCacheControl {
+		t.Errorf("expected the route's Cache-Control, got %q", got)
+	}
+	if rec.Header().Get("ETag") != "" {
+		t.Error("pages that must not be stored aren't tagged")
+	}
+}
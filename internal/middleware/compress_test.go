@@ -0,0 +1,166 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+)
+
+var largePage = "<!DOCTYPE html><html><body>" + strings.Repeat("<p>Runtown events</p>", 200) + "</body></html>"
+
+func serveCompressed(t *testing.T, acceptEncoding string, handler http.HandlerFunc) *httptest.ResponseRecorder {
+	t.Helper()
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	if acceptEncoding != "" {
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+	}
+	rec := httptest.NewRecorder()
+	Compress(handler).ServeHTTP(rec, req)
+	return rec
+}
+
+func writePage(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	io.WriteString(w, largePage)
+}
+
+func TestNegotiateEncoding(t *testing.T) {
+	tests := []struct {
+		header string
+		want   string
+	}{
+		{"gzip, deflate, br", "br"},
+		{"gzip", "gzip"},
+		{"br;q=0, gzip;q=0.8", "gzip"},
+		{"*", "br"},
+		{"gzip;q=0, *", "br"},
+		{"identity", ""},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		if got := negotiateEncoding(tt.header); got != tt.want {
+			t.Errorf("negotiateEncoding(%q) = %q, want %q", tt.header, got, tt.want)
+		}
+	}
+}
+
+func TestCompress_Encodings(t *testing.T) {
+	tests := []struct {
+		encoding string
+		decode   func(io.Reader) (io.Reader, error)
+	}{
+		{"gzip", func(r io.Reader) (io.Reader, error) { return gzip.NewReader(r) }},
+		{"br", func(r io.Reader) (io.Reader, error) { return brotli.NewReader(r), nil }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.encoding, func(t *testing.T) {
+			rec := serveCompressed(t, tt.encoding, writePage)
+
+			if got := rec.Header().Get("Content-Encoding"); got != tt.encoding {
+				t.Fatalf("expected Content-Encoding %s, got %q", tt.encoding, got)
+			}
+			if rec.Header().Get("Vary") != "Accept-Encoding" {
+				t.Errorf("expected Vary: Accept-Encoding, got %q", rec.Header().Get("Vary"))
+			}
+			if rec.Body.Len() >= len(largePage) {
+				t.Errorf("expected a smaller body, got %d bytes for %d", rec.Body.Len(), len(largePage))
+			}
+
+			reader, err := tt.decode(rec.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			body, err := io.ReadAll(reader)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if string(body) != largePage {
+				t.Error("decompressed body differs from the page")
+			}
+		})
+	}
+}
+
+func TestCompress_Skips(t *testing.T) {
+	tests := []struct {
+		name           string
+		acceptEncoding string
+		handler        http.HandlerFunc
+	}{
+		{"client without compression", "", writePage},
+		{"small response", "gzip", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+			io.WriteString(w, "<p>ok</p>")
+		}},
+		{"image", "gzip", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "image/jpeg")
+			io.WriteString(w, largePage)
+		}},
+		{"already encoded", "gzip", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/html")
+			w.Header().Set("Content-Encoding", "br")
+			io.WriteString(w, largePage)
+		}},
+		{"event stream", "gzip", func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", "text/event-stream")
+			io.WriteString(w, largePage)
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := serveCompressed(t, tt.acceptEncoding, tt.handler)
+			if got := rec.Header().Get("Content-Encoding"); got == "gzip" {
+				t.Errorf("expected the response to be left uncompressed")
+			}
+			if !strings.Contains(rec.Body.String(), "<p>") {
+				t.Errorf("expected the plain body, got %q", rec.Body.String())
+			}
+		})
+	}
+}
+
+func TestCompress_KeepsStatusAndWeakensETag(t *testing.T) {
+	rec := serveCompressed(t, "gzip", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"abc"`)
+		w.WriteHeader(http.StatusCreated)
+		io.WriteString(w, `{"events":"`+strings.Repeat("x", 2000)+`"}`)
+	})
+
+	if rec.Code != http.StatusCreated {
+		t.Errorf("expected status 201, got %d", rec.Code)
+	}
+	if rec.Header().Get("ETag") != `W/"abc"` {
+		t.Errorf("expected a weak ETag, got %q", rec.Header().Get("ETag"))
+	}
+}
+
+func TestCompress_Flush(t *testing.T) {
+	rec := serveCompressed(t, "gzip", func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		io.WriteString(w, largePage)
+		if err := http.NewResponseController(w).Flush(); err != nil {
+			t.Errorf("flush failed: %v", err)
+		}
+	})
+
+	if !rec.Flushed {
+		t.Error("expected the response to be flushed")
+	}
+	reader, err := gzip.NewReader(rec.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, _ := io.ReadAll(reader)
+	if string(body) != largePage {
+		t.Error("decompressed body differs from the page")
+	}
+}
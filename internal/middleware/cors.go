@@ -3,7 +3,9 @@ package middleware
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"strings"
+	"time"
 )
 
 // CORSConfig holds CORS configuration
@@ -43,55 +45,151 @@ func DefaultCORSConfig() CORSConfig {
 	}
 }
 
+// SameOriginCORSConfig returns a configuration that allows no cross-origin
+// access, for pages that rely on the session cookie such as the dashboard and admin
+func SameOriginCORSConfig() CORSConfig {
+	return CORSConfig{}
+}
+
+// PublicAPICORSConfig returns a configuration for the token-authenticated
+// public API. Cookies are never sent, so configured origins can't act as the user.
+func PublicAPICORSConfig(allowedOrigins []string, maxAge time.Duration) CORSConfig {
+	return CORSConfig{
+		AllowedOrigins: allowedOrigins,
+		AllowedMethods: []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
+		AllowedHeaders: []string{
+			"Accept",
+			"Authorization",
+			"Content-Type",
+			"Idempotency-Key",
+		},
+		ExposedHeaders: []string{
+			"Location",
+			"Retry-After",
+		},
+		MaxAge: int(maxAge / time.Second),
+	}
+}
+
+// WidgetCORSConfig returns a read-only configuration for the embeddable event widget
+func WidgetCORSConfig(allowedOrigins []string, maxAge time.Duration) CORSConfig {
+	return CORSConfig{
+		AllowedOrigins: allowedOrigins,
+		AllowedMethods: []string{"GET", "OPTIONS"},
+		AllowedHeaders: []string{"Accept", "Content-Type"},
+		MaxAge:         int(maxAge / time.Second),
+	}
+}
+
 // CORSMiddleware creates a CORS middleware with the given configuration
 func CORSMiddleware(config CORSConfig) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			origin := r.Header.Get("Origin")
-			
-			// Check if origin is allowed
-			if isOriginAllowed(origin, config.AllowedOrigins) {
-				w.Header().Set("Access-Control-Allow-Origin", origin)
+
+			// Responses differ by origin, so caches must not share them
+			w.Header().Add("Vary", "Origin")
+
+			preflight := r.Method == "OPTIONS" && r.Header.Get("Access-Control-Request-Method") != ""
+
+			if origin == "" || !isOriginAllowed(origin, config.AllowedOrigins) {
+				// Browsers block the response without CORS headers; reject
+				// cross-origin preflights outright so the request is never sent
+				if preflight && origin != "" && !isSameOrigin(r, origin) {
+					http.Error(w, "Origin not allowed", http.StatusForbidden)
+					return
+				}
+				next.ServeHTTP(w, r)
+				return
 			}
-			
+
+			w.Header().Set("Access-Control-Allow-Origin", origin)
+
 			// Set other CORS headers
 			if config.AllowCredentials {
 				w.Header().Set("Access-Control-Allow-Credentials", "true")
 			}
-			
-			if len(config.AllowedMethods) > 0 {
-				w.Header().Set("Access-Control-Allow-Methods", strings.Join(config.AllowedMethods, ", "))
-			}
-			
-			if len(config.AllowedHeaders) > 0 {
-				w.Header().Set("Access-Control-Allow-Headers", strings.Join(config.AllowedHeaders, ", "))
-			}
-			
+
 			if len(config.ExposedHeaders) > 0 {
 				w.Header().Set("Access-Control-Expose-Headers", strings.Join(config.ExposedHeaders, ", "))
 			}
-			
-			if config.MaxAge > 0 {
-				w.Header().Set("Access-Control-Max-Age", fmt.Sprintf("%d", config.MaxAge))
-			}
-			
+
 			// Handle preflight requests
 			if r.Method == "OPTIONS" {
+				if len(config.AllowedMethods) > 0 {
+					w.Header().Set("Access-Control-Allow-Methods", strings.Join(config.AllowedMethods, ", "))
+				}
+
+				if len(config.AllowedHeaders) > 0 {
+					w.Header().Set("Access-Control-Allow-Headers", strings.Join(config.AllowedHeaders, ", "))
+				}
+
+				if config.MaxAge > 0 {
+					w.Header().Set("Access-Control-Max-Age", fmt.Sprintf("%d", config.MaxAge))
+				}
+
 				w.WriteHeader(http.StatusNoContent)
 				return
 			}
-			
+
 			next.ServeHTTP(w, r)
 		})
 	}
 }
 
+// CORSPolicy applies a CORS configuration to every route under a path prefix
+type CORSPolicy struct {
+	PathPrefix string
+	Config     CORSConfig
+}
+
+// CORSPolicyMiddleware applies the policy with the longest matching path prefix,
+// or defaultConfig when none match. It runs before routing so preflight requests
+// are answered before any authentication middleware in the route group.
+func CORSPolicyMiddleware(defaultConfig CORSConfig, policies ...CORSPolicy) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		defaultHandler := CORSMiddleware(defaultConfig)(next)
+
+		handlers := make([]http.Handler, len(policies))
+		for i, policy := range policies {
+			handlers[i] = CORSMiddleware(policy.Config)(next)
+		}
+
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			handler := defaultHandler
+			longest := -1
+			for i, policy := range policies {
+				if matchesPathPrefix(r.URL.Path, policy.PathPrefix) && len(policy.PathPrefix) > longest {
+					handler = handlers[i]
+					longest = len(policy.PathPrefix)
+				}
+			}
+			handler.ServeHTTP(w, r)
+		})
+	}
+}
+
+// matchesPathPrefix reports whether path is prefix or a path below it
+func matchesPathPrefix(path, prefix string) bool {
+	prefix = strings.TrimSuffix(prefix, "/")
+	return path == prefix || strings.HasPrefix(path, prefix+"/")
+}
+
+// isSameOrigin checks if the origin is the host serving the request
+func isSameOrigin(r *http.Request, origin string) bool {
+	parsed, err := url.Parse(origin)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(parsed.Host, r.Host)
+}
+
 // isOriginAllowed checks if the origin is in the allowed list
 func isOriginAllowed(origin string, allowedOrigins []string) bool {
 	if len(allowedOrigins) == 0 {
 		return false
 	}
-	
+
 	for _, allowed := range allowedOrigins {
 		if allowed == "*" {
 			return true
@@ -101,13 +199,13 @@ func isOriginAllowed(origin string, allowedOrigins []string) bool {
 		}
 		// Support wildcard subdomains (e.g., *.example.com)
 		if strings.HasPrefix(allowed, "*.") {
-			domain := strings.TrimPrefix(allowed, "*.")
+			domain := strings.TrimPrefix(allowed, "*")
 			if strings.HasSuffix(origin, domain) {
 				return true
 			}
 		}
 	}
-	
+
 	return false
 }
 
@@ -0,0 +1,75 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func newCORSPolicyHandler() http.Handler {
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	return CORSPolicyMiddleware(
+		SameOriginCORSConfig(),
+		CORSPolicy{PathPrefix: "/api/v1", Config: PublicAPICORSConfig([]string{"https://partner.example.com", "*.trusted.com"}, 2*time.Hour)},
+		CORSPolicy{PathPrefix: "/widget", Config: WidgetCORSConfig([]string{"*"}, 10*time.Minute)},
+	)(next)
+}
+
+func TestCORSPolicyMiddleware(t *testing.T) {
+	handler := newCORSPolicyHandler()
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		origin      string
+		preflight   bool
+		status      int
+		allowOrigin string
+		maxAge      string
+	}{
+		{name: "dashboard rejects cross-origin preflight", method: "OPTIONS", path: "/dashboard/events", origin: "https://evil.com", preflight: true, status: http.StatusForbidden},
+		{name: "admin gets no CORS headers", method: "POST", path: "/admin/users", origin: "https://evil.com", status: http.StatusOK},
+		{name: "same-origin request", method: "GET", path: "/admin", origin: "http://example.com", status: http.StatusOK},
+		{name: "internal api is same-origin only", method: "OPTIONS", path: "/api/organizer/dashboard", origin: "https://partner.example.com", preflight: true, status: http.StatusForbidden},
+		{name: "public api preflight from configured origin", method: "OPTIONS", path: "/api/v1/events", origin: "https://partner.example.com", preflight: true, status: http.StatusNoContent, allowOrigin: "https://partner.example.com", maxAge: "7200"},
+		{name: "public api request from configured origin", method: "GET", path: "/api/v1/events", origin: "https://partner.example.com", status: http.StatusOK, allowOrigin: "https://partner.example.com"},
+		{name: "public api wildcard subdomain", method: "GET", path: "/api/v1/events", origin: "https://app.trusted.com", status: http.StatusOK, allowOrigin: "https://app.trusted.com"},
+		{name: "public api rejects lookalike domain", method: "OPTIONS", path: "/api/v1/events", origin: "https://untrusted.com", preflight: true, status: http.StatusForbidden},
+		{name: "public api rejects unknown origin", method: "OPTIONS", path: "/api/v1/events", origin: "https://evil.com", preflight: true, status: http.StatusForbidden},
+		{name: "prefix must end at a path segment", method: "OPTIONS", path: "/api/v10", origin: "https://partner.example.com", preflight: true, status: http.StatusForbidden},
+		{name: "widget allows any origin", method: "OPTIONS", path: "/widget/events/1", origin: "https://blog.example.org", preflight: true, status: http.StatusNoContent, allowOrigin: "https://blog.example.org", maxAge: "600"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "http://example.com"+tt.path, nil)
+			req.Header.Set("Origin", tt.origin)
+			if tt.preflight {
+				req.Header.Set("Access-Control-Request-Method", "POST")
+			}
+			rr := httptest.NewRecorder()
+
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.status {
+				t.Errorf("expected status %d, got %d", tt.status, rr.Code)
+			}
+			if got := rr.Header().Get("Access-Control-Allow-Origin"); got != tt.allowOrigin {
+				t.Errorf("expected Access-Control-Allow-Origin %q, got %q", tt.allowOrigin, got)
+			}
+			if got := rr.Header().Get("Access-Control-Max-Age"); got != tt.maxAge {
+				t.Errorf("expected Access-Control-Max-Age %q, got %q", tt.maxAge, got)
+			}
+			if rr.Header().Get("Access-Control-Allow-Credentials") != "" {
+				t.Error("expected credentials never to be allowed cross-origin")
+			}
+			if rr.Header().Get("Vary") != "Origin" {
+				t.Errorf("expected Vary: Origin, got %q", rr.Header().Get("Vary"))
+			}
+		})
+	}
+}
@@ -2,9 +2,10 @@ package middleware
 
 import (
 	"context"
-	"fmt"
 	"net/http"
 
+	"event-ticketing-platform/internal/logging"
+
 	"github.com/gorilla/sessions"
 )
 
@@ -50,12 +51,9 @@ func (m *CSRFMiddleware) CSRFProtection(next http.Handler) http.Handler {
 			requestToken = r.FormValue("csrf_token")
 		}
 
-		// Debug logging for CSRF validation
-		fmt.Printf("CSRF validation - Session token: %s, Request token: %s, Match: %t\n", 
-			sessionToken, requestToken, requestToken == sessionToken)
-
 		// Validate CSRF token
 		if requestToken != sessionToken {
+			logging.FromContext(r.Context()).Warn("CSRF token mismatch", "path", r.URL.Path, "token_sent", requestToken != "")
 			if IsHTMXRequest(r) {
 				w.WriteHeader(http.StatusForbidden)
 				w.Write([]byte(`
@@ -93,9 +91,6 @@ func (m *CSRFMiddleware) EnsureCSRFToken(next http.Handler) http.Handler {
 				token = GenerateCSRFToken()
 				session.Values["csrf_token"] = token
 				session.Save(r, w)
-				fmt.Printf("Generated new CSRF token: %s\n", token)
-			} else {
-				fmt.Printf("Using existing CSRF token: %s\n", token)
 			}
 			
 			// Add CSRF token to request context for templates
@@ -103,7 +98,7 @@ func (m *CSRFMiddleware) EnsureCSRFToken(next http.Handler) http.Handler {
 			ctx = context.WithValue(ctx, "csrf_token", token)
 			r = r.WithContext(ctx)
 		} else {
-			fmt.Printf("Failed to get session for CSRF token: %v\n", err)
+			logging.FromContext(r.Context()).Warn("failed to get session for CSRF token", "error", err)
 		}
 
 		next.ServeHTTP(w, r)
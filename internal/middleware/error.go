@@ -1,9 +1,10 @@
 package middleware
 
 import (
-	"log"
 	"net/http"
 	"runtime/debug"
+
+	"event-ticketing-platform/internal/logging"
 )
 
 // ErrorHandlingMiddleware handles panics and errors
@@ -12,7 +13,7 @@ func ErrorHandlingMiddleware(next http.Handler) http.Handler {
 		defer func() {
 			if err := recover(); err != nil {
 				// Log the panic with stack trace
-				log.Printf("PANIC: %v\n%s", err, debug.Stack())
+				logging.FromContext(r.Context()).Error("panic", "error", err, "stack", string(debug.Stack()))
 				
 				// Return appropriate error response
 				if IsHTMXRequest(r) {
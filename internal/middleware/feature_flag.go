@@ -0,0 +1,26 @@
+package middleware
+
+import (
+	"net/http"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// FeatureChecker reports whether features are switched on
+type FeatureChecker interface {
+	FeatureEnabled(flag models.FeatureFlag) bool
+}
+
+// RequireFeature responds 404 Not Found to the routes of a feature while
+// its flag is switched off, as if they didn't exist
+func RequireFeature(checker FeatureChecker, flag models.FeatureFlag) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !checker.FeatureEnabled(flag) {
+				http.NotFound(w, r)
+				return
+			}
+			next.ServeHTTP(w, r)
+		})
+	}
+}
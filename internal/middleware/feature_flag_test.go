@@ -0,0 +1,41 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"event-ticketing-platform/internal/models"
+)
+
+type mockFeatureChecker map[models.FeatureFlag]bool
+
+func (m mockFeatureChecker) FeatureEnabled(flag models.FeatureFlag) bool {
+	return m[flag]
+}
+
+func TestRequireFeature(t *testing.T) {
+	checker := mockFeatureChecker{models.FeatureWaitlist: true}
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	tests := []struct {
+		name           string
+		flag           models.FeatureFlag
+		expectedStatus int
+	}{
+		{name: "feature on", flag: models.FeatureWaitlist, expectedStatus: http.StatusOK},
+		{name: "feature off", flag: models.FeatureGuestCheckout, expectedStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			RequireFeature(checker, tt.flag)(next).ServeHTTP(rr, httptest.NewRequest("GET", "/events/1/waitlist", nil))
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+		})
+	}
+}
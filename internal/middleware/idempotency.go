@@ -0,0 +1,164 @@
+package middleware
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"net/http"
+	"time"
+
+	"event-ticketing-platform/internal/logging"
+	"event-ticketing-platform/internal/models"
+)
+
+// Idempotency keys are sent in a header by API clients, and in a hidden form
+// field by pages
+const (
+	IdempotencyKeyHeader = "Idempotency-Key"
+	IdempotencyKeyField  = "idempotency_key"
+)
+
+// idempotencyWait is how long a retry waits for the request holding its key
+// to finish, so a double-clicked form still ends up on its result
+const idempotencyWait = 10 * time.Second
+
+// idempotencyPoll is how often a waiting retry checks the key again
+const idempotencyPoll = 200 * time.Millisecond
+
+// IdempotencyStore remembers the results of requests by their idempotency key
+type IdempotencyStore interface {
+	Begin(scope string, userID int, key string) (*models.IdempotencyRecord, bool, error)
+	Complete(scope string, userID int, key string, status int, location string) error
+	Release(scope string, userID int, key string) error
+}
+
+// NewIdempotencyKey returns a random key for a form to send, so submitting it
+// twice is recognised as one request
+func NewIdempotencyKey() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
+
+// Idempotent middleware makes requests sent with an idempotency key safe to
+// retry. The first request with a key runs; if it answers with a redirect,
+// as a completed checkout does, retries are sent to the same place instead
+// of running again. Any other answer, such as a form with errors, releases
+// the key so it can be tried again. Keys belong to the signed-in user, and
+// requests without one run as normal.
+func Idempotent(store IdempotencyStore, scope string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			key := r.Header.Get(IdempotencyKeyHeader)
+			if key == "" {
+				key = r.FormValue(IdempotencyKeyField)
+			}
+			if key == "" {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			userID := 0
+			if user := GetUserFromContext(r.Context()); user != nil {
+				userID = user.ID
+			}
+
+			record, claimed, err := store.Begin(scope, userID, key)
+			deadline := time.Now().Add(idempotencyWait)
+			for err == nil && !claimed && !record.IsCompleted() && time.Now().Before(deadline) {
+				select {
+				case <-r.Context().Done():
+					return
+				case <-time.After(idempotencyPoll):
+				}
+				record, claimed, err = store.Begin(scope, userID, key)
+			}
+			if err != nil {
+				if !models.ValidIdempotencyKey(key) {
+					http.Error(w, "Invalid idempotency key", http.StatusBadRequest)
+					return
+				}
+				logging.FromContext(r.Context()).Error("failed to check idempotency key", "scope", scope, "error", err)
+				http.Error(w, "Please try again in a moment", http.StatusServiceUnavailable)
+				return
+			}
+
+			if !claimed {
+				if !record.IsCompleted() {
+					w.Header().Set("Retry-After", "5")
+					http.Error(w, "This request is still being processed", http.StatusConflict)
+					return
+				}
+				replayIdempotent(w, r, record)
+				return
+			}
+
+			recorder := &idempotencyRecorder{ResponseWriter: w}
+			defer func() {
+				location := recorder.redirect()
+				if location == "" {
+					if err := store.Release(scope, userID, key); err != nil {
+						logging.FromContext(r.Context()).Warn("failed to release idempotency key", "scope", scope, "error", err)
+					}
+					return
+				}
+				if err := store.Complete(scope, userID, key, recorder.status, location); err != nil {
+					logging.FromContext(r.Context()).Error("failed to record idempotent result", "scope", scope, "error", err)
+				}
+			}()
+			next.ServeHTTP(recorder, r)
+		})
+	}
+}
+
+// replayIdempotent sends a retry to where the request holding its key was
+// redirected
+func replayIdempotent(w http.ResponseWriter, r *http.Request, record *models.IdempotencyRecord) {
+	w.Header().Set("Idempotent-Replayed", "true")
+	if IsHTMXRequest(r) {
+		w.Header().Set("HX-Redirect", record.ResponseLocation)
+		w.WriteHeader(http.StatusOK)
+		return
+	}
+
+	status := record.ResponseStatus
+	if status < 300 || status >= 400 {
+		status = http.StatusSeeOther
+	}
+	http.Redirect(w, r, record.ResponseLocation, status)
+}
+
+// idempotencyRecorder notes the status a request is answered with
+type idempotencyRecorder struct {
+	http.ResponseWriter
+	status int
+}
+
+func (ir *idempotencyRecorder) WriteHeader(status int) {
+	if ir.status == 0 && (status < 100 || status >= 200) {
+		ir.status = status
+	}
+	ir.ResponseWriter.WriteHeader(status)
+}
+
+func (ir *idempotencyRecorder) Write(b []byte) (int, error) {
+	if ir.status == 0 {
+		ir.status = http.StatusOK
+	}
+	return ir.ResponseWriter.Write(b)
+}
+
+// redirect returns where the response redirected to, by HTMX header or
+// Location, or "" when it didn't
+func (ir *idempotencyRecorder) redirect() string {
+	if location := ir.Header().Get("HX-Redirect"); location != "" && ir.status < 300 {
+		return location
+	}
+	if ir.status >= 300 && ir.status < 400 {
+		return ir.Header().Get("Location")
+	}
+	return ""
+}
+
+func (ir *idempotencyRecorder) Unwrap() http.ResponseWriter {
+	return ir.ResponseWriter
+}
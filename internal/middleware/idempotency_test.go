@@ -0,0 +1,165 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// memoryIdempotencyStore keeps idempotency records in memory
+type memoryIdempotencyStore struct {
+	mu      sync.Mutex
+	records map[string]*models.IdempotencyRecord
+}
+
+func newMemoryIdempotencyStore() *memoryIdempotencyStore {
+	return &memoryIdempotencyStore{records: make(map[string]*models.IdempotencyRecord)}
+}
+
+func (m *memoryIdempotencyStore) Begin(scope string, userID int, key string) (*models.IdempotencyRecord, bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	id := scope + "/" + key
+	if record, ok := m.records[id]; ok && record.UserID == userID {
+		copied := *record
+		return &copied, false, nil
+	}
+	m.records[id] = &models.IdempotencyRecord{Scope: scope, UserID: userID, Key: key, Status: models.IdempotencyProcessing}
+	return m.records[id], true, nil
+}
+
+func (m *memoryIdempotencyStore) Complete(scope string, userID int, key string, status int, location string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	record := m.records[scope+"/"+key]
+	record.Status = models.IdempotencyCompleted
+	record.ResponseStatus = status
+	record.ResponseLocation = location
+	return nil
+}
+
+func (m *memoryIdempotencyStore) Release(scope string, userID int, key string) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.records, scope+"/"+key)
+	return nil
+}
+
+func postWithKey(key string, htmx bool) *http.Request {
+	form := url.Values{IdempotencyKeyField: {key}}
+	req := httptest.NewRequest("POST", "/checkout", strings.NewReader(form.Encode()))
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	if htmx {
+		req.Header.Set("HX-Request", "true")
+	}
+	return req
+}
+
+func TestIdempotent_ReplaysRedirects(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	var runs atomic.Int32
+	handler := Idempotent(store, "checkout")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		runs.Add(1)
+		http.Redirect(w, r, "/orders/42/confirmation", http.StatusSeeOther)
+	}))
+
+	for i := 0; i < 3; i++ {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, postWithKey("key-1", false))
+		if rr.Code != http.StatusSeeOther || rr.Header().Get("Location") != "/orders/42/confirmation" {
+			t.Fatalf("attempt %d: expected redirect to the order, got %d %q", i, rr.Code, rr.Header().Get("Location"))
+		}
+	}
+	if runs.Load() != 1 {
+		t.Errorf("expected the checkout to run once, ran %d times", runs.Load())
+	}
+
+	// HTMX retries are redirected the HTMX way
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, postWithKey("key-1", true))
+	if rr.Header().Get("HX-Redirect") != "/orders/42/confirmation" || rr.Header().Get("Idempotent-Replayed") != "true" {
+		t.Errorf("expected HTMX replay, got headers %v", rr.Header())
+	}
+
+	// Another key is another checkout
+	handler.ServeHTTP(httptest.NewRecorder(), postWithKey("key-2", false))
+	if runs.Load() != 2 {
+		t.Errorf("expected a new key to run the checkout again, ran %d times", runs.Load())
+	}
+}
+
+func TestIdempotent_ReleasesFailedAttempts(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	var runs atomic.Int32
+	handler := Idempotent(store, "checkout")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if runs.Add(1) == 1 {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte("form with errors"))
+			return
+		}
+		w.Header().Set("HX-Redirect", "/orders/42/confirmation")
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), postWithKey("key-1", true))
+	handler.ServeHTTP(httptest.NewRecorder(), postWithKey("key-1", true))
+	handler.ServeHTTP(httptest.NewRecorder(), postWithKey("key-1", true))
+	if runs.Load() != 2 {
+		t.Errorf("expected a failed attempt to be retried and the success replayed, ran %d times", runs.Load())
+	}
+}
+
+func TestIdempotent_WaitsForRequestInProgress(t *testing.T) {
+	store := newMemoryIdempotencyStore()
+	started := make(chan struct{})
+	finish := make(chan struct{})
+	var runs atomic.Int32
+	handler := Idempotent(store, "checkout")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		runs.Add(1)
+		close(started)
+		<-finish
+		http.Redirect(w, r, "/orders/42/confirmation", http.StatusSeeOther)
+	}))
+
+	go handler.ServeHTTP(httptest.NewRecorder(), postWithKey("key-1", false))
+	<-started
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, postWithKey("key-1", false))
+		done <- rr
+	}()
+
+	time.Sleep(50 * time.Millisecond)
+	close(finish)
+
+	rr := <-done
+	if rr.Code != http.StatusSeeOther || rr.Header().Get("Location") != "/orders/42/confirmation" {
+		t.Errorf("expected the double submission to end on the first's result, got %d %q", rr.Code, rr.Header().Get("Location"))
+	}
+	if runs.Load() != 1 {
+		t.Errorf("expected the checkout to run once, ran %d times", runs.Load())
+	}
+}
+
+func TestIdempotent_WithoutKey(t *testing.T) {
+	var runs atomic.Int32
+	handler := Idempotent(newMemoryIdempotencyStore(), "checkout")(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		runs.Add(1)
+		http.Redirect(w, r, "/orders/42/confirmation", http.StatusSeeOther)
+	}))
+
+	handler.ServeHTTP(httptest.NewRecorder(), postWithKey("", false))
+	handler.ServeHTTP(httptest.NewRecorder(), postWithKey("", false))
+	if runs.Load() != 2 {
+		t.Errorf("expected requests without a key to run normally, ran %d times", runs.Load())
+	}
+}
@@ -0,0 +1,108 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"event-ticketing-platform/internal/logging"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
+
+	"github.com/gorilla/sessions"
+)
+
+const (
+	// ImpersonationContextKey holds the active impersonation for templates
+	ImpersonationContextKey = "impersonation"
+
+	impersonateUserKey      = "impersonate_user_id"
+	impersonationStartedKey = "impersonation_started_at"
+)
+
+// ImpersonationResolver reloads impersonations and audits what is done during them
+type ImpersonationResolver interface {
+	Resume(admin *models.User, targetID int, startedAt time.Time) (*models.Impersonation, error)
+	LogRequest(impersonation *models.Impersonation, r *http.Request)
+}
+
+// Impersonation swaps the logged in admin for the user they are
+// impersonating. The admin's own session is left untouched, so ending the
+// impersonation only has to drop the impersonation from the session.
+// Expired or invalid impersonations are dropped and the admin continues as
+// themselves. Every request that changes something is audited.
+func Impersonation(store sessions.Store, resolver ImpersonationResolver) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			admin := GetUserFromContext(r.Context())
+			if admin == nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			session, err := store.Get(r, "session")
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			targetID, ok := session.Values[impersonateUserKey].(int)
+			if !ok {
+				next.ServeHTTP(w, r)
+				return
+			}
+			startedAt, _ := session.Values[impersonationStartedKey].(int64)
+
+			impersonation, err := resolver.Resume(admin, targetID, time.Unix(startedAt, 0))
+			if err != nil {
+				clearImpersonation(session)
+				session.Save(r, w)
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			if r.Method != http.MethodGet && r.Method != http.MethodHead && r.Method != http.MethodOptions {
+				resolver.LogRequest(impersonation, r)
+			}
+
+			ctx := context.WithValue(r.Context(), UserContextKey, impersonation.User)
+			ctx = context.WithValue(ctx, ImpersonationContextKey, impersonation)
+			ctx = logging.WithAttrs(ctx, "impersonated_user_id", impersonation.User.ID) // user_id stays the admin's
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
+
+// GetImpersonation returns the active impersonation, or nil
+func GetImpersonation(ctx context.Context) *models.Impersonation {
+	impersonation, _ := ctx.Value(ImpersonationContextKey).(*models.Impersonation)
+	return impersonation
+}
+
+// StartImpersonation stores an impersonation in the admin's session
+func StartImpersonation(w http.ResponseWriter, r *http.Request, store sessions.Store, impersonation *models.Impersonation) error {
+	session, err := store.Get(r, "session")
+	if err != nil {
+		return err
+	}
+	services.RenewSessionID(session)
+	session.Values[impersonateUserKey] = impersonation.User.ID
+	session.Values[impersonationStartedKey] = impersonation.StartedAt.Unix()
+	return session.Save(r, w)
+}
+
+// StopImpersonation drops the impersonation from the admin's session
+func StopImpersonation(w http.ResponseWriter, r *http.Request, store sessions.Store) error {
+	session, err := store.Get(r, "session")
+	if err != nil {
+		return err
+	}
+	services.RenewSessionID(session)
+	clearImpersonation(session)
+	return session.Save(r, w)
+}
+
+func clearImpersonation(session *sessions.Session) {
+	delete(session.Values, impersonateUserKey)
+	delete(session.Values, impersonationStartedKey)
+}
@@ -0,0 +1,131 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+
+	"github.com/gorilla/sessions"
+)
+
+type mockImpersonationResolver struct {
+	target *models.User
+	err    error
+	logged []string
+}
+
+func (m *mockImpersonationResolver) Resume(admin *models.User, targetID int, startedAt time.Time) (*models.Impersonation, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &models.Impersonation{Admin: admin, User: m.target, StartedAt: startedAt}, nil
+}
+
+func (m *mockImpersonationResolver) LogRequest(impersonation *models.Impersonation, r *http.Request) {
+	m.logged = append(m.logged, r.Method+" "+r.URL.Path)
+}
+
+// impersonationCookies returns the session cookies of an admin impersonating the target
+func impersonationCookies(t *testing.T, store sessions.Store, target *models.User) []*http.Cookie {
+	t.Helper()
+	rr := httptest.NewRecorder()
+	impersonation := &models.Impersonation{User: target, StartedAt: time.Now()}
+	if err := StartImpersonation(rr, httptest.NewRequest("POST", "/", nil), store, impersonation); err != nil {
+		t.Fatalf("StartImpersonation failed: %v", err)
+	}
+	return rr.Result().Cookies()
+}
+
+func TestImpersonation(t *testing.T) {
+	store := sessions.NewCookieStore([]byte("test-secret"))
+	admin := &models.User{ID: 1, Role: models.UserRoleAdmin}
+	target := &models.User{ID: 7, Role: models.UserRoleUser}
+	cookies := impersonationCookies(t, store, target)
+
+	var seenUser *models.User
+	var seenImpersonation *models.Impersonation
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		seenUser = GetUserFromContext(r.Context())
+		seenImpersonation = GetImpersonation(r.Context())
+	})
+
+	serve := func(resolver *mockImpersonationResolver, method string, user *models.User) *httptest.ResponseRecorder {
+		seenUser, seenImpersonation = nil, nil
+		req := httptest.NewRequest(method, "/dashboard", nil)
+		for _, cookie := range cookies {
+			req.AddCookie(cookie)
+		}
+		if user != nil {
+			req = req.WithContext(SetUserContext(req.Context(), user))
+		}
+		rr := httptest.NewRecorder()
+		Impersonation(store, resolver)(next).ServeHTTP(rr, req)
+		return rr
+	}
+
+	t.Run("swaps in the impersonated user", func(t *testing.T) {
+		resolver := &mockImpersonationResolver{target: target}
+		serve(resolver, "GET", admin)
+
+		if seenUser == nil || seenUser.ID != 7 {
+			t.Errorf("expected impersonated user in context, got %+v", seenUser)
+		}
+		if seenImpersonation == nil || seenImpersonation.Admin.ID != 1 {
+			t.Errorf("expected impersonation in context, got %+v", seenImpersonation)
+		}
+		if len(resolver.logged) != 0 {
+			t.Errorf("expected reads not to be audited, got %v", resolver.logged)
+		}
+	})
+
+	t.Run("audits changes", func(t *testing.T) {
+		resolver := &mockImpersonationResolver{target: target}
+		serve(resolver, "POST", admin)
+
+		if len(resolver.logged) != 1 || resolver.logged[0] != "POST /dashboard" {
+			t.Errorf("expected the request to be audited, got %v", resolver.logged)
+		}
+	})
+
+	t.Run("drops invalid impersonations", func(t *testing.T) {
+		resolver := &mockImpersonationResolver{err: errors.New("impersonation has expired")}
+		rr := serve(resolver, "GET", admin)
+
+		if seenUser != admin || seenImpersonation != nil {
+			t.Errorf("expected the admin to continue as themselves, got %+v", seenUser)
+		}
+		if len(rr.Result().Cookies()) == 0 {
+			t.Error("expected the session to be saved without the impersonation")
+		}
+	})
+
+	t.Run("ignores anonymous requests", func(t *testing.T) {
+		serve(&mockImpersonationResolver{target: target}, "GET", nil)
+
+		if seenUser != nil || seenImpersonation != nil {
+			t.Errorf("expected no user, got %+v", seenUser)
+		}
+	})
+}
+
+func TestStopImpersonation(t *testing.T) {
+	store := sessions.NewCookieStore([]byte("test-secret"))
+	cookies := impersonationCookies(t, store, &models.User{ID: 7})
+
+	req := httptest.NewRequest("POST", "/impersonation/stop", nil)
+	for _, cookie := range cookies {
+		req.AddCookie(cookie)
+	}
+	if err := StopImpersonation(httptest.NewRecorder(), req, store); err != nil {
+		t.Fatalf("StopImpersonation failed: %v", err)
+	}
+
+	session, _ := store.Get(req, "session")
+	if _, ok := session.Values[impersonateUserKey]; ok {
+		t.Error("expected the impersonation to be removed from the session")
+	}
+}
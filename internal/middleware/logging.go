@@ -2,13 +2,25 @@ package middleware
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
-	"log"
+	"log/slog"
 	"net/http"
 	"time"
+
+	"event-ticketing-platform/internal/logging"
 )
 
-// LoggingMiddleware logs HTTP requests
+// RequestIDContextKey holds the request's ID in its context
+const RequestIDContextKey contextKey = "request_id"
+
+// maxRequestIDLength bounds request IDs accepted from proxies
+const maxRequestIDLength = 64
+
+// LoggingMiddleware logs each HTTP request as a structured record through the
+// request's logger, so it carries the request ID and, when mounted after the
+// user is loaded, the user ID. Server errors are logged at error level.
 func LoggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		start := time.Now()
@@ -23,15 +35,17 @@ func LoggingMiddleware(next http.Handler) http.Handler {
 		next.ServeHTTP(wrapped, r)
 
 		// Log request details
-		duration := time.Since(start)
-		log.Printf(
-			"%s %s %d %v %s %s",
-			r.Method,
-			r.URL.Path,
-			wrapped.statusCode,
-			duration,
-			r.RemoteAddr,
-			r.UserAgent(),
+		level := slog.LevelInfo
+		if wrapped.statusCode >= http.StatusInternalServerError {
+			level = slog.LevelError
+		}
+		logging.FromContext(r.Context()).Log(r.Context(), level, "request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"status", wrapped.statusCode,
+			"duration", time.Since(start),
+			"remote_addr", r.RemoteAddr,
+			"user_agent", r.UserAgent(),
 		)
 	})
 }
@@ -47,6 +61,12 @@ func (rw *responseWriter) WriteHeader(code int) {
 	rw.ResponseWriter.WriteHeader(code)
 }
 
+// Unwrap lets http.ResponseController reach the underlying writer, so
+// handlers behind the logger can flush
+func (rw *responseWriter) Unwrap() http.ResponseWriter {
+	return rw.ResponseWriter
+}
+
 // DetailedLoggingMiddleware provides more detailed logging including request body size
 func DetailedLoggingMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -60,29 +80,25 @@ func DetailedLoggingMiddleware(next http.Handler) http.Handler {
 		}
 
 		// Get user info if available
-		var userInfo string
+		userInfo := "anonymous"
 		if user := GetUserFromContext(r.Context()); user != nil {
 			userInfo = user.Email
-		} else {
-			userInfo = "anonymous"
 		}
 
 		// Process request
 		next.ServeHTTP(wrapped, r)
 
 		// Log detailed request information
-		duration := time.Since(start)
-		log.Printf(
-			"[%s] %s %s %d %d bytes %v - User: %s - IP: %s - UA: %s",
-			r.Method,
-			r.URL.Path,
-			r.URL.RawQuery,
-			wrapped.statusCode,
-			wrapped.size,
-			duration,
-			userInfo,
-			getClientIP(r),
-			r.UserAgent(),
+		logging.FromContext(r.Context()).Info("request",
+			"method", r.Method,
+			"path", r.URL.Path,
+			"query", r.URL.RawQuery,
+			"status", wrapped.statusCode,
+			"bytes", wrapped.size,
+			"duration", time.Since(start),
+			"user", userInfo,
+			"ip", getClientIP(r),
+			"user_agent", r.UserAgent(),
 		)
 	})
 }
@@ -121,24 +137,51 @@ func getClientIP(r *http.Request) string {
 	return r.RemoteAddr
 }
 
-// RequestIDMiddleware adds a unique request ID to each request
+// RequestIDMiddleware gives each request an ID, echoed in the X-Request-ID
+// response header and added to the request's logger. An ID sent by a proxy
+// in the X-Request-ID request header is kept so logs correlate across hops.
 func RequestIDMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		requestID := generateRequestID()
-		
+		requestID := r.Header.Get("X-Request-ID")
+		if !validRequestID(requestID) {
+			requestID = generateRequestID()
+		}
+
 		// Add request ID to response headers
 		w.Header().Set("X-Request-ID", requestID)
-		
-		// Add request ID to context for use in handlers
-		ctx := r.Context()
-		ctx = context.WithValue(ctx, "request_id", requestID)
-		
+
+		// Add request ID to context for use in handlers and their logs
+		ctx := context.WithValue(r.Context(), RequestIDContextKey, requestID)
+		ctx = logging.WithAttrs(ctx, "request_id", requestID)
+
 		next.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
-// generateRequestID generates a unique request ID
+// GetRequestID returns the ID RequestIDMiddleware gave the request
+func GetRequestID(ctx context.Context) string {
+	requestID, _ := ctx.Value(RequestIDContextKey).(string)
+	return requestID
+}
+
+// validRequestID reports whether an incoming request ID is safe to log and echo
+func validRequestID(id string) bool {
+	if id == "" || len(id) > maxRequestIDLength {
+		return false
+	}
+	for _, c := range id {
+		if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.') {
+			return false
+		}
+	}
+	return true
+}
+
+// generateRequestID generates a random hex request ID
 func generateRequestID() string {
-	// Simple implementation - in production, use a proper UUID library
-	return fmt.Sprintf("%d", time.Now().UnixNano())
-}
\ No newline at end of file
+	bytes := make([]byte, 8)
+	if _, err := rand.Read(bytes); err != nil {
+		return fmt.Sprintf("%d", time.Now().UnixNano())
+	}
+	return hex.EncodeToString(bytes)
+}
@@ -11,6 +11,7 @@ import (
 	"testing"
 	"time"
 
+	"event-ticketing-platform/internal/logging"
 	"event-ticketing-platform/internal/models"
 
 	"github.com/stretchr/testify/assert"
@@ -86,11 +87,11 @@ func TestDetailedLoggingMiddleware(t *testing.T) {
 
 	// Check log output
 	logOutput := buf.String()
-	assert.Contains(t, logOutput, "[POST]")
+	assert.Contains(t, logOutput, "method=POST")
 	assert.Contains(t, logOutput, "/test")
 	assert.Contains(t, logOutput, "param=value")
 	assert.Contains(t, logOutput, "201")
-	assert.Contains(t, logOutput, "7 bytes") // "created" is 7 bytes
+	assert.Contains(t, logOutput, "bytes=7") // "created" is 7 bytes
 	assert.Contains(t, logOutput, "test@example.com")
 	assert.Contains(t, logOutput, "192.168.1.1:54321")
 	assert.Contains(t, logOutput, "detailed-test-agent")
@@ -201,7 +202,7 @@ func TestRequestIDMiddleware(t *testing.T) {
 	// Create test handler that captures request ID
 	var capturedRequestID string
 	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		capturedRequestID = r.Context().Value("request_id").(string)
+		capturedRequestID = GetRequestID(r.Context())
 		w.WriteHeader(http.StatusOK)
 	})
 
@@ -217,6 +218,60 @@ func TestRequestIDMiddleware(t *testing.T) {
 	assert.Equal(t, capturedRequestID, rr.Header().Get("X-Request-ID"))
 }
 
+func TestRequestIDMiddleware_ProxyID(t *testing.T) {
+	var capturedRequestID string
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		capturedRequestID = GetRequestID(r.Context())
+	})
+
+	// IDs from the proxy are kept
+	req := httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("X-Request-ID", "edge-7f3a.1")
+	rr := httptest.NewRecorder()
+	RequestIDMiddleware(handler).ServeHTTP(rr, req)
+	assert.Equal(t, "edge-7f3a.1", capturedRequestID)
+	assert.Equal(t, "edge-7f3a.1", rr.Header().Get("X-Request-ID"))
+
+	// IDs that could forge log lines are replaced
+	req = httptest.NewRequest("GET", "/test", nil)
+	req.Header.Set("X-Request-ID", "abc\nlevel=ERROR")
+	rr = httptest.NewRecorder()
+	RequestIDMiddleware(handler).ServeHTTP(rr, req)
+	assert.NotEqual(t, "abc\nlevel=ERROR", capturedRequestID)
+	assert.Len(t, capturedRequestID, 16)
+}
+
+func TestLoggingMiddleware_CorrelationFields(t *testing.T) {
+	var buf bytes.Buffer
+	log.SetOutput(&buf)
+	defer log.SetOutput(os.Stderr)
+
+	// Handlers log through the request's logger
+	handler := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		logging.FromContext(r.Context()).Info("order completed", "order_id", 42)
+		w.WriteHeader(http.StatusInternalServerError)
+	})
+	withUser := func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := logging.WithAttrs(r.Context(), "user_id", 7)
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+
+	req := httptest.NewRequest("POST", "/checkout", nil)
+	req.Header.Set("X-Request-ID", "req-1")
+	rr := httptest.NewRecorder()
+	RequestIDMiddleware(withUser(LoggingMiddleware(handler))).ServeHTTP(rr, req)
+
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if assert.Len(t, lines, 2) {
+		assert.Contains(t, lines[0], "order completed")
+		assert.Contains(t, lines[0], "request_id=req-1 user_id=7 order_id=42")
+		assert.Contains(t, lines[1], "ERROR request")
+		assert.Contains(t, lines[1], "request_id=req-1 user_id=7 method=POST path=/checkout status=500")
+	}
+}
+
 func TestGenerateRequestID(t *testing.T) {
 	// Generate multiple request IDs
 	id1 := generateRequestID()
@@ -228,6 +283,7 @@ func TestGenerateRequestID(t *testing.T) {
 	assert.NotEmpty(t, id2)
 	assert.NotEqual(t, id1, id2)
 	
-	// Should be numeric (timestamp)
-	assert.True(t, strings.ContainsAny(id1, "0123456789"))
+	// Should be 8 random bytes in hex
+	assert.Len(t, id1, 16)
+	assert.True(t, validRequestID(id1))
 }
\ No newline at end of file
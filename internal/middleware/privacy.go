@@ -0,0 +1,84 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"event-ticketing-platform/internal/logging"
+	"event-ticketing-platform/internal/models"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// PrivacyConsentCookie identifies the browser a visitor's privacy
+	// preferences were saved from
+	PrivacyConsentCookie = "privacy_consent"
+	// PrivacyPreferencesContextKey holds the visitor's privacy preferences
+	// for handlers and templates
+	PrivacyPreferencesContextKey = "privacy_preferences"
+)
+
+// PrivacyPreferenceProvider returns a visitor's privacy preferences, or nil
+// if they haven't chosen yet
+type PrivacyPreferenceProvider interface {
+	Preferences(userID int, consentID string) (*models.PrivacyPreferences, error)
+}
+
+// PrivacyConsent middleware loads the visitor's privacy preferences into the
+// request context, so tracking can be skipped and the consent banner shown
+// until they choose. It must run after the user is loaded.
+func PrivacyConsent(provider PrivacyPreferenceProvider) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if !isAttributedPath(r.URL.Path) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			userID := 0
+			if user := GetUserFromContext(r.Context()); user != nil {
+				userID = user.ID
+			}
+
+			prefs, err := provider.Preferences(userID, PrivacyConsentID(r))
+			if err != nil {
+				// Visitors are treated as not having chosen, so nothing is tracked
+				logging.FromContext(r.Context()).Warn("failed to load privacy preferences", "error", err)
+				prefs = nil
+			}
+
+			next.ServeHTTP(w, r.WithContext(WithPrivacyPreferences(r.Context(), prefs)))
+		})
+	}
+}
+
+// PrivacyConsentID returns the consent ID in the request's cookie, or ""
+// when it has none
+func PrivacyConsentID(r *http.Request) string {
+	cookie, err := r.Cookie(PrivacyConsentCookie)
+	if err != nil {
+		return ""
+	}
+	if _, err := uuid.Parse(cookie.Value); err != nil {
+		return ""
+	}
+	return cookie.Value
+}
+
+// WithPrivacyPreferences returns a copy of ctx holding the privacy preferences
+func WithPrivacyPreferences(ctx context.Context, prefs *models.PrivacyPreferences) context.Context {
+	return context.WithValue(ctx, PrivacyPreferencesContextKey, prefs)
+}
+
+// GetPrivacyPreferences returns the visitor's privacy preferences from the
+// context, or nil if they haven't chosen yet
+func GetPrivacyPreferences(ctx context.Context) *models.PrivacyPreferences {
+	prefs, _ := ctx.Value(PrivacyPreferencesContextKey).(*models.PrivacyPreferences)
+	return prefs
+}
+
+// AnalyticsAllowed reports whether the visitor agreed to analytics tracking
+func AnalyticsAllowed(ctx context.Context) bool {
+	return GetPrivacyPreferences(ctx).AllowsAnalytics()
+}
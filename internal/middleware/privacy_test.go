@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"event-ticketing-platform/internal/models"
+)
+
+type stubPrivacyPreferenceProvider struct {
+	prefs     map[string]*models.PrivacyPreferences
+	userID    int
+	consentID string
+	calls     int
+}
+
+func (s *stubPrivacyPreferenceProvider) Preferences(userID int, consentID string) (*models.PrivacyPreferences, error) {
+	s.calls++
+	s.userID, s.consentID = userID, consentID
+	return s.prefs[consentID], nil
+}
+
+func TestPrivacyConsent(t *testing.T) {
+	const consentID = "2f1c8d4e-6a8b-4c1e-9d3f-5b7a9c0e1f2a"
+	provider := &stubPrivacyPreferenceProvider{prefs: map[string]*models.PrivacyPreferences{
+		consentID: {ConsentID: consentID, Analytics: true},
+	}}
+
+	var analytics bool
+	var prefs *models.PrivacyPreferences
+	handler := PrivacyConsent(provider)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		analytics = AnalyticsAllowed(r.Context())
+		prefs = GetPrivacyPreferences(r.Context())
+	}))
+
+	serve := func(path, cookie string, user *models.User) {
+		analytics, prefs = false, nil
+		req := httptest.NewRequest("GET", path, nil)
+		if cookie != "" {
+			req.AddCookie(&http.Cookie{Name: PrivacyConsentCookie, Value: cookie})
+		}
+		if user != nil {
+			req = req.WithContext(SetUserContext(req.Context(), user))
+		}
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	serve("/events/5", consentID, &models.User{ID: 9})
+	if !analytics || prefs == nil || provider.userID != 9 || provider.consentID != consentID {
+		t.Errorf("expected the consented preferences to be loaded for user 9, got %+v (user %d, consent %q)", prefs, provider.userID, provider.consentID)
+	}
+
+	// Visitors who haven't chosen aren't tracked
+	serve("/events/5", "", nil)
+	if analytics || prefs != nil || provider.userID != 0 {
+		t.Errorf("expected no preferences for a new visitor, got %+v", prefs)
+	}
+
+	// Cookies that aren't consent IDs are ignored
+	serve("/events/5", "'; DROP TABLE privacy_preferences; --", nil)
+	if provider.consentID != "" {
+		t.Errorf("expected an invalid consent cookie to be ignored, got %q", provider.consentID)
+	}
+
+	calls := provider.calls
+	serve("/static/css/app.css", consentID, nil)
+	if provider.calls != calls {
+		t.Error("expected static files not to load preferences")
+	}
+}
@@ -2,8 +2,11 @@ package middleware
 
 import (
 	"net/http"
+	"strconv"
 	"sync"
 	"time"
+
+	"event-ticketing-platform/internal/ratelimit"
 )
 
 // LoginRateLimiter provides rate limiting specifically for login attempts
@@ -170,3 +173,65 @@ func LoginRateLimit(rateLimiter *LoginRateLimiter) func(http.Handler) http.Handl
 	}
 }
 
+
+// AccountKeyFunc extracts the account a request acts on behalf of, or "" if unknown
+type AccountKeyFunc func(r *http.Request) string
+
+// AccountFromUser uses the authenticated user's ID as the account key
+func AccountFromUser(r *http.Request) string {
+	if user := GetUserFromContext(r.Context()); user != nil {
+		return strconv.Itoa(user.ID)
+	}
+	return ""
+}
+
+// AccountFromForm uses a submitted form field, such as the email on a login form, as the account key
+func AccountFromForm(field string) AccountKeyFunc {
+	return func(r *http.Request) string {
+		return r.FormValue(field)
+	}
+}
+
+// RateLimit applies a sliding-window rate limit per client IP and, when
+// accountKey is given, per account
+func RateLimit(limiter *ratelimit.Limiter, rule ratelimit.Rule, accountKey AccountKeyFunc) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			keys := []string{ratelimit.IPKey(r)}
+			if accountKey != nil {
+				keys = append(keys, ratelimit.AccountKey(accountKey(r)))
+			}
+
+			result := limiter.Check(r, rule, keys...)
+			w.Header().Set("X-RateLimit-Limit", strconv.Itoa(result.Limit))
+			w.Header().Set("X-RateLimit-Remaining", strconv.Itoa(result.Remaining))
+
+			if !result.Allowed {
+				WriteRateLimitExceeded(w, r, result)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// WriteRateLimitExceeded writes a 429 response with a Retry-After header
+func WriteRateLimitExceeded(w http.ResponseWriter, r *http.Request, result ratelimit.Result) {
+	retryAfter := result.RetryAfterSeconds()
+	w.Header().Set("Retry-After", strconv.Itoa(retryAfter))
+
+	wait := (time.Duration(retryAfter) * time.Second).String()
+	if IsHTMXRequest(r) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte(`
+			<div class="bg-red-50 border border-red-200 text-red-800 p-4 rounded-lg">
+				<p class="text-sm">Too many requests. Please try again in ` + wait + `.</p>
+			</div>
+		`))
+		return
+	}
+
+	http.Error(w, "Too many requests. Please try again in "+wait+".", http.StatusTooManyRequests)
+}
@@ -3,8 +3,14 @@ package middleware
 import (
 	"net/http"
 	"net/http/httptest"
+	"net/url"
+	"strings"
 	"testing"
 	"time"
+
+	"event-ticketing-platform/internal/cache"
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/ratelimit"
 )
 
 func TestLoginRateLimiter_IsAllowed(t *testing.T) {
@@ -182,6 +188,99 @@ func TestLoginRateLimit_HTMX(t *testing.T) {
 	}
 }
 
+func TestRateLimit_PerIP(t *testing.T) {
+	limiter := ratelimit.NewLimiter(cache.NewMemoryCache())
+	rule := ratelimit.Rule{Name: "api", Limit: 2, Window: time.Minute}
+
+	handler := RateLimit(limiter, rule, nil)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest("GET", "/api/events", nil)
+		req.RemoteAddr = "192.168.1.1:1234"
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK {
+			t.Fatalf("request %d should be allowed, got status %d", i+1, w.Code)
+		}
+		if w.Header().Get("X-RateLimit-Limit") != "2" {
+			t.Errorf("expected X-RateLimit-Limit header, got %q", w.Header().Get("X-RateLimit-Limit"))
+		}
+	}
+
+	req := httptest.NewRequest("GET", "/api/events", nil)
+	req.RemoteAddr = "192.168.1.1:5678"
+	w := httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+
+	if w.Code != http.StatusTooManyRequests {
+		t.Fatalf("third request should be blocked, got status %d", w.Code)
+	}
+	if w.Header().Get("Retry-After") == "" {
+		t.Error("expected Retry-After header on blocked request")
+	}
+
+	// Other clients are unaffected
+	req = httptest.NewRequest("GET", "/api/events", nil)
+	req.RemoteAddr = "192.168.1.2:1234"
+	w = httptest.NewRecorder()
+	handler.ServeHTTP(w, req)
+	if w.Code != http.StatusOK {
+		t.Errorf("different IP should be allowed, got status %d", w.Code)
+	}
+}
+
+func TestRateLimit_PerAccount(t *testing.T) {
+	limiter := ratelimit.NewLimiter(cache.NewMemoryCache())
+	rule := ratelimit.Rule{Name: "login", Limit: 1, Window: time.Minute}
+
+	var tripped []string
+	limiter.OnExceeded(func(r *http.Request, rule ratelimit.Rule, key string) {
+		tripped = append(tripped, rule.Name+" "+key)
+	})
+
+	handler := RateLimit(limiter, rule, AccountFromForm("email"))(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	codes := []int{}
+	for _, ip := range []string{"10.0.0.1:1234", "10.0.0.2:1234"} {
+		form := url.Values{"email": {"user@example.com"}}
+		req := httptest.NewRequest("POST", "/auth/login", strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		req.Header.Set("HX-Request", "true")
+		req.RemoteAddr = ip
+		w := httptest.NewRecorder()
+		handler.ServeHTTP(w, req)
+		codes = append(codes, w.Code)
+
+		if w.Code == http.StatusTooManyRequests && !strings.Contains(w.Body.String(), "Too many requests") {
+			t.Error("expected HTML error message for HTMX request")
+		}
+	}
+
+	if codes[0] != http.StatusOK || codes[1] != http.StatusTooManyRequests {
+		t.Errorf("expected the account to be limited across IPs, got %v", codes)
+	}
+	if len(tripped) != 1 || tripped[0] != "login account:user@example.com" {
+		t.Errorf("expected the exceeded callback for the account, got %v", tripped)
+	}
+}
+
+func TestAccountFromUser(t *testing.T) {
+	req := httptest.NewRequest("GET", "/checkout", nil)
+	if key := AccountFromUser(req); key != "" {
+		t.Errorf("expected no account for anonymous request, got %q", key)
+	}
+
+	req = req.WithContext(SetUserContext(req.Context(), &models.User{ID: 42}))
+	if key := AccountFromUser(req); key != "42" {
+		t.Errorf("expected user ID as account key, got %q", key)
+	}
+}
+
 // Helper function to check if string contains substring
 func contains(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(substr) == 0 || 
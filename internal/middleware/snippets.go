@@ -0,0 +1,24 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// SnippetProvider returns the current content of every snippet, by key
+type SnippetProvider interface {
+	All() map[models.SnippetKey]string
+}
+
+// ContentSnippets middleware adds the admin-editable content snippets to the
+// request context for templates
+func ContentSnippets(provider SnippetProvider) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			ctx := context.WithValue(r.Context(), "snippets", provider.All())
+			next.ServeHTTP(w, r.WithContext(ctx))
+		})
+	}
+}
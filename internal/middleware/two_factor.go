@@ -0,0 +1,48 @@
+package middleware
+
+import (
+	"net/http"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// TwoFactorEnrollmentPath is where users are sent to set up required two-factor authentication
+const TwoFactorEnrollmentPath = "/dashboard/security?required=1"
+
+// TwoFactorChecker reports whether a user has or needs two-factor authentication
+type TwoFactorChecker interface {
+	IsEnabled(userID int) (bool, error)
+	IsRequired(user *models.User) bool
+}
+
+// RequireTwoFactorEnrollment redirects users whose role requires two-factor
+// authentication to the security page until they have enabled it
+func RequireTwoFactorEnrollment(checker TwoFactorChecker) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			user := GetUserFromContext(r.Context())
+			if user == nil || !checker.IsRequired(user) {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			enabled, err := checker.IsEnabled(user.ID)
+			if err != nil {
+				http.Error(w, "Failed to check two-factor authentication", http.StatusInternalServerError)
+				return
+			}
+
+			if !enabled {
+				if IsHTMXRequest(r) {
+					w.Header().Set("HX-Redirect", TwoFactorEnrollmentPath)
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				http.Redirect(w, r, TwoFactorEnrollmentPath, http.StatusSeeOther)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,71 @@
+package middleware
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"event-ticketing-platform/internal/models"
+)
+
+type mockTwoFactorChecker struct {
+	enabled       map[int]bool
+	requiredRoles map[models.UserRole]bool
+}
+
+func (m *mockTwoFactorChecker) IsEnabled(userID int) (bool, error) {
+	return m.enabled[userID], nil
+}
+
+func (m *mockTwoFactorChecker) IsRequired(user *models.User) bool {
+	return m.requiredRoles[user.Role]
+}
+
+func TestRequireTwoFactorEnrollment(t *testing.T) {
+	checker := &mockTwoFactorChecker{
+		enabled:       map[int]bool{2: true},
+		requiredRoles: map[models.UserRole]bool{models.UserRoleAdmin: true},
+	}
+
+	handler := RequireTwoFactorEnrollment(checker)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name           string
+		user           *models.User
+		htmx           bool
+		expectedStatus int
+	}{
+		{name: "anonymous request", user: nil, expectedStatus: http.StatusOK},
+		{name: "role without requirement", user: &models.User{ID: 1, Role: models.UserRoleOrganizer}, expectedStatus: http.StatusOK},
+		{name: "required and enabled", user: &models.User{ID: 2, Role: models.UserRoleAdmin}, expectedStatus: http.StatusOK},
+		{name: "required but not enabled", user: &models.User{ID: 3, Role: models.UserRoleAdmin}, expectedStatus: http.StatusSeeOther},
+		{name: "required but not enabled via HTMX", user: &models.User{ID: 3, Role: models.UserRoleAdmin}, htmx: true, expectedStatus: http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest("GET", "/admin/dashboard", nil)
+			if tt.user != nil {
+				req = req.WithContext(SetUserContext(req.Context(), tt.user))
+			}
+			if tt.htmx {
+				req.Header.Set("HX-Request", "true")
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+			if tt.expectedStatus == http.StatusSeeOther && rr.Header().Get("Location") != TwoFactorEnrollmentPath {
+				t.Errorf("expected redirect to %s, got %s", TwoFactorEnrollmentPath, rr.Header().Get("Location"))
+			}
+			if tt.htmx && rr.Header().Get("HX-Redirect") != TwoFactorEnrollmentPath {
+				t.Errorf("expected HX-Redirect to %s", TwoFactorEnrollmentPath)
+			}
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// WaitingRoomGate reports whether the visitor holding a waiting room token
+// may shop for an event
+type WaitingRoomGate interface {
+	IsAdmitted(eventID int, token string) (bool, error)
+}
+
+// WaitingRoomCookie names the cookie holding a visitor's position token for
+// an event's waiting room
+func WaitingRoomCookie(eventID int) string {
+	return fmt.Sprintf("waiting_room_%d", eventID)
+}
+
+// WaitingRoomPath is the waiting room page of an event
+func WaitingRoomPath(eventID int) string {
+	return fmt.Sprintf("/events/%d/waiting-room", eventID)
+}
+
+// RequireWaitingRoomAdmission stops visitors adding an event's tickets to
+// their cart until its waiting room has admitted them, sending them to the
+// waiting room instead. The event is taken from the URL, else the event_id
+// form field; requests without one are left for the handler to reject.
+func RequireWaitingRoomAdmission(gate WaitingRoomGate) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			eventIDParam := chi.URLParam(r, "id")
+			if eventIDParam == "" {
+				eventIDParam = r.FormValue("event_id")
+			}
+			eventID, err := strconv.Atoi(eventIDParam)
+			if err != nil {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			token := ""
+			if cookie, err := r.Cookie(WaitingRoomCookie(eventID)); err == nil {
+				token = cookie.Value
+			}
+
+			admitted, err := gate.IsAdmitted(eventID, token)
+			if err != nil {
+				http.Error(w, "Failed to check the waiting room", http.StatusInternalServerError)
+				return
+			}
+
+			if !admitted {
+				if IsHTMXRequest(r) {
+					w.Header().Set("HX-Redirect", WaitingRoomPath(eventID))
+					w.WriteHeader(http.StatusForbidden)
+					return
+				}
+				http.Redirect(w, r, WaitingRoomPath(eventID), http.StatusSeeOther)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package middleware
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/go-chi/chi/v5"
+)
+
+// mockWaitingRoomGate admits the tokens listed for each event, and every
+// visitor to events without a waiting room
+type mockWaitingRoomGate struct {
+	queued   map[int]bool
+	admitted map[string]bool
+}
+
+func (m *mockWaitingRoomGate) IsAdmitted(eventID int, token string) (bool, error) {
+	return !m.queued[eventID] || m.admitted[token], nil
+}
+
+func TestRequireWaitingRoomAdmission(t *testing.T) {
+	gate := &mockWaitingRoomGate{
+		queued:   map[int]bool{7: true},
+		admitted: map[string]bool{"front-of-queue": true},
+	}
+
+	handler := RequireWaitingRoomAdmission(gate)(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name           string
+		urlEventID     string
+		formEventID    string
+		token          string
+		htmx           bool
+		expectedStatus int
+	}{
+		{name: "event without waiting room", urlEventID: "3", expectedStatus: http.StatusOK},
+		{name: "not yet admitted", urlEventID: "7", token: "back-of-queue", expectedStatus: http.StatusSeeOther},
+		{name: "without a token", urlEventID: "7", expectedStatus: http.StatusSeeOther},
+		{name: "admitted", urlEventID: "7", token: "front-of-queue", expectedStatus: http.StatusOK},
+		{name: "event from the form", formEventID: "7", expectedStatus: http.StatusSeeOther},
+		{name: "not yet admitted via HTMX", formEventID: "7", htmx: true, expectedStatus: http.StatusForbidden},
+		{name: "invalid event left to the handler", formEventID: "abc", expectedStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			form := url.Values{"event_id": {tt.formEventID}}
+			req := httptest.NewRequest("POST", "/cart/add", strings.NewReader(form.Encode()))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			if tt.urlEventID != "" {
+				rctx := chi.NewRouteContext()
+				rctx.URLParams.Add("id", tt.urlEventID)
+				req = req.WithContext(context.WithValue(req.Context(), chi.RouteCtxKey, rctx))
+			}
+			if tt.token != "" {
+				req.AddCookie(&http.Cookie{Name: WaitingRoomCookie(7), Value: tt.token})
+			}
+			if tt.htmx {
+				req.Header.Set("HX-Request", "true")
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			if rr.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, rr.Code)
+			}
+			if tt.expectedStatus == http.StatusSeeOther && rr.Header().Get("Location") != WaitingRoomPath(7) {
+				t.Errorf("expected redirect to %s, got %s", WaitingRoomPath(7), rr.Header().Get("Location"))
+			}
+			if tt.htmx && rr.Header().Get("HX-Redirect") != WaitingRoomPath(7) {
+				t.Errorf("expected HX-Redirect to %s", WaitingRoomPath(7))
+			}
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// SignalKind is the kind of identifying signal seen for an account
+type SignalKind string
+
+const (
+	SignalIP     SignalKind = "ip"
+	SignalDevice SignalKind = "device" // Long-lived browser cookie
+	SignalPayout SignalKind = "payout" // Withdrawal bank details
+)
+
+// MaxAccountsPerSignal is the most accounts a signal can be shared by and
+// still link them. Busier values, like a campus or mobile carrier IP, say
+// little about who is behind an account.
+const MaxAccountsPerSignal = 10
+
+var payoutInvalidChars = regexp.MustCompile(`[^a-z0-9]`)
+var payoutNonDigits = regexp.MustCompile(`[^0-9]`)
+
+// SignalWeight returns how strongly sharing a signal of the kind ties two
+// accounts to the same person
+func SignalWeight(kind SignalKind) int {
+	switch kind {
+	case SignalPayout:
+		return 3
+	case SignalDevice:
+		return 2
+	default:
+		return 1
+	}
+}
+
+// SignalFingerprint hashes a signal value so raw payout details are never
+// stored twice. Payout details are compared ignoring case, spacing and
+// punctuation. It must stay in sync with the create_account_signals backfill.
+func SignalFingerprint(kind SignalKind, value string) string {
+	value = strings.TrimSpace(value)
+	if kind == SignalPayout {
+		value = payoutInvalidChars.ReplaceAllString(strings.ToLower(value), "")
+	}
+	if value == "" {
+		return ""
+	}
+
+	sum := sha256.Sum256([]byte(string(kind) + ":" + value))
+	return hex.EncodeToString(sum[:])
+}
+
+// PayoutLabel masks payout details down to their last four digits
+func PayoutLabel(details string) string {
+	digits := payoutNonDigits.ReplaceAllString(details, "")
+	if len(digits) > 4 {
+		digits = digits[len(digits)-4:]
+	}
+	return strings.TrimSpace("•••• " + digits)
+}
+
+// AccountSignal is an identifying signal seen for an account
+type AccountSignal struct {
+	UserID      int        `json:"user_id"`
+	Kind        SignalKind `json:"kind"`
+	Fingerprint string     `json:"-"`
+	Label       string     `json:"label"`
+	SeenCount   int        `json:"seen_count"`
+	FirstSeenAt time.Time  `json:"first_seen_at"`
+	LastSeenAt  time.Time  `json:"last_seen_at"`
+}
+
+// AccountLink is a signal shared by two accounts
+type AccountLink struct {
+	UserA int        `json:"user_a"`
+	UserB int        `json:"user_b"`
+	Kind  SignalKind `json:"kind"`
+	Label string     `json:"label"`
+}
+
+// LinkedAccount is an account in a cluster of linked accounts, with the
+// history that makes the cluster risky
+type LinkedAccount struct {
+	ID        int       `json:"id"`
+	Email     string    `json:"email"`
+	Name      string    `json:"name"`
+	Role      UserRole  `json:"role"`
+	IsActive  bool      `json:"is_active"`
+	CreatedAt time.Time `json:"created_at"`
+
+	// RefundedOrders counts refunded orders on the account's events, the
+	// closest record of chargebacks the platform keeps
+	RefundedOrders  int `json:"refunded_orders"`
+	CancelledEvents int `json:"cancelled_events"`
+}
+
+// IsBanned returns true if an admin has suspended the account
+func (a *LinkedAccount) IsBanned() bool {
+	return !a.IsActive
+}
+
+// HasRefundHistory returns true if buyers have had money back from the account's events
+func (a *LinkedAccount) HasRefundHistory() bool {
+	return a.RefundedOrders > 0 || a.CancelledEvents > 0
+}
+
+// AccountCluster is a group of accounts connected by shared signals
+type AccountCluster struct {
+	Accounts []*LinkedAccount `json:"accounts"`
+	Links    []*AccountLink   `json:"links"`
+}
+
+// Account returns the account with the given ID, or nil
+func (c *AccountCluster) Account(id int) *LinkedAccount {
+	for _, account := range c.Accounts {
+		if account.ID == id {
+			return account
+		}
+	}
+	return nil
+}
+
+// HasBannedAccount returns true if a suspended account is in the cluster
+func (c *AccountCluster) HasBannedAccount() bool {
+	for _, account := range c.Accounts {
+		if account.IsBanned() {
+			return true
+		}
+	}
+	return false
+}
+
+// ActiveOrganizers returns the cluster's organizers that can still sell tickets
+func (c *AccountCluster) ActiveOrganizers() []*LinkedAccount {
+	var organizers []*LinkedAccount
+	for _, account := range c.Accounts {
+		if account.Role == RoleOrganizer && account.IsActive {
+			organizers = append(organizers, account)
+		}
+	}
+	return organizers
+}
+
+// IsBanEvasion returns true if a suspended account is linked to an
+// organizer that is still active, the pattern of a banned organizer
+// returning under a new email
+func (c *AccountCluster) IsBanEvasion() bool {
+	return c.HasBannedAccount() && len(c.ActiveOrganizers()) > 0
+}
+
+// RiskScore ranks clusters for review. Strong links, suspended accounts and
+// refund history all raise it.
+func (c *AccountCluster) RiskScore() int {
+	score := 0
+	for _, link := range c.Links {
+		score += SignalWeight(link.Kind)
+	}
+	for _, account := range c.Accounts {
+		if account.IsBanned() {
+			score += 5
+		}
+		if account.HasRefundHistory() {
+			score += 2
+		}
+	}
+	if c.IsBanEvasion() {
+		score += 10
+	}
+	return score
+}
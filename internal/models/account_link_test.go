@@ -0,0 +1,57 @@
+package models
+
+import "testing"
+
+func TestSignalFingerprint(t *testing.T) {
+	a := SignalFingerprint(SignalPayout, "Equity Bank, Acc 0123-456-789")
+	b := SignalFingerprint(SignalPayout, "equity bank acc 0123456789")
+	if a == "" || a != b {
+		t.Errorf("expected payout details to match ignoring case and punctuation, got %q and %q", a, b)
+	}
+
+	if SignalFingerprint(SignalIP, "10.0.0.1") == SignalFingerprint(SignalDevice, "10.0.0.1") {
+		t.Error("expected the same value of different kinds not to match")
+	}
+	if SignalFingerprint(SignalPayout, " -- ") != "" || SignalFingerprint(SignalIP, "") != "" {
+		t.Error("expected empty values to have no fingerprint")
+	}
+}
+
+func TestPayoutLabel(t *testing.T) {
+	tests := map[string]string{
+		"Equity Bank, Acc 0123-456-789": "•••• 6789",
+		"M-Pesa 42":                     "•••• 42",
+		"Paypal":                        "••••",
+	}
+	for details, want := range tests {
+		if got := PayoutLabel(details); got != want {
+			t.Errorf("PayoutLabel(%q) = %q, want %q", details, got, want)
+		}
+	}
+}
+
+func TestAccountCluster_RiskScore(t *testing.T) {
+	cluster := &AccountCluster{
+		Accounts: []*LinkedAccount{
+			{ID: 1, Role: RoleOrganizer, IsActive: false, RefundedOrders: 3},
+			{ID: 2, Role: RoleOrganizer, IsActive: true},
+		},
+		Links: []*AccountLink{
+			{UserA: 1, UserB: 2, Kind: SignalPayout},
+			{UserA: 1, UserB: 2, Kind: SignalIP},
+		},
+	}
+
+	if !cluster.IsBanEvasion() {
+		t.Error("expected a suspended organizer linked to an active one to be flagged")
+	}
+	// 3 + 1 for the links, 5 suspended, 2 refunds, 10 ban evasion
+	if score := cluster.RiskScore(); score != 21 {
+		t.Errorf("expected risk score 21, got %d", score)
+	}
+
+	cluster.Accounts[0].IsActive = true
+	if cluster.IsBanEvasion() || cluster.RiskScore() != 6 {
+		t.Errorf("expected risk score 6 without suspended accounts, got %d", cluster.RiskScore())
+	}
+}
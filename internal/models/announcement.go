@@ -0,0 +1,215 @@
+package models
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// AnnouncementLevel sets how prominently an announcement banner is shown
+type AnnouncementLevel string
+
+const (
+	AnnouncementInfo     AnnouncementLevel = "info"
+	AnnouncementWarning  AnnouncementLevel = "warning"
+	AnnouncementCritical AnnouncementLevel = "critical"
+)
+
+// AnnouncementLevels lists the levels in the order admins choose from
+var AnnouncementLevels = []AnnouncementLevel{AnnouncementInfo, AnnouncementWarning, AnnouncementCritical}
+
+// DisplayName returns a human-readable level name
+func (l AnnouncementLevel) DisplayName() string {
+	switch l {
+	case AnnouncementInfo:
+		return "Information"
+	case AnnouncementWarning:
+		return "Warning"
+	case AnnouncementCritical:
+		return "Critical"
+	default:
+		return string(l)
+	}
+}
+
+// AnnouncementAudience is who an announcement banner is shown to
+type AnnouncementAudience string
+
+const (
+	AnnouncementEveryone   AnnouncementAudience = "everyone"
+	AnnouncementSignedIn   AnnouncementAudience = "signed_in"
+	AnnouncementAttendees  AnnouncementAudience = "attendees"
+	AnnouncementOrganizers AnnouncementAudience = "organizers"
+	AnnouncementAdmins     AnnouncementAudience = "admins"
+)
+
+// AnnouncementAudiences lists the audiences in the order admins choose from
+var AnnouncementAudiences = []AnnouncementAudience{
+	AnnouncementEveryone,
+	AnnouncementSignedIn,
+	AnnouncementAttendees,
+	AnnouncementOrganizers,
+	AnnouncementAdmins,
+}
+
+// DisplayName returns a human-readable audience name
+func (a AnnouncementAudience) DisplayName() string {
+	switch a {
+	case AnnouncementEveryone:
+		return "Everyone"
+	case AnnouncementSignedIn:
+		return "Signed-in users"
+	case AnnouncementAttendees:
+		return "Attendees"
+	case AnnouncementOrganizers:
+		return "Organizers"
+	case AnnouncementAdmins:
+		return "Admins"
+	default:
+		return string(a)
+	}
+}
+
+// Includes returns true if the audience includes the user, nil for
+// visitors who aren't signed in
+func (a AnnouncementAudience) Includes(user *User) bool {
+	switch a {
+	case AnnouncementEveryone:
+		return true
+	case AnnouncementSignedIn:
+		return user != nil
+	case AnnouncementAttendees:
+		return user != nil && user.Role == UserRoleUser
+	case AnnouncementOrganizers:
+		return user != nil && user.Role == UserRoleOrganizer
+	case AnnouncementAdmins:
+		return user != nil && user.Role == UserRoleAdmin
+	default:
+		return false
+	}
+}
+
+// MaxAnnouncementLength is the longest an announcement's message may be
+const MaxAnnouncementLength = 500
+
+// Announcement is a banner admins publish across the site, such as notice
+// of planned downtime or a change in fees. It is shown to its audience from
+// StartsAt until EndsAt.
+type Announcement struct {
+	ID       int                  `json:"id" db:"id"`
+	Message  string               `json:"message" db:"message"`
+	LinkURL  string               `json:"link_url,omitempty" db:"link_url"`
+	LinkText string               `json:"link_text,omitempty" db:"link_text"`
+	Level    AnnouncementLevel    `json:"level" db:"level"`
+	Audience AnnouncementAudience `json:"audience" db:"audience"`
+	StartsAt time.Time            `json:"starts_at" db:"starts_at"`
+	EndsAt   *time.Time           `json:"ends_at,omitempty" db:"ends_at"` // nil to show until deleted
+	// Dismissible announcements can be closed by each visitor
+	Dismissible bool      `json:"dismissible" db:"dismissible"`
+	CreatedBy   *int      `json:"created_by,omitempty" db:"created_by"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// IsActive returns true if the announcement is scheduled to show at the time
+func (a *Announcement) IsActive(at time.Time) bool {
+	return !at.Before(a.StartsAt) && (a.EndsAt == nil || at.Before(*a.EndsAt))
+}
+
+// HasEnded returns true if the announcement's schedule is over
+func (a *Announcement) HasEnded(at time.Time) bool {
+	return a.EndsAt != nil && !at.Before(*a.EndsAt)
+}
+
+// ScheduleStatus returns "Scheduled", "Active" or "Ended"
+func (a *Announcement) ScheduleStatus(at time.Time) string {
+	switch {
+	case a.HasEnded(at):
+		return "Ended"
+	case a.IsActive(at):
+		return "Active"
+	default:
+		return "Scheduled"
+	}
+}
+
+// AnnouncementRequest represents a request to publish or change an announcement
+type AnnouncementRequest struct {
+	Message     string               `json:"message"`
+	LinkURL     string               `json:"link_url"`
+	LinkText    string               `json:"link_text"`
+	Level       AnnouncementLevel    `json:"level"`
+	Audience    AnnouncementAudience `json:"audience"`
+	StartsAt    time.Time            `json:"starts_at"`
+	EndsAt      *time.Time           `json:"ends_at"`
+	Dismissible bool                 `json:"dismissible"`
+}
+
+// Validate validates the announcement request
+func (r *AnnouncementRequest) Validate() error {
+	r.Message = strings.TrimSpace(r.Message)
+	r.LinkURL = strings.TrimSpace(r.LinkURL)
+	r.LinkText = strings.TrimSpace(r.LinkText)
+
+	if r.Message == "" {
+		return errors.New("message is required")
+	}
+	if len(r.Message) > MaxAnnouncementLength {
+		return errors.New("message must be 500 characters or fewer")
+	}
+
+	if r.LinkURL != "" {
+		if !isAnnouncementLink(r.LinkURL) {
+			return errors.New("link must be a path on this site or an http(s) URL")
+		}
+		if r.LinkText == "" {
+			r.LinkText = "Learn more"
+		}
+	} else {
+		r.LinkText = ""
+	}
+
+	if !containsAnnouncementLevel(r.Level) {
+		return errors.New("invalid announcement level")
+	}
+	if !containsAnnouncementAudience(r.Audience) {
+		return errors.New("invalid announcement audience")
+	}
+	if r.StartsAt.IsZero() {
+		return errors.New("start time is required")
+	}
+	if r.EndsAt != nil && !r.EndsAt.After(r.StartsAt) {
+		return errors.New("end time must be after the start time")
+	}
+	return nil
+}
+
+// isAnnouncementLink returns true for paths on this site and http(s) URLs
+func isAnnouncementLink(link string) bool {
+	if strings.HasPrefix(link, "/") {
+		return !strings.HasPrefix(link, "//")
+	}
+	parsed, err := url.Parse(link)
+	return err == nil && (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != ""
+}
+
+// containsAnnouncementLevel returns true for the known levels
+func containsAnnouncementLevel(level AnnouncementLevel) bool {
+	for _, known := range AnnouncementLevels {
+		if level == known {
+			return true
+		}
+	}
+	return false
+}
+
+// containsAnnouncementAudience returns true for the known audiences
+func containsAnnouncementAudience(audience AnnouncementAudience) bool {
+	for _, known := range AnnouncementAudiences {
+		if audience == known {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,108 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestAnnouncementRequest_Validate(t *testing.T) {
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	before := start.Add(-time.Hour)
+	valid := func() *AnnouncementRequest {
+		return &AnnouncementRequest{
+			Message:  "  Planned maintenance on Sunday  ",
+			LinkURL:  "/help/maintenance",
+			Level:    AnnouncementWarning,
+			Audience: AnnouncementEveryone,
+			StartsAt: start,
+		}
+	}
+
+	req := valid()
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if req.Message != "Planned maintenance on Sunday" {
+		t.Errorf("expected the message trimmed, got %q", req.Message)
+	}
+	if req.LinkText != "Learn more" {
+		t.Errorf("expected a default link text, got %q", req.LinkText)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*AnnouncementRequest)
+	}{
+		{"empty message", func(r *AnnouncementRequest) { r.Message = "   " }},
+		{"long message", func(r *AnnouncementRequest) { r.Message = strings.Repeat("a", MaxAnnouncementLength+1) }},
+		{"protocol-relative link", func(r *AnnouncementRequest) { r.LinkURL = "//evil.example.com" }},
+		{"script link", func(r *AnnouncementRequest) { r.LinkURL = "javascript:alert(1)" }},
+		{"unknown level", func(r *AnnouncementRequest) { r.Level = "urgent" }},
+		{"unknown audience", func(r *AnnouncementRequest) { r.Audience = "vips" }},
+		{"missing start", func(r *AnnouncementRequest) { r.StartsAt = time.Time{} }},
+		{"ends before start", func(r *AnnouncementRequest) { r.EndsAt = &before }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.modify(req)
+			if err := req.Validate(); err == nil {
+				t.Error("expected a validation error")
+			}
+		})
+	}
+}
+
+func TestAnnouncement_ScheduleStatus(t *testing.T) {
+	start := time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC)
+	end := start.Add(24 * time.Hour)
+	announcement := &Announcement{StartsAt: start, EndsAt: &end}
+
+	tests := []struct {
+		at     time.Time
+		status string
+	}{
+		{start.Add(-time.Minute), "Scheduled"},
+		{start, "Active"},
+		{end.Add(-time.Minute), "Active"},
+		{end, "Ended"},
+	}
+	for _, tt := range tests {
+		if got := announcement.ScheduleStatus(tt.at); got != tt.status {
+			t.Errorf("ScheduleStatus(%v) = %q, want %q", tt.at, got, tt.status)
+		}
+	}
+
+	announcement.EndsAt = nil
+	if !announcement.IsActive(start.AddDate(1, 0, 0)) {
+		t.Error("expected an announcement without an end to stay active")
+	}
+}
+
+func TestAnnouncementAudience_Includes(t *testing.T) {
+	attendee := &User{Role: UserRoleUser}
+	organizer := &User{Role: UserRoleOrganizer}
+	admin := &User{Role: UserRoleAdmin}
+
+	tests := []struct {
+		audience AnnouncementAudience
+		user     *User
+		want     bool
+	}{
+		{AnnouncementEveryone, nil, true},
+		{AnnouncementSignedIn, nil, false},
+		{AnnouncementSignedIn, organizer, true},
+		{AnnouncementAttendees, attendee, true},
+		{AnnouncementAttendees, organizer, false},
+		{AnnouncementOrganizers, organizer, true},
+		{AnnouncementOrganizers, nil, false},
+		{AnnouncementAdmins, admin, true},
+		{AnnouncementAdmins, attendee, false},
+	}
+	for _, tt := range tests {
+		if got := tt.audience.Includes(tt.user); got != tt.want {
+			t.Errorf("%s.Includes(%+v) = %v, want %v", tt.audience, tt.user, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+// APITokenPrefix starts every API token so leaked tokens are easy to spot
+const APITokenPrefix = "rt_"
+
+// APIToken is a token a partner integration uses to call the API on behalf
+// of an account
+type APIToken struct {
+	ID         int        `json:"id" db:"id"`
+	UserID     int        `json:"user_id" db:"user_id"`
+	Name       string     `json:"name" db:"name"`
+	TokenHash  string     `json:"-" db:"token_hash"`
+	Prefix     string     `json:"prefix" db:"prefix"` // First characters of the token, to tell tokens apart
+	LastUsedAt *time.Time `json:"last_used_at,omitempty" db:"last_used_at"`
+	RevokedAt  *time.Time `json:"revoked_at,omitempty" db:"revoked_at"`
+	CreatedAt  time.Time  `json:"created_at" db:"created_at"`
+}
+
+// Validate validates the API token data
+func (t *APIToken) Validate() error {
+	t.Name = strings.TrimSpace(t.Name)
+	if t.Name == "" {
+		return errors.New("token name is required")
+	}
+	if len(t.Name) > 100 {
+		return errors.New("token name must be 100 characters or less")
+	}
+	return nil
+}
+
+// IsRevoked returns true if the token can no longer be used
+func (t *APIToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+// HashAPIToken returns the stored form of a token
+func HashAPIToken(token string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(token)))
+	return hex.EncodeToString(sum[:])
+}
+
+// APITokenDisplayPrefix returns the part of a token that is kept in clear
+func APITokenDisplayPrefix(token string) string {
+	if len(token) > 10 {
+		return token[:10]
+	}
+	return token
+}
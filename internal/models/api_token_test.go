@@ -0,0 +1,45 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAPIToken_Validate(t *testing.T) {
+	token := &APIToken{Name: "  Partner sync  "}
+	if err := token.Validate(); err != nil {
+		t.Fatalf("expected valid token, got %v", err)
+	}
+	if token.Name != "Partner sync" {
+		t.Errorf("expected trimmed name, got %q", token.Name)
+	}
+
+	if err := (&APIToken{Name: " "}).Validate(); err == nil {
+		t.Error("expected error for empty name")
+	}
+	if err := (&APIToken{Name: strings.Repeat("a", 101)}).Validate(); err == nil {
+		t.Error("expected error for long name")
+	}
+}
+
+func TestHashAPIToken(t *testing.T) {
+	hash := HashAPIToken("rt_abc")
+	if len(hash) != 64 {
+		t.Errorf("expected a sha256 hex digest, got %q", hash)
+	}
+	if HashAPIToken(" rt_abc ") != hash {
+		t.Error("expected surrounding whitespace to be ignored")
+	}
+	if HashAPIToken("rt_abd") == hash {
+		t.Error("expected different tokens to hash differently")
+	}
+}
+
+func TestAPITokenDisplayPrefix(t *testing.T) {
+	if got := APITokenDisplayPrefix("rt_0123456789abcdef"); got != "rt_0123456" {
+		t.Errorf("expected first 10 characters, got %q", got)
+	}
+	if got := APITokenDisplayPrefix("rt_1"); got != "rt_1" {
+		t.Errorf("expected short token unchanged, got %q", got)
+	}
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ArrivalGracePeriod is how early or late ticket holders can arrive around
+// their arrival slot before check-in flags it
+const ArrivalGracePeriod = 15 * time.Minute
+
+// ArrivalSlot is a window in which some of an event's ticket holders are
+// asked to arrive, to spread out the queue at the entrance
+type ArrivalSlot struct {
+	ID        int       `json:"id" db:"id"`
+	EventID   int       `json:"event_id" db:"event_id"`
+	StartsAt  time.Time `json:"starts_at" db:"starts_at"`
+	EndsAt    time.Time `json:"ends_at" db:"ends_at"`
+	Capacity  int       `json:"capacity" db:"capacity"` // Tickets that can choose the slot
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+
+	// Tickets in completed orders that chose the slot
+	Booked int `json:"booked"`
+}
+
+// Remaining returns how many more tickets can choose the slot
+func (s *ArrivalSlot) Remaining() int {
+	if s.Booked >= s.Capacity {
+		return 0
+	}
+	return s.Capacity - s.Booked
+}
+
+// IsFull returns true if no more tickets can choose the slot
+func (s *ArrivalSlot) IsFull() bool {
+	return s.Remaining() == 0
+}
+
+// Label returns the slot's times, e.g. "18:00–18:30"
+func (s *ArrivalSlot) Label() string {
+	return fmt.Sprintf("%s–%s", s.StartsAt.Format("15:04"), s.EndsAt.Format("15:04"))
+}
+
+// ArrivalDeviation returns how far outside the slot, allowing for the grace
+// period, a ticket holder arrived. It is negative when they are early,
+// positive when they are late and zero when they are on time.
+func (s *ArrivalSlot) ArrivalDeviation(arrivedAt time.Time) time.Duration {
+	if earliest := s.StartsAt.Add(-ArrivalGracePeriod); arrivedAt.Before(earliest) {
+		return arrivedAt.Sub(s.StartsAt)
+	}
+	if latest := s.EndsAt.Add(ArrivalGracePeriod); arrivedAt.After(latest) {
+		return arrivedAt.Sub(s.EndsAt)
+	}
+	return 0
+}
+
+// ArrivalSlotCreateRequest represents a request to add an arrival slot to an event
+type ArrivalSlotCreateRequest struct {
+	EventID  int       `json:"event_id"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Capacity int       `json:"capacity"`
+}
+
+// Validate validates the arrival slot request
+func (r *ArrivalSlotCreateRequest) Validate() error {
+	if r.StartsAt.IsZero() || r.EndsAt.IsZero() {
+		return errors.New("start and end times are required")
+	}
+	if !r.EndsAt.After(r.StartsAt) {
+		return errors.New("end time must be after start time")
+	}
+	if r.EndsAt.Sub(r.StartsAt) > 24*time.Hour {
+		return errors.New("arrival slots must be 24 hours or shorter")
+	}
+	if r.Capacity <= 0 {
+		return errors.New("capacity must be greater than 0")
+	}
+	return nil
+}
@@ -0,0 +1,81 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestArrivalSlot_Remaining(t *testing.T) {
+	slot := &ArrivalSlot{Capacity: 10, Booked: 7}
+	if slot.Remaining() != 3 || slot.IsFull() {
+		t.Errorf("expected 3 remaining, got %d", slot.Remaining())
+	}
+
+	// Concurrent checkouts can overbook a slot slightly
+	slot.Booked = 12
+	if slot.Remaining() != 0 || !slot.IsFull() {
+		t.Errorf("expected overbooked slot to be full, got %d remaining", slot.Remaining())
+	}
+}
+
+func TestArrivalSlot_Label(t *testing.T) {
+	slot := &ArrivalSlot{
+		StartsAt: time.Date(2025, 6, 1, 18, 0, 0, 0, time.UTC),
+		EndsAt:   time.Date(2025, 6, 1, 18, 30, 0, 0, time.UTC),
+	}
+	if got := slot.Label(); got != "18:00–18:30" {
+		t.Errorf("expected label 18:00–18:30, got %s", got)
+	}
+}
+
+func TestArrivalSlot_ArrivalDeviation(t *testing.T) {
+	slot := &ArrivalSlot{
+		StartsAt: time.Date(2025, 6, 1, 18, 0, 0, 0, time.UTC),
+		EndsAt:   time.Date(2025, 6, 1, 18, 30, 0, 0, time.UTC),
+	}
+
+	tests := []struct {
+		name      string
+		arrivedAt time.Time
+		expected  time.Duration
+	}{
+		{name: "during slot", arrivedAt: time.Date(2025, 6, 1, 18, 10, 0, 0, time.UTC), expected: 0},
+		{name: "early within grace period", arrivedAt: time.Date(2025, 6, 1, 17, 50, 0, 0, time.UTC), expected: 0},
+		{name: "late within grace period", arrivedAt: time.Date(2025, 6, 1, 18, 45, 0, 0, time.UTC), expected: 0},
+		{name: "early", arrivedAt: time.Date(2025, 6, 1, 17, 30, 0, 0, time.UTC), expected: -30 * time.Minute},
+		{name: "late", arrivedAt: time.Date(2025, 6, 1, 19, 0, 0, 0, time.UTC), expected: 30 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := slot.ArrivalDeviation(tt.arrivedAt); got != tt.expected {
+				t.Errorf("expected deviation %v, got %v", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestArrivalSlotCreateRequest_Validate(t *testing.T) {
+	start := time.Date(2025, 6, 1, 18, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		req     ArrivalSlotCreateRequest
+		wantErr bool
+	}{
+		{name: "valid", req: ArrivalSlotCreateRequest{StartsAt: start, EndsAt: start.Add(30 * time.Minute), Capacity: 50}},
+		{name: "missing times", req: ArrivalSlotCreateRequest{Capacity: 50}, wantErr: true},
+		{name: "ends before start", req: ArrivalSlotCreateRequest{StartsAt: start, EndsAt: start.Add(-time.Minute), Capacity: 50}, wantErr: true},
+		{name: "longer than a day", req: ArrivalSlotCreateRequest{StartsAt: start, EndsAt: start.Add(25 * time.Hour), Capacity: 50}, wantErr: true},
+		{name: "no capacity", req: ArrivalSlotCreateRequest{StartsAt: start, EndsAt: start.Add(30 * time.Minute)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,84 @@
+package models
+
+import (
+	"net/url"
+	"strings"
+	"unicode/utf8"
+)
+
+// Sources of orders without UTM parameters or an external referrer
+const (
+	AttributionSourceDirect  = "direct"
+	AttributionSourceUnknown = "unknown" // orders placed before attribution was tracked
+	// AttributionSourceBoxOffice is the source of tickets an organizer sold
+	// at the door
+	AttributionSourceBoxOffice = "box_office"
+)
+
+// attributionFieldLimit bounds the length of each UTM parameter stored
+const attributionFieldLimit = 100
+
+// Attribution records where a buyer came from: the UTM parameters and
+// external referrer of their first visit in the session, and the code of the
+// last promoter link they followed
+type Attribution struct {
+	Source       string `json:"source" db:"source"`
+	Medium       string `json:"medium" db:"medium"`
+	Campaign     string `json:"campaign" db:"campaign"`
+	Term         string `json:"term" db:"term"`
+	Content      string `json:"content" db:"content"`
+	ReferrerHost string `json:"referrer_host" db:"referrer_host"`
+	LandingPath  string `json:"landing_path" db:"landing_path"`
+	Ref          string `json:"ref,omitempty" db:"ref"`
+}
+
+// NewAttribution builds an attribution from a landing page's query
+// parameters, the host of the external site that referred the visitor, if
+// any, and the landing page's path. UTM sources and mediums are lowercased
+// so "Instagram" and "instagram" count as one source.
+func NewAttribution(query url.Values, referrerHost, landingPath string) *Attribution {
+	return &Attribution{
+		Source:       strings.ToLower(utmValue(query, "utm_source")),
+		Medium:       strings.ToLower(utmValue(query, "utm_medium")),
+		Campaign:     utmValue(query, "utm_campaign"),
+		Term:         utmValue(query, "utm_term"),
+		Content:      utmValue(query, "utm_content"),
+		ReferrerHost: truncate(strings.ToLower(referrerHost), 255),
+		LandingPath:  truncate(landingPath, 500),
+		Ref:          PromoterRef(query),
+	}
+}
+
+// SourceLabel returns the source an order is reported under: its UTM source,
+// else its referrer, else direct
+func (a *Attribution) SourceLabel() string {
+	if a.Source != "" {
+		return a.Source
+	}
+	if a.ReferrerHost != "" {
+		return a.ReferrerHost
+	}
+	return AttributionSourceDirect
+}
+
+// PromoterRef returns the normalized promoter link code of a landing page's
+// ref parameter, if any
+func PromoterRef(query url.Values) string {
+	return truncate(PromoterCode(query.Get("ref")), MaxPromoterCodeLength)
+}
+
+// utmValue returns a trimmed and bounded UTM parameter
+func utmValue(query url.Values, key string) string {
+	return truncate(strings.TrimSpace(query.Get(key)), attributionFieldLimit)
+}
+
+// truncate cuts s to at most n bytes without splitting a character
+func truncate(s string, n int) string {
+	if len(s) <= n {
+		return s
+	}
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n]
+}
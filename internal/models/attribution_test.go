@@ -0,0 +1,43 @@
+package models
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestNewAttribution(t *testing.T) {
+	query := url.Values{
+		"utm_source":   {" Instagram "},
+		"utm_medium":   {"Social"},
+		"utm_campaign": {"Summer Launch"},
+		"utm_content":  {strings.Repeat("é", 80)},
+	}
+
+	a := NewAttribution(query, "Instagram.com", "/events/5")
+	if a.Source != "instagram" || a.Medium != "social" || a.Campaign != "Summer Launch" {
+		t.Errorf("expected trimmed, lowercased source and medium, got %+v", a)
+	}
+	if len(a.Content) > attributionFieldLimit || !strings.HasPrefix(strings.Repeat("é", 80), a.Content) {
+		t.Errorf("expected content to be cut at a character boundary, got %d bytes", len(a.Content))
+	}
+	if a.ReferrerHost != "instagram.com" || a.LandingPath != "/events/5" {
+		t.Errorf("unexpected referrer or landing path: %+v", a)
+	}
+}
+
+func TestAttribution_SourceLabel(t *testing.T) {
+	tests := []struct {
+		attribution Attribution
+		want        string
+	}{
+		{Attribution{Source: "newsletter", ReferrerHost: "mail.example.org"}, "newsletter"},
+		{Attribution{ReferrerHost: "facebook.com"}, "facebook.com"},
+		{Attribution{}, AttributionSourceDirect},
+	}
+	for _, tt := range tests {
+		if got := tt.attribution.SourceLabel(); got != tt.want {
+			t.Errorf("expected %q, got %q", tt.want, got)
+		}
+	}
+}
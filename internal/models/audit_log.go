@@ -2,6 +2,7 @@ package models
 
 import (
 	"encoding/json"
+	"strings"
 	"time"
 )
 
@@ -46,6 +47,40 @@ const (
 	AuditActionWithdrawalApprove = "withdrawal_approve"
 	AuditActionWithdrawalReject  = "withdrawal_reject"
 	AuditActionWithdrawalComplete = "withdrawal_complete"
+	AuditActionRateLimitExceeded  = "rate_limit_exceeded"
+	AuditActionMagicLinkRequested = "magic_link_requested"
+	AuditActionMagicLinkLogin     = "magic_link_login"
+	AuditActionMagicLinkRejected  = "magic_link_rejected"
+	AuditActionDataQualityRemediate = "data_quality_remediate"
+	AuditActionEventBroadcast       = "event_broadcast"
+	AuditActionEventCancel          = "event_cancel"
+	AuditActionSnippetUpdate        = "snippet_update"
+	AuditActionImpersonationStart   = "impersonation_start"
+	AuditActionImpersonationStop    = "impersonation_stop"
+	AuditActionImpersonatedRequest  = "impersonated_request"
+	AuditActionEventRequestChanges  = "event_request_changes"
+	AuditActionEventAssignReviewer  = "event_assign_reviewer"
+	AuditActionAccountLocked        = "account_locked"
+	AuditActionAccountUnlock        = "account_unlock"
+	AuditActionEmailRetry           = "email_retry"
+	AuditActionPaymentReconcile     = "payment_reconcile"
+	AuditActionTrashRestore         = "trash_restore"
+	AuditActionSettingsUpdate       = "settings_update"
+	AuditActionOrderRefund          = "order_refund"
+	AuditActionLoginNewDevice       = "login_new_device"
+	AuditActionPasswordResetSent    = "password_reset_sent"
+	AuditActionEmailVerify          = "email_verify"
+	AuditActionEventUnpublish       = "event_unpublish"
+	AuditActionEventCategoryChange  = "event_category_change"
+	AuditActionFeatureFlagUpdate    = "feature_flag_update"
+	AuditActionAnnouncementCreate   = "announcement_create"
+	AuditActionAnnouncementUpdate   = "announcement_update"
+	AuditActionAnnouncementDelete   = "announcement_delete"
+	AuditActionHelpArticleCreate    = "help_article_create"
+	AuditActionHelpArticleUpdate    = "help_article_update"
+	AuditActionHelpArticleDelete    = "help_article_delete"
+	AuditActionCategoryFeature      = "category_feature"
+	AuditActionCategoryUnfeature    = "category_unfeature"
 )
 
 // Common target types
@@ -54,4 +89,51 @@ const (
 	AuditTargetUser       = "user"
 	AuditTargetCategory   = "category"
 	AuditTargetWithdrawal = "withdrawal"
-)
\ No newline at end of file
+	AuditTargetRateLimit  = "rate_limit"
+	AuditTargetDataQuality  = "data_quality"
+	AuditTargetSnippet      = "snippet"
+	AuditTargetEmail        = "email"
+	AuditTargetPayment      = "payment"
+	AuditTargetTicketType   = "ticket_type"
+	AuditTargetSettings     = "settings"
+	AuditTargetOrder        = "order"
+	AuditTargetAnnouncement = "announcement"
+	AuditTargetHelpArticle  = "help_article"
+)
+
+// AuditLogFilter narrows the audit log. Zero values match every entry.
+type AuditLogFilter struct {
+	// Actor is the ID or part of the email of the user who acted, or
+	// AuditActorSystem for entries no user triggered
+	Actor      string
+	Action     string
+	TargetType string
+	TargetID   int
+	From       *time.Time // Inclusive
+	To         *time.Time // Exclusive
+}
+
+// AuditActorSystem filters the audit log to entries no user triggered, like
+// rate limits and refunds issued by background jobs
+const AuditActorSystem = "system"
+
+// AuditLabel turns an audit action or target type into words, such as
+// "User role change" for AuditActionUserRoleChange
+func AuditLabel(value string) string {
+	label := strings.ReplaceAll(value, "_", " ")
+	if label == "" {
+		return label
+	}
+	return strings.ToUpper(label[:1]) + label[1:]
+}
+
+// ActorName returns who performed the action, or "System" when no user did
+func (l *AuditLog) ActorName() string {
+	if l.AdminUserID == 0 || l.AdminUser == nil {
+		return "System"
+	}
+	if name := strings.TrimSpace(l.AdminUser.FirstName + " " + l.AdminUser.LastName); name != "" {
+		return name
+	}
+	return l.AdminUser.Email
+}
\ No newline at end of file
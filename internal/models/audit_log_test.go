@@ -0,0 +1,32 @@
+package models
+
+import "testing"
+
+func TestAuditLog_ActorName(t *testing.T) {
+	tests := []struct {
+		name string
+		log  *AuditLog
+		want string
+	}{
+		{"no actor", &AuditLog{AdminUser: &User{}}, "System"},
+		{"named actor", &AuditLog{AdminUserID: 3, AdminUser: &User{FirstName: "Ada", LastName: "Okafor", Email: "ada@example.com"}}, "Ada Okafor"},
+		{"unnamed actor", &AuditLog{AdminUserID: 3, AdminUser: &User{Email: "ada@example.com"}}, "ada@example.com"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.log.ActorName(); got != tt.want {
+				t.Errorf("ActorName() = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuditLabel(t *testing.T) {
+	if got := AuditLabel(AuditActionUserRoleChange); got != "User role change" {
+		t.Errorf("AuditLabel() = %q, want %q", got, "User role change")
+	}
+	if got := AuditLabel(""); got != "" {
+		t.Errorf("AuditLabel(\"\") = %q, want empty", got)
+	}
+}
@@ -0,0 +1,105 @@
+package models
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"regexp"
+	"strings"
+)
+
+// BoxOfficePaymentMethod is how a buyer paid at the door
+type BoxOfficePaymentMethod string
+
+const (
+	BoxOfficeCash BoxOfficePaymentMethod = "cash"
+	// BoxOfficeCard is a card payment taken on the organizer's own terminal,
+	// outside the online payment providers
+	BoxOfficeCard BoxOfficePaymentMethod = "card"
+)
+
+// boxOfficePaymentPrefix starts the payment IDs of box office orders, which
+// were paid at the door rather than through a payment provider
+const boxOfficePaymentPrefix = "box_office_"
+
+// MaxBoxOfficeTickets bounds how many tickets a single door sale can issue
+const MaxBoxOfficeTickets = 50
+
+var boxOfficeEmailRegex = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+
+// BoxOfficeItem is a ticket type and how many of it are sold at the door
+type BoxOfficeItem struct {
+	TicketTypeID int `json:"ticket_type_id"`
+	Quantity     int `json:"quantity"`
+	Price        int `json:"price"` // Per ticket; the amount paid for pay-what-you-want ticket types
+}
+
+// BoxOfficeSale is a sale an organizer records at the door. The buyer's name
+// and email are optional; without an email the tickets are sent to the
+// seller to print.
+type BoxOfficeSale struct {
+	Items         []BoxOfficeItem        `json:"items"`
+	PaymentMethod BoxOfficePaymentMethod `json:"payment_method"`
+	BuyerName     string                 `json:"buyer_name"`
+	BuyerEmail    string                 `json:"buyer_email"`
+}
+
+// Validate validates the box office sale
+func (s *BoxOfficeSale) Validate() error {
+	if s.PaymentMethod != BoxOfficeCash && s.PaymentMethod != BoxOfficeCard {
+		return errors.New("payment method must be cash or card")
+	}
+
+	tickets := 0
+	for _, item := range s.Items {
+		if item.Quantity < 0 {
+			return errors.New("ticket quantity cannot be negative")
+		}
+		tickets += item.Quantity
+	}
+	if tickets == 0 {
+		return errors.New("select at least one ticket")
+	}
+	if tickets > MaxBoxOfficeTickets {
+		return errors.New("a box office sale cannot issue more than 50 tickets")
+	}
+
+	if len(s.BuyerName) > 255 {
+		return errors.New("buyer name must be less than 255 characters")
+	}
+	if email := strings.TrimSpace(s.BuyerEmail); email != "" && !boxOfficeEmailRegex.MatchString(email) {
+		return errors.New("buyer email format is invalid")
+	}
+	return nil
+}
+
+// NewBoxOfficePaymentID returns a unique payment ID for a door sale paid by
+// the method
+func NewBoxOfficePaymentID(method BoxOfficePaymentMethod) (string, error) {
+	randomBytes := make([]byte, 8)
+	if _, err := rand.Read(randomBytes); err != nil {
+		return "", err
+	}
+	return boxOfficePaymentPrefix + string(method) + "_" + hex.EncodeToString(randomBytes), nil
+}
+
+// IsBoxOfficePayment returns true for the payment IDs of box office orders,
+// which no payment provider knows about
+func IsBoxOfficePayment(paymentID string) bool {
+	return strings.HasPrefix(paymentID, boxOfficePaymentPrefix)
+}
+
+// OnlinePaymentCondition returns an SQL condition that leaves out box office
+// orders, whose money the organizer took at the door, for the queries behind
+// payouts, platform fees and the revenue still to be paid out. column is the
+// orders' payment_id column, such as "o.payment_id".
+func OnlinePaymentCondition(column string) string {
+	pattern := strings.ReplaceAll(boxOfficePaymentPrefix, "_", `\_`) + "%"
+	return "COALESCE(" + column + ", '') NOT LIKE '" + pattern + "'"
+}
+
+// BoxOfficeAttribution returns the attribution door sales are recorded with,
+// so analytics report them under the box office channel by payment method
+func BoxOfficeAttribution(method BoxOfficePaymentMethod) *Attribution {
+	return &Attribution{Source: AttributionSourceBoxOffice, Medium: string(method)}
+}
@@ -0,0 +1,55 @@
+package models
+
+import "testing"
+
+func TestBoxOfficeSale_Validate(t *testing.T) {
+	valid := BoxOfficeSale{
+		Items:         []BoxOfficeItem{{TicketTypeID: 1, Quantity: 2}},
+		PaymentMethod: BoxOfficeCash,
+		BuyerEmail:    "walkin@example.com",
+	}
+	if err := valid.Validate(); err != nil {
+		t.Fatalf("Validate() = %v, want nil", err)
+	}
+
+	tests := map[string]func(s *BoxOfficeSale){
+		"unknown payment method": func(s *BoxOfficeSale) { s.PaymentMethod = "cheque" },
+		"no tickets":             func(s *BoxOfficeSale) { s.Items = []BoxOfficeItem{{TicketTypeID: 1}} },
+		"negative quantity":      func(s *BoxOfficeSale) { s.Items = []BoxOfficeItem{{TicketTypeID: 1, Quantity: -1}} },
+		"too many tickets": func(s *BoxOfficeSale) {
+			s.Items = []BoxOfficeItem{{TicketTypeID: 1, Quantity: MaxBoxOfficeTickets + 1}}
+		},
+		"invalid email": func(s *BoxOfficeSale) { s.BuyerEmail = "walkin" },
+	}
+	for name, change := range tests {
+		sale := valid
+		change(&sale)
+		if err := sale.Validate(); err == nil {
+			t.Errorf("%s: Validate() = nil, want an error", name)
+		}
+	}
+}
+
+func TestNewBoxOfficePaymentID(t *testing.T) {
+	first, err := NewBoxOfficePaymentID(BoxOfficeCard)
+	if err != nil {
+		t.Fatalf("NewBoxOfficePaymentID() error = %v", err)
+	}
+	second, _ := NewBoxOfficePaymentID(BoxOfficeCard)
+	if first == second {
+		t.Errorf("NewBoxOfficePaymentID() returned %q twice", first)
+	}
+	if !IsBoxOfficePayment(first) {
+		t.Errorf("IsBoxOfficePayment(%q) = false, want true", first)
+	}
+	if IsBoxOfficePayment("mock_pay_1700000000_5000") {
+		t.Error("IsBoxOfficePayment() = true for an online payment")
+	}
+}
+
+func TestOnlinePaymentCondition(t *testing.T) {
+	want := `COALESCE(o.payment_id, '') NOT LIKE 'box\_office\_%'`
+	if got := OnlinePaymentCondition("o.payment_id"); got != want {
+		t.Errorf("OnlinePaymentCondition() = %s, want %s", got, want)
+	}
+}
@@ -0,0 +1,80 @@
+package models
+
+import "fmt"
+
+// MaxBulkItems caps how many users or events one bulk action can change
+const MaxBulkItems = 100
+
+// BulkAction is an action admins can take on many users or events at once
+type BulkAction string
+
+const (
+	BulkSuspendUsers    BulkAction = "suspend"
+	BulkChangeRole      BulkAction = "change_role"
+	BulkUnpublishEvents BulkAction = "unpublish"
+	BulkAssignCategory  BulkAction = "assign_category"
+)
+
+// BulkUserActions are the bulk actions on users
+var BulkUserActions = []BulkAction{BulkSuspendUsers, BulkChangeRole}
+
+// BulkEventActions are the bulk actions on events
+var BulkEventActions = []BulkAction{BulkUnpublishEvents, BulkAssignCategory}
+
+// DisplayName returns a human-readable action name
+func (a BulkAction) DisplayName() string {
+	switch a {
+	case BulkSuspendUsers:
+		return "Suspend"
+	case BulkChangeRole:
+		return "Change role"
+	case BulkUnpublishEvents:
+		return "Unpublish"
+	case BulkAssignCategory:
+		return "Assign category"
+	default:
+		return string(a)
+	}
+}
+
+// BulkItemResult is the outcome of a bulk action on one user or event
+type BulkItemResult struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"` // The user's email or the event's title, when known
+	Error string `json:"error,omitempty"`
+}
+
+// OK returns true if the action succeeded for the item
+func (r BulkItemResult) OK() bool {
+	return r.Error == ""
+}
+
+// BulkResult is the outcome of a bulk action, item by item
+type BulkResult struct {
+	Action BulkAction       `json:"action"`
+	Items  []BulkItemResult `json:"items"`
+}
+
+// Succeeded returns how many items the action succeeded for
+func (r *BulkResult) Succeeded() int {
+	succeeded := 0
+	for _, item := range r.Items {
+		if item.OK() {
+			succeeded++
+		}
+	}
+	return succeeded
+}
+
+// Failed returns how many items the action failed for
+func (r *BulkResult) Failed() int {
+	return len(r.Items) - r.Succeeded()
+}
+
+// Summary describes the outcome, such as "Suspend: 3 succeeded, 1 failed"
+func (r *BulkResult) Summary() string {
+	if r.Failed() == 0 {
+		return fmt.Sprintf("%s: %d succeeded", r.Action.DisplayName(), r.Succeeded())
+	}
+	return fmt.Sprintf("%s: %d succeeded, %d failed", r.Action.DisplayName(), r.Succeeded(), r.Failed())
+}
@@ -0,0 +1,18 @@
+package models
+
+import "testing"
+
+func TestBulkResult_Summary(t *testing.T) {
+	result := &BulkResult{Action: BulkSuspendUsers, Items: []BulkItemResult{{ID: 1}, {ID: 2}}}
+	if got := result.Summary(); got != "Suspend: 2 succeeded" {
+		t.Errorf("Summary() = %q", got)
+	}
+
+	result.Items = append(result.Items, BulkItemResult{ID: 3, Error: "user not found"})
+	if result.Succeeded() != 2 || result.Failed() != 1 {
+		t.Errorf("Succeeded() = %d, Failed() = %d, want 2 and 1", result.Succeeded(), result.Failed())
+	}
+	if got := result.Summary(); got != "Suspend: 2 succeeded, 1 failed" {
+		t.Errorf("Summary() = %q", got)
+	}
+}
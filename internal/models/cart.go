@@ -5,15 +5,79 @@ type Cart struct {
 	EventID     int        `json:"event_id"`
 	EventTitle  string     `json:"event_title"`
 	Items       []CartItem `json:"items"`
-	TotalAmount int        `json:"total_amount"` // in cents
-	ExpiresAt   int64      `json:"expires_at"`   // Unix timestamp
+	TotalAmount int        `json:"total_amount"`  // in cents, including the donation and tax
+	ExpiresAt   int64      `json:"expires_at"`    // Unix timestamp
+	Donation    int        `json:"donation"`      // in cents, added at checkout
+	Tax         *TaxLine   `json:"tax,omitempty"` // Set at checkout for taxed events
 }
 
 // CartItem represents an item in the shopping cart
 type CartItem struct {
 	TicketTypeID int    `json:"ticket_type_id"`
 	TicketName   string `json:"ticket_name"`
-	Price        int    `json:"price"`    // in cents
+	Price        int    `json:"price"` // in cents, chosen by the buyer if PayWhatYouWant
 	Quantity     int    `json:"quantity"`
 	Subtotal     int    `json:"subtotal"` // in cents
-}
\ No newline at end of file
+
+	PayWhatYouWant bool `json:"pay_what_you_want"`
+}
+
+// RecalculateTotal updates the subtotal of each item, the tax on the tickets
+// and the cart total. Donations aren't taxed.
+func (c *Cart) RecalculateTotal() {
+	tickets := 0
+	for i := range c.Items {
+		c.Items[i].Subtotal = c.Items[i].Price * c.Items[i].Quantity
+		tickets += c.Items[i].Subtotal
+	}
+	if c.Tax != nil {
+		c.Tax.Recalculate(tickets)
+	}
+	c.TotalAmount = tickets + c.Donation + c.Tax.Added()
+}
+
+// ApplyTax sets the tax rate the cart's tickets are sold with, nil if they
+// aren't taxed, and recalculates the total
+func (c *Cart) ApplyTax(rate *TaxRate) {
+	c.Tax = nil
+	if rate != nil {
+		c.Tax = rate.Apply(0)
+	}
+	c.RecalculateTotal()
+}
+
+// TicketsTotal returns the total of the cart's tickets, without the donation
+// or tax added to them
+func (c *Cart) TicketsTotal() int {
+	return c.TotalAmount - c.Donation - c.Tax.Added()
+}
+
+// ItemPrices returns the price each ticket type in the cart is bought at
+func (c *Cart) ItemPrices() []OrderItemPrice {
+	prices := make([]OrderItemPrice, 0, len(c.Items))
+	for _, item := range c.Items {
+		prices = append(prices, OrderItemPrice{TicketTypeID: item.TicketTypeID, UnitPrice: item.Price})
+	}
+	return prices
+}
+
+// TicketCount returns the number of tickets in the cart
+func (c *Cart) TicketCount() int {
+	count := 0
+	for _, item := range c.Items {
+		count += item.Quantity
+	}
+	return count
+}
+
+// AttendeeTickets returns the ticket name of each attendee in the cart, in
+// the order their tickets are created at checkout
+func (c *Cart) AttendeeTickets() []string {
+	var names []string
+	for _, item := range c.Items {
+		for i := 0; i < item.Quantity; i++ {
+			names = append(names, item.TicketName)
+		}
+	}
+	return names
+}
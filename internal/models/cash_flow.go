@@ -0,0 +1,84 @@
+package models
+
+import "time"
+
+// CashFlowWeeks is how many weeks ahead the cash-flow projection covers
+const CashFlowWeeks = 8
+
+// EventRevenue is the ticket revenue an organizer has collected for an event
+type EventRevenue struct {
+	EventID    int       `json:"event_id"`
+	Title      string    `json:"title"`
+	StartDate  time.Time `json:"start_date"`
+	EndDate    time.Time `json:"end_date"`
+	Orders     int       `json:"orders"`
+	GrossCents int64     `json:"gross_cents"`
+}
+
+// Gross returns what buyers paid, which is what they would get back if the
+// event was cancelled
+func (e *EventRevenue) Gross() float64 {
+	return float64(e.GrossCents) / 100.0
+}
+
+// Net returns the revenue after the platform fee, as counted in the balance
+func (e *EventRevenue) Net() float64 {
+	return e.Gross() * (1 - PlatformFeeRate)
+}
+
+// CashFlowWeek is one week of an organizer's cash-flow projection
+type CashFlowWeek struct {
+	Start time.Time `json:"start"`
+
+	// Withdrawals on their way to the organizer's bank. They have no
+	// payout date, so they are expected in the current week.
+	PendingPayouts     float64 `json:"pending_payouts"`     // requested, awaiting review
+	ScheduledTransfers float64 `json:"scheduled_transfers"` // approved, not yet paid out
+
+	// SettlingSales is the net revenue of events ending this week. Until an
+	// event ends its revenue would be refunded if it was cancelled.
+	SettlingSales float64 `json:"settling_sales"`
+
+	// RefundExposure is what buyers paid for events starting this week
+	RefundExposure float64 `json:"refund_exposure"`
+
+	Events []*EventRevenue `json:"events"` // Events starting this week
+}
+
+// End returns the start of the following week
+func (w *CashFlowWeek) End() time.Time {
+	return w.Start.AddDate(0, 0, 7)
+}
+
+// Payouts returns the withdrawals expected to reach the organizer this week
+func (w *CashFlowWeek) Payouts() float64 {
+	return w.PendingPayouts + w.ScheduledTransfers
+}
+
+// CashFlowProjection is an organizer's projected incoming funds by week and
+// their exposure to refunds for upcoming events
+type CashFlowProjection struct {
+	GeneratedAt      time.Time `json:"generated_at"`
+	AvailableBalance float64   `json:"available_balance"`
+
+	// HeldForRefunds is true while ticket holders of a cancelled event are
+	// being refunded, during which the whole balance is held back
+	HeldForRefunds bool `json:"held_for_refunds"`
+
+	PendingPayouts     float64 `json:"pending_payouts"`
+	ScheduledTransfers float64 `json:"scheduled_transfers"`
+	UnsettledSales     float64 `json:"unsettled_sales"` // Net revenue of events that have not ended
+	RefundExposure     float64 `json:"refund_exposure"` // What buyers paid for events that have not ended
+
+	Weeks []*CashFlowWeek `json:"weeks"`
+
+	// Events beyond the projected weeks
+	LaterSettlingSales  float64 `json:"later_settling_sales"`
+	LaterRefundExposure float64 `json:"later_refund_exposure"`
+}
+
+// WeekStart returns midnight on the Monday of t's week
+func WeekStart(t time.Time) time.Time {
+	daysSinceMonday := (int(t.Weekday()) + 6) % 7
+	return time.Date(t.Year(), t.Month(), t.Day()-daysSinceMonday, 0, 0, 0, 0, t.Location())
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"errors"
+	"fmt"
 	"regexp"
 	"strings"
 	"time"
@@ -9,11 +10,14 @@ import (
 
 // Category represents an event category
 type Category struct {
-	ID          int       `json:"id" db:"id"`
-	Name        string    `json:"name" db:"name"`
-	Slug        string    `json:"slug" db:"slug"`
-	Description string    `json:"description" db:"description"`
-	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	ID              int       `json:"id" db:"id"`
+	Name            string    `json:"name" db:"name"`
+	Slug            string    `json:"slug" db:"slug"`
+	Description     string    `json:"description" db:"description"`
+	HeroImageURL    string    `json:"hero_image_url" db:"hero_image_url"` // Landing page hero, curated by admins
+	MetaTitle       string    `json:"meta_title" db:"meta_title"`
+	MetaDescription string    `json:"meta_description" db:"meta_description"`
+	CreatedAt       time.Time `json:"created_at" db:"created_at"`
 }
 
 // CategoryCreateRequest represents the data needed to create a new category
@@ -175,4 +179,65 @@ func GenerateSlug(name string) string {
 // HasDescription returns true if the category has a description
 func (c *Category) HasDescription() bool {
 	return strings.TrimSpace(c.Description) != ""
+}
+
+// Category landing page limits. Search engines truncate titles and
+// descriptions past these lengths.
+const (
+	MaxFeaturedCategoryEvents        = 6
+	MaxCategoryMetaTitleLength       = 70
+	MaxCategoryMetaDescriptionLength = 160
+)
+
+// Path returns the URL path of the category's landing page
+func (c *Category) Path() string {
+	return "/categories/" + c.Slug
+}
+
+// PageTitle returns the title of the category's landing page: its search
+// title if admins set one, else one from its name
+func (c *Category) PageTitle() string {
+	if c.MetaTitle != "" {
+		return c.MetaTitle
+	}
+	return c.Name + " Events"
+}
+
+// PageDescription returns the search description of the category's landing
+// page: the one admins set, else its description, else one from its name
+func (c *Category) PageDescription() string {
+	if c.MetaDescription != "" {
+		return c.MetaDescription
+	}
+	if c.HasDescription() {
+		return c.Description
+	}
+	return "Discover upcoming " + c.Name + " events and buy tickets on Runtown."
+}
+
+// CategoryLandingPageRequest represents the settings admins curate a
+// category's landing page with
+type CategoryLandingPageRequest struct {
+	HeroImageURL    string `json:"hero_image_url"`
+	MetaTitle       string `json:"meta_title"`
+	MetaDescription string `json:"meta_description"`
+}
+
+// Validate validates the landing page settings
+func (req *CategoryLandingPageRequest) Validate() error {
+	req.HeroImageURL = strings.TrimSpace(req.HeroImageURL)
+	req.MetaTitle = strings.TrimSpace(req.MetaTitle)
+	req.MetaDescription = strings.TrimSpace(req.MetaDescription)
+
+	if err := validateImageURL(req.HeroImageURL); err != nil {
+		return fmt.Errorf("hero %w", err)
+	}
+	if len(req.MetaTitle) > MaxCategoryMetaTitleLength {
+		return fmt.Errorf("search title must be at most %d characters", MaxCategoryMetaTitleLength)
+	}
+	if len(req.MetaDescription) > MaxCategoryMetaDescriptionLength {
+		return fmt.Errorf("search description must be at most %d characters", MaxCategoryMetaDescriptionLength)
+	}
+
+	return nil
 }
\ No newline at end of file
@@ -1,6 +1,7 @@
 package models
 
 import (
+	"strings"
 	"testing"
 )
 
@@ -248,4 +249,55 @@ func TestValidateCategorySlug(t *testing.T) {
 			}
 		})
 	}
+}
+
+func TestCategory_PageTitleAndDescription(t *testing.T) {
+	category := &Category{Name: "Music", Slug: "music", Description: "Concerts and festivals"}
+	if got := category.PageTitle(); got != "Music Events" {
+		t.Errorf("PageTitle() = %q, want the default from the name", got)
+	}
+	if got := category.PageDescription(); got != "Concerts and festivals" {
+		t.Errorf("PageDescription() = %q, want the category description", got)
+	}
+	if got := category.Path(); got != "/categories/music" {
+		t.Errorf("Path() = %q, want /categories/music", got)
+	}
+
+	category.MetaTitle = "Live Music in Kenya"
+	category.MetaDescription = "Gigs and festivals near you"
+	if got := category.PageTitle(); got != "Live Music in Kenya" {
+		t.Errorf("PageTitle() = %q, want the curated search title", got)
+	}
+	if got := category.PageDescription(); got != "Gigs and festivals near you" {
+		t.Errorf("PageDescription() = %q, want the curated search description", got)
+	}
+
+	empty := &Category{Name: "Sports"}
+	if got := empty.PageDescription(); got != "Discover upcoming Sports events and buy tickets on Runtown." {
+		t.Errorf("PageDescription() = %q, want one from the name", got)
+	}
+}
+
+func TestCategoryLandingPageRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     CategoryLandingPageRequest
+		wantErr bool
+	}{
+		{name: "empty", req: CategoryLandingPageRequest{}},
+		{name: "full", req: CategoryLandingPageRequest{HeroImageURL: "https://cdn.example.com/music.jpg", MetaTitle: "Music", MetaDescription: "Concerts"}},
+		{name: "site path", req: CategoryLandingPageRequest{HeroImageURL: "/uploads/music.png"}},
+		{name: "bad scheme", req: CategoryLandingPageRequest{HeroImageURL: "javascript:alert(1)"}, wantErr: true},
+		{name: "long title", req: CategoryLandingPageRequest{MetaTitle: strings.Repeat("a", MaxCategoryMetaTitleLength+1)}, wantErr: true},
+		{name: "long description", req: CategoryLandingPageRequest{MetaDescription: strings.Repeat("a", MaxCategoryMetaDescriptionLength+1)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
 }
\ No newline at end of file
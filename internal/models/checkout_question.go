@@ -0,0 +1,136 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// CheckoutQuestionType is the kind of form field a checkout question uses
+type CheckoutQuestionType string
+
+const (
+	QuestionTypeText   CheckoutQuestionType = "text"
+	QuestionTypeSelect CheckoutQuestionType = "select"
+)
+
+// Limits on checkout questions, which keep the checkout form short
+const (
+	MaxCheckoutQuestions     = 10
+	MaxCheckoutQuestionLabel = 200
+	MaxCheckoutOptions       = 30
+	MaxCheckoutAnswerLength  = 200
+)
+
+// CheckoutQuestion is a custom form field an organizer asks every attendee
+// at checkout, e.g. t-shirt size or dietary needs
+type CheckoutQuestion struct {
+	ID        int                  `json:"id" db:"id"`
+	EventID   int                  `json:"event_id" db:"event_id"`
+	Label     string               `json:"label" db:"label"`
+	Type      CheckoutQuestionType `json:"type" db:"field_type"`
+	Options   []string             `json:"options,omitempty" db:"options"` // Choices of select questions
+	Required  bool                 `json:"required" db:"required"`
+	Position  int                  `json:"position" db:"position"`
+	CreatedAt time.Time            `json:"created_at" db:"created_at"`
+}
+
+// CheckAnswer validates an attendee's answer and returns it trimmed. Optional
+// questions can be left blank.
+func (q *CheckoutQuestion) CheckAnswer(answer string) (string, error) {
+	answer = strings.TrimSpace(answer)
+	if answer == "" {
+		if q.Required {
+			return "", fmt.Errorf("%s is required", q.Label)
+		}
+		return "", nil
+	}
+
+	if q.Type == QuestionTypeSelect {
+		for _, option := range q.Options {
+			if option == answer {
+				return answer, nil
+			}
+		}
+		return "", fmt.Errorf("please choose one of the options for %s", q.Label)
+	}
+
+	if len(answer) > MaxCheckoutAnswerLength {
+		return "", fmt.Errorf("%s must be %d characters or less", q.Label, MaxCheckoutAnswerLength)
+	}
+	return answer, nil
+}
+
+// CheckoutQuestionCreateRequest represents a request to add a checkout
+// question to an event
+type CheckoutQuestionCreateRequest struct {
+	EventID  int                  `json:"event_id"`
+	Label    string               `json:"label"`
+	Type     CheckoutQuestionType `json:"type"`
+	Options  []string             `json:"options"`
+	Required bool                 `json:"required"`
+}
+
+// Validate validates the checkout question request, trimming the label and
+// options
+func (r *CheckoutQuestionCreateRequest) Validate() error {
+	r.Label = strings.TrimSpace(r.Label)
+	if r.Label == "" {
+		return errors.New("question is required")
+	}
+	if len(r.Label) > MaxCheckoutQuestionLabel {
+		return fmt.Errorf("question must be %d characters or less", MaxCheckoutQuestionLabel)
+	}
+
+	switch r.Type {
+	case QuestionTypeText:
+		r.Options = nil
+	case QuestionTypeSelect:
+		seen := map[string]bool{}
+		var options []string
+		for _, option := range r.Options {
+			option = strings.TrimSpace(option)
+			if option == "" || seen[option] {
+				continue
+			}
+			if len(option) > MaxCheckoutAnswerLength {
+				return fmt.Errorf("options must be %d characters or less", MaxCheckoutAnswerLength)
+			}
+			seen[option] = true
+			options = append(options, option)
+		}
+		if len(options) < 2 {
+			return errors.New("dropdown questions need at least two options")
+		}
+		if len(options) > MaxCheckoutOptions {
+			return fmt.Errorf("dropdown questions can have at most %d options", MaxCheckoutOptions)
+		}
+		r.Options = options
+	default:
+		return errors.New("invalid question type")
+	}
+
+	return nil
+}
+
+// AttendeeAnswers are one attendee's answers to an event's checkout
+// questions, keyed by question ID
+type AttendeeAnswers map[int]string
+
+// CheckoutAnswerField is the checkout form field for an attendee's answer to
+// a question. Attendees are numbered from 0 in the order of the cart.
+func CheckoutAnswerField(attendee, questionID int) string {
+	return fmt.Sprintf("answer_%d_%d", attendee, questionID)
+}
+
+// TicketAnswer is an attendee's answer to a checkout question, stored with
+// their ticket
+type TicketAnswer struct {
+	TicketID   int    `json:"ticket_id" db:"ticket_id"`
+	QuestionID int    `json:"question_id" db:"question_id"`
+	Answer     string `json:"answer" db:"answer"`
+
+	// Related data
+	OrderID int `json:"order_id,omitempty"`
+}
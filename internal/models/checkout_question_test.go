@@ -0,0 +1,66 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestCheckoutQuestionCreateRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     CheckoutQuestionCreateRequest
+		wantErr bool
+	}{
+		{name: "text question", req: CheckoutQuestionCreateRequest{Label: "Company", Type: QuestionTypeText}},
+		{name: "dropdown question", req: CheckoutQuestionCreateRequest{Label: "T-shirt size", Type: QuestionTypeSelect, Options: []string{"S", "M", "L"}}},
+		{name: "missing label", req: CheckoutQuestionCreateRequest{Label: "  ", Type: QuestionTypeText}, wantErr: true},
+		{name: "long label", req: CheckoutQuestionCreateRequest{Label: strings.Repeat("a", MaxCheckoutQuestionLabel+1), Type: QuestionTypeText}, wantErr: true},
+		{name: "unknown type", req: CheckoutQuestionCreateRequest{Label: "Company", Type: "checkbox"}, wantErr: true},
+		{name: "dropdown with one option", req: CheckoutQuestionCreateRequest{Label: "Diet", Type: QuestionTypeSelect, Options: []string{"Vegan", " Vegan ", ""}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCheckoutQuestionCreateRequest_ValidateCleansOptions(t *testing.T) {
+	req := CheckoutQuestionCreateRequest{Label: " Diet ", Type: QuestionTypeSelect, Options: []string{" None", "Vegan", "", "None", "Halal "}}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.Label != "Diet" || strings.Join(req.Options, ",") != "None,Vegan,Halal" {
+		t.Errorf("expected trimmed, unique options, got %q %v", req.Label, req.Options)
+	}
+
+	text := CheckoutQuestionCreateRequest{Label: "Company", Type: QuestionTypeText, Options: []string{"ignored"}}
+	if err := text.Validate(); err != nil || text.Options != nil {
+		t.Errorf("expected text questions to have no options, got %v, %v", text.Options, err)
+	}
+}
+
+func TestCheckoutQuestion_CheckAnswer(t *testing.T) {
+	size := &CheckoutQuestion{Label: "T-shirt size", Type: QuestionTypeSelect, Options: []string{"S", "M", "L"}, Required: true}
+	company := &CheckoutQuestion{Label: "Company", Type: QuestionTypeText}
+
+	if answer, err := size.CheckAnswer(" M "); err != nil || answer != "M" {
+		t.Errorf("expected M, got %q, %v", answer, err)
+	}
+	if _, err := size.CheckAnswer("XXL"); err == nil {
+		t.Error("expected answers outside the options to be refused")
+	}
+	if _, err := size.CheckAnswer(""); err == nil {
+		t.Error("expected required questions to need an answer")
+	}
+	if answer, err := company.CheckAnswer("  "); err != nil || answer != "" {
+		t.Errorf("expected optional questions to allow blank answers, got %q, %v", answer, err)
+	}
+	if _, err := company.CheckAnswer(strings.Repeat("a", MaxCheckoutAnswerLength+1)); err == nil {
+		t.Error("expected long answers to be refused")
+	}
+}
@@ -0,0 +1,33 @@
+package models
+
+import (
+	"regexp"
+	"strings"
+)
+
+var citySlugInvalidChars = regexp.MustCompile(`[^a-z0-9]+`)
+
+// City represents a normalized city aggregated from event locations
+type City struct {
+	Slug       string `json:"slug" db:"city_slug"`
+	Name       string `json:"name"`
+	EventCount int    `json:"event_count"`
+	Region     string `json:"region,omitempty"` // County, from the places dataset
+}
+
+// CityName extracts the city part of a free-text location, which by
+// convention is its last comma-separated component ("KICC, Nairobi" -> "Nairobi")
+func CityName(location string) string {
+	if idx := strings.LastIndex(location, ","); idx >= 0 {
+		location = location[idx+1:]
+	}
+	return strings.TrimSpace(location)
+}
+
+// CitySlug normalizes a location into the slug used for city landing pages.
+// It must stay in sync with the events.city_slug generated column.
+func CitySlug(location string) string {
+	slug := strings.ToLower(CityName(location))
+	slug = citySlugInvalidChars.ReplaceAllString(slug, "-")
+	return strings.Trim(slug, "-")
+}
@@ -0,0 +1,28 @@
+package models
+
+import "testing"
+
+func TestCitySlug(t *testing.T) {
+	tests := []struct {
+		location string
+		name     string
+		slug     string
+	}{
+		{"KICC, Harambee Avenue, Nairobi", "Nairobi", "nairobi"},
+		{"Nairobi", "Nairobi", "nairobi"},
+		{"Beach Resort,  Diani Beach ", "Diani Beach", "diani-beach"},
+		{"Online", "Online", "online"},
+		{"", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.location, func(t *testing.T) {
+			if got := CityName(tt.location); got != tt.name {
+				t.Errorf("CityName(%q) = %q, want %q", tt.location, got, tt.name)
+			}
+			if got := CitySlug(tt.location); got != tt.slug {
+				t.Errorf("CitySlug(%q) = %q, want %q", tt.location, got, tt.slug)
+			}
+		})
+	}
+}
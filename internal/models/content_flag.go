@@ -0,0 +1,13 @@
+package models
+
+import "time"
+
+// EventContentFlag records why automatic content screening held an event
+// back for moderation
+type EventContentFlag struct {
+	ID        int       `json:"id" db:"id"`
+	EventID   int       `json:"event_id" db:"event_id"`
+	Score     int       `json:"score" db:"score"`
+	Reasons   []string  `json:"reasons" db:"reasons"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+}
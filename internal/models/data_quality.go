@@ -0,0 +1,91 @@
+package models
+
+import "time"
+
+// DataQualityCheck identifies a data-integrity check
+type DataQualityCheck string
+
+const (
+	CheckEventsWithoutTicketTypes DataQualityCheck = "events_without_ticket_types"
+	CheckOrdersWithoutTickets     DataQualityCheck = "orders_without_tickets"
+	CheckSoldCountMismatch        DataQualityCheck = "sold_count_mismatch"
+	CheckOrphanedImages           DataQualityCheck = "orphaned_images"
+)
+
+// DataQualityIssue is a single record that failed a data-integrity check
+type DataQualityIssue struct {
+	TargetType string `json:"target_type"`
+	TargetID   int    `json:"target_id,omitempty"`
+	TargetKey  string `json:"target_key,omitempty"`
+	Summary    string `json:"summary"`
+	// Fixable is true when the check's remediation job can repair the record
+	Fixable bool `json:"fixable"`
+}
+
+// DataQualityCheckResult holds the issues found by one check
+type DataQualityCheckResult struct {
+	Check       DataQualityCheck    `json:"check"`
+	Name        string              `json:"name"`
+	Description string              `json:"description"`
+	Remediation string              `json:"remediation,omitempty"`
+	Issues      []*DataQualityIssue `json:"issues"`
+	Error       string              `json:"error,omitempty"`
+}
+
+// FixableCount returns the number of issues the remediation job can repair
+func (r *DataQualityCheckResult) FixableCount() int {
+	count := 0
+	for _, issue := range r.Issues {
+		if issue.Fixable {
+			count++
+		}
+	}
+	return count
+}
+
+// DataQualityReport is the result of running every data-integrity check
+type DataQualityReport struct {
+	Results     []*DataQualityCheckResult `json:"results"`
+	StartedAt   time.Time                 `json:"started_at"`
+	CompletedAt time.Time                 `json:"completed_at"`
+}
+
+// TotalIssues returns the number of issues found across all checks
+func (r *DataQualityReport) TotalIssues() int {
+	total := 0
+	for _, result := range r.Results {
+		total += len(result.Issues)
+	}
+	return total
+}
+
+// DataQualityRemediation is the outcome of a one-click remediation job
+type DataQualityRemediation struct {
+	Check       DataQualityCheck `json:"check"`
+	Fixed       int              `json:"fixed"`
+	Errors      []string         `json:"errors"`
+	CompletedAt time.Time        `json:"completed_at"`
+}
+
+// OrderWithoutTickets is a completed order that has no ticket rows, along with
+// its event's ticket types so tickets can be reissued when unambiguous
+type OrderWithoutTickets struct {
+	OrderID         int    `json:"order_id"`
+	OrderNumber     string `json:"order_number"`
+	EventID         int    `json:"event_id"`
+	EventTitle      string `json:"event_title"`
+	TotalAmount     int    `json:"total_amount"`
+	TicketTypeCount int    `json:"ticket_type_count"`
+	// TicketTypeID and TicketPrice are only set when the event has a single ticket type
+	TicketTypeID int `json:"ticket_type_id"`
+	TicketPrice  int `json:"ticket_price"`
+}
+
+// ReissueQuantity returns how many tickets the order paid for when it can be
+// derived from the event's only ticket type, or 0 when it can't
+func (o *OrderWithoutTickets) ReissueQuantity() int {
+	if o.TicketTypeCount != 1 || o.TicketPrice <= 0 || o.TotalAmount <= 0 || o.TotalAmount%o.TicketPrice != 0 {
+		return 0
+	}
+	return o.TotalAmount / o.TicketPrice
+}
@@ -0,0 +1,39 @@
+package models
+
+import "time"
+
+// DomainEventType names something that happened on the platform that other
+// modules react to
+type DomainEventType string
+
+const (
+	DomainEventPublished  DomainEventType = "event.published"
+	DomainOrderCompleted  DomainEventType = "order.completed"
+	DomainTicketCheckedIn DomainEventType = "ticket.checked_in"
+	DomainRefundIssued    DomainEventType = "refund.issued"
+)
+
+// DomainEvent is published on the domain event bus. Only the subject of its
+// type is set: Event for published events, Order for completed and refunded
+// orders and Scan for check-ins.
+type DomainEvent struct {
+	Type       DomainEventType `json:"type"`
+	OccurredAt time.Time       `json:"occurred_at"`
+	Event      *Event          `json:"event,omitempty"`
+	Order      *Order          `json:"order,omitempty"`
+	Scan       *TicketScan     `json:"scan,omitempty"`
+}
+
+// EventID returns the ID of the event the domain event concerns
+func (e *DomainEvent) EventID() int {
+	switch {
+	case e.Event != nil:
+		return e.Event.ID
+	case e.Order != nil:
+		return e.Order.EventID
+	case e.Scan != nil:
+		return e.Scan.EventID
+	default:
+		return 0
+	}
+}
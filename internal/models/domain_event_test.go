@@ -0,0 +1,20 @@
+package models
+
+import "testing"
+
+func TestDomainEvent_EventID(t *testing.T) {
+	tests := []struct {
+		event *DomainEvent
+		want  int
+	}{
+		{&DomainEvent{Type: DomainEventPublished, Event: &Event{ID: 3}}, 3},
+		{&DomainEvent{Type: DomainOrderCompleted, Order: &Order{ID: 9, EventID: 4}}, 4},
+		{&DomainEvent{Type: DomainTicketCheckedIn, Scan: &TicketScan{ID: 1, EventID: 5}}, 5},
+		{&DomainEvent{Type: DomainRefundIssued}, 0},
+	}
+	for _, tt := range tests {
+		if got := tt.event.EventID(); got != tt.want {
+			t.Errorf("%s: expected event %d, got %d", tt.event.Type, tt.want, got)
+		}
+	}
+}
@@ -0,0 +1,40 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// Donation limits, in cents
+const (
+	MaxDonationAmount        = 1000000
+	MaxDonationMessageLength = 280
+)
+
+// DonationSettings holds an organizer's settings for asking buyers of an
+// event's tickets for a donation at checkout
+type DonationSettings struct {
+	EventID   int       `json:"event_id" db:"event_id"`
+	Enabled   bool      `json:"enabled" db:"enabled"`
+	Message   string    `json:"message" db:"message"` // Shown to buyers above the donation field
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Validate validates the donation settings
+func (s *DonationSettings) Validate() error {
+	if len(s.Message) > MaxDonationMessageLength {
+		return errors.New("donation message must be 280 characters or fewer")
+	}
+	return nil
+}
+
+// ValidateDonation checks an amount a buyer offered to donate
+func ValidateDonation(amount int) error {
+	if amount < 0 {
+		return errors.New("donation cannot be negative")
+	}
+	if amount > MaxDonationAmount {
+		return errors.New("donation cannot exceed KSh 10,000")
+	}
+	return nil
+}
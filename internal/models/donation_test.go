@@ -0,0 +1,43 @@
+package models
+
+import "testing"
+
+func TestCart_RecalculateTotal(t *testing.T) {
+	cart := &Cart{
+		Items: []CartItem{
+			{TicketTypeID: 1, Price: 1000, Quantity: 2},
+			{TicketTypeID: 2, Price: 2500, Quantity: 1, PayWhatYouWant: true},
+		},
+		Donation: 300,
+	}
+
+	cart.RecalculateTotal()
+
+	if cart.Items[0].Subtotal != 2000 || cart.Items[1].Subtotal != 2500 {
+		t.Errorf("subtotals = %d, %d, want 2000, 2500", cart.Items[0].Subtotal, cart.Items[1].Subtotal)
+	}
+	if cart.TotalAmount != 4800 {
+		t.Errorf("TotalAmount = %d, want 4800", cart.TotalAmount)
+	}
+	if got := cart.TicketsTotal(); got != 4500 {
+		t.Errorf("TicketsTotal() = %d, want 4500", got)
+	}
+
+	prices := cart.ItemPrices()
+	if len(prices) != 2 || prices[1] != (OrderItemPrice{TicketTypeID: 2, UnitPrice: 2500}) {
+		t.Errorf("ItemPrices() = %+v", prices)
+	}
+}
+
+func TestValidateDonation(t *testing.T) {
+	for _, amount := range []int{0, 100, MaxDonationAmount} {
+		if err := ValidateDonation(amount); err != nil {
+			t.Errorf("ValidateDonation(%d) = %v, want nil", amount, err)
+		}
+	}
+	for _, amount := range []int{-1, MaxDonationAmount + 1} {
+		if err := ValidateDonation(amount); err == nil {
+			t.Errorf("ValidateDonation(%d) = nil, want an error", amount)
+		}
+	}
+}
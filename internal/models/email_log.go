@@ -0,0 +1,70 @@
+package models
+
+import (
+	"encoding/json"
+	"time"
+)
+
+// EmailStatus is the delivery status of a logged email
+type EmailStatus string
+
+const (
+	EmailSent       EmailStatus = "sent"       // Accepted by the provider
+	EmailFailed     EmailStatus = "failed"     // The provider rejected the send
+	EmailDelayed    EmailStatus = "delayed"    // The recipient's server deferred delivery
+	EmailDelivered  EmailStatus = "delivered"  // Accepted by the recipient's server
+	EmailBounced    EmailStatus = "bounced"    // The recipient's server rejected the email
+	EmailComplained EmailStatus = "complained" // The recipient marked the email as spam
+)
+
+// EmailLogStatuses lists the statuses in the order admins filter by them
+var EmailLogStatuses = []EmailStatus{EmailFailed, EmailBounced, EmailComplained, EmailDelayed, EmailSent, EmailDelivered}
+
+// EmailLog is an email sent through the email provider
+type EmailLog struct {
+	ID                int             `json:"id" db:"id"`
+	EmailType         string          `json:"email_type" db:"email_type"`
+	Recipient         string          `json:"recipient" db:"recipient"`
+	Subject           string          `json:"subject" db:"subject"`
+	Provider          string          `json:"provider" db:"provider"`
+	ProviderMessageID string          `json:"provider_message_id" db:"provider_message_id"`
+	Status            EmailStatus     `json:"status" db:"status"`
+	Error             string          `json:"error" db:"error"`
+	Request           json.RawMessage `json:"-" db:"request"` // The request sent to the provider, for retries
+	Attempts          int             `json:"attempts" db:"attempts"`
+	CreatedAt         time.Time       `json:"created_at" db:"created_at"`
+	UpdatedAt         time.Time       `json:"updated_at" db:"updated_at"`
+}
+
+// CanRetry reports whether the email can be sent again. Only sends the
+// provider rejected are retried; bounces would bounce again.
+func (l *EmailLog) CanRetry() bool {
+	return l.Status == EmailFailed
+}
+
+// DisplayName returns the status as shown to admins
+func (s EmailStatus) DisplayName() string {
+	switch s {
+	case EmailSent:
+		return "Sent"
+	case EmailFailed:
+		return "Failed"
+	case EmailDelayed:
+		return "Delayed"
+	case EmailDelivered:
+		return "Delivered"
+	case EmailBounced:
+		return "Bounced"
+	case EmailComplained:
+		return "Spam Complaint"
+	}
+	return string(s)
+}
+
+// EmailLogFilter selects logged emails for the admin dashboard
+type EmailLogFilter struct {
+	Status    EmailStatus // Every status when empty
+	Recipient string      // Partial match
+	Limit     int
+	Offset    int
+}
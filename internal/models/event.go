@@ -34,6 +34,7 @@ type EventImageMetadata struct {
 type Event struct {
 	ID          int         `json:"id" db:"id"`
 	Title       string      `json:"title" db:"title"`
+	Slug        string      `json:"slug" db:"slug"`
 	Description string      `json:"description" db:"description"`
 	StartDate   time.Time   `json:"start_date" db:"start_date"`
 	EndDate     time.Time   `json:"end_date" db:"end_date"`
@@ -47,17 +48,22 @@ type Event struct {
 	ImageWidth  int         `json:"image_width" db:"image_width"`
 	ImageHeight int         `json:"image_height" db:"image_height"`
 	ImageUploadedAt *time.Time `json:"image_uploaded_at" db:"image_uploaded_at"`
+	ImageAltText string     `json:"image_alt_text" db:"image_alt_text"`
+	ImageVariants EventImageVariants `json:"image_variants" db:"image_variants"`
 	Status      EventStatus `json:"status" db:"status"`
 	ReviewedAt  *time.Time  `json:"reviewed_at" db:"reviewed_at"`
 	ReviewedBy  *int        `json:"reviewed_by" db:"reviewed_by"`
 	RejectionReason string  `json:"rejection_reason" db:"rejection_reason"`
+	Coordinates *GeoPoint   `json:"coordinates,omitempty"` // Geocoded from Location, only loaded by search
 	CreatedAt   time.Time   `json:"created_at" db:"created_at"`
 	UpdatedAt   time.Time   `json:"updated_at" db:"updated_at"`
 	
 	// Related data
-	Organizer *User     `json:"organizer,omitempty"`
-	Category  *Category `json:"category,omitempty"`
-	Reviewer  *User     `json:"reviewer,omitempty"`
+	Organizer  *User            `json:"organizer,omitempty"`
+	Category   *Category        `json:"category,omitempty"`
+	Reviewer   *User            `json:"reviewer,omitempty"`
+	Venue      *Venue           `json:"venue,omitempty"`
+	AccessLink *EventAccessLink `json:"-"` // Only loaded for the organizer's event editor
 }
 
 // EventCreateRequest represents the data needed to create a new event
@@ -74,6 +80,8 @@ type EventCreateRequest struct {
 	ImageFormat string      `json:"image_format"`
 	ImageWidth  int         `json:"image_width"`
 	ImageHeight int         `json:"image_height"`
+	ImageAltText string     `json:"image_alt_text"`
+	ImageVariants EventImageVariants `json:"image_variants"`
 	Status      EventStatus `json:"status"`
 }
 
@@ -91,6 +99,8 @@ type EventUpdateRequest struct {
 	ImageFormat string      `json:"image_format"`
 	ImageWidth  int         `json:"image_width"`
 	ImageHeight int         `json:"image_height"`
+	ImageAltText string     `json:"image_alt_text"`
+	ImageVariants EventImageVariants `json:"image_variants"`
 	Status      EventStatus `json:"status"`
 }
 
@@ -423,6 +433,12 @@ func (e *Event) IsRejected() bool {
 	return e.Status == StatusRejected
 }
 
+// IsOnline returns true if the event is held online, with an access link
+// for ticket holders instead of a place
+func (e *Event) IsOnline() bool {
+	return e.Location == OnlineEventLocation
+}
+
 // IsUpcoming returns true if the event is in the future
 func (e *Event) IsUpcoming() bool {
 	return e.StartDate.After(time.Now())
@@ -461,6 +477,29 @@ func (e *Event) HasImage() bool {
 	return e.ImageURL != "" && e.ImageKey != ""
 }
 
+// ImageAlt returns the alt text for the event image, falling back to a
+// description generated from the event details
+func (e *Event) ImageAlt() string {
+	if alt := strings.TrimSpace(e.ImageAltText); alt != "" {
+		return alt
+	}
+	return DefaultImageAltText(e.Title, e.Location)
+}
+
+// DefaultImageAltText generates alt text for an event image from the event details
+func DefaultImageAltText(title, location string) string {
+	title = strings.TrimSpace(title)
+	location = strings.TrimSpace(location)
+	switch {
+	case title == "":
+		return "Event image"
+	case location == "":
+		return "Promotional image for " + title
+	default:
+		return "Promotional image for " + title + " in " + location
+	}
+}
+
 // GetImageMetadata returns the image metadata for the event
 func (e *Event) GetImageMetadata() *EventImageMetadata {
 	if !e.HasImage() {
@@ -503,6 +542,7 @@ func (e *Event) ClearImageMetadata() {
 	e.ImageWidth = 0
 	e.ImageHeight = 0
 	e.ImageUploadedAt = nil
+	e.ImageVariants = nil
 }
 
 // GetImageAspectRatio returns the aspect ratio of the image (width/height)
@@ -0,0 +1,67 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// OnlineEventLocation is the location of online events. Ticket holders get
+// the event's access link instead of an address.
+const OnlineEventLocation = "Online"
+
+// Limits on online event access links
+const (
+	MaxAccessURLLength     = 2048
+	MaxAccessRevealMinutes = 7 * 24 * 60
+)
+
+// EventAccessLink is the stream or meeting URL of an online event. It is
+// never shown on the public event page, only to ticket holders.
+type EventAccessLink struct {
+	EventID             int       `json:"event_id" db:"event_id"`
+	URL                 string    `json:"-" db:"url"`
+	RevealMinutesBefore int       `json:"reveal_minutes_before" db:"reveal_minutes_before"` // 0 reveals it as soon as a ticket is bought
+	CreatedAt           time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Validate validates an access link an organizer has entered, trimming its URL
+func (l *EventAccessLink) Validate() error {
+	l.URL = strings.TrimSpace(l.URL)
+
+	if l.URL == "" {
+		return errors.New("a stream or meeting link is required for online events")
+	}
+	u, err := url.Parse(l.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || len(l.URL) > MaxAccessURLLength {
+		return errors.New("the stream or meeting link must be an http or https URL")
+	}
+	if l.RevealMinutesBefore < 0 || l.RevealMinutesBefore > MaxAccessRevealMinutes {
+		return fmt.Errorf("the link can be revealed at most %d days before the event", MaxAccessRevealMinutes/(24*60))
+	}
+	return nil
+}
+
+// RevealAt returns when ticket holders can see the link of an event starting
+// at start, or the zero time if they can see it as soon as they buy a ticket
+func (l *EventAccessLink) RevealAt(start time.Time) time.Time {
+	if l.RevealMinutesBefore == 0 {
+		return time.Time{}
+	}
+	return start.Add(-time.Duration(l.RevealMinutesBefore) * time.Minute)
+}
+
+// IsRevealed returns true if ticket holders can see the link at now
+func (l *EventAccessLink) IsRevealed(start, now time.Time) bool {
+	return !now.Before(l.RevealAt(start))
+}
+
+// EventAccess is what a visitor may see of an online event's access link
+type EventAccess struct {
+	HasTicket bool      `json:"has_ticket"`
+	URL       string    `json:"url,omitempty"`       // Only set for ticket holders once the link is revealed
+	RevealAt  time.Time `json:"reveal_at,omitempty"` // When ticket holders will see the link, if not yet
+}
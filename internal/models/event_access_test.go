@@ -0,0 +1,52 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestEventAccessLink_Validate(t *testing.T) {
+	link := &EventAccessLink{URL: " https://meet.example.com/abc-defg ", RevealMinutesBefore: 30}
+	if err := link.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if link.URL != "https://meet.example.com/abc-defg" {
+		t.Errorf("expected the URL to be trimmed, got %q", link.URL)
+	}
+
+	tests := []struct {
+		name string
+		link EventAccessLink
+	}{
+		{"missing URL", EventAccessLink{URL: " "}},
+		{"not http", EventAccessLink{URL: "javascript:alert(1)"}},
+		{"no host", EventAccessLink{URL: "https://"}},
+		{"negative reveal", EventAccessLink{URL: "https://meet.example.com", RevealMinutesBefore: -1}},
+		{"reveal too early", EventAccessLink{URL: "https://meet.example.com", RevealMinutesBefore: MaxAccessRevealMinutes + 1}},
+	}
+	for _, tt := range tests {
+		if err := tt.link.Validate(); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+func TestEventAccessLink_IsRevealed(t *testing.T) {
+	start := time.Date(2025, 6, 4, 18, 0, 0, 0, time.UTC)
+
+	immediate := &EventAccessLink{URL: "https://meet.example.com"}
+	if !immediate.RevealAt(start).IsZero() || !immediate.IsRevealed(start, start.AddDate(0, -1, 0)) {
+		t.Error("expected a link without a reveal time to be shown as soon as a ticket is bought")
+	}
+
+	nearStart := &EventAccessLink{URL: "https://meet.example.com", RevealMinutesBefore: 60}
+	if got := nearStart.RevealAt(start); !got.Equal(start.Add(-time.Hour)) {
+		t.Errorf("RevealAt() = %v, want an hour before the start", got)
+	}
+	if nearStart.IsRevealed(start, start.Add(-61*time.Minute)) {
+		t.Error("expected the link to be hidden until an hour before the start")
+	}
+	if !nearStart.IsRevealed(start, start.Add(-time.Hour)) {
+		t.Error("expected the link to be shown an hour before the start")
+	}
+}
@@ -0,0 +1,135 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Limits on event agendas
+const (
+	MaxAgendaSessions      = 100
+	MaxAgendaSpeakers      = 50
+	MaxSessionTitleLength  = 200
+	MaxSessionStageLength  = 100
+	MaxSpeakerNameLength   = 150
+	MaxSpeakerRoleLength   = 150
+	MaxAgendaDetailsLength = 2000
+)
+
+// EventSpeaker is a speaker or performer in an event's lineup
+type EventSpeaker struct {
+	ID        int       `json:"id" db:"id"`
+	EventID   int       `json:"event_id" db:"event_id"`
+	Name      string    `json:"name" db:"name"`
+	Role      string    `json:"role" db:"role"` // e.g. "CTO, Safaricom" or "Headliner"
+	Bio       string    `json:"bio" db:"bio"`
+	PhotoURL  string    `json:"photo_url" db:"photo_url"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Validate validates a speaker an organizer has entered, trimming its fields
+func (s *EventSpeaker) Validate() error {
+	s.Name = strings.TrimSpace(s.Name)
+	s.Role = strings.TrimSpace(s.Role)
+	s.Bio = strings.TrimSpace(s.Bio)
+
+	if s.Name == "" {
+		return errors.New("speaker name is required")
+	}
+	if len(s.Name) > MaxSpeakerNameLength {
+		return fmt.Errorf("speaker name must be %d characters or less", MaxSpeakerNameLength)
+	}
+	if len(s.Role) > MaxSpeakerRoleLength {
+		return fmt.Errorf("speaker role must be %d characters or less", MaxSpeakerRoleLength)
+	}
+	if len(s.Bio) > MaxAgendaDetailsLength {
+		return fmt.Errorf("speaker bio must be %d characters or less", MaxAgendaDetailsLength)
+	}
+	return nil
+}
+
+// EventSession is a slot in an event's agenda, such as a talk, a workshop or
+// a set
+type EventSession struct {
+	ID          int       `json:"id" db:"id"`
+	EventID     int       `json:"event_id" db:"event_id"`
+	Title       string    `json:"title" db:"title"`
+	Description string    `json:"description" db:"description"`
+	Stage       string    `json:"stage" db:"stage"` // Room or stage, if the event has several
+	StartsAt    time.Time `json:"starts_at" db:"starts_at"`
+	EndsAt      time.Time `json:"ends_at" db:"ends_at"` // Zero if the session has no set end
+	SpeakerIDs  []int     `json:"speaker_ids"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+
+	// Related data
+	Speakers []*EventSpeaker `json:"speakers,omitempty"`
+}
+
+// Validate validates a session an organizer has entered for an event,
+// trimming its fields. Sessions start while the event is on.
+func (s *EventSession) Validate(event *Event) error {
+	s.Title = strings.TrimSpace(s.Title)
+	s.Description = strings.TrimSpace(s.Description)
+	s.Stage = strings.TrimSpace(s.Stage)
+
+	if s.Title == "" {
+		return errors.New("session title is required")
+	}
+	if len(s.Title) > MaxSessionTitleLength {
+		return fmt.Errorf("session title must be %d characters or less", MaxSessionTitleLength)
+	}
+	if len(s.Stage) > MaxSessionStageLength {
+		return fmt.Errorf("stage must be %d characters or less", MaxSessionStageLength)
+	}
+	if len(s.Description) > MaxAgendaDetailsLength {
+		return fmt.Errorf("session description must be %d characters or less", MaxAgendaDetailsLength)
+	}
+	if s.StartsAt.IsZero() {
+		return errors.New("session start time is required")
+	}
+	if s.StartsAt.Before(event.StartDate) || s.StartsAt.After(event.EndDate) {
+		return errors.New("sessions must start between the event's start and end")
+	}
+	if !s.EndsAt.IsZero() && !s.EndsAt.After(s.StartsAt) {
+		return errors.New("sessions must end after they start")
+	}
+	return nil
+}
+
+// EventAgenda is an event's sessions and its lineup of speakers or performers
+type EventAgenda struct {
+	Sessions []*EventSession `json:"sessions"` // By start time
+	Speakers []*EventSpeaker `json:"speakers"`
+}
+
+// IsEmpty returns true if the organizer hasn't added any sessions or speakers
+func (a *EventAgenda) IsEmpty() bool {
+	return a == nil || (len(a.Sessions) == 0 && len(a.Speakers) == 0)
+}
+
+// AgendaDay is the sessions of an event starting on one day
+type AgendaDay struct {
+	Date     time.Time
+	Sessions []*EventSession
+}
+
+// Days groups the agenda's sessions by the day they start on, so agendas of
+// events running over several days are shown a day at a time
+func (a *EventAgenda) Days() []AgendaDay {
+	var days []AgendaDay
+	for _, session := range a.Sessions {
+		year, month, day := session.StartsAt.Date()
+		if n := len(days); n > 0 {
+			if y, m, d := days[n-1].Date.Date(); y == year && m == month && d == day {
+				days[n-1].Sessions = append(days[n-1].Sessions, session)
+				continue
+			}
+		}
+		days = append(days, AgendaDay{Date: session.StartsAt, Sessions: []*EventSession{session}})
+	}
+	return days
+}
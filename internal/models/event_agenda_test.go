@@ -0,0 +1,89 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestEventSpeaker_Validate(t *testing.T) {
+	speaker := &EventSpeaker{Name: " Sauti Sol ", Role: " Headliner "}
+	if err := speaker.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if speaker.Name != "Sauti Sol" || speaker.Role != "Headliner" {
+		t.Errorf("expected fields to be trimmed, got %+v", speaker)
+	}
+
+	for name, speaker := range map[string]*EventSpeaker{
+		"missing name": {Name: " "},
+		"long name":    {Name: strings.Repeat("a", MaxSpeakerNameLength+1)},
+		"long role":    {Name: "Amina", Role: strings.Repeat("a", MaxSpeakerRoleLength+1)},
+		"long bio":     {Name: "Amina", Bio: strings.Repeat("a", MaxAgendaDetailsLength+1)},
+	} {
+		if err := speaker.Validate(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestEventSession_Validate(t *testing.T) {
+	start := time.Date(2025, 6, 4, 9, 0, 0, 0, time.UTC)
+	event := &Event{StartDate: start, EndDate: start.Add(8 * time.Hour)}
+	valid := func() *EventSession {
+		return &EventSession{Title: " Keynote ", Stage: " Main hall ", StartsAt: start.Add(time.Hour), EndsAt: start.Add(2 * time.Hour)}
+	}
+
+	session := valid()
+	if err := session.Validate(event); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if session.Title != "Keynote" || session.Stage != "Main hall" {
+		t.Errorf("expected fields to be trimmed, got %+v", session)
+	}
+
+	// Sessions can be open-ended
+	session = valid()
+	session.EndsAt = time.Time{}
+	if err := session.Validate(event); err != nil {
+		t.Errorf("unexpected error for an open-ended session: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*EventSession)
+	}{
+		{"missing title", func(s *EventSession) { s.Title = "" }},
+		{"long title", func(s *EventSession) { s.Title = strings.Repeat("a", MaxSessionTitleLength+1) }},
+		{"missing start", func(s *EventSession) { s.StartsAt = time.Time{} }},
+		{"before the event", func(s *EventSession) { s.StartsAt = start.Add(-time.Hour) }},
+		{"after the event", func(s *EventSession) { s.StartsAt = start.Add(9 * time.Hour); s.EndsAt = time.Time{} }},
+		{"ends before it starts", func(s *EventSession) { s.EndsAt = s.StartsAt }},
+	}
+	for _, tt := range tests {
+		session := valid()
+		tt.modify(session)
+		if err := session.Validate(event); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+func TestEventAgenda_Days(t *testing.T) {
+	day1 := time.Date(2025, 6, 4, 9, 0, 0, 0, time.UTC)
+	day2 := day1.AddDate(0, 0, 1)
+	agenda := &EventAgenda{Sessions: []*EventSession{
+		{ID: 1, StartsAt: day1},
+		{ID: 2, StartsAt: day1.Add(3 * time.Hour)},
+		{ID: 3, StartsAt: day2},
+	}}
+
+	days := agenda.Days()
+	if len(days) != 2 || len(days[0].Sessions) != 2 || len(days[1].Sessions) != 1 || days[1].Sessions[0].ID != 3 {
+		t.Errorf("expected sessions grouped into two days, got %+v", days)
+	}
+
+	if !(&EventAgenda{}).IsEmpty() || agenda.IsEmpty() {
+		t.Error("expected only agendas without sessions or speakers to be empty")
+	}
+}
@@ -0,0 +1,75 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// BroadcastStatus tracks a broadcast through the send queue
+type BroadcastStatus string
+
+const (
+	BroadcastQueued  BroadcastStatus = "queued"
+	BroadcastSending BroadcastStatus = "sending"
+	BroadcastSent    BroadcastStatus = "sent"
+)
+
+// Limits on what organizers can write in a broadcast
+const (
+	MaxBroadcastSubjectLength = 150
+	MaxBroadcastMessageLength = 5000
+)
+
+// EventBroadcast is an email an organizer sends to all ticket holders of an event
+type EventBroadcast struct {
+	ID             int             `json:"id" db:"id"`
+	EventID        int             `json:"event_id" db:"event_id"`
+	OrganizerID    int             `json:"organizer_id" db:"organizer_id"`
+	Subject        string          `json:"subject" db:"subject"`
+	Message        string          `json:"message" db:"message"`
+	Status         BroadcastStatus `json:"status" db:"status"`
+	RecipientCount int             `json:"recipient_count" db:"recipient_count"`
+	SentCount      int             `json:"sent_count" db:"sent_count"`
+	FailedCount    int             `json:"failed_count" db:"failed_count"`
+	CreatedAt      time.Time       `json:"created_at" db:"created_at"`
+	CompletedAt    *time.Time      `json:"completed_at,omitempty" db:"completed_at"`
+}
+
+// IsComplete returns true once every recipient has been emailed
+func (b *EventBroadcast) IsComplete() bool {
+	return b.Status == BroadcastSent
+}
+
+// EventBroadcastCreateRequest represents a request to queue a broadcast
+type EventBroadcastCreateRequest struct {
+	EventID     int    `json:"event_id"`
+	OrganizerID int    `json:"organizer_id"`
+	Subject     string `json:"subject"`
+	Message     string `json:"message"`
+}
+
+// Validate validates the broadcast request
+func (r *EventBroadcastCreateRequest) Validate() error {
+	if strings.TrimSpace(r.Subject) == "" {
+		return errors.New("subject is required")
+	}
+	if len(r.Subject) > MaxBroadcastSubjectLength {
+		return errors.New("subject must be 150 characters or less")
+	}
+	if strings.TrimSpace(r.Message) == "" {
+		return errors.New("message is required")
+	}
+	if len(r.Message) > MaxBroadcastMessageLength {
+		return errors.New("message must be 5000 characters or less")
+	}
+	return nil
+}
+
+// BroadcastRecipient is a ticket holder who has not yet been sent a broadcast
+type BroadcastRecipient struct {
+	OrderID int    `json:"order_id"`
+	Email   string `json:"email"`
+	Name    string `json:"name"`
+	Locale  string `json:"locale"` // Language chosen at checkout, else the buyer's or the event's
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEventBroadcastCreateRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     EventBroadcastCreateRequest
+		wantErr bool
+	}{
+		{"valid", EventBroadcastCreateRequest{Subject: "Venue change", Message: "We have moved."}, false},
+		{"missing subject", EventBroadcastCreateRequest{Subject: " ", Message: "We have moved."}, true},
+		{"missing message", EventBroadcastCreateRequest{Subject: "Venue change"}, true},
+		{"subject too long", EventBroadcastCreateRequest{Subject: strings.Repeat("a", MaxBroadcastSubjectLength+1), Message: "Hi"}, true},
+		{"message too long", EventBroadcastCreateRequest{Subject: "Hi", Message: strings.Repeat("a", MaxBroadcastMessageLength+1)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := tt.req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
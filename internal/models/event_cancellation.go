@@ -0,0 +1,77 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// CancellationStatus tracks a cancelled event's refunds
+type CancellationStatus string
+
+const (
+	CancellationRefunding CancellationStatus = "refunding"
+	CancellationCompleted CancellationStatus = "completed"
+)
+
+// MaxCancellationReasonLength limits the explanation organizers send to ticket holders
+const MaxCancellationReasonLength = 2000
+
+// MaxCancellationRefundAttempts is how many times an order's refund is tried
+// before it is left for the support team
+const MaxCancellationRefundAttempts = 3
+
+// EventCancellation records a cancelled event and the progress refunding its
+// ticket holders
+type EventCancellation struct {
+	ID            int                `json:"id" db:"id"`
+	EventID       int                `json:"event_id" db:"event_id"`
+	CancelledBy   int                `json:"cancelled_by" db:"cancelled_by"`
+	Reason        string             `json:"reason" db:"reason"`
+	Status        CancellationStatus `json:"status" db:"status"`
+	OrderCount    int                `json:"order_count" db:"order_count"`
+	RefundTotal   int                `json:"refund_total" db:"refund_total"` // In cents
+	RefundedCount int                `json:"refunded_count" db:"refunded_count"`
+	FailedCount   int                `json:"failed_count" db:"failed_count"`
+	CreatedAt     time.Time          `json:"created_at" db:"created_at"`
+	CompletedAt   *time.Time         `json:"completed_at,omitempty" db:"completed_at"`
+}
+
+// IsComplete returns true once every order has been refunded or given up on
+func (c *EventCancellation) IsComplete() bool {
+	return c.Status == CancellationCompleted
+}
+
+// RefundTotalInCurrency returns the amount owed to ticket holders in currency units
+func (c *EventCancellation) RefundTotalInCurrency() float64 {
+	return float64(c.RefundTotal) / 100.0
+}
+
+// EventCancellationRequest represents an organizer's request to cancel an event
+type EventCancellationRequest struct {
+	EventID     int    `json:"event_id"`
+	CancelledBy int    `json:"cancelled_by"`
+	Reason      string `json:"reason"`
+}
+
+// Validate validates the cancellation request
+func (r *EventCancellationRequest) Validate() error {
+	if strings.TrimSpace(r.Reason) == "" {
+		return errors.New("please tell ticket holders why the event is cancelled")
+	}
+	if len(r.Reason) > MaxCancellationReasonLength {
+		return errors.New("reason must be 2000 characters or less")
+	}
+	return nil
+}
+
+// CancellationOrder is a completed order for a cancelled event that still
+// needs refunding
+type CancellationOrder struct {
+	OrderID     int    `json:"order_id"`
+	OrderNumber string `json:"order_number"`
+	TotalAmount int    `json:"total_amount"` // In cents
+	Email       string `json:"email"`
+	Name        string `json:"name"`
+	Locale      string `json:"locale"` // Language chosen at checkout, else the buyer's or the event's
+}
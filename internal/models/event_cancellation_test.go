@@ -0,0 +1,34 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEventCancellationRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		reason  string
+		wantErr bool
+	}{
+		{name: "valid", reason: "The venue has flooded."},
+		{name: "missing reason", reason: "  ", wantErr: true},
+		{name: "reason too long", reason: strings.Repeat("a", MaxCancellationReasonLength+1), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := &EventCancellationRequest{EventID: 1, CancelledBy: 7, Reason: tt.reason}
+			if err := req.Validate(); (err != nil) != tt.wantErr {
+				t.Errorf("expected error %v, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestEventCancellation_RefundTotalInCurrency(t *testing.T) {
+	cancellation := &EventCancellation{RefundTotal: 125050}
+	if got := cancellation.RefundTotalInCurrency(); got != 1250.50 {
+		t.Errorf("expected 1250.50, got %.2f", got)
+	}
+}
@@ -0,0 +1,100 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Limits on event FAQs and policies
+const (
+	MaxEventFAQs         = 30
+	MaxFAQQuestionLength = 300
+	MaxFAQAnswerLength   = 2000
+	MaxEventPolicyLength = 2000
+	MaxEventMinimumAge   = 21
+)
+
+// EventFAQ is a question an organizer answers ahead of time on the event page
+type EventFAQ struct {
+	ID        int       `json:"id" db:"id"`
+	EventID   int       `json:"event_id" db:"event_id"`
+	Question  string    `json:"question" db:"question"`
+	Answer    string    `json:"answer" db:"answer"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Validate validates an FAQ entry an organizer has entered, trimming its fields
+func (f *EventFAQ) Validate() error {
+	f.Question = strings.TrimSpace(f.Question)
+	f.Answer = strings.TrimSpace(f.Answer)
+
+	if f.Question == "" {
+		return errors.New("question is required")
+	}
+	if len(f.Question) > MaxFAQQuestionLength {
+		return fmt.Errorf("question must be %d characters or less", MaxFAQQuestionLength)
+	}
+	if f.Answer == "" {
+		return errors.New("answer is required")
+	}
+	if len(f.Answer) > MaxFAQAnswerLength {
+		return fmt.Errorf("answer must be %d characters or less", MaxFAQAnswerLength)
+	}
+	return nil
+}
+
+// EventPolicies are the terms an organizer sets for attending an event. Empty
+// fields aren't shown.
+type EventPolicies struct {
+	EventID           int       `json:"event_id" db:"event_id"`
+	RefundPolicy      string    `json:"refund_policy" db:"refund_policy"`
+	MinimumAge        int       `json:"minimum_age" db:"minimum_age"` // 0 for all ages
+	AccessibilityInfo string    `json:"accessibility_info" db:"accessibility_info"`
+	UpdatedAt         time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Validate validates policies an organizer has entered, trimming their fields
+func (p *EventPolicies) Validate() error {
+	p.RefundPolicy = strings.TrimSpace(p.RefundPolicy)
+	p.AccessibilityInfo = strings.TrimSpace(p.AccessibilityInfo)
+
+	if len(p.RefundPolicy) > MaxEventPolicyLength {
+		return fmt.Errorf("refund policy must be %d characters or less", MaxEventPolicyLength)
+	}
+	if p.MinimumAge < 0 || p.MinimumAge > MaxEventMinimumAge {
+		return fmt.Errorf("minimum age must be between 0 and %d", MaxEventMinimumAge)
+	}
+	if len(p.AccessibilityInfo) > MaxEventPolicyLength {
+		return fmt.Errorf("accessibility information must be %d characters or less", MaxEventPolicyLength)
+	}
+	return nil
+}
+
+// IsEmpty returns true if the organizer hasn't set any policy
+func (p *EventPolicies) IsEmpty() bool {
+	return p == nil || (p.RefundPolicy == "" && p.MinimumAge == 0 && p.AccessibilityInfo == "")
+}
+
+// AgeRestriction describes the event's minimum age, such as "18+", or is
+// empty for all-ages events
+func (p *EventPolicies) AgeRestriction() string {
+	if p == nil || p.MinimumAge == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%d+", p.MinimumAge)
+}
+
+// EventInfo is what an organizer wants attendees to know before they go: the
+// event's FAQs and policies
+type EventInfo struct {
+	FAQs     []*EventFAQ    `json:"faqs"`
+	Policies *EventPolicies `json:"policies,omitempty"` // nil until the organizer sets them
+}
+
+// IsEmpty returns true if the event has no FAQs or policies to show
+func (i *EventInfo) IsEmpty() bool {
+	return i == nil || (len(i.FAQs) == 0 && i.Policies.IsEmpty())
+}
@@ -0,0 +1,71 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEventFAQ_Validate(t *testing.T) {
+	faq := &EventFAQ{Question: " Is there parking? ", Answer: " Yes, at the north gate. "}
+	if err := faq.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if faq.Question != "Is there parking?" || faq.Answer != "Yes, at the north gate." {
+		t.Errorf("expected fields to be trimmed, got %+v", faq)
+	}
+
+	for name, faq := range map[string]*EventFAQ{
+		"missing question": {Answer: "Yes"},
+		"missing answer":   {Question: "Is there parking?", Answer: " "},
+		"long question":    {Question: strings.Repeat("a", MaxFAQQuestionLength+1), Answer: "Yes"},
+		"long answer":      {Question: "Is there parking?", Answer: strings.Repeat("a", MaxFAQAnswerLength+1)},
+	} {
+		if err := faq.Validate(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestEventPolicies_Validate(t *testing.T) {
+	policies := &EventPolicies{RefundPolicy: " Refunds up to 7 days before. ", MinimumAge: 18}
+	if err := policies.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if policies.RefundPolicy != "Refunds up to 7 days before." {
+		t.Errorf("expected the refund policy to be trimmed, got %q", policies.RefundPolicy)
+	}
+
+	for name, policies := range map[string]*EventPolicies{
+		"negative age":       {MinimumAge: -1},
+		"age too high":       {MinimumAge: MaxEventMinimumAge + 1},
+		"long refund policy": {RefundPolicy: strings.Repeat("a", MaxEventPolicyLength+1)},
+		"long accessibility": {AccessibilityInfo: strings.Repeat("a", MaxEventPolicyLength+1)},
+	} {
+		if err := policies.Validate(); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestEventPolicies_AgeRestriction(t *testing.T) {
+	if got := (&EventPolicies{MinimumAge: 18}).AgeRestriction(); got != "18+" {
+		t.Errorf("expected 18+, got %q", got)
+	}
+	var none *EventPolicies
+	if got := none.AgeRestriction(); got != "" {
+		t.Errorf("expected no age restriction without policies, got %q", got)
+	}
+}
+
+func TestEventInfo_IsEmpty(t *testing.T) {
+	var none *EventInfo
+	if !none.IsEmpty() || !(&EventInfo{Policies: &EventPolicies{}}).IsEmpty() {
+		t.Error("expected events without FAQs or policies to have no info")
+	}
+	if (&EventInfo{Policies: &EventPolicies{MinimumAge: 18}}).IsEmpty() {
+		t.Error("expected an age restriction to be shown")
+	}
+	if (&EventInfo{FAQs: []*EventFAQ{{Question: "Parking?", Answer: "Yes"}}}).IsEmpty() {
+		t.Error("expected FAQs to be shown")
+	}
+}
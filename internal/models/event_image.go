@@ -0,0 +1,124 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Event image variant names, each cropped to the shape the pages show it at
+const (
+	ImageVariantThumbnail = "thumbnail"
+	ImageVariantCard      = "card"
+	ImageVariantHero      = "hero"
+	ImageVariantOG        = "og"
+)
+
+// ImageFallbackFormat is the format every variant is available in, for
+// browsers and crawlers without WebP or AVIF support
+const ImageFallbackFormat = "jpeg"
+
+// EventImageVariant is one stored size and format of an event's image
+type EventImageVariant struct {
+	Name   string `json:"name"`
+	Format string `json:"format"`
+	Width  int    `json:"width"`
+	Height int    `json:"height"`
+	Key    string `json:"key"`
+	URL    string `json:"url"`
+}
+
+// EventImageVariants lists the stored variants of an event's image. It is
+// kept in the events table as JSON.
+type EventImageVariants []EventImageVariant
+
+// Value stores the variants as JSON
+func (v EventImageVariants) Value() (driver.Value, error) {
+	if v == nil {
+		return "[]", nil
+	}
+	data, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan reads the variants from their JSON column
+func (v *EventImageVariants) Scan(value interface{}) error {
+	var data []byte
+	switch value := value.(type) {
+	case nil:
+		*v = nil
+		return nil
+	case []byte:
+		data = value
+	case string:
+		data = []byte(value)
+	default:
+		return fmt.Errorf("cannot scan %T into image variants", value)
+	}
+	return json.Unmarshal(data, v)
+}
+
+// of returns the named variant in the format, smallest first
+func (v EventImageVariants) of(name, format string) []EventImageVariant {
+	var matches []EventImageVariant
+	for _, variant := range v {
+		if variant.Name == name && variant.Format == format {
+			matches = append(matches, variant)
+		}
+	}
+	sort.Slice(matches, func(i, j int) bool { return matches[i].Width < matches[j].Width })
+	return matches
+}
+
+// Has reports whether the named variant was generated
+func (v EventImageVariants) Has(name string) bool {
+	return len(v.of(name, ImageFallbackFormat)) > 0
+}
+
+// Largest returns the widest fallback copy of the named variant
+func (v EventImageVariants) Largest(name string) (EventImageVariant, bool) {
+	matches := v.of(name, ImageFallbackFormat)
+	if len(matches) == 0 {
+		return EventImageVariant{}, false
+	}
+	return matches[len(matches)-1], true
+}
+
+// Srcset returns the srcset listing each width of the named variant in the
+// format, such as "a-400.webp 400w, a-800.webp 800w"
+func (v EventImageVariants) Srcset(name, format string) string {
+	matches := v.of(name, format)
+	candidates := make([]string, len(matches))
+	for i, variant := range matches {
+		candidates[i] = variant.URL + " " + strconv.Itoa(variant.Width) + "w"
+	}
+	return strings.Join(candidates, ", ")
+}
+
+// ModernFormats returns the formats besides the fallback the named variant is
+// available in, most compact first, for <picture> sources
+func (v EventImageVariants) ModernFormats(name string) []string {
+	var formats []string
+	for _, format := range []string{"avif", "webp"} {
+		if len(v.of(name, format)) > 0 {
+			formats = append(formats, format)
+		}
+	}
+	return formats
+}
+
+// ImageVariantURL returns the URL of the widest fallback copy of the named
+// image variant, or the image URL for images uploaded before variants were
+// generated
+func (e *Event) ImageVariantURL(name string) string {
+	if variant, ok := e.ImageVariants.Largest(name); ok {
+		return variant.URL
+	}
+	return e.ImageURL
+}
@@ -0,0 +1,72 @@
+package models
+
+import "testing"
+
+func testImageVariants() EventImageVariants {
+	return EventImageVariants{
+		{Name: ImageVariantCard, Format: "jpeg", Width: 800, Height: 450, URL: "/card-800.jpg"},
+		{Name: ImageVariantCard, Format: "jpeg", Width: 400, Height: 225, URL: "/card-400.jpg"},
+		{Name: ImageVariantCard, Format: "webp", Width: 400, Height: 225, URL: "/card-400.webp"},
+		{Name: ImageVariantOG, Format: "jpeg", Width: 1200, Height: 630, URL: "/og-1200.jpg"},
+	}
+}
+
+func TestEventImageVariants_Srcset(t *testing.T) {
+	variants := testImageVariants()
+
+	if got := variants.Srcset(ImageVariantCard, "jpeg"); got != "/card-400.jpg 400w, /card-800.jpg 800w" {
+		t.Errorf("unexpected jpeg srcset %q", got)
+	}
+	if got := variants.Srcset(ImageVariantCard, "webp"); got != "/card-400.webp 400w" {
+		t.Errorf("unexpected webp srcset %q", got)
+	}
+	if got := variants.Srcset(ImageVariantHero, "jpeg"); got != "" {
+		t.Errorf("expected no srcset for a missing variant, got %q", got)
+	}
+}
+
+func TestEventImageVariants_Largest(t *testing.T) {
+	variants := testImageVariants()
+
+	if variant, ok := variants.Largest(ImageVariantCard); !ok || variant.Width != 800 {
+		t.Errorf("expected the 800px card, got %+v", variant)
+	}
+	if variants.Has(ImageVariantHero) {
+		t.Error("expected no hero variant")
+	}
+	if formats := variants.ModernFormats(ImageVariantCard); len(formats) != 1 || formats[0] != "webp" {
+		t.Errorf("expected webp only, got %v", formats)
+	}
+	if formats := variants.ModernFormats(ImageVariantOG); len(formats) != 0 {
+		t.Errorf("expected no modern formats for og, got %v", formats)
+	}
+}
+
+func TestEventImageVariants_ValueScan(t *testing.T) {
+	value, err := EventImageVariants(nil).Value()
+	if err != nil || value != "[]" {
+		t.Fatalf("expected an empty JSON list, got %v, %v", value, err)
+	}
+
+	value, err = testImageVariants().Value()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var scanned EventImageVariants
+	if err := scanned.Scan([]byte(value.(string))); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(scanned) != 4 || scanned[3].URL != "/og-1200.jpg" {
+		t.Errorf("unexpected variants %+v", scanned)
+	}
+}
+
+func TestEvent_ImageVariantURL(t *testing.T) {
+	event := Event{ImageURL: "/uploads/events/a.jpg", ImageVariants: testImageVariants()}
+	if got := event.ImageVariantURL(ImageVariantOG); got != "/og-1200.jpg" {
+		t.Errorf("expected the og variant, got %q", got)
+	}
+	if got := event.ImageVariantURL(ImageVariantHero); got != event.ImageURL {
+		t.Errorf("expected the image URL without a hero variant, got %q", got)
+	}
+}
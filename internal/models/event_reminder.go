@@ -0,0 +1,103 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// ReminderType identifies when an attendee reminder is sent, relative to the event start
+type ReminderType string
+
+const (
+	ReminderSevenDays ReminderType = "7d"
+	ReminderOneDay    ReminderType = "24h"
+	ReminderTwoHours  ReminderType = "2h"
+)
+
+// ReminderTypes lists the reminders from the earliest to the latest
+var ReminderTypes = []ReminderType{ReminderSevenDays, ReminderOneDay, ReminderTwoHours}
+
+// MaxReminderMessageLength is the longest custom message organizers can add to reminders
+const MaxReminderMessageLength = 1000
+
+// Before returns how long before the event start the reminder is due
+func (t ReminderType) Before() time.Duration {
+	switch t {
+	case ReminderSevenDays:
+		return 7 * 24 * time.Hour
+	case ReminderOneDay:
+		return 24 * time.Hour
+	case ReminderTwoHours:
+		return 2 * time.Hour
+	}
+	return 0
+}
+
+// Label returns the reminder's lead time in words
+func (t ReminderType) Label() string {
+	switch t {
+	case ReminderSevenDays:
+		return "7 days"
+	case ReminderOneDay:
+		return "24 hours"
+	case ReminderTwoHours:
+		return "2 hours"
+	}
+	return string(t)
+}
+
+// EventReminderSettings holds an organizer's attendee reminder settings for an event
+type EventReminderSettings struct {
+	EventID   int       `json:"event_id" db:"event_id"`
+	Enabled   bool      `json:"enabled" db:"enabled"`
+	SevenDays bool      `json:"remind_7d" db:"remind_7d"`
+	OneDay    bool      `json:"remind_24h" db:"remind_24h"`
+	TwoHours  bool      `json:"remind_2h" db:"remind_2h"`
+	Message   string    `json:"message" db:"message"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// DefaultEventReminderSettings returns the settings used until an organizer changes them
+func DefaultEventReminderSettings(eventID int) *EventReminderSettings {
+	return &EventReminderSettings{
+		EventID:   eventID,
+		Enabled:   true,
+		SevenDays: true,
+		OneDay:    true,
+		TwoHours:  true,
+	}
+}
+
+// Wants returns true if the given reminder should be sent for the event
+func (s *EventReminderSettings) Wants(reminder ReminderType) bool {
+	if !s.Enabled {
+		return false
+	}
+	switch reminder {
+	case ReminderSevenDays:
+		return s.SevenDays
+	case ReminderOneDay:
+		return s.OneDay
+	case ReminderTwoHours:
+		return s.TwoHours
+	}
+	return false
+}
+
+// Validate validates the reminder settings
+func (s *EventReminderSettings) Validate() error {
+	if len(s.Message) > MaxReminderMessageLength {
+		return errors.New("reminder message must be 1000 characters or less")
+	}
+	return nil
+}
+
+// ReminderRecipient is a ticket holder who is due an event reminder
+type ReminderRecipient struct {
+	OrderID     int    `json:"order_id"`
+	OrderNumber string `json:"order_number"`
+	Email       string `json:"email"`
+	Name        string `json:"name"`
+	TicketCount int    `json:"ticket_count"`
+	Locale      string `json:"locale"` // Language chosen at checkout, else the buyer's or the event's
+}
@@ -0,0 +1,30 @@
+package models
+
+import "testing"
+
+func TestEventReminderSettings_Wants(t *testing.T) {
+	settings := DefaultEventReminderSettings(1)
+	for _, reminder := range ReminderTypes {
+		if !settings.Wants(reminder) {
+			t.Errorf("expected default settings to want the %s reminder", reminder)
+		}
+	}
+
+	settings.OneDay = false
+	if settings.Wants(ReminderOneDay) {
+		t.Error("expected disabled 24 hour toggle to be respected")
+	}
+
+	settings.Enabled = false
+	if settings.Wants(ReminderSevenDays) {
+		t.Error("expected disabled reminders to turn off every reminder")
+	}
+}
+
+func TestReminderType_Before(t *testing.T) {
+	for i := 1; i < len(ReminderTypes); i++ {
+		if ReminderTypes[i].Before() >= ReminderTypes[i-1].Before() {
+			t.Errorf("expected %s reminder to come after %s", ReminderTypes[i], ReminderTypes[i-1])
+		}
+	}
+}
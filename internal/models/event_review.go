@@ -0,0 +1,89 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// ReviewDecision is what a comment in an event's review records
+type ReviewDecision string
+
+const (
+	ReviewComment          ReviewDecision = "comment"
+	ReviewChangesRequested ReviewDecision = "changes_requested"
+	ReviewApproved         ReviewDecision = "approved"
+	ReviewRejected         ReviewDecision = "rejected"
+)
+
+// MaxReviewCommentLength is the longest review comment that can be left
+const MaxReviewCommentLength = 2000
+
+// EventReviewComment is a comment or decision left while reviewing an event.
+// Organizers see every comment on their events.
+type EventReviewComment struct {
+	ID        int            `json:"id" db:"id"`
+	EventID   int            `json:"event_id" db:"event_id"`
+	AuthorID  int            `json:"author_id" db:"author_id"`
+	Decision  ReviewDecision `json:"decision" db:"decision"`
+	Body      string         `json:"body" db:"body"`
+	CreatedAt time.Time      `json:"created_at" db:"created_at"`
+
+	// Related data
+	AuthorName string   `json:"author_name,omitempty"`
+	AuthorRole UserRole `json:"author_role,omitempty"`
+}
+
+// Validate validates the review comment. Only approvals can be left without
+// an explanation.
+func (c *EventReviewComment) Validate() error {
+	c.Body = strings.TrimSpace(c.Body)
+	switch c.Decision {
+	case ReviewComment, ReviewChangesRequested, ReviewRejected:
+		if c.Body == "" {
+			return errors.New("a comment is required")
+		}
+	case ReviewApproved:
+	default:
+		return errors.New("invalid review decision")
+	}
+	if len(c.Body) > MaxReviewCommentLength {
+		return errors.New("comment must be 2000 characters or less")
+	}
+	return nil
+}
+
+// IsDecision returns true if the comment approved, rejected or requested
+// changes to the event
+func (c *EventReviewComment) IsDecision() bool {
+	return c.Decision != ReviewComment
+}
+
+// EventReviewAssignment is the moderator responsible for reviewing an event
+type EventReviewAssignment struct {
+	EventID    int       `json:"event_id" db:"event_id"`
+	ReviewerID int       `json:"reviewer_id" db:"reviewer_id"`
+	AssignedAt time.Time `json:"assigned_at" db:"assigned_at"`
+
+	// Related data
+	ReviewerName string `json:"reviewer_name,omitempty"`
+}
+
+// NextReviewer picks the reviewer after the one assigned most recently, in
+// order of ID, so reviews are shared round-robin. It returns 0 when there are
+// no reviewers.
+func NextReviewer(reviewerIDs []int, lastReviewerID int) int {
+	first, next := 0, 0
+	for _, id := range reviewerIDs {
+		if first == 0 || id < first {
+			first = id
+		}
+		if id > lastReviewerID && (next == 0 || id < next) {
+			next = id
+		}
+	}
+	if next == 0 {
+		return first
+	}
+	return next
+}
@@ -0,0 +1,56 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEventReviewComment_Validate(t *testing.T) {
+	comment := &EventReviewComment{Decision: ReviewChangesRequested, Body: "  Add a venue address  "}
+	if err := comment.Validate(); err != nil {
+		t.Fatalf("expected valid comment, got %v", err)
+	}
+	if comment.Body != "Add a venue address" {
+		t.Errorf("expected trimmed body, got %q", comment.Body)
+	}
+
+	if err := (&EventReviewComment{Decision: ReviewApproved}).Validate(); err != nil {
+		t.Errorf("expected approvals not to need a comment, got %v", err)
+	}
+	for _, decision := range []ReviewDecision{ReviewComment, ReviewChangesRequested, ReviewRejected} {
+		if err := (&EventReviewComment{Decision: decision, Body: " "}).Validate(); err == nil {
+			t.Errorf("expected %s without a comment to be refused", decision)
+		}
+	}
+	if err := (&EventReviewComment{Decision: "escalated", Body: "x"}).Validate(); err == nil {
+		t.Error("expected unknown decisions to be refused")
+	}
+	if err := (&EventReviewComment{Decision: ReviewComment, Body: strings.Repeat("a", MaxReviewCommentLength+1)}).Validate(); err == nil {
+		t.Error("expected long comments to be refused")
+	}
+}
+
+func TestNextReviewer(t *testing.T) {
+	reviewers := []int{9, 3, 5}
+
+	tests := []struct {
+		last int
+		want int
+	}{
+		{0, 3},
+		{3, 5},
+		{5, 9},
+		{9, 3},  // Wraps around
+		{4, 5},  // Last reviewer is no longer a moderator
+		{12, 3}, // Last reviewer is after every moderator
+	}
+	for _, tt := range tests {
+		if got := NextReviewer(reviewers, tt.last); got != tt.want {
+			t.Errorf("NextReviewer(%v, %d) = %d, want %d", reviewers, tt.last, got, tt.want)
+		}
+	}
+
+	if got := NextReviewer(nil, 3); got != 0 {
+		t.Errorf("expected no reviewer without moderators, got %d", got)
+	}
+}
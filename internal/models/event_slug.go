@@ -0,0 +1,53 @@
+package models
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// MaxEventSlugLength is the longest slug generated from an event title
+const MaxEventSlugLength = 80
+
+// EventSlug turns an event title into the slug used in its public URL
+// ("Nairobi Jazz Night!" -> "nairobi-jazz-night"). Slugs always start with a
+// letter so they never look like a numeric event ID. It must stay in sync
+// with the backfill in the add_event_slugs migration.
+func EventSlug(title string) string {
+	slug := citySlugInvalidChars.ReplaceAllString(strings.ToLower(title), "-")
+	slug = strings.Trim(slug, "-")
+	if len(slug) > MaxEventSlugLength {
+		slug = strings.Trim(slug[:MaxEventSlugLength], "-")
+	}
+
+	switch {
+	case slug == "":
+		return "event"
+	case slug[0] >= '0' && slug[0] <= '9':
+		return "event-" + slug
+	default:
+		return slug
+	}
+}
+
+// UniqueEventSlug returns base, or base with the lowest numeric suffix that
+// is not taken ("jazz-night-2")
+func UniqueEventSlug(base string, taken map[string]bool) string {
+	if !taken[base] {
+		return base
+	}
+	for i := 2; ; i++ {
+		slug := base + "-" + strconv.Itoa(i)
+		if !taken[slug] {
+			return slug
+		}
+	}
+}
+
+// Path returns the event's public URL path, by slug when it has one
+func (e *Event) Path() string {
+	if e.Slug != "" {
+		return "/events/" + e.Slug
+	}
+	return fmt.Sprintf("/events/%d", e.ID)
+}
@@ -0,0 +1,53 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEventSlug(t *testing.T) {
+	tests := []struct {
+		title string
+		slug  string
+	}{
+		{"Nairobi Jazz Night!", "nairobi-jazz-night"},
+		{"  Tech --- Meetup  ", "tech-meetup"},
+		{"2025 Marathon", "event-2025-marathon"},
+		{"Café & Co", "caf-co"},
+		{"!!!", "event"},
+		{"", "event"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.title, func(t *testing.T) {
+			if got := EventSlug(tt.title); got != tt.slug {
+				t.Errorf("EventSlug(%q) = %q, want %q", tt.title, got, tt.slug)
+			}
+		})
+	}
+
+	long := EventSlug(strings.Repeat("festival ", 20))
+	if len(long) > MaxEventSlugLength || strings.HasSuffix(long, "-") {
+		t.Errorf("expected long slugs to be truncated cleanly, got %q", long)
+	}
+}
+
+func TestUniqueEventSlug(t *testing.T) {
+	if got := UniqueEventSlug("jazz-night", map[string]bool{}); got != "jazz-night" {
+		t.Errorf("expected the base slug when it is free, got %q", got)
+	}
+
+	taken := map[string]bool{"jazz-night": true, "jazz-night-2": true}
+	if got := UniqueEventSlug("jazz-night", taken); got != "jazz-night-3" {
+		t.Errorf("expected jazz-night-3, got %q", got)
+	}
+}
+
+func TestEventPath(t *testing.T) {
+	if got := (&Event{ID: 42, Slug: "jazz-night"}).Path(); got != "/events/jazz-night" {
+		t.Errorf("expected the slug URL, got %q", got)
+	}
+	if got := (&Event{ID: 42}).Path(); got != "/events/42" {
+		t.Errorf("expected the ID URL for events without a slug, got %q", got)
+	}
+}
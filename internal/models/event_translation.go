@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+)
+
+// EventTranslation is an event's title and description in another language
+type EventTranslation struct {
+	EventID     int       `json:"event_id" db:"event_id"`
+	Locale      string    `json:"locale" db:"locale"`
+	Title       string    `json:"title" db:"title"`
+	Description string    `json:"description" db:"description"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// EventTranslationRequest represents an organizer's translation of an event
+type EventTranslationRequest struct {
+	EventID     int    `json:"event_id"`
+	Locale      string `json:"locale"`
+	Title       string `json:"title"`
+	Description string `json:"description"`
+}
+
+// Validate validates the translation, using the same rules as the event's
+// own title and description
+func (r *EventTranslationRequest) Validate() error {
+	if err := validateTitle(r.Title); err != nil {
+		return err
+	}
+	return validateDescription(r.Description)
+}
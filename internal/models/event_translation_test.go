@@ -0,0 +1,30 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEventTranslationRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     EventTranslationRequest
+		wantErr bool
+	}{
+		{"valid", EventTranslationRequest{EventID: 1, Locale: "sw", Title: "Usiku wa Jazz", Description: "Muziki mzuri"}, false},
+		{"description optional", EventTranslationRequest{EventID: 1, Locale: "fr", Title: "Soirée Jazz"}, false},
+		{"missing title", EventTranslationRequest{EventID: 1, Locale: "fr", Description: "Bonne musique"}, true},
+		{"blank title", EventTranslationRequest{EventID: 1, Locale: "fr", Title: "   "}, true},
+		{"title too long", EventTranslationRequest{EventID: 1, Locale: "fr", Title: strings.Repeat("a", 256)}, true},
+		{"description too long", EventTranslationRequest{EventID: 1, Locale: "fr", Title: "Soirée Jazz", Description: strings.Repeat("a", 10001)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// EventViewDedupeWindow is how long repeat views of an event by the same
+// visitor count as one view
+const EventViewDedupeWindow = 30 * time.Minute
+
+// EventView is a view of an event's detail page
+type EventView struct {
+	ID           int64     `json:"id" db:"id"`
+	EventID      int       `json:"event_id" db:"event_id"`
+	VisitorID    string    `json:"visitor_id" db:"visitor_id"`
+	UserID       *int      `json:"user_id,omitempty" db:"user_id"`
+	ReferrerHost string    `json:"referrer_host" db:"referrer_host"`
+	ViewedAt     time.Time `json:"viewed_at" db:"viewed_at"`
+}
+
+// Validate checks the view names an event and a visitor
+func (v *EventView) Validate() error {
+	if v.EventID <= 0 {
+		return errors.New("event is required")
+	}
+	if v.VisitorID == "" || len(v.VisitorID) > 64 {
+		return errors.New("invalid visitor")
+	}
+	if len(v.ReferrerHost) > 255 {
+		v.ReferrerHost = v.ReferrerHost[:255]
+	}
+	return nil
+}
+
+// EventViewDay is the views of an event on one day
+type EventViewDay struct {
+	Day      time.Time `json:"day"`
+	Views    int       `json:"views"`
+	Visitors int       `json:"visitors"`
+}
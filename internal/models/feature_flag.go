@@ -0,0 +1,74 @@
+package models
+
+import "time"
+
+// FeatureFlag names a feature admins can switch on or off without a deploy
+type FeatureFlag string
+
+const (
+	// FeatureWaitlist lets buyers join a waitlist for sold-out ticket types
+	FeatureWaitlist FeatureFlag = "enable_waitlist"
+	// FeatureGuestCheckout lets buyers check out without an account
+	FeatureGuestCheckout FeatureFlag = "enable_guest_checkout"
+)
+
+// FeatureFlagDefinition describes a feature flag and whether the feature is
+// on until an admin switches it
+type FeatureFlagDefinition struct {
+	Flag        FeatureFlag
+	Label       string
+	Description string
+	Default     bool
+}
+
+// FeatureFlags lists every feature flag in the order they are shown
+var FeatureFlags = []FeatureFlagDefinition{
+	{Flag: FeatureWaitlist, Label: "Waitlists", Description: "Buyers can join a waitlist when a ticket type sells out"},
+	{Flag: FeatureGuestCheckout, Label: "Guest Checkout", Description: "Buyers can check out with just an email address, without creating an account"},
+}
+
+// LookupFeatureFlag returns the definition of a feature flag
+func LookupFeatureFlag(flag FeatureFlag) (FeatureFlagDefinition, bool) {
+	for _, definition := range FeatureFlags {
+		if definition.Flag == flag {
+			return definition, true
+		}
+	}
+	return FeatureFlagDefinition{}, false
+}
+
+// FeatureFlagOverride is an admin's choice to switch a feature on or off
+type FeatureFlagOverride struct {
+	Flag      FeatureFlag `json:"flag" db:"flag"`
+	Enabled   bool        `json:"enabled" db:"enabled"`
+	UpdatedBy *int        `json:"updated_by,omitempty" db:"updated_by"`
+	UpdatedAt time.Time   `json:"updated_at" db:"updated_at"`
+}
+
+// FeatureFlagState is a feature flag and whether its feature is on
+type FeatureFlagState struct {
+	FeatureFlagDefinition
+	Enabled bool
+	// Override is the admin's choice, or nil while the flag has its default
+	Override *FeatureFlagOverride
+}
+
+// ResolveFeatureFlags returns the state of every feature flag given the
+// overrides admins have made
+func ResolveFeatureFlags(overrides []*FeatureFlagOverride) []*FeatureFlagState {
+	byFlag := make(map[FeatureFlag]*FeatureFlagOverride, len(overrides))
+	for _, override := range overrides {
+		byFlag[override.Flag] = override
+	}
+
+	states := make([]*FeatureFlagState, 0, len(FeatureFlags))
+	for _, definition := range FeatureFlags {
+		state := &FeatureFlagState{FeatureFlagDefinition: definition, Enabled: definition.Default}
+		if override, ok := byFlag[definition.Flag]; ok {
+			state.Enabled = override.Enabled
+			state.Override = override
+		}
+		states = append(states, state)
+	}
+	return states
+}
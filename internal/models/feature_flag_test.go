@@ -0,0 +1,30 @@
+package models
+
+import "testing"
+
+func TestResolveFeatureFlags(t *testing.T) {
+	states := ResolveFeatureFlags([]*FeatureFlagOverride{
+		{Flag: FeatureGuestCheckout, Enabled: true},
+		{Flag: "enable_retired_feature", Enabled: true},
+	})
+	if len(states) != len(FeatureFlags) {
+		t.Fatalf("expected a state for each feature flag, got %d", len(states))
+	}
+
+	for _, state := range states {
+		switch state.Flag {
+		case FeatureGuestCheckout:
+			if !state.Enabled || state.Override == nil {
+				t.Errorf("expected guest checkout switched on by an admin, got %+v", state)
+			}
+		case FeatureWaitlist:
+			if state.Enabled != state.Default || state.Override != nil {
+				t.Errorf("expected waitlists to have their default, got %+v", state)
+			}
+		}
+	}
+
+	if _, ok := LookupFeatureFlag("enable_retired_feature"); ok {
+		t.Error("expected an unknown flag not to be found")
+	}
+}
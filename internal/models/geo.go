@@ -0,0 +1,102 @@
+package models
+
+import (
+	"math"
+	"strconv"
+	"strings"
+)
+
+// EarthRadiusKm is the mean radius of the Earth used for distances
+const EarthRadiusKm = 6371.0
+
+// "Near me" search radius limits, in km
+const (
+	DefaultSearchRadiusKm = 25
+	MaxSearchRadiusKm     = 200
+)
+
+// SearchRadiusOptions are the radiuses offered for "near me" searches, in km
+var SearchRadiusOptions = []int{5, 10, 25, 50, 100}
+
+// GeoPoint is a position on the Earth in decimal degrees
+type GeoPoint struct {
+	Latitude  float64 `json:"lat"`
+	Longitude float64 `json:"lng"`
+}
+
+// Valid returns true if the point's latitude and longitude are in range
+func (p GeoPoint) Valid() bool {
+	return p.Latitude >= -90 && p.Latitude <= 90 && p.Longitude >= -180 && p.Longitude <= 180 &&
+		!math.IsNaN(p.Latitude) && !math.IsNaN(p.Longitude)
+}
+
+// DistanceKm returns the great-circle distance to another point, using the
+// haversine formula
+func (p GeoPoint) DistanceKm(to GeoPoint) float64 {
+	lat1 := p.Latitude * math.Pi / 180
+	lat2 := to.Latitude * math.Pi / 180
+	dLat := lat2 - lat1
+	dLng := (to.Longitude - p.Longitude) * math.Pi / 180
+
+	a := math.Pow(math.Sin(dLat/2), 2) + math.Cos(lat1)*math.Cos(lat2)*math.Pow(math.Sin(dLng/2), 2)
+	return 2 * EarthRadiusKm * math.Asin(math.Min(1, math.Sqrt(a)))
+}
+
+// BoundingBox returns the latitude and longitude ranges containing every
+// point within radiusKm, used to narrow a radius search down before
+// distances are calculated. The longitude range is unbounded (-180 to 180)
+// near the poles and when the box would cross the antimeridian.
+func (p GeoPoint) BoundingBox(radiusKm float64) (minLat, maxLat, minLng, maxLng float64) {
+	latDelta := radiusKm / EarthRadiusKm * 180 / math.Pi
+	minLat = math.Max(-90, p.Latitude-latDelta)
+	maxLat = math.Min(90, p.Latitude+latDelta)
+
+	cosLat := math.Cos(p.Latitude * math.Pi / 180)
+	if maxLat >= 90 || minLat <= -90 || cosLat <= 0 {
+		return minLat, maxLat, -180, 180
+	}
+	lngDelta := latDelta / cosLat
+	minLng = p.Longitude - lngDelta
+	maxLng = p.Longitude + lngDelta
+	if minLng < -180 || maxLng > 180 {
+		return minLat, maxLat, -180, 180
+	}
+	return minLat, maxLat, minLng, maxLng
+}
+
+// ParseGeoPoint parses a latitude and longitude from query parameters,
+// returning nil if either is missing, malformed or out of range
+func ParseGeoPoint(lat, lng string) *GeoPoint {
+	latitude, err := strconv.ParseFloat(strings.TrimSpace(lat), 64)
+	if err != nil {
+		return nil
+	}
+	longitude, err := strconv.ParseFloat(strings.TrimSpace(lng), 64)
+	if err != nil {
+		return nil
+	}
+
+	point := GeoPoint{Latitude: latitude, Longitude: longitude}
+	if !point.Valid() {
+		return nil
+	}
+	return &point
+}
+
+// ClampSearchRadius returns the radius to search within for a requested
+// radius in km, using the default when none was given
+func ClampSearchRadius(radiusKm int) int {
+	if radiusKm <= 0 {
+		return DefaultSearchRadiusKm
+	}
+	if radiusKm > MaxSearchRadiusKm {
+		return MaxSearchRadiusKm
+	}
+	return radiusKm
+}
+
+// EventLocation is an event's free-text location waiting to be geocoded
+type EventLocation struct {
+	EventID  int    `json:"event_id" db:"id"`
+	Location string `json:"location" db:"location"`
+}
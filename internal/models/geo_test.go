@@ -0,0 +1,86 @@
+package models
+
+import (
+	"math"
+	"testing"
+)
+
+func TestGeoPointDistanceKm(t *testing.T) {
+	nairobi := GeoPoint{Latitude: -1.2864, Longitude: 36.8172}
+	mombasa := GeoPoint{Latitude: -4.0435, Longitude: 39.6682}
+
+	if got := nairobi.DistanceKm(nairobi); got != 0 {
+		t.Errorf("DistanceKm to itself = %v, want 0", got)
+	}
+	// Nairobi to Mombasa is about 440 km as the crow flies
+	if got := nairobi.DistanceKm(mombasa); math.Abs(got-440) > 5 {
+		t.Errorf("DistanceKm(Nairobi, Mombasa) = %v, want about 440", got)
+	}
+	if a, b := nairobi.DistanceKm(mombasa), mombasa.DistanceKm(nairobi); math.Abs(a-b) > 1e-9 {
+		t.Errorf("DistanceKm is not symmetric: %v and %v", a, b)
+	}
+}
+
+func TestGeoPointBoundingBox(t *testing.T) {
+	nairobi := GeoPoint{Latitude: -1.2864, Longitude: 36.8172}
+	minLat, maxLat, minLng, maxLng := nairobi.BoundingBox(25)
+
+	// Points on the edge of the radius in each direction must be in the box
+	for _, bearing := range []float64{0, 90, 180, 270} {
+		edge := offset(nairobi, 24.9, bearing)
+		if edge.Latitude < minLat || edge.Latitude > maxLat || edge.Longitude < minLng || edge.Longitude > maxLng {
+			t.Errorf("point %v at bearing %v is outside the box", edge, bearing)
+		}
+	}
+	if maxLat-minLat > 0.5 || maxLng-minLng > 0.5 {
+		t.Errorf("box is too large: %v..%v, %v..%v", minLat, maxLat, minLng, maxLng)
+	}
+
+	// Near the antimeridian the longitude range is unbounded
+	if _, _, minLng, maxLng := (GeoPoint{Latitude: 0, Longitude: 179.9}).BoundingBox(50); minLng != -180 || maxLng != 180 {
+		t.Errorf("antimeridian box longitudes = %v..%v, want -180..180", minLng, maxLng)
+	}
+}
+
+// offset returns the point distanceKm from p at a bearing in degrees
+func offset(p GeoPoint, distanceKm, bearing float64) GeoPoint {
+	d := distanceKm / EarthRadiusKm
+	b := bearing * math.Pi / 180
+	lat1 := p.Latitude * math.Pi / 180
+	lng1 := p.Longitude * math.Pi / 180
+
+	lat2 := math.Asin(math.Sin(lat1)*math.Cos(d) + math.Cos(lat1)*math.Sin(d)*math.Cos(b))
+	lng2 := lng1 + math.Atan2(math.Sin(b)*math.Sin(d)*math.Cos(lat1), math.Cos(d)-math.Sin(lat1)*math.Sin(lat2))
+	return GeoPoint{Latitude: lat2 * 180 / math.Pi, Longitude: lng2 * 180 / math.Pi}
+}
+
+func TestParseGeoPoint(t *testing.T) {
+	tests := []struct {
+		lat, lng string
+		valid    bool
+	}{
+		{"-1.2864", "36.8172", true},
+		{" -1.2864 ", "36.8172", true},
+		{"", "36.8172", false},
+		{"-1.2864", "east", false},
+		{"91", "36.8172", false},
+		{"-1.2864", "181", false},
+		{"NaN", "36.8172", false},
+	}
+
+	for _, tt := range tests {
+		point := ParseGeoPoint(tt.lat, tt.lng)
+		if (point != nil) != tt.valid {
+			t.Errorf("ParseGeoPoint(%q, %q) = %v, want valid %v", tt.lat, tt.lng, point, tt.valid)
+		}
+	}
+}
+
+func TestClampSearchRadius(t *testing.T) {
+	tests := map[int]int{0: DefaultSearchRadiusKm, -5: DefaultSearchRadiusKm, 10: 10, 1000: MaxSearchRadiusKm}
+	for radius, want := range tests {
+		if got := ClampSearchRadius(radius); got != want {
+			t.Errorf("ClampSearchRadius(%d) = %d, want %d", radius, got, want)
+		}
+	}
+}
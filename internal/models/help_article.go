@@ -0,0 +1,212 @@
+package models
+
+import (
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// HelpCategory groups help articles on the help center
+type HelpCategory string
+
+const (
+	HelpGettingStarted HelpCategory = "getting_started"
+	HelpBuyingTickets  HelpCategory = "buying_tickets"
+	HelpOrganizing     HelpCategory = "organizing"
+	HelpPayments       HelpCategory = "payments"
+	HelpAccount        HelpCategory = "account"
+)
+
+// HelpCategories lists the categories in the order the help center shows them
+var HelpCategories = []HelpCategory{HelpGettingStarted, HelpBuyingTickets, HelpOrganizing, HelpPayments, HelpAccount}
+
+// DisplayName returns a human-readable category name
+func (c HelpCategory) DisplayName() string {
+	switch c {
+	case HelpGettingStarted:
+		return "Getting Started"
+	case HelpBuyingTickets:
+		return "Buying Tickets"
+	case HelpOrganizing:
+		return "Organizing Events"
+	case HelpPayments:
+		return "Payments & Payouts"
+	case HelpAccount:
+		return "Account & Security"
+	default:
+		return string(c)
+	}
+}
+
+// Description returns what the category's articles cover
+func (c HelpCategory) Description() string {
+	switch c {
+	case HelpGettingStarted:
+		return "New to Runtown? Start here."
+	case HelpBuyingTickets:
+		return "Finding events, checking out, your tickets and refunds."
+	case HelpOrganizing:
+		return "Creating events, ticket types, promotion and check-in."
+	case HelpPayments:
+		return "Fees, your balance and withdrawing your earnings."
+	case HelpAccount:
+		return "Signing in, your profile, privacy and keeping your account safe."
+	default:
+		return ""
+	}
+}
+
+// HelpArticleStatus represents whether a help article is public
+type HelpArticleStatus string
+
+const (
+	HelpArticleDraft     HelpArticleStatus = "draft"
+	HelpArticlePublished HelpArticleStatus = "published"
+)
+
+// Slugs of the help articles linked from dashboard pages. The
+// create_help_articles migration publishes a first version of each.
+const (
+	HelpTopicCreatingEvents = "creating-an-event"
+	HelpTopicTicketTypes    = "setting-up-ticket-types"
+	HelpTopicGettingPaid    = "getting-paid"
+	HelpTopicYourTickets    = "finding-your-tickets"
+	HelpTopicRefunds        = "refunds"
+)
+
+// Help article limits
+const (
+	MaxHelpSlugLength    = 80
+	MaxHelpTitleLength   = 200
+	MaxHelpSummaryLength = 300
+	MaxHelpBodyLength    = 20000
+)
+
+// helpSlugPattern matches lowercase words joined by hyphens
+var helpSlugPattern = regexp.MustCompile(`^[a-z0-9]+(-[a-z0-9]+)*$`)
+
+// HelpArticle is a help center article written by admins. Its body is
+// Markdown.
+type HelpArticle struct {
+	ID          int               `json:"id" db:"id"`
+	Slug        string            `json:"slug" db:"slug"`
+	Title       string            `json:"title" db:"title"`
+	Summary     string            `json:"summary" db:"summary"`
+	Body        string            `json:"body" db:"body"`
+	Category    HelpCategory      `json:"category" db:"category"`
+	Status      HelpArticleStatus `json:"status" db:"status"`
+	UpdatedBy   *int              `json:"updated_by,omitempty" db:"updated_by"`
+	CreatedAt   time.Time         `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time         `json:"updated_at" db:"updated_at"`
+	PublishedAt *time.Time        `json:"published_at,omitempty" db:"published_at"`
+}
+
+// Path returns the article's URL path on the help center
+func (a *HelpArticle) Path() string {
+	return "/help/" + a.Slug
+}
+
+// IsPublished returns true if the article is on the public help center
+func (a *HelpArticle) IsPublished() bool {
+	return a.Status == HelpArticlePublished
+}
+
+// HelpSection is a category of the help center with its published articles
+type HelpSection struct {
+	Category HelpCategory   `json:"category"`
+	Articles []*HelpArticle `json:"articles"`
+}
+
+// GroupHelpArticles groups articles into sections in HelpCategories order,
+// leaving out categories without any
+func GroupHelpArticles(articles []*HelpArticle) []*HelpSection {
+	byCategory := make(map[HelpCategory][]*HelpArticle)
+	for _, article := range articles {
+		byCategory[article.Category] = append(byCategory[article.Category], article)
+	}
+
+	var sections []*HelpSection
+	for _, category := range HelpCategories {
+		if len(byCategory[category]) > 0 {
+			sections = append(sections, &HelpSection{Category: category, Articles: byCategory[category]})
+		}
+	}
+	return sections
+}
+
+// HelpArticleFilter narrows the admin list of help articles. Zero values
+// match every article.
+type HelpArticleFilter struct {
+	Category HelpCategory
+	Status   HelpArticleStatus
+}
+
+// HelpArticleRequest represents a request to write or change a help article
+type HelpArticleRequest struct {
+	Slug     string            `json:"slug"`
+	Title    string            `json:"title"`
+	Summary  string            `json:"summary"`
+	Body     string            `json:"body"`
+	Category HelpCategory      `json:"category"`
+	Status   HelpArticleStatus `json:"status"`
+}
+
+// Validate validates the help article request. An empty slug is made from
+// the title.
+func (r *HelpArticleRequest) Validate() error {
+	r.Title = strings.TrimSpace(r.Title)
+	r.Summary = strings.TrimSpace(r.Summary)
+	r.Body = strings.TrimSpace(r.Body)
+	r.Slug = strings.ToLower(strings.TrimSpace(r.Slug))
+
+	if r.Title == "" {
+		return errors.New("title is required")
+	}
+	if len(r.Title) > MaxHelpTitleLength {
+		return errors.New("title must be 200 characters or fewer")
+	}
+	if r.Slug == "" {
+		r.Slug = HelpArticleSlug(r.Title)
+	}
+	if len(r.Slug) > MaxHelpSlugLength || !helpSlugPattern.MatchString(r.Slug) {
+		return errors.New("URL slug may only contain lowercase letters, numbers and hyphens")
+	}
+	if len(r.Summary) > MaxHelpSummaryLength {
+		return errors.New("summary must be 300 characters or fewer")
+	}
+	if r.Body == "" {
+		return errors.New("article body is required")
+	}
+	if len(r.Body) > MaxHelpBodyLength {
+		return errors.New("article body must be 20000 characters or fewer")
+	}
+	if !containsHelpCategory(r.Category) {
+		return errors.New("invalid help category")
+	}
+	if r.Status != HelpArticleDraft && r.Status != HelpArticlePublished {
+		return errors.New("invalid article status")
+	}
+	return nil
+}
+
+// HelpArticleSlug turns an article title into its URL slug ("How do
+// refunds work?" -> "how-do-refunds-work")
+func HelpArticleSlug(title string) string {
+	slug := citySlugInvalidChars.ReplaceAllString(strings.ToLower(title), "-")
+	slug = strings.Trim(slug, "-")
+	if len(slug) > MaxHelpSlugLength {
+		slug = strings.Trim(slug[:MaxHelpSlugLength], "-")
+	}
+	return slug
+}
+
+// containsHelpCategory returns true for the known categories
+func containsHelpCategory(category HelpCategory) bool {
+	for _, known := range HelpCategories {
+		if category == known {
+			return true
+		}
+	}
+	return false
+}
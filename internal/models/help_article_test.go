@@ -0,0 +1,76 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestHelpArticleRequest_Validate(t *testing.T) {
+	valid := func() *HelpArticleRequest {
+		return &HelpArticleRequest{
+			Title:    "  How do refunds work?  ",
+			Body:     "Refunds go back to your card.",
+			Category: HelpBuyingTickets,
+			Status:   HelpArticlePublished,
+		}
+	}
+
+	req := valid()
+	if err := req.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if req.Title != "How do refunds work?" {
+		t.Errorf("expected the title trimmed, got %q", req.Title)
+	}
+	if req.Slug != "how-do-refunds-work" {
+		t.Errorf("expected a slug made from the title, got %q", req.Slug)
+	}
+
+	req = valid()
+	req.Slug = " Refunds "
+	if err := req.Validate(); err != nil || req.Slug != "refunds" {
+		t.Errorf("expected the slug given kept in lowercase, got %q (%v)", req.Slug, err)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*HelpArticleRequest)
+	}{
+		{"empty title", func(r *HelpArticleRequest) { r.Title = " " }},
+		{"long title", func(r *HelpArticleRequest) { r.Title = strings.Repeat("a", MaxHelpTitleLength+1) }},
+		{"slug with spaces", func(r *HelpArticleRequest) { r.Slug = "how refunds work" }},
+		{"slug with doubled hyphens", func(r *HelpArticleRequest) { r.Slug = "refunds--policy" }},
+		{"title without letters", func(r *HelpArticleRequest) { r.Title = "???" }},
+		{"empty body", func(r *HelpArticleRequest) { r.Body = "" }},
+		{"long summary", func(r *HelpArticleRequest) { r.Summary = strings.Repeat("a", MaxHelpSummaryLength+1) }},
+		{"unknown category", func(r *HelpArticleRequest) { r.Category = "billing" }},
+		{"unknown status", func(r *HelpArticleRequest) { r.Status = "archived" }},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := valid()
+			tt.modify(req)
+			if err := req.Validate(); err == nil {
+				t.Error("expected a validation error")
+			}
+		})
+	}
+}
+
+func TestGroupHelpArticles(t *testing.T) {
+	sections := GroupHelpArticles([]*HelpArticle{
+		{ID: 1, Category: HelpPayments},
+		{ID: 2, Category: HelpGettingStarted},
+		{ID: 3, Category: HelpPayments},
+	})
+
+	if len(sections) != 2 {
+		t.Fatalf("expected a section for each category with articles, got %d", len(sections))
+	}
+	if sections[0].Category != HelpGettingStarted || sections[1].Category != HelpPayments {
+		t.Errorf("expected sections in HelpCategories order, got %s then %s", sections[0].Category, sections[1].Category)
+	}
+	if len(sections[1].Articles) != 2 || sections[1].Articles[0].ID != 1 {
+		t.Errorf("expected payments articles kept in order, got %+v", sections[1].Articles)
+	}
+}
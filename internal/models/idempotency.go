@@ -0,0 +1,55 @@
+package models
+
+import "time"
+
+// IdempotencyStatus is how far a request sent with an idempotency key got
+type IdempotencyStatus string
+
+const (
+	IdempotencyProcessing IdempotencyStatus = "processing"
+	IdempotencyCompleted  IdempotencyStatus = "completed"
+)
+
+// Idempotency scopes, so keys sent to different endpoints never collide
+const (
+	IdempotencyScopeCheckout        = "checkout"
+	IdempotencyScopePaymentCallback = "payment_callback"
+	IdempotencyScopeAPIPurchase     = "api_purchase"
+)
+
+// MaxIdempotencyKeyLength is the longest idempotency key accepted
+const MaxIdempotencyKeyLength = 255
+
+// IdempotencyRecord is a request made with an idempotency key and, once it
+// completed, the redirect it answered with, which retries are sent to
+// instead of repeating the request
+type IdempotencyRecord struct {
+	Scope            string            `json:"scope" db:"scope"`
+	UserID           int               `json:"user_id" db:"user_id"` // 0 for requests made without signing in
+	Key              string            `json:"key" db:"idempotency_key"`
+	Status           IdempotencyStatus `json:"status" db:"status"`
+	ResponseStatus   int               `json:"response_status" db:"response_status"`
+	ResponseLocation string            `json:"response_location" db:"response_location"`
+	CreatedAt        time.Time         `json:"created_at" db:"created_at"`
+	CompletedAt      *time.Time        `json:"completed_at,omitempty" db:"completed_at"`
+	ExpiresAt        time.Time         `json:"expires_at" db:"expires_at"`
+}
+
+// IsCompleted returns true once the request's result can be replayed
+func (r *IdempotencyRecord) IsCompleted() bool {
+	return r.Status == IdempotencyCompleted
+}
+
+// ValidIdempotencyKey reports whether a client-chosen key may be stored:
+// printable ASCII of at most MaxIdempotencyKeyLength characters
+func ValidIdempotencyKey(key string) bool {
+	if key == "" || len(key) > MaxIdempotencyKeyLength {
+		return false
+	}
+	for _, c := range key {
+		if c < '!' || c > '~' {
+			return false
+		}
+	}
+	return true
+}
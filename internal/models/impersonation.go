@@ -0,0 +1,27 @@
+package models
+
+import "time"
+
+// ImpersonationTimeout is how long an admin can act as another user before
+// the impersonation ends on its own
+const ImpersonationTimeout = time.Hour
+
+// MaxImpersonationReasonLength caps the reason recorded in the audit log
+const MaxImpersonationReasonLength = 200
+
+// Impersonation is an admin acting as another user for support debugging
+type Impersonation struct {
+	Admin     *User     `json:"admin"`
+	User      *User     `json:"user"`
+	StartedAt time.Time `json:"started_at"`
+}
+
+// ExpiresAt returns when the impersonation ends on its own
+func (i *Impersonation) ExpiresAt() time.Time {
+	return i.StartedAt.Add(ImpersonationTimeout)
+}
+
+// IsExpired returns true if the impersonation has run past its timeout
+func (i *Impersonation) IsExpired(now time.Time) bool {
+	return !now.Before(i.ExpiresAt())
+}
@@ -0,0 +1,21 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestImpersonation_IsExpired(t *testing.T) {
+	started := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	impersonation := &Impersonation{StartedAt: started}
+
+	if !impersonation.ExpiresAt().Equal(started.Add(ImpersonationTimeout)) {
+		t.Errorf("expected expiry %v after start, got %v", ImpersonationTimeout, impersonation.ExpiresAt())
+	}
+	if impersonation.IsExpired(started.Add(59 * time.Minute)) {
+		t.Error("expected impersonation to be active before the timeout")
+	}
+	if !impersonation.IsExpired(started.Add(ImpersonationTimeout)) {
+		t.Error("expected impersonation to expire at the timeout")
+	}
+}
@@ -0,0 +1,206 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// Installment plan limits
+const (
+	MinInstallments                = 2
+	MaxInstallments                = 12
+	DefaultInstallments            = 3
+	MinInstallmentIntervalDays     = 7
+	MaxInstallmentIntervalDays     = 31
+	DefaultInstallmentIntervalDays = 30
+)
+
+// InstallmentRetryDelays are how long after each failed charge of an
+// installment it is tried again. Once they run out, the plan defaults.
+var InstallmentRetryDelays = []time.Duration{24 * time.Hour, 3 * 24 * time.Hour, 5 * 24 * time.Hour}
+
+// InstallmentSettings holds an organizer's settings for paying for an event's
+// tickets in installments. While they are enabled, buyers of orders of at
+// least MinOrderAmount can pay a first installment at checkout and have the
+// rest charged to the same card every IntervalDays.
+type InstallmentSettings struct {
+	EventID        int  `json:"event_id" db:"event_id"`
+	Enabled        bool `json:"enabled" db:"enabled"`
+	Installments   int  `json:"installments" db:"installments"`
+	IntervalDays   int  `json:"interval_days" db:"interval_days"`
+	MinOrderAmount int  `json:"min_order_amount" db:"min_order_amount"` // in cents
+	// IssueTicketsUpfront issues the tickets once the first installment is
+	// paid rather than once the order is paid in full
+	IssueTicketsUpfront bool      `json:"issue_tickets_upfront" db:"issue_tickets_upfront"`
+	UpdatedAt           time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// DefaultInstallmentSettings returns the settings used until an organizer
+// enables installments
+func DefaultInstallmentSettings(eventID int) *InstallmentSettings {
+	return &InstallmentSettings{
+		EventID:      eventID,
+		Installments: DefaultInstallments,
+		IntervalDays: DefaultInstallmentIntervalDays,
+	}
+}
+
+// Validate validates the installment settings
+func (s *InstallmentSettings) Validate() error {
+	if s.Installments < MinInstallments || s.Installments > MaxInstallments {
+		return errors.New("number of installments must be between 2 and 12")
+	}
+	if s.IntervalDays < MinInstallmentIntervalDays || s.IntervalDays > MaxInstallmentIntervalDays {
+		return errors.New("days between installments must be between 7 and 31")
+	}
+	if s.MinOrderAmount < 0 {
+		return errors.New("minimum order amount cannot be negative")
+	}
+	return nil
+}
+
+// Offer returns the installment plan offered for an order of the total, or
+// nil if the order can't be paid in installments
+func (s *InstallmentSettings) Offer(total int) *InstallmentOffer {
+	if !s.Enabled || total < s.MinOrderAmount || total < s.Installments {
+		return nil
+	}
+	return &InstallmentOffer{
+		Amounts:             SplitInstallments(total, s.Installments),
+		IntervalDays:        s.IntervalDays,
+		IssueTicketsUpfront: s.IssueTicketsUpfront,
+	}
+}
+
+// SplitInstallments splits a total into count installments as even as
+// possible, with the cents that don't divide evenly paid up front
+func SplitInstallments(total, count int) []int {
+	amounts := make([]int, count)
+	for i := range amounts {
+		amounts[i] = total / count
+	}
+	amounts[0] += total % count
+	return amounts
+}
+
+// InstallmentOffer is the installment plan a buyer can choose at checkout
+type InstallmentOffer struct {
+	Amounts             []int `json:"amounts"` // in cents, the first paid at checkout
+	IntervalDays        int   `json:"interval_days"`
+	IssueTicketsUpfront bool  `json:"issue_tickets_upfront"`
+}
+
+// Count returns the number of installments
+func (o *InstallmentOffer) Count() int {
+	return len(o.Amounts)
+}
+
+// InstallmentPlanStatus represents the status of an order's installment plan
+type InstallmentPlanStatus string
+
+const (
+	InstallmentPlanActive    InstallmentPlanStatus = "active"
+	InstallmentPlanCompleted InstallmentPlanStatus = "completed"
+	// InstallmentPlanDefaulted is a plan whose installment couldn't be
+	// charged, so its order was cancelled
+	InstallmentPlanDefaulted InstallmentPlanStatus = "defaulted"
+)
+
+// InstallmentStatus represents the status of a single installment
+type InstallmentStatus string
+
+const (
+	InstallmentPending InstallmentStatus = "pending"
+	InstallmentPaid    InstallmentStatus = "paid"
+	// InstallmentFailed is an installment every charge of failed
+	InstallmentFailed InstallmentStatus = "failed"
+)
+
+// InstallmentItem is a ticket type and how many of it an installment order
+// is for, kept to issue its tickets once it is paid in full
+type InstallmentItem struct {
+	TicketTypeID int `json:"ticket_type_id"`
+	Quantity     int `json:"quantity"`
+}
+
+// InstallmentPlan is an order's payment schedule: the first installment is
+// paid at checkout and the rest are charged to the buyer's card as they fall
+// due, using the authorization the first payment left
+type InstallmentPlan struct {
+	ID                  int                   `json:"id" db:"id"`
+	OrderID             int                   `json:"order_id" db:"order_id"`
+	UserID              int                   `json:"user_id" db:"user_id"`
+	EventID             int                   `json:"event_id" db:"event_id"`
+	TotalAmount         int                   `json:"total_amount" db:"total_amount"` // in cents
+	Status              InstallmentPlanStatus `json:"status" db:"status"`
+	IssueTicketsUpfront bool                  `json:"issue_tickets_upfront" db:"issue_tickets_upfront"`
+	AuthorizationCode   string                `json:"-" db:"authorization_code"`
+	CustomerEmail       string                `json:"customer_email" db:"customer_email"`
+	Items               []InstallmentItem     `json:"items" db:"items"`
+	Attendees           []TicketAttendee      `json:"attendees,omitempty" db:"attendees"`
+	Installments        []*Installment        `json:"installments"`
+	CreatedAt           time.Time             `json:"created_at" db:"created_at"`
+	CompletedAt         *time.Time            `json:"completed_at,omitempty" db:"completed_at"`
+}
+
+// PaidAmount returns how much of the plan has been paid
+func (p *InstallmentPlan) PaidAmount() int {
+	paid := 0
+	for _, installment := range p.Installments {
+		if installment.Status == InstallmentPaid {
+			paid += installment.Amount
+		}
+	}
+	return paid
+}
+
+// IsPaidInFull returns true once every installment has been paid
+func (p *InstallmentPlan) IsPaidInFull() bool {
+	for _, installment := range p.Installments {
+		if installment.Status != InstallmentPaid {
+			return false
+		}
+	}
+	return true
+}
+
+// Installment is one payment of an installment plan
+type Installment struct {
+	ID               int               `json:"id" db:"id"`
+	PlanID           int               `json:"plan_id" db:"plan_id"`
+	Sequence         int               `json:"sequence" db:"sequence"` // 1 for the installment paid at checkout
+	Amount           int               `json:"amount" db:"amount"`     // in cents
+	DueAt            time.Time         `json:"due_at" db:"due_at"`
+	Status           InstallmentStatus `json:"status" db:"status"`
+	PaymentReference string            `json:"payment_reference" db:"payment_reference"` // Of the latest charge
+	Attempts         int               `json:"attempts" db:"attempts"`
+	NextAttemptAt    time.Time         `json:"next_attempt_at" db:"next_attempt_at"`
+	LastError        string            `json:"last_error,omitempty" db:"last_error"`
+	PaidAt           *time.Time        `json:"paid_at,omitempty" db:"paid_at"`
+}
+
+// GetStatusDisplayName returns a human-readable status name
+func (i *Installment) GetStatusDisplayName() string {
+	switch i.Status {
+	case InstallmentPending:
+		if i.Attempts > 0 {
+			return "Retrying"
+		}
+		return "Scheduled"
+	case InstallmentPaid:
+		return "Paid"
+	case InstallmentFailed:
+		return "Failed"
+	default:
+		return string(i.Status)
+	}
+}
+
+// PaymentAuthorization is a card authorization a payment left, which later
+// payments can be charged to without the buyer
+type PaymentAuthorization struct {
+	Code  string `json:"-"`
+	Email string `json:"email"`
+	Last4 string `json:"last4"`
+	Brand string `json:"brand"`
+}
@@ -0,0 +1,247 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"time"
+)
+
+// LedgerAccount is an account of an organizer's ledger
+type LedgerAccount string
+
+const (
+	// LedgerOrganizer is what the platform owes the organizer, their balance
+	LedgerOrganizer LedgerAccount = "organizer"
+	// LedgerHeld is the organizer's share of disputed orders, frozen until
+	// the dispute is resolved
+	LedgerHeld LedgerAccount = "held"
+	// LedgerBuyerPayments is the money collected from buyers
+	LedgerBuyerPayments LedgerAccount = "buyer_payments"
+	// LedgerPlatformFees is the platform's fee on sales
+	LedgerPlatformFees LedgerAccount = "platform_fees"
+	// LedgerPayouts is the money paid out to the organizer's bank account
+	LedgerPayouts LedgerAccount = "payouts"
+)
+
+// LedgerTransactionKind is what a ledger transaction records
+type LedgerTransactionKind string
+
+const (
+	LedgerSale      LedgerTransactionKind = "sale"      // Source: order
+	LedgerAmendment LedgerTransactionKind = "amendment" // Source: order amendment
+	LedgerRefund    LedgerTransactionKind = "refund"    // Source: order
+	// LedgerDisputeHold freezes a disputed order's share. Source: dispute
+	LedgerDisputeHold    LedgerTransactionKind = "dispute_hold"
+	LedgerDisputeRelease LedgerTransactionKind = "dispute_release" // Source: won dispute
+	LedgerChargeback     LedgerTransactionKind = "chargeback"      // Source: lost dispute
+	LedgerPayout         LedgerTransactionKind = "payout"          // Source: withdrawal
+	// LedgerPayoutReversal returns a payout of a withdrawal rejected after
+	// it was approved. Source: withdrawal
+	LedgerPayoutReversal LedgerTransactionKind = "payout_reversal"
+)
+
+// DisplayName returns a human-readable name of the kind
+func (k LedgerTransactionKind) DisplayName() string {
+	switch k {
+	case LedgerSale:
+		return "Sale"
+	case LedgerAmendment:
+		return "Order change"
+	case LedgerRefund:
+		return "Refund"
+	case LedgerDisputeHold:
+		return "Dispute hold"
+	case LedgerDisputeRelease:
+		return "Dispute won"
+	case LedgerChargeback:
+		return "Chargeback"
+	case LedgerPayout:
+		return "Payout"
+	case LedgerPayoutReversal:
+		return "Payout reversed"
+	default:
+		return string(k)
+	}
+}
+
+// LedgerEntry is one side of a ledger transaction
+type LedgerEntry struct {
+	ID            int           `json:"id" db:"id"`
+	TransactionID int           `json:"transaction_id" db:"transaction_id"`
+	Account       LedgerAccount `json:"account" db:"account"`
+	Amount        int64         `json:"amount" db:"amount"` // in cents, credits positive and debits negative
+}
+
+// LedgerTransaction is a movement of money between accounts of an
+// organizer's ledger. Its entries always sum to zero, so the ledger as a
+// whole does too. Each transaction is posted once for its source, which is
+// the record of what happened, like an order or a withdrawal.
+type LedgerTransaction struct {
+	ID          int                   `json:"id" db:"id"`
+	OrganizerID int                   `json:"organizer_id" db:"organizer_id"`
+	Kind        LedgerTransactionKind `json:"kind" db:"kind"`
+	SourceID    int                   `json:"source_id" db:"source_id"`
+	OrderID     int                   `json:"order_id,omitempty" db:"order_id"` // 0 for payouts
+	Description string                `json:"description" db:"description"`
+	OccurredAt  time.Time             `json:"occurred_at" db:"occurred_at"`
+	CreatedAt   time.Time             `json:"created_at" db:"created_at"`
+	Entries     []LedgerEntry         `json:"entries"`
+	// BalanceAfter is the organizer's balance once the transaction was
+	// posted, set when reading their history
+	BalanceAfter int64 `json:"balance_after" db:"balance_after"`
+}
+
+// Validate checks the transaction balances
+func (t *LedgerTransaction) Validate() error {
+	if len(t.Entries) < 2 {
+		return errors.New("ledger transaction needs at least two entries")
+	}
+	var sum int64
+	for _, entry := range t.Entries {
+		if entry.Account == "" {
+			return errors.New("ledger entry needs an account")
+		}
+		sum += entry.Amount
+	}
+	if sum != 0 {
+		return fmt.Errorf("ledger transaction is unbalanced by %d cents", sum)
+	}
+	return nil
+}
+
+// OrganizerChange returns how much the transaction changed the organizer's
+// balance by
+func (t *LedgerTransaction) OrganizerChange() int64 {
+	var change int64
+	for _, entry := range t.Entries {
+		if entry.Account == LedgerOrganizer {
+			change += entry.Amount
+		}
+	}
+	return change
+}
+
+// LedgerBalances are the balances of ledger accounts, in cents
+type LedgerBalances map[LedgerAccount]int64
+
+// Add adds a transaction's entries to the balances
+func (b LedgerBalances) Add(transaction *LedgerTransaction) {
+	for _, entry := range transaction.Entries {
+		b[entry.Account] += entry.Amount
+	}
+}
+
+// Total returns the sum of every account's balance, which is zero for a
+// balanced ledger
+func (b LedgerBalances) Total() int64 {
+	var total int64
+	for _, balance := range b {
+		total += balance
+	}
+	return total
+}
+
+// LedgerSource is something that happened which moves an organizer's money,
+// not yet posted to their ledger
+type LedgerSource struct {
+	Kind        LedgerTransactionKind `json:"kind"`
+	SourceID    int                   `json:"source_id"`
+	OrganizerID int                   `json:"organizer_id"`
+	OrderID     int                   `json:"order_id,omitempty"`
+	Amount      int64                 `json:"amount"`    // in cents, for sales, amendments and payouts
+	Reference   string                `json:"reference"` // The order number, or the withdrawal ID
+	OccurredAt  time.Time             `json:"occurred_at"`
+}
+
+// PlatformFeeCents returns the platform fee on a sale of amount cents,
+// rounded to the nearest cent
+func PlatformFeeCents(amount int64) int64 {
+	return int64(math.Round(float64(amount) * PlatformFeeRate))
+}
+
+// NewLedgerTransaction returns the transaction posting the source. Refunds,
+// chargebacks and released holds reverse earlier postings: prior holds the
+// balances the order's postings left for refunds and chargebacks, and what
+// the dispute's hold moved for released holds.
+func NewLedgerTransaction(source *LedgerSource, prior LedgerBalances) *LedgerTransaction {
+	transaction := &LedgerTransaction{
+		OrganizerID: source.OrganizerID,
+		Kind:        source.Kind,
+		SourceID:    source.SourceID,
+		OrderID:     source.OrderID,
+		OccurredAt:  source.OccurredAt,
+	}
+
+	switch source.Kind {
+	case LedgerSale, LedgerAmendment:
+		fee := PlatformFeeCents(source.Amount)
+		transaction.Entries = []LedgerEntry{
+			{Account: LedgerBuyerPayments, Amount: -source.Amount},
+			{Account: LedgerOrganizer, Amount: source.Amount - fee},
+			{Account: LedgerPlatformFees, Amount: fee},
+		}
+	case LedgerRefund, LedgerChargeback:
+		// The buyer gets their payment back, which comes out of the
+		// organizer's share, held or not, and the platform's fee
+		transaction.Entries = []LedgerEntry{
+			{Account: LedgerBuyerPayments, Amount: -prior[LedgerBuyerPayments]},
+			{Account: LedgerOrganizer, Amount: -prior[LedgerOrganizer]},
+		}
+		for _, account := range []LedgerAccount{LedgerHeld, LedgerPlatformFees} {
+			if prior[account] != 0 {
+				transaction.Entries = append(transaction.Entries, LedgerEntry{Account: account, Amount: -prior[account]})
+			}
+		}
+	case LedgerDisputeHold:
+		share := prior[LedgerOrganizer]
+		transaction.Entries = []LedgerEntry{
+			{Account: LedgerOrganizer, Amount: -share},
+			{Account: LedgerHeld, Amount: share},
+		}
+	case LedgerDisputeRelease:
+		held := prior[LedgerHeld]
+		transaction.Entries = []LedgerEntry{
+			{Account: LedgerHeld, Amount: -held},
+			{Account: LedgerOrganizer, Amount: held},
+		}
+	case LedgerPayout:
+		transaction.Entries = []LedgerEntry{
+			{Account: LedgerOrganizer, Amount: -source.Amount},
+			{Account: LedgerPayouts, Amount: source.Amount},
+		}
+	case LedgerPayoutReversal:
+		transaction.Entries = []LedgerEntry{
+			{Account: LedgerPayouts, Amount: -source.Amount},
+			{Account: LedgerOrganizer, Amount: source.Amount},
+		}
+	}
+
+	transaction.Description = ledgerDescription(source)
+	return transaction
+}
+
+// ledgerDescription describes a source as shown in the organizer's balance
+// history
+func ledgerDescription(source *LedgerSource) string {
+	switch source.Kind {
+	case LedgerSale:
+		return "Order " + source.Reference
+	case LedgerAmendment:
+		return "Change to order " + source.Reference
+	case LedgerRefund:
+		return "Refund of order " + source.Reference
+	case LedgerDisputeHold:
+		return "Order " + source.Reference + " disputed"
+	case LedgerDisputeRelease:
+		return "Dispute over order " + source.Reference + " won"
+	case LedgerChargeback:
+		return "Dispute over order " + source.Reference + " lost"
+	case LedgerPayout:
+		return "Withdrawal #" + source.Reference
+	case LedgerPayoutReversal:
+		return "Withdrawal #" + source.Reference + " rejected"
+	default:
+		return source.Reference
+	}
+}
@@ -0,0 +1,139 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+// ledgerScenario posts sources in order the way the ledger repository does,
+// giving reversals the postings they reverse
+type ledgerScenario struct {
+	t            *testing.T
+	transactions []*LedgerTransaction
+}
+
+func (s *ledgerScenario) post(source *LedgerSource) *LedgerTransaction {
+	prior := make(LedgerBalances)
+	for _, transaction := range s.transactions {
+		switch source.Kind {
+		case LedgerRefund, LedgerChargeback, LedgerDisputeHold:
+			if transaction.OrderID == source.OrderID {
+				prior.Add(transaction)
+			}
+		case LedgerDisputeRelease:
+			if transaction.Kind == LedgerDisputeHold && transaction.SourceID == source.SourceID {
+				prior.Add(transaction)
+			}
+		}
+	}
+
+	transaction := NewLedgerTransaction(source, prior)
+	if err := transaction.Validate(); err != nil {
+		s.t.Fatalf("%s transaction is invalid: %v (%+v)", source.Kind, err, transaction.Entries)
+	}
+	s.transactions = append(s.transactions, transaction)
+	return transaction
+}
+
+func (s *ledgerScenario) balances() LedgerBalances {
+	balances := make(LedgerBalances)
+	for _, transaction := range s.transactions {
+		balances.Add(transaction)
+	}
+	if total := balances.Total(); total != 0 {
+		s.t.Fatalf("ledger is unbalanced by %d: %v", total, balances)
+	}
+	return balances
+}
+
+func (s *ledgerScenario) expect(want LedgerBalances) {
+	s.t.Helper()
+	got := s.balances()
+	for _, account := range []LedgerAccount{LedgerOrganizer, LedgerHeld, LedgerBuyerPayments, LedgerPlatformFees, LedgerPayouts} {
+		if got[account] != want[account] {
+			s.t.Errorf("%s balance = %d, want %d", account, got[account], want[account])
+		}
+	}
+}
+
+func TestNewLedgerTransaction_Sale(t *testing.T) {
+	s := &ledgerScenario{t: t}
+	sale := s.post(&LedgerSource{Kind: LedgerSale, SourceID: 1, OrganizerID: 2, OrderID: 1, Amount: 10000, Reference: "ORD-1", OccurredAt: time.Now()})
+
+	if sale.OrganizerChange() != 9500 || sale.Description != "Order ORD-1" || sale.OrganizerID != 2 {
+		t.Errorf("sale = %+v, want 95.00 to the organizer", sale)
+	}
+	s.expect(LedgerBalances{LedgerOrganizer: 9500, LedgerBuyerPayments: -10000, LedgerPlatformFees: 500})
+}
+
+func TestNewLedgerTransaction_RefundReversesOrder(t *testing.T) {
+	s := &ledgerScenario{t: t}
+	s.post(&LedgerSource{Kind: LedgerSale, SourceID: 1, OrderID: 1, Amount: 10000})
+	s.post(&LedgerSource{Kind: LedgerAmendment, SourceID: 8, OrderID: 1, Amount: 2510})
+	s.post(&LedgerSource{Kind: LedgerAmendment, SourceID: 9, OrderID: 1, Amount: -1005})
+	s.post(&LedgerSource{Kind: LedgerSale, SourceID: 2, OrderID: 2, Amount: 4000})
+	s.post(&LedgerSource{Kind: LedgerRefund, SourceID: 1, OrderID: 1})
+
+	// Only the second order is left, fees included
+	s.expect(LedgerBalances{LedgerOrganizer: 3800, LedgerBuyerPayments: -4000, LedgerPlatformFees: 200})
+}
+
+func TestNewLedgerTransaction_Disputes(t *testing.T) {
+	s := &ledgerScenario{t: t}
+	s.post(&LedgerSource{Kind: LedgerSale, SourceID: 1, OrderID: 1, Amount: 10000})
+	s.post(&LedgerSource{Kind: LedgerSale, SourceID: 2, OrderID: 2, Amount: 10000})
+
+	s.post(&LedgerSource{Kind: LedgerDisputeHold, SourceID: 5, OrderID: 1})
+	s.post(&LedgerSource{Kind: LedgerDisputeHold, SourceID: 6, OrderID: 2})
+	s.expect(LedgerBalances{LedgerHeld: 19000, LedgerBuyerPayments: -20000, LedgerPlatformFees: 1000})
+
+	// Won disputes return the share to the organizer
+	s.post(&LedgerSource{Kind: LedgerDisputeRelease, SourceID: 5, OrderID: 1})
+	s.expect(LedgerBalances{LedgerOrganizer: 9500, LedgerHeld: 9500, LedgerBuyerPayments: -20000, LedgerPlatformFees: 1000})
+
+	// Lost ones give the buyer their money back out of the held share
+	s.post(&LedgerSource{Kind: LedgerChargeback, SourceID: 6, OrderID: 2})
+	s.expect(LedgerBalances{LedgerOrganizer: 9500, LedgerBuyerPayments: -10000, LedgerPlatformFees: 500})
+}
+
+func TestNewLedgerTransaction_Payouts(t *testing.T) {
+	s := &ledgerScenario{t: t}
+	s.post(&LedgerSource{Kind: LedgerSale, SourceID: 1, OrderID: 1, Amount: 10000})
+	payout := s.post(&LedgerSource{Kind: LedgerPayout, SourceID: 3, Amount: 5000, Reference: "3"})
+	if payout.OrganizerChange() != -5000 || payout.Description != "Withdrawal #3" {
+		t.Errorf("payout = %+v, want 50.00 out of the balance", payout)
+	}
+	s.expect(LedgerBalances{LedgerOrganizer: 4500, LedgerBuyerPayments: -10000, LedgerPlatformFees: 500, LedgerPayouts: 5000})
+
+	s.post(&LedgerSource{Kind: LedgerPayoutReversal, SourceID: 3, Amount: 5000})
+	s.expect(LedgerBalances{LedgerOrganizer: 9500, LedgerBuyerPayments: -10000, LedgerPlatformFees: 500})
+
+	// A refund after a payout leaves the organizer owing the platform
+	s.post(&LedgerSource{Kind: LedgerPayout, SourceID: 4, Amount: 9500})
+	s.post(&LedgerSource{Kind: LedgerRefund, SourceID: 1, OrderID: 1})
+	s.expect(LedgerBalances{LedgerOrganizer: -9500, LedgerPayouts: 9500})
+}
+
+func TestLedgerTransaction_Validate(t *testing.T) {
+	unbalanced := &LedgerTransaction{Entries: []LedgerEntry{{Account: LedgerOrganizer, Amount: 100}, {Account: LedgerBuyerPayments, Amount: -99}}}
+	if err := unbalanced.Validate(); err == nil {
+		t.Error("Validate() of an unbalanced transaction = nil, want an error")
+	}
+	single := &LedgerTransaction{Entries: []LedgerEntry{{Account: LedgerOrganizer, Amount: 0}}}
+	if err := single.Validate(); err == nil {
+		t.Error("Validate() of a single entry = nil, want an error")
+	}
+	unnamed := &LedgerTransaction{Entries: []LedgerEntry{{Amount: 100}, {Account: LedgerOrganizer, Amount: -100}}}
+	if err := unnamed.Validate(); err == nil {
+		t.Error("Validate() of an entry without an account = nil, want an error")
+	}
+}
+
+func TestPlatformFeeCents(t *testing.T) {
+	tests := map[int64]int64{10000: 500, 1010: 51, 1009: 50, 0: 0, -1010: -51}
+	for amount, want := range tests {
+		if got := PlatformFeeCents(amount); got != want {
+			t.Errorf("PlatformFeeCents(%d) = %d, want %d", amount, got, want)
+		}
+	}
+}
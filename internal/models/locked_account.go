@@ -0,0 +1,24 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrAccountNotLocked is returned when unlocking an account that isn't locked
+var ErrAccountNotLocked = errors.New("account is not locked")
+
+// LockedAccount is an account locked after too many failed logins
+type LockedAccount struct {
+	UserID      int        `json:"user_id" db:"id"`
+	Email       string     `json:"email" db:"email"`
+	FirstName   string     `json:"first_name" db:"first_name"`
+	LastName    string     `json:"last_name" db:"last_name"`
+	LockedUntil time.Time  `json:"locked_until" db:"locked_until"`
+	LastAttempt *time.Time `json:"last_attempt,omitempty" db:"last_attempt"`
+}
+
+// FullName returns the account holder's full name
+func (a *LockedAccount) FullName() string {
+	return a.FirstName + " " + a.LastName
+}
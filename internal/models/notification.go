@@ -0,0 +1,75 @@
+package models
+
+import "time"
+
+// NotificationType represents the kind of event that triggered a notification
+type NotificationType string
+
+const (
+	NotificationFirstSale  NotificationType = "first_sale"
+	NotificationHalfSold   NotificationType = "half_sold"
+	NotificationSoldOut    NotificationType = "sold_out"
+	NotificationSaleEnding NotificationType = "sale_ending"
+
+	// Disputes are always announced, as they need the organizer's evidence
+	NotificationDisputeOpened   NotificationType = "dispute_opened"
+	NotificationDisputeResolved NotificationType = "dispute_resolved"
+)
+
+// Notification represents an in-app notification for a user
+type Notification struct {
+	ID        int              `json:"id" db:"id"`
+	UserID    int              `json:"user_id" db:"user_id"`
+	EventID   *int             `json:"event_id" db:"event_id"`
+	Type      NotificationType `json:"type" db:"type"`
+	Title     string           `json:"title" db:"title"`
+	Message   string           `json:"message" db:"message"`
+	Link      string           `json:"link" db:"link"`
+	ReadAt    *time.Time       `json:"read_at" db:"read_at"`
+	CreatedAt time.Time        `json:"created_at" db:"created_at"`
+}
+
+// IsRead returns true if the notification has been read
+func (n *Notification) IsRead() bool {
+	return n.ReadAt != nil
+}
+
+// NotificationPreferences holds an organizer's notification toggles
+type NotificationPreferences struct {
+	UserID       int       `json:"user_id" db:"user_id"`
+	EmailEnabled bool      `json:"email_enabled" db:"email_enabled"`
+	InAppEnabled bool      `json:"in_app_enabled" db:"in_app_enabled"`
+	FirstSale    bool      `json:"first_sale" db:"first_sale"`
+	HalfSold     bool      `json:"half_sold" db:"half_sold"`
+	SoldOut      bool      `json:"sold_out" db:"sold_out"`
+	SaleEnding   bool      `json:"sale_ending" db:"sale_ending"`
+	UpdatedAt    time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// DefaultNotificationPreferences returns the preferences used until an organizer changes them
+func DefaultNotificationPreferences(userID int) *NotificationPreferences {
+	return &NotificationPreferences{
+		UserID:       userID,
+		EmailEnabled: true,
+		InAppEnabled: true,
+		FirstSale:    true,
+		HalfSold:     true,
+		SoldOut:      true,
+		SaleEnding:   true,
+	}
+}
+
+// Wants returns true if the organizer wants to be notified about the given type
+func (p *NotificationPreferences) Wants(notificationType NotificationType) bool {
+	switch notificationType {
+	case NotificationFirstSale:
+		return p.FirstSale
+	case NotificationHalfSold:
+		return p.HalfSold
+	case NotificationSoldOut:
+		return p.SoldOut
+	case NotificationSaleEnding:
+		return p.SaleEnding
+	}
+	return false
+}
@@ -0,0 +1,36 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestNotificationPreferences_Wants(t *testing.T) {
+	prefs := DefaultNotificationPreferences(1)
+	for _, notificationType := range []NotificationType{NotificationFirstSale, NotificationHalfSold, NotificationSoldOut, NotificationSaleEnding} {
+		if !prefs.Wants(notificationType) {
+			t.Errorf("expected default preferences to want %s", notificationType)
+		}
+	}
+
+	prefs.SoldOut = false
+	if prefs.Wants(NotificationSoldOut) {
+		t.Error("expected disabled sold out toggle to be respected")
+	}
+	if prefs.Wants(NotificationType("unknown")) {
+		t.Error("expected unknown notification types to be unwanted")
+	}
+}
+
+func TestNotification_IsRead(t *testing.T) {
+	notification := &Notification{}
+	if notification.IsRead() {
+		t.Error("expected new notification to be unread")
+	}
+
+	now := time.Now()
+	notification.ReadAt = &now
+	if !notification.IsRead() {
+		t.Error("expected notification with read time to be read")
+	}
+}
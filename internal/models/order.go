@@ -18,31 +18,53 @@ const (
 	OrderCompleted OrderStatus = "completed"
 	OrderCancelled OrderStatus = "cancelled"
 	OrderRefunded  OrderStatus = "refunded"
+	// OrderDisputed is an order whose payment the buyer disputed with their
+	// card issuer. It leaves the organizer's balance until the dispute is won.
+	OrderDisputed OrderStatus = "disputed"
 )
 
 // Order represents an order in the system
 type Order struct {
-	ID           int         `json:"id" db:"id"`
-	UserID       int         `json:"user_id" db:"user_id"`
-	EventID      int         `json:"event_id" db:"event_id"`
-	OrderNumber  string      `json:"order_number" db:"order_number"`
-	TotalAmount  int         `json:"total_amount" db:"total_amount"` // Amount in cents
-	Status       OrderStatus `json:"status" db:"status"`
-	PaymentID    string      `json:"payment_id" db:"payment_id"`
-	BillingEmail string      `json:"billing_email" db:"billing_email"`
-	BillingName  string      `json:"billing_name" db:"billing_name"`
-	CreatedAt    time.Time   `json:"created_at" db:"created_at"`
-	UpdatedAt    time.Time   `json:"updated_at" db:"updated_at"`
+	ID             int         `json:"id" db:"id"`
+	UserID         int         `json:"user_id" db:"user_id"`
+	EventID        int         `json:"event_id" db:"event_id"`
+	OrderNumber    string      `json:"order_number" db:"order_number"`
+	TotalAmount    int         `json:"total_amount" db:"total_amount"` // Amount in cents
+	Status         OrderStatus `json:"status" db:"status"`
+	PaymentID      string      `json:"payment_id" db:"payment_id"`
+	BillingEmail   string      `json:"billing_email" db:"billing_email"`
+	BillingName    string      `json:"billing_name" db:"billing_name"`
+	Locale         string      `json:"locale" db:"locale"` // Email language chosen at checkout, empty if not chosen
+	ArrivalSlotID  *int        `json:"arrival_slot_id,omitempty" db:"arrival_slot_id"`
+	DonationAmount int         `json:"donation_amount" db:"donation_amount"` // Part of TotalAmount, in cents
+	Tax            *TaxLine    `json:"tax,omitempty" db:"tax"`               // Nil for untaxed orders
+	CreatedAt      time.Time   `json:"created_at" db:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at" db:"updated_at"`
+
+	// Related data
+	ArrivalSlot *ArrivalSlot `json:"arrival_slot,omitempty"`
 }
 
 // OrderCreateRequest represents the data needed to create a new order
 type OrderCreateRequest struct {
-	UserID       int         `json:"user_id"`
-	EventID      int         `json:"event_id"`
-	TotalAmount  int         `json:"total_amount"`
-	BillingEmail string      `json:"billing_email"`
-	BillingName  string      `json:"billing_name"`
-	Status       OrderStatus `json:"status"`
+	UserID         int              `json:"user_id"`
+	EventID        int              `json:"event_id"`
+	TotalAmount    int              `json:"total_amount"`
+	BillingEmail   string           `json:"billing_email"`
+	BillingName    string           `json:"billing_name"`
+	Locale         string           `json:"locale"`
+	ArrivalSlotID  *int             `json:"arrival_slot_id,omitempty"`
+	Status         OrderStatus      `json:"status"`
+	PaymentID      string           `json:"payment_id"` // Set when the order is made for a payment already taken
+	DonationAmount int              `json:"donation_amount"`
+	Tax            *TaxLine         `json:"tax,omitempty"`         // Part of TotalAmount unless inclusive
+	ItemPrices     []OrderItemPrice `json:"item_prices,omitempty"` // Price of each ticket type bought
+}
+
+// OrderItemPrice is the price per ticket an order bought a ticket type at
+type OrderItemPrice struct {
+	TicketTypeID int `json:"ticket_type_id" db:"ticket_type_id"`
+	UnitPrice    int `json:"unit_price" db:"unit_price"` // in cents
 }
 
 // OrderUpdateRequest represents the data that can be updated for an order
@@ -150,7 +172,7 @@ func validateOrderTotalAmount(totalAmount int) error {
 // validateOrderStatus validates an order status
 func validateOrderStatus(status OrderStatus) error {
 	switch status {
-	case OrderPending, OrderCompleted, OrderCancelled, OrderRefunded:
+	case OrderPending, OrderCompleted, OrderCancelled, OrderRefunded, OrderDisputed:
 		return nil
 	default:
 		return errors.New("invalid order status")
@@ -240,6 +262,16 @@ func (o *Order) TotalAmountInCurrency() float64 {
 	return float64(o.TotalAmount) / 100.0
 }
 
+// DonationInCurrency returns the donation in the main currency as a float
+func (o *Order) DonationInCurrency() float64 {
+	return float64(o.DonationAmount) / 100.0
+}
+
+// HasTax returns true if tax was charged on the order
+func (o *Order) HasTax() bool {
+	return o.Tax != nil && o.Tax.Amount > 0
+}
+
 // TotalAmountInDollars returns the total amount in dollars as a float (legacy method)
 func (o *Order) TotalAmountInDollars() float64 {
 	return o.TotalAmountInCurrency()
@@ -270,6 +302,8 @@ func (o *Order) GetStatusDisplayName() string {
 		return "Cancelled"
 	case OrderRefunded:
 		return "Refunded"
+	case OrderDisputed:
+		return "Disputed"
 	default:
 		return string(o.Status)
 	}
@@ -0,0 +1,85 @@
+package models
+
+import "time"
+
+// OrderAmendmentCutoff is how long before its event starts an order can last
+// be changed
+const OrderAmendmentCutoff = 24 * time.Hour
+
+// OrderAmendmentStatus represents the status of an order amendment
+type OrderAmendmentStatus string
+
+const (
+	// OrderAmendmentPending is an amendment the buyer hasn't confirmed, or
+	// whose difference they haven't paid yet
+	OrderAmendmentPending OrderAmendmentStatus = "pending"
+	// OrderAmendmentProcessing is an amendment that costs less whose tickets
+	// have been changed, while the difference is refunded
+	OrderAmendmentProcessing OrderAmendmentStatus = "processing"
+	OrderAmendmentCompleted  OrderAmendmentStatus = "completed"
+	// OrderAmendmentCancelled is an amendment replaced by a later one, or
+	// whose tickets could no longer be issued
+	OrderAmendmentCancelled OrderAmendmentStatus = "cancelled"
+)
+
+// OrderAmendmentItem is how many tickets of a type an order is changed from
+// and to, and the price each is paid or refunded at
+type OrderAmendmentItem struct {
+	TicketTypeID   int    `json:"ticket_type_id"`
+	TicketTypeName string `json:"ticket_type_name"`
+	From           int    `json:"from"`
+	To             int    `json:"to"`
+	UnitPrice      int    `json:"unit_price"` // in cents
+}
+
+// Delta returns how much the item changes the order's total by
+func (i OrderAmendmentItem) Delta() int {
+	return (i.To - i.From) * i.UnitPrice
+}
+
+// OrderAmendment is a change of an order's ticket types or quantities. An
+// upgrade is swapping tickets of one type for another. Buyers pay the
+// difference when the order costs more and are refunded it when it costs
+// less; removed tickets are voided and new ones issued in their place.
+type OrderAmendment struct {
+	ID               int                  `json:"id" db:"id"`
+	OrderID          int                  `json:"order_id" db:"order_id"`
+	UserID           int                  `json:"user_id" db:"user_id"`
+	Items            []OrderAmendmentItem `json:"items" db:"items"`             // Only the ticket types that change
+	PriceDelta       int                  `json:"price_delta" db:"price_delta"` // Paid by the buyer, or refunded when negative, in cents
+	Status           OrderAmendmentStatus `json:"status" db:"status"`
+	PaymentReference string               `json:"payment_reference" db:"payment_reference"` // Of the payment of the difference or its refund
+	CreatedAt        time.Time            `json:"created_at" db:"created_at"`
+	CompletedAt      *time.Time           `json:"completed_at,omitempty" db:"completed_at"`
+}
+
+// IsPending returns true until the amendment is applied or cancelled
+func (a *OrderAmendment) IsPending() bool {
+	return a.Status == OrderAmendmentPending
+}
+
+// RequiresPayment returns true if the buyer pays for the amendment
+func (a *OrderAmendment) RequiresPayment() bool {
+	return a.PriceDelta > 0
+}
+
+// Refund returns how much the buyer is refunded, in cents
+func (a *OrderAmendment) Refund() int {
+	if a.PriceDelta < 0 {
+		return -a.PriceDelta
+	}
+	return 0
+}
+
+// PriceDeltaInCurrency returns the absolute difference in currency units
+func (a *OrderAmendment) PriceDeltaInCurrency() float64 {
+	if a.PriceDelta < 0 {
+		return float64(-a.PriceDelta) / 100
+	}
+	return float64(a.PriceDelta) / 100
+}
+
+// AmendmentDeadline returns when orders for an event can last be changed
+func AmendmentDeadline(event *Event) time.Time {
+	return event.StartDate.Add(-OrderAmendmentCutoff)
+}
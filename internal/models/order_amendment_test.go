@@ -0,0 +1,39 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestOrderAmendment_Delta(t *testing.T) {
+	amendment := &OrderAmendment{Items: []OrderAmendmentItem{
+		{TicketTypeID: 1, From: 2, To: 1, UnitPrice: 800},
+		{TicketTypeID: 2, From: 0, To: 1, UnitPrice: 2500},
+	}}
+	delta := 0
+	for _, item := range amendment.Items {
+		delta += item.Delta()
+	}
+	if delta != 1700 {
+		t.Fatalf("Delta() sum = %d, want 1700", delta)
+	}
+
+	amendment.PriceDelta = delta
+	if !amendment.RequiresPayment() || amendment.Refund() != 0 {
+		t.Errorf("upgrade RequiresPayment() = %v, Refund() = %d, want true, 0", amendment.RequiresPayment(), amendment.Refund())
+	}
+
+	amendment.PriceDelta = -800
+	if amendment.RequiresPayment() || amendment.Refund() != 800 || amendment.PriceDeltaInCurrency() != 8 {
+		t.Errorf("downgrade RequiresPayment() = %v, Refund() = %d, PriceDeltaInCurrency() = %v, want false, 800, 8",
+			amendment.RequiresPayment(), amendment.Refund(), amendment.PriceDeltaInCurrency())
+	}
+}
+
+func TestAmendmentDeadline(t *testing.T) {
+	start := time.Date(2026, 11, 20, 18, 0, 0, 0, time.UTC)
+	want := time.Date(2026, 11, 19, 18, 0, 0, 0, time.UTC)
+	if got := AmendmentDeadline(&Event{StartDate: start}); !got.Equal(want) {
+		t.Errorf("AmendmentDeadline() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+// TeamPermission is a part of an organizer's account a team member can use
+type TeamPermission string
+
+const (
+	PermissionManageEvents  TeamPermission = "manage_events"
+	PermissionViewAnalytics TeamPermission = "view_analytics"
+	PermissionCheckIn       TeamPermission = "check_in"
+)
+
+// TeamPermissions lists every permission in the order they are shown
+var TeamPermissions = []TeamPermission{PermissionManageEvents, PermissionViewAnalytics, PermissionCheckIn}
+
+// MaxTeamMembers is how many members, invited or joined, an organizer's team can have
+const MaxTeamMembers = 20
+
+// TeamInvitationTTL is how long an invitation to a team can be accepted for
+const TeamInvitationTTL = 7 * 24 * time.Hour
+
+// OrganizerMember is someone an organizer has invited to help run their
+// events. Members never have access to the organizer's withdrawals.
+type OrganizerMember struct {
+	ID               int        `json:"id" db:"id"`
+	OrganizerID      int        `json:"organizer_id" db:"organizer_id"`
+	UserID           *int       `json:"user_id,omitempty" db:"user_id"` // Set once the invitation is accepted
+	Email            string     `json:"email" db:"email"`
+	CanManageEvents  bool       `json:"can_manage_events" db:"can_manage_events"`
+	CanViewAnalytics bool       `json:"can_view_analytics" db:"can_view_analytics"`
+	CanCheckIn       bool       `json:"can_check_in" db:"can_check_in"`
+	InvitationHash   string     `json:"-" db:"invitation_token_hash"`
+	InvitedAt        time.Time  `json:"invited_at" db:"invited_at"`
+	AcceptedAt       *time.Time `json:"accepted_at,omitempty" db:"accepted_at"`
+
+	// Related data
+	MemberName    string `json:"member_name,omitempty"`
+	OrganizerName string `json:"organizer_name,omitempty"`
+}
+
+// Validate validates the team member data
+func (m *OrganizerMember) Validate() error {
+	m.Email = strings.ToLower(strings.TrimSpace(m.Email))
+	if err := validateEmail(m.Email); err != nil {
+		return err
+	}
+	if len(m.Permissions()) == 0 {
+		return errors.New("choose at least one thing the team member can do")
+	}
+	return nil
+}
+
+// IsAccepted returns true if the member has joined the team
+func (m *OrganizerMember) IsAccepted() bool {
+	return m.AcceptedAt != nil && m.UserID != nil
+}
+
+// IsInvitationExpired returns true if the invitation can no longer be accepted
+func (m *OrganizerMember) IsInvitationExpired(now time.Time) bool {
+	return !m.IsAccepted() && now.After(m.InvitedAt.Add(TeamInvitationTTL))
+}
+
+// Has returns true if the member can use the given part of the account
+func (m *OrganizerMember) Has(permission TeamPermission) bool {
+	switch permission {
+	case PermissionManageEvents:
+		return m.CanManageEvents
+	case PermissionViewAnalytics:
+		return m.CanViewAnalytics
+	case PermissionCheckIn:
+		return m.CanCheckIn
+	default:
+		return false
+	}
+}
+
+// Permissions returns the member's permissions in display order
+func (m *OrganizerMember) Permissions() []TeamPermission {
+	var permissions []TeamPermission
+	for _, permission := range TeamPermissions {
+		if m.Has(permission) {
+			permissions = append(permissions, permission)
+		}
+	}
+	return permissions
+}
+
+// SetPermissions grants exactly the given permissions. Unknown permissions
+// are ignored.
+func (m *OrganizerMember) SetPermissions(permissions []TeamPermission) {
+	m.CanManageEvents, m.CanViewAnalytics, m.CanCheckIn = false, false, false
+	for _, permission := range permissions {
+		switch permission {
+		case PermissionManageEvents:
+			m.CanManageEvents = true
+		case PermissionViewAnalytics:
+			m.CanViewAnalytics = true
+		case PermissionCheckIn:
+			m.CanCheckIn = true
+		}
+	}
+}
+
+// HashInvitationToken returns the stored form of a team invitation token
+func HashInvitationToken(token string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(token)))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,59 @@
+package models
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestOrganizerMember_Validate(t *testing.T) {
+	member := &OrganizerMember{Email: "  Door@Example.com ", CanCheckIn: true}
+	if err := member.Validate(); err != nil {
+		t.Fatalf("expected valid member, got %v", err)
+	}
+	if member.Email != "door@example.com" {
+		t.Errorf("expected normalized email, got %q", member.Email)
+	}
+
+	if err := (&OrganizerMember{Email: "not-an-email", CanCheckIn: true}).Validate(); err == nil {
+		t.Error("expected error for invalid email")
+	}
+	if err := (&OrganizerMember{Email: "door@example.com"}).Validate(); err == nil {
+		t.Error("expected error for member without permissions")
+	}
+}
+
+func TestOrganizerMember_Permissions(t *testing.T) {
+	member := &OrganizerMember{}
+	member.SetPermissions([]TeamPermission{PermissionCheckIn, "withdrawals", PermissionManageEvents})
+
+	want := []TeamPermission{PermissionManageEvents, PermissionCheckIn}
+	if got := member.Permissions(); !reflect.DeepEqual(got, want) {
+		t.Errorf("expected %v, got %v", want, got)
+	}
+	if member.Has(PermissionViewAnalytics) {
+		t.Error("expected analytics not to be granted")
+	}
+	if member.Has("withdrawals") {
+		t.Error("expected unknown permissions never to be granted")
+	}
+}
+
+func TestOrganizerMember_IsInvitationExpired(t *testing.T) {
+	invitedAt := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	member := &OrganizerMember{InvitedAt: invitedAt}
+
+	if member.IsInvitationExpired(invitedAt.Add(TeamInvitationTTL - time.Minute)) {
+		t.Error("expected invitation to be open within its lifetime")
+	}
+	if !member.IsInvitationExpired(invitedAt.Add(TeamInvitationTTL + time.Minute)) {
+		t.Error("expected invitation to expire")
+	}
+
+	userID := 5
+	acceptedAt := invitedAt.Add(time.Hour)
+	member.UserID, member.AcceptedAt = &userID, &acceptedAt
+	if member.IsInvitationExpired(invitedAt.Add(2 * TeamInvitationTTL)) {
+		t.Error("expected accepted members never to expire")
+	}
+}
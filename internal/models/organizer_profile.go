@@ -0,0 +1,111 @@
+package models
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// MaxOrganizerSlugLength is the longest slug generated from an organizer's name
+const MaxOrganizerSlugLength = 60
+
+// organizerSlugPattern is what organizers may choose as their storefront slug
+var organizerSlugPattern = regexp.MustCompile(`^[a-z][a-z0-9-]{2,99}$`)
+
+// OrganizerProfile is the branding shown on an organizer's public storefront
+type OrganizerProfile struct {
+	UserID      int       `json:"user_id" db:"user_id"`
+	Slug        string    `json:"slug" db:"slug"`
+	DisplayName string    `json:"display_name" db:"display_name"`
+	Description string    `json:"description" db:"description"`
+	LogoURL     string    `json:"logo_url" db:"logo_url"`
+	BannerURL   string    `json:"banner_url" db:"banner_url"`
+	WebsiteURL  string    `json:"website_url" db:"website_url"`
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Path returns the storefront's public URL path
+func (p *OrganizerProfile) Path() string {
+	return "/o/" + p.Slug
+}
+
+// Initials returns up to two initials of the display name, shown when the
+// organizer has no logo
+func (p *OrganizerProfile) Initials() string {
+	initials := ""
+	for _, word := range strings.Fields(p.DisplayName) {
+		initials += strings.ToUpper(string([]rune(word)[0]))
+		if len([]rune(initials)) == 2 {
+			break
+		}
+	}
+	return initials
+}
+
+// Validate validates the profile an organizer has edited
+func (p *OrganizerProfile) Validate() error {
+	p.Slug = strings.ToLower(strings.TrimSpace(p.Slug))
+	p.DisplayName = strings.TrimSpace(p.DisplayName)
+	p.WebsiteURL = strings.TrimSpace(p.WebsiteURL)
+
+	if !organizerSlugPattern.MatchString(p.Slug) || strings.HasSuffix(p.Slug, "-") || strings.Contains(p.Slug, "--") {
+		return fmt.Errorf("storefront address must be 3-100 lowercase letters, digits and single hyphens, starting with a letter")
+	}
+	if p.DisplayName == "" {
+		return fmt.Errorf("display name is required")
+	}
+	if len(p.DisplayName) > 100 {
+		return fmt.Errorf("display name must be less than 100 characters")
+	}
+	if len(p.Description) > 2000 {
+		return fmt.Errorf("description must be less than 2000 characters")
+	}
+	if p.WebsiteURL != "" {
+		u, err := url.Parse(p.WebsiteURL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || len(p.WebsiteURL) > 500 {
+			return fmt.Errorf("website must be a valid http or https URL")
+		}
+	}
+	return nil
+}
+
+// OrganizerSlug turns an organizer's name into their default storefront slug
+// ("Nairobi Jazz Club" -> "nairobi-jazz-club"). It must stay in sync with the
+// backfill in the create_organizer_storefronts migration.
+func OrganizerSlug(name string) string {
+	slug := citySlugInvalidChars.ReplaceAllString(strings.ToLower(name), "-")
+	slug = strings.Trim(slug, "-")
+	if len(slug) > MaxOrganizerSlugLength {
+		slug = strings.Trim(slug[:MaxOrganizerSlugLength], "-")
+	}
+
+	switch {
+	case slug == "":
+		return "organizer"
+	case slug[0] >= '0' && slug[0] <= '9':
+		return "organizer-" + slug
+	default:
+		return slug
+	}
+}
+
+// OrganizerStorefront is everything shown on an organizer's public page
+type OrganizerStorefront struct {
+	Profile        *OrganizerProfile `json:"profile"`
+	UpcomingEvents []*Event          `json:"upcoming_events"`
+	PastEvents     []*Event          `json:"past_events"`
+	Followers      int               `json:"followers"`
+	IsFollowing    bool              `json:"is_following"`
+}
+
+// OrganizerFollower is an attendee following an organizer, with the language
+// to email them in
+type OrganizerFollower struct {
+	UserID int    `json:"user_id"`
+	Email  string `json:"email"`
+	Name   string `json:"name"`
+	Locale string `json:"locale"`
+}
@@ -0,0 +1,45 @@
+package models
+
+import "testing"
+
+func TestOrganizerSlug(t *testing.T) {
+	tests := map[string]string{
+		"Nairobi Jazz Club": "nairobi-jazz-club",
+		"  Café Noir!  ":    "caf-noir",
+		"254 Events":        "organizer-254-events",
+		"!!!":               "organizer",
+	}
+	for name, want := range tests {
+		if got := OrganizerSlug(name); got != want {
+			t.Errorf("OrganizerSlug(%q) = %q, want %q", name, got, want)
+		}
+	}
+}
+
+func TestOrganizerProfile_Validate(t *testing.T) {
+	valid := OrganizerProfile{Slug: "jazz-club", DisplayName: "Jazz Club", WebsiteURL: "https://jazz.example.com"}
+	if err := valid.Validate(); err != nil {
+		t.Errorf("expected a valid profile, got %v", err)
+	}
+
+	invalid := []OrganizerProfile{
+		{Slug: "1jazz", DisplayName: "Jazz Club"},
+		{Slug: "jazz--club", DisplayName: "Jazz Club"},
+		{Slug: "jazz-", DisplayName: "Jazz Club"},
+		{Slug: "jz", DisplayName: "Jazz Club"},
+		{Slug: "jazz-club", DisplayName: "  "},
+		{Slug: "jazz-club", DisplayName: "Jazz Club", WebsiteURL: "ftp://jazz.example.com"},
+	}
+	for _, profile := range invalid {
+		if err := profile.Validate(); err == nil {
+			t.Errorf("expected %+v to be invalid", profile)
+		}
+	}
+}
+
+func TestOrganizerProfile_Initials(t *testing.T) {
+	profile := &OrganizerProfile{DisplayName: "nairobi jazz club"}
+	if got := profile.Initials(); got != "NJ" {
+		t.Errorf("expected NJ, got %q", got)
+	}
+}
@@ -0,0 +1,91 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// EventReportReason is why an attendee reported an event
+type EventReportReason string
+
+const (
+	ReportScam          EventReportReason = "scam"
+	ReportMisleading    EventReportReason = "misleading"
+	ReportInappropriate EventReportReason = "inappropriate"
+	ReportCancelled     EventReportReason = "cancelled"
+	ReportOther         EventReportReason = "other"
+)
+
+// EventReportReasons lists the reasons attendees can choose from
+var EventReportReasons = []EventReportReason{ReportScam, ReportMisleading, ReportInappropriate, ReportCancelled, ReportOther}
+
+// Label returns a human readable report reason
+func (r EventReportReason) Label() string {
+	switch r {
+	case ReportScam:
+		return "Scam or fraud"
+	case ReportMisleading:
+		return "Misleading information"
+	case ReportInappropriate:
+		return "Inappropriate content"
+	case ReportCancelled:
+		return "Event didn't happen"
+	case ReportOther:
+		return "Something else"
+	}
+	return string(r)
+}
+
+// EventReport is an attendee's report of a problem with an event
+type EventReport struct {
+	ID         int               `json:"id" db:"id"`
+	EventID    int               `json:"event_id" db:"event_id"`
+	ReporterID int               `json:"reporter_id" db:"reporter_id"`
+	Reason     EventReportReason `json:"reason" db:"reason"`
+	Details    string            `json:"details" db:"details"`
+	CreatedAt  time.Time         `json:"created_at" db:"created_at"`
+}
+
+// Validate validates the event report
+func (r *EventReport) Validate() error {
+	valid := false
+	for _, reason := range EventReportReasons {
+		if r.Reason == reason {
+			valid = true
+		}
+	}
+	if !valid {
+		return errors.New("please choose a reason for the report")
+	}
+	if len(r.Details) > 2000 {
+		return errors.New("report details must be 2000 characters or less")
+	}
+	return nil
+}
+
+// OrganizerHistory is the track record an organizer's reputation is computed from
+type OrganizerHistory struct {
+	OrganizerID     int `json:"organizer_id"`
+	ApprovedEvents  int `json:"approved_events"`  // Events that have been published
+	RejectedEvents  int `json:"rejected_events"`  // Events rejected by moderators
+	CompletedOrders int `json:"completed_orders"` // Orders that were paid and not refunded
+	RefundedOrders  int `json:"refunded_orders"`
+	Reports         int `json:"reports"` // Attendee reports across all events
+}
+
+// RefundRate returns the share of paid orders that were refunded
+func (h *OrganizerHistory) RefundRate() float64 {
+	paid := h.CompletedOrders + h.RefundedOrders
+	if paid == 0 {
+		return 0
+	}
+	return float64(h.RefundedOrders) / float64(paid)
+}
+
+// OrganizerReputation is an organizer's reputation score out of 100 and
+// whether it lets them publish without manual moderation
+type OrganizerReputation struct {
+	History   *OrganizerHistory `json:"history"`
+	Score     int               `json:"score"`
+	FastTrack bool              `json:"fast_track"`
+}
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestEventReport_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		report  EventReport
+		wantErr bool
+	}{
+		{"valid", EventReport{Reason: ReportScam, Details: "Fake venue"}, false},
+		{"no details", EventReport{Reason: ReportCancelled}, false},
+		{"missing reason", EventReport{}, true},
+		{"unknown reason", EventReport{Reason: "spam"}, true},
+		{"details too long", EventReport{Reason: ReportOther, Details: strings.Repeat("a", 2001)}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.report.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestOrganizerHistory_RefundRate(t *testing.T) {
+	if rate := (&OrganizerHistory{}).RefundRate(); rate != 0 {
+		t.Errorf("expected 0 refund rate without orders, got %v", rate)
+	}
+	if rate := (&OrganizerHistory{CompletedOrders: 75, RefundedOrders: 25}).RefundRate(); rate != 0.25 {
+		t.Errorf("expected 0.25 refund rate, got %v", rate)
+	}
+}
@@ -0,0 +1,111 @@
+package models
+
+import (
+	"errors"
+	"strings"
+	"time"
+)
+
+// MaxDisputeEvidenceSize limits the size of a dispute's evidence file
+const MaxDisputeEvidenceSize = 5 << 20
+
+// DisputeEvidenceTypes are the content types of the files organizers can
+// submit as evidence, and the extensions they are stored with
+var DisputeEvidenceTypes = map[string]string{
+	"application/pdf": ".pdf",
+	"image/jpeg":      ".jpg",
+	"image/png":       ".png",
+}
+
+// DisputeStatus represents the status of a payment dispute
+type DisputeStatus string
+
+const (
+	// DisputeOpen is a dispute awaiting the organizer's evidence
+	DisputeOpen DisputeStatus = "open"
+	// DisputeUnderReview is a dispute whose evidence has been submitted
+	DisputeUnderReview DisputeStatus = "under_review"
+	// DisputeWon is a dispute decided for the organizer, whose order is
+	// restored
+	DisputeWon DisputeStatus = "won"
+	// DisputeLost is a dispute decided for the buyer, who gets the payment
+	// back. Its order stays disputed and its tickets are voided.
+	DisputeLost DisputeStatus = "lost"
+)
+
+// PaymentDispute is a chargeback a buyer raised with their card issuer over
+// an order's payment. While it is open the order is disputed, which freezes
+// its amount out of the organizer's balance.
+type PaymentDispute struct {
+	ID                  int           `json:"id" db:"id"`
+	ProviderDisputeID   string        `json:"provider_dispute_id" db:"provider_dispute_id"`
+	OrderID             int           `json:"order_id" db:"order_id"`
+	PaymentReference    string        `json:"payment_reference" db:"payment_reference"`
+	Amount              int           `json:"amount" db:"amount"` // Disputed, in cents
+	Currency            string        `json:"currency" db:"currency"`
+	Reason              string        `json:"reason" db:"reason"`
+	Status              DisputeStatus `json:"status" db:"status"`
+	OrderStatus         OrderStatus   `json:"order_status" db:"order_status"` // Before the dispute, restored if it is won
+	EvidenceText        string        `json:"evidence_text" db:"evidence_text"`
+	EvidenceURL         string        `json:"evidence_url" db:"evidence_url"`
+	EvidenceSubmittedAt *time.Time    `json:"evidence_submitted_at,omitempty" db:"evidence_submitted_at"`
+	DueAt               *time.Time    `json:"due_at,omitempty" db:"due_at"` // When the evidence is due
+	ResolutionNote      string        `json:"resolution_note" db:"resolution_note"`
+	CreatedAt           time.Time     `json:"created_at" db:"created_at"`
+	UpdatedAt           time.Time     `json:"updated_at" db:"updated_at"`
+	ResolvedAt          *time.Time    `json:"resolved_at,omitempty" db:"resolved_at"`
+
+	// Related data
+	OrderNumber string `json:"order_number,omitempty"`
+	EventID     int    `json:"event_id,omitempty"`
+	EventTitle  string `json:"event_title,omitempty"`
+	OrganizerID int    `json:"organizer_id,omitempty"`
+}
+
+// IsResolved returns true once the dispute has been won or lost
+func (d *PaymentDispute) IsResolved() bool {
+	return d.Status == DisputeWon || d.Status == DisputeLost
+}
+
+// AcceptsEvidence returns true while the organizer can still submit evidence
+func (d *PaymentDispute) AcceptsEvidence() bool {
+	return !d.IsResolved()
+}
+
+// AmountInCurrency returns the disputed amount in the main currency
+func (d *PaymentDispute) AmountInCurrency() float64 {
+	return float64(d.Amount) / 100.0
+}
+
+// GetStatusDisplayName returns a human-readable status name
+func (d *PaymentDispute) GetStatusDisplayName() string {
+	switch d.Status {
+	case DisputeOpen:
+		return "Awaiting Evidence"
+	case DisputeUnderReview:
+		return "Under Review"
+	case DisputeWon:
+		return "Won"
+	case DisputeLost:
+		return "Lost"
+	default:
+		return string(d.Status)
+	}
+}
+
+// DisputeEvidence is what an organizer submits to contest a dispute
+type DisputeEvidence struct {
+	Text    string
+	FileURL string
+}
+
+// Validate validates the dispute evidence
+func (e *DisputeEvidence) Validate() error {
+	if strings.TrimSpace(e.Text) == "" && e.FileURL == "" {
+		return errors.New("describe what the buyer received or attach a file")
+	}
+	if len(e.Text) > 5000 {
+		return errors.New("evidence cannot be longer than 5000 characters")
+	}
+	return nil
+}
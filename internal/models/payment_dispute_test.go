@@ -0,0 +1,28 @@
+package models
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPaymentDispute_AcceptsEvidence(t *testing.T) {
+	for status, want := range map[DisputeStatus]bool{
+		DisputeOpen:        true,
+		DisputeUnderReview: true,
+		DisputeWon:         false,
+		DisputeLost:        false,
+	} {
+		dispute := &PaymentDispute{Status: status}
+		assert.Equal(t, want, dispute.AcceptsEvidence(), status)
+		assert.Equal(t, !want, dispute.IsResolved(), status)
+	}
+}
+
+func TestDisputeEvidence_Validate(t *testing.T) {
+	assert.Error(t, (&DisputeEvidence{Text: "   "}).Validate())
+	assert.Error(t, (&DisputeEvidence{Text: strings.Repeat("a", 5001)}).Validate())
+	assert.NoError(t, (&DisputeEvidence{Text: "The tickets were scanned at the entrance"}).Validate())
+	assert.NoError(t, (&DisputeEvidence{FileURL: "https://example.com/disputes/1/receipt.pdf"}).Validate())
+}
@@ -0,0 +1,66 @@
+package models
+
+import "time"
+
+// Payment methods offered at checkout
+const (
+	PaymentMethodPaystack = "paystack"
+	PaymentMethodStripe   = "stripe"
+	PaymentMethodPayPal   = "paypal"
+)
+
+// PaymentMethods lists the checkout payment methods in the order they are shown
+var PaymentMethods = []string{PaymentMethodPaystack, PaymentMethodStripe, PaymentMethodPayPal}
+
+// DefaultPaymentMethod is preselected at checkout while it is healthy
+const DefaultPaymentMethod = PaymentMethodPaystack
+
+// PaymentMethodLabel returns a human readable payment method name
+func PaymentMethodLabel(method string) string {
+	switch method {
+	case PaymentMethodPaystack:
+		return "Paystack"
+	case PaymentMethodStripe:
+		return "Credit/Debit Card (Stripe)"
+	case PaymentMethodPayPal:
+		return "PayPal"
+	}
+	return method
+}
+
+// PaymentProviderHealth is a payment provider's success rate over the
+// recent monitoring window
+type PaymentProviderHealth struct {
+	Provider    string     `json:"provider"`
+	Attempts    int        `json:"attempts"`
+	Failures    int        `json:"failures"`
+	SuccessRate float64    `json:"success_rate"` // Between 0 and 1; 1 when there were no attempts
+	Degraded    bool       `json:"degraded"`
+	LastFailure *time.Time `json:"last_failure,omitempty"`
+}
+
+// Label returns the provider's human readable name
+func (h *PaymentProviderHealth) Label() string {
+	return PaymentMethodLabel(h.Provider)
+}
+
+// CheckoutPaymentStatus tells checkout which payment method to preselect and
+// which ones are currently failing
+type CheckoutPaymentStatus struct {
+	DefaultMethod string   `json:"default_method"`
+	Degraded      []string `json:"degraded"`
+	Suggested     string   `json:"suggested,omitempty"` // A healthy alternative to the degraded methods
+	// Installments is the plan the order can be paid in by card through
+	// Paystack, or nil if the event doesn't offer one for it
+	Installments *InstallmentOffer `json:"installments,omitempty"`
+}
+
+// IsDegraded returns true if the payment method is currently failing
+func (s *CheckoutPaymentStatus) IsDegraded(method string) bool {
+	for _, degraded := range s.Degraded {
+		if degraded == method {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,101 @@
+package models
+
+import "time"
+
+// ReconciliationMismatch is how a payment provider's transaction and the
+// local order made for it disagree
+type ReconciliationMismatch string
+
+const (
+	// MismatchPaidNotCompleted is a successful payment whose order is still
+	// pending or was cancelled, so the buyer paid without getting tickets
+	MismatchPaidNotCompleted ReconciliationMismatch = "paid_not_completed"
+	// MismatchPaidWithoutOrder is a successful payment no order was made for,
+	// usually because the buyer never returned from the payment page
+	MismatchPaidWithoutOrder ReconciliationMismatch = "paid_without_order"
+	// MismatchAmount is a payment for a different amount than its order
+	MismatchAmount ReconciliationMismatch = "amount_mismatch"
+	// MismatchCompletedNotPaid is a completed order whose payment failed or
+	// was abandoned
+	MismatchCompletedNotPaid ReconciliationMismatch = "completed_not_paid"
+)
+
+// Label returns a human-readable name for the mismatch
+func (m ReconciliationMismatch) Label() string {
+	switch m {
+	case MismatchPaidNotCompleted:
+		return "Paid, order not completed"
+	case MismatchPaidWithoutOrder:
+		return "Paid, no order"
+	case MismatchAmount:
+		return "Amount mismatch"
+	case MismatchCompletedNotPaid:
+		return "Completed, not paid"
+	default:
+		return string(m)
+	}
+}
+
+// ReconciliationResolution is how a reconciliation issue was closed
+type ReconciliationResolution string
+
+const (
+	// ReconciliationResolved is an issue an admin fixed, e.g. by issuing the
+	// tickets or refunding the buyer
+	ReconciliationResolved ReconciliationResolution = "resolved"
+	// ReconciliationIgnored is an issue an admin decided needs no action
+	ReconciliationIgnored ReconciliationResolution = "ignored"
+	// ReconciliationCleared is an issue a later run found no longer mismatched
+	ReconciliationCleared ReconciliationResolution = "cleared"
+)
+
+// PaymentTransaction is a transaction as the payment provider reports it
+type PaymentTransaction struct {
+	Reference string     `json:"reference"`
+	Status    string     `json:"status"` // success, failed or pending, as in PaymentStatus
+	Amount    int        `json:"amount"` // in cents
+	Currency  string     `json:"currency"`
+	Email     string     `json:"email"`
+	PaidAt    *time.Time `json:"paid_at,omitempty"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+// ReconciliationIssue is a payment the provider and local orders disagree
+// about. The same mismatch for a reference is only recorded once, and later
+// runs update when it was last seen.
+type ReconciliationIssue struct {
+	ID               int                      `json:"id" db:"id"`
+	Kind             ReconciliationMismatch   `json:"kind" db:"kind"`
+	PaymentReference string                   `json:"payment_reference" db:"payment_reference"`
+	OrderID          *int                     `json:"order_id,omitempty" db:"order_id"`
+	ProviderStatus   string                   `json:"provider_status" db:"provider_status"`
+	ProviderAmount   int                      `json:"provider_amount" db:"provider_amount"`
+	Currency         string                   `json:"currency" db:"currency"`
+	CustomerEmail    string                   `json:"customer_email" db:"customer_email"`
+	OrderStatus      OrderStatus              `json:"order_status,omitempty" db:"order_status"`
+	OrderAmount      int                      `json:"order_amount" db:"order_amount"`
+	DetectedAt       time.Time                `json:"detected_at" db:"detected_at"`
+	LastSeenAt       time.Time                `json:"last_seen_at" db:"last_seen_at"`
+	ResolvedAt       *time.Time               `json:"resolved_at,omitempty" db:"resolved_at"`
+	ResolvedBy       *int                     `json:"resolved_by,omitempty" db:"resolved_by"`
+	Resolution       ReconciliationResolution `json:"resolution,omitempty" db:"resolution"`
+	ResolutionNote   string                   `json:"resolution_note" db:"resolution_note"`
+}
+
+// IsOpen returns true until the issue is resolved, ignored or cleared
+func (i *ReconciliationIssue) IsOpen() bool {
+	return i.ResolvedAt == nil
+}
+
+// ReconciliationRun is the result of comparing the provider's transactions
+// in a window against local orders
+type ReconciliationRun struct {
+	WindowStart  time.Time `json:"window_start"`
+	WindowEnd    time.Time `json:"window_end"`
+	Transactions int       `json:"transactions"`
+	Flagged      int       `json:"flagged"`
+	Cleared      int       `json:"cleared"`
+	Error        string    `json:"error,omitempty"`
+	StartedAt    time.Time `json:"started_at"`
+	CompletedAt  time.Time `json:"completed_at"`
+}
@@ -0,0 +1,119 @@
+package models
+
+import "strings"
+
+// Place is a city or town in the local dataset used for location
+// autocomplete and for grouping city pages by region
+type Place struct {
+	Name   string `json:"name"`
+	Region string `json:"region,omitempty"` // County the place is in
+}
+
+// Slug returns the city slug of the place, as used by city landing pages
+func (p Place) Slug() string {
+	return CitySlug(p.Name)
+}
+
+// OtherRegion is the region of cities that aren't in the places dataset
+const OtherRegion = "Other"
+
+// Places are the cities and towns organizers can pick from when entering an
+// event's location, with the county each is in
+var Places = []Place{
+	{Name: "Nairobi", Region: "Nairobi"},
+	{Name: "Mombasa", Region: "Mombasa"},
+	{Name: "Kisumu", Region: "Kisumu"},
+	{Name: "Nakuru", Region: "Nakuru"},
+	{Name: "Naivasha", Region: "Nakuru"},
+	{Name: "Eldoret", Region: "Uasin Gishu"},
+	{Name: "Thika", Region: "Kiambu"},
+	{Name: "Kiambu", Region: "Kiambu"},
+	{Name: "Ruiru", Region: "Kiambu"},
+	{Name: "Kikuyu", Region: "Kiambu"},
+	{Name: "Limuru", Region: "Kiambu"},
+	{Name: "Machakos", Region: "Machakos"},
+	{Name: "Athi River", Region: "Machakos"},
+	{Name: "Kitengela", Region: "Kajiado"},
+	{Name: "Ngong", Region: "Kajiado"},
+	{Name: "Kajiado", Region: "Kajiado"},
+	{Name: "Malindi", Region: "Kilifi"},
+	{Name: "Kilifi", Region: "Kilifi"},
+	{Name: "Watamu", Region: "Kilifi"},
+	{Name: "Diani Beach", Region: "Kwale"},
+	{Name: "Ukunda", Region: "Kwale"},
+	{Name: "Lamu", Region: "Lamu"},
+	{Name: "Voi", Region: "Taita Taveta"},
+	{Name: "Nyeri", Region: "Nyeri"},
+	{Name: "Nanyuki", Region: "Laikipia"},
+	{Name: "Nyahururu", Region: "Laikipia"},
+	{Name: "Murang'a", Region: "Murang'a"},
+	{Name: "Embu", Region: "Embu"},
+	{Name: "Meru", Region: "Meru"},
+	{Name: "Isiolo", Region: "Isiolo"},
+	{Name: "Kitui", Region: "Kitui"},
+	{Name: "Garissa", Region: "Garissa"},
+	{Name: "Narok", Region: "Narok"},
+	{Name: "Kericho", Region: "Kericho"},
+	{Name: "Bomet", Region: "Bomet"},
+	{Name: "Kisii", Region: "Kisii"},
+	{Name: "Homa Bay", Region: "Homa Bay"},
+	{Name: "Migori", Region: "Migori"},
+	{Name: "Kakamega", Region: "Kakamega"},
+	{Name: "Bungoma", Region: "Bungoma"},
+	{Name: "Busia", Region: "Busia"},
+	{Name: "Kitale", Region: "Trans Nzoia"},
+	{Name: "Lodwar", Region: "Turkana"},
+	{Name: "Marsabit", Region: "Marsabit"},
+}
+
+// placesBySlug indexes Places by city slug
+var placesBySlug = func() map[string]Place {
+	index := make(map[string]Place, len(Places))
+	for _, place := range Places {
+		index[place.Slug()] = place
+	}
+	return index
+}()
+
+// FindPlace returns the place in the dataset with a city slug, if any
+func FindPlace(citySlug string) (Place, bool) {
+	place, ok := placesBySlug[citySlug]
+	return place, ok
+}
+
+// PlaceRegion returns the region of a city, or OtherRegion if the city isn't
+// in the places dataset
+func PlaceRegion(citySlug string) string {
+	if place, ok := FindPlace(citySlug); ok {
+		return place.Region
+	}
+	return OtherRegion
+}
+
+// ComposeLocation joins an event's venue and city into its free-text
+// location, keeping the city last so city pages can find the event
+// ("KICC", "Nairobi" -> "KICC, Nairobi")
+func ComposeLocation(venue, city string) string {
+	venue = strings.Trim(strings.TrimSpace(venue), ",")
+	city = strings.Trim(strings.TrimSpace(city), ",")
+	switch {
+	case city == "":
+		return venue
+	case venue == "", strings.EqualFold(venue, city):
+		return city
+	case strings.EqualFold(CityName(venue), city):
+		return venue // the venue already ends with the city
+	default:
+		return venue + ", " + city
+	}
+}
+
+// SplitLocation splits a free-text location into its venue and city, the
+// reverse of ComposeLocation. A location without a comma is taken to be a city.
+func SplitLocation(location string) (venue, city string) {
+	city = CityName(location)
+	if idx := strings.LastIndex(location, ","); idx >= 0 {
+		venue = strings.TrimSpace(location[:idx])
+	}
+	return venue, city
+}
@@ -0,0 +1,68 @@
+package models
+
+import "testing"
+
+func TestComposeLocation(t *testing.T) {
+	tests := []struct {
+		venue, city string
+		want        string
+	}{
+		{"KICC", "Nairobi", "KICC, Nairobi"},
+		{" KICC, ", " Nairobi ", "KICC, Nairobi"},
+		{"", "Nairobi", "Nairobi"},
+		{"KICC", "", "KICC"},
+		{"Nairobi", "nairobi", "nairobi"},
+		{"KICC, Harambee Avenue, Nairobi", "Nairobi", "KICC, Harambee Avenue, Nairobi"},
+		{"", "", ""},
+	}
+
+	for _, tt := range tests {
+		if got := ComposeLocation(tt.venue, tt.city); got != tt.want {
+			t.Errorf("ComposeLocation(%q, %q) = %q, want %q", tt.venue, tt.city, got, tt.want)
+		}
+	}
+}
+
+func TestSplitLocation(t *testing.T) {
+	tests := []struct {
+		location    string
+		venue, city string
+	}{
+		{"KICC, Nairobi", "KICC", "Nairobi"},
+		{"KICC, Harambee Avenue, Nairobi", "KICC, Harambee Avenue", "Nairobi"},
+		{"Mombasa", "", "Mombasa"},
+		{"", "", ""},
+	}
+
+	for _, tt := range tests {
+		venue, city := SplitLocation(tt.location)
+		if venue != tt.venue || city != tt.city {
+			t.Errorf("SplitLocation(%q) = %q, %q, want %q, %q", tt.location, venue, city, tt.venue, tt.city)
+		}
+		// Splitting and composing again gives the same location
+		if got := ComposeLocation(venue, city); got != tt.location {
+			t.Errorf("ComposeLocation(SplitLocation(%q)) = %q", tt.location, got)
+		}
+	}
+}
+
+func TestPlaceRegion(t *testing.T) {
+	if got := PlaceRegion("diani-beach"); got != "Kwale" {
+		t.Errorf("PlaceRegion(diani-beach) = %q, want Kwale", got)
+	}
+	if got := PlaceRegion("atlantis"); got != OtherRegion {
+		t.Errorf("PlaceRegion(atlantis) = %q, want %q", got, OtherRegion)
+	}
+
+	// Every place has a region and a unique slug
+	seen := map[string]bool{}
+	for _, place := range Places {
+		if place.Region == "" {
+			t.Errorf("%s has no region", place.Name)
+		}
+		if seen[place.Slug()] {
+			t.Errorf("duplicate place slug %s", place.Slug())
+		}
+		seen[place.Slug()] = true
+	}
+}
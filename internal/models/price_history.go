@@ -0,0 +1,71 @@
+package models
+
+import (
+	"time"
+)
+
+// PriceIncreaseNoticeWindow is how long before an early-bird tier ends that
+// attendees are told the price is going up
+const PriceIncreaseNoticeWindow = 72 * time.Hour
+
+// TicketPriceChange records a ticket type's price changing
+type TicketPriceChange struct {
+	ID             int       `json:"id" db:"id"`
+	TicketTypeID   int       `json:"ticket_type_id" db:"ticket_type_id"`
+	EventID        int       `json:"event_id" db:"event_id"`
+	TicketTypeName string    `json:"ticket_type_name" db:"ticket_type_name"`
+	OldPrice       *int      `json:"old_price,omitempty" db:"old_price"` // Nil when the ticket type was created
+	NewPrice       int       `json:"new_price" db:"new_price"`
+	ChangedAt      time.Time `json:"changed_at" db:"changed_at"`
+}
+
+// IsDrop returns true if the change lowered the price
+func (c *TicketPriceChange) IsDrop() bool {
+	return c.OldPrice != nil && c.NewPrice < *c.OldPrice
+}
+
+// PriceDrop is a lowered price whose watchers have not been told yet, with
+// the ticket type's current price and sale window
+type PriceDrop struct {
+	ChangeID       int
+	EventID        int
+	TicketTypeName string
+	OldPrice       int
+	CurrentPrice   int
+	SaleStart      time.Time
+	SaleEnd        time.Time
+}
+
+// EndingTier is an early-bird tier that is about to end, with the price of
+// the tier that follows it
+type EndingTier struct {
+	TicketTypeID int
+	EventID      int
+	Name         string
+	Price        int
+	SaleEnd      time.Time
+	NextName     string
+	NextPrice    int
+}
+
+// EventWatcher is an attendee who saved an event
+type EventWatcher struct {
+	UserID int
+	Email  string
+	Name   string
+	Locale string
+}
+
+// UpcomingPriceIncrease returns the tier that replaces ticketType when its
+// sales end within PriceIncreaseNoticeWindow at a higher price, or nil
+func UpcomingPriceIncrease(ticketTypes []*TicketType, ticketType *TicketType, now time.Time) *TicketType {
+	if ticketType.NextTierID == nil || !ticketType.SaleEnd.After(now) || ticketType.SaleEnd.After(now.Add(PriceIncreaseNoticeWindow)) {
+		return nil
+	}
+	for _, next := range ticketTypes {
+		if next.ID == *ticketType.NextTierID && next.Price > ticketType.Price {
+			return next
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestUpcomingPriceIncrease(t *testing.T) {
+	now := time.Now()
+	nextTierID := 2
+	earlyBird := &TicketType{ID: 1, Price: 1000, SaleEnd: now.Add(48 * time.Hour), NextTierID: &nextTierID}
+	regular := &TicketType{ID: 2, Price: 1500, SaleEnd: now.Add(30 * 24 * time.Hour)}
+	ticketTypes := []*TicketType{earlyBird, regular}
+
+	if next := UpcomingPriceIncrease(ticketTypes, earlyBird, now); next != regular {
+		t.Errorf("expected the regular tier to replace the early bird, got %+v", next)
+	}
+	if next := UpcomingPriceIncrease(ticketTypes, regular, now); next != nil {
+		t.Errorf("expected no increase for a ticket type without a next tier, got %+v", next)
+	}
+
+	earlyBird.SaleEnd = now.Add(PriceIncreaseNoticeWindow + time.Hour)
+	if next := UpcomingPriceIncrease(ticketTypes, earlyBird, now); next != nil {
+		t.Error("expected no notice before the window")
+	}
+
+	earlyBird.SaleEnd = now.Add(time.Hour)
+	regular.Price = 800
+	if next := UpcomingPriceIncrease(ticketTypes, earlyBird, now); next != nil {
+		t.Error("expected no notice when the next tier is cheaper")
+	}
+}
+
+func TestTicketPriceChange_IsDrop(t *testing.T) {
+	oldPrice := 2000
+	if (&TicketPriceChange{NewPrice: 1000}).IsDrop() {
+		t.Error("expected the first price not to be a drop")
+	}
+	if !(&TicketPriceChange{OldPrice: &oldPrice, NewPrice: 1500}).IsDrop() {
+		t.Error("expected a lower price to be a drop")
+	}
+	if (&TicketPriceChange{OldPrice: &oldPrice, NewPrice: 2500}).IsDrop() {
+		t.Error("expected a higher price not to be a drop")
+	}
+}
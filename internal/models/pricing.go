@@ -0,0 +1,73 @@
+package models
+
+import (
+	"errors"
+	"math"
+)
+
+// Fee calculator limits
+const (
+	MaxCalculatorTicketPrice = 1000000.0
+	MaxCalculatorTickets     = 100000
+)
+
+// FeeSchedule is what organizers pay to sell tickets and withdraw their
+// earnings, as published on the pricing page
+type FeeSchedule struct {
+	PlatformFeePercentage    float64 `json:"platform_fee_percentage"`
+	MinWithdrawalAmount      float64 `json:"min_withdrawal_amount"`
+	MaxWithdrawalAmount      float64 `json:"max_withdrawal_amount"`
+	WithdrawalProcessingDays int     `json:"withdrawal_processing_days"`
+	MaxTicketsPerOrder       int     `json:"max_tickets_per_order"`
+}
+
+// FeeSchedule returns the fee schedule the settings set
+func (s *SystemSettings) FeeSchedule() *FeeSchedule {
+	return &FeeSchedule{
+		PlatformFeePercentage:    s.PlatformFeePercentage,
+		MinWithdrawalAmount:      s.MinWithdrawalAmount,
+		MaxWithdrawalAmount:      s.MaxWithdrawalAmount,
+		WithdrawalProcessingDays: s.WithdrawalProcessingDays,
+		MaxTicketsPerOrder:       s.MaxTicketsPerOrder,
+	}
+}
+
+// FeeBreakdown is what an organizer earns from selling tickets, in
+// shillings
+type FeeBreakdown struct {
+	TicketPrice float64 `json:"ticket_price"`
+	Quantity    int     `json:"quantity"`
+	Gross       float64 `json:"gross"`
+	PlatformFee float64 `json:"platform_fee"`
+	Payout      float64 `json:"payout"`
+}
+
+// PayoutPerTicket returns what the organizer earns from each ticket
+func (b *FeeBreakdown) PayoutPerTicket() float64 {
+	if b.Quantity == 0 {
+		return 0
+	}
+	return b.Payout / float64(b.Quantity)
+}
+
+// Calculate returns what an organizer earns from selling quantity tickets
+// at price. The fee is taken from the total and rounded to the nearest cent,
+// as it is when sales are posted.
+func (f *FeeSchedule) Calculate(price float64, quantity int) (*FeeBreakdown, error) {
+	if price < 0 || price > MaxCalculatorTicketPrice || math.IsNaN(price) {
+		return nil, errors.New("ticket price must be between 0 and 1,000,000")
+	}
+	if quantity < 1 || quantity > MaxCalculatorTickets {
+		return nil, errors.New("number of tickets must be between 1 and 100,000")
+	}
+
+	grossCents := math.Round(price*100) * float64(quantity)
+	feeCents := math.Round(grossCents * f.PlatformFeePercentage / 100)
+	return &FeeBreakdown{
+		TicketPrice: price,
+		Quantity:    quantity,
+		Gross:       grossCents / 100,
+		PlatformFee: feeCents / 100,
+		Payout:      (grossCents - feeCents) / 100,
+	}, nil
+}
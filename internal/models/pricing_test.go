@@ -0,0 +1,48 @@
+package models
+
+import "testing"
+
+func TestFeeSchedule_Calculate(t *testing.T) {
+	schedule := DefaultSettings().FeeSchedule()
+	schedule.PlatformFeePercentage = 5
+
+	breakdown, err := schedule.Calculate(1000, 100)
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+	if breakdown.Gross != 100000 || breakdown.PlatformFee != 5000 || breakdown.Payout != 95000 {
+		t.Errorf("expected KSh 100000 gross, 5000 fee and 95000 payout, got %+v", breakdown)
+	}
+	if breakdown.PayoutPerTicket() != 950 {
+		t.Errorf("expected KSh 950 per ticket, got %.2f", breakdown.PayoutPerTicket())
+	}
+
+	// The fee is rounded to the nearest cent
+	schedule.PlatformFeePercentage = 2.5
+	breakdown, err = schedule.Calculate(9.99, 3)
+	if err != nil {
+		t.Fatalf("Calculate() error = %v", err)
+	}
+	if breakdown.PlatformFee != 0.75 || breakdown.Payout != 29.22 {
+		t.Errorf("expected a 0.75 fee and 29.22 payout, got %+v", breakdown)
+	}
+
+	free, err := schedule.Calculate(0, 50)
+	if err != nil || free.PlatformFee != 0 || free.Payout != 0 {
+		t.Errorf("expected free tickets to cost nothing, got %+v (%v)", free, err)
+	}
+
+	for _, tt := range []struct {
+		price    float64
+		quantity int
+	}{
+		{-1, 10},
+		{MaxCalculatorTicketPrice + 1, 10},
+		{100, 0},
+		{100, MaxCalculatorTickets + 1},
+	} {
+		if _, err := schedule.Calculate(tt.price, tt.quantity); err == nil {
+			t.Errorf("Calculate(%v, %d) expected an error", tt.price, tt.quantity)
+		}
+	}
+}
@@ -0,0 +1,31 @@
+package models
+
+import "time"
+
+// PrivacyPreferences records what a visitor agreed to beyond the cookies the
+// site needs to work. Anonymous visitors are recognized by the consent ID in
+// their cookie; once signed in, the choice they save is theirs on every
+// browser.
+type PrivacyPreferences struct {
+	ID        int    `json:"id" db:"id"`
+	ConsentID string `json:"consent_id" db:"consent_id"`
+	UserID    *int   `json:"user_id,omitempty" db:"user_id"`
+	// Analytics allows page view tracking and recording where visitors came from
+	Analytics bool `json:"analytics" db:"analytics"`
+	// Marketing allows promotional emails, like organizers' new event announcements
+	Marketing bool      `json:"marketing" db:"marketing"`
+	CreatedAt time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// AllowsAnalytics reports whether the preferences allow analytics tracking.
+// Visitors who haven't chosen yet are not tracked.
+func (p *PrivacyPreferences) AllowsAnalytics() bool {
+	return p != nil && p.Analytics
+}
+
+// AllowsMarketing reports whether the preferences allow marketing emails.
+// Users who haven't chosen yet are not sent any.
+func (p *PrivacyPreferences) AllowsMarketing() bool {
+	return p != nil && p.Marketing
+}
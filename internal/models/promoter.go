@@ -0,0 +1,118 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+// Limits on promoter links
+const (
+	MaxPromoterNameLength = 100
+	MaxPromoterCodeLength = 50
+)
+
+// PromoterLink is a named tracking link an organizer gives a promoter for
+// one of their events. Orders placed by buyers who followed the link count
+// towards the promoter's sales, earning them CommissionRate percent. Links
+// are deactivated rather than deleted so their sales stay attributed.
+type PromoterLink struct {
+	ID             int       `json:"id" db:"id"`
+	EventID        int       `json:"event_id" db:"event_id"`
+	Code           string    `json:"code" db:"code"`
+	Name           string    `json:"name" db:"name"`
+	CommissionRate float64   `json:"commission_rate" db:"commission_rate"` // Percentage of the revenue the link sells
+	Active         bool      `json:"active" db:"active"`                   // Inactive links no longer earn sales
+	CreatedAt      time.Time `json:"created_at" db:"created_at"`
+
+	// Joined from the event
+	EventTitle string `json:"event_title" db:"event_title"`
+	EventSlug  string `json:"event_slug" db:"event_slug"`
+}
+
+// Path returns the link's URL path: the event's page with the link's code
+func (l *PromoterLink) Path() string {
+	event := &Event{ID: l.EventID, Slug: l.EventSlug}
+	return event.Path() + "?ref=" + l.Code
+}
+
+// PromoterLinkCreateRequest represents a request to create a promoter link
+type PromoterLinkCreateRequest struct {
+	EventID        int     `json:"event_id"`
+	Name           string  `json:"name"`
+	Code           string  `json:"code"`
+	CommissionRate float64 `json:"commission_rate"`
+}
+
+// Validate validates the promoter link request. The code defaults to the
+// promoter's name and is normalized like a slug ("DJ Kevo" -> "dj-kevo").
+func (r *PromoterLinkCreateRequest) Validate() error {
+	if r.EventID <= 0 {
+		return errors.New("event is required")
+	}
+
+	r.Name = strings.TrimSpace(r.Name)
+	if r.Name == "" {
+		return errors.New("promoter name is required")
+	}
+	if len(r.Name) > MaxPromoterNameLength {
+		return fmt.Errorf("promoter name must be %d characters or less", MaxPromoterNameLength)
+	}
+
+	if strings.TrimSpace(r.Code) == "" {
+		r.Code = r.Name
+	}
+	r.Code = PromoterCode(r.Code)
+	if r.Code == "" {
+		return errors.New("code must contain letters or numbers")
+	}
+	if len(r.Code) > MaxPromoterCodeLength {
+		return fmt.Errorf("code must be %d characters or less", MaxPromoterCodeLength)
+	}
+
+	if math.IsNaN(r.CommissionRate) || r.CommissionRate < 0 || r.CommissionRate > 100 {
+		return errors.New("commission must be between 0 and 100 percent")
+	}
+	return nil
+}
+
+// PromoterCode normalizes a promoter link code into its URL form. Codes in
+// links are normalized the same way, so they match regardless of case.
+func PromoterCode(code string) string {
+	return strings.Trim(citySlugInvalidChars.ReplaceAllString(strings.ToLower(strings.TrimSpace(code)), "-"), "-")
+}
+
+// PromoterLinkStats is a promoter link's completed sales and the commission
+// they earned
+type PromoterLinkStats struct {
+	Link        *PromoterLink `json:"link"`
+	Orders      int           `json:"orders"`
+	TicketsSold int           `json:"tickets_sold"`
+	Revenue     float64       `json:"revenue"`
+	Commission  float64       `json:"commission"`
+}
+
+// PromoterPayoutSummary totals the sales and commission of an organizer's
+// promoter links
+type PromoterPayoutSummary struct {
+	Links       []*PromoterLinkStats `json:"links"`
+	Orders      int                  `json:"orders"`
+	TicketsSold int                  `json:"tickets_sold"`
+	Revenue     float64              `json:"revenue"`
+	Commission  float64              `json:"commission"`
+}
+
+// NewPromoterPayoutSummary computes each link's commission and totals them
+func NewPromoterPayoutSummary(links []*PromoterLinkStats) *PromoterPayoutSummary {
+	summary := &PromoterPayoutSummary{Links: links}
+	for _, stats := range links {
+		stats.Commission = math.Round(stats.Revenue*stats.Link.CommissionRate) / 100
+		summary.Orders += stats.Orders
+		summary.TicketsSold += stats.TicketsSold
+		summary.Revenue += stats.Revenue
+		summary.Commission += stats.Commission
+	}
+	return summary
+}
@@ -0,0 +1,54 @@
+package models
+
+import "testing"
+
+func TestPromoterLinkCreateRequest_Validate(t *testing.T) {
+	req := &PromoterLinkCreateRequest{EventID: 1, Name: "  DJ Kevo ", CommissionRate: 10}
+	if err := req.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if req.Name != "DJ Kevo" || req.Code != "dj-kevo" {
+		t.Errorf("expected the code to default to the name, got %q", req.Code)
+	}
+
+	custom := &PromoterLinkCreateRequest{EventID: 1, Name: "Campus Reps", Code: "UoN_2026!"}
+	if err := custom.Validate(); err != nil || custom.Code != "uon-2026" {
+		t.Errorf("expected a normalized code, got %q, %v", custom.Code, err)
+	}
+
+	invalid := []*PromoterLinkCreateRequest{
+		{Name: "DJ Kevo"},
+		{EventID: 1},
+		{EventID: 1, Name: "DJ Kevo", Code: "!!!"},
+		{EventID: 1, Name: "DJ Kevo", CommissionRate: 120},
+		{EventID: 1, Name: "DJ Kevo", CommissionRate: -5},
+	}
+	for _, req := range invalid {
+		if err := req.Validate(); err == nil {
+			t.Errorf("expected an error for %+v", req)
+		}
+	}
+}
+
+func TestPromoterLink_Path(t *testing.T) {
+	link := &PromoterLink{EventID: 4, EventSlug: "nairobi-jazz-night", Code: "dj-kevo"}
+	if got := link.Path(); got != "/events/nairobi-jazz-night?ref=dj-kevo" {
+		t.Errorf("unexpected path %q", got)
+	}
+}
+
+func TestNewPromoterPayoutSummary(t *testing.T) {
+	links := []*PromoterLinkStats{
+		{Link: &PromoterLink{CommissionRate: 10}, Orders: 3, TicketsSold: 5, Revenue: 2500},
+		{Link: &PromoterLink{CommissionRate: 7.5}, Orders: 1, TicketsSold: 1, Revenue: 333.33},
+		{Link: &PromoterLink{}, Orders: 2, TicketsSold: 2, Revenue: 1000},
+	}
+
+	summary := NewPromoterPayoutSummary(links)
+	if links[0].Commission != 250 || links[1].Commission != 25 || links[2].Commission != 0 {
+		t.Errorf("unexpected commissions: %v, %v, %v", links[0].Commission, links[1].Commission, links[2].Commission)
+	}
+	if summary.Orders != 6 || summary.TicketsSold != 8 || summary.Commission != 275 {
+		t.Errorf("unexpected totals: %+v", summary)
+	}
+}
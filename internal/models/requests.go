@@ -11,6 +11,8 @@ type TicketTypeCreateRequest struct {
 	Quantity    int       `json:"quantity" validate:"required,min=1"`
 	SaleStart   time.Time `json:"sale_start" validate:"required"`
 	SaleEnd     time.Time `json:"sale_end" validate:"required"`
+	// PayWhatYouWant makes Price the least buyers can pay
+	PayWhatYouWant bool `json:"pay_what_you_want"`
 }
 
 // TicketTypeUpdateRequest represents a request to update a ticket type
@@ -21,4 +23,6 @@ type TicketTypeUpdateRequest struct {
 	Quantity    int       `json:"quantity" validate:"required,min=1"`
 	SaleStart   time.Time `json:"sale_start" validate:"required"`
 	SaleEnd     time.Time `json:"sale_end" validate:"required"`
+	// PayWhatYouWant makes Price the least buyers can pay
+	PayWhatYouWant bool `json:"pay_what_you_want"`
 }
\ No newline at end of file
@@ -0,0 +1,121 @@
+package models
+
+import (
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+	"unicode"
+)
+
+// MaxSavedSearchesPerUser is how many searches an attendee can save
+const MaxSavedSearchesPerUser = 20
+
+// SavedSearch is a search an attendee saved to be emailed about matching
+// new events. Empty criteria match every event.
+type SavedSearch struct {
+	ID         int       `json:"id" db:"id"`
+	UserID     int       `json:"user_id" db:"user_id"`
+	Query      string    `json:"query" db:"query"`
+	CategoryID int       `json:"category_id" db:"category_id"` // 0 for any category
+	Location   string    `json:"location" db:"location"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+
+	// Related data
+	CategoryName string `json:"category_name,omitempty"`
+}
+
+// Normalize trims the search's criteria
+func (s *SavedSearch) Normalize() {
+	s.Query = strings.Join(strings.Fields(s.Query), " ")
+	s.Location = strings.Join(strings.Fields(s.Location), " ")
+}
+
+// Validate checks the saved search has at least one criterion
+func (s *SavedSearch) Validate() error {
+	if s.Query == "" && s.CategoryID == 0 && s.Location == "" {
+		return errors.New("enter a search, category or location to save")
+	}
+	if len(s.Query) > 200 {
+		return errors.New("search must be at most 200 characters")
+	}
+	if len(s.Location) > 100 {
+		return errors.New("location must be at most 100 characters")
+	}
+	return nil
+}
+
+// Label describes the search for the dashboard and emails, listing its
+// criteria without words that would need translating
+func (s *SavedSearch) Label() string {
+	var parts []string
+	if s.Query != "" {
+		parts = append(parts, `"`+s.Query+`"`)
+	}
+	if s.CategoryName != "" {
+		parts = append(parts, s.CategoryName)
+	}
+	if s.Location != "" {
+		parts = append(parts, s.Location)
+	}
+	return strings.Join(parts, ", ")
+}
+
+// SearchURL returns the events listing filtered by the search
+func (s *SavedSearch) SearchURL() string {
+	values := url.Values{}
+	if s.Query != "" {
+		values.Set("q", s.Query)
+	}
+	if s.CategoryID != 0 {
+		values.Set("category", strconv.Itoa(s.CategoryID))
+	}
+	if s.Location != "" {
+		values.Set("location", s.Location)
+	}
+	return "/events?" + values.Encode()
+}
+
+// Matches returns true if the event meets every criterion of the search.
+// Like the events search, each word of the query must begin a word of the
+// event's title, description or location.
+func (s *SavedSearch) Matches(event *Event) bool {
+	if s.CategoryID != 0 && event.CategoryID != s.CategoryID {
+		return false
+	}
+	if s.Location != "" && !strings.Contains(strings.ToLower(event.Location), strings.ToLower(s.Location)) {
+		return false
+	}
+
+	words := searchWords(event.Title + " " + event.Description + " " + event.Location)
+	for _, term := range searchWords(s.Query) {
+		found := false
+		for _, word := range words {
+			if strings.HasPrefix(word, term) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// searchWords splits text into lowercase words
+func searchWords(text string) []string {
+	return strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// SavedSearchOwner is an attendee with saved searches, to email about matches
+type SavedSearchOwner struct {
+	UserID   int
+	Email    string
+	Name     string
+	Locale   string
+	Searches []*SavedSearch
+}
@@ -0,0 +1,58 @@
+package models
+
+import "testing"
+
+func TestSavedSearch_Matches(t *testing.T) {
+	event := &Event{
+		Title:       "Nairobi Jazz Festival",
+		Description: "Three nights of live music at the arboretum",
+		Location:    "Nairobi Arboretum, Nairobi",
+		CategoryID:  2,
+	}
+
+	tests := []struct {
+		name   string
+		search SavedSearch
+		want   bool
+	}{
+		{"query words", SavedSearch{Query: "jazz live"}, true},
+		{"word prefix", SavedSearch{Query: "fest"}, true},
+		{"case and punctuation", SavedSearch{Query: "JAZZ!"}, true},
+		{"missing word", SavedSearch{Query: "jazz comedy"}, false},
+		{"middle of a word", SavedSearch{Query: "azz"}, false},
+		{"category", SavedSearch{CategoryID: 2}, true},
+		{"other category", SavedSearch{Query: "jazz", CategoryID: 3}, false},
+		{"location", SavedSearch{Location: "nairobi"}, true},
+		{"other location", SavedSearch{Query: "jazz", Location: "Mombasa"}, false},
+	}
+	for _, tt := range tests {
+		if got := tt.search.Matches(event); got != tt.want {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestSavedSearch_Validate(t *testing.T) {
+	search := &SavedSearch{Query: "  live   jazz ", Location: " Nairobi "}
+	search.Normalize()
+	if search.Query != "live jazz" || search.Location != "Nairobi" {
+		t.Errorf("expected trimmed criteria, got %q and %q", search.Query, search.Location)
+	}
+	if err := search.Validate(); err != nil {
+		t.Errorf("expected a valid search, got %v", err)
+	}
+
+	if err := (&SavedSearch{}).Validate(); err == nil {
+		t.Error("expected a search without criteria to be invalid")
+	}
+}
+
+func TestSavedSearch_LabelAndURL(t *testing.T) {
+	search := &SavedSearch{Query: "live jazz", CategoryID: 2, CategoryName: "Music", Location: "Nairobi"}
+	if got := search.Label(); got != `"live jazz", Music, Nairobi` {
+		t.Errorf("unexpected label %q", got)
+	}
+	if got := search.SearchURL(); got != "/events?category=2&location=Nairobi&q=live+jazz" {
+		t.Errorf("unexpected search URL %q", got)
+	}
+}
@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// SearchSuggestionSource is an upcoming published event with the organizer,
+// venue and category details that search suggestions are built from
+type SearchSuggestionSource struct {
+	EventID       int       `json:"event_id" db:"id"`
+	Title         string    `json:"title" db:"title"`
+	StartDate     time.Time `json:"start_date" db:"start_date"`
+	OrganizerID   int       `json:"organizer_id" db:"organizer_id"`
+	OrganizerName string    `json:"organizer_name" db:"organizer_name"`
+	Location      string    `json:"location" db:"location"`
+	CategoryName  string    `json:"category_name" db:"category_name"`
+	CategorySlug  string    `json:"category_slug" db:"category_slug"`
+	TicketsSold   int       `json:"tickets_sold" db:"tickets_sold"`
+}
@@ -0,0 +1,77 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+)
+
+// ErrSessionNotFound is returned for sessions that don't exist or have expired
+var ErrSessionNotFound = errors.New("session not found")
+
+// Session represents a user session. Sessions kept by the session store hold
+// the browser session's values in Data, the cookie only carrying its signed
+// ID; login sessions created by AuthService have no data.
+type Session struct {
+	ID         string    `json:"-" db:"id"` // Hash of the session ID, see HashSessionID
+	UserID     *int      `json:"user_id,omitempty" db:"user_id"`
+	Data       string    `json:"-" db:"data"`
+	ExpiresAt  time.Time `json:"expires_at" db:"expires_at"`
+	IPAddress  string    `json:"ip_address" db:"ip_address"` // Address the session was last saved from
+	UserAgent  string    `json:"user_agent" db:"user_agent"`
+	LastSeenAt time.Time `json:"last_seen_at" db:"last_seen_at"`
+	CreatedAt  time.Time `json:"created_at" db:"created_at"`
+}
+
+// Device describes the browser and operating system of the session's user
+// agent, such as "Chrome on Windows"
+func (s *Session) Device() string {
+	ua := s.UserAgent
+
+	browser := ""
+	switch {
+	case strings.Contains(ua, "Edg/"):
+		browser = "Edge"
+	case strings.Contains(ua, "OPR/"):
+		browser = "Opera"
+	case strings.Contains(ua, "Firefox/"):
+		browser = "Firefox"
+	case strings.Contains(ua, "Chrome/"):
+		browser = "Chrome"
+	case strings.Contains(ua, "Safari/"):
+		browser = "Safari"
+	}
+
+	system := ""
+	switch {
+	case strings.Contains(ua, "iPhone"), strings.Contains(ua, "iPad"):
+		system = "iOS"
+	case strings.Contains(ua, "Android"):
+		system = "Android"
+	case strings.Contains(ua, "Windows"):
+		system = "Windows"
+	case strings.Contains(ua, "Mac OS X"):
+		system = "macOS"
+	case strings.Contains(ua, "Linux"):
+		system = "Linux"
+	}
+
+	switch {
+	case browser != "" && system != "":
+		return browser + " on " + system
+	case browser != "":
+		return browser
+	case system != "":
+		return "Browser on " + system
+	}
+	return "Unknown device"
+}
+
+// HashSessionID returns the stored form of a session ID, so the sessions
+// table can't be used to take over sessions
+func HashSessionID(id string) string {
+	sum := sha256.Sum256([]byte(id))
+	return hex.EncodeToString(sum[:])
+}
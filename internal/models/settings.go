@@ -14,6 +14,13 @@ type SystemSettings struct {
 	EventModerationEnabled bool     `json:"event_moderation_enabled" db:"event_moderation_enabled"`
 	AutoApproveOrganizers bool      `json:"auto_approve_organizers" db:"auto_approve_organizers"`
 	MaintenanceMode       bool      `json:"maintenance_mode" db:"maintenance_mode"`
+	RequireTwoFactorAdmins     bool `json:"require_2fa_admins" db:"require_2fa_admins"`
+	RequireTwoFactorOrganizers bool `json:"require_2fa_organizers" db:"require_2fa_organizers"`
+	ReputationFastTrack         bool `json:"reputation_fast_track_enabled" db:"reputation_fast_track_enabled"`
+	ReputationAutoPublishScore  int  `json:"reputation_auto_publish_score" db:"reputation_auto_publish_score"`
+	ReputationMinApprovedEvents int  `json:"reputation_min_approved_events" db:"reputation_min_approved_events"`
+	MaxTicketsPerOrder          int       `json:"max_tickets_per_order" db:"max_tickets_per_order"`
+	EmailReplyTo                string    `json:"email_reply_to" db:"email_reply_to"` // Empty sends emails without a Reply-To
 	CreatedAt             time.Time `json:"created_at" db:"created_at"`
 	UpdatedAt             time.Time `json:"updated_at" db:"updated_at"`
 }
@@ -27,6 +34,13 @@ type SettingsUpdateRequest struct {
 	EventModerationEnabled   *bool    `json:"event_moderation_enabled"`
 	AutoApproveOrganizers    *bool    `json:"auto_approve_organizers"`
 	MaintenanceMode          *bool    `json:"maintenance_mode"`
+	RequireTwoFactorAdmins     *bool  `json:"require_2fa_admins"`
+	RequireTwoFactorOrganizers *bool  `json:"require_2fa_organizers"`
+	ReputationFastTrack         *bool `json:"reputation_fast_track_enabled"`
+	ReputationAutoPublishScore  *int  `json:"reputation_auto_publish_score" validate:"omitempty,min=0,max=100"`
+	ReputationMinApprovedEvents *int  `json:"reputation_min_approved_events" validate:"omitempty,min=0,max=100"`
+	MaxTicketsPerOrder          *int     `json:"max_tickets_per_order" validate:"omitempty,min=1,max=100"`
+	EmailReplyTo                *string  `json:"email_reply_to" validate:"omitempty,email"`
 }
 
 // DefaultSettings returns the default system settings
@@ -39,6 +53,12 @@ func DefaultSettings() *SystemSettings {
 		EventModerationEnabled:   true, // Enable moderation by default
 		AutoApproveOrganizers:    false, // Manual organizer approval
 		MaintenanceMode:          false, // Not in maintenance mode
+		RequireTwoFactorAdmins:     false, // Two-factor authentication is optional
+		RequireTwoFactorOrganizers: false,
+		ReputationFastTrack:         true, // Trusted organizers skip moderation
+		ReputationAutoPublishScore:  70,
+		ReputationMinApprovedEvents: 3,
+		MaxTicketsPerOrder:          10,
 		CreatedAt:                time.Now(),
 		UpdatedAt:                time.Now(),
 	}
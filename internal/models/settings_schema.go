@@ -0,0 +1,293 @@
+package models
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// SettingGroup groups related system settings on the admin settings page
+type SettingGroup string
+
+const (
+	SettingGroupPayments   SettingGroup = "payments"
+	SettingGroupEmail      SettingGroup = "email"
+	SettingGroupLimits     SettingGroup = "limits"
+	SettingGroupModeration SettingGroup = "moderation"
+	SettingGroupSecurity   SettingGroup = "security"
+)
+
+// SettingGroups lists the setting groups in the order they are shown
+var SettingGroups = []SettingGroup{
+	SettingGroupPayments,
+	SettingGroupEmail,
+	SettingGroupLimits,
+	SettingGroupModeration,
+	SettingGroupSecurity,
+}
+
+// DisplayName returns a human-readable group name
+func (g SettingGroup) DisplayName() string {
+	switch g {
+	case SettingGroupPayments:
+		return "Payments"
+	case SettingGroupEmail:
+		return "Email"
+	case SettingGroupLimits:
+		return "Limits"
+	case SettingGroupModeration:
+		return "Moderation"
+	case SettingGroupSecurity:
+		return "Security & Access"
+	default:
+		return string(g)
+	}
+}
+
+// SettingType is the type of a setting's value
+type SettingType string
+
+const (
+	SettingTypeBool  SettingType = "bool"
+	SettingTypeInt   SettingType = "int"
+	SettingTypeFloat SettingType = "float"
+	SettingTypeEmail SettingType = "email"
+)
+
+// SettingDefinition describes a system setting: the group it is shown in,
+// the type of its value and the values it accepts
+type SettingDefinition struct {
+	Key         string // JSON name on SystemSettings and SettingsUpdateRequest
+	Group       SettingGroup
+	Type        SettingType
+	Label       string
+	Description string
+	Unit        string // "$" before or "%" after number inputs
+	Min         float64
+	Max         float64 // 0 for no upper bound
+	Required    bool
+}
+
+// SettingsSchema lists every system setting admins can change
+var SettingsSchema = []SettingDefinition{
+	{Key: "platform_fee_percentage", Group: SettingGroupPayments, Type: SettingTypeFloat, Label: "Platform Fee Percentage", Description: "Percentage fee charged on each ticket sale, as published on the pricing page", Unit: "%", Min: 0, Max: 50, Required: true},
+	{Key: "min_withdrawal_amount", Group: SettingGroupPayments, Type: SettingTypeFloat, Label: "Minimum Withdrawal Amount", Description: "Minimum amount organizers can withdraw", Unit: "$", Min: 1, Required: true},
+	{Key: "max_withdrawal_amount", Group: SettingGroupPayments, Type: SettingTypeFloat, Label: "Maximum Withdrawal Amount", Description: "Maximum amount organizers can withdraw at once", Unit: "$", Min: 1, Required: true},
+	{Key: "withdrawal_processing_days", Group: SettingGroupPayments, Type: SettingTypeInt, Label: "Withdrawal Processing Days", Description: "Number of business days to process withdrawals", Min: 1, Max: 30, Required: true},
+	{Key: "email_reply_to", Group: SettingGroupEmail, Type: SettingTypeEmail, Label: "Reply-To Address", Description: "Where replies to platform emails go. Leave empty to send emails without one."},
+	{Key: "max_tickets_per_order", Group: SettingGroupLimits, Type: SettingTypeInt, Label: "Maximum Tickets per Order", Description: "Most tickets a buyer can reserve at once", Min: 1, Max: 100, Required: true},
+	{Key: "event_moderation_enabled", Group: SettingGroupModeration, Type: SettingTypeBool, Label: "Enable Event Moderation", Description: "Require admin approval before events are published"},
+	{Key: "auto_approve_organizers", Group: SettingGroupModeration, Type: SettingTypeBool, Label: "Auto-Approve Organizers", Description: "Automatically approve new organizer registrations"},
+	{Key: "reputation_fast_track_enabled", Group: SettingGroupModeration, Type: SettingTypeBool, Label: "Reputation Fast-Track", Description: "Let organizers with a good track record publish without waiting for moderation"},
+	{Key: "reputation_auto_publish_score", Group: SettingGroupModeration, Type: SettingTypeInt, Label: "Auto-Publish Reputation Score", Description: "Minimum score (0-100) an organizer needs to skip moderation", Min: 0, Max: 100},
+	{Key: "reputation_min_approved_events", Group: SettingGroupModeration, Type: SettingTypeInt, Label: "Minimum Approved Events", Description: "New organizers are always moderated until this many events are approved", Min: 0, Max: 100},
+	{Key: "maintenance_mode", Group: SettingGroupSecurity, Type: SettingTypeBool, Label: "Maintenance Mode", Description: "Put the platform in maintenance mode (only admins can access)"},
+	{Key: "require_2fa_admins", Group: SettingGroupSecurity, Type: SettingTypeBool, Label: "Require Two-Factor for Admins", Description: "Admins must enable two-factor authentication before using the admin dashboard"},
+	{Key: "require_2fa_organizers", Group: SettingGroupSecurity, Type: SettingTypeBool, Label: "Require Two-Factor for Organizers", Description: "Organizers must enable two-factor authentication before managing events"},
+}
+
+// settingEmailPattern matches the email addresses settings accept
+var settingEmailPattern = regexp.MustCompile(`^[a-zA-Z0-9._%+-]+@[a-zA-Z0-9.-]+\.[a-zA-Z]{2,}$`)
+
+// SettingsInGroup returns the settings shown in a group, in schema order
+func SettingsInGroup(group SettingGroup) []SettingDefinition {
+	var definitions []SettingDefinition
+	for _, definition := range SettingsSchema {
+		if definition.Group == group {
+			definitions = append(definitions, definition)
+		}
+	}
+	return definitions
+}
+
+// LookupSetting returns the definition of the setting with the key
+func LookupSetting(key string) (SettingDefinition, bool) {
+	for _, definition := range SettingsSchema {
+		if definition.Key == key {
+			return definition, true
+		}
+	}
+	return SettingDefinition{}, false
+}
+
+// Parse converts a submitted form value to the setting's type and checks
+// it is one the setting accepts
+func (d SettingDefinition) Parse(raw string) (interface{}, error) {
+	raw = strings.TrimSpace(raw)
+
+	var value interface{}
+	switch d.Type {
+	case SettingTypeBool:
+		value = raw == "on" || raw == "true"
+	case SettingTypeInt:
+		number, err := strconv.Atoi(raw)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a whole number", strings.ToLower(d.Label))
+		}
+		value = number
+	case SettingTypeFloat:
+		number, err := strconv.ParseFloat(raw, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%s must be a number", strings.ToLower(d.Label))
+		}
+		value = number
+	default:
+		value = raw
+	}
+
+	if err := d.Validate(value); err != nil {
+		return nil, err
+	}
+	return value, nil
+}
+
+// Validate checks a value is one the setting accepts. Numbers may be of any
+// numeric type, as they are when decoded from JSON.
+func (d SettingDefinition) Validate(value interface{}) error {
+	switch d.Type {
+	case SettingTypeBool:
+		if _, ok := value.(bool); !ok {
+			return fmt.Errorf("%s must be on or off", strings.ToLower(d.Label))
+		}
+	case SettingTypeInt, SettingTypeFloat:
+		number, ok := settingNumber(value)
+		if !ok {
+			return fmt.Errorf("%s must be a number", strings.ToLower(d.Label))
+		}
+		if d.Type == SettingTypeInt && number != float64(int(number)) {
+			return fmt.Errorf("%s must be a whole number", strings.ToLower(d.Label))
+		}
+		if d.Max > 0 && (number < d.Min || number > d.Max) {
+			return fmt.Errorf("%s must be between %s and %s", strings.ToLower(d.Label), d.formatNumber(d.Min), d.formatNumber(d.Max))
+		}
+		if number < d.Min {
+			return fmt.Errorf("%s must be at least %s", strings.ToLower(d.Label), d.formatNumber(d.Min))
+		}
+	case SettingTypeEmail:
+		email, ok := value.(string)
+		if !ok || (email != "" && !settingEmailPattern.MatchString(email)) {
+			return fmt.Errorf("%s must be a valid email address", strings.ToLower(d.Label))
+		}
+	}
+	return nil
+}
+
+// formatNumber formats a number in the setting's unit
+func (d SettingDefinition) formatNumber(number float64) string {
+	formatted := strconv.FormatFloat(number, 'f', -1, 64)
+	switch d.Unit {
+	case "$":
+		return "$" + formatted
+	case "":
+		return formatted
+	default:
+		return formatted + d.Unit
+	}
+}
+
+// settingNumber returns a numeric value as a float64
+func settingNumber(value interface{}) (float64, bool) {
+	switch number := value.(type) {
+	case int:
+		return float64(number), true
+	case float64:
+		return number, true
+	default:
+		return 0, false
+	}
+}
+
+// ParseSettingsForm builds a settings update from the admin settings form,
+// along with an error for each field whose value isn't accepted. Number
+// fields left empty are not updated, and unchecked checkboxes are off.
+func ParseSettingsForm(form url.Values) (*SettingsUpdateRequest, map[string]string) {
+	values := make(map[string]interface{})
+	errors := make(map[string]string)
+	for _, definition := range SettingsSchema {
+		raw := form.Get(definition.Key)
+		if strings.TrimSpace(raw) == "" && (definition.Type == SettingTypeInt || definition.Type == SettingTypeFloat) {
+			continue
+		}
+		value, err := definition.Parse(raw)
+		if err != nil {
+			errors[definition.Key] = capitalize(err.Error())
+			continue
+		}
+		values[definition.Key] = value
+	}
+
+	req := &SettingsUpdateRequest{}
+	if data, err := json.Marshal(values); err == nil {
+		_ = json.Unmarshal(data, req)
+	}
+	return req, errors
+}
+
+// capitalize upper-cases the first letter of a message
+func capitalize(message string) string {
+	if message == "" {
+		return message
+	}
+	return strings.ToUpper(message[:1]) + message[1:]
+}
+
+// Validate checks every setting the request changes against the schema
+func (r *SettingsUpdateRequest) Validate() error {
+	data, err := json.Marshal(r)
+	if err != nil {
+		return err
+	}
+	values := make(map[string]interface{})
+	if err := json.Unmarshal(data, &values); err != nil {
+		return err
+	}
+
+	// Checked in schema order so the same request always reports the same error
+	for _, definition := range SettingsSchema {
+		value, ok := values[definition.Key]
+		if !ok || value == nil {
+			continue
+		}
+		if err := definition.Validate(value); err != nil {
+			return err
+		}
+	}
+
+	if r.MinWithdrawalAmount != nil && r.MaxWithdrawalAmount != nil {
+		if *r.MinWithdrawalAmount >= *r.MaxWithdrawalAmount {
+			return fmt.Errorf("minimum withdrawal amount must be less than maximum")
+		}
+	}
+	return nil
+}
+
+// FormValues returns each setting's value as the admin settings form shows
+// it, keyed by setting. Checkboxes that are on have the value "on".
+func (s *SystemSettings) FormValues() map[string]string {
+	values := make(map[string]interface{})
+	if data, err := json.Marshal(s); err == nil {
+		_ = json.Unmarshal(data, &values)
+	}
+
+	formValues := make(map[string]string)
+	for _, definition := range SettingsSchema {
+		switch value := values[definition.Key].(type) {
+		case bool:
+			if value {
+				formValues[definition.Key] = "on"
+			}
+		case float64:
+			if definition.Type == SettingTypeFloat {
+				formValues[definition.Key] = strconv.FormatFloat(value, 'f', 2, 64)
+			} else {
+				formValues[definition.Key] = strconv.FormatFloat(value, 'f', 0, 64)
+			}
+		case string:
+			formValues[definition.Key] = value
+		}
+	}
+	return formValues
+}
@@ -0,0 +1,120 @@
+package models
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestParseSettingsForm(t *testing.T) {
+	form := url.Values{
+		"platform_fee_percentage":    {"7.5"},
+		"min_withdrawal_amount":      {""},
+		"withdrawal_processing_days": {"5"},
+		"max_tickets_per_order":      {"20"},
+		"email_reply_to":             {"support@example.com"},
+		"maintenance_mode":           {"on"},
+	}
+
+	req, errors := ParseSettingsForm(form)
+	if len(errors) != 0 {
+		t.Fatalf("ParseSettingsForm() errors = %v", errors)
+	}
+	if req.PlatformFeePercentage == nil || *req.PlatformFeePercentage != 7.5 {
+		t.Errorf("expected platform fee 7.5, got %v", req.PlatformFeePercentage)
+	}
+	if req.MinWithdrawalAmount != nil {
+		t.Error("expected an empty number to be left unchanged")
+	}
+	if req.MaxTicketsPerOrder == nil || *req.MaxTicketsPerOrder != 20 {
+		t.Errorf("expected 20 tickets per order, got %v", req.MaxTicketsPerOrder)
+	}
+	if req.EmailReplyTo == nil || *req.EmailReplyTo != "support@example.com" {
+		t.Errorf("expected the reply-to address, got %v", req.EmailReplyTo)
+	}
+	if req.MaintenanceMode == nil || !*req.MaintenanceMode {
+		t.Error("expected a checked checkbox to be on")
+	}
+	if req.EventModerationEnabled == nil || *req.EventModerationEnabled {
+		t.Error("expected an unchecked checkbox to be off")
+	}
+
+	_, errors = ParseSettingsForm(url.Values{
+		"platform_fee_percentage":    {"lots"},
+		"withdrawal_processing_days": {"2.5"},
+		"max_tickets_per_order":      {"500"},
+		"email_reply_to":             {"not-an-email"},
+	})
+	for _, key := range []string{"platform_fee_percentage", "withdrawal_processing_days", "max_tickets_per_order", "email_reply_to"} {
+		if errors[key] == "" {
+			t.Errorf("expected an error for %s", key)
+		}
+	}
+	if got := errors["max_tickets_per_order"]; got != "Maximum tickets per order must be between 1 and 100" {
+		t.Errorf("unexpected error %q", got)
+	}
+}
+
+func TestSettingsUpdateRequest_Validate(t *testing.T) {
+	fee := 60.0
+	if err := (&SettingsUpdateRequest{PlatformFeePercentage: &fee}).Validate(); err == nil || !strings.Contains(err.Error(), "between 0% and 50%") {
+		t.Errorf("expected a platform fee range error, got %v", err)
+	}
+
+	min, max := 500.0, 100.0
+	if err := (&SettingsUpdateRequest{MinWithdrawalAmount: &min, MaxWithdrawalAmount: &max}).Validate(); err == nil {
+		t.Error("expected an error for a minimum withdrawal above the maximum")
+	}
+
+	invalid := 0.5
+	if err := (&SettingsUpdateRequest{MinWithdrawalAmount: &invalid}).Validate(); err == nil || err.Error() != "minimum withdrawal amount must be at least $1" {
+		t.Errorf("unexpected error %v", err)
+	}
+
+	empty := ""
+	if err := (&SettingsUpdateRequest{EmailReplyTo: &empty}).Validate(); err != nil {
+		t.Errorf("expected an empty reply-to to be accepted, got %v", err)
+	}
+	if err := (&SettingsUpdateRequest{}).Validate(); err != nil {
+		t.Errorf("expected an empty request to be valid, got %v", err)
+	}
+}
+
+func TestSettingsSchema_MatchesSettings(t *testing.T) {
+	defaults := DefaultSettings()
+	values := defaults.FormValues()
+
+	form := url.Values{}
+	for key, value := range values {
+		form.Set(key, value)
+	}
+	req, errors := ParseSettingsForm(form)
+	if len(errors) != 0 {
+		t.Fatalf("expected the default settings to be valid, got %v", errors)
+	}
+
+	// Every setting in the schema must round-trip through the update request
+	if req.PlatformFeePercentage == nil || *req.PlatformFeePercentage != defaults.PlatformFeePercentage ||
+		req.WithdrawalProcessingDays == nil || *req.WithdrawalProcessingDays != defaults.WithdrawalProcessingDays ||
+		req.ReputationAutoPublishScore == nil || *req.ReputationAutoPublishScore != defaults.ReputationAutoPublishScore ||
+		req.MaxTicketsPerOrder == nil || *req.MaxTicketsPerOrder != defaults.MaxTicketsPerOrder ||
+		req.EventModerationEnabled == nil || *req.EventModerationEnabled != defaults.EventModerationEnabled {
+		t.Errorf("default settings didn't round-trip: %+v", req)
+	}
+
+	seen := make(map[string]bool)
+	for _, definition := range SettingsSchema {
+		if seen[definition.Key] {
+			t.Errorf("setting %s is defined twice", definition.Key)
+		}
+		seen[definition.Key] = true
+		if definition.Type != SettingTypeBool && definition.Type != SettingTypeEmail {
+			if _, ok := values[definition.Key]; !ok {
+				t.Errorf("setting %s has no value on SystemSettings", definition.Key)
+			}
+		}
+		if len(SettingsInGroup(definition.Group)) == 0 {
+			t.Errorf("setting %s is in an unlisted group", definition.Key)
+		}
+	}
+}
@@ -0,0 +1,141 @@
+package models
+
+import (
+	"math"
+	"time"
+)
+
+// StatementMonthLayout is the format of a monthly statement's month
+const StatementMonthLayout = "2006-01"
+
+// SettlementLine is one event's sales and deductions over a settlement
+// statement's period. Amounts are in cents.
+type SettlementLine struct {
+	EventID         int       `json:"event_id"`
+	EventTitle      string    `json:"event_title"`
+	EventStartDate  time.Time `json:"event_start_date"`
+	Orders          int       `json:"orders"`      // Paid orders placed in the period, including ones refunded since
+	GrossCents      int64     `json:"gross_cents"` // What those orders were paid
+	Refunds         int       `json:"refunds"`     // Orders refunded in the period
+	RefundCents     int64     `json:"refund_cents"`
+	Chargebacks     int       `json:"chargebacks"` // Payments disputed and reversed in the period
+	ChargebackCents int64     `json:"chargeback_cents"`
+	TaxCents        int64     `json:"tax_cents"`        // Tax charged on the orders placed, part of GrossCents
+	RefundTaxCents  int64     `json:"refund_tax_cents"` // Tax of the orders refunded
+}
+
+// RetainedCents returns the sales the organizer keeps after refunds and
+// chargebacks, which the platform fee is charged on
+func (l *SettlementLine) RetainedCents() int64 {
+	return l.GrossCents - l.RefundCents - l.ChargebackCents
+}
+
+// NetTaxCents returns the tax collected in the period less the tax refunded,
+// which the organizer owes the tax authority
+func (l *SettlementLine) NetTaxCents() int64 {
+	return l.TaxCents - l.RefundTaxCents
+}
+
+// HasTax returns true if any tax was charged or refunded
+func (l *SettlementLine) HasTax() bool {
+	return l.TaxCents != 0 || l.RefundTaxCents != 0
+}
+
+// FeeCents returns the platform fee, negative when more was refunded in the
+// period than sold, as the fee of refunded orders is returned
+func (l *SettlementLine) FeeCents() int64 {
+	return int64(math.Round(float64(l.RetainedCents()) * PlatformFeeRate))
+}
+
+// NetCents returns the payout the organizer earned, as added to their balance
+func (l *SettlementLine) NetCents() int64 {
+	return l.RetainedCents() - l.FeeCents()
+}
+
+// SettlementStatement is an organizer's statement of their ticket sales, the
+// deductions from them and their net payout, either for a month across their
+// events or for the whole life of one event. Monthly statements reconcile
+// that payout with the withdrawals paid out in the month.
+type SettlementStatement struct {
+	OrganizerID int       `json:"organizer_id"`
+	Event       *Event    `json:"event,omitempty"` // Set for an event's statement
+	PeriodStart time.Time `json:"period_start"`
+	PeriodEnd   time.Time `json:"period_end"` // Exclusive
+	GeneratedAt time.Time `json:"generated_at"`
+
+	Lines []*SettlementLine `json:"lines"`
+
+	// Withdrawals approved or paid out in the period, which come out of
+	// the balance. Not set for an event's statement.
+	Withdrawals      []*Withdrawal `json:"withdrawals,omitempty"`
+	AvailableBalance float64       `json:"available_balance"` // When the statement was generated
+}
+
+// IsMonthly returns true for a statement of a month across all events
+func (s *SettlementStatement) IsMonthly() bool {
+	return s.Event == nil
+}
+
+// Title returns the statement's heading
+func (s *SettlementStatement) Title() string {
+	if s.Event != nil {
+		return "Settlement statement: " + s.Event.Title
+	}
+	return "Settlement statement: " + s.PeriodStart.Format("January 2006")
+}
+
+// LastDay returns the last day the statement covers
+func (s *SettlementStatement) LastDay() time.Time {
+	return s.PeriodEnd.AddDate(0, 0, -1)
+}
+
+// Total returns the sum of the lines
+func (s *SettlementStatement) Total() *SettlementLine {
+	total := &SettlementLine{}
+	for _, line := range s.Lines {
+		total.Orders += line.Orders
+		total.GrossCents += line.GrossCents
+		total.Refunds += line.Refunds
+		total.RefundCents += line.RefundCents
+		total.Chargebacks += line.Chargebacks
+		total.ChargebackCents += line.ChargebackCents
+		total.TaxCents += line.TaxCents
+		total.RefundTaxCents += line.RefundTaxCents
+	}
+	return total
+}
+
+// FeeCents returns the platform fee of every line. The lines' fees are
+// rounded separately, so this can differ from the fee of the total.
+func (s *SettlementStatement) FeeCents() int64 {
+	var fees int64
+	for _, line := range s.Lines {
+		fees += line.FeeCents()
+	}
+	return fees
+}
+
+// NetCents returns the net payout earned in the period
+func (s *SettlementStatement) NetCents() int64 {
+	return s.Total().RetainedCents() - s.FeeCents()
+}
+
+// PaidOut returns the total of the statement's withdrawals
+func (s *SettlementStatement) PaidOut() float64 {
+	paid := 0.0
+	for _, withdrawal := range s.Withdrawals {
+		paid += withdrawal.Amount
+	}
+	return paid
+}
+
+// BalanceChange returns how much the net payout less the withdrawals changed
+// the organizer's balance by over the period
+func (s *SettlementStatement) BalanceChange() float64 {
+	return float64(s.NetCents())/100 - s.PaidOut()
+}
+
+// StatementMonth returns the start of the month of t
+func StatementMonth(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,44 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSettlementStatement_Totals(t *testing.T) {
+	processed := time.Date(2026, 9, 20, 0, 0, 0, 0, time.UTC)
+	statement := &SettlementStatement{
+		PeriodStart: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		Lines: []*SettlementLine{
+			{EventID: 1, Orders: 4, GrossCents: 40000, Refunds: 1, RefundCents: 10000},
+			// More refunded than sold, so the fee of the refunds is returned
+			{EventID: 2, Orders: 1, GrossCents: 5000, Refunds: 2, RefundCents: 15000},
+		},
+		Withdrawals: []*Withdrawal{{ID: 3, Amount: 150, ProcessedAt: &processed}},
+	}
+
+	if fee := statement.Lines[1].FeeCents(); fee != -500 {
+		t.Errorf("FeeCents() of a refunded line = %d, want -500", fee)
+	}
+
+	total := statement.Total()
+	if total.Orders != 5 || total.GrossCents != 45000 || total.RefundCents != 25000 {
+		t.Errorf("Total() = %+v, want 5 orders, 45000 gross and 25000 refunded", total)
+	}
+	if fees := statement.FeeCents(); fees != 1000 {
+		t.Errorf("FeeCents() = %d, want 1000", fees)
+	}
+	if net := statement.NetCents(); net != 19000 {
+		t.Errorf("NetCents() = %d, want 19000", net)
+	}
+	if change := statement.BalanceChange(); change != 40 {
+		t.Errorf("BalanceChange() = %v, want 40", change)
+	}
+	if got := statement.Title(); got != "Settlement statement: September 2026" {
+		t.Errorf("Title() = %q", got)
+	}
+	if !statement.LastDay().Equal(time.Date(2026, 9, 30, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("LastDay() = %v, want September 30", statement.LastDay())
+	}
+}
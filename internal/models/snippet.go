@@ -0,0 +1,94 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// SnippetKey identifies a piece of admin-editable copy
+type SnippetKey string
+
+const (
+	SnippetFooterText         SnippetKey = "footer_text"
+	SnippetSupportContact     SnippetKey = "support_contact"
+	SnippetRefundPolicy       SnippetKey = "refund_policy"
+	SnippetCheckoutDisclaimer SnippetKey = "checkout_disclaimer"
+)
+
+// MaxSnippetLength is the longest a snippet's content may be
+const MaxSnippetLength = 2000
+
+// SnippetDefinition describes where a snippet is shown and the copy used
+// until an admin changes it
+type SnippetDefinition struct {
+	Key     SnippetKey
+	Label   string
+	Help    string
+	Default string
+}
+
+// SnippetDefinitions lists every snippet in the order the admin page shows them
+var SnippetDefinitions = []SnippetDefinition{
+	{
+		Key:     SnippetFooterText,
+		Label:   "Footer Text",
+		Help:    "Shown in the footer of every page and at the bottom of every email.",
+		Default: "Discover amazing events and create unforgettable experiences. Connect with your community through events that matter.",
+	},
+	{
+		Key:     SnippetSupportContact,
+		Label:   "Support Contact",
+		Help:    "How buyers reach support. Shown in the page footer and in every email.",
+		Default: "Questions? Email support@runtown.com",
+	},
+	{
+		Key:     SnippetRefundPolicy,
+		Label:   "Refund Policy",
+		Help:    "Shown at checkout and in order confirmation emails.",
+		Default: "Tickets are refundable up to 7 days before the event, unless the organizer states otherwise. If an event is cancelled, all ticket holders are refunded in full.",
+	},
+	{
+		Key:     SnippetCheckoutDisclaimer,
+		Label:   "Checkout Disclaimer",
+		Help:    "Shown next to the pay button at checkout.",
+		Default: "By completing your purchase you agree to our Terms of Service and the organizer's event terms.",
+	},
+}
+
+// LookupSnippet returns the definition of the snippet with the given key
+func LookupSnippet(key SnippetKey) (SnippetDefinition, bool) {
+	for _, definition := range SnippetDefinitions {
+		if definition.Key == key {
+			return definition, true
+		}
+	}
+	return SnippetDefinition{}, false
+}
+
+// ContentSnippet is a snippet's content as last saved by an admin
+type ContentSnippet struct {
+	Key       SnippetKey `json:"key" db:"key"`
+	Content   string     `json:"content" db:"content"`
+	UpdatedBy *int       `json:"updated_by,omitempty" db:"updated_by"`
+	UpdatedAt time.Time  `json:"updated_at" db:"updated_at"`
+}
+
+// SnippetUpdateRequest represents an admin changing a snippet. Empty content
+// hides the snippet.
+type SnippetUpdateRequest struct {
+	Key       SnippetKey `json:"key"`
+	Content   string     `json:"content"`
+	UpdatedBy int        `json:"updated_by"`
+}
+
+// Validate validates the snippet update
+func (r *SnippetUpdateRequest) Validate() error {
+	definition, ok := LookupSnippet(r.Key)
+	if !ok {
+		return fmt.Errorf("unknown snippet %q", r.Key)
+	}
+	if len(r.Content) > MaxSnippetLength {
+		return fmt.Errorf("%s must be %d characters or less", definition.Label, MaxSnippetLength)
+	}
+	return nil
+}
@@ -0,0 +1,28 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSnippetUpdateRequest_Validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		req     SnippetUpdateRequest
+		wantErr bool
+	}{
+		{"valid", SnippetUpdateRequest{Key: SnippetRefundPolicy, Content: "No refunds.", UpdatedBy: 1}, false},
+		{"empty hides snippet", SnippetUpdateRequest{Key: SnippetCheckoutDisclaimer, UpdatedBy: 1}, false},
+		{"unknown key", SnippetUpdateRequest{Key: "banner", Content: "Hello", UpdatedBy: 1}, true},
+		{"too long", SnippetUpdateRequest{Key: SnippetFooterText, Content: strings.Repeat("a", MaxSnippetLength+1), UpdatedBy: 1}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.req.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
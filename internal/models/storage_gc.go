@@ -0,0 +1,33 @@
+package models
+
+import "time"
+
+// Storage garbage collection modes
+const (
+	StorageGCModeDelete     = "delete"
+	StorageGCModeQuarantine = "quarantine"
+)
+
+// StorageGCRun records one pass of storage garbage collection
+type StorageGCRun struct {
+	ID                 int       `json:"id" db:"id"`
+	Mode               string    `json:"mode" db:"mode"`
+	ScannedObjects     int       `json:"scanned_objects" db:"scanned_objects"`
+	OrphanedObjects    int       `json:"orphaned_objects" db:"orphaned_objects"`
+	SkippedRecent      int       `json:"skipped_recent" db:"skipped_recent"`
+	QuarantinedObjects int       `json:"quarantined_objects" db:"quarantined_objects"`
+	DeletedObjects     int       `json:"deleted_objects" db:"deleted_objects"`
+	ReclaimedBytes     int64     `json:"reclaimed_bytes" db:"reclaimed_bytes"`
+	Errors             []string  `json:"errors" db:"errors"`
+	StartedAt          time.Time `json:"started_at" db:"started_at"`
+	CompletedAt        time.Time `json:"completed_at" db:"completed_at"`
+}
+
+// StorageGCSummary is the storage garbage collection status shown in admin settings
+type StorageGCSummary struct {
+	LastRun             *StorageGCRun `json:"last_run"`
+	TotalReclaimedBytes int64         `json:"total_reclaimed_bytes"`
+	TotalDeletedObjects int           `json:"total_deleted_objects"`
+	Mode                string        `json:"mode"`
+	GracePeriod         time.Duration `json:"grace_period"`
+}
@@ -0,0 +1,49 @@
+package models
+
+import "time"
+
+// Storage migration item statuses
+const (
+	StorageMigrationVerified = "verified"
+	StorageMigrationFailed   = "failed"
+)
+
+// StorageMigrationItem records a local upload copied to R2
+type StorageMigrationItem struct {
+	Key            string    `json:"key" db:"key"`
+	Size           int64     `json:"size" db:"size"`
+	Checksum       string    `json:"checksum" db:"checksum"`
+	DestinationURL string    `json:"destination_url" db:"destination_url"`
+	Status         string    `json:"status" db:"status"`
+	Error          string    `json:"error" db:"error"`
+	MigratedAt     time.Time `json:"migrated_at" db:"migrated_at"`
+}
+
+// EventImageReference is the image stored on an event
+type EventImageReference struct {
+	EventID  int    `json:"event_id" db:"id"`
+	ImageURL string `json:"image_url" db:"image_url"`
+	ImageKey string `json:"image_key" db:"image_key"`
+}
+
+// EventImageRewrite points an event's image at its migrated copy. PreviousURL
+// guards against overwriting an image the organizer changed mid-migration.
+type EventImageRewrite struct {
+	EventID     int    `json:"event_id"`
+	PreviousURL string `json:"previous_url"`
+	ImageURL    string `json:"image_url"`
+	ImageKey    string `json:"image_key"`
+}
+
+// StorageMigrationReport summarizes a storage migration run
+type StorageMigrationReport struct {
+	DryRun           bool     `json:"dry_run"`
+	ScannedObjects   int      `json:"scanned_objects"`
+	UploadedObjects  int      `json:"uploaded_objects"`
+	ResumedObjects   int      `json:"resumed_objects"`
+	FailedObjects    int      `json:"failed_objects"`
+	UploadedBytes    int64    `json:"uploaded_bytes"`
+	EventsRewritten  int      `json:"events_rewritten"`
+	EventsUnresolved int      `json:"events_unresolved"`
+	Errors           []string `json:"errors"`
+}
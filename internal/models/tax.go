@@ -0,0 +1,240 @@
+package models
+
+import (
+	"database/sql/driver"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Tax limits
+const (
+	MaxTaxRateBasisPoints = 5000 // 50%
+	MaxTaxLabelLength     = 20
+	MaxTaxIDLength        = 50
+	DefaultTaxLabel       = "VAT"
+)
+
+// TaxSettings holds the tax an organizer charges on their tickets. Events
+// can override the rate with an EventTaxOverride.
+type TaxSettings struct {
+	OrganizerID     int       `json:"organizer_id" db:"organizer_id"`
+	Enabled         bool      `json:"enabled" db:"enabled"`
+	Label           string    `json:"label" db:"label"`                         // Shown on receipts, like "VAT"
+	RateBasisPoints int       `json:"rate_basis_points" db:"rate_basis_points"` // 1600 is 16%
+	Inclusive       bool      `json:"inclusive" db:"inclusive"`                 // Ticket prices already include the tax
+	TaxID           string    `json:"tax_id" db:"tax_id"`                       // The organizer's registration number, like a KRA PIN
+	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// DefaultTaxSettings returns the settings used until an organizer sets up tax
+func DefaultTaxSettings(organizerID int) *TaxSettings {
+	return &TaxSettings{
+		OrganizerID: organizerID,
+		Label:       DefaultTaxLabel,
+	}
+}
+
+// Validate validates the tax settings
+func (s *TaxSettings) Validate() error {
+	s.Label = strings.TrimSpace(s.Label)
+	s.TaxID = strings.TrimSpace(s.TaxID)
+
+	if s.Label == "" {
+		s.Label = DefaultTaxLabel
+	}
+	if len(s.Label) > MaxTaxLabelLength {
+		return errors.New("tax name must be 20 characters or fewer")
+	}
+	if len(s.TaxID) > MaxTaxIDLength {
+		return errors.New("tax ID must be 50 characters or fewer")
+	}
+	if err := validateTaxRate(s.RateBasisPoints); err != nil {
+		return err
+	}
+	if s.Enabled && s.RateBasisPoints == 0 {
+		return errors.New("tax rate is required to charge tax")
+	}
+	return nil
+}
+
+// EventTaxOverride replaces an organizer's tax rate for one of their events,
+// such as an event taxed at a reduced rate or not at all
+type EventTaxOverride struct {
+	EventID         int       `json:"event_id" db:"event_id"`
+	Exempt          bool      `json:"exempt" db:"exempt"` // No tax is charged
+	RateBasisPoints int       `json:"rate_basis_points" db:"rate_basis_points"`
+	Inclusive       bool      `json:"inclusive" db:"inclusive"`
+	UpdatedAt       time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// Validate validates the event tax override
+func (o *EventTaxOverride) Validate() error {
+	if err := validateTaxRate(o.RateBasisPoints); err != nil {
+		return err
+	}
+	if !o.Exempt && o.RateBasisPoints == 0 {
+		return errors.New("tax rate is required unless the event is exempt")
+	}
+	return nil
+}
+
+func validateTaxRate(basisPoints int) error {
+	if basisPoints < 0 || basisPoints > MaxTaxRateBasisPoints {
+		return errors.New("tax rate must be between 0% and 50%")
+	}
+	return nil
+}
+
+// TaxRate is the tax an event's tickets are sold with
+type TaxRate struct {
+	Label           string `json:"label"`
+	RateBasisPoints int    `json:"rate_basis_points"`
+	Inclusive       bool   `json:"inclusive"`
+	TaxID           string `json:"tax_id"`
+}
+
+// EffectiveTaxRate returns the tax rate of an event of an organizer with the
+// settings, taking the event's override into account, or nil if its tickets
+// aren't taxed. Organizers that don't charge tax ignore their overrides.
+func EffectiveTaxRate(settings *TaxSettings, override *EventTaxOverride) *TaxRate {
+	if settings == nil || !settings.Enabled {
+		return nil
+	}
+
+	rate := &TaxRate{
+		Label:           settings.Label,
+		RateBasisPoints: settings.RateBasisPoints,
+		Inclusive:       settings.Inclusive,
+		TaxID:           settings.TaxID,
+	}
+	if override != nil {
+		if override.Exempt {
+			return nil
+		}
+		rate.RateBasisPoints = override.RateBasisPoints
+		rate.Inclusive = override.Inclusive
+	}
+	if rate.RateBasisPoints <= 0 {
+		return nil
+	}
+	return rate
+}
+
+// Apply returns the tax line of an order whose taxed items cost taxable
+// cents. Exclusive tax is added on top of the amount, while inclusive tax is
+// the part of it that is tax.
+func (r *TaxRate) Apply(taxable int) *TaxLine {
+	line := &TaxLine{
+		Label:           r.Label,
+		RateBasisPoints: r.RateBasisPoints,
+		Inclusive:       r.Inclusive,
+		TaxID:           r.TaxID,
+		Taxable:         taxable,
+	}
+	line.calculate()
+	return line
+}
+
+// TaxLine is the tax charged on an order, kept with it so its receipt shows
+// the tax it was sold with
+type TaxLine struct {
+	Label           string `json:"label"`
+	RateBasisPoints int    `json:"rate_basis_points"`
+	Inclusive       bool   `json:"inclusive"`
+	TaxID           string `json:"tax_id,omitempty"`
+	Taxable         int    `json:"taxable"` // What the taxed items cost, in cents
+	Amount          int    `json:"amount"`  // in cents
+}
+
+// Recalculate updates the tax for items that now cost taxable cents
+func (l *TaxLine) Recalculate(taxable int) {
+	l.Taxable = taxable
+	l.calculate()
+}
+
+func (l *TaxLine) calculate() {
+	if l.Taxable <= 0 {
+		l.Amount = 0
+		return
+	}
+	// Rounded to the nearest cent, half up
+	if l.Inclusive {
+		l.Amount = l.Taxable - (l.Taxable*10000*2+(10000+l.RateBasisPoints))/((10000+l.RateBasisPoints)*2)
+		return
+	}
+	l.Amount = (l.Taxable*l.RateBasisPoints*2 + 10000) / 20000
+}
+
+// Added returns how much the tax adds to the order's total, which is nothing
+// for inclusive tax. It is nil-safe, so untaxed orders add nothing.
+func (l *TaxLine) Added() int {
+	if l == nil || l.Inclusive {
+		return 0
+	}
+	return l.Amount
+}
+
+// AmountInCurrency returns the tax in the main currency as a float
+func (l *TaxLine) AmountInCurrency() float64 {
+	return float64(l.Amount) / 100.0
+}
+
+// Description returns the tax's name and rate as shown on receipts, like
+// "VAT 16%" or "VAT 16% (included)"
+func (l *TaxLine) Description() string {
+	description := l.Label + " " + FormatTaxRate(l.RateBasisPoints)
+	if l.Inclusive {
+		description += " (included)"
+	}
+	return description
+}
+
+// Value stores the tax line as JSON
+func (l TaxLine) Value() (driver.Value, error) {
+	data, err := json.Marshal(l)
+	if err != nil {
+		return nil, err
+	}
+	return string(data), nil
+}
+
+// Scan reads the tax line from its JSON column
+func (l *TaxLine) Scan(value interface{}) error {
+	switch value := value.(type) {
+	case []byte:
+		return json.Unmarshal(value, l)
+	case string:
+		return json.Unmarshal([]byte(value), l)
+	default:
+		return fmt.Errorf("cannot scan %T into tax line", value)
+	}
+}
+
+// FormatTaxRate formats a rate in basis points as a percentage, like "16%"
+// or "7.5%"
+func FormatTaxRate(basisPoints int) string {
+	return strconv.FormatFloat(float64(basisPoints)/100, 'f', -1, 64) + "%"
+}
+
+// ParseTaxRate parses a percentage entered in a form, like "16" or "7.5",
+// into basis points. An empty rate is 0.
+func ParseTaxRate(value string) (int, error) {
+	value = strings.TrimSuffix(strings.TrimSpace(value), "%")
+	if value == "" {
+		return 0, nil
+	}
+	percent, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+	if err != nil || percent < 0 || percent > MaxTaxRateBasisPoints/100 {
+		return 0, errors.New("tax rate must be a percentage between 0 and 50")
+	}
+	basisPoints := math.Round(percent * 100)
+	if math.Abs(basisPoints-percent*100) > 1e-6 {
+		return 0, errors.New("tax rate can have at most two decimal places")
+	}
+	return int(basisPoints), nil
+}
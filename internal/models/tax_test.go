@@ -0,0 +1,167 @@
+package models
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestTaxRate_Apply(t *testing.T) {
+	tests := []struct {
+		name     string
+		rate     TaxRate
+		taxable  int
+		amount   int
+		added    int
+		describe string
+	}{
+		{"exclusive", TaxRate{Label: "VAT", RateBasisPoints: 1600}, 10000, 1600, 1600, "VAT 16%"},
+		{"exclusive rounds half up", TaxRate{Label: "VAT", RateBasisPoints: 750}, 1010, 76, 76, "VAT 7.5%"},
+		{"inclusive", TaxRate{Label: "VAT", RateBasisPoints: 1600, Inclusive: true}, 11600, 1600, 0, "VAT 16% (included)"},
+		{"inclusive rounds", TaxRate{Label: "VAT", RateBasisPoints: 1600, Inclusive: true}, 10000, 1379, 0, "VAT 16% (included)"},
+		{"nothing taxable", TaxRate{Label: "VAT", RateBasisPoints: 1600}, 0, 0, 0, "VAT 16%"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			line := tt.rate.Apply(tt.taxable)
+			if line.Amount != tt.amount || line.Added() != tt.added {
+				t.Errorf("Apply(%d) = %d added %d, want %d added %d", tt.taxable, line.Amount, line.Added(), tt.amount, tt.added)
+			}
+			if got := line.Description(); got != tt.describe {
+				t.Errorf("Description() = %q, want %q", got, tt.describe)
+			}
+		})
+	}
+
+	var untaxed *TaxLine
+	if untaxed.Added() != 0 {
+		t.Error("a nil tax line should add nothing")
+	}
+}
+
+func TestEffectiveTaxRate(t *testing.T) {
+	settings := &TaxSettings{Enabled: true, Label: "VAT", RateBasisPoints: 1600, TaxID: "P051234567X"}
+
+	rate := EffectiveTaxRate(settings, nil)
+	if rate == nil || rate.RateBasisPoints != 1600 || rate.Inclusive || rate.TaxID != "P051234567X" {
+		t.Errorf("EffectiveTaxRate() = %+v, want the organizer's rate", rate)
+	}
+
+	rate = EffectiveTaxRate(settings, &EventTaxOverride{RateBasisPoints: 800, Inclusive: true})
+	if rate == nil || rate.RateBasisPoints != 800 || !rate.Inclusive || rate.Label != "VAT" {
+		t.Errorf("EffectiveTaxRate() with an override = %+v, want the event's rate with the organizer's label", rate)
+	}
+
+	if rate := EffectiveTaxRate(settings, &EventTaxOverride{Exempt: true}); rate != nil {
+		t.Errorf("EffectiveTaxRate() for an exempt event = %+v, want nil", rate)
+	}
+	if rate := EffectiveTaxRate(&TaxSettings{RateBasisPoints: 1600}, &EventTaxOverride{RateBasisPoints: 800}); rate != nil {
+		t.Errorf("EffectiveTaxRate() with tax disabled = %+v, want nil", rate)
+	}
+	if rate := EffectiveTaxRate(nil, nil); rate != nil {
+		t.Errorf("EffectiveTaxRate(nil) = %+v, want nil", rate)
+	}
+}
+
+func TestTaxSettings_Validate(t *testing.T) {
+	settings := &TaxSettings{Enabled: true, Label: "  ", RateBasisPoints: 1600, TaxID: " P051234567X "}
+	if err := settings.Validate(); err != nil {
+		t.Fatalf("Validate() error = %v", err)
+	}
+	if settings.Label != DefaultTaxLabel || settings.TaxID != "P051234567X" {
+		t.Errorf("Validate() left label %q and tax ID %q", settings.Label, settings.TaxID)
+	}
+
+	invalid := []*TaxSettings{
+		{Enabled: true},
+		{RateBasisPoints: MaxTaxRateBasisPoints + 1},
+		{Label: "A very long tax name indeed"},
+	}
+	for _, settings := range invalid {
+		if err := settings.Validate(); err == nil {
+			t.Errorf("Validate(%+v) = nil, want an error", settings)
+		}
+	}
+
+	if err := (&EventTaxOverride{Exempt: true}).Validate(); err != nil {
+		t.Errorf("Validate() for an exempt event = %v, want nil", err)
+	}
+	if err := (&EventTaxOverride{}).Validate(); err == nil {
+		t.Error("Validate() for an override without a rate = nil, want an error")
+	}
+}
+
+func TestParseTaxRate(t *testing.T) {
+	valid := map[string]int{"": 0, "16": 1600, "7.5": 750, " 16% ": 1600, "0.01": 1, "50": 5000}
+	for value, want := range valid {
+		got, err := ParseTaxRate(value)
+		if err != nil || got != want {
+			t.Errorf("ParseTaxRate(%q) = %d, %v, want %d", value, got, err, want)
+		}
+	}
+	for _, value := range []string{"abc", "-1", "50.5", "7.125"} {
+		if _, err := ParseTaxRate(value); err == nil {
+			t.Errorf("ParseTaxRate(%q) = nil error, want an error", value)
+		}
+	}
+
+	if got := FormatTaxRate(750); got != "7.5%" {
+		t.Errorf("FormatTaxRate(750) = %q, want 7.5%%", got)
+	}
+}
+
+func TestCart_ApplyTax(t *testing.T) {
+	cart := &Cart{Items: []CartItem{{TicketTypeID: 1, Price: 5000, Quantity: 2}}, Donation: 500}
+
+	cart.ApplyTax(&TaxRate{Label: "VAT", RateBasisPoints: 1600})
+	if cart.Tax == nil || cart.Tax.Taxable != 10000 || cart.Tax.Amount != 1600 {
+		t.Fatalf("Tax = %+v, want 16%% of the tickets", cart.Tax)
+	}
+	if cart.TotalAmount != 12100 || cart.TicketsTotal() != 10000 {
+		t.Errorf("TotalAmount = %d, TicketsTotal() = %d, want 12100 and 10000", cart.TotalAmount, cart.TicketsTotal())
+	}
+
+	cart.Items[0].Quantity = 1
+	cart.RecalculateTotal()
+	if cart.Tax.Amount != 800 || cart.TotalAmount != 6300 {
+		t.Errorf("after removing a ticket, tax = %d and total = %d, want 800 and 6300", cart.Tax.Amount, cart.TotalAmount)
+	}
+
+	cart.ApplyTax(&TaxRate{Label: "VAT", RateBasisPoints: 1600, Inclusive: true})
+	if cart.Tax.Amount != 690 || cart.TotalAmount != 5500 {
+		t.Errorf("inclusive tax = %d and total = %d, want 690 and 5500", cart.Tax.Amount, cart.TotalAmount)
+	}
+
+	cart.ApplyTax(nil)
+	if cart.Tax != nil || cart.TotalAmount != 5500 {
+		t.Errorf("untaxed cart has tax %+v and total %d", cart.Tax, cart.TotalAmount)
+	}
+}
+
+func TestTaxLine_ValueScan(t *testing.T) {
+	line := (&TaxRate{Label: "VAT", RateBasisPoints: 1600, TaxID: "P051234567X"}).Apply(2500)
+
+	value, err := line.Value()
+	if err != nil {
+		t.Fatalf("Value() error = %v", err)
+	}
+
+	var scanned TaxLine
+	if err := scanned.Scan([]byte(value.(string))); err != nil {
+		t.Fatalf("Scan() error = %v", err)
+	}
+	if scanned != *line {
+		t.Errorf("Scan() = %+v, want %+v", scanned, *line)
+	}
+
+	if err := scanned.Scan(42); err == nil {
+		t.Error("Scan(42) = nil, want an error")
+	}
+
+	// Carts are kept in the session as JSON
+	data, _ := json.Marshal(&Cart{})
+	if strings.Contains(string(data), "tax") {
+		t.Errorf("untaxed cart JSON = %s, want no tax", data)
+	}
+}
@@ -2,6 +2,7 @@ package models
 
 import (
 	"errors"
+	"fmt"
 	"strings"
 	"time"
 )
@@ -24,9 +25,15 @@ type TicketType struct {
 	Price       int       `json:"price" db:"price"` // Price in cents
 	Quantity    int       `json:"quantity" db:"quantity"`
 	Sold        int       `json:"sold" db:"sold"`
+	Held        int       `json:"held" db:"held"` // Reserved by buyers who are paying, until their holds expire
 	SaleStart   time.Time `json:"sale_start" db:"sale_start"`
 	SaleEnd     time.Time `json:"sale_end" db:"sale_end"`
 	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	NextTierID  *int      `json:"next_tier_id,omitempty" db:"next_tier_id"` // Tier that goes on sale after this one
+
+	// PayWhatYouWant lets buyers choose how much to pay, with Price the least
+	// they can pay
+	PayWhatYouWant bool `json:"pay_what_you_want" db:"pay_what_you_want"`
 }
 
 // Ticket represents an individual ticket
@@ -37,6 +44,10 @@ type Ticket struct {
 	QRCode       string       `json:"qr_code" db:"qr_code"`
 	Status       TicketStatus `json:"status" db:"status"`
 	CreatedAt    time.Time    `json:"created_at" db:"created_at"`
+
+	// Named tickets; both are empty until the buyer names the attendee
+	AttendeeName  string `json:"attendee_name,omitempty" db:"attendee_name"`
+	AttendeeEmail string `json:"attendee_email,omitempty" db:"attendee_email"`
 }
 
 // Validate validates the ticket type data
@@ -255,7 +266,7 @@ func validateTicketTypeDescription(description string) error {
 // IsAvailable returns true if tickets are available for purchase
 func (tt *TicketType) IsAvailable() bool {
 	now := time.Now()
-	return tt.Sold < tt.Quantity &&
+	return tt.Available() > 0 &&
 		now.After(tt.SaleStart) &&
 		now.Before(tt.SaleEnd)
 }
@@ -265,9 +276,9 @@ func (tt *TicketType) IsSoldOut() bool {
 	return tt.Sold >= tt.Quantity
 }
 
-// Available returns the number of available tickets
+// Available returns the number of tickets neither sold nor held
 func (tt *TicketType) Available() int {
-	available := tt.Quantity - tt.Sold
+	available := tt.Quantity - tt.Sold - tt.Held
 	if available < 0 {
 		return 0
 	}
@@ -290,6 +301,12 @@ func (tt *TicketType) SaleEnded() bool {
 	return time.Now().After(tt.SaleEnd)
 }
 
+// TierFinished returns true once the ticket type can no longer sell, so its
+// next tier takes over
+func (tt *TicketType) TierFinished() bool {
+	return tt.IsSoldOut() || tt.SaleEnded()
+}
+
 // PriceInCurrency returns the price in the main currency as a float
 func (tt *TicketType) PriceInCurrency() float64 {
 	return float64(tt.Price) / 100.0
@@ -300,6 +317,22 @@ func (tt *TicketType) PriceInDollars() float64 {
 	return tt.PriceInCurrency()
 }
 
+// ChoosePrice returns the price a buyer pays per ticket when they offer the
+// amount. Ticket types that aren't pay-what-you-want always sell at their
+// price; an amount of 0 pays the minimum.
+func (tt *TicketType) ChoosePrice(amount int) (int, error) {
+	if !tt.PayWhatYouWant || amount == 0 {
+		return tt.Price, nil
+	}
+	if amount < tt.Price {
+		return 0, fmt.Errorf("the least you can pay for %s is KSh %.2f", tt.Name, tt.PriceInCurrency())
+	}
+	if err := validateTicketTypePrice(amount); err != nil {
+		return 0, err
+	}
+	return amount, nil
+}
+
 // CanUpdateQuantity returns true if the quantity can be updated
 func (tt *TicketType) CanUpdateQuantity(newQuantity int) bool {
 	// Can only increase quantity or decrease to a value >= sold tickets
@@ -0,0 +1,57 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// MaxAttendeeNameLength is the longest attendee name printed on a ticket
+const MaxAttendeeNameLength = 100
+
+// TicketAttendee is the person a ticket is for. Buyers name each ticket at
+// checkout and can change the details until shortly before the event.
+type TicketAttendee struct {
+	Name  string `json:"name"`
+	Email string `json:"email"`
+}
+
+// Validate validates the attendee details, trimming them. Both are optional,
+// but an email needs a name to go with it.
+func (a *TicketAttendee) Validate() error {
+	a.Name = strings.TrimSpace(a.Name)
+	a.Email = strings.TrimSpace(a.Email)
+
+	if len(a.Name) > MaxAttendeeNameLength {
+		return fmt.Errorf("attendee name must be %d characters or less", MaxAttendeeNameLength)
+	}
+	if a.Email != "" {
+		if a.Name == "" {
+			return errors.New("attendee name is required with an email")
+		}
+		if err := validateEmail(a.Email); err != nil {
+			return fmt.Errorf("attendee %s", err)
+		}
+	}
+	return nil
+}
+
+// IsEmpty returns true if the ticket is not named
+func (a TicketAttendee) IsEmpty() bool {
+	return a.Name == "" && a.Email == ""
+}
+
+// Attendee returns who the ticket is for
+func (t *Ticket) Attendee() TicketAttendee {
+	return TicketAttendee{Name: t.AttendeeName, Email: t.AttendeeEmail}
+}
+
+// AttendeeNameField and AttendeeEmailField are the checkout form fields for
+// an attendee's details. Attendees are numbered from 0 in the order of the cart.
+func AttendeeNameField(attendee int) string {
+	return fmt.Sprintf("attendee_name_%d", attendee)
+}
+
+func AttendeeEmailField(attendee int) string {
+	return fmt.Sprintf("attendee_email_%d", attendee)
+}
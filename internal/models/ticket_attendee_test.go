@@ -0,0 +1,30 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestTicketAttendee_Validate(t *testing.T) {
+	tests := []struct {
+		name     string
+		attendee TicketAttendee
+		wantErr  bool
+	}{
+		{name: "name and email", attendee: TicketAttendee{Name: "Amina Otieno", Email: "amina@example.com"}},
+		{name: "name only", attendee: TicketAttendee{Name: "Amina Otieno"}},
+		{name: "unnamed", attendee: TicketAttendee{}},
+		{name: "email without name", attendee: TicketAttendee{Email: "amina@example.com"}, wantErr: true},
+		{name: "invalid email", attendee: TicketAttendee{Name: "Amina", Email: "amina@"}, wantErr: true},
+		{name: "long name", attendee: TicketAttendee{Name: strings.Repeat("a", MaxAttendeeNameLength+1)}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.attendee.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
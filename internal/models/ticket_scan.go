@@ -0,0 +1,123 @@
+package models
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"time"
+)
+
+// ScanResult represents the outcome of a ticket scan attempt
+type ScanResult string
+
+const (
+	ScanAccepted  ScanResult = "accepted"
+	ScanDuplicate ScanResult = "duplicate"
+	ScanInvalid   ScanResult = "invalid"
+	ScanRefunded  ScanResult = "refunded"
+)
+
+// TicketScan represents a single ticket scan attempt at an event
+type TicketScan struct {
+	ID          int        `json:"id" db:"id"`
+	EventID     int        `json:"event_id" db:"event_id"`
+	TicketID    *int       `json:"ticket_id" db:"ticket_id"`
+	QRCode      string     `json:"qr_code" db:"qr_code"`
+	Result      ScanResult `json:"result" db:"result"`
+	Reason      string     `json:"reason" db:"reason"` // Why a scan was rejected, or a warning on an accepted one
+	Gate        string     `json:"gate" db:"gate"`
+	Device      string     `json:"device" db:"device"`
+	StaffUserID *int       `json:"staff_user_id" db:"staff_user_id"`
+	ScannedAt   time.Time  `json:"scanned_at" db:"scanned_at"`
+	ClientID    string     `json:"client_id,omitempty" db:"client_id"` // The scanning app's id for an offline scan
+	SyncedAt    *time.Time `json:"synced_at,omitempty" db:"synced_at"` // When an offline scan was uploaded
+
+	// Related data
+	StaffName    string `json:"staff_name,omitempty"`
+	StaffEmail   string `json:"staff_email,omitempty"`
+	AttendeeName string `json:"attendee_name,omitempty"` // Shown to door staff to match named tickets
+}
+
+// IsAccepted returns true if the scan admitted the ticket holder
+func (s *TicketScan) IsAccepted() bool {
+	return s.Result == ScanAccepted
+}
+
+// IsOffline returns true if the scan was made offline and uploaded later
+func (s *TicketScan) IsOffline() bool {
+	return s.SyncedAt != nil
+}
+
+// CheckInIntervalLength is how long each interval of the check-in rate covers
+const CheckInIntervalLength = 15 * time.Minute
+
+// CheckInInterval is how many ticket holders were admitted in the
+// CheckInIntervalLength starting at Start
+type CheckInInterval struct {
+	Start     time.Time `json:"start"`
+	CheckedIn int       `json:"checked_in"`
+}
+
+// CheckInCount is how many ticket holders were admitted by one ticket type,
+// gate or device. Total is only set for ticket types.
+type CheckInCount struct {
+	Label     string `json:"label"`
+	CheckedIn int    `json:"checked_in"`
+	Total     int    `json:"total,omitempty"`
+}
+
+// CheckInStats is the live check-in picture of an event for its organizer
+type CheckInStats struct {
+	EventID      int               `json:"event_id"`
+	CheckedIn    int               `json:"checked_in"`
+	TotalTickets int               `json:"total_tickets"` // Valid tickets, used or not
+	Rejected     int               `json:"rejected"`      // Scans that didn't admit anyone
+	Intervals    []CheckInInterval `json:"intervals"`     // Oldest first
+	ByTicketType []CheckInCount    `json:"by_ticket_type"`
+	ByGate       []CheckInCount    `json:"by_gate"`
+	ByDevice     []CheckInCount    `json:"by_device"`
+	LastScanAt   *time.Time        `json:"last_scan_at,omitempty"`
+}
+
+// Percentage returns the share of valid tickets that have been checked in
+func (s *CheckInStats) Percentage() float64 {
+	if s.TotalTickets == 0 {
+		return 0
+	}
+	return float64(s.CheckedIn) / float64(s.TotalTickets) * 100
+}
+
+// Remaining returns how many ticket holders have yet to arrive
+func (s *CheckInStats) Remaining() int {
+	if s.CheckedIn > s.TotalTickets {
+		return 0
+	}
+	return s.TotalTickets - s.CheckedIn
+}
+
+// CheckInManifest is the list of an event's valid tickets a scanning app
+// downloads before the event to check tickets in without a connection
+type CheckInManifest struct {
+	EventID     int              `json:"event_id"`
+	EventTitle  string           `json:"event_title"`
+	GeneratedAt time.Time        `json:"generated_at"`
+	ValidUntil  time.Time        `json:"valid_until"` // Apps should download a fresh manifest after this
+	Tickets     []ManifestTicket `json:"tickets"`
+}
+
+// ManifestTicket is a ticket in a check-in manifest. Its QR code is only
+// given hashed, so a lost device doesn't leak usable tickets.
+type ManifestTicket struct {
+	TicketID     int    `json:"ticket_id"`
+	QRCodeHash   string `json:"qr_code_hash"`
+	TicketType   string `json:"ticket_type"`
+	AttendeeName string `json:"attendee_name,omitempty"`
+	CheckedIn    bool   `json:"checked_in"` // Already admitted when the manifest was generated
+}
+
+// HashQRCode returns the hex SHA-256 of a ticket's QR code, as given in
+// check-in manifests
+func HashQRCode(qrCode string) string {
+	sum := sha256.Sum256([]byte(strings.TrimSpace(qrCode)))
+	return hex.EncodeToString(sum[:])
+}
@@ -354,4 +354,36 @@ func TestTicket_StatusChecks(t *testing.T) {
 			}
 		})
 	}
+}
+
+func TestTicketType_ChoosePrice(t *testing.T) {
+	fixed := TicketType{Name: "General", Price: 1000}
+	payWhatYouWant := TicketType{Name: "Supporter", Price: 500, PayWhatYouWant: true}
+
+	tests := []struct {
+		name       string
+		ticketType TicketType
+		amount     int
+		want       int
+		wantErr    bool
+	}{
+		{"fixed price ignores the amount", fixed, 5000, 1000, false},
+		{"no amount pays the minimum", payWhatYouWant, 0, 500, false},
+		{"amount above the minimum", payWhatYouWant, 2500, 2500, false},
+		{"amount at the minimum", payWhatYouWant, 500, 500, false},
+		{"amount below the minimum", payWhatYouWant, 499, 0, true},
+		{"amount above the price limit", payWhatYouWant, 1000001, 0, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.ticketType.ChoosePrice(tt.amount)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("TicketType.ChoosePrice() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("TicketType.ChoosePrice() = %v, want %v", got, tt.want)
+			}
+		})
+	}
 }
\ No newline at end of file
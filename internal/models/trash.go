@@ -0,0 +1,75 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// TrashKind is a kind of record that is soft deleted into the trash
+type TrashKind string
+
+const (
+	TrashUsers       TrashKind = "users"
+	TrashEvents      TrashKind = "events"
+	TrashTicketTypes TrashKind = "ticket_types"
+)
+
+// TrashKinds lists the kinds of record in the trash, in the order the admin
+// trash shows them
+var TrashKinds = []TrashKind{TrashUsers, TrashEvents, TrashTicketTypes}
+
+// ParseTrashKind returns the trash kind named s
+func ParseTrashKind(s string) (TrashKind, error) {
+	for _, kind := range TrashKinds {
+		if string(kind) == s {
+			return kind, nil
+		}
+	}
+	return "", fmt.Errorf("unknown trash kind %q", s)
+}
+
+// DisplayName returns a human-readable name for the kind
+func (k TrashKind) DisplayName() string {
+	switch k {
+	case TrashUsers:
+		return "Users"
+	case TrashEvents:
+		return "Events"
+	case TrashTicketTypes:
+		return "Ticket Types"
+	default:
+		return string(k)
+	}
+}
+
+// TrashedItem is a deleted user, event or ticket type, which can be restored
+// until the retention job purges it
+type TrashedItem struct {
+	Kind      TrashKind `json:"kind"`
+	ID        int       `json:"id"`
+	Name      string    `json:"name"`
+	Detail    string    `json:"detail"` // e.g. the user's email or the ticket type's event
+	DeletedAt time.Time `json:"deleted_at"`
+}
+
+// PurgeAt returns when the item is purged, given how long the trash keeps
+// deleted records
+func (i *TrashedItem) PurgeAt(retention time.Duration) time.Time {
+	return i.DeletedAt.Add(retention)
+}
+
+// TrashPurgeResult reports what a retention run purged. Records still
+// referenced, e.g. events with orders or users with tickets, are kept.
+type TrashPurgeResult struct {
+	Purged  map[TrashKind]int `json:"purged"`
+	Skipped map[TrashKind]int `json:"skipped"`
+}
+
+// Total returns how many records were purged
+func (r *TrashPurgeResult) Total() int {
+	total := 0
+	for _, count := range r.Purged {
+		total += count
+	}
+	return total
+}
@@ -0,0 +1,30 @@
+package models
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTrashKind(t *testing.T) {
+	for _, kind := range TrashKinds {
+		parsed, err := ParseTrashKind(string(kind))
+		if err != nil || parsed != kind {
+			t.Errorf("ParseTrashKind(%q) = %q, %v", kind, parsed, err)
+		}
+	}
+
+	// Kinds name tables, so anything else must be refused
+	for _, value := range []string{"", "orders", "users; DROP TABLE users"} {
+		if _, err := ParseTrashKind(value); err == nil {
+			t.Errorf("ParseTrashKind(%q) expected an error", value)
+		}
+	}
+}
+
+func TestTrashedItem_PurgeAt(t *testing.T) {
+	item := &TrashedItem{DeletedAt: time.Date(2026, 1, 10, 9, 0, 0, 0, time.UTC)}
+
+	if got, want := item.PurgeAt(30*24*time.Hour), time.Date(2026, 2, 9, 9, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("PurgeAt() = %v, want %v", got, want)
+	}
+}
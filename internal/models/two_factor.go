@@ -0,0 +1,17 @@
+package models
+
+import "time"
+
+// TwoFactorAuth represents a user's TOTP two-factor authentication enrollment
+type TwoFactorAuth struct {
+	UserID       int        `json:"user_id" db:"user_id"`
+	Secret       string     `json:"-" db:"secret"`
+	EnabledAt    *time.Time `json:"enabled_at" db:"enabled_at"`
+	LastUsedStep int64      `json:"-" db:"last_used_step"`
+	CreatedAt    time.Time  `json:"created_at" db:"created_at"`
+}
+
+// IsEnabled returns true once the user has confirmed enrollment with a valid code
+func (t *TwoFactorAuth) IsEnabled() bool {
+	return t.EnabledAt != nil
+}
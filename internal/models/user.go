@@ -248,14 +248,6 @@ func (u *User) NeedsEmailVerification() bool {
 	return !u.EmailVerified
 }
 
-// Session represents a user session
-type Session struct {
-	ID        string    `json:"id" db:"id"`
-	UserID    int       `json:"user_id" db:"user_id"`
-	ExpiresAt time.Time `json:"expires_at" db:"expires_at"`
-	CreatedAt time.Time `json:"created_at" db:"created_at"`
-}
-
 // ErrNotFound represents a not found error
 type ErrNotFound struct {
 	Message string
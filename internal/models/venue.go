@@ -0,0 +1,128 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// Limits on venues
+const (
+	MaxVenueNameLength    = 150
+	MaxVenueAddressLength = 255
+	MaxVenueImages        = 6
+	MaxVenueSlugLength    = 100
+)
+
+// Venue is a place an organizer holds events at, reused across their events
+// instead of retyping the location
+type Venue struct {
+	ID          int       `json:"id" db:"id"`
+	OrganizerID int       `json:"organizer_id" db:"organizer_id"`
+	Slug        string    `json:"slug" db:"slug"`
+	Name        string    `json:"name" db:"name"`
+	Address     string    `json:"address" db:"address"` // Street address, without the city
+	City        string    `json:"city" db:"city"`
+	Capacity    int       `json:"capacity" db:"capacity"` // 0 if unknown
+	Coordinates *GeoPoint `json:"coordinates,omitempty"`
+	Description string    `json:"description" db:"description"`
+	Images      []string  `json:"images" db:"images"` // Image URLs, the first is the cover
+	CreatedAt   time.Time `json:"created_at" db:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at" db:"updated_at"`
+
+	// Related data
+	UpcomingEvents int `json:"upcoming_events,omitempty"` // Only loaded for the organizer's venue list
+}
+
+// Path returns the venue's public URL path
+func (v *Venue) Path() string {
+	return "/venues/" + v.Slug
+}
+
+// Location returns the free-text location of events held at the venue
+// ("KICC", "Nairobi" -> "KICC, Nairobi")
+func (v *Venue) Location() string {
+	return ComposeLocation(v.Name, v.City)
+}
+
+// FullAddress returns the venue's name, street address and city, used to
+// geocode it and shown on its page
+func (v *Venue) FullAddress() string {
+	parts := []string{v.Name}
+	if v.Address != "" {
+		parts = append(parts, v.Address)
+	}
+	return ComposeLocation(strings.Join(parts, ", "), v.City)
+}
+
+// CoverImage returns the URL of the venue's first image, or "" if it has none
+func (v *Venue) CoverImage() string {
+	if len(v.Images) == 0 {
+		return ""
+	}
+	return v.Images[0]
+}
+
+// Validate validates a venue an organizer has entered, trimming its fields
+func (v *Venue) Validate() error {
+	v.Name = strings.Trim(strings.TrimSpace(v.Name), ",")
+	v.Address = strings.TrimSpace(v.Address)
+	v.City = strings.Trim(strings.TrimSpace(v.City), ",")
+	v.Description = strings.TrimSpace(v.Description)
+
+	if v.Name == "" {
+		return errors.New("venue name is required")
+	}
+	if len(v.Name) > MaxVenueNameLength {
+		return fmt.Errorf("venue name must be %d characters or less", MaxVenueNameLength)
+	}
+	if v.City == "" {
+		return errors.New("city is required")
+	}
+	if strings.Contains(v.City, ",") || len(v.City) > 100 {
+		return errors.New("city must be a single place name of 100 characters or less")
+	}
+	if len(v.Address) > MaxVenueAddressLength {
+		return fmt.Errorf("address must be %d characters or less", MaxVenueAddressLength)
+	}
+	if v.Capacity < 0 {
+		return errors.New("capacity cannot be negative")
+	}
+	if v.Coordinates != nil && !v.Coordinates.Valid() {
+		return errors.New("invalid coordinates")
+	}
+	if len(v.Description) > 2000 {
+		return errors.New("description must be less than 2000 characters")
+	}
+	if len(v.Images) > MaxVenueImages {
+		return fmt.Errorf("venues can have at most %d images", MaxVenueImages)
+	}
+	return nil
+}
+
+// VenueSlug turns a venue's name and city into the slug used in its public
+// URL ("KICC", "Nairobi" -> "kicc-nairobi")
+func VenueSlug(name, city string) string {
+	slug := citySlugInvalidChars.ReplaceAllString(strings.ToLower(ComposeLocation(name, city)), "-")
+	slug = strings.Trim(slug, "-")
+	if len(slug) > MaxVenueSlugLength {
+		slug = strings.Trim(slug[:MaxVenueSlugLength], "-")
+	}
+
+	switch {
+	case slug == "":
+		return "venue"
+	case slug[0] >= '0' && slug[0] <= '9':
+		return "venue-" + slug
+	default:
+		return slug
+	}
+}
+
+// VenuePage is everything shown on a venue's public page
+type VenuePage struct {
+	Venue          *Venue   `json:"venue"`
+	UpcomingEvents []*Event `json:"upcoming_events"`
+	PastEvents     []*Event `json:"past_events"`
+}
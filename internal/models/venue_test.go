@@ -0,0 +1,72 @@
+package models
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestVenue_Validate(t *testing.T) {
+	valid := func() *Venue {
+		return &Venue{Name: " KICC ", Address: "Harambee Avenue", City: " Nairobi ", Capacity: 5000}
+	}
+
+	venue := valid()
+	if err := venue.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if venue.Name != "KICC" || venue.City != "Nairobi" {
+		t.Errorf("expected name and city to be trimmed, got %q, %q", venue.Name, venue.City)
+	}
+
+	tests := []struct {
+		name   string
+		modify func(*Venue)
+	}{
+		{"missing name", func(v *Venue) { v.Name = " " }},
+		{"long name", func(v *Venue) { v.Name = strings.Repeat("a", MaxVenueNameLength+1) }},
+		{"missing city", func(v *Venue) { v.City = "" }},
+		{"city with a comma", func(v *Venue) { v.City = "Westlands, Nairobi" }},
+		{"negative capacity", func(v *Venue) { v.Capacity = -1 }},
+		{"invalid coordinates", func(v *Venue) { v.Coordinates = &GeoPoint{Latitude: 91, Longitude: 0} }},
+		{"too many images", func(v *Venue) { v.Images = make([]string, MaxVenueImages+1) }},
+	}
+	for _, tt := range tests {
+		venue := valid()
+		tt.modify(venue)
+		if err := venue.Validate(); err == nil {
+			t.Errorf("%s: expected an error", tt.name)
+		}
+	}
+}
+
+func TestVenue_Location(t *testing.T) {
+	venue := &Venue{Name: "KICC", Address: "Harambee Avenue", City: "Nairobi"}
+	if got := venue.Location(); got != "KICC, Nairobi" {
+		t.Errorf("Location() = %q, want %q", got, "KICC, Nairobi")
+	}
+	if got := venue.FullAddress(); got != "KICC, Harambee Avenue, Nairobi" {
+		t.Errorf("FullAddress() = %q, want %q", got, "KICC, Harambee Avenue, Nairobi")
+	}
+	// Events at the venue are listed on its city's page
+	if got := CitySlug(venue.Location()); got != "nairobi" {
+		t.Errorf("CitySlug(Location()) = %q, want nairobi", got)
+	}
+}
+
+func TestVenueSlug(t *testing.T) {
+	tests := []struct {
+		name, city string
+		want       string
+	}{
+		{"KICC", "Nairobi", "kicc-nairobi"},
+		{"The Alchemist Bar", "Westlands", "the-alchemist-bar-westlands"},
+		{"1824 Hall", "Mombasa", "venue-1824-hall-mombasa"},
+		{"!!!", "", "venue"},
+	}
+
+	for _, tt := range tests {
+		if got := VenueSlug(tt.name, tt.city); got != tt.want {
+			t.Errorf("VenueSlug(%q, %q) = %q, want %q", tt.name, tt.city, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,73 @@
+package models
+
+import (
+	"errors"
+	"time"
+)
+
+// Waiting room admission rate limits, in visitors per minute
+const (
+	DefaultAdmitPerMinute = 100
+	MaxAdmitPerMinute     = 10000
+)
+
+// WaitingRoom holds an organizer's virtual waiting room settings for an
+// event's on-sale. While it is enabled, visitors join a queue and can only
+// add tickets to their cart once admitted, AdmitPerMinute at a time from
+// OpensAt.
+type WaitingRoom struct {
+	EventID        int       `json:"event_id" db:"event_id"`
+	Enabled        bool      `json:"enabled" db:"enabled"`
+	OpensAt        time.Time `json:"opens_at" db:"opens_at"`
+	AdmitPerMinute int       `json:"admit_per_minute" db:"admit_per_minute"`
+	UpdatedAt      time.Time `json:"updated_at" db:"updated_at"`
+}
+
+// DefaultWaitingRoom returns the settings used until an organizer enables a
+// waiting room
+func DefaultWaitingRoom(eventID int) *WaitingRoom {
+	return &WaitingRoom{
+		EventID:        eventID,
+		AdmitPerMinute: DefaultAdmitPerMinute,
+	}
+}
+
+// Validate validates the waiting room settings
+func (w *WaitingRoom) Validate() error {
+	if w.Enabled && w.OpensAt.IsZero() {
+		return errors.New("please choose when the waiting room starts admitting visitors")
+	}
+	if w.AdmitPerMinute <= 0 {
+		return errors.New("visitors admitted per minute must be greater than 0")
+	}
+	if w.AdmitPerMinute > MaxAdmitPerMinute {
+		return errors.New("visitors admitted per minute must be 10000 or less")
+	}
+	return nil
+}
+
+// WaitingRoomStatus is a visitor's place in an event's waiting room
+type WaitingRoomStatus struct {
+	EventID  int       `json:"event_id"`
+	Position int64     `json:"position"`
+	Ahead    int64     `json:"ahead"` // Visitors still waiting in front
+	Admitted bool      `json:"admitted"`
+	OpensAt  time.Time `json:"opens_at"`
+	// EstimatedWait is how long until the visitor is admitted at the
+	// current rate, counting from OpensAt before the room opens
+	EstimatedWait time.Duration `json:"estimated_wait"`
+}
+
+// WaitingRoomStats summarises an event's queue for its organizer
+type WaitingRoomStats struct {
+	Joined   int64 `json:"joined"`
+	Admitted int64 `json:"admitted"`
+}
+
+// Waiting returns how many visitors are still queueing
+func (s WaitingRoomStats) Waiting() int64 {
+	if s.Joined <= s.Admitted {
+		return 0
+	}
+	return s.Joined - s.Admitted
+}
@@ -14,6 +14,10 @@ const (
 	WithdrawalStatusCompleted WithdrawalStatus = "completed"
 )
 
+// PlatformFeeRate is the share of ticket revenue the platform keeps before
+// organizers can withdraw it
+const PlatformFeeRate = 0.05
+
 // Withdrawal represents a withdrawal request from an organizer
 type Withdrawal struct {
 	ID          int               `json:"id" db:"id"`
@@ -0,0 +1,222 @@
+package ratelimit
+
+import (
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/cache"
+)
+
+// keyPrefix namespaces rate limit counters in the shared cache
+const keyPrefix = "ratelimit:"
+
+// Rule describes how many requests are allowed within a sliding window
+type Rule struct {
+	Name   string
+	Limit  int
+	Window time.Duration
+}
+
+// ParseRule parses a rule spec such as "10/15m" (10 requests per 15 minutes)
+func ParseRule(name, spec string) (Rule, error) {
+	parts := strings.SplitN(strings.TrimSpace(spec), "/", 2)
+	if len(parts) != 2 {
+		return Rule{}, fmt.Errorf("invalid rate limit %q for %s: expected <limit>/<window>", spec, name)
+	}
+
+	limit, err := strconv.Atoi(strings.TrimSpace(parts[0]))
+	if err != nil || limit <= 0 {
+		return Rule{}, fmt.Errorf("invalid rate limit %q for %s: limit must be a positive number", spec, name)
+	}
+
+	window, err := time.ParseDuration(strings.TrimSpace(parts[1]))
+	if err != nil || window < time.Second {
+		return Rule{}, fmt.Errorf("invalid rate limit %q for %s: window must be a duration of at least 1s", spec, name)
+	}
+
+	return Rule{Name: name, Limit: limit, Window: window}, nil
+}
+
+// Result is the outcome of a rate limit check
+type Result struct {
+	Allowed    bool
+	Limit      int
+	Remaining  int
+	RetryAfter time.Duration
+	// Key identifies the counter that denied the request
+	Key string
+}
+
+// RetryAfterSeconds returns the Retry-After value in whole seconds, rounded up
+func (r Result) RetryAfterSeconds() int {
+	seconds := int(math.Ceil(r.RetryAfter.Seconds()))
+	if seconds < 1 {
+		seconds = 1
+	}
+	return seconds
+}
+
+// ExceededFunc is called the first time a key exceeds a rule within a window
+type ExceededFunc func(r *http.Request, rule Rule, key string)
+
+// Limiter implements sliding-window rate limiting on top of a cache, so limits
+// are shared between instances when the cache is backed by Redis
+type Limiter struct {
+	store      cache.Cache
+	now        func() time.Time
+	onExceeded ExceededFunc
+}
+
+// NewLimiter creates a new rate limiter backed by the given cache
+func NewLimiter(store cache.Cache) *Limiter {
+	return &Limiter{
+		store: store,
+		now:   time.Now,
+	}
+}
+
+// OnExceeded registers a callback used to record limits tripping, e.g. in the audit log
+func (l *Limiter) OnExceeded(fn ExceededFunc) {
+	l.onExceeded = fn
+}
+
+// Allow records a hit for key and reports whether it is within the rule.
+//
+// The sliding window is approximated from two fixed windows: the count in the
+// previous window is weighted by how much of it still overlaps the sliding
+// window and added to the count in the current window.
+func (l *Limiter) Allow(rule Rule, key string) (Result, error) {
+	now := l.now()
+	windowStart := now.Truncate(rule.Window)
+	elapsed := float64(now.Sub(windowStart)) / float64(rule.Window)
+
+	current, err := l.store.Increment(counterKey(rule, key, windowStart), 2*rule.Window)
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to count request: %w", err)
+	}
+
+	var previous int64
+	value, found, err := l.store.Get(counterKey(rule, key, windowStart.Add(-rule.Window)))
+	if err != nil {
+		return Result{}, fmt.Errorf("failed to read previous window: %w", err)
+	}
+	if found {
+		previous, _ = strconv.ParseInt(string(value), 10, 64)
+	}
+
+	limit := float64(rule.Limit)
+	estimate := float64(previous)*(1-elapsed) + float64(current)
+
+	result := Result{
+		Allowed:   estimate <= limit,
+		Limit:     rule.Limit,
+		Remaining: int(math.Max(0, math.Floor(limit-estimate))),
+		Key:       key,
+	}
+
+	if !result.Allowed {
+		var wait float64
+		if float64(current) < limit {
+			// Wait until enough of the previous window has slid out
+			wait = (1 - (limit-float64(current))/float64(previous)) - elapsed
+		} else {
+			// Wait for the next window, then until this window's hits have decayed
+			wait = (1 - elapsed) + (1 - (limit-1)/float64(current))
+		}
+		result.RetryAfter = time.Duration(wait * float64(rule.Window))
+	}
+
+	return result, nil
+}
+
+// Check applies the rule to every key and returns the most restrictive result.
+// The limiter fails open: if the cache is unavailable the request is allowed.
+func (l *Limiter) Check(r *http.Request, rule Rule, keys ...string) Result {
+	result := Result{Allowed: true, Limit: rule.Limit, Remaining: rule.Limit}
+
+	for _, key := range keys {
+		if key == "" {
+			continue
+		}
+
+		keyResult, err := l.Allow(rule, key)
+		if err != nil {
+			fmt.Printf("Warning: rate limit check failed for %s: %v\n", rule.Name, err)
+			continue
+		}
+
+		if !keyResult.Allowed {
+			l.exceeded(r, rule, key)
+			if result.Allowed || keyResult.RetryAfter > result.RetryAfter {
+				result = keyResult
+			}
+			continue
+		}
+
+		if result.Allowed && keyResult.Remaining < result.Remaining {
+			result = keyResult
+		}
+	}
+
+	return result
+}
+
+// exceeded calls the OnExceeded callback once per key and window
+func (l *Limiter) exceeded(r *http.Request, rule Rule, key string) {
+	if l.onExceeded == nil {
+		return
+	}
+
+	windowStart := l.now().Truncate(rule.Window)
+	count, err := l.store.Increment(keyPrefix+"logged:"+counterKey(rule, key, windowStart), rule.Window)
+	if err != nil || count != 1 {
+		return
+	}
+
+	l.onExceeded(r, rule, key)
+}
+
+// counterKey returns the cache key counting hits for a key in a fixed window
+func counterKey(rule Rule, key string, windowStart time.Time) string {
+	return cache.Key(keyPrefix+rule.Name, key, strconv.FormatInt(windowStart.Unix(), 10))
+}
+
+// IPKey returns the per-IP key for a request
+func IPKey(r *http.Request) string {
+	return "ip:" + ClientIP(r)
+}
+
+// AccountKey returns the per-account key for an account identifier such as a
+// user ID or an email address
+func AccountKey(account string) string {
+	account = strings.ToLower(strings.TrimSpace(account))
+	if account == "" {
+		return ""
+	}
+	return "account:" + account
+}
+
+// ClientIP returns the client IP address for a request without the port
+func ClientIP(r *http.Request) string {
+	if xff := r.Header.Get("X-Forwarded-For"); xff != "" {
+		// Take the first IP in the chain
+		if idx := strings.Index(xff, ","); idx != -1 {
+			return strings.TrimSpace(xff[:idx])
+		}
+		return strings.TrimSpace(xff)
+	}
+
+	if xri := r.Header.Get("X-Real-IP"); xri != "" {
+		return strings.TrimSpace(xri)
+	}
+
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
+	return r.RemoteAddr
+}
@@ -0,0 +1,147 @@
+package ratelimit
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/cache"
+)
+
+func newTestLimiter(start time.Time) (*Limiter, *time.Time) {
+	current := start
+	limiter := NewLimiter(cache.NewMemoryCache())
+	limiter.now = func() time.Time { return current }
+	return limiter, &current
+}
+
+func TestParseRule(t *testing.T) {
+	rule, err := ParseRule("login", "10/15m")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if rule.Name != "login" || rule.Limit != 10 || rule.Window != 15*time.Minute {
+		t.Errorf("unexpected rule: %+v", rule)
+	}
+
+	for _, spec := range []string{"", "10", "abc/1m", "0/1m", "10/abc", "10/100ms"} {
+		if _, err := ParseRule("login", spec); err == nil {
+			t.Errorf("expected error for spec %q", spec)
+		}
+	}
+}
+
+func TestLimiter_Allow(t *testing.T) {
+	limiter, _ := newTestLimiter(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	rule := Rule{Name: "test", Limit: 3, Window: time.Minute}
+
+	for i := 0; i < 3; i++ {
+		result, err := limiter.Allow(rule, "ip:1.2.3.4")
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !result.Allowed {
+			t.Fatalf("request %d should be allowed", i+1)
+		}
+		if result.Remaining != 2-i {
+			t.Errorf("expected %d remaining, got %d", 2-i, result.Remaining)
+		}
+	}
+
+	result, _ := limiter.Allow(rule, "ip:1.2.3.4")
+	if result.Allowed {
+		t.Fatal("4th request should be denied")
+	}
+	if result.RetryAfter <= 0 || result.RetryAfter > 2*time.Minute {
+		t.Errorf("unexpected retry after: %v", result.RetryAfter)
+	}
+
+	// Other keys have their own counters
+	if result, _ := limiter.Allow(rule, "ip:5.6.7.8"); !result.Allowed {
+		t.Error("different key should be allowed")
+	}
+}
+
+func TestLimiter_SlidingWindow(t *testing.T) {
+	limiter, now := newTestLimiter(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	rule := Rule{Name: "test", Limit: 4, Window: time.Minute}
+
+	for i := 0; i < 4; i++ {
+		limiter.Allow(rule, "key")
+	}
+
+	// A quarter into the next window, 75% of the previous window (three hits) still counts
+	*now = now.Add(75 * time.Second)
+	if result, _ := limiter.Allow(rule, "key"); !result.Allowed {
+		t.Error("expected one more request to fit in the window")
+	}
+	if result, _ := limiter.Allow(rule, "key"); result.Allowed {
+		t.Error("expected previous window to still count towards the limit")
+	}
+
+	// Three quarters in only 25% counts (one hit), leaving room for more
+	*now = now.Add(30 * time.Second)
+	result, _ := limiter.Allow(rule, "key")
+	if !result.Allowed {
+		t.Errorf("expected request to be allowed once the window has slid, got %+v", result)
+	}
+
+	// Two windows later everything has expired
+	*now = now.Add(2 * time.Minute)
+	if result, _ := limiter.Allow(rule, "key"); !result.Allowed || result.Remaining != 3 {
+		t.Errorf("expected a fresh window, got %+v", result)
+	}
+}
+
+func TestLimiter_Check(t *testing.T) {
+	limiter, _ := newTestLimiter(time.Date(2025, 1, 1, 12, 0, 0, 0, time.UTC))
+	rule := Rule{Name: "login", Limit: 2, Window: time.Minute}
+
+	var tripped []string
+	limiter.OnExceeded(func(r *http.Request, rule Rule, key string) {
+		tripped = append(tripped, key)
+	})
+
+	req := httptest.NewRequest("POST", "/auth/login", nil)
+	account := AccountKey("User@Example.com")
+
+	// The account limit trips even when requests come from different IPs
+	for i, ip := range []string{"10.0.0.1:1234", "10.0.0.2:1234", "10.0.0.3:1234", "10.0.0.4:1234"} {
+		req.RemoteAddr = ip
+		result := limiter.Check(req, rule, IPKey(req), account)
+		if expected := i < 2; result.Allowed != expected {
+			t.Errorf("request %d: expected allowed=%v, got %v", i+1, expected, result.Allowed)
+		}
+		if !result.Allowed && result.Key != account {
+			t.Errorf("expected the account key to deny the request, got %s", result.Key)
+		}
+	}
+
+	// Subsequent denials in the same window are only reported once
+	if len(tripped) != 1 || tripped[0] != "account:user@example.com" {
+		t.Errorf("expected a single exceeded callback for the account, got %v", tripped)
+	}
+}
+
+func TestClientIP(t *testing.T) {
+	req := httptest.NewRequest("GET", "/", nil)
+	req.RemoteAddr = "192.168.1.10:54321"
+	if ip := ClientIP(req); ip != "192.168.1.10" {
+		t.Errorf("expected RemoteAddr without port, got %s", ip)
+	}
+
+	req.Header.Set("X-Forwarded-For", "203.0.113.5, 10.0.0.1")
+	if ip := ClientIP(req); ip != "203.0.113.5" {
+		t.Errorf("expected first forwarded IP, got %s", ip)
+	}
+}
+
+func TestResult_RetryAfterSeconds(t *testing.T) {
+	if seconds := (Result{RetryAfter: 1500 * time.Millisecond}).RetryAfterSeconds(); seconds != 2 {
+		t.Errorf("expected 2 seconds, got %d", seconds)
+	}
+	if seconds := (Result{}).RetryAfterSeconds(); seconds != 1 {
+		t.Errorf("expected at least 1 second, got %d", seconds)
+	}
+}
@@ -0,0 +1,163 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// AnnouncementRepository handles announcement banner data operations
+type AnnouncementRepository struct {
+	db *sql.DB
+}
+
+// NewAnnouncementRepository creates a new announcement repository
+func NewAnnouncementRepository(db *sql.DB) *AnnouncementRepository {
+	return &AnnouncementRepository{db: db}
+}
+
+const announcementColumns = `id, message, link_url, link_text, level, audience, starts_at, ends_at,
+		       dismissible, created_by, created_at, updated_at`
+
+// List returns every announcement, those starting latest first
+func (r *AnnouncementRepository) List() ([]*models.Announcement, error) {
+	query := `SELECT ` + announcementColumns + ` FROM announcements ORDER BY starts_at DESC, id DESC`
+	return r.query(query)
+}
+
+// GetCurrent returns the announcements that haven't ended at the time, the
+// ones scheduled to start later included, soonest starting first
+func (r *AnnouncementRepository) GetCurrent(at time.Time) ([]*models.Announcement, error) {
+	query := `SELECT ` + announcementColumns + `
+		FROM announcements
+		WHERE ends_at IS NULL OR ends_at > $1
+		ORDER BY starts_at, id`
+	return r.query(query, at)
+}
+
+// GetByID returns an announcement
+func (r *AnnouncementRepository) GetByID(id int) (*models.Announcement, error) {
+	query := `SELECT ` + announcementColumns + ` FROM announcements WHERE id = $1`
+	announcement, err := scanAnnouncement(r.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("announcement with id %d not found", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get announcement: %w", err)
+	}
+	return announcement, nil
+}
+
+// Create saves a new announcement published by an admin
+func (r *AnnouncementRepository) Create(req *models.AnnouncementRequest, adminID int) (*models.Announcement, error) {
+	query := `
+		INSERT INTO announcements (message, link_url, link_text, level, audience, starts_at, ends_at, dismissible, created_by)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		RETURNING ` + announcementColumns
+
+	var createdBy interface{}
+	if adminID > 0 {
+		createdBy = adminID
+	}
+
+	announcement, err := scanAnnouncement(r.db.QueryRow(query,
+		req.Message, req.LinkURL, req.LinkText, req.Level, req.Audience, req.StartsAt, req.EndsAt, req.Dismissible, createdBy,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create announcement: %w", err)
+	}
+	return announcement, nil
+}
+
+// Update changes an announcement
+func (r *AnnouncementRepository) Update(id int, req *models.AnnouncementRequest) (*models.Announcement, error) {
+	query := `
+		UPDATE announcements
+		SET message = $2, link_url = $3, link_text = $4, level = $5, audience = $6,
+		    starts_at = $7, ends_at = $8, dismissible = $9, updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + announcementColumns
+
+	announcement, err := scanAnnouncement(r.db.QueryRow(query,
+		id, req.Message, req.LinkURL, req.LinkText, req.Level, req.Audience, req.StartsAt, req.EndsAt, req.Dismissible,
+	))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("announcement with id %d not found", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update announcement: %w", err)
+	}
+	return announcement, nil
+}
+
+// Delete removes an announcement
+func (r *AnnouncementRepository) Delete(id int) error {
+	result, err := r.db.Exec(`DELETE FROM announcements WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete announcement: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return fmt.Errorf("announcement with id %d not found", id)
+	}
+	return nil
+}
+
+// query runs a query returning announcements
+func (r *AnnouncementRepository) query(query string, args ...interface{}) ([]*models.Announcement, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query announcements: %w", err)
+	}
+	defer rows.Close()
+
+	var announcements []*models.Announcement
+	for rows.Next() {
+		announcement, err := scanAnnouncement(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan announcement: %w", err)
+		}
+		announcements = append(announcements, announcement)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating announcements: %w", err)
+	}
+
+	return announcements, nil
+}
+
+// scanAnnouncement scans a row of announcementColumns
+func scanAnnouncement(row interface {
+	Scan(dest ...interface{}) error
+}) (*models.Announcement, error) {
+	announcement := &models.Announcement{}
+	var endsAt sql.NullTime
+	var createdBy sql.NullInt64
+	err := row.Scan(
+		&announcement.ID,
+		&announcement.Message,
+		&announcement.LinkURL,
+		&announcement.LinkText,
+		&announcement.Level,
+		&announcement.Audience,
+		&announcement.StartsAt,
+		&endsAt,
+		&announcement.Dismissible,
+		&createdBy,
+		&announcement.CreatedAt,
+		&announcement.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if endsAt.Valid {
+		announcement.EndsAt = &endsAt.Time
+	}
+	if createdBy.Valid {
+		id := int(createdBy.Int64)
+		announcement.CreatedBy = &id
+	}
+	return announcement, nil
+}
@@ -0,0 +1,103 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// APITokenRepository handles API tokens issued to partner integrations
+type APITokenRepository struct {
+	db *sql.DB
+}
+
+// NewAPITokenRepository creates a new API token repository
+func NewAPITokenRepository(db *sql.DB) *APITokenRepository {
+	return &APITokenRepository{db: db}
+}
+
+// Create stores a new token
+func (r *APITokenRepository) Create(token *models.APIToken) error {
+	err := r.db.QueryRow(`
+		INSERT INTO api_tokens (user_id, name, token_hash, prefix)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`,
+		token.UserID, token.Name, token.TokenHash, token.Prefix,
+	).Scan(&token.ID, &token.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create API token: %w", err)
+	}
+	return nil
+}
+
+// GetByHash retrieves a token by the hash of its value
+func (r *APITokenRepository) GetByHash(hash string) (*models.APIToken, error) {
+	token := &models.APIToken{}
+	err := r.db.QueryRow(`
+		SELECT id, user_id, name, token_hash, prefix, last_used_at, revoked_at, created_at
+		FROM api_tokens
+		WHERE token_hash = $1`, hash,
+	).Scan(&token.ID, &token.UserID, &token.Name, &token.TokenHash, &token.Prefix,
+		&token.LastUsedAt, &token.RevokedAt, &token.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("API token not found")
+		}
+		return nil, fmt.Errorf("failed to get API token: %w", err)
+	}
+	return token, nil
+}
+
+// GetByUser retrieves the tokens issued to a user, newest first
+func (r *APITokenRepository) GetByUser(userID int) ([]*models.APIToken, error) {
+	rows, err := r.db.Query(`
+		SELECT id, user_id, name, token_hash, prefix, last_used_at, revoked_at, created_at
+		FROM api_tokens
+		WHERE user_id = $1
+		ORDER BY created_at DESC`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get API tokens: %w", err)
+	}
+	defer rows.Close()
+
+	var tokens []*models.APIToken
+	for rows.Next() {
+		token := &models.APIToken{}
+		if err := rows.Scan(&token.ID, &token.UserID, &token.Name, &token.TokenHash, &token.Prefix,
+			&token.LastUsedAt, &token.RevokedAt, &token.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan API token: %w", err)
+		}
+		tokens = append(tokens, token)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating API tokens: %w", err)
+	}
+	return tokens, nil
+}
+
+// MarkUsed records that a token was just used
+func (r *APITokenRepository) MarkUsed(id int) error {
+	if _, err := r.db.Exec("UPDATE api_tokens SET last_used_at = CURRENT_TIMESTAMP WHERE id = $1", id); err != nil {
+		return fmt.Errorf("failed to mark API token used: %w", err)
+	}
+	return nil
+}
+
+// Revoke stops a token from being used
+func (r *APITokenRepository) Revoke(id int) error {
+	result, err := r.db.Exec(`
+		UPDATE api_tokens SET revoked_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND revoked_at IS NULL`, id)
+	if err != nil {
+		return fmt.Errorf("failed to revoke API token: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("API token not found or already revoked")
+	}
+	return nil
+}
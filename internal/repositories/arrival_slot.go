@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// ArrivalSlotRepository handles event arrival slot data operations
+type ArrivalSlotRepository struct {
+	db *sql.DB
+}
+
+// NewArrivalSlotRepository creates a new arrival slot repository
+func NewArrivalSlotRepository(db *sql.DB) *ArrivalSlotRepository {
+	return &ArrivalSlotRepository{db: db}
+}
+
+// arrivalSlotQuery selects slots with the number of tickets that chose them.
+// Refunded tickets and unfinished orders do not take up a place.
+const arrivalSlotQuery = `
+	SELECT s.id, s.event_id, s.starts_at, s.ends_at, s.capacity, s.created_at,
+		COUNT(t.id)
+	FROM event_arrival_slots s
+	LEFT JOIN orders o ON o.arrival_slot_id = s.id AND o.status = 'completed'
+	LEFT JOIN tickets t ON t.order_id = o.id AND t.status IN ('active', 'used')`
+
+// Create adds an arrival slot to an event
+func (r *ArrivalSlotRepository) Create(req *models.ArrivalSlotCreateRequest) (*models.ArrivalSlot, error) {
+	query := `
+		INSERT INTO event_arrival_slots (event_id, starts_at, ends_at, capacity, created_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		RETURNING id, event_id, starts_at, ends_at, capacity, created_at`
+
+	slot := &models.ArrivalSlot{}
+	err := r.db.QueryRow(query, req.EventID, req.StartsAt, req.EndsAt, req.Capacity).Scan(
+		&slot.ID,
+		&slot.EventID,
+		&slot.StartsAt,
+		&slot.EndsAt,
+		&slot.Capacity,
+		&slot.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create arrival slot: %w", err)
+	}
+
+	return slot, nil
+}
+
+// GetByID retrieves an arrival slot with its bookings
+func (r *ArrivalSlotRepository) GetByID(id int) (*models.ArrivalSlot, error) {
+	query := arrivalSlotQuery + `
+		WHERE s.id = $1
+		GROUP BY s.id`
+
+	slot := &models.ArrivalSlot{}
+	err := r.db.QueryRow(query, id).Scan(
+		&slot.ID,
+		&slot.EventID,
+		&slot.StartsAt,
+		&slot.EndsAt,
+		&slot.Capacity,
+		&slot.CreatedAt,
+		&slot.Booked,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("arrival slot with id %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get arrival slot: %w", err)
+	}
+
+	return slot, nil
+}
+
+// GetByEvent retrieves an event's arrival slots with their bookings, earliest first
+func (r *ArrivalSlotRepository) GetByEvent(eventID int) ([]*models.ArrivalSlot, error) {
+	query := arrivalSlotQuery + `
+		WHERE s.event_id = $1
+		GROUP BY s.id
+		ORDER BY s.starts_at, s.id`
+
+	rows, err := r.db.Query(query, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query arrival slots: %w", err)
+	}
+	defer rows.Close()
+
+	var slots []*models.ArrivalSlot
+	for rows.Next() {
+		slot := &models.ArrivalSlot{}
+		err := rows.Scan(
+			&slot.ID,
+			&slot.EventID,
+			&slot.StartsAt,
+			&slot.EndsAt,
+			&slot.Capacity,
+			&slot.CreatedAt,
+			&slot.Booked,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan arrival slot: %w", err)
+		}
+		slots = append(slots, slot)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating arrival slots: %w", err)
+	}
+
+	return slots, nil
+}
+
+// Delete removes an arrival slot from an event
+func (r *ArrivalSlotRepository) Delete(id, eventID int) error {
+	result, err := r.db.Exec("DELETE FROM event_arrival_slots WHERE id = $1 AND event_id = $2", id, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to delete arrival slot: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("arrival slot with id %d not found", id)
+	}
+
+	return nil
+}
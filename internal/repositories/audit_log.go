@@ -3,6 +3,8 @@ package repositories
 import (
 	"database/sql"
 	"fmt"
+	"strconv"
+	"strings"
 	"time"
 
 	"event-ticketing-platform/internal/models"
@@ -22,8 +24,8 @@ func NewAuditLogRepository(db *sql.DB) *AuditLogRepository {
 func (r *AuditLogRepository) Create(req *models.AuditLogCreateRequest) (*models.AuditLog, error) {
 	query := `
 		INSERT INTO admin_audit_log (admin_user_id, action, target_type, target_id, details, ip_address, user_agent, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8)
-		RETURNING id, admin_user_id, action, target_type, target_id, details, ip_address, user_agent, created_at`
+		VALUES (NULLIF($1, 0), $2, $3, $4, $5, $6, $7, $8)
+		RETURNING id, COALESCE(admin_user_id, 0), action, target_type, target_id, details, ip_address, user_agent, created_at`
 
 	auditLog := &models.AuditLog{}
 	now := time.Now()
@@ -69,11 +71,11 @@ func (r *AuditLogRepository) GetByAdminUser(adminUserID int, limit, offset int)
 
 	// Get audit logs
 	query := `
-		SELECT al.id, al.admin_user_id, al.action, al.target_type, al.target_id, 
+		SELECT al.id, COALESCE(al.admin_user_id, 0), al.action, al.target_type, al.target_id, 
 		       al.details, al.ip_address, al.user_agent, al.created_at,
-		       u.first_name, u.last_name, u.email
+		       COALESCE(u.first_name, ''), COALESCE(u.last_name, ''), COALESCE(u.email, '')
 		FROM admin_audit_log al
-		JOIN users u ON al.admin_user_id = u.id
+		LEFT JOIN users u ON al.admin_user_id = u.id
 		WHERE al.admin_user_id = $1
 		ORDER BY al.created_at DESC
 		LIMIT $2 OFFSET $3`
@@ -155,11 +157,11 @@ func (r *AuditLogRepository) GetAll(limit, offset int, action, targetType string
 
 	// Get audit logs
 	query := fmt.Sprintf(`
-		SELECT al.id, al.admin_user_id, al.action, al.target_type, al.target_id,
+		SELECT al.id, COALESCE(al.admin_user_id, 0), al.action, al.target_type, al.target_id,
 		       al.details, al.ip_address, al.user_agent, al.created_at,
-		       u.first_name, u.last_name, u.email
+		       COALESCE(u.first_name, ''), COALESCE(u.last_name, ''), COALESCE(u.email, '')
 		FROM admin_audit_log al
-		JOIN users u ON al.admin_user_id = u.id
+		LEFT JOIN users u ON al.admin_user_id = u.id
 		%s
 		ORDER BY al.created_at DESC
 		LIMIT $%d OFFSET $%d`, whereClause, argIndex, argIndex+1)
@@ -206,6 +208,138 @@ func (r *AuditLogRepository) GetAll(limit, offset int, action, targetType string
 	return auditLogs, totalCount, nil
 }
 
+// Search retrieves the audit logs matching a filter, newest first, with the
+// total number that match
+func (r *AuditLogRepository) Search(filter models.AuditLogFilter, limit, offset int) ([]*models.AuditLog, int, error) {
+	var whereConditions []string
+	var args []interface{}
+	addCondition := func(condition string, arg interface{}) {
+		args = append(args, arg)
+		whereConditions = append(whereConditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if actor := strings.TrimSpace(filter.Actor); actor != "" {
+		if actor == models.AuditActorSystem {
+			whereConditions = append(whereConditions, "al.admin_user_id IS NULL")
+		} else if actorID, err := strconv.Atoi(actor); err == nil {
+			addCondition("al.admin_user_id = $%d", actorID)
+		} else {
+			addCondition("u.email ILIKE $%d", "%"+actor+"%")
+		}
+	}
+	if filter.Action != "" {
+		addCondition("al.action = $%d", filter.Action)
+	}
+	if filter.TargetType != "" {
+		addCondition("al.target_type = $%d", filter.TargetType)
+	}
+	if filter.TargetID > 0 {
+		addCondition("al.target_id = $%d", filter.TargetID)
+	}
+	if filter.From != nil {
+		addCondition("al.created_at >= $%d", *filter.From)
+	}
+	if filter.To != nil {
+		addCondition("al.created_at < $%d", *filter.To)
+	}
+
+	whereClause := ""
+	if len(whereConditions) > 0 {
+		whereClause = "WHERE " + strings.Join(whereConditions, " AND ")
+	}
+
+	countQuery := fmt.Sprintf(`
+		SELECT COUNT(*)
+		FROM admin_audit_log al
+		LEFT JOIN users u ON al.admin_user_id = u.id
+		%s`, whereClause)
+	var totalCount int
+	if err := r.db.QueryRow(countQuery, args...).Scan(&totalCount); err != nil {
+		return nil, 0, fmt.Errorf("failed to get audit log count: %w", err)
+	}
+
+	query := fmt.Sprintf(`
+		SELECT al.id, COALESCE(al.admin_user_id, 0), al.action, al.target_type, COALESCE(al.target_id, 0),
+		       al.details, COALESCE(al.ip_address, ''), COALESCE(al.user_agent, ''), al.created_at,
+		       COALESCE(u.first_name, ''), COALESCE(u.last_name, ''), COALESCE(u.email, '')
+		FROM admin_audit_log al
+		LEFT JOIN users u ON al.admin_user_id = u.id
+		%s
+		ORDER BY al.created_at DESC, al.id DESC
+		LIMIT $%d OFFSET $%d`, whereClause, len(args)+1, len(args)+2)
+
+	rows, err := r.db.Query(query, append(args, limit, offset)...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query audit logs: %w", err)
+	}
+	defer rows.Close()
+
+	var auditLogs []*models.AuditLog
+	for rows.Next() {
+		auditLog := &models.AuditLog{
+			AdminUser: &models.User{},
+		}
+
+		err := rows.Scan(
+			&auditLog.ID,
+			&auditLog.AdminUserID,
+			&auditLog.Action,
+			&auditLog.TargetType,
+			&auditLog.TargetID,
+			&auditLog.Details,
+			&auditLog.IPAddress,
+			&auditLog.UserAgent,
+			&auditLog.CreatedAt,
+			&auditLog.AdminUser.FirstName,
+			&auditLog.AdminUser.LastName,
+			&auditLog.AdminUser.Email,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan audit log: %w", err)
+		}
+
+		auditLogs = append(auditLogs, auditLog)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating audit logs: %w", err)
+	}
+
+	return auditLogs, totalCount, nil
+}
+
+// GetFilterOptions returns the actions and target types that appear in the
+// audit log, for filtering it
+func (r *AuditLogRepository) GetFilterOptions() ([]string, []string, error) {
+	distinct := func(column string) ([]string, error) {
+		rows, err := r.db.Query(fmt.Sprintf("SELECT DISTINCT %s FROM admin_audit_log WHERE %s IS NOT NULL ORDER BY %s", column, column, column))
+		if err != nil {
+			return nil, fmt.Errorf("failed to query audit log %ss: %w", column, err)
+		}
+		defer rows.Close()
+
+		var values []string
+		for rows.Next() {
+			var value string
+			if err := rows.Scan(&value); err != nil {
+				return nil, fmt.Errorf("failed to scan audit log %s: %w", column, err)
+			}
+			values = append(values, value)
+		}
+		return values, rows.Err()
+	}
+
+	actions, err := distinct("action")
+	if err != nil {
+		return nil, nil, err
+	}
+	targetTypes, err := distinct("target_type")
+	if err != nil {
+		return nil, nil, err
+	}
+	return actions, targetTypes, nil
+}
+
 // GetByTarget retrieves audit logs for a specific target
 func (r *AuditLogRepository) GetByTarget(targetType string, targetID int, limit, offset int) ([]*models.AuditLog, int, error) {
 	// Get total count
@@ -218,11 +352,11 @@ func (r *AuditLogRepository) GetByTarget(targetType string, targetID int, limit,
 
 	// Get audit logs
 	query := `
-		SELECT al.id, al.admin_user_id, al.action, al.target_type, al.target_id,
+		SELECT al.id, COALESCE(al.admin_user_id, 0), al.action, al.target_type, al.target_id,
 		       al.details, al.ip_address, al.user_agent, al.created_at,
-		       u.first_name, u.last_name, u.email
+		       COALESCE(u.first_name, ''), COALESCE(u.last_name, ''), COALESCE(u.email, '')
 		FROM admin_audit_log al
-		JOIN users u ON al.admin_user_id = u.id
+		LEFT JOIN users u ON al.admin_user_id = u.id
 		WHERE al.target_type = $1 AND al.target_id = $2
 		ORDER BY al.created_at DESC
 		LIMIT $3 OFFSET $4`
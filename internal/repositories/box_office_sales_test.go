@@ -0,0 +1,109 @@
+package repositories
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// recordingConnector opens database connections that record the SQL sent to
+// them and return no rows, so tests can check what a query asks for without
+// a database
+type recordingConnector struct {
+	queries []string
+}
+
+func (c *recordingConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return &recordingConn{connector: c}, nil
+}
+
+func (c *recordingConnector) Driver() driver.Driver {
+	return recordingDriver{connector: c}
+}
+
+type recordingDriver struct {
+	connector *recordingConnector
+}
+
+func (d recordingDriver) Open(name string) (driver.Conn, error) {
+	return d.connector.Connect(context.Background())
+}
+
+type recordingConn struct {
+	connector *recordingConnector
+}
+
+func (c *recordingConn) Prepare(query string) (driver.Stmt, error) {
+	c.connector.queries = append(c.connector.queries, query)
+	return recordingStmt{}, nil
+}
+
+func (c *recordingConn) Close() error              { return nil }
+func (c *recordingConn) Begin() (driver.Tx, error) { return recordingTx{}, nil }
+
+type recordingStmt struct{}
+
+func (recordingStmt) Close() error  { return nil }
+func (recordingStmt) NumInput() int { return -1 }
+func (recordingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(0), nil
+}
+func (recordingStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return noRows{}, nil
+}
+
+type recordingTx struct{}
+
+func (recordingTx) Commit() error   { return nil }
+func (recordingTx) Rollback() error { return nil }
+
+type noRows struct{}
+
+func (noRows) Columns() []string              { return nil }
+func (noRows) Close() error                   { return nil }
+func (noRows) Next(dest []driver.Value) error { return io.EOF }
+
+// newRecordingDB returns a database that records the queries run on it
+func newRecordingDB() (*sql.DB, *recordingConnector) {
+	connector := &recordingConnector{}
+	return sql.OpenDB(connector), connector
+}
+
+// TestBoxOfficeSalesLeftOutOfPayouts checks that the queries behind payouts,
+// platform fees and revenue still to be paid out leave out box office sales,
+// which the organizer was paid for at the door
+func TestBoxOfficeSalesLeftOutOfPayouts(t *testing.T) {
+	now := time.Now()
+	tests := map[string]func(db *sql.DB){
+		"withdrawable balance": func(db *sql.DB) {
+			NewWithdrawalRepository(db).GetOrganizerBalance(1)
+		},
+		"ledger sync": func(db *sql.DB) {
+			tx, _ := db.Begin()
+			defer tx.Rollback()
+			NewLedgerRepository(db).pendingSources(tx, 1)
+		},
+		"settlement lines": func(db *sql.DB) {
+			NewFinanceRepository(db).GetSettlementLines(1, 0, now.AddDate(0, -1, 0), now)
+		},
+		"cash-flow revenue": func(db *sql.DB) {
+			NewCashFlowRepository(db).GetUnsettledEventRevenue(1, now)
+		},
+	}
+
+	condition := models.OnlinePaymentCondition("o.payment_id")
+	for name, run := range tests {
+		db, recorded := newRecordingDB()
+		run(db)
+		db.Close()
+		if len(recorded.queries) == 0 || !strings.Contains(recorded.queries[0], condition) {
+			t.Errorf("%s: expected the query to leave out box office sales, got %q", name, recorded.queries)
+		}
+	}
+}
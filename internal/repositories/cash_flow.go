@@ -0,0 +1,72 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// CashFlowRepository reads the revenue and withdrawals behind organizers'
+// cash-flow projections
+type CashFlowRepository struct {
+	db *sql.DB
+}
+
+// NewCashFlowRepository creates a new cash-flow repository
+func NewCashFlowRepository(db *sql.DB) *CashFlowRepository {
+	return &CashFlowRepository{db: db}
+}
+
+// GetUnsettledEventRevenue returns the completed order revenue of the
+// organizer's events that have not ended by now, soonest first. Cancelled
+// events are left out because their revenue is already being refunded, and
+// box office sales because the organizer was paid for them at the door.
+func (r *CashFlowRepository) GetUnsettledEventRevenue(organizerID int, now time.Time) ([]*models.EventRevenue, error) {
+	query := fmt.Sprintf(`
+		SELECT e.id, e.title, e.start_date, e.end_date, COUNT(o.id), COALESCE(SUM(o.total_amount), 0)
+		FROM events e
+		JOIN orders o ON o.event_id = e.id AND o.status = 'completed' AND %s
+		WHERE e.organizer_id = $1 AND e.status <> 'cancelled' AND e.end_date > $2
+		GROUP BY e.id, e.title, e.start_date, e.end_date
+		ORDER BY e.start_date, e.id`, models.OnlinePaymentCondition("o.payment_id"))
+
+	rows, err := r.db.Query(query, organizerID, now)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query event revenue: %w", err)
+	}
+	defer rows.Close()
+
+	var revenue []*models.EventRevenue
+	for rows.Next() {
+		event := &models.EventRevenue{}
+		if err := rows.Scan(&event.EventID, &event.Title, &event.StartDate, &event.EndDate, &event.Orders, &event.GrossCents); err != nil {
+			return nil, fmt.Errorf("failed to scan event revenue: %w", err)
+		}
+		revenue = append(revenue, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating event revenue: %w", err)
+	}
+
+	return revenue, nil
+}
+
+// GetOpenWithdrawalTotals returns the totals of the organizer's withdrawals
+// awaiting review and of those approved but not yet paid out
+func (r *CashFlowRepository) GetOpenWithdrawalTotals(organizerID int) (pending, approved float64, err error) {
+	query := `
+		SELECT
+			COALESCE(SUM(amount) FILTER (WHERE status = 'pending'), 0),
+			COALESCE(SUM(amount) FILTER (WHERE status = 'approved'), 0)
+		FROM withdrawals
+		WHERE organizer_id = $1`
+
+	if err := r.db.QueryRow(query, organizerID).Scan(&pending, &approved); err != nil {
+		return 0, 0, fmt.Errorf("failed to get open withdrawals: %w", err)
+	}
+
+	return pending, approved, nil
+}
@@ -0,0 +1,156 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// CategoryRepository handles category landing page data operations
+type CategoryRepository struct {
+	db *sql.DB
+}
+
+// NewCategoryRepository creates a new category repository
+func NewCategoryRepository(db *sql.DB) *CategoryRepository {
+	return &CategoryRepository{db: db}
+}
+
+const categoryColumns = `id, name, slug, COALESCE(description, '') AS description, hero_image_url, meta_title,
+		       meta_description, created_at`
+
+// GetBySlug returns the category with the slug
+func (r *CategoryRepository) GetBySlug(slug string) (*models.Category, error) {
+	query := `SELECT ` + categoryColumns + ` FROM categories WHERE slug = $1`
+	category, err := scanCategory(r.db.QueryRow(query, slug))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("category %q not found", slug)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+	return category, nil
+}
+
+// GetByID returns a category
+func (r *CategoryRepository) GetByID(id int) (*models.Category, error) {
+	query := `SELECT ` + categoryColumns + ` FROM categories WHERE id = $1`
+	category, err := scanCategory(r.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("category with id %d not found", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get category: %w", err)
+	}
+	return category, nil
+}
+
+// UpdateLandingPage saves a category's hero image and search metadata
+func (r *CategoryRepository) UpdateLandingPage(id int, req *models.CategoryLandingPageRequest) (*models.Category, error) {
+	query := `
+		UPDATE categories
+		SET hero_image_url = $2, meta_title = $3, meta_description = $4
+		WHERE id = $1
+		RETURNING ` + categoryColumns
+
+	category, err := scanCategory(r.db.QueryRow(query, id, req.HeroImageURL, req.MetaTitle, req.MetaDescription))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("category with id %d not found", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update category landing page: %w", err)
+	}
+	return category, nil
+}
+
+// GetFeaturedEvents returns the events featured on a category's landing page
+// in the order admins added them. With liveOnly, it leaves out events that
+// aren't published or have ended.
+func (r *CategoryRepository) GetFeaturedEvents(categoryID int, liveOnly bool) ([]*models.Event, error) {
+	query := `
+		SELECT e.id, e.title, e.description, e.start_date, e.end_date, e.location, e.category_id, e.organizer_id, e.image_url, e.image_key, e.image_size, e.image_format, e.image_width, e.image_height, e.image_uploaded_at, e.image_alt_text, e.image_variants, e.slug, e.status, e.created_at, e.updated_at
+		FROM category_featured_events f
+		JOIN events e ON e.id = f.event_id
+		WHERE f.category_id = $1 AND e.deleted_at IS NULL`
+	args := []interface{}{categoryID}
+	if liveOnly {
+		query += ` AND e.status = $2 AND e.end_date > NOW()`
+		args = append(args, models.StatusPublished)
+	}
+	query += ` ORDER BY f.position`
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query featured events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*models.Event
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan featured event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating featured events: %w", err)
+	}
+
+	return events, nil
+}
+
+// AddFeaturedEvent features an event of the category on its landing page,
+// after the events already featured. It returns false if the event isn't in
+// the category.
+func (r *CategoryRepository) AddFeaturedEvent(categoryID, eventID, adminID int) (bool, error) {
+	query := `
+		INSERT INTO category_featured_events (category_id, event_id, position, added_by)
+		SELECT $1, e.id,
+		       COALESCE((SELECT MAX(position) FROM category_featured_events WHERE category_id = $1), 0) + 1, $3
+		FROM events e
+		WHERE e.id = $2 AND e.category_id = $1 AND e.deleted_at IS NULL
+		ON CONFLICT (category_id, event_id) DO NOTHING`
+
+	result, err := r.db.Exec(query, categoryID, eventID, nullableAdminID(adminID))
+	if err != nil {
+		return false, fmt.Errorf("failed to feature event: %w", err)
+	}
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to feature event: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// RemoveFeaturedEvent stops featuring an event on a category's landing page
+func (r *CategoryRepository) RemoveFeaturedEvent(categoryID, eventID int) error {
+	_, err := r.db.Exec(`DELETE FROM category_featured_events WHERE category_id = $1 AND event_id = $2`, categoryID, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to remove featured event: %w", err)
+	}
+	return nil
+}
+
+// scanCategory scans a row of categoryColumns
+func scanCategory(row interface {
+	Scan(dest ...interface{}) error
+}) (*models.Category, error) {
+	category := &models.Category{}
+	err := row.Scan(
+		&category.ID,
+		&category.Name,
+		&category.Slug,
+		&category.Description,
+		&category.HeroImageURL,
+		&category.MetaTitle,
+		&category.MetaDescription,
+		&category.CreatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return category, nil
+}
@@ -0,0 +1,167 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// CheckoutQuestionRepository handles events' checkout questions and the
+// answers stored with tickets
+type CheckoutQuestionRepository struct {
+	db *sql.DB
+}
+
+// NewCheckoutQuestionRepository creates a new checkout question repository
+func NewCheckoutQuestionRepository(db *sql.DB) *CheckoutQuestionRepository {
+	return &CheckoutQuestionRepository{db: db}
+}
+
+// Create adds a checkout question to the end of an event's questions
+func (r *CheckoutQuestionRepository) Create(req *models.CheckoutQuestionCreateRequest) (*models.CheckoutQuestion, error) {
+	query := `
+		INSERT INTO event_checkout_questions (event_id, label, field_type, options, required, position, created_at)
+		VALUES ($1, $2, $3, $4, $5,
+			(SELECT COALESCE(MAX(position), 0) + 1 FROM event_checkout_questions WHERE event_id = $1), NOW())
+		RETURNING id, event_id, label, field_type, options, required, position, created_at`
+
+	options := req.Options
+	if options == nil {
+		options = []string{}
+	}
+
+	question := &models.CheckoutQuestion{}
+	err := r.db.QueryRow(query, req.EventID, req.Label, req.Type, pq.Array(options), req.Required).Scan(
+		&question.ID,
+		&question.EventID,
+		&question.Label,
+		&question.Type,
+		pq.Array(&question.Options),
+		&question.Required,
+		&question.Position,
+		&question.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create checkout question: %w", err)
+	}
+
+	return question, nil
+}
+
+// GetByEvent retrieves an event's checkout questions in the order they are asked
+func (r *CheckoutQuestionRepository) GetByEvent(eventID int) ([]*models.CheckoutQuestion, error) {
+	query := `
+		SELECT id, event_id, label, field_type, options, required, position, created_at
+		FROM event_checkout_questions
+		WHERE event_id = $1
+		ORDER BY position, id`
+
+	rows, err := r.db.Query(query, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checkout questions: %w", err)
+	}
+	defer rows.Close()
+
+	var questions []*models.CheckoutQuestion
+	for rows.Next() {
+		question := &models.CheckoutQuestion{}
+		if err := rows.Scan(
+			&question.ID,
+			&question.EventID,
+			&question.Label,
+			&question.Type,
+			pq.Array(&question.Options),
+			&question.Required,
+			&question.Position,
+			&question.CreatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan checkout question: %w", err)
+		}
+		questions = append(questions, question)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating checkout questions: %w", err)
+	}
+
+	return questions, nil
+}
+
+// Delete removes a checkout question from an event, with its answers
+func (r *CheckoutQuestionRepository) Delete(id, eventID int) error {
+	result, err := r.db.Exec(`DELETE FROM event_checkout_questions WHERE id = $1 AND event_id = $2`, id, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to delete checkout question: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("checkout question with id %d not found", id)
+	}
+
+	return nil
+}
+
+// SaveAnswers stores attendees' answers with their tickets
+func (r *CheckoutQuestionRepository) SaveAnswers(answers []*models.TicketAnswer) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	for _, answer := range answers {
+		_, err := tx.Exec(`
+			INSERT INTO ticket_answers (ticket_id, question_id, answer)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (ticket_id, question_id) DO UPDATE SET answer = EXCLUDED.answer`,
+			answer.TicketID, answer.QuestionID, answer.Answer)
+		if err != nil {
+			return fmt.Errorf("failed to save answer: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit answers: %w", err)
+	}
+	return nil
+}
+
+// GetAnswersByEvent retrieves the answers attendees of an event gave, in
+// ticket order
+func (r *CheckoutQuestionRepository) GetAnswersByEvent(eventID int) ([]*models.TicketAnswer, error) {
+	query := `
+		SELECT a.ticket_id, a.question_id, a.answer, t.order_id
+		FROM ticket_answers a
+		JOIN event_checkout_questions q ON q.id = a.question_id
+		JOIN tickets t ON t.id = a.ticket_id
+		WHERE q.event_id = $1
+		ORDER BY t.id, q.position`
+
+	rows, err := r.db.Query(query, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get answers: %w", err)
+	}
+	defer rows.Close()
+
+	var answers []*models.TicketAnswer
+	for rows.Next() {
+		answer := &models.TicketAnswer{}
+		if err := rows.Scan(&answer.TicketID, &answer.QuestionID, &answer.Answer, &answer.OrderID); err != nil {
+			return nil, fmt.Errorf("failed to scan answer: %w", err)
+		}
+		answers = append(answers, answer)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating answers: %w", err)
+	}
+
+	return answers, nil
+}
@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// ContentFlagRepository handles event content screening flags
+type ContentFlagRepository struct {
+	db *sql.DB
+}
+
+// NewContentFlagRepository creates a new content flag repository
+func NewContentFlagRepository(db *sql.DB) *ContentFlagRepository {
+	return &ContentFlagRepository{db: db}
+}
+
+// Create stores a content flag for an event
+func (r *ContentFlagRepository) Create(flag *models.EventContentFlag) error {
+	reasonsJSON, err := json.Marshal(flag.Reasons)
+	if err != nil {
+		return fmt.Errorf("failed to encode flag reasons: %w", err)
+	}
+
+	query := `
+		INSERT INTO event_content_flags (event_id, score, reasons)
+		VALUES ($1, $2, $3)
+		RETURNING id, created_at`
+
+	err = r.db.QueryRow(query, flag.EventID, flag.Score, reasonsJSON).Scan(&flag.ID, &flag.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create content flag: %w", err)
+	}
+
+	return nil
+}
+
+// GetLatestByEvents returns the most recent content flag of each event that
+// has one, keyed by event ID
+func (r *ContentFlagRepository) GetLatestByEvents(eventIDs []int) (map[int]*models.EventContentFlag, error) {
+	flags := make(map[int]*models.EventContentFlag)
+	if len(eventIDs) == 0 {
+		return flags, nil
+	}
+
+	query := `
+		SELECT DISTINCT ON (event_id) id, event_id, score, reasons, created_at
+		FROM event_content_flags
+		WHERE event_id = ANY($1)
+		ORDER BY event_id, created_at DESC, id DESC`
+
+	rows, err := r.db.Query(query, pq.Array(eventIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query content flags: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		flag := &models.EventContentFlag{}
+		var reasonsJSON []byte
+		if err := rows.Scan(&flag.ID, &flag.EventID, &flag.Score, &reasonsJSON, &flag.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan content flag: %w", err)
+		}
+		if err := json.Unmarshal(reasonsJSON, &flag.Reasons); err != nil {
+			return nil, fmt.Errorf("failed to decode flag reasons: %w", err)
+		}
+		flags[flag.EventID] = flag
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating content flags: %w", err)
+	}
+
+	return flags, nil
+}
@@ -0,0 +1,241 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// DataQualityRepository runs data-integrity checks and their remediation queries
+type DataQualityRepository struct {
+	db *sql.DB
+}
+
+// NewDataQualityRepository creates a new data quality repository
+func NewDataQualityRepository(db *sql.DB) *DataQualityRepository {
+	return &DataQualityRepository{db: db}
+}
+
+// issuedTicketsSQL counts the tickets that hold inventory for the ticket type aliased tt
+const issuedTicketsSQL = `(SELECT COUNT(*) FROM tickets t WHERE t.ticket_type_id = tt.id AND t.status != 'refunded')`
+
+// checkoutInProgressSQL matches events aliased tt with a recent pending order,
+// which are left alone while their checkout may still be completing
+const checkoutInProgressSQL = `EXISTS (
+	SELECT 1 FROM orders po
+	WHERE po.event_id = tt.event_id AND po.status = 'pending'
+	  AND po.created_at > CURRENT_TIMESTAMP - INTERVAL '30 minutes'
+)`
+
+// FindPublishedEventsWithoutTicketTypes finds published events nobody can buy tickets for
+func (r *DataQualityRepository) FindPublishedEventsWithoutTicketTypes() ([]*models.DataQualityIssue, error) {
+	query := `
+		SELECT e.id, e.title
+		FROM events e
+		WHERE e.status = 'published'
+		  AND NOT EXISTS (SELECT 1 FROM ticket_types tt WHERE tt.event_id = e.id)
+		ORDER BY e.start_date ASC`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events without ticket types: %w", err)
+	}
+	defer rows.Close()
+
+	var issues []*models.DataQualityIssue
+	for rows.Next() {
+		var id int
+		var title string
+		if err := rows.Scan(&id, &title); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		issues = append(issues, &models.DataQualityIssue{
+			TargetType: models.AuditTargetEvent,
+			TargetID:   id,
+			Summary:    fmt.Sprintf("%q is published but has no ticket types", title),
+			Fixable:    true,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating events: %w", err)
+	}
+
+	return issues, nil
+}
+
+// FindCompletedOrdersWithoutTickets finds paid orders that never had tickets issued
+func (r *DataQualityRepository) FindCompletedOrdersWithoutTickets() ([]*models.OrderWithoutTickets, error) {
+	query := `
+		SELECT o.id, o.order_number, o.event_id, COALESCE(e.title, ''), o.total_amount,
+		       COALESCE(tt.type_count, 0), COALESCE(tt.type_id, 0), COALESCE(tt.price, 0)
+		FROM orders o
+		LEFT JOIN events e ON e.id = o.event_id
+		LEFT JOIN (
+			SELECT event_id, COUNT(*) AS type_count,
+			       CASE WHEN COUNT(*) = 1 THEN MIN(id) END AS type_id,
+			       CASE WHEN COUNT(*) = 1 THEN MIN(price) END AS price
+			FROM ticket_types
+			GROUP BY event_id
+		) tt ON tt.event_id = o.event_id
+		WHERE o.status = 'completed'
+		  AND NOT EXISTS (SELECT 1 FROM tickets t WHERE t.order_id = o.id)
+		ORDER BY o.created_at ASC`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query orders without tickets: %w", err)
+	}
+	defer rows.Close()
+
+	var orders []*models.OrderWithoutTickets
+	for rows.Next() {
+		order := &models.OrderWithoutTickets{}
+		if err := rows.Scan(
+			&order.OrderID,
+			&order.OrderNumber,
+			&order.EventID,
+			&order.EventTitle,
+			&order.TotalAmount,
+			&order.TicketTypeCount,
+			&order.TicketTypeID,
+			&order.TicketPrice,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan order: %w", err)
+		}
+		orders = append(orders, order)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating orders: %w", err)
+	}
+
+	return orders, nil
+}
+
+// FindSoldCountMismatches finds ticket types whose sold count doesn't match the
+// number of issued tickets. Mismatches on events with a checkout in progress
+// are reported but not fixable, since their reservations aren't tickets yet.
+func (r *DataQualityRepository) FindSoldCountMismatches() ([]*models.DataQualityIssue, error) {
+	query := `
+		SELECT tt.id, tt.name, COALESCE(e.title, ''), tt.sold, tt.quantity, ` + issuedTicketsSQL + ` AS issued,
+		       ` + checkoutInProgressSQL + ` AS in_progress
+		FROM ticket_types tt
+		LEFT JOIN events e ON e.id = tt.event_id
+		WHERE tt.sold != ` + issuedTicketsSQL + `
+		ORDER BY tt.event_id, tt.id`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sold count mismatches: %w", err)
+	}
+	defer rows.Close()
+
+	var issues []*models.DataQualityIssue
+	for rows.Next() {
+		var id, sold, quantity, issued int
+		var name, eventTitle string
+		var inProgress bool
+		if err := rows.Scan(&id, &name, &eventTitle, &sold, &quantity, &issued, &inProgress); err != nil {
+			return nil, fmt.Errorf("failed to scan ticket type: %w", err)
+		}
+
+		summary := fmt.Sprintf("%s (%s): sold count is %d but %d tickets are issued", name, eventTitle, sold, issued)
+		if inProgress {
+			summary += "; a checkout is in progress"
+		}
+		issues = append(issues, &models.DataQualityIssue{
+			TargetType: "ticket_type",
+			TargetID:   id,
+			Summary:    summary,
+			Fixable:    !inProgress && issued <= quantity,
+		})
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating ticket types: %w", err)
+	}
+
+	return issues, nil
+}
+
+// UnpublishEventWithoutTicketTypes moves a published event back to draft if it
+// still has no ticket types. It returns false if the event no longer qualifies.
+func (r *DataQualityRepository) UnpublishEventWithoutTicketTypes(eventID int) (bool, error) {
+	query := `
+		UPDATE events e
+		SET status = 'draft', updated_at = CURRENT_TIMESTAMP
+		WHERE e.id = $1 AND e.status = 'published'
+		  AND NOT EXISTS (SELECT 1 FROM ticket_types tt WHERE tt.event_id = e.id)`
+
+	result, err := r.db.Exec(query, eventID)
+	if err != nil {
+		return false, fmt.Errorf("failed to unpublish event: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// RecalculateSoldCount sets a ticket type's sold count to the number of issued
+// tickets, unless a checkout is in progress for its event
+func (r *DataQualityRepository) RecalculateSoldCount(ticketTypeID int) (bool, error) {
+	query := `
+		UPDATE ticket_types tt
+		SET sold = ` + issuedTicketsSQL + `
+		WHERE tt.id = $1
+		  AND tt.sold != ` + issuedTicketsSQL + `
+		  AND ` + issuedTicketsSQL + ` <= tt.quantity
+		  AND NOT ` + checkoutInProgressSQL
+
+	result, err := r.db.Exec(query, ticketTypeID)
+	if err != nil {
+		return false, fmt.Errorf("failed to recalculate sold count: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// CreateTickets issues tickets for an order in a single transaction, as long as
+// the order still has none
+func (r *DataQualityRepository) CreateTickets(orderID, ticketTypeID int, qrCodes []string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var existing int
+	if err := tx.QueryRow(`SELECT COUNT(*) FROM tickets WHERE order_id = $1`, orderID).Scan(&existing); err != nil {
+		return fmt.Errorf("failed to count order tickets: %w", err)
+	}
+	if existing > 0 {
+		return fmt.Errorf("order %d already has tickets", orderID)
+	}
+
+	for _, qrCode := range qrCodes {
+		_, err := tx.Exec(`
+			INSERT INTO tickets (order_id, ticket_type_id, qr_code, status, created_at)
+			VALUES ($1, $2, $3, 'active', CURRENT_TIMESTAMP)`,
+			orderID, ticketTypeID, qrCode)
+		if err != nil {
+			return fmt.Errorf("failed to create ticket: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit tickets: %w", err)
+	}
+
+	return nil
+}
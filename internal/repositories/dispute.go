@@ -0,0 +1,268 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// DisputeRepository handles payment disputes and the orders they freeze
+type DisputeRepository struct {
+	db *sql.DB
+}
+
+// NewDisputeRepository creates a new dispute repository
+func NewDisputeRepository(db *sql.DB) *DisputeRepository {
+	return &DisputeRepository{db: db}
+}
+
+// disputeColumns selects a dispute with its order and event, from
+// payment_disputes d joined to orders o and events e
+const disputeColumns = `d.id, d.provider_dispute_id, d.order_id, d.payment_reference, d.amount, d.currency, d.reason, d.status,
+	d.order_status, d.evidence_text, d.evidence_url, d.evidence_submitted_at, d.due_at, d.resolution_note,
+	d.created_at, d.updated_at, d.resolved_at, o.order_number, e.id, e.title, e.organizer_id`
+
+const disputeJoins = `FROM payment_disputes d
+	JOIN orders o ON o.id = d.order_id
+	JOIN events e ON e.id = o.event_id`
+
+// maxListedDisputes bounds the disputes listed at once
+const maxListedDisputes = 200
+
+// FindOrderByPaymentReference returns the order a payment was made for, be it
+// the checkout payment, an installment or the difference of an amendment,
+// or nil if there is none
+func (r *DisputeRepository) FindOrderByPaymentReference(reference string) (*models.Order, error) {
+	query := `
+		SELECT id, event_id, order_number, total_amount, status, payment_id
+		FROM orders
+		WHERE id = (
+			SELECT id FROM orders WHERE payment_id = $1
+			UNION ALL
+			SELECT p.order_id FROM order_installments i JOIN order_installment_plans p ON p.id = i.plan_id WHERE i.payment_reference = $1
+			UNION ALL
+			SELECT order_id FROM order_amendments WHERE payment_reference = $1
+			LIMIT 1
+		)`
+
+	order := &models.Order{}
+	err := r.db.QueryRow(query, reference).Scan(&order.ID, &order.EventID, &order.OrderNumber, &order.TotalAmount, &order.Status, &order.PaymentID)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to find order by payment reference: %w", err)
+	}
+
+	return order, nil
+}
+
+// OpenDispute stores a new dispute and marks its order disputed if it was
+// completed. It returns false without changing anything if the provider's
+// dispute is already stored.
+func (r *DisputeRepository) OpenDispute(dispute *models.PaymentDispute) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(`
+		INSERT INTO payment_disputes (provider_dispute_id, order_id, payment_reference, amount, currency, reason, status, order_status, due_at)
+		SELECT $1, o.id, $3, $4, $5, $6, $7, o.status, $8
+		FROM orders o
+		WHERE o.id = $2
+		ON CONFLICT (provider_dispute_id) DO NOTHING
+		RETURNING id, order_status, created_at`,
+		dispute.ProviderDisputeID,
+		dispute.OrderID,
+		dispute.PaymentReference,
+		dispute.Amount,
+		dispute.Currency,
+		dispute.Reason,
+		models.DisputeOpen,
+		dispute.DueAt,
+	).Scan(&dispute.ID, &dispute.OrderStatus, &dispute.CreatedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to create dispute: %w", err)
+	}
+	dispute.Status = models.DisputeOpen
+
+	if _, err := tx.Exec(`UPDATE orders SET status = $2, updated_at = NOW() WHERE id = $1 AND status = $3`,
+		dispute.OrderID, models.OrderDisputed, models.OrderCompleted); err != nil {
+		return false, fmt.Errorf("failed to mark order disputed: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit dispute: %w", err)
+	}
+
+	return true, nil
+}
+
+// GetDispute retrieves a dispute, or nil if there is none
+func (r *DisputeRepository) GetDispute(id int) (*models.PaymentDispute, error) {
+	return r.getDispute(`SELECT `+disputeColumns+` `+disputeJoins+` WHERE d.id = $1`, id)
+}
+
+// GetDisputeByProviderID retrieves a dispute by the provider's ID for it, or
+// nil if there is none
+func (r *DisputeRepository) GetDisputeByProviderID(providerDisputeID string) (*models.PaymentDispute, error) {
+	return r.getDispute(`SELECT `+disputeColumns+` `+disputeJoins+` WHERE d.provider_dispute_id = $1`, providerDisputeID)
+}
+
+func (r *DisputeRepository) getDispute(query string, arg interface{}) (*models.PaymentDispute, error) {
+	dispute, err := scanDispute(r.db.QueryRow(query, arg))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get dispute: %w", err)
+	}
+	return dispute, nil
+}
+
+// GetDisputes returns the disputes of an organizer's orders, or of every
+// order for an organizerID of 0, unresolved ones first and then the latest
+func (r *DisputeRepository) GetDisputes(organizerID int) ([]*models.PaymentDispute, error) {
+	query := `SELECT ` + disputeColumns + ` ` + disputeJoins + `
+		WHERE $1 = 0 OR e.organizer_id = $1
+		ORDER BY d.resolved_at IS NOT NULL, d.created_at DESC
+		LIMIT $2`
+
+	rows, err := r.db.Query(query, organizerID, maxListedDisputes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query disputes: %w", err)
+	}
+	defer rows.Close()
+
+	var disputes []*models.PaymentDispute
+	for rows.Next() {
+		dispute, err := scanDispute(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan dispute: %w", err)
+		}
+		disputes = append(disputes, dispute)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating disputes: %w", err)
+	}
+
+	return disputes, nil
+}
+
+// SubmitEvidence stores the organizer's evidence against an unresolved
+// dispute and puts it under review
+func (r *DisputeRepository) SubmitEvidence(id int, evidence *models.DisputeEvidence) error {
+	result, err := r.db.Exec(`
+		UPDATE payment_disputes
+		SET evidence_text = $2, evidence_url = $3, evidence_submitted_at = NOW(), status = $4, updated_at = NOW()
+		WHERE id = $1 AND resolved_at IS NULL`,
+		id, evidence.Text, evidence.FileURL, models.DisputeUnderReview)
+	if err != nil {
+		return fmt.Errorf("failed to submit dispute evidence: %w", err)
+	}
+
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("dispute is already resolved")
+	}
+	return nil
+}
+
+// ResolveDispute records a dispute's outcome. A won dispute restores its
+// order's status from before the dispute; a lost one voids its tickets, as
+// the buyer has their payment back. It returns false if the dispute was
+// already resolved.
+func (r *DisputeRepository) ResolveDispute(id int, status models.DisputeStatus, note string, resolvedAt time.Time) (bool, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return false, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	var orderID int
+	var orderStatus models.OrderStatus
+	err = tx.QueryRow(`
+		UPDATE payment_disputes
+		SET status = $2, resolution_note = $3, resolved_at = $4, updated_at = NOW()
+		WHERE id = $1 AND resolved_at IS NULL
+		RETURNING order_id, order_status`,
+		id, status, note, resolvedAt).Scan(&orderID, &orderStatus)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to resolve dispute: %w", err)
+	}
+
+	if status == models.DisputeWon {
+		// Other disputes of the same order keep it frozen
+		_, err = tx.Exec(`
+			UPDATE orders SET status = $2, updated_at = NOW()
+			WHERE id = $1 AND status = $3
+				AND NOT EXISTS (SELECT 1 FROM payment_disputes WHERE order_id = $1 AND resolved_at IS NULL)`,
+			orderID, orderStatus, models.OrderDisputed)
+	} else {
+		_, err = tx.Exec(`UPDATE tickets SET status = $2 WHERE order_id = $1 AND status = $3`,
+			orderID, models.TicketRefunded, models.TicketActive)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to apply dispute outcome: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return false, fmt.Errorf("failed to commit dispute outcome: %w", err)
+	}
+
+	return true, nil
+}
+
+// scanDispute scans a row selected with disputeColumns
+func scanDispute(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.PaymentDispute, error) {
+	dispute := &models.PaymentDispute{}
+	var evidenceSubmittedAt, dueAt, resolvedAt sql.NullTime
+	err := scanner.Scan(
+		&dispute.ID,
+		&dispute.ProviderDisputeID,
+		&dispute.OrderID,
+		&dispute.PaymentReference,
+		&dispute.Amount,
+		&dispute.Currency,
+		&dispute.Reason,
+		&dispute.Status,
+		&dispute.OrderStatus,
+		&dispute.EvidenceText,
+		&dispute.EvidenceURL,
+		&evidenceSubmittedAt,
+		&dueAt,
+		&dispute.ResolutionNote,
+		&dispute.CreatedAt,
+		&dispute.UpdatedAt,
+		&resolvedAt,
+		&dispute.OrderNumber,
+		&dispute.EventID,
+		&dispute.EventTitle,
+		&dispute.OrganizerID,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if evidenceSubmittedAt.Valid {
+		dispute.EvidenceSubmittedAt = &evidenceSubmittedAt.Time
+	}
+	if dueAt.Valid {
+		dispute.DueAt = &dueAt.Time
+	}
+	if resolvedAt.Valid {
+		dispute.ResolvedAt = &resolvedAt.Time
+	}
+	return dispute, nil
+}
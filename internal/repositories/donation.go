@@ -0,0 +1,62 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// DonationRepository handles organizers' donation settings
+type DonationRepository struct {
+	db *sql.DB
+}
+
+// NewDonationRepository creates a new donation repository
+func NewDonationRepository(db *sql.DB) *DonationRepository {
+	return &DonationRepository{db: db}
+}
+
+// GetSettings retrieves an event's donation settings. Events that have none
+// don't ask for donations.
+func (r *DonationRepository) GetSettings(eventID int) (*models.DonationSettings, error) {
+	query := `
+		SELECT event_id, enabled, message, updated_at
+		FROM event_donation_settings
+		WHERE event_id = $1`
+
+	settings := &models.DonationSettings{}
+	err := r.db.QueryRow(query, eventID).Scan(
+		&settings.EventID,
+		&settings.Enabled,
+		&settings.Message,
+		&settings.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return &models.DonationSettings{EventID: eventID}, nil
+		}
+		return nil, fmt.Errorf("failed to get donation settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// SaveSettings creates or updates an event's donation settings
+func (r *DonationRepository) SaveSettings(settings *models.DonationSettings) error {
+	query := `
+		INSERT INTO event_donation_settings (event_id, enabled, message, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (event_id) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			message = EXCLUDED.message,
+			updated_at = NOW()
+		RETURNING updated_at`
+
+	err := r.db.QueryRow(query, settings.EventID, settings.Enabled, settings.Message).Scan(&settings.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save donation settings: %w", err)
+	}
+
+	return nil
+}
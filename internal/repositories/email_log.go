@@ -0,0 +1,185 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"event-ticketing-platform/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// EmailLogRepository handles the outbound email log
+type EmailLogRepository struct {
+	db *sql.DB
+}
+
+// NewEmailLogRepository creates a new email log repository
+func NewEmailLogRepository(db *sql.DB) *EmailLogRepository {
+	return &EmailLogRepository{db: db}
+}
+
+const emailLogColumns = `id, email_type, recipient, subject, provider, COALESCE(provider_message_id, ''),
+	status, error, request, attempts, created_at, updated_at`
+
+// Create records an email that was sent, or that failed to send
+func (r *EmailLogRepository) Create(entry *models.EmailLog) error {
+	query := `
+		INSERT INTO email_log (email_type, recipient, subject, provider, provider_message_id, status, error, request)
+		VALUES ($1, $2, $3, $4, NULLIF($5, ''), $6, $7, $8)
+		RETURNING id, attempts, created_at, updated_at`
+
+	err := r.db.QueryRow(query, entry.EmailType, entry.Recipient, entry.Subject, entry.Provider,
+		entry.ProviderMessageID, entry.Status, entry.Error, []byte(entry.Request),
+	).Scan(&entry.ID, &entry.Attempts, &entry.CreatedAt, &entry.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to log email: %w", err)
+	}
+	return nil
+}
+
+// GetByID returns a logged email
+func (r *EmailLogRepository) GetByID(id int) (*models.EmailLog, error) {
+	row := r.db.QueryRow(`SELECT `+emailLogColumns+` FROM email_log WHERE id = $1`, id)
+
+	entry, err := scanEmailLog(row)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("email log entry not found")
+		}
+		return nil, fmt.Errorf("failed to get email log entry: %w", err)
+	}
+	return entry, nil
+}
+
+// List returns logged emails matching the filter, newest first, and how many
+// match in total
+func (r *EmailLogRepository) List(filter models.EmailLogFilter) ([]*models.EmailLog, int, error) {
+	var conditions []string
+	var args []interface{}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+	if filter.Recipient != "" {
+		args = append(args, "%"+filter.Recipient+"%")
+		conditions = append(conditions, fmt.Sprintf("recipient ILIKE $%d", len(args)))
+	}
+
+	where := ""
+	if len(conditions) > 0 {
+		where = " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	var total int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM email_log`+where, args...).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count email log entries: %w", err)
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+	args = append(args, limit, filter.Offset)
+	query := fmt.Sprintf(`SELECT %s FROM email_log%s ORDER BY created_at DESC, id DESC LIMIT $%d OFFSET $%d`,
+		emailLogColumns, where, len(args)-1, len(args))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query email log: %w", err)
+	}
+	defer rows.Close()
+
+	var entries []*models.EmailLog
+	for rows.Next() {
+		entry, err := scanEmailLog(rows)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan email log entry: %w", err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating email log: %w", err)
+	}
+
+	return entries, total, nil
+}
+
+// CountByStatus returns how many logged emails have each status
+func (r *EmailLogRepository) CountByStatus() (map[models.EmailStatus]int, error) {
+	rows, err := r.db.Query(`SELECT status, COUNT(*) FROM email_log GROUP BY status`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count email log entries: %w", err)
+	}
+	defer rows.Close()
+
+	counts := make(map[models.EmailStatus]int)
+	for rows.Next() {
+		var status models.EmailStatus
+		var count int
+		if err := rows.Scan(&status, &count); err != nil {
+			return nil, fmt.Errorf("failed to scan email status count: %w", err)
+		}
+		counts[status] = count
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating email status counts: %w", err)
+	}
+
+	return counts, nil
+}
+
+// RecordAttempt records the outcome of sending a logged email again
+func (r *EmailLogRepository) RecordAttempt(id int, status models.EmailStatus, provider, providerMessageID, errorMessage string) error {
+	query := `
+		UPDATE email_log
+		SET status = $2, provider = COALESCE(NULLIF($3, ''), provider),
+			provider_message_id = COALESCE(NULLIF($4, ''), provider_message_id),
+			error = $5, attempts = attempts + 1, updated_at = NOW()
+		WHERE id = $1`
+
+	if _, err := r.db.Exec(query, id, status, provider, providerMessageID, errorMessage); err != nil {
+		return fmt.Errorf("failed to record email attempt: %w", err)
+	}
+	return nil
+}
+
+// UpdateStatusByMessageID sets the status the provider reported for an
+// email, but only while the email has one of the given statuses, so events
+// arriving out of order don't undo a bounce or complaint. It reports whether
+// an email was updated.
+func (r *EmailLogRepository) UpdateStatusByMessageID(providerMessageID string, status models.EmailStatus, detail string, from []models.EmailStatus) (bool, error) {
+	statuses := make([]string, len(from))
+	for i, s := range from {
+		statuses[i] = string(s)
+	}
+
+	query := `
+		UPDATE email_log
+		SET status = $2, error = CASE WHEN $3 = '' THEN error ELSE $3 END, updated_at = NOW()
+		WHERE provider_message_id = $1 AND status = ANY($4)`
+
+	result, err := r.db.Exec(query, providerMessageID, status, detail, pq.Array(statuses))
+	if err != nil {
+		return false, fmt.Errorf("failed to update email status: %w", err)
+	}
+
+	rowsAffected, _ := result.RowsAffected()
+	return rowsAffected > 0, nil
+}
+
+func scanEmailLog(row interface{ Scan(...interface{}) error }) (*models.EmailLog, error) {
+	entry := &models.EmailLog{}
+	var request []byte
+	err := row.Scan(&entry.ID, &entry.EmailType, &entry.Recipient, &entry.Subject, &entry.Provider,
+		&entry.ProviderMessageID, &entry.Status, &entry.Error, &request, &entry.Attempts,
+		&entry.CreatedAt, &entry.UpdatedAt)
+	if err != nil {
+		return nil, err
+	}
+	entry.Request = request
+	return entry, nil
+}
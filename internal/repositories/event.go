@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"strings"
 	"time"
+	"unicode"
 
 	"event-ticketing-platform/internal/models"
 )
@@ -23,18 +24,31 @@ func NewEventRepository(db *sql.DB) *EventRepository {
 type EventSearchFilters struct {
 	Query      string              // Search query for title/description
 	CategoryID int                 // Filter by category
+	OrganizerID int                // Filter by organizer
 	Location   string              // Filter by location
+	CitySlug   string              // Filter by normalized city (events.city_slug)
 	Status     models.EventStatus  // Filter by status
 	DateFrom   *time.Time          // Filter events starting from this date
 	DateTo     *time.Time          // Filter events ending before this date
+	StartFrom  *time.Time          // Filter events starting at or after this time
+	StartBefore *time.Time         // Filter events starting before this time
 	PriceMin   *int                // Minimum price filter (in cents)
 	PriceMax   *int                // Maximum price filter (in cents)
+	Near       *models.GeoPoint    // Filter by distance from this point
+	RadiusKm   float64             // Radius around Near to search within
 	Limit      int                 // Number of results to return
 	Offset     int                 // Number of results to skip
-	SortBy     string              // "created_at", "start_date", "title"
+	SortBy     string              // "created_at", "start_date", "title", "relevance", "distance"
 	SortDesc   bool                // Sort in descending order
 }
 
+// distanceExpr returns the SQL for an event's distance in km from the point
+// in the latitude and longitude arguments, using the haversine formula
+func distanceExpr(latArg, lngArg int) string {
+	return fmt.Sprintf("(2 * %g * asin(least(1, sqrt(power(sin(radians(events.latitude - $%d) / 2), 2) + cos(radians($%d)) * cos(radians(events.latitude)) * power(sin(radians(events.longitude - $%d) / 2), 2)))))",
+		models.EarthRadiusKm, latArg, latArg, lngArg)
+}
+
 // Create creates a new event
 func (r *EventRepository) Create(req *models.EventCreateRequest, organizerID int) (*models.Event, error) {
 	if err := req.Validate(); err != nil {
@@ -42,9 +56,14 @@ func (r *EventRepository) Create(req *models.EventCreateRequest, organizerID int
 	}
 
 	query := `
-		INSERT INTO events (title, description, start_date, end_date, location, category_id, organizer_id, image_url, image_key, image_size, image_format, image_width, image_height, image_uploaded_at, status, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)
-		RETURNING id, title, description, start_date, end_date, location, category_id, organizer_id, image_url, image_key, image_size, image_format, image_width, image_height, image_uploaded_at, status, created_at, updated_at`
+		INSERT INTO events (title, description, start_date, end_date, location, category_id, organizer_id, image_url, image_key, image_size, image_format, image_width, image_height, image_uploaded_at, image_alt_text, slug, status, created_at, updated_at, image_variants)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18, $19, $20)
+		RETURNING id, title, description, start_date, end_date, location, category_id, organizer_id, image_url, image_key, image_size, image_format, image_width, image_height, image_uploaded_at, image_alt_text, image_variants, slug, status, created_at, updated_at`
+
+	slug, err := r.uniqueSlug(req.Title)
+	if err != nil {
+		return nil, err
+	}
 
 	now := time.Now()
 	event := &models.Event{}
@@ -58,8 +77,10 @@ func (r *EventRepository) Create(req *models.EventCreateRequest, organizerID int
 	var imageURL, imageKey, imageFormat interface{}
 	var imageSize interface{}
 	var imageWidth, imageHeight interface{}
+	var imageVariants models.EventImageVariants
 	
 	if req.ImageURL != "" && req.ImageKey != "" {
+		imageVariants = req.ImageVariants
 		imageURL = req.ImageURL
 		imageKey = req.ImageKey
 		imageSize = req.ImageSize
@@ -81,7 +102,7 @@ func (r *EventRepository) Create(req *models.EventCreateRequest, organizerID int
 	var imageWidthScan, imageHeightScan sql.NullInt32
 	var imageUploadedAtScan sql.NullTime
 
-	err := r.db.QueryRow(
+	err = r.db.QueryRow(
 		query,
 		req.Title,
 		req.Description,
@@ -97,9 +118,12 @@ func (r *EventRepository) Create(req *models.EventCreateRequest, organizerID int
 		imageWidth,
 		imageHeight,
 		imageUploadedAt,
+		req.ImageAltText,
+		slug,
 		req.Status,
 		now,
 		now,
+		imageVariants,
 	).Scan(
 		&event.ID,
 		&event.Title,
@@ -116,6 +140,9 @@ func (r *EventRepository) Create(req *models.EventCreateRequest, organizerID int
 		&imageWidthScan,
 		&imageHeightScan,
 		&imageUploadedAtScan,
+		&event.ImageAltText,
+		&event.ImageVariants,
+		&event.Slug,
 		&event.Status,
 		&event.CreatedAt,
 		&event.UpdatedAt,
@@ -154,9 +181,9 @@ func (r *EventRepository) Create(req *models.EventCreateRequest, organizerID int
 // GetByID retrieves an event by ID
 func (r *EventRepository) GetByID(id int) (*models.Event, error) {
 	query := `
-		SELECT id, title, description, start_date, end_date, location, category_id, organizer_id, image_url, image_key, image_size, image_format, image_width, image_height, image_uploaded_at, status, created_at, updated_at
+		SELECT id, title, description, start_date, end_date, location, category_id, organizer_id, image_url, image_key, image_size, image_format, image_width, image_height, image_uploaded_at, image_alt_text, image_variants, slug, status, created_at, updated_at
 		FROM events
-		WHERE id = $1`
+		WHERE id = $1 AND deleted_at IS NULL`
 
 	event, err := scanEvent(r.db.QueryRow(query, id))
 
@@ -170,6 +197,84 @@ func (r *EventRepository) GetByID(id int) (*models.Event, error) {
 	return event, nil
 }
 
+// GetBySlug retrieves an event by its URL slug
+func (r *EventRepository) GetBySlug(slug string) (*models.Event, error) {
+	query := `
+		SELECT id, title, description, start_date, end_date, location, category_id, organizer_id, image_url, image_key, image_size, image_format, image_width, image_height, image_uploaded_at, image_alt_text, image_variants, slug, status, created_at, updated_at
+		FROM events
+		WHERE slug = $1 AND deleted_at IS NULL`
+
+	event, err := scanEvent(r.db.QueryRow(query, slug))
+
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("event with slug %s not found", slug)
+		}
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	return event, nil
+}
+
+// uniqueSlug generates a slug for a new event from its title that no other
+// event or city landing page uses
+func (r *EventRepository) uniqueSlug(title string) (string, error) {
+	base := models.EventSlug(title)
+
+	rows, err := r.db.Query(`
+		SELECT slug FROM events WHERE slug = $1 OR slug LIKE $1 || '-%'
+		UNION
+		SELECT city_slug FROM events WHERE city_slug = $1 OR city_slug LIKE $1 || '-%'`, base)
+	if err != nil {
+		return "", fmt.Errorf("failed to check event slugs: %w", err)
+	}
+	defer rows.Close()
+
+	taken := make(map[string]bool)
+	for rows.Next() {
+		var slug string
+		if err := rows.Scan(&slug); err != nil {
+			return "", fmt.Errorf("failed to scan event slug: %w", err)
+		}
+		taken[slug] = true
+	}
+
+	if err = rows.Err(); err != nil {
+		return "", fmt.Errorf("error iterating event slugs: %w", err)
+	}
+
+	return models.UniqueEventSlug(base, taken), nil
+}
+
+// GetSitemapEvents retrieves the ID, slug and last update of every published
+// event, most recently updated first
+func (r *EventRepository) GetSitemapEvents() ([]*models.Event, error) {
+	rows, err := r.db.Query(`
+		SELECT id, slug, updated_at
+		FROM events
+		WHERE status = $1 AND deleted_at IS NULL
+		ORDER BY updated_at DESC`, models.StatusPublished)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sitemap events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*models.Event
+	for rows.Next() {
+		event := &models.Event{}
+		if err := rows.Scan(&event.ID, &event.Slug, &event.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating events: %w", err)
+	}
+
+	return events, nil
+}
+
 // Update updates an event
 func (r *EventRepository) Update(id int, req *models.EventUpdateRequest, organizerID int) (*models.Event, error) {
 	if err := req.Validate(); err != nil {
@@ -193,9 +298,9 @@ func (r *EventRepository) Update(id int, req *models.EventUpdateRequest, organiz
 
 	query := `
 		UPDATE events
-		SET title = $2, description = $3, start_date = $4, end_date = $5, location = $6, category_id = $7, image_url = $8, image_key = $9, image_size = $10, image_format = $11, image_width = $12, image_height = $13, image_uploaded_at = $14, status = $15, updated_at = $16
+		SET title = $2, description = $3, start_date = $4, end_date = $5, location = $6, category_id = $7, image_url = $8, image_key = $9, image_size = $10, image_format = $11, image_width = $12, image_height = $13, image_uploaded_at = $14, image_alt_text = $15, status = $16, updated_at = $17, image_variants = $18
 		WHERE id = $1
-		RETURNING id, title, description, start_date, end_date, location, category_id, organizer_id, image_url, image_key, image_size, image_format, image_width, image_height, image_uploaded_at, status, created_at, updated_at`
+		RETURNING id, title, description, start_date, end_date, location, category_id, organizer_id, image_url, image_key, image_size, image_format, image_width, image_height, image_uploaded_at, image_alt_text, image_variants, slug, status, created_at, updated_at`
 
 	event := &models.Event{}
 	now := time.Now()
@@ -209,8 +314,10 @@ func (r *EventRepository) Update(id int, req *models.EventUpdateRequest, organiz
 	var imageURL, imageKey, imageFormat interface{}
 	var imageSize interface{}
 	var imageWidth, imageHeight interface{}
+	var imageVariants models.EventImageVariants
 	
 	if req.ImageURL != "" && req.ImageKey != "" {
+		imageVariants = req.ImageVariants
 		imageURL = req.ImageURL
 		imageKey = req.ImageKey
 		imageSize = req.ImageSize
@@ -248,8 +355,10 @@ func (r *EventRepository) Update(id int, req *models.EventUpdateRequest, organiz
 		imageWidth,
 		imageHeight,
 		imageUploadedAt,
+		req.ImageAltText,
 		req.Status,
 		now,
+		imageVariants,
 	).Scan(
 		&event.ID,
 		&event.Title,
@@ -266,6 +375,9 @@ func (r *EventRepository) Update(id int, req *models.EventUpdateRequest, organiz
 		&imageWidthScan,
 		&imageHeightScan,
 		&imageUploadedAtScan,
+		&event.ImageAltText,
+		&event.ImageVariants,
+		&event.Slug,
 		&event.Status,
 		&event.CreatedAt,
 		&event.UpdatedAt,
@@ -301,7 +413,8 @@ func (r *EventRepository) Update(id int, req *models.EventUpdateRequest, organiz
 	return event, nil
 }
 
-// Delete deletes an event by ID (only if it belongs to the organizer)
+// Delete moves an event to the trash (only if it belongs to the organizer).
+// Events with orders can't be deleted, as their buyers hold tickets.
 func (r *EventRepository) Delete(id int, organizerID int) error {
 	// First check if the event exists and belongs to the organizer
 	existingEvent, err := r.GetByID(id)
@@ -313,7 +426,10 @@ func (r *EventRepository) Delete(id int, organizerID int) error {
 		return fmt.Errorf("event does not belong to organizer")
 	}
 
-	query := `DELETE FROM events WHERE id = $1`
+	query := `
+		UPDATE events SET deleted_at = NOW()
+		WHERE id = $1 AND deleted_at IS NULL
+			AND NOT EXISTS (SELECT 1 FROM orders WHERE event_id = $1)`
 
 	result, err := r.db.Exec(query, id)
 	if err != nil {
@@ -326,7 +442,7 @@ func (r *EventRepository) Delete(id int, organizerID int) error {
 	}
 
 	if rowsAffected == 0 {
-		return fmt.Errorf("event with id %d not found", id)
+		return fmt.Errorf("cannot delete an event with orders, cancel it instead")
 	}
 
 	return nil
@@ -335,9 +451,9 @@ func (r *EventRepository) Delete(id int, organizerID int) error {
 // GetByOrganizer retrieves events by organizer ID
 func (r *EventRepository) GetByOrganizer(organizerID int) ([]*models.Event, error) {
 	query := `
-		SELECT id, title, description, start_date, end_date, location, category_id, organizer_id, image_url, image_key, image_size, image_format, image_width, image_height, image_uploaded_at, status, created_at, updated_at
+		SELECT id, title, description, start_date, end_date, location, category_id, organizer_id, image_url, image_key, image_size, image_format, image_width, image_height, image_uploaded_at, image_alt_text, image_variants, slug, status, created_at, updated_at
 		FROM events
-		WHERE organizer_id = $1
+		WHERE organizer_id = $1 AND deleted_at IS NULL
 		ORDER BY created_at DESC`
 
 	rows, err := r.db.Query(query, organizerID)
@@ -370,6 +486,9 @@ func (r *EventRepository) GetByOrganizer(organizerID int) ([]*models.Event, erro
 			&imageWidth,
 			&imageHeight,
 			&imageUploadedAt,
+			&event.ImageAltText,
+			&event.ImageVariants,
+			&event.Slug,
 			&event.Status,
 			&event.CreatedAt,
 			&event.UpdatedAt,
@@ -411,6 +530,101 @@ func (r *EventRepository) GetByOrganizer(organizerID int) ([]*models.Event, erro
 	return events, nil
 }
 
+// GetTicketedEventsForUser retrieves events ending after from that the user
+// holds valid tickets for, including cancelled ones so calendars can update
+func (r *EventRepository) GetTicketedEventsForUser(userID int, from time.Time) ([]*models.Event, error) {
+	query := `
+		SELECT e.id, e.title, e.slug, e.description, e.start_date, e.end_date, e.location, e.status, e.updated_at
+		FROM events e
+		WHERE e.end_date >= $2 AND e.deleted_at IS NULL
+		  AND EXISTS (
+			SELECT 1
+			FROM orders o
+			JOIN tickets t ON t.order_id = o.id
+			WHERE o.event_id = e.id AND o.user_id = $1 AND o.status = $3 AND t.status != $4
+		  )
+		ORDER BY e.start_date ASC`
+
+	rows, err := r.db.Query(query, userID, from, models.OrderCompleted, models.TicketRefunded)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticketed events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*models.Event
+	for rows.Next() {
+		event := &models.Event{}
+		err := rows.Scan(
+			&event.ID,
+			&event.Title,
+			&event.Slug,
+			&event.Description,
+			&event.StartDate,
+			&event.EndDate,
+			&event.Location,
+			&event.Status,
+			&event.UpdatedAt,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating events: %w", err)
+	}
+
+	return events, nil
+}
+
+// GetSuggestionSources retrieves the upcoming published events that search
+// suggestions are built from, with their organizer, category and tickets sold
+func (r *EventRepository) GetSuggestionSources() ([]*models.SearchSuggestionSource, error) {
+	query := `
+		SELECT e.id, e.title, e.start_date, e.organizer_id,
+		       TRIM(COALESCE(u.first_name, '') || ' ' || COALESCE(u.last_name, '')) AS organizer_name,
+		       COALESCE(e.location, ''), COALESCE(c.name, ''), COALESCE(c.slug, ''),
+		       COALESCE((SELECT SUM(tt.sold) FROM ticket_types tt WHERE tt.event_id = e.id), 0) AS tickets_sold
+		FROM events e
+		LEFT JOIN users u ON u.id = e.organizer_id
+		LEFT JOIN categories c ON c.id = e.category_id
+		WHERE e.status = $1 AND e.end_date >= NOW() AND e.deleted_at IS NULL
+		ORDER BY e.start_date ASC`
+
+	rows, err := r.db.Query(query, models.StatusPublished)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get search suggestion sources: %w", err)
+	}
+	defer rows.Close()
+
+	var sources []*models.SearchSuggestionSource
+	for rows.Next() {
+		source := &models.SearchSuggestionSource{}
+		err := rows.Scan(
+			&source.EventID,
+			&source.Title,
+			&source.StartDate,
+			&source.OrganizerID,
+			&source.OrganizerName,
+			&source.Location,
+			&source.CategoryName,
+			&source.CategorySlug,
+			&source.TicketsSold,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan search suggestion source: %w", err)
+		}
+		sources = append(sources, source)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating search suggestion sources: %w", err)
+	}
+
+	return sources, nil
+}
+
 // Search searches for events with filters and pagination
 func (r *EventRepository) Search(filters EventSearchFilters) ([]*models.Event, int, error) {
 	// Build WHERE clause
@@ -418,6 +632,9 @@ func (r *EventRepository) Search(filters EventSearchFilters) ([]*models.Event, i
 	var args []interface{}
 	argIndex := 1
 
+	// Deleted events are never shown
+	conditions = append(conditions, "events.deleted_at IS NULL")
+
 	// Only show published events by default in search
 	if filters.Status == "" {
 		conditions = append(conditions, fmt.Sprintf("status = $%d", argIndex))
@@ -429,11 +646,22 @@ func (r *EventRepository) Search(filters EventSearchFilters) ([]*models.Event, i
 		argIndex++
 	}
 
-	// Text search in title and description
+	// Full-text search on the search_vector column with prefix matching,
+	// falling back to trigram similarity on the title to tolerate typos
+	var rankExpr string
 	if filters.Query != "" {
-		conditions = append(conditions, fmt.Sprintf("(title ILIKE $%d OR description ILIKE $%d)", argIndex, argIndex))
-		args = append(args, "%"+filters.Query+"%")
-		argIndex++
+		tsQuery := BuildPrefixTSQuery(filters.Query)
+		if tsQuery != "" {
+			conditions = append(conditions, fmt.Sprintf("(events.search_vector @@ to_tsquery('english', $%d) OR $%d <%% events.title)", argIndex, argIndex+1))
+			rankExpr = fmt.Sprintf("(ts_rank_cd(events.search_vector, to_tsquery('english', $%d)) + word_similarity($%d, events.title))", argIndex, argIndex+1)
+			args = append(args, tsQuery, filters.Query)
+			argIndex += 2
+		} else {
+			conditions = append(conditions, fmt.Sprintf("$%d <%% events.title", argIndex))
+			rankExpr = fmt.Sprintf("word_similarity($%d, events.title)", argIndex)
+			args = append(args, filters.Query)
+			argIndex++
+		}
 	}
 
 	// Category filter
@@ -443,6 +671,13 @@ func (r *EventRepository) Search(filters EventSearchFilters) ([]*models.Event, i
 		argIndex++
 	}
 
+	// Organizer filter
+	if filters.OrganizerID > 0 {
+		conditions = append(conditions, fmt.Sprintf("organizer_id = $%d", argIndex))
+		args = append(args, filters.OrganizerID)
+		argIndex++
+	}
+
 	// Location filter
 	if filters.Location != "" {
 		conditions = append(conditions, fmt.Sprintf("location ILIKE $%d", argIndex))
@@ -450,6 +685,13 @@ func (r *EventRepository) Search(filters EventSearchFilters) ([]*models.Event, i
 		argIndex++
 	}
 
+	// City filter
+	if filters.CitySlug != "" {
+		conditions = append(conditions, fmt.Sprintf("city_slug = $%d", argIndex))
+		args = append(args, filters.CitySlug)
+		argIndex++
+	}
+
 	// Date filters
 	if filters.DateFrom != nil {
 		conditions = append(conditions, fmt.Sprintf("start_date >= $%d", argIndex))
@@ -463,22 +705,51 @@ func (r *EventRepository) Search(filters EventSearchFilters) ([]*models.Event, i
 		argIndex++
 	}
 
-	// Price filters (need to join with ticket_types for price filtering)
-	var joinClause string
+	// Start date window (used by curated presets such as "this weekend")
+	if filters.StartFrom != nil {
+		conditions = append(conditions, fmt.Sprintf("start_date >= $%d", argIndex))
+		args = append(args, *filters.StartFrom)
+		argIndex++
+	}
+
+	if filters.StartBefore != nil {
+		conditions = append(conditions, fmt.Sprintf("start_date < $%d", argIndex))
+		args = append(args, *filters.StartBefore)
+		argIndex++
+	}
+
+	// Price filters (an event matches if any of its ticket types is in range)
 	if filters.PriceMin != nil || filters.PriceMax != nil {
-		joinClause = "LEFT JOIN ticket_types tt ON events.id = tt.event_id"
-		
+		var priceConditions []string
 		if filters.PriceMin != nil {
-			conditions = append(conditions, fmt.Sprintf("tt.price >= $%d", argIndex))
+			priceConditions = append(priceConditions, fmt.Sprintf("tt.price >= $%d", argIndex))
 			args = append(args, *filters.PriceMin)
 			argIndex++
 		}
 
 		if filters.PriceMax != nil {
-			conditions = append(conditions, fmt.Sprintf("tt.price <= $%d", argIndex))
+			priceConditions = append(priceConditions, fmt.Sprintf("tt.price <= $%d", argIndex))
 			args = append(args, *filters.PriceMax)
 			argIndex++
 		}
+
+		conditions = append(conditions, fmt.Sprintf("EXISTS (SELECT 1 FROM ticket_types tt WHERE tt.event_id = events.id AND tt.deleted_at IS NULL AND %s)", strings.Join(priceConditions, " AND ")))
+	}
+
+	// Radius filter, narrowed down with a bounding box the coordinates index
+	// can use before distances are calculated
+	var distance string
+	if filters.Near != nil && filters.RadiusKm > 0 {
+		minLat, maxLat, minLng, maxLng := filters.Near.BoundingBox(filters.RadiusKm)
+		conditions = append(conditions, fmt.Sprintf("events.latitude BETWEEN $%d AND $%d", argIndex, argIndex+1))
+		conditions = append(conditions, fmt.Sprintf("events.longitude BETWEEN $%d AND $%d", argIndex+2, argIndex+3))
+		args = append(args, minLat, maxLat, minLng, maxLng)
+		argIndex += 4
+
+		distance = distanceExpr(argIndex, argIndex+1)
+		conditions = append(conditions, fmt.Sprintf("%s <= $%d", distance, argIndex+2))
+		args = append(args, filters.Near.Latitude, filters.Near.Longitude, filters.RadiusKm)
+		argIndex += 3
 	}
 
 	whereClause := ""
@@ -497,6 +768,14 @@ func (r *EventRepository) Search(filters EventSearchFilters) ([]*models.Event, i
 		switch filters.SortBy {
 		case "created_at", "start_date", "title":
 			orderBy = fmt.Sprintf("ORDER BY %s %s", filters.SortBy, direction)
+		case "relevance":
+			if rankExpr != "" {
+				orderBy = fmt.Sprintf("ORDER BY %s DESC, start_date ASC", rankExpr)
+			}
+		case "distance":
+			if distance != "" {
+				orderBy = fmt.Sprintf("ORDER BY %s ASC, start_date ASC", distance)
+			}
 		}
 	}
 
@@ -510,12 +789,9 @@ func (r *EventRepository) Search(filters EventSearchFilters) ([]*models.Event, i
 
 	// Build the base query
 	baseQuery := "FROM events"
-	if joinClause != "" {
-		baseQuery = fmt.Sprintf("FROM events %s", joinClause)
-	}
 
 	// Get total count
-	countQuery := fmt.Sprintf("SELECT COUNT(DISTINCT events.id) %s %s", baseQuery, whereClause)
+	countQuery := fmt.Sprintf("SELECT COUNT(*) %s %s", baseQuery, whereClause)
 	var total int
 	err := r.db.QueryRow(countQuery, args...).Scan(&total)
 	if err != nil {
@@ -523,7 +799,7 @@ func (r *EventRepository) Search(filters EventSearchFilters) ([]*models.Event, i
 	}
 
 	// Get events
-	selectClause := "SELECT DISTINCT events.id, events.title, events.description, events.start_date, events.end_date, events.location, events.category_id, events.organizer_id, events.image_url, events.image_key, events.image_size, events.image_format, events.image_width, events.image_height, events.image_uploaded_at, events.status, events.created_at, events.updated_at"
+	selectClause := "SELECT events.id, events.title, events.description, events.start_date, events.end_date, events.location, events.category_id, events.organizer_id, events.image_url, events.image_key, events.image_size, events.image_format, events.image_width, events.image_height, events.image_uploaded_at, events.image_alt_text, events.image_variants, events.slug, events.status, events.created_at, events.updated_at, events.latitude, events.longitude"
 	query := fmt.Sprintf(`
 		%s
 		%s
@@ -547,6 +823,7 @@ func (r *EventRepository) Search(filters EventSearchFilters) ([]*models.Event, i
 		var imageSize sql.NullInt64
 		var imageWidth, imageHeight sql.NullInt32
 		var imageUploadedAt sql.NullTime
+		var latitude, longitude sql.NullFloat64
 
 		err := rows.Scan(
 			&event.ID,
@@ -564,9 +841,14 @@ func (r *EventRepository) Search(filters EventSearchFilters) ([]*models.Event, i
 			&imageWidth,
 			&imageHeight,
 			&imageUploadedAt,
+			&event.ImageAltText,
+			&event.ImageVariants,
+			&event.Slug,
 			&event.Status,
 			&event.CreatedAt,
 			&event.UpdatedAt,
+			&latitude,
+			&longitude,
 		)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan event: %w", err)
@@ -594,6 +876,9 @@ func (r *EventRepository) Search(filters EventSearchFilters) ([]*models.Event, i
 		if imageUploadedAt.Valid {
 			event.ImageUploadedAt = &imageUploadedAt.Time
 		}
+		if latitude.Valid && longitude.Valid {
+			event.Coordinates = &models.GeoPoint{Latitude: latitude.Float64, Longitude: longitude.Float64}
+		}
 
 		events = append(events, event)
 	}
@@ -631,11 +916,13 @@ func (r *EventRepository) GetUpcomingEvents(limit int) ([]*models.Event, error)
 	return events, err
 }
 
-// GetEventsByCategory retrieves events by category with pagination
+// GetEventsByCategory retrieves upcoming events by category with pagination
 func (r *EventRepository) GetEventsByCategory(categoryID int, limit, offset int) ([]*models.Event, int, error) {
+	now := time.Now()
 	filters := EventSearchFilters{
 		Status:     models.StatusPublished,
 		CategoryID: categoryID,
+		DateFrom:   &now,
 		Limit:      limit,
 		Offset:     offset,
 		SortBy:     "start_date",
@@ -644,6 +931,93 @@ func (r *EventRepository) GetEventsByCategory(categoryID int, limit, offset int)
 	return r.Search(filters)
 }
 
+// GetCities retrieves cities that have upcoming published events, busiest first
+func (r *EventRepository) GetCities(limit int) ([]*models.City, error) {
+	query := `
+		SELECT city_slug, MIN(trim(regexp_replace(location, '^.*,', ''))) AS name, COUNT(*) AS event_count
+		FROM events
+		WHERE status = $1 AND start_date >= $2 AND city_slug <> '' AND city_slug <> $4 AND deleted_at IS NULL
+		GROUP BY city_slug
+		ORDER BY event_count DESC, city_slug ASC
+		LIMIT $3`
+
+	// Online events have no city to list them under
+	rows, err := r.db.Query(query, models.StatusPublished, time.Now(), limit, models.CitySlug(models.OnlineEventLocation))
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cities: %w", err)
+	}
+	defer rows.Close()
+
+	var cities []*models.City
+	for rows.Next() {
+		city := &models.City{}
+		if err := rows.Scan(&city.Slug, &city.Name, &city.EventCount); err != nil {
+			return nil, fmt.Errorf("failed to scan city: %w", err)
+		}
+		cities = append(cities, city)
+	}
+
+	return cities, nil
+}
+
+// GetCityBySlug retrieves a city by its normalized slug
+func (r *EventRepository) GetCityBySlug(slug string) (*models.City, error) {
+	query := `
+		SELECT city_slug, MIN(trim(regexp_replace(location, '^.*,', ''))) AS name, COUNT(*) AS event_count
+		FROM events
+		WHERE status = $1 AND start_date >= $2 AND city_slug = $3 AND deleted_at IS NULL
+		GROUP BY city_slug`
+
+	city := &models.City{}
+	err := r.db.QueryRow(query, models.StatusPublished, time.Now(), slug).Scan(&city.Slug, &city.Name, &city.EventCount)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("city %s not found", slug)
+		}
+		return nil, fmt.Errorf("failed to get city: %w", err)
+	}
+
+	return city, nil
+}
+
+// GetVenues retrieves the venues events have been held at in a city, most
+// used first, for location autocomplete. The venue is the part of an event's
+// location before the city. Only published events and the organizer's own
+// events are included; an empty city slug matches every city.
+func (r *EventRepository) GetVenues(citySlug, query string, organizerID, limit int) ([]string, error) {
+	sqlQuery := `
+		SELECT venue
+		FROM (
+			SELECT trim(regexp_replace(location, ',[^,]*$', '')) AS venue
+			FROM events
+			WHERE deleted_at IS NULL
+			  AND position(',' in location) > 0
+			  AND ($1 = '' OR city_slug = $1)
+			  AND (status = $2 OR organizer_id = $3)
+		) venues
+		WHERE venue <> '' AND venue ILIKE $4
+		GROUP BY venue
+		ORDER BY COUNT(*) DESC, venue ASC
+		LIMIT $5`
+
+	rows, err := r.db.Query(sqlQuery, citySlug, models.StatusPublished, organizerID, "%"+query+"%", limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get venues: %w", err)
+	}
+	defer rows.Close()
+
+	venues := []string{}
+	for rows.Next() {
+		var venue string
+		if err := rows.Scan(&venue); err != nil {
+			return nil, fmt.Errorf("failed to scan venue: %w", err)
+		}
+		venues = append(venues, venue)
+	}
+
+	return venues, nil
+}
+
 // GetFeaturedEvents retrieves featured events (published, upcoming, limited)
 func (r *EventRepository) GetFeaturedEvents(limit int) ([]*models.Event, error) {
 	// For now, featured events are just upcoming events
@@ -653,10 +1027,7 @@ func (r *EventRepository) GetFeaturedEvents(limit int) ([]*models.Event, error)
 
 // GetCategories retrieves all event categories
 func (r *EventRepository) GetCategories() ([]*models.Category, error) {
-	query := `
-		SELECT id, name, slug, COALESCE(description, '') as description, created_at
-		FROM categories
-		ORDER BY name ASC`
+	query := `SELECT ` + categoryColumns + ` FROM categories ORDER BY name ASC`
 
 	rows, err := r.db.Query(query)
 	if err != nil {
@@ -666,14 +1037,7 @@ func (r *EventRepository) GetCategories() ([]*models.Category, error) {
 
 	var categories []*models.Category
 	for rows.Next() {
-		category := &models.Category{}
-		err := rows.Scan(
-			&category.ID,
-			&category.Name,
-			&category.Slug,
-			&category.Description,
-			&category.CreatedAt,
-		)
+		category, err := scanCategory(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan category: %w", err)
 		}
@@ -692,7 +1056,7 @@ func (r *EventRepository) GetCategories() ([]*models.Category, error) {
 // GetEventCount returns the total number of events
 func (r *EventRepository) GetEventCount() (int, error) {
 	var count int
-	err := r.db.QueryRow("SELECT COUNT(*) FROM events").Scan(&count)
+	err := r.db.QueryRow("SELECT COUNT(*) FROM events WHERE deleted_at IS NULL").Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get event count: %w", err)
 	}
@@ -702,7 +1066,7 @@ func (r *EventRepository) GetEventCount() (int, error) {
 // GetPublishedEventCount returns the number of published events
 func (r *EventRepository) GetPublishedEventCount() (int, error) {
 	var count int
-	err := r.db.QueryRow("SELECT COUNT(*) FROM events WHERE status = 'published'").Scan(&count)
+	err := r.db.QueryRow("SELECT COUNT(*) FROM events WHERE status = 'published' AND deleted_at IS NULL").Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get published event count: %w", err)
 	}
@@ -714,7 +1078,7 @@ func (r *EventRepository) GetPublishedEventCount() (int, error) {
 // GetPendingEvents retrieves events that are pending review
 func (r *EventRepository) GetPendingEvents(limit, offset int) ([]*models.Event, int, error) {
 	// Get total count
-	countQuery := "SELECT COUNT(*) FROM events WHERE status = 'pending_review'"
+	countQuery := "SELECT COUNT(*) FROM events WHERE status = 'pending_review' AND deleted_at IS NULL"
 	var totalCount int
 	err := r.db.QueryRow(countQuery).Scan(&totalCount)
 	if err != nil {
@@ -725,7 +1089,7 @@ func (r *EventRepository) GetPendingEvents(limit, offset int) ([]*models.Event,
 	query := `
 		SELECT e.id, e.title, e.description, e.start_date, e.end_date, e.location, 
 		       e.category_id, e.organizer_id, e.image_url, e.image_key, e.image_size, 
-		       e.image_format, e.image_width, e.image_height, e.image_uploaded_at, 
+		       e.image_format, e.image_width, e.image_height, e.image_uploaded_at, e.image_alt_text, e.image_variants, e.slug,
 		       e.status, e.reviewed_at, e.reviewed_by, e.rejection_reason, 
 		       e.created_at, e.updated_at,
 		       u.first_name, u.last_name, u.email,
@@ -733,7 +1097,7 @@ func (r *EventRepository) GetPendingEvents(limit, offset int) ([]*models.Event,
 		FROM events e
 		JOIN users u ON e.organizer_id = u.id
 		LEFT JOIN categories c ON e.category_id = c.id
-		WHERE e.status = 'pending_review'
+		WHERE e.status = 'pending_review' AND e.deleted_at IS NULL
 		ORDER BY e.created_at ASC
 		LIMIT $1 OFFSET $2`
 
@@ -768,6 +1132,9 @@ func (r *EventRepository) GetPendingEvents(limit, offset int) ([]*models.Event,
 			&event.ImageWidth,
 			&event.ImageHeight,
 			&event.ImageUploadedAt,
+			&event.ImageAltText,
+			&event.ImageVariants,
+			&event.Slug,
 			&event.Status,
 			&reviewedAt,
 			&reviewedBy,
@@ -851,6 +1218,82 @@ func (r *EventRepository) RejectEvent(eventID int, reviewerID int, reason string
 	return nil
 }
 
+// ReturnToDraft sends an event waiting for review back to its organizer as a
+// draft, so they can make the changes a reviewer asked for and publish again
+func (r *EventRepository) ReturnToDraft(eventID int, reviewerID int) error {
+	query := `
+		UPDATE events 
+		SET status = 'draft', reviewed_at = $1, reviewed_by = $2, rejection_reason = '', updated_at = $3
+		WHERE id = $4 AND status = 'pending_review'`
+
+	now := time.Now()
+	result, err := r.db.Exec(query, now, reviewerID, now, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to return event to draft: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("event not found or not in pending review status")
+	}
+
+	return nil
+}
+
+// Unpublish takes a published event down by returning it to its organizer
+// as a draft on behalf of an admin
+func (r *EventRepository) Unpublish(eventID int, adminID int) error {
+	query := `
+		UPDATE events
+		SET status = 'draft', reviewed_at = $1, reviewed_by = $2, updated_at = $3
+		WHERE id = $4 AND status = 'published' AND deleted_at IS NULL`
+
+	now := time.Now()
+	result, err := r.db.Exec(query, now, adminID, now, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to unpublish event: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("event not found or not published")
+	}
+
+	return nil
+}
+
+// UpdateCategory moves an event to another category
+func (r *EventRepository) UpdateCategory(eventID int, categoryID int) error {
+	query := `
+		UPDATE events
+		SET category_id = $1, updated_at = $2
+		WHERE id = $3 AND deleted_at IS NULL`
+
+	result, err := r.db.Exec(query, categoryID, time.Now(), eventID)
+	if err != nil {
+		return fmt.Errorf("failed to update event category: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("event not found")
+	}
+
+	return nil
+}
+
 // SubmitForReview submits an event for admin review
 func (r *EventRepository) SubmitForReview(eventID int, organizerID int) error {
 	query := `
@@ -881,7 +1324,7 @@ func (r *EventRepository) GetEventModerationHistory(eventID int) (*models.Event,
 	query := `
 		SELECT e.id, e.title, e.description, e.start_date, e.end_date, e.location, 
 		       e.category_id, e.organizer_id, e.image_url, e.image_key, e.image_size, 
-		       e.image_format, e.image_width, e.image_height, e.image_uploaded_at, 
+		       e.image_format, e.image_width, e.image_height, e.image_uploaded_at, e.image_alt_text, e.image_variants, e.slug,
 		       e.status, e.reviewed_at, e.reviewed_by, e.rejection_reason, 
 		       e.created_at, e.updated_at,
 		       u.first_name, u.last_name, u.email,
@@ -918,6 +1361,9 @@ func (r *EventRepository) GetEventModerationHistory(eventID int) (*models.Event,
 		&event.ImageWidth,
 		&event.ImageHeight,
 		&event.ImageUploadedAt,
+		&event.ImageAltText,
+		&event.ImageVariants,
+		&event.Slug,
 		&event.Status,
 		&reviewedAt,
 		&reviewedBy,
@@ -987,6 +1433,9 @@ func scanEvent(scanner interface {
 		&imageWidth,
 		&imageHeight,
 		&imageUploadedAt,
+		&event.ImageAltText,
+		&event.ImageVariants,
+		&event.Slug,
 		&event.Status,
 		&event.CreatedAt,
 		&event.UpdatedAt,
@@ -1020,4 +1469,21 @@ func scanEvent(scanner interface {
 	}
 
 	return event, nil
-}
\ No newline at end of file
+}
+
+// SearchTerms splits a search query into lowercase alphanumeric terms
+func SearchTerms(query string) []string {
+	return strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
+
+// BuildPrefixTSQuery converts a free-text query into a tsquery string where
+// every term must match as a prefix, e.g. "jazz fest" becomes "jazz:* & fest:*"
+func BuildPrefixTSQuery(query string) string {
+	terms := SearchTerms(query)
+	for i, term := range terms {
+		terms[i] = term + ":*"
+	}
+	return strings.Join(terms, " & ")
+}
@@ -0,0 +1,89 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// EventAccessRepository handles the access links of online events
+type EventAccessRepository struct {
+	db *sql.DB
+}
+
+// NewEventAccessRepository creates a new event access repository
+func NewEventAccessRepository(db *sql.DB) *EventAccessRepository {
+	return &EventAccessRepository{db: db}
+}
+
+// GetLink retrieves an event's access link, or nil if it has none
+func (r *EventAccessRepository) GetLink(eventID int) (*models.EventAccessLink, error) {
+	query := `
+		SELECT event_id, url, reveal_minutes_before, created_at, updated_at
+		FROM event_access_links
+		WHERE event_id = $1`
+
+	link := &models.EventAccessLink{}
+	err := r.db.QueryRow(query, eventID).Scan(
+		&link.EventID,
+		&link.URL,
+		&link.RevealMinutesBefore,
+		&link.CreatedAt,
+		&link.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get access link: %w", err)
+	}
+
+	return link, nil
+}
+
+// SaveLink creates or updates an event's access link
+func (r *EventAccessRepository) SaveLink(link *models.EventAccessLink) error {
+	query := `
+		INSERT INTO event_access_links (event_id, url, reveal_minutes_before, created_at, updated_at)
+		VALUES ($1, $2, $3, NOW(), NOW())
+		ON CONFLICT (event_id) DO UPDATE SET
+			url = EXCLUDED.url,
+			reveal_minutes_before = EXCLUDED.reveal_minutes_before,
+			updated_at = NOW()
+		RETURNING created_at, updated_at`
+
+	err := r.db.QueryRow(query, link.EventID, link.URL, link.RevealMinutesBefore).Scan(&link.CreatedAt, &link.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save access link: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteLink deletes an event's access link, if it has one
+func (r *EventAccessRepository) DeleteLink(eventID int) error {
+	if _, err := r.db.Exec(`DELETE FROM event_access_links WHERE event_id = $1`, eventID); err != nil {
+		return fmt.Errorf("failed to delete access link: %w", err)
+	}
+	return nil
+}
+
+// HasTicket returns true if the user has an active or used ticket from a
+// completed order for the event
+func (r *EventAccessRepository) HasTicket(eventID, userID int) (bool, error) {
+	query := `
+		SELECT EXISTS (
+			SELECT 1
+			FROM orders o
+			JOIN tickets t ON t.order_id = o.id AND t.status IN ('active', 'used')
+			WHERE o.event_id = $1 AND o.user_id = $2 AND o.status = 'completed'
+		)`
+
+	var hasTicket bool
+	if err := r.db.QueryRow(query, eventID, userID).Scan(&hasTicket); err != nil {
+		return false, fmt.Errorf("failed to check tickets: %w", err)
+	}
+
+	return hasTicket, nil
+}
@@ -0,0 +1,301 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// EventAgendaRepository handles the sessions and speakers of events' agendas
+type EventAgendaRepository struct {
+	db *sql.DB
+}
+
+// NewEventAgendaRepository creates a new event agenda repository
+func NewEventAgendaRepository(db *sql.DB) *EventAgendaRepository {
+	return &EventAgendaRepository{db: db}
+}
+
+const eventSessionColumns = `s.id, s.event_id, s.title, s.description, s.stage, s.starts_at, s.ends_at,
+	COALESCE(array_agg(ss.speaker_id ORDER BY ss.speaker_id) FILTER (WHERE ss.speaker_id IS NOT NULL), '{}'),
+	s.created_at, s.updated_at`
+
+func scanEventSession(row interface{ Scan(...interface{}) error }) (*models.EventSession, error) {
+	session := &models.EventSession{}
+	var endsAt sql.NullTime
+	var speakerIDs pq.Int64Array
+	if err := row.Scan(&session.ID, &session.EventID, &session.Title, &session.Description, &session.Stage,
+		&session.StartsAt, &endsAt, &speakerIDs, &session.CreatedAt, &session.UpdatedAt); err != nil {
+		return nil, err
+	}
+	if endsAt.Valid {
+		session.EndsAt = endsAt.Time
+	}
+	for _, id := range speakerIDs {
+		session.SpeakerIDs = append(session.SpeakerIDs, int(id))
+	}
+	return session, nil
+}
+
+// sessionEnd returns the ends_at argument of a session
+func sessionEnd(session *models.EventSession) sql.NullTime {
+	if session.EndsAt.IsZero() {
+		return sql.NullTime{}
+	}
+	return sql.NullTime{Time: session.EndsAt, Valid: true}
+}
+
+// GetSessions retrieves an event's sessions by start time
+func (r *EventAgendaRepository) GetSessions(eventID int) ([]*models.EventSession, error) {
+	query := `
+		SELECT ` + eventSessionColumns + `
+		FROM event_sessions s
+		LEFT JOIN event_session_speakers ss ON ss.session_id = s.id
+		WHERE s.event_id = $1
+		GROUP BY s.id
+		ORDER BY s.starts_at, s.id`
+
+	rows, err := r.db.Query(query, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*models.EventSession
+	for rows.Next() {
+		session, err := scanEventSession(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sessions: %w", err)
+	}
+
+	return sessions, nil
+}
+
+// GetSession retrieves a session by ID
+func (r *EventAgendaRepository) GetSession(id int) (*models.EventSession, error) {
+	query := `
+		SELECT ` + eventSessionColumns + `
+		FROM event_sessions s
+		LEFT JOIN event_session_speakers ss ON ss.session_id = s.id
+		WHERE s.id = $1
+		GROUP BY s.id`
+
+	session, err := scanEventSession(r.db.QueryRow(query, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("session with id %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+
+	return session, nil
+}
+
+// CreateSession adds a session to an event's agenda with its speakers
+func (r *EventAgendaRepository) CreateSession(session *models.EventSession) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(`
+		INSERT INTO event_sessions (event_id, title, description, stage, starts_at, ends_at, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW(), NOW())
+		RETURNING id, created_at, updated_at`,
+		session.EventID, session.Title, session.Description, session.Stage, session.StartsAt, sessionEnd(session),
+	).Scan(&session.ID, &session.CreatedAt, &session.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create session: %w", err)
+	}
+
+	if err := setSessionSpeakers(tx, session); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// UpdateSession saves changes to a session and its speakers
+func (r *EventAgendaRepository) UpdateSession(session *models.EventSession) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(`
+		UPDATE event_sessions
+		SET title = $3, description = $4, stage = $5, starts_at = $6, ends_at = $7, updated_at = NOW()
+		WHERE id = $1 AND event_id = $2
+		RETURNING updated_at`,
+		session.ID, session.EventID, session.Title, session.Description, session.Stage, session.StartsAt, sessionEnd(session),
+	).Scan(&session.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("session with id %d not found", session.ID)
+		}
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM event_session_speakers WHERE session_id = $1`, session.ID); err != nil {
+		return fmt.Errorf("failed to clear session speakers: %w", err)
+	}
+	if err := setSessionSpeakers(tx, session); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+	return nil
+}
+
+// setSessionSpeakers links a session to its speakers, which must be the
+// session's event's
+func setSessionSpeakers(tx *sql.Tx, session *models.EventSession) error {
+	for _, speakerID := range session.SpeakerIDs {
+		_, err := tx.Exec(`
+			INSERT INTO event_session_speakers (session_id, speaker_id)
+			SELECT $1, id FROM event_speakers WHERE id = $2 AND event_id = $3
+			ON CONFLICT DO NOTHING`, session.ID, speakerID, session.EventID)
+		if err != nil {
+			return fmt.Errorf("failed to add session speaker: %w", err)
+		}
+	}
+	return nil
+}
+
+// DeleteSession removes a session from an event's agenda
+func (r *EventAgendaRepository) DeleteSession(id, eventID int) error {
+	result, err := r.db.Exec(`DELETE FROM event_sessions WHERE id = $1 AND event_id = $2`, id, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("session with id %d not found", id)
+	}
+
+	return nil
+}
+
+const eventSpeakerColumns = `id, event_id, name, role, bio, photo_url, created_at, updated_at`
+
+func scanEventSpeaker(row interface{ Scan(...interface{}) error }) (*models.EventSpeaker, error) {
+	speaker := &models.EventSpeaker{}
+	if err := row.Scan(&speaker.ID, &speaker.EventID, &speaker.Name, &speaker.Role, &speaker.Bio,
+		&speaker.PhotoURL, &speaker.CreatedAt, &speaker.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return speaker, nil
+}
+
+// GetSpeakers retrieves an event's lineup in the order it was added
+func (r *EventAgendaRepository) GetSpeakers(eventID int) ([]*models.EventSpeaker, error) {
+	rows, err := r.db.Query(`
+		SELECT `+eventSpeakerColumns+`
+		FROM event_speakers
+		WHERE event_id = $1
+		ORDER BY id`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get speakers: %w", err)
+	}
+	defer rows.Close()
+
+	var speakers []*models.EventSpeaker
+	for rows.Next() {
+		speaker, err := scanEventSpeaker(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan speaker: %w", err)
+		}
+		speakers = append(speakers, speaker)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating speakers: %w", err)
+	}
+
+	return speakers, nil
+}
+
+// GetSpeaker retrieves a speaker by ID
+func (r *EventAgendaRepository) GetSpeaker(id int) (*models.EventSpeaker, error) {
+	speaker, err := scanEventSpeaker(r.db.QueryRow(`SELECT `+eventSpeakerColumns+` FROM event_speakers WHERE id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("speaker with id %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get speaker: %w", err)
+	}
+
+	return speaker, nil
+}
+
+// CreateSpeaker adds a speaker to an event's lineup
+func (r *EventAgendaRepository) CreateSpeaker(speaker *models.EventSpeaker) error {
+	err := r.db.QueryRow(`
+		INSERT INTO event_speakers (event_id, name, role, bio, photo_url, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, NOW(), NOW())
+		RETURNING id, created_at, updated_at`,
+		speaker.EventID, speaker.Name, speaker.Role, speaker.Bio, speaker.PhotoURL,
+	).Scan(&speaker.ID, &speaker.CreatedAt, &speaker.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create speaker: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateSpeaker saves changes to a speaker
+func (r *EventAgendaRepository) UpdateSpeaker(speaker *models.EventSpeaker) error {
+	err := r.db.QueryRow(`
+		UPDATE event_speakers
+		SET name = $3, role = $4, bio = $5, photo_url = $6, updated_at = NOW()
+		WHERE id = $1 AND event_id = $2
+		RETURNING updated_at`,
+		speaker.ID, speaker.EventID, speaker.Name, speaker.Role, speaker.Bio, speaker.PhotoURL,
+	).Scan(&speaker.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("speaker with id %d not found", speaker.ID)
+		}
+		return fmt.Errorf("failed to update speaker: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteSpeaker removes a speaker from an event's lineup and its sessions
+func (r *EventAgendaRepository) DeleteSpeaker(id, eventID int) error {
+	result, err := r.db.Exec(`DELETE FROM event_speakers WHERE id = $1 AND event_id = $2`, id, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to delete speaker: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("speaker with id %d not found", id)
+	}
+
+	return nil
+}
@@ -0,0 +1,221 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// EventBroadcastRepository handles organizer broadcast data operations
+type EventBroadcastRepository struct {
+	db *sql.DB
+}
+
+// NewEventBroadcastRepository creates a new event broadcast repository
+func NewEventBroadcastRepository(db *sql.DB) *EventBroadcastRepository {
+	return &EventBroadcastRepository{db: db}
+}
+
+const broadcastColumns = `id, event_id, organizer_id, subject, message, status, recipient_count, sent_count, failed_count, created_at, completed_at`
+
+// Create queues a new broadcast
+func (r *EventBroadcastRepository) Create(req *models.EventBroadcastCreateRequest, recipientCount int) (*models.EventBroadcast, error) {
+	query := `
+		INSERT INTO event_broadcasts (event_id, organizer_id, subject, message, status, recipient_count)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + broadcastColumns
+
+	broadcast, err := scanBroadcast(r.db.QueryRow(query,
+		req.EventID,
+		req.OrganizerID,
+		req.Subject,
+		req.Message,
+		models.BroadcastQueued,
+		recipientCount,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create broadcast: %w", err)
+	}
+
+	return broadcast, nil
+}
+
+// GetByEvent returns an event's broadcasts, newest first
+func (r *EventBroadcastRepository) GetByEvent(eventID, limit int) ([]*models.EventBroadcast, error) {
+	query := `
+		SELECT ` + broadcastColumns + `
+		FROM event_broadcasts
+		WHERE event_id = $1
+		ORDER BY created_at DESC
+		LIMIT $2`
+
+	return r.queryBroadcasts(query, eventID, limit)
+}
+
+// GetPending returns the broadcasts that have not finished sending, oldest first
+func (r *EventBroadcastRepository) GetPending() ([]*models.EventBroadcast, error) {
+	query := `
+		SELECT ` + broadcastColumns + `
+		FROM event_broadcasts
+		WHERE status <> $1
+		ORDER BY created_at`
+
+	return r.queryBroadcasts(query, models.BroadcastSent)
+}
+
+// CountRecipients returns how many orders for an event hold active tickets
+func (r *EventBroadcastRepository) CountRecipients(eventID int) (int, error) {
+	query := `
+		SELECT COUNT(DISTINCT o.id)
+		FROM orders o
+		JOIN tickets t ON t.order_id = o.id AND t.status = 'active'
+		WHERE o.event_id = $1 AND o.status = 'completed'`
+
+	var count int
+	if err := r.db.QueryRow(query, eventID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count broadcast recipients: %w", err)
+	}
+
+	return count, nil
+}
+
+// GetPendingRecipients returns up to limit completed orders for the
+// broadcast's event that still hold active tickets and have not been sent it,
+// with the language to email each buyer in
+func (r *EventBroadcastRepository) GetPendingRecipients(broadcast *models.EventBroadcast, limit int) ([]*models.BroadcastRecipient, error) {
+	query := `
+		SELECT o.id,
+			COALESCE(NULLIF(o.billing_email, ''), u.email),
+			COALESCE(NULLIF(o.billing_name, ''), TRIM(u.first_name || ' ' || u.last_name)),
+			COALESCE(NULLIF(o.locale, ''), NULLIF(u.locale, ''), e.locale)
+		FROM orders o
+		JOIN users u ON u.id = o.user_id
+		JOIN events e ON e.id = o.event_id
+		WHERE o.event_id = $1 AND o.status = 'completed'
+			AND EXISTS (SELECT 1 FROM tickets t WHERE t.order_id = o.id AND t.status = 'active')
+			AND NOT EXISTS (
+				SELECT 1 FROM event_broadcast_deliveries d
+				WHERE d.broadcast_id = $2 AND d.order_id = o.id
+			)
+		ORDER BY o.id
+		LIMIT $3`
+
+	rows, err := r.db.Query(query, broadcast.EventID, broadcast.ID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query broadcast recipients: %w", err)
+	}
+	defer rows.Close()
+
+	var recipients []*models.BroadcastRecipient
+	for rows.Next() {
+		recipient := &models.BroadcastRecipient{}
+		if err := rows.Scan(&recipient.OrderID, &recipient.Email, &recipient.Name, &recipient.Locale); err != nil {
+			return nil, fmt.Errorf("failed to scan broadcast recipient: %w", err)
+		}
+		recipients = append(recipients, recipient)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating broadcast recipients: %w", err)
+	}
+
+	return recipients, nil
+}
+
+// RecordDelivery marks a broadcast as sent to an order. It returns false if
+// the delivery had already been recorded.
+func (r *EventBroadcastRepository) RecordDelivery(broadcastID, orderID int) (bool, error) {
+	result, err := r.db.Exec(`
+		INSERT INTO event_broadcast_deliveries (broadcast_id, order_id)
+		VALUES ($1, $2)
+		ON CONFLICT (broadcast_id, order_id) DO NOTHING`, broadcastID, orderID)
+	if err != nil {
+		return false, fmt.Errorf("failed to record broadcast delivery: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// UpdateProgress adds a batch's sent and failed counts to a broadcast and
+// moves it to the given status, setting completed_at once it is sent
+func (r *EventBroadcastRepository) UpdateProgress(broadcastID int, status models.BroadcastStatus, sent, failed int) error {
+	result, err := r.db.Exec(`
+		UPDATE event_broadcasts
+		SET status = $1,
+			sent_count = sent_count + $2,
+			failed_count = failed_count + $3,
+			completed_at = CASE WHEN $1 = 'sent' THEN NOW() ELSE completed_at END
+		WHERE id = $4`, status, sent, failed, broadcastID)
+	if err != nil {
+		return fmt.Errorf("failed to update broadcast: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("broadcast with id %d not found", broadcastID)
+	}
+
+	return nil
+}
+
+// queryBroadcasts runs a query returning broadcast rows
+func (r *EventBroadcastRepository) queryBroadcasts(query string, args ...interface{}) ([]*models.EventBroadcast, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query broadcasts: %w", err)
+	}
+	defer rows.Close()
+
+	var broadcasts []*models.EventBroadcast
+	for rows.Next() {
+		broadcast, err := scanBroadcast(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan broadcast: %w", err)
+		}
+		broadcasts = append(broadcasts, broadcast)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating broadcasts: %w", err)
+	}
+
+	return broadcasts, nil
+}
+
+// scanBroadcast scans a row selected with broadcastColumns
+func scanBroadcast(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.EventBroadcast, error) {
+	broadcast := &models.EventBroadcast{}
+	var completedAt sql.NullTime
+	err := scanner.Scan(
+		&broadcast.ID,
+		&broadcast.EventID,
+		&broadcast.OrganizerID,
+		&broadcast.Subject,
+		&broadcast.Message,
+		&broadcast.Status,
+		&broadcast.RecipientCount,
+		&broadcast.SentCount,
+		&broadcast.FailedCount,
+		&broadcast.CreatedAt,
+		&completedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if completedAt.Valid {
+		broadcast.CompletedAt = &completedAt.Time
+	}
+	return broadcast, nil
+}
@@ -0,0 +1,303 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// EventCancellationRepository handles event cancellation and refund data operations
+type EventCancellationRepository struct {
+	db *sql.DB
+}
+
+// NewEventCancellationRepository creates a new event cancellation repository
+func NewEventCancellationRepository(db *sql.DB) *EventCancellationRepository {
+	return &EventCancellationRepository{db: db}
+}
+
+const cancellationColumns = `c.id, c.event_id, c.cancelled_by, c.reason, c.status, c.order_count, c.refund_total, c.refunded_count, c.failed_count, c.created_at, c.completed_at`
+
+// Create records an event's cancellation with the orders it has to refund
+func (r *EventCancellationRepository) Create(req *models.EventCancellationRequest, orderCount, refundTotal int) (*models.EventCancellation, error) {
+	query := `
+		INSERT INTO event_cancellations AS c (event_id, cancelled_by, reason, status, order_count, refund_total)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING ` + cancellationColumns
+
+	cancellation, err := scanCancellation(r.db.QueryRow(query,
+		req.EventID,
+		req.CancelledBy,
+		req.Reason,
+		models.CancellationRefunding,
+		orderCount,
+		refundTotal,
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create event cancellation: %w", err)
+	}
+
+	return cancellation, nil
+}
+
+// GetByEvent returns an event's cancellation, or nil if it has not been cancelled
+func (r *EventCancellationRepository) GetByEvent(eventID int) (*models.EventCancellation, error) {
+	query := `
+		SELECT ` + cancellationColumns + `
+		FROM event_cancellations c
+		WHERE c.event_id = $1`
+
+	cancellation, err := scanCancellation(r.db.QueryRow(query, eventID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get event cancellation: %w", err)
+	}
+
+	return cancellation, nil
+}
+
+// Delete removes a cancellation, for when the event's status could not be changed
+func (r *EventCancellationRepository) Delete(id int) error {
+	result, err := r.db.Exec("DELETE FROM event_cancellations WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete event cancellation: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("event cancellation with id %d not found", id)
+	}
+
+	return nil
+}
+
+// GetPending returns the cancellations still refunding orders, oldest first.
+// Only events that are actually cancelled are included.
+func (r *EventCancellationRepository) GetPending() ([]*models.EventCancellation, error) {
+	query := `
+		SELECT ` + cancellationColumns + `
+		FROM event_cancellations c
+		JOIN events e ON e.id = c.event_id
+		WHERE c.status = $1 AND e.status = 'cancelled'
+		ORDER BY c.created_at`
+
+	rows, err := r.db.Query(query, models.CancellationRefunding)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query event cancellations: %w", err)
+	}
+	defer rows.Close()
+
+	var cancellations []*models.EventCancellation
+	for rows.Next() {
+		cancellation, err := scanCancellation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event cancellation: %w", err)
+		}
+		cancellations = append(cancellations, cancellation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating event cancellations: %w", err)
+	}
+
+	return cancellations, nil
+}
+
+// GetRefundTotals returns how many completed orders an event has and their
+// total amount in cents
+func (r *EventCancellationRepository) GetRefundTotals(eventID int) (int, int, error) {
+	query := `
+		SELECT COUNT(*), COALESCE(SUM(total_amount), 0)
+		FROM orders
+		WHERE event_id = $1 AND status = 'completed'`
+
+	var orderCount, refundTotal int
+	if err := r.db.QueryRow(query, eventID).Scan(&orderCount, &refundTotal); err != nil {
+		return 0, 0, fmt.Errorf("failed to get refund totals: %w", err)
+	}
+
+	return orderCount, refundTotal, nil
+}
+
+// CancelPendingOrders cancels an event's orders that are still awaiting
+// payment and returns how many were cancelled
+func (r *EventCancellationRepository) CancelPendingOrders(eventID int) (int, error) {
+	result, err := r.db.Exec(`
+		UPDATE orders SET status = 'cancelled', updated_at = NOW()
+		WHERE event_id = $1 AND status = 'pending'`, eventID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to cancel pending orders: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return int(rowsAffected), nil
+}
+
+// GetOrdersToRefund returns up to limit completed orders for the cancelled
+// event that have not been refunded and have refund attempts left, with the
+// language to email each buyer in
+func (r *EventCancellationRepository) GetOrdersToRefund(cancellation *models.EventCancellation, maxAttempts, limit int) ([]*models.CancellationOrder, error) {
+	query := `
+		SELECT o.id, o.order_number, o.total_amount,
+			COALESCE(NULLIF(o.billing_email, ''), u.email),
+			COALESCE(NULLIF(o.billing_name, ''), TRIM(u.first_name || ' ' || u.last_name)),
+			COALESCE(NULLIF(o.locale, ''), NULLIF(u.locale, ''), e.locale)
+		FROM orders o
+		JOIN users u ON u.id = o.user_id
+		JOIN events e ON e.id = o.event_id
+		WHERE o.event_id = $1 AND o.status = 'completed'
+			AND NOT EXISTS (
+				SELECT 1 FROM event_cancellation_refunds f
+				WHERE f.cancellation_id = $2 AND f.order_id = o.id
+					AND (f.status <> 'failed' OR f.attempts >= $3)
+			)
+		ORDER BY o.id
+		LIMIT $4`
+
+	rows, err := r.db.Query(query, cancellation.EventID, cancellation.ID, maxAttempts, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query orders to refund: %w", err)
+	}
+	defer rows.Close()
+
+	var orders []*models.CancellationOrder
+	for rows.Next() {
+		order := &models.CancellationOrder{}
+		if err := rows.Scan(&order.OrderID, &order.OrderNumber, &order.TotalAmount, &order.Email, &order.Name, &order.Locale); err != nil {
+			return nil, fmt.Errorf("failed to scan order to refund: %w", err)
+		}
+		orders = append(orders, order)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating orders to refund: %w", err)
+	}
+
+	return orders, nil
+}
+
+// ClaimRefund marks an order's refund as in progress before the payment
+// provider is called, so several instances running the job never refund an
+// order twice. It returns the attempt number, or 0 if the order was already
+// claimed or has no attempts left.
+func (r *EventCancellationRepository) ClaimRefund(cancellationID, orderID, maxAttempts int) (int, error) {
+	query := `
+		INSERT INTO event_cancellation_refunds AS f (cancellation_id, order_id, status, attempts)
+		VALUES ($1, $2, 'pending', 1)
+		ON CONFLICT (cancellation_id, order_id) DO UPDATE
+		SET status = 'pending', attempts = f.attempts + 1, updated_at = NOW()
+		WHERE f.status = 'failed' AND f.attempts < $3
+		RETURNING f.attempts`
+
+	var attempts int
+	err := r.db.QueryRow(query, cancellationID, orderID, maxAttempts).Scan(&attempts)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return 0, nil
+		}
+		return 0, fmt.Errorf("failed to claim refund: %w", err)
+	}
+
+	return attempts, nil
+}
+
+// RecordRefund records the outcome of a claimed refund
+func (r *EventCancellationRepository) RecordRefund(cancellationID, orderID int, refunded bool, refundID, errorMessage string) error {
+	status := "failed"
+	if refunded {
+		status = "refunded"
+	}
+
+	result, err := r.db.Exec(`
+		UPDATE event_cancellation_refunds
+		SET status = $1, refund_id = NULLIF($2, ''), error = NULLIF($3, ''), updated_at = NOW()
+		WHERE cancellation_id = $4 AND order_id = $5`, status, refundID, errorMessage, cancellationID, orderID)
+	if err != nil {
+		return fmt.Errorf("failed to record refund: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("refund for order %d not found", orderID)
+	}
+
+	return nil
+}
+
+// UpdateProgress recounts a cancellation's refunded and failed orders and,
+// if complete, marks it completed
+func (r *EventCancellationRepository) UpdateProgress(cancellationID int, complete bool) error {
+	result, err := r.db.Exec(`
+		UPDATE event_cancellations c
+		SET refunded_count = (
+				SELECT COUNT(*) FROM event_cancellation_refunds
+				WHERE cancellation_id = c.id AND status = 'refunded'
+			),
+			failed_count = (
+				SELECT COUNT(*) FROM event_cancellation_refunds
+				WHERE cancellation_id = c.id AND status = 'failed'
+			),
+			status = CASE WHEN $1 THEN 'completed' ELSE c.status END,
+			completed_at = CASE WHEN $1 THEN NOW() ELSE c.completed_at END
+		WHERE c.id = $2`, complete, cancellationID)
+	if err != nil {
+		return fmt.Errorf("failed to update event cancellation: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("event cancellation with id %d not found", cancellationID)
+	}
+
+	return nil
+}
+
+// scanCancellation scans a row selected with cancellationColumns
+func scanCancellation(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.EventCancellation, error) {
+	cancellation := &models.EventCancellation{}
+	var completedAt sql.NullTime
+
+	err := scanner.Scan(
+		&cancellation.ID,
+		&cancellation.EventID,
+		&cancellation.CancelledBy,
+		&cancellation.Reason,
+		&cancellation.Status,
+		&cancellation.OrderCount,
+		&cancellation.RefundTotal,
+		&cancellation.RefundedCount,
+		&cancellation.FailedCount,
+		&cancellation.CreatedAt,
+		&completedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if completedAt.Valid {
+		cancellation.CompletedAt = &completedAt.Time
+	}
+
+	return cancellation, nil
+}
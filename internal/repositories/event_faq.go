@@ -0,0 +1,167 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// EventFAQRepository handles events' FAQs and policies
+type EventFAQRepository struct {
+	db *sql.DB
+}
+
+// NewEventFAQRepository creates a new event FAQ repository
+func NewEventFAQRepository(db *sql.DB) *EventFAQRepository {
+	return &EventFAQRepository{db: db}
+}
+
+const eventFAQColumns = `id, event_id, question, answer, created_at, updated_at`
+
+func scanEventFAQ(row interface{ Scan(...interface{}) error }) (*models.EventFAQ, error) {
+	faq := &models.EventFAQ{}
+	if err := row.Scan(&faq.ID, &faq.EventID, &faq.Question, &faq.Answer, &faq.CreatedAt, &faq.UpdatedAt); err != nil {
+		return nil, err
+	}
+	return faq, nil
+}
+
+// GetFAQs retrieves an event's FAQs in the order they were added
+func (r *EventFAQRepository) GetFAQs(eventID int) ([]*models.EventFAQ, error) {
+	rows, err := r.db.Query(`
+		SELECT `+eventFAQColumns+`
+		FROM event_faqs
+		WHERE event_id = $1
+		ORDER BY id`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get FAQs: %w", err)
+	}
+	defer rows.Close()
+
+	var faqs []*models.EventFAQ
+	for rows.Next() {
+		faq, err := scanEventFAQ(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan FAQ: %w", err)
+		}
+		faqs = append(faqs, faq)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating FAQs: %w", err)
+	}
+
+	return faqs, nil
+}
+
+// GetFAQ retrieves an FAQ entry by ID
+func (r *EventFAQRepository) GetFAQ(id int) (*models.EventFAQ, error) {
+	faq, err := scanEventFAQ(r.db.QueryRow(`SELECT `+eventFAQColumns+` FROM event_faqs WHERE id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("FAQ with id %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get FAQ: %w", err)
+	}
+
+	return faq, nil
+}
+
+// CreateFAQ adds an FAQ entry to an event
+func (r *EventFAQRepository) CreateFAQ(faq *models.EventFAQ) error {
+	err := r.db.QueryRow(`
+		INSERT INTO event_faqs (event_id, question, answer, created_at, updated_at)
+		VALUES ($1, $2, $3, NOW(), NOW())
+		RETURNING id, created_at, updated_at`,
+		faq.EventID, faq.Question, faq.Answer,
+	).Scan(&faq.ID, &faq.CreatedAt, &faq.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create FAQ: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateFAQ saves changes to an FAQ entry
+func (r *EventFAQRepository) UpdateFAQ(faq *models.EventFAQ) error {
+	err := r.db.QueryRow(`
+		UPDATE event_faqs
+		SET question = $3, answer = $4, updated_at = NOW()
+		WHERE id = $1 AND event_id = $2
+		RETURNING updated_at`,
+		faq.ID, faq.EventID, faq.Question, faq.Answer,
+	).Scan(&faq.UpdatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("FAQ with id %d not found", faq.ID)
+		}
+		return fmt.Errorf("failed to update FAQ: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteFAQ removes an FAQ entry from an event
+func (r *EventFAQRepository) DeleteFAQ(id, eventID int) error {
+	result, err := r.db.Exec(`DELETE FROM event_faqs WHERE id = $1 AND event_id = $2`, id, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to delete FAQ: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("FAQ with id %d not found", id)
+	}
+
+	return nil
+}
+
+// GetPolicies retrieves an event's policies, or nil if the organizer hasn't
+// set them
+func (r *EventFAQRepository) GetPolicies(eventID int) (*models.EventPolicies, error) {
+	query := `
+		SELECT event_id, refund_policy, minimum_age, accessibility_info, updated_at
+		FROM event_policies
+		WHERE event_id = $1`
+
+	policies := &models.EventPolicies{}
+	err := r.db.QueryRow(query, eventID).Scan(
+		&policies.EventID,
+		&policies.RefundPolicy,
+		&policies.MinimumAge,
+		&policies.AccessibilityInfo,
+		&policies.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get policies: %w", err)
+	}
+
+	return policies, nil
+}
+
+// SavePolicies creates or updates an event's policies
+func (r *EventFAQRepository) SavePolicies(policies *models.EventPolicies) error {
+	query := `
+		INSERT INTO event_policies (event_id, refund_policy, minimum_age, accessibility_info, updated_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (event_id) DO UPDATE SET
+			refund_policy = EXCLUDED.refund_policy,
+			minimum_age = EXCLUDED.minimum_age,
+			accessibility_info = EXCLUDED.accessibility_info,
+			updated_at = NOW()
+		RETURNING updated_at`
+
+	err := r.db.QueryRow(query, policies.EventID, policies.RefundPolicy, policies.MinimumAge, policies.AccessibilityInfo).Scan(&policies.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save policies: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// EventGeocodeRepository handles the coordinates events are geocoded to
+type EventGeocodeRepository struct {
+	db *sql.DB
+}
+
+// NewEventGeocodeRepository creates a new event geocode repository
+func NewEventGeocodeRepository(db *sql.DB) *EventGeocodeRepository {
+	return &EventGeocodeRepository{db: db}
+}
+
+// UpdateCoordinates stores the coordinates an event's location was geocoded
+// to, or clears them when point is nil because the location couldn't be
+// found. Nothing is stored if the event's location changed in the meantime.
+func (r *EventGeocodeRepository) UpdateCoordinates(eventID int, location string, point *models.GeoPoint) error {
+	var latitude, longitude sql.NullFloat64
+	if point != nil {
+		latitude = sql.NullFloat64{Float64: point.Latitude, Valid: true}
+		longitude = sql.NullFloat64{Float64: point.Longitude, Valid: true}
+	}
+
+	query := `
+		UPDATE events
+		SET latitude = $2, longitude = $3, geocoded_location = $4
+		WHERE id = $1 AND location = $4`
+
+	if _, err := r.db.Exec(query, eventID, latitude, longitude, location); err != nil {
+		return fmt.Errorf("failed to update event coordinates: %w", err)
+	}
+
+	return nil
+}
+
+// GetPending retrieves the locations of upcoming events that haven't been
+// geocoded since their location last changed, oldest events first
+func (r *EventGeocodeRepository) GetPending(limit int) ([]models.EventLocation, error) {
+	query := `
+		SELECT id, location
+		FROM events
+		WHERE deleted_at IS NULL
+		  AND status <> $1
+		  AND end_date > NOW()
+		  AND geocoded_location IS DISTINCT FROM location
+		ORDER BY id
+		LIMIT $2`
+
+	rows, err := r.db.Query(query, models.StatusCancelled, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get events to geocode: %w", err)
+	}
+	defer rows.Close()
+
+	var locations []models.EventLocation
+	for rows.Next() {
+		var location models.EventLocation
+		if err := rows.Scan(&location.EventID, &location.Location); err != nil {
+			return nil, fmt.Errorf("failed to scan event location: %w", err)
+		}
+		locations = append(locations, location)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating event locations: %w", err)
+	}
+
+	return locations, nil
+}
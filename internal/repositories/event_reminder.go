@@ -0,0 +1,177 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// EventReminderRepository handles attendee reminder data operations
+type EventReminderRepository struct {
+	db *sql.DB
+}
+
+// NewEventReminderRepository creates a new event reminder repository
+func NewEventReminderRepository(db *sql.DB) *EventReminderRepository {
+	return &EventReminderRepository{db: db}
+}
+
+// GetSettings retrieves an event's reminder settings, falling back to the defaults
+func (r *EventReminderRepository) GetSettings(eventID int) (*models.EventReminderSettings, error) {
+	query := `
+		SELECT event_id, enabled, remind_7d, remind_24h, remind_2h, message, updated_at
+		FROM event_reminder_settings
+		WHERE event_id = $1`
+
+	settings := &models.EventReminderSettings{}
+	err := r.db.QueryRow(query, eventID).Scan(
+		&settings.EventID,
+		&settings.Enabled,
+		&settings.SevenDays,
+		&settings.OneDay,
+		&settings.TwoHours,
+		&settings.Message,
+		&settings.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.DefaultEventReminderSettings(eventID), nil
+		}
+		return nil, fmt.Errorf("failed to get reminder settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// SaveSettings creates or updates an event's reminder settings
+func (r *EventReminderRepository) SaveSettings(settings *models.EventReminderSettings) error {
+	query := `
+		INSERT INTO event_reminder_settings
+			(event_id, enabled, remind_7d, remind_24h, remind_2h, message, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		ON CONFLICT (event_id) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			remind_7d = EXCLUDED.remind_7d,
+			remind_24h = EXCLUDED.remind_24h,
+			remind_2h = EXCLUDED.remind_2h,
+			message = EXCLUDED.message,
+			updated_at = NOW()
+		RETURNING updated_at`
+
+	err := r.db.QueryRow(query,
+		settings.EventID,
+		settings.Enabled,
+		settings.SevenDays,
+		settings.OneDay,
+		settings.TwoHours,
+		settings.Message,
+	).Scan(&settings.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save reminder settings: %w", err)
+	}
+
+	return nil
+}
+
+// GetEventsStartingBetween returns published events starting within (from, to]
+func (r *EventReminderRepository) GetEventsStartingBetween(from, to time.Time) ([]int, error) {
+	query := `
+		SELECT id
+		FROM events
+		WHERE status = 'published' AND start_date > $1 AND start_date <= $2
+		ORDER BY start_date`
+
+	rows, err := r.db.Query(query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query upcoming events: %w", err)
+	}
+	defer rows.Close()
+
+	var eventIDs []int
+	for rows.Next() {
+		var eventID int
+		if err := rows.Scan(&eventID); err != nil {
+			return nil, fmt.Errorf("failed to scan event ID: %w", err)
+		}
+		eventIDs = append(eventIDs, eventID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating events: %w", err)
+	}
+
+	return eventIDs, nil
+}
+
+// GetReminderRecipients returns the completed orders for an event that still
+// hold active tickets and have not been sent the given reminder, with the
+// language to remind each buyer in
+func (r *EventReminderRepository) GetReminderRecipients(eventID int, reminder models.ReminderType) ([]*models.ReminderRecipient, error) {
+	query := `
+		SELECT o.id, o.order_number,
+			COALESCE(NULLIF(o.billing_email, ''), u.email),
+			COALESCE(NULLIF(o.billing_name, ''), TRIM(u.first_name || ' ' || u.last_name)),
+			COUNT(t.id),
+			COALESCE(NULLIF(o.locale, ''), NULLIF(u.locale, ''), e.locale)
+		FROM orders o
+		JOIN users u ON u.id = o.user_id
+		JOIN events e ON e.id = o.event_id
+		JOIN tickets t ON t.order_id = o.id AND t.status = 'active'
+		WHERE o.event_id = $1 AND o.status = 'completed'
+			AND NOT EXISTS (
+				SELECT 1 FROM event_reminder_deliveries d
+				WHERE d.order_id = o.id AND d.reminder = $2
+			)
+		GROUP BY o.id, o.order_number, o.billing_email, o.billing_name, o.locale, u.email, u.first_name, u.last_name, u.locale, e.locale
+		ORDER BY o.id`
+
+	rows, err := r.db.Query(query, eventID, reminder)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reminder recipients: %w", err)
+	}
+	defer rows.Close()
+
+	var recipients []*models.ReminderRecipient
+	for rows.Next() {
+		recipient := &models.ReminderRecipient{}
+		err := rows.Scan(
+			&recipient.OrderID,
+			&recipient.OrderNumber,
+			&recipient.Email,
+			&recipient.Name,
+			&recipient.TicketCount,
+			&recipient.Locale,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reminder recipient: %w", err)
+		}
+		recipients = append(recipients, recipient)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reminder recipients: %w", err)
+	}
+
+	return recipients, nil
+}
+
+// RecordReminderSent marks a reminder as sent for an order. It returns false
+// if the reminder had already been recorded.
+func (r *EventReminderRepository) RecordReminderSent(eventID, orderID int, reminder models.ReminderType) (bool, error) {
+	result, err := r.db.Exec(`
+		INSERT INTO event_reminder_deliveries (event_id, order_id, reminder)
+		VALUES ($1, $2, $3)
+		ON CONFLICT (order_id, reminder) DO NOTHING`, eventID, orderID, reminder)
+	if err != nil {
+		return false, fmt.Errorf("failed to record reminder: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected == 1, nil
+}
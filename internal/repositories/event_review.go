@@ -0,0 +1,164 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"github.com/lib/pq"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// EventReviewRepository handles the event approval queue's reviewer
+// assignments and review comments
+type EventReviewRepository struct {
+	db *sql.DB
+}
+
+// NewEventReviewRepository creates a new event review repository
+func NewEventReviewRepository(db *sql.DB) *EventReviewRepository {
+	return &EventReviewRepository{db: db}
+}
+
+// AddComment saves a review comment or decision
+func (r *EventReviewRepository) AddComment(comment *models.EventReviewComment) error {
+	err := r.db.QueryRow(`
+		INSERT INTO event_review_comments (event_id, author_id, decision, body)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, created_at`,
+		comment.EventID, comment.AuthorID, comment.Decision, comment.Body,
+	).Scan(&comment.ID, &comment.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save review comment: %w", err)
+	}
+	return nil
+}
+
+// GetComments retrieves the review comments on events, oldest first, keyed
+// by event ID
+func (r *EventReviewRepository) GetComments(eventIDs []int) (map[int][]*models.EventReviewComment, error) {
+	comments := make(map[int][]*models.EventReviewComment)
+	if len(eventIDs) == 0 {
+		return comments, nil
+	}
+
+	rows, err := r.db.Query(`
+		SELECT c.id, c.event_id, c.author_id, c.decision, c.body, c.created_at,
+			TRIM(u.first_name || ' ' || u.last_name), u.role
+		FROM event_review_comments c
+		JOIN users u ON u.id = c.author_id
+		WHERE c.event_id = ANY($1)
+		ORDER BY c.created_at, c.id`, pq.Array(eventIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query review comments: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		comment := &models.EventReviewComment{}
+		if err := rows.Scan(&comment.ID, &comment.EventID, &comment.AuthorID, &comment.Decision, &comment.Body,
+			&comment.CreatedAt, &comment.AuthorName, &comment.AuthorRole); err != nil {
+			return nil, fmt.Errorf("failed to scan review comment: %w", err)
+		}
+		comments[comment.EventID] = append(comments[comment.EventID], comment)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating review comments: %w", err)
+	}
+
+	return comments, nil
+}
+
+// GetUnassignedPendingEvents returns the events waiting for review that no
+// moderator has been assigned, oldest first
+func (r *EventReviewRepository) GetUnassignedPendingEvents() ([]int, error) {
+	rows, err := r.db.Query(`
+		SELECT e.id
+		FROM events e
+		LEFT JOIN event_review_assignments a ON a.event_id = e.id
+		WHERE e.status = 'pending_review' AND a.event_id IS NULL
+		ORDER BY e.created_at, e.id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unassigned events: %w", err)
+	}
+	defer rows.Close()
+
+	var eventIDs []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan event ID: %w", err)
+		}
+		eventIDs = append(eventIDs, id)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating unassigned events: %w", err)
+	}
+
+	return eventIDs, nil
+}
+
+// GetLastAssignedReviewer returns the reviewer assigned most recently, or 0
+// if no event has been assigned
+func (r *EventReviewRepository) GetLastAssignedReviewer() (int, error) {
+	var reviewerID int
+	err := r.db.QueryRow(`
+		SELECT reviewer_id FROM event_review_assignments
+		ORDER BY assigned_at DESC, event_id DESC
+		LIMIT 1`).Scan(&reviewerID)
+	if err == sql.ErrNoRows {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to get last assigned reviewer: %w", err)
+	}
+	return reviewerID, nil
+}
+
+// Assign makes the reviewer responsible for an event, replacing any earlier
+// assignment
+func (r *EventReviewRepository) Assign(eventID, reviewerID int) error {
+	_, err := r.db.Exec(`
+		INSERT INTO event_review_assignments (event_id, reviewer_id)
+		VALUES ($1, $2)
+		ON CONFLICT (event_id) DO UPDATE SET reviewer_id = EXCLUDED.reviewer_id, assigned_at = CURRENT_TIMESTAMP`,
+		eventID, reviewerID)
+	if err != nil {
+		return fmt.Errorf("failed to assign reviewer: %w", err)
+	}
+	return nil
+}
+
+// GetAssignments retrieves the reviewers assigned to events, keyed by event ID
+func (r *EventReviewRepository) GetAssignments(eventIDs []int) (map[int]*models.EventReviewAssignment, error) {
+	assignments := make(map[int]*models.EventReviewAssignment)
+	if len(eventIDs) == 0 {
+		return assignments, nil
+	}
+
+	rows, err := r.db.Query(`
+		SELECT a.event_id, a.reviewer_id, a.assigned_at, TRIM(u.first_name || ' ' || u.last_name)
+		FROM event_review_assignments a
+		JOIN users u ON u.id = a.reviewer_id
+		WHERE a.event_id = ANY($1)`, pq.Array(eventIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query reviewer assignments: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		assignment := &models.EventReviewAssignment{}
+		if err := rows.Scan(&assignment.EventID, &assignment.ReviewerID, &assignment.AssignedAt, &assignment.ReviewerName); err != nil {
+			return nil, fmt.Errorf("failed to scan reviewer assignment: %w", err)
+		}
+		assignments[assignment.EventID] = assignment
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reviewer assignments: %w", err)
+	}
+
+	return assignments, nil
+}
@@ -0,0 +1,143 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// EventTranslationRepository handles event translation data operations
+type EventTranslationRepository struct {
+	db *sql.DB
+}
+
+// NewEventTranslationRepository creates a new event translation repository
+func NewEventTranslationRepository(db *sql.DB) *EventTranslationRepository {
+	return &EventTranslationRepository{db: db}
+}
+
+const translationColumns = `t.event_id, t.locale, t.title, t.description, t.created_at, t.updated_at`
+
+// GetByEvent returns all of an event's translations, ordered by locale
+func (r *EventTranslationRepository) GetByEvent(eventID int) ([]*models.EventTranslation, error) {
+	query := `
+		SELECT ` + translationColumns + `
+		FROM event_translations t
+		WHERE t.event_id = $1
+		ORDER BY t.locale`
+
+	rows, err := r.db.Query(query, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query event translations: %w", err)
+	}
+	defer rows.Close()
+
+	var translations []*models.EventTranslation
+	for rows.Next() {
+		translation, err := scanTranslation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event translation: %w", err)
+		}
+		translations = append(translations, translation)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating event translations: %w", err)
+	}
+
+	return translations, nil
+}
+
+// GetForEvents returns the translations into locale of the events that have
+// one, keyed by event ID. Events already written in locale are left out, in
+// case their default language was changed after they were translated.
+func (r *EventTranslationRepository) GetForEvents(eventIDs []int, locale string) (map[int]*models.EventTranslation, error) {
+	translations := make(map[int]*models.EventTranslation)
+	if len(eventIDs) == 0 {
+		return translations, nil
+	}
+
+	query := `
+		SELECT ` + translationColumns + `
+		FROM event_translations t
+		JOIN events e ON e.id = t.event_id
+		WHERE t.event_id = ANY($1) AND t.locale = $2 AND e.locale <> t.locale`
+
+	rows, err := r.db.Query(query, pq.Array(eventIDs), locale)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query event translations: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		translation, err := scanTranslation(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event translation: %w", err)
+		}
+		translations[translation.EventID] = translation
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating event translations: %w", err)
+	}
+
+	return translations, nil
+}
+
+// Upsert creates or replaces an event's translation into the request's locale
+func (r *EventTranslationRepository) Upsert(req *models.EventTranslationRequest) (*models.EventTranslation, error) {
+	query := `
+		INSERT INTO event_translations AS t (event_id, locale, title, description)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (event_id, locale) DO UPDATE
+		SET title = EXCLUDED.title, description = EXCLUDED.description, updated_at = NOW()
+		RETURNING ` + translationColumns
+
+	translation, err := scanTranslation(r.db.QueryRow(query, req.EventID, req.Locale, req.Title, req.Description))
+	if err != nil {
+		return nil, fmt.Errorf("failed to save event translation: %w", err)
+	}
+
+	return translation, nil
+}
+
+// Delete removes an event's translation into locale
+func (r *EventTranslationRepository) Delete(eventID int, locale string) error {
+	result, err := r.db.Exec("DELETE FROM event_translations WHERE event_id = $1 AND locale = $2", eventID, locale)
+	if err != nil {
+		return fmt.Errorf("failed to delete event translation: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("translation of event %d into %s not found", eventID, locale)
+	}
+
+	return nil
+}
+
+// scanTranslation scans a row selected with translationColumns
+func scanTranslation(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.EventTranslation, error) {
+	translation := &models.EventTranslation{}
+	err := scanner.Scan(
+		&translation.EventID,
+		&translation.Locale,
+		&translation.Title,
+		&translation.Description,
+		&translation.CreatedAt,
+		&translation.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return translation, nil
+}
@@ -0,0 +1,124 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// EventViewRepository handles event page views and their daily rollups
+type EventViewRepository struct {
+	db *sql.DB
+}
+
+// NewEventViewRepository creates a new event view repository
+func NewEventViewRepository(db *sql.DB) *EventViewRepository {
+	return &EventViewRepository{db: db}
+}
+
+// Record stores a view unless the visitor already viewed the event within
+// the dedupe window, and returns whether it was stored
+func (r *EventViewRepository) Record(view *models.EventView, dedupe time.Duration) (bool, error) {
+	result, err := r.db.Exec(`
+		INSERT INTO event_views (event_id, visitor_id, user_id, referrer_host)
+		SELECT $1, $2, $3, $4
+		WHERE NOT EXISTS (
+			SELECT 1 FROM event_views
+			WHERE event_id = $1 AND visitor_id = $2 AND viewed_at > $5
+		)`, view.EventID, view.VisitorID, view.UserID, view.ReferrerHost, time.Now().Add(-dedupe))
+	if err != nil {
+		return false, fmt.Errorf("failed to record event view: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to record event view: %w", err)
+	}
+	return rows > 0, nil
+}
+
+// CountVisitors counts the visitors who viewed an event since a time.
+// Rolled up days count from the start of the day since falls on, and a
+// visitor counts once for each day they visited.
+func (r *EventViewRepository) CountVisitors(eventID int, since time.Time) (int, error) {
+	var visitors int
+	err := r.db.QueryRow(`
+		SELECT
+			COALESCE((SELECT SUM(visitors) FROM event_view_daily
+				WHERE event_id = $1 AND day >= $2::date), 0)
+			+ (SELECT COUNT(DISTINCT visitor_id) FROM event_views
+				WHERE event_id = $1 AND viewed_at >= $2)`, eventID, since).Scan(&visitors)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count event visitors: %w", err)
+	}
+	return visitors, nil
+}
+
+// GetDaily returns an event's views for each day since a time that had any,
+// oldest first
+func (r *EventViewRepository) GetDaily(eventID int, since time.Time) ([]*models.EventViewDay, error) {
+	rows, err := r.db.Query(`
+		SELECT day, SUM(views), SUM(visitors) FROM (
+			SELECT day, views, visitors FROM event_view_daily
+			WHERE event_id = $1 AND day >= $2::date
+			UNION ALL
+			SELECT viewed_at::date, COUNT(*), COUNT(DISTINCT visitor_id) FROM event_views
+			WHERE event_id = $1 AND viewed_at >= $2
+			GROUP BY viewed_at::date
+		) v
+		GROUP BY day
+		ORDER BY day`, eventID, since)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get daily event views: %w", err)
+	}
+	defer rows.Close()
+
+	var days []*models.EventViewDay
+	for rows.Next() {
+		day := &models.EventViewDay{}
+		if err := rows.Scan(&day.Day, &day.Views, &day.Visitors); err != nil {
+			return nil, fmt.Errorf("failed to scan daily event views: %w", err)
+		}
+		days = append(days, day)
+	}
+	return days, rows.Err()
+}
+
+// Rollup adds the views before a time to the daily totals and deletes
+// them, returning how many views were rolled up
+func (r *EventViewRepository) Rollup(before time.Time) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	_, err = tx.Exec(`
+		INSERT INTO event_view_daily (event_id, day, views, visitors)
+		SELECT event_id, viewed_at::date, COUNT(*), COUNT(DISTINCT visitor_id)
+		FROM event_views
+		WHERE viewed_at < $1
+		GROUP BY event_id, viewed_at::date
+		ON CONFLICT (event_id, day) DO UPDATE SET
+			views = event_view_daily.views + EXCLUDED.views,
+			visitors = event_view_daily.visitors + EXCLUDED.visitors`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to roll up event views: %w", err)
+	}
+
+	result, err := tx.Exec(`DELETE FROM event_views WHERE viewed_at < $1`, before)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete rolled up event views: %w", err)
+	}
+	rolledUp, err := result.RowsAffected()
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete rolled up event views: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit event view rollup: %w", err)
+	}
+	return int(rolledUp), nil
+}
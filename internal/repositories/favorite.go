@@ -0,0 +1,115 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// FavoriteRepository handles attendees' saved events
+type FavoriteRepository struct {
+	db *sql.DB
+}
+
+// NewFavoriteRepository creates a new favorite repository
+func NewFavoriteRepository(db *sql.DB) *FavoriteRepository {
+	return &FavoriteRepository{db: db}
+}
+
+// Add saves an event for a user. Saving an event twice is not an error.
+func (r *FavoriteRepository) Add(userID, eventID int) error {
+	_, err := r.db.Exec(`
+		INSERT INTO event_favorites (user_id, event_id)
+		VALUES ($1, $2)
+		ON CONFLICT (user_id, event_id) DO NOTHING`, userID, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to save event: %w", err)
+	}
+	return nil
+}
+
+// Remove unsaves an event for a user
+func (r *FavoriteRepository) Remove(userID, eventID int) error {
+	if _, err := r.db.Exec("DELETE FROM event_favorites WHERE user_id = $1 AND event_id = $2", userID, eventID); err != nil {
+		return fmt.Errorf("failed to unsave event: %w", err)
+	}
+	return nil
+}
+
+// IsFavorite returns true if the user has saved the event
+func (r *FavoriteRepository) IsFavorite(userID, eventID int) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(`
+		SELECT EXISTS (SELECT 1 FROM event_favorites WHERE user_id = $1 AND event_id = $2)`, userID, eventID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check saved event: %w", err)
+	}
+	return exists, nil
+}
+
+// GetWatchers returns the active users who saved the event, with the
+// language to email each of them in
+func (r *FavoriteRepository) GetWatchers(eventID int) ([]*models.EventWatcher, error) {
+	query := `
+		SELECT u.id, u.email, TRIM(u.first_name || ' ' || u.last_name),
+			COALESCE(NULLIF(u.locale, ''), e.locale)
+		FROM event_favorites f
+		JOIN users u ON u.id = f.user_id
+		JOIN events e ON e.id = f.event_id
+		WHERE f.event_id = $1 AND u.is_active = true
+		ORDER BY f.created_at`
+
+	rows, err := r.db.Query(query, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query event watchers: %w", err)
+	}
+	defer rows.Close()
+
+	var watchers []*models.EventWatcher
+	for rows.Next() {
+		watcher := &models.EventWatcher{}
+		if err := rows.Scan(&watcher.UserID, &watcher.Email, &watcher.Name, &watcher.Locale); err != nil {
+			return nil, fmt.Errorf("failed to scan event watcher: %w", err)
+		}
+		watchers = append(watchers, watcher)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating event watchers: %w", err)
+	}
+
+	return watchers, nil
+}
+
+// GetSavedEvents returns the published events a user saved that have not
+// ended, soonest first
+func (r *FavoriteRepository) GetSavedEvents(userID int) ([]*models.Event, error) {
+	query := `
+		SELECT e.id, e.title, e.description, e.start_date, e.end_date, e.location, e.category_id, e.organizer_id, e.image_url, e.image_key, e.image_size, e.image_format, e.image_width, e.image_height, e.image_uploaded_at, e.image_alt_text, e.image_variants, e.slug, e.status, e.created_at, e.updated_at
+		FROM event_favorites f
+		JOIN events e ON e.id = f.event_id
+		WHERE f.user_id = $1 AND e.status = $2 AND e.end_date > NOW()
+		ORDER BY e.start_date`
+
+	rows, err := r.db.Query(query, userID, models.StatusPublished)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query saved events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*models.Event
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan saved event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating saved events: %w", err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,114 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// FinanceRepository reads the sales and withdrawals behind organizers'
+// settlement statements
+type FinanceRepository struct {
+	db *sql.DB
+}
+
+// NewFinanceRepository creates a new finance repository
+func NewFinanceRepository(db *sql.DB) *FinanceRepository {
+	return &FinanceRepository{db: db}
+}
+
+// GetSettlementLines returns the sales, refunds and chargebacks of the
+// organizer's events from from until to, one line per event with any,
+// soonest event first. Orders count as sales when they are placed, as
+// refunds when they are refunded and as chargebacks when a dispute over them
+// is lost, so an order reversed in a later period appears in both. The tax
+// charged on orders is counted the same way. Box office sales are left out,
+// as the platform neither collected them nor takes a fee on them. An eventID
+// of 0 covers all of the organizer's events.
+func (r *FinanceRepository) GetSettlementLines(organizerID, eventID int, from, to time.Time) ([]*models.SettlementLine, error) {
+	query := fmt.Sprintf(`
+		SELECT e.id, e.title, e.start_date,
+			COUNT(o.id) FILTER (WHERE o.status IN ('completed', 'refunded', 'disputed') AND o.created_at >= $3 AND o.created_at < $4),
+			COALESCE(SUM(o.total_amount) FILTER (WHERE o.status IN ('completed', 'refunded', 'disputed') AND o.created_at >= $3 AND o.created_at < $4), 0),
+			COUNT(o.id) FILTER (WHERE o.status = 'refunded' AND o.updated_at >= $3 AND o.updated_at < $4),
+			COALESCE(SUM(o.total_amount) FILTER (WHERE o.status = 'refunded' AND o.updated_at >= $3 AND o.updated_at < $4), 0),
+			COUNT(d.order_id),
+			COALESCE(SUM(d.amount), 0),
+			COALESCE(SUM((o.tax->>'amount')::bigint) FILTER (WHERE o.status IN ('completed', 'refunded', 'disputed') AND o.created_at >= $3 AND o.created_at < $4), 0),
+			COALESCE(SUM((o.tax->>'amount')::bigint) FILTER (WHERE o.status = 'refunded' AND o.updated_at >= $3 AND o.updated_at < $4), 0)
+		FROM events e
+		JOIN orders o ON o.event_id = e.id
+		LEFT JOIN (
+			SELECT order_id, SUM(amount) AS amount
+			FROM payment_disputes
+			WHERE status = 'lost' AND resolved_at >= $3 AND resolved_at < $4
+			GROUP BY order_id
+		) d ON d.order_id = o.id
+		WHERE e.organizer_id = $1 AND ($2 = 0 OR e.id = $2) AND %s
+			AND ((o.created_at >= $3 AND o.created_at < $4) OR (o.updated_at >= $3 AND o.updated_at < $4) OR d.order_id IS NOT NULL)
+		GROUP BY e.id, e.title, e.start_date
+		ORDER BY e.start_date, e.id`, models.OnlinePaymentCondition("o.payment_id"))
+
+	rows, err := r.db.Query(query, organizerID, eventID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query settlement lines: %w", err)
+	}
+	defer rows.Close()
+
+	var lines []*models.SettlementLine
+	for rows.Next() {
+		line := &models.SettlementLine{}
+		if err := rows.Scan(&line.EventID, &line.EventTitle, &line.EventStartDate, &line.Orders, &line.GrossCents, &line.Refunds, &line.RefundCents, &line.Chargebacks, &line.ChargebackCents, &line.TaxCents, &line.RefundTaxCents); err != nil {
+			return nil, fmt.Errorf("failed to scan settlement line: %w", err)
+		}
+		if line.Orders == 0 && line.Refunds == 0 && line.Chargebacks == 0 {
+			continue
+		}
+		lines = append(lines, line)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating settlement lines: %w", err)
+	}
+
+	return lines, nil
+}
+
+// GetPaidOutWithdrawals returns the organizer's withdrawals approved or
+// completed from from until to, which are the ones counted out of their
+// balance, oldest first
+func (r *FinanceRepository) GetPaidOutWithdrawals(organizerID int, from, to time.Time) ([]*models.Withdrawal, error) {
+	query := `
+		SELECT id, organizer_id, amount, status, reason, requested_at, processed_at
+		FROM withdrawals
+		WHERE organizer_id = $1 AND status IN ('approved', 'completed')
+			AND processed_at >= $2 AND processed_at < $3
+		ORDER BY processed_at, id`
+
+	rows, err := r.db.Query(query, organizerID, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query paid out withdrawals: %w", err)
+	}
+	defer rows.Close()
+
+	var withdrawals []*models.Withdrawal
+	for rows.Next() {
+		withdrawal := &models.Withdrawal{}
+		var processedAt sql.NullTime
+		if err := rows.Scan(&withdrawal.ID, &withdrawal.OrganizerID, &withdrawal.Amount, &withdrawal.Status, &withdrawal.Reason, &withdrawal.RequestedAt, &processedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan withdrawal: %w", err)
+		}
+		if processedAt.Valid {
+			withdrawal.ProcessedAt = &processedAt.Time
+		}
+		withdrawals = append(withdrawals, withdrawal)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating withdrawals: %w", err)
+	}
+
+	return withdrawals, nil
+}
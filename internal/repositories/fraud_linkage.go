@@ -0,0 +1,142 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// FraudLinkageRepository handles the identifying signals that link accounts
+type FraudLinkageRepository struct {
+	db *sql.DB
+}
+
+// NewFraudLinkageRepository creates a new fraud linkage repository
+func NewFraudLinkageRepository(db *sql.DB) *FraudLinkageRepository {
+	return &FraudLinkageRepository{db: db}
+}
+
+// RecordSignal records that a signal was seen for a user, counting repeat sightings
+func (r *FraudLinkageRepository) RecordSignal(userID int, kind models.SignalKind, fingerprint, label string) error {
+	_, err := r.db.Exec(`
+		INSERT INTO account_signals (user_id, kind, fingerprint, label)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (user_id, kind, fingerprint) DO UPDATE
+		SET label = EXCLUDED.label,
+			seen_count = account_signals.seen_count + 1,
+			last_seen_at = CURRENT_TIMESTAMP`, userID, kind, fingerprint, label)
+	if err != nil {
+		return fmt.Errorf("failed to record account signal: %w", err)
+	}
+	return nil
+}
+
+// GetSignals returns the signals seen for a user, most recent first
+func (r *FraudLinkageRepository) GetSignals(userID int) ([]*models.AccountSignal, error) {
+	query := `
+		SELECT user_id, kind, fingerprint, label, seen_count, first_seen_at, last_seen_at
+		FROM account_signals
+		WHERE user_id = $1
+		ORDER BY last_seen_at DESC`
+
+	rows, err := r.db.Query(query, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query account signals: %w", err)
+	}
+	defer rows.Close()
+
+	var signals []*models.AccountSignal
+	for rows.Next() {
+		signal := &models.AccountSignal{}
+		if err := rows.Scan(&signal.UserID, &signal.Kind, &signal.Fingerprint, &signal.Label,
+			&signal.SeenCount, &signal.FirstSeenAt, &signal.LastSeenAt); err != nil {
+			return nil, fmt.Errorf("failed to scan account signal: %w", err)
+		}
+		signals = append(signals, signal)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating account signals: %w", err)
+	}
+
+	return signals, nil
+}
+
+// GetSharedLinks returns every pair of accounts that share a signal.
+// Signals shared by more than maxAccounts accounts are left out.
+func (r *FraudLinkageRepository) GetSharedLinks(maxAccounts int) ([]*models.AccountLink, error) {
+	query := `
+		WITH usable AS (
+			SELECT kind, fingerprint
+			FROM account_signals
+			GROUP BY kind, fingerprint
+			HAVING COUNT(*) BETWEEN 2 AND $1
+		)
+		SELECT a.user_id, b.user_id, a.kind, a.label
+		FROM account_signals a
+		JOIN usable u ON u.kind = a.kind AND u.fingerprint = a.fingerprint
+		JOIN account_signals b ON b.kind = a.kind AND b.fingerprint = a.fingerprint AND b.user_id > a.user_id
+		ORDER BY a.user_id, b.user_id, a.kind`
+
+	rows, err := r.db.Query(query, maxAccounts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query shared account signals: %w", err)
+	}
+	defer rows.Close()
+
+	var links []*models.AccountLink
+	for rows.Next() {
+		link := &models.AccountLink{}
+		if err := rows.Scan(&link.UserA, &link.UserB, &link.Kind, &link.Label); err != nil {
+			return nil, fmt.Errorf("failed to scan account link: %w", err)
+		}
+		links = append(links, link)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating account links: %w", err)
+	}
+
+	return links, nil
+}
+
+// GetLinkedAccounts returns the accounts with the given IDs and their refund history
+func (r *FraudLinkageRepository) GetLinkedAccounts(userIDs []int) ([]*models.LinkedAccount, error) {
+	if len(userIDs) == 0 {
+		return nil, nil
+	}
+
+	query := `
+		SELECT u.id, u.email, TRIM(u.first_name || ' ' || u.last_name), u.role, u.is_active, u.created_at,
+			(SELECT COUNT(*) FROM orders o JOIN events e ON e.id = o.event_id
+				WHERE e.organizer_id = u.id AND o.status = 'refunded'),
+			(SELECT COUNT(*) FROM events e WHERE e.organizer_id = u.id AND e.status = 'cancelled')
+		FROM users u
+		WHERE u.id = ANY($1)
+		ORDER BY u.created_at`
+
+	rows, err := r.db.Query(query, pq.Array(userIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query linked accounts: %w", err)
+	}
+	defer rows.Close()
+
+	var accounts []*models.LinkedAccount
+	for rows.Next() {
+		account := &models.LinkedAccount{}
+		if err := rows.Scan(&account.ID, &account.Email, &account.Name, &account.Role, &account.IsActive,
+			&account.CreatedAt, &account.RefundedOrders, &account.CancelledEvents); err != nil {
+			return nil, fmt.Errorf("failed to scan linked account: %w", err)
+		}
+		accounts = append(accounts, account)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating linked accounts: %w", err)
+	}
+
+	return accounts, nil
+}
@@ -0,0 +1,203 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// HelpArticleRepository handles help center data operations
+type HelpArticleRepository struct {
+	db *sql.DB
+}
+
+// NewHelpArticleRepository creates a new help article repository
+func NewHelpArticleRepository(db *sql.DB) *HelpArticleRepository {
+	return &HelpArticleRepository{db: db}
+}
+
+const helpArticleColumns = `id, slug, title, summary, body, category, status, updated_by,
+		       created_at, updated_at, published_at`
+
+// List returns the articles matching the filter, grouped by category and
+// alphabetical within each
+func (r *HelpArticleRepository) List(filter models.HelpArticleFilter) ([]*models.HelpArticle, error) {
+	var conditions []string
+	var args []interface{}
+	if filter.Category != "" {
+		args = append(args, filter.Category)
+		conditions = append(conditions, fmt.Sprintf("category = $%d", len(args)))
+	}
+	if filter.Status != "" {
+		args = append(args, filter.Status)
+		conditions = append(conditions, fmt.Sprintf("status = $%d", len(args)))
+	}
+
+	query := `SELECT ` + helpArticleColumns + ` FROM help_articles`
+	if len(conditions) > 0 {
+		query += ` WHERE ` + strings.Join(conditions, " AND ")
+	}
+	query += ` ORDER BY category, title`
+	return r.query(query, args...)
+}
+
+// Search returns the published articles matching the query, best matches
+// first. Every term must match as a prefix, or the title must be similar to
+// the query to allow for typos.
+func (r *HelpArticleRepository) Search(query string, limit int) ([]*models.HelpArticle, error) {
+	tsQuery := BuildPrefixTSQuery(query)
+	if tsQuery == "" {
+		return nil, nil
+	}
+
+	return r.query(`
+		SELECT `+helpArticleColumns+`
+		FROM help_articles
+		WHERE status = $1 AND (search_vector @@ to_tsquery('english', $2) OR $3 <% title)
+		ORDER BY ts_rank_cd(search_vector, to_tsquery('english', $2)) + word_similarity($3, title) DESC, title
+		LIMIT $4`, models.HelpArticlePublished, tsQuery, query, limit)
+}
+
+// GetBySlug returns the article with the slug
+func (r *HelpArticleRepository) GetBySlug(slug string) (*models.HelpArticle, error) {
+	query := `SELECT ` + helpArticleColumns + ` FROM help_articles WHERE slug = $1`
+	article, err := scanHelpArticle(r.db.QueryRow(query, slug))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("help article %q not found", slug)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get help article: %w", err)
+	}
+	return article, nil
+}
+
+// GetByID returns a help article
+func (r *HelpArticleRepository) GetByID(id int) (*models.HelpArticle, error) {
+	query := `SELECT ` + helpArticleColumns + ` FROM help_articles WHERE id = $1`
+	article, err := scanHelpArticle(r.db.QueryRow(query, id))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("help article with id %d not found", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get help article: %w", err)
+	}
+	return article, nil
+}
+
+// Create saves a new help article written by an admin
+func (r *HelpArticleRepository) Create(req *models.HelpArticleRequest, adminID int) (*models.HelpArticle, error) {
+	query := `
+		INSERT INTO help_articles (slug, title, summary, body, category, status, updated_by, published_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, CASE WHEN $6 = 'published' THEN NOW() END)
+		RETURNING ` + helpArticleColumns
+
+	article, err := scanHelpArticle(r.db.QueryRow(query,
+		req.Slug, req.Title, req.Summary, req.Body, req.Category, req.Status, nullableAdminID(adminID),
+	))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create help article: %w", err)
+	}
+	return article, nil
+}
+
+// Update changes a help article. It keeps the time the article was first
+// published while it stays published.
+func (r *HelpArticleRepository) Update(id int, req *models.HelpArticleRequest, adminID int) (*models.HelpArticle, error) {
+	query := `
+		UPDATE help_articles
+		SET slug = $2, title = $3, summary = $4, body = $5, category = $6, status = $7, updated_by = $8,
+		    published_at = CASE WHEN $7 = 'published' THEN COALESCE(published_at, NOW()) END,
+		    updated_at = NOW()
+		WHERE id = $1
+		RETURNING ` + helpArticleColumns
+
+	article, err := scanHelpArticle(r.db.QueryRow(query,
+		id, req.Slug, req.Title, req.Summary, req.Body, req.Category, req.Status, nullableAdminID(adminID),
+	))
+	if err == sql.ErrNoRows {
+		return nil, fmt.Errorf("help article with id %d not found", id)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to update help article: %w", err)
+	}
+	return article, nil
+}
+
+// Delete removes a help article
+func (r *HelpArticleRepository) Delete(id int) error {
+	result, err := r.db.Exec(`DELETE FROM help_articles WHERE id = $1`, id)
+	if err != nil {
+		return fmt.Errorf("failed to delete help article: %w", err)
+	}
+	if rows, err := result.RowsAffected(); err == nil && rows == 0 {
+		return fmt.Errorf("help article with id %d not found", id)
+	}
+	return nil
+}
+
+// query runs a query returning help articles
+func (r *HelpArticleRepository) query(query string, args ...interface{}) ([]*models.HelpArticle, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query help articles: %w", err)
+	}
+	defer rows.Close()
+
+	var articles []*models.HelpArticle
+	for rows.Next() {
+		article, err := scanHelpArticle(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan help article: %w", err)
+		}
+		articles = append(articles, article)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating help articles: %w", err)
+	}
+
+	return articles, nil
+}
+
+// nullableAdminID returns the admin ID to store, NULL when there isn't one
+func nullableAdminID(adminID int) interface{} {
+	if adminID > 0 {
+		return adminID
+	}
+	return nil
+}
+
+// scanHelpArticle scans a row of helpArticleColumns
+func scanHelpArticle(row interface {
+	Scan(dest ...interface{}) error
+}) (*models.HelpArticle, error) {
+	article := &models.HelpArticle{}
+	var updatedBy sql.NullInt64
+	var publishedAt sql.NullTime
+	err := row.Scan(
+		&article.ID,
+		&article.Slug,
+		&article.Title,
+		&article.Summary,
+		&article.Body,
+		&article.Category,
+		&article.Status,
+		&updatedBy,
+		&article.CreatedAt,
+		&article.UpdatedAt,
+		&publishedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if updatedBy.Valid {
+		id := int(updatedBy.Int64)
+		article.UpdatedBy = &id
+	}
+	if publishedAt.Valid {
+		article.PublishedAt = &publishedAt.Time
+	}
+	return article, nil
+}
@@ -0,0 +1,116 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// IdempotencyRepository handles the idempotency keys requests were made with
+type IdempotencyRepository struct {
+	db *sql.DB
+}
+
+// NewIdempotencyRepository creates a new idempotency repository
+func NewIdempotencyRepository(db *sql.DB) *IdempotencyRepository {
+	return &IdempotencyRepository{db: db}
+}
+
+// Claim starts processing a request with the record's key, reporting whether
+// it was claimed. A key already in use is only taken over once it has
+// expired, or when it was still processing at staleBefore, because the
+// request that claimed it never finished.
+func (r *IdempotencyRepository) Claim(record *models.IdempotencyRecord, staleBefore time.Time) (bool, error) {
+	query := `
+		INSERT INTO idempotency_keys (scope, user_id, idempotency_key, status, created_at, expires_at)
+		VALUES ($1, $2, $3, 'processing', NOW(), $4)
+		ON CONFLICT (scope, user_id, idempotency_key) DO UPDATE SET
+			status = 'processing',
+			response_status = 0,
+			response_location = '',
+			created_at = NOW(),
+			completed_at = NULL,
+			expires_at = EXCLUDED.expires_at
+		WHERE idempotency_keys.expires_at <= NOW()
+		   OR (idempotency_keys.status = 'processing' AND idempotency_keys.created_at < $5)
+		RETURNING created_at`
+
+	err := r.db.QueryRow(query, record.Scope, record.UserID, record.Key, record.ExpiresAt, staleBefore).Scan(&record.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return false, nil
+		}
+		return false, fmt.Errorf("failed to claim idempotency key: %w", err)
+	}
+
+	record.Status = models.IdempotencyProcessing
+	return true, nil
+}
+
+// Get retrieves the record of a key
+func (r *IdempotencyRepository) Get(scope string, userID int, key string) (*models.IdempotencyRecord, error) {
+	query := `
+		SELECT scope, user_id, idempotency_key, status, response_status, response_location, created_at, completed_at, expires_at
+		FROM idempotency_keys
+		WHERE scope = $1 AND user_id = $2 AND idempotency_key = $3`
+
+	record := &models.IdempotencyRecord{}
+	var completedAt sql.NullTime
+	err := r.db.QueryRow(query, scope, userID, key).Scan(
+		&record.Scope,
+		&record.UserID,
+		&record.Key,
+		&record.Status,
+		&record.ResponseStatus,
+		&record.ResponseLocation,
+		&record.CreatedAt,
+		&completedAt,
+		&record.ExpiresAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("idempotency key not found")
+		}
+		return nil, fmt.Errorf("failed to get idempotency key: %w", err)
+	}
+
+	if completedAt.Valid {
+		record.CompletedAt = &completedAt.Time
+	}
+
+	return record, nil
+}
+
+// Complete records the redirect a request answered with
+func (r *IdempotencyRepository) Complete(scope string, userID int, key string, status int, location string) error {
+	query := `
+		UPDATE idempotency_keys
+		SET status = 'completed', response_status = $4, response_location = $5, completed_at = NOW()
+		WHERE scope = $1 AND user_id = $2 AND idempotency_key = $3`
+
+	if _, err := r.db.Exec(query, scope, userID, key, status, location); err != nil {
+		return fmt.Errorf("failed to complete idempotency key: %w", err)
+	}
+	return nil
+}
+
+// Release forgets a key whose request didn't complete, so it can be retried
+func (r *IdempotencyRepository) Release(scope string, userID int, key string) error {
+	query := `DELETE FROM idempotency_keys WHERE scope = $1 AND user_id = $2 AND idempotency_key = $3`
+
+	if _, err := r.db.Exec(query, scope, userID, key); err != nil {
+		return fmt.Errorf("failed to release idempotency key: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpired deletes expired keys, returning how many were deleted
+func (r *IdempotencyRepository) DeleteExpired() (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM idempotency_keys WHERE expires_at <= NOW()`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired idempotency keys: %w", err)
+	}
+	return result.RowsAffected()
+}
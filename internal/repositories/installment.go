@@ -0,0 +1,437 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// InstallmentRepository handles installment settings and orders' payment schedules
+type InstallmentRepository struct {
+	db *sql.DB
+}
+
+// NewInstallmentRepository creates a new installment repository
+func NewInstallmentRepository(db *sql.DB) *InstallmentRepository {
+	return &InstallmentRepository{db: db}
+}
+
+// GetSettings retrieves an event's installment settings, falling back to the defaults
+func (r *InstallmentRepository) GetSettings(eventID int) (*models.InstallmentSettings, error) {
+	query := `
+		SELECT event_id, enabled, installments, interval_days, min_order_amount, issue_tickets_upfront, updated_at
+		FROM event_installment_settings
+		WHERE event_id = $1`
+
+	settings := &models.InstallmentSettings{}
+	err := r.db.QueryRow(query, eventID).Scan(
+		&settings.EventID,
+		&settings.Enabled,
+		&settings.Installments,
+		&settings.IntervalDays,
+		&settings.MinOrderAmount,
+		&settings.IssueTicketsUpfront,
+		&settings.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.DefaultInstallmentSettings(eventID), nil
+		}
+		return nil, fmt.Errorf("failed to get installment settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// SaveSettings creates or updates an event's installment settings
+func (r *InstallmentRepository) SaveSettings(settings *models.InstallmentSettings) error {
+	query := `
+		INSERT INTO event_installment_settings (event_id, enabled, installments, interval_days, min_order_amount,
+			issue_tickets_upfront, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		ON CONFLICT (event_id) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			installments = EXCLUDED.installments,
+			interval_days = EXCLUDED.interval_days,
+			min_order_amount = EXCLUDED.min_order_amount,
+			issue_tickets_upfront = EXCLUDED.issue_tickets_upfront,
+			updated_at = NOW()
+		RETURNING updated_at`
+
+	err := r.db.QueryRow(query,
+		settings.EventID,
+		settings.Enabled,
+		settings.Installments,
+		settings.IntervalDays,
+		settings.MinOrderAmount,
+		settings.IssueTicketsUpfront,
+	).Scan(&settings.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save installment settings: %w", err)
+	}
+
+	return nil
+}
+
+// CreatePlan stores an order's payment schedule with its installments. Plans
+// whose tickets are issued once paid in full take the tickets out of
+// inventory now, turning the buyer's holds into the sale, so they can't sell
+// out before the last installment.
+func (r *InstallmentRepository) CreatePlan(plan *models.InstallmentPlan) error {
+	items, err := json.Marshal(plan.Items)
+	if err != nil {
+		return fmt.Errorf("failed to encode plan items: %w", err)
+	}
+	attendees, err := json.Marshal(plan.Attendees)
+	if err != nil {
+		return fmt.Errorf("failed to encode plan attendees: %w", err)
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	err = tx.QueryRow(`
+		INSERT INTO order_installment_plans (order_id, user_id, event_id, total_amount, status, issue_tickets_upfront,
+			authorization_code, customer_email, items, attendees, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, NOW())
+		RETURNING id, created_at`,
+		plan.OrderID,
+		plan.UserID,
+		plan.EventID,
+		plan.TotalAmount,
+		plan.Status,
+		plan.IssueTicketsUpfront,
+		plan.AuthorizationCode,
+		plan.CustomerEmail,
+		items,
+		attendees,
+	).Scan(&plan.ID, &plan.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create installment plan: %w", err)
+	}
+
+	for _, installment := range plan.Installments {
+		installment.PlanID = plan.ID
+		err = tx.QueryRow(`
+			INSERT INTO order_installments (plan_id, sequence, amount, due_at, status, payment_reference, attempts,
+				next_attempt_at, paid_at)
+			VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			RETURNING id`,
+			installment.PlanID,
+			installment.Sequence,
+			installment.Amount,
+			installment.DueAt,
+			installment.Status,
+			installment.PaymentReference,
+			installment.Attempts,
+			installment.NextAttemptAt,
+			installment.PaidAt,
+		).Scan(&installment.ID)
+		if err != nil {
+			return fmt.Errorf("failed to create installment: %w", err)
+		}
+	}
+
+	if !plan.IssueTicketsUpfront {
+		quantities := make(map[int]int)
+		for _, item := range plan.Items {
+			quantities[item.TicketTypeID] += item.Quantity
+		}
+		if err := sellTickets(tx, plan.UserID, quantities); err != nil {
+			return err
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit installment plan: %w", err)
+	}
+
+	return nil
+}
+
+const installmentPlanColumns = `id, order_id, user_id, event_id, total_amount, status, issue_tickets_upfront,
+	authorization_code, customer_email, items, attendees, created_at, completed_at`
+
+// GetPlan retrieves a payment schedule with its installments
+func (r *InstallmentRepository) GetPlan(id int) (*models.InstallmentPlan, error) {
+	plan, err := r.getPlan(`SELECT `+installmentPlanColumns+` FROM order_installment_plans WHERE id = $1`, id)
+	if err != nil {
+		return nil, err
+	}
+	if plan == nil {
+		return nil, fmt.Errorf("installment plan %d not found", id)
+	}
+	return plan, nil
+}
+
+// GetPlanByOrder retrieves an order's payment schedule with its
+// installments, or nil if the order isn't being paid in installments
+func (r *InstallmentRepository) GetPlanByOrder(orderID int) (*models.InstallmentPlan, error) {
+	return r.getPlan(`SELECT `+installmentPlanColumns+` FROM order_installment_plans WHERE order_id = $1`, orderID)
+}
+
+func (r *InstallmentRepository) getPlan(query string, arg int) (*models.InstallmentPlan, error) {
+	plan := &models.InstallmentPlan{}
+	var items, attendees []byte
+	var completedAt sql.NullTime
+	err := r.db.QueryRow(query, arg).Scan(
+		&plan.ID,
+		&plan.OrderID,
+		&plan.UserID,
+		&plan.EventID,
+		&plan.TotalAmount,
+		&plan.Status,
+		&plan.IssueTicketsUpfront,
+		&plan.AuthorizationCode,
+		&plan.CustomerEmail,
+		&items,
+		&attendees,
+		&plan.CreatedAt,
+		&completedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get installment plan: %w", err)
+	}
+
+	if completedAt.Valid {
+		plan.CompletedAt = &completedAt.Time
+	}
+	if err := json.Unmarshal(items, &plan.Items); err != nil {
+		return nil, fmt.Errorf("failed to decode plan items: %w", err)
+	}
+	if err := json.Unmarshal(attendees, &plan.Attendees); err != nil {
+		return nil, fmt.Errorf("failed to decode plan attendees: %w", err)
+	}
+
+	plan.Installments, err = r.listInstallments(`
+		SELECT `+installmentColumns+`
+		FROM order_installments
+		WHERE plan_id = $1
+		ORDER BY sequence`, plan.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return plan, nil
+}
+
+const installmentColumns = `id, plan_id, sequence, amount, due_at, status, payment_reference, attempts,
+	next_attempt_at, last_error, paid_at`
+
+func (r *InstallmentRepository) listInstallments(query string, args ...interface{}) ([]*models.Installment, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list installments: %w", err)
+	}
+	defer rows.Close()
+
+	var installments []*models.Installment
+	for rows.Next() {
+		installment := &models.Installment{}
+		var paidAt sql.NullTime
+		if err := rows.Scan(
+			&installment.ID,
+			&installment.PlanID,
+			&installment.Sequence,
+			&installment.Amount,
+			&installment.DueAt,
+			&installment.Status,
+			&installment.PaymentReference,
+			&installment.Attempts,
+			&installment.NextAttemptAt,
+			&installment.LastError,
+			&paidAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan installment: %w", err)
+		}
+		if paidAt.Valid {
+			installment.PaidAt = &paidAt.Time
+		}
+		installments = append(installments, installment)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating installments: %w", err)
+	}
+
+	return installments, nil
+}
+
+// ClaimDueInstallments returns up to limit pending installments of active
+// plans due to be charged by now, pushing their next attempt back by lease
+// so concurrent runs don't charge them twice
+func (r *InstallmentRepository) ClaimDueInstallments(now time.Time, lease time.Duration, limit int) ([]*models.Installment, error) {
+	return r.listInstallments(`
+		UPDATE order_installments
+		SET next_attempt_at = $2
+		WHERE id IN (
+			SELECT i.id
+			FROM order_installments i
+			JOIN order_installment_plans p ON p.id = i.plan_id
+			WHERE i.status = 'pending' AND p.status = 'active' AND i.next_attempt_at <= $1
+			ORDER BY i.next_attempt_at
+			LIMIT $3
+			FOR UPDATE OF i SKIP LOCKED
+		)
+		RETURNING `+installmentColumns, now, now.Add(lease), limit)
+}
+
+// StartInstallmentAttempt records the reference an installment is about to
+// be charged with, so a charge whose outcome is lost can be checked rather
+// than taken again
+func (r *InstallmentRepository) StartInstallmentAttempt(id int, reference string) error {
+	_, err := r.db.Exec(`
+		UPDATE order_installments
+		SET payment_reference = $2, attempts = attempts + 1
+		WHERE id = $1 AND status = 'pending'`, id, reference)
+	if err != nil {
+		return fmt.Errorf("failed to start installment attempt: %w", err)
+	}
+	return nil
+}
+
+// MarkInstallmentPaid records that an installment's latest charge succeeded
+func (r *InstallmentRepository) MarkInstallmentPaid(id int) error {
+	_, err := r.db.Exec(`
+		UPDATE order_installments
+		SET status = 'paid', paid_at = NOW(), last_error = ''
+		WHERE id = $1 AND status = 'pending'`, id)
+	if err != nil {
+		return fmt.Errorf("failed to mark installment paid: %w", err)
+	}
+	return nil
+}
+
+// RecordInstallmentFailure records why an installment's latest charge
+// failed and when it is tried again, or fails it for good if retryAt is nil
+func (r *InstallmentRepository) RecordInstallmentFailure(id int, message string, retryAt *time.Time) error {
+	var err error
+	if retryAt != nil {
+		_, err = r.db.Exec(`
+			UPDATE order_installments
+			SET last_error = $2, next_attempt_at = $3
+			WHERE id = $1 AND status = 'pending'`, id, message, *retryAt)
+	} else {
+		_, err = r.db.Exec(`
+			UPDATE order_installments
+			SET status = 'failed', last_error = $2
+			WHERE id = $1 AND status = 'pending'`, id, message)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to record installment failure: %w", err)
+	}
+	return nil
+}
+
+// CompletePlan marks an active plan paid in full
+func (r *InstallmentRepository) CompletePlan(planID int) error {
+	_, err := r.db.Exec(`
+		UPDATE order_installment_plans
+		SET status = 'completed', completed_at = NOW()
+		WHERE id = $1 AND status = 'active'`, planID)
+	if err != nil {
+		return fmt.Errorf("failed to complete installment plan: %w", err)
+	}
+	return nil
+}
+
+// IssuePlanTickets completes a pending order whose plan has been paid in
+// full and creates its tickets. The tickets were taken out of inventory when
+// the plan was created, so they aren't sold again.
+func (r *InstallmentRepository) IssuePlanTickets(orderID int, ticketData []struct {
+	TicketTypeID int
+	QRCode       string
+}) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		UPDATE orders
+		SET status = $2, updated_at = NOW()
+		WHERE id = $1 AND status = $3`,
+		orderID, models.OrderCompleted, models.OrderPending)
+	if err != nil {
+		return fmt.Errorf("failed to update order status: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("order %d is not pending", orderID)
+	}
+
+	for _, ticket := range ticketData {
+		if _, err := tx.Exec(`
+			INSERT INTO tickets (order_id, ticket_type_id, qr_code, status, created_at)
+			VALUES ($1, $2, $3, $4, NOW())`,
+			orderID, ticket.TicketTypeID, ticket.QRCode, models.TicketActive); err != nil {
+			return fmt.Errorf("failed to create ticket: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit ticket issuance: %w", err)
+	}
+
+	return nil
+}
+
+// DefaultPlan marks an active plan whose installment couldn't be charged as
+// defaulted and cancels its order: the tickets go back on sale, and any
+// issued up front are voided
+func (r *InstallmentRepository) DefaultPlan(plan *models.InstallmentPlan) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	result, err := tx.Exec(`
+		UPDATE order_installment_plans
+		SET status = 'defaulted'
+		WHERE id = $1 AND status = 'active'`, plan.ID)
+	if err != nil {
+		return fmt.Errorf("failed to default installment plan: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("installment plan %d is not active", plan.ID)
+	}
+
+	if _, err := tx.Exec(`
+		UPDATE orders
+		SET status = $2, updated_at = NOW()
+		WHERE id = $1`, plan.OrderID, models.OrderCancelled); err != nil {
+		return fmt.Errorf("failed to cancel order: %w", err)
+	}
+
+	if _, err := tx.Exec(`
+		UPDATE tickets
+		SET status = $2
+		WHERE order_id = $1 AND status = $3`, plan.OrderID, models.TicketRefunded, models.TicketActive); err != nil {
+		return fmt.Errorf("failed to void tickets: %w", err)
+	}
+
+	for _, item := range plan.Items {
+		if _, err := tx.Exec(`
+			UPDATE ticket_types
+			SET sold = GREATEST(sold - $2, 0)
+			WHERE id = $1`, item.TicketTypeID, item.Quantity); err != nil {
+			return fmt.Errorf("failed to return tickets to sale: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit plan default: %w", err)
+	}
+
+	return nil
+}
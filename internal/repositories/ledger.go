@@ -0,0 +1,311 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// ledgerLockClass namespaces the advisory locks serializing ledger syncs
+const ledgerLockClass = 4090
+
+// LedgerRepository handles organizers' ledgers
+type LedgerRepository struct {
+	db *sql.DB
+}
+
+// NewLedgerRepository creates a new ledger repository
+func NewLedgerRepository(db *sql.DB) *LedgerRepository {
+	return &LedgerRepository{db: db}
+}
+
+// Sync posts whatever moved the organizer's money since their ledger was last
+// synced: sales, order changes, refunds, disputes and withdrawals. Each is
+// posted once, so an organizer's first sync backfills their history. It
+// returns how many transactions were posted.
+func (r *LedgerRepository) Sync(organizerID int) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Syncs of the same organizer wait for each other, so postings that
+	// reverse earlier ones see them
+	if _, err := tx.Exec(`SELECT pg_advisory_xact_lock($1, $2)`, ledgerLockClass, organizerID); err != nil {
+		return 0, fmt.Errorf("failed to lock ledger: %w", err)
+	}
+
+	sources, err := r.pendingSources(tx, organizerID)
+	if err != nil {
+		return 0, err
+	}
+
+	posted := 0
+	for _, source := range sources {
+		prior, err := r.priorBalances(tx, source)
+		if err != nil {
+			return 0, err
+		}
+
+		transaction := models.NewLedgerTransaction(source, prior)
+		if err := transaction.Validate(); err != nil {
+			return 0, fmt.Errorf("failed to post %s %d: %w", source.Kind, source.SourceID, err)
+		}
+
+		inserted, err := r.insertTransaction(tx, transaction)
+		if err != nil {
+			return 0, err
+		}
+		if inserted {
+			posted++
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit ledger postings: %w", err)
+	}
+
+	return posted, nil
+}
+
+// pendingSources returns what the organizer's ledger is missing, in the order
+// it happened. Sales are posted without the order changes made since, which
+// are posted on their own. Box office sales were paid to the organizer at the
+// door rather than collected by the platform, so they aren't posted.
+func (r *LedgerRepository) pendingSources(tx *sql.Tx, organizerID int) ([]*models.LedgerSource, error) {
+	query := fmt.Sprintf(`
+		WITH organizer_orders AS (
+			SELECT o.id, o.order_number, o.total_amount, o.status, o.created_at, o.updated_at
+			FROM orders o
+			JOIN events e ON e.id = o.event_id
+			WHERE e.organizer_id = $1 AND %s
+		)
+		SELECT s.kind, s.source_id, s.order_id, s.amount, s.reference, s.occurred_at
+		FROM (
+			SELECT 'sale' AS kind, o.id AS source_id, o.id AS order_id,
+				(o.total_amount - COALESCE((SELECT SUM(a.price_delta) FROM order_amendments a
+					WHERE a.order_id = o.id AND a.status IN ('processing', 'completed')), 0))::bigint AS amount,
+				o.order_number AS reference, o.created_at AS occurred_at, 1 AS step
+			FROM organizer_orders o
+			WHERE o.status IN ('completed', 'refunded', 'disputed')
+			UNION ALL
+			SELECT 'amendment', a.id, o.id, a.price_delta::bigint, o.order_number, a.completed_at, 2
+			FROM order_amendments a
+			JOIN organizer_orders o ON o.id = a.order_id
+			WHERE a.status = 'completed' AND a.price_delta <> 0 AND a.completed_at IS NOT NULL
+			UNION ALL
+			SELECT 'dispute_hold', d.id, o.id, 0, o.order_number, d.created_at, 3
+			FROM payment_disputes d
+			JOIN organizer_orders o ON o.id = d.order_id
+			UNION ALL
+			SELECT CASE WHEN d.status = 'won' THEN 'dispute_release' ELSE 'chargeback' END, d.id, o.id, 0, o.order_number, d.resolved_at, 4
+			FROM payment_disputes d
+			JOIN organizer_orders o ON o.id = d.order_id
+			WHERE d.status IN ('won', 'lost') AND d.resolved_at IS NOT NULL
+			UNION ALL
+			SELECT 'refund', o.id, o.id, 0, o.order_number, o.updated_at, 5
+			FROM organizer_orders o
+			WHERE o.status = 'refunded'
+			UNION ALL
+			SELECT 'payout', w.id, 0, ROUND(w.amount * 100)::bigint, w.id::text, COALESCE(w.processed_at, w.requested_at), 6
+			FROM withdrawals w
+			WHERE w.organizer_id = $1 AND w.status IN ('approved', 'completed')
+			UNION ALL
+			SELECT 'payout_reversal', w.id, 0, ROUND(w.amount * 100)::bigint, w.id::text, w.updated_at, 7
+			FROM withdrawals w
+			WHERE w.organizer_id = $1 AND w.status = 'rejected'
+				AND EXISTS (SELECT 1 FROM ledger_transactions t WHERE t.kind = 'payout' AND t.source_id = w.id)
+		) s
+		WHERE NOT EXISTS (SELECT 1 FROM ledger_transactions t WHERE t.kind = s.kind AND t.source_id = s.source_id)
+		ORDER BY s.occurred_at, s.step, s.source_id`, models.OnlinePaymentCondition("o.payment_id"))
+
+	rows, err := tx.Query(query, organizerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unposted ledger sources: %w", err)
+	}
+	defer rows.Close()
+
+	var sources []*models.LedgerSource
+	for rows.Next() {
+		source := &models.LedgerSource{OrganizerID: organizerID}
+		if err := rows.Scan(&source.Kind, &source.SourceID, &source.OrderID, &source.Amount, &source.Reference, &source.OccurredAt); err != nil {
+			return nil, fmt.Errorf("failed to scan ledger source: %w", err)
+		}
+		sources = append(sources, source)
+	}
+
+	return sources, rows.Err()
+}
+
+// priorBalances returns the postings a source reverses or moves: the
+// order's for refunds, chargebacks and dispute holds, and the hold's for
+// won disputes
+func (r *LedgerRepository) priorBalances(tx *sql.Tx, source *models.LedgerSource) (models.LedgerBalances, error) {
+	var rows *sql.Rows
+	var err error
+	switch source.Kind {
+	case models.LedgerRefund, models.LedgerChargeback, models.LedgerDisputeHold:
+		rows, err = tx.Query(`
+			SELECT e.account, SUM(e.amount)
+			FROM ledger_entries e
+			JOIN ledger_transactions t ON t.id = e.transaction_id
+			WHERE t.order_id = $1
+			GROUP BY e.account`, source.OrderID)
+	case models.LedgerDisputeRelease:
+		rows, err = tx.Query(`
+			SELECT e.account, SUM(e.amount)
+			FROM ledger_entries e
+			JOIN ledger_transactions t ON t.id = e.transaction_id
+			WHERE t.kind = $1 AND t.source_id = $2
+			GROUP BY e.account`, models.LedgerDisputeHold, source.SourceID)
+	default:
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to query prior ledger postings: %w", err)
+	}
+
+	return scanLedgerBalances(rows)
+}
+
+// insertTransaction posts a transaction and its entries. It returns false if
+// its source was already posted.
+func (r *LedgerRepository) insertTransaction(tx *sql.Tx, transaction *models.LedgerTransaction) (bool, error) {
+	orderID := sql.NullInt64{Int64: int64(transaction.OrderID), Valid: transaction.OrderID != 0}
+	err := tx.QueryRow(`
+		INSERT INTO ledger_transactions (organizer_id, kind, source_id, order_id, description, occurred_at)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		ON CONFLICT (kind, source_id) DO NOTHING
+		RETURNING id, created_at`,
+		transaction.OrganizerID, transaction.Kind, transaction.SourceID, orderID, transaction.Description, transaction.OccurredAt,
+	).Scan(&transaction.ID, &transaction.CreatedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to insert ledger transaction: %w", err)
+	}
+
+	for i := range transaction.Entries {
+		entry := &transaction.Entries[i]
+		entry.TransactionID = transaction.ID
+		err := tx.QueryRow(`
+			INSERT INTO ledger_entries (transaction_id, account, amount)
+			VALUES ($1, $2, $3)
+			RETURNING id`,
+			entry.TransactionID, entry.Account, entry.Amount,
+		).Scan(&entry.ID)
+		if err != nil {
+			return false, fmt.Errorf("failed to insert ledger entry: %w", err)
+		}
+	}
+
+	return true, nil
+}
+
+// GetBalances returns the balance of each account of the organizer's ledger
+func (r *LedgerRepository) GetBalances(organizerID int) (models.LedgerBalances, error) {
+	rows, err := r.db.Query(`
+		SELECT e.account, SUM(e.amount)
+		FROM ledger_entries e
+		JOIN ledger_transactions t ON t.id = e.transaction_id
+		WHERE t.organizer_id = $1
+		GROUP BY e.account`, organizerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ledger balances: %w", err)
+	}
+
+	return scanLedgerBalances(rows)
+}
+
+// scanLedgerBalances reads account balances and closes the rows
+func scanLedgerBalances(rows *sql.Rows) (models.LedgerBalances, error) {
+	defer rows.Close()
+
+	balances := make(models.LedgerBalances)
+	for rows.Next() {
+		var account models.LedgerAccount
+		var balance int64
+		if err := rows.Scan(&account, &balance); err != nil {
+			return nil, fmt.Errorf("failed to scan ledger balance: %w", err)
+		}
+		balances[account] = balance
+	}
+
+	return balances, rows.Err()
+}
+
+// GetTransactions returns a page of the organizer's ledger, newest first,
+// with their balance after each transaction, and how many transactions it
+// has in total
+func (r *LedgerRepository) GetTransactions(organizerID, limit, offset int) ([]*models.LedgerTransaction, int, error) {
+	var total int
+	if err := r.db.QueryRow(`SELECT COUNT(*) FROM ledger_transactions WHERE organizer_id = $1`, organizerID).Scan(&total); err != nil {
+		return nil, 0, fmt.Errorf("failed to count ledger transactions: %w", err)
+	}
+
+	query := `
+		SELECT id, kind, source_id, order_id, description, occurred_at, created_at,
+			SUM(change) OVER (ORDER BY occurred_at, id) AS balance_after
+		FROM (
+			SELECT t.id, t.kind, t.source_id, COALESCE(t.order_id, 0) AS order_id, t.description, t.occurred_at, t.created_at,
+				COALESCE((SELECT SUM(e.amount) FROM ledger_entries e
+					WHERE e.transaction_id = t.id AND e.account = $2), 0) AS change
+			FROM ledger_transactions t
+			WHERE t.organizer_id = $1
+		) t
+		ORDER BY occurred_at DESC, id DESC
+		LIMIT $3 OFFSET $4`
+
+	rows, err := r.db.Query(query, organizerID, models.LedgerOrganizer, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query ledger transactions: %w", err)
+	}
+	defer rows.Close()
+
+	var transactions []*models.LedgerTransaction
+	byID := make(map[int]*models.LedgerTransaction)
+	var ids []int64
+	for rows.Next() {
+		transaction := &models.LedgerTransaction{OrganizerID: organizerID}
+		if err := rows.Scan(&transaction.ID, &transaction.Kind, &transaction.SourceID, &transaction.OrderID, &transaction.Description,
+			&transaction.OccurredAt, &transaction.CreatedAt, &transaction.BalanceAfter); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan ledger transaction: %w", err)
+		}
+		transactions = append(transactions, transaction)
+		byID[transaction.ID] = transaction
+		ids = append(ids, int64(transaction.ID))
+	}
+	if err := rows.Err(); err != nil {
+		return nil, 0, err
+	}
+	if len(ids) == 0 {
+		return transactions, total, nil
+	}
+
+	entryRows, err := r.db.Query(`
+		SELECT id, transaction_id, account, amount
+		FROM ledger_entries
+		WHERE transaction_id = ANY($1)
+		ORDER BY id`, pq.Array(ids))
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query ledger entries: %w", err)
+	}
+	defer entryRows.Close()
+
+	for entryRows.Next() {
+		var entry models.LedgerEntry
+		if err := entryRows.Scan(&entry.ID, &entry.TransactionID, &entry.Account, &entry.Amount); err != nil {
+			return nil, 0, fmt.Errorf("failed to scan ledger entry: %w", err)
+		}
+		if transaction, ok := byID[entry.TransactionID]; ok {
+			transaction.Entries = append(transaction.Entries, entry)
+		}
+	}
+
+	return transactions, total, entryRows.Err()
+}
@@ -0,0 +1,80 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+)
+
+// LocaleRepository handles the email language preferences of users and events
+type LocaleRepository struct {
+	db *sql.DB
+}
+
+// NewLocaleRepository creates a new locale repository
+func NewLocaleRepository(db *sql.DB) *LocaleRepository {
+	return &LocaleRepository{db: db}
+}
+
+// GetUserLocale returns a user's preferred language, empty if they have not chosen one
+func (r *LocaleRepository) GetUserLocale(userID int) (string, error) {
+	var locale string
+	err := r.db.QueryRow("SELECT locale FROM users WHERE id = $1", userID).Scan(&locale)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", fmt.Errorf("user with id %d not found", userID)
+		}
+		return "", fmt.Errorf("failed to get user locale: %w", err)
+	}
+	return locale, nil
+}
+
+// SetUserLocale sets a user's preferred language
+func (r *LocaleRepository) SetUserLocale(userID int, locale string) error {
+	result, err := r.db.Exec("UPDATE users SET locale = $1, updated_at = NOW() WHERE id = $2", locale, userID)
+	if err != nil {
+		return fmt.Errorf("failed to update user locale: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("user with id %d not found", userID)
+	}
+
+	return nil
+}
+
+// GetEventLocale returns the language an event's emails default to
+func (r *LocaleRepository) GetEventLocale(eventID int) (string, error) {
+	var locale string
+	err := r.db.QueryRow("SELECT locale FROM events WHERE id = $1", eventID).Scan(&locale)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return "", fmt.Errorf("event with id %d not found", eventID)
+		}
+		return "", fmt.Errorf("failed to get event locale: %w", err)
+	}
+	return locale, nil
+}
+
+// SetEventLocale sets the language an event's emails default to
+func (r *LocaleRepository) SetEventLocale(eventID int, locale string) error {
+	result, err := r.db.Exec("UPDATE events SET locale = $1, updated_at = NOW() WHERE id = $2", locale, eventID)
+	if err != nil {
+		return fmt.Errorf("failed to update event locale: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("event with id %d not found", eventID)
+	}
+
+	return nil
+}
@@ -0,0 +1,259 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// NotificationRepository handles notification data operations
+type NotificationRepository struct {
+	db *sql.DB
+}
+
+// NewNotificationRepository creates a new notification repository
+func NewNotificationRepository(db *sql.DB) *NotificationRepository {
+	return &NotificationRepository{db: db}
+}
+
+// Create stores a new in-app notification
+func (r *NotificationRepository) Create(notification *models.Notification) error {
+	query := `
+		INSERT INTO notifications (user_id, event_id, type, title, message, link)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at`
+
+	err := r.db.QueryRow(query,
+		notification.UserID,
+		notification.EventID,
+		notification.Type,
+		notification.Title,
+		notification.Message,
+		notification.Link,
+	).Scan(&notification.ID, &notification.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create notification: %w", err)
+	}
+
+	return nil
+}
+
+// GetByUser retrieves a user's notifications, newest first
+func (r *NotificationRepository) GetByUser(userID int, limit, offset int) ([]*models.Notification, int, error) {
+	var totalCount int
+	err := r.db.QueryRow("SELECT COUNT(*) FROM notifications WHERE user_id = $1", userID).Scan(&totalCount)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get notification count: %w", err)
+	}
+
+	query := `
+		SELECT id, user_id, event_id, type, title, message, link, read_at, created_at
+		FROM notifications
+		WHERE user_id = $1
+		ORDER BY created_at DESC, id DESC
+		LIMIT $2 OFFSET $3`
+
+	rows, err := r.db.Query(query, userID, limit, offset)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to query notifications: %w", err)
+	}
+	defer rows.Close()
+
+	var notifications []*models.Notification
+	for rows.Next() {
+		notification := &models.Notification{}
+		var eventID sql.NullInt64
+		var readAt sql.NullTime
+
+		err := rows.Scan(
+			&notification.ID,
+			&notification.UserID,
+			&eventID,
+			&notification.Type,
+			&notification.Title,
+			&notification.Message,
+			&notification.Link,
+			&readAt,
+			&notification.CreatedAt,
+		)
+		if err != nil {
+			return nil, 0, fmt.Errorf("failed to scan notification: %w", err)
+		}
+
+		if eventID.Valid {
+			id := int(eventID.Int64)
+			notification.EventID = &id
+		}
+		if readAt.Valid {
+			notification.ReadAt = &readAt.Time
+		}
+
+		notifications = append(notifications, notification)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, 0, fmt.Errorf("error iterating notifications: %w", err)
+	}
+
+	return notifications, totalCount, nil
+}
+
+// CountUnread returns the number of unread notifications for a user
+func (r *NotificationRepository) CountUnread(userID int) (int, error) {
+	var count int
+	err := r.db.QueryRow("SELECT COUNT(*) FROM notifications WHERE user_id = $1 AND read_at IS NULL", userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+	return count, nil
+}
+
+// MarkRead marks a single notification owned by the user as read
+func (r *NotificationRepository) MarkRead(id, userID int) error {
+	result, err := r.db.Exec(`
+		UPDATE notifications SET read_at = NOW()
+		WHERE id = $1 AND user_id = $2 AND read_at IS NULL`, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to mark notification as read: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		var exists bool
+		err := r.db.QueryRow("SELECT EXISTS(SELECT 1 FROM notifications WHERE id = $1 AND user_id = $2)", id, userID).Scan(&exists)
+		if err != nil {
+			return fmt.Errorf("failed to check notification: %w", err)
+		}
+		if !exists {
+			return fmt.Errorf("notification not found")
+		}
+	}
+
+	return nil
+}
+
+// MarkAllRead marks all of a user's notifications as read
+func (r *NotificationRepository) MarkAllRead(userID int) error {
+	_, err := r.db.Exec("UPDATE notifications SET read_at = NOW() WHERE user_id = $1 AND read_at IS NULL", userID)
+	if err != nil {
+		return fmt.Errorf("failed to mark notifications as read: %w", err)
+	}
+	return nil
+}
+
+// GetPreferences retrieves a user's notification preferences, falling back to the defaults
+func (r *NotificationRepository) GetPreferences(userID int) (*models.NotificationPreferences, error) {
+	query := `
+		SELECT user_id, email_enabled, in_app_enabled, first_sale, half_sold, sold_out, sale_ending, updated_at
+		FROM notification_preferences
+		WHERE user_id = $1`
+
+	prefs := &models.NotificationPreferences{}
+	err := r.db.QueryRow(query, userID).Scan(
+		&prefs.UserID,
+		&prefs.EmailEnabled,
+		&prefs.InAppEnabled,
+		&prefs.FirstSale,
+		&prefs.HalfSold,
+		&prefs.SoldOut,
+		&prefs.SaleEnding,
+		&prefs.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.DefaultNotificationPreferences(userID), nil
+		}
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	return prefs, nil
+}
+
+// SavePreferences creates or updates a user's notification preferences
+func (r *NotificationRepository) SavePreferences(prefs *models.NotificationPreferences) error {
+	query := `
+		INSERT INTO notification_preferences
+			(user_id, email_enabled, in_app_enabled, first_sale, half_sold, sold_out, sale_ending, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, NOW())
+		ON CONFLICT (user_id) DO UPDATE SET
+			email_enabled = EXCLUDED.email_enabled,
+			in_app_enabled = EXCLUDED.in_app_enabled,
+			first_sale = EXCLUDED.first_sale,
+			half_sold = EXCLUDED.half_sold,
+			sold_out = EXCLUDED.sold_out,
+			sale_ending = EXCLUDED.sale_ending,
+			updated_at = NOW()
+		RETURNING updated_at`
+
+	err := r.db.QueryRow(query,
+		prefs.UserID,
+		prefs.EmailEnabled,
+		prefs.InAppEnabled,
+		prefs.FirstSale,
+		prefs.HalfSold,
+		prefs.SoldOut,
+		prefs.SaleEnding,
+	).Scan(&prefs.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save notification preferences: %w", err)
+	}
+
+	return nil
+}
+
+// RecordMilestone marks a sales milestone as reached for an event. It returns
+// false if the milestone had already been recorded.
+func (r *NotificationRepository) RecordMilestone(eventID int, milestone models.NotificationType) (bool, error) {
+	result, err := r.db.Exec(`
+		INSERT INTO event_milestones (event_id, milestone)
+		VALUES ($1, $2)
+		ON CONFLICT (event_id, milestone) DO NOTHING`, eventID, milestone)
+	if err != nil {
+		return false, fmt.Errorf("failed to record milestone: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// GetEventsWithSalesEndingBetween returns published events whose last ticket
+// sale window closes within [from, to)
+func (r *NotificationRepository) GetEventsWithSalesEndingBetween(from, to time.Time) ([]int, error) {
+	query := `
+		SELECT tt.event_id
+		FROM ticket_types tt
+		JOIN events e ON e.id = tt.event_id
+		WHERE e.status = 'published'
+		GROUP BY tt.event_id
+		HAVING MAX(tt.sale_end) >= $1 AND MAX(tt.sale_end) < $2`
+
+	rows, err := r.db.Query(query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query events with sales ending: %w", err)
+	}
+	defer rows.Close()
+
+	var eventIDs []int
+	for rows.Next() {
+		var eventID int
+		if err := rows.Scan(&eventID); err != nil {
+			return nil, fmt.Errorf("failed to scan event ID: %w", err)
+		}
+		eventIDs = append(eventIDs, eventID)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating events: %w", err)
+	}
+
+	return eventIDs, nil
+}
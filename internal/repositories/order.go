@@ -72,9 +72,9 @@ func (r *OrderRepository) Create(req *models.OrderCreateRequest) (*models.Order,
 	}
 
 	query := `
-		INSERT INTO orders (user_id, event_id, order_number, total_amount, status, billing_email, billing_name, created_at, updated_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-		RETURNING id, user_id, event_id, order_number, total_amount, status, payment_id, billing_email, billing_name, created_at, updated_at`
+		INSERT INTO orders (user_id, event_id, order_number, total_amount, status, payment_id, billing_email, billing_name, locale, arrival_slot_id, donation_amount, tax, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14)
+		RETURNING id, user_id, event_id, order_number, total_amount, status, payment_id, billing_email, billing_name, locale, arrival_slot_id, donation_amount, tax, created_at, updated_at`
 
 	now := time.Now()
 	order := &models.Order{}
@@ -86,8 +86,13 @@ func (r *OrderRepository) Create(req *models.OrderCreateRequest) (*models.Order,
 		orderNumber,
 		req.TotalAmount,
 		req.Status,
+		req.PaymentID,
 		req.BillingEmail,
 		req.BillingName,
+		req.Locale,
+		req.ArrivalSlotID,
+		req.DonationAmount,
+		req.Tax,
 		now,
 		now,
 	).Scan(
@@ -100,6 +105,10 @@ func (r *OrderRepository) Create(req *models.OrderCreateRequest) (*models.Order,
 		&order.PaymentID,
 		&order.BillingEmail,
 		&order.BillingName,
+		&order.Locale,
+		order.ArrivalSlotID,
+		&order.DonationAmount,
+		&order.Tax,
 		&order.CreatedAt,
 		&order.UpdatedAt,
 	)
@@ -108,6 +117,17 @@ func (r *OrderRepository) Create(req *models.OrderCreateRequest) (*models.Order,
 		return nil, fmt.Errorf("failed to create order: %w", err)
 	}
 
+	for _, price := range req.ItemPrices {
+		_, err = tx.Exec(`
+			INSERT INTO order_item_prices (order_id, ticket_type_id, unit_price)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (order_id, ticket_type_id) DO UPDATE SET unit_price = EXCLUDED.unit_price`,
+			order.ID, price.TicketTypeID, price.UnitPrice)
+		if err != nil {
+			return nil, fmt.Errorf("failed to record order item prices: %w", err)
+		}
+	}
+
 	if err = tx.Commit(); err != nil {
 		return nil, fmt.Errorf("failed to commit order creation: %w", err)
 	}
@@ -118,7 +138,7 @@ func (r *OrderRepository) Create(req *models.OrderCreateRequest) (*models.Order,
 // GetByID retrieves an order by ID
 func (r *OrderRepository) GetByID(id int) (*models.Order, error) {
 	query := `
-		SELECT id, user_id, event_id, order_number, total_amount, status, payment_id, billing_email, billing_name, created_at, updated_at
+		SELECT id, user_id, event_id, order_number, total_amount, status, payment_id, billing_email, billing_name, locale, arrival_slot_id, donation_amount, tax, created_at, updated_at
 		FROM orders
 		WHERE id = $1`
 
@@ -133,6 +153,10 @@ func (r *OrderRepository) GetByID(id int) (*models.Order, error) {
 		&order.PaymentID,
 		&order.BillingEmail,
 		&order.BillingName,
+		&order.Locale,
+		order.ArrivalSlotID,
+		&order.DonationAmount,
+		&order.Tax,
 		&order.CreatedAt,
 		&order.UpdatedAt,
 	)
@@ -150,7 +174,7 @@ func (r *OrderRepository) GetByID(id int) (*models.Order, error) {
 // GetByOrderNumber retrieves an order by order number
 func (r *OrderRepository) GetByOrderNumber(orderNumber string) (*models.Order, error) {
 	query := `
-		SELECT id, user_id, event_id, order_number, total_amount, status, payment_id, billing_email, billing_name, created_at, updated_at
+		SELECT id, user_id, event_id, order_number, total_amount, status, payment_id, billing_email, billing_name, locale, arrival_slot_id, donation_amount, tax, created_at, updated_at
 		FROM orders
 		WHERE order_number = $1`
 
@@ -165,6 +189,10 @@ func (r *OrderRepository) GetByOrderNumber(orderNumber string) (*models.Order, e
 		&order.PaymentID,
 		&order.BillingEmail,
 		&order.BillingName,
+		&order.Locale,
+		order.ArrivalSlotID,
+		&order.DonationAmount,
+		&order.Tax,
 		&order.CreatedAt,
 		&order.UpdatedAt,
 	)
@@ -189,7 +217,7 @@ func (r *OrderRepository) Update(id int, req *models.OrderUpdateRequest) (*model
 		UPDATE orders
 		SET status = $2, payment_id = $3, updated_at = $4
 		WHERE id = $1
-		RETURNING id, user_id, event_id, order_number, total_amount, status, payment_id, billing_email, billing_name, created_at, updated_at`
+		RETURNING id, user_id, event_id, order_number, total_amount, status, payment_id, billing_email, billing_name, locale, arrival_slot_id, donation_amount, tax, created_at, updated_at`
 
 	order := &models.Order{}
 	err := r.db.QueryRow(
@@ -208,6 +236,10 @@ func (r *OrderRepository) Update(id int, req *models.OrderUpdateRequest) (*model
 		&order.PaymentID,
 		&order.BillingEmail,
 		&order.BillingName,
+		&order.Locale,
+		order.ArrivalSlotID,
+		&order.DonationAmount,
+		&order.Tax,
 		&order.CreatedAt,
 		&order.UpdatedAt,
 	)
@@ -424,7 +456,7 @@ func (r *OrderRepository) Search(filters OrderSearchFilters) ([]*models.Order, i
 
 	// Get orders
 	query := fmt.Sprintf(`
-		SELECT id, user_id, event_id, order_number, total_amount, status, payment_id, billing_email, billing_name, created_at, updated_at
+		SELECT id, user_id, event_id, order_number, total_amount, status, payment_id, billing_email, billing_name, locale, arrival_slot_id, donation_amount, tax, created_at, updated_at
 		FROM orders
 		%s
 		%s
@@ -452,6 +484,10 @@ func (r *OrderRepository) Search(filters OrderSearchFilters) ([]*models.Order, i
 			&order.PaymentID,
 			&order.BillingEmail,
 			&order.BillingName,
+			&order.Locale,
+			order.ArrivalSlotID,
+			&order.DonationAmount,
+			&order.Tax,
 			&order.CreatedAt,
 			&order.UpdatedAt,
 		)
@@ -549,7 +585,7 @@ func (r *OrderRepository) GetOrdersWithDetails(filters OrderSearchFilters) ([]*O
 	query := fmt.Sprintf(`
 		SELECT 
 			o.id, o.user_id, o.event_id, o.order_number, o.total_amount, o.status, 
-			o.payment_id, o.billing_email, o.billing_name, o.created_at, o.updated_at,
+			o.payment_id, o.billing_email, o.billing_name, o.locale, o.arrival_slot_id, o.donation_amount, o.tax, o.created_at, o.updated_at,
 			e.title as event_title, e.start_date as event_date,
 			COUNT(t.id) as ticket_count
 		FROM orders o
@@ -584,6 +620,10 @@ func (r *OrderRepository) GetOrdersWithDetails(filters OrderSearchFilters) ([]*O
 			&orderDetail.Order.PaymentID,
 			&orderDetail.Order.BillingEmail,
 			&orderDetail.Order.BillingName,
+			&orderDetail.Order.Locale,
+			orderDetail.Order.ArrivalSlotID,
+			&orderDetail.Order.DonationAmount,
+			&orderDetail.Order.Tax,
 			&orderDetail.Order.CreatedAt,
 			&orderDetail.Order.UpdatedAt,
 			&orderDetail.EventTitle,
@@ -608,7 +648,7 @@ func (r *OrderRepository) GetExpiredOrders(expirationDuration time.Duration) ([]
 	expirationTime := time.Now().Add(-expirationDuration)
 	
 	query := `
-		SELECT id, user_id, event_id, order_number, total_amount, status, payment_id, billing_email, billing_name, created_at, updated_at
+		SELECT id, user_id, event_id, order_number, total_amount, status, payment_id, billing_email, billing_name, locale, arrival_slot_id, donation_amount, tax, created_at, updated_at
 		FROM orders
 		WHERE status = $1 AND created_at < $2
 		ORDER BY created_at ASC`
@@ -632,6 +672,10 @@ func (r *OrderRepository) GetExpiredOrders(expirationDuration time.Duration) ([]
 			&order.PaymentID,
 			&order.BillingEmail,
 			&order.BillingName,
+			&order.Locale,
+			order.ArrivalSlotID,
+			&order.DonationAmount,
+			&order.Tax,
 			&order.CreatedAt,
 			&order.UpdatedAt,
 		)
@@ -661,16 +705,31 @@ func (r *OrderRepository) ProcessOrderCompletion(orderID int, paymentID string,
 	defer tx.Rollback()
 
 	// Update order status to completed
-	_, err = tx.Exec(`
+	var userID int
+	err = tx.QueryRow(`
 		UPDATE orders 
 		SET status = $2, payment_id = $3, updated_at = $4 
-		WHERE id = $1 AND status = $5`,
-		orderID, models.OrderCompleted, paymentID, time.Now(), models.OrderPending)
+		WHERE id = $1 AND status = $5
+		RETURNING user_id`,
+		orderID, models.OrderCompleted, paymentID, time.Now(), models.OrderPending).Scan(&userID)
 
 	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("order %d is not pending", orderID)
+		}
 		return fmt.Errorf("failed to update order status: %w", err)
 	}
 
+	// Take the tickets out of inventory, with the ticket types locked so
+	// concurrent orders can't sell more than there are
+	quantities := make(map[int]int)
+	for _, ticket := range ticketData {
+		quantities[ticket.TicketTypeID]++
+	}
+	if err := sellTickets(tx, userID, quantities); err != nil {
+		return err
+	}
+
 	// Create tickets
 	for _, ticket := range ticketData {
 		_, err = tx.Exec(`
@@ -779,4 +838,26 @@ func (r *OrderRepository) GetTotalRevenue() (float64, error) {
 		return 0, fmt.Errorf("failed to get total revenue: %w", err)
 	}
 	return revenue, nil
+}
+
+// GetItemPrices returns the price per ticket each ticket type of an order was
+// bought at, keyed by ticket type ID. Orders from before prices were recorded
+// have none.
+func (r *OrderRepository) GetItemPrices(orderID int) (map[int]int, error) {
+	rows, err := r.db.Query("SELECT ticket_type_id, unit_price FROM order_item_prices WHERE order_id = $1", orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order item prices: %w", err)
+	}
+	defer rows.Close()
+
+	prices := make(map[int]int)
+	for rows.Next() {
+		var ticketTypeID, unitPrice int
+		if err := rows.Scan(&ticketTypeID, &unitPrice); err != nil {
+			return nil, fmt.Errorf("failed to scan order item price: %w", err)
+		}
+		prices[ticketTypeID] = unitPrice
+	}
+
+	return prices, rows.Err()
 }
\ No newline at end of file
@@ -0,0 +1,276 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// ErrAmendmentNotPending is returned when an amendment has already been
+// applied or cancelled, such as by a concurrent confirmation
+var ErrAmendmentNotPending = errors.New("order amendment is not pending")
+
+// OrderAmendmentRepository handles buyers' changes to their orders
+type OrderAmendmentRepository struct {
+	db *sql.DB
+}
+
+// NewOrderAmendmentRepository creates a new order amendment repository
+func NewOrderAmendmentRepository(db *sql.DB) *OrderAmendmentRepository {
+	return &OrderAmendmentRepository{db: db}
+}
+
+const orderAmendmentColumns = `id, order_id, user_id, items, price_delta, status, payment_reference, created_at, completed_at`
+
+// CreateAmendment stores a pending amendment of an order, cancelling any
+// earlier one still pending so only the latest can be applied
+func (r *OrderAmendmentRepository) CreateAmendment(amendment *models.OrderAmendment) error {
+	items, err := json.Marshal(amendment.Items)
+	if err != nil {
+		return fmt.Errorf("failed to encode amendment items: %w", err)
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`
+		UPDATE order_amendments
+		SET status = $2
+		WHERE order_id = $1 AND status = $3`,
+		amendment.OrderID, models.OrderAmendmentCancelled, models.OrderAmendmentPending); err != nil {
+		return fmt.Errorf("failed to cancel pending amendments: %w", err)
+	}
+
+	err = tx.QueryRow(`
+		INSERT INTO order_amendments (order_id, user_id, items, price_delta, status, created_at)
+		VALUES ($1, $2, $3, $4, $5, NOW())
+		RETURNING id, created_at`,
+		amendment.OrderID,
+		amendment.UserID,
+		items,
+		amendment.PriceDelta,
+		models.OrderAmendmentPending,
+	).Scan(&amendment.ID, &amendment.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create order amendment: %w", err)
+	}
+	amendment.Status = models.OrderAmendmentPending
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit order amendment: %w", err)
+	}
+
+	return nil
+}
+
+// GetAmendment retrieves an order amendment, or nil if there is none
+func (r *OrderAmendmentRepository) GetAmendment(id int) (*models.OrderAmendment, error) {
+	return r.getAmendment(`SELECT `+orderAmendmentColumns+` FROM order_amendments WHERE id = $1`, id)
+}
+
+// GetAmendmentByPaymentReference retrieves the amendment whose difference a
+// payment is for, or nil if there is none
+func (r *OrderAmendmentRepository) GetAmendmentByPaymentReference(reference string) (*models.OrderAmendment, error) {
+	if reference == "" {
+		return nil, nil
+	}
+	return r.getAmendment(`SELECT `+orderAmendmentColumns+` FROM order_amendments WHERE payment_reference = $1`, reference)
+}
+
+// GetPendingAmendment retrieves an order's pending amendment, or nil if there is none
+func (r *OrderAmendmentRepository) GetPendingAmendment(orderID int) (*models.OrderAmendment, error) {
+	return r.getAmendment(`SELECT `+orderAmendmentColumns+` FROM order_amendments WHERE order_id = $1 AND status = 'pending'
+		ORDER BY created_at DESC LIMIT 1`, orderID)
+}
+
+func (r *OrderAmendmentRepository) getAmendment(query string, arg interface{}) (*models.OrderAmendment, error) {
+	amendment := &models.OrderAmendment{}
+	var items []byte
+	var completedAt sql.NullTime
+	err := r.db.QueryRow(query, arg).Scan(
+		&amendment.ID,
+		&amendment.OrderID,
+		&amendment.UserID,
+		&items,
+		&amendment.PriceDelta,
+		&amendment.Status,
+		&amendment.PaymentReference,
+		&amendment.CreatedAt,
+		&completedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get order amendment: %w", err)
+	}
+
+	if completedAt.Valid {
+		amendment.CompletedAt = &completedAt.Time
+	}
+	if err := json.Unmarshal(items, &amendment.Items); err != nil {
+		return nil, fmt.Errorf("failed to decode amendment items: %w", err)
+	}
+
+	return amendment, nil
+}
+
+// SetPaymentReference records the payment of a pending amendment's difference
+func (r *OrderAmendmentRepository) SetPaymentReference(id int, reference string) error {
+	_, err := r.db.Exec(`
+		UPDATE order_amendments
+		SET payment_reference = $2
+		WHERE id = $1 AND status = $3`,
+		id, reference, models.OrderAmendmentPending)
+	if err != nil {
+		return fmt.Errorf("failed to record amendment payment: %w", err)
+	}
+	return nil
+}
+
+// CancelAmendment cancels a pending amendment, returning
+// ErrAmendmentNotPending if it isn't pending anymore
+func (r *OrderAmendmentRepository) CancelAmendment(id int) error {
+	result, err := r.db.Exec(`
+		UPDATE order_amendments
+		SET status = $2
+		WHERE id = $1 AND status = $3`,
+		id, models.OrderAmendmentCancelled, models.OrderAmendmentPending)
+	if err != nil {
+		return fmt.Errorf("failed to cancel order amendment: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrAmendmentNotPending
+	}
+	return nil
+}
+
+// CompleteRefund completes a processing amendment once its difference has
+// been refunded
+func (r *OrderAmendmentRepository) CompleteRefund(id int, reference string) error {
+	result, err := r.db.Exec(`
+		UPDATE order_amendments
+		SET status = $2, payment_reference = $3, completed_at = NOW()
+		WHERE id = $1 AND status = $4`,
+		id, models.OrderAmendmentCompleted, reference, models.OrderAmendmentProcessing)
+	if err != nil {
+		return fmt.Errorf("failed to complete order amendment: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return fmt.Errorf("order amendment %d is not processing", id)
+	}
+	return nil
+}
+
+// ApplyAmendment claims a pending amendment and changes its order in one
+// transaction: the removed tickets are voided and go back on sale, the new
+// tickets are taken out of inventory and created, and the order's total and
+// item prices are updated. The amendment is completed, or processing if its
+// difference is still to be refunded. An amendment is only applied once;
+// ErrAmendmentNotPending is returned if it has already been claimed.
+func (r *OrderAmendmentRepository) ApplyAmendment(amendment *models.OrderAmendment, voidTicketIDs []int, ticketData []struct {
+	TicketTypeID int
+	QRCode       string
+}) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// Refunds are made once the tickets have changed, so the amendment waits
+	// for its refund as processing
+	status := models.OrderAmendmentCompleted
+	if amendment.Refund() > 0 {
+		status = models.OrderAmendmentProcessing
+	}
+	var completedAt *time.Time
+	if status == models.OrderAmendmentCompleted {
+		now := time.Now()
+		completedAt = &now
+	}
+	result, err := tx.Exec(`
+		UPDATE order_amendments
+		SET status = $2, payment_reference = $3, completed_at = $4
+		WHERE id = $1 AND status = $5`,
+		amendment.ID, status, amendment.PaymentReference, completedAt, models.OrderAmendmentPending)
+	if err != nil {
+		return fmt.Errorf("failed to claim order amendment: %w", err)
+	}
+	if rows, _ := result.RowsAffected(); rows == 0 {
+		return ErrAmendmentNotPending
+	}
+
+	// The removed tickets must still be unused
+	if len(voidTicketIDs) > 0 {
+		result, err = tx.Exec(`
+			UPDATE tickets
+			SET status = $3
+			WHERE id = ANY($1) AND order_id = $2 AND status = $4`,
+			pq.Array(voidTicketIDs), amendment.OrderID, models.TicketRefunded, models.TicketActive)
+		if err != nil {
+			return fmt.Errorf("failed to void tickets: %w", err)
+		}
+		if rows, _ := result.RowsAffected(); int(rows) != len(voidTicketIDs) {
+			return fmt.Errorf("tickets of order %d were used or changed in the meantime", amendment.OrderID)
+		}
+	}
+
+	sold := make(map[int]int)
+	for _, item := range amendment.Items {
+		if item.To < item.From {
+			if _, err := tx.Exec(`
+				UPDATE ticket_types
+				SET sold = GREATEST(sold - $2, 0)
+				WHERE id = $1`, item.TicketTypeID, item.From-item.To); err != nil {
+				return fmt.Errorf("failed to return tickets to sale: %w", err)
+			}
+		} else if item.To > item.From {
+			sold[item.TicketTypeID] = item.To - item.From
+		}
+
+		if _, err := tx.Exec(`
+			INSERT INTO order_item_prices (order_id, ticket_type_id, unit_price)
+			VALUES ($1, $2, $3)
+			ON CONFLICT (order_id, ticket_type_id) DO UPDATE SET unit_price = EXCLUDED.unit_price`,
+			amendment.OrderID, item.TicketTypeID, item.UnitPrice); err != nil {
+			return fmt.Errorf("failed to record item price: %w", err)
+		}
+	}
+
+	// Locks the ticket types, so the new tickets can't be sold to someone else
+	if err := sellTickets(tx, amendment.UserID, sold); err != nil {
+		return err
+	}
+
+	for _, ticket := range ticketData {
+		if _, err := tx.Exec(`
+			INSERT INTO tickets (order_id, ticket_type_id, qr_code, status, created_at)
+			VALUES ($1, $2, $3, $4, NOW())`,
+			amendment.OrderID, ticket.TicketTypeID, ticket.QRCode, models.TicketActive); err != nil {
+			return fmt.Errorf("failed to create ticket: %w", err)
+		}
+	}
+
+	if _, err := tx.Exec(`
+		UPDATE orders
+		SET total_amount = total_amount + $2, updated_at = NOW()
+		WHERE id = $1`, amendment.OrderID, amendment.PriceDelta); err != nil {
+		return fmt.Errorf("failed to update order total: %w", err)
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit order amendment: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,160 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// OrganizerMemberRepository handles organizers' team members and invitations
+type OrganizerMemberRepository struct {
+	db *sql.DB
+}
+
+// NewOrganizerMemberRepository creates a new organizer member repository
+func NewOrganizerMemberRepository(db *sql.DB) *OrganizerMemberRepository {
+	return &OrganizerMemberRepository{db: db}
+}
+
+const organizerMemberColumns = `m.id, m.organizer_id, m.user_id, m.email, m.can_manage_events, m.can_view_analytics,
+	m.can_check_in, COALESCE(m.invitation_token_hash, ''), m.invited_at, m.accepted_at`
+
+// Create saves an invitation to an organizer's team
+func (r *OrganizerMemberRepository) Create(member *models.OrganizerMember) error {
+	err := r.db.QueryRow(`
+		INSERT INTO organizer_members (organizer_id, email, can_manage_events, can_view_analytics, can_check_in, invitation_token_hash)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, invited_at`,
+		member.OrganizerID, member.Email, member.CanManageEvents, member.CanViewAnalytics, member.CanCheckIn, member.InvitationHash,
+	).Scan(&member.ID, &member.InvitedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create team invitation: %w", err)
+	}
+	return nil
+}
+
+// GetByOrganizer retrieves an organizer's team, oldest first, with the names
+// of members who have joined
+func (r *OrganizerMemberRepository) GetByOrganizer(organizerID int) ([]*models.OrganizerMember, error) {
+	return r.query(`
+		SELECT `+organizerMemberColumns+`, COALESCE(TRIM(u.first_name || ' ' || u.last_name), ''), ''
+		FROM organizer_members m
+		LEFT JOIN users u ON u.id = m.user_id
+		WHERE m.organizer_id = $1
+		ORDER BY m.invited_at, m.id`, organizerID)
+}
+
+// GetByUser retrieves the teams a user has joined, with the organizers' names
+func (r *OrganizerMemberRepository) GetByUser(userID int) ([]*models.OrganizerMember, error) {
+	return r.query(`
+		SELECT `+organizerMemberColumns+`, '',
+			COALESCE(NULLIF(p.display_name, ''), TRIM(o.first_name || ' ' || o.last_name))
+		FROM organizer_members m
+		JOIN users o ON o.id = m.organizer_id
+		LEFT JOIN organizer_profiles p ON p.user_id = m.organizer_id
+		WHERE m.user_id = $1 AND m.accepted_at IS NOT NULL
+		ORDER BY m.accepted_at, m.id`, userID)
+}
+
+// GetByInvitationHash retrieves an open invitation by the hash of its token,
+// with the organizer's name
+func (r *OrganizerMemberRepository) GetByInvitationHash(hash string) (*models.OrganizerMember, error) {
+	members, err := r.query(`
+		SELECT `+organizerMemberColumns+`, '',
+			COALESCE(NULLIF(p.display_name, ''), TRIM(o.first_name || ' ' || o.last_name))
+		FROM organizer_members m
+		JOIN users o ON o.id = m.organizer_id
+		LEFT JOIN organizer_profiles p ON p.user_id = m.organizer_id
+		WHERE m.invitation_token_hash = $1 AND m.accepted_at IS NULL`, hash)
+	if err != nil {
+		return nil, err
+	}
+	if len(members) == 0 {
+		return nil, fmt.Errorf("team invitation not found")
+	}
+	return members[0], nil
+}
+
+// GetMembership retrieves the user's membership of an organizer's team, or
+// nil if they have not joined it
+func (r *OrganizerMemberRepository) GetMembership(organizerID, userID int) (*models.OrganizerMember, error) {
+	members, err := r.query(`
+		SELECT `+organizerMemberColumns+`, '', ''
+		FROM organizer_members m
+		WHERE m.organizer_id = $1 AND m.user_id = $2 AND m.accepted_at IS NOT NULL`, organizerID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if len(members) == 0 {
+		return nil, nil
+	}
+	return members[0], nil
+}
+
+// Accept joins the user to the team and retires the invitation token
+func (r *OrganizerMemberRepository) Accept(id, userID int) error {
+	result, err := r.db.Exec(`
+		UPDATE organizer_members
+		SET user_id = $2, accepted_at = CURRENT_TIMESTAMP, invitation_token_hash = NULL
+		WHERE id = $1 AND accepted_at IS NULL`, id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to accept team invitation: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("team invitation not found or already accepted")
+	}
+	return nil
+}
+
+// Delete removes a member or invitation from an organizer's team
+func (r *OrganizerMemberRepository) Delete(id, organizerID int) error {
+	result, err := r.db.Exec("DELETE FROM organizer_members WHERE id = $1 AND organizer_id = $2", id, organizerID)
+	if err != nil {
+		return fmt.Errorf("failed to remove team member: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("team member not found")
+	}
+	return nil
+}
+
+// query runs a query selecting organizerMemberColumns followed by the
+// member's and organizer's names
+func (r *OrganizerMemberRepository) query(query string, args ...interface{}) ([]*models.OrganizerMember, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query team members: %w", err)
+	}
+	defer rows.Close()
+
+	var members []*models.OrganizerMember
+	for rows.Next() {
+		member := &models.OrganizerMember{}
+		var userID sql.NullInt64
+		if err := rows.Scan(&member.ID, &member.OrganizerID, &userID, &member.Email, &member.CanManageEvents,
+			&member.CanViewAnalytics, &member.CanCheckIn, &member.InvitationHash, &member.InvitedAt,
+			&member.AcceptedAt, &member.MemberName, &member.OrganizerName); err != nil {
+			return nil, fmt.Errorf("failed to scan team member: %w", err)
+		}
+		if userID.Valid {
+			id := int(userID.Int64)
+			member.UserID = &id
+		}
+		members = append(members, member)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating team members: %w", err)
+	}
+
+	return members, nil
+}
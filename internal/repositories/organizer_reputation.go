@@ -0,0 +1,67 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// OrganizerReputationRepository handles the data organizer reputation is
+// computed from
+type OrganizerReputationRepository struct {
+	db *sql.DB
+}
+
+// NewOrganizerReputationRepository creates a new organizer reputation repository
+func NewOrganizerReputationRepository(db *sql.DB) *OrganizerReputationRepository {
+	return &OrganizerReputationRepository{db: db}
+}
+
+// GetHistory returns an organizer's track record across all their events
+func (r *OrganizerReputationRepository) GetHistory(organizerID int) (*models.OrganizerHistory, error) {
+	query := `
+		SELECT
+			(SELECT COUNT(*) FROM events WHERE organizer_id = $1 AND status = 'published'),
+			(SELECT COUNT(*) FROM events WHERE organizer_id = $1 AND status = 'rejected'),
+			(SELECT COUNT(*) FROM orders o JOIN events e ON e.id = o.event_id
+				WHERE e.organizer_id = $1 AND o.status = 'completed'),
+			(SELECT COUNT(*) FROM orders o JOIN events e ON e.id = o.event_id
+				WHERE e.organizer_id = $1 AND o.status = 'refunded'),
+			(SELECT COUNT(*) FROM event_reports er JOIN events e ON e.id = er.event_id
+				WHERE e.organizer_id = $1)`
+
+	history := &models.OrganizerHistory{OrganizerID: organizerID}
+	err := r.db.QueryRow(query, organizerID).Scan(
+		&history.ApprovedEvents,
+		&history.RejectedEvents,
+		&history.CompletedOrders,
+		&history.RefundedOrders,
+		&history.Reports,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organizer history: %w", err)
+	}
+
+	return history, nil
+}
+
+// CreateReport stores an event report. It returns false if the reporter has
+// already reported the event.
+func (r *OrganizerReputationRepository) CreateReport(report *models.EventReport) (bool, error) {
+	query := `
+		INSERT INTO event_reports (event_id, reporter_id, reason, details)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (event_id, reporter_id) DO NOTHING
+		RETURNING id, created_at`
+
+	err := r.db.QueryRow(query, report.EventID, report.ReporterID, report.Reason, report.Details).Scan(&report.ID, &report.CreatedAt)
+	if err == sql.ErrNoRows {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to create event report: %w", err)
+	}
+
+	return true, nil
+}
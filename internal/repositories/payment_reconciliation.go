@@ -0,0 +1,265 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// PaymentReconciliationRepository handles the orders payments are reconciled
+// against and the mismatches found between them
+type PaymentReconciliationRepository struct {
+	db *sql.DB
+}
+
+// NewPaymentReconciliationRepository creates a new payment reconciliation repository
+func NewPaymentReconciliationRepository(db *sql.DB) *PaymentReconciliationRepository {
+	return &PaymentReconciliationRepository{db: db}
+}
+
+const reconciliationIssueColumns = `id, kind, payment_reference, order_id, provider_status, provider_amount, currency,
+	customer_email, order_status, order_amount, detected_at, last_seen_at, resolved_at, resolved_by,
+	COALESCE(resolution, ''), resolution_note`
+
+func scanReconciliationIssue(scanner interface{ Scan(...interface{}) error }) (*models.ReconciliationIssue, error) {
+	issue := &models.ReconciliationIssue{}
+	var orderID, resolvedBy sql.NullInt64
+	var resolvedAt sql.NullTime
+	err := scanner.Scan(
+		&issue.ID,
+		&issue.Kind,
+		&issue.PaymentReference,
+		&orderID,
+		&issue.ProviderStatus,
+		&issue.ProviderAmount,
+		&issue.Currency,
+		&issue.CustomerEmail,
+		&issue.OrderStatus,
+		&issue.OrderAmount,
+		&issue.DetectedAt,
+		&issue.LastSeenAt,
+		&resolvedAt,
+		&resolvedBy,
+		&issue.Resolution,
+		&issue.ResolutionNote,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if orderID.Valid {
+		id := int(orderID.Int64)
+		issue.OrderID = &id
+	}
+	if resolvedAt.Valid {
+		issue.ResolvedAt = &resolvedAt.Time
+	}
+	if resolvedBy.Valid {
+		id := int(resolvedBy.Int64)
+		issue.ResolvedBy = &id
+	}
+
+	return issue, nil
+}
+
+// FindOrdersByPaymentIDs returns the orders paid with each of the references,
+// keyed by reference. References no order was made for are left out. An
+// installment's reference is matched to its order with the installment's
+// amount, completed once the installment has been paid.
+func (r *PaymentReconciliationRepository) FindOrdersByPaymentIDs(references []string) (map[string]*models.Order, error) {
+	orders := make(map[string]*models.Order)
+	if len(references) == 0 {
+		return orders, nil
+	}
+
+	query := `
+		SELECT DISTINCT ON (payment_id) id, user_id, event_id, order_number, total_amount, status, payment_id, created_at, updated_at
+		FROM orders
+		WHERE payment_id = ANY($1)
+		ORDER BY payment_id, created_at DESC`
+
+	rows, err := r.db.Query(query, pq.Array(references))
+	if err != nil {
+		return nil, fmt.Errorf("failed to find orders by payment reference: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		order := &models.Order{}
+		if err := rows.Scan(
+			&order.ID,
+			&order.UserID,
+			&order.EventID,
+			&order.OrderNumber,
+			&order.TotalAmount,
+			&order.Status,
+			&order.PaymentID,
+			&order.CreatedAt,
+			&order.UpdatedAt,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan order: %w", err)
+		}
+		orders[order.PaymentID] = order
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating orders: %w", err)
+	}
+
+	if err := r.findInstallmentOrders(references, orders); err != nil {
+		return nil, err
+	}
+
+	return orders, nil
+}
+
+// findInstallmentOrders adds the orders each installment reference paid
+// towards to orders, in place of the order the first installment's
+// reference was made with
+func (r *PaymentReconciliationRepository) findInstallmentOrders(references []string, orders map[string]*models.Order) error {
+	query := `
+		SELECT i.payment_reference, i.amount, i.status, o.id, o.user_id, o.event_id, o.order_number, o.created_at, o.updated_at
+		FROM order_installments i
+		JOIN order_installment_plans p ON p.id = i.plan_id
+		JOIN orders o ON o.id = p.order_id
+		WHERE i.payment_reference = ANY($1)`
+
+	rows, err := r.db.Query(query, pq.Array(references))
+	if err != nil {
+		return fmt.Errorf("failed to find installments by payment reference: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		order := &models.Order{Status: models.OrderPending}
+		var status models.InstallmentStatus
+		if err := rows.Scan(
+			&order.PaymentID,
+			&order.TotalAmount,
+			&status,
+			&order.ID,
+			&order.UserID,
+			&order.EventID,
+			&order.OrderNumber,
+			&order.CreatedAt,
+			&order.UpdatedAt,
+		); err != nil {
+			return fmt.Errorf("failed to scan installment order: %w", err)
+		}
+		if status == models.InstallmentPaid {
+			order.Status = models.OrderCompleted
+		}
+		orders[order.PaymentID] = order
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterating installment orders: %w", err)
+	}
+
+	return nil
+}
+
+// SaveIssue records a mismatch, or updates when it was last seen and what
+// the provider and order last said if it was already recorded. Issues an
+// admin resolved or ignored stay closed.
+func (r *PaymentReconciliationRepository) SaveIssue(issue *models.ReconciliationIssue) error {
+	query := `
+		INSERT INTO payment_reconciliation_issues (kind, payment_reference, order_id, provider_status, provider_amount,
+			currency, customer_email, order_status, order_amount, detected_at, last_seen_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, NOW(), NOW())
+		ON CONFLICT (kind, payment_reference) DO UPDATE SET
+			order_id = EXCLUDED.order_id,
+			provider_status = EXCLUDED.provider_status,
+			provider_amount = EXCLUDED.provider_amount,
+			currency = EXCLUDED.currency,
+			customer_email = EXCLUDED.customer_email,
+			order_status = EXCLUDED.order_status,
+			order_amount = EXCLUDED.order_amount,
+			last_seen_at = NOW(),
+			resolved_at = CASE WHEN payment_reconciliation_issues.resolution = 'cleared' THEN NULL ELSE payment_reconciliation_issues.resolved_at END,
+			resolution = CASE WHEN payment_reconciliation_issues.resolution = 'cleared' THEN NULL ELSE payment_reconciliation_issues.resolution END
+		RETURNING ` + reconciliationIssueColumns
+
+	saved, err := scanReconciliationIssue(r.db.QueryRow(query,
+		issue.Kind,
+		issue.PaymentReference,
+		issue.OrderID,
+		issue.ProviderStatus,
+		issue.ProviderAmount,
+		issue.Currency,
+		issue.CustomerEmail,
+		issue.OrderStatus,
+		issue.OrderAmount,
+	))
+	if err != nil {
+		return fmt.Errorf("failed to save reconciliation issue: %w", err)
+	}
+
+	*issue = *saved
+	return nil
+}
+
+// ListOpenIssues returns the issues still awaiting an admin, oldest first
+func (r *PaymentReconciliationRepository) ListOpenIssues() ([]*models.ReconciliationIssue, error) {
+	return r.listIssues(`
+		SELECT ` + reconciliationIssueColumns + `
+		FROM payment_reconciliation_issues
+		WHERE resolved_at IS NULL
+		ORDER BY detected_at, id`)
+}
+
+// ListResolvedIssues returns the most recently closed issues
+func (r *PaymentReconciliationRepository) ListResolvedIssues(limit int) ([]*models.ReconciliationIssue, error) {
+	return r.listIssues(`
+		SELECT `+reconciliationIssueColumns+`
+		FROM payment_reconciliation_issues
+		WHERE resolved_at IS NOT NULL
+		ORDER BY resolved_at DESC, id DESC
+		LIMIT $1`, limit)
+}
+
+func (r *PaymentReconciliationRepository) listIssues(query string, args ...interface{}) ([]*models.ReconciliationIssue, error) {
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list reconciliation issues: %w", err)
+	}
+	defer rows.Close()
+
+	var issues []*models.ReconciliationIssue
+	for rows.Next() {
+		issue, err := scanReconciliationIssue(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan reconciliation issue: %w", err)
+		}
+		issues = append(issues, issue)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating reconciliation issues: %w", err)
+	}
+
+	return issues, nil
+}
+
+// ResolveIssue closes an open issue. resolvedBy is nil for issues a run
+// cleared on its own.
+func (r *PaymentReconciliationRepository) ResolveIssue(id int, resolution models.ReconciliationResolution, resolvedBy *int, note string) (*models.ReconciliationIssue, error) {
+	query := `
+		UPDATE payment_reconciliation_issues
+		SET resolved_at = NOW(), resolution = $2, resolved_by = $3, resolution_note = $4
+		WHERE id = $1 AND resolved_at IS NULL
+		RETURNING ` + reconciliationIssueColumns
+
+	issue, err := scanReconciliationIssue(r.db.QueryRow(query, id, resolution, resolvedBy, note))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("reconciliation issue %d not found or already resolved", id)
+		}
+		return nil, fmt.Errorf("failed to resolve reconciliation issue: %w", err)
+	}
+
+	return issue, nil
+}
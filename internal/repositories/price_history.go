@@ -0,0 +1,181 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// PriceHistoryRepository handles ticket price history and the price alerts
+// sent to attendees who saved an event
+type PriceHistoryRepository struct {
+	db *sql.DB
+}
+
+// NewPriceHistoryRepository creates a new price history repository
+func NewPriceHistoryRepository(db *sql.DB) *PriceHistoryRepository {
+	return &PriceHistoryRepository{db: db}
+}
+
+// RecordPriceChange records a ticket type's new price. oldPrice is nil when
+// the ticket type was just created. Only price drops are left for the alert
+// job to announce.
+func (r *PriceHistoryRepository) RecordPriceChange(ticketType *models.TicketType, oldPrice *int) error {
+	query := `
+		INSERT INTO ticket_price_changes (ticket_type_id, event_id, old_price, new_price, notified_at)
+		VALUES ($1, $2, $3, $4, CASE WHEN $3::INTEGER IS NOT NULL AND $4 < $3::INTEGER THEN NULL ELSE NOW() END)`
+
+	var old sql.NullInt64
+	if oldPrice != nil {
+		old = sql.NullInt64{Int64: int64(*oldPrice), Valid: true}
+	}
+
+	if _, err := r.db.Exec(query, ticketType.ID, ticketType.EventID, old, ticketType.Price); err != nil {
+		return fmt.Errorf("failed to record price change: %w", err)
+	}
+	return nil
+}
+
+// GetByEvent returns the price changes of an event's ticket types, newest first
+func (r *PriceHistoryRepository) GetByEvent(eventID int) ([]*models.TicketPriceChange, error) {
+	query := `
+		SELECT c.id, c.ticket_type_id, c.event_id, t.name, c.old_price, c.new_price, c.changed_at
+		FROM ticket_price_changes c
+		JOIN ticket_types t ON t.id = c.ticket_type_id
+		WHERE c.event_id = $1
+		ORDER BY c.changed_at DESC, c.id DESC`
+
+	rows, err := r.db.Query(query, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query price changes: %w", err)
+	}
+	defer rows.Close()
+
+	var changes []*models.TicketPriceChange
+	for rows.Next() {
+		change := &models.TicketPriceChange{}
+		var oldPrice sql.NullInt64
+		if err := rows.Scan(&change.ID, &change.TicketTypeID, &change.EventID, &change.TicketTypeName, &oldPrice, &change.NewPrice, &change.ChangedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan price change: %w", err)
+		}
+		if oldPrice.Valid {
+			price := int(oldPrice.Int64)
+			change.OldPrice = &price
+		}
+		changes = append(changes, change)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating price changes: %w", err)
+	}
+
+	return changes, nil
+}
+
+// GetPendingDrops returns the price drops not announced yet, oldest first,
+// with the ticket type's current price
+func (r *PriceHistoryRepository) GetPendingDrops() ([]*models.PriceDrop, error) {
+	query := `
+		SELECT c.id, c.event_id, t.name, c.old_price, t.price, t.sale_start, t.sale_end
+		FROM ticket_price_changes c
+		JOIN ticket_types t ON t.id = c.ticket_type_id
+		WHERE c.notified_at IS NULL AND c.old_price IS NOT NULL
+		ORDER BY c.id`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query price drops: %w", err)
+	}
+	defer rows.Close()
+
+	var drops []*models.PriceDrop
+	for rows.Next() {
+		drop := &models.PriceDrop{}
+		if err := rows.Scan(&drop.ChangeID, &drop.EventID, &drop.TicketTypeName, &drop.OldPrice, &drop.CurrentPrice, &drop.SaleStart, &drop.SaleEnd); err != nil {
+			return nil, fmt.Errorf("failed to scan price drop: %w", err)
+		}
+		drops = append(drops, drop)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating price drops: %w", err)
+	}
+
+	return drops, nil
+}
+
+// ClaimPriceDrop marks a price drop as announced before its watchers are
+// emailed, so several instances running the job never announce it twice. It
+// returns false if the drop was already claimed.
+func (r *PriceHistoryRepository) ClaimPriceDrop(changeID int) (bool, error) {
+	result, err := r.db.Exec(`
+		UPDATE ticket_price_changes SET notified_at = NOW()
+		WHERE id = $1 AND notified_at IS NULL`, changeID)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim price drop: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected == 1, nil
+}
+
+// GetEndingTiers returns the published events' early-bird tiers that stop
+// selling between from and to and are followed by a more expensive tier,
+// skipping tiers already announced
+func (r *PriceHistoryRepository) GetEndingTiers(from, to time.Time) ([]*models.EndingTier, error) {
+	query := `
+		SELECT t.id, t.event_id, t.name, t.price, t.sale_end, n.name, n.price
+		FROM ticket_types t
+		JOIN ticket_types n ON n.id = t.next_tier_id
+		JOIN events e ON e.id = t.event_id
+		WHERE e.status = 'published' AND t.sale_end > $1 AND t.sale_end <= $2
+			AND t.sold < t.quantity AND n.price > t.price
+			AND NOT EXISTS (SELECT 1 FROM ticket_tier_ending_notices s WHERE s.ticket_type_id = t.id)
+		ORDER BY t.sale_end`
+
+	rows, err := r.db.Query(query, from, to)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ending tiers: %w", err)
+	}
+	defer rows.Close()
+
+	var tiers []*models.EndingTier
+	for rows.Next() {
+		tier := &models.EndingTier{}
+		if err := rows.Scan(&tier.TicketTypeID, &tier.EventID, &tier.Name, &tier.Price, &tier.SaleEnd, &tier.NextName, &tier.NextPrice); err != nil {
+			return nil, fmt.Errorf("failed to scan ending tier: %w", err)
+		}
+		tiers = append(tiers, tier)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating ending tiers: %w", err)
+	}
+
+	return tiers, nil
+}
+
+// ClaimTierEndingNotice records that a tier's end is being announced and
+// returns false if it already was
+func (r *PriceHistoryRepository) ClaimTierEndingNotice(ticketTypeID int) (bool, error) {
+	result, err := r.db.Exec(`
+		INSERT INTO ticket_tier_ending_notices (ticket_type_id)
+		VALUES ($1)
+		ON CONFLICT (ticket_type_id) DO NOTHING`, ticketTypeID)
+	if err != nil {
+		return false, fmt.Errorf("failed to record tier ending notice: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected == 1, nil
+}
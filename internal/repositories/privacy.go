@@ -0,0 +1,88 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// PrivacyRepository handles privacy preference data operations
+type PrivacyRepository struct {
+	db *sql.DB
+}
+
+// NewPrivacyRepository creates a new privacy repository
+func NewPrivacyRepository(db *sql.DB) *PrivacyRepository {
+	return &PrivacyRepository{db: db}
+}
+
+// GetByUserID returns the preferences a user saved most recently, on any
+// browser, or nil if they never chose
+func (r *PrivacyRepository) GetByUserID(userID int) (*models.PrivacyPreferences, error) {
+	query := `
+		SELECT id, consent_id, user_id, analytics, marketing, created_at, updated_at
+		FROM privacy_preferences
+		WHERE user_id = $1
+		ORDER BY updated_at DESC
+		LIMIT 1`
+
+	return r.scan(r.db.QueryRow(query, userID))
+}
+
+// GetByConsentID returns the preferences saved under a consent cookie, or
+// nil if there are none
+func (r *PrivacyRepository) GetByConsentID(consentID string) (*models.PrivacyPreferences, error) {
+	query := `
+		SELECT id, consent_id, user_id, analytics, marketing, created_at, updated_at
+		FROM privacy_preferences
+		WHERE consent_id = $1`
+
+	return r.scan(r.db.QueryRow(query, consentID))
+}
+
+// Save creates or updates the preferences of a consent cookie. A signed-in
+// user's ID is kept once set, so signing out doesn't orphan their choice.
+func (r *PrivacyRepository) Save(prefs *models.PrivacyPreferences) error {
+	query := `
+		INSERT INTO privacy_preferences (consent_id, user_id, analytics, marketing, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, NOW(), NOW())
+		ON CONFLICT (consent_id) DO UPDATE SET
+			user_id = COALESCE(EXCLUDED.user_id, privacy_preferences.user_id),
+			analytics = EXCLUDED.analytics,
+			marketing = EXCLUDED.marketing,
+			updated_at = NOW()
+		RETURNING id, user_id, created_at, updated_at`
+
+	var userID sql.NullInt64
+	err := r.db.QueryRow(query, prefs.ConsentID, prefs.UserID, prefs.Analytics, prefs.Marketing).Scan(
+		&prefs.ID, &userID, &prefs.CreatedAt, &prefs.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save privacy preferences: %w", err)
+	}
+
+	if userID.Valid {
+		id := int(userID.Int64)
+		prefs.UserID = &id
+	}
+	return nil
+}
+
+// scan scans a privacy preferences row, returning nil when there is none
+func (r *PrivacyRepository) scan(row *sql.Row) (*models.PrivacyPreferences, error) {
+	prefs := &models.PrivacyPreferences{}
+	var userID sql.NullInt64
+	err := row.Scan(&prefs.ID, &prefs.ConsentID, &userID, &prefs.Analytics, &prefs.Marketing, &prefs.CreatedAt, &prefs.UpdatedAt)
+	if err == sql.ErrNoRows {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get privacy preferences: %w", err)
+	}
+
+	if userID.Valid {
+		id := int(userID.Int64)
+		prefs.UserID = &id
+	}
+	return prefs, nil
+}
@@ -0,0 +1,129 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// PromoterRepository handles promoter links and the sales attributed to them
+type PromoterRepository struct {
+	db *sql.DB
+}
+
+// NewPromoterRepository creates a new promoter repository
+func NewPromoterRepository(db *sql.DB) *PromoterRepository {
+	return &PromoterRepository{db: db}
+}
+
+// Create adds a promoter link to an event
+func (r *PromoterRepository) Create(req *models.PromoterLinkCreateRequest) (*models.PromoterLink, error) {
+	query := `
+		INSERT INTO promoter_links (event_id, code, name, commission_rate)
+		VALUES ($1, $2, $3, $4)
+		RETURNING id, event_id, code, name, commission_rate, deactivated_at IS NULL, created_at,
+			(SELECT title FROM events WHERE id = $1), (SELECT COALESCE(slug, '') FROM events WHERE id = $1)`
+
+	link := &models.PromoterLink{}
+	err := r.db.QueryRow(query, req.EventID, req.Code, req.Name, req.CommissionRate).Scan(
+		&link.ID,
+		&link.EventID,
+		&link.Code,
+		&link.Name,
+		&link.CommissionRate,
+		&link.Active,
+		&link.CreatedAt,
+		&link.EventTitle,
+		&link.EventSlug,
+	)
+	if err != nil {
+		if strings.Contains(err.Error(), "duplicate key") {
+			return nil, fmt.Errorf("the event already has a link with the code %s", req.Code)
+		}
+		return nil, fmt.Errorf("failed to create promoter link: %w", err)
+	}
+
+	return link, nil
+}
+
+// GetStatsByOrganizer retrieves the promoter links of an organizer's events
+// with their completed sales, newest first. A sale counts towards a link when
+// its buyer last followed the link's code for the order's event, before the
+// link was deactivated.
+func (r *PromoterRepository) GetStatsByOrganizer(organizerID int) ([]*models.PromoterLinkStats, error) {
+	query := `
+		SELECT
+			pl.id, pl.event_id, pl.code, pl.name, pl.commission_rate, pl.deactivated_at IS NULL, pl.created_at,
+			e.title, COALESCE(e.slug, ''),
+			COUNT(o.id) as orders,
+			COALESCE(SUM(tc.ticket_count), 0) as tickets_sold,
+			COALESCE(SUM(o.total_amount), 0) as revenue
+		FROM promoter_links pl
+		JOIN events e ON e.id = pl.event_id
+		LEFT JOIN order_attributions a ON a.ref = pl.code
+		LEFT JOIN orders o ON o.id = a.order_id AND o.event_id = pl.event_id AND o.status = 'completed'
+			AND (pl.deactivated_at IS NULL OR o.created_at < pl.deactivated_at)
+		LEFT JOIN (
+			SELECT order_id, COUNT(*) as ticket_count
+			FROM tickets
+			GROUP BY order_id
+		) tc ON tc.order_id = o.id
+		WHERE e.organizer_id = $1
+		GROUP BY pl.id, e.title, e.slug
+		ORDER BY pl.created_at DESC, pl.id DESC`
+
+	rows, err := r.db.Query(query, organizerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get promoter links: %w", err)
+	}
+	defer rows.Close()
+
+	var links []*models.PromoterLinkStats
+	for rows.Next() {
+		stats := &models.PromoterLinkStats{Link: &models.PromoterLink{}}
+		var revenue int64
+		if err := rows.Scan(
+			&stats.Link.ID,
+			&stats.Link.EventID,
+			&stats.Link.Code,
+			&stats.Link.Name,
+			&stats.Link.CommissionRate,
+			&stats.Link.Active,
+			&stats.Link.CreatedAt,
+			&stats.Link.EventTitle,
+			&stats.Link.EventSlug,
+			&stats.Orders,
+			&stats.TicketsSold,
+			&revenue,
+		); err != nil {
+			return nil, fmt.Errorf("failed to scan promoter link: %w", err)
+		}
+		stats.Revenue = float64(revenue) / 100.0
+		links = append(links, stats)
+	}
+
+	return links, rows.Err()
+}
+
+// Deactivate stops a promoter link of one of an organizer's events earning
+// new sales
+func (r *PromoterRepository) Deactivate(id, organizerID int) error {
+	result, err := r.db.Exec(`
+		UPDATE promoter_links SET deactivated_at = CURRENT_TIMESTAMP
+		WHERE id = $1 AND deactivated_at IS NULL
+			AND event_id IN (SELECT id FROM events WHERE organizer_id = $2)`, id, organizerID)
+	if err != nil {
+		return fmt.Errorf("failed to deactivate promoter link: %w", err)
+	}
+
+	rows, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to deactivate promoter link: %w", err)
+	}
+	if rows == 0 {
+		return fmt.Errorf("promoter link not found")
+	}
+	return nil
+}
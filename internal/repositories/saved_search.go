@@ -0,0 +1,175 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// SavedSearchRepository handles attendees' saved searches
+type SavedSearchRepository struct {
+	db *sql.DB
+}
+
+// NewSavedSearchRepository creates a new saved search repository
+func NewSavedSearchRepository(db *sql.DB) *SavedSearchRepository {
+	return &SavedSearchRepository{db: db}
+}
+
+// Create saves a search for a user
+func (r *SavedSearchRepository) Create(search *models.SavedSearch) error {
+	err := r.db.QueryRow(`
+		INSERT INTO saved_searches (user_id, query, category_id, location)
+		VALUES ($1, $2, NULLIF($3, 0), $4)
+		RETURNING id, created_at`,
+		search.UserID, search.Query, search.CategoryID, search.Location,
+	).Scan(&search.ID, &search.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save search: %w", err)
+	}
+	return nil
+}
+
+// Delete removes one of a user's saved searches
+func (r *SavedSearchRepository) Delete(id, userID int) error {
+	if _, err := r.db.Exec("DELETE FROM saved_searches WHERE id = $1 AND user_id = $2", id, userID); err != nil {
+		return fmt.Errorf("failed to delete saved search: %w", err)
+	}
+	return nil
+}
+
+// CountByUser returns how many searches a user has saved
+func (r *SavedSearchRepository) CountByUser(userID int) (int, error) {
+	var count int
+	if err := r.db.QueryRow("SELECT COUNT(*) FROM saved_searches WHERE user_id = $1", userID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count saved searches: %w", err)
+	}
+	return count, nil
+}
+
+// GetByUser returns a user's saved searches, newest first
+func (r *SavedSearchRepository) GetByUser(userID int) ([]*models.SavedSearch, error) {
+	rows, err := r.db.Query(`
+		SELECT s.id, s.user_id, s.query, COALESCE(s.category_id, 0), s.location, s.created_at, COALESCE(c.name, '')
+		FROM saved_searches s
+		LEFT JOIN categories c ON c.id = s.category_id
+		WHERE s.user_id = $1
+		ORDER BY s.created_at DESC, s.id DESC`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query saved searches: %w", err)
+	}
+	defer rows.Close()
+
+	var searches []*models.SavedSearch
+	for rows.Next() {
+		search, err := scanSavedSearch(rows)
+		if err != nil {
+			return nil, err
+		}
+		searches = append(searches, search)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating saved searches: %w", err)
+	}
+
+	return searches, nil
+}
+
+// GetOwners returns every active user with saved searches, with their
+// searches and the language to email them in
+func (r *SavedSearchRepository) GetOwners() ([]*models.SavedSearchOwner, error) {
+	rows, err := r.db.Query(`
+		SELECT s.id, s.user_id, s.query, COALESCE(s.category_id, 0), s.location, s.created_at, COALESCE(c.name, ''),
+			u.email, TRIM(u.first_name || ' ' || u.last_name), COALESCE(u.locale, '')
+		FROM saved_searches s
+		JOIN users u ON u.id = s.user_id
+		LEFT JOIN categories c ON c.id = s.category_id
+		WHERE u.is_active = true
+		ORDER BY s.user_id, s.id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query saved search owners: %w", err)
+	}
+	defer rows.Close()
+
+	var owners []*models.SavedSearchOwner
+	for rows.Next() {
+		search := &models.SavedSearch{}
+		owner := &models.SavedSearchOwner{}
+		if err := rows.Scan(&search.ID, &search.UserID, &search.Query, &search.CategoryID, &search.Location, &search.CreatedAt, &search.CategoryName,
+			&owner.Email, &owner.Name, &owner.Locale); err != nil {
+			return nil, fmt.Errorf("failed to scan saved search owner: %w", err)
+		}
+
+		if len(owners) == 0 || owners[len(owners)-1].UserID != search.UserID {
+			owner.UserID = search.UserID
+			owners = append(owners, owner)
+		}
+		last := owners[len(owners)-1]
+		last.Searches = append(last.Searches, search)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating saved search owners: %w", err)
+	}
+
+	return owners, nil
+}
+
+// GetUnmatchedEvents returns the IDs of published events that have not been
+// matched against saved searches yet
+func (r *SavedSearchRepository) GetUnmatchedEvents() ([]int, error) {
+	rows, err := r.db.Query(`
+		SELECT e.id
+		FROM events e
+		LEFT JOIN saved_search_matched_events m ON m.event_id = e.id
+		WHERE e.status = $1 AND e.deleted_at IS NULL AND m.event_id IS NULL
+		ORDER BY e.id`, models.StatusPublished)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unmatched events: %w", err)
+	}
+	defer rows.Close()
+
+	var eventIDs []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan event ID: %w", err)
+		}
+		eventIDs = append(eventIDs, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating unmatched events: %w", err)
+	}
+
+	return eventIDs, nil
+}
+
+// ClaimEventMatch marks an event as matched against saved searches. It
+// returns false if it already had been, so each event is matched once.
+func (r *SavedSearchRepository) ClaimEventMatch(eventID int) (bool, error) {
+	result, err := r.db.Exec(`
+		INSERT INTO saved_search_matched_events (event_id)
+		VALUES ($1)
+		ON CONFLICT (event_id) DO NOTHING`, eventID)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim saved search match: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected == 1, nil
+}
+
+// scanSavedSearch scans a saved search with its category name
+func scanSavedSearch(rows *sql.Rows) (*models.SavedSearch, error) {
+	search := &models.SavedSearch{}
+	if err := rows.Scan(&search.ID, &search.UserID, &search.Query, &search.CategoryID, &search.Location, &search.CreatedAt, &search.CategoryName); err != nil {
+		return nil, fmt.Errorf("failed to scan saved search: %w", err)
+	}
+	return search, nil
+}
@@ -0,0 +1,146 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// SessionRepository handles the browser sessions kept by the session store.
+// Login sessions created by UserRepository.CreateSession share the table but
+// have no data, and are left alone except when a user's sessions are ended.
+type SessionRepository struct {
+	db *sql.DB
+}
+
+// NewSessionRepository creates a new session repository
+func NewSessionRepository(db *sql.DB) *SessionRepository {
+	return &SessionRepository{db: db}
+}
+
+// GetByID retrieves an unexpired session by its hashed ID
+func (r *SessionRepository) GetByID(id string) (*models.Session, error) {
+	session := &models.Session{}
+	err := r.db.QueryRow(`
+		SELECT id, user_id, data, ip_address, user_agent, expires_at, last_seen_at, created_at
+		FROM sessions
+		WHERE id = $1 AND data IS NOT NULL AND expires_at > CURRENT_TIMESTAMP`, id,
+	).Scan(&session.ID, &session.UserID, &session.Data, &session.IPAddress, &session.UserAgent, &session.ExpiresAt, &session.LastSeenAt, &session.CreatedAt)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, models.ErrSessionNotFound
+		}
+		return nil, fmt.Errorf("failed to get session: %w", err)
+	}
+	return session, nil
+}
+
+// Save creates or updates a session, marking it as just used
+func (r *SessionRepository) Save(session *models.Session) error {
+	err := r.db.QueryRow(`
+		INSERT INTO sessions (id, user_id, data, ip_address, user_agent, expires_at, last_seen_at, created_at)
+		VALUES ($1, $2, $3, $4, $5, $6, CURRENT_TIMESTAMP, CURRENT_TIMESTAMP)
+		ON CONFLICT (id) DO UPDATE SET
+			user_id = EXCLUDED.user_id,
+			data = EXCLUDED.data,
+			ip_address = EXCLUDED.ip_address,
+			user_agent = EXCLUDED.user_agent,
+			expires_at = EXCLUDED.expires_at,
+			last_seen_at = CURRENT_TIMESTAMP
+		RETURNING last_seen_at, created_at`,
+		session.ID, session.UserID, session.Data, session.IPAddress, session.UserAgent, session.ExpiresAt,
+	).Scan(&session.LastSeenAt, &session.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save session: %w", err)
+	}
+	return nil
+}
+
+// Touch records that a session was just used
+func (r *SessionRepository) Touch(id string) error {
+	if _, err := r.db.Exec("UPDATE sessions SET last_seen_at = CURRENT_TIMESTAMP WHERE id = $1", id); err != nil {
+		return fmt.Errorf("failed to touch session: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes a session
+func (r *SessionRepository) Delete(id string) error {
+	if _, err := r.db.Exec("DELETE FROM sessions WHERE id = $1", id); err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
+
+// GetActiveByUser retrieves a user's unexpired sessions, most recently used first
+func (r *SessionRepository) GetActiveByUser(userID int) ([]*models.Session, error) {
+	rows, err := r.db.Query(`
+		SELECT id, user_id, data, ip_address, user_agent, expires_at, last_seen_at, created_at
+		FROM sessions
+		WHERE user_id = $1 AND data IS NOT NULL AND expires_at > CURRENT_TIMESTAMP
+		ORDER BY last_seen_at DESC`, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+	defer rows.Close()
+
+	var sessions []*models.Session
+	for rows.Next() {
+		session := &models.Session{}
+		if err := rows.Scan(&session.ID, &session.UserID, &session.Data, &session.IPAddress, &session.UserAgent, &session.ExpiresAt, &session.LastSeenAt, &session.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan session: %w", err)
+		}
+		sessions = append(sessions, session)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating sessions: %w", err)
+	}
+	return sessions, nil
+}
+
+// DeleteUserSession deletes one of a user's sessions
+func (r *SessionRepository) DeleteUserSession(userID int, id string) error {
+	result, err := r.db.Exec("DELETE FROM sessions WHERE id = $1 AND user_id = $2 AND data IS NOT NULL", id, userID)
+	if err != nil {
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return models.ErrSessionNotFound
+	}
+	return nil
+}
+
+// DeleteUserSessionsExcept deletes all of a user's sessions, including login
+// sessions, other than those listed in keep. It returns how many session
+// store sessions were deleted.
+func (r *SessionRepository) DeleteUserSessionsExcept(userID int, keep []string) (int, error) {
+	var deleted int
+	err := r.db.QueryRow(`
+		WITH deleted AS (
+			DELETE FROM sessions
+			WHERE user_id = $1 AND NOT (id = ANY($2))
+			RETURNING data
+		)
+		SELECT COUNT(*) FROM deleted WHERE data IS NOT NULL`, userID, pq.Array(keep),
+	).Scan(&deleted)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete user sessions: %w", err)
+	}
+	return deleted, nil
+}
+
+// DeleteExpired deletes every expired session, returning how many were deleted
+func (r *SessionRepository) DeleteExpired() (int64, error) {
+	result, err := r.db.Exec("DELETE FROM sessions WHERE expires_at <= CURRENT_TIMESTAMP")
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired sessions: %w", err)
+	}
+	return result.RowsAffected()
+}
@@ -23,7 +23,9 @@ func (r *SettingsRepository) GetSettings() (*models.SystemSettings, error) {
 	query := `
 		SELECT id, platform_fee_percentage, min_withdrawal_amount, max_withdrawal_amount,
 		       withdrawal_processing_days, event_moderation_enabled, auto_approve_organizers,
-		       maintenance_mode, created_at, updated_at
+		       maintenance_mode, require_2fa_admins, require_2fa_organizers, reputation_fast_track_enabled,
+		       reputation_auto_publish_score, reputation_min_approved_events, max_tickets_per_order,
+		       email_reply_to, created_at, updated_at
 		FROM system_settings
 		ORDER BY id DESC
 		LIMIT 1`
@@ -38,6 +40,13 @@ func (r *SettingsRepository) GetSettings() (*models.SystemSettings, error) {
 		&settings.EventModerationEnabled,
 		&settings.AutoApproveOrganizers,
 		&settings.MaintenanceMode,
+		&settings.RequireTwoFactorAdmins,
+		&settings.RequireTwoFactorOrganizers,
+		&settings.ReputationFastTrack,
+		&settings.ReputationAutoPublishScore,
+		&settings.ReputationMinApprovedEvents,
+		&settings.MaxTicketsPerOrder,
+		&settings.EmailReplyTo,
 		&settings.CreatedAt,
 		&settings.UpdatedAt,
 	)
@@ -84,6 +93,27 @@ func (r *SettingsRepository) UpdateSettings(req *models.SettingsUpdateRequest) (
 	if req.MaintenanceMode != nil {
 		current.MaintenanceMode = *req.MaintenanceMode
 	}
+	if req.RequireTwoFactorAdmins != nil {
+		current.RequireTwoFactorAdmins = *req.RequireTwoFactorAdmins
+	}
+	if req.RequireTwoFactorOrganizers != nil {
+		current.RequireTwoFactorOrganizers = *req.RequireTwoFactorOrganizers
+	}
+	if req.ReputationFastTrack != nil {
+		current.ReputationFastTrack = *req.ReputationFastTrack
+	}
+	if req.ReputationAutoPublishScore != nil {
+		current.ReputationAutoPublishScore = *req.ReputationAutoPublishScore
+	}
+	if req.ReputationMinApprovedEvents != nil {
+		current.ReputationMinApprovedEvents = *req.ReputationMinApprovedEvents
+	}
+	if req.MaxTicketsPerOrder != nil {
+		current.MaxTicketsPerOrder = *req.MaxTicketsPerOrder
+	}
+	if req.EmailReplyTo != nil {
+		current.EmailReplyTo = *req.EmailReplyTo
+	}
 
 	current.UpdatedAt = time.Now()
 
@@ -92,8 +122,10 @@ func (r *SettingsRepository) UpdateSettings(req *models.SettingsUpdateRequest) (
 		INSERT INTO system_settings (
 			platform_fee_percentage, min_withdrawal_amount, max_withdrawal_amount,
 			withdrawal_processing_days, event_moderation_enabled, auto_approve_organizers,
-			maintenance_mode, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+			maintenance_mode, require_2fa_admins, require_2fa_organizers, reputation_fast_track_enabled,
+			reputation_auto_publish_score, reputation_min_approved_events, max_tickets_per_order, email_reply_to,
+			created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
 		RETURNING id`
 
 	err = r.db.QueryRow(query,
@@ -104,6 +136,13 @@ func (r *SettingsRepository) UpdateSettings(req *models.SettingsUpdateRequest) (
 		current.EventModerationEnabled,
 		current.AutoApproveOrganizers,
 		current.MaintenanceMode,
+		current.RequireTwoFactorAdmins,
+		current.RequireTwoFactorOrganizers,
+		current.ReputationFastTrack,
+		current.ReputationAutoPublishScore,
+		current.ReputationMinApprovedEvents,
+		current.MaxTicketsPerOrder,
+		current.EmailReplyTo,
 		current.CreatedAt,
 		current.UpdatedAt,
 	).Scan(&current.ID)
@@ -129,8 +168,10 @@ func (r *SettingsRepository) InitializeDefaultSettings() error {
 		INSERT INTO system_settings (
 			platform_fee_percentage, min_withdrawal_amount, max_withdrawal_amount,
 			withdrawal_processing_days, event_moderation_enabled, auto_approve_organizers,
-			maintenance_mode, created_at, updated_at
-		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`
+			maintenance_mode, require_2fa_admins, require_2fa_organizers, reputation_fast_track_enabled,
+			reputation_auto_publish_score, reputation_min_approved_events, max_tickets_per_order, email_reply_to,
+			created_at, updated_at
+		) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`
 
 	_, err = r.db.Exec(query,
 		defaults.PlatformFeePercentage,
@@ -140,6 +181,13 @@ func (r *SettingsRepository) InitializeDefaultSettings() error {
 		defaults.EventModerationEnabled,
 		defaults.AutoApproveOrganizers,
 		defaults.MaintenanceMode,
+		defaults.RequireTwoFactorAdmins,
+		defaults.RequireTwoFactorOrganizers,
+		defaults.ReputationFastTrack,
+		defaults.ReputationAutoPublishScore,
+		defaults.ReputationMinApprovedEvents,
+		defaults.MaxTicketsPerOrder,
+		defaults.EmailReplyTo,
 		defaults.CreatedAt,
 		defaults.UpdatedAt,
 	)
@@ -148,5 +196,61 @@ func (r *SettingsRepository) InitializeDefaultSettings() error {
 		return fmt.Errorf("failed to initialize default settings: %w", err)
 	}
 
+	return nil
+}
+
+// GetFeatureFlagOverrides returns the feature flags admins have switched on or off
+func (r *SettingsRepository) GetFeatureFlagOverrides() ([]*models.FeatureFlagOverride, error) {
+	query := `
+		SELECT flag, enabled, updated_by, updated_at
+		FROM feature_flags
+		ORDER BY flag`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get feature flags: %w", err)
+	}
+	defer rows.Close()
+
+	var overrides []*models.FeatureFlagOverride
+	for rows.Next() {
+		override := &models.FeatureFlagOverride{}
+		var updatedBy sql.NullInt64
+		if err := rows.Scan(&override.Flag, &override.Enabled, &updatedBy, &override.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan feature flag: %w", err)
+		}
+		if updatedBy.Valid {
+			id := int(updatedBy.Int64)
+			override.UpdatedBy = &id
+		}
+		overrides = append(overrides, override)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("failed to iterate feature flags: %w", err)
+	}
+
+	return overrides, nil
+}
+
+// SetFeatureFlag switches a feature on or off on behalf of an admin
+func (r *SettingsRepository) SetFeatureFlag(flag models.FeatureFlag, enabled bool, adminID int) error {
+	query := `
+		INSERT INTO feature_flags (flag, enabled, updated_by, updated_at)
+		VALUES ($1, $2, $3, $4)
+		ON CONFLICT (flag) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			updated_by = EXCLUDED.updated_by,
+			updated_at = EXCLUDED.updated_at`
+
+	var updatedBy interface{}
+	if adminID > 0 {
+		updatedBy = adminID
+	}
+
+	if _, err := r.db.Exec(query, flag, enabled, updatedBy, time.Now()); err != nil {
+		return fmt.Errorf("failed to set feature flag: %w", err)
+	}
+
 	return nil
 }
\ No newline at end of file
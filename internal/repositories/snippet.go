@@ -0,0 +1,70 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// SnippetRepository handles content snippet data operations
+type SnippetRepository struct {
+	db *sql.DB
+}
+
+// NewSnippetRepository creates a new content snippet repository
+func NewSnippetRepository(db *sql.DB) *SnippetRepository {
+	return &SnippetRepository{db: db}
+}
+
+// GetAll returns every snippet an admin has saved
+func (r *SnippetRepository) GetAll() ([]*models.ContentSnippet, error) {
+	rows, err := r.db.Query(`SELECT key, content, updated_by, updated_at FROM content_snippets ORDER BY key`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query content snippets: %w", err)
+	}
+	defer rows.Close()
+
+	var snippets []*models.ContentSnippet
+	for rows.Next() {
+		snippet := &models.ContentSnippet{}
+		var updatedBy sql.NullInt64
+		if err := rows.Scan(&snippet.Key, &snippet.Content, &updatedBy, &snippet.UpdatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan content snippet: %w", err)
+		}
+		if updatedBy.Valid {
+			id := int(updatedBy.Int64)
+			snippet.UpdatedBy = &id
+		}
+		snippets = append(snippets, snippet)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating content snippets: %w", err)
+	}
+
+	return snippets, nil
+}
+
+// Upsert saves a snippet's content
+func (r *SnippetRepository) Upsert(req *models.SnippetUpdateRequest) error {
+	query := `
+		INSERT INTO content_snippets (key, content, updated_by, updated_at)
+		VALUES ($1, $2, $3, NOW())
+		ON CONFLICT (key) DO UPDATE
+		SET content = EXCLUDED.content, updated_by = EXCLUDED.updated_by, updated_at = NOW()`
+
+	if _, err := r.db.Exec(query, req.Key, req.Content, req.UpdatedBy); err != nil {
+		return fmt.Errorf("failed to save content snippet: %w", err)
+	}
+
+	return nil
+}
+
+// Delete removes a saved snippet so its default is used again
+func (r *SnippetRepository) Delete(key models.SnippetKey) error {
+	if _, err := r.db.Exec("DELETE FROM content_snippets WHERE key = $1", key); err != nil {
+		return fmt.Errorf("failed to delete content snippet: %w", err)
+	}
+	return nil
+}
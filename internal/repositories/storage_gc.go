@@ -0,0 +1,133 @@
+package repositories
+
+import (
+	"database/sql"
+	"encoding/json"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// StorageGCRepository handles storage garbage collection data operations
+type StorageGCRepository struct {
+	db *sql.DB
+}
+
+// NewStorageGCRepository creates a new storage garbage collection repository
+func NewStorageGCRepository(db *sql.DB) *StorageGCRepository {
+	return &StorageGCRepository{db: db}
+}
+
+// GetImageReferences returns every image key and image URL stored on events
+func (r *StorageGCRepository) GetImageReferences() ([]string, error) {
+	query := `
+		SELECT image_key FROM events WHERE image_key IS NOT NULL AND image_key != ''
+		UNION
+		SELECT image_url FROM events WHERE image_url IS NOT NULL AND image_url != ''`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query image references: %w", err)
+	}
+	defer rows.Close()
+
+	var references []string
+	for rows.Next() {
+		var reference string
+		if err := rows.Scan(&reference); err != nil {
+			return nil, fmt.Errorf("failed to scan image reference: %w", err)
+		}
+		references = append(references, reference)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating image references: %w", err)
+	}
+
+	return references, nil
+}
+
+// CreateRun records a storage garbage collection run
+func (r *StorageGCRepository) CreateRun(run *models.StorageGCRun) error {
+	errorsJSON, err := json.Marshal(run.Errors)
+	if err != nil {
+		return fmt.Errorf("failed to encode errors: %w", err)
+	}
+
+	query := `
+		INSERT INTO storage_gc_runs (mode, scanned_objects, orphaned_objects, skipped_recent,
+		                             quarantined_objects, deleted_objects, reclaimed_bytes, errors,
+		                             started_at, completed_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id`
+
+	err = r.db.QueryRow(query,
+		run.Mode,
+		run.ScannedObjects,
+		run.OrphanedObjects,
+		run.SkippedRecent,
+		run.QuarantinedObjects,
+		run.DeletedObjects,
+		run.ReclaimedBytes,
+		errorsJSON,
+		run.StartedAt,
+		run.CompletedAt,
+	).Scan(&run.ID)
+	if err != nil {
+		return fmt.Errorf("failed to create storage GC run: %w", err)
+	}
+
+	return nil
+}
+
+// GetLatestRun returns the most recent run, or nil if none have been recorded
+func (r *StorageGCRepository) GetLatestRun() (*models.StorageGCRun, error) {
+	query := `
+		SELECT id, mode, scanned_objects, orphaned_objects, skipped_recent, quarantined_objects,
+		       deleted_objects, reclaimed_bytes, errors, started_at, completed_at
+		FROM storage_gc_runs
+		ORDER BY completed_at DESC
+		LIMIT 1`
+
+	run := &models.StorageGCRun{}
+	var errorsJSON []byte
+	err := r.db.QueryRow(query).Scan(
+		&run.ID,
+		&run.Mode,
+		&run.ScannedObjects,
+		&run.OrphanedObjects,
+		&run.SkippedRecent,
+		&run.QuarantinedObjects,
+		&run.DeletedObjects,
+		&run.ReclaimedBytes,
+		&errorsJSON,
+		&run.StartedAt,
+		&run.CompletedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get latest storage GC run: %w", err)
+	}
+
+	if err := json.Unmarshal(errorsJSON, &run.Errors); err != nil {
+		return nil, fmt.Errorf("failed to decode errors: %w", err)
+	}
+
+	return run, nil
+}
+
+// GetTotals returns the bytes reclaimed and objects deleted across all runs
+func (r *StorageGCRepository) GetTotals() (int64, int, error) {
+	var reclaimedBytes int64
+	var deletedObjects int
+	err := r.db.QueryRow(`
+		SELECT COALESCE(SUM(reclaimed_bytes), 0), COALESCE(SUM(deleted_objects), 0)
+		FROM storage_gc_runs`).Scan(&reclaimedBytes, &deletedObjects)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get storage GC totals: %w", err)
+	}
+
+	return reclaimedBytes, deletedObjects, nil
+}
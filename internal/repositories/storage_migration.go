@@ -0,0 +1,160 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// StorageMigrationRepository handles the data operations of the local-to-R2 storage migration
+type StorageMigrationRepository struct {
+	db *sql.DB
+}
+
+// NewStorageMigrationRepository creates a new storage migration repository
+func NewStorageMigrationRepository(db *sql.DB) *StorageMigrationRepository {
+	return &StorageMigrationRepository{db: db}
+}
+
+// GetItem returns the migration record for a key, or nil if it hasn't been migrated
+func (r *StorageMigrationRepository) GetItem(key string) (*models.StorageMigrationItem, error) {
+	query := `
+		SELECT key, size, checksum, destination_url, status, error, migrated_at
+		FROM storage_migration_items
+		WHERE key = $1`
+
+	item := &models.StorageMigrationItem{}
+	err := r.db.QueryRow(query, key).Scan(
+		&item.Key,
+		&item.Size,
+		&item.Checksum,
+		&item.DestinationURL,
+		&item.Status,
+		&item.Error,
+		&item.MigratedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get storage migration item: %w", err)
+	}
+
+	return item, nil
+}
+
+// SaveItem records the outcome of migrating a key, replacing any earlier attempt
+func (r *StorageMigrationRepository) SaveItem(item *models.StorageMigrationItem) error {
+	query := `
+		INSERT INTO storage_migration_items (key, size, checksum, destination_url, status, error, migrated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, CURRENT_TIMESTAMP)
+		ON CONFLICT (key) DO UPDATE SET
+			size = EXCLUDED.size,
+			checksum = EXCLUDED.checksum,
+			destination_url = EXCLUDED.destination_url,
+			status = EXCLUDED.status,
+			error = EXCLUDED.error,
+			migrated_at = EXCLUDED.migrated_at
+		RETURNING migrated_at`
+
+	err := r.db.QueryRow(query, item.Key, item.Size, item.Checksum, item.DestinationURL, item.Status, item.Error).Scan(&item.MigratedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save storage migration item: %w", err)
+	}
+
+	return nil
+}
+
+// GetVerifiedKeys returns every key whose copy has been verified
+func (r *StorageMigrationRepository) GetVerifiedKeys() ([]string, error) {
+	rows, err := r.db.Query(`SELECT key FROM storage_migration_items WHERE status = $1`, models.StorageMigrationVerified)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query verified keys: %w", err)
+	}
+	defer rows.Close()
+
+	var keys []string
+	for rows.Next() {
+		var key string
+		if err := rows.Scan(&key); err != nil {
+			return nil, fmt.Errorf("failed to scan verified key: %w", err)
+		}
+		keys = append(keys, key)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating verified keys: %w", err)
+	}
+
+	return keys, nil
+}
+
+// GetEventImages returns the image of every event that has one
+func (r *StorageMigrationRepository) GetEventImages() ([]*models.EventImageReference, error) {
+	query := `
+		SELECT id, COALESCE(image_url, ''), COALESCE(image_key, '')
+		FROM events
+		WHERE (image_url IS NOT NULL AND image_url != '') OR (image_key IS NOT NULL AND image_key != '')
+		ORDER BY id`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query event images: %w", err)
+	}
+	defer rows.Close()
+
+	var images []*models.EventImageReference
+	for rows.Next() {
+		image := &models.EventImageReference{}
+		if err := rows.Scan(&image.EventID, &image.ImageURL, &image.ImageKey); err != nil {
+			return nil, fmt.Errorf("failed to scan event image: %w", err)
+		}
+		images = append(images, image)
+	}
+
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating event images: %w", err)
+	}
+
+	return images, nil
+}
+
+// RewriteEventImages points events at their migrated images in a single
+// transaction. Events whose image changed since it was read are left alone.
+// It returns the number of events updated.
+func (r *StorageMigrationRepository) RewriteEventImages(rewrites []*models.EventImageRewrite) (int, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	stmt, err := tx.Prepare(`
+		UPDATE events
+		SET image_url = $1, image_key = $2, updated_at = CURRENT_TIMESTAMP
+		WHERE id = $3 AND COALESCE(image_url, '') = $4`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to prepare image rewrite: %w", err)
+	}
+	defer stmt.Close()
+
+	updated := 0
+	for _, rewrite := range rewrites {
+		result, err := stmt.Exec(rewrite.ImageURL, rewrite.ImageKey, rewrite.EventID, rewrite.PreviousURL)
+		if err != nil {
+			return 0, fmt.Errorf("failed to rewrite image of event %d: %w", rewrite.EventID, err)
+		}
+		rows, err := result.RowsAffected()
+		if err != nil {
+			return 0, fmt.Errorf("failed to get rows affected: %w", err)
+		}
+		updated += int(rows)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return 0, fmt.Errorf("failed to commit image rewrite: %w", err)
+	}
+
+	return updated, nil
+}
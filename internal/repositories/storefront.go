@@ -0,0 +1,255 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// StorefrontRepository handles organizer profiles and their followers
+type StorefrontRepository struct {
+	db *sql.DB
+}
+
+// NewStorefrontRepository creates a new storefront repository
+func NewStorefrontRepository(db *sql.DB) *StorefrontRepository {
+	return &StorefrontRepository{db: db}
+}
+
+const organizerProfileColumns = `user_id, slug, display_name, description, logo_url, banner_url, website_url, created_at, updated_at`
+
+func scanOrganizerProfile(row interface{ Scan(...interface{}) error }) (*models.OrganizerProfile, error) {
+	profile := &models.OrganizerProfile{}
+	err := row.Scan(&profile.UserID, &profile.Slug, &profile.DisplayName, &profile.Description,
+		&profile.LogoURL, &profile.BannerURL, &profile.WebsiteURL, &profile.CreatedAt, &profile.UpdatedAt)
+	return profile, err
+}
+
+// GetByUserID retrieves an organizer's profile, or nil if they have none yet
+func (r *StorefrontRepository) GetByUserID(userID int) (*models.OrganizerProfile, error) {
+	profile, err := scanOrganizerProfile(r.db.QueryRow(
+		"SELECT "+organizerProfileColumns+" FROM organizer_profiles WHERE user_id = $1", userID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get organizer profile: %w", err)
+	}
+	return profile, nil
+}
+
+// GetBySlug retrieves the profile of an active organizer by storefront slug
+func (r *StorefrontRepository) GetBySlug(slug string) (*models.OrganizerProfile, error) {
+	profile, err := scanOrganizerProfile(r.db.QueryRow(`
+		SELECT p.user_id, p.slug, p.display_name, p.description, p.logo_url, p.banner_url, p.website_url, p.created_at, p.updated_at
+		FROM organizer_profiles p
+		JOIN users u ON u.id = p.user_id
+		WHERE p.slug = $1 AND u.is_active = true`, slug))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("organizer with slug %s not found", slug)
+		}
+		return nil, fmt.Errorf("failed to get organizer profile: %w", err)
+	}
+	return profile, nil
+}
+
+// Create creates an organizer's profile, adding a numeric suffix to the slug
+// if another storefront has it
+func (r *StorefrontRepository) Create(profile *models.OrganizerProfile) error {
+	rows, err := r.db.Query(`
+		SELECT slug FROM organizer_profiles WHERE slug = $1 OR slug LIKE $1 || '-%'`, profile.Slug)
+	if err != nil {
+		return fmt.Errorf("failed to check storefront slugs: %w", err)
+	}
+	defer rows.Close()
+
+	taken := make(map[string]bool)
+	for rows.Next() {
+		var slug string
+		if err := rows.Scan(&slug); err != nil {
+			return fmt.Errorf("failed to scan storefront slug: %w", err)
+		}
+		taken[slug] = true
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterating storefront slugs: %w", err)
+	}
+
+	profile.Slug = models.UniqueEventSlug(profile.Slug, taken)
+	err = r.db.QueryRow(`
+		INSERT INTO organizer_profiles (user_id, slug, display_name, description, logo_url, banner_url, website_url)
+		VALUES ($1, $2, $3, $4, $5, $6, $7)
+		ON CONFLICT (user_id) DO UPDATE SET user_id = EXCLUDED.user_id
+		RETURNING `+organizerProfileColumns,
+		profile.UserID, profile.Slug, profile.DisplayName, profile.Description,
+		profile.LogoURL, profile.BannerURL, profile.WebsiteURL).Scan(
+		&profile.UserID, &profile.Slug, &profile.DisplayName, &profile.Description,
+		&profile.LogoURL, &profile.BannerURL, &profile.WebsiteURL, &profile.CreatedAt, &profile.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create organizer profile: %w", err)
+	}
+	return nil
+}
+
+// SlugTaken returns true if another organizer's storefront has the slug
+func (r *StorefrontRepository) SlugTaken(slug string, userID int) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(`
+		SELECT EXISTS (SELECT 1 FROM organizer_profiles WHERE slug = $1 AND user_id <> $2)`, slug, userID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check storefront slug: %w", err)
+	}
+	return exists, nil
+}
+
+// Update saves an organizer's edited profile
+func (r *StorefrontRepository) Update(profile *models.OrganizerProfile) error {
+	profile.UpdatedAt = time.Now()
+	result, err := r.db.Exec(`
+		UPDATE organizer_profiles
+		SET slug = $2, display_name = $3, description = $4, logo_url = $5, banner_url = $6, website_url = $7, updated_at = $8
+		WHERE user_id = $1`,
+		profile.UserID, profile.Slug, profile.DisplayName, profile.Description,
+		profile.LogoURL, profile.BannerURL, profile.WebsiteURL, profile.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update organizer profile: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("organizer profile not found")
+	}
+	return nil
+}
+
+// Follow makes a user follow an organizer. Following twice is not an error.
+func (r *StorefrontRepository) Follow(organizerID, followerID int) error {
+	_, err := r.db.Exec(`
+		INSERT INTO organizer_followers (organizer_id, follower_id)
+		VALUES ($1, $2)
+		ON CONFLICT (organizer_id, follower_id) DO NOTHING`, organizerID, followerID)
+	if err != nil {
+		return fmt.Errorf("failed to follow organizer: %w", err)
+	}
+	return nil
+}
+
+// Unfollow stops a user following an organizer
+func (r *StorefrontRepository) Unfollow(organizerID, followerID int) error {
+	if _, err := r.db.Exec("DELETE FROM organizer_followers WHERE organizer_id = $1 AND follower_id = $2", organizerID, followerID); err != nil {
+		return fmt.Errorf("failed to unfollow organizer: %w", err)
+	}
+	return nil
+}
+
+// IsFollowing returns true if the user follows the organizer
+func (r *StorefrontRepository) IsFollowing(organizerID, followerID int) (bool, error) {
+	var exists bool
+	err := r.db.QueryRow(`
+		SELECT EXISTS (SELECT 1 FROM organizer_followers WHERE organizer_id = $1 AND follower_id = $2)`, organizerID, followerID).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check follower: %w", err)
+	}
+	return exists, nil
+}
+
+// CountFollowers returns how many active users follow the organizer
+func (r *StorefrontRepository) CountFollowers(organizerID int) (int, error) {
+	var count int
+	err := r.db.QueryRow(`
+		SELECT COUNT(*)
+		FROM organizer_followers f
+		JOIN users u ON u.id = f.follower_id
+		WHERE f.organizer_id = $1 AND u.is_active = true`, organizerID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count followers: %w", err)
+	}
+	return count, nil
+}
+
+// GetFollowers returns the active users following the organizer, with the
+// language to email each of them in
+func (r *StorefrontRepository) GetFollowers(organizerID int) ([]*models.OrganizerFollower, error) {
+	query := `
+		SELECT u.id, u.email, TRIM(u.first_name || ' ' || u.last_name), u.locale
+		FROM organizer_followers f
+		JOIN users u ON u.id = f.follower_id
+		WHERE f.organizer_id = $1 AND u.is_active = true
+		ORDER BY f.created_at`
+
+	rows, err := r.db.Query(query, organizerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query followers: %w", err)
+	}
+	defer rows.Close()
+
+	var followers []*models.OrganizerFollower
+	for rows.Next() {
+		follower := &models.OrganizerFollower{}
+		if err := rows.Scan(&follower.UserID, &follower.Email, &follower.Name, &follower.Locale); err != nil {
+			return nil, fmt.Errorf("failed to scan follower: %w", err)
+		}
+		followers = append(followers, follower)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating followers: %w", err)
+	}
+
+	return followers, nil
+}
+
+// GetUnannouncedEvents returns the IDs of published events whose organizer's
+// followers have not been told about them yet
+func (r *StorefrontRepository) GetUnannouncedEvents() ([]int, error) {
+	rows, err := r.db.Query(`
+		SELECT e.id
+		FROM events e
+		LEFT JOIN organizer_event_announcements a ON a.event_id = e.id
+		WHERE e.status = $1 AND e.deleted_at IS NULL AND a.event_id IS NULL
+		ORDER BY e.id`, models.StatusPublished)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query unannounced events: %w", err)
+	}
+	defer rows.Close()
+
+	var eventIDs []int
+	for rows.Next() {
+		var id int
+		if err := rows.Scan(&id); err != nil {
+			return nil, fmt.Errorf("failed to scan event ID: %w", err)
+		}
+		eventIDs = append(eventIDs, id)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating unannounced events: %w", err)
+	}
+
+	return eventIDs, nil
+}
+
+// ClaimEventAnnouncement marks an event as announced to followers. It
+// returns false if it already had been, so each event is announced once.
+func (r *StorefrontRepository) ClaimEventAnnouncement(eventID int) (bool, error) {
+	result, err := r.db.Exec(`
+		INSERT INTO organizer_event_announcements (event_id)
+		VALUES ($1)
+		ON CONFLICT (event_id) DO NOTHING`, eventID)
+	if err != nil {
+		return false, fmt.Errorf("failed to claim event announcement: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	return rowsAffected == 1, nil
+}
@@ -0,0 +1,163 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// TaxRepository handles organizers' tax settings and their events' overrides
+type TaxRepository struct {
+	db *sql.DB
+}
+
+// NewTaxRepository creates a new tax repository
+func NewTaxRepository(db *sql.DB) *TaxRepository {
+	return &TaxRepository{db: db}
+}
+
+// GetSettings retrieves an organizer's tax settings. Organizers that have
+// none don't charge tax.
+func (r *TaxRepository) GetSettings(organizerID int) (*models.TaxSettings, error) {
+	query := `
+		SELECT organizer_id, enabled, label, rate_basis_points, inclusive, tax_id, updated_at
+		FROM organizer_tax_settings
+		WHERE organizer_id = $1`
+
+	settings := &models.TaxSettings{}
+	err := r.db.QueryRow(query, organizerID).Scan(
+		&settings.OrganizerID,
+		&settings.Enabled,
+		&settings.Label,
+		&settings.RateBasisPoints,
+		&settings.Inclusive,
+		&settings.TaxID,
+		&settings.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.DefaultTaxSettings(organizerID), nil
+		}
+		return nil, fmt.Errorf("failed to get tax settings: %w", err)
+	}
+
+	return settings, nil
+}
+
+// SaveSettings creates or updates an organizer's tax settings
+func (r *TaxRepository) SaveSettings(settings *models.TaxSettings) error {
+	query := `
+		INSERT INTO organizer_tax_settings (organizer_id, enabled, label, rate_basis_points, inclusive, tax_id, updated_at)
+		VALUES ($1, $2, $3, $4, $5, $6, NOW())
+		ON CONFLICT (organizer_id) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			label = EXCLUDED.label,
+			rate_basis_points = EXCLUDED.rate_basis_points,
+			inclusive = EXCLUDED.inclusive,
+			tax_id = EXCLUDED.tax_id,
+			updated_at = NOW()
+		RETURNING updated_at`
+
+	err := r.db.QueryRow(query, settings.OrganizerID, settings.Enabled, settings.Label, settings.RateBasisPoints, settings.Inclusive, settings.TaxID).Scan(&settings.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save tax settings: %w", err)
+	}
+
+	return nil
+}
+
+// GetOverride retrieves an event's tax override, or nil if it is taxed at
+// its organizer's rate
+func (r *TaxRepository) GetOverride(eventID int) (*models.EventTaxOverride, error) {
+	query := `
+		SELECT event_id, exempt, rate_basis_points, inclusive, updated_at
+		FROM event_tax_overrides
+		WHERE event_id = $1`
+
+	override := &models.EventTaxOverride{}
+	err := r.db.QueryRow(query, eventID).Scan(
+		&override.EventID,
+		&override.Exempt,
+		&override.RateBasisPoints,
+		&override.Inclusive,
+		&override.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get event tax override: %w", err)
+	}
+
+	return override, nil
+}
+
+// SaveOverride creates or updates an event's tax override
+func (r *TaxRepository) SaveOverride(override *models.EventTaxOverride) error {
+	query := `
+		INSERT INTO event_tax_overrides (event_id, exempt, rate_basis_points, inclusive, updated_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (event_id) DO UPDATE SET
+			exempt = EXCLUDED.exempt,
+			rate_basis_points = EXCLUDED.rate_basis_points,
+			inclusive = EXCLUDED.inclusive,
+			updated_at = NOW()
+		RETURNING updated_at`
+
+	err := r.db.QueryRow(query, override.EventID, override.Exempt, override.RateBasisPoints, override.Inclusive).Scan(&override.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save event tax override: %w", err)
+	}
+
+	return nil
+}
+
+// DeleteOverride taxes an event at its organizer's rate again
+func (r *TaxRepository) DeleteOverride(eventID int) error {
+	if _, err := r.db.Exec("DELETE FROM event_tax_overrides WHERE event_id = $1", eventID); err != nil {
+		return fmt.Errorf("failed to delete event tax override: %w", err)
+	}
+	return nil
+}
+
+// GetEventTax retrieves the tax settings of an event's organizer and the
+// event's override, which is nil if it has none
+func (r *TaxRepository) GetEventTax(eventID int) (*models.TaxSettings, *models.EventTaxOverride, error) {
+	query := `
+		SELECT e.organizer_id,
+			COALESCE(s.enabled, FALSE), COALESCE(s.label, ''), COALESCE(s.rate_basis_points, 0), COALESCE(s.inclusive, FALSE), COALESCE(s.tax_id, ''),
+			o.event_id, COALESCE(o.exempt, FALSE), COALESCE(o.rate_basis_points, 0), COALESCE(o.inclusive, FALSE)
+		FROM events e
+		LEFT JOIN organizer_tax_settings s ON s.organizer_id = e.organizer_id
+		LEFT JOIN event_tax_overrides o ON o.event_id = e.id
+		WHERE e.id = $1`
+
+	settings := &models.TaxSettings{}
+	override := &models.EventTaxOverride{}
+	var overrideEventID sql.NullInt64
+	err := r.db.QueryRow(query, eventID).Scan(
+		&settings.OrganizerID,
+		&settings.Enabled,
+		&settings.Label,
+		&settings.RateBasisPoints,
+		&settings.Inclusive,
+		&settings.TaxID,
+		&overrideEventID,
+		&override.Exempt,
+		&override.RateBasisPoints,
+		&override.Inclusive,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil, nil
+		}
+		return nil, nil, fmt.Errorf("failed to get event tax: %w", err)
+	}
+
+	if !overrideEventID.Valid {
+		return settings, nil, nil
+	}
+	override.EventID = int(overrideEventID.Int64)
+	return settings, override, nil
+}
@@ -2,11 +2,16 @@ package repositories
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"sort"
+	"strconv"
 	"strings"
 	"time"
 
 	"event-ticketing-platform/internal/models"
+
+	"github.com/lib/pq"
 )
 
 // TicketRepository handles ticket and ticket type data operations
@@ -19,6 +24,10 @@ func NewTicketRepository(db *sql.DB) *TicketRepository {
 	return &TicketRepository{db: db}
 }
 
+// ErrInsufficientTickets is returned when fewer tickets are left than a
+// reservation or order needs
+var ErrInsufficientTickets = errors.New("insufficient tickets available")
+
 // TicketReservation represents a temporary ticket reservation
 type TicketReservation struct {
 	ID           string    `json:"id"`
@@ -43,6 +52,44 @@ type TicketSearchFilters struct {
 
 // TicketType operations
 
+const ticketTypeColumns = `id, event_id, name, description, price, quantity, sold, sale_start, sale_end, created_at, next_tier_id, pay_what_you_want,
+	(SELECT COALESCE(SUM(r.quantity), 0) FROM ticket_reservations r
+	 WHERE r.ticket_type_id = ticket_types.id AND r.expires_at > NOW()) AS held`
+
+// scanTicketType scans a row selected with ticketTypeColumns
+func scanTicketType(scanner interface {
+	Scan(dest ...interface{}) error
+}) (*models.TicketType, error) {
+	ticketType := &models.TicketType{}
+	var nextTierID sql.NullInt64
+
+	err := scanner.Scan(
+		&ticketType.ID,
+		&ticketType.EventID,
+		&ticketType.Name,
+		&ticketType.Description,
+		&ticketType.Price,
+		&ticketType.Quantity,
+		&ticketType.Sold,
+		&ticketType.SaleStart,
+		&ticketType.SaleEnd,
+		&ticketType.CreatedAt,
+		&nextTierID,
+		&ticketType.PayWhatYouWant,
+		&ticketType.Held,
+	)
+	if err != nil {
+		return nil, err
+	}
+
+	if nextTierID.Valid {
+		id := int(nextTierID.Int64)
+		ticketType.NextTierID = &id
+	}
+
+	return ticketType, nil
+}
+
 // CreateTicketType creates a new ticket type
 func (r *TicketRepository) CreateTicketType(req *models.TicketTypeCreateRequest) (*models.TicketType, error) {
 	if err := req.Validate(); err != nil {
@@ -50,12 +97,11 @@ func (r *TicketRepository) CreateTicketType(req *models.TicketTypeCreateRequest)
 	}
 
 	query := `
-		INSERT INTO ticket_types (event_id, name, description, price, quantity, sold, sale_start, sale_end, created_at)
-		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
-		RETURNING id, event_id, name, description, price, quantity, sold, sale_start, sale_end, created_at`
+		INSERT INTO ticket_types (event_id, name, description, price, quantity, sold, sale_start, sale_end, created_at, pay_what_you_want)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING ` + ticketTypeColumns
 
-	ticketType := &models.TicketType{}
-	err := r.db.QueryRow(
+	ticketType, err := scanTicketType(r.db.QueryRow(
 		query,
 		req.EventID,
 		req.Name,
@@ -66,18 +112,8 @@ func (r *TicketRepository) CreateTicketType(req *models.TicketTypeCreateRequest)
 		req.SaleStart,
 		req.SaleEnd,
 		time.Now(),
-	).Scan(
-		&ticketType.ID,
-		&ticketType.EventID,
-		&ticketType.Name,
-		&ticketType.Description,
-		&ticketType.Price,
-		&ticketType.Quantity,
-		&ticketType.Sold,
-		&ticketType.SaleStart,
-		&ticketType.SaleEnd,
-		&ticketType.CreatedAt,
-	)
+		req.PayWhatYouWant,
+	))
 
 	if err != nil {
 		return nil, fmt.Errorf("failed to create ticket type: %w", err)
@@ -89,23 +125,11 @@ func (r *TicketRepository) CreateTicketType(req *models.TicketTypeCreateRequest)
 // GetTicketTypeByID retrieves a ticket type by ID
 func (r *TicketRepository) GetTicketTypeByID(id int) (*models.TicketType, error) {
 	query := `
-		SELECT id, event_id, name, description, price, quantity, sold, sale_start, sale_end, created_at
+		SELECT ` + ticketTypeColumns + `
 		FROM ticket_types
-		WHERE id = $1`
+		WHERE id = $1 AND deleted_at IS NULL`
 
-	ticketType := &models.TicketType{}
-	err := r.db.QueryRow(query, id).Scan(
-		&ticketType.ID,
-		&ticketType.EventID,
-		&ticketType.Name,
-		&ticketType.Description,
-		&ticketType.Price,
-		&ticketType.Quantity,
-		&ticketType.Sold,
-		&ticketType.SaleStart,
-		&ticketType.SaleEnd,
-		&ticketType.CreatedAt,
-	)
+	ticketType, err := scanTicketType(r.db.QueryRow(query, id))
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -120,9 +144,9 @@ func (r *TicketRepository) GetTicketTypeByID(id int) (*models.TicketType, error)
 // GetTicketTypesByEvent retrieves all ticket types for an event
 func (r *TicketRepository) GetTicketTypesByEvent(eventID int) ([]*models.TicketType, error) {
 	query := `
-		SELECT id, event_id, name, description, price, quantity, sold, sale_start, sale_end, created_at
+		SELECT ` + ticketTypeColumns + `
 		FROM ticket_types
-		WHERE event_id = $1
+		WHERE event_id = $1 AND deleted_at IS NULL
 		ORDER BY price ASC, created_at ASC`
 
 	rows, err := r.db.Query(query, eventID)
@@ -133,19 +157,7 @@ func (r *TicketRepository) GetTicketTypesByEvent(eventID int) ([]*models.TicketT
 
 	var ticketTypes []*models.TicketType
 	for rows.Next() {
-		ticketType := &models.TicketType{}
-		err := rows.Scan(
-			&ticketType.ID,
-			&ticketType.EventID,
-			&ticketType.Name,
-			&ticketType.Description,
-			&ticketType.Price,
-			&ticketType.Quantity,
-			&ticketType.Sold,
-			&ticketType.SaleStart,
-			&ticketType.SaleEnd,
-			&ticketType.CreatedAt,
-		)
+		ticketType, err := scanTicketType(rows)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan ticket type: %w", err)
 		}
@@ -178,12 +190,11 @@ func (r *TicketRepository) UpdateTicketType(id int, req *models.TicketTypeUpdate
 
 	query := `
 		UPDATE ticket_types
-		SET name = $2, description = $3, price = $4, quantity = $5, sale_start = $6, sale_end = $7
+		SET name = $2, description = $3, price = $4, quantity = $5, sale_start = $6, sale_end = $7, pay_what_you_want = $8
 		WHERE id = $1
-		RETURNING id, event_id, name, description, price, quantity, sold, sale_start, sale_end, created_at`
+		RETURNING ` + ticketTypeColumns
 
-	ticketType := &models.TicketType{}
-	err = r.db.QueryRow(
+	ticketType, err := scanTicketType(r.db.QueryRow(
 		query,
 		id,
 		req.Name,
@@ -192,18 +203,8 @@ func (r *TicketRepository) UpdateTicketType(id int, req *models.TicketTypeUpdate
 		req.Quantity,
 		req.SaleStart,
 		req.SaleEnd,
-	).Scan(
-		&ticketType.ID,
-		&ticketType.EventID,
-		&ticketType.Name,
-		&ticketType.Description,
-		&ticketType.Price,
-		&ticketType.Quantity,
-		&ticketType.Sold,
-		&ticketType.SaleStart,
-		&ticketType.SaleEnd,
-		&ticketType.CreatedAt,
-	)
+		req.PayWhatYouWant,
+	))
 
 	if err != nil {
 		if err == sql.ErrNoRows {
@@ -215,7 +216,7 @@ func (r *TicketRepository) UpdateTicketType(id int, req *models.TicketTypeUpdate
 	return ticketType, nil
 }
 
-// DeleteTicketType deletes a ticket type (only if no tickets sold)
+// DeleteTicketType moves a ticket type to the trash (only if no tickets sold)
 func (r *TicketRepository) DeleteTicketType(id int) error {
 	// First check if any tickets have been sold
 	ticketType, err := r.GetTicketTypeByID(id)
@@ -227,7 +228,7 @@ func (r *TicketRepository) DeleteTicketType(id int) error {
 		return fmt.Errorf("cannot delete ticket type with sold tickets")
 	}
 
-	query := `DELETE FROM ticket_types WHERE id = $1`
+	query := `UPDATE ticket_types SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
 
 	result, err := r.db.Exec(query, id)
 	if err != nil {
@@ -246,104 +247,198 @@ func (r *TicketRepository) DeleteTicketType(id int) error {
 	return nil
 }
 
-// ReserveTickets creates a temporary reservation for tickets
-func (r *TicketRepository) ReserveTickets(ticketTypeID, quantity, userID int, expirationMinutes int) (*TicketReservation, error) {
-	// Start transaction
+// SetNextTier sets the ticket type that goes on sale after this one, or
+// clears it if nextTierID is nil. The next tier's sales are moved to start no
+// earlier than this tier's end, so only one tier is on sale at a time.
+func (r *TicketRepository) SetNextTier(ticketTypeID int, nextTierID *int) error {
 	tx, err := r.db.Begin()
 	if err != nil {
-		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
 	defer tx.Rollback()
 
-	// Check ticket availability with row locking
-	var available int
-	err = tx.QueryRow(`
-		SELECT (quantity - sold) 
-		FROM ticket_types 
-		WHERE id = $1 
-		FOR UPDATE`, ticketTypeID).Scan(&available)
+	result, err := tx.Exec("UPDATE ticket_types SET next_tier_id = $2 WHERE id = $1", ticketTypeID, nextTierID)
+	if err != nil {
+		return fmt.Errorf("failed to set next tier: %w", err)
+	}
 
+	rowsAffected, err := result.RowsAffected()
 	if err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("ticket type not found")
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("ticket type with id %d not found", ticketTypeID)
+	}
+
+	if nextTierID != nil {
+		_, err = tx.Exec(`
+			UPDATE ticket_types following
+			SET sale_start = tier.sale_end
+			FROM ticket_types tier
+			WHERE tier.id = $1 AND following.id = $2 AND following.sale_start < tier.sale_end`, ticketTypeID, *nextTierID)
+		if err != nil {
+			return fmt.Errorf("failed to align next tier sales: %w", err)
 		}
-		return nil, fmt.Errorf("failed to check ticket availability: %w", err)
 	}
 
-	if available < quantity {
-		return nil, fmt.Errorf("insufficient tickets available (requested: %d, available: %d)", quantity, available)
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
 	}
 
-	// Check if ticket type is currently on sale
-	var saleStart, saleEnd time.Time
-	err = tx.QueryRow(`
-		SELECT sale_start, sale_end 
-		FROM ticket_types 
-		WHERE id = $1`, ticketTypeID).Scan(&saleStart, &saleEnd)
+	return nil
+}
 
+// AdvanceTier ends the sale of a finished tier and puts the next tier on sale
+// now, in one transaction so buyers never see both or neither on sale
+func (r *TicketRepository) AdvanceTier(tierID, nextTierID int) error {
+	tx, err := r.db.Begin()
 	if err != nil {
-		return nil, fmt.Errorf("failed to get sale period: %w", err)
+		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
+	defer tx.Rollback()
 
-	now := time.Now()
-	if now.Before(saleStart) {
-		return nil, fmt.Errorf("ticket sales have not started yet")
+	result, err := tx.Exec(`
+		UPDATE ticket_types SET sale_end = LEAST(sale_end, NOW())
+		WHERE id = $1 AND next_tier_id = $2`, tierID, nextTierID)
+	if err != nil {
+		return fmt.Errorf("failed to end tier sales: %w", err)
 	}
-	if now.After(saleEnd) {
-		return nil, fmt.Errorf("ticket sales have ended")
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
 	}
 
-	// Create reservation record (using a simple table for reservations)
-	// In a production system, you might use Redis or a dedicated reservations table
-	reservationID := fmt.Sprintf("RES-%d-%d-%d", ticketTypeID, userID, time.Now().Unix())
-	expiresAt := time.Now().Add(time.Duration(expirationMinutes) * time.Minute)
+	if rowsAffected == 0 {
+		return fmt.Errorf("ticket type %d is not followed by tier %d", tierID, nextTierID)
+	}
 
-	// For now, we'll temporarily increase the sold count to reserve tickets
-	// This is a simplified approach - in production you'd want a proper reservations system
 	_, err = tx.Exec(`
-		UPDATE ticket_types 
-		SET sold = sold + $2 
-		WHERE id = $1`, ticketTypeID, quantity)
+		UPDATE ticket_types SET sale_start = NOW()
+		WHERE id = $1 AND sale_start > NOW()`, nextTierID)
+	if err != nil {
+		return fmt.Errorf("failed to start next tier sales: %w", err)
+	}
 
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// TicketHold is how many tickets of a type to hold
+type TicketHold struct {
+	TicketTypeID int
+	Quantity     int
+}
+
+// ReserveTickets creates a temporary reservation for tickets
+func (r *TicketRepository) ReserveTickets(ticketTypeID, quantity, userID int, expirationMinutes int) (*TicketReservation, error) {
+	reservations, err := r.HoldTickets(userID, []TicketHold{{TicketTypeID: ticketTypeID, Quantity: quantity}}, expirationMinutes)
 	if err != nil {
-		return nil, fmt.Errorf("failed to reserve tickets: %w", err)
+		return nil, err
 	}
+	return reservations[0], nil
+}
 
-	if err = tx.Commit(); err != nil {
-		return nil, fmt.Errorf("failed to commit reservation: %w", err)
+// HoldTickets reserves tickets of one or more types for a user until the
+// reservations expire, replacing what the user already holds of those types.
+// Either every hold is made or none is.
+func (r *TicketRepository) HoldTickets(userID int, holds []TicketHold, expirationMinutes int) ([]*TicketReservation, error) {
+	quantities := make(map[int]int)
+	for _, hold := range holds {
+		if hold.Quantity <= 0 {
+			return nil, fmt.Errorf("quantity must be greater than 0")
+		}
+		quantities[hold.TicketTypeID] += hold.Quantity
+	}
+	if len(quantities) == 0 {
+		return nil, fmt.Errorf("no tickets to hold")
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	now := time.Now()
+	expiresAt := now.Add(time.Duration(expirationMinutes) * time.Minute)
+
+	var reservations []*TicketReservation
+	for _, ticketTypeID := range lockOrder(quantities) {
+		quantity := quantities[ticketTypeID]
+		if err := lockTicketInventory(tx, ticketTypeID, quantity, userID, true); err != nil {
+			return nil, err
+		}
+
+		if _, err := tx.Exec(`
+			DELETE FROM ticket_reservations
+			WHERE user_id = $1 AND ticket_type_id = $2`, userID, ticketTypeID); err != nil {
+			return nil, fmt.Errorf("failed to replace reservation: %w", err)
+		}
+
+		var id int
+		err = tx.QueryRow(`
+			INSERT INTO ticket_reservations (ticket_type_id, user_id, quantity, expires_at, created_at)
+			VALUES ($1, $2, $3, $4, $5)
+			RETURNING id`, ticketTypeID, userID, quantity, expiresAt, now).Scan(&id)
+		if err != nil {
+			return nil, fmt.Errorf("failed to reserve tickets: %w", err)
+		}
+
+		reservations = append(reservations, &TicketReservation{
+			ID:           strconv.Itoa(id),
+			TicketTypeID: ticketTypeID,
+			Quantity:     quantity,
+			UserID:       userID,
+			ExpiresAt:    expiresAt,
+			CreatedAt:    now,
+		})
 	}
 
-	reservation := &TicketReservation{
-		ID:           reservationID,
-		TicketTypeID: ticketTypeID,
-		Quantity:     quantity,
-		UserID:       userID,
-		ExpiresAt:    expiresAt,
-		CreatedAt:    now,
+	if err = tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit reservation: %w", err)
 	}
 
-	return reservation, nil
+	return reservations, nil
 }
 
-// ReleaseReservation releases a ticket reservation
+// ReleaseReservation gives back quantity tickets of a reservation, deleting
+// it once none are left
 func (r *TicketRepository) ReleaseReservation(reservationID string, ticketTypeID, quantity int) error {
-	// In a simplified approach, we just reduce the sold count
-	// In production, you'd have a proper reservations table to track this
-	query := `
-		UPDATE ticket_types 
-		SET sold = sold - $2 
-		WHERE id = $1 AND sold >= $2`
+	id, err := strconv.Atoi(reservationID)
+	if err != nil {
+		return fmt.Errorf("invalid reservation ID: %s", reservationID)
+	}
 
-	result, err := r.db.Exec(query, ticketTypeID, quantity)
+	result, err := r.db.Exec(`
+		DELETE FROM ticket_reservations
+		WHERE id = $1 AND ticket_type_id = $2 AND quantity <= $3`, id, ticketTypeID, quantity)
 	if err != nil {
 		return fmt.Errorf("failed to release reservation: %w", err)
 	}
-
 	rowsAffected, err := result.RowsAffected()
 	if err != nil {
 		return fmt.Errorf("failed to get rows affected: %w", err)
 	}
 
+	if rowsAffected == 0 {
+		result, err = r.db.Exec(`
+			UPDATE ticket_reservations
+			SET quantity = quantity - $3
+			WHERE id = $1 AND ticket_type_id = $2 AND quantity > $3`, id, ticketTypeID, quantity)
+		if err != nil {
+			return fmt.Errorf("failed to release reservation: %w", err)
+		}
+		rowsAffected, err = result.RowsAffected()
+		if err != nil {
+			return fmt.Errorf("failed to get rows affected: %w", err)
+		}
+	}
+
 	if rowsAffected == 0 {
 		return fmt.Errorf("reservation not found or already released")
 	}
@@ -351,6 +446,115 @@ func (r *TicketRepository) ReleaseReservation(reservationID string, ticketTypeID
 	return nil
 }
 
+// ReleaseUserReservations gives back what a user holds of the ticket types,
+// when their checkout fails
+func (r *TicketRepository) ReleaseUserReservations(userID int, ticketTypeIDs []int) error {
+	_, err := r.db.Exec(`
+		DELETE FROM ticket_reservations
+		WHERE user_id = $1 AND ticket_type_id = ANY($2)`, userID, pq.Array(ticketTypeIDs))
+	if err != nil {
+		return fmt.Errorf("failed to release reservations: %w", err)
+	}
+	return nil
+}
+
+// DeleteExpiredReservations deletes holds that have expired, returning how
+// many were deleted. Expired holds already stop counting against
+// availability; this keeps the table small.
+func (r *TicketRepository) DeleteExpiredReservations() (int64, error) {
+	result, err := r.db.Exec(`DELETE FROM ticket_reservations WHERE expires_at <= NOW()`)
+	if err != nil {
+		return 0, fmt.Errorf("failed to delete expired reservations: %w", err)
+	}
+	return result.RowsAffected()
+}
+
+// lockOrder returns the ticket types to lock in ascending ID order, so
+// checkouts locking the same types can't deadlock
+func lockOrder(quantities map[int]int) []int {
+	ticketTypeIDs := make([]int, 0, len(quantities))
+	for ticketTypeID := range quantities {
+		ticketTypeIDs = append(ticketTypeIDs, ticketTypeID)
+	}
+	sort.Ints(ticketTypeIDs)
+	return ticketTypeIDs
+}
+
+// lockTicketInventory locks a ticket type's row until tx ends and checks
+// quantity tickets are left after sales and other buyers' unexpired holds;
+// the user's own holds are about to be replaced or sold. Holds and sales
+// only change with the row locked, so the count stays true until tx ends.
+func lockTicketInventory(tx *sql.Tx, ticketTypeID, quantity, userID int, requireOnSale bool) error {
+	var available int
+	var saleStart, saleEnd time.Time
+	err := tx.QueryRow(`
+		SELECT quantity - sold, sale_start, sale_end
+		FROM ticket_types
+		WHERE id = $1 AND deleted_at IS NULL
+		FOR UPDATE`, ticketTypeID).Scan(&available, &saleStart, &saleEnd)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return fmt.Errorf("ticket type not found")
+		}
+		return fmt.Errorf("failed to check ticket availability: %w", err)
+	}
+
+	var heldByOthers int
+	err = tx.QueryRow(`
+		SELECT COALESCE(SUM(quantity), 0)
+		FROM ticket_reservations
+		WHERE ticket_type_id = $1 AND user_id != $2 AND expires_at > NOW()`, ticketTypeID, userID).Scan(&heldByOthers)
+	if err != nil {
+		return fmt.Errorf("failed to check ticket reservations: %w", err)
+	}
+
+	available -= heldByOthers
+	if available < quantity {
+		if available < 0 {
+			available = 0
+		}
+		return fmt.Errorf("%w (requested: %d, available: %d)", ErrInsufficientTickets, quantity, available)
+	}
+
+	if requireOnSale {
+		now := time.Now()
+		if now.Before(saleStart) {
+			return fmt.Errorf("ticket sales have not started yet")
+		}
+		if now.After(saleEnd) {
+			return fmt.Errorf("ticket sales have ended")
+		}
+	}
+
+	return nil
+}
+
+// sellTickets counts tickets as sold within tx, turning the user's holds on
+// their types into the sale. It fails with ErrInsufficientTickets, leaving
+// tx to be rolled back, when too few are left.
+func sellTickets(tx *sql.Tx, userID int, quantities map[int]int) error {
+	for _, ticketTypeID := range lockOrder(quantities) {
+		quantity := quantities[ticketTypeID]
+		if err := lockTicketInventory(tx, ticketTypeID, quantity, userID, false); err != nil {
+			return err
+		}
+
+		if _, err := tx.Exec(`
+			UPDATE ticket_types
+			SET sold = sold + $2
+			WHERE id = $1`, ticketTypeID, quantity); err != nil {
+			return fmt.Errorf("failed to update sold count: %w", err)
+		}
+
+		if _, err := tx.Exec(`
+			DELETE FROM ticket_reservations
+			WHERE user_id = $1 AND ticket_type_id = $2`, userID, ticketTypeID); err != nil {
+			return fmt.Errorf("failed to consume reservation: %w", err)
+		}
+	}
+	return nil
+}
+
 // Ticket operations
 
 // CreateTicket creates a new ticket
@@ -358,7 +562,7 @@ func (r *TicketRepository) CreateTicket(orderID, ticketTypeID int, qrCode string
 	query := `
 		INSERT INTO tickets (order_id, ticket_type_id, qr_code, status, created_at)
 		VALUES ($1, $2, $3, $4, $5)
-		RETURNING id, order_id, ticket_type_id, qr_code, status, created_at`
+		RETURNING id, order_id, ticket_type_id, qr_code, status, created_at, attendee_name, attendee_email`
 
 	ticket := &models.Ticket{}
 	err := r.db.QueryRow(
@@ -375,6 +579,8 @@ func (r *TicketRepository) CreateTicket(orderID, ticketTypeID int, qrCode string
 		&ticket.QRCode,
 		&ticket.Status,
 		&ticket.CreatedAt,
+		&ticket.AttendeeName,
+		&ticket.AttendeeEmail,
 	)
 
 	if err != nil {
@@ -390,7 +596,7 @@ func (r *TicketRepository) CreateTicket(orderID, ticketTypeID int, qrCode string
 // GetTicketByID retrieves a ticket by ID
 func (r *TicketRepository) GetTicketByID(id int) (*models.Ticket, error) {
 	query := `
-		SELECT id, order_id, ticket_type_id, qr_code, status, created_at
+		SELECT id, order_id, ticket_type_id, qr_code, status, created_at, attendee_name, attendee_email
 		FROM tickets
 		WHERE id = $1`
 
@@ -402,6 +608,8 @@ func (r *TicketRepository) GetTicketByID(id int) (*models.Ticket, error) {
 		&ticket.QRCode,
 		&ticket.Status,
 		&ticket.CreatedAt,
+		&ticket.AttendeeName,
+		&ticket.AttendeeEmail,
 	)
 
 	if err != nil {
@@ -417,7 +625,7 @@ func (r *TicketRepository) GetTicketByID(id int) (*models.Ticket, error) {
 // GetTicketByQRCode retrieves a ticket by QR code
 func (r *TicketRepository) GetTicketByQRCode(qrCode string) (*models.Ticket, error) {
 	query := `
-		SELECT id, order_id, ticket_type_id, qr_code, status, created_at
+		SELECT id, order_id, ticket_type_id, qr_code, status, created_at, attendee_name, attendee_email
 		FROM tickets
 		WHERE qr_code = $1`
 
@@ -429,6 +637,8 @@ func (r *TicketRepository) GetTicketByQRCode(qrCode string) (*models.Ticket, err
 		&ticket.QRCode,
 		&ticket.Status,
 		&ticket.CreatedAt,
+		&ticket.AttendeeName,
+		&ticket.AttendeeEmail,
 	)
 
 	if err != nil {
@@ -444,7 +654,7 @@ func (r *TicketRepository) GetTicketByQRCode(qrCode string) (*models.Ticket, err
 // GetTicketsByOrder retrieves all tickets for an order
 func (r *TicketRepository) GetTicketsByOrder(orderID int) ([]*models.Ticket, error) {
 	query := `
-		SELECT id, order_id, ticket_type_id, qr_code, status, created_at
+		SELECT id, order_id, ticket_type_id, qr_code, status, created_at, attendee_name, attendee_email
 		FROM tickets
 		WHERE order_id = $1
 		ORDER BY created_at ASC`
@@ -465,6 +675,8 @@ func (r *TicketRepository) GetTicketsByOrder(orderID int) ([]*models.Ticket, err
 			&ticket.QRCode,
 			&ticket.Status,
 			&ticket.CreatedAt,
+			&ticket.AttendeeName,
+			&ticket.AttendeeEmail,
 		)
 		if err != nil {
 			return nil, fmt.Errorf("failed to scan ticket: %w", err)
@@ -499,9 +711,20 @@ func (r *TicketRepository) UpdateTicketStatus(id int, status models.TicketStatus
 		}
 	}
 
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	// A refund only applies to a ticket not already refunded, so it is
+	// given back to inventory once
 	query := `UPDATE tickets SET status = $2 WHERE id = $1`
+	if status == models.TicketRefunded {
+		query += ` AND status != $2`
+	}
 
-	result, err := r.db.Exec(query, id, status)
+	result, err := tx.Exec(query, id, status)
 	if err != nil {
 		return fmt.Errorf("failed to update ticket status: %w", err)
 	}
@@ -511,6 +734,44 @@ func (r *TicketRepository) UpdateTicketStatus(id int, status models.TicketStatus
 		return fmt.Errorf("failed to get rows affected: %w", err)
 	}
 
+	if rowsAffected == 0 {
+		if status == models.TicketRefunded {
+			return fmt.Errorf("ticket with id %d not found or already refunded", id)
+		}
+		return fmt.Errorf("ticket with id %d not found", id)
+	}
+
+	// Refunded tickets go back on sale
+	if status == models.TicketRefunded {
+		if _, err := tx.Exec(`
+			UPDATE ticket_types
+			SET sold = sold - 1
+			WHERE id = $1 AND sold > 0`, ticket.TicketTypeID); err != nil {
+			return fmt.Errorf("failed to update sold count: %w", err)
+		}
+	}
+
+	if err = tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit ticket status: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateTicketAttendee sets who a ticket is for
+func (r *TicketRepository) UpdateTicketAttendee(id int, attendee models.TicketAttendee) error {
+	query := `UPDATE tickets SET attendee_name = $2, attendee_email = $3 WHERE id = $1`
+
+	result, err := r.db.Exec(query, id, attendee.Name, attendee.Email)
+	if err != nil {
+		return fmt.Errorf("failed to update ticket attendee: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
 	if rowsAffected == 0 {
 		return fmt.Errorf("ticket with id %d not found", id)
 	}
@@ -597,7 +858,7 @@ func (r *TicketRepository) SearchTickets(filters TicketSearchFilters) ([]*models
 	}
 
 	// Get tickets
-	selectClause := "SELECT tickets.id, tickets.order_id, tickets.ticket_type_id, tickets.qr_code, tickets.status, tickets.created_at"
+	selectClause := "SELECT tickets.id, tickets.order_id, tickets.ticket_type_id, tickets.qr_code, tickets.status, tickets.created_at, tickets.attendee_name, tickets.attendee_email"
 	query := fmt.Sprintf(`
 		%s
 		%s
@@ -624,6 +885,8 @@ func (r *TicketRepository) SearchTickets(filters TicketSearchFilters) ([]*models
 			&ticket.QRCode,
 			&ticket.Status,
 			&ticket.CreatedAt,
+			&ticket.AttendeeName,
+			&ticket.AttendeeEmail,
 		)
 		if err != nil {
 			return nil, 0, fmt.Errorf("failed to scan ticket: %w", err)
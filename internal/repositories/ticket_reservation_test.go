@@ -0,0 +1,281 @@
+package repositories
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/database"
+)
+
+// setupInventoryTestDB connects to the database named by TEST_DATABASE_URL
+// and migrates it. These tests need the real schema and row locks, and they
+// write to it, so they are skipped rather than run against a shared database.
+func setupInventoryTestDB(t *testing.T) *sql.DB {
+	dbURL := os.Getenv("TEST_DATABASE_URL")
+	if dbURL == "" {
+		t.Skip("TEST_DATABASE_URL is not set")
+	}
+
+	db, err := sql.Open("postgres", dbURL)
+	if err != nil {
+		t.Skipf("Failed to connect to test database: %v", err)
+	}
+	if err := db.Ping(); err != nil {
+		t.Skipf("Failed to ping test database: %v", err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	if err := database.NewMigrator(db).RunMigrations(); err != nil {
+		t.Fatalf("Failed to migrate test database: %v", err)
+	}
+
+	return db
+}
+
+// inventoryFixture is an on-sale ticket type and buyers to race for it
+type inventoryFixture struct {
+	eventID      int
+	ticketTypeID int
+	buyers       []int
+}
+
+// createInventoryFixture creates an event with quantity tickets on sale and
+// the buyers, deleting them all when the test ends
+func createInventoryFixture(t *testing.T, db *sql.DB, quantity, buyers int) *inventoryFixture {
+	t.Helper()
+	suffix := time.Now().UnixNano()
+	fixture := &inventoryFixture{}
+
+	for i := 0; i < buyers; i++ {
+		var userID int
+		err := db.QueryRow(`
+			INSERT INTO users (email, password_hash, first_name, last_name, role)
+			VALUES ($1, 'hashedpassword', 'Test', 'Buyer', 'attendee')
+			RETURNING id`, fmt.Sprintf("buyer-%d-%d@example.com", suffix, i)).Scan(&userID)
+		if err != nil {
+			t.Fatalf("Failed to create buyer: %v", err)
+		}
+		fixture.buyers = append(fixture.buyers, userID)
+	}
+
+	err := db.QueryRow(`
+		INSERT INTO events (title, description, start_date, end_date, location, organizer_id, status)
+		VALUES ('Inventory Test', 'Concurrency test event', $1, $2, 'Nairobi', $3, 'published')
+		RETURNING id`, time.Now().Add(48*time.Hour), time.Now().Add(52*time.Hour), fixture.buyers[0]).Scan(&fixture.eventID)
+	if err != nil {
+		t.Fatalf("Failed to create event: %v", err)
+	}
+
+	err = db.QueryRow(`
+		INSERT INTO ticket_types (event_id, name, description, price, quantity, sold, sale_start, sale_end)
+		VALUES ($1, 'General', 'General admission', 1000, $2, 0, $3, $4)
+		RETURNING id`, fixture.eventID, quantity, time.Now().Add(-time.Hour), time.Now().Add(24*time.Hour)).Scan(&fixture.ticketTypeID)
+	if err != nil {
+		t.Fatalf("Failed to create ticket type: %v", err)
+	}
+
+	t.Cleanup(func() {
+		db.Exec(`DELETE FROM tickets WHERE ticket_type_id = $1`, fixture.ticketTypeID)
+		db.Exec(`DELETE FROM orders WHERE event_id = $1`, fixture.eventID)
+		db.Exec(`DELETE FROM ticket_reservations WHERE ticket_type_id = $1`, fixture.ticketTypeID)
+		db.Exec(`DELETE FROM ticket_types WHERE id = $1`, fixture.ticketTypeID)
+		db.Exec(`DELETE FROM events WHERE id = $1`, fixture.eventID)
+		for _, userID := range fixture.buyers {
+			db.Exec(`DELETE FROM users WHERE id = $1`, userID)
+		}
+	})
+
+	return fixture
+}
+
+// createPendingOrder creates a pending order for a buyer
+func createPendingOrder(t *testing.T, db *sql.DB, fixture *inventoryFixture, userID int) int {
+	t.Helper()
+	var orderID int
+	err := db.QueryRow(`
+		INSERT INTO orders (user_id, event_id, order_number, total_amount, status, billing_email, billing_name)
+		VALUES ($1, $2, $3, 1000, 'pending', 'buyer@example.com', 'Test Buyer')
+		RETURNING id`, userID, fixture.eventID, fmt.Sprintf("INV-%d-%d", time.Now().UnixNano(), userID)).Scan(&orderID)
+	if err != nil {
+		t.Fatalf("Failed to create order: %v", err)
+	}
+	return orderID
+}
+
+// ticketsFor is the ticket data completing an order of n tickets
+func ticketsFor(orderID, ticketTypeID, n int) []struct {
+	TicketTypeID int
+	QRCode       string
+} {
+	var tickets []struct {
+		TicketTypeID int
+		QRCode       string
+	}
+	for i := 0; i < n; i++ {
+		tickets = append(tickets, struct {
+			TicketTypeID int
+			QRCode       string
+		}{ticketTypeID, fmt.Sprintf("INV-QR-%d-%d-%d", orderID, i, time.Now().UnixNano())})
+	}
+	return tickets
+}
+
+func TestTicketRepository_HoldTickets_Concurrent(t *testing.T) {
+	db := setupInventoryTestDB(t)
+	repo := NewTicketRepository(db)
+	fixture := createInventoryFixture(t, db, 5, 20)
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(fixture.buyers))
+	for i, userID := range fixture.buyers {
+		wg.Add(1)
+		go func(i, userID int) {
+			defer wg.Done()
+			_, errs[i] = repo.ReserveTickets(fixture.ticketTypeID, 1, userID, 15)
+		}(i, userID)
+	}
+	wg.Wait()
+
+	held := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			held++
+		case !errors.Is(err, ErrInsufficientTickets):
+			t.Errorf("Unexpected reservation error: %v", err)
+		}
+	}
+	if held != 5 {
+		t.Errorf("Expected 5 buyers to hold tickets, got %d", held)
+	}
+
+	ticketType, err := repo.GetTicketTypeByID(fixture.ticketTypeID)
+	if err != nil {
+		t.Fatalf("Failed to get ticket type: %v", err)
+	}
+	if ticketType.Held != 5 || ticketType.Sold != 0 || ticketType.Available() != 0 {
+		t.Errorf("Expected 5 held, 0 sold and none available, got held=%d sold=%d available=%d",
+			ticketType.Held, ticketType.Sold, ticketType.Available())
+	}
+}
+
+func TestOrderRepository_ProcessOrderCompletion_Concurrent(t *testing.T) {
+	db := setupInventoryTestDB(t)
+	tickets := NewTicketRepository(db)
+	orders := NewOrderRepository(db)
+	fixture := createInventoryFixture(t, db, 5, 20)
+
+	orderIDs := make([]int, len(fixture.buyers))
+	for i, userID := range fixture.buyers {
+		orderIDs[i] = createPendingOrder(t, db, fixture, userID)
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, len(orderIDs))
+	for i, orderID := range orderIDs {
+		wg.Add(1)
+		go func(i, orderID int) {
+			defer wg.Done()
+			errs[i] = orders.ProcessOrderCompletion(orderID, fmt.Sprintf("pay-%d", orderID), ticketsFor(orderID, fixture.ticketTypeID, 1))
+		}(i, orderID)
+	}
+	wg.Wait()
+
+	completed := 0
+	for _, err := range errs {
+		switch {
+		case err == nil:
+			completed++
+		case !errors.Is(err, ErrInsufficientTickets):
+			t.Errorf("Unexpected completion error: %v", err)
+		}
+	}
+	if completed != 5 {
+		t.Errorf("Expected 5 orders to complete, got %d", completed)
+	}
+
+	ticketType, err := tickets.GetTicketTypeByID(fixture.ticketTypeID)
+	if err != nil {
+		t.Fatalf("Failed to get ticket type: %v", err)
+	}
+	var issued, pending int
+	db.QueryRow(`SELECT COUNT(*) FROM tickets WHERE ticket_type_id = $1`, fixture.ticketTypeID).Scan(&issued)
+	db.QueryRow(`SELECT COUNT(*) FROM orders WHERE event_id = $1 AND status = 'pending'`, fixture.eventID).Scan(&pending)
+	if ticketType.Sold != 5 || issued != 5 {
+		t.Errorf("Expected 5 sold and issued, got sold=%d issued=%d", ticketType.Sold, issued)
+	}
+	if pending != 15 {
+		t.Errorf("Expected the 15 orders that missed out to stay pending, got %d", pending)
+	}
+}
+
+func TestOrderRepository_ProcessOrderCompletion_RespectsHolds(t *testing.T) {
+	db := setupInventoryTestDB(t)
+	tickets := NewTicketRepository(db)
+	orders := NewOrderRepository(db)
+	fixture := createInventoryFixture(t, db, 5, 2)
+	holder, other := fixture.buyers[0], fixture.buyers[1]
+
+	if _, err := tickets.ReserveTickets(fixture.ticketTypeID, 3, holder, 15); err != nil {
+		t.Fatalf("Failed to hold tickets: %v", err)
+	}
+
+	// Only the 2 tickets nobody holds are left for others
+	otherOrder := createPendingOrder(t, db, fixture, other)
+	err := orders.ProcessOrderCompletion(otherOrder, "pay-other", ticketsFor(otherOrder, fixture.ticketTypeID, 3))
+	if !errors.Is(err, ErrInsufficientTickets) {
+		t.Errorf("Expected held tickets to be unavailable to others, got %v", err)
+	}
+
+	// The holder's order sells their held tickets and consumes the hold
+	holderOrder := createPendingOrder(t, db, fixture, holder)
+	if err := orders.ProcessOrderCompletion(holderOrder, "pay-holder", ticketsFor(holderOrder, fixture.ticketTypeID, 3)); err != nil {
+		t.Fatalf("Expected the holder's order to complete, got %v", err)
+	}
+
+	ticketType, err := tickets.GetTicketTypeByID(fixture.ticketTypeID)
+	if err != nil {
+		t.Fatalf("Failed to get ticket type: %v", err)
+	}
+	if ticketType.Sold != 3 || ticketType.Held != 0 || ticketType.Available() != 2 {
+		t.Errorf("Expected 3 sold, 0 held and 2 available, got sold=%d held=%d available=%d",
+			ticketType.Sold, ticketType.Held, ticketType.Available())
+	}
+
+	// Completing an order twice doesn't sell its tickets twice
+	if err := orders.ProcessOrderCompletion(holderOrder, "pay-holder", ticketsFor(holderOrder, fixture.ticketTypeID, 3)); err == nil {
+		t.Error("Expected completing a completed order to fail")
+	}
+}
+
+func TestTicketRepository_ExpiredHolds(t *testing.T) {
+	db := setupInventoryTestDB(t)
+	repo := NewTicketRepository(db)
+	fixture := createInventoryFixture(t, db, 5, 2)
+
+	_, err := db.Exec(`
+		INSERT INTO ticket_reservations (ticket_type_id, user_id, quantity, expires_at)
+		VALUES ($1, $2, 5, $3)`, fixture.ticketTypeID, fixture.buyers[0], time.Now().Add(-time.Minute))
+	if err != nil {
+		t.Fatalf("Failed to create expired hold: %v", err)
+	}
+
+	// Expired holds no longer count against availability
+	if _, err := repo.ReserveTickets(fixture.ticketTypeID, 5, fixture.buyers[1], 15); err != nil {
+		t.Errorf("Expected expired hold to be ignored, got %v", err)
+	}
+
+	if _, err := repo.DeleteExpiredReservations(); err != nil {
+		t.Fatalf("Failed to delete expired reservations: %v", err)
+	}
+	var remaining int
+	db.QueryRow(`SELECT COUNT(*) FROM ticket_reservations WHERE ticket_type_id = $1`, fixture.ticketTypeID).Scan(&remaining)
+	if remaining != 1 {
+		t.Errorf("Expected only the unexpired hold to remain, got %d", remaining)
+	}
+}
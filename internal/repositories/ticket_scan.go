@@ -0,0 +1,306 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// TicketScanRepository handles ticket scan audit data operations
+type TicketScanRepository struct {
+	db *sql.DB
+}
+
+// NewTicketScanRepository creates a new ticket scan repository
+func NewTicketScanRepository(db *sql.DB) *TicketScanRepository {
+	return &TicketScanRepository{db: db}
+}
+
+// Create records a ticket scan attempt. Scans without a time are recorded as
+// scanned now.
+func (r *TicketScanRepository) Create(scan *models.TicketScan) error {
+	query := `
+		INSERT INTO ticket_scans (event_id, ticket_id, qr_code, result, reason, gate, device, staff_user_id, scanned_at, client_id, synced_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, COALESCE($9, CURRENT_TIMESTAMP), NULLIF($10, ''), $11)
+		RETURNING id, scanned_at`
+
+	var scannedAt sql.NullTime
+	if !scan.ScannedAt.IsZero() {
+		scannedAt = sql.NullTime{Time: scan.ScannedAt, Valid: true}
+	}
+
+	err := r.db.QueryRow(query,
+		scan.EventID,
+		scan.TicketID,
+		scan.QRCode,
+		scan.Result,
+		scan.Reason,
+		scan.Gate,
+		scan.Device,
+		scan.StaffUserID,
+		scannedAt,
+		scan.ClientID,
+		scan.SyncedAt,
+	).Scan(&scan.ID, &scan.ScannedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create ticket scan: %w", err)
+	}
+
+	return nil
+}
+
+// GetByEvent retrieves every scan attempt for an event in the order they happened
+func (r *TicketScanRepository) GetByEvent(eventID int) ([]*models.TicketScan, error) {
+	query := `
+		SELECT s.id, s.event_id, s.ticket_id, s.qr_code, s.result, s.reason, s.gate, s.device,
+		       s.staff_user_id, s.scanned_at, COALESCE(s.client_id, ''), s.synced_at,
+		       COALESCE(u.first_name || ' ' || u.last_name, ''), COALESCE(u.email, ''),
+		       COALESCE(t.attendee_name, '')
+		FROM ticket_scans s
+		LEFT JOIN users u ON s.staff_user_id = u.id
+		LEFT JOIN tickets t ON s.ticket_id = t.id
+		WHERE s.event_id = $1
+		ORDER BY s.scanned_at ASC, s.id ASC`
+
+	rows, err := r.db.Query(query, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query ticket scans: %w", err)
+	}
+	defer rows.Close()
+
+	var scans []*models.TicketScan
+	for rows.Next() {
+		scan := &models.TicketScan{}
+		var ticketID, staffUserID sql.NullInt64
+		var syncedAt sql.NullTime
+
+		err := rows.Scan(
+			&scan.ID,
+			&scan.EventID,
+			&ticketID,
+			&scan.QRCode,
+			&scan.Result,
+			&scan.Reason,
+			&scan.Gate,
+			&scan.Device,
+			&staffUserID,
+			&scan.ScannedAt,
+			&scan.ClientID,
+			&syncedAt,
+			&scan.StaffName,
+			&scan.StaffEmail,
+			&scan.AttendeeName,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan ticket scan: %w", err)
+		}
+
+		if ticketID.Valid {
+			id := int(ticketID.Int64)
+			scan.TicketID = &id
+		}
+		if staffUserID.Valid {
+			id := int(staffUserID.Int64)
+			scan.StaffUserID = &id
+		}
+		if syncedAt.Valid {
+			scan.SyncedAt = &syncedAt.Time
+		}
+
+		scans = append(scans, scan)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating ticket scans: %w", err)
+	}
+
+	return scans, nil
+}
+
+// GetCheckInStats counts an event's check-ins in total, per 15 minutes, by
+// ticket type, by gate and by device. Intervals with no check-ins are left out.
+func (r *TicketScanRepository) GetCheckInStats(eventID int) (*models.CheckInStats, error) {
+	stats := &models.CheckInStats{EventID: eventID}
+
+	var lastScanAt sql.NullTime
+	err := r.db.QueryRow(`
+		SELECT COUNT(CASE WHEN result = 'accepted' THEN 1 END),
+		       COUNT(CASE WHEN result <> 'accepted' THEN 1 END),
+		       MAX(scanned_at)
+		FROM ticket_scans
+		WHERE event_id = $1`, eventID).Scan(&stats.CheckedIn, &stats.Rejected, &lastScanAt)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count ticket scans: %w", err)
+	}
+	if lastScanAt.Valid {
+		stats.LastScanAt = &lastScanAt.Time
+	}
+
+	// Refunded and cancelled tickets aren't expected at the door
+	rows, err := r.db.Query(`
+		SELECT tt.name, COUNT(t.id), COUNT(s.id)
+		FROM ticket_types tt
+		LEFT JOIN tickets t ON t.ticket_type_id = tt.id AND t.status IN ('active', 'used')
+		LEFT JOIN ticket_scans s ON s.ticket_id = t.id AND s.result = 'accepted'
+		WHERE tt.event_id = $1
+		GROUP BY tt.id, tt.name
+		ORDER BY tt.id`, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count check-ins by ticket type: %w", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var count models.CheckInCount
+		if err := rows.Scan(&count.Label, &count.Total, &count.CheckedIn); err != nil {
+			return nil, fmt.Errorf("failed to scan ticket type check-ins: %w", err)
+		}
+		stats.TotalTickets += count.Total
+		stats.ByTicketType = append(stats.ByTicketType, count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating ticket type check-ins: %w", err)
+	}
+
+	stats.Intervals, err = r.getCheckInIntervals(eventID)
+	if err != nil {
+		return nil, err
+	}
+	stats.ByGate, err = r.countCheckInsBy(eventID, "gate")
+	if err != nil {
+		return nil, err
+	}
+	stats.ByDevice, err = r.countCheckInsBy(eventID, "device")
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// getCheckInIntervals counts an event's check-ins per CheckInIntervalLength
+func (r *TicketScanRepository) getCheckInIntervals(eventID int) ([]models.CheckInInterval, error) {
+	seconds := int(models.CheckInIntervalLength.Seconds())
+	rows, err := r.db.Query(`
+		SELECT to_timestamp(floor(extract(epoch FROM scanned_at) / $2) * $2) AS interval_start, COUNT(*)
+		FROM ticket_scans
+		WHERE event_id = $1 AND result = 'accepted'
+		GROUP BY interval_start
+		ORDER BY interval_start`, eventID, seconds)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count check-ins by interval: %w", err)
+	}
+	defer rows.Close()
+
+	var intervals []models.CheckInInterval
+	for rows.Next() {
+		var interval models.CheckInInterval
+		if err := rows.Scan(&interval.Start, &interval.CheckedIn); err != nil {
+			return nil, fmt.Errorf("failed to scan check-in interval: %w", err)
+		}
+		intervals = append(intervals, interval)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating check-in intervals: %w", err)
+	}
+
+	return intervals, nil
+}
+
+// countCheckInsBy counts an event's check-ins by the gate or device column,
+// busiest first. Scans that didn't name one are counted under "".
+func (r *TicketScanRepository) countCheckInsBy(eventID int, column string) ([]models.CheckInCount, error) {
+	if column != "gate" && column != "device" {
+		return nil, fmt.Errorf("cannot count check-ins by %s", column)
+	}
+
+	rows, err := r.db.Query(`
+		SELECT `+column+`, COUNT(*)
+		FROM ticket_scans
+		WHERE event_id = $1 AND result = 'accepted'
+		GROUP BY `+column+`
+		ORDER BY COUNT(*) DESC, `+column, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count check-ins by %s: %w", column, err)
+	}
+	defer rows.Close()
+
+	var counts []models.CheckInCount
+	for rows.Next() {
+		var count models.CheckInCount
+		if err := rows.Scan(&count.Label, &count.CheckedIn); err != nil {
+			return nil, fmt.Errorf("failed to scan %s check-ins: %w", column, err)
+		}
+		counts = append(counts, count)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating %s check-ins: %w", column, err)
+	}
+
+	return counts, nil
+}
+
+// GetManifestTickets retrieves the tickets expected at an event's door, used
+// or not, for its check-in manifest
+func (r *TicketScanRepository) GetManifestTickets(eventID int) ([]models.ManifestTicket, error) {
+	query := `
+		SELECT t.id, t.qr_code, tt.name, COALESCE(t.attendee_name, ''), t.status = 'used'
+		FROM tickets t
+		JOIN ticket_types tt ON t.ticket_type_id = tt.id
+		WHERE tt.event_id = $1 AND t.status IN ('active', 'used')
+		ORDER BY t.id`
+
+	rows, err := r.db.Query(query, eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query manifest tickets: %w", err)
+	}
+	defer rows.Close()
+
+	tickets := []models.ManifestTicket{}
+	for rows.Next() {
+		var ticket models.ManifestTicket
+		var qrCode string
+		if err := rows.Scan(&ticket.TicketID, &qrCode, &ticket.TicketType, &ticket.AttendeeName, &ticket.CheckedIn); err != nil {
+			return nil, fmt.Errorf("failed to scan manifest ticket: %w", err)
+		}
+		ticket.QRCodeHash = models.HashQRCode(qrCode)
+		tickets = append(tickets, ticket)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating manifest tickets: %w", err)
+	}
+
+	return tickets, nil
+}
+
+// GetSyncedClientIDs returns which of the scanning app's scan ids have
+// already been uploaded for an event
+func (r *TicketScanRepository) GetSyncedClientIDs(eventID int, clientIDs []string) (map[string]bool, error) {
+	synced := make(map[string]bool)
+	if len(clientIDs) == 0 {
+		return synced, nil
+	}
+
+	rows, err := r.db.Query(`
+		SELECT client_id FROM ticket_scans
+		WHERE event_id = $1 AND client_id = ANY($2)`, eventID, pq.Array(clientIDs))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query synced scans: %w", err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var clientID string
+		if err := rows.Scan(&clientID); err != nil {
+			return nil, fmt.Errorf("failed to scan synced scan: %w", err)
+		}
+		synced[clientID] = true
+	}
+	if err := rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating synced scans: %w", err)
+	}
+
+	return synced, nil
+}
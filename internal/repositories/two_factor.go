@@ -0,0 +1,193 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// TwoFactorRepository handles two-factor authentication data operations
+type TwoFactorRepository struct {
+	db *sql.DB
+}
+
+// NewTwoFactorRepository creates a new two-factor authentication repository
+func NewTwoFactorRepository(db *sql.DB) *TwoFactorRepository {
+	return &TwoFactorRepository{db: db}
+}
+
+// GetByUser retrieves the two-factor enrollment for a user
+func (r *TwoFactorRepository) GetByUser(userID int) (*models.TwoFactorAuth, error) {
+	query := `
+		SELECT user_id, secret, enabled_at, last_used_step, created_at
+		FROM user_two_factor
+		WHERE user_id = $1`
+
+	tfa := &models.TwoFactorAuth{}
+	var enabledAt sql.NullTime
+	err := r.db.QueryRow(query, userID).Scan(
+		&tfa.UserID,
+		&tfa.Secret,
+		&enabledAt,
+		&tfa.LastUsedStep,
+		&tfa.CreatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("two-factor authentication not found")
+		}
+		return nil, fmt.Errorf("failed to get two-factor authentication: %w", err)
+	}
+
+	if enabledAt.Valid {
+		tfa.EnabledAt = &enabledAt.Time
+	}
+
+	return tfa, nil
+}
+
+// SavePending stores a new, not yet confirmed secret for a user, replacing any previous one
+func (r *TwoFactorRepository) SavePending(userID int, secret string) error {
+	query := `
+		INSERT INTO user_two_factor (user_id, secret, enabled_at, last_used_step, created_at)
+		VALUES ($1, $2, NULL, 0, CURRENT_TIMESTAMP)
+		ON CONFLICT (user_id) DO UPDATE
+		SET secret = EXCLUDED.secret, enabled_at = NULL, last_used_step = 0, created_at = CURRENT_TIMESTAMP`
+
+	if _, err := r.db.Exec(query, userID, secret); err != nil {
+		return fmt.Errorf("failed to save two-factor secret: %w", err)
+	}
+
+	return nil
+}
+
+// Enable marks a user's enrollment as confirmed and records the step of the confirming code
+func (r *TwoFactorRepository) Enable(userID int, step int64) error {
+	query := `
+		UPDATE user_two_factor
+		SET enabled_at = CURRENT_TIMESTAMP, last_used_step = $2
+		WHERE user_id = $1`
+
+	result, err := r.db.Exec(query, userID, step)
+	if err != nil {
+		return fmt.Errorf("failed to enable two-factor authentication: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	if rowsAffected == 0 {
+		return fmt.Errorf("two-factor authentication not found")
+	}
+
+	return nil
+}
+
+// UpdateLastUsedStep records the time step of an accepted code. It returns
+// false if the step is not newer than the last one used, so that a code can
+// only be used once.
+func (r *TwoFactorRepository) UpdateLastUsedStep(userID int, step int64) (bool, error) {
+	query := `
+		UPDATE user_two_factor
+		SET last_used_step = $2
+		WHERE user_id = $1 AND last_used_step < $2`
+
+	result, err := r.db.Exec(query, userID, step)
+	if err != nil {
+		return false, fmt.Errorf("failed to update two-factor step: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// Delete removes a user's two-factor enrollment and backup codes
+func (r *TwoFactorRepository) Delete(userID int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM user_backup_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete backup codes: %w", err)
+	}
+
+	if _, err := tx.Exec(`DELETE FROM user_two_factor WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete two-factor authentication: %w", err)
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// ReplaceBackupCodes replaces all of a user's backup codes with the given hashes
+func (r *TwoFactorRepository) ReplaceBackupCodes(userID int, codeHashes []string) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`DELETE FROM user_backup_codes WHERE user_id = $1`, userID); err != nil {
+		return fmt.Errorf("failed to delete backup codes: %w", err)
+	}
+
+	for _, hash := range codeHashes {
+		if _, err := tx.Exec(`INSERT INTO user_backup_codes (user_id, code_hash) VALUES ($1, $2)`, userID, hash); err != nil {
+			return fmt.Errorf("failed to create backup code: %w", err)
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
+
+// UseBackupCode consumes an unused backup code. It returns false if no unused
+// code matches the hash.
+func (r *TwoFactorRepository) UseBackupCode(userID int, codeHash string) (bool, error) {
+	query := `
+		UPDATE user_backup_codes
+		SET used_at = CURRENT_TIMESTAMP
+		WHERE id = (
+			SELECT id FROM user_backup_codes
+			WHERE user_id = $1 AND code_hash = $2 AND used_at IS NULL
+			LIMIT 1
+		) AND used_at IS NULL`
+
+	result, err := r.db.Exec(query, userID, codeHash)
+	if err != nil {
+		return false, fmt.Errorf("failed to use backup code: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return false, fmt.Errorf("failed to get rows affected: %w", err)
+	}
+
+	return rowsAffected > 0, nil
+}
+
+// CountUnusedBackupCodes returns the number of backup codes a user has left
+func (r *TwoFactorRepository) CountUnusedBackupCodes(userID int) (int, error) {
+	var count int
+	err := r.db.QueryRow(`SELECT COUNT(*) FROM user_backup_codes WHERE user_id = $1 AND used_at IS NULL`, userID).Scan(&count)
+	if err != nil {
+		return 0, fmt.Errorf("failed to count backup codes: %w", err)
+	}
+
+	return count, nil
+}
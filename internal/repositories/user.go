@@ -89,7 +89,7 @@ func (r *UserRepository) GetByID(id int) (*models.User, error) {
 	query := `
 		SELECT id, email, password_hash, first_name, last_name, role, email_verified, email_verified_at, verification_token, created_at, updated_at
 		FROM users
-		WHERE id = $1`
+		WHERE id = $1 AND deleted_at IS NULL`
 
 	user := &models.User{}
 	var verificationToken sql.NullString
@@ -127,7 +127,7 @@ func (r *UserRepository) GetByEmail(email string) (*models.User, error) {
 	query := `
 		SELECT id, email, password_hash, first_name, last_name, role, is_active, email_verified, email_verified_at, verification_token, created_at, updated_at
 		FROM users
-		WHERE email = $1`
+		WHERE email = $1 AND deleted_at IS NULL`
 
 	user := &models.User{}
 	var verificationToken sql.NullString
@@ -225,9 +225,10 @@ func (r *UserRepository) UpdatePassword(id int, passwordHash string) error {
 	return nil
 }
 
-// Delete deletes a user by ID
+// Delete moves a user to the trash. Deleted users can't sign in and are left
+// out of lookups until restored or purged.
 func (r *UserRepository) Delete(id int) error {
-	query := `DELETE FROM users WHERE id = $1`
+	query := `UPDATE users SET deleted_at = NOW() WHERE id = $1 AND deleted_at IS NULL`
 
 	result, err := r.db.Exec(query, id)
 	if err != nil {
@@ -249,7 +250,7 @@ func (r *UserRepository) Delete(id int) error {
 // Search searches for users with filters and pagination
 func (r *UserRepository) Search(filters UserSearchFilters) ([]*models.User, int, error) {
 	// Build WHERE clause
-	var conditions []string
+	conditions := []string{"deleted_at IS NULL"}
 	var args []interface{}
 	argIndex := 1
 
@@ -355,7 +356,7 @@ func (r *UserRepository) GetByRole(role models.UserRole) ([]*models.User, error)
 	query := `
 		SELECT id, email, first_name, last_name, role, created_at, updated_at
 		FROM users
-		WHERE role = $1
+		WHERE role = $1 AND deleted_at IS NULL
 		ORDER BY created_at DESC`
 
 	rows, err := r.db.Query(query, role)
@@ -411,7 +412,7 @@ func (r *UserRepository) GetUserBySession(sessionID string) (*models.User, error
 		SELECT u.id, u.email, u.password_hash, u.first_name, u.last_name, u.role, u.created_at, u.updated_at
 		FROM users u
 		JOIN sessions s ON u.id = s.user_id
-		WHERE s.id = $1 AND s.expires_at > $2`
+		WHERE s.id = $1 AND s.expires_at > $2 AND u.deleted_at IS NULL`
 
 	user := &models.User{}
 	err := r.db.QueryRow(query, sessionID, time.Now()).Scan(
@@ -502,7 +503,7 @@ func (r *UserRepository) GetByVerificationToken(token string) (*models.User, err
 	query := `
 		SELECT id, email, password_hash, first_name, last_name, role, email_verified, email_verified_at, verification_token, created_at, updated_at
 		FROM users
-		WHERE verification_token = $1`
+		WHERE verification_token = $1 AND deleted_at IS NULL`
 
 	user := &models.User{}
 	var verificationToken sql.NullString
@@ -611,7 +612,7 @@ func (r *UserRepository) GetByPasswordResetToken(token string) (*models.User, er
 		SELECT id, email, password_hash, first_name, last_name, role, email_verified, email_verified_at, 
 		       verification_token, password_reset_token, password_reset_expires, created_at, updated_at
 		FROM users 
-		WHERE password_reset_token = $1 AND password_reset_expires > $2`
+		WHERE password_reset_token = $1 AND password_reset_expires > $2 AND deleted_at IS NULL`
 	
 	user := &models.User{}
 	var emailVerifiedAt sql.NullTime
@@ -711,7 +712,7 @@ func (r *UserRepository) GetUsersWithPagination(page, limit int, search, roleFil
 	offset := (page - 1) * limit
 	
 	// Build the WHERE clause
-	var whereConditions []string
+	whereConditions := []string{"deleted_at IS NULL"}
 	var args []interface{}
 	argIndex := 1
 	
@@ -795,7 +796,7 @@ func (r *UserRepository) GetUsersWithPagination(page, limit int, search, roleFil
 // GetUserCount returns the total number of users
 func (r *UserRepository) GetUserCount() (int, error) {
 	var count int
-	err := r.db.QueryRow("SELECT COUNT(*) FROM users").Scan(&count)
+	err := r.db.QueryRow("SELECT COUNT(*) FROM users WHERE deleted_at IS NULL").Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get user count: %w", err)
 	}
@@ -805,7 +806,7 @@ func (r *UserRepository) GetUserCount() (int, error) {
 // GetActiveUserCount returns the number of active users
 func (r *UserRepository) GetActiveUserCount() (int, error) {
 	var count int
-	err := r.db.QueryRow("SELECT COUNT(*) FROM users WHERE is_active = true").Scan(&count)
+	err := r.db.QueryRow("SELECT COUNT(*) FROM users WHERE is_active = true AND deleted_at IS NULL").Scan(&count)
 	if err != nil {
 		return 0, fmt.Errorf("failed to get active user count: %w", err)
 	}
@@ -830,4 +831,60 @@ func (r *UserRepository) UpdateUserStatus(userID int, isActive bool) error {
 		return fmt.Errorf("failed to update user status: %w", err)
 	}
 	return nil
-}
\ No newline at end of file
+}
+// GetLockedAccounts returns the accounts currently locked after too many
+// failed logins, soonest to unlock first
+func (r *UserRepository) GetLockedAccounts() ([]*models.LockedAccount, error) {
+	query := `
+		SELECT id, email, first_name, last_name, locked_until, last_attempt
+		FROM users
+		WHERE locked_until > NOW()
+		ORDER BY locked_until ASC
+	`
+
+	rows, err := r.db.Query(query)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get locked accounts: %w", err)
+	}
+	defer rows.Close()
+
+	var accounts []*models.LockedAccount
+	for rows.Next() {
+		account := &models.LockedAccount{}
+		if err := rows.Scan(&account.UserID, &account.Email, &account.FirstName, &account.LastName, &account.LockedUntil, &account.LastAttempt); err != nil {
+			return nil, fmt.Errorf("failed to scan locked account: %w", err)
+		}
+		accounts = append(accounts, account)
+	}
+
+	return accounts, rows.Err()
+}
+
+// UnlockAccount lifts a login lock and clears the failed attempts, revoking
+// any unlock links emailed for it. It returns models.ErrAccountNotLocked when
+// the account isn't locked.
+func (r *UserRepository) UnlockAccount(userID int) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	query := `
+		UPDATE users SET locked_until = NULL, attempt_count = 0, last_attempt = NULL, updated_at = $1
+		WHERE id = $2 AND locked_until > NOW()
+	`
+	result, err := tx.Exec(query, time.Now(), userID)
+	if err != nil {
+		return fmt.Errorf("failed to unlock account: %w", err)
+	}
+	if rowsAffected, _ := result.RowsAffected(); rowsAffected == 0 {
+		return models.ErrAccountNotLocked
+	}
+
+	if _, err := tx.Exec(`DELETE FROM account_unlock_tokens WHERE user_id = $1 AND used_at IS NULL`, userID); err != nil {
+		return fmt.Errorf("failed to revoke unlock links: %w", err)
+	}
+
+	return tx.Commit()
+}
@@ -0,0 +1,305 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+
+	"github.com/lib/pq"
+)
+
+// VenueRepository handles organizers' venues and the events held at them
+type VenueRepository struct {
+	db *sql.DB
+}
+
+// NewVenueRepository creates a new venue repository
+func NewVenueRepository(db *sql.DB) *VenueRepository {
+	return &VenueRepository{db: db}
+}
+
+const venueColumns = `v.id, v.organizer_id, v.slug, v.name, v.address, v.city, v.capacity, v.latitude, v.longitude,
+	v.description, v.images, v.created_at, v.updated_at`
+
+func scanVenue(row interface{ Scan(...interface{}) error }, extra ...interface{}) (*models.Venue, error) {
+	venue := &models.Venue{}
+	var latitude, longitude sql.NullFloat64
+	dest := []interface{}{&venue.ID, &venue.OrganizerID, &venue.Slug, &venue.Name, &venue.Address, &venue.City,
+		&venue.Capacity, &latitude, &longitude, &venue.Description, pq.Array(&venue.Images), &venue.CreatedAt, &venue.UpdatedAt}
+	if err := row.Scan(append(dest, extra...)...); err != nil {
+		return nil, err
+	}
+	if latitude.Valid && longitude.Valid {
+		venue.Coordinates = &models.GeoPoint{Latitude: latitude.Float64, Longitude: longitude.Float64}
+	}
+	return venue, nil
+}
+
+// venueCoordinates returns the latitude and longitude arguments of a venue
+func venueCoordinates(venue *models.Venue) (sql.NullFloat64, sql.NullFloat64) {
+	if venue.Coordinates == nil {
+		return sql.NullFloat64{}, sql.NullFloat64{}
+	}
+	return sql.NullFloat64{Float64: venue.Coordinates.Latitude, Valid: true},
+		sql.NullFloat64{Float64: venue.Coordinates.Longitude, Valid: true}
+}
+
+// Create creates a venue, adding a numeric suffix to its slug if another
+// venue has it
+func (r *VenueRepository) Create(venue *models.Venue) error {
+	rows, err := r.db.Query(`
+		SELECT slug FROM venues WHERE slug = $1 OR slug LIKE $1 || '-%'`, venue.Slug)
+	if err != nil {
+		return fmt.Errorf("failed to check venue slugs: %w", err)
+	}
+	defer rows.Close()
+
+	taken := make(map[string]bool)
+	for rows.Next() {
+		var slug string
+		if err := rows.Scan(&slug); err != nil {
+			return fmt.Errorf("failed to scan venue slug: %w", err)
+		}
+		taken[slug] = true
+	}
+
+	if err = rows.Err(); err != nil {
+		return fmt.Errorf("error iterating venue slugs: %w", err)
+	}
+
+	images := venue.Images
+	if images == nil {
+		images = []string{}
+	}
+
+	venue.Slug = models.UniqueEventSlug(venue.Slug, taken)
+	latitude, longitude := venueCoordinates(venue)
+	err = r.db.QueryRow(`
+		INSERT INTO venues (organizer_id, slug, name, address, city, capacity, latitude, longitude, description, images)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
+		RETURNING id, created_at, updated_at`,
+		venue.OrganizerID, venue.Slug, venue.Name, venue.Address, venue.City, venue.Capacity,
+		latitude, longitude, venue.Description, pq.Array(images)).Scan(&venue.ID, &venue.CreatedAt, &venue.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to create venue: %w", err)
+	}
+	return nil
+}
+
+// GetByID retrieves a venue by ID
+func (r *VenueRepository) GetByID(id int) (*models.Venue, error) {
+	venue, err := scanVenue(r.db.QueryRow(`SELECT `+venueColumns+` FROM venues v WHERE v.id = $1`, id))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("venue with id %d not found", id)
+		}
+		return nil, fmt.Errorf("failed to get venue: %w", err)
+	}
+	return venue, nil
+}
+
+// GetBySlug retrieves a venue by its URL slug
+func (r *VenueRepository) GetBySlug(slug string) (*models.Venue, error) {
+	venue, err := scanVenue(r.db.QueryRow(`SELECT `+venueColumns+` FROM venues v WHERE v.slug = $1`, slug))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, fmt.Errorf("venue with slug %s not found", slug)
+		}
+		return nil, fmt.Errorf("failed to get venue: %w", err)
+	}
+	return venue, nil
+}
+
+// GetByOrganizer retrieves an organizer's venues by name, with how many of
+// their upcoming events are at each
+func (r *VenueRepository) GetByOrganizer(organizerID int) ([]*models.Venue, error) {
+	query := `
+		SELECT ` + venueColumns + `,
+		       (SELECT COUNT(*) FROM events e WHERE e.venue_id = v.id AND e.deleted_at IS NULL AND e.end_date > NOW())
+		FROM venues v
+		WHERE v.organizer_id = $1
+		ORDER BY lower(v.name), v.city`
+
+	rows, err := r.db.Query(query, organizerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get venues: %w", err)
+	}
+	defer rows.Close()
+
+	var venues []*models.Venue
+	for rows.Next() {
+		var upcoming int
+		venue, err := scanVenue(rows, &upcoming)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan venue: %w", err)
+		}
+		venue.UpcomingEvents = upcoming
+		venues = append(venues, venue)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating venues: %w", err)
+	}
+
+	return venues, nil
+}
+
+// Update saves an edited venue, and moves the upcoming events held at it to
+// its new name and city. The slug is kept so links to the venue keep working.
+func (r *VenueRepository) Update(venue *models.Venue) error {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	images := venue.Images
+	if images == nil {
+		images = []string{}
+	}
+
+	venue.UpdatedAt = time.Now()
+	latitude, longitude := venueCoordinates(venue)
+	result, err := tx.Exec(`
+		UPDATE venues
+		SET name = $3, address = $4, city = $5, capacity = $6, latitude = $7, longitude = $8,
+		    description = $9, images = $10, updated_at = $11
+		WHERE id = $1 AND organizer_id = $2`,
+		venue.ID, venue.OrganizerID, venue.Name, venue.Address, venue.City, venue.Capacity,
+		latitude, longitude, venue.Description, pq.Array(images), venue.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to update venue: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("venue not found")
+	}
+
+	if err := setVenueEventLocations(tx, venue, 0); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit venue update: %w", err)
+	}
+	return nil
+}
+
+// Delete deletes an organizer's venue. Events held at it keep their location.
+func (r *VenueRepository) Delete(id, organizerID int) error {
+	result, err := r.db.Exec(`DELETE FROM venues WHERE id = $1 AND organizer_id = $2`, id, organizerID)
+	if err != nil {
+		return fmt.Errorf("failed to delete venue: %w", err)
+	}
+
+	rowsAffected, err := result.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to get rows affected: %w", err)
+	}
+	if rowsAffected == 0 {
+		return fmt.Errorf("venue not found")
+	}
+	return nil
+}
+
+// GetEventVenue retrieves the venue an event is held at, or nil if it has none
+func (r *VenueRepository) GetEventVenue(eventID int) (*models.Venue, error) {
+	venue, err := scanVenue(r.db.QueryRow(`
+		SELECT `+venueColumns+`
+		FROM events e
+		JOIN venues v ON v.id = e.venue_id
+		WHERE e.id = $1`, eventID))
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get event venue: %w", err)
+	}
+	return venue, nil
+}
+
+// SetEventVenue sets the venue an event is held at, or clears it when venue
+// is nil. The event's location is set from the venue, and so are its
+// coordinates if the venue has them, so the event isn't geocoded again.
+func (r *VenueRepository) SetEventVenue(eventID int, venue *models.Venue) error {
+	if venue == nil {
+		if _, err := r.db.Exec(`UPDATE events SET venue_id = NULL WHERE id = $1 AND venue_id IS NOT NULL`, eventID); err != nil {
+			return fmt.Errorf("failed to clear event venue: %w", err)
+		}
+		return nil
+	}
+
+	tx, err := r.db.Begin()
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(`UPDATE events SET venue_id = $2 WHERE id = $1`, eventID, venue.ID); err != nil {
+		return fmt.Errorf("failed to set event venue: %w", err)
+	}
+	if err := setVenueEventLocations(tx, venue, eventID); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("failed to commit event venue: %w", err)
+	}
+	return nil
+}
+
+// setVenueEventLocations sets the location of one of the venue's events, or
+// of all its upcoming events when eventID is 0, from the venue along with
+// its coordinates if it has them
+func setVenueEventLocations(tx *sql.Tx, venue *models.Venue, eventID int) error {
+	latitude, longitude := venueCoordinates(venue)
+	query := `
+		UPDATE events
+		SET location = $2,
+		    latitude = CASE WHEN $3::double precision IS NULL THEN latitude ELSE $3 END,
+		    longitude = CASE WHEN $3::double precision IS NULL THEN longitude ELSE $4 END,
+		    geocoded_location = CASE WHEN $3::double precision IS NULL THEN geocoded_location ELSE $2 END
+		WHERE venue_id = $1 AND deleted_at IS NULL
+		  AND (($5 = 0 AND end_date > NOW()) OR id = $5)`
+
+	if _, err := tx.Exec(query, venue.ID, venue.Location(), latitude, longitude, eventID); err != nil {
+		return fmt.Errorf("failed to update venue event locations: %w", err)
+	}
+	return nil
+}
+
+// GetEvents retrieves the published events held at a venue, soonest first
+func (r *VenueRepository) GetEvents(venueID int) ([]*models.Event, error) {
+	query := `
+		SELECT id, title, description, start_date, end_date, location, category_id, organizer_id, image_url, image_key, image_size, image_format, image_width, image_height, image_uploaded_at, image_alt_text, image_variants, slug, status, created_at, updated_at
+		FROM events
+		WHERE venue_id = $1 AND status = $2 AND deleted_at IS NULL
+		ORDER BY start_date`
+
+	rows, err := r.db.Query(query, venueID, models.StatusPublished)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query venue events: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*models.Event
+	for rows.Next() {
+		event, err := scanEvent(rows)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan venue event: %w", err)
+		}
+		events = append(events, event)
+	}
+
+	if err = rows.Err(); err != nil {
+		return nil, fmt.Errorf("error iterating venue events: %w", err)
+	}
+
+	return events, nil
+}
@@ -0,0 +1,63 @@
+package repositories
+
+import (
+	"database/sql"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// WaitingRoomRepository handles event waiting room settings
+type WaitingRoomRepository struct {
+	db *sql.DB
+}
+
+// NewWaitingRoomRepository creates a new waiting room repository
+func NewWaitingRoomRepository(db *sql.DB) *WaitingRoomRepository {
+	return &WaitingRoomRepository{db: db}
+}
+
+// Get retrieves an event's waiting room settings, falling back to the defaults
+func (r *WaitingRoomRepository) Get(eventID int) (*models.WaitingRoom, error) {
+	query := `
+		SELECT event_id, enabled, opens_at, admit_per_minute, updated_at
+		FROM event_waiting_rooms
+		WHERE event_id = $1`
+
+	room := &models.WaitingRoom{}
+	err := r.db.QueryRow(query, eventID).Scan(
+		&room.EventID,
+		&room.Enabled,
+		&room.OpensAt,
+		&room.AdmitPerMinute,
+		&room.UpdatedAt,
+	)
+	if err != nil {
+		if err == sql.ErrNoRows {
+			return models.DefaultWaitingRoom(eventID), nil
+		}
+		return nil, fmt.Errorf("failed to get waiting room: %w", err)
+	}
+
+	return room, nil
+}
+
+// Save creates or updates an event's waiting room settings
+func (r *WaitingRoomRepository) Save(room *models.WaitingRoom) error {
+	query := `
+		INSERT INTO event_waiting_rooms (event_id, enabled, opens_at, admit_per_minute, updated_at)
+		VALUES ($1, $2, $3, $4, NOW())
+		ON CONFLICT (event_id) DO UPDATE SET
+			enabled = EXCLUDED.enabled,
+			opens_at = EXCLUDED.opens_at,
+			admit_per_minute = EXCLUDED.admit_per_minute,
+			updated_at = NOW()
+		RETURNING updated_at`
+
+	err := r.db.QueryRow(query, room.EventID, room.Enabled, room.OpensAt, room.AdmitPerMinute).Scan(&room.UpdatedAt)
+	if err != nil {
+		return fmt.Errorf("failed to save waiting room: %w", err)
+	}
+
+	return nil
+}
@@ -261,14 +261,35 @@ func (r *WithdrawalRepository) UpdateStatus(id int, status models.WithdrawalStat
 	return nil
 }
 
+// CountRefundingCancellations returns how many of an organizer's cancelled
+// events are still refunding ticket holders
+func (r *WithdrawalRepository) CountRefundingCancellations(organizerID int) (int, error) {
+	query := `
+		SELECT COUNT(*)
+		FROM event_cancellations c
+		JOIN events e ON e.id = c.event_id
+		WHERE e.organizer_id = $1 AND c.status = 'refunding'`
+
+	var count int
+	if err := r.db.QueryRow(query, organizerID).Scan(&count); err != nil {
+		return 0, fmt.Errorf("failed to count refunding cancellations: %w", err)
+	}
+
+	return count, nil
+}
+
 // GetOrganizerBalance calculates available balance for an organizer
 func (r *WithdrawalRepository) GetOrganizerBalance(organizerID int) (float64, error) {
-	// Get total earnings from completed orders (convert from cents to dollars)
-	query := `
+	// Get total earnings from completed orders (convert from cents to dollars).
+	// Cancelled events earn nothing, as their orders are being refunded. Box
+	// office sales were paid to the organizer at the door, so they aren't
+	// paid out.
+	query := fmt.Sprintf(`
 		SELECT COALESCE(SUM(total_amount), 0) as total_earnings_cents
 		FROM orders o
 		JOIN events e ON o.event_id = e.id
-		WHERE e.organizer_id = $1 AND o.status = 'completed'`
+		WHERE e.organizer_id = $1 AND o.status = 'completed' AND e.status <> 'cancelled' AND %s`,
+		models.OnlinePaymentCondition("o.payment_id"))
 
 	var totalEarningsCents int64
 	err := r.db.QueryRow(query, organizerID).Scan(&totalEarningsCents)
@@ -291,8 +312,8 @@ func (r *WithdrawalRepository) GetOrganizerBalance(organizerID int) (float64, er
 		return 0, fmt.Errorf("failed to get total withdrawn: %w", err)
 	}
 
-	// Calculate available balance (subtract platform fee of 5%)
-	platformFee := totalEarnings * 0.05
+	// Calculate available balance (subtract the platform fee)
+	platformFee := totalEarnings * models.PlatformFeeRate
 	availableBalance := totalEarnings - platformFee - totalWithdrawn
 	if availableBalance < 0 {
 		availableBalance = 0
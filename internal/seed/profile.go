@@ -0,0 +1,214 @@
+// Package seed fills a database with fixture data from a named profile: demo
+// content for trying the platform out, a large catalogue for load tests, or
+// the small fixed set end-to-end tests rely on. Seeding is idempotent, so a
+// profile can be run again to top up anything missing without duplicates.
+package seed
+
+import (
+	"bytes"
+	"embed"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"event-ticketing-platform/internal/models"
+
+	"gopkg.in/yaml.v3"
+)
+
+//go:embed profiles/*
+var profileFiles embed.FS
+
+// Profile is a set of fixtures to seed, read from a YAML or JSON file
+type Profile struct {
+	Name        string         `yaml:"name" json:"name"`
+	Description string         `yaml:"description" json:"description"`
+	Password    string         `yaml:"password" json:"password"` // Password of every seeded user
+	Users       []UserFixture  `yaml:"users" json:"users"`
+	Events      []EventFixture `yaml:"events" json:"events"`
+}
+
+// UserFixture is an account to seed, matched to existing accounts by email
+type UserFixture struct {
+	Email     string          `yaml:"email" json:"email"`
+	FirstName string          `yaml:"first_name" json:"first_name"`
+	LastName  string          `yaml:"last_name" json:"last_name"`
+	Role      models.UserRole `yaml:"role" json:"role"`
+}
+
+// EventFixture is an event to seed, matched to the organizer's existing
+// events by title. Dates are relative to when the seed runs, so seeded events
+// are always upcoming.
+type EventFixture struct {
+	Title         string              `yaml:"title" json:"title"`
+	Description   string              `yaml:"description" json:"description"`
+	Organizer     string              `yaml:"organizer" json:"organizer"` // Email of one of the profile's users
+	Category      string              `yaml:"category" json:"category"`   // Category slug, e.g. "music"
+	Location      string              `yaml:"location" json:"location"`
+	Status        models.EventStatus  `yaml:"status" json:"status"`                 // Defaults to published
+	StartsInDays  int                 `yaml:"starts_in_days" json:"starts_in_days"` // Days from today
+	StartHour     int                 `yaml:"start_hour" json:"start_hour"`
+	DurationHours int                 `yaml:"duration_hours" json:"duration_hours"`
+	Copies        int                 `yaml:"copies" json:"copies"` // Seed this many numbered copies, e.g. for load tests
+	TicketTypes   []TicketTypeFixture `yaml:"ticket_types" json:"ticket_types"`
+	Orders        []OrderFixture      `yaml:"orders" json:"orders"`
+}
+
+// TicketTypeFixture is a ticket type to seed, matched by name within its event
+type TicketTypeFixture struct {
+	Name        string `yaml:"name" json:"name"`
+	Description string `yaml:"description" json:"description"`
+	PriceCents  int    `yaml:"price_cents" json:"price_cents"`
+	Quantity    int    `yaml:"quantity" json:"quantity"`
+}
+
+// OrderFixture is a completed order to seed. An event's orders are only
+// seeded while it has none, so running a profile again doesn't sell more.
+type OrderFixture struct {
+	Buyer      string `yaml:"buyer" json:"buyer"` // Email of one of the profile's users
+	TicketType string `yaml:"ticket_type" json:"ticket_type"`
+	Quantity   int    `yaml:"quantity" json:"quantity"`
+}
+
+// ProfileNames lists the built-in profiles
+func ProfileNames() []string {
+	entries, _ := profileFiles.ReadDir("profiles")
+	var names []string
+	for _, entry := range entries {
+		names = append(names, strings.TrimSuffix(entry.Name(), filepath.Ext(entry.Name())))
+	}
+	return names
+}
+
+// LoadProfile loads a built-in profile by name (see ProfileNames), or a
+// profile file when given a path ending in .yaml, .yml or .json
+func LoadProfile(nameOrPath string) (*Profile, error) {
+	switch filepath.Ext(nameOrPath) {
+	case ".yaml", ".yml", ".json":
+		data, err := os.ReadFile(nameOrPath)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read profile: %w", err)
+		}
+		return ParseProfile(nameOrPath, data)
+	}
+
+	for _, ext := range []string{".yaml", ".json"} {
+		data, err := profileFiles.ReadFile("profiles/" + nameOrPath + ext)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read profile %s: %w", nameOrPath, err)
+		}
+		return ParseProfile(nameOrPath+ext, data)
+	}
+	return nil, fmt.Errorf("unknown profile %q; built-in profiles are %s", nameOrPath, strings.Join(ProfileNames(), ", "))
+}
+
+// ParseProfile decodes and validates a profile, as JSON when filename ends in
+// .json and as YAML otherwise
+func ParseProfile(filename string, data []byte) (*Profile, error) {
+	profile := &Profile{}
+	var err error
+	if filepath.Ext(filename) == ".json" {
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		err = decoder.Decode(profile)
+	} else {
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		err = decoder.Decode(profile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse profile %s: %w", filename, err)
+	}
+
+	if err := profile.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid profile %s: %w", filename, err)
+	}
+	return profile, nil
+}
+
+// Validate checks that the profile's fixtures are complete and refer to each
+// other correctly, so a bad profile fails before anything is written
+func (p *Profile) Validate() error {
+	var problems []string
+	add := func(format string, args ...any) {
+		problems = append(problems, fmt.Sprintf(format, args...))
+	}
+
+	if len(p.Password) < 8 {
+		add("password must be at least 8 characters")
+	}
+
+	users := make(map[string]models.UserRole)
+	for _, user := range p.Users {
+		email := strings.ToLower(user.Email)
+		if !strings.Contains(email, "@") {
+			add("user %q needs an email address", user.Email)
+		}
+		if _, exists := users[email]; exists {
+			add("user %s is listed twice", user.Email)
+		}
+		if user.FirstName == "" || user.LastName == "" {
+			add("user %s needs a first and last name", user.Email)
+		}
+		if !slices.Contains([]models.UserRole{models.UserRoleUser, models.UserRoleOrganizer, models.UserRoleModerator, models.UserRoleAdmin}, user.Role) {
+			add("user %s has unknown role %q", user.Email, user.Role)
+		}
+		users[email] = user.Role
+	}
+
+	for _, event := range p.Events {
+		if event.Title == "" || event.Location == "" || event.Category == "" {
+			add("event %q needs a title, location and category", event.Title)
+		}
+		if role, ok := users[strings.ToLower(event.Organizer)]; !ok {
+			add("event %q has organizer %q, who is not one of the profile's users", event.Title, event.Organizer)
+		} else if role != models.UserRoleOrganizer && role != models.UserRoleAdmin {
+			add("event %q's organizer %s is not an organizer", event.Title, event.Organizer)
+		}
+		if event.StartsInDays < 1 {
+			add("event %q must start at least 1 day from now", event.Title)
+		}
+		if event.StartHour < 0 || event.StartHour > 23 || event.DurationHours < 1 {
+			add("event %q needs a start hour between 0 and 23 and a duration of at least 1 hour", event.Title)
+		}
+		if event.Copies < 0 {
+			add("event %q has a negative number of copies", event.Title)
+		}
+
+		ticketTypes := make(map[string]bool)
+		for _, ticketType := range event.TicketTypes {
+			if ticketType.Name == "" || ticketType.PriceCents < 0 || ticketType.Quantity < 1 {
+				add("event %q has a ticket type %q without a name, price or quantity", event.Title, ticketType.Name)
+			}
+			ticketTypes[ticketType.Name] = true
+		}
+
+		if len(event.Orders) > 0 && event.Status != "" && event.Status != models.StatusPublished {
+			add("event %q only takes orders when published", event.Title)
+		}
+		for _, order := range event.Orders {
+			if _, ok := users[strings.ToLower(order.Buyer)]; !ok {
+				add("event %q has an order from %q, who is not one of the profile's users", event.Title, order.Buyer)
+			}
+			if !ticketTypes[order.TicketType] {
+				add("event %q has an order for unknown ticket type %q", event.Title, order.TicketType)
+			}
+			if order.Quantity < 1 {
+				add("event %q has an order without a quantity", event.Title)
+			}
+		}
+	}
+
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package seed
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestLoadProfile_BuiltIn(t *testing.T) {
+	names := ProfileNames()
+	for _, want := range []string{"demo", "e2e", "load-test"} {
+		found := false
+		for _, name := range names {
+			found = found || name == want
+		}
+		if !found {
+			t.Errorf("expected built-in profile %q, got %v", want, names)
+		}
+	}
+
+	for _, name := range names {
+		profile, err := LoadProfile(name)
+		if err != nil {
+			t.Errorf("failed to load %s: %v", name, err)
+			continue
+		}
+		if profile.Name != name {
+			t.Errorf("expected profile %s to be named %s, got %q", name, name, profile.Name)
+		}
+		for _, user := range profile.Users {
+			if !strings.HasSuffix(user.Email, "@example.com") {
+				t.Errorf("profile %s seeds %s; fixtures must use example.com addresses", name, user.Email)
+			}
+		}
+	}
+
+	if _, err := LoadProfile("production"); err == nil || !strings.Contains(err.Error(), "demo") {
+		t.Errorf("expected an unknown profile to list the built-in ones, got %v", err)
+	}
+}
+
+func TestParseProfile_JSONAndYAML(t *testing.T) {
+	yamlProfile := `
+name: tiny
+password: Password123!
+users:
+  - {email: org@example.com, first_name: Org, last_name: One, role: organizer}
+events:
+  - title: Tiny Event
+    organizer: org@example.com
+    category: music
+    location: Nairobi
+    starts_in_days: 3
+    start_hour: 18
+    duration_hours: 2
+    ticket_types:
+      - {name: General, price_cents: 1000, quantity: 10}
+`
+	jsonProfile := `{"name": "tiny", "password": "Password123!",
+		"users": [{"email": "org@example.com", "first_name": "Org", "last_name": "One", "role": "organizer"}],
+		"events": [{"title": "Tiny Event", "organizer": "org@example.com", "category": "music", "location": "Nairobi",
+			"starts_in_days": 3, "start_hour": 18, "duration_hours": 2,
+			"ticket_types": [{"name": "General", "price_cents": 1000, "quantity": 10}]}]}`
+
+	for filename, data := range map[string]string{"tiny.yaml": yamlProfile, "tiny.json": jsonProfile} {
+		profile, err := ParseProfile(filename, []byte(data))
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", filename, err)
+			continue
+		}
+		if len(profile.Events) != 1 || profile.Events[0].TicketTypes[0].PriceCents != 1000 {
+			t.Errorf("%s: unexpected profile: %+v", filename, profile)
+		}
+	}
+
+	if _, err := ParseProfile("typo.yaml", []byte("name: typo\npasword: Password123!\n")); err == nil {
+		t.Error("expected an error for an unknown YAML field")
+	}
+	if _, err := ParseProfile("typo.json", []byte(`{"name": "typo", "pasword": "Password123!"}`)); err == nil {
+		t.Error("expected an error for an unknown JSON field")
+	}
+}
+
+func TestProfile_Validate(t *testing.T) {
+	profile := &Profile{
+		Password: "short",
+		Users: []UserFixture{
+			{Email: "buyer@example.com", FirstName: "Buyer", LastName: "One", Role: "user"},
+			{Email: "ghost@example.com", FirstName: "Ghost", LastName: "One", Role: "superuser"},
+		},
+		Events: []EventFixture{{
+			Title:         "Draft Event",
+			Organizer:     "buyer@example.com",
+			Category:      "music",
+			Location:      "Nairobi",
+			Status:        "draft",
+			StartsInDays:  0,
+			StartHour:     25,
+			DurationHours: 2,
+			TicketTypes:   []TicketTypeFixture{{Name: "General", PriceCents: 100, Quantity: 10}},
+			Orders:        []OrderFixture{{Buyer: "stranger@example.com", TicketType: "VIP", Quantity: 1}},
+		}},
+	}
+
+	err := profile.Validate()
+	if err == nil {
+		t.Fatal("expected validation to fail")
+	}
+	for _, want := range []string{
+		"password must be at least 8 characters",
+		"unknown role \"superuser\"",
+		"is not an organizer",
+		"at least 1 day from now",
+		"start hour",
+		"only takes orders when published",
+		"\"stranger@example.com\", who is not one of the profile's users",
+		"unknown ticket type \"VIP\"",
+	} {
+		if !strings.Contains(err.Error(), want) {
+			t.Errorf("expected %q in %v", want, err)
+		}
+	}
+}
@@ -0,0 +1,303 @@
+package seed
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/repositories"
+	"event-ticketing-platform/internal/utils"
+)
+
+// UserRepository defines the user data operations seeding needs
+type UserRepository interface {
+	GetByEmail(email string) (*models.User, error)
+	Create(req *models.UserCreateRequest) (*models.User, error)
+	VerifyEmail(userID int) error
+}
+
+// EventRepository defines the event data operations seeding needs
+type EventRepository interface {
+	GetCategories() ([]*models.Category, error)
+	GetByOrganizer(organizerID int) ([]*models.Event, error)
+	Create(req *models.EventCreateRequest, organizerID int) (*models.Event, error)
+}
+
+// TicketRepository defines the ticket data operations seeding needs
+type TicketRepository interface {
+	GetTicketTypesByEvent(eventID int) ([]*models.TicketType, error)
+	CreateTicketType(req *models.TicketTypeCreateRequest) (*models.TicketType, error)
+	ReserveTickets(ticketTypeID, quantity, userID int, expirationMinutes int) (*repositories.TicketReservation, error)
+}
+
+// OrderRepository defines the order data operations seeding needs
+type OrderRepository interface {
+	GetByEvent(eventID int, limit, offset int) ([]*models.Order, int, error)
+	Create(req *models.OrderCreateRequest) (*models.Order, error)
+	ProcessOrderCompletion(orderID int, paymentID string, ticketData []struct {
+		TicketTypeID int
+		QRCode       string
+	}) error
+}
+
+// Result counts what a seed run created. Fixtures that already existed are
+// left as they are and not counted.
+type Result struct {
+	Users       int
+	Events      int
+	TicketTypes int
+	Orders      int
+}
+
+// Seeder writes profiles to the database through the repositories, so seeded
+// data goes through the same code paths as data created by the application
+type Seeder struct {
+	users   UserRepository
+	events  EventRepository
+	tickets TicketRepository
+	orders  OrderRepository
+	now     func() time.Time
+}
+
+// NewSeeder creates a new seeder
+func NewSeeder(users UserRepository, events EventRepository, tickets TicketRepository, orders OrderRepository) *Seeder {
+	return &Seeder{users: users, events: events, tickets: tickets, orders: orders, now: time.Now}
+}
+
+// Seed creates the profile's users, events, ticket types and orders that
+// don't exist yet
+func (s *Seeder) Seed(profile *Profile) (*Result, error) {
+	result := &Result{}
+
+	passwordHash, err := utils.HashPassword(profile.Password)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash password: %w", err)
+	}
+
+	users := make(map[string]*models.User)
+	for _, fixture := range profile.Users {
+		user, created, err := s.seedUser(fixture, passwordHash)
+		if err != nil {
+			return result, err
+		}
+		if created {
+			result.Users++
+		}
+		users[strings.ToLower(fixture.Email)] = user
+	}
+
+	categories, err := s.events.GetCategories()
+	if err != nil {
+		return result, fmt.Errorf("failed to get categories: %w", err)
+	}
+	categoryIDs := make(map[string]int)
+	for _, category := range categories {
+		categoryIDs[category.Slug] = category.ID
+	}
+
+	// Existing events by organizer, then title
+	existing := make(map[int]map[string]*models.Event)
+	for _, fixture := range profile.Events {
+		categoryID, ok := categoryIDs[fixture.Category]
+		if !ok {
+			return result, fmt.Errorf("event %q has unknown category %q; run the migrations to create the default categories", fixture.Title, fixture.Category)
+		}
+
+		organizer := users[strings.ToLower(fixture.Organizer)]
+		if existing[organizer.ID] == nil {
+			events, err := s.events.GetByOrganizer(organizer.ID)
+			if err != nil {
+				return result, fmt.Errorf("failed to get events of %s: %w", organizer.Email, err)
+			}
+			existing[organizer.ID] = make(map[string]*models.Event)
+			for _, event := range events {
+				existing[organizer.ID][event.Title] = event
+			}
+		}
+
+		for _, title := range fixture.titles() {
+			event := existing[organizer.ID][title]
+			if event == nil {
+				if event, err = s.createEvent(fixture, title, categoryID, organizer.ID); err != nil {
+					return result, err
+				}
+				existing[organizer.ID][title] = event
+				result.Events++
+			}
+
+			ticketTypes, created, err := s.seedTicketTypes(event, fixture.TicketTypes)
+			if err != nil {
+				return result, err
+			}
+			result.TicketTypes += created
+
+			orders, err := s.seedOrders(event, fixture.Orders, ticketTypes, users)
+			if err != nil {
+				return result, err
+			}
+			result.Orders += orders
+		}
+	}
+
+	return result, nil
+}
+
+// seedUser finds the fixture's account by email, creating it with a verified
+// email when it doesn't exist. Existing accounts are left unchanged.
+func (s *Seeder) seedUser(fixture UserFixture, passwordHash string) (*models.User, bool, error) {
+	user, err := s.users.GetByEmail(fixture.Email)
+	if err == nil {
+		return user, false, nil
+	}
+	if !strings.Contains(err.Error(), "not found") {
+		return nil, false, fmt.Errorf("failed to look up user %s: %w", fixture.Email, err)
+	}
+
+	user, err = s.users.Create(&models.UserCreateRequest{
+		Email:     fixture.Email,
+		Password:  passwordHash,
+		FirstName: fixture.FirstName,
+		LastName:  fixture.LastName,
+		Role:      fixture.Role,
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to create user %s: %w", fixture.Email, err)
+	}
+	if err := s.users.VerifyEmail(user.ID); err != nil {
+		return nil, false, fmt.Errorf("failed to verify user %s: %w", fixture.Email, err)
+	}
+	return user, true, nil
+}
+
+// titles returns the titles of the events the fixture seeds: its own title,
+// or numbered titles when it seeds copies
+func (f EventFixture) titles() []string {
+	if f.Copies <= 1 {
+		return []string{f.Title}
+	}
+	titles := make([]string, f.Copies)
+	for i := range titles {
+		titles[i] = fmt.Sprintf("%s #%d", f.Title, i+1)
+	}
+	return titles
+}
+
+// startsAt returns when the fixture's event starts, StartsInDays from today
+func (s *Seeder) startsAt(fixture EventFixture) time.Time {
+	now := s.now()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+	return today.AddDate(0, 0, fixture.StartsInDays).Add(time.Duration(fixture.StartHour) * time.Hour)
+}
+
+func (s *Seeder) createEvent(fixture EventFixture, title string, categoryID, organizerID int) (*models.Event, error) {
+	status := fixture.Status
+	if status == "" {
+		status = models.StatusPublished
+	}
+
+	start := s.startsAt(fixture)
+	event, err := s.events.Create(&models.EventCreateRequest{
+		Title:       title,
+		Description: fixture.Description,
+		StartDate:   start,
+		EndDate:     start.Add(time.Duration(fixture.DurationHours) * time.Hour),
+		Location:    fixture.Location,
+		CategoryID:  categoryID,
+		Status:      status,
+	}, organizerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create event %q: %w", title, err)
+	}
+	return event, nil
+}
+
+// seedTicketTypes creates the event's missing ticket types, on sale from a
+// day ago until an hour before the event starts. It returns all of the
+// event's ticket types by name.
+func (s *Seeder) seedTicketTypes(event *models.Event, fixtures []TicketTypeFixture) (map[string]*models.TicketType, int, error) {
+	existing, err := s.tickets.GetTicketTypesByEvent(event.ID)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to get ticket types of %q: %w", event.Title, err)
+	}
+	ticketTypes := make(map[string]*models.TicketType)
+	for _, ticketType := range existing {
+		ticketTypes[ticketType.Name] = ticketType
+	}
+
+	created := 0
+	for _, fixture := range fixtures {
+		if ticketTypes[fixture.Name] != nil {
+			continue
+		}
+		ticketType, err := s.tickets.CreateTicketType(&models.TicketTypeCreateRequest{
+			EventID:     event.ID,
+			Name:        fixture.Name,
+			Description: fixture.Description,
+			Price:       fixture.PriceCents,
+			Quantity:    fixture.Quantity,
+			SaleStart:   s.now().Add(-24 * time.Hour),
+			SaleEnd:     event.StartDate.Add(-time.Hour),
+		})
+		if err != nil {
+			return nil, created, fmt.Errorf("failed to create ticket type %q of %q: %w", fixture.Name, event.Title, err)
+		}
+		ticketTypes[fixture.Name] = ticketType
+		created++
+	}
+	return ticketTypes, created, nil
+}
+
+// seedOrders creates the event's completed orders, with their tickets, when
+// the event has no orders yet
+func (s *Seeder) seedOrders(event *models.Event, fixtures []OrderFixture, ticketTypes map[string]*models.TicketType, users map[string]*models.User) (int, error) {
+	if len(fixtures) == 0 {
+		return 0, nil
+	}
+	_, total, err := s.orders.GetByEvent(event.ID, 1, 0)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get orders of %q: %w", event.Title, err)
+	}
+	if total > 0 {
+		return 0, nil
+	}
+
+	for i, fixture := range fixtures {
+		buyer := users[strings.ToLower(fixture.Buyer)]
+		ticketType := ticketTypes[fixture.TicketType]
+
+		// Reserving counts the tickets as sold
+		if _, err := s.tickets.ReserveTickets(ticketType.ID, fixture.Quantity, buyer.ID, 15); err != nil {
+			return i, fmt.Errorf("failed to reserve %s tickets for %s: %w", fixture.TicketType, fixture.Buyer, err)
+		}
+
+		order, err := s.orders.Create(&models.OrderCreateRequest{
+			UserID:       buyer.ID,
+			EventID:      event.ID,
+			TotalAmount:  ticketType.Price * fixture.Quantity,
+			BillingEmail: buyer.Email,
+			BillingName:  buyer.FirstName + " " + buyer.LastName,
+			Status:       models.OrderPending,
+		})
+		if err != nil {
+			return i, fmt.Errorf("failed to create order for %s: %w", fixture.Buyer, err)
+		}
+
+		tickets := make([]struct {
+			TicketTypeID int
+			QRCode       string
+		}, fixture.Quantity)
+		for j := range tickets {
+			qrCode, err := utils.GenerateSecureToken(16)
+			if err != nil {
+				return i, fmt.Errorf("failed to generate QR code: %w", err)
+			}
+			tickets[j].TicketTypeID = ticketType.ID
+			tickets[j].QRCode = qrCode
+		}
+		if err := s.orders.ProcessOrderCompletion(order.ID, "seed-"+order.OrderNumber, tickets); err != nil {
+			return i, fmt.Errorf("failed to complete order %s: %w", order.OrderNumber, err)
+		}
+	}
+	return len(fixtures), nil
+}
@@ -0,0 +1,235 @@
+package seed
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/repositories"
+)
+
+// memoryStore implements the seeding repositories in memory
+type memoryStore struct {
+	users       []*models.User
+	categories  []*models.Category
+	events      []*models.Event
+	ticketTypes []*models.TicketType
+	orders      []*models.Order
+	sold        map[int]int
+	completed   map[int]int
+}
+
+func newMemoryStore() *memoryStore {
+	return &memoryStore{
+		categories: []*models.Category{{ID: 7, Slug: "music"}, {ID: 9, Slug: "sports"}},
+		sold:       make(map[int]int),
+		completed:  make(map[int]int),
+	}
+}
+
+type memoryUsers struct{ *memoryStore }
+type memoryEvents struct{ *memoryStore }
+type memoryTickets struct{ *memoryStore }
+type memoryOrders struct{ *memoryStore }
+
+func (s memoryUsers) GetByEmail(email string) (*models.User, error) {
+	for _, user := range s.users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+	return nil, fmt.Errorf("user with email %s not found", email)
+}
+
+func (s memoryUsers) Create(req *models.UserCreateRequest) (*models.User, error) {
+	user := &models.User{ID: len(s.users) + 1, Email: req.Email, FirstName: req.FirstName, LastName: req.LastName, Role: req.Role}
+	s.users = append(s.users, user)
+	return user, nil
+}
+
+func (s memoryUsers) VerifyEmail(userID int) error {
+	s.users[userID-1].EmailVerified = true
+	return nil
+}
+
+func (s memoryEvents) GetCategories() ([]*models.Category, error) {
+	return s.categories, nil
+}
+
+func (s memoryEvents) GetByOrganizer(organizerID int) ([]*models.Event, error) {
+	var events []*models.Event
+	for _, event := range s.events {
+		if event.OrganizerID == organizerID {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+func (s memoryEvents) Create(req *models.EventCreateRequest, organizerID int) (*models.Event, error) {
+	event := &models.Event{
+		ID:          len(s.events) + 1,
+		Title:       req.Title,
+		StartDate:   req.StartDate,
+		EndDate:     req.EndDate,
+		CategoryID:  req.CategoryID,
+		OrganizerID: organizerID,
+		Status:      req.Status,
+	}
+	s.events = append(s.events, event)
+	return event, nil
+}
+
+func (s memoryTickets) GetTicketTypesByEvent(eventID int) ([]*models.TicketType, error) {
+	var ticketTypes []*models.TicketType
+	for _, ticketType := range s.ticketTypes {
+		if ticketType.EventID == eventID {
+			ticketTypes = append(ticketTypes, ticketType)
+		}
+	}
+	return ticketTypes, nil
+}
+
+func (s memoryTickets) CreateTicketType(req *models.TicketTypeCreateRequest) (*models.TicketType, error) {
+	ticketType := &models.TicketType{ID: len(s.ticketTypes) + 1, EventID: req.EventID, Name: req.Name, Price: req.Price, Quantity: req.Quantity}
+	s.ticketTypes = append(s.ticketTypes, ticketType)
+	return ticketType, nil
+}
+
+func (s memoryTickets) ReserveTickets(ticketTypeID, quantity, userID int, expirationMinutes int) (*repositories.TicketReservation, error) {
+	s.sold[ticketTypeID] += quantity
+	return &repositories.TicketReservation{TicketTypeID: ticketTypeID, Quantity: quantity, UserID: userID}, nil
+}
+
+func (s memoryOrders) GetByEvent(eventID int, limit, offset int) ([]*models.Order, int, error) {
+	var orders []*models.Order
+	for _, order := range s.orders {
+		if order.EventID == eventID {
+			orders = append(orders, order)
+		}
+	}
+	return orders, len(orders), nil
+}
+
+func (s memoryOrders) Create(req *models.OrderCreateRequest) (*models.Order, error) {
+	order := &models.Order{ID: len(s.orders) + 1, OrderNumber: fmt.Sprintf("ORD-%d", len(s.orders)+1), UserID: req.UserID, EventID: req.EventID, TotalAmount: req.TotalAmount, Status: req.Status}
+	s.orders = append(s.orders, order)
+	return order, nil
+}
+
+func (s memoryOrders) ProcessOrderCompletion(orderID int, paymentID string, ticketData []struct {
+	TicketTypeID int
+	QRCode       string
+}) error {
+	s.orders[orderID-1].Status = models.OrderCompleted
+	s.completed[orderID] = len(ticketData)
+	return nil
+}
+
+func newTestSeeder(store *memoryStore) *Seeder {
+	seeder := NewSeeder(memoryUsers{store}, memoryEvents{store}, memoryTickets{store}, memoryOrders{store})
+	seeder.now = func() time.Time { return time.Date(2026, 3, 1, 15, 30, 0, 0, time.UTC) }
+	return seeder
+}
+
+func testProfile() *Profile {
+	return &Profile{
+		Name:     "test",
+		Password: "Password123!",
+		Users: []UserFixture{
+			{Email: "org@example.com", FirstName: "Org", LastName: "One", Role: models.UserRoleOrganizer},
+			{Email: "buyer@example.com", FirstName: "Buyer", LastName: "One", Role: models.UserRoleUser},
+		},
+		Events: []EventFixture{
+			{
+				Title: "Concert", Organizer: "org@example.com", Category: "music", Location: "Nairobi",
+				StartsInDays: 10, StartHour: 19, DurationHours: 3,
+				TicketTypes: []TicketTypeFixture{{Name: "Regular", PriceCents: 1500, Quantity: 100}},
+				Orders:      []OrderFixture{{Buyer: "buyer@example.com", TicketType: "Regular", Quantity: 2}},
+			},
+			{
+				Title: "Match", Organizer: "org@example.com", Category: "sports", Location: "Nairobi",
+				StartsInDays: 5, StartHour: 15, DurationHours: 2, Copies: 3,
+				TicketTypes: []TicketTypeFixture{{Name: "Terraces", PriceCents: 500, Quantity: 1000}},
+			},
+		},
+	}
+}
+
+func TestSeeder_Seed(t *testing.T) {
+	store := newMemoryStore()
+	result, err := newTestSeeder(store).Seed(testProfile())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if *result != (Result{Users: 2, Events: 4, TicketTypes: 4, Orders: 1}) {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	if !store.users[0].EmailVerified || store.users[0].Role != models.UserRoleOrganizer {
+		t.Errorf("expected a verified organizer, got %+v", store.users[0])
+	}
+
+	concert := store.events[0]
+	if concert.CategoryID != 7 || concert.Status != models.StatusPublished {
+		t.Errorf("expected a published music event, got %+v", concert)
+	}
+	if want := time.Date(2026, 3, 11, 19, 0, 0, 0, time.UTC); !concert.StartDate.Equal(want) {
+		t.Errorf("expected the concert to start at %v, got %v", want, concert.StartDate)
+	}
+
+	var titles []string
+	for _, event := range store.events[1:] {
+		titles = append(titles, event.Title)
+	}
+	if strings.Join(titles, ", ") != "Match #1, Match #2, Match #3" {
+		t.Errorf("expected numbered copies, got %v", titles)
+	}
+
+	order := store.orders[0]
+	if order.Status != models.OrderCompleted || order.TotalAmount != 3000 || store.completed[order.ID] != 2 || store.sold[1] != 2 {
+		t.Errorf("expected a completed order for 2 tickets, got %+v (tickets=%d, sold=%d)", order, store.completed[order.ID], store.sold[1])
+	}
+}
+
+func TestSeeder_SeedIsIdempotent(t *testing.T) {
+	store := newMemoryStore()
+	seeder := newTestSeeder(store)
+	if _, err := seeder.Seed(testProfile()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	result, err := seeder.Seed(testProfile())
+	if err != nil {
+		t.Fatalf("unexpected error seeding again: %v", err)
+	}
+	if *result != (Result{}) {
+		t.Errorf("expected seeding again to create nothing, got %+v", result)
+	}
+	if len(store.users) != 2 || len(store.events) != 4 || len(store.ticketTypes) != 4 || len(store.orders) != 1 {
+		t.Errorf("expected no duplicates, got %d users, %d events, %d ticket types, %d orders", len(store.users), len(store.events), len(store.ticketTypes), len(store.orders))
+	}
+
+	// A ticket type added to the profile later is topped up
+	profile := testProfile()
+	profile.Events[0].TicketTypes = append(profile.Events[0].TicketTypes, TicketTypeFixture{Name: "VIP", PriceCents: 5000, Quantity: 10})
+	result, err = seeder.Seed(profile)
+	if err != nil || *result != (Result{TicketTypes: 1}) {
+		t.Errorf("expected only the new ticket type to be created, got %+v, %v", result, err)
+	}
+}
+
+func TestSeeder_UnknownCategory(t *testing.T) {
+	store := newMemoryStore()
+	profile := testProfile()
+	profile.Events[0].Category = "knitting"
+
+	if _, err := newTestSeeder(store).Seed(profile); err == nil || !strings.Contains(err.Error(), `unknown category "knitting"`) {
+		t.Errorf("expected an unknown category error, got %v", err)
+	}
+	if len(store.events) != 0 {
+		t.Errorf("expected no events to be created, got %d", len(store.events))
+	}
+}
@@ -7,6 +7,7 @@ import (
 
 	"event-ticketing-platform/internal/auth"
 	"event-ticketing-platform/internal/middleware"
+	"event-ticketing-platform/internal/ratelimit"
 	"event-ticketing-platform/internal/services"
 
 	"github.com/go-chi/chi/v5"
@@ -36,6 +37,31 @@ func NewAuthbossIntegration(db *sql.DB, sessionStore sessions.Store, emailServic
 	}, nil
 }
 
+// SetRateLimiter enables rate limiting of login and registration attempts
+func (ai *AuthbossIntegration) SetRateLimiter(limiter *ratelimit.Limiter, rules ...ratelimit.Rule) {
+	ai.authbossConfig.SetRateLimiter(limiter, rules...)
+}
+
+// SetTwoFactorService enables the TOTP second step after password login
+func (ai *AuthbossIntegration) SetTwoFactorService(twoFactorService *services.TwoFactorService) {
+	ai.authbossConfig.SetTwoFactorService(twoFactorService)
+}
+
+// SetAuditService records passwordless login events in the admin audit log
+func (ai *AuthbossIntegration) SetAuditService(auditService *services.AuditService) {
+	ai.authbossConfig.SetAuditService(auditService)
+}
+
+// SetFraudLinkageService records where users sign in from, to link duplicate accounts
+func (ai *AuthbossIntegration) SetFraudLinkageService(fraudLinkage *services.FraudLinkageService) {
+	ai.authbossConfig.SetFraudLinkageService(fraudLinkage)
+}
+
+// SetOAuthProviders enables social login with the given providers
+func (ai *AuthbossIntegration) SetOAuthProviders(providers ...*auth.OAuthProvider) {
+	ai.authbossConfig.SetOAuthProviders(providers...)
+}
+
 // SetupAuthRoutes sets up the Authboss authentication routes
 func (ai *AuthbossIntegration) SetupAuthRoutes(r chi.Router) {
 	// Set up auth routes directly without mounting
@@ -46,6 +72,15 @@ func (ai *AuthbossIntegration) SetupAuthRoutes(r chi.Router) {
 		r.Handle("/recover", ai.authbossConfig.GetAuthbossHandler())
 		r.Handle("/confirm", ai.authbossConfig.GetAuthbossHandler())
 		r.Handle("/forgot-password", ai.authbossConfig.GetAuthbossHandler())
+		r.Handle("/2fa", ai.authbossConfig.GetAuthbossHandler())
+		r.Handle("/magic-link", ai.authbossConfig.GetAuthbossHandler())
+		r.Handle("/magic-link/verify", ai.authbossConfig.GetAuthbossHandler())
+		r.Handle("/unlock", ai.authbossConfig.GetAuthbossHandler())
+
+		// Social login
+		r.Get("/oauth/{provider}", ai.authbossConfig.HandleOAuthStart)
+		r.Get("/oauth/{provider}/callback", ai.authbossConfig.HandleOAuthCallback)
+		r.Post("/oauth/{provider}/callback", ai.authbossConfig.HandleOAuthCallback)
 		
 		// Add CSRF token endpoint for AJAX requests
 		r.Get("/csrf-token", ai.handleGetCSRFToken)
@@ -0,0 +1,160 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// Lifecycle runs the HTTP server and its periodic background jobs, and shuts
+// them down in order: the server stops accepting connections and drains
+// outstanding requests, then background jobs stop, waiting for any that are
+// running to finish, then resources such as the database close in the
+// reverse order they were registered.
+type Lifecycle struct {
+	timeout time.Duration
+
+	ctx    context.Context // Cancelled when background jobs should stop
+	cancel context.CancelFunc
+	jobs   sync.WaitGroup
+
+	mu         sync.Mutex
+	closers    []lifecycleCloser
+	onShutdown []func()
+}
+
+type lifecycleCloser struct {
+	name  string
+	close func() error
+}
+
+// NewLifecycle creates a lifecycle that gives requests and running background
+// jobs up to timeout to finish when shutting down
+func NewLifecycle(timeout time.Duration) *Lifecycle {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Lifecycle{
+		timeout: timeout,
+		ctx:     ctx,
+		cancel:  cancel,
+	}
+}
+
+// Every runs job every interval until shutdown. The job's context is
+// cancelled when shutdown starts, so long jobs can stop early.
+func (l *Lifecycle) Every(interval time.Duration, job func(ctx context.Context)) {
+	l.schedule(interval, false, job)
+}
+
+// EveryFromStart runs job straight away and then every interval until shutdown
+func (l *Lifecycle) EveryFromStart(interval time.Duration, job func(ctx context.Context)) {
+	l.schedule(interval, true, job)
+}
+
+func (l *Lifecycle) schedule(interval time.Duration, immediately bool, job func(ctx context.Context)) {
+	l.jobs.Add(1)
+	go func() {
+		defer l.jobs.Done()
+		if immediately {
+			job(l.ctx)
+		}
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-l.ctx.Done():
+				return
+			case <-ticker.C:
+				job(l.ctx)
+			}
+		}
+	}()
+}
+
+// OnClose registers a resource to close on shutdown, after the server and
+// background jobs have stopped. Resources close in reverse order, so register
+// them as they are opened.
+func (l *Lifecycle) OnClose(name string, close func() error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.closers = append(l.closers, lifecycleCloser{name: name, close: close})
+}
+
+// OnShutdown registers a function to run as soon as shutdown starts, while
+// requests drain. Long-lived requests such as event streams use it to end,
+// since draining waits for every request.
+func (l *Lifecycle) OnShutdown(f func()) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.onShutdown = append(l.onShutdown, f)
+}
+
+// Serve runs srv until ctx is cancelled, typically on SIGINT or SIGTERM, then
+// shuts everything down. It returns the first error from serving or shutting
+// down; resources are closed either way.
+func (l *Lifecycle) Serve(ctx context.Context, srv *http.Server) error {
+	l.mu.Lock()
+	for _, f := range l.onShutdown {
+		srv.RegisterOnShutdown(f)
+	}
+	l.mu.Unlock()
+
+	serveErr := make(chan error, 1)
+	go func() {
+		serveErr <- srv.ListenAndServe()
+	}()
+
+	var err error
+	select {
+	case err = <-serveErr:
+		// The server failed to start or stopped on its own
+		if errors.Is(err, http.ErrServerClosed) {
+			err = nil
+		}
+	case <-ctx.Done():
+		slog.Info("shutting down", "timeout", l.timeout)
+	}
+
+	return errors.Join(err, l.shutdown(srv))
+}
+
+// shutdown drains srv's requests, stops background jobs and closes the
+// registered resources
+func (l *Lifecycle) shutdown(srv *http.Server) error {
+	ctx, cancel := context.WithTimeout(context.Background(), l.timeout)
+	defer cancel()
+
+	var errs []error
+	if err := srv.Shutdown(ctx); err != nil {
+		errs = append(errs, fmt.Errorf("failed to drain requests: %w", err))
+	}
+
+	// Stop background jobs, waiting for running ones to finish
+	l.cancel()
+	stopped := make(chan struct{})
+	go func() {
+		l.jobs.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-ctx.Done():
+		errs = append(errs, errors.New("timed out waiting for background jobs to stop"))
+	}
+
+	l.mu.Lock()
+	closers := l.closers
+	l.closers = nil
+	l.mu.Unlock()
+	for i := len(closers) - 1; i >= 0; i-- {
+		if err := closers[i].close(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to close %s: %w", closers[i].name, err))
+		}
+	}
+
+	return errors.Join(errs...)
+}
@@ -0,0 +1,186 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestLifecycle_ServeDrainsRequestsThenStopsJobsAndClosesResources(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to reserve a port: %v", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	var order []string
+	var requestDone, jobStopped atomic.Bool
+	started := make(chan struct{})
+	release := make(chan struct{})
+
+	lifecycle := NewLifecycle(5 * time.Second)
+	lifecycle.OnClose("database", func() error {
+		order = append(order, "database")
+		return nil
+	})
+	lifecycle.OnClose("sessions", func() error {
+		order = append(order, "sessions")
+		return nil
+	})
+	lifecycle.EveryFromStart(time.Hour, func(ctx context.Context) {
+		<-ctx.Done()
+		if !requestDone.Load() {
+			t.Error("background jobs stopped before outstanding requests finished")
+		}
+		jobStopped.Store(true)
+	})
+
+	srv := &http.Server{Addr: addr, Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		requestDone.Store(true)
+		w.Write([]byte("done"))
+	})}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- lifecycle.Serve(ctx, srv) }()
+
+	// Start a request, then shut down while it is outstanding
+	response := make(chan string, 1)
+	go func() {
+		for i := 0; i < 50; i++ {
+			resp, err := http.Get("http://" + addr)
+			if err != nil {
+				time.Sleep(20 * time.Millisecond)
+				continue
+			}
+			body := make([]byte, 4)
+			n, _ := resp.Body.Read(body)
+			resp.Body.Close()
+			response <- string(body[:n])
+			return
+		}
+		response <- "unreachable"
+	}()
+	<-started
+	cancel()
+	time.Sleep(50 * time.Millisecond)
+	close(release)
+
+	if err := <-served; err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := <-response; got != "done" {
+		t.Errorf("expected the outstanding request to finish, got %q", got)
+	}
+	if !jobStopped.Load() {
+		t.Error("expected background jobs to stop")
+	}
+	if strings.Join(order, ",") != "sessions,database" {
+		t.Errorf("expected resources to close in reverse order, got %v", order)
+	}
+}
+
+func TestLifecycle_Every(t *testing.T) {
+	lifecycle := NewLifecycle(time.Second)
+
+	var runs atomic.Int32
+	lifecycle.Every(10*time.Millisecond, func(ctx context.Context) {
+		runs.Add(1)
+	})
+	time.Sleep(55 * time.Millisecond)
+
+	srv := &http.Server{}
+	if err := lifecycle.shutdown(srv); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	stoppedAt := runs.Load()
+	if stoppedAt == 0 {
+		t.Fatal("expected the job to run every interval")
+	}
+
+	time.Sleep(30 * time.Millisecond)
+	if runs.Load() != stoppedAt {
+		t.Error("expected the job to stop running after shutdown")
+	}
+}
+
+func TestLifecycle_ServeReportsStartupErrors(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to reserve a port: %v", err)
+	}
+	defer listener.Close()
+
+	closed := false
+	lifecycle := NewLifecycle(time.Second)
+	lifecycle.OnClose("database", func() error {
+		closed = true
+		return errors.New("connection reset")
+	})
+
+	// The port is taken, so the server cannot start
+	err = lifecycle.Serve(context.Background(), &http.Server{Addr: listener.Addr().String()})
+	if err == nil || !strings.Contains(err.Error(), "address already in use") {
+		t.Errorf("expected the listen error, got %v", err)
+	}
+	if !closed || !strings.Contains(err.Error(), "failed to close database: connection reset") {
+		t.Errorf("expected resources to close after a failed start, got %v", err)
+	}
+}
+
+func TestLifecycle_OnShutdownEndsLongLivedRequests(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("failed to reserve a port: %v", err)
+	}
+	addr := listener.Addr().String()
+	listener.Close()
+
+	// The stream only ends when shutdown tells it to
+	streamsClosed := make(chan struct{})
+	started := make(chan struct{})
+	lifecycle := NewLifecycle(5 * time.Second)
+	lifecycle.OnShutdown(func() { close(streamsClosed) })
+
+	srv := &http.Server{Addr: addr, Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+		http.NewResponseController(w).Flush()
+		close(started)
+		<-streamsClosed
+	})}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	served := make(chan error, 1)
+	go func() { served <- lifecycle.Serve(ctx, srv) }()
+
+	go func() {
+		for i := 0; i < 50; i++ {
+			resp, err := http.Get("http://" + addr)
+			if err != nil {
+				time.Sleep(20 * time.Millisecond)
+				continue
+			}
+			resp.Body.Close()
+			return
+		}
+	}()
+	<-started
+	cancel()
+
+	select {
+	case err := <-served:
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	case <-time.After(2 * time.Second):
+		t.Fatal("expected shutdown to end the stream instead of waiting for it")
+	}
+}
@@ -0,0 +1,324 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// AccessibilitySeverity indicates whether an accessibility issue blocks publishing
+type AccessibilitySeverity string
+
+const (
+	AccessibilityError   AccessibilitySeverity = "error"
+	AccessibilityWarning AccessibilitySeverity = "warning"
+)
+
+// Accessibility thresholds
+const (
+	// MaxImageAltTextLength is the length after which screen readers become tedious
+	MaxImageAltTextLength = 150
+	// MinTextContrastRatio is the WCAG 2.1 AA minimum for normal text
+	MinTextContrastRatio = 4.5
+	// longDescriptionLength is the description length above which headings are expected
+	longDescriptionLength = 800
+)
+
+// AccessibilityIssue is a single problem found by the accessibility check
+type AccessibilityIssue struct {
+	Severity AccessibilitySeverity `json:"severity"`
+	Field    string                `json:"field"`
+	Message  string                `json:"message"`
+}
+
+// AccessibilityReport is the result of checking an event for accessibility issues
+type AccessibilityReport struct {
+	Issues           []AccessibilityIssue `json:"issues"`
+	SuggestedAltText string               `json:"suggested_alt_text"`
+}
+
+// HasErrors returns true if the report contains issues that block publishing
+func (r *AccessibilityReport) HasErrors() bool {
+	for _, issue := range r.Issues {
+		if issue.Severity == AccessibilityError {
+			return true
+		}
+	}
+	return false
+}
+
+// Errors returns the issues that block publishing
+func (r *AccessibilityReport) Errors() []AccessibilityIssue {
+	var errors []AccessibilityIssue
+	for _, issue := range r.Issues {
+		if issue.Severity == AccessibilityError {
+			errors = append(errors, issue)
+		}
+	}
+	return errors
+}
+
+func (r *AccessibilityReport) add(severity AccessibilitySeverity, field, message string) {
+	r.Issues = append(r.Issues, AccessibilityIssue{Severity: severity, Field: field, Message: message})
+}
+
+// AccessibilityCheckRequest holds the organizer-provided event content to check
+type AccessibilityCheckRequest struct {
+	Title        string
+	Description  string
+	Location     string
+	ImageAltText string
+	HasImage     bool
+}
+
+var (
+	imageFilenamePattern    = regexp.MustCompile(`(?i)(\.(jpe?g|png|gif|webp|svg)$|^(img|dsc|image)[-_]?\d+)`)
+	redundantAltPattern     = regexp.MustCompile(`(?i)^(an? )?(image|picture|photo|graphic) of\b`)
+	htmlHeadingPattern      = regexp.MustCompile(`(?i)<h([1-6])[\s>]`)
+	markdownHeadingPattern  = regexp.MustCompile(`(?m)^(#{1,6})\s+\S`)
+	htmlImagePattern        = regexp.MustCompile(`(?i)<img\b[^>]*>`)
+	htmlAltPattern          = regexp.MustCompile(`(?i)\balt\s*=\s*("([^"]*)"|'([^']*)')`)
+	markdownImagePattern    = regexp.MustCompile(`!\[([^\]]*)\]\([^)]*\)`)
+	htmlLinkPattern         = regexp.MustCompile(`(?is)<a\b[^>]*>(.*?)</a>`)
+	markdownLinkPattern     = regexp.MustCompile(`(?:^|[^!])\[([^\]]+)\]\([^)]*\)`)
+	htmlTagPattern          = regexp.MustCompile(`<[^>]+>`)
+	styleAttributePattern   = regexp.MustCompile(`(?i)\bstyle\s*=\s*("([^"]*)"|'([^']*)')`)
+	colorDeclarationPattern = regexp.MustCompile(`(?i)(?:^|;)\s*(color|background-color|background)\s*:\s*([^;]+)`)
+	rgbColorPattern         = regexp.MustCompile(`(?i)^rgba?\(\s*(\d{1,3})\s*,\s*(\d{1,3})\s*,\s*(\d{1,3})`)
+)
+
+// vagueLinkTexts are link texts that make no sense out of context
+var vagueLinkTexts = map[string]bool{
+	"click here": true,
+	"here":       true,
+	"read more":  true,
+	"more":       true,
+	"link":       true,
+	"this link":  true,
+}
+
+// namedColors are the CSS color keywords common enough to check for contrast
+var namedColors = map[string][3]int{
+	"white":  {255, 255, 255},
+	"black":  {0, 0, 0},
+	"red":    {255, 0, 0},
+	"green":  {0, 128, 0},
+	"blue":   {0, 0, 255},
+	"yellow": {255, 255, 0},
+	"orange": {255, 165, 0},
+	"gray":   {128, 128, 128},
+	"grey":   {128, 128, 128},
+	"silver": {192, 192, 192},
+	"pink":   {255, 192, 203},
+	"purple": {128, 0, 128},
+	"navy":   {0, 0, 128},
+}
+
+// CheckEventAccessibility checks the event image alt text and the organizer's
+// HTML/Markdown description for common accessibility problems. Errors block
+// publishing; warnings are shown in the event form.
+func CheckEventAccessibility(req *AccessibilityCheckRequest) *AccessibilityReport {
+	report := &AccessibilityReport{
+		SuggestedAltText: models.DefaultImageAltText(req.Title, req.Location),
+	}
+
+	checkImageAltText(report, req)
+	checkHeadings(report, req.Description)
+	checkDescriptionImages(report, req.Description)
+	checkLinkText(report, req.Description)
+	checkColorContrast(report, req.Description)
+
+	return report
+}
+
+func checkImageAltText(report *AccessibilityReport, req *AccessibilityCheckRequest) {
+	alt := strings.TrimSpace(req.ImageAltText)
+	if alt == "" {
+		if req.HasImage {
+			report.add(AccessibilityError, "image_alt_text", "Add alt text describing the event image for people using screen readers.")
+		}
+		return
+	}
+
+	if imageFilenamePattern.MatchString(alt) {
+		report.add(AccessibilityError, "image_alt_text", "The image alt text looks like a file name. Describe what the image shows instead.")
+	}
+	if redundantAltPattern.MatchString(alt) {
+		report.add(AccessibilityWarning, "image_alt_text", `Screen readers already announce images, so the alt text doesn't need to start with "image of".`)
+	}
+	if len(alt) > MaxImageAltTextLength {
+		report.add(AccessibilityWarning, "image_alt_text", fmt.Sprintf("Keep the image alt text under %d characters; put longer details in the description.", MaxImageAltTextLength))
+	}
+	if strings.EqualFold(alt, strings.TrimSpace(req.Title)) {
+		report.add(AccessibilityWarning, "image_alt_text", "The image alt text repeats the event title. Describe what the image shows instead.")
+	}
+}
+
+// checkHeadings looks for missing or skipped heading levels in the description
+func checkHeadings(report *AccessibilityReport, description string) {
+	var levels []int
+	for _, match := range htmlHeadingPattern.FindAllStringSubmatch(description, -1) {
+		level, _ := strconv.Atoi(match[1])
+		levels = append(levels, level)
+	}
+	for _, match := range markdownHeadingPattern.FindAllStringSubmatch(description, -1) {
+		levels = append(levels, len(match[1]))
+	}
+
+	if len(levels) == 0 {
+		if len(plainText(description)) > longDescriptionLength {
+			report.add(AccessibilityWarning, "description", "Long descriptions are easier to navigate with headings. Break the description into sections.")
+		}
+		return
+	}
+
+	previous := 1 // the event title is the page's main heading
+	for _, level := range levels {
+		if level == 1 {
+			report.add(AccessibilityWarning, "description", "The event title is already the page's main heading. Start description headings at level 2.")
+		} else if level > previous+1 {
+			report.add(AccessibilityWarning, "description", fmt.Sprintf("Heading level %d follows level %d. Don't skip heading levels.", level, previous))
+		}
+		previous = level
+	}
+}
+
+// checkDescriptionImages requires alt text on images embedded in the description
+func checkDescriptionImages(report *AccessibilityReport, description string) {
+	missing := 0
+	for _, tag := range htmlImagePattern.FindAllString(description, -1) {
+		if !htmlAltPattern.MatchString(tag) {
+			missing++
+		}
+	}
+	for _, match := range markdownImagePattern.FindAllStringSubmatch(description, -1) {
+		if strings.TrimSpace(match[1]) == "" {
+			missing++
+		}
+	}
+
+	if missing > 0 {
+		report.add(AccessibilityError, "description", fmt.Sprintf("%d image(s) in the description have no alt text.", missing))
+	}
+}
+
+// checkLinkText flags links whose text doesn't describe where they go
+func checkLinkText(report *AccessibilityReport, description string) {
+	var texts []string
+	for _, match := range htmlLinkPattern.FindAllStringSubmatch(description, -1) {
+		texts = append(texts, plainText(match[1]))
+	}
+	for _, match := range markdownLinkPattern.FindAllStringSubmatch(description, -1) {
+		texts = append(texts, match[1])
+	}
+
+	for _, text := range texts {
+		normalized := strings.ToLower(strings.Trim(strings.TrimSpace(text), ".!"))
+		if vagueLinkTexts[normalized] {
+			report.add(AccessibilityWarning, "description", fmt.Sprintf(`Link text "%s" doesn't say where the link goes. Use descriptive link text.`, strings.TrimSpace(text)))
+		}
+	}
+}
+
+// checkColorContrast flags inline text colors with too little contrast
+// against their background (white unless the style sets one)
+func checkColorContrast(report *AccessibilityReport, description string) {
+	for _, match := range styleAttributePattern.FindAllStringSubmatch(description, -1) {
+		style := match[2] + match[3]
+
+		var foreground, background *[3]int
+		for _, declaration := range colorDeclarationPattern.FindAllStringSubmatch(style, -1) {
+			color, ok := parseCSSColor(declaration[2])
+			if !ok {
+				continue
+			}
+			if strings.EqualFold(declaration[1], "color") {
+				foreground = &color
+			} else {
+				background = &color
+			}
+		}
+
+		if foreground == nil {
+			continue
+		}
+		if background == nil {
+			background = &[3]int{255, 255, 255}
+		}
+
+		ratio := ContrastRatio(*foreground, *background)
+		if ratio < MinTextContrastRatio {
+			report.add(AccessibilityWarning, "description", fmt.Sprintf("Text color contrast is %.1f:1; at least %.1f:1 is needed for readable text.", ratio, MinTextContrastRatio))
+		}
+	}
+}
+
+// ContrastRatio returns the WCAG contrast ratio between two RGB colors
+func ContrastRatio(a, b [3]int) float64 {
+	la, lb := relativeLuminance(a), relativeLuminance(b)
+	if la < lb {
+		la, lb = lb, la
+	}
+	return (la + 0.05) / (lb + 0.05)
+}
+
+// relativeLuminance implements the WCAG 2.1 relative luminance formula
+func relativeLuminance(color [3]int) float64 {
+	var channels [3]float64
+	for i, value := range color {
+		c := float64(value) / 255
+		if c <= 0.03928 {
+			channels[i] = c / 12.92
+		} else {
+			channels[i] = math.Pow((c+0.055)/1.055, 2.4)
+		}
+	}
+	return 0.2126*channels[0] + 0.7152*channels[1] + 0.0722*channels[2]
+}
+
+// parseCSSColor parses hex, rgb() and common named CSS colors
+func parseCSSColor(value string) ([3]int, bool) {
+	value = strings.ToLower(strings.TrimSpace(strings.TrimSuffix(strings.TrimSpace(value), "!important")))
+
+	if color, ok := namedColors[value]; ok {
+		return color, true
+	}
+
+	if match := rgbColorPattern.FindStringSubmatch(value); match != nil {
+		var color [3]int
+		for i := 0; i < 3; i++ {
+			n, _ := strconv.Atoi(match[i+1])
+			if n > 255 {
+				return color, false
+			}
+			color[i] = n
+		}
+		return color, true
+	}
+
+	if strings.HasPrefix(value, "#") {
+		hex := value[1:]
+		if len(hex) == 3 {
+			hex = string([]byte{hex[0], hex[0], hex[1], hex[1], hex[2], hex[2]})
+		}
+		if len(hex) != 6 {
+			return [3]int{}, false
+		}
+		n, err := strconv.ParseUint(hex, 16, 32)
+		if err != nil {
+			return [3]int{}, false
+		}
+		return [3]int{int(n >> 16 & 0xff), int(n >> 8 & 0xff), int(n & 0xff)}, true
+	}
+
+	return [3]int{}, false
+}
+
+// plainText strips HTML tags from organizer-provided content
+func plainText(content string) string {
+	return strings.TrimSpace(htmlTagPattern.ReplaceAllString(content, " "))
+}
@@ -0,0 +1,104 @@
+package services
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func hasIssue(report *AccessibilityReport, severity AccessibilitySeverity, field, contains string) bool {
+	for _, issue := range report.Issues {
+		if issue.Severity == severity && issue.Field == field && strings.Contains(issue.Message, contains) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestCheckEventAccessibility_ImageAltText(t *testing.T) {
+	tests := []struct {
+		name      string
+		alt       string
+		hasImage  bool
+		severity  AccessibilitySeverity
+		contains  string
+		wantError bool
+	}{
+		{name: "missing alt text with image", alt: "", hasImage: true, severity: AccessibilityError, contains: "Add alt text", wantError: true},
+		{name: "file name as alt text", alt: "IMG_2041.jpg", hasImage: true, severity: AccessibilityError, contains: "file name", wantError: true},
+		{name: "redundant prefix", alt: "Image of a crowd at sunset", hasImage: true, severity: AccessibilityWarning, contains: "image of"},
+		{name: "repeats title", alt: "Jazz Night", hasImage: true, severity: AccessibilityWarning, contains: "repeats the event title"},
+		{name: "too long", alt: strings.Repeat("a crowd ", 25), hasImage: true, severity: AccessibilityWarning, contains: "under 150"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := CheckEventAccessibility(&AccessibilityCheckRequest{
+				Title:        "Jazz Night",
+				ImageAltText: tt.alt,
+				HasImage:     tt.hasImage,
+			})
+			if !hasIssue(report, tt.severity, "image_alt_text", tt.contains) {
+				t.Errorf("expected %s containing %q, got %+v", tt.severity, tt.contains, report.Issues)
+			}
+			if report.HasErrors() != tt.wantError {
+				t.Errorf("expected HasErrors %v, got %v", tt.wantError, report.HasErrors())
+			}
+		})
+	}
+
+	report := CheckEventAccessibility(&AccessibilityCheckRequest{Title: "Jazz Night", Location: "Nairobi"})
+	if len(report.Issues) != 0 {
+		t.Errorf("expected no issues without an image, got %+v", report.Issues)
+	}
+	if report.SuggestedAltText != "Promotional image for Jazz Night in Nairobi" {
+		t.Errorf("unexpected suggested alt text: %s", report.SuggestedAltText)
+	}
+}
+
+func TestCheckEventAccessibility_Description(t *testing.T) {
+	tests := []struct {
+		name        string
+		description string
+		severity    AccessibilitySeverity
+		contains    string
+	}{
+		{name: "long description without headings", description: strings.Repeat("Great music all night. ", 40), severity: AccessibilityWarning, contains: "headings"},
+		{name: "h1 heading", description: "<h1>About</h1><p>Details</p>", severity: AccessibilityWarning, contains: "level 2"},
+		{name: "skipped markdown heading level", description: "## Lineup\n\n#### Headliner\n", severity: AccessibilityWarning, contains: "Heading level 4 follows level 2"},
+		{name: "html image without alt", description: `<p>Venue</p><img src="/map.png">`, severity: AccessibilityError, contains: "1 image(s)"},
+		{name: "markdown image without alt", description: "![](https://example.com/map.png)", severity: AccessibilityError, contains: "1 image(s)"},
+		{name: "vague html link", description: `Tickets <a href="/faq">click here</a>.`, severity: AccessibilityWarning, contains: `"click here"`},
+		{name: "vague markdown link", description: "See the lineup [here](https://example.com).", severity: AccessibilityWarning, contains: `"here"`},
+		{name: "low contrast text", description: `<p style="color: #aaaaaa">Doors open at 6pm</p>`, severity: AccessibilityWarning, contains: "contrast"},
+		{name: "low contrast on background", description: `<span style="background-color:navy;color:rgb(40, 40, 90)">Sold out</span><span style="color:yellow; background: #ffffff">VIP</span>`, severity: AccessibilityWarning, contains: "contrast"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			report := CheckEventAccessibility(&AccessibilityCheckRequest{Title: "Jazz Night", Description: tt.description})
+			if !hasIssue(report, tt.severity, "description", tt.contains) {
+				t.Errorf("expected %s containing %q, got %+v", tt.severity, tt.contains, report.Issues)
+			}
+		})
+	}
+
+	clean := "## Lineup\n\nLive bands from 6pm.\n\n### Getting there\n\n![Map of the venue entrance](/map.png)\n\nRead the [parking guide](/parking).\n\n<p style=\"color: #333333\">Doors open at 6pm</p>"
+	report := CheckEventAccessibility(&AccessibilityCheckRequest{Title: "Jazz Night", Description: clean, ImageAltText: "Saxophonist on a dimly lit stage", HasImage: true})
+	if len(report.Issues) != 0 {
+		t.Errorf("expected no issues, got %+v", report.Issues)
+	}
+}
+
+func TestContrastRatio(t *testing.T) {
+	if ratio := ContrastRatio([3]int{0, 0, 0}, [3]int{255, 255, 255}); math.Abs(ratio-21) > 0.01 {
+		t.Errorf("expected black on white to be 21:1, got %.2f", ratio)
+	}
+	if ratio := ContrastRatio([3]int{255, 255, 255}, [3]int{255, 255, 255}); math.Abs(ratio-1) > 0.01 {
+		t.Errorf("expected white on white to be 1:1, got %.2f", ratio)
+	}
+	// #767676 is the lightest gray that passes AA on white
+	if ratio := ContrastRatio([3]int{0x76, 0x76, 0x76}, [3]int{255, 255, 255}); ratio < MinTextContrastRatio {
+		t.Errorf("expected #767676 on white to pass, got %.2f", ratio)
+	}
+}
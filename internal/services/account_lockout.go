@@ -0,0 +1,56 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// LockedAccountRepository defines the data operations for accounts locked
+// after too many failed logins
+type LockedAccountRepository interface {
+	GetLockedAccounts() ([]*models.LockedAccount, error)
+	UnlockAccount(userID int) error
+}
+
+// AccountLockoutService lets admins see which accounts are locked after too
+// many failed logins and unlock them before the lock lifts by itself
+type AccountLockoutService struct {
+	repo         LockedAccountRepository
+	auditService *AuditService
+}
+
+// NewAccountLockoutService creates a new account lockout service
+func NewAccountLockoutService(repo LockedAccountRepository) *AccountLockoutService {
+	return &AccountLockoutService{repo: repo}
+}
+
+// SetAuditService records manual unlocks in the audit log
+func (s *AccountLockoutService) SetAuditService(auditService *AuditService) {
+	s.auditService = auditService
+}
+
+// GetLockedAccounts returns the accounts that are currently locked
+func (s *AccountLockoutService) GetLockedAccounts() ([]*models.LockedAccount, error) {
+	accounts, err := s.repo.GetLockedAccounts()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get locked accounts: %w", err)
+	}
+	return accounts, nil
+}
+
+// UnlockAccount lifts the lock on a user's account on behalf of an admin
+func (s *AccountLockoutService) UnlockAccount(adminID, userID int, r *http.Request) error {
+	if err := s.repo.UnlockAccount(userID); err != nil {
+		return err
+	}
+
+	if s.auditService != nil {
+		details := map[string]interface{}{"method": "admin"}
+		if err := s.auditService.LogAction(adminID, models.AuditActionAccountUnlock, models.AuditTargetUser, userID, details, r); err != nil {
+			fmt.Printf("Warning: failed to log unlock of user %d: %v\n", userID, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,51 @@
+package services
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock LockedAccountRepository for testing
+type mockLockedAccountRepository struct {
+	accounts map[int]*models.LockedAccount
+}
+
+func (m *mockLockedAccountRepository) GetLockedAccounts() ([]*models.LockedAccount, error) {
+	var result []*models.LockedAccount
+	for _, account := range m.accounts {
+		result = append(result, account)
+	}
+	return result, nil
+}
+
+func (m *mockLockedAccountRepository) UnlockAccount(userID int) error {
+	if _, ok := m.accounts[userID]; !ok {
+		return models.ErrAccountNotLocked
+	}
+	delete(m.accounts, userID)
+	return nil
+}
+
+func TestAccountLockoutService_UnlockAccount(t *testing.T) {
+	repo := &mockLockedAccountRepository{accounts: map[int]*models.LockedAccount{
+		7: {UserID: 7, Email: "locked@example.com", LockedUntil: time.Now().Add(20 * time.Minute)},
+	}}
+	service := NewAccountLockoutService(repo)
+	r := httptest.NewRequest("POST", "/admin/locked-accounts/7/unlock", nil)
+
+	if err := service.UnlockAccount(1, 7, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	accounts, err := service.GetLockedAccounts()
+	if err != nil || len(accounts) != 0 {
+		t.Errorf("expected no locked accounts after unlocking, got %v, %v", accounts, err)
+	}
+
+	if err := service.UnlockAccount(1, 7, r); !errors.Is(err, models.ErrAccountNotLocked) {
+		t.Errorf("expected ErrAccountNotLocked unlocking an unlocked account, got %v", err)
+	}
+}
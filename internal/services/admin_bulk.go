@@ -0,0 +1,150 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// BulkUserUpdater changes users on behalf of an admin, recording each change
+// in the audit log
+type BulkUserUpdater interface {
+	SuspendUser(adminID, userID int, r *http.Request) error
+	UpdateUserRole(adminID, userID int, role models.UserRole, r *http.Request) error
+}
+
+// BulkUserLookup looks up the users a bulk action is for
+type BulkUserLookup interface {
+	GetByID(id int) (*models.User, error)
+}
+
+// BulkEventUpdater changes events on behalf of an admin, recording each
+// change in the audit log
+type BulkEventUpdater interface {
+	UnpublishEvent(eventID int, adminID int, r *http.Request) (*models.Event, error)
+	AssignCategory(eventID, categoryID int, adminID int, r *http.Request) (*models.Event, error)
+}
+
+// AdminBulkService runs an admin's action on many users or events at once,
+// carrying on past items it fails for and reporting each one's outcome
+type AdminBulkService struct {
+	userUpdater BulkUserUpdater
+	users       BulkUserLookup
+	events      BulkEventUpdater
+}
+
+// NewAdminBulkService creates a new admin bulk service
+func NewAdminBulkService(userUpdater BulkUserUpdater, users BulkUserLookup, events BulkEventUpdater) *AdminBulkService {
+	return &AdminBulkService{
+		userUpdater: userUpdater,
+		users:       users,
+		events:      events,
+	}
+}
+
+// RunUserAction suspends users or changes their role. Admins can't include
+// themselves, so they can't lock themselves out.
+func (s *AdminBulkService) RunUserAction(adminID int, action models.BulkAction, userIDs []int, role models.UserRole, r *http.Request) (*models.BulkResult, error) {
+	switch action {
+	case models.BulkSuspendUsers:
+	case models.BulkChangeRole:
+		switch role {
+		case models.UserRoleUser, models.UserRoleOrganizer, models.UserRoleAdmin:
+		default:
+			return nil, errors.New("choose the role to change the users to")
+		}
+	default:
+		return nil, fmt.Errorf("unknown user action %q", action)
+	}
+
+	ids, err := bulkIDs(userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.BulkResult{Action: action}
+	for _, userID := range ids {
+		item := models.BulkItemResult{ID: userID}
+		user, err := s.users.GetByID(userID)
+		switch {
+		case err != nil:
+			item.Error = "user not found"
+		case userID == adminID:
+			item.Error = "you can't change your own account"
+		case action == models.BulkSuspendUsers:
+			item.Name = user.Email
+			if err := s.userUpdater.SuspendUser(adminID, userID, r); err != nil {
+				item.Error = err.Error()
+			}
+		default:
+			item.Name = user.Email
+			if err := s.userUpdater.UpdateUserRole(adminID, userID, role, r); err != nil {
+				item.Error = err.Error()
+			}
+		}
+		result.Items = append(result.Items, item)
+	}
+	return result, nil
+}
+
+// RunEventAction unpublishes events or moves them to a category
+func (s *AdminBulkService) RunEventAction(adminID int, action models.BulkAction, eventIDs []int, categoryID int, r *http.Request) (*models.BulkResult, error) {
+	switch action {
+	case models.BulkUnpublishEvents:
+	case models.BulkAssignCategory:
+		if categoryID <= 0 {
+			return nil, errors.New("choose the category to assign the events to")
+		}
+	default:
+		return nil, fmt.Errorf("unknown event action %q", action)
+	}
+
+	ids, err := bulkIDs(eventIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &models.BulkResult{Action: action}
+	for _, eventID := range ids {
+		var event *models.Event
+		if action == models.BulkUnpublishEvents {
+			event, err = s.events.UnpublishEvent(eventID, adminID, r)
+		} else {
+			event, err = s.events.AssignCategory(eventID, categoryID, adminID, r)
+		}
+
+		item := models.BulkItemResult{ID: eventID}
+		if event != nil {
+			item.Name = event.Title
+		}
+		if err != nil {
+			item.Error = err.Error()
+		}
+		result.Items = append(result.Items, item)
+	}
+	return result, nil
+}
+
+// bulkIDs returns the distinct IDs a bulk action is for, in the order they
+// were selected
+func bulkIDs(ids []int) ([]int, error) {
+	seen := make(map[int]bool)
+	var distinct []int
+	for _, id := range ids {
+		if id <= 0 || seen[id] {
+			continue
+		}
+		seen[id] = true
+		distinct = append(distinct, id)
+	}
+
+	if len(distinct) == 0 {
+		return nil, errors.New("select at least one item")
+	}
+	if len(distinct) > models.MaxBulkItems {
+		return nil, fmt.Errorf("select at most %d items at a time", models.MaxBulkItems)
+	}
+	return distinct, nil
+}
@@ -0,0 +1,153 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock BulkUserUpdater for testing
+type mockBulkUserUpdater struct {
+	suspended []int
+	roles     map[int]models.UserRole
+	failFor   int
+}
+
+func (m *mockBulkUserUpdater) SuspendUser(adminID, userID int, r *http.Request) error {
+	if userID == m.failFor {
+		return errors.New("database is down")
+	}
+	m.suspended = append(m.suspended, userID)
+	return nil
+}
+
+func (m *mockBulkUserUpdater) UpdateUserRole(adminID, userID int, role models.UserRole, r *http.Request) error {
+	m.roles[userID] = role
+	return nil
+}
+
+// Mock BulkEventUpdater for testing
+type mockBulkEventUpdater struct {
+	events      map[int]*models.Event
+	unpublished []int
+	categories  map[int]int
+}
+
+func (m *mockBulkEventUpdater) UnpublishEvent(eventID int, adminID int, r *http.Request) (*models.Event, error) {
+	event, ok := m.events[eventID]
+	if !ok {
+		return nil, fmt.Errorf("failed to get event")
+	}
+	if event.Status != models.StatusPublished {
+		return event, fmt.Errorf("event not found or not published")
+	}
+	m.unpublished = append(m.unpublished, eventID)
+	return event, nil
+}
+
+func (m *mockBulkEventUpdater) AssignCategory(eventID, categoryID int, adminID int, r *http.Request) (*models.Event, error) {
+	event, ok := m.events[eventID]
+	if !ok {
+		return nil, fmt.Errorf("failed to get event")
+	}
+	m.categories[eventID] = categoryID
+	return event, nil
+}
+
+func newBulkUsers() *mockAdminUserRepository {
+	return &mockAdminUserRepository{users: map[int]*models.User{
+		1: {ID: 1, Email: "admin@example.com", Role: models.UserRoleAdmin},
+		2: {ID: 2, Email: "amina@example.com"},
+		3: {ID: 3, Email: "brian@example.com"},
+	}}
+}
+
+func TestAdminBulkService_RunUserAction(t *testing.T) {
+	updater := &mockBulkUserUpdater{roles: make(map[int]models.UserRole), failFor: 3}
+	service := NewAdminBulkService(updater, newBulkUsers(), nil)
+
+	result, err := service.RunUserAction(1, models.BulkSuspendUsers, []int{2, 2, 3, 1, 99}, "", nil)
+	if err != nil {
+		t.Fatalf("RunUserAction() error = %v", err)
+	}
+	if len(result.Items) != 4 {
+		t.Fatalf("expected a result for each distinct user, got %+v", result.Items)
+	}
+	if result.Succeeded() != 1 || len(updater.suspended) != 1 || updater.suspended[0] != 2 {
+		t.Errorf("expected only user 2 suspended, got %v", updater.suspended)
+	}
+	for _, item := range result.Items[1:] {
+		if item.OK() {
+			t.Errorf("expected user %d to fail", item.ID)
+		}
+	}
+	if result.Items[0].Name != "amina@example.com" {
+		t.Errorf("expected the user's email as name, got %q", result.Items[0].Name)
+	}
+
+	result, err = service.RunUserAction(1, models.BulkChangeRole, []int{2, 3}, models.UserRoleOrganizer, nil)
+	if err != nil {
+		t.Fatalf("RunUserAction() error = %v", err)
+	}
+	if result.Failed() != 0 || updater.roles[2] != models.UserRoleOrganizer || updater.roles[3] != models.UserRoleOrganizer {
+		t.Errorf("expected both users made organizers, got %v", updater.roles)
+	}
+}
+
+func TestAdminBulkService_RunUserAction_Validates(t *testing.T) {
+	service := NewAdminBulkService(&mockBulkUserUpdater{roles: make(map[int]models.UserRole)}, newBulkUsers(), nil)
+
+	if _, err := service.RunUserAction(1, models.BulkChangeRole, []int{2}, "superuser", nil); err == nil {
+		t.Error("expected an error for an unknown role")
+	}
+	if _, err := service.RunUserAction(1, models.BulkUnpublishEvents, []int{2}, "", nil); err == nil {
+		t.Error("expected an error for an event action on users")
+	}
+	if _, err := service.RunUserAction(1, models.BulkSuspendUsers, nil, "", nil); err == nil {
+		t.Error("expected an error without any users selected")
+	}
+
+	tooMany := make([]int, models.MaxBulkItems+1)
+	for i := range tooMany {
+		tooMany[i] = i + 1
+	}
+	if _, err := service.RunUserAction(1, models.BulkSuspendUsers, tooMany, "", nil); err == nil {
+		t.Error("expected an error for too many users")
+	}
+}
+
+func TestAdminBulkService_RunEventAction(t *testing.T) {
+	updater := &mockBulkEventUpdater{
+		events: map[int]*models.Event{
+			10: {ID: 10, Title: "Jazz Night", Status: models.StatusPublished},
+			11: {ID: 11, Title: "Draft Gala", Status: models.StatusDraft},
+		},
+		categories: make(map[int]int),
+	}
+	service := NewAdminBulkService(nil, nil, updater)
+
+	result, err := service.RunEventAction(1, models.BulkUnpublishEvents, []int{10, 11, 12}, 0, nil)
+	if err != nil {
+		t.Fatalf("RunEventAction() error = %v", err)
+	}
+	if result.Succeeded() != 1 || result.Failed() != 2 {
+		t.Errorf("expected 1 success and 2 failures, got %s", result.Summary())
+	}
+	if result.Items[1].Name != "Draft Gala" || result.Items[1].OK() {
+		t.Errorf("expected the draft to fail with its title, got %+v", result.Items[1])
+	}
+
+	if _, err := service.RunEventAction(1, models.BulkAssignCategory, []int{10}, 0, nil); err == nil {
+		t.Error("expected an error without a category")
+	}
+	result, err = service.RunEventAction(1, models.BulkAssignCategory, []int{10, 11}, 4, nil)
+	if err != nil {
+		t.Fatalf("RunEventAction() error = %v", err)
+	}
+	if result.Failed() != 0 || updater.categories[10] != 4 || updater.categories[11] != 4 {
+		t.Errorf("expected both events moved to category 4, got %v", updater.categories)
+	}
+}
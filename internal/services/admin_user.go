@@ -0,0 +1,285 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/repositories"
+)
+
+// Limits on how much of a user's history their admin page shows
+const (
+	adminUserRecentOrders      = 20
+	adminUserRecentWithdrawals = 20
+	adminUserRecentAuditLogs   = 50
+	adminUserTimelineLimit     = 100
+)
+
+// ErrEmailAlreadyVerified is returned when an admin verifies an email address
+// that has already been verified
+var ErrEmailAlreadyVerified = errors.New("email is already verified")
+
+// AdminUserRepository defines the user data operations behind the admin user
+// page
+type AdminUserRepository interface {
+	GetByID(id int) (*models.User, error)
+	VerifyEmail(userID int) error
+}
+
+// AdminUserOrders returns a user's orders, newest first
+type AdminUserOrders interface {
+	GetUserOrders(userID int, limit, offset int) ([]*repositories.OrderWithDetails, int, error)
+}
+
+// AdminUserEvents returns the events an organizer created
+type AdminUserEvents interface {
+	GetByOrganizer(organizerID int) ([]*models.Event, error)
+}
+
+// AdminUserWithdrawals returns an organizer's withdrawals, newest first
+type AdminUserWithdrawals interface {
+	GetOrganizerWithdrawals(organizerID int, page, limit int) ([]*models.Withdrawal, int, error)
+}
+
+// AdminUserSessions returns the devices a user is signed in on
+type AdminUserSessions interface {
+	UserSessions(userID int) ([]*models.Session, error)
+}
+
+// PasswordResetRequester emails users a link to reset their password
+type PasswordResetRequester interface {
+	RequestPasswordReset(req *PasswordResetRequest) error
+}
+
+// AdminUserActivity is one entry of the timeline on a user's admin page
+type AdminUserActivity struct {
+	At     time.Time
+	Kind   string // "account", "order", "event", "withdrawal", "session" or "audit"
+	Title  string
+	Detail string
+	Link   string
+}
+
+// AdminUserDetail is everything admins see about a user on their admin page
+type AdminUserDetail struct {
+	User            *models.User
+	Orders          []*repositories.OrderWithDetails
+	OrderCount      int
+	Events          []*models.Event
+	Withdrawals     []*models.Withdrawal
+	WithdrawalCount int
+	Sessions        []*models.Session
+	AuditLogs       []*models.AuditLog // Entries about the user
+	ActorAuditLogs  []*models.AuditLog // Entries of what the user did
+	Timeline        []AdminUserActivity
+}
+
+// AdminUserService gathers a user's orders, events, withdrawals, sessions and
+// audit entries for admins, and carries out admins' quick actions on them
+type AdminUserService struct {
+	users          AdminUserRepository
+	orders         AdminUserOrders
+	events         AdminUserEvents
+	withdrawals    AdminUserWithdrawals
+	sessions       AdminUserSessions
+	auditService   *AuditService
+	passwordResets PasswordResetRequester
+}
+
+// NewAdminUserService creates a new admin user service
+func NewAdminUserService(users AdminUserRepository, orders AdminUserOrders, events AdminUserEvents, withdrawals AdminUserWithdrawals, sessions AdminUserSessions, auditService *AuditService) *AdminUserService {
+	return &AdminUserService{
+		users:        users,
+		orders:       orders,
+		events:       events,
+		withdrawals:  withdrawals,
+		sessions:     sessions,
+		auditService: auditService,
+	}
+}
+
+// SetPasswordResets sets what emails users a password reset link when an
+// admin asks
+func (s *AdminUserService) SetPasswordResets(passwordResets PasswordResetRequester) {
+	s.passwordResets = passwordResets
+}
+
+// GetDetail returns a user's admin page data, with their recent history
+// merged into a timeline
+func (s *AdminUserService) GetDetail(userID int) (*AdminUserDetail, error) {
+	user, err := s.users.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	detail := &AdminUserDetail{User: user}
+
+	detail.Orders, detail.OrderCount, err = s.orders.GetUserOrders(userID, adminUserRecentOrders, 0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get orders: %w", err)
+	}
+
+	if user.Role == models.UserRoleOrganizer || user.Role == models.UserRoleAdmin {
+		detail.Events, err = s.events.GetByOrganizer(userID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get events: %w", err)
+		}
+		detail.Withdrawals, detail.WithdrawalCount, err = s.withdrawals.GetOrganizerWithdrawals(userID, 1, adminUserRecentWithdrawals)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get withdrawals: %w", err)
+		}
+	}
+
+	detail.Sessions, err = s.sessions.UserSessions(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+
+	if s.auditService != nil {
+		detail.AuditLogs, _, err = s.auditService.GetAuditLogsByTarget(models.AuditTargetUser, userID, 1, adminUserRecentAuditLogs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get audit log: %w", err)
+		}
+		detail.ActorAuditLogs, _, err = s.auditService.GetAuditLogsByAdmin(userID, 1, adminUserRecentAuditLogs)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get audit log: %w", err)
+		}
+	}
+
+	detail.Timeline = buildAdminUserTimeline(detail)
+	return detail, nil
+}
+
+// SendPasswordReset emails a user a link to reset their password on behalf
+// of an admin
+func (s *AdminUserService) SendPasswordReset(adminID, userID int, r *http.Request) error {
+	if s.passwordResets == nil {
+		return errors.New("password resets are not available")
+	}
+
+	user, err := s.users.GetByID(userID)
+	if err != nil {
+		return fmt.Errorf("user not found: %w", err)
+	}
+
+	if err := s.passwordResets.RequestPasswordReset(&PasswordResetRequest{Email: user.Email}); err != nil {
+		return err
+	}
+
+	s.logAction(adminID, models.AuditActionPasswordResetSent, userID, map[string]interface{}{"email": user.Email}, r)
+	return nil
+}
+
+// VerifyEmail marks a user's email address verified on behalf of an admin
+func (s *AdminUserService) VerifyEmail(adminID, userID int, r *http.Request) error {
+	user, err := s.users.GetByID(userID)
+	if err != nil {
+		return fmt.Errorf("user not found: %w", err)
+	}
+	if user.EmailVerified {
+		return ErrEmailAlreadyVerified
+	}
+
+	if err := s.users.VerifyEmail(userID); err != nil {
+		return fmt.Errorf("failed to verify email: %w", err)
+	}
+
+	s.logAction(adminID, models.AuditActionEmailVerify, userID, map[string]interface{}{"email": user.Email}, r)
+	return nil
+}
+
+// logAction records an admin's quick action on a user in the audit log
+func (s *AdminUserService) logAction(adminID int, action string, userID int, details interface{}, r *http.Request) {
+	if s.auditService == nil {
+		return
+	}
+	if err := s.auditService.LogAction(adminID, action, models.AuditTargetUser, userID, details, r); err != nil {
+		fmt.Printf("Warning: failed to log %s of user %d: %v\n", action, userID, err)
+	}
+}
+
+// buildAdminUserTimeline merges a user's history into one timeline, newest
+// first
+func buildAdminUserTimeline(detail *AdminUserDetail) []AdminUserActivity {
+	timeline := []AdminUserActivity{{
+		At:     detail.User.CreatedAt,
+		Kind:   "account",
+		Title:  "Signed up",
+		Detail: fmt.Sprintf("as %s", detail.User.Role),
+	}}
+
+	for _, order := range detail.Orders {
+		timeline = append(timeline, AdminUserActivity{
+			At:     order.CreatedAt,
+			Kind:   "order",
+			Title:  fmt.Sprintf("Placed order %s", order.OrderNumber),
+			Detail: fmt.Sprintf("%s · KSh %.2f · %s", order.EventTitle, order.TotalAmountInCurrency(), order.GetStatusDisplayName()),
+			Link:   fmt.Sprintf("/events/%d", order.EventID),
+		})
+	}
+
+	for _, event := range detail.Events {
+		timeline = append(timeline, AdminUserActivity{
+			At:     event.CreatedAt,
+			Kind:   "event",
+			Title:  fmt.Sprintf("Created event %s", event.Title),
+			Detail: string(event.Status),
+			Link:   fmt.Sprintf("/events/%d", event.ID),
+		})
+	}
+
+	for _, withdrawal := range detail.Withdrawals {
+		timeline = append(timeline, AdminUserActivity{
+			At:     withdrawal.CreatedAt,
+			Kind:   "withdrawal",
+			Title:  fmt.Sprintf("Requested a withdrawal of KSh %.2f", withdrawal.Amount),
+			Detail: string(withdrawal.Status),
+			Link:   "/admin/withdrawals",
+		})
+	}
+
+	for _, session := range detail.Sessions {
+		timeline = append(timeline, AdminUserActivity{
+			At:     session.CreatedAt,
+			Kind:   "session",
+			Title:  fmt.Sprintf("Signed in on %s", session.Device()),
+			Detail: session.IPAddress,
+		})
+	}
+
+	// An entry can be both about the user and by them, like a new device
+	// sign-in, so each is shown once
+	seen := make(map[int]bool)
+	for _, logs := range [][]*models.AuditLog{detail.AuditLogs, detail.ActorAuditLogs} {
+		for _, log := range logs {
+			if seen[log.ID] {
+				continue
+			}
+			seen[log.ID] = true
+
+			activity := AdminUserActivity{
+				At:     log.CreatedAt,
+				Kind:   "audit",
+				Title:  models.AuditLabel(log.Action),
+				Detail: "by " + log.ActorName(),
+				Link:   fmt.Sprintf("/admin/audit?target_type=%s&target_id=%d", log.TargetType, log.TargetID),
+			}
+			if log.TargetID == 0 {
+				activity.Link = ""
+			}
+			timeline = append(timeline, activity)
+		}
+	}
+
+	sort.SliceStable(timeline, func(i, j int) bool {
+		return timeline[i].At.After(timeline[j].At)
+	})
+	if len(timeline) > adminUserTimelineLimit {
+		timeline = timeline[:adminUserTimelineLimit]
+	}
+	return timeline
+}
@@ -0,0 +1,111 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/repositories"
+)
+
+// Mock AdminUserRepository for testing
+type mockAdminUserRepository struct {
+	users    map[int]*models.User
+	verified []int
+}
+
+func (m *mockAdminUserRepository) GetByID(id int) (*models.User, error) {
+	user, ok := m.users[id]
+	if !ok {
+		return nil, fmt.Errorf("user not found")
+	}
+	return user, nil
+}
+
+func (m *mockAdminUserRepository) VerifyEmail(userID int) error {
+	m.verified = append(m.verified, userID)
+	m.users[userID].EmailVerified = true
+	return nil
+}
+
+// Mock PasswordResetRequester for testing
+type mockPasswordResets struct {
+	emails []string
+}
+
+func (m *mockPasswordResets) RequestPasswordReset(req *PasswordResetRequest) error {
+	m.emails = append(m.emails, req.Email)
+	return nil
+}
+
+func TestAdminUserService_VerifyEmail(t *testing.T) {
+	repo := &mockAdminUserRepository{users: map[int]*models.User{4: {ID: 4, Email: "wanjiru@example.com"}}}
+	service := NewAdminUserService(repo, nil, nil, nil, nil, nil)
+
+	if err := service.VerifyEmail(1, 4, nil); err != nil {
+		t.Fatalf("VerifyEmail() error = %v", err)
+	}
+	if len(repo.verified) != 1 || repo.verified[0] != 4 {
+		t.Errorf("expected user 4 to be verified, got %v", repo.verified)
+	}
+
+	if err := service.VerifyEmail(1, 4, nil); err != ErrEmailAlreadyVerified {
+		t.Errorf("VerifyEmail() of a verified user = %v, want ErrEmailAlreadyVerified", err)
+	}
+	if err := service.VerifyEmail(1, 99, nil); err == nil {
+		t.Error("expected an error for an unknown user")
+	}
+}
+
+func TestAdminUserService_SendPasswordReset(t *testing.T) {
+	repo := &mockAdminUserRepository{users: map[int]*models.User{4: {ID: 4, Email: "wanjiru@example.com"}}}
+	service := NewAdminUserService(repo, nil, nil, nil, nil, nil)
+
+	if err := service.SendPasswordReset(1, 4, nil); err == nil {
+		t.Error("expected an error without a password reset requester")
+	}
+
+	resets := &mockPasswordResets{}
+	service.SetPasswordResets(resets)
+	if err := service.SendPasswordReset(1, 4, nil); err != nil {
+		t.Fatalf("SendPasswordReset() error = %v", err)
+	}
+	if len(resets.emails) != 1 || resets.emails[0] != "wanjiru@example.com" {
+		t.Errorf("expected a reset for the user's email, got %v", resets.emails)
+	}
+}
+
+func TestBuildAdminUserTimeline(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 5, d, 12, 0, 0, 0, time.UTC) }
+	roleChange := &models.AuditLog{ID: 7, AdminUserID: 1, AdminUser: &models.User{Email: "admin@example.com"}, Action: models.AuditActionUserRoleChange, TargetType: models.AuditTargetUser, TargetID: 4, CreatedAt: day(4)}
+	detail := &AdminUserDetail{
+		User: &models.User{ID: 4, Role: models.UserRoleOrganizer, CreatedAt: day(1)},
+		Orders: []*repositories.OrderWithDetails{
+			{Order: &models.Order{OrderNumber: "ORD-1", EventID: 9, TotalAmount: 150000, Status: models.OrderCompleted, CreatedAt: day(2)}, EventTitle: "Jazz Night"},
+		},
+		Events:      []*models.Event{{ID: 9, Title: "Jazz Night", Status: models.StatusPublished, CreatedAt: day(3)}},
+		Withdrawals: []*models.Withdrawal{{Amount: 500, Status: models.WithdrawalStatusPending, CreatedAt: day(5)}},
+		// The role change is both about the user and listed again by actor
+		AuditLogs:      []*models.AuditLog{roleChange},
+		ActorAuditLogs: []*models.AuditLog{roleChange},
+	}
+
+	timeline := buildAdminUserTimeline(detail)
+	wantKinds := []string{"withdrawal", "audit", "event", "order", "account"}
+	if len(timeline) != len(wantKinds) {
+		t.Fatalf("expected %d entries, got %+v", len(wantKinds), timeline)
+	}
+	for i, kind := range wantKinds {
+		if timeline[i].Kind != kind {
+			t.Errorf("timeline[%d].Kind = %q, want %q", i, timeline[i].Kind, kind)
+		}
+	}
+
+	if got := timeline[1]; got.Title != "User role change" || got.Detail != "by admin@example.com" {
+		t.Errorf("unexpected audit entry %+v", got)
+	}
+	if got := timeline[3].Detail; got != "Jazz Night · KSh 1500.00 · Completed" {
+		t.Errorf("unexpected order detail %q", got)
+	}
+}
@@ -2,14 +2,21 @@ package services
 
 import (
 	"database/sql"
-	"encoding/csv"
 	"fmt"
-	"strings"
 	"time"
 
+	"event-ticketing-platform/internal/cache"
+	"event-ticketing-platform/internal/models"
 	"event-ticketing-platform/internal/repositories"
 )
 
+// Analytics are polled by open dashboards; purchases invalidate them
+const (
+	analyticsCachePrefix   = "analytics:"
+	analyticsCacheTTL      = 30 * time.Second
+	analyticsCacheStaleTTL = time.Minute
+)
+
 // AnalyticsService handles analytics and reporting operations
 type AnalyticsService struct {
 	db              *sql.DB
@@ -17,6 +24,9 @@ type AnalyticsService struct {
 	eventRepo       *repositories.EventRepository
 	ticketRepo      *repositories.TicketRepository
 	userRepo        *repositories.UserRepository
+	cache           cache.Cache
+	questions       *CheckoutQuestionService
+	views           EventViewCounter
 }
 
 // NewAnalyticsService creates a new analytics service
@@ -32,8 +42,67 @@ func NewAnalyticsService(db *sql.DB, orderRepo *repositories.OrderRepository, ev
 
 
 
+// SetCache enables caching of organizer analytics
+func (s *AnalyticsService) SetCache(c cache.Cache) {
+	s.cache = c
+}
+
+// SetCheckoutQuestions adds attendees' answers to events' checkout
+// questions to the attendee export
+func (s *AnalyticsService) SetCheckoutQuestions(questions *CheckoutQuestionService) {
+	s.questions = questions
+}
+
+// OrderCompleted drops the cached analytics of the order's event and its
+// organizer's dashboard. It implements OrderCompletionHook.
+func (s *AnalyticsService) OrderCompleted(order *models.Order) {
+	s.invalidateOrder(order)
+}
+
+// OrderRefunded drops the cached analytics of the order's event and its
+// organizer's dashboard. It implements OrderRefundHook.
+func (s *AnalyticsService) OrderRefunded(order *models.Order) {
+	s.invalidateOrder(order)
+}
+
+// invalidateOrder drops the cached analytics an order counts towards
+func (s *AnalyticsService) invalidateOrder(order *models.Order) {
+	if s.cache == nil {
+		return
+	}
+
+	if err := s.cache.DeletePrefix(cache.Key(analyticsCachePrefix+"event", order.EventID) + ":"); err != nil {
+		fmt.Printf("Warning: failed to invalidate analytics for event %d: %v\n", order.EventID, err)
+	}
+
+	if err := s.cache.DeletePrefix(analyticsCachePrefix + "platform:"); err != nil {
+		fmt.Printf("Warning: failed to invalidate platform reports: %v\n", err)
+	}
+
+	event, err := s.eventRepo.GetByID(order.EventID)
+	if err != nil {
+		fmt.Printf("Warning: failed to get event %d to invalidate organizer analytics: %v\n", order.EventID, err)
+		return
+	}
+	organizerKey := cache.Key(analyticsCachePrefix+"organizer", event.OrganizerID)
+	if err := s.cache.Delete(organizerKey); err != nil {
+		fmt.Printf("Warning: failed to invalidate analytics for organizer %d: %v\n", event.OrganizerID, err)
+	}
+	if err := s.cache.DeletePrefix(organizerKey + ":"); err != nil {
+		fmt.Printf("Warning: failed to invalidate sales series for organizer %d: %v\n", event.OrganizerID, err)
+	}
+}
+
 // GetOrganizerDashboard retrieves dashboard data for an organizer
 func (s *AnalyticsService) GetOrganizerDashboard(organizerID int) (*OrganizerDashboardData, error) {
+	key := cache.Key(analyticsCachePrefix+"organizer", organizerID)
+	return cache.RememberStale(s.cache, key, analyticsCacheTTL, analyticsCacheStaleTTL, func() (*OrganizerDashboardData, error) {
+		return s.loadOrganizerDashboard(organizerID)
+	})
+}
+
+// loadOrganizerDashboard queries the dashboard data for an organizer
+func (s *AnalyticsService) loadOrganizerDashboard(organizerID int) (*OrganizerDashboardData, error) {
 	dashboard := &OrganizerDashboardData{}
 
 	// Get event counts by status
@@ -77,12 +146,28 @@ func (s *AnalyticsService) GetOrganizerDashboard(organizerID int) (*OrganizerDas
 	if err != nil {
 		return nil, fmt.Errorf("failed to get sales over time: %w", err)
 	}
+	dashboard.SalesSeries = fillDailySales(dashboard.SalesOverTime, time.Now(), 30)
+
+	// Get sales by source
+	dashboard.SalesBySource, err = s.loadSalesBySource(organizerSalesCondition, organizerID)
+	if err != nil {
+		return nil, err
+	}
 
 	return dashboard, nil
 }
 
 // GetEventAnalytics retrieves detailed analytics for a specific event
 func (s *AnalyticsService) GetEventAnalytics(eventID int, organizerID int) (*EventAnalyticsData, error) {
+	// Keyed by organizer too, so a cached result is only served to the organizer it was checked for
+	key := cache.Key(analyticsCachePrefix+"event", eventID, "organizer", organizerID)
+	return cache.RememberStale(s.cache, key, analyticsCacheTTL, analyticsCacheStaleTTL, func() (*EventAnalyticsData, error) {
+		return s.loadEventAnalytics(eventID, organizerID)
+	})
+}
+
+// loadEventAnalytics queries detailed analytics for a specific event
+func (s *AnalyticsService) loadEventAnalytics(eventID int, organizerID int) (*EventAnalyticsData, error) {
 	// Verify organizer owns the event
 	canAccess, err := s.canOrganizerAccessEvent(eventID, organizerID)
 	if err != nil {
@@ -120,6 +205,12 @@ func (s *AnalyticsService) GetEventAnalytics(eventID int, organizerID int) (*Eve
 		return nil, fmt.Errorf("failed to get ticket type analytics: %w", err)
 	}
 
+	// Get donations given with orders
+	analytics.DonationRevenue, analytics.Donations, err = s.getEventDonations(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get donations: %w", err)
+	}
+
 	// Get sales by day
 	analytics.SalesByDay, err = s.getEventSalesByDay(eventID, 30)
 	if err != nil {
@@ -150,70 +241,104 @@ func (s *AnalyticsService) GetEventAnalytics(eventID int, organizerID int) (*Eve
 		return nil, fmt.Errorf("failed to get attendee data: %w", err)
 	}
 
-	return analytics, nil
-}
-
-// ExportAttendeeData exports attendee data as CSV
-func (s *AnalyticsService) ExportAttendeeData(eventID int, organizerID int) ([]byte, error) {
-	// Verify organizer owns the event
-	canAccess, err := s.canOrganizerAccessEvent(eventID, organizerID)
+	// Get sales funnel, comparison to previous events and sales by source
+	analytics.SalesSeries = fillDailySales(analytics.SalesByDay, time.Now(), 30)
+	analytics.Funnel, err = s.loadEventFunnel(eventID, time.Time{})
 	if err != nil {
-		return nil, fmt.Errorf("failed to verify event access: %w", err)
+		return nil, err
 	}
-	if !canAccess {
-		return nil, fmt.Errorf("organizer does not have access to this event")
+	analytics.Comparison, err = s.loadEventComparison(eventID)
+	if err != nil {
+		return nil, err
+	}
+	analytics.SalesBySource, err = s.loadSalesBySource(eventSalesCondition, eventID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Get attendee data
-	attendees, err := s.getAttendeeData(eventID)
+	return analytics, nil
+}
+
+// Helper methods
+
+// GetCategoryPerformance retrieves event and sales performance for every category
+func (s *AnalyticsService) GetCategoryPerformance() ([]*CategoryPerformance, error) {
+	query := `
+		SELECT
+			c.id, c.name, c.slug,
+			COUNT(e.id) as event_count,
+			COUNT(CASE WHEN e.status = 'published' THEN 1 END) as published_events,
+			COALESCE(SUM(cap.capacity), 0) as tickets_available,
+			COALESCE(SUM(sales.tickets_sold), 0) as tickets_sold,
+			COALESCE(SUM(sales.revenue), 0) as revenue,
+			COALESCE(SUM(sales.total_orders), 0) as total_orders,
+			COALESCE(SUM(sales.completed_orders), 0) as completed_orders
+		FROM categories c
+		LEFT JOIN events e ON e.category_id = c.id
+		LEFT JOIN (
+			SELECT event_id, SUM(quantity) as capacity
+			FROM ticket_types
+			GROUP BY event_id
+		) cap ON cap.event_id = e.id
+		LEFT JOIN (
+			SELECT
+				o.event_id,
+				COUNT(*) as total_orders,
+				COUNT(CASE WHEN o.status = 'completed' THEN 1 END) as completed_orders,
+				COALESCE(SUM(CASE WHEN o.status = 'completed' THEN tc.ticket_count END), 0) as tickets_sold,
+				COALESCE(SUM(CASE WHEN o.status = 'completed' THEN o.total_amount END), 0) as revenue
+			FROM orders o
+			LEFT JOIN (
+				SELECT order_id, COUNT(*) as ticket_count
+				FROM tickets
+				GROUP BY order_id
+			) tc ON tc.order_id = o.id
+			GROUP BY o.event_id
+		) sales ON sales.event_id = e.id
+		GROUP BY c.id, c.name, c.slug
+		ORDER BY revenue DESC, c.name ASC`
+
+	rows, err := s.db.Query(query)
 	if err != nil {
-		return nil, fmt.Errorf("failed to get attendee data: %w", err)
+		return nil, fmt.Errorf("failed to query category performance: %w", err)
 	}
+	defer rows.Close()
 
-	// Create CSV
-	var csvData strings.Builder
-	writer := csv.NewWriter(&csvData)
-
-	// Write header
-	header := []string{
-		"Order Number",
-		"Attendee Name",
-		"Email",
-		"Ticket Count",
-		"Total Amount",
-		"Order Date",
-		"Status",
-	}
-	if err := writer.Write(header); err != nil {
-		return nil, fmt.Errorf("failed to write CSV header: %w", err)
-	}
-
-	// Write data rows
-	for _, attendee := range attendees {
-		row := []string{
-			attendee.OrderNumber,
-			attendee.BillingName,
-			attendee.BillingEmail,
-			fmt.Sprintf("%d", attendee.TicketCount),
-			fmt.Sprintf("%.2f", attendee.TotalAmount),
-			attendee.OrderDate.Format("2006-01-02 15:04:05"),
-			attendee.Status,
+	var categories []*CategoryPerformance
+	for rows.Next() {
+		category := &CategoryPerformance{}
+		var revenue int64
+		err := rows.Scan(
+			&category.CategoryID,
+			&category.Name,
+			&category.Slug,
+			&category.EventCount,
+			&category.PublishedEvents,
+			&category.TicketsAvailable,
+			&category.TicketsSold,
+			&revenue,
+			&category.TotalOrders,
+			&category.CompletedOrders,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan category performance: %w", err)
 		}
-		if err := writer.Write(row); err != nil {
-			return nil, fmt.Errorf("failed to write CSV row: %w", err)
+		category.Revenue = float64(revenue) / 100.0
+
+		if category.TotalOrders > 0 {
+			category.ConversionRate = (float64(category.CompletedOrders) / float64(category.TotalOrders)) * 100
+		}
+
+		if category.TicketsAvailable > 0 {
+			category.SellThroughRate = (float64(category.TicketsSold) / float64(category.TicketsAvailable)) * 100
 		}
-	}
 
-	writer.Flush()
-	if err := writer.Error(); err != nil {
-		return nil, fmt.Errorf("failed to flush CSV writer: %w", err)
+		categories = append(categories, category)
 	}
 
-	return []byte(csvData.String()), nil
+	return categories, rows.Err()
 }
 
-// Helper methods
-
 func (s *AnalyticsService) getEventCountsByStatus(organizerID int) (map[string]int, error) {
 	query := `
 		SELECT 
@@ -443,7 +568,14 @@ func (s *AnalyticsService) getSalesOverTime(organizerID int, days int) ([]*Daily
 
 func (s *AnalyticsService) canOrganizerAccessEvent(eventID int, organizerID int) (bool, error) {
 	var count int
-	query := `SELECT COUNT(*) FROM events WHERE id = $1 AND organizer_id = $2`
+	// Team members the organizer granted analytics access count too
+	query := `
+		SELECT COUNT(*) FROM events e
+		WHERE e.id = $1 AND (e.organizer_id = $2 OR EXISTS (
+			SELECT 1 FROM organizer_members m
+			WHERE m.organizer_id = e.organizer_id AND m.user_id = $2
+				AND m.accepted_at IS NOT NULL AND m.can_view_analytics
+		))`
 	err := s.db.QueryRow(query, eventID, organizerID).Scan(&count)
 	if err != nil {
 		return false, err
@@ -482,14 +614,15 @@ func (s *AnalyticsService) getEventStatistics(eventID int) (map[string]float64,
 func (s *AnalyticsService) getTicketTypeAnalytics(eventID int) ([]*TicketTypeAnalytics, error) {
 	query := `
 		SELECT 
-			tt.id, tt.name, tt.price, tt.quantity,
+			tt.id, tt.name, tt.price, tt.quantity, tt.pay_what_you_want,
 			COUNT(t.id) as tickets_sold,
-			COALESCE(SUM(CASE WHEN o.status = 'completed' THEN tt.price END), 0) as revenue
+			COALESCE(SUM(CASE WHEN o.status = 'completed' THEN COALESCE(p.unit_price, tt.price) END), 0) as revenue
 		FROM ticket_types tt
 		LEFT JOIN tickets t ON tt.id = t.ticket_type_id
 		LEFT JOIN orders o ON t.order_id = o.id
+		LEFT JOIN order_item_prices p ON p.order_id = t.order_id AND p.ticket_type_id = t.ticket_type_id
 		WHERE tt.event_id = $1
-		GROUP BY tt.id, tt.name, tt.price, tt.quantity
+		GROUP BY tt.id, tt.name, tt.price, tt.quantity, tt.pay_what_you_want
 		ORDER BY tt.price DESC`
 
 	rows, err := s.db.Query(query, eventID)
@@ -507,6 +640,7 @@ func (s *AnalyticsService) getTicketTypeAnalytics(eventID int) ([]*TicketTypeAna
 			&data.Name,
 			&price,
 			&data.TotalTickets,
+			&data.PayWhatYouWant,
 			&data.TicketsSold,
 			&revenue,
 		)
@@ -515,6 +649,9 @@ func (s *AnalyticsService) getTicketTypeAnalytics(eventID int) ([]*TicketTypeAna
 		}
 		data.Price = float64(price) / 100.0
 		data.Revenue = float64(revenue) / 100.0
+		if data.TicketsSold > 0 {
+			data.AveragePrice = data.Revenue / float64(data.TicketsSold)
+		}
 		
 		if data.TotalTickets > 0 {
 			data.SoldOutPercentage = (float64(data.TicketsSold) / float64(data.TotalTickets)) * 100
@@ -526,6 +663,21 @@ func (s *AnalyticsService) getTicketTypeAnalytics(eventID int) ([]*TicketTypeAna
 	return analytics, rows.Err()
 }
 
+// getEventDonations returns the total of the donations given with an event's
+// completed orders, which is part of its revenue, and how many were given
+func (s *AnalyticsService) getEventDonations(eventID int) (float64, int, error) {
+	query := `
+		SELECT COALESCE(SUM(donation_amount), 0), COUNT(CASE WHEN donation_amount > 0 THEN 1 END)
+		FROM orders
+		WHERE event_id = $1 AND status = 'completed'`
+
+	var total, count int
+	if err := s.db.QueryRow(query, eventID).Scan(&total, &count); err != nil {
+		return 0, 0, err
+	}
+	return float64(total) / 100.0, count, nil
+}
+
 func (s *AnalyticsService) getEventSalesByDay(eventID int, days int) ([]*DailySales, error) {
 	query := `
 		SELECT 
@@ -25,7 +25,7 @@ func TestAnalyticsService_GetEventAnalytics(t *testing.T) {
 	})
 }
 
-func TestAnalyticsService_ExportAttendeeData(t *testing.T) {
+func TestAnalyticsService_ExportAttendees(t *testing.T) {
 	t.Run("test requires database setup", func(t *testing.T) {
 		// This test would require a real database setup to test properly
 		// For now, we'll skip it and focus on data structure tests
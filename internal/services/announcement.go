@@ -0,0 +1,179 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"event-ticketing-platform/internal/cache"
+	"event-ticketing-platform/internal/models"
+)
+
+// Cache settings for announcements, which are read on every page
+const (
+	announcementCacheKey = "announcements:current"
+	announcementCacheTTL = 5 * time.Minute
+)
+
+// AnnouncementRepository defines the data operations for announcements
+type AnnouncementRepository interface {
+	List() ([]*models.Announcement, error)
+	GetCurrent(at time.Time) ([]*models.Announcement, error)
+	GetByID(id int) (*models.Announcement, error)
+	Create(req *models.AnnouncementRequest, adminID int) (*models.Announcement, error)
+	Update(id int, req *models.AnnouncementRequest) (*models.Announcement, error)
+	Delete(id int) error
+}
+
+// AnnouncementService manages the banners admins publish across the site
+// and picks the ones each visitor sees
+type AnnouncementService struct {
+	repo         AnnouncementRepository
+	cache        cache.Cache
+	auditService *AuditService
+	now          func() time.Time
+}
+
+// NewAnnouncementService creates a new announcement service
+func NewAnnouncementService(repo AnnouncementRepository) *AnnouncementService {
+	return &AnnouncementService{repo: repo, now: time.Now}
+}
+
+// SetCache enables caching of the current announcements
+func (s *AnnouncementService) SetCache(c cache.Cache) {
+	s.cache = c
+}
+
+// SetAuditService records announcement changes in the audit log
+func (s *AnnouncementService) SetAuditService(auditService *AuditService) {
+	s.auditService = auditService
+}
+
+// List returns every announcement, those starting latest first
+func (s *AnnouncementService) List() ([]*models.Announcement, error) {
+	return s.repo.List()
+}
+
+// Get returns an announcement
+func (s *AnnouncementService) Get(id int) (*models.Announcement, error) {
+	return s.repo.GetByID(id)
+}
+
+// Create publishes an announcement on behalf of an admin
+func (s *AnnouncementService) Create(adminID int, req *models.AnnouncementRequest, r *http.Request) (*models.Announcement, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	announcement, err := s.repo.Create(req, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateCache()
+	s.logAction(adminID, models.AuditActionAnnouncementCreate, announcement, r)
+	return announcement, nil
+}
+
+// Update changes an announcement on behalf of an admin
+func (s *AnnouncementService) Update(adminID, id int, req *models.AnnouncementRequest, r *http.Request) (*models.Announcement, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	announcement, err := s.repo.Update(id, req)
+	if err != nil {
+		return nil, err
+	}
+
+	s.invalidateCache()
+	s.logAction(adminID, models.AuditActionAnnouncementUpdate, announcement, r)
+	return announcement, nil
+}
+
+// Delete removes an announcement on behalf of an admin
+func (s *AnnouncementService) Delete(adminID, id int, r *http.Request) error {
+	announcement, err := s.repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		return err
+	}
+
+	s.invalidateCache()
+	s.logAction(adminID, models.AuditActionAnnouncementDelete, announcement, r)
+	return nil
+}
+
+// Visible returns the announcements shown to the user right now, nil for
+// visitors who aren't signed in, leaving out the ones they dismissed. The
+// most urgent come first. It never fails: if the announcements can't be
+// loaded none are shown.
+func (s *AnnouncementService) Visible(user *models.User, dismissed map[int]bool) []*models.Announcement {
+	// Announcements that haven't started yet are cached too, so each one
+	// appears as it starts without waiting for the cache to expire
+	current, err := cache.Remember(s.cache, announcementCacheKey, announcementCacheTTL, func() ([]*models.Announcement, error) {
+		return s.repo.GetCurrent(s.now())
+	})
+	if err != nil {
+		fmt.Printf("Warning: failed to load announcements: %v\n", err)
+		return nil
+	}
+
+	now := s.now()
+	var visible []*models.Announcement
+	for _, announcement := range current {
+		if !announcement.IsActive(now) || !announcement.Audience.Includes(user) {
+			continue
+		}
+		if announcement.Dismissible && dismissed[announcement.ID] {
+			continue
+		}
+		visible = append(visible, announcement)
+	}
+
+	sort.SliceStable(visible, func(i, j int) bool {
+		return announcementUrgency(visible[i].Level) > announcementUrgency(visible[j].Level)
+	})
+	return visible
+}
+
+// announcementUrgency ranks levels from least to most urgent
+func announcementUrgency(level models.AnnouncementLevel) int {
+	switch level {
+	case models.AnnouncementCritical:
+		return 2
+	case models.AnnouncementWarning:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// invalidateCache drops the cached announcements after they change
+func (s *AnnouncementService) invalidateCache() {
+	if s.cache == nil {
+		return
+	}
+	if err := s.cache.Delete(announcementCacheKey); err != nil {
+		fmt.Printf("Warning: failed to invalidate cached announcements: %v\n", err)
+	}
+}
+
+// logAction records an announcement change in the audit log
+func (s *AnnouncementService) logAction(adminID int, action string, announcement *models.Announcement, r *http.Request) {
+	if s.auditService == nil {
+		return
+	}
+	details := map[string]interface{}{
+		"message":  announcement.Message,
+		"level":    string(announcement.Level),
+		"audience": string(announcement.Audience),
+	}
+	if err := s.auditService.LogAction(adminID, action, models.AuditTargetAnnouncement, announcement.ID, details, r); err != nil {
+		fmt.Printf("Warning: failed to log announcement change: %v\n", err)
+	}
+}
@@ -0,0 +1,143 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/cache"
+	"event-ticketing-platform/internal/models"
+)
+
+type mockAnnouncementRepository struct {
+	announcements []*models.Announcement
+	currentLoads  int
+}
+
+func (m *mockAnnouncementRepository) List() ([]*models.Announcement, error) {
+	return m.announcements, nil
+}
+
+func (m *mockAnnouncementRepository) GetCurrent(at time.Time) ([]*models.Announcement, error) {
+	m.currentLoads++
+	var current []*models.Announcement
+	for _, announcement := range m.announcements {
+		if !announcement.HasEnded(at) {
+			current = append(current, announcement)
+		}
+	}
+	return current, nil
+}
+
+func (m *mockAnnouncementRepository) GetByID(id int) (*models.Announcement, error) {
+	for _, announcement := range m.announcements {
+		if announcement.ID == id {
+			return announcement, nil
+		}
+	}
+	return nil, errors.New("announcement not found")
+}
+
+func (m *mockAnnouncementRepository) Create(req *models.AnnouncementRequest, adminID int) (*models.Announcement, error) {
+	announcement := &models.Announcement{
+		ID:          len(m.announcements) + 1,
+		Message:     req.Message,
+		Level:       req.Level,
+		Audience:    req.Audience,
+		StartsAt:    req.StartsAt,
+		EndsAt:      req.EndsAt,
+		Dismissible: req.Dismissible,
+		CreatedBy:   &adminID,
+	}
+	m.announcements = append(m.announcements, announcement)
+	return announcement, nil
+}
+
+func (m *mockAnnouncementRepository) Update(id int, req *models.AnnouncementRequest) (*models.Announcement, error) {
+	announcement, err := m.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	announcement.Message = req.Message
+	return announcement, nil
+}
+
+func (m *mockAnnouncementRepository) Delete(id int) error {
+	return nil
+}
+
+func TestAnnouncementService_Visible(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	ended := now.Add(-time.Hour)
+	repo := &mockAnnouncementRepository{announcements: []*models.Announcement{
+		{ID: 1, Message: "New fees", Level: models.AnnouncementInfo, Audience: models.AnnouncementOrganizers, StartsAt: now.Add(-time.Hour), Dismissible: true},
+		{ID: 2, Message: "Outage", Level: models.AnnouncementCritical, Audience: models.AnnouncementEveryone, StartsAt: now.Add(-time.Hour), Dismissible: true},
+		{ID: 3, Message: "Maintenance tonight", Level: models.AnnouncementWarning, Audience: models.AnnouncementEveryone, StartsAt: now.Add(-time.Hour)},
+		{ID: 4, Message: "Next week", Level: models.AnnouncementInfo, Audience: models.AnnouncementEveryone, StartsAt: now.Add(time.Hour)},
+		{ID: 5, Message: "Last week", Level: models.AnnouncementInfo, Audience: models.AnnouncementEveryone, StartsAt: now.Add(-48 * time.Hour), EndsAt: &ended},
+		{ID: 6, Message: "Admins only", Level: models.AnnouncementInfo, Audience: models.AnnouncementAdmins, StartsAt: now.Add(-time.Hour)},
+	}}
+	service := NewAnnouncementService(repo)
+	service.now = func() time.Time { return now }
+
+	ids := func(announcements []*models.Announcement) []int {
+		var ids []int
+		for _, announcement := range announcements {
+			ids = append(ids, announcement.ID)
+		}
+		return ids
+	}
+
+	organizer := &models.User{ID: 7, Role: models.UserRoleOrganizer}
+	if got := ids(service.Visible(organizer, nil)); fmt.Sprint(got) != fmt.Sprint([]int{2, 3, 1}) {
+		t.Errorf("expected the active organizer announcements most urgent first, got %v", got)
+	}
+	if got := ids(service.Visible(nil, nil)); fmt.Sprint(got) != fmt.Sprint([]int{2, 3}) {
+		t.Errorf("expected visitors to see announcements for everyone, got %v", got)
+	}
+
+	// Announcements that can't be dismissed stay
+	dismissed := map[int]bool{2: true, 3: true}
+	if got := ids(service.Visible(nil, dismissed)); fmt.Sprint(got) != fmt.Sprint([]int{3}) {
+		t.Errorf("expected a dismissed announcement hidden, got %v", got)
+	}
+
+	// The scheduled announcement appears once it starts
+	now = now.Add(2 * time.Hour)
+	if got := ids(service.Visible(nil, nil)); fmt.Sprint(got) != fmt.Sprint([]int{2, 3, 4}) {
+		t.Errorf("expected the scheduled announcement shown once it starts, got %v", got)
+	}
+}
+
+func TestAnnouncementService_CachesCurrent(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	repo := &mockAnnouncementRepository{}
+	service := NewAnnouncementService(repo)
+	service.SetCache(cache.NewMemoryCache())
+	service.now = func() time.Time { return now }
+
+	for i := 0; i < 3; i++ {
+		service.Visible(nil, nil)
+	}
+	if repo.currentLoads != 1 {
+		t.Errorf("expected announcements loaded once, got %d", repo.currentLoads)
+	}
+
+	req := &models.AnnouncementRequest{
+		Message:  "Tickets for the festival go on sale Friday",
+		Level:    models.AnnouncementInfo,
+		Audience: models.AnnouncementEveryone,
+		StartsAt: now.Add(-time.Minute),
+	}
+	if _, err := service.Create(1, req, nil); err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+	if visible := service.Visible(nil, nil); len(visible) != 1 {
+		t.Errorf("expected a new announcement shown straight away, got %d", len(visible))
+	}
+
+	if _, err := service.Create(1, &models.AnnouncementRequest{Level: models.AnnouncementInfo}, nil); err == nil {
+		t.Error("expected an error for an announcement without a message")
+	}
+}
@@ -0,0 +1,119 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// ErrInvalidAPIToken is returned for unknown, revoked or malformed API tokens
+var ErrInvalidAPIToken = errors.New("invalid API token")
+
+// apiTokenUseInterval is how often a token's last use is written back
+const apiTokenUseInterval = time.Minute
+
+// APITokenRepository stores API tokens
+type APITokenRepository interface {
+	Create(token *models.APIToken) error
+	GetByHash(hash string) (*models.APIToken, error)
+	GetByUser(userID int) ([]*models.APIToken, error)
+	MarkUsed(id int) error
+	Revoke(id int) error
+}
+
+// APITokenService issues API tokens and authenticates requests made with them
+type APITokenService struct {
+	repo     APITokenRepository
+	userRepo UserRepository
+	now      func() time.Time
+}
+
+// NewAPITokenService creates a new API token service
+func NewAPITokenService(repo APITokenRepository, userRepo UserRepository) *APITokenService {
+	return &APITokenService{
+		repo:     repo,
+		userRepo: userRepo,
+		now:      time.Now,
+	}
+}
+
+// IssueToken creates a token for a user. The returned token is the only
+// time its value is available.
+func (s *APITokenService) IssueToken(userID int, name string) (string, *models.APIToken, error) {
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return "", nil, fmt.Errorf("user not found: %w", err)
+	}
+	if !user.IsActive {
+		return "", nil, fmt.Errorf("cannot issue a token to a suspended account")
+	}
+
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return "", nil, fmt.Errorf("failed to generate API token: %w", err)
+	}
+	raw := models.APITokenPrefix + hex.EncodeToString(tokenBytes)
+
+	token := &models.APIToken{
+		UserID:    userID,
+		Name:      name,
+		TokenHash: models.HashAPIToken(raw),
+		Prefix:    models.APITokenDisplayPrefix(raw),
+	}
+	if err := token.Validate(); err != nil {
+		return "", nil, err
+	}
+	if err := s.repo.Create(token); err != nil {
+		return "", nil, err
+	}
+	return raw, token, nil
+}
+
+// Authenticate returns the account a token belongs to
+func (s *APITokenService) Authenticate(raw string) (*models.User, error) {
+	raw = strings.TrimSpace(raw)
+	if !strings.HasPrefix(raw, models.APITokenPrefix) {
+		return nil, ErrInvalidAPIToken
+	}
+
+	token, err := s.repo.GetByHash(models.HashAPIToken(raw))
+	if err != nil || token.IsRevoked() {
+		return nil, ErrInvalidAPIToken
+	}
+
+	user, err := s.userRepo.GetByID(token.UserID)
+	if err != nil || !user.IsActive {
+		return nil, ErrInvalidAPIToken
+	}
+
+	if token.LastUsedAt == nil || s.now().Sub(*token.LastUsedAt) >= apiTokenUseInterval {
+		if err := s.repo.MarkUsed(token.ID); err != nil {
+			fmt.Printf("Warning: failed to record API token use: %v\n", err)
+		}
+	}
+	return user, nil
+}
+
+// GetTokens returns the tokens issued to a user
+func (s *APITokenService) GetTokens(userID int) ([]*models.APIToken, error) {
+	return s.repo.GetByUser(userID)
+}
+
+// RevokeToken revokes one of a user's tokens
+func (s *APITokenService) RevokeToken(tokenID, userID int) error {
+	tokens, err := s.repo.GetByUser(userID)
+	if err != nil {
+		return err
+	}
+	for _, token := range tokens {
+		if token.ID == tokenID {
+			return s.repo.Revoke(tokenID)
+		}
+	}
+	return fmt.Errorf("API token not found")
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+type mockAPITokenRepository struct {
+	tokens []*models.APIToken
+	used   []int
+}
+
+func (m *mockAPITokenRepository) Create(token *models.APIToken) error {
+	token.ID = len(m.tokens) + 1
+	m.tokens = append(m.tokens, token)
+	return nil
+}
+
+func (m *mockAPITokenRepository) GetByHash(hash string) (*models.APIToken, error) {
+	for _, token := range m.tokens {
+		if token.TokenHash == hash {
+			return token, nil
+		}
+	}
+	return nil, errors.New("API token not found")
+}
+
+func (m *mockAPITokenRepository) GetByUser(userID int) ([]*models.APIToken, error) {
+	var tokens []*models.APIToken
+	for _, token := range m.tokens {
+		if token.UserID == userID {
+			tokens = append(tokens, token)
+		}
+	}
+	return tokens, nil
+}
+
+func (m *mockAPITokenRepository) MarkUsed(id int) error {
+	m.used = append(m.used, id)
+	return nil
+}
+
+func (m *mockAPITokenRepository) Revoke(id int) error {
+	now := time.Now()
+	m.tokens[id-1].RevokedAt = &now
+	return nil
+}
+
+func setupAPITokenService() (*APITokenService, *mockAPITokenRepository, *MockUserRepository) {
+	repo := &mockAPITokenRepository{}
+	users := &MockUserRepository{}
+	users.On("GetByID", 7).Return(&models.User{ID: 7, IsActive: true}, nil)
+	users.On("GetByID", 8).Return(&models.User{ID: 8, IsActive: false}, nil)
+	return NewAPITokenService(repo, users), repo, users
+}
+
+func TestAPITokenService_IssueAndAuthenticate(t *testing.T) {
+	service, repo, _ := setupAPITokenService()
+
+	raw, token, err := service.IssueToken(7, "Partner sync")
+	if err != nil {
+		t.Fatalf("IssueToken failed: %v", err)
+	}
+	if !strings.HasPrefix(raw, models.APITokenPrefix) {
+		t.Errorf("expected token to start with %q, got %q", models.APITokenPrefix, raw)
+	}
+	if token.TokenHash == raw || token.TokenHash != models.HashAPIToken(raw) {
+		t.Error("expected only the token hash to be stored")
+	}
+
+	user, err := service.Authenticate(raw)
+	if err != nil {
+		t.Fatalf("Authenticate failed: %v", err)
+	}
+	if user.ID != 7 {
+		t.Errorf("expected user 7, got %d", user.ID)
+	}
+	if len(repo.used) != 1 {
+		t.Errorf("expected token use to be recorded once, got %d", len(repo.used))
+	}
+
+	// Uses within the interval are not written back
+	usedAt := time.Now()
+	token.LastUsedAt = &usedAt
+	if _, err := service.Authenticate(raw); err != nil {
+		t.Fatalf("Authenticate failed: %v", err)
+	}
+	if len(repo.used) != 1 {
+		t.Errorf("expected recent use not to be recorded again, got %d", len(repo.used))
+	}
+}
+
+func TestAPITokenService_AuthenticateRejectsInvalidTokens(t *testing.T) {
+	service, repo, _ := setupAPITokenService()
+
+	raw, token, err := service.IssueToken(7, "Partner sync")
+	if err != nil {
+		t.Fatalf("IssueToken failed: %v", err)
+	}
+
+	for _, candidate := range []string{"", "not-a-token", models.APITokenPrefix + "unknown"} {
+		if _, err := service.Authenticate(candidate); err != ErrInvalidAPIToken {
+			t.Errorf("expected ErrInvalidAPIToken for %q, got %v", candidate, err)
+		}
+	}
+
+	if err := service.RevokeToken(token.ID, 7); err != nil {
+		t.Fatalf("RevokeToken failed: %v", err)
+	}
+	if _, err := service.Authenticate(raw); err != ErrInvalidAPIToken {
+		t.Errorf("expected revoked token to be rejected, got %v", err)
+	}
+
+	// Tokens stop working when their account is suspended
+	repo.tokens = append(repo.tokens, &models.APIToken{ID: 2, UserID: 8, TokenHash: models.HashAPIToken("rt_suspended")})
+	if _, err := service.Authenticate("rt_suspended"); err != ErrInvalidAPIToken {
+		t.Errorf("expected suspended account's token to be rejected, got %v", err)
+	}
+}
+
+func TestAPITokenService_IssueTokenRejectsSuspendedAccounts(t *testing.T) {
+	service, _, _ := setupAPITokenService()
+
+	if _, _, err := service.IssueToken(8, "Partner sync"); err == nil {
+		t.Error("expected error issuing a token to a suspended account")
+	}
+	if _, _, err := service.IssueToken(7, " "); err == nil {
+		t.Error("expected error for a token without a name")
+	}
+}
+
+func TestAPITokenService_RevokeTokenChecksOwner(t *testing.T) {
+	service, _, users := setupAPITokenService()
+	users.On("GetByID", 9).Return(&models.User{ID: 9, IsActive: true}, nil)
+
+	_, token, err := service.IssueToken(7, "Partner sync")
+	if err != nil {
+		t.Fatalf("IssueToken failed: %v", err)
+	}
+	if err := service.RevokeToken(token.ID, 9); err == nil {
+		t.Error("expected error revoking another user's token")
+	}
+	if token.IsRevoked() {
+		t.Error("expected token to stay active")
+	}
+}
@@ -0,0 +1,116 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Arrival slot errors shown to buyers at checkout
+var (
+	ErrArrivalSlotRequired = errors.New("please choose an arrival time")
+	ErrArrivalSlotFull     = errors.New("that arrival time is full, please choose another")
+)
+
+// ArrivalSlotRepository defines the data operations for event arrival slots
+type ArrivalSlotRepository interface {
+	Create(req *models.ArrivalSlotCreateRequest) (*models.ArrivalSlot, error)
+	GetByID(id int) (*models.ArrivalSlot, error)
+	GetByEvent(eventID int) ([]*models.ArrivalSlot, error)
+	Delete(id, eventID int) error
+}
+
+// ArrivalSlotLookup loads the arrival slot an order chose, to print it on
+// tickets and check it at the entrance
+type ArrivalSlotLookup interface {
+	GetSlot(id int) (*models.ArrivalSlot, error)
+}
+
+// ArrivalSlotService manages the arrival windows organizers can ask buyers to
+// choose from. Events without slots do not ask buyers to choose one.
+type ArrivalSlotService struct {
+	repo ArrivalSlotRepository
+}
+
+// NewArrivalSlotService creates a new arrival slot service
+func NewArrivalSlotService(repo ArrivalSlotRepository) *ArrivalSlotService {
+	return &ArrivalSlotService{repo: repo}
+}
+
+// GetSlots returns an event's arrival slots, earliest first
+func (s *ArrivalSlotService) GetSlots(eventID int) ([]*models.ArrivalSlot, error) {
+	slots, err := s.repo.GetByEvent(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get arrival slots: %w", err)
+	}
+	return slots, nil
+}
+
+// GetSlot returns an arrival slot with its bookings
+func (s *ArrivalSlotService) GetSlot(id int) (*models.ArrivalSlot, error) {
+	slot, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get arrival slot: %w", err)
+	}
+	return slot, nil
+}
+
+// AddSlot validates and adds an arrival slot to an event
+func (s *ArrivalSlotService) AddSlot(req *models.ArrivalSlotCreateRequest) (*models.ArrivalSlot, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	slot, err := s.repo.Create(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add arrival slot: %w", err)
+	}
+	return slot, nil
+}
+
+// DeleteSlot removes an arrival slot from an event. Slots that tickets have
+// already chosen are kept, since the time is printed on those tickets.
+func (s *ArrivalSlotService) DeleteSlot(eventID, slotID int) error {
+	slot, err := s.repo.GetByID(slotID)
+	if err != nil || slot.EventID != eventID {
+		return fmt.Errorf("arrival slot with id %d not found", slotID)
+	}
+	if slot.Booked > 0 {
+		return errors.New("arrival times that tickets have already chosen cannot be deleted")
+	}
+
+	if err := s.repo.Delete(slotID, eventID); err != nil {
+		return fmt.Errorf("failed to delete arrival slot: %w", err)
+	}
+	return nil
+}
+
+// ChooseSlot checks the arrival slot a buyer chose for the given number of
+// tickets and returns it. It returns nil if the event does not use arrival
+// slots. Caps are checked when the buyer checks out, so a slot can end up
+// slightly over capacity when the last places are bought at the same time.
+func (s *ArrivalSlotService) ChooseSlot(eventID int, slotID *int, quantity int) (*models.ArrivalSlot, error) {
+	slots, err := s.GetSlots(eventID)
+	if err != nil {
+		return nil, err
+	}
+	if len(slots) == 0 {
+		return nil, nil
+	}
+	if slotID == nil {
+		return nil, ErrArrivalSlotRequired
+	}
+
+	for _, slot := range slots {
+		if slot.ID != *slotID {
+			continue
+		}
+		if slot.Remaining() < quantity {
+			return nil, ErrArrivalSlotFull
+		}
+		return slot, nil
+	}
+
+	return nil, ErrArrivalSlotRequired
+}
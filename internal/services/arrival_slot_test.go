@@ -0,0 +1,177 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock ArrivalSlotRepository for testing
+type mockArrivalSlotRepository struct {
+	slots  map[int]*models.ArrivalSlot
+	nextID int
+}
+
+func newMockArrivalSlotRepository() *mockArrivalSlotRepository {
+	return &mockArrivalSlotRepository{slots: make(map[int]*models.ArrivalSlot), nextID: 1}
+}
+
+func (m *mockArrivalSlotRepository) Create(req *models.ArrivalSlotCreateRequest) (*models.ArrivalSlot, error) {
+	slot := &models.ArrivalSlot{
+		ID:       m.nextID,
+		EventID:  req.EventID,
+		StartsAt: req.StartsAt,
+		EndsAt:   req.EndsAt,
+		Capacity: req.Capacity,
+	}
+	m.slots[slot.ID] = slot
+	m.nextID++
+	return slot, nil
+}
+
+func (m *mockArrivalSlotRepository) GetByID(id int) (*models.ArrivalSlot, error) {
+	slot, ok := m.slots[id]
+	if !ok {
+		return nil, fmt.Errorf("arrival slot with id %d not found", id)
+	}
+	return slot, nil
+}
+
+func (m *mockArrivalSlotRepository) GetByEvent(eventID int) ([]*models.ArrivalSlot, error) {
+	var result []*models.ArrivalSlot
+	for id := 1; id < m.nextID; id++ {
+		if slot, ok := m.slots[id]; ok && slot.EventID == eventID {
+			result = append(result, slot)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockArrivalSlotRepository) Delete(id, eventID int) error {
+	delete(m.slots, id)
+	return nil
+}
+
+func setupArrivalSlotService(t *testing.T) (*ArrivalSlotService, *mockArrivalSlotRepository) {
+	repo := newMockArrivalSlotRepository()
+	service := NewArrivalSlotService(repo)
+
+	start := time.Date(2025, 6, 1, 18, 0, 0, 0, time.UTC)
+	for i := 0; i < 2; i++ {
+		_, err := service.AddSlot(&models.ArrivalSlotCreateRequest{
+			EventID:  1,
+			StartsAt: start.Add(time.Duration(i) * 30 * time.Minute),
+			EndsAt:   start.Add(time.Duration(i+1) * 30 * time.Minute),
+			Capacity: 10,
+		})
+		if err != nil {
+			t.Fatalf("failed to add slot: %v", err)
+		}
+	}
+	repo.slots[1].Booked = 8
+
+	return service, repo
+}
+
+func TestArrivalSlotService_ChooseSlot(t *testing.T) {
+	service, _ := setupArrivalSlotService(t)
+	slotID := func(id int) *int { return &id }
+
+	tests := []struct {
+		name     string
+		eventID  int
+		slotID   *int
+		quantity int
+		wantSlot int
+		wantErr  error
+	}{
+		{name: "event without slots", eventID: 2, quantity: 2},
+		{name: "slot with room", eventID: 1, slotID: slotID(1), quantity: 2, wantSlot: 1},
+		{name: "slot without enough room", eventID: 1, slotID: slotID(1), quantity: 3, wantErr: ErrArrivalSlotFull},
+		{name: "no slot chosen", eventID: 1, quantity: 1, wantErr: ErrArrivalSlotRequired},
+		{name: "slot for another event", eventID: 1, slotID: slotID(99), quantity: 1, wantErr: ErrArrivalSlotRequired},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			slot, err := service.ChooseSlot(tt.eventID, tt.slotID, tt.quantity)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if tt.wantSlot == 0 {
+				if slot != nil {
+					t.Errorf("expected no slot, got %d", slot.ID)
+				}
+				return
+			}
+			if slot == nil || slot.ID != tt.wantSlot {
+				t.Errorf("expected slot %d, got %v", tt.wantSlot, slot)
+			}
+		})
+	}
+}
+
+func TestArrivalSlotService_DeleteSlot(t *testing.T) {
+	service, repo := setupArrivalSlotService(t)
+
+	if err := service.DeleteSlot(1, 1); err == nil || !strings.Contains(err.Error(), "cannot be deleted") {
+		t.Errorf("expected booked slot to be kept, got %v", err)
+	}
+	if err := service.DeleteSlot(2, 2); err == nil || !strings.Contains(err.Error(), "not found") {
+		t.Errorf("expected slot of another event to be not found, got %v", err)
+	}
+	if err := service.DeleteSlot(1, 2); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, ok := repo.slots[2]; ok {
+		t.Error("expected unbooked slot to be deleted")
+	}
+}
+
+func TestTicketScanService_ScanTicket_ArrivalSlot(t *testing.T) {
+	service, _, ticketRepo := setupTicketScanService()
+	slots, _ := setupArrivalSlotService(t)
+	service.SetArrivalSlots(slots)
+
+	var ticket *models.Ticket
+	for _, candidate := range ticketRepo.tickets {
+		if candidate.QRCode == "QR-ACTIVE" {
+			ticket = candidate
+		}
+	}
+	order, _ := service.orderRepo.GetByID(ticket.OrderID)
+	slotID := 1
+	order.ArrivalSlotID = &slotID
+
+	tests := []struct {
+		name     string
+		now      time.Time
+		expected string
+	}{
+		{name: "early", now: time.Date(2025, 6, 1, 17, 20, 0, 0, time.UTC), expected: "arrived 40 min early for the 18:00–18:30 arrival slot"},
+		{name: "within grace period", now: time.Date(2025, 6, 1, 18, 40, 0, 0, time.UTC), expected: ""},
+		{name: "late", now: time.Date(2025, 6, 1, 20, 0, 0, 0, time.UTC), expected: "arrived 1 h 30 min late for the 18:00–18:30 arrival slot"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ticket.Status = models.TicketActive
+			service.now = func() time.Time { return tt.now }
+
+			scan, err := service.ScanTicket(&ScanRequest{EventID: 1, QRCode: "QR-ACTIVE", StaffUserID: 7})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if scan.Result != models.ScanAccepted {
+				t.Fatalf("expected ticket to be admitted, got %s", scan.Result)
+			}
+			if scan.Reason != tt.expected {
+				t.Errorf("expected reason %q, got %q", tt.expected, scan.Reason)
+			}
+		})
+	}
+}
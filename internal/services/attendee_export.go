@@ -0,0 +1,270 @@
+package services
+
+import (
+	"database/sql"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// AttendeeExportFormat is a file format attendee lists export to
+type AttendeeExportFormat string
+
+const (
+	AttendeeExportCSV  AttendeeExportFormat = "csv"
+	AttendeeExportXLSX AttendeeExportFormat = "xlsx"
+)
+
+// ContentType returns the MIME type of the export format
+func (f AttendeeExportFormat) ContentType() string {
+	if f == AttendeeExportXLSX {
+		return "application/vnd.openxmlformats-officedocument.spreadsheetml.sheet"
+	}
+	return "text/csv"
+}
+
+// AttendeeExportOptions selects the format of an attendee export and the
+// tickets it includes
+type AttendeeExportOptions struct {
+	Format       AttendeeExportFormat
+	TicketTypeID int                // 0 exports every ticket type
+	OrderStatus  models.OrderStatus // empty exports completed orders
+}
+
+// Validate fills in defaults and checks the export options
+func (o *AttendeeExportOptions) Validate() error {
+	if o.Format == "" {
+		o.Format = AttendeeExportCSV
+	}
+	if o.Format != AttendeeExportCSV && o.Format != AttendeeExportXLSX {
+		return errors.New("export format must be csv or xlsx")
+	}
+
+	if o.OrderStatus == "" {
+		o.OrderStatus = models.OrderCompleted
+	}
+	switch o.OrderStatus {
+	case models.OrderPending, models.OrderCompleted, models.OrderCancelled, models.OrderRefunded:
+	default:
+		return errors.New("invalid order status")
+	}
+
+	if o.TicketTypeID < 0 {
+		return errors.New("invalid ticket type")
+	}
+	return nil
+}
+
+// attendeeRowWriter writes the rows of an attendee export
+type attendeeRowWriter interface {
+	Write(row []string) error
+	Close() error
+}
+
+// csvRowWriter adapts csv.Writer to attendeeRowWriter
+type csvRowWriter struct {
+	*csv.Writer
+}
+
+// Close flushes the buffered rows
+func (c csvRowWriter) Close() error {
+	c.Flush()
+	if err := c.Error(); err != nil {
+		return fmt.Errorf("failed to flush CSV writer: %w", err)
+	}
+	return nil
+}
+
+// newAttendeeRowWriter starts an export in the given format
+func newAttendeeRowWriter(w io.Writer, format AttendeeExportFormat, sheetName string) (attendeeRowWriter, error) {
+	if format == AttendeeExportXLSX {
+		return newXLSXWriter(w, sheetName)
+	}
+	return csvRowWriter{csv.NewWriter(w)}, nil
+}
+
+// attendeeExportHeader returns the export's columns, with a column per
+// checkout question after the fixed ones
+func attendeeExportHeader(questions []*models.CheckoutQuestion) []string {
+	header := []string{
+		"Order Number",
+		"Order Status",
+		"Order Date",
+		"Buyer Name",
+		"Buyer Email",
+		"Ticket ID",
+		"Ticket Type",
+		"Attendee Name",
+		"Attendee Email",
+		"Checked In",
+		"Checked In At",
+	}
+	for _, question := range questions {
+		header = append(header, question.Label)
+	}
+	return header
+}
+
+// attendeeExportRow is one ticket of an attendee export
+type attendeeExportRow struct {
+	OrderNumber   string
+	OrderStatus   string
+	OrderDate     time.Time
+	BuyerName     string
+	BuyerEmail    string
+	TicketID      int
+	TicketType    string
+	AttendeeName  string
+	AttendeeEmail string
+	TicketStatus  models.TicketStatus
+	CheckedInAt   *time.Time
+	Answers       map[int]string // by question ID
+}
+
+// values formats the row in the columns of attendeeExportHeader
+func (r *attendeeExportRow) values(questions []*models.CheckoutQuestion) []string {
+	checkedIn, checkedInAt := "No", ""
+	if r.TicketStatus == models.TicketUsed {
+		checkedIn = "Yes"
+	}
+	if r.CheckedInAt != nil {
+		checkedIn = "Yes"
+		checkedInAt = r.CheckedInAt.Format("2006-01-02 15:04:05")
+	}
+
+	values := []string{
+		r.OrderNumber,
+		r.OrderStatus,
+		r.OrderDate.Format("2006-01-02 15:04:05"),
+		r.BuyerName,
+		r.BuyerEmail,
+		strconv.Itoa(r.TicketID),
+		r.TicketType,
+		r.AttendeeName,
+		r.AttendeeEmail,
+		checkedIn,
+		checkedInAt,
+	}
+	for _, question := range questions {
+		values = append(values, r.Answers[question.ID])
+	}
+	return values
+}
+
+// ExportAttendees writes an event's attendees to w, one row per ticket with
+// its check-in status and checkout answers. Rows are streamed from the
+// database as they are written, so large events are not held in memory.
+// Nothing is written if the organizer cannot access the event or the
+// options are invalid.
+func (s *AnalyticsService) ExportAttendees(w io.Writer, eventID int, organizerID int, opts AttendeeExportOptions) error {
+	if err := opts.Validate(); err != nil {
+		return err
+	}
+
+	// Verify organizer owns the event
+	canAccess, err := s.canOrganizerAccessEvent(eventID, organizerID)
+	if err != nil {
+		return fmt.Errorf("failed to verify event access: %w", err)
+	}
+	if !canAccess {
+		return fmt.Errorf("organizer does not have access to this event")
+	}
+
+	var questions []*models.CheckoutQuestion
+	if s.questions != nil {
+		if questions, err = s.questions.GetQuestions(eventID); err != nil {
+			return err
+		}
+	}
+
+	query := `
+		SELECT
+			o.order_number, o.status, o.created_at, o.billing_name, o.billing_email,
+			t.id, tt.name, t.attendee_name, t.attendee_email, t.status,
+			(SELECT MIN(s.scanned_at) FROM ticket_scans s
+				WHERE s.ticket_id = t.id AND s.result = 'accepted') as checked_in_at,
+			COALESCE((SELECT json_object_agg(a.question_id, a.answer) FROM ticket_answers a
+				WHERE a.ticket_id = t.id), '{}') as answers
+		FROM tickets t
+		JOIN orders o ON o.id = t.order_id
+		JOIN ticket_types tt ON tt.id = t.ticket_type_id
+		WHERE o.event_id = $1 AND o.status = $2 AND ($3 = 0 OR t.ticket_type_id = $3)
+		ORDER BY o.created_at DESC, t.id`
+
+	rows, err := s.db.Query(query, eventID, opts.OrderStatus, opts.TicketTypeID)
+	if err != nil {
+		return fmt.Errorf("failed to query attendees: %w", err)
+	}
+	defer rows.Close()
+
+	writer, err := newAttendeeRowWriter(w, opts.Format, "Attendees")
+	if err != nil {
+		return err
+	}
+	if err := writer.Write(attendeeExportHeader(questions)); err != nil {
+		return fmt.Errorf("failed to write export header: %w", err)
+	}
+
+	for rows.Next() {
+		row, err := scanAttendeeExportRow(rows)
+		if err != nil {
+			return err
+		}
+		if err := writer.Write(row.values(questions)); err != nil {
+			return fmt.Errorf("failed to write export row: %w", err)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return fmt.Errorf("failed to read attendees: %w", err)
+	}
+
+	return writer.Close()
+}
+
+// scanAttendeeExportRow scans a row of the ExportAttendees query
+func scanAttendeeExportRow(rows *sql.Rows) (*attendeeExportRow, error) {
+	row := &attendeeExportRow{}
+	var checkedInAt sql.NullTime
+	var answers []byte
+	err := rows.Scan(
+		&row.OrderNumber,
+		&row.OrderStatus,
+		&row.OrderDate,
+		&row.BuyerName,
+		&row.BuyerEmail,
+		&row.TicketID,
+		&row.TicketType,
+		&row.AttendeeName,
+		&row.AttendeeEmail,
+		&row.TicketStatus,
+		&checkedInAt,
+		&answers,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to scan attendee: %w", err)
+	}
+	if checkedInAt.Valid {
+		row.CheckedInAt = &checkedInAt.Time
+	}
+
+	var byQuestion map[string]string
+	if err := json.Unmarshal(answers, &byQuestion); err != nil {
+		return nil, fmt.Errorf("failed to decode answers for ticket %d: %w", row.TicketID, err)
+	}
+	row.Answers = make(map[int]string, len(byQuestion))
+	for id, answer := range byQuestion {
+		questionID, err := strconv.Atoi(strings.TrimSpace(id))
+		if err != nil {
+			continue
+		}
+		row.Answers[questionID] = answer
+	}
+	return row, nil
+}
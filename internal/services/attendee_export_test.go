@@ -0,0 +1,116 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+func TestAttendeeExportOptions_Validate(t *testing.T) {
+	opts := AttendeeExportOptions{}
+	if err := opts.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if opts.Format != AttendeeExportCSV || opts.OrderStatus != models.OrderCompleted {
+		t.Errorf("expected CSV of completed orders by default, got %q of %q", opts.Format, opts.OrderStatus)
+	}
+
+	invalid := []AttendeeExportOptions{
+		{Format: "pdf"},
+		{OrderStatus: "shipped"},
+		{TicketTypeID: -1},
+	}
+	for _, opts := range invalid {
+		if err := opts.Validate(); err == nil {
+			t.Errorf("expected %+v to be invalid", opts)
+		}
+	}
+}
+
+func TestAttendeeExportRow_Values(t *testing.T) {
+	questions := []*models.CheckoutQuestion{{ID: 4, Label: "T-shirt size"}, {ID: 9, Label: "Dietary needs"}}
+	scannedAt := time.Date(2026, 6, 1, 18, 30, 0, 0, time.UTC)
+	row := &attendeeExportRow{
+		OrderNumber:  "ORD-1",
+		OrderStatus:  "completed",
+		OrderDate:    time.Date(2026, 5, 2, 9, 0, 0, 0, time.UTC),
+		TicketID:     12,
+		TicketType:   "VIP",
+		AttendeeName: "Amina Otieno",
+		TicketStatus: models.TicketUsed,
+		CheckedInAt:  &scannedAt,
+		Answers:      map[int]string{4: "M"},
+	}
+
+	header := attendeeExportHeader(questions)
+	values := row.values(questions)
+	if len(values) != len(header) {
+		t.Fatalf("expected %d values to match the header, got %d", len(header), len(values))
+	}
+
+	got := map[string]string{}
+	for i, column := range header {
+		got[column] = values[i]
+	}
+	if got["Checked In"] != "Yes" || got["Checked In At"] != "2026-06-01 18:30:00" {
+		t.Errorf("expected the check-in to be exported, got %q at %q", got["Checked In"], got["Checked In At"])
+	}
+	if got["T-shirt size"] != "M" || got["Dietary needs"] != "" {
+		t.Errorf("expected answers in their question's column, got %q and %q", got["T-shirt size"], got["Dietary needs"])
+	}
+
+	row.TicketStatus, row.CheckedInAt = models.TicketActive, nil
+	if values := row.values(questions); values[9] != "No" {
+		t.Errorf("expected an unscanned ticket not to be checked in, got %q", values[9])
+	}
+}
+
+func TestXLSXWriter(t *testing.T) {
+	var buf bytes.Buffer
+	writer, err := newAttendeeRowWriter(&buf, AttendeeExportXLSX, "Jazz Night: Attendees")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	writer.Write([]string{"Name", "Notes"})
+	writer.Write([]string{"Amina", "<vegan> & gluten-free"})
+	if err := writer.Close(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	zr, err := zip.NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	if err != nil {
+		t.Fatalf("expected a zip archive: %v", err)
+	}
+	parts := map[string]string{}
+	for _, f := range zr.File {
+		rc, _ := f.Open()
+		content, _ := io.ReadAll(rc)
+		rc.Close()
+		parts[f.Name] = string(content)
+	}
+
+	if !strings.Contains(parts["xl/workbook.xml"], `name="Jazz Night- Attendees"`) {
+		t.Errorf("expected the sheet name to be sanitized, got %s", parts["xl/workbook.xml"])
+	}
+	sheet := parts["xl/worksheets/sheet1.xml"]
+	if !strings.Contains(sheet, `<c r="B2" t="inlineStr"><is><t xml:space="preserve">&lt;vegan&gt; &amp; gluten-free</t></is></c>`) {
+		t.Errorf("expected escaped inline cells, got %s", sheet)
+	}
+	if !strings.HasSuffix(sheet, `</sheetData></worksheet>`) {
+		t.Error("expected the worksheet to be closed")
+	}
+}
+
+func TestXLSXColumn(t *testing.T) {
+	cases := map[int]string{0: "A", 25: "Z", 26: "AA", 27: "AB", 701: "ZZ", 702: "AAA"}
+	for i, want := range cases {
+		if got := xlsxColumn(i); got != want {
+			t.Errorf("xlsxColumn(%d) = %q, want %q", i, got, want)
+		}
+	}
+}
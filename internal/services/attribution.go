@@ -0,0 +1,129 @@
+package services
+
+import (
+	"fmt"
+
+	"event-ticketing-platform/internal/cache"
+	"event-ticketing-platform/internal/models"
+)
+
+// OrderAttributionRecorder stores where an order's buyer came from
+type OrderAttributionRecorder interface {
+	RecordOrderAttribution(orderID int, attribution *models.Attribution) error
+}
+
+// Conditions loadSalesBySource groups an organizer's or an event's sales by
+const (
+	organizerSalesCondition = "e.organizer_id = $1"
+	eventSalesCondition     = "o.event_id = $1"
+)
+
+// SalesBySource is the completed sales attributed to one source and medium
+type SalesBySource struct {
+	Source       string  `json:"source"`
+	Medium       string  `json:"medium"`
+	Orders       int     `json:"orders"`
+	TicketsSold  int     `json:"tickets_sold"`
+	Revenue      float64 `json:"revenue"`
+	RevenueShare float64 `json:"revenue_share"` // percentage of the revenue of all sources
+}
+
+// RecordOrderAttribution stores where an order's buyer came from. It
+// implements OrderAttributionRecorder.
+func (s *AnalyticsService) RecordOrderAttribution(orderID int, attribution *models.Attribution) error {
+	_, err := s.db.Exec(`
+		INSERT INTO order_attributions (order_id, source, medium, campaign, term, content, referrer_host, landing_path, ref)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+		ON CONFLICT (order_id) DO NOTHING`,
+		orderID, attribution.Source, attribution.Medium, attribution.Campaign, attribution.Term,
+		attribution.Content, attribution.ReferrerHost, attribution.LandingPath, attribution.Ref)
+	if err != nil {
+		return fmt.Errorf("failed to record order attribution: %w", err)
+	}
+	return nil
+}
+
+// GetOrganizerSalesBySource returns an organizer's completed sales across
+// their events by source, highest revenue first
+func (s *AnalyticsService) GetOrganizerSalesBySource(organizerID int) ([]*SalesBySource, error) {
+	key := cache.Key(analyticsCachePrefix+"organizer", organizerID, "sources")
+	return cache.RememberStale(s.cache, key, analyticsCacheTTL, analyticsCacheStaleTTL, func() ([]*SalesBySource, error) {
+		return s.loadSalesBySource(organizerSalesCondition, organizerID)
+	})
+}
+
+// GetEventSalesBySource returns an event's completed sales by source,
+// highest revenue first
+func (s *AnalyticsService) GetEventSalesBySource(eventID int, organizerID int) ([]*SalesBySource, error) {
+	if err := s.checkEventAccess(eventID, organizerID); err != nil {
+		return nil, err
+	}
+
+	key := cache.Key(analyticsCachePrefix+"event", eventID, "sources")
+	return cache.RememberStale(s.cache, key, analyticsCacheTTL, analyticsCacheStaleTTL, func() ([]*SalesBySource, error) {
+		return s.loadSalesBySource(eventSalesCondition, eventID)
+	})
+}
+
+// loadSalesBySource groups the completed orders matching a condition on
+// their order o or event e by source. An order's source is its UTM source,
+// else its referrer, else direct; orders from before attribution was tracked
+// are unknown.
+func (s *AnalyticsService) loadSalesBySource(condition string, id int) ([]*SalesBySource, error) {
+	query := `
+		SELECT
+			CASE WHEN a.order_id IS NULL THEN $2
+				ELSE COALESCE(NULLIF(a.source, ''), NULLIF(a.referrer_host, ''), $3) END as source,
+			COALESCE(a.medium, '') as medium,
+			COUNT(o.id) as orders,
+			COALESCE(SUM(tc.ticket_count), 0) as tickets_sold,
+			COALESCE(SUM(o.total_amount), 0) as revenue
+		FROM orders o
+		JOIN events e ON e.id = o.event_id
+		LEFT JOIN order_attributions a ON a.order_id = o.id
+		LEFT JOIN (
+			SELECT order_id, COUNT(*) as ticket_count
+			FROM tickets
+			GROUP BY order_id
+		) tc ON tc.order_id = o.id
+		WHERE o.status = 'completed' AND ` + condition + `
+		GROUP BY 1, 2
+		ORDER BY revenue DESC, orders DESC`
+
+	rows, err := s.db.Query(query, id, models.AttributionSourceUnknown, models.AttributionSourceDirect)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query sales by source: %w", err)
+	}
+	defer rows.Close()
+
+	var sources []*SalesBySource
+	for rows.Next() {
+		source := &SalesBySource{}
+		var revenue int64
+		if err := rows.Scan(&source.Source, &source.Medium, &source.Orders, &source.TicketsSold, &revenue); err != nil {
+			return nil, fmt.Errorf("failed to scan sales by source: %w", err)
+		}
+		source.Revenue = float64(revenue) / 100.0
+		sources = append(sources, source)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	addRevenueShares(sources)
+	return sources, nil
+}
+
+// addRevenueShares sets each source's share of the revenue of all sources
+func addRevenueShares(sources []*SalesBySource) {
+	var total float64
+	for _, source := range sources {
+		total += source.Revenue
+	}
+	if total <= 0 {
+		return
+	}
+	for _, source := range sources {
+		source.RevenueShare = roundPercent(source.Revenue / total * 100)
+	}
+}
@@ -0,0 +1,24 @@
+package services
+
+import "testing"
+
+func TestAddRevenueShares(t *testing.T) {
+	sources := []*SalesBySource{
+		{Source: "instagram", Revenue: 600},
+		{Source: "direct", Revenue: 300},
+		{Source: "unknown", Revenue: 100},
+	}
+
+	addRevenueShares(sources)
+	for i, want := range []float64{60, 30, 10} {
+		if sources[i].RevenueShare != want {
+			t.Errorf("expected %s to have a %.0f%% share, got %v", sources[i].Source, want, sources[i].RevenueShare)
+		}
+	}
+
+	empty := []*SalesBySource{{Source: "direct"}}
+	addRevenueShares(empty)
+	if empty[0].RevenueShare != 0 {
+		t.Errorf("expected no share without revenue, got %v", empty[0].RevenueShare)
+	}
+}
@@ -1,8 +1,15 @@
 package services
 
 import (
+	"encoding/csv"
 	"encoding/json"
+	"fmt"
+	"io"
+	"net"
 	"net/http"
+	"strconv"
+	"strings"
+	"time"
 
 	"event-ticketing-platform/internal/models"
 	"event-ticketing-platform/internal/repositories"
@@ -32,9 +39,12 @@ func (s *AuditService) LogAction(adminUserID int, action, targetType string, tar
 		detailsJSON = detailsBytes
 	}
 
-	// Get IP address and user agent from request
-	ipAddress := getClientIP(r)
-	userAgent := r.UserAgent()
+	// Get IP address and user agent from request, if there is one
+	var ipAddress, userAgent string
+	if r != nil {
+		ipAddress = getClientIP(r)
+		userAgent = r.UserAgent()
+	}
 
 	req := &models.AuditLogCreateRequest{
 		AdminUserID: adminUserID,
@@ -50,12 +60,93 @@ func (s *AuditService) LogAction(adminUserID int, action, targetType string, tar
 	return err
 }
 
+// LogSecurityEvent logs a security event that was not triggered by an admin,
+// such as a rate limit tripping
+func (s *AuditService) LogSecurityEvent(action, targetType string, details interface{}, r *http.Request) error {
+	return s.LogAction(0, action, targetType, 0, details, r)
+}
+
 // GetAuditLogs retrieves audit logs with pagination and filtering
 func (s *AuditService) GetAuditLogs(page, limit int, action, targetType string) ([]*models.AuditLog, int, error) {
 	offset := (page - 1) * limit
 	return s.auditRepo.GetAll(limit, offset, action, targetType)
 }
 
+// SearchAuditLogs retrieves the audit logs matching a filter with pagination
+func (s *AuditService) SearchAuditLogs(filter models.AuditLogFilter, page, limit int) ([]*models.AuditLog, int, error) {
+	offset := (page - 1) * limit
+	return s.auditRepo.Search(filter, limit, offset)
+}
+
+// GetFilterOptions returns the actions and target types the audit log can be
+// filtered by
+func (s *AuditService) GetFilterOptions() ([]string, []string, error) {
+	return s.auditRepo.GetFilterOptions()
+}
+
+// OrderRefunded records a refund in the audit log. It implements
+// OrderRefundHook.
+func (s *AuditService) OrderRefunded(order *models.Order) {
+	details := map[string]interface{}{
+		"amount":   order.TotalAmount,
+		"event_id": order.EventID,
+		"user_id":  order.UserID,
+	}
+	if err := s.LogAction(0, models.AuditActionOrderRefund, models.AuditTargetOrder, order.ID, details, nil); err != nil {
+		fmt.Printf("Warning: failed to log refund of order %d: %v\n", order.ID, err)
+	}
+}
+
+// auditCSVHeader is the header row of an exported audit log
+var auditCSVHeader = []string{"Time", "Actor", "Actor Email", "Action", "Target Type", "Target ID", "Details", "IP Address", "User Agent"}
+
+// ExportAuditLogs writes audit logs to w as CSV
+func (s *AuditService) ExportAuditLogs(w io.Writer, logs []*models.AuditLog) error {
+	writer := csv.NewWriter(w)
+	if err := writer.Write(auditCSVHeader); err != nil {
+		return err
+	}
+
+	for _, log := range logs {
+		actorEmail := ""
+		if log.AdminUser != nil {
+			actorEmail = log.AdminUser.Email
+		}
+		targetID := ""
+		if log.TargetID != 0 {
+			targetID = strconv.Itoa(log.TargetID)
+		}
+		record := []string{
+			log.CreatedAt.UTC().Format(time.RFC3339),
+			log.ActorName(),
+			actorEmail,
+			log.Action,
+			log.TargetType,
+			targetID,
+			string(log.Details),
+			log.IPAddress,
+			log.UserAgent,
+		}
+		for i, value := range record {
+			record[i] = csvSafe(value)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+
+	writer.Flush()
+	return writer.Error()
+}
+
+// csvSafe stops a spreadsheet opening an exported value as a formula
+func csvSafe(value string) string {
+	if value != "" && strings.ContainsRune("=+-@", rune(value[0])) {
+		return "'" + value
+	}
+	return value
+}
+
 // GetAuditLogsByAdmin retrieves audit logs for a specific admin user
 func (s *AuditService) GetAuditLogsByAdmin(adminUserID int, page, limit int) ([]*models.AuditLog, int, error) {
 	offset := (page - 1) * limit
@@ -96,6 +187,9 @@ func getClientIP(r *http.Request) string {
 		return realIP
 	}
 
-	// Fall back to RemoteAddr
+	// Fall back to RemoteAddr without the port
+	if host, _, err := net.SplitHostPort(r.RemoteAddr); err == nil {
+		return host
+	}
 	return r.RemoteAddr
 }
\ No newline at end of file
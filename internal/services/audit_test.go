@@ -0,0 +1,62 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+func TestAuditService_ExportAuditLogs(t *testing.T) {
+	service := NewAuditService(nil)
+	logs := []*models.AuditLog{
+		{
+			AdminUserID: 3,
+			AdminUser:   &models.User{FirstName: "Ada", LastName: "Okafor", Email: "ada@example.com"},
+			Action:      models.AuditActionUserRoleChange,
+			TargetType:  models.AuditTargetUser,
+			TargetID:    12,
+			Details:     json.RawMessage(`{"new_role":"admin"}`),
+			IPAddress:   "192.0.2.10",
+			UserAgent:   "=HYPERLINK(\"http://example.com\")",
+			CreatedAt:   time.Date(2026, 3, 4, 9, 30, 0, 0, time.UTC),
+		},
+		{
+			AdminUser:  &models.User{},
+			Action:     models.AuditActionOrderRefund,
+			TargetType: models.AuditTargetOrder,
+			TargetID:   40,
+			CreatedAt:  time.Date(2026, 3, 5, 10, 0, 0, 0, time.UTC),
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := service.ExportAuditLogs(&buf, logs); err != nil {
+		t.Fatalf("ExportAuditLogs() error = %v", err)
+	}
+
+	records, err := csv.NewReader(&buf).ReadAll()
+	if err != nil {
+		t.Fatalf("failed to read the export: %v", err)
+	}
+	if len(records) != 3 {
+		t.Fatalf("expected a header and 2 rows, got %d", len(records))
+	}
+
+	first := records[1]
+	if first[0] != "2026-03-04T09:30:00Z" || first[1] != "Ada Okafor" || first[2] != "ada@example.com" || first[5] != "12" {
+		t.Errorf("unexpected first row %q", first)
+	}
+	if first[6] != `{"new_role":"admin"}` {
+		t.Errorf("expected the details as JSON, got %q", first[6])
+	}
+	if first[8] != `'=HYPERLINK("http://example.com")` {
+		t.Errorf("expected a formula to be escaped, got %q", first[8])
+	}
+	if second := records[2]; second[1] != "System" || second[2] != "" {
+		t.Errorf("expected a refund without an actor to be the system's, got %q", second)
+	}
+}
@@ -4,11 +4,14 @@ import (
 	"crypto/rand"
 	"encoding/hex"
 	"fmt"
+	"log/slog"
 	"time"
 
 	"event-ticketing-platform/internal/models"
 	"event-ticketing-platform/internal/repositories"
 	"event-ticketing-platform/internal/utils"
+
+	"github.com/gorilla/sessions"
 )
 
 // UserRepository interface for user data operations
@@ -40,6 +43,7 @@ type UserRepository interface {
 type AuthService struct {
 	userRepo     UserRepository
 	emailService EmailService // Interface for email service
+	sessionStore *SessionStore
 }
 
 // EmailService interface for sending emails
@@ -60,6 +64,12 @@ func NewAuthService(userRepo UserRepository, emailService EmailService) *AuthSer
 	}
 }
 
+// SetSessionStore lets password changes keep the session making the change
+// signed in while logging out every other device
+func (s *AuthService) SetSessionStore(sessionStore *SessionStore) {
+	s.sessionStore = sessionStore
+}
+
 // RegisterRequest represents a user registration request
 type RegisterRequest struct {
 	Email     string           `json:"email"`
@@ -85,6 +95,10 @@ type PasswordResetRequest struct {
 type PasswordChangeRequest struct {
 	OldPassword string `json:"old_password"`
 	NewPassword string `json:"new_password"`
+
+	// KeepSession stays signed in when the password changes, usually the
+	// session making the change. Requires SetSessionStore.
+	KeepSession *sessions.Session `json:"-"`
 }
 
 // PasswordResetCompleteRequest represents a password reset completion request
@@ -152,7 +166,7 @@ func (s *AuthService) Register(req *RegisterRequest) (*AuthResponse, error) {
 		err = s.emailService.SendVerificationEmail(user.Email, userName, verificationToken)
 		if err != nil {
 			// Log the error but don't fail registration
-			fmt.Printf("Warning: failed to send verification email to %s: %v\n", user.Email, err)
+			slog.Warn("failed to send verification email", "user_id", user.ID, "error", err)
 		}
 	}
 	
@@ -317,14 +331,14 @@ func (s *AuthService) CompletePasswordReset(req *PasswordResetCompleteRequest) e
 	err = s.userRepo.ClearPasswordResetToken(user.ID)
 	if err != nil {
 		// Log this error but don't fail the password reset
-		fmt.Printf("Warning: failed to clear password reset token: %v\n", err)
+		slog.Warn("failed to clear password reset token", "user_id", user.ID, "error", err)
 	}
 	
 	// Invalidate all existing sessions for this user
 	err = s.userRepo.DeleteUserSessions(user.ID)
 	if err != nil {
 		// Log this error but don't fail the password reset
-		fmt.Printf("Warning: failed to delete user sessions after password reset: %v\n", err)
+		slog.Warn("failed to delete user sessions after password reset", "user_id", user.ID, "error", err)
 	}
 	
 	return nil
@@ -376,11 +390,16 @@ func (s *AuthService) ChangePassword(userID int, req *PasswordChangeRequest) err
 	}
 	
 	// Invalidate all existing sessions for this user
-	err = s.userRepo.DeleteUserSessions(userID)
-	if err != nil {
+	if s.sessionStore != nil && req.KeepSession != nil {
+		ended, err := s.sessionStore.LogOutOtherSessions(userID, req.KeepSession)
+		if err != nil {
+			slog.Warn("failed to log out other sessions after password change", "user_id", userID, "error", err)
+		} else {
+			slog.Info("logged out other sessions after password change", "user_id", userID, "sessions", ended)
+		}
+	} else if err := s.userRepo.DeleteUserSessions(userID); err != nil {
 		// Log this error but don't fail the password change
-		// In a real implementation, you'd use a proper logger
-		fmt.Printf("Warning: failed to delete user sessions after password change: %v\n", err)
+		slog.Warn("failed to delete user sessions after password change", "user_id", userID, "error", err)
 	}
 	
 	return nil
@@ -514,7 +533,7 @@ func (s *AuthService) VerifyEmail(token string) (*models.User, error) {
 		err = s.emailService.SendWelcomeEmail(user.Email, userName)
 		if err != nil {
 			// Log the error but don't fail verification
-			fmt.Printf("Warning: failed to send welcome email to %s: %v\n", user.Email, err)
+			slog.Warn("failed to send welcome email", "user_id", user.ID, "error", err)
 		}
 	}
 	
@@ -0,0 +1,174 @@
+package services
+
+import (
+	"log"
+	"sync"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// availabilityBroadcastInterval is the most often an event's subscribers are
+// sent its availability during an on-sale
+const availabilityBroadcastInterval = 500 * time.Millisecond
+
+// AvailabilityBroker pushes ticket availability to the live streams watching
+// an event. Changes are coalesced: however many orders complete at once, the
+// availability is loaded once and subscribers get the latest, at most every
+// availabilityBroadcastInterval. Changes only reach streams on this server;
+// streams refresh periodically to pick up the rest.
+type AvailabilityBroker struct {
+	load     func(eventID int) ([]*models.TicketType, error)
+	interval time.Duration
+
+	mu     sync.Mutex
+	topics map[int]*availabilityTopic
+	closed bool
+}
+
+// availabilityTopic is the streams watching one event
+type availabilityTopic struct {
+	subscribers  map[chan []*models.TicketType]struct{}
+	pending      bool // Availability changed since it was last loaded
+	broadcasting bool
+}
+
+// NewAvailabilityBroker creates a broker that loads an event's availability
+// with load, usually the ticket service's cached GetTicketAvailability
+func NewAvailabilityBroker(load func(eventID int) ([]*models.TicketType, error)) *AvailabilityBroker {
+	return &AvailabilityBroker{
+		load:     load,
+		interval: availabilityBroadcastInterval,
+		topics:   make(map[int]*availabilityTopic),
+	}
+}
+
+// Subscribe returns a channel the event's availability is sent on after it
+// changes, and a function to stop. A subscriber that falls behind only gets
+// the latest availability. The channel is closed when the broker is.
+func (b *AvailabilityBroker) Subscribe(eventID int) (<-chan []*models.TicketType, func()) {
+	updates := make(chan []*models.TicketType, 1)
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		close(updates)
+		return updates, func() {}
+	}
+	topic := b.topics[eventID]
+	if topic == nil {
+		topic = &availabilityTopic{subscribers: make(map[chan []*models.TicketType]struct{})}
+		b.topics[eventID] = topic
+	}
+	topic.subscribers[updates] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return updates, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			if b.closed {
+				return
+			}
+			delete(topic.subscribers, updates)
+			if len(topic.subscribers) == 0 && !topic.broadcasting {
+				b.removeTopic(eventID, topic)
+			}
+		})
+	}
+}
+
+// Close ends every subscription, so streams finish when the server shuts
+// down
+func (b *AvailabilityBroker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for _, topic := range b.topics {
+		for updates := range topic.subscribers {
+			close(updates)
+		}
+		topic.subscribers = nil
+	}
+	b.topics = make(map[int]*availabilityTopic)
+}
+
+// Subscribers returns the number of streams watching an event
+func (b *AvailabilityBroker) Subscribers(eventID int) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if topic := b.topics[eventID]; topic != nil {
+		return len(topic.subscribers)
+	}
+	return 0
+}
+
+// Notify tells the broker an event's availability changed. It returns
+// straight away; nothing is loaded when no stream is watching. Notifying a
+// nil broker does nothing.
+func (b *AvailabilityBroker) Notify(eventID int) {
+	if b == nil {
+		return
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	topic := b.topics[eventID]
+	if topic == nil {
+		return
+	}
+	topic.pending = true
+	if !topic.broadcasting {
+		topic.broadcasting = true
+		go b.broadcast(eventID, topic)
+	}
+}
+
+// removeTopic forgets an event's topic once nothing watches it, unless it
+// was already replaced by a new subscriber's. The caller holds the lock.
+func (b *AvailabilityBroker) removeTopic(eventID int, topic *availabilityTopic) {
+	if b.topics[eventID] == topic {
+		delete(b.topics, eventID)
+	}
+}
+
+// broadcast loads and sends the event's availability until no change is
+// pending
+func (b *AvailabilityBroker) broadcast(eventID int, topic *availabilityTopic) {
+	for {
+		b.mu.Lock()
+		if !topic.pending || len(topic.subscribers) == 0 {
+			topic.broadcasting = false
+			if len(topic.subscribers) == 0 {
+				b.removeTopic(eventID, topic)
+			}
+			b.mu.Unlock()
+			return
+		}
+		topic.pending = false
+		b.mu.Unlock()
+
+		ticketTypes, err := b.load(eventID)
+		if err != nil {
+			log.Printf("Failed to load ticket availability of event %d for live streams: %v", eventID, err)
+		} else {
+			b.mu.Lock()
+			// Close empties the subscribers, so nothing is sent once closed
+			for updates := range topic.subscribers {
+				// Replace an update the subscriber hasn't read yet
+				select {
+				case <-updates:
+				default:
+				}
+				updates <- ticketTypes
+			}
+			b.mu.Unlock()
+		}
+
+		time.Sleep(b.interval)
+	}
+}
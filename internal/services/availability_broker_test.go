@@ -0,0 +1,113 @@
+package services
+
+import (
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestAvailabilityBroker returns a broker that loads a single ticket type
+// with remaining tickets, counting the loads
+func newTestAvailabilityBroker(remaining *atomic.Int32, loads *atomic.Int32) *AvailabilityBroker {
+	broker := NewAvailabilityBroker(func(eventID int) ([]*models.TicketType, error) {
+		loads.Add(1)
+		return []*models.TicketType{{ID: 1, EventID: eventID, Quantity: int(remaining.Load())}}, nil
+	})
+	broker.interval = 20 * time.Millisecond
+	return broker
+}
+
+func receiveAvailability(t *testing.T, updates <-chan []*models.TicketType) []*models.TicketType {
+	t.Helper()
+	select {
+	case ticketTypes, ok := <-updates:
+		require.True(t, ok, "updates closed")
+		return ticketTypes
+	case <-time.After(time.Second):
+		t.Fatal("no availability update")
+		return nil
+	}
+}
+
+func TestAvailabilityBroker_CoalescesChangesIntoOneLoad(t *testing.T) {
+	var remaining, loads atomic.Int32
+	remaining.Store(10)
+	broker := newTestAvailabilityBroker(&remaining, &loads)
+	defer broker.Close()
+
+	first, unsubscribeFirst := broker.Subscribe(7)
+	defer unsubscribeFirst()
+	second, unsubscribeSecond := broker.Subscribe(7)
+	defer unsubscribeSecond()
+
+	remaining.Store(4)
+	for i := 0; i < 5; i++ {
+		broker.Notify(7)
+	}
+
+	assert.Equal(t, 4, receiveAvailability(t, first)[0].Quantity)
+	assert.Equal(t, 4, receiveAvailability(t, second)[0].Quantity)
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, int32(1), loads.Load())
+}
+
+func TestAvailabilityBroker_SlowSubscriberGetsLatest(t *testing.T) {
+	var remaining, loads atomic.Int32
+	remaining.Store(10)
+	broker := newTestAvailabilityBroker(&remaining, &loads)
+	defer broker.Close()
+
+	updates, unsubscribe := broker.Subscribe(7)
+	defer unsubscribe()
+
+	remaining.Store(8)
+	broker.Notify(7)
+	require.Eventually(t, func() bool { return loads.Load() == 1 }, time.Second, 5*time.Millisecond)
+	remaining.Store(3)
+	broker.Notify(7)
+	require.Eventually(t, func() bool { return loads.Load() == 2 }, time.Second, 5*time.Millisecond)
+	time.Sleep(10 * time.Millisecond)
+
+	assert.Equal(t, 3, receiveAvailability(t, updates)[0].Quantity)
+}
+
+func TestAvailabilityBroker_NotifyWithoutSubscribersLoadsNothing(t *testing.T) {
+	var remaining, loads atomic.Int32
+	broker := newTestAvailabilityBroker(&remaining, &loads)
+	defer broker.Close()
+
+	broker.Notify(7)
+	_, unsubscribe := broker.Subscribe(8)
+	unsubscribe()
+	broker.Notify(8)
+	time.Sleep(30 * time.Millisecond)
+
+	assert.Equal(t, int32(0), loads.Load())
+	assert.Equal(t, 0, broker.Subscribers(8))
+
+	var nilBroker *AvailabilityBroker
+	assert.NotPanics(t, func() { nilBroker.Notify(7) })
+}
+
+func TestAvailabilityBroker_CloseEndsSubscriptions(t *testing.T) {
+	var remaining, loads atomic.Int32
+	broker := newTestAvailabilityBroker(&remaining, &loads)
+
+	updates, unsubscribe := broker.Subscribe(7)
+	assert.Equal(t, 1, broker.Subscribers(7))
+	broker.Close()
+	unsubscribe()
+
+	_, ok := <-updates
+	assert.False(t, ok)
+	assert.Equal(t, 0, broker.Subscribers(7))
+
+	later, _ := broker.Subscribe(7)
+	_, ok = <-later
+	assert.False(t, ok)
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// BoxOfficeTicketTypes retrieves the ticket types sold at an event's door
+type BoxOfficeTicketTypes interface {
+	GetTicketTypesByEvent(eventID int) ([]*models.TicketType, error)
+}
+
+// BoxOfficeOrderStore records door sales as orders
+type BoxOfficeOrderStore interface {
+	Create(req *models.OrderCreateRequest) (*models.Order, error)
+	GetByID(id int) (*models.Order, error)
+	UpdateStatus(id int, status models.OrderStatus) error
+	ProcessOrderCompletion(orderID int, paymentID string, ticketData []struct {
+		TicketTypeID int
+		QRCode       string
+	}) error
+}
+
+// BoxOfficeOrderNotifier emails and publishes an order once its tickets
+// have been issued
+type BoxOfficeOrderNotifier interface {
+	NotifyOrderCompleted(orderID int, attendees []models.TicketAttendee) error
+}
+
+// BoxOfficeService records the tickets organizers sell at the door for cash
+// or on their own card terminal. Door sales come out of the same inventory
+// as online sales and are issued straight away, but no payment provider is
+// involved: the organizer has already been paid.
+type BoxOfficeService struct {
+	ticketTypes  BoxOfficeTicketTypes
+	orders       BoxOfficeOrderStore
+	notifier     BoxOfficeOrderNotifier
+	attributions OrderAttributionRecorder
+}
+
+// NewBoxOfficeService creates a new box office service
+func NewBoxOfficeService(ticketTypes BoxOfficeTicketTypes, orders BoxOfficeOrderStore, notifier BoxOfficeOrderNotifier, attributions OrderAttributionRecorder) *BoxOfficeService {
+	return &BoxOfficeService{
+		ticketTypes:  ticketTypes,
+		orders:       orders,
+		notifier:     notifier,
+		attributions: attributions,
+	}
+}
+
+// TicketTypes returns the ticket types of an event that can be sold at the
+// door. Unlike online sales they are sold outside their sale window, until
+// the event ends.
+func (s *BoxOfficeService) TicketTypes(eventID int) ([]*models.TicketType, error) {
+	ticketTypes, err := s.ticketTypes.GetTicketTypesByEvent(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket types: %w", err)
+	}
+	return ticketTypes, nil
+}
+
+// Sell records a door sale of an event's tickets by the seller, issuing its
+// tickets and emailing them to the buyer, or to the seller to print when the
+// buyer gives no email. The order is attributed to the box office so it is
+// reported apart from online sales.
+func (s *BoxOfficeService) Sell(event *models.Event, seller *models.User, sale *models.BoxOfficeSale) (*models.Order, error) {
+	if err := sale.Validate(); err != nil {
+		return nil, err
+	}
+	if event.IsCancelled() {
+		return nil, fmt.Errorf("tickets cannot be sold for a cancelled event")
+	}
+	if time.Now().After(event.EndDate) {
+		return nil, fmt.Errorf("tickets cannot be sold after the event has ended")
+	}
+
+	ticketTypes, err := s.TicketTypes(event.ID)
+	if err != nil {
+		return nil, err
+	}
+	total, itemPrices, err := priceBoxOfficeSale(ticketTypes, sale.Items)
+	if err != nil {
+		return nil, err
+	}
+
+	billingName := strings.TrimSpace(sale.BuyerName)
+	if billingName == "" {
+		billingName = seller.FullName()
+	}
+	billingEmail := strings.TrimSpace(sale.BuyerEmail)
+	if billingEmail == "" {
+		billingEmail = seller.Email
+	}
+
+	// Door sales belong to the seller, who can print their tickets from the
+	// order like any buyer
+	order, err := s.orders.Create(&models.OrderCreateRequest{
+		UserID:       seller.ID,
+		EventID:      event.ID,
+		TotalAmount:  total,
+		BillingEmail: billingEmail,
+		BillingName:  billingName,
+		Status:       models.OrderPending,
+		ItemPrices:   itemPrices,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create order: %w", err)
+	}
+
+	paymentID, err := models.NewBoxOfficePaymentID(sale.PaymentMethod)
+	if err != nil {
+		s.orders.UpdateStatus(order.ID, models.OrderCancelled)
+		return nil, fmt.Errorf("failed to generate payment ID: %w", err)
+	}
+
+	var ticketData []struct {
+		TicketTypeID int
+		QRCode       string
+	}
+	for _, item := range sale.Items {
+		for i := 0; i < item.Quantity; i++ {
+			qrCode, err := generateTicketQRCode(order.ID, item.TicketTypeID)
+			if err != nil {
+				s.orders.UpdateStatus(order.ID, models.OrderCancelled)
+				return nil, fmt.Errorf("failed to generate QR code: %w", err)
+			}
+			ticketData = append(ticketData, struct {
+				TicketTypeID int
+				QRCode       string
+			}{TicketTypeID: item.TicketTypeID, QRCode: qrCode})
+		}
+	}
+
+	// Inventory is checked again with the ticket types locked, so the door
+	// can't sell tickets bought online in the meantime
+	if err := s.orders.ProcessOrderCompletion(order.ID, paymentID, ticketData); err != nil {
+		s.orders.UpdateStatus(order.ID, models.OrderCancelled)
+		return nil, fmt.Errorf("failed to issue tickets: %w", err)
+	}
+
+	// Attributed before the order is published, so analytics refreshed by
+	// its completion count it under the box office
+	if s.attributions != nil {
+		if err := s.attributions.RecordOrderAttribution(order.ID, models.BoxOfficeAttribution(sale.PaymentMethod)); err != nil {
+			fmt.Printf("Warning: failed to attribute box office order %s: %v\n", order.OrderNumber, err)
+		}
+	}
+
+	if s.notifier != nil {
+		if err := s.notifier.NotifyOrderCompleted(order.ID, nil); err != nil {
+			fmt.Printf("Warning: failed to send tickets for box office order %s: %v\n", order.OrderNumber, err)
+		}
+	}
+
+	completedOrder, err := s.orders.GetByID(order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get completed order: %w", err)
+	}
+	return completedOrder, nil
+}
+
+// priceBoxOfficeSale checks that each item of a door sale is one of the
+// event's ticket types with enough tickets left, returning the sale's total
+// and the price each ticket type sold at
+func priceBoxOfficeSale(ticketTypes []*models.TicketType, items []models.BoxOfficeItem) (int, []models.OrderItemPrice, error) {
+	byID := make(map[int]*models.TicketType, len(ticketTypes))
+	for _, ticketType := range ticketTypes {
+		byID[ticketType.ID] = ticketType
+	}
+
+	total := 0
+	var itemPrices []models.OrderItemPrice
+	for _, item := range items {
+		if item.Quantity == 0 {
+			continue
+		}
+		ticketType, ok := byID[item.TicketTypeID]
+		if !ok {
+			return 0, nil, fmt.Errorf("ticket type %d is not sold at this event", item.TicketTypeID)
+		}
+		if item.Quantity > ticketType.Available() {
+			return 0, nil, fmt.Errorf("insufficient tickets available for '%s' (requested: %d, available: %d)",
+				ticketType.Name, item.Quantity, ticketType.Available())
+		}
+
+		price, err := ticketType.ChoosePrice(item.Price)
+		if err != nil {
+			return 0, nil, err
+		}
+		total += price * item.Quantity
+		itemPrices = append(itemPrices, models.OrderItemPrice{TicketTypeID: ticketType.ID, UnitPrice: price})
+	}
+	return total, itemPrices, nil
+}
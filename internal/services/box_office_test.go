@@ -0,0 +1,147 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// memoryBoxOfficeOrders keeps the orders door sales are recorded as in memory
+type memoryBoxOfficeOrders struct {
+	ticketTypes  []*models.TicketType
+	orders       map[int]*models.Order
+	tickets      map[int]int
+	attributions map[int]*models.Attribution
+	notified     []int
+}
+
+func newMemoryBoxOfficeOrders(ticketTypes ...*models.TicketType) *memoryBoxOfficeOrders {
+	return &memoryBoxOfficeOrders{
+		ticketTypes:  ticketTypes,
+		orders:       make(map[int]*models.Order),
+		tickets:      make(map[int]int),
+		attributions: make(map[int]*models.Attribution),
+	}
+}
+
+func (m *memoryBoxOfficeOrders) GetTicketTypesByEvent(eventID int) ([]*models.TicketType, error) {
+	return m.ticketTypes, nil
+}
+
+func (m *memoryBoxOfficeOrders) Create(req *models.OrderCreateRequest) (*models.Order, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	order := &models.Order{
+		ID:           len(m.orders) + 1,
+		OrderNumber:  "ORD-20260101-000001",
+		UserID:       req.UserID,
+		EventID:      req.EventID,
+		TotalAmount:  req.TotalAmount,
+		Status:       req.Status,
+		BillingEmail: req.BillingEmail,
+		BillingName:  req.BillingName,
+	}
+	m.orders[order.ID] = order
+	return order, nil
+}
+
+func (m *memoryBoxOfficeOrders) GetByID(id int) (*models.Order, error) {
+	return m.orders[id], nil
+}
+
+func (m *memoryBoxOfficeOrders) UpdateStatus(id int, status models.OrderStatus) error {
+	m.orders[id].Status = status
+	return nil
+}
+
+func (m *memoryBoxOfficeOrders) ProcessOrderCompletion(orderID int, paymentID string, ticketData []struct {
+	TicketTypeID int
+	QRCode       string
+}) error {
+	m.orders[orderID].Status = models.OrderCompleted
+	m.orders[orderID].PaymentID = paymentID
+	m.tickets[orderID] = len(ticketData)
+	return nil
+}
+
+func (m *memoryBoxOfficeOrders) RecordOrderAttribution(orderID int, attribution *models.Attribution) error {
+	m.attributions[orderID] = attribution
+	return nil
+}
+
+func (m *memoryBoxOfficeOrders) NotifyOrderCompleted(orderID int, attendees []models.TicketAttendee) error {
+	m.notified = append(m.notified, orderID)
+	return nil
+}
+
+func newTestBoxOffice(ticketTypes ...*models.TicketType) (*BoxOfficeService, *memoryBoxOfficeOrders) {
+	store := newMemoryBoxOfficeOrders(ticketTypes...)
+	return NewBoxOfficeService(store, store, store, store), store
+}
+
+func TestBoxOfficeService_Sell(t *testing.T) {
+	// Online sales have ended, but the door still sells
+	general := &models.TicketType{ID: 1, EventID: 7, Name: "General", Price: 2000, Quantity: 10, Sold: 8,
+		SaleEnd: time.Now().Add(-time.Hour)}
+	supporter := &models.TicketType{ID: 2, EventID: 7, Name: "Supporter", Price: 1000, Quantity: 10, PayWhatYouWant: true}
+	service, store := newTestBoxOffice(general, supporter)
+
+	event := &models.Event{ID: 7, Title: "Gig", EndDate: time.Now().Add(2 * time.Hour)}
+	seller := &models.User{ID: 3, Email: "organizer@example.com", FirstName: "Ada", LastName: "Otieno"}
+	order, err := service.Sell(event, seller, &models.BoxOfficeSale{
+		Items:         []models.BoxOfficeItem{{TicketTypeID: 1, Quantity: 2}, {TicketTypeID: 2, Quantity: 1, Price: 3000}},
+		PaymentMethod: models.BoxOfficeCard,
+	})
+	if err != nil {
+		t.Fatalf("Sell() error = %v", err)
+	}
+
+	if order.Status != models.OrderCompleted || order.TotalAmount != 7000 {
+		t.Errorf("order = %s for %d, want completed for 7000", order.Status, order.TotalAmount)
+	}
+	if !models.IsBoxOfficePayment(order.PaymentID) {
+		t.Errorf("PaymentID = %q, want a box office payment", order.PaymentID)
+	}
+	if store.tickets[order.ID] != 3 {
+		t.Errorf("issued %d tickets, want 3", store.tickets[order.ID])
+	}
+	// Without buyer details the tickets go to the seller
+	if order.UserID != seller.ID || order.BillingEmail != seller.Email {
+		t.Errorf("order belongs to %d <%s>, want the seller", order.UserID, order.BillingEmail)
+	}
+	attribution := store.attributions[order.ID]
+	if attribution == nil || attribution.SourceLabel() != models.AttributionSourceBoxOffice || attribution.Medium != "card" {
+		t.Errorf("attribution = %+v, want the box office by card", attribution)
+	}
+	if len(store.notified) != 1 || store.notified[0] != order.ID {
+		t.Errorf("notified = %v, want [%d]", store.notified, order.ID)
+	}
+}
+
+func TestBoxOfficeService_Sell_Rejects(t *testing.T) {
+	general := &models.TicketType{ID: 1, EventID: 7, Name: "General", Price: 2000, Quantity: 10, Sold: 9}
+	service, store := newTestBoxOffice(general)
+	seller := &models.User{ID: 3, Email: "organizer@example.com", FirstName: "Ada", LastName: "Otieno"}
+	event := &models.Event{ID: 7, EndDate: time.Now().Add(time.Hour)}
+
+	tests := map[string]struct {
+		event *models.Event
+		items []models.BoxOfficeItem
+	}{
+		"sold out":             {event, []models.BoxOfficeItem{{TicketTypeID: 1, Quantity: 2}}},
+		"another event's type": {event, []models.BoxOfficeItem{{TicketTypeID: 99, Quantity: 1}}},
+		"event ended":          {&models.Event{ID: 7, EndDate: time.Now().Add(-time.Hour)}, []models.BoxOfficeItem{{TicketTypeID: 1, Quantity: 1}}},
+		"event cancelled":      {&models.Event{ID: 7, Status: models.StatusCancelled, EndDate: time.Now().Add(time.Hour)}, []models.BoxOfficeItem{{TicketTypeID: 1, Quantity: 1}}},
+	}
+	for name, tt := range tests {
+		sale := &models.BoxOfficeSale{Items: tt.items, PaymentMethod: models.BoxOfficeCash}
+		if _, err := service.Sell(tt.event, seller, sale); err == nil {
+			t.Errorf("%s: Sell() = nil error, want an error", name)
+		}
+	}
+	if len(store.orders) != 0 {
+		t.Errorf("rejected sales created %d orders", len(store.orders))
+	}
+}
@@ -0,0 +1,223 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// CalendarView represents the granularity of a calendar view
+type CalendarView string
+
+const (
+	CalendarViewMonth CalendarView = "month"
+	CalendarViewWeek  CalendarView = "week"
+)
+
+// CalendarEntryType distinguishes event occurrences from ticket sale windows
+type CalendarEntryType string
+
+const (
+	CalendarEntryEvent      CalendarEntryType = "event"
+	CalendarEntrySaleWindow CalendarEntryType = "sale_window"
+)
+
+// CalendarEntry represents a single item shown on a calendar day
+type CalendarEntry struct {
+	Type         CalendarEntryType  `json:"type"`
+	EventID      int                `json:"event_id"`
+	TicketTypeID int                `json:"ticket_type_id,omitempty"`
+	Title        string             `json:"title"`
+	Start        time.Time          `json:"start"`
+	End          time.Time          `json:"end"`
+	Status       models.EventStatus `json:"status"`
+	Draggable    bool               `json:"draggable"` // Only drafts can be rescheduled from the calendar
+	IsStartDay   bool               `json:"is_start_day"`
+}
+
+// CalendarDay represents one cell of the calendar grid
+type CalendarDay struct {
+	Date    time.Time        `json:"date"`
+	InRange bool             `json:"in_range"` // False for padding days outside the displayed month
+	IsToday bool             `json:"is_today"`
+	Entries []*CalendarEntry `json:"entries"`
+}
+
+// OrganizerCalendar represents a month or week calendar of an organizer's events
+type OrganizerCalendar struct {
+	View       CalendarView          `json:"view"`
+	Anchor     time.Time             `json:"anchor"`
+	RangeStart time.Time             `json:"range_start"`
+	RangeEnd   time.Time             `json:"range_end"`
+	Weeks      [][]*CalendarDay      `json:"weeks"`
+	PrevAnchor time.Time             `json:"prev_anchor"`
+	NextAnchor time.Time             `json:"next_anchor"`
+	Events     map[int]*models.Event `json:"-"` // Events referenced by entries, keyed by ID
+}
+
+// Title returns a human readable heading for the calendar range
+func (c *OrganizerCalendar) Title() string {
+	if c.View == CalendarViewWeek {
+		end := c.RangeEnd.AddDate(0, 0, -1)
+		return fmt.Sprintf("%s – %s", c.RangeStart.Format("Jan 2"), end.Format("Jan 2, 2006"))
+	}
+	return c.Anchor.Format("January 2006")
+}
+
+// CalendarService builds calendar views of organizer events and ticket sale windows
+type CalendarService struct {
+	eventRepo  EventRepository
+	ticketRepo TicketRepository
+	now        func() time.Time
+}
+
+// NewCalendarService creates a new calendar service
+func NewCalendarService(eventRepo EventRepository, ticketRepo TicketRepository) *CalendarService {
+	return &CalendarService{
+		eventRepo:  eventRepo,
+		ticketRepo: ticketRepo,
+		now:        time.Now,
+	}
+}
+
+// ParseCalendarView parses a view query parameter, defaulting to month
+func ParseCalendarView(value string) CalendarView {
+	if CalendarView(value) == CalendarViewWeek {
+		return CalendarViewWeek
+	}
+	return CalendarViewMonth
+}
+
+// GetOrganizerCalendar returns the calendar for an organizer around the anchor date
+func (s *CalendarService) GetOrganizerCalendar(organizerID int, view CalendarView, anchor time.Time) (*OrganizerCalendar, error) {
+	if anchor.IsZero() {
+		anchor = s.now()
+	}
+	anchor = truncateToDay(anchor)
+
+	calendar := &OrganizerCalendar{
+		View:   view,
+		Anchor: anchor,
+		Events: make(map[int]*models.Event),
+	}
+
+	// Work out the visible range, padded to whole weeks starting on Monday
+	switch view {
+	case CalendarViewWeek:
+		calendar.RangeStart = startOfWeek(anchor)
+		calendar.RangeEnd = calendar.RangeStart.AddDate(0, 0, 7)
+		calendar.PrevAnchor = anchor.AddDate(0, 0, -7)
+		calendar.NextAnchor = anchor.AddDate(0, 0, 7)
+	default:
+		calendar.View = CalendarViewMonth
+		firstOfMonth := time.Date(anchor.Year(), anchor.Month(), 1, 0, 0, 0, 0, anchor.Location())
+		calendar.Anchor = firstOfMonth
+		calendar.RangeStart = startOfWeek(firstOfMonth)
+		lastOfMonth := firstOfMonth.AddDate(0, 1, -1)
+		calendar.RangeEnd = startOfWeek(lastOfMonth).AddDate(0, 0, 7)
+		calendar.PrevAnchor = firstOfMonth.AddDate(0, -1, 0)
+		calendar.NextAnchor = firstOfMonth.AddDate(0, 1, 0)
+	}
+
+	events, err := s.eventRepo.GetByOrganizer(organizerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organizer events: %w", err)
+	}
+
+	var entries []*CalendarEntry
+	for _, event := range events {
+		if event.Status != models.StatusCancelled && overlaps(event.StartDate, event.EndDate, calendar.RangeStart, calendar.RangeEnd) {
+			calendar.Events[event.ID] = event
+			entries = append(entries, &CalendarEntry{
+				Type:      CalendarEntryEvent,
+				EventID:   event.ID,
+				Title:     event.Title,
+				Start:     event.StartDate,
+				End:       event.EndDate,
+				Status:    event.Status,
+				Draggable: event.IsDraft() && event.StartDate.After(s.now()), // Same rule as Event.CanBeEdited
+			})
+		}
+
+		ticketTypes, err := s.ticketRepo.GetTicketTypesByEvent(event.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get ticket types for event %d: %w", event.ID, err)
+		}
+		for _, tt := range ticketTypes {
+			if !overlaps(tt.SaleStart, tt.SaleEnd, calendar.RangeStart, calendar.RangeEnd) {
+				continue
+			}
+			calendar.Events[event.ID] = event
+			entries = append(entries, &CalendarEntry{
+				Type:         CalendarEntrySaleWindow,
+				EventID:      event.ID,
+				TicketTypeID: tt.ID,
+				Title:        fmt.Sprintf("%s sales: %s", tt.Name, event.Title),
+				Start:        tt.SaleStart,
+				End:          tt.SaleEnd,
+				Status:       event.Status,
+			})
+		}
+	}
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		if entries[i].Type != entries[j].Type {
+			return entries[i].Type == CalendarEntryEvent
+		}
+		return entries[i].Start.Before(entries[j].Start)
+	})
+
+	today := truncateToDay(s.now())
+	var week []*CalendarDay
+	for day := calendar.RangeStart; day.Before(calendar.RangeEnd); day = day.AddDate(0, 0, 1) {
+		cell := &CalendarDay{
+			Date:    day,
+			InRange: view == CalendarViewWeek || day.Month() == calendar.Anchor.Month(),
+			IsToday: day.Equal(today),
+		}
+
+		next := day.AddDate(0, 0, 1)
+		for _, entry := range entries {
+			if !overlaps(entry.Start, entry.End, day, next) {
+				continue
+			}
+			dayEntry := *entry
+			dayEntry.IsStartDay = truncateToDay(entry.Start).Equal(day) || (day.Equal(calendar.RangeStart) && entry.Start.Before(day))
+			// Multi-day items are only draggable from their first day
+			dayEntry.Draggable = entry.Draggable && truncateToDay(entry.Start).Equal(day)
+			cell.Entries = append(cell.Entries, &dayEntry)
+		}
+
+		week = append(week, cell)
+		if len(week) == 7 {
+			calendar.Weeks = append(calendar.Weeks, week)
+			week = nil
+		}
+	}
+
+	return calendar, nil
+}
+
+// truncateToDay returns midnight of the given time in its location
+func truncateToDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// startOfWeek returns the Monday on or before the given day
+func startOfWeek(t time.Time) time.Time {
+	offset := (int(t.Weekday()) + 6) % 7
+	return truncateToDay(t).AddDate(0, 0, -offset)
+}
+
+// overlaps reports whether [start, end] intersects [rangeStart, rangeEnd)
+func overlaps(start, end, rangeStart, rangeEnd time.Time) bool {
+	if start.IsZero() {
+		return false
+	}
+	if end.IsZero() || end.Before(start) {
+		end = start
+	}
+	return start.Before(rangeEnd) && !end.Before(rangeStart)
+}
@@ -0,0 +1,154 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+func setupCalendarService(now time.Time) (*CalendarService, *mockEventRepository, *mockTicketRepository) {
+	eventRepo := newMockEventRepository()
+	ticketRepo := newMockTicketRepository()
+	service := NewCalendarService(eventRepo, ticketRepo)
+	service.now = func() time.Time { return now }
+	return service, eventRepo, ticketRepo
+}
+
+func findCalendarDay(calendar *OrganizerCalendar, date time.Time) *CalendarDay {
+	for _, week := range calendar.Weeks {
+		for _, day := range week {
+			if day.Date.Equal(date) {
+				return day
+			}
+		}
+	}
+	return nil
+}
+
+func TestCalendarService_MonthView(t *testing.T) {
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	service, eventRepo, ticketRepo := setupCalendarService(now)
+
+	eventRepo.events[1] = &models.Event{
+		ID:          1,
+		Title:       "Draft Gig",
+		OrganizerID: 1,
+		Status:      models.StatusDraft,
+		StartDate:   time.Date(2025, 3, 20, 18, 0, 0, 0, time.UTC),
+		EndDate:     time.Date(2025, 3, 21, 1, 0, 0, 0, time.UTC),
+	}
+	eventRepo.events[2] = &models.Event{
+		ID:          2,
+		Title:       "Published Show",
+		OrganizerID: 1,
+		Status:      models.StatusPublished,
+		StartDate:   time.Date(2025, 3, 25, 19, 0, 0, 0, time.UTC),
+		EndDate:     time.Date(2025, 3, 25, 22, 0, 0, 0, time.UTC),
+	}
+	eventRepo.events[3] = &models.Event{
+		ID:          3,
+		Title:       "Someone Else",
+		OrganizerID: 2,
+		Status:      models.StatusDraft,
+		StartDate:   time.Date(2025, 3, 20, 18, 0, 0, 0, time.UTC),
+		EndDate:     time.Date(2025, 3, 20, 20, 0, 0, 0, time.UTC),
+	}
+	ticketRepo.ticketTypes[1] = &models.TicketType{
+		ID:        1,
+		EventID:   2,
+		Name:      "General",
+		SaleStart: time.Date(2025, 3, 1, 0, 0, 0, 0, time.UTC),
+		SaleEnd:   time.Date(2025, 3, 24, 23, 0, 0, 0, time.UTC),
+	}
+
+	calendar, err := service.GetOrganizerCalendar(1, CalendarViewMonth, time.Date(2025, 3, 15, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// March 2025 starts on a Saturday and ends on a Monday
+	if !calendar.RangeStart.Equal(time.Date(2025, 2, 24, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected range start %v", calendar.RangeStart)
+	}
+	if !calendar.RangeEnd.Equal(time.Date(2025, 4, 7, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected range end %v", calendar.RangeEnd)
+	}
+	if len(calendar.Weeks) != 6 {
+		t.Errorf("expected 6 weeks, got %d", len(calendar.Weeks))
+	}
+	if calendar.Title() != "March 2025" {
+		t.Errorf("unexpected title %q", calendar.Title())
+	}
+
+	start := findCalendarDay(calendar, time.Date(2025, 3, 20, 0, 0, 0, 0, time.UTC))
+	if start == nil || len(start.Entries) != 2 {
+		t.Fatalf("expected draft event and sale window on March 20, got %+v", start)
+	}
+	if start.Entries[0].EventID != 1 || !start.Entries[0].Draggable || !start.Entries[0].IsStartDay {
+		t.Errorf("expected draggable draft entry first, got %+v", start.Entries[0])
+	}
+	if start.Entries[1].Type != CalendarEntrySaleWindow {
+		t.Errorf("expected sale window entry, got %+v", start.Entries[1])
+	}
+
+	// Multi-day drafts are only draggable from their first day
+	next := findCalendarDay(calendar, time.Date(2025, 3, 21, 0, 0, 0, 0, time.UTC))
+	if next == nil || len(next.Entries) != 2 || next.Entries[0].Draggable {
+		t.Errorf("expected non-draggable continuation on March 21, got %+v", next)
+	}
+
+	show := findCalendarDay(calendar, time.Date(2025, 3, 25, 0, 0, 0, 0, time.UTC))
+	if show == nil || len(show.Entries) != 1 || show.Entries[0].Draggable {
+		t.Errorf("expected single non-draggable published entry on March 25, got %+v", show)
+	}
+
+	padding := findCalendarDay(calendar, time.Date(2025, 2, 24, 0, 0, 0, 0, time.UTC))
+	if padding == nil || padding.InRange {
+		t.Errorf("expected February padding day to be out of range")
+	}
+
+	if calendar.Events[3] != nil {
+		t.Errorf("expected other organizers' events to be excluded")
+	}
+}
+
+func TestCalendarService_WeekView(t *testing.T) {
+	now := time.Date(2025, 3, 10, 9, 0, 0, 0, time.UTC)
+	service, eventRepo, _ := setupCalendarService(now)
+
+	eventRepo.events[1] = &models.Event{
+		ID:          1,
+		Title:       "Cancelled",
+		OrganizerID: 1,
+		Status:      models.StatusCancelled,
+		StartDate:   time.Date(2025, 3, 12, 18, 0, 0, 0, time.UTC),
+		EndDate:     time.Date(2025, 3, 12, 20, 0, 0, 0, time.UTC),
+	}
+
+	calendar, err := service.GetOrganizerCalendar(1, ParseCalendarView("week"), time.Date(2025, 3, 12, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if calendar.View != CalendarViewWeek {
+		t.Errorf("expected week view, got %s", calendar.View)
+	}
+	if len(calendar.Weeks) != 1 || len(calendar.Weeks[0]) != 7 {
+		t.Fatalf("expected a single 7 day week")
+	}
+	if !calendar.Weeks[0][0].Date.Equal(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected week to start on Monday March 10, got %v", calendar.Weeks[0][0].Date)
+	}
+	if !calendar.Weeks[0][0].IsToday {
+		t.Errorf("expected March 10 to be marked as today")
+	}
+	for _, day := range calendar.Weeks[0] {
+		if len(day.Entries) != 0 {
+			t.Errorf("expected cancelled events to be hidden, got %d entries on %v", len(day.Entries), day.Date)
+		}
+	}
+	if !calendar.PrevAnchor.Equal(time.Date(2025, 3, 5, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("unexpected previous anchor %v", calendar.PrevAnchor)
+	}
+}
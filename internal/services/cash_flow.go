@@ -0,0 +1,106 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// CashFlowRepository defines the data operations for cash-flow projections
+type CashFlowRepository interface {
+	GetUnsettledEventRevenue(organizerID int, now time.Time) ([]*models.EventRevenue, error)
+	GetOpenWithdrawalTotals(organizerID int) (pending, approved float64, err error)
+}
+
+// OrganizerBalanceReader reads an organizer's withdrawable balance
+type OrganizerBalanceReader interface {
+	GetOrganizerBalance(organizerID int) (float64, error)
+	CountRefundingCancellations(organizerID int) (int, error)
+}
+
+// CashFlowService projects organizers' incoming funds by week
+type CashFlowService struct {
+	repo     CashFlowRepository
+	balances OrganizerBalanceReader
+	now      func() time.Time
+}
+
+// NewCashFlowService creates a new cash-flow service
+func NewCashFlowService(repo CashFlowRepository, balances OrganizerBalanceReader) *CashFlowService {
+	return &CashFlowService{
+		repo:     repo,
+		balances: balances,
+		now:      time.Now,
+	}
+}
+
+// GetProjection builds the organizer's cash-flow projection for the current
+// week and the models.CashFlowWeeks-1 weeks after it
+func (s *CashFlowService) GetProjection(organizerID int) (*models.CashFlowProjection, error) {
+	now := s.now()
+
+	balance, err := s.balances.GetOrganizerBalance(organizerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organizer balance: %w", err)
+	}
+	refunding, err := s.balances.CountRefundingCancellations(organizerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check cancelled events: %w", err)
+	}
+	pending, approved, err := s.repo.GetOpenWithdrawalTotals(organizerID)
+	if err != nil {
+		return nil, err
+	}
+	revenue, err := s.repo.GetUnsettledEventRevenue(organizerID, now)
+	if err != nil {
+		return nil, err
+	}
+
+	projection := &models.CashFlowProjection{
+		GeneratedAt:        now,
+		AvailableBalance:   balance,
+		HeldForRefunds:     refunding > 0,
+		PendingPayouts:     pending,
+		ScheduledTransfers: approved,
+	}
+
+	start := models.WeekStart(now)
+	for i := 0; i < models.CashFlowWeeks; i++ {
+		projection.Weeks = append(projection.Weeks, &models.CashFlowWeek{Start: start.AddDate(0, 0, 7*i)})
+	}
+	projection.Weeks[0].PendingPayouts = pending
+	projection.Weeks[0].ScheduledTransfers = approved
+
+	for _, event := range revenue {
+		projection.UnsettledSales += event.Net()
+		projection.RefundExposure += event.Gross()
+
+		// Events already under way count towards the current week
+		if week := projectionWeek(projection.Weeks, event.StartDate); week != nil {
+			week.RefundExposure += event.Gross()
+			week.Events = append(week.Events, event)
+		} else {
+			projection.LaterRefundExposure += event.Gross()
+		}
+
+		if week := projectionWeek(projection.Weeks, event.EndDate); week != nil {
+			week.SettlingSales += event.Net()
+		} else {
+			projection.LaterSettlingSales += event.Net()
+		}
+	}
+
+	return projection, nil
+}
+
+// projectionWeek returns the week t falls in, the first week for earlier
+// times, or nil if t is after the last week
+func projectionWeek(weeks []*models.CashFlowWeek, t time.Time) *models.CashFlowWeek {
+	for _, week := range weeks {
+		if t.Before(week.End()) {
+			return week
+		}
+	}
+	return nil
+}
@@ -0,0 +1,109 @@
+package services
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock CashFlowRepository for testing
+type mockCashFlowRepository struct {
+	revenue           []*models.EventRevenue
+	pending, approved float64
+}
+
+func (m *mockCashFlowRepository) GetUnsettledEventRevenue(organizerID int, now time.Time) ([]*models.EventRevenue, error) {
+	var revenue []*models.EventRevenue
+	for _, event := range m.revenue {
+		if event.EndDate.After(now) {
+			revenue = append(revenue, event)
+		}
+	}
+	return revenue, nil
+}
+
+func (m *mockCashFlowRepository) GetOpenWithdrawalTotals(organizerID int) (float64, float64, error) {
+	return m.pending, m.approved, nil
+}
+
+// Mock OrganizerBalanceReader for testing
+type mockOrganizerBalanceReader struct {
+	balance   float64
+	refunding int
+}
+
+func (m *mockOrganizerBalanceReader) GetOrganizerBalance(organizerID int) (float64, error) {
+	return m.balance, nil
+}
+
+func (m *mockOrganizerBalanceReader) CountRefundingCancellations(organizerID int) (int, error) {
+	return m.refunding, nil
+}
+
+func TestCashFlowService_GetProjection(t *testing.T) {
+	// Wednesday
+	now := time.Date(2025, 6, 4, 12, 0, 0, 0, time.UTC)
+	monday := time.Date(2025, 6, 2, 0, 0, 0, 0, time.UTC)
+
+	repo := &mockCashFlowRepository{
+		pending:  300,
+		approved: 200,
+		revenue: []*models.EventRevenue{
+			// Under way since last week, ends this week
+			{EventID: 1, Title: "Art Fair", StartDate: now.AddDate(0, 0, -8), EndDate: now.Add(24 * time.Hour), Orders: 4, GrossCents: 100000},
+			// Starts next week and ends the week after
+			{EventID: 2, Title: "Jazz Night", StartDate: now.AddDate(0, 0, 5), EndDate: now.AddDate(0, 0, 12), Orders: 10, GrossCents: 500000},
+			// Beyond the projected weeks
+			{EventID: 3, Title: "New Year Gala", StartDate: now.AddDate(0, 6, 0), EndDate: now.AddDate(0, 6, 1), Orders: 2, GrossCents: 200000},
+		},
+	}
+	balances := &mockOrganizerBalanceReader{balance: 7000}
+
+	service := NewCashFlowService(repo, balances)
+	service.now = func() time.Time { return now }
+
+	projection, err := service.GetProjection(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(projection.Weeks) != models.CashFlowWeeks || !projection.Weeks[0].Start.Equal(monday) {
+		t.Fatalf("expected %d weeks from %v, got %d from %v", models.CashFlowWeeks, monday, len(projection.Weeks), projection.Weeks[0].Start)
+	}
+	if projection.AvailableBalance != 7000 || projection.HeldForRefunds {
+		t.Errorf("unexpected balance: %+v", projection)
+	}
+	if projection.Weeks[0].Payouts() != 500 || projection.Weeks[1].Payouts() != 0 {
+		t.Errorf("expected open withdrawals in the current week, got %v and %v", projection.Weeks[0].Payouts(), projection.Weeks[1].Payouts())
+	}
+
+	assertAmount := func(name string, got, want float64) {
+		t.Helper()
+		if math.Abs(got-want) > 0.001 {
+			t.Errorf("%s: expected %.2f, got %.2f", name, want, got)
+		}
+	}
+
+	assertAmount("refund exposure", projection.RefundExposure, 8000)
+	assertAmount("unsettled sales", projection.UnsettledSales, 7600)
+
+	assertAmount("week 1 exposure", projection.Weeks[0].RefundExposure, 1000)
+	assertAmount("week 1 settling", projection.Weeks[0].SettlingSales, 950)
+	assertAmount("week 2 exposure", projection.Weeks[1].RefundExposure, 5000)
+	assertAmount("week 2 settling", projection.Weeks[1].SettlingSales, 0)
+	assertAmount("week 3 settling", projection.Weeks[2].SettlingSales, 4750)
+	assertAmount("later exposure", projection.LaterRefundExposure, 2000)
+	assertAmount("later settling", projection.LaterSettlingSales, 1900)
+
+	if len(projection.Weeks[1].Events) != 1 || projection.Weeks[1].Events[0].Title != "Jazz Night" {
+		t.Errorf("expected Jazz Night to start in week 2, got %v", projection.Weeks[1].Events)
+	}
+
+	balances.refunding = 1
+	projection, _ = service.GetProjection(1)
+	if !projection.HeldForRefunds {
+		t.Error("expected the balance to be held while a cancelled event refunds")
+	}
+}
@@ -0,0 +1,283 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"event-ticketing-platform/internal/cache"
+	"event-ticketing-platform/internal/models"
+)
+
+// Category landing page errors
+var (
+	ErrCategoryNotFound           = errors.New("category not found")
+	ErrFeaturedEventNotInCategory = errors.New("only events in this category can be featured on its page")
+	ErrFeaturedEventsFull         = fmt.Errorf("at most %d events can be featured on a category page", models.MaxFeaturedCategoryEvents)
+)
+
+// categoryCandidateLimit is how many of a category's upcoming events admins
+// are offered to feature
+const categoryCandidateLimit = 20
+
+// CategoryPageRepository defines the data operations for category landing pages
+type CategoryPageRepository interface {
+	GetBySlug(slug string) (*models.Category, error)
+	GetByID(id int) (*models.Category, error)
+	UpdateLandingPage(id int, req *models.CategoryLandingPageRequest) (*models.Category, error)
+	GetFeaturedEvents(categoryID int, liveOnly bool) ([]*models.Event, error)
+	AddFeaturedEvent(categoryID, eventID, adminID int) (bool, error)
+	RemoveFeaturedEvent(categoryID, eventID int) error
+}
+
+// CategoryEventLister lists a category's upcoming events a page at a time
+type CategoryEventLister interface {
+	GetEventsByCategory(categoryID int, page, pageSize int) (*EventSearchResponse, error)
+}
+
+// CategoryPageData represents the data for a category landing page
+type CategoryPageData struct {
+	Category        *models.Category `json:"category"`
+	Featured        []*models.Event  `json:"featured"` // Only on the first page
+	Events          []*models.Event  `json:"events"`
+	Page            int              `json:"page"`
+	TotalPages      int              `json:"total_pages"`
+	Total           int              `json:"total"`
+	MetaTitle       string           `json:"meta_title"`
+	MetaDescription string           `json:"meta_description"`
+	CanonicalURL    string           `json:"canonical_url"`
+	ImageURL        string           `json:"image_url"` // Absolute hero image URL for link previews
+}
+
+// CategoryCuration represents what admins curate a category's landing page
+// from: the events featured on it and the upcoming ones they can add
+type CategoryCuration struct {
+	Category   *models.Category `json:"category"`
+	Featured   []*models.Event  `json:"featured"`
+	Candidates []*models.Event  `json:"candidates"`
+}
+
+// CanFeatureMore returns true while fewer than the maximum are featured
+func (c *CategoryCuration) CanFeatureMore() bool {
+	return len(c.Featured) < models.MaxFeaturedCategoryEvents
+}
+
+// CategoryPageService builds category landing pages and lets admins curate them
+type CategoryPageService struct {
+	repo         CategoryPageRepository
+	events       CategoryEventLister
+	baseURL      string
+	cache        cache.Cache
+	auditService *AuditService
+}
+
+// NewCategoryPageService creates a new category landing page service
+func NewCategoryPageService(repo CategoryPageRepository, events CategoryEventLister, baseURL string) *CategoryPageService {
+	return &CategoryPageService{
+		repo:    repo,
+		events:  events,
+		baseURL: baseURL,
+	}
+}
+
+// SetCache sets the cache the event service keeps categories in, so changes
+// to a category's landing page show straight away
+func (s *CategoryPageService) SetCache(c cache.Cache) {
+	s.cache = c
+}
+
+// SetAuditService records landing page changes in the audit log
+func (s *CategoryPageService) SetAuditService(auditService *AuditService) {
+	s.auditService = auditService
+}
+
+// GetCategoryPage builds the landing page for a category: its featured
+// events on the first page, then a page of its upcoming events
+func (s *CategoryPageService) GetCategoryPage(slug string, page, pageSize int) (*CategoryPageData, error) {
+	if page <= 0 {
+		page = 1
+	}
+
+	category, err := s.repo.GetBySlug(slug)
+	if err != nil {
+		return nil, ErrCategoryNotFound
+	}
+
+	response, err := s.events.GetEventsByCategory(category.ID, page, pageSize)
+	if err != nil {
+		return nil, err
+	}
+
+	data := &CategoryPageData{
+		Category:        category,
+		Events:          response.Events,
+		Page:            response.Page,
+		TotalPages:      response.TotalPages,
+		Total:           response.Total,
+		MetaTitle:       category.PageTitle(),
+		MetaDescription: category.PageDescription(),
+		CanonicalURL:    s.CategoryURL(category),
+		ImageURL:        s.absoluteURL(category.HeroImageURL),
+	}
+	// Later pages are their own canonical pages rather than duplicates of the first
+	if data.Page > 1 {
+		data.CanonicalURL = fmt.Sprintf("%s?page=%d", data.CanonicalURL, data.Page)
+		data.MetaTitle = fmt.Sprintf("%s - Page %d", data.MetaTitle, data.Page)
+	} else {
+		data.Featured = s.featuredEvents(category.ID)
+	}
+
+	return data, nil
+}
+
+// CategoryURL returns the absolute URL of a category's landing page
+func (s *CategoryPageService) CategoryURL(category *models.Category) string {
+	return s.baseURL + category.Path()
+}
+
+// Curation returns a category with its featured events, including ones no
+// longer shown because they ended or were unpublished, and the upcoming
+// events admins can feature
+func (s *CategoryPageService) Curation(id int) (*CategoryCuration, error) {
+	category, err := s.repo.GetByID(id)
+	if err != nil {
+		return nil, ErrCategoryNotFound
+	}
+
+	featured, err := s.repo.GetFeaturedEvents(id, false)
+	if err != nil {
+		return nil, err
+	}
+
+	response, err := s.events.GetEventsByCategory(id, 1, categoryCandidateLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	curation := &CategoryCuration{Category: category, Featured: featured}
+	for _, event := range response.Events {
+		if !containsEvent(featured, event.ID) {
+			curation.Candidates = append(curation.Candidates, event)
+		}
+	}
+	return curation, nil
+}
+
+// UpdateLandingPage sets a category's hero image and search metadata on
+// behalf of an admin
+func (s *CategoryPageService) UpdateLandingPage(adminID, id int, req *models.CategoryLandingPageRequest, r *http.Request) (*models.Category, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	category, err := s.repo.UpdateLandingPage(id, req)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateCategories()
+
+	s.logAction(adminID, models.AuditActionCategoryUpdate, category, map[string]interface{}{
+		"hero_image_url":   category.HeroImageURL,
+		"meta_title":       category.MetaTitle,
+		"meta_description": category.MetaDescription,
+	}, r)
+	return category, nil
+}
+
+// FeatureEvent features one of a category's events on its landing page on
+// behalf of an admin. Featuring an event that already is does nothing.
+func (s *CategoryPageService) FeatureEvent(adminID, categoryID, eventID int, r *http.Request) error {
+	category, err := s.repo.GetByID(categoryID)
+	if err != nil {
+		return ErrCategoryNotFound
+	}
+
+	featured, err := s.repo.GetFeaturedEvents(categoryID, false)
+	if err != nil {
+		return err
+	}
+	if containsEvent(featured, eventID) {
+		return nil
+	}
+	if len(featured) >= models.MaxFeaturedCategoryEvents {
+		return ErrFeaturedEventsFull
+	}
+
+	added, err := s.repo.AddFeaturedEvent(categoryID, eventID, adminID)
+	if err != nil {
+		return err
+	}
+	if !added {
+		return ErrFeaturedEventNotInCategory
+	}
+
+	s.logAction(adminID, models.AuditActionCategoryFeature, category, map[string]interface{}{"event_id": eventID}, r)
+	return nil
+}
+
+// UnfeatureEvent stops featuring an event on a category's landing page on
+// behalf of an admin
+func (s *CategoryPageService) UnfeatureEvent(adminID, categoryID, eventID int, r *http.Request) error {
+	category, err := s.repo.GetByID(categoryID)
+	if err != nil {
+		return ErrCategoryNotFound
+	}
+
+	if err := s.repo.RemoveFeaturedEvent(categoryID, eventID); err != nil {
+		return err
+	}
+
+	s.logAction(adminID, models.AuditActionCategoryUnfeature, category, map[string]interface{}{"event_id": eventID}, r)
+	return nil
+}
+
+// featuredEvents returns the live events featured on a category's page. It
+// never fails: if they can't be loaded none are shown.
+func (s *CategoryPageService) featuredEvents(categoryID int) []*models.Event {
+	featured, err := s.repo.GetFeaturedEvents(categoryID, true)
+	if err != nil {
+		fmt.Printf("Warning: failed to load featured category events: %v\n", err)
+		return nil
+	}
+	return featured
+}
+
+// absoluteURL makes a site path absolute, leaving full URLs as they are
+func (s *CategoryPageService) absoluteURL(url string) string {
+	if strings.HasPrefix(url, "/") {
+		return s.baseURL + url
+	}
+	return url
+}
+
+// invalidateCategories drops the cached category list
+func (s *CategoryPageService) invalidateCategories() {
+	if s.cache == nil {
+		return
+	}
+	if err := s.cache.Delete(eventCachePrefix + "categories"); err != nil {
+		fmt.Printf("Warning: failed to invalidate category cache: %v\n", err)
+	}
+}
+
+// logAction records a landing page change in the audit log
+func (s *CategoryPageService) logAction(adminID int, action string, category *models.Category, details map[string]interface{}, r *http.Request) {
+	if s.auditService == nil {
+		return
+	}
+	details["slug"] = category.Slug
+	if err := s.auditService.LogAction(adminID, action, models.AuditTargetCategory, category.ID, details, r); err != nil {
+		fmt.Printf("Warning: failed to log category change: %v\n", err)
+	}
+}
+
+// containsEvent returns true if one of the events has the ID
+func containsEvent(events []*models.Event, id int) bool {
+	for _, event := range events {
+		if event.ID == id {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,227 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+type mockCategoryPageRepository struct {
+	categories []*models.Category
+	events     []*models.Event
+	featured   map[int][]int
+}
+
+func (m *mockCategoryPageRepository) GetBySlug(slug string) (*models.Category, error) {
+	for _, category := range m.categories {
+		if category.Slug == slug {
+			return category, nil
+		}
+	}
+	return nil, errors.New("category not found")
+}
+
+func (m *mockCategoryPageRepository) GetByID(id int) (*models.Category, error) {
+	for _, category := range m.categories {
+		if category.ID == id {
+			return category, nil
+		}
+	}
+	return nil, errors.New("category not found")
+}
+
+func (m *mockCategoryPageRepository) UpdateLandingPage(id int, req *models.CategoryLandingPageRequest) (*models.Category, error) {
+	category, err := m.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	category.HeroImageURL = req.HeroImageURL
+	category.MetaTitle = req.MetaTitle
+	category.MetaDescription = req.MetaDescription
+	return category, nil
+}
+
+func (m *mockCategoryPageRepository) GetFeaturedEvents(categoryID int, liveOnly bool) ([]*models.Event, error) {
+	var events []*models.Event
+	for _, id := range m.featured[categoryID] {
+		for _, event := range m.events {
+			if event.ID == id && (!liveOnly || event.Status == models.StatusPublished) {
+				events = append(events, event)
+			}
+		}
+	}
+	return events, nil
+}
+
+func (m *mockCategoryPageRepository) AddFeaturedEvent(categoryID, eventID, adminID int) (bool, error) {
+	for _, event := range m.events {
+		if event.ID == eventID && event.CategoryID == categoryID {
+			m.featured[categoryID] = append(m.featured[categoryID], eventID)
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *mockCategoryPageRepository) RemoveFeaturedEvent(categoryID, eventID int) error {
+	var kept []int
+	for _, id := range m.featured[categoryID] {
+		if id != eventID {
+			kept = append(kept, id)
+		}
+	}
+	m.featured[categoryID] = kept
+	return nil
+}
+
+type mockCategoryEventLister struct {
+	events []*models.Event
+}
+
+func (m *mockCategoryEventLister) GetEventsByCategory(categoryID int, page, pageSize int) (*EventSearchResponse, error) {
+	var matching []*models.Event
+	for _, event := range m.events {
+		if event.CategoryID == categoryID && event.Status == models.StatusPublished {
+			matching = append(matching, event)
+		}
+	}
+
+	response := &EventSearchResponse{Total: len(matching), Page: page, PageSize: pageSize, TotalPages: (len(matching) + pageSize - 1) / pageSize}
+	start := (page - 1) * pageSize
+	if start < len(matching) {
+		end := start + pageSize
+		if end > len(matching) {
+			end = len(matching)
+		}
+		response.Events = matching[start:end]
+	}
+	return response, nil
+}
+
+func newTestCategoryPageService() (*CategoryPageService, *mockCategoryPageRepository) {
+	start := time.Now().Add(24 * time.Hour)
+	events := []*models.Event{
+		{ID: 1, Title: "Jazz Night", CategoryID: 1, Status: models.StatusPublished, StartDate: start, EndDate: start.Add(time.Hour)},
+		{ID: 2, Title: "Rock Fest", CategoryID: 1, Status: models.StatusPublished, StartDate: start, EndDate: start.Add(time.Hour)},
+		{ID: 3, Title: "Choir Draft", CategoryID: 1, Status: models.StatusDraft, StartDate: start, EndDate: start.Add(time.Hour)},
+		{ID: 4, Title: "Marathon", CategoryID: 2, Status: models.StatusPublished, StartDate: start, EndDate: start.Add(time.Hour)},
+	}
+	repo := &mockCategoryPageRepository{
+		categories: []*models.Category{
+			{ID: 1, Name: "Music", Slug: "music", Description: "Concerts and festivals", HeroImageURL: "/uploads/music.jpg"},
+			{ID: 2, Name: "Sports", Slug: "sports"},
+		},
+		events:   events,
+		featured: map[int][]int{1: {2, 3}},
+	}
+	return NewCategoryPageService(repo, &mockCategoryEventLister{events: events}, "https://runtown.test"), repo
+}
+
+func TestCategoryPageService_GetCategoryPage(t *testing.T) {
+	service, _ := newTestCategoryPageService()
+
+	data, err := service.GetCategoryPage("music", 1, 1)
+	if err != nil {
+		t.Fatalf("GetCategoryPage() error = %v", err)
+	}
+	if data.Total != 2 || data.TotalPages != 2 || len(data.Events) != 1 {
+		t.Errorf("got %d events of %d on %d pages, want 1 of 2 on 2", len(data.Events), data.Total, data.TotalPages)
+	}
+	if len(data.Featured) != 1 || data.Featured[0].ID != 2 {
+		t.Errorf("Featured = %v, want only the live featured event", data.Featured)
+	}
+	if data.MetaTitle != "Music Events" || data.MetaDescription != "Concerts and festivals" {
+		t.Errorf("meta = %q / %q, want the defaults", data.MetaTitle, data.MetaDescription)
+	}
+	if data.CanonicalURL != "https://runtown.test/categories/music" {
+		t.Errorf("CanonicalURL = %q", data.CanonicalURL)
+	}
+	if data.ImageURL != "https://runtown.test/uploads/music.jpg" {
+		t.Errorf("ImageURL = %q, want the hero image made absolute", data.ImageURL)
+	}
+
+	data, err = service.GetCategoryPage("music", 2, 1)
+	if err != nil {
+		t.Fatalf("GetCategoryPage() page 2 error = %v", err)
+	}
+	if len(data.Featured) != 0 {
+		t.Errorf("page 2 shows %d featured events, want them only on the first page", len(data.Featured))
+	}
+	if data.CanonicalURL != "https://runtown.test/categories/music?page=2" || data.MetaTitle != "Music Events - Page 2" {
+		t.Errorf("page 2 canonical = %q, title = %q", data.CanonicalURL, data.MetaTitle)
+	}
+
+	if _, err := service.GetCategoryPage("unknown", 1, 12); !errors.Is(err, ErrCategoryNotFound) {
+		t.Errorf("GetCategoryPage() unknown slug error = %v, want ErrCategoryNotFound", err)
+	}
+}
+
+func TestCategoryPageService_Curation(t *testing.T) {
+	service, _ := newTestCategoryPageService()
+
+	curation, err := service.Curation(1)
+	if err != nil {
+		t.Fatalf("Curation() error = %v", err)
+	}
+	if len(curation.Featured) != 2 {
+		t.Errorf("Featured = %d events, want every featured event including unpublished ones", len(curation.Featured))
+	}
+	if len(curation.Candidates) != 1 || curation.Candidates[0].ID != 1 {
+		t.Errorf("Candidates = %v, want the upcoming events not already featured", curation.Candidates)
+	}
+}
+
+func TestCategoryPageService_FeatureEvent(t *testing.T) {
+	service, repo := newTestCategoryPageService()
+
+	if err := service.FeatureEvent(9, 1, 1, nil); err != nil {
+		t.Fatalf("FeatureEvent() error = %v", err)
+	}
+	if got := repo.featured[1]; len(got) != 3 || got[2] != 1 {
+		t.Errorf("featured = %v, want the event added last", got)
+	}
+
+	// Featuring an event again changes nothing
+	if err := service.FeatureEvent(9, 1, 1, nil); err != nil || len(repo.featured[1]) != 3 {
+		t.Errorf("featuring again: error = %v, featured = %v", err, repo.featured[1])
+	}
+
+	if err := service.FeatureEvent(9, 1, 4, nil); !errors.Is(err, ErrFeaturedEventNotInCategory) {
+		t.Errorf("FeatureEvent() other category error = %v, want ErrFeaturedEventNotInCategory", err)
+	}
+
+	for id := 5; id <= 7; id++ {
+		repo.events = append(repo.events, &models.Event{ID: id, CategoryID: 1, Status: models.StatusPublished})
+	}
+	repo.featured[1] = []int{1, 2, 3, 5, 6, 7}
+	if err := service.FeatureEvent(9, 1, 8, nil); !errors.Is(err, ErrFeaturedEventsFull) {
+		t.Errorf("FeatureEvent() past the limit error = %v, want ErrFeaturedEventsFull", err)
+	}
+
+	if err := service.UnfeatureEvent(9, 1, 2, nil); err != nil {
+		t.Fatalf("UnfeatureEvent() error = %v", err)
+	}
+	if got := repo.featured[1]; len(got) != 5 {
+		t.Errorf("featured = %v, want the event removed", got)
+	}
+}
+
+func TestCategoryPageService_UpdateLandingPage(t *testing.T) {
+	service, _ := newTestCategoryPageService()
+
+	req := &models.CategoryLandingPageRequest{HeroImageURL: " https://cdn.example.com/sports.jpg ", MetaTitle: "Sports in Kenya"}
+	category, err := service.UpdateLandingPage(9, 2, req, nil)
+	if err != nil {
+		t.Fatalf("UpdateLandingPage() error = %v", err)
+	}
+	if category.HeroImageURL != "https://cdn.example.com/sports.jpg" || category.PageTitle() != "Sports in Kenya" {
+		t.Errorf("category = %+v, want the trimmed settings saved", category)
+	}
+
+	bad := &models.CategoryLandingPageRequest{HeroImageURL: "ftp://example.com/sports.jpg"}
+	if _, err := service.UpdateLandingPage(9, 2, bad, nil); err == nil {
+		t.Error("UpdateLandingPage() with an ftp hero image succeeded, want a validation error")
+	}
+}
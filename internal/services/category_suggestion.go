@@ -0,0 +1,155 @@
+package services
+
+import (
+	"sort"
+	"strings"
+	"unicode"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// CategoryLister provides the available event categories
+type CategoryLister interface {
+	GetCategories() ([]*models.Category, error)
+}
+
+// CategorySuggestion is a proposed category for an event
+type CategorySuggestion struct {
+	CategoryID      int      `json:"category_id"`
+	CategoryName    string   `json:"category_name"`
+	Score           int      `json:"score"`
+	MatchedKeywords []string `json:"matched_keywords"`
+}
+
+// categoryKeywords maps the seeded category slugs to common event keywords.
+// Categories added later still match on the words in their name and description.
+var categoryKeywords = map[string][]string{
+	"music":           {"concert", "festival", "band", "dj", "live", "gig", "jazz", "rock", "hiphop", "rap", "gospel", "choir", "orchestra", "album", "singer", "acoustic", "amapiano", "afrobeat"},
+	"sports":          {"match", "game", "tournament", "league", "marathon", "race", "football", "soccer", "rugby", "basketball", "cricket", "tennis", "golf", "athletics", "cup", "championship"},
+	"arts-culture":    {"art", "exhibition", "gallery", "theater", "theatre", "play", "museum", "poetry", "dance", "ballet", "painting", "film", "cultural", "heritage"},
+	"business":        {"conference", "summit", "networking", "seminar", "startup", "entrepreneur", "investor", "leadership", "marketing", "expo", "forum", "pitch"},
+	"food-drink":      {"food", "wine", "beer", "tasting", "brunch", "dinner", "cooking", "chef", "culinary", "cocktail", "barbecue", "coffee"},
+	"technology":      {"tech", "hackathon", "developer", "coding", "software", "ai", "data", "cloud", "blockchain", "meetup", "devops", "startup"},
+	"health-wellness": {"yoga", "fitness", "wellness", "meditation", "health", "workout", "run", "mindfulness", "nutrition", "bootcamp"},
+	"education":       {"workshop", "class", "course", "training", "masterclass", "lecture", "bootcamp", "webinar", "tutorial", "school", "learn"},
+	"community":       {"community", "charity", "fundraiser", "volunteer", "cleanup", "neighborhood", "church", "meetup", "gathering", "picnic"},
+	"entertainment":   {"comedy", "standup", "show", "movie", "cinema", "party", "karaoke", "magic", "quiz", "night"},
+}
+
+// suggestionStopWords are ignored when deriving keywords from category names and descriptions
+var suggestionStopWords = map[string]bool{
+	"and": true, "the": true, "for": true, "with": true, "events": true, "event": true,
+	"local": true, "other": true, "shows": true,
+}
+
+// Title matches count more than description matches
+const (
+	titleKeywordWeight       = 3
+	descriptionKeywordWeight = 1
+)
+
+// CategorySuggestionService proposes a category for new events from their title and description
+type CategorySuggestionService struct {
+	categories CategoryLister
+}
+
+// NewCategorySuggestionService creates a new category suggestion service
+func NewCategorySuggestionService(categories CategoryLister) *CategorySuggestionService {
+	return &CategorySuggestionService{
+		categories: categories,
+	}
+}
+
+// Suggest returns the best matching category for an event, or nil if no
+// category matches any keyword
+func (s *CategorySuggestionService) Suggest(title, description string) (*CategorySuggestion, error) {
+	categories, err := s.categories.GetCategories()
+	if err != nil {
+		return nil, err
+	}
+
+	titleWords := suggestionWords(title)
+	descriptionWords := suggestionWords(description)
+	if len(titleWords) == 0 && len(descriptionWords) == 0 {
+		return nil, nil
+	}
+
+	var best *CategorySuggestion
+	for _, category := range categories {
+		score := 0
+		var matched []string
+		for _, keyword := range keywordsForCategory(category) {
+			hit := false
+			if titleWords[keyword] {
+				score += titleKeywordWeight
+				hit = true
+			}
+			if descriptionWords[keyword] {
+				score += descriptionKeywordWeight
+				hit = true
+			}
+			if hit {
+				matched = append(matched, keyword)
+			}
+		}
+
+		if score > 0 && (best == nil || score > best.Score) {
+			sort.Strings(matched)
+			best = &CategorySuggestion{
+				CategoryID:      category.ID,
+				CategoryName:    category.Name,
+				Score:           score,
+				MatchedKeywords: matched,
+			}
+		}
+	}
+
+	return best, nil
+}
+
+// keywordsForCategory combines the built-in keywords for a category with the
+// words of its name and description
+func keywordsForCategory(category *models.Category) []string {
+	seen := make(map[string]bool)
+	var keywords []string
+	add := func(word string) {
+		if !seen[word] {
+			seen[word] = true
+			keywords = append(keywords, word)
+		}
+	}
+
+	for _, keyword := range categoryKeywords[category.Slug] {
+		add(normalizeSuggestionWord(keyword))
+	}
+	for word := range suggestionWords(category.Name + " " + category.Description) {
+		if len(word) >= 4 {
+			add(word)
+		}
+	}
+
+	return keywords
+}
+
+// suggestionWords splits text into a set of normalized words
+func suggestionWords(text string) map[string]bool {
+	words := make(map[string]bool)
+	fields := strings.FieldsFunc(strings.ToLower(text), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsNumber(r)
+	})
+	for _, field := range fields {
+		if suggestionStopWords[field] {
+			continue
+		}
+		words[normalizeSuggestionWord(field)] = true
+	}
+	return words
+}
+
+// normalizeSuggestionWord strips a plural "s" so that "concerts" matches "concert"
+func normalizeSuggestionWord(word string) string {
+	if len(word) > 4 && strings.HasSuffix(word, "s") && !strings.HasSuffix(word, "ss") {
+		return strings.TrimSuffix(word, "s")
+	}
+	return word
+}
@@ -0,0 +1,68 @@
+package services
+
+import (
+	"testing"
+
+	"event-ticketing-platform/internal/models"
+)
+
+type mockCategoryLister struct {
+	categories []*models.Category
+}
+
+func (m *mockCategoryLister) GetCategories() ([]*models.Category, error) {
+	return m.categories, nil
+}
+
+func setupCategorySuggestionService() *CategorySuggestionService {
+	return NewCategorySuggestionService(&mockCategoryLister{categories: []*models.Category{
+		{ID: 1, Name: "Music", Slug: "music", Description: "Concerts, festivals, and musical performances"},
+		{ID: 2, Name: "Sports", Slug: "sports", Description: "Sporting events, games, and competitions"},
+		{ID: 6, Name: "Technology", Slug: "technology", Description: "Tech conferences, workshops, and meetups"},
+		{ID: 11, Name: "Motoring", Slug: "motoring", Description: "Car shows and rallies"},
+	}})
+}
+
+func TestCategorySuggestionService_Suggest(t *testing.T) {
+	service := setupCategorySuggestionService()
+
+	tests := []struct {
+		name        string
+		title       string
+		description string
+		expectedID  int
+	}{
+		{name: "title keyword", title: "Jazz Night Live", expectedID: 1},
+		{name: "plural keyword", title: "Summer Concerts in the Park", expectedID: 1},
+		{name: "description keyword", title: "Saturday Special", description: "Join the football tournament final", expectedID: 2},
+		{name: "title outweighs description", title: "Nairobi Hackathon", description: "Live music at the closing party", expectedID: 6},
+		{name: "custom category description", title: "Classic Motoring Weekend", expectedID: 11},
+		{name: "no match", title: "Something Else", expectedID: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			suggestion, err := service.Suggest(tt.title, tt.description)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.expectedID == 0 {
+				if suggestion != nil {
+					t.Errorf("expected no suggestion, got %s", suggestion.CategoryName)
+				}
+				return
+			}
+
+			if suggestion == nil {
+				t.Fatalf("expected category %d, got no suggestion", tt.expectedID)
+			}
+			if suggestion.CategoryID != tt.expectedID {
+				t.Errorf("expected category %d, got %d (%v)", tt.expectedID, suggestion.CategoryID, suggestion.MatchedKeywords)
+			}
+			if len(suggestion.MatchedKeywords) == 0 {
+				t.Error("expected matched keywords")
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"sync"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// CheckInBroker tells the live check-in dashboards watching an event when a
+// ticket holder is admitted there. Dashboards reload the statistics
+// themselves, so admissions that arrive together are only loaded once.
+// Admissions only reach dashboards on this server; dashboards refresh
+// periodically to pick up the rest.
+type CheckInBroker struct {
+	mu     sync.Mutex
+	topics map[int]map[chan struct{}]struct{}
+	closed bool
+}
+
+// NewCheckInBroker creates a check-in broker. Subscribe it to the domain
+// event bus to hear about admissions.
+func NewCheckInBroker() *CheckInBroker {
+	return &CheckInBroker{topics: make(map[int]map[chan struct{}]struct{})}
+}
+
+// Subscribe returns a channel that receives after ticket holders are admitted
+// to the event, and a function to stop. Admissions the subscriber hasn't
+// caught up with are merged into one. The channel is closed when the broker
+// is.
+func (b *CheckInBroker) Subscribe(eventID int) (<-chan struct{}, func()) {
+	updates := make(chan struct{}, 1)
+
+	b.mu.Lock()
+	if b.closed {
+		b.mu.Unlock()
+		close(updates)
+		return updates, func() {}
+	}
+	subscribers := b.topics[eventID]
+	if subscribers == nil {
+		subscribers = make(map[chan struct{}]struct{})
+		b.topics[eventID] = subscribers
+	}
+	subscribers[updates] = struct{}{}
+	b.mu.Unlock()
+
+	var once sync.Once
+	return updates, func() {
+		once.Do(func() {
+			b.mu.Lock()
+			defer b.mu.Unlock()
+			if b.closed {
+				return
+			}
+			delete(subscribers, updates)
+			if len(subscribers) == 0 {
+				delete(b.topics, eventID)
+			}
+		})
+	}
+}
+
+// Close ends every subscription, so dashboards finish when the server shuts
+// down
+func (b *CheckInBroker) Close() {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if b.closed {
+		return
+	}
+	b.closed = true
+	for _, subscribers := range b.topics {
+		for updates := range subscribers {
+			close(updates)
+		}
+	}
+	b.topics = make(map[int]map[chan struct{}]struct{})
+}
+
+// Subscribers returns the number of dashboards watching an event
+func (b *CheckInBroker) Subscribers(eventID int) int {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return len(b.topics[eventID])
+}
+
+// TicketCheckedIn tells the dashboards watching the scan's event that a
+// ticket holder was admitted. It never blocks the scan.
+func (b *CheckInBroker) TicketCheckedIn(scan *models.TicketScan) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	for updates := range b.topics[scan.EventID] {
+		select {
+		case updates <- struct{}{}:
+		default: // The subscriber already has an admission to catch up with
+		}
+	}
+}
@@ -0,0 +1,64 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCheckInBroker_NotifiesEventSubscribers(t *testing.T) {
+	broker := NewCheckInBroker()
+	defer broker.Close()
+
+	watching, unsubscribe := broker.Subscribe(1)
+	defer unsubscribe()
+	other, unsubscribeOther := broker.Subscribe(2)
+	defer unsubscribeOther()
+
+	// Admissions the dashboard hasn't caught up with are merged, and never
+	// block the scan
+	for i := 0; i < 3; i++ {
+		broker.TicketCheckedIn(&models.TicketScan{EventID: 1, Result: models.ScanAccepted})
+	}
+
+	select {
+	case <-watching:
+	case <-time.After(time.Second):
+		t.Fatal("no check-in update")
+	}
+	select {
+	case <-watching:
+		t.Error("expected admissions to be merged into one update")
+	default:
+	}
+	select {
+	case <-other:
+		t.Error("expected other events' dashboards not to be notified")
+	default:
+	}
+}
+
+func TestCheckInBroker_UnsubscribeAndClose(t *testing.T) {
+	broker := NewCheckInBroker()
+
+	updates, unsubscribe := broker.Subscribe(1)
+	_, unsubscribeSecond := broker.Subscribe(1)
+	assert.Equal(t, 2, broker.Subscribers(1))
+
+	unsubscribeSecond()
+	unsubscribeSecond()
+	assert.Equal(t, 1, broker.Subscribers(1))
+
+	broker.Close()
+	_, ok := <-updates
+	assert.False(t, ok, "expected updates to be closed")
+	unsubscribe()
+
+	closed, _ := broker.Subscribe(1)
+	_, ok = <-closed
+	assert.False(t, ok, "expected subscriptions after closing to be closed")
+	assert.Equal(t, 0, broker.Subscribers(1))
+}
@@ -0,0 +1,234 @@
+package services
+
+import (
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Offline check-in limits
+const (
+	// MaxCheckInSyncScans is the most scans one upload can carry
+	MaxCheckInSyncScans = 1000
+	// checkInManifestGrace is how long after an event ends its manifest
+	// stays valid, for late departures and re-entry
+	checkInManifestGrace = 6 * time.Hour
+	// offlineScanClockSkew is how far in the future an uploaded scan's time
+	// may be, allowing for devices whose clocks run fast
+	offlineScanClockSkew = 5 * time.Minute
+)
+
+// SignedCheckInManifest is a check-in manifest and its Ed25519 signature.
+// Manifest holds the exact bytes that were signed, so apps can verify them
+// with the key from ManifestPublicKey before trusting the tickets.
+type SignedCheckInManifest struct {
+	Manifest  json.RawMessage `json:"manifest"`
+	Signature string          `json:"signature"` // Base64
+}
+
+// CheckInSyncRequest is a batch of scans a scanning app made offline
+type CheckInSyncRequest struct {
+	EventID     int           `json:"event_id"`
+	Device      string        `json:"device"`
+	Scans       []OfflineScan `json:"scans"`
+	StaffUserID int           `json:"staff_user_id"`
+}
+
+// OfflineScan is one scan made offline. ClientID is the app's own id for
+// the scan, so uploading it again after a dropped connection is harmless.
+type OfflineScan struct {
+	ClientID  string    `json:"client_id"`
+	QRCode    string    `json:"qr_code"`
+	Gate      string    `json:"gate"`
+	ScannedAt time.Time `json:"scanned_at"`
+}
+
+// CheckInSyncResult reports what became of each uploaded scan
+type CheckInSyncResult struct {
+	Accepted      int             `json:"accepted"`
+	Duplicates    int             `json:"duplicates"` // Tickets another scan had already admitted
+	Rejected      int             `json:"rejected"`   // Invalid or refunded tickets, and malformed scans
+	AlreadySynced int             `json:"already_synced"`
+	Scans         []SyncedScanLog `json:"scans"`
+}
+
+// SyncedScanLog is the outcome of one uploaded scan
+type SyncedScanLog struct {
+	ClientID string `json:"client_id"`
+	// Result is a scan result, or "already_synced" or "malformed" for scans
+	// that weren't recorded
+	Result   string `json:"result"`
+	Reason   string `json:"reason,omitempty"`
+	TicketID *int   `json:"ticket_id,omitempty"`
+	// Conflict flags a ticket admitted offline that another scan, online or
+	// on another device, had already admitted. Only the first counts.
+	Conflict bool `json:"conflict,omitempty"`
+}
+
+// SetManifestSecret enables signed check-in manifests, with a signing key
+// derived from the secret
+func (s *TicketScanService) SetManifestSecret(secret string) {
+	seed := sha256.Sum256([]byte("check-in manifest:" + secret))
+	s.manifestKey = ed25519.NewKeyFromSeed(seed[:])
+}
+
+// ManifestPublicKey returns the key check-in manifests are verified with,
+// or nil if manifests aren't signed
+func (s *TicketScanService) ManifestPublicKey() ed25519.PublicKey {
+	if s.manifestKey == nil {
+		return nil
+	}
+	return s.manifestKey.Public().(ed25519.PublicKey)
+}
+
+// BuildManifest lists the tickets of an event the user runs check-in for,
+// signed so a scanning app can check them in without a connection
+func (s *TicketScanService) BuildManifest(eventID, userID int) (*SignedCheckInManifest, error) {
+	if s.manifestKey == nil {
+		return nil, fmt.Errorf("check-in manifests are not configured")
+	}
+
+	event, err := s.accessibleEvent(eventID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	tickets, err := s.scanRepo.GetManifestTickets(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get manifest tickets: %w", err)
+	}
+
+	manifest := &models.CheckInManifest{
+		EventID:     event.ID,
+		EventTitle:  event.Title,
+		GeneratedAt: s.now().UTC(),
+		ValidUntil:  event.EndDate.Add(checkInManifestGrace).UTC(),
+		Tickets:     tickets,
+	}
+	data, err := json.Marshal(manifest)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	return &SignedCheckInManifest{
+		Manifest:  data,
+		Signature: base64.StdEncoding.EncodeToString(ed25519.Sign(s.manifestKey, data)),
+	}, nil
+}
+
+// SyncCheckIns records scans a scanning app made offline, oldest first, as
+// if they had been made online when they were. A ticket admitted by an
+// earlier scan, whether online or uploaded from another device, is recorded
+// as a flagged duplicate, so every ticket holder is counted once.
+func (s *TicketScanService) SyncCheckIns(req *CheckInSyncRequest) (*CheckInSyncResult, error) {
+	if err := s.checkEventAccess(req.EventID, req.StaffUserID); err != nil {
+		return nil, err
+	}
+	if len(req.Scans) == 0 {
+		return nil, fmt.Errorf("at least one scan is required")
+	}
+	if len(req.Scans) > MaxCheckInSyncScans {
+		return nil, fmt.Errorf("at most %d scans are allowed per upload", MaxCheckInSyncScans)
+	}
+
+	clientIDs := make([]string, 0, len(req.Scans))
+	for _, scan := range req.Scans {
+		clientIDs = append(clientIDs, strings.TrimSpace(scan.ClientID))
+	}
+	synced, err := s.scanRepo.GetSyncedClientIDs(req.EventID, clientIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check synced scans: %w", err)
+	}
+
+	scans := make([]OfflineScan, len(req.Scans))
+	copy(scans, req.Scans)
+	sort.SliceStable(scans, func(i, j int) bool { return scans[i].ScannedAt.Before(scans[j].ScannedAt) })
+
+	now := s.now()
+	staffUserID := req.StaffUserID
+	device := truncateField(strings.TrimSpace(req.Device), 255)
+	result := &CheckInSyncResult{Scans: make([]SyncedScanLog, 0, len(scans))}
+	for _, offline := range scans {
+		clientID := strings.TrimSpace(offline.ClientID)
+		entry := SyncedScanLog{ClientID: clientID}
+
+		if err := validateOfflineScan(clientID, offline, now); err != nil {
+			entry.Result = "malformed"
+			entry.Reason = err.Error()
+			result.Rejected++
+			result.Scans = append(result.Scans, entry)
+			continue
+		}
+		if synced[clientID] {
+			entry.Result = "already_synced"
+			result.AlreadySynced++
+			result.Scans = append(result.Scans, entry)
+			continue
+		}
+		synced[clientID] = true // The same id twice in one upload is only recorded once
+
+		syncedAt := now
+		scan := &models.TicketScan{
+			EventID:     req.EventID,
+			QRCode:      strings.TrimSpace(offline.QRCode),
+			Gate:        truncateField(strings.TrimSpace(offline.Gate), 100),
+			Device:      device,
+			StaffUserID: &staffUserID,
+			ScannedAt:   offline.ScannedAt,
+			ClientID:    clientID,
+			SyncedAt:    &syncedAt,
+		}
+		if err := s.classifyScan(scan); err != nil {
+			return nil, err
+		}
+		if scan.Result == models.ScanDuplicate {
+			scan.Reason = "ticket was already checked in by another scan"
+			entry.Conflict = true
+		}
+		if err := s.scanRepo.Create(scan); err != nil {
+			return nil, fmt.Errorf("failed to record scan: %w", err)
+		}
+		if scan.IsAccepted() {
+			s.events.PublishTicketCheckedIn(scan)
+		}
+
+		entry.Result = string(scan.Result)
+		entry.Reason = scan.Reason
+		entry.TicketID = scan.TicketID
+		switch scan.Result {
+		case models.ScanAccepted:
+			result.Accepted++
+		case models.ScanDuplicate:
+			result.Duplicates++
+		default:
+			result.Rejected++
+		}
+		result.Scans = append(result.Scans, entry)
+	}
+
+	return result, nil
+}
+
+// validateOfflineScan checks an uploaded scan can be recorded
+func validateOfflineScan(clientID string, scan OfflineScan, now time.Time) error {
+	switch {
+	case clientID == "":
+		return fmt.Errorf("client_id is required")
+	case len(clientID) > 64:
+		return fmt.Errorf("client_id must be 64 characters or less")
+	case strings.TrimSpace(scan.QRCode) == "":
+		return fmt.Errorf("qr_code is required")
+	case scan.ScannedAt.IsZero():
+		return fmt.Errorf("scanned_at is required")
+	case scan.ScannedAt.After(now.Add(offlineScanClockSkew)):
+		return fmt.Errorf("scanned_at is in the future")
+	}
+	return nil
+}
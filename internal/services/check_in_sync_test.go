@@ -0,0 +1,117 @@
+package services
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+func TestTicketScanService_BuildManifest(t *testing.T) {
+	service, _, _ := setupTicketScanService()
+
+	if _, err := service.BuildManifest(1, 7); err == nil || !strings.Contains(err.Error(), "not configured") {
+		t.Fatalf("expected manifests to need a secret, got %v", err)
+	}
+
+	service.SetManifestSecret("test-secret")
+	signed, err := service.BuildManifest(1, 7)
+	if err != nil {
+		t.Fatalf("BuildManifest() error = %v", err)
+	}
+
+	signature, err := base64.StdEncoding.DecodeString(signed.Signature)
+	if err != nil {
+		t.Fatalf("invalid signature encoding: %v", err)
+	}
+	if !ed25519.Verify(service.ManifestPublicKey(), signed.Manifest, signature) {
+		t.Error("expected the manifest signature to verify")
+	}
+	tampered := []byte(strings.Replace(string(signed.Manifest), "General", "VIP", 1))
+	if ed25519.Verify(service.ManifestPublicKey(), tampered, signature) {
+		t.Error("expected a changed manifest not to verify")
+	}
+
+	var manifest models.CheckInManifest
+	if err := json.Unmarshal(signed.Manifest, &manifest); err != nil {
+		t.Fatalf("invalid manifest: %v", err)
+	}
+	if manifest.EventID != 1 || len(manifest.Tickets) != 1 || manifest.Tickets[0].QRCodeHash != models.HashQRCode("QR-ACTIVE") {
+		t.Errorf("unexpected manifest %+v", manifest)
+	}
+
+	if _, err := service.BuildManifest(1, 99); err == nil || !strings.Contains(err.Error(), "does not have access") {
+		t.Errorf("expected access error, got %v", err)
+	}
+}
+
+func TestTicketScanService_SyncCheckIns(t *testing.T) {
+	service, scanRepo, _ := setupTicketScanService()
+	syncTime := time.Date(2025, 6, 1, 23, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return syncTime }
+	at := func(minute int) time.Time { return time.Date(2025, 6, 1, 18, minute, 0, 0, time.UTC) }
+
+	result, err := service.SyncCheckIns(&CheckInSyncRequest{
+		EventID:     1,
+		Device:      "gate-1",
+		StaffUserID: 7,
+		Scans: []OfflineScan{
+			{ClientID: "b", QRCode: "QR-REFUNDED", ScannedAt: at(10)},
+			{ClientID: "a", QRCode: "QR-ACTIVE", Gate: "North", ScannedAt: at(5)},
+			{ClientID: "", QRCode: "QR-ACTIVE", ScannedAt: at(6)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("SyncCheckIns() error = %v", err)
+	}
+	if result.Accepted != 1 || result.Rejected != 2 || result.Duplicates != 0 {
+		t.Errorf("unexpected first upload %+v", result)
+	}
+	if result.Scans[0].ClientID != "a" || result.Scans[0].Result != string(models.ScanAccepted) {
+		t.Errorf("expected scans to be recorded oldest first, got %+v", result.Scans)
+	}
+	recorded := scanRepo.scans[0]
+	if !recorded.ScannedAt.Equal(at(5)) || !recorded.IsOffline() || recorded.ClientID != "a" {
+		t.Errorf("expected the offline scan's time and client id to be kept, got %+v", recorded)
+	}
+
+	// Another device scanned the same ticket offline. It uploaded later, so
+	// its scan is the duplicate even though it was made first.
+	result, err = service.SyncCheckIns(&CheckInSyncRequest{
+		EventID:     1,
+		Device:      "gate-2",
+		StaffUserID: 7,
+		Scans: []OfflineScan{
+			{ClientID: "a", QRCode: "QR-ACTIVE", ScannedAt: at(5)},
+			{ClientID: "c", QRCode: "QR-ACTIVE", ScannedAt: at(2)},
+			{ClientID: "d", QRCode: "QR-ACTIVE", ScannedAt: syncTime.Add(time.Hour)},
+		},
+	})
+	if err != nil {
+		t.Fatalf("SyncCheckIns() error = %v", err)
+	}
+	if result.AlreadySynced != 1 || result.Duplicates != 1 || result.Accepted != 0 || result.Rejected != 1 {
+		t.Errorf("unexpected second upload %+v", result)
+	}
+	for _, scan := range result.Scans {
+		if scan.ClientID == "c" && !scan.Conflict {
+			t.Errorf("expected the other device's scan to be flagged, got %+v", scan)
+		}
+	}
+
+	stats, _ := service.GetCheckInStats(1, 7)
+	if stats.CheckedIn != 1 {
+		t.Errorf("expected the ticket holder to be counted once, got %d", stats.CheckedIn)
+	}
+
+	if _, err := service.SyncCheckIns(&CheckInSyncRequest{EventID: 1, StaffUserID: 99, Scans: []OfflineScan{{ClientID: "e"}}}); err == nil {
+		t.Error("expected access error for another organizer")
+	}
+	if _, err := service.SyncCheckIns(&CheckInSyncRequest{EventID: 1, StaffUserID: 7}); err == nil {
+		t.Error("expected an upload without scans to be refused")
+	}
+}
@@ -0,0 +1,133 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// CheckoutQuestionRepository defines the data operations for events'
+// checkout questions and attendees' answers
+type CheckoutQuestionRepository interface {
+	Create(req *models.CheckoutQuestionCreateRequest) (*models.CheckoutQuestion, error)
+	GetByEvent(eventID int) ([]*models.CheckoutQuestion, error)
+	Delete(id, eventID int) error
+	SaveAnswers(answers []*models.TicketAnswer) error
+	GetAnswersByEvent(eventID int) ([]*models.TicketAnswer, error)
+}
+
+// OrderTicketLister lists the tickets created for an order
+type OrderTicketLister interface {
+	GetTicketsByOrder(orderID int) ([]*models.Ticket, error)
+}
+
+// CheckoutAnswerRecorder stores the answers given at checkout with an
+// order's tickets
+type CheckoutAnswerRecorder interface {
+	RecordAnswers(orderID int, answers []models.AttendeeAnswers) error
+}
+
+// CheckoutQuestionService manages the custom questions organizers ask every
+// attendee at checkout. Events without questions do not ask any.
+type CheckoutQuestionService struct {
+	repo    CheckoutQuestionRepository
+	tickets OrderTicketLister
+}
+
+// NewCheckoutQuestionService creates a new checkout question service
+func NewCheckoutQuestionService(repo CheckoutQuestionRepository, tickets OrderTicketLister) *CheckoutQuestionService {
+	return &CheckoutQuestionService{repo: repo, tickets: tickets}
+}
+
+// GetQuestions returns an event's checkout questions in the order they are asked
+func (s *CheckoutQuestionService) GetQuestions(eventID int) ([]*models.CheckoutQuestion, error) {
+	questions, err := s.repo.GetByEvent(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checkout questions: %w", err)
+	}
+	return questions, nil
+}
+
+// AddQuestion validates and adds a checkout question to an event
+func (s *CheckoutQuestionService) AddQuestion(req *models.CheckoutQuestionCreateRequest) (*models.CheckoutQuestion, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	questions, err := s.GetQuestions(req.EventID)
+	if err != nil {
+		return nil, err
+	}
+	if len(questions) >= models.MaxCheckoutQuestions {
+		return nil, fmt.Errorf("events can ask at most %d questions at checkout", models.MaxCheckoutQuestions)
+	}
+
+	question, err := s.repo.Create(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to add checkout question: %w", err)
+	}
+	return question, nil
+}
+
+// DeleteQuestion removes a checkout question and the answers given to it
+func (s *CheckoutQuestionService) DeleteQuestion(eventID, questionID int) error {
+	if err := s.repo.Delete(questionID, eventID); err != nil {
+		return fmt.Errorf("failed to delete checkout question: %w", err)
+	}
+	return nil
+}
+
+// RecordAnswers stores each attendee's answers with their ticket. Attendees
+// are in the order of the cart, which is the order the tickets were created in.
+func (s *CheckoutQuestionService) RecordAnswers(orderID int, answers []models.AttendeeAnswers) error {
+	if len(answers) == 0 {
+		return nil
+	}
+
+	tickets, err := s.tickets.GetTicketsByOrder(orderID)
+	if err != nil {
+		return fmt.Errorf("failed to get order tickets: %w", err)
+	}
+	if len(tickets) != len(answers) {
+		return errors.New("the number of attendees does not match the order's tickets")
+	}
+	sort.Slice(tickets, func(i, j int) bool { return tickets[i].ID < tickets[j].ID })
+
+	var ticketAnswers []*models.TicketAnswer
+	for i, ticket := range tickets {
+		for questionID, answer := range answers[i] {
+			if answer == "" {
+				continue
+			}
+			ticketAnswers = append(ticketAnswers, &models.TicketAnswer{TicketID: ticket.ID, QuestionID: questionID, Answer: answer})
+		}
+	}
+	if len(ticketAnswers) == 0 {
+		return nil
+	}
+
+	if err := s.repo.SaveAnswers(ticketAnswers); err != nil {
+		return fmt.Errorf("failed to record checkout answers: %w", err)
+	}
+	return nil
+}
+
+// GetAnswersByOrder returns the answers an event's attendees gave, grouped
+// by order and then by question, in ticket order
+func (s *CheckoutQuestionService) GetAnswersByOrder(eventID int) (map[int]map[int][]string, error) {
+	answers, err := s.repo.GetAnswersByEvent(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get checkout answers: %w", err)
+	}
+
+	byOrder := make(map[int]map[int][]string)
+	for _, answer := range answers {
+		if byOrder[answer.OrderID] == nil {
+			byOrder[answer.OrderID] = make(map[int][]string)
+		}
+		byOrder[answer.OrderID][answer.QuestionID] = append(byOrder[answer.OrderID][answer.QuestionID], answer.Answer)
+	}
+	return byOrder, nil
+}
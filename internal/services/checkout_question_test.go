@@ -0,0 +1,132 @@
+package services
+
+import (
+	"strings"
+	"testing"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock CheckoutQuestionRepository for testing
+type mockCheckoutQuestionRepository struct {
+	questions []*models.CheckoutQuestion
+	answers   []*models.TicketAnswer
+	nextID    int
+}
+
+func (m *mockCheckoutQuestionRepository) Create(req *models.CheckoutQuestionCreateRequest) (*models.CheckoutQuestion, error) {
+	m.nextID++
+	question := &models.CheckoutQuestion{
+		ID:       m.nextID,
+		EventID:  req.EventID,
+		Label:    req.Label,
+		Type:     req.Type,
+		Options:  req.Options,
+		Required: req.Required,
+		Position: m.nextID,
+	}
+	m.questions = append(m.questions, question)
+	return question, nil
+}
+
+func (m *mockCheckoutQuestionRepository) GetByEvent(eventID int) ([]*models.CheckoutQuestion, error) {
+	var result []*models.CheckoutQuestion
+	for _, question := range m.questions {
+		if question.EventID == eventID {
+			result = append(result, question)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockCheckoutQuestionRepository) Delete(id, eventID int) error {
+	return nil
+}
+
+func (m *mockCheckoutQuestionRepository) SaveAnswers(answers []*models.TicketAnswer) error {
+	m.answers = append(m.answers, answers...)
+	return nil
+}
+
+func (m *mockCheckoutQuestionRepository) GetAnswersByEvent(eventID int) ([]*models.TicketAnswer, error) {
+	return m.answers, nil
+}
+
+// Mock OrderTicketLister for testing
+type mockOrderTicketLister struct {
+	tickets map[int][]*models.Ticket
+}
+
+func (m *mockOrderTicketLister) GetTicketsByOrder(orderID int) ([]*models.Ticket, error) {
+	return m.tickets[orderID], nil
+}
+
+func TestCheckoutQuestionService_AddQuestionLimit(t *testing.T) {
+	service := NewCheckoutQuestionService(&mockCheckoutQuestionRepository{}, &mockOrderTicketLister{})
+
+	for i := 0; i < models.MaxCheckoutQuestions; i++ {
+		if _, err := service.AddQuestion(&models.CheckoutQuestionCreateRequest{EventID: 1, Label: "Company", Type: models.QuestionTypeText}); err != nil {
+			t.Fatalf("unexpected error adding question %d: %v", i+1, err)
+		}
+	}
+
+	_, err := service.AddQuestion(&models.CheckoutQuestionCreateRequest{EventID: 1, Label: "One more", Type: models.QuestionTypeText})
+	if err == nil || !strings.Contains(err.Error(), "at most") {
+		t.Errorf("expected the question limit to be enforced, got %v", err)
+	}
+
+	if _, err := service.AddQuestion(&models.CheckoutQuestionCreateRequest{EventID: 2, Label: "Company", Type: models.QuestionTypeText}); err != nil {
+		t.Errorf("expected the limit to be per event, got %v", err)
+	}
+}
+
+func TestCheckoutQuestionService_RecordAnswers(t *testing.T) {
+	repo := &mockCheckoutQuestionRepository{}
+	tickets := &mockOrderTicketLister{tickets: map[int][]*models.Ticket{
+		7: {{ID: 12, OrderID: 7}, {ID: 11, OrderID: 7}},
+	}}
+	service := NewCheckoutQuestionService(repo, tickets)
+
+	answers := []models.AttendeeAnswers{
+		{1: "M", 2: ""},
+		{1: "L", 2: "Vegan"},
+	}
+	if err := service.RecordAnswers(7, answers); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	got := map[int]map[int]string{}
+	for _, answer := range repo.answers {
+		if got[answer.TicketID] == nil {
+			got[answer.TicketID] = map[int]string{}
+		}
+		got[answer.TicketID][answer.QuestionID] = answer.Answer
+	}
+	if len(repo.answers) != 3 {
+		t.Errorf("expected blank answers to be skipped, got %d answers", len(repo.answers))
+	}
+	if got[11][1] != "M" || got[12][1] != "L" || got[12][2] != "Vegan" {
+		t.Errorf("expected answers to follow ticket order, got %v", got)
+	}
+
+	if err := service.RecordAnswers(7, answers[:1]); err == nil {
+		t.Error("expected an error when attendees do not match the order's tickets")
+	}
+}
+
+func TestCheckoutQuestionService_GetAnswersByOrder(t *testing.T) {
+	repo := &mockCheckoutQuestionRepository{answers: []*models.TicketAnswer{
+		{TicketID: 1, QuestionID: 1, Answer: "M", OrderID: 5},
+		{TicketID: 2, QuestionID: 1, Answer: "L", OrderID: 5},
+		{TicketID: 3, QuestionID: 1, Answer: "S", OrderID: 6},
+	}}
+	service := NewCheckoutQuestionService(repo, &mockOrderTicketLister{})
+
+	byOrder, err := service.GetAnswersByOrder(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Join(byOrder[5][1], ",") != "M,L" || strings.Join(byOrder[6][1], ",") != "S" {
+		t.Errorf("expected answers grouped by order, got %v", byOrder)
+	}
+}
@@ -0,0 +1,192 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/repositories"
+)
+
+// CityRepository interface for city aggregation over events
+type CityRepository interface {
+	GetCities(limit int) ([]*models.City, error)
+	GetCityBySlug(slug string) (*models.City, error)
+	Search(filters repositories.EventSearchFilters) ([]*models.Event, int, error)
+	GetCategories() ([]*models.Category, error)
+}
+
+// CityPageData represents the data for a city landing page
+type CityPageData struct {
+	City            *models.City       `json:"city"`
+	Events          []*models.Event    `json:"events"`
+	Categories      []*models.Category `json:"categories"`
+	CategorySlug    string             `json:"category_slug"` // Active category filter, empty for all
+	Page            int                `json:"page"`
+	TotalPages      int                `json:"total_pages"`
+	Total           int                `json:"total"`
+	MetaTitle       string             `json:"meta_title"`
+	MetaDescription string             `json:"meta_description"`
+	CanonicalURL    string             `json:"canonical_url"`
+}
+
+// CityRegion is a region and its cities with upcoming events, for the
+// cities directory
+type CityRegion struct {
+	Name       string         `json:"name"`
+	Cities     []*models.City `json:"cities"`
+	EventCount int            `json:"event_count"`
+}
+
+// CityService handles city landing pages
+type CityService struct {
+	cityRepo CityRepository
+	baseURL  string
+}
+
+// NewCityService creates a new city service
+func NewCityService(cityRepo CityRepository, baseURL string) *CityService {
+	return &CityService{
+		cityRepo: cityRepo,
+		baseURL:  baseURL,
+	}
+}
+
+// GetCities retrieves cities with upcoming published events
+func (s *CityService) GetCities(limit int) ([]*models.City, error) {
+	if limit <= 0 {
+		limit = 100
+	}
+	cities, err := s.cityRepo.GetCities(limit)
+	if err != nil {
+		return nil, err
+	}
+	for _, city := range cities {
+		setCityRegion(city)
+	}
+	return cities, nil
+}
+
+// GetCityDirectory groups the cities with upcoming published events by
+// region, the regions with the most events first and cities outside the
+// places dataset last
+func (s *CityService) GetCityDirectory() ([]*CityRegion, error) {
+	cities, err := s.GetCities(0)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cities: %w", err)
+	}
+
+	var regions []*CityRegion
+	byName := map[string]*CityRegion{}
+	for _, city := range cities {
+		name := city.Region
+		if name == "" {
+			name = models.OtherRegion
+		}
+		region, ok := byName[name]
+		if !ok {
+			region = &CityRegion{Name: name}
+			byName[name] = region
+			regions = append(regions, region)
+		}
+		region.Cities = append(region.Cities, city)
+		region.EventCount += city.EventCount
+	}
+
+	sort.SliceStable(regions, func(i, j int) bool {
+		if (regions[i].Name == models.OtherRegion) != (regions[j].Name == models.OtherRegion) {
+			return regions[j].Name == models.OtherRegion
+		}
+		if regions[i].EventCount != regions[j].EventCount {
+			return regions[i].EventCount > regions[j].EventCount
+		}
+		return regions[i].Name < regions[j].Name
+	})
+	return regions, nil
+}
+
+// setCityRegion sets the region of a city in the places dataset
+func setCityRegion(city *models.City) {
+	if place, ok := models.FindPlace(city.Slug); ok {
+		city.Region = place.Region
+	}
+}
+
+// GetCityPage builds the landing page for a city, optionally filtered by category slug
+func (s *CityService) GetCityPage(slug, categorySlug string, page, pageSize int) (*CityPageData, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 12
+	}
+
+	city, err := s.cityRepo.GetCityBySlug(slug)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get city: %w", err)
+	}
+	setCityRegion(city)
+
+	categories, err := s.cityRepo.GetCategories()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get categories: %w", err)
+	}
+
+	now := time.Now()
+	filters := repositories.EventSearchFilters{
+		Status:   models.StatusPublished,
+		CitySlug: city.Slug,
+		DateFrom: &now,
+		Limit:    pageSize,
+		Offset:   (page - 1) * pageSize,
+		SortBy:   "start_date",
+	}
+
+	// Resolve the category filter; unknown slugs fall back to all categories
+	var activeCategory *models.Category
+	for _, category := range categories {
+		if categorySlug != "" && category.Slug == categorySlug {
+			activeCategory = category
+			filters.CategoryID = category.ID
+			break
+		}
+	}
+
+	events, total, err := s.cityRepo.Search(filters)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get city events: %w", err)
+	}
+
+	data := &CityPageData{
+		City:         city,
+		Events:       events,
+		Categories:   categories,
+		Page:         page,
+		Total:        total,
+		TotalPages:   (total + pageSize - 1) / pageSize,
+		CanonicalURL: s.CityURL(city.Slug),
+	}
+
+	if activeCategory != nil {
+		data.CategorySlug = activeCategory.Slug
+		data.MetaTitle = fmt.Sprintf("%s events in %s", activeCategory.Name, city.Name)
+		data.MetaDescription = fmt.Sprintf("Discover %d upcoming %s events in %s. Browse dates, venues and buy tickets on Runtown.", total, activeCategory.Name, city.Name)
+		data.CanonicalURL = fmt.Sprintf("%s?category=%s", data.CanonicalURL, activeCategory.Slug)
+	} else {
+		data.MetaTitle = fmt.Sprintf("Events in %s", city.Name)
+		data.MetaDescription = fmt.Sprintf("Discover %d upcoming events in %s. Browse concerts, conferences, sports and more, and buy tickets on Runtown.", city.EventCount, city.Name)
+	}
+
+	return data, nil
+}
+
+// CityURL returns the absolute URL of a city landing page
+func (s *CityService) CityURL(slug string) string {
+	return fmt.Sprintf("%s/events/%s", s.baseURL, slug)
+}
+
+// DirectoryURL returns the absolute URL of the cities directory
+func (s *CityService) DirectoryURL() string {
+	return s.baseURL + "/cities"
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/repositories"
+)
+
+// Mock CityRepository for testing
+type mockCityRepository struct {
+	cities      map[string]*models.City
+	events      []*models.Event
+	categories  []*models.Category
+	lastFilters repositories.EventSearchFilters
+}
+
+func (m *mockCityRepository) GetCities(limit int) ([]*models.City, error) {
+	var cities []*models.City
+	for _, city := range m.cities {
+		cities = append(cities, city)
+	}
+	return cities, nil
+}
+
+func (m *mockCityRepository) GetCityBySlug(slug string) (*models.City, error) {
+	city, exists := m.cities[slug]
+	if !exists {
+		return nil, fmt.Errorf("city %s not found", slug)
+	}
+	return city, nil
+}
+
+func (m *mockCityRepository) Search(filters repositories.EventSearchFilters) ([]*models.Event, int, error) {
+	m.lastFilters = filters
+	return m.events, len(m.events), nil
+}
+
+func (m *mockCityRepository) GetCategories() ([]*models.Category, error) {
+	return m.categories, nil
+}
+
+func TestCityService_GetCityPage(t *testing.T) {
+	repo := &mockCityRepository{
+		cities: map[string]*models.City{
+			"nairobi": {Slug: "nairobi", Name: "Nairobi", EventCount: 3},
+		},
+		events: []*models.Event{
+			{ID: 1, Title: "Jazz Night", Location: "Alliance Francaise, Nairobi"},
+		},
+		categories: []*models.Category{
+			{ID: 1, Name: "Music", Slug: "music"},
+			{ID: 2, Name: "Technology", Slug: "technology"},
+		},
+	}
+	service := NewCityService(repo, "https://runtown.example")
+
+	t.Run("all categories", func(t *testing.T) {
+		data, err := service.GetCityPage("nairobi", "", 1, 12)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if repo.lastFilters.CitySlug != "nairobi" || repo.lastFilters.Status != models.StatusPublished || repo.lastFilters.CategoryID != 0 {
+			t.Errorf("unexpected search filters %+v", repo.lastFilters)
+		}
+		if data.MetaTitle != "Events in Nairobi" {
+			t.Errorf("unexpected meta title %q", data.MetaTitle)
+		}
+		if data.City.Region != "Nairobi" {
+			t.Errorf("expected the city's region to be set, got %q", data.City.Region)
+		}
+		if data.CanonicalURL != "https://runtown.example/events/nairobi" {
+			t.Errorf("unexpected canonical URL %q", data.CanonicalURL)
+		}
+		if !strings.Contains(data.MetaDescription, "3 upcoming events in Nairobi") {
+			t.Errorf("unexpected meta description %q", data.MetaDescription)
+		}
+	})
+
+	t.Run("category filter", func(t *testing.T) {
+		data, err := service.GetCityPage("nairobi", "music", 2, 12)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if repo.lastFilters.CategoryID != 1 || repo.lastFilters.Offset != 12 {
+			t.Errorf("unexpected search filters %+v", repo.lastFilters)
+		}
+		if data.CategorySlug != "music" || data.MetaTitle != "Music events in Nairobi" {
+			t.Errorf("unexpected category page data %+v", data)
+		}
+		if data.CanonicalURL != "https://runtown.example/events/nairobi?category=music" {
+			t.Errorf("unexpected canonical URL %q", data.CanonicalURL)
+		}
+	})
+
+	t.Run("unknown category falls back to all", func(t *testing.T) {
+		data, err := service.GetCityPage("nairobi", "unknown", 1, 12)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if data.CategorySlug != "" || repo.lastFilters.CategoryID != 0 {
+			t.Errorf("expected unknown category to be ignored")
+		}
+	})
+
+	t.Run("unknown city", func(t *testing.T) {
+		_, err := service.GetCityPage("atlantis", "", 1, 12)
+		if err == nil || !strings.Contains(err.Error(), "not found") {
+			t.Errorf("expected not found error, got %v", err)
+		}
+	})
+}
+
+func TestCityService_GetCityDirectory(t *testing.T) {
+	repo := &mockCityRepository{
+		cities: map[string]*models.City{
+			"nairobi":  {Slug: "nairobi", Name: "Nairobi", EventCount: 3},
+			"naivasha": {Slug: "naivasha", Name: "Naivasha", EventCount: 2},
+			"nakuru":   {Slug: "nakuru", Name: "Nakuru", EventCount: 2},
+			"atlantis": {Slug: "atlantis", Name: "Atlantis", EventCount: 9},
+		},
+	}
+	service := NewCityService(repo, "https://runtown.example")
+
+	regions, err := service.GetCityDirectory()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(regions) != 3 {
+		t.Fatalf("expected 3 regions, got %d", len(regions))
+	}
+	if regions[0].Name != "Nakuru" || regions[0].EventCount != 4 || len(regions[0].Cities) != 2 {
+		t.Errorf("unexpected first region %+v", regions[0])
+	}
+	if regions[1].Name != "Nairobi" {
+		t.Errorf("unexpected second region %+v", regions[1])
+	}
+	// Cities outside the places dataset are listed last
+	if regions[2].Name != models.OtherRegion || regions[2].Cities[0].Slug != "atlantis" {
+		t.Errorf("unexpected last region %+v", regions[2])
+	}
+}
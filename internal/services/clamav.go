@@ -0,0 +1,118 @@
+package services
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"net"
+	"strings"
+	"time"
+)
+
+// clamAVChunkSize is how much of a file is sent to clamd in each INSTREAM chunk
+const clamAVChunkSize = 64 << 10
+
+// ClamAVScanner scans uploads with a clamd daemon, streaming each file over
+// the INSTREAM command. It implements UploadScanner.
+type ClamAVScanner struct {
+	network string
+	address string
+	timeout time.Duration
+}
+
+// NewClamAVScanner creates a scanner for the clamd daemon at address, either
+// host:port or unix:/path/to/clamd.sock
+func NewClamAVScanner(address string, timeout time.Duration) *ClamAVScanner {
+	network := "tcp"
+	if path, ok := strings.CutPrefix(address, "unix:"); ok {
+		network, address = "unix", path
+	}
+	return &ClamAVScanner{network: network, address: address, timeout: timeout}
+}
+
+// Scan streams the file to clamd and reports any signature it matches
+func (c *ClamAVScanner) Scan(ctx context.Context, data []byte) error {
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("zINSTREAM\x00")); err != nil {
+		return fmt.Errorf("failed to start clamd scan: %w", err)
+	}
+	size := make([]byte, 4)
+	for len(data) > 0 {
+		chunk := data[:min(len(data), clamAVChunkSize)]
+		data = data[len(chunk):]
+		binary.BigEndian.PutUint32(size, uint32(len(chunk)))
+		if _, err := conn.Write(size); err != nil {
+			return fmt.Errorf("failed to stream file to clamd: %w", err)
+		}
+		if _, err := conn.Write(chunk); err != nil {
+			return fmt.Errorf("failed to stream file to clamd: %w", err)
+		}
+	}
+	if _, err := conn.Write([]byte{0, 0, 0, 0}); err != nil {
+		return fmt.Errorf("failed to stream file to clamd: %w", err)
+	}
+
+	reply, err := readClamAVReply(conn)
+	if err != nil {
+		return err
+	}
+	switch {
+	case strings.HasSuffix(reply, " OK"):
+		return nil
+	case strings.HasSuffix(reply, " FOUND"):
+		signature := strings.TrimSuffix(strings.TrimPrefix(reply, "stream: "), " FOUND")
+		return fmt.Errorf("%w (%s)", ErrMalwareDetected, signature)
+	default:
+		return fmt.Errorf("clamd scan failed: %s", reply)
+	}
+}
+
+// Ping checks that clamd is reachable
+func (c *ClamAVScanner) Ping(ctx context.Context) error {
+	conn, err := c.dial(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write([]byte("zPING\x00")); err != nil {
+		return fmt.Errorf("failed to ping clamd: %w", err)
+	}
+	reply, err := readClamAVReply(conn)
+	if err != nil {
+		return err
+	}
+	if reply != "PONG" {
+		return fmt.Errorf("unexpected clamd reply to ping: %s", reply)
+	}
+	return nil
+}
+
+func (c *ClamAVScanner) dial(ctx context.Context) (net.Conn, error) {
+	dialer := &net.Dialer{Timeout: c.timeout}
+	conn, err := dialer.DialContext(ctx, c.network, c.address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to clamd: %w", err)
+	}
+	deadline := time.Now().Add(c.timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+	conn.SetDeadline(deadline)
+	return conn, nil
+}
+
+// readClamAVReply reads clamd's null-terminated reply
+func readClamAVReply(conn net.Conn) (string, error) {
+	reply, err := io.ReadAll(io.LimitReader(conn, 1024))
+	if err != nil {
+		return "", fmt.Errorf("failed to read clamd reply: %w", err)
+	}
+	return strings.TrimRight(string(reply), "\x00\n"), nil
+}
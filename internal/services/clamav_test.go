@@ -0,0 +1,105 @@
+package services
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"io"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// startFakeClamd serves the zPING and zINSTREAM commands, reporting streams
+// containing the EICAR marker as infected
+func startFakeClamd(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	t.Cleanup(func() { listener.Close() })
+
+	go func() {
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			go serveFakeClamd(conn)
+		}
+	}()
+
+	return listener.Addr().String()
+}
+
+func serveFakeClamd(conn net.Conn) {
+	defer conn.Close()
+	reader := bufio.NewReader(conn)
+
+	command, err := reader.ReadString(0)
+	if err != nil {
+		return
+	}
+	switch command {
+	case "zPING\x00":
+		conn.Write([]byte("PONG\x00"))
+	case "zINSTREAM\x00":
+		var stream bytes.Buffer
+		size := make([]byte, 4)
+		for {
+			if _, err := io.ReadFull(reader, size); err != nil {
+				return
+			}
+			n := binary.BigEndian.Uint32(size)
+			if n == 0 {
+				break
+			}
+			if _, err := io.CopyN(&stream, reader, int64(n)); err != nil {
+				return
+			}
+		}
+		if bytes.Contains(stream.Bytes(), []byte("EICAR-STANDARD-ANTIVIRUS-TEST-FILE")) {
+			conn.Write([]byte("stream: Eicar-Test-Signature FOUND\x00"))
+		} else {
+			conn.Write([]byte("stream: OK\x00"))
+		}
+	default:
+		conn.Write([]byte("UNKNOWN COMMAND\x00"))
+	}
+}
+
+func TestClamAVScanner(t *testing.T) {
+	scanner := NewClamAVScanner(startFakeClamd(t), 5*time.Second)
+	ctx := context.Background()
+
+	require.NoError(t, scanner.Ping(ctx))
+
+	// Larger than one chunk, so the file is streamed in several
+	clean := bytes.Repeat(createTestJPEG(50, 50), 100)
+	assert.NoError(t, scanner.Scan(ctx, clean))
+
+	infected := append(createTestJPEG(10, 10), []byte("X5O!P%@AP[4\\PZX54(P^)7CC)7}$EICAR-STANDARD-ANTIVIRUS-TEST-FILE!$H+H*")...)
+	err := scanner.Scan(ctx, infected)
+	assert.ErrorIs(t, err, ErrMalwareDetected)
+	assert.Contains(t, err.Error(), "Eicar-Test-Signature")
+}
+
+func TestClamAVScanner_Unreachable(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	address := listener.Addr().String()
+	listener.Close()
+
+	err = NewClamAVScanner(address, time.Second).Scan(context.Background(), []byte("data"))
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrMalwareDetected))
+}
+
+func TestNewClamAVScanner_UnixSocket(t *testing.T) {
+	scanner := NewClamAVScanner("unix:/var/run/clamav/clamd.ctl", time.Second)
+	assert.Equal(t, "unix", scanner.network)
+	assert.Equal(t, "/var/run/clamav/clamd.ctl", scanner.address)
+}
@@ -0,0 +1,460 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"html"
+	"image"
+	_ "image/jpeg"
+	_ "image/png"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// ContentFlagRepository defines the data operations for content screening flags
+type ContentFlagRepository interface {
+	Create(flag *models.EventContentFlag) error
+	GetLatestByEvents(eventIDs []int) (map[int]*models.EventContentFlag, error)
+}
+
+// ContentModerationClient classifies text with an external moderation service
+type ContentModerationClient interface {
+	Moderate(text string) (*ContentModerationResult, error)
+}
+
+// ImageModerationClient classifies an event's image, fetched from its URL
+type ImageModerationClient interface {
+	ModerateImage(imageURL string) (*ContentModerationResult, error)
+}
+
+// ContentModerationResult is an external moderation service's verdict
+type ContentModerationResult struct {
+	Flagged    bool
+	Categories []string
+}
+
+// contentFlagThreshold is the score at which content is held for review
+const contentFlagThreshold = 3
+
+// ContentScreenResult is the outcome of screening event content
+type ContentScreenResult struct {
+	Score   int      `json:"score"`
+	Reasons []string `json:"reasons"`
+}
+
+// Suspicious returns true if the content should be reviewed before publishing
+func (r *ContentScreenResult) Suspicious() bool {
+	return r.Score >= contentFlagThreshold
+}
+
+func (r *ContentScreenResult) add(score int, reason string) {
+	r.Score += score
+	r.Reasons = append(r.Reasons, reason)
+}
+
+var (
+	urlShortenerPattern = regexp.MustCompile(`(?i)\b(bit\.ly|tinyurl\.com|t\.co|goo\.gl|ow\.ly|is\.gd|buff\.ly|cutt\.ly|rebrand\.ly|shorturl\.at|tiny\.cc|rb\.gy|t\.ly)/\S+`)
+	phoneNumberPattern  = regexp.MustCompile(`\+?\d[\d\s().-]{7,}\d`)
+	messagingAppPattern = regexp.MustCompile(`(?i)\b(whats\s?app|telegram|signal)\b`)
+)
+
+// scamPhrases are phrases common in crypto scams and phishing, in lowercase
+var scamPhrases = []string{
+	"double your bitcoin",
+	"double your crypto",
+	"double your money",
+	"send btc",
+	"send usdt",
+	"send eth",
+	"guaranteed returns",
+	"guaranteed profit",
+	"100% profit",
+	"risk-free investment",
+	"investment opportunity",
+	"crypto giveaway",
+	"free airdrop",
+	"wallet address",
+	"seed phrase",
+	"recovery phrase",
+	"forex signals",
+	"binary options",
+	"verify your account",
+	"confirm your password",
+	"claim your prize",
+	"you have won",
+}
+
+// ContentScreenService screens event content for spam and phishing with
+// built-in rules and, when configured, an external moderation API
+type ContentScreenService struct {
+	flagRepo         ContentFlagRepository
+	moderationClient ContentModerationClient
+	imageClient      ImageModerationClient
+	blockedKeywords  []string
+}
+
+// NewContentScreenService creates a new content screening service
+func NewContentScreenService(flagRepo ContentFlagRepository) *ContentScreenService {
+	return &ContentScreenService{
+		flagRepo: flagRepo,
+	}
+}
+
+// SetModerationClient sets the external moderation API to consult after the
+// built-in rules
+func (s *ContentScreenService) SetModerationClient(client ContentModerationClient) {
+	s.moderationClient = client
+}
+
+// SetImageModerationClient enables screening of event images, with either
+// the external moderation API or the built-in skin tone heuristic
+func (s *ContentScreenService) SetImageModerationClient(client ImageModerationClient) {
+	s.imageClient = client
+}
+
+// SetBlockedKeywords sets words and phrases that always hold an event for
+// review, on top of the built-in scam phrases
+func (s *ContentScreenService) SetBlockedKeywords(keywords []string) {
+	s.blockedKeywords = nil
+	for _, keyword := range keywords {
+		if keyword = strings.ToLower(strings.TrimSpace(keyword)); keyword != "" {
+			s.blockedKeywords = append(s.blockedKeywords, keyword)
+		}
+	}
+}
+
+// ScreenEvent scores an event's title, description and, when image
+// screening is enabled, its image
+func (s *ContentScreenService) ScreenEvent(title, description, imageURL string) *ContentScreenResult {
+	result := s.Screen(title, description)
+	if s.imageClient == nil || imageURL == "" {
+		return result
+	}
+
+	moderation, err := s.imageClient.ModerateImage(imageURL)
+	if err != nil {
+		fmt.Printf("Warning: image moderation failed: %v\n", err)
+	} else if moderation.Flagged {
+		reason := "Event image flagged by image screening"
+		if len(moderation.Categories) > 0 {
+			reason += " (" + strings.Join(moderation.Categories, ", ") + ")"
+		}
+		result.add(contentFlagThreshold, reason)
+	}
+	return result
+}
+
+// Screen scores an event's title and description. The external moderation
+// API is best effort: if it fails, the rules alone decide.
+func (s *ContentScreenService) Screen(title, description string) *ContentScreenResult {
+	text := title + "\n" + html.UnescapeString(plainText(description))
+	result := screenContentRules(text)
+
+	lower := strings.ToLower(text)
+	for _, keyword := range s.blockedKeywords {
+		if strings.Contains(lower, keyword) {
+			result.add(contentFlagThreshold, fmt.Sprintf("Contains the blocked keyword %q", keyword))
+		}
+	}
+
+	if s.moderationClient != nil {
+		moderation, err := s.moderationClient.Moderate(text)
+		if err != nil {
+			fmt.Printf("Warning: content moderation API failed: %v\n", err)
+		} else if moderation.Flagged {
+			reason := "Flagged by the content moderation service"
+			if len(moderation.Categories) > 0 {
+				reason += " (" + strings.Join(moderation.Categories, ", ") + ")"
+			}
+			result.add(contentFlagThreshold, reason)
+		}
+	}
+
+	return result
+}
+
+// RecordFlag stores why an event was held for review
+func (s *ContentScreenService) RecordFlag(eventID int, result *ContentScreenResult) error {
+	flag := &models.EventContentFlag{
+		EventID: eventID,
+		Score:   result.Score,
+		Reasons: result.Reasons,
+	}
+	if err := s.flagRepo.Create(flag); err != nil {
+		return fmt.Errorf("failed to record content flag: %w", err)
+	}
+	return nil
+}
+
+// GetFlags returns the latest content flag of each given event that has one
+func (s *ContentScreenService) GetFlags(eventIDs []int) (map[int]*models.EventContentFlag, error) {
+	flags, err := s.flagRepo.GetLatestByEvents(eventIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get content flags: %w", err)
+	}
+	return flags, nil
+}
+
+// screenContentRules applies the built-in spam and phishing rules to plain text
+func screenContentRules(text string) *ContentScreenResult {
+	result := &ContentScreenResult{Reasons: []string{}}
+
+	shorteners := map[string]bool{}
+	for _, match := range urlShortenerPattern.FindAllStringSubmatch(text, -1) {
+		shorteners[strings.ToLower(match[1])] = true
+	}
+	if len(shorteners) > 0 {
+		result.add(3, "Links through a URL shortener ("+strings.Join(sortedKeys(shorteners), ", ")+")")
+	}
+
+	lower := strings.ToLower(text)
+	for _, phrase := range scamPhrases {
+		if strings.Contains(lower, phrase) {
+			result.add(3, fmt.Sprintf("Contains the phrase %q", phrase))
+		}
+	}
+
+	var letters, upper int
+	for _, r := range text {
+		if unicode.IsLetter(r) {
+			letters++
+			if unicode.IsUpper(r) {
+				upper++
+			}
+		}
+	}
+	if letters >= 40 && upper*10 > letters*6 {
+		result.add(2, fmt.Sprintf("Mostly capital letters (%d%%)", upper*100/letters))
+	}
+
+	phoneNumbers := map[string]bool{}
+	for _, match := range phoneNumberPattern.FindAllString(text, -1) {
+		digits := strings.Map(func(r rune) rune {
+			if unicode.IsDigit(r) {
+				return r
+			}
+			return -1
+		}, match)
+		if len(digits) >= 9 && len(digits) <= 15 {
+			phoneNumbers[digits] = true
+		}
+	}
+	if len(phoneNumbers) >= 3 {
+		result.add(3, fmt.Sprintf("Lists %d phone numbers", len(phoneNumbers)))
+	}
+	if len(phoneNumbers) > 0 && messagingAppPattern.MatchString(text) {
+		result.add(2, "Asks attendees to get in touch through a messaging app")
+	}
+
+	return result
+}
+
+func sortedKeys(set map[string]bool) []string {
+	keys := make([]string, 0, len(set))
+	for key := range set {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// HTTPContentModerationClient calls a moderation API that follows the
+// request and response format of OpenAI's moderation endpoint
+type HTTPContentModerationClient struct {
+	apiURL     string
+	apiKey     string
+	model      string
+	httpClient *http.Client
+}
+
+// NewHTTPContentModerationClient creates a new moderation API client
+func NewHTTPContentModerationClient(apiURL, apiKey, model string) *HTTPContentModerationClient {
+	return &HTTPContentModerationClient{
+		apiURL:     apiURL,
+		apiKey:     apiKey,
+		model:      model,
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Moderate classifies text, returning the categories it was flagged for
+func (c *HTTPContentModerationClient) Moderate(text string) (*ContentModerationResult, error) {
+	return c.moderate(text)
+}
+
+// ModerateImage classifies the image at a public URL, returning the
+// categories it was flagged for. The API must accept image inputs.
+func (c *HTTPContentModerationClient) ModerateImage(imageURL string) (*ContentModerationResult, error) {
+	return c.moderate([]map[string]interface{}{
+		{"type": "image_url", "image_url": map[string]string{"url": imageURL}},
+	})
+}
+
+// moderate sends text or a list of inputs to the moderation API
+func (c *HTTPContentModerationClient) moderate(input interface{}) (*ContentModerationResult, error) {
+	payload := map[string]interface{}{"input": input}
+	if c.model != "" {
+		payload["model"] = c.model
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode moderation request: %w", err)
+	}
+
+	req, err := http.NewRequest(http.MethodPost, c.apiURL, bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create moderation request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if c.apiKey != "" {
+		req.Header.Set("Authorization", "Bearer "+c.apiKey)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("moderation request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("moderation API returned status %d", resp.StatusCode)
+	}
+
+	var response struct {
+		Results []struct {
+			Flagged    bool            `json:"flagged"`
+			Categories map[string]bool `json:"categories"`
+		} `json:"results"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode moderation response: %w", err)
+	}
+
+	result := &ContentModerationResult{}
+	categories := map[string]bool{}
+	for _, r := range response.Results {
+		if r.Flagged {
+			result.Flagged = true
+		}
+		for category, flagged := range r.Categories {
+			if flagged {
+				categories[category] = true
+			}
+		}
+	}
+	result.Categories = sortedKeys(categories)
+
+	return result, nil
+}
+
+// skinToneFlagRatio is the share of an image's pixels in skin tones at which
+// the heuristic flags it
+const skinToneFlagRatio = 0.6
+
+// maxScreenedImageSize is the largest image the heuristic downloads
+const maxScreenedImageSize = 10 << 20
+
+// SkinToneImageClassifier flags images that are mostly skin tones, a rough
+// local stand-in for a moderation API that catches most explicit images but
+// also close-up portraits, which a moderator then approves
+type SkinToneImageClassifier struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewSkinToneImageClassifier creates a new skin tone heuristic. Relative
+// image URLs, as served by local storage, are resolved against baseURL.
+func NewSkinToneImageClassifier(baseURL string) *SkinToneImageClassifier {
+	return &SkinToneImageClassifier{
+		baseURL:    baseURL,
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// ModerateImage downloads the image and measures how much of it is skin tones
+func (c *SkinToneImageClassifier) ModerateImage(imageURL string) (*ContentModerationResult, error) {
+	resolved, err := url.Parse(imageURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid image URL: %w", err)
+	}
+	if !resolved.IsAbs() {
+		base, err := url.Parse(c.baseURL)
+		if err != nil {
+			return nil, fmt.Errorf("invalid base URL: %w", err)
+		}
+		resolved = base.ResolveReference(resolved)
+	}
+
+	resp, err := c.httpClient.Get(resolved.String())
+	if err != nil {
+		return nil, fmt.Errorf("failed to download image: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("image download returned status %d", resp.StatusCode)
+	}
+
+	img, _, err := image.Decode(io.LimitReader(resp.Body, maxScreenedImageSize))
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	ratio := skinToneRatio(img)
+	if ratio < skinToneFlagRatio {
+		return &ContentModerationResult{}, nil
+	}
+	return &ContentModerationResult{
+		Flagged:    true,
+		Categories: []string{fmt.Sprintf("%.0f%% skin tones", ratio*100)},
+	}, nil
+}
+
+// skinToneRatio returns the share of the image's pixels in skin tones,
+// sampling a grid of about 100 by 100 pixels
+func skinToneRatio(img image.Image) float64 {
+	bounds := img.Bounds()
+	stepX, stepY := bounds.Dx()/100, bounds.Dy()/100
+	if stepX < 1 {
+		stepX = 1
+	}
+	if stepY < 1 {
+		stepY = 1
+	}
+
+	var skin, total int
+	for y := bounds.Min.Y; y < bounds.Max.Y; y += stepY {
+		for x := bounds.Min.X; x < bounds.Max.X; x += stepX {
+			r, g, b, _ := img.At(x, y).RGBA()
+			if isSkinTone(int(r>>8), int(g>>8), int(b>>8)) {
+				skin++
+			}
+			total++
+		}
+	}
+	if total == 0 {
+		return 0
+	}
+	return float64(skin) / float64(total)
+}
+
+// isSkinTone applies the RGB skin colour rule of Peer et al. for daylight
+// images
+func isSkinTone(r, g, b int) bool {
+	lowest := g
+	if b < lowest {
+		lowest = b
+	}
+	// r is the highest channel when r > g and r > b
+	return r > 95 && g > 40 && b > 20 &&
+		r-lowest > 15 && r-g > 15 && r > b
+}
@@ -0,0 +1,297 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock ContentFlagRepository for testing
+type mockContentFlagRepository struct {
+	flags []*models.EventContentFlag
+}
+
+func (m *mockContentFlagRepository) Create(flag *models.EventContentFlag) error {
+	flag.ID = len(m.flags) + 1
+	m.flags = append(m.flags, flag)
+	return nil
+}
+
+func (m *mockContentFlagRepository) GetLatestByEvents(eventIDs []int) (map[int]*models.EventContentFlag, error) {
+	flags := make(map[int]*models.EventContentFlag)
+	for _, flag := range m.flags {
+		for _, eventID := range eventIDs {
+			if flag.EventID == eventID {
+				flags[eventID] = flag
+			}
+		}
+	}
+	return flags, nil
+}
+
+// mockContentModerationClient returns a fixed moderation verdict
+type mockContentModerationClient struct {
+	result *ContentModerationResult
+	err    error
+}
+
+func (m *mockContentModerationClient) Moderate(text string) (*ContentModerationResult, error) {
+	return m.result, m.err
+}
+
+func (m *mockContentModerationClient) ModerateImage(imageURL string) (*ContentModerationResult, error) {
+	return m.result, m.err
+}
+
+func TestContentScreenService_Screen(t *testing.T) {
+	service := NewContentScreenService(&mockContentFlagRepository{})
+
+	tests := []struct {
+		name        string
+		title       string
+		description string
+		suspicious  bool
+		reason      string
+	}{
+		{
+			name:        "ordinary event",
+			title:       "Nairobi Jazz Festival",
+			description: "<p>An evening of live jazz at Uhuru Gardens. Call +254 712 345 678 for group bookings.</p>",
+		},
+		{
+			name:        "URL shortener",
+			title:       "Free concert",
+			description: "Register at https://bit.ly/3xYzAbc",
+			suspicious:  true,
+			reason:      "URL shortener (bit.ly)",
+		},
+		{
+			name:        "crypto scam phrase",
+			title:       "Crypto masterclass",
+			description: "Learn how to <strong>double your bitcoin</strong> in a week.",
+			suspicious:  true,
+			reason:      `"double your bitcoin"`,
+		},
+		{
+			name:        "phone number farming",
+			title:       "Job fair",
+			description: "Call 0712345678, 0723456789 or 0734567890 to reserve a place.",
+			suspicious:  true,
+			reason:      "Lists 3 phone numbers",
+		},
+		{
+			name:        "excessive caps and messaging app",
+			title:       "BIGGEST PARTY OF THE YEAR",
+			description: "LIMITED SPOTS AVAILABLE BOOK NOW ON WHATSAPP +254 712 345 678",
+			suspicious:  true,
+			reason:      "Mostly capital letters",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := service.Screen(tt.title, tt.description)
+			if result.Suspicious() != tt.suspicious {
+				t.Fatalf("expected suspicious=%v, got score %d: %v", tt.suspicious, result.Score, result.Reasons)
+			}
+			if tt.reason != "" && !strings.Contains(strings.Join(result.Reasons, "\n"), tt.reason) {
+				t.Errorf("expected a reason containing %q, got %v", tt.reason, result.Reasons)
+			}
+		})
+	}
+}
+
+func TestContentScreenService_ModerationClient(t *testing.T) {
+	service := NewContentScreenService(&mockContentFlagRepository{})
+
+	service.SetModerationClient(&mockContentModerationClient{result: &ContentModerationResult{Flagged: true, Categories: []string{"harassment"}}})
+	result := service.Screen("Community meetup", "Meet the neighbours")
+	if !result.Suspicious() || !strings.Contains(result.Reasons[0], "harassment") {
+		t.Errorf("expected content flagged by the moderation API to be suspicious, got %+v", result)
+	}
+
+	service.SetModerationClient(&mockContentModerationClient{err: errors.New("connection refused")})
+	if result := service.Screen("Community meetup", "Meet the neighbours"); result.Suspicious() {
+		t.Errorf("expected the rules alone to decide when the moderation API fails, got %+v", result)
+	}
+}
+
+func TestContentScreenService_BlockedKeywords(t *testing.T) {
+	service := NewContentScreenService(&mockContentFlagRepository{})
+	service.SetBlockedKeywords([]string{" Escort ", "", "fake ID"})
+
+	result := service.Screen("After party", "<p>Bring your FAKE ID</p>")
+	if !result.Suspicious() || !strings.Contains(result.Reasons[0], `"fake id"`) {
+		t.Errorf("expected blocked keyword to hold the event, got %+v", result)
+	}
+	if result := service.Screen("After party", "Dancing until late"); result.Suspicious() {
+		t.Errorf("expected clean content to pass, got %+v", result)
+	}
+}
+
+func TestContentScreenService_ScreenEventImage(t *testing.T) {
+	service := NewContentScreenService(&mockContentFlagRepository{})
+	if result := service.ScreenEvent("Community meetup", "Meet the neighbours", "https://cdn.example.com/a.jpg"); result.Suspicious() {
+		t.Errorf("expected images not to be screened without a client, got %+v", result)
+	}
+
+	service.SetImageModerationClient(&mockContentModerationClient{result: &ContentModerationResult{Flagged: true, Categories: []string{"sexual"}}})
+	result := service.ScreenEvent("Community meetup", "Meet the neighbours", "https://cdn.example.com/a.jpg")
+	if !result.Suspicious() || !strings.Contains(result.Reasons[0], "image") || !strings.Contains(result.Reasons[0], "sexual") {
+		t.Errorf("expected flagged image to hold the event with the reason, got %+v", result)
+	}
+	if result := service.ScreenEvent("Community meetup", "Meet the neighbours", ""); result.Suspicious() {
+		t.Errorf("expected events without images to pass, got %+v", result)
+	}
+
+	service.SetImageModerationClient(&mockContentModerationClient{err: errors.New("timeout")})
+	if result := service.ScreenEvent("Community meetup", "Meet the neighbours", "https://cdn.example.com/a.jpg"); result.Suspicious() {
+		t.Errorf("expected image moderation failures not to hold events, got %+v", result)
+	}
+}
+
+func TestSkinToneImageClassifier_ModerateImage(t *testing.T) {
+	solid := func(c color.Color) image.Image {
+		img := image.NewRGBA(image.Rect(0, 0, 64, 48))
+		for y := 0; y < 48; y++ {
+			for x := 0; x < 64; x++ {
+				img.Set(x, y, c)
+			}
+		}
+		return img
+	}
+	images := map[string]image.Image{
+		"/uploads/skin.png":  solid(color.RGBA{R: 224, G: 172, B: 138, A: 255}),
+		"/uploads/stage.png": solid(color.RGBA{R: 20, G: 40, B: 160, A: 255}),
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		img, ok := images[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		png.Encode(w, img)
+	}))
+	defer server.Close()
+
+	classifier := NewSkinToneImageClassifier(server.URL)
+
+	result, err := classifier.ModerateImage("/uploads/skin.png")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Flagged || result.Categories[0] != "100% skin tones" {
+		t.Errorf("expected a skin toned image to be flagged, got %+v", result)
+	}
+
+	result, err = classifier.ModerateImage(server.URL + "/uploads/stage.png")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Flagged {
+		t.Errorf("expected a blue image not to be flagged, got %+v", result)
+	}
+
+	if _, err := classifier.ModerateImage("/uploads/missing.png"); err == nil {
+		t.Error("expected missing images to return an error")
+	}
+}
+
+func TestHTTPContentModerationClient_Moderate(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer secret" {
+			t.Errorf("expected bearer token, got %q", r.Header.Get("Authorization"))
+		}
+		var request map[string]string
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+		if request["input"] != "Win big" || request["model"] != "omni-moderation-latest" {
+			t.Errorf("unexpected request %v", request)
+		}
+		w.Write([]byte(`{"results":[{"flagged":true,"categories":{"violence":false,"illicit":true,"hate":true}}]}`))
+	}))
+	defer server.Close()
+
+	client := NewHTTPContentModerationClient(server.URL, "secret", "omni-moderation-latest")
+	result, err := client.Moderate("Win big")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !result.Flagged || strings.Join(result.Categories, ",") != "hate,illicit" {
+		t.Errorf("unexpected result %+v", result)
+	}
+}
+
+func TestEventService_ContentScreening(t *testing.T) {
+	service, eventRepo, userRepo := setupEventService()
+	defer os.RemoveAll(service.uploadPath)
+
+	flagRepo := &mockContentFlagRepository{}
+	service.SetContentScreen(NewContentScreenService(flagRepo))
+	createTestUser(userRepo, 1, models.RoleOrganizer)
+	createTestUser(userRepo, 2, models.RoleAdmin)
+
+	request := func(organizerID int, description string) *EventCreateRequest {
+		return &EventCreateRequest{
+			Title:       "Investment Summit",
+			Description: description,
+			StartDate:   time.Now().Add(24 * time.Hour),
+			EndDate:     time.Now().Add(26 * time.Hour),
+			Location:    "Nairobi",
+			CategoryID:  1,
+			Status:      models.StatusPublished,
+			OrganizerID: organizerID,
+		}
+	}
+
+	event, err := service.CreateEvent(request(1, "Guaranteed returns, get tickets at tinyurl.com/summit"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.Status != models.StatusPendingReview {
+		t.Errorf("expected suspicious event to be held for review, got %s", event.Status)
+	}
+	if len(flagRepo.flags) != 1 || flagRepo.flags[0].EventID != event.ID {
+		t.Fatalf("expected a content flag for the event, got %+v", flagRepo.flags)
+	}
+
+	event, err = service.CreateEvent(request(1, "Meet founders and investors from across the region."))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.Status != models.StatusPublished {
+		t.Errorf("expected clean event to be published, got %s", event.Status)
+	}
+
+	event, err = service.CreateEvent(request(2, "Guaranteed returns, get tickets at tinyurl.com/summit"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.Status != models.StatusPublished {
+		t.Errorf("expected admins' events not to be screened, got %s", event.Status)
+	}
+
+	draft := createTestEvent(eventRepo, 50, 1)
+	draft.Description = "Send USDT to our wallet address to reserve a seat"
+	event, err = service.UpdateEventStatus(draft.ID, models.StatusPublished, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.Status != models.StatusPendingReview {
+		t.Errorf("expected publishing suspicious draft to hold it for review, got %s", event.Status)
+	}
+	if len(flagRepo.flags) != 2 {
+		t.Errorf("expected two content flags, got %d", len(flagRepo.flags))
+	}
+}
@@ -0,0 +1,312 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"event-ticketing-platform/internal/cache"
+	"event-ticketing-platform/internal/models"
+)
+
+// DataQualityRepositoryInterface defines the data-integrity queries used by the data quality service
+type DataQualityRepositoryInterface interface {
+	FindPublishedEventsWithoutTicketTypes() ([]*models.DataQualityIssue, error)
+	FindCompletedOrdersWithoutTickets() ([]*models.OrderWithoutTickets, error)
+	FindSoldCountMismatches() ([]*models.DataQualityIssue, error)
+	UnpublishEventWithoutTicketTypes(eventID int) (bool, error)
+	RecalculateSoldCount(ticketTypeID int) (bool, error)
+	CreateTickets(orderID, ticketTypeID int, qrCodes []string) error
+}
+
+// DataQualityService runs data-integrity checks for the admin dashboard and
+// the remediation jobs that repair what they find
+type DataQualityService struct {
+	repo         DataQualityRepositoryInterface
+	storageGC    *StorageGCService
+	auditService *AuditService
+	cache        cache.Cache
+
+	mu              sync.RWMutex
+	lastReport      *models.DataQualityReport
+	lastRemediation *models.DataQualityRemediation
+}
+
+// NewDataQualityService creates a new data quality service
+func NewDataQualityService(repo DataQualityRepositoryInterface, auditService *AuditService) *DataQualityService {
+	return &DataQualityService{
+		repo:         repo,
+		auditService: auditService,
+	}
+}
+
+// SetStorageGCService enables the orphaned image check
+func (s *DataQualityService) SetStorageGCService(storageGC *StorageGCService) {
+	s.storageGC = storageGC
+}
+
+// SetCache sets the event cache to invalidate when remediation unpublishes events
+func (s *DataQualityService) SetCache(c cache.Cache) {
+	s.cache = c
+}
+
+// dataQualityChecks describes each check in the order it is shown on the dashboard
+var dataQualityChecks = []struct {
+	check       models.DataQualityCheck
+	name        string
+	description string
+	remediation string
+}{
+	{
+		check:       models.CheckEventsWithoutTicketTypes,
+		name:        "Published events without ticket types",
+		description: "Published events that nobody can buy tickets for.",
+		remediation: "Move back to draft",
+	},
+	{
+		check:       models.CheckOrdersWithoutTickets,
+		name:        "Completed orders without tickets",
+		description: "Paid orders that never had tickets issued. Tickets can only be reissued automatically when the event has a single paid ticket type.",
+		remediation: "Reissue tickets",
+	},
+	{
+		check:       models.CheckSoldCountMismatch,
+		name:        "Sold counts that don't match tickets",
+		description: "Ticket types whose sold count differs from the number of issued, non-refunded tickets.",
+		remediation: "Recalculate sold counts",
+	},
+	{
+		check:       models.CheckOrphanedImages,
+		name:        "Orphaned images",
+		description: "Uploaded images that no event references and that are older than the storage garbage collection grace period.",
+		remediation: "Collect orphaned images",
+	},
+}
+
+// RunChecks runs every data-integrity check and stores the report for the dashboard.
+// A failing check is recorded in its result rather than aborting the run.
+func (s *DataQualityService) RunChecks(ctx context.Context) *models.DataQualityReport {
+	report := &models.DataQualityReport{StartedAt: time.Now()}
+
+	for _, definition := range dataQualityChecks {
+		result := &models.DataQualityCheckResult{
+			Check:       definition.check,
+			Name:        definition.name,
+			Description: definition.description,
+			Remediation: definition.remediation,
+			Issues:      []*models.DataQualityIssue{},
+		}
+
+		issues, err := s.runCheck(ctx, definition.check)
+		if err != nil {
+			result.Error = err.Error()
+		} else if issues != nil {
+			result.Issues = issues
+		}
+		report.Results = append(report.Results, result)
+	}
+
+	report.CompletedAt = time.Now()
+
+	s.mu.Lock()
+	s.lastReport = report
+	s.mu.Unlock()
+
+	return report
+}
+
+// LastReport returns the most recent check report, or nil if checks haven't run yet
+func (s *DataQualityService) LastReport() *models.DataQualityReport {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastReport
+}
+
+// LastRemediation returns the most recent remediation job outcome, if any
+func (s *DataQualityService) LastRemediation() *models.DataQualityRemediation {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastRemediation
+}
+
+func (s *DataQualityService) runCheck(ctx context.Context, check models.DataQualityCheck) ([]*models.DataQualityIssue, error) {
+	switch check {
+	case models.CheckEventsWithoutTicketTypes:
+		return s.repo.FindPublishedEventsWithoutTicketTypes()
+	case models.CheckOrdersWithoutTickets:
+		orders, err := s.repo.FindCompletedOrdersWithoutTickets()
+		if err != nil {
+			return nil, err
+		}
+		var issues []*models.DataQualityIssue
+		for _, order := range orders {
+			issues = append(issues, &models.DataQualityIssue{
+				TargetType: "order",
+				TargetID:   order.OrderID,
+				Summary:    fmt.Sprintf("Order %s for %q (KSh %.2f) has no tickets", order.OrderNumber, order.EventTitle, float64(order.TotalAmount)/100),
+				Fixable:    order.ReissueQuantity() > 0,
+			})
+		}
+		return issues, nil
+	case models.CheckSoldCountMismatch:
+		return s.repo.FindSoldCountMismatches()
+	case models.CheckOrphanedImages:
+		if s.storageGC == nil {
+			return nil, fmt.Errorf("image storage is not configured")
+		}
+		result, err := s.storageGC.FindOrphans(ctx)
+		if err != nil {
+			return nil, err
+		}
+		var issues []*models.DataQualityIssue
+		for _, image := range result.Orphans {
+			issues = append(issues, &models.DataQualityIssue{
+				TargetType: "image",
+				TargetKey:  image.Key,
+				Summary:    fmt.Sprintf("%s (%d bytes) is not referenced by any event", image.Key, image.Size),
+				Fixable:    true,
+			})
+		}
+		return issues, nil
+	default:
+		return nil, fmt.Errorf("unknown data quality check: %s", check)
+	}
+}
+
+// Remediate runs the one-click remediation job for a check, records it in the
+// audit log and re-runs the checks so the dashboard reflects the fix
+func (s *DataQualityService) Remediate(ctx context.Context, check models.DataQualityCheck, adminUserID int, r *http.Request) (*models.DataQualityRemediation, error) {
+	remediation := &models.DataQualityRemediation{Check: check, Errors: []string{}}
+
+	var err error
+	switch check {
+	case models.CheckEventsWithoutTicketTypes:
+		err = s.unpublishEventsWithoutTicketTypes(remediation)
+	case models.CheckOrdersWithoutTickets:
+		err = s.reissueMissingTickets(remediation)
+	case models.CheckSoldCountMismatch:
+		err = s.recalculateSoldCounts(remediation)
+	case models.CheckOrphanedImages:
+		err = s.deleteOrphanedImages(ctx, remediation)
+	default:
+		return nil, fmt.Errorf("unknown data quality check: %s", check)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	remediation.CompletedAt = time.Now()
+
+	if s.auditService != nil && r != nil {
+		details := map[string]interface{}{
+			"check":  check,
+			"fixed":  remediation.Fixed,
+			"errors": remediation.Errors,
+		}
+		if err := s.auditService.LogAction(adminUserID, models.AuditActionDataQualityRemediate, models.AuditTargetDataQuality, 0, details, r); err != nil {
+			fmt.Printf("Warning: failed to log data quality remediation: %v\n", err)
+		}
+	}
+
+	s.mu.Lock()
+	s.lastRemediation = remediation
+	s.mu.Unlock()
+
+	s.RunChecks(ctx)
+
+	return remediation, nil
+}
+
+func (s *DataQualityService) unpublishEventsWithoutTicketTypes(remediation *models.DataQualityRemediation) error {
+	issues, err := s.repo.FindPublishedEventsWithoutTicketTypes()
+	if err != nil {
+		return err
+	}
+
+	for _, issue := range issues {
+		updated, err := s.repo.UnpublishEventWithoutTicketTypes(issue.TargetID)
+		if err != nil {
+			remediation.Errors = append(remediation.Errors, fmt.Sprintf("event %d: %v", issue.TargetID, err))
+			continue
+		}
+		if updated {
+			remediation.Fixed++
+		}
+	}
+
+	if remediation.Fixed > 0 {
+		invalidateEventCache(s.cache)
+	}
+	return nil
+}
+
+func (s *DataQualityService) reissueMissingTickets(remediation *models.DataQualityRemediation) error {
+	orders, err := s.repo.FindCompletedOrdersWithoutTickets()
+	if err != nil {
+		return err
+	}
+
+	for _, order := range orders {
+		quantity := order.ReissueQuantity()
+		if quantity == 0 {
+			continue
+		}
+
+		qrCodes := make([]string, 0, quantity)
+		for i := 0; i < quantity; i++ {
+			qrCode, err := generateTicketQRCode(order.OrderID, order.TicketTypeID)
+			if err != nil {
+				return err
+			}
+			qrCodes = append(qrCodes, qrCode)
+		}
+
+		if err := s.repo.CreateTickets(order.OrderID, order.TicketTypeID, qrCodes); err != nil {
+			remediation.Errors = append(remediation.Errors, fmt.Sprintf("order %s: %v", order.OrderNumber, err))
+			continue
+		}
+		remediation.Fixed++
+	}
+
+	return nil
+}
+
+func (s *DataQualityService) recalculateSoldCounts(remediation *models.DataQualityRemediation) error {
+	issues, err := s.repo.FindSoldCountMismatches()
+	if err != nil {
+		return err
+	}
+
+	for _, issue := range issues {
+		if !issue.Fixable {
+			continue
+		}
+		updated, err := s.repo.RecalculateSoldCount(issue.TargetID)
+		if err != nil {
+			remediation.Errors = append(remediation.Errors, fmt.Sprintf("ticket type %d: %v", issue.TargetID, err))
+			continue
+		}
+		if updated {
+			remediation.Fixed++
+		}
+	}
+
+	return nil
+}
+
+func (s *DataQualityService) deleteOrphanedImages(ctx context.Context, remediation *models.DataQualityRemediation) error {
+	if s.storageGC == nil {
+		return fmt.Errorf("image storage is not configured")
+	}
+
+	result, err := s.storageGC.Run(ctx)
+	if err != nil {
+		return err
+	}
+
+	remediation.Fixed = result.Run.QuarantinedObjects + result.Run.DeletedObjects
+	remediation.Errors = append(remediation.Errors, result.Run.Errors...)
+	return nil
+}
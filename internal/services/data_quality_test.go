@@ -0,0 +1,173 @@
+package services
+
+import (
+	"context"
+	"testing"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock DataQualityRepository for testing
+type mockDataQualityRepository struct {
+	eventsWithoutTypes []*models.DataQualityIssue
+	ordersWithoutTix   []*models.OrderWithoutTickets
+	soldMismatches     []*models.DataQualityIssue
+
+	unpublished  []int
+	recalculated []int
+	issued       map[int][]string
+}
+
+func (m *mockDataQualityRepository) FindPublishedEventsWithoutTicketTypes() ([]*models.DataQualityIssue, error) {
+	return m.eventsWithoutTypes, nil
+}
+
+func (m *mockDataQualityRepository) FindCompletedOrdersWithoutTickets() ([]*models.OrderWithoutTickets, error) {
+	var orders []*models.OrderWithoutTickets
+	for _, order := range m.ordersWithoutTix {
+		if _, ok := m.issued[order.OrderID]; !ok {
+			orders = append(orders, order)
+		}
+	}
+	return orders, nil
+}
+
+func (m *mockDataQualityRepository) FindSoldCountMismatches() ([]*models.DataQualityIssue, error) {
+	return m.soldMismatches, nil
+}
+
+func (m *mockDataQualityRepository) UnpublishEventWithoutTicketTypes(eventID int) (bool, error) {
+	m.unpublished = append(m.unpublished, eventID)
+	return true, nil
+}
+
+func (m *mockDataQualityRepository) RecalculateSoldCount(ticketTypeID int) (bool, error) {
+	m.recalculated = append(m.recalculated, ticketTypeID)
+	return true, nil
+}
+
+func (m *mockDataQualityRepository) CreateTickets(orderID, ticketTypeID int, qrCodes []string) error {
+	m.issued[orderID] = qrCodes
+	return nil
+}
+
+func newMockDataQualityRepository() *mockDataQualityRepository {
+	return &mockDataQualityRepository{
+		eventsWithoutTypes: []*models.DataQualityIssue{
+			{TargetType: "event", TargetID: 4, Summary: "no ticket types", Fixable: true},
+		},
+		ordersWithoutTix: []*models.OrderWithoutTickets{
+			{OrderID: 10, OrderNumber: "ORD-10", TotalAmount: 3000, TicketTypeCount: 1, TicketTypeID: 2, TicketPrice: 1000},
+			{OrderID: 11, OrderNumber: "ORD-11", TotalAmount: 3000, TicketTypeCount: 2},
+		},
+		soldMismatches: []*models.DataQualityIssue{
+			{TargetType: "ticket_type", TargetID: 7, Fixable: true},
+			{TargetType: "ticket_type", TargetID: 8, Fixable: false},
+		},
+		issued: map[int][]string{},
+	}
+}
+
+func findCheckResult(report *models.DataQualityReport, check models.DataQualityCheck) *models.DataQualityCheckResult {
+	for _, result := range report.Results {
+		if result.Check == check {
+			return result
+		}
+	}
+	return nil
+}
+
+func TestDataQualityService_RunChecks(t *testing.T) {
+	service := NewDataQualityService(newMockDataQualityRepository(), nil)
+
+	if service.LastReport() != nil {
+		t.Fatal("expected no report before checks run")
+	}
+
+	report := service.RunChecks(context.Background())
+	if service.LastReport() != report {
+		t.Error("expected the report to be stored for the dashboard")
+	}
+	if len(report.Results) != 4 {
+		t.Fatalf("expected 4 check results, got %d", len(report.Results))
+	}
+	if report.TotalIssues() != 5 {
+		t.Errorf("expected 5 issues, got %d", report.TotalIssues())
+	}
+
+	orders := findCheckResult(report, models.CheckOrdersWithoutTickets)
+	if orders.FixableCount() != 1 {
+		t.Errorf("expected only the single ticket type order to be fixable, got %d", orders.FixableCount())
+	}
+
+	images := findCheckResult(report, models.CheckOrphanedImages)
+	if images.Error == "" {
+		t.Error("expected orphaned image check to report missing storage")
+	}
+}
+
+func TestDataQualityService_Remediate(t *testing.T) {
+	repo := newMockDataQualityRepository()
+	service := NewDataQualityService(repo, nil)
+	ctx := context.Background()
+
+	result, err := service.Remediate(ctx, models.CheckOrdersWithoutTickets, 1, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Fixed != 1 {
+		t.Errorf("expected 1 order fixed, got %d", result.Fixed)
+	}
+	if len(repo.issued[10]) != 3 {
+		t.Errorf("expected 3 tickets reissued for order 10, got %d", len(repo.issued[10]))
+	}
+	if _, ok := repo.issued[11]; ok {
+		t.Error("expected ambiguous order not to be reissued")
+	}
+
+	if _, err := service.Remediate(ctx, models.CheckSoldCountMismatch, 1, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.recalculated) != 1 || repo.recalculated[0] != 7 {
+		t.Errorf("expected only fixable ticket type 7 to be recalculated, got %v", repo.recalculated)
+	}
+
+	if _, err := service.Remediate(ctx, models.CheckEventsWithoutTicketTypes, 1, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.unpublished) != 1 || repo.unpublished[0] != 4 {
+		t.Errorf("expected event 4 unpublished, got %v", repo.unpublished)
+	}
+
+	if service.LastRemediation().Check != models.CheckEventsWithoutTicketTypes {
+		t.Errorf("expected last remediation to be stored, got %+v", service.LastRemediation())
+	}
+	if orders := findCheckResult(service.LastReport(), models.CheckOrdersWithoutTickets); len(orders.Issues) != 1 {
+		t.Errorf("expected checks to re-run after remediation, got %d order issues", len(orders.Issues))
+	}
+
+	if _, err := service.Remediate(ctx, models.DataQualityCheck("unknown"), 1, nil); err == nil {
+		t.Error("expected error for unknown check")
+	}
+}
+
+func TestOrderWithoutTickets_ReissueQuantity(t *testing.T) {
+	tests := []struct {
+		name     string
+		order    models.OrderWithoutTickets
+		expected int
+	}{
+		{name: "single paid ticket type", order: models.OrderWithoutTickets{TotalAmount: 2500, TicketTypeCount: 1, TicketPrice: 500}, expected: 5},
+		{name: "several ticket types", order: models.OrderWithoutTickets{TotalAmount: 2500, TicketTypeCount: 2, TicketPrice: 500}, expected: 0},
+		{name: "free ticket type", order: models.OrderWithoutTickets{TotalAmount: 0, TicketTypeCount: 1, TicketPrice: 0}, expected: 0},
+		{name: "amount not a multiple of price", order: models.OrderWithoutTickets{TotalAmount: 2600, TicketTypeCount: 1, TicketPrice: 500}, expected: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.order.ReissueQuantity(); got != tt.expected {
+				t.Errorf("expected %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
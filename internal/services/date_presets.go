@@ -0,0 +1,90 @@
+package services
+
+import (
+	"fmt"
+	"time"
+)
+
+// DatePreset represents a curated date filter for browsing events
+type DatePreset string
+
+const (
+	DatePresetToday     DatePreset = "today"
+	DatePresetTomorrow  DatePreset = "tomorrow"
+	DatePresetWeekend   DatePreset = "weekend"
+	DatePresetNext7Days DatePreset = "next_7_days"
+)
+
+// DatePresetOption describes a date preset for display in filters
+type DatePresetOption struct {
+	Value DatePreset `json:"value"`
+	Label string     `json:"label"`
+}
+
+// DatePresetOptions lists the available date presets in display order
+var DatePresetOptions = []DatePresetOption{
+	{Value: DatePresetToday, Label: "Today"},
+	{Value: DatePresetTomorrow, Label: "Tomorrow"},
+	{Value: DatePresetWeekend, Label: "This weekend"},
+	{Value: DatePresetNext7Days, Label: "Next 7 days"},
+}
+
+// defaultTimeZone is used to resolve date presets when a request does not specify one
+var defaultTimeZone = time.UTC
+
+// SetDefaultTimeZone sets the time zone used for date presets by IANA name, e.g. "Africa/Nairobi"
+func SetDefaultTimeZone(name string) error {
+	loc, err := time.LoadLocation(name)
+	if err != nil {
+		return fmt.Errorf("invalid time zone %q: %w", name, err)
+	}
+	defaultTimeZone = loc
+	return nil
+}
+
+// ResolveTimeZone returns the named time zone, falling back to the default
+func ResolveTimeZone(name string) *time.Location {
+	if name != "" {
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc
+		}
+	}
+	return defaultTimeZone
+}
+
+// ResolveDatePreset returns the [start, end) range of event start dates matched
+// by a preset, as seen from now in now's time zone. Event dates are stored as
+// wall-clock times, so the range is returned as wall-clock times in UTC.
+func ResolveDatePreset(preset DatePreset, now time.Time) (start, end time.Time, ok bool) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	switch preset {
+	case DatePresetToday:
+		return today, today.AddDate(0, 0, 1), true
+	case DatePresetTomorrow:
+		return today.AddDate(0, 0, 1), today.AddDate(0, 0, 2), true
+	case DatePresetNext7Days:
+		return today, today.AddDate(0, 0, 7), true
+	case DatePresetWeekend:
+		// The weekend runs from Friday evening until the end of Sunday
+		var friday time.Time
+		switch now.Weekday() {
+		case time.Saturday:
+			friday = today.AddDate(0, 0, -1)
+		case time.Sunday:
+			friday = today.AddDate(0, 0, -2)
+		default:
+			friday = today.AddDate(0, 0, int(time.Friday-now.Weekday()))
+		}
+		start = friday.Add(18 * time.Hour)
+		end = friday.AddDate(0, 0, 3)
+
+		// Once the weekend has started, only show what is still to come today onwards
+		if today.After(start) {
+			start = today
+		}
+		return start, end, true
+	}
+
+	return time.Time{}, time.Time{}, false
+}
@@ -0,0 +1,100 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestResolveDatePreset(t *testing.T) {
+	nairobi, err := time.LoadLocation("Africa/Nairobi")
+	if err != nil {
+		t.Skip("time zone data not available")
+	}
+
+	day := func(month time.Month, d, hour int) time.Time {
+		return time.Date(2025, month, d, hour, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name   string
+		preset DatePreset
+		now    time.Time
+		start  time.Time
+		end    time.Time
+	}{
+		{
+			name:   "today",
+			preset: DatePresetToday,
+			now:    time.Date(2025, 3, 12, 10, 0, 0, 0, nairobi), // Wednesday
+			start:  day(3, 12, 0),
+			end:    day(3, 13, 0),
+		},
+		{
+			name:   "today uses the requester's date, not UTC",
+			preset: DatePresetToday,
+			now:    time.Date(2025, 3, 12, 22, 0, 0, 0, time.UTC).In(nairobi), // Already Thursday in Nairobi
+			start:  day(3, 13, 0),
+			end:    day(3, 14, 0),
+		},
+		{
+			name:   "tomorrow",
+			preset: DatePresetTomorrow,
+			now:    time.Date(2025, 3, 12, 10, 0, 0, 0, nairobi),
+			start:  day(3, 13, 0),
+			end:    day(3, 14, 0),
+		},
+		{
+			name:   "weekend from a weekday",
+			preset: DatePresetWeekend,
+			now:    time.Date(2025, 3, 12, 10, 0, 0, 0, nairobi),
+			start:  day(3, 14, 18),
+			end:    day(3, 17, 0),
+		},
+		{
+			name:   "weekend on saturday",
+			preset: DatePresetWeekend,
+			now:    time.Date(2025, 3, 15, 10, 0, 0, 0, nairobi),
+			start:  day(3, 15, 0),
+			end:    day(3, 17, 0),
+		},
+		{
+			name:   "weekend on sunday",
+			preset: DatePresetWeekend,
+			now:    time.Date(2025, 3, 16, 10, 0, 0, 0, nairobi),
+			start:  day(3, 16, 0),
+			end:    day(3, 17, 0),
+		},
+		{
+			name:   "next 7 days",
+			preset: DatePresetNext7Days,
+			now:    time.Date(2025, 3, 12, 10, 0, 0, 0, nairobi),
+			start:  day(3, 12, 0),
+			end:    day(3, 19, 0),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			start, end, ok := ResolveDatePreset(tt.preset, tt.now)
+			if !ok {
+				t.Fatalf("expected preset %s to resolve", tt.preset)
+			}
+			if !start.Equal(tt.start) || !end.Equal(tt.end) {
+				t.Errorf("expected [%v, %v), got [%v, %v)", tt.start, tt.end, start, end)
+			}
+		})
+	}
+
+	if _, _, ok := ResolveDatePreset("someday", time.Now()); ok {
+		t.Errorf("expected unknown preset not to resolve")
+	}
+}
+
+func TestResolveTimeZone(t *testing.T) {
+	if loc := ResolveTimeZone("Not/AZone"); loc != defaultTimeZone {
+		t.Errorf("expected invalid time zone to fall back to the default, got %v", loc)
+	}
+	if loc := ResolveTimeZone(""); loc != defaultTimeZone {
+		t.Errorf("expected empty time zone to fall back to the default, got %v", loc)
+	}
+}
@@ -0,0 +1,293 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// ErrDisputeNotFound is returned for disputes that don't exist or aren't the
+// organizer's
+var ErrDisputeNotFound = errors.New("dispute not found")
+
+// DisputeRepositoryInterface defines the data operations for payment disputes
+type DisputeRepositoryInterface interface {
+	FindOrderByPaymentReference(reference string) (*models.Order, error)
+	OpenDispute(dispute *models.PaymentDispute) (bool, error)
+	GetDispute(id int) (*models.PaymentDispute, error)
+	GetDisputeByProviderID(providerDisputeID string) (*models.PaymentDispute, error)
+	GetDisputes(organizerID int) ([]*models.PaymentDispute, error)
+	SubmitEvidence(id int, evidence *models.DisputeEvidence) error
+	ResolveDispute(id int, status models.DisputeStatus, note string, resolvedAt time.Time) (bool, error)
+}
+
+// WebhookSignatureVerifier verifies the signature a payment provider signs
+// its webhook requests with
+type WebhookSignatureVerifier interface {
+	VerifyWebhookSignature(payload []byte, signature string) bool
+}
+
+// DisputeNotifier tells organizers about disputes of their orders
+type DisputeNotifier interface {
+	DisputeOpened(dispute *models.PaymentDispute)
+	DisputeResolved(dispute *models.PaymentDispute)
+}
+
+// DisputeService records the chargebacks buyers raise against their
+// payments, collects organizers' evidence and applies the outcomes
+type DisputeService struct {
+	repo     DisputeRepositoryInterface
+	verifier WebhookSignatureVerifier
+	storage  StorageService
+	notifier DisputeNotifier
+	now      func() time.Time
+}
+
+// NewDisputeService creates a new dispute service
+func NewDisputeService(repo DisputeRepositoryInterface, verifier WebhookSignatureVerifier, storage StorageService, notifier DisputeNotifier) *DisputeService {
+	return &DisputeService{
+		repo:     repo,
+		verifier: verifier,
+		storage:  storage,
+		notifier: notifier,
+		now:      time.Now,
+	}
+}
+
+// paystackWebhookEvent is the envelope of a Paystack webhook request
+type paystackWebhookEvent struct {
+	Event string          `json:"event"`
+	Data  json.RawMessage `json:"data"`
+}
+
+// paystackDispute is the dispute a Paystack charge.dispute event reports
+type paystackDispute struct {
+	ID           json.Number `json:"id"`
+	RefundAmount int         `json:"refund_amount"`
+	Currency     string      `json:"currency"`
+	Status       string      `json:"status"`
+	Resolution   string      `json:"resolution"`
+	Category     string      `json:"category"`
+	DueAt        string      `json:"dueAt"`
+	Transaction  struct {
+		Reference string `json:"reference"`
+		Amount    int    `json:"amount"`
+	} `json:"transaction"`
+}
+
+// HandlePaystackWebhook verifies a Paystack webhook request and applies the
+// dispute event it reports. Other events are acknowledged and ignored, as
+// payments are confirmed when buyers return from checkout.
+func (s *DisputeService) HandlePaystackWebhook(header http.Header, body []byte) error {
+	if s.verifier == nil || !s.verifier.VerifyWebhookSignature(body, header.Get("x-paystack-signature")) {
+		return ErrInvalidWebhookSignature
+	}
+
+	var event paystackWebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return fmt.Errorf("failed to decode webhook event: %w", err)
+	}
+
+	if !strings.HasPrefix(event.Event, "charge.dispute.") {
+		return nil
+	}
+
+	var data paystackDispute
+	if err := json.Unmarshal(event.Data, &data); err != nil {
+		return fmt.Errorf("failed to decode dispute: %w", err)
+	}
+	if data.ID == "" {
+		return nil
+	}
+
+	if event.Event == "charge.dispute.resolve" {
+		return s.resolveProviderDispute(data)
+	}
+	return s.openProviderDispute(data)
+}
+
+// openProviderDispute records a dispute the provider reported, freezing its
+// order. Reminders of disputes already recorded change nothing.
+func (s *DisputeService) openProviderDispute(data paystackDispute) error {
+	order, err := s.repo.FindOrderByPaymentReference(data.Transaction.Reference)
+	if err != nil {
+		return err
+	}
+	if order == nil {
+		// Nothing of this platform's was paid with the transaction
+		fmt.Printf("Warning: dispute %s is for unknown payment %q\n", data.ID, data.Transaction.Reference)
+		return nil
+	}
+
+	amount := data.RefundAmount
+	if amount <= 0 {
+		amount = data.Transaction.Amount
+	}
+	dispute := &models.PaymentDispute{
+		ProviderDisputeID: data.ID.String(),
+		OrderID:           order.ID,
+		PaymentReference:  data.Transaction.Reference,
+		Amount:            amount,
+		Currency:          data.Currency,
+		Reason:            data.Category,
+	}
+	if dueAt, err := time.Parse(time.RFC3339, data.DueAt); err == nil {
+		dispute.DueAt = &dueAt
+	}
+
+	created, err := s.repo.OpenDispute(dispute)
+	if err != nil || !created {
+		return err
+	}
+
+	stored, err := s.repo.GetDispute(dispute.ID)
+	if err != nil {
+		return err
+	}
+	if stored != nil && s.notifier != nil {
+		s.notifier.DisputeOpened(stored)
+	}
+	return nil
+}
+
+// resolveProviderDispute applies the outcome the provider reported.
+// Paystack resolves a dispute "merchant-accepted" when the buyer is refunded
+// and "declined" when the charge stands.
+func (s *DisputeService) resolveProviderDispute(data paystackDispute) error {
+	var status models.DisputeStatus
+	switch data.Resolution {
+	case "declined":
+		status = models.DisputeWon
+	case "merchant-accepted":
+		status = models.DisputeLost
+	default:
+		return fmt.Errorf("unknown dispute resolution %q", data.Resolution)
+	}
+
+	dispute, err := s.repo.GetDisputeByProviderID(data.ID.String())
+	if err != nil {
+		return err
+	}
+	if dispute == nil {
+		// The dispute was opened before disputes were recorded
+		return nil
+	}
+
+	return s.resolve(dispute, status, "Resolved by the payment provider")
+}
+
+// Resolve records the outcome of a dispute decided outside the provider's
+// webhooks, such as one an admin settled with the provider directly
+func (s *DisputeService) Resolve(id int, status models.DisputeStatus, note string) error {
+	if status != models.DisputeWon && status != models.DisputeLost {
+		return fmt.Errorf("a dispute can only be won or lost")
+	}
+
+	dispute, err := s.repo.GetDispute(id)
+	if err != nil {
+		return err
+	}
+	if dispute == nil {
+		return ErrDisputeNotFound
+	}
+	if dispute.IsResolved() {
+		return fmt.Errorf("dispute is already resolved")
+	}
+
+	return s.resolve(dispute, status, strings.TrimSpace(note))
+}
+
+func (s *DisputeService) resolve(dispute *models.PaymentDispute, status models.DisputeStatus, note string) error {
+	resolvedAt := s.now()
+	resolved, err := s.repo.ResolveDispute(dispute.ID, status, note, resolvedAt)
+	if err != nil || !resolved {
+		return err
+	}
+
+	dispute.Status = status
+	dispute.ResolutionNote = note
+	dispute.ResolvedAt = &resolvedAt
+	if s.notifier != nil {
+		s.notifier.DisputeResolved(dispute)
+	}
+	return nil
+}
+
+// Disputes returns the disputes of an organizer's orders
+func (s *DisputeService) Disputes(organizerID int) ([]*models.PaymentDispute, error) {
+	return s.repo.GetDisputes(organizerID)
+}
+
+// AllDisputes returns the disputes of every organizer's orders
+func (s *DisputeService) AllDisputes() ([]*models.PaymentDispute, error) {
+	return s.repo.GetDisputes(0)
+}
+
+// SubmitEvidence stores an organizer's evidence against a dispute of one of
+// their orders, with an optional PDF or image file
+func (s *DisputeService) SubmitEvidence(ctx context.Context, id, organizerID int, text string, file io.Reader) error {
+	dispute, err := s.repo.GetDispute(id)
+	if err != nil {
+		return err
+	}
+	if dispute == nil || dispute.OrganizerID != organizerID {
+		return ErrDisputeNotFound
+	}
+	if !dispute.AcceptsEvidence() {
+		return fmt.Errorf("this dispute has already been resolved")
+	}
+
+	evidence := &models.DisputeEvidence{Text: strings.TrimSpace(text)}
+	if file != nil {
+		evidence.FileURL, err = s.uploadEvidence(ctx, dispute, file)
+		if err != nil {
+			return err
+		}
+	}
+	if err := evidence.Validate(); err != nil {
+		return err
+	}
+
+	return s.repo.SubmitEvidence(dispute.ID, evidence)
+}
+
+// uploadEvidence stores an evidence file under a key that can't be guessed
+// and returns its URL
+func (s *DisputeService) uploadEvidence(ctx context.Context, dispute *models.PaymentDispute, file io.Reader) (string, error) {
+	data, err := io.ReadAll(io.LimitReader(file, models.MaxDisputeEvidenceSize+1))
+	if err != nil {
+		return "", fmt.Errorf("failed to read evidence file: %w", err)
+	}
+	if len(data) > models.MaxDisputeEvidenceSize {
+		return "", fmt.Errorf("evidence file cannot be larger than %d MB", models.MaxDisputeEvidenceSize>>20)
+	}
+
+	contentType := http.DetectContentType(data)
+	extension, ok := models.DisputeEvidenceTypes[contentType]
+	if !ok {
+		return "", fmt.Errorf("evidence file must be a PDF, JPEG or PNG")
+	}
+
+	random := make([]byte, 16)
+	if _, err := rand.Read(random); err != nil {
+		return "", fmt.Errorf("failed to name evidence file: %w", err)
+	}
+	key := "disputes/" + strconv.Itoa(dispute.ID) + "/" + hex.EncodeToString(random) + extension
+
+	url, err := s.storage.Upload(ctx, key, bytes.NewReader(data), contentType, int64(len(data)))
+	if err != nil {
+		return "", fmt.Errorf("failed to upload evidence file: %w", err)
+	}
+	return url, nil
+}
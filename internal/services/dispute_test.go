@@ -0,0 +1,221 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryDisputes is an in-memory dispute repository
+type memoryDisputes struct {
+	orders   map[string]*models.Order
+	disputes map[int]*models.PaymentDispute
+	nextID   int
+}
+
+func newMemoryDisputes() *memoryDisputes {
+	return &memoryDisputes{
+		orders:   map[string]*models.Order{},
+		disputes: map[int]*models.PaymentDispute{},
+	}
+}
+
+func (m *memoryDisputes) FindOrderByPaymentReference(reference string) (*models.Order, error) {
+	return m.orders[reference], nil
+}
+
+func (m *memoryDisputes) OpenDispute(dispute *models.PaymentDispute) (bool, error) {
+	if existing, _ := m.GetDisputeByProviderID(dispute.ProviderDisputeID); existing != nil {
+		return false, nil
+	}
+	m.nextID++
+	dispute.ID = m.nextID
+	dispute.Status = models.DisputeOpen
+	stored := *dispute
+	stored.OrganizerID = 7
+	m.disputes[dispute.ID] = &stored
+	return true, nil
+}
+
+func (m *memoryDisputes) GetDispute(id int) (*models.PaymentDispute, error) {
+	if dispute, ok := m.disputes[id]; ok {
+		copied := *dispute
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (m *memoryDisputes) GetDisputeByProviderID(providerDisputeID string) (*models.PaymentDispute, error) {
+	for id, dispute := range m.disputes {
+		if dispute.ProviderDisputeID == providerDisputeID {
+			return m.GetDispute(id)
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryDisputes) GetDisputes(organizerID int) ([]*models.PaymentDispute, error) {
+	var disputes []*models.PaymentDispute
+	for _, dispute := range m.disputes {
+		if organizerID == 0 || dispute.OrganizerID == organizerID {
+			disputes = append(disputes, dispute)
+		}
+	}
+	return disputes, nil
+}
+
+func (m *memoryDisputes) SubmitEvidence(id int, evidence *models.DisputeEvidence) error {
+	dispute := m.disputes[id]
+	dispute.EvidenceText = evidence.Text
+	dispute.EvidenceURL = evidence.FileURL
+	dispute.Status = models.DisputeUnderReview
+	return nil
+}
+
+func (m *memoryDisputes) ResolveDispute(id int, status models.DisputeStatus, note string, resolvedAt time.Time) (bool, error) {
+	dispute := m.disputes[id]
+	if dispute == nil || dispute.IsResolved() {
+		return false, nil
+	}
+	dispute.Status = status
+	dispute.ResolutionNote = note
+	dispute.ResolvedAt = &resolvedAt
+	return true, nil
+}
+
+// signatureStub accepts requests signed "valid"
+type signatureStub struct{}
+
+func (signatureStub) VerifyWebhookSignature(payload []byte, signature string) bool {
+	return signature == "valid"
+}
+
+// recordingDisputeNotifier records the disputes it is told about
+type recordingDisputeNotifier struct {
+	opened   []*models.PaymentDispute
+	resolved []*models.PaymentDispute
+}
+
+func (n *recordingDisputeNotifier) DisputeOpened(dispute *models.PaymentDispute) {
+	n.opened = append(n.opened, dispute)
+}
+
+func (n *recordingDisputeNotifier) DisputeResolved(dispute *models.PaymentDispute) {
+	n.resolved = append(n.resolved, dispute)
+}
+
+func signedHeader(signature string) http.Header {
+	header := http.Header{}
+	header.Set("x-paystack-signature", signature)
+	return header
+}
+
+func disputeEvent(event, resolution string) []byte {
+	return []byte(`{"event":"` + event + `","data":{"id":4021,"refund_amount":250000,"currency":"KES","category":"fraud","resolution":"` + resolution + `","dueAt":"2026-03-10T12:00:00Z","transaction":{"reference":"ref-1","amount":250000}}}`)
+}
+
+func newTestDisputeService() (*DisputeService, *memoryDisputes, *recordingDisputeNotifier) {
+	repo := newMemoryDisputes()
+	repo.orders["ref-1"] = &models.Order{ID: 31, Status: models.OrderCompleted}
+	notifier := &recordingDisputeNotifier{}
+	service := NewDisputeService(repo, signatureStub{}, NewMockCleanupStorageService(), notifier)
+	return service, repo, notifier
+}
+
+func TestDisputeService_HandlePaystackWebhook_RejectsInvalidSignature(t *testing.T) {
+	service, repo, _ := newTestDisputeService()
+
+	err := service.HandlePaystackWebhook(signedHeader("forged"), disputeEvent("charge.dispute.create", ""))
+
+	assert.ErrorIs(t, err, ErrInvalidWebhookSignature)
+	assert.Empty(t, repo.disputes)
+}
+
+func TestDisputeService_HandlePaystackWebhook_OpensDisputeOnce(t *testing.T) {
+	service, repo, notifier := newTestDisputeService()
+
+	require.NoError(t, service.HandlePaystackWebhook(signedHeader("valid"), disputeEvent("charge.dispute.create", "")))
+	require.NoError(t, service.HandlePaystackWebhook(signedHeader("valid"), disputeEvent("charge.dispute.remind", "")))
+
+	require.Len(t, repo.disputes, 1)
+	dispute := repo.disputes[1]
+	assert.Equal(t, "4021", dispute.ProviderDisputeID)
+	assert.Equal(t, 31, dispute.OrderID)
+	assert.Equal(t, 250000, dispute.Amount)
+	assert.Equal(t, "fraud", dispute.Reason)
+	require.NotNil(t, dispute.DueAt)
+	assert.Equal(t, time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC), dispute.DueAt.UTC())
+	assert.Len(t, notifier.opened, 1)
+}
+
+func TestDisputeService_HandlePaystackWebhook_IgnoresUnknownPayments(t *testing.T) {
+	service, repo, notifier := newTestDisputeService()
+	delete(repo.orders, "ref-1")
+
+	require.NoError(t, service.HandlePaystackWebhook(signedHeader("valid"), disputeEvent("charge.dispute.create", "")))
+	require.NoError(t, service.HandlePaystackWebhook(signedHeader("valid"), []byte(`{"event":"charge.success","data":{"reference":"ref-1"}}`)))
+
+	assert.Empty(t, repo.disputes)
+	assert.Empty(t, notifier.opened)
+}
+
+func TestDisputeService_HandlePaystackWebhook_Resolves(t *testing.T) {
+	tests := []struct {
+		resolution string
+		want       models.DisputeStatus
+	}{
+		{"declined", models.DisputeWon},
+		{"merchant-accepted", models.DisputeLost},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.resolution, func(t *testing.T) {
+			service, repo, notifier := newTestDisputeService()
+			require.NoError(t, service.HandlePaystackWebhook(signedHeader("valid"), disputeEvent("charge.dispute.create", "")))
+
+			require.NoError(t, service.HandlePaystackWebhook(signedHeader("valid"), disputeEvent("charge.dispute.resolve", tt.resolution)))
+
+			assert.Equal(t, tt.want, repo.disputes[1].Status)
+			assert.NotNil(t, repo.disputes[1].ResolvedAt)
+			require.Len(t, notifier.resolved, 1)
+			assert.Equal(t, tt.want, notifier.resolved[0].Status)
+		})
+	}
+}
+
+func TestDisputeService_Resolve_RejectsResolvedDisputes(t *testing.T) {
+	service, _, _ := newTestDisputeService()
+	require.NoError(t, service.HandlePaystackWebhook(signedHeader("valid"), disputeEvent("charge.dispute.create", "")))
+
+	assert.Error(t, service.Resolve(1, models.DisputeOpen, ""))
+	require.NoError(t, service.Resolve(1, models.DisputeWon, "Settled with Paystack"))
+	assert.Error(t, service.Resolve(1, models.DisputeLost, ""))
+	assert.ErrorIs(t, service.Resolve(2, models.DisputeLost, ""), ErrDisputeNotFound)
+}
+
+func TestDisputeService_SubmitEvidence(t *testing.T) {
+	service, repo, _ := newTestDisputeService()
+	require.NoError(t, service.HandlePaystackWebhook(signedHeader("valid"), disputeEvent("charge.dispute.create", "")))
+	ctx := context.Background()
+
+	assert.ErrorIs(t, service.SubmitEvidence(ctx, 1, 8, "Scanned at the door", nil), ErrDisputeNotFound)
+	assert.Error(t, service.SubmitEvidence(ctx, 1, 7, "Scanned at the door", strings.NewReader("plain text is not a PDF")))
+	assert.Error(t, service.SubmitEvidence(ctx, 1, 7, "  ", nil))
+
+	pdf := bytes.NewReader([]byte("%PDF-1.4\n%âãÏÓ\n1 0 obj\n<<>>\nendobj\n"))
+	require.NoError(t, service.SubmitEvidence(ctx, 1, 7, "Scanned at the door", pdf))
+
+	dispute := repo.disputes[1]
+	assert.Equal(t, models.DisputeUnderReview, dispute.Status)
+	assert.Equal(t, "Scanned at the door", dispute.EvidenceText)
+	assert.True(t, strings.HasPrefix(dispute.EvidenceURL, "https://example.com/disputes/1/"))
+	assert.True(t, strings.HasSuffix(dispute.EvidenceURL, ".pdf"))
+}
@@ -0,0 +1,124 @@
+package services
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// DomainEventHandler reacts to a domain event
+type DomainEventHandler func(event *models.DomainEvent)
+
+// EventPublishedHook is notified after an event goes live
+type EventPublishedHook interface {
+	EventPublished(event *models.Event)
+}
+
+// TicketCheckInHook is notified after a ticket holder is admitted
+type TicketCheckInHook interface {
+	TicketCheckedIn(scan *models.TicketScan)
+}
+
+// DomainEventBus delivers domain events to the modules subscribed to them, so
+// the services where things happen don't call every module that reacts.
+// Handlers run synchronously in the order they subscribed; a handler that
+// panics is logged and skipped without affecting the others or the publisher.
+type DomainEventBus struct {
+	mu       sync.RWMutex
+	handlers map[models.DomainEventType][]DomainEventHandler
+	now      func() time.Time
+}
+
+// NewDomainEventBus creates a new domain event bus
+func NewDomainEventBus() *DomainEventBus {
+	return &DomainEventBus{
+		handlers: make(map[models.DomainEventType][]DomainEventHandler),
+		now:      time.Now,
+	}
+}
+
+// Subscribe registers a handler for domain events of the given type
+func (b *DomainEventBus) Subscribe(eventType models.DomainEventType, handler DomainEventHandler) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.handlers[eventType] = append(b.handlers[eventType], handler)
+}
+
+// OnOrderCompleted subscribes a hook to completed orders
+func (b *DomainEventBus) OnOrderCompleted(hook OrderCompletionHook) {
+	b.Subscribe(models.DomainOrderCompleted, func(event *models.DomainEvent) {
+		hook.OrderCompleted(event.Order)
+	})
+}
+
+// OnRefundIssued subscribes a hook to refunded orders
+func (b *DomainEventBus) OnRefundIssued(hook OrderRefundHook) {
+	b.Subscribe(models.DomainRefundIssued, func(event *models.DomainEvent) {
+		hook.OrderRefunded(event.Order)
+	})
+}
+
+// OnEventPublished subscribes a hook to events going live
+func (b *DomainEventBus) OnEventPublished(hook EventPublishedHook) {
+	b.Subscribe(models.DomainEventPublished, func(event *models.DomainEvent) {
+		hook.EventPublished(event.Event)
+	})
+}
+
+// OnTicketCheckedIn subscribes a hook to admitted ticket holders
+func (b *DomainEventBus) OnTicketCheckedIn(hook TicketCheckInHook) {
+	b.Subscribe(models.DomainTicketCheckedIn, func(event *models.DomainEvent) {
+		hook.TicketCheckedIn(event.Scan)
+	})
+}
+
+// Publish delivers a domain event to its subscribers. Publishing on a nil bus
+// does nothing, so services work without one.
+func (b *DomainEventBus) Publish(event *models.DomainEvent) {
+	if b == nil {
+		return
+	}
+	if event.OccurredAt.IsZero() {
+		event.OccurredAt = b.now()
+	}
+
+	b.mu.RLock()
+	handlers := b.handlers[event.Type]
+	b.mu.RUnlock()
+
+	for _, handler := range handlers {
+		b.deliver(handler, event)
+	}
+}
+
+// deliver runs one handler, recovering from its panics
+func (b *DomainEventBus) deliver(handler DomainEventHandler, event *models.DomainEvent) {
+	defer func() {
+		if r := recover(); r != nil {
+			fmt.Printf("Warning: %s handler for event %d panicked: %v\n", event.Type, event.EventID(), r)
+		}
+	}()
+	handler(event)
+}
+
+// PublishOrderCompleted publishes an order.completed domain event
+func (b *DomainEventBus) PublishOrderCompleted(order *models.Order) {
+	b.Publish(&models.DomainEvent{Type: models.DomainOrderCompleted, Order: order})
+}
+
+// PublishRefundIssued publishes a refund.issued domain event
+func (b *DomainEventBus) PublishRefundIssued(order *models.Order) {
+	b.Publish(&models.DomainEvent{Type: models.DomainRefundIssued, Order: order})
+}
+
+// PublishEventPublished publishes an event.published domain event
+func (b *DomainEventBus) PublishEventPublished(event *models.Event) {
+	b.Publish(&models.DomainEvent{Type: models.DomainEventPublished, Event: event})
+}
+
+// PublishTicketCheckedIn publishes a ticket.checked_in domain event
+func (b *DomainEventBus) PublishTicketCheckedIn(scan *models.TicketScan) {
+	b.Publish(&models.DomainEvent{Type: models.DomainTicketCheckedIn, Scan: scan})
+}
@@ -0,0 +1,80 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Records the orders it is notified about
+type recordingOrderHook struct {
+	completed, refunded []int
+}
+
+func (h *recordingOrderHook) OrderCompleted(order *models.Order) {
+	h.completed = append(h.completed, order.ID)
+}
+
+func (h *recordingOrderHook) OrderRefunded(order *models.Order) {
+	h.refunded = append(h.refunded, order.ID)
+}
+
+func TestDomainEventBus_Publish(t *testing.T) {
+	now := time.Date(2025, 6, 4, 12, 0, 0, 0, time.UTC)
+	bus := NewDomainEventBus()
+	bus.now = func() time.Time { return now }
+
+	var delivered []string
+	bus.Subscribe(models.DomainOrderCompleted, func(event *models.DomainEvent) {
+		delivered = append(delivered, "first")
+		if !event.OccurredAt.Equal(now) {
+			t.Errorf("expected the event to be timestamped, got %v", event.OccurredAt)
+		}
+	})
+	bus.Subscribe(models.DomainOrderCompleted, func(event *models.DomainEvent) {
+		panic("subscriber failed")
+	})
+	bus.Subscribe(models.DomainOrderCompleted, func(event *models.DomainEvent) {
+		delivered = append(delivered, "third")
+	})
+
+	hook := &recordingOrderHook{}
+	bus.OnOrderCompleted(hook)
+	bus.OnRefundIssued(hook)
+
+	bus.PublishOrderCompleted(&models.Order{ID: 1, EventID: 10})
+	bus.PublishRefundIssued(&models.Order{ID: 2, EventID: 10})
+
+	if len(delivered) != 2 || delivered[0] != "first" || delivered[1] != "third" {
+		t.Errorf("expected subscribers in order despite the panic, got %v", delivered)
+	}
+	if len(hook.completed) != 1 || hook.completed[0] != 1 || len(hook.refunded) != 1 || hook.refunded[0] != 2 {
+		t.Errorf("expected each hook to get its own event type, got %v and %v", hook.completed, hook.refunded)
+	}
+
+	// Services without a bus publish nowhere
+	var none *DomainEventBus
+	none.PublishOrderCompleted(&models.Order{ID: 3})
+}
+
+func TestTicketScanService_PublishesCheckIns(t *testing.T) {
+	service, _, _ := setupTicketScanService()
+	bus := NewDomainEventBus()
+	service.SetEventBus(bus)
+
+	var checkedIn []*models.TicketScan
+	bus.Subscribe(models.DomainTicketCheckedIn, func(event *models.DomainEvent) {
+		checkedIn = append(checkedIn, event.Scan)
+	})
+
+	for i := 0; i < 2; i++ {
+		if _, err := service.ScanTicket(&ScanRequest{EventID: 1, QRCode: "QR-ACTIVE", StaffUserID: 7}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+
+	if len(checkedIn) != 1 || !checkedIn[0].IsAccepted() {
+		t.Errorf("expected only the accepted scan to be published, got %d", len(checkedIn))
+	}
+}
@@ -0,0 +1,67 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// DonationRepositoryInterface defines the data operations for donation settings
+type DonationRepositoryInterface interface {
+	GetSettings(eventID int) (*models.DonationSettings, error)
+	SaveSettings(settings *models.DonationSettings) error
+}
+
+// DonationService lets organizers ask buyers for a donation at checkout, on
+// top of the tickets they buy
+type DonationService struct {
+	repo DonationRepositoryInterface
+}
+
+// NewDonationService creates a new donation service
+func NewDonationService(repo DonationRepositoryInterface) *DonationService {
+	return &DonationService{repo: repo}
+}
+
+// GetSettings retrieves an event's donation settings
+func (s *DonationService) GetSettings(eventID int) (*models.DonationSettings, error) {
+	return s.repo.GetSettings(eventID)
+}
+
+// UpdateSettings validates and saves an event's donation settings
+func (s *DonationService) UpdateSettings(settings *models.DonationSettings) error {
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+	return s.repo.SaveSettings(settings)
+}
+
+// CheckoutSettings returns the donation settings shown at checkout for an
+// event, or nil if it doesn't ask for donations
+func (s *DonationService) CheckoutSettings(eventID int) *models.DonationSettings {
+	settings, err := s.repo.GetSettings(eventID)
+	if err != nil {
+		fmt.Printf("Warning: failed to get donation settings for event %d: %v\n", eventID, err)
+		return nil
+	}
+	if !settings.Enabled {
+		return nil
+	}
+	return settings
+}
+
+// ChooseDonation checks the amount a buyer offered to donate with their
+// order for an event, returning the amount to add to it
+func (s *DonationService) ChooseDonation(eventID, amount int) (int, error) {
+	if amount == 0 {
+		return 0, nil
+	}
+	if err := models.ValidateDonation(amount); err != nil {
+		return 0, err
+	}
+	if s.CheckoutSettings(eventID) == nil {
+		return 0, errors.New("this event doesn't accept donations")
+	}
+	return amount, nil
+}
@@ -0,0 +1,69 @@
+package services
+
+import (
+	"testing"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// memoryDonationRepository keeps donation settings in memory
+type memoryDonationRepository struct {
+	settings map[int]*models.DonationSettings
+}
+
+func (m *memoryDonationRepository) GetSettings(eventID int) (*models.DonationSettings, error) {
+	if settings, ok := m.settings[eventID]; ok {
+		copied := *settings
+		return &copied, nil
+	}
+	return &models.DonationSettings{EventID: eventID}, nil
+}
+
+func (m *memoryDonationRepository) SaveSettings(settings *models.DonationSettings) error {
+	saved := *settings
+	m.settings[settings.EventID] = &saved
+	return nil
+}
+
+func TestDonationService_ChooseDonation(t *testing.T) {
+	service := NewDonationService(&memoryDonationRepository{settings: make(map[int]*models.DonationSettings)})
+	if err := service.UpdateSettings(&models.DonationSettings{EventID: 1, Enabled: true, Message: "Support the choir"}); err != nil {
+		t.Fatalf("UpdateSettings() error = %v", err)
+	}
+
+	if amount, err := service.ChooseDonation(1, 5000); err != nil || amount != 5000 {
+		t.Errorf("ChooseDonation(1, 5000) = %d, %v, want 5000, nil", amount, err)
+	}
+	if _, err := service.ChooseDonation(1, -100); err == nil {
+		t.Error("ChooseDonation() accepted a negative donation")
+	}
+	if _, err := service.ChooseDonation(1, models.MaxDonationAmount+1); err == nil {
+		t.Error("ChooseDonation() accepted a donation over the limit")
+	}
+
+	// Events that don't ask for donations only accept none
+	if amount, err := service.ChooseDonation(2, 0); err != nil || amount != 0 {
+		t.Errorf("ChooseDonation(2, 0) = %d, %v, want 0, nil", amount, err)
+	}
+	if _, err := service.ChooseDonation(2, 5000); err == nil {
+		t.Error("ChooseDonation() accepted a donation for an event without donations")
+	}
+	if settings := service.CheckoutSettings(2); settings != nil {
+		t.Errorf("CheckoutSettings(2) = %+v, want nil", settings)
+	}
+}
+
+func TestDonationService_UpdateSettings_Validates(t *testing.T) {
+	service := NewDonationService(&memoryDonationRepository{settings: make(map[int]*models.DonationSettings)})
+
+	long := make([]byte, models.MaxDonationMessageLength+1)
+	for i := range long {
+		long[i] = 'a'
+	}
+	if err := service.UpdateSettings(&models.DonationSettings{EventID: 1, Enabled: true, Message: string(long)}); err == nil {
+		t.Error("UpdateSettings() accepted a message over the limit")
+	}
+	if settings := service.CheckoutSettings(1); settings != nil {
+		t.Errorf("CheckoutSettings(1) = %+v, want nil after a rejected update", settings)
+	}
+}
@@ -0,0 +1,220 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// ErrEmailNotRetryable is returned when retrying an email that did not fail
+var ErrEmailNotRetryable = errors.New("only failed emails can be retried")
+
+// ErrInvalidWebhookSignature is returned for webhook requests that were not
+// signed with the webhook secret
+var ErrInvalidWebhookSignature = errors.New("invalid webhook signature")
+
+// webhookTolerance is how old a webhook request may be, so captured requests
+// can't be replayed later
+const webhookTolerance = 5 * time.Minute
+
+// EmailLogRepository defines the data operations for the outbound email log
+type EmailLogRepository interface {
+	GetByID(id int) (*models.EmailLog, error)
+	List(filter models.EmailLogFilter) ([]*models.EmailLog, int, error)
+	CountByStatus() (map[models.EmailStatus]int, error)
+	RecordAttempt(id int, status models.EmailStatus, provider, providerMessageID, errorMessage string) error
+	UpdateStatusByMessageID(providerMessageID string, status models.EmailStatus, detail string, from []models.EmailStatus) (bool, error)
+}
+
+// EmailRedeliverer sends a logged email again
+type EmailRedeliverer interface {
+	Redeliver(request json.RawMessage) (*EmailReceipt, error)
+}
+
+// EmailLogService lets admins follow the delivery of outbound emails and
+// retry failed sends, and applies the delivery events the email provider
+// reports through its webhooks
+type EmailLogService struct {
+	repo          EmailLogRepository
+	sender        EmailRedeliverer
+	webhookSecret string
+	auditService  *AuditService
+	now           func() time.Time
+}
+
+// NewEmailLogService creates a new email log service. Webhooks are rejected
+// when webhookSecret is empty.
+func NewEmailLogService(repo EmailLogRepository, sender EmailRedeliverer, webhookSecret string) *EmailLogService {
+	return &EmailLogService{
+		repo:          repo,
+		sender:        sender,
+		webhookSecret: webhookSecret,
+		now:           time.Now,
+	}
+}
+
+// SetAuditService records retries in the audit log
+func (s *EmailLogService) SetAuditService(auditService *AuditService) {
+	s.auditService = auditService
+}
+
+// List returns the logged emails matching the filter and how many match
+func (s *EmailLogService) List(filter models.EmailLogFilter) ([]*models.EmailLog, int, error) {
+	entries, total, err := s.repo.List(filter)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to list emails: %w", err)
+	}
+	return entries, total, nil
+}
+
+// CountByStatus returns how many logged emails have each status
+func (s *EmailLogService) CountByStatus() (map[models.EmailStatus]int, error) {
+	return s.repo.CountByStatus()
+}
+
+// Retry sends a failed email again on behalf of an admin
+func (s *EmailLogService) Retry(adminID, id int, r *http.Request) error {
+	entry, err := s.repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+	if !entry.CanRetry() {
+		return ErrEmailNotRetryable
+	}
+
+	receipt, sendErr := s.sender.Redeliver(entry.Request)
+	status, errorMessage := models.EmailSent, ""
+	provider, messageID := "", ""
+	if sendErr != nil {
+		status, errorMessage = models.EmailFailed, sendErr.Error()
+	} else if receipt != nil {
+		provider, messageID = receipt.Provider, receipt.MessageID
+	}
+	if err := s.repo.RecordAttempt(entry.ID, status, provider, messageID, errorMessage); err != nil {
+		return err
+	}
+
+	if s.auditService != nil {
+		details := map[string]interface{}{
+			"recipient":  entry.Recipient,
+			"email_type": entry.EmailType,
+			"succeeded":  sendErr == nil,
+		}
+		if err := s.auditService.LogAction(adminID, models.AuditActionEmailRetry, models.AuditTargetEmail, entry.ID, details, r); err != nil {
+			fmt.Printf("Warning: failed to log retry of email %d: %v\n", entry.ID, err)
+		}
+	}
+
+	if sendErr != nil {
+		return fmt.Errorf("retry failed: %w", sendErr)
+	}
+	return nil
+}
+
+// resendWebhookEvent is the part of a Resend webhook event the log uses
+type resendWebhookEvent struct {
+	Type string `json:"type"`
+	Data struct {
+		EmailID string `json:"email_id"`
+		Bounce  struct {
+			Message string `json:"message"`
+		} `json:"bounce"`
+	} `json:"data"`
+}
+
+// HandleResendWebhook verifies a Resend webhook request and applies the
+// delivery event it reports to the logged email
+func (s *EmailLogService) HandleResendWebhook(header http.Header, body []byte) error {
+	if err := s.verifyWebhookSignature(header, body); err != nil {
+		return err
+	}
+
+	var event resendWebhookEvent
+	if err := json.Unmarshal(body, &event); err != nil {
+		return fmt.Errorf("failed to decode webhook event: %w", err)
+	}
+	if event.Data.EmailID == "" {
+		return nil
+	}
+
+	var status models.EmailStatus
+	var from []models.EmailStatus
+	detail := ""
+	switch event.Type {
+	case "email.delivery_delayed":
+		status = models.EmailDelayed
+		from = []models.EmailStatus{models.EmailSent}
+	case "email.delivered":
+		status = models.EmailDelivered
+		from = []models.EmailStatus{models.EmailSent, models.EmailDelayed}
+	case "email.bounced":
+		status = models.EmailBounced
+		from = []models.EmailStatus{models.EmailSent, models.EmailDelayed, models.EmailDelivered}
+		detail = event.Data.Bounce.Message
+	case "email.complained":
+		status = models.EmailComplained
+		from = []models.EmailStatus{models.EmailSent, models.EmailDelayed, models.EmailDelivered, models.EmailBounced}
+	default:
+		// Opens, clicks and the like don't change the delivery status
+		return nil
+	}
+
+	if _, err := s.repo.UpdateStatusByMessageID(event.Data.EmailID, status, detail, from); err != nil {
+		return err
+	}
+	return nil
+}
+
+// verifyWebhookSignature checks the Svix signature Resend signs its webhook
+// requests with: an HMAC-SHA256 of the message ID, timestamp and body, keyed
+// with the base64 part of the "whsec_" secret
+func (s *EmailLogService) verifyWebhookSignature(header http.Header, body []byte) error {
+	if s.webhookSecret == "" {
+		return ErrInvalidWebhookSignature
+	}
+
+	id := header.Get("svix-id")
+	timestamp := header.Get("svix-timestamp")
+	signatures := header.Get("svix-signature")
+	if id == "" || timestamp == "" || signatures == "" {
+		return ErrInvalidWebhookSignature
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidWebhookSignature
+	}
+	sentAt := time.Unix(seconds, 0)
+	if age := s.now().Sub(sentAt); age > webhookTolerance || age < -webhookTolerance {
+		return ErrInvalidWebhookSignature
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(s.webhookSecret, "whsec_"))
+	if err != nil {
+		return fmt.Errorf("invalid webhook secret: %w", err)
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(id + "." + timestamp + "."))
+	mac.Write(body)
+	expected := base64.StdEncoding.EncodeToString(mac.Sum(nil))
+
+	// The header lists space separated "version,signature" pairs, one per
+	// active secret
+	for _, signature := range strings.Fields(signatures) {
+		version, value, ok := strings.Cut(signature, ",")
+		if ok && version == "v1" && hmac.Equal([]byte(value), []byte(expected)) {
+			return nil
+		}
+	}
+	return ErrInvalidWebhookSignature
+}
@@ -0,0 +1,239 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock EmailLogRepository for testing
+type mockEmailLogRepository struct {
+	entries map[int]*models.EmailLog
+}
+
+func (m *mockEmailLogRepository) GetByID(id int) (*models.EmailLog, error) {
+	entry, ok := m.entries[id]
+	if !ok {
+		return nil, fmt.Errorf("email log entry not found")
+	}
+	return entry, nil
+}
+
+func (m *mockEmailLogRepository) List(filter models.EmailLogFilter) ([]*models.EmailLog, int, error) {
+	var result []*models.EmailLog
+	for _, entry := range m.entries {
+		if filter.Status == "" || entry.Status == filter.Status {
+			result = append(result, entry)
+		}
+	}
+	return result, len(result), nil
+}
+
+func (m *mockEmailLogRepository) CountByStatus() (map[models.EmailStatus]int, error) {
+	counts := make(map[models.EmailStatus]int)
+	for _, entry := range m.entries {
+		counts[entry.Status]++
+	}
+	return counts, nil
+}
+
+func (m *mockEmailLogRepository) RecordAttempt(id int, status models.EmailStatus, provider, providerMessageID, errorMessage string) error {
+	entry := m.entries[id]
+	entry.Status = status
+	if provider != "" {
+		entry.Provider = provider
+	}
+	if providerMessageID != "" {
+		entry.ProviderMessageID = providerMessageID
+	}
+	entry.Error = errorMessage
+	entry.Attempts++
+	return nil
+}
+
+func (m *mockEmailLogRepository) UpdateStatusByMessageID(providerMessageID string, status models.EmailStatus, detail string, from []models.EmailStatus) (bool, error) {
+	for _, entry := range m.entries {
+		if entry.ProviderMessageID != providerMessageID {
+			continue
+		}
+		for _, s := range from {
+			if entry.Status == s {
+				entry.Status = status
+				if detail != "" {
+					entry.Error = detail
+				}
+				return true, nil
+			}
+		}
+	}
+	return false, nil
+}
+
+// Mock EmailRedeliverer for testing
+type mockEmailRedeliverer struct {
+	err       error
+	delivered []json.RawMessage
+}
+
+func (m *mockEmailRedeliverer) Redeliver(request json.RawMessage) (*EmailReceipt, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.delivered = append(m.delivered, request)
+	return &EmailReceipt{Provider: "smtp", MessageID: fmt.Sprintf("msg-retry-%d", len(m.delivered))}, nil
+}
+
+const testWebhookSecret = "whsec_dGVzdC13ZWJob29rLXNlY3JldA=="
+
+func signWebhook(t *testing.T, id string, sentAt time.Time, body []byte) http.Header {
+	t.Helper()
+	key, err := base64.StdEncoding.DecodeString("dGVzdC13ZWJob29rLXNlY3JldA==")
+	if err != nil {
+		t.Fatal(err)
+	}
+	timestamp := fmt.Sprint(sentAt.Unix())
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(id + "." + timestamp + "." + string(body)))
+
+	header := http.Header{}
+	header.Set("svix-id", id)
+	header.Set("svix-timestamp", timestamp)
+	header.Set("svix-signature", "v1,bm90LXRoZS1zaWduYXR1cmU= v1,"+base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+	return header
+}
+
+func newTestEmailLogService(entries ...*models.EmailLog) (*EmailLogService, *mockEmailLogRepository, *mockEmailRedeliverer) {
+	repo := &mockEmailLogRepository{entries: make(map[int]*models.EmailLog)}
+	for _, entry := range entries {
+		repo.entries[entry.ID] = entry
+	}
+	sender := &mockEmailRedeliverer{}
+	return NewEmailLogService(repo, sender, testWebhookSecret), repo, sender
+}
+
+func TestEmailLogService_Retry(t *testing.T) {
+	service, repo, sender := newTestEmailLogService(
+		&models.EmailLog{ID: 1, Status: models.EmailFailed, Provider: "resend", Attempts: 1, Error: "rate limited", Request: json.RawMessage(`{"to":["a@example.com"]}`)},
+		&models.EmailLog{ID: 2, Status: models.EmailBounced, Attempts: 1, Request: json.RawMessage(`{}`)},
+	)
+	r := httptest.NewRequest("POST", "/admin/emails/1/retry", nil)
+
+	if err := service.Retry(1, 1, r); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	entry := repo.entries[1]
+	if entry.Status != models.EmailSent || entry.Attempts != 2 || entry.Error != "" || entry.ProviderMessageID != "msg-retry-1" || entry.Provider != "smtp" {
+		t.Errorf("expected the retried email to be sent, got %+v", entry)
+	}
+	if len(sender.delivered) != 1 || string(sender.delivered[0]) != `{"to":["a@example.com"]}` {
+		t.Errorf("expected the logged request to be sent again, got %s", sender.delivered)
+	}
+
+	if err := service.Retry(1, 1, r); !errors.Is(err, ErrEmailNotRetryable) {
+		t.Errorf("expected ErrEmailNotRetryable retrying a sent email, got %v", err)
+	}
+	if err := service.Retry(1, 2, r); !errors.Is(err, ErrEmailNotRetryable) {
+		t.Errorf("expected ErrEmailNotRetryable retrying a bounced email, got %v", err)
+	}
+}
+
+func TestEmailLogService_RetryFails(t *testing.T) {
+	service, repo, sender := newTestEmailLogService(
+		&models.EmailLog{ID: 1, Status: models.EmailFailed, Attempts: 1, Request: json.RawMessage(`{}`)},
+	)
+	sender.err = errors.New("failed to send email: invalid from address")
+
+	if err := service.Retry(1, 1, httptest.NewRequest("POST", "/admin/emails/1/retry", nil)); err == nil {
+		t.Fatal("expected an error when the retry fails")
+	}
+	entry := repo.entries[1]
+	if entry.Status != models.EmailFailed || entry.Attempts != 2 || entry.Error != sender.err.Error() {
+		t.Errorf("expected the failed attempt to be recorded, got %+v", entry)
+	}
+}
+
+func TestEmailLogService_HandleResendWebhook(t *testing.T) {
+	service, repo, _ := newTestEmailLogService(
+		&models.EmailLog{ID: 1, Status: models.EmailSent, ProviderMessageID: "msg-1"},
+	)
+	now := time.Now()
+	service.now = func() time.Time { return now }
+
+	send := func(event string) error {
+		body := []byte(event)
+		return service.HandleResendWebhook(signWebhook(t, "evt_1", now, body), body)
+	}
+
+	if err := send(`{"type":"email.bounced","data":{"email_id":"msg-1","bounce":{"message":"Mailbox does not exist"}}}`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entry := repo.entries[1]; entry.Status != models.EmailBounced || entry.Error != "Mailbox does not exist" {
+		t.Errorf("expected the email to be marked bounced, got %+v", entry)
+	}
+
+	// A delivery event arriving after the bounce doesn't undo it
+	if err := send(`{"type":"email.delivered","data":{"email_id":"msg-1"}}`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entry := repo.entries[1]; entry.Status != models.EmailBounced {
+		t.Errorf("expected the email to stay bounced, got %s", entry.Status)
+	}
+
+	if err := send(`{"type":"email.complained","data":{"email_id":"msg-1"}}`); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if entry := repo.entries[1]; entry.Status != models.EmailComplained {
+		t.Errorf("expected the email to be marked complained, got %s", entry.Status)
+	}
+
+	// Events that don't change the delivery status, or for unknown emails, are ignored
+	if err := send(`{"type":"email.opened","data":{"email_id":"msg-1"}}`); err != nil {
+		t.Errorf("unexpected error for an ignored event: %v", err)
+	}
+	if err := send(`{"type":"email.delivered","data":{"email_id":"msg-unknown"}}`); err != nil {
+		t.Errorf("unexpected error for an unknown email: %v", err)
+	}
+}
+
+func TestEmailLogService_HandleResendWebhook_RejectsBadSignatures(t *testing.T) {
+	now := time.Now()
+	body := []byte(`{"type":"email.bounced","data":{"email_id":"msg-1"}}`)
+
+	tests := []struct {
+		name   string
+		secret string
+		header http.Header
+		body   []byte
+	}{
+		{name: "missing headers", secret: testWebhookSecret, header: http.Header{}, body: body},
+		{name: "tampered body", secret: testWebhookSecret, header: signWebhook(t, "evt_1", now, body), body: []byte(`{"type":"email.complained","data":{"email_id":"msg-1"}}`)},
+		{name: "stale timestamp", secret: testWebhookSecret, header: signWebhook(t, "evt_1", now.Add(-10*time.Minute), body), body: body},
+		{name: "no secret configured", secret: "", header: signWebhook(t, "evt_1", now, body), body: body},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			service, repo, _ := newTestEmailLogService(
+				&models.EmailLog{ID: 1, Status: models.EmailSent, ProviderMessageID: "msg-1"},
+			)
+			service.webhookSecret = tt.secret
+			service.now = func() time.Time { return now }
+
+			if err := service.HandleResendWebhook(tt.header, tt.body); !errors.Is(err, ErrInvalidWebhookSignature) {
+				t.Errorf("expected ErrInvalidWebhookSignature, got %v", err)
+			}
+			if repo.entries[1].Status != models.EmailSent {
+				t.Errorf("expected the email to be unchanged, got %s", repo.entries[1].Status)
+			}
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+)
+
+// Email provider names, as configured with EMAIL_PROVIDER
+const (
+	EmailProviderResend = "resend"
+	EmailProviderSMTP   = "smtp"
+	EmailProviderSES    = "ses"
+)
+
+// failoverCooldown is how long emails go straight to the secondary provider
+// after the primary fails, before the primary is tried again
+const failoverCooldown = time.Minute
+
+// EmailMessage is an email ready to hand to a provider. Its JSON form is
+// what the email log keeps for retries.
+type EmailMessage struct {
+	From    string            `json:"from"`
+	To      []string          `json:"to"`
+	Subject string            `json:"subject"`
+	HTML    string            `json:"html,omitempty"`
+	Text    string            `json:"text,omitempty"`
+	Headers map[string]string `json:"headers,omitempty"`
+	Tags    []EmailTag        `json:"tags,omitempty"`
+}
+
+// EmailTag categorizes an email for the provider's reporting
+type EmailTag struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// EmailReceipt identifies a sent email at the provider that accepted it
+type EmailReceipt struct {
+	Provider  string
+	MessageID string
+}
+
+// EmailProvider delivers composed emails
+type EmailProvider interface {
+	Name() string
+	Send(message *EmailMessage) (*EmailReceipt, error)
+}
+
+// emailConnectionTester is implemented by providers that can check their
+// credentials without sending an email
+type emailConnectionTester interface {
+	TestConnection() error
+}
+
+// EmailProviderConfigs holds the settings of every provider that can be
+// selected
+type EmailProviderConfigs struct {
+	Resend ResendConfig
+	SMTP   SMTPConfig
+	SES    SESConfig
+}
+
+// NewEmailProvider creates the named provider
+func NewEmailProvider(name string, configs EmailProviderConfigs) (EmailProvider, error) {
+	switch name {
+	case EmailProviderResend:
+		return NewResendProvider(configs.Resend), nil
+	case EmailProviderSMTP:
+		return NewSMTPProvider(configs.SMTP), nil
+	case EmailProviderSES:
+		return NewSESProvider(configs.SES), nil
+	}
+	return nil, fmt.Errorf("unknown email provider %q", name)
+}
+
+// FailoverEmailProvider sends through a primary provider and falls back to a
+// secondary one when the primary fails. After a failure the primary is
+// skipped for a cooldown, so an outage doesn't slow every email down.
+type FailoverEmailProvider struct {
+	primary   EmailProvider
+	secondary EmailProvider
+	now       func() time.Time
+
+	mu          sync.Mutex
+	skipPrimary time.Time // The primary is skipped until then
+}
+
+// NewFailoverEmailProvider creates a provider that fails over from primary
+// to secondary
+func NewFailoverEmailProvider(primary, secondary EmailProvider) *FailoverEmailProvider {
+	return &FailoverEmailProvider{primary: primary, secondary: secondary, now: time.Now}
+}
+
+// Name returns the name of the primary provider
+func (p *FailoverEmailProvider) Name() string {
+	return p.primary.Name()
+}
+
+// Send sends the email through the primary provider, or the secondary when
+// the primary fails or is cooling down after a failure
+func (p *FailoverEmailProvider) Send(message *EmailMessage) (*EmailReceipt, error) {
+	var primaryErr error
+	if p.primaryAvailable() {
+		receipt, err := p.primary.Send(message)
+		if err == nil {
+			return receipt, nil
+		}
+		primaryErr = err
+		p.markPrimaryFailed()
+		slog.Warn("email provider failed, failing over", "provider", p.primary.Name(), "fallback", p.secondary.Name(), "error", err)
+	}
+
+	receipt, err := p.secondary.Send(message)
+	if err != nil {
+		if primaryErr != nil {
+			return nil, errors.Join(primaryErr, fmt.Errorf("%s: %w", p.secondary.Name(), err))
+		}
+		return nil, err
+	}
+	return receipt, nil
+}
+
+// TestConnection tests the primary provider's connection
+func (p *FailoverEmailProvider) TestConnection() error {
+	if tester, ok := p.primary.(emailConnectionTester); ok {
+		return tester.TestConnection()
+	}
+	return nil
+}
+
+func (p *FailoverEmailProvider) primaryAvailable() bool {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return !p.now().Before(p.skipPrimary)
+}
+
+func (p *FailoverEmailProvider) markPrimaryFailed() {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.skipPrimary = p.now().Add(failoverCooldown)
+}
@@ -0,0 +1,129 @@
+package services
+
+import (
+	"errors"
+	"mime"
+	"mime/multipart"
+	"net/mail"
+	"strings"
+	"testing"
+	"time"
+)
+
+// Fake EmailProvider for testing
+type fakeEmailProvider struct {
+	name string
+	err  error
+	sent int
+}
+
+func (p *fakeEmailProvider) Name() string {
+	return p.name
+}
+
+func (p *fakeEmailProvider) Send(message *EmailMessage) (*EmailReceipt, error) {
+	p.sent++
+	if p.err != nil {
+		return nil, p.err
+	}
+	return &EmailReceipt{Provider: p.name, MessageID: p.name + "-1"}, nil
+}
+
+func TestFailoverEmailProvider_Send(t *testing.T) {
+	primary := &fakeEmailProvider{name: "resend"}
+	secondary := &fakeEmailProvider{name: "smtp"}
+	provider := NewFailoverEmailProvider(primary, secondary)
+	now := time.Now()
+	provider.now = func() time.Time { return now }
+	message := &EmailMessage{To: []string{"a@example.com"}, Subject: "Hello", Text: "Hi"}
+
+	receipt, err := provider.Send(message)
+	if err != nil || receipt.Provider != "resend" || secondary.sent != 0 {
+		t.Fatalf("expected the primary to send, got %+v, %v", receipt, err)
+	}
+
+	primary.err = errors.New("rate limited")
+	receipt, err = provider.Send(message)
+	if err != nil || receipt.Provider != "smtp" {
+		t.Fatalf("expected the secondary to take over, got %+v, %v", receipt, err)
+	}
+
+	// The primary is skipped while it cools down
+	primary.err = nil
+	if receipt, _ := provider.Send(message); receipt.Provider != "smtp" || primary.sent != 2 {
+		t.Errorf("expected the primary to be skipped, got %s after %d primary sends", receipt.Provider, primary.sent)
+	}
+
+	now = now.Add(failoverCooldown)
+	if receipt, _ := provider.Send(message); receipt.Provider != "resend" {
+		t.Errorf("expected the primary to be tried again after the cooldown, got %s", receipt.Provider)
+	}
+}
+
+func TestFailoverEmailProvider_SendBothFail(t *testing.T) {
+	primaryErr := errors.New("rate limited")
+	secondaryErr := errors.New("connection refused")
+	provider := NewFailoverEmailProvider(&fakeEmailProvider{name: "resend", err: primaryErr}, &fakeEmailProvider{name: "smtp", err: secondaryErr})
+
+	_, err := provider.Send(&EmailMessage{To: []string{"a@example.com"}})
+	if !errors.Is(err, primaryErr) || !errors.Is(err, secondaryErr) {
+		t.Errorf("expected both providers' errors, got %v", err)
+	}
+}
+
+func TestNewEmailProvider(t *testing.T) {
+	for _, name := range []string{EmailProviderResend, EmailProviderSMTP, EmailProviderSES} {
+		provider, err := NewEmailProvider(name, EmailProviderConfigs{})
+		if err != nil || provider.Name() != name {
+			t.Errorf("expected a %s provider, got %v, %v", name, provider, err)
+		}
+	}
+	if _, err := NewEmailProvider("sendgrid", EmailProviderConfigs{}); err == nil {
+		t.Error("expected an error for an unknown provider")
+	}
+}
+
+func TestBuildMIMEMessage(t *testing.T) {
+	message := &EmailMessage{
+		From:    "Event Tickets <noreply@example.com>",
+		To:      []string{"a@example.com"},
+		Subject: "Tikiti zako – Nairobi",
+		HTML:    "<p>Your tickets</p>",
+		Text:    "Your tickets",
+		Headers: map[string]string{"List-Unsubscribe": "<https://example.com/unsubscribe>"},
+	}
+
+	data, err := buildMIMEMessage(message, "<id@example.com>", time.Date(2026, 3, 1, 9, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	parsed, err := mail.ReadMessage(strings.NewReader(string(data)))
+	if err != nil {
+		t.Fatalf("expected a valid message: %v", err)
+	}
+
+	subject, _ := new(mime.WordDecoder).DecodeHeader(parsed.Header.Get("Subject"))
+	if subject != message.Subject || parsed.Header.Get("Message-Id") != "<id@example.com>" {
+		t.Errorf("unexpected headers: %v", parsed.Header)
+	}
+	if parsed.Header.Get("List-Unsubscribe") != "<https://example.com/unsubscribe>" {
+		t.Errorf("expected the custom header, got %v", parsed.Header)
+	}
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	if err != nil || mediaType != "multipart/alternative" {
+		t.Fatalf("expected multipart/alternative, got %s", parsed.Header.Get("Content-Type"))
+	}
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	var types []string
+	for {
+		part, err := reader.NextPart()
+		if err != nil {
+			break
+		}
+		types = append(types, part.Header.Get("Content-Type"))
+	}
+	if len(types) != 2 || !strings.HasPrefix(types[0], "text/plain") || !strings.HasPrefix(types[1], "text/html") {
+		t.Errorf("expected plain text then HTML parts, got %v", types)
+	}
+}
@@ -1,15 +1,25 @@
 package services
 
 import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
+	"image"
+	_ "image/gif"
+	_ "image/jpeg"
+	_ "image/png"
 	"io"
 	"mime/multipart"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"strings"
 	"time"
 
+	"event-ticketing-platform/internal/cache"
 	"event-ticketing-platform/internal/models"
 	"event-ticketing-platform/internal/repositories"
 )
@@ -18,6 +28,7 @@ import (
 type EventRepository interface {
 	Create(req *models.EventCreateRequest, organizerID int) (*models.Event, error)
 	GetByID(id int) (*models.Event, error)
+	GetBySlug(slug string) (*models.Event, error)
 	Update(id int, req *models.EventUpdateRequest, organizerID int) (*models.Event, error)
 	Delete(id int, organizerID int) error
 	GetByOrganizer(organizerID int) ([]*models.Event, error)
@@ -27,17 +38,41 @@ type EventRepository interface {
 	GetEventsByCategory(categoryID int, limit, offset int) ([]*models.Event, int, error)
 	GetFeaturedEvents(limit int) ([]*models.Event, error)
 	GetCategories() ([]*models.Category, error)
+	GetSitemapEvents() ([]*models.Event, error)
 	
 	// Admin-specific methods
 	GetEventCount() (int, error)
 	GetPublishedEventCount() (int, error)
 }
 
+// Cache settings for public event reads
+const (
+	eventCachePrefix    = "events:"
+	eventListCacheTTL   = 2 * time.Minute
+	eventSearchCacheTTL = time.Minute
+	categoriesCacheTTL  = 30 * time.Minute
+)
+
 // EventService handles event-related business logic
 type EventService struct {
 	eventRepo   EventRepository
 	authService *AuthService
 	uploadPath  string
+	cache       cache.Cache
+
+	changeHooks   []EventChangeHook
+	events        *DomainEventBus
+	contentScreen *ContentScreenService
+	reputation    *OrganizerReputationService
+	team          TeamAccessChecker
+	imageService  ImageServiceInterface
+	geocoding     *GeocodingService
+}
+
+// EventChangeHook is notified after events are created, updated, published
+// or deleted
+type EventChangeHook interface {
+	EventsChanged()
 }
 
 // NewEventService creates a new event service
@@ -49,6 +84,168 @@ func NewEventService(eventRepo EventRepository, authService *AuthService, upload
 	}
 }
 
+// SetCache enables caching of public event reads. Without a cache every read
+// goes to the repository.
+func (s *EventService) SetCache(c cache.Cache) {
+	s.cache = c
+}
+
+// InvalidateCache drops all cached event reads after events change
+func (s *EventService) InvalidateCache() {
+	invalidateEventCache(s.cache)
+}
+
+// AddChangeHook registers a hook to run after events change
+func (s *EventService) AddChangeHook(hook EventChangeHook) {
+	s.changeHooks = append(s.changeHooks, hook)
+}
+
+// SetEventBus sets the bus events going live are published on
+func (s *EventService) SetEventBus(events *DomainEventBus) {
+	s.events = events
+}
+
+// SetContentScreen enables spam, phishing and image screening. Organizers'
+// events that would be published with suspicious content are held for review
+// instead.
+func (s *EventService) SetContentScreen(screen *ContentScreenService) {
+	s.contentScreen = screen
+}
+
+// screenForPublishing returns the status to save an event with, holding
+// suspicious content back for moderation. The screen result is returned when
+// the event was held so the reasons can be recorded once the event is saved.
+func (s *EventService) screenForPublishing(user *models.User, status models.EventStatus, title, description, imageURL string) (models.EventStatus, *ContentScreenResult) {
+	if s.contentScreen == nil || status != models.StatusPublished || user.Role == models.RoleAdmin {
+		return status, nil
+	}
+
+	result := s.contentScreen.ScreenEvent(title, description, imageURL)
+	if !result.Suspicious() {
+		return status, nil
+	}
+	return models.StatusPendingReview, result
+}
+
+// SetReputationService enables moderation of new and low-reputation
+// organizers. Their events are held for review when they would be published,
+// while organizers with a good track record publish straight away.
+func (s *EventService) SetReputationService(reputation *OrganizerReputationService) {
+	s.reputation = reputation
+}
+
+// SetImageService stores event images through the image service, which
+// generates the cropped thumbnail, card, hero and og variants public pages
+// serve in srcset. Without it images are saved to the upload path as is.
+func (s *EventService) SetImageService(imageService ImageServiceInterface) {
+	s.imageService = imageService
+}
+
+// SetTeamAccess lets organizers' team members work on their events with the
+// permissions they were granted
+func (s *EventService) SetTeamAccess(team TeamAccessChecker) {
+	s.team = team
+}
+
+// SetGeocoding geocodes event locations as events are saved, so they can be
+// found by distance and shown on the events map
+func (s *EventService) SetGeocoding(geocoding *GeocodingService) {
+	s.geocoding = geocoding
+}
+
+// geocode looks up the coordinates of a saved event whose location changed
+// from the previous one. Failures don't fail the save; the event is left for
+// the geocoding job to retry.
+func (s *EventService) geocode(event *models.Event, previousLocation string) {
+	if s.geocoding == nil || event.Location == previousLocation {
+		return
+	}
+	point, err := s.geocoding.GeocodeEvent(event.ID, event.Location)
+	if err != nil {
+		fmt.Printf("Warning: failed to geocode event %d: %v\n", event.ID, err)
+		return
+	}
+	event.Coordinates = point
+}
+
+// actsFor returns true if the user owns the event or has joined its
+// organizer's team with the given permission
+func (s *EventService) actsFor(event *models.Event, userID int, permission models.TeamPermission) bool {
+	if event.OrganizerID == userID {
+		return true
+	}
+	if s.team == nil {
+		return false
+	}
+
+	ok, err := s.team.HasAccess(event.OrganizerID, userID, permission)
+	if err != nil {
+		fmt.Printf("Warning: failed to check team access to event %d: %v\n", event.ID, err)
+		return false
+	}
+	return ok
+}
+
+// holdForModeration returns the status to save an event with, holding it for
+// review if it is being published for an organizer who needs moderation,
+// whether by the organizer or a team member. Events that are already
+// published stay published.
+func (s *EventService) holdForModeration(user *models.User, organizerID int, currentStatus, status models.EventStatus) models.EventStatus {
+	if s.reputation == nil || status != models.StatusPublished || currentStatus == models.StatusPublished || user.Role == models.RoleAdmin {
+		return status
+	}
+
+	requiresModeration, err := s.reputation.RequiresModeration(organizerID)
+	if err != nil {
+		fmt.Printf("Warning: failed to check organizer reputation: %v\n", err)
+	}
+	if requiresModeration {
+		return models.StatusPendingReview
+	}
+	return status
+}
+
+// recordContentFlag stores why an event was held for review
+func (s *EventService) recordContentFlag(eventID int, result *ContentScreenResult) {
+	if result == nil {
+		return
+	}
+	if err := s.contentScreen.RecordFlag(eventID, result); err != nil {
+		fmt.Printf("Warning: %v\n", err)
+	}
+}
+
+// eventsChanged drops cached event reads and notifies the change hooks
+func (s *EventService) eventsChanged() {
+	s.InvalidateCache()
+	notifyEventChangeHooks(s.changeHooks)
+}
+
+// publishIfLive publishes an event that went live with a change from the
+// previous status
+func (s *EventService) publishIfLive(event *models.Event, previousStatus models.EventStatus) {
+	if event.Status == models.StatusPublished && previousStatus != models.StatusPublished {
+		s.events.PublishEventPublished(event)
+	}
+}
+
+// notifyEventChangeHooks runs each hook after events change
+func notifyEventChangeHooks(hooks []EventChangeHook) {
+	for _, hook := range hooks {
+		hook.EventsChanged()
+	}
+}
+
+// invalidateEventCache drops all cached event reads from c
+func invalidateEventCache(c cache.Cache) {
+	if c == nil {
+		return
+	}
+	if err := c.DeletePrefix(eventCachePrefix); err != nil {
+		fmt.Printf("Warning: failed to invalidate event cache: %v\n", err)
+	}
+}
+
 // EventCreateRequest represents a request to create an event
 type EventCreateRequest struct {
 	Title       string                `json:"title"`
@@ -60,6 +257,7 @@ type EventCreateRequest struct {
 	Status      models.EventStatus    `json:"status"`
 	OrganizerID int                   `json:"organizer_id"`
 	Image       *multipart.FileHeader `json:"-"` // For image upload
+	ImageAltText string               `json:"image_alt_text"`
 }
 
 // EventUpdateRequest represents a request to update an event
@@ -73,31 +271,38 @@ type EventUpdateRequest struct {
 	Status      models.EventStatus    `json:"status"`
 	OrganizerID int                   `json:"organizer_id"`
 	Image       *multipart.FileHeader `json:"-"` // For image upload
+	ImageAltText string               `json:"image_alt_text"`
 }
 
 // EventSearchRequest represents a request to search events
 type EventSearchRequest struct {
 	Query      string             `json:"query"`
 	CategoryID int                `json:"category_id"`
+	OrganizerID int               `json:"organizer_id"`
 	Location   string             `json:"location"`
 	Status     models.EventStatus `json:"status"`
 	DateFrom   *time.Time         `json:"date_from"`
 	DateTo     *time.Time         `json:"date_to"`
+	StartFrom  *time.Time         `json:"start_from"`
+	StartBefore *time.Time        `json:"start_before"`
 	PriceMin   *int               `json:"price_min"`
 	PriceMax   *int               `json:"price_max"`
+	Near       *models.GeoPoint   `json:"near,omitempty"`
+	RadiusKm   int                `json:"radius_km,omitempty"`
 	Page       int                `json:"page"`
 	PageSize   int                `json:"page_size"`
-	SortBy     string             `json:"sort_by"`
+	SortBy     string             `json:"sort_by"` // "created_at", "start_date", "title", "relevance", "distance"
 	SortDesc   bool               `json:"sort_desc"`
 }
 
 // EventSearchResponse represents the response from event search
 type EventSearchResponse struct {
-	Events     []*models.Event `json:"events"`
-	Total      int             `json:"total"`
-	Page       int             `json:"page"`
-	PageSize   int             `json:"page_size"`
-	TotalPages int             `json:"total_pages"`
+	Events     []*models.Event         `json:"events"`
+	Total      int                     `json:"total"`
+	Page       int                     `json:"page"`
+	PageSize   int                     `json:"page_size"`
+	TotalPages int                     `json:"total_pages"`
+	Highlights map[int]*EventHighlight `json:"highlights,omitempty"` // Keyed by event ID, only set for text queries
 }
 
 // EventStatistics represents statistics for an event
@@ -129,23 +334,22 @@ func (s *EventService) CreateEvent(req *EventCreateRequest) (*models.Event, erro
 	}
 
 	// Handle image upload if provided
-	var imageURL, imageKey, imageFormat string
-	var imageSize int64
+	img := &uploadedImage{}
 	if req.Image != nil {
-		url, key, size, format, err := s.handleImageUpload(req.Image)
+		uploaded, err := s.handleImageUpload(req.Image)
 		if err != nil {
 			return nil, fmt.Errorf("failed to upload image: %w", err)
 		}
-		imageURL = url
-		imageKey = key
-		imageSize = size
-		imageFormat = format
+		img = uploaded
 	}
+	imageURL := img.URL
 
 	// Set default status if not provided
 	if req.Status == "" {
 		req.Status = models.StatusDraft
 	}
+	status, flag := s.screenForPublishing(organizer, req.Status, req.Title, req.Description, imageURL)
+	status = s.holdForModeration(organizer, organizer.ID, "", status)
 
 	// Create the event request for repository
 	createReq := &models.EventCreateRequest{
@@ -155,25 +359,29 @@ func (s *EventService) CreateEvent(req *EventCreateRequest) (*models.Event, erro
 		EndDate:     req.EndDate,
 		Location:    req.Location,
 		CategoryID:  req.CategoryID,
-		ImageURL:    imageURL,
-		ImageKey:    imageKey,
-		ImageSize:   imageSize,
-		ImageFormat: imageFormat,
-		ImageWidth:  800,  // Default width - TODO: Read actual dimensions
-		ImageHeight: 600,  // Default height - TODO: Read actual dimensions
-		Status:      req.Status,
+		ImageURL:    img.URL,
+		ImageKey:    img.Key,
+		ImageSize:   img.Size,
+		ImageFormat: img.Format,
+		ImageWidth:  img.Width,
+		ImageHeight: img.Height,
+		ImageAltText: strings.TrimSpace(req.ImageAltText),
+		ImageVariants: img.Variants,
+		Status:      status,
 	}
 
 	// Create the event
 	event, err := s.eventRepo.Create(createReq, req.OrganizerID)
 	if err != nil {
 		// Clean up uploaded image if event creation fails
-		if imageURL != "" {
-			s.cleanupImage(imageURL)
-		}
+		s.cleanupImage(img.URL, img.Key)
 		return nil, fmt.Errorf("failed to create event: %w", err)
 	}
 
+	s.recordContentFlag(event.ID, flag)
+	s.geocode(event, "")
+	s.eventsChanged()
+	s.publishIfLive(event, "")
 	return event, nil
 }
 
@@ -196,30 +404,26 @@ func (s *EventService) UpdateEvent(eventID int, req *EventUpdateRequest) (*model
 		return nil, fmt.Errorf("event not found: %w", err)
 	}
 
-	// For non-admin users, ensure they own the event
-	if organizer.Role != models.RoleAdmin && existingEvent.OrganizerID != req.OrganizerID {
+	// For non-admin users, ensure they own the event or manage it for its organizer
+	if organizer.Role != models.RoleAdmin && !s.actsFor(existingEvent, req.OrganizerID, models.PermissionManageEvents) {
 		return nil, fmt.Errorf("insufficient permissions: event belongs to another organizer")
 	}
 
 	// Handle image upload if provided
-	var imageURL, imageKey, imageFormat string
-	var imageSize int64
+	var img *uploadedImage
 	if req.Image != nil {
-		url, key, size, format, err := s.handleImageUpload(req.Image)
+		img, err = s.handleImageUpload(req.Image)
 		if err != nil {
 			return nil, fmt.Errorf("failed to upload image: %w", err)
 		}
-		imageURL = url
-		imageKey = key
-		imageSize = size
-		imageFormat = format
 	} else {
 		// Keep existing image metadata if no new image provided
-		imageURL = existingEvent.ImageURL
-		imageKey = existingEvent.ImageKey
-		imageSize = existingEvent.ImageSize
-		imageFormat = existingEvent.ImageFormat
+		img = existingImage(existingEvent)
 	}
+	imageURL := img.URL
+
+	status, flag := s.screenForPublishing(organizer, req.Status, req.Title, req.Description, imageURL)
+	status = s.holdForModeration(organizer, existingEvent.OrganizerID, existingEvent.Status, status)
 
 	// Create the update request for repository
 	updateReq := &models.EventUpdateRequest{
@@ -229,30 +433,36 @@ func (s *EventService) UpdateEvent(eventID int, req *EventUpdateRequest) (*model
 		EndDate:     req.EndDate,
 		Location:    req.Location,
 		CategoryID:  req.CategoryID,
-		ImageURL:    imageURL,
-		ImageKey:    imageKey,
-		ImageSize:   imageSize,
-		ImageFormat: imageFormat,
-		ImageWidth:  func() int { if req.Image != nil { return 800 } else { return existingEvent.ImageWidth } }(),   // Set default for new images
-		ImageHeight: func() int { if req.Image != nil { return 600 } else { return existingEvent.ImageHeight } }(), // Set default for new images
-		Status:      req.Status,
+		ImageURL:    img.URL,
+		ImageKey:    img.Key,
+		ImageSize:   img.Size,
+		ImageFormat: img.Format,
+		ImageWidth:  img.Width,
+		ImageHeight: img.Height,
+		ImageAltText: strings.TrimSpace(req.ImageAltText),
+		ImageVariants: img.Variants,
+		Status:      status,
 	}
 
 	// Update the event
 	event, err := s.eventRepo.Update(eventID, updateReq, existingEvent.OrganizerID)
 	if err != nil {
 		// Clean up uploaded image if event update fails
-		if req.Image != nil && imageURL != "" {
-			s.cleanupImage(imageURL)
+		if req.Image != nil {
+			s.cleanupImage(img.URL, img.Key)
 		}
 		return nil, fmt.Errorf("failed to update event: %w", err)
 	}
 
 	// Clean up old image if a new one was uploaded successfully
 	if req.Image != nil && existingEvent.ImageURL != "" && existingEvent.ImageURL != imageURL {
-		s.cleanupImage(existingEvent.ImageURL)
+		s.cleanupImage(existingEvent.ImageURL, existingEvent.ImageKey)
 	}
 
+	s.recordContentFlag(event.ID, flag)
+	s.geocode(event, existingEvent.Location)
+	s.eventsChanged()
+	s.publishIfLive(event, existingEvent.Status)
 	return event, nil
 }
 
@@ -266,13 +476,34 @@ func (s *EventService) GetEvent(eventID int) (*models.Event, error) {
 	return event, nil
 }
 
-// GetEventsByOrganizer retrieves events for a specific organizer
+// GetEventsByOrganizer retrieves events for a specific organizer, along with
+// the events of teams they manage events for, newest first
 // Note: Authorization should be handled at the handler/middleware level
 func (s *EventService) GetEventsByOrganizer(organizerID int) ([]*models.Event, error) {
 	events, err := s.eventRepo.GetByOrganizer(organizerID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get events by organizer: %w", err)
 	}
+	if s.team == nil {
+		return events, nil
+	}
+
+	teamOrganizers, err := s.team.GetOrganizersFor(organizerID, models.PermissionManageEvents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get teams: %w", err)
+	}
+	for _, teamOrganizerID := range teamOrganizers {
+		teamEvents, err := s.eventRepo.GetByOrganizer(teamOrganizerID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get team events: %w", err)
+		}
+		events = append(events, teamEvents...)
+	}
+	if len(teamOrganizers) > 0 {
+		sort.SliceStable(events, func(i, j int) bool {
+			return events[i].CreatedAt.After(events[j].CreatedAt)
+		})
+	}
 
 	return events, nil
 }
@@ -297,34 +528,49 @@ func (s *EventService) SearchEventsDetailed(req *EventSearchRequest) (*EventSear
 	filters := repositories.EventSearchFilters{
 		Query:      req.Query,
 		CategoryID: req.CategoryID,
+		OrganizerID: req.OrganizerID,
 		Location:   req.Location,
 		Status:     req.Status,
 		DateFrom:   req.DateFrom,
 		DateTo:     req.DateTo,
+		StartFrom:  req.StartFrom,
+		StartBefore: req.StartBefore,
 		PriceMin:   req.PriceMin,
 		PriceMax:   req.PriceMax,
+		Near:       req.Near,
+		RadiusKm:   float64(req.RadiusKm),
 		Limit:      req.PageSize,
 		Offset:     offset,
 		SortBy:     req.SortBy,
 		SortDesc:   req.SortDesc,
 	}
 
-	// Search events
-	events, total, err := s.eventRepo.Search(filters)
-	if err != nil {
-		return nil, fmt.Errorf("failed to search events: %w", err)
-	}
+	return cache.Remember(s.cache, searchCacheKey(req), eventSearchCacheTTL, func() (*EventSearchResponse, error) {
+		// Search events
+		events, total, err := s.eventRepo.Search(filters)
+		if err != nil {
+			return nil, fmt.Errorf("failed to search events: %w", err)
+		}
 
-	// Calculate total pages
-	totalPages := (total + req.PageSize - 1) / req.PageSize
+		// Calculate total pages
+		totalPages := (total + req.PageSize - 1) / req.PageSize
+
+		return &EventSearchResponse{
+			Events:     events,
+			Total:      total,
+			Page:       req.Page,
+			PageSize:   req.PageSize,
+			TotalPages: totalPages,
+			Highlights: buildHighlights(events, req.Query),
+		}, nil
+	})
+}
 
-	return &EventSearchResponse{
-		Events:     events,
-		Total:      total,
-		Page:       req.Page,
-		PageSize:   req.PageSize,
-		TotalPages: totalPages,
-	}, nil
+// searchCacheKey builds a cache key identifying a search request
+func searchCacheKey(req *EventSearchRequest) string {
+	data, _ := json.Marshal(req)
+	sum := sha1.Sum(data)
+	return cache.Key(eventCachePrefix+"search", hex.EncodeToString(sum[:]))
 }
 
 // GetPublishedEvents retrieves published events with pagination
@@ -341,20 +587,23 @@ func (s *EventService) GetPublishedEvents(page, pageSize int) (*EventSearchRespo
 
 	offset := (page - 1) * pageSize
 
-	events, total, err := s.eventRepo.GetPublishedEvents(pageSize, offset)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get published events: %w", err)
-	}
+	key := cache.Key(eventCachePrefix+"published", page, pageSize)
+	return cache.Remember(s.cache, key, eventListCacheTTL, func() (*EventSearchResponse, error) {
+		events, total, err := s.eventRepo.GetPublishedEvents(pageSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get published events: %w", err)
+		}
 
-	totalPages := (total + pageSize - 1) / pageSize
+		totalPages := (total + pageSize - 1) / pageSize
 
-	return &EventSearchResponse{
-		Events:     events,
-		Total:      total,
-		Page:       page,
-		PageSize:   pageSize,
-		TotalPages: totalPages,
-	}, nil
+		return &EventSearchResponse{
+			Events:     events,
+			Total:      total,
+			Page:       page,
+			PageSize:   pageSize,
+			TotalPages: totalPages,
+		}, nil
+	})
 }
 
 // GetUpcomingEvents retrieves upcoming published events
@@ -366,12 +615,15 @@ func (s *EventService) GetUpcomingEvents(limit int) ([]*models.Event, error) {
 		limit = 50
 	}
 
-	events, err := s.eventRepo.GetUpcomingEvents(limit)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get upcoming events: %w", err)
-	}
+	key := cache.Key(eventCachePrefix+"upcoming", limit)
+	return cache.Remember(s.cache, key, eventListCacheTTL, func() ([]*models.Event, error) {
+		events, err := s.eventRepo.GetUpcomingEvents(limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get upcoming events: %w", err)
+		}
 
-	return events, nil
+		return events, nil
+	})
 }
 
 // GetFeaturedEvents retrieves featured events
@@ -383,12 +635,15 @@ func (s *EventService) GetFeaturedEvents(limit int) ([]*models.Event, error) {
 		limit = 20
 	}
 
-	events, err := s.eventRepo.GetFeaturedEvents(limit)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get featured events: %w", err)
-	}
+	key := cache.Key(eventCachePrefix+"featured", limit)
+	return cache.Remember(s.cache, key, eventListCacheTTL, func() ([]*models.Event, error) {
+		events, err := s.eventRepo.GetFeaturedEvents(limit)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get featured events: %w", err)
+		}
 
-	return events, nil
+		return events, nil
+	})
 }
 
 // GetEventsByCategory retrieves events by category with pagination
@@ -405,20 +660,23 @@ func (s *EventService) GetEventsByCategory(categoryID int, page, pageSize int) (
 
 	offset := (page - 1) * pageSize
 
-	events, total, err := s.eventRepo.GetEventsByCategory(categoryID, pageSize, offset)
-	if err != nil {
-		return nil, fmt.Errorf("failed to get events by category: %w", err)
-	}
+	key := cache.Key(eventCachePrefix+"category", categoryID, page, pageSize)
+	return cache.Remember(s.cache, key, eventListCacheTTL, func() (*EventSearchResponse, error) {
+		events, total, err := s.eventRepo.GetEventsByCategory(categoryID, pageSize, offset)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get events by category: %w", err)
+		}
 
-	totalPages := (total + pageSize - 1) / pageSize
+		totalPages := (total + pageSize - 1) / pageSize
 
-	return &EventSearchResponse{
-		Events:     events,
-		Total:      total,
-		Page:       page,
-		PageSize:   pageSize,
-		TotalPages: totalPages,
-	}, nil
+		return &EventSearchResponse{
+			Events:     events,
+			Total:      total,
+			Page:       page,
+			PageSize:   pageSize,
+			TotalPages: totalPages,
+		}, nil
+	})
 }
 
 // CanUserEditEvent checks if a user can edit a specific event
@@ -445,19 +703,26 @@ func (s *EventService) CanUserEditEvent(eventID int, userID int) (bool, error) {
 		return false, fmt.Errorf("event not found: %w", err)
 	}
 
-	// Check if user owns the event and it can be edited
-	return event.OrganizerID == userID && event.CanBeEdited(), nil
+	// Check if user owns or manages the event and it can be edited
+	return event.CanBeEdited() && s.actsFor(event, userID, models.PermissionManageEvents), nil
 }
 
 // CanUserDeleteEvent checks if a user can delete a specific event
 func (s *EventService) CanUserDeleteEvent(eventID int, userID int) (bool, error) {
+	return s.CanUserManageEvent(eventID, userID)
+}
+
+// CanUserManageEvent checks if a user can run a specific event, such as
+// selling at its door or messaging its ticket holders. Unlike editing, this
+// doesn't end once the event has started.
+func (s *EventService) CanUserManageEvent(eventID int, userID int) (bool, error) {
 	// Get the user
 	user, err := s.authService.userRepo.GetByID(userID)
 	if err != nil {
 		return false, fmt.Errorf("user not found: %w", err)
 	}
 
-	// Admins can delete any event
+	// Admins can manage any event
 	if user.Role == models.RoleAdmin {
 		return true, nil
 	}
@@ -473,8 +738,8 @@ func (s *EventService) CanUserDeleteEvent(eventID int, userID int) (bool, error)
 		return false, fmt.Errorf("event not found: %w", err)
 	}
 
-	// Check if user owns the event
-	return event.OrganizerID == userID, nil
+	// Check if user owns or manages the event
+	return s.actsFor(event, userID, models.PermissionManageEvents), nil
 }
 
 // GetEventStatistics returns statistics for an event (for organizers)
@@ -491,8 +756,8 @@ func (s *EventService) GetEventStatistics(eventID int, requestingUserID int) (*E
 		return nil, fmt.Errorf("event not found: %w", err)
 	}
 
-	// Check permissions - only event owner or admin can view statistics
-	if user.Role != models.RoleAdmin && event.OrganizerID != requestingUserID {
+	// Check permissions - only the event owner, their analytics team members or admins can view statistics
+	if user.Role != models.RoleAdmin && !s.actsFor(event, requestingUserID, models.PermissionViewAnalytics) {
 		return nil, fmt.Errorf("insufficient permissions to view event statistics")
 	}
 
@@ -534,11 +799,17 @@ func (s *EventService) DuplicateEvent(eventID int, organizerID int, newTitle str
 		return nil, fmt.Errorf("original event not found: %w", err)
 	}
 
-	// For non-admin users, ensure they own the original event
-	if organizer.Role != models.RoleAdmin && originalEvent.OrganizerID != organizerID {
+	// For non-admin users, ensure they own or manage the original event
+	if organizer.Role != models.RoleAdmin && !s.actsFor(originalEvent, organizerID, models.PermissionManageEvents) {
 		return nil, fmt.Errorf("insufficient permissions: event belongs to another organizer")
 	}
 
+	// Team members duplicate events for the organizer they work for
+	ownerID := organizerID
+	if organizer.Role != models.RoleAdmin {
+		ownerID = originalEvent.OrganizerID
+	}
+
 	// Create the duplicate event request
 	duplicateReq := &models.EventCreateRequest{
 		Title:       newTitle,
@@ -548,15 +819,18 @@ func (s *EventService) DuplicateEvent(eventID int, organizerID int, newTitle str
 		Location:    originalEvent.Location,
 		CategoryID:  originalEvent.CategoryID,
 		ImageURL:    originalEvent.ImageURL, // Keep same image
+		ImageAltText: originalEvent.ImageAltText,
 		Status:      models.StatusDraft,     // Always start as draft
 	}
 
 	// Create the duplicate event
-	duplicateEvent, err := s.eventRepo.Create(duplicateReq, organizerID)
+	duplicateEvent, err := s.eventRepo.Create(duplicateReq, ownerID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to duplicate event: %w", err)
 	}
 
+	s.geocode(duplicateEvent, "")
+	s.eventsChanged()
 	return duplicateEvent, nil
 }
 
@@ -579,8 +853,8 @@ func (s *EventService) UpdateEventStatus(eventID int, status models.EventStatus,
 		return nil, fmt.Errorf("event not found: %w", err)
 	}
 
-	// For non-admin users, ensure they own the event
-	if organizer.Role != models.RoleAdmin && existingEvent.OrganizerID != organizerID {
+	// For non-admin users, ensure they own or manage the event
+	if organizer.Role != models.RoleAdmin && !s.actsFor(existingEvent, organizerID, models.PermissionManageEvents) {
 		return nil, fmt.Errorf("insufficient permissions: event belongs to another organizer")
 	}
 
@@ -589,6 +863,9 @@ func (s *EventService) UpdateEventStatus(eventID int, status models.EventStatus,
 		return nil, fmt.Errorf("invalid status transition: %w", err)
 	}
 
+	status, flag := s.screenForPublishing(organizer, status, existingEvent.Title, existingEvent.Description, existingEvent.ImageURL)
+	status = s.holdForModeration(organizer, existingEvent.OrganizerID, existingEvent.Status, status)
+
 	// Create update request with only status change
 	updateReq := &models.EventUpdateRequest{
 		Title:       existingEvent.Title,
@@ -598,6 +875,13 @@ func (s *EventService) UpdateEventStatus(eventID int, status models.EventStatus,
 		Location:    existingEvent.Location,
 		CategoryID:  existingEvent.CategoryID,
 		ImageURL:    existingEvent.ImageURL,
+		ImageKey:    existingEvent.ImageKey,
+		ImageSize:   existingEvent.ImageSize,
+		ImageFormat: existingEvent.ImageFormat,
+		ImageWidth:  existingEvent.ImageWidth,
+		ImageHeight: existingEvent.ImageHeight,
+		ImageAltText: existingEvent.ImageAltText,
+		ImageVariants: existingEvent.ImageVariants,
 		Status:      status,
 	}
 
@@ -607,13 +891,67 @@ func (s *EventService) UpdateEventStatus(eventID int, status models.EventStatus,
 		return nil, fmt.Errorf("failed to update event status: %w", err)
 	}
 
+	s.recordContentFlag(event.ID, flag)
+	s.eventsChanged()
+	s.publishIfLive(event, existingEvent.Status)
 	return event, nil
 }
 
-// DeleteEvent deletes an event
+// SetEventImage replaces an event's image with one already processed by the
+// image service, such as a completed direct upload. The caller removes the
+// image when it can't be set. It implements EventImageRecorder.
+func (s *EventService) SetEventImage(eventID, userID int, result *ImageUploadResult) (*models.Event, error) {
+	user, err := s.authService.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("organizer not found: %w", err)
+	}
+
+	existingEvent, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("event not found: %w", err)
+	}
+
+	// For non-admin users, ensure they own or manage the event
+	if user.Role != models.RoleAdmin && !s.actsFor(existingEvent, userID, models.PermissionManageEvents) {
+		return nil, fmt.Errorf("insufficient permissions: event belongs to another organizer")
+	}
+
+	img := imageFromUploadResult(result)
+	updateReq := &models.EventUpdateRequest{
+		Title:       existingEvent.Title,
+		Description: existingEvent.Description,
+		StartDate:   existingEvent.StartDate,
+		EndDate:     existingEvent.EndDate,
+		Location:    existingEvent.Location,
+		CategoryID:  existingEvent.CategoryID,
+		ImageURL:    img.URL,
+		ImageKey:    img.Key,
+		ImageSize:   img.Size,
+		ImageFormat: img.Format,
+		ImageWidth:  img.Width,
+		ImageHeight: img.Height,
+		ImageAltText: existingEvent.ImageAltText,
+		ImageVariants: img.Variants,
+		Status:      existingEvent.Status,
+	}
+
+	event, err := s.eventRepo.Update(eventID, updateReq, existingEvent.OrganizerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update event image: %w", err)
+	}
+
+	if existingEvent.ImageURL != img.URL {
+		s.cleanupImage(existingEvent.ImageURL, existingEvent.ImageKey)
+	}
+
+	s.eventsChanged()
+	return event, nil
+}
+
+// DeleteEvent moves an event to the trash. Its image is kept so the event
+// can be restored; storage cleanup removes it once the event is purged.
 // Note: Authorization should be handled at the handler/middleware level
 func (s *EventService) DeleteEvent(eventID int) error {
-	// Get existing event to get image URL for cleanup
 	existingEvent, err := s.eventRepo.GetByID(eventID)
 	if err != nil {
 		return fmt.Errorf("event not found: %w", err)
@@ -625,19 +963,39 @@ func (s *EventService) DeleteEvent(eventID int) error {
 		return fmt.Errorf("failed to delete event: %w", err)
 	}
 
-	// Clean up image if it exists
-	if existingEvent.ImageURL != "" {
-		s.cleanupImage(existingEvent.ImageURL)
-	}
-
+	s.eventsChanged()
 	return nil
 }
 
+// uploadedImage describes a stored event image
+type uploadedImage struct {
+	URL      string
+	Key      string
+	Size     int64
+	Format   string
+	Width    int
+	Height   int
+	Variants models.EventImageVariants
+}
+
+// existingImage returns the stored image of an event
+func existingImage(event *models.Event) *uploadedImage {
+	return &uploadedImage{
+		URL:      event.ImageURL,
+		Key:      event.ImageKey,
+		Size:     event.ImageSize,
+		Format:   event.ImageFormat,
+		Width:    event.ImageWidth,
+		Height:   event.ImageHeight,
+		Variants: event.ImageVariants,
+	}
+}
+
 // handleImageUpload handles the upload of event images and returns URL and metadata
-func (s *EventService) handleImageUpload(fileHeader *multipart.FileHeader) (string, string, int64, string, error) {
+func (s *EventService) handleImageUpload(fileHeader *multipart.FileHeader) (*uploadedImage, error) {
 	// Validate file size (max 5MB)
 	if fileHeader.Size > 5*1024*1024 {
-		return "", "", 0, "", fmt.Errorf("image file too large (max 5MB)")
+		return nil, fmt.Errorf("image file too large (max 5MB)")
 	}
 
 	// Validate file type by content type and extension
@@ -645,44 +1003,99 @@ func (s *EventService) handleImageUpload(fileHeader *multipart.FileHeader) (stri
 	if !s.isValidImageType(contentType) {
 		// Fallback to file extension check
 		if !s.isValidImageExtension(fileHeader.Filename) {
-			return "", "", 0, "", fmt.Errorf("invalid image type (only JPEG, PNG, GIF allowed). Content-Type: %s, Filename: %s", contentType, fileHeader.Filename)
+			return nil, fmt.Errorf("invalid image type (only JPEG, PNG, GIF allowed). Content-Type: %s, Filename: %s", contentType, fileHeader.Filename)
 		}
 	}
 
 	// Open the uploaded file
 	file, err := fileHeader.Open()
 	if err != nil {
-		return "", "", 0, "", fmt.Errorf("failed to open uploaded file: %w", err)
+		return nil, fmt.Errorf("failed to open uploaded file: %w", err)
 	}
 	defer file.Close()
 
+	if s.imageService != nil {
+		return s.uploadImageVariants(file, fileHeader)
+	}
+
 	// Generate unique filename
 	filename := s.generateImageFilename(fileHeader.Filename)
 
 	// Ensure upload directory exists
 	if err := os.MkdirAll(s.uploadPath, 0755); err != nil {
-		return "", "", 0, "", fmt.Errorf("failed to create upload directory: %w", err)
+		return nil, fmt.Errorf("failed to create upload directory: %w", err)
 	}
 
 	// Create destination file
 	destPath := filepath.Join(s.uploadPath, filename)
 	destFile, err := os.Create(destPath)
 	if err != nil {
-		return "", "", 0, "", fmt.Errorf("failed to create destination file: %w", err)
+		return nil, fmt.Errorf("failed to create destination file: %w", err)
 	}
 	defer destFile.Close()
 
 	// Copy file content
 	_, err = io.Copy(destFile, file)
 	if err != nil {
-		return "", "", 0, "", fmt.Errorf("failed to copy file content: %w", err)
+		return nil, fmt.Errorf("failed to copy file content: %w", err)
 	}
 
-	// Determine image format from content type or extension
-	format := s.getImageFormat(fileHeader.Header.Get("Content-Type"), fileHeader.Filename)
-	
-	// Return URL, key, size, format
-	return "/uploads/events/" + filename, "events/" + filename, fileHeader.Size, format, nil
+	// Read the dimensions back from the saved copy
+	img := &uploadedImage{
+		URL:    "/uploads/events/" + filename,
+		Key:    "events/" + filename,
+		Size:   fileHeader.Size,
+		Format: s.getImageFormat(fileHeader.Header.Get("Content-Type"), fileHeader.Filename),
+	}
+	if _, err := destFile.Seek(0, io.SeekStart); err == nil {
+		img.Width, img.Height = imageDimensions(destFile)
+	}
+	return img, nil
+}
+
+// uploadImageVariants stores the image with its cropped variants through the
+// image service
+func (s *EventService) uploadImageVariants(file io.Reader, fileHeader *multipart.FileHeader) (*uploadedImage, error) {
+	result, err := s.imageService.UploadImageWithOptions(context.Background(), file, fileHeader.Filename, ImageProcessingOptions{
+		Quality:          85,
+		EnableWebP:       true,
+		EnableAVIF:       true,
+		CompressionLevel: 6,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return imageFromUploadResult(result), nil
+}
+
+// imageFromUploadResult describes an image stored through the image service.
+// The hero's widest JPEG is kept as the image URL so pages and feeds that
+// don't use srcset still get a sized copy.
+func imageFromUploadResult(result *ImageUploadResult) *uploadedImage {
+	variants := result.EventImageVariants()
+	img := &uploadedImage{
+		URL:      result.Original.URL,
+		Key:      strings.TrimSuffix(result.Original.Key, "/original"),
+		Size:     result.Original.Size,
+		Format:   strings.TrimPrefix(result.Original.ContentType, "image/"),
+		Width:    result.Original.Width,
+		Height:   result.Original.Height,
+		Variants: variants,
+	}
+	if hero, ok := variants.Largest(models.ImageVariantHero); ok {
+		img.URL = hero.URL
+	}
+	return img
+}
+
+// imageDimensions returns the width and height of an image, or zeros when it
+// can't be decoded
+func imageDimensions(r io.Reader) (int, int) {
+	config, _, err := image.DecodeConfig(r)
+	if err != nil {
+		return 0, 0
+	}
+	return config.Width, config.Height
 }
 
 // isValidImageType checks if the content type is a valid image type
@@ -759,8 +1172,9 @@ func (s *EventService) generateImageFilename(originalFilename string) string {
 	return fmt.Sprintf("event_%d%s", timestamp, ext)
 }
 
-// cleanupImage removes an uploaded image file
-func (s *EventService) cleanupImage(imageURL string) {
+// cleanupImage removes an uploaded image file, or the image and its variants
+// from storage
+func (s *EventService) cleanupImage(imageURL, imageKey string) {
 	if imageURL == "" {
 		return
 	}
@@ -768,7 +1182,11 @@ func (s *EventService) cleanupImage(imageURL string) {
 	// Extract filename from URL
 	filename := strings.TrimPrefix(imageURL, "/uploads/")
 	if filename == imageURL {
-		return // Not a local upload
+		// Not a local upload
+		if s.imageService != nil && imageKey != "" {
+			s.imageService.DeleteImage(context.Background(), imageKey) // Ignore errors for cleanup
+		}
+		return
 	}
 
 	// Remove file
@@ -856,6 +1274,16 @@ func (s *EventService) GetEventByID(id int) (*models.Event, error) {
 	return s.eventRepo.GetByID(id)
 }
 
+// GetEventBySlug retrieves an event by its URL slug
+func (s *EventService) GetEventBySlug(slug string) (*models.Event, error) {
+	return s.eventRepo.GetBySlug(slug)
+}
+
+// GetSitemapEvents retrieves the published events listed in the sitemap
+func (s *EventService) GetSitemapEvents() ([]*models.Event, error) {
+	return s.eventRepo.GetSitemapEvents()
+}
+
 // GetEventOrganizer retrieves the organizer of an event
 func (s *EventService) GetEventOrganizer(eventID int) (*models.User, error) {
 	// Get the event first
@@ -877,10 +1305,18 @@ func (s *EventService) GetEventOrganizer(eventID int) (*models.User, error) {
 func (s *EventService) SearchEvents(filters EventSearchFilters) ([]*models.Event, int, error) {
 	// Convert interface filters to internal request format
 	req := &EventSearchRequest{
-		Query:    filters.Query,
-		Location: filters.Location,
-		Page:     filters.Page,
-		PageSize: filters.PerPage,
+		Query:       filters.Query,
+		OrganizerID: filters.OrganizerID,
+		Location:    filters.Location,
+		Page:        filters.Page,
+		PageSize:    filters.PerPage,
+		SortBy:      filters.SortBy,
+	}
+
+	// Search within a radius around the requester
+	if filters.Near != nil {
+		req.Near = filters.Near
+		req.RadiusKm = models.ClampSearchRadius(filters.RadiusKm)
 	}
 	
 	// Convert category string to CategoryID if provided
@@ -904,6 +1340,15 @@ func (s *EventService) SearchEvents(filters EventSearchFilters) ([]*models.Event
 			req.DateTo = &dateTo
 		}
 	}
+
+	// Resolve curated date presets in the requester's time zone
+	if filters.When != "" {
+		now := time.Now().In(ResolveTimeZone(filters.TimeZone))
+		if start, end, ok := ResolveDatePreset(DatePreset(filters.When), now); ok {
+			req.StartFrom = &start
+			req.StartBefore = &end
+		}
+	}
 	
 	// Call the detailed search method
 	response, err := s.SearchEventsDetailed(req)
@@ -916,7 +1361,7 @@ func (s *EventService) SearchEvents(filters EventSearchFilters) ([]*models.Event
 
 // GetCategories retrieves all event categories
 func (s *EventService) GetCategories() ([]*models.Category, error) {
-	return s.eventRepo.GetCategories()
+	return cache.Remember(s.cache, eventCachePrefix+"categories", categoriesCacheTTL, s.eventRepo.GetCategories)
 }
 
 // getCategoryIDByName looks up a category ID by name or slug
@@ -0,0 +1,94 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// EventAccessRepository defines the data operations for online event access links
+type EventAccessRepository interface {
+	GetLink(eventID int) (*models.EventAccessLink, error)
+	SaveLink(link *models.EventAccessLink) error
+	DeleteLink(eventID int) error
+	HasTicket(eventID, userID int) (bool, error)
+}
+
+// EventAccessService manages the stream or meeting links of online events,
+// revealing them only to ticket holders
+type EventAccessService struct {
+	repo EventAccessRepository
+	now  func() time.Time
+}
+
+// NewEventAccessService creates a new event access service
+func NewEventAccessService(repo EventAccessRepository) *EventAccessService {
+	return &EventAccessService{
+		repo: repo,
+		now:  time.Now,
+	}
+}
+
+// GetLink returns an event's access link, or nil if it has none
+func (s *EventAccessService) GetLink(eventID int) (*models.EventAccessLink, error) {
+	return s.repo.GetLink(eventID)
+}
+
+// SaveLink validates and saves an event's access link
+func (s *EventAccessService) SaveLink(link *models.EventAccessLink) error {
+	if err := link.Validate(); err != nil {
+		return err
+	}
+	return s.repo.SaveLink(link)
+}
+
+// DeleteLink removes an event's access link, when it is no longer online
+func (s *EventAccessService) DeleteLink(eventID int) error {
+	return s.repo.DeleteLink(eventID)
+}
+
+// GetAccess returns what a user may see of an online event's access link, or
+// nil for events held in person. Visitors without a ticket only learn that
+// the link is shared with ticket holders.
+func (s *EventAccessService) GetAccess(event *models.Event, userID int) (*models.EventAccess, error) {
+	if !event.IsOnline() {
+		return nil, nil
+	}
+	if userID == 0 {
+		return &models.EventAccess{}, nil
+	}
+
+	hasTicket, err := s.repo.HasTicket(event.ID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !hasTicket {
+		return &models.EventAccess{}, nil
+	}
+	return s.TicketHolderAccess(event)
+}
+
+// TicketHolderAccess returns what ticket holders may see of an online event's
+// access link, or nil for events held in person
+func (s *EventAccessService) TicketHolderAccess(event *models.Event) (*models.EventAccess, error) {
+	if !event.IsOnline() {
+		return nil, nil
+	}
+
+	link, err := s.repo.GetLink(event.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get access link: %w", err)
+	}
+
+	access := &models.EventAccess{HasTicket: true}
+	if link == nil {
+		return access, nil
+	}
+	if link.IsRevealed(event.StartDate, s.now()) {
+		access.URL = link.URL
+	} else {
+		access.RevealAt = link.RevealAt(event.StartDate)
+	}
+	return access, nil
+}
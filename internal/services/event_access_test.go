@@ -0,0 +1,101 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock EventAccessRepository for testing
+type mockEventAccessRepository struct {
+	links   map[int]*models.EventAccessLink
+	holders map[int]bool
+}
+
+func newMockEventAccessRepository() *mockEventAccessRepository {
+	return &mockEventAccessRepository{
+		links:   make(map[int]*models.EventAccessLink),
+		holders: make(map[int]bool),
+	}
+}
+
+func (m *mockEventAccessRepository) GetLink(eventID int) (*models.EventAccessLink, error) {
+	return m.links[eventID], nil
+}
+
+func (m *mockEventAccessRepository) SaveLink(link *models.EventAccessLink) error {
+	m.links[link.EventID] = link
+	return nil
+}
+
+func (m *mockEventAccessRepository) DeleteLink(eventID int) error {
+	delete(m.links, eventID)
+	return nil
+}
+
+func (m *mockEventAccessRepository) HasTicket(eventID, userID int) (bool, error) {
+	return m.holders[userID], nil
+}
+
+func TestEventAccessService_SaveLink(t *testing.T) {
+	repo := newMockEventAccessRepository()
+	service := NewEventAccessService(repo)
+
+	if err := service.SaveLink(&models.EventAccessLink{EventID: 1, URL: "meet me online"}); err == nil {
+		t.Error("expected an invalid link to be refused")
+	}
+	if len(repo.links) != 0 {
+		t.Errorf("expected nothing to be saved, got %v", repo.links)
+	}
+
+	if err := service.SaveLink(&models.EventAccessLink{EventID: 1, URL: " https://meet.example.com/abc "}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.links[1].URL != "https://meet.example.com/abc" {
+		t.Errorf("expected the trimmed link to be saved, got %+v", repo.links[1])
+	}
+}
+
+func TestEventAccessService_GetAccess(t *testing.T) {
+	now := time.Date(2025, 6, 4, 12, 0, 0, 0, time.UTC)
+	repo := newMockEventAccessRepository()
+	service := NewEventAccessService(repo)
+	service.now = func() time.Time { return now }
+
+	event := &models.Event{ID: 1, Location: models.OnlineEventLocation, StartDate: now.Add(2 * time.Hour)}
+	repo.links[1] = &models.EventAccessLink{EventID: 1, URL: "https://meet.example.com/abc", RevealMinutesBefore: 60}
+	repo.holders[7] = true
+
+	inPerson := &models.Event{ID: 2, Location: "Nairobi", StartDate: event.StartDate}
+	if access, err := service.GetAccess(inPerson, 7); err != nil || access != nil {
+		t.Errorf("expected no access for in-person events, got %+v, %v", access, err)
+	}
+
+	for _, userID := range []int{0, 8} {
+		access, err := service.GetAccess(event, userID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if access.HasTicket || access.URL != "" {
+			t.Errorf("expected the link to be hidden from user %d, got %+v", userID, access)
+		}
+	}
+
+	access, err := service.GetAccess(event, 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !access.HasTicket || access.URL != "" || !access.RevealAt.Equal(now.Add(time.Hour)) {
+		t.Errorf("expected the link to be revealed an hour before the start, got %+v", access)
+	}
+
+	now = now.Add(90 * time.Minute)
+	access, err = service.GetAccess(event, 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if access.URL != "https://meet.example.com/abc" {
+		t.Errorf("expected ticket holders to see the link, got %+v", access)
+	}
+}
@@ -0,0 +1,323 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// ErrAgendaItemNotFound is returned when a session or speaker doesn't exist
+// or belongs to another event
+var ErrAgendaItemNotFound = errors.New("agenda item not found")
+
+// maxSpeakerPhotoSize is the largest speaker photo organizers can upload
+const maxSpeakerPhotoSize = 5 * 1024 * 1024
+
+// EventAgendaRepository defines the data operations for events' agendas
+type EventAgendaRepository interface {
+	GetSessions(eventID int) ([]*models.EventSession, error)
+	GetSession(id int) (*models.EventSession, error)
+	CreateSession(session *models.EventSession) error
+	UpdateSession(session *models.EventSession) error
+	DeleteSession(id, eventID int) error
+	GetSpeakers(eventID int) ([]*models.EventSpeaker, error)
+	GetSpeaker(id int) (*models.EventSpeaker, error)
+	CreateSpeaker(speaker *models.EventSpeaker) error
+	UpdateSpeaker(speaker *models.EventSpeaker) error
+	DeleteSpeaker(id, eventID int) error
+}
+
+// SpeakerRequest is a speaker or performer an organizer has entered or edited
+type SpeakerRequest struct {
+	Name        string
+	Role        string
+	Bio         string
+	Photo       *multipart.FileHeader // New photo, if one was uploaded
+	RemovePhoto bool
+}
+
+// EventAgendaService manages events' agendas: the sessions attendees can go
+// to and the lineup of speakers or performers in them
+type EventAgendaService struct {
+	repo       EventAgendaRepository
+	uploadPath string
+	now        func() time.Time
+}
+
+// NewEventAgendaService creates a new event agenda service. Speaker photos
+// are saved under uploadPath.
+func NewEventAgendaService(repo EventAgendaRepository, uploadPath string) *EventAgendaService {
+	return &EventAgendaService{
+		repo:       repo,
+		uploadPath: uploadPath,
+		now:        time.Now,
+	}
+}
+
+// GetAgenda returns an event's sessions by start time, each with its
+// speakers, and its lineup
+func (s *EventAgendaService) GetAgenda(eventID int) (*models.EventAgenda, error) {
+	sessions, err := s.repo.GetSessions(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sessions: %w", err)
+	}
+	speakers, err := s.repo.GetSpeakers(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get speakers: %w", err)
+	}
+
+	byID := make(map[int]*models.EventSpeaker, len(speakers))
+	for _, speaker := range speakers {
+		byID[speaker.ID] = speaker
+	}
+	for _, session := range sessions {
+		session.Speakers = nil
+		for _, id := range session.SpeakerIDs {
+			if speaker, ok := byID[id]; ok {
+				session.Speakers = append(session.Speakers, speaker)
+			}
+		}
+	}
+
+	return &models.EventAgenda{Sessions: sessions, Speakers: speakers}, nil
+}
+
+// GetSession returns one of an event's sessions
+func (s *EventAgendaService) GetSession(eventID, id int) (*models.EventSession, error) {
+	session, err := s.repo.GetSession(id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrAgendaItemNotFound
+		}
+		return nil, err
+	}
+	if session.EventID != eventID {
+		return nil, ErrAgendaItemNotFound
+	}
+	return session, nil
+}
+
+// AddSession validates and adds a session to an event's agenda
+func (s *EventAgendaService) AddSession(event *models.Event, session *models.EventSession) error {
+	session.EventID = event.ID
+	if err := s.checkSession(event, session); err != nil {
+		return err
+	}
+
+	sessions, err := s.repo.GetSessions(event.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get sessions: %w", err)
+	}
+	if len(sessions) >= models.MaxAgendaSessions {
+		return fmt.Errorf("agendas can have at most %d sessions", models.MaxAgendaSessions)
+	}
+
+	if err := s.repo.CreateSession(session); err != nil {
+		return fmt.Errorf("failed to add session: %w", err)
+	}
+	return nil
+}
+
+// UpdateSession validates and saves changes to one of an event's sessions
+func (s *EventAgendaService) UpdateSession(event *models.Event, session *models.EventSession) error {
+	if _, err := s.GetSession(event.ID, session.ID); err != nil {
+		return err
+	}
+	session.EventID = event.ID
+	if err := s.checkSession(event, session); err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdateSession(session); err != nil {
+		return fmt.Errorf("failed to update session: %w", err)
+	}
+	return nil
+}
+
+// DeleteSession removes a session from an event's agenda
+func (s *EventAgendaService) DeleteSession(eventID, id int) error {
+	if err := s.repo.DeleteSession(id, eventID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return ErrAgendaItemNotFound
+		}
+		return fmt.Errorf("failed to delete session: %w", err)
+	}
+	return nil
+}
+
+// checkSession validates a session and that its speakers are in the event's
+// lineup
+func (s *EventAgendaService) checkSession(event *models.Event, session *models.EventSession) error {
+	if err := session.Validate(event); err != nil {
+		return err
+	}
+	if len(session.SpeakerIDs) == 0 {
+		return nil
+	}
+
+	speakers, err := s.repo.GetSpeakers(event.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get speakers: %w", err)
+	}
+	lineup := make(map[int]bool, len(speakers))
+	for _, speaker := range speakers {
+		lineup[speaker.ID] = true
+	}
+	for _, id := range session.SpeakerIDs {
+		if !lineup[id] {
+			return errors.New("pick speakers from the event's lineup")
+		}
+	}
+	return nil
+}
+
+// GetSpeaker returns one of the speakers in an event's lineup
+func (s *EventAgendaService) GetSpeaker(eventID, id int) (*models.EventSpeaker, error) {
+	speaker, err := s.repo.GetSpeaker(id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrAgendaItemNotFound
+		}
+		return nil, err
+	}
+	if speaker.EventID != eventID {
+		return nil, ErrAgendaItemNotFound
+	}
+	return speaker, nil
+}
+
+// AddSpeaker validates and adds a speaker to an event's lineup
+func (s *EventAgendaService) AddSpeaker(eventID int, req *SpeakerRequest) (*models.EventSpeaker, error) {
+	speaker := &models.EventSpeaker{EventID: eventID, Name: req.Name, Role: req.Role, Bio: req.Bio}
+	if err := speaker.Validate(); err != nil {
+		return nil, err
+	}
+
+	speakers, err := s.repo.GetSpeakers(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get speakers: %w", err)
+	}
+	if len(speakers) >= models.MaxAgendaSpeakers {
+		return nil, fmt.Errorf("lineups can have at most %d speakers", models.MaxAgendaSpeakers)
+	}
+
+	if req.Photo != nil {
+		if speaker.PhotoURL, err = s.savePhoto(req.Photo, eventID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.CreateSpeaker(speaker); err != nil {
+		s.removePhoto(speaker.PhotoURL)
+		return nil, fmt.Errorf("failed to add speaker: %w", err)
+	}
+	return speaker, nil
+}
+
+// UpdateSpeaker saves an organizer's edits to a speaker in an event's lineup
+func (s *EventAgendaService) UpdateSpeaker(eventID, id int, req *SpeakerRequest) (*models.EventSpeaker, error) {
+	current, err := s.GetSpeaker(eventID, id)
+	if err != nil {
+		return nil, err
+	}
+
+	speaker := *current
+	speaker.Name, speaker.Role, speaker.Bio = req.Name, req.Role, req.Bio
+	if err := speaker.Validate(); err != nil {
+		return nil, err
+	}
+
+	if req.RemovePhoto {
+		speaker.PhotoURL = ""
+	}
+	if req.Photo != nil {
+		if speaker.PhotoURL, err = s.savePhoto(req.Photo, eventID); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := s.repo.UpdateSpeaker(&speaker); err != nil {
+		if speaker.PhotoURL != current.PhotoURL {
+			s.removePhoto(speaker.PhotoURL)
+		}
+		return nil, fmt.Errorf("failed to update speaker: %w", err)
+	}
+	if speaker.PhotoURL != current.PhotoURL {
+		s.removePhoto(current.PhotoURL)
+	}
+	return &speaker, nil
+}
+
+// DeleteSpeaker removes a speaker from an event's lineup, its sessions and
+// deletes its photo
+func (s *EventAgendaService) DeleteSpeaker(eventID, id int) error {
+	speaker, err := s.GetSpeaker(eventID, id)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.DeleteSpeaker(id, eventID); err != nil {
+		return fmt.Errorf("failed to delete speaker: %w", err)
+	}
+	s.removePhoto(speaker.PhotoURL)
+	return nil
+}
+
+// savePhoto stores an uploaded speaker photo and returns its URL
+func (s *EventAgendaService) savePhoto(fileHeader *multipart.FileHeader, eventID int) (string, error) {
+	if fileHeader.Size > maxSpeakerPhotoSize {
+		return "", fmt.Errorf("speaker photo too large (max 5MB)")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer file.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return "", fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+	ext, ok := uploadImageTypes[http.DetectContentType(head[:n])]
+	if !ok {
+		return "", fmt.Errorf("invalid speaker photo type (only JPEG, PNG, GIF and WebP allowed)")
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+
+	if err := os.MkdirAll(s.uploadPath, 0755); err != nil {
+		return "", fmt.Errorf("failed to create upload directory: %w", err)
+	}
+
+	filename := fmt.Sprintf("speaker_%d_%d%s", eventID, s.now().UnixNano(), ext)
+	destFile, err := os.Create(filepath.Join(s.uploadPath, filename))
+	if err != nil {
+		return "", fmt.Errorf("failed to create destination file: %w", err)
+	}
+	defer destFile.Close()
+
+	if _, err := io.Copy(destFile, file); err != nil {
+		return "", fmt.Errorf("failed to copy file content: %w", err)
+	}
+
+	return "/uploads/speakers/" + filename, nil
+}
+
+// removePhoto deletes an uploaded speaker photo that is no longer used
+func (s *EventAgendaService) removePhoto(url string) {
+	filename := strings.TrimPrefix(url, "/uploads/speakers/")
+	if filename == url || filename == "" || strings.Contains(filename, "/") {
+		return // Not one of our uploads
+	}
+	os.Remove(filepath.Join(s.uploadPath, filename)) // Ignore errors for cleanup
+}
@@ -0,0 +1,205 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock EventAgendaRepository for testing
+type mockEventAgendaRepository struct {
+	sessions map[int]*models.EventSession
+	speakers map[int]*models.EventSpeaker
+	nextID   int
+}
+
+func newMockEventAgendaRepository() *mockEventAgendaRepository {
+	return &mockEventAgendaRepository{
+		sessions: make(map[int]*models.EventSession),
+		speakers: make(map[int]*models.EventSpeaker),
+		nextID:   1,
+	}
+}
+
+func (m *mockEventAgendaRepository) GetSessions(eventID int) ([]*models.EventSession, error) {
+	var sessions []*models.EventSession
+	for _, session := range m.sessions {
+		if session.EventID == eventID {
+			sessions = append(sessions, session)
+		}
+	}
+	sort.Slice(sessions, func(i, j int) bool { return sessions[i].StartsAt.Before(sessions[j].StartsAt) })
+	return sessions, nil
+}
+
+func (m *mockEventAgendaRepository) GetSession(id int) (*models.EventSession, error) {
+	session, ok := m.sessions[id]
+	if !ok {
+		return nil, fmt.Errorf("session with id %d not found", id)
+	}
+	return session, nil
+}
+
+func (m *mockEventAgendaRepository) CreateSession(session *models.EventSession) error {
+	session.ID = m.nextID
+	m.nextID++
+	m.sessions[session.ID] = session
+	return nil
+}
+
+func (m *mockEventAgendaRepository) UpdateSession(session *models.EventSession) error {
+	m.sessions[session.ID] = session
+	return nil
+}
+
+func (m *mockEventAgendaRepository) DeleteSession(id, eventID int) error {
+	if session, ok := m.sessions[id]; !ok || session.EventID != eventID {
+		return fmt.Errorf("session with id %d not found", id)
+	}
+	delete(m.sessions, id)
+	return nil
+}
+
+func (m *mockEventAgendaRepository) GetSpeakers(eventID int) ([]*models.EventSpeaker, error) {
+	var speakers []*models.EventSpeaker
+	for _, speaker := range m.speakers {
+		if speaker.EventID == eventID {
+			speakers = append(speakers, speaker)
+		}
+	}
+	sort.Slice(speakers, func(i, j int) bool { return speakers[i].ID < speakers[j].ID })
+	return speakers, nil
+}
+
+func (m *mockEventAgendaRepository) GetSpeaker(id int) (*models.EventSpeaker, error) {
+	speaker, ok := m.speakers[id]
+	if !ok {
+		return nil, fmt.Errorf("speaker with id %d not found", id)
+	}
+	return speaker, nil
+}
+
+func (m *mockEventAgendaRepository) CreateSpeaker(speaker *models.EventSpeaker) error {
+	speaker.ID = m.nextID
+	m.nextID++
+	m.speakers[speaker.ID] = speaker
+	return nil
+}
+
+func (m *mockEventAgendaRepository) UpdateSpeaker(speaker *models.EventSpeaker) error {
+	m.speakers[speaker.ID] = speaker
+	return nil
+}
+
+func (m *mockEventAgendaRepository) DeleteSpeaker(id, eventID int) error {
+	delete(m.speakers, id)
+	return nil
+}
+
+func agendaTestEvent() *models.Event {
+	start := time.Date(2025, 6, 4, 9, 0, 0, 0, time.UTC)
+	return &models.Event{ID: 1, StartDate: start, EndDate: start.AddDate(0, 0, 1)}
+}
+
+func TestEventAgendaService_AddSession(t *testing.T) {
+	repo := newMockEventAgendaRepository()
+	service := NewEventAgendaService(repo, t.TempDir())
+	event := agendaTestEvent()
+
+	speaker, err := service.AddSpeaker(event.ID, &SpeakerRequest{Name: "Amina Hassan", Role: "CTO"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	other, err := service.AddSpeaker(2, &SpeakerRequest{Name: "Brian Otieno"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if err := service.AddSession(event, &models.EventSession{Title: "Keynote", StartsAt: event.StartDate.Add(-time.Hour)}); err == nil {
+		t.Error("expected a session before the event to be refused")
+	}
+	if err := service.AddSession(event, &models.EventSession{Title: "Keynote", StartsAt: event.StartDate, SpeakerIDs: []int{other.ID}}); err == nil {
+		t.Error("expected another event's speaker to be refused")
+	}
+
+	session := &models.EventSession{Title: " Keynote ", StartsAt: event.StartDate.Add(time.Hour), SpeakerIDs: []int{speaker.ID}}
+	if err := service.AddSession(event, session); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if session.EventID != event.ID || repo.sessions[session.ID].Title != "Keynote" {
+		t.Errorf("expected the session to be saved for the event, got %+v", session)
+	}
+
+	if _, err := service.GetSession(2, session.ID); !errors.Is(err, ErrAgendaItemNotFound) {
+		t.Errorf("expected another event's session to be hidden, got %v", err)
+	}
+	if err := service.UpdateSession(&models.Event{ID: 2, StartDate: event.StartDate, EndDate: event.EndDate},
+		&models.EventSession{ID: session.ID, Title: "Mine now", StartsAt: event.StartDate}); !errors.Is(err, ErrAgendaItemNotFound) {
+		t.Errorf("expected another event's session not to be editable, got %v", err)
+	}
+	if err := service.DeleteSession(2, session.ID); !errors.Is(err, ErrAgendaItemNotFound) {
+		t.Errorf("expected another event's session not to be deletable, got %v", err)
+	}
+}
+
+func TestEventAgendaService_GetAgenda(t *testing.T) {
+	repo := newMockEventAgendaRepository()
+	service := NewEventAgendaService(repo, t.TempDir())
+	event := agendaTestEvent()
+
+	repo.speakers[1] = &models.EventSpeaker{ID: 1, EventID: 1, Name: "Amina Hassan"}
+	repo.speakers[2] = &models.EventSpeaker{ID: 2, EventID: 1, Name: "Brian Otieno"}
+	repo.sessions[3] = &models.EventSession{ID: 3, EventID: 1, Title: "Panel", StartsAt: event.StartDate.Add(2 * time.Hour), SpeakerIDs: []int{1, 2}}
+	repo.sessions[4] = &models.EventSession{ID: 4, EventID: 1, Title: "Keynote", StartsAt: event.StartDate, SpeakerIDs: []int{2}}
+
+	agenda, err := service.GetAgenda(event.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(agenda.Sessions) != 2 || agenda.Sessions[0].ID != 4 {
+		t.Fatalf("expected sessions by start time, got %v", agenda.Sessions)
+	}
+	if len(agenda.Sessions[1].Speakers) != 2 || agenda.Sessions[1].Speakers[0].Name != "Amina Hassan" {
+		t.Errorf("expected the panel's speakers, got %v", agenda.Sessions[1].Speakers)
+	}
+	if len(agenda.Speakers) != 2 {
+		t.Errorf("expected the lineup, got %v", agenda.Speakers)
+	}
+}
+
+func TestEventAgendaService_UpdateSpeaker(t *testing.T) {
+	uploadPath := t.TempDir()
+	repo := newMockEventAgendaRepository()
+	service := NewEventAgendaService(repo, uploadPath)
+
+	photo := filepath.Join(uploadPath, "speaker_1_1.jpg")
+	if err := os.WriteFile(photo, []byte("photo"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	repo.speakers[1] = &models.EventSpeaker{ID: 1, EventID: 1, Name: "Amina", PhotoURL: "/uploads/speakers/speaker_1_1.jpg"}
+
+	if _, err := service.UpdateSpeaker(1, 1, &SpeakerRequest{Name: " "}); err == nil {
+		t.Error("expected a speaker without a name to be refused")
+	}
+
+	speaker, err := service.UpdateSpeaker(1, 1, &SpeakerRequest{Name: "Amina Hassan", Role: "Keynote", RemovePhoto: true})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if speaker.Name != "Amina Hassan" || speaker.PhotoURL != "" {
+		t.Errorf("expected the speaker to be renamed without a photo, got %+v", speaker)
+	}
+	if _, err := os.Stat(photo); !os.IsNotExist(err) {
+		t.Errorf("expected the removed photo to be deleted, got %v", err)
+	}
+
+	if _, err := service.UpdateSpeaker(2, 1, &SpeakerRequest{Name: "Mine now"}); !errors.Is(err, ErrAgendaItemNotFound) {
+		t.Errorf("expected another event's speaker not to be editable, got %v", err)
+	}
+}
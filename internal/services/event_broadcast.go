@@ -0,0 +1,200 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/i18n"
+	"event-ticketing-platform/internal/models"
+)
+
+// ErrNoBroadcastRecipients is returned when an event has no ticket holders to email
+var ErrNoBroadcastRecipients = errors.New("this event has no ticket holders to email yet")
+
+// Broadcast sending defaults, kept low to stay within the email provider's rate limits
+const (
+	DefaultBroadcastBatchSize  = 50
+	DefaultBroadcastBatchDelay = 2 * time.Second
+)
+
+// EventBroadcastRepository defines the data operations for organizer broadcasts
+type EventBroadcastRepository interface {
+	Create(req *models.EventBroadcastCreateRequest, recipientCount int) (*models.EventBroadcast, error)
+	GetByEvent(eventID, limit int) ([]*models.EventBroadcast, error)
+	GetPending() ([]*models.EventBroadcast, error)
+	CountRecipients(eventID int) (int, error)
+	GetPendingRecipients(broadcast *models.EventBroadcast, limit int) ([]*models.BroadcastRecipient, error)
+	RecordDelivery(broadcastID, orderID int) (bool, error)
+	UpdateProgress(broadcastID int, status models.BroadcastStatus, sent, failed int) error
+}
+
+// EventBroadcastEmailSender sends organizer broadcasts, wrapped in the given language
+type EventBroadcastEmailSender interface {
+	SendEventBroadcastEmail(email, userName, subject, locale string, event *models.Event, message, link string) error
+}
+
+// EventBroadcastService queues organizers' emails to all ticket holders of an
+// event and sends them in batches in the background
+type EventBroadcastService struct {
+	repo         EventBroadcastRepository
+	eventRepo    EventRepository
+	emailSender  EventBroadcastEmailSender
+	auditService *AuditService
+	baseURL      string
+	batchSize    int
+	batchDelay   time.Duration
+	sleep        func(time.Duration)
+}
+
+// NewEventBroadcastService creates a new event broadcast service
+func NewEventBroadcastService(repo EventBroadcastRepository, eventRepo EventRepository, emailSender EventBroadcastEmailSender, baseURL string) *EventBroadcastService {
+	return &EventBroadcastService{
+		repo:        repo,
+		eventRepo:   eventRepo,
+		emailSender: emailSender,
+		baseURL:     baseURL,
+		batchSize:   DefaultBroadcastBatchSize,
+		batchDelay:  DefaultBroadcastBatchDelay,
+		sleep:       time.Sleep,
+	}
+}
+
+// SetAuditService records queued broadcasts in the audit log
+func (s *EventBroadcastService) SetAuditService(auditService *AuditService) {
+	s.auditService = auditService
+}
+
+// SetBatching sets how many emails are sent per batch and the pause between batches
+func (s *EventBroadcastService) SetBatching(batchSize int, batchDelay time.Duration) {
+	if batchSize > 0 {
+		s.batchSize = batchSize
+	}
+	if batchDelay >= 0 {
+		s.batchDelay = batchDelay
+	}
+}
+
+// QueueBroadcast validates a broadcast and queues it for sending, recording
+// the organizer who sent it in the audit log
+func (s *EventBroadcastService) QueueBroadcast(req *models.EventBroadcastCreateRequest, r *http.Request) (*models.EventBroadcast, error) {
+	req.Subject = strings.TrimSpace(req.Subject)
+	req.Message = strings.TrimSpace(req.Message)
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	recipients, err := s.repo.CountRecipients(req.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count recipients: %w", err)
+	}
+	if recipients == 0 {
+		return nil, ErrNoBroadcastRecipients
+	}
+
+	broadcast, err := s.repo.Create(req, recipients)
+	if err != nil {
+		return nil, fmt.Errorf("failed to queue broadcast: %w", err)
+	}
+
+	if s.auditService != nil && r != nil {
+		details := map[string]interface{}{
+			"broadcast_id":    broadcast.ID,
+			"subject":         broadcast.Subject,
+			"recipient_count": broadcast.RecipientCount,
+		}
+		if err := s.auditService.LogAction(req.OrganizerID, models.AuditActionEventBroadcast, models.AuditTargetEvent, req.EventID, details, r); err != nil {
+			fmt.Printf("Warning: failed to log broadcast %d: %v\n", broadcast.ID, err)
+		}
+	}
+
+	return broadcast, nil
+}
+
+// GetBroadcasts returns an event's most recent broadcasts
+func (s *EventBroadcastService) GetBroadcasts(eventID int) ([]*models.EventBroadcast, error) {
+	broadcasts, err := s.repo.GetByEvent(eventID, 20)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get broadcasts: %w", err)
+	}
+	return broadcasts, nil
+}
+
+// ProcessQueue sends every queued broadcast and returns how many emails were
+// sent. Broadcasts interrupted by a restart are resumed where they stopped.
+// It is meant to run periodically.
+func (s *EventBroadcastService) ProcessQueue() (int, error) {
+	broadcasts, err := s.repo.GetPending()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get queued broadcasts: %w", err)
+	}
+
+	sent := 0
+	for _, broadcast := range broadcasts {
+		count, err := s.sendBroadcast(broadcast)
+		sent += count
+		if err != nil {
+			fmt.Printf("Warning: failed to send broadcast %d for event %d: %v\n", broadcast.ID, broadcast.EventID, err)
+		}
+	}
+
+	return sent, nil
+}
+
+// sendBroadcast emails a broadcast to the event's ticket holders who have not
+// received it yet, one batch at a time, recording progress after each batch
+func (s *EventBroadcastService) sendBroadcast(broadcast *models.EventBroadcast) (int, error) {
+	event, err := s.eventRepo.GetByID(broadcast.EventID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	link := fmt.Sprintf("%s/events/%d", s.baseURL, event.ID)
+	sent := 0
+	for batch := 0; ; batch++ {
+		recipients, err := s.repo.GetPendingRecipients(broadcast, s.batchSize)
+		if err != nil {
+			return sent, fmt.Errorf("failed to get broadcast recipients: %w", err)
+		}
+		if len(recipients) == 0 {
+			break
+		}
+		if batch > 0 && s.batchDelay > 0 {
+			s.sleep(s.batchDelay)
+		}
+
+		batchSent, batchFailed := 0, 0
+		for _, recipient := range recipients {
+			// Record the delivery before sending so several instances running
+			// the job never email the same attendee twice. Failed sends are
+			// not retried, or a bad address would stall the broadcast.
+			isNew, err := s.repo.RecordDelivery(broadcast.ID, recipient.OrderID)
+			if err != nil {
+				return sent, fmt.Errorf("failed to record broadcast delivery: %w", err)
+			}
+			if !isNew {
+				continue
+			}
+
+			locale := i18n.Resolve(recipient.Locale)
+			if err := s.emailSender.SendEventBroadcastEmail(recipient.Email, recipient.Name, broadcast.Subject, locale, event, broadcast.Message, link); err != nil {
+				fmt.Printf("Warning: failed to send broadcast email to %s: %v\n", recipient.Email, err)
+				batchFailed++
+				continue
+			}
+			batchSent++
+		}
+		sent += batchSent
+
+		if err := s.repo.UpdateProgress(broadcast.ID, models.BroadcastSending, batchSent, batchFailed); err != nil {
+			return sent, fmt.Errorf("failed to record broadcast progress: %w", err)
+		}
+	}
+
+	if err := s.repo.UpdateProgress(broadcast.ID, models.BroadcastSent, 0, 0); err != nil {
+		return sent, fmt.Errorf("failed to complete broadcast: %w", err)
+	}
+	return sent, nil
+}
@@ -0,0 +1,268 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock EventBroadcastRepository for testing
+type mockEventBroadcastRepository struct {
+	broadcasts map[int]*models.EventBroadcast
+	recipients map[int][]*models.BroadcastRecipient
+	delivered  map[string]bool
+	batches    []int
+	nextID     int
+}
+
+func newMockEventBroadcastRepository() *mockEventBroadcastRepository {
+	return &mockEventBroadcastRepository{
+		broadcasts: make(map[int]*models.EventBroadcast),
+		recipients: make(map[int][]*models.BroadcastRecipient),
+		delivered:  make(map[string]bool),
+		nextID:     1,
+	}
+}
+
+func (m *mockEventBroadcastRepository) Create(req *models.EventBroadcastCreateRequest, recipientCount int) (*models.EventBroadcast, error) {
+	broadcast := &models.EventBroadcast{
+		ID:             m.nextID,
+		EventID:        req.EventID,
+		OrganizerID:    req.OrganizerID,
+		Subject:        req.Subject,
+		Message:        req.Message,
+		Status:         models.BroadcastQueued,
+		RecipientCount: recipientCount,
+		CreatedAt:      time.Now(),
+	}
+	m.broadcasts[broadcast.ID] = broadcast
+	m.nextID++
+	return broadcast, nil
+}
+
+func (m *mockEventBroadcastRepository) GetByEvent(eventID, limit int) ([]*models.EventBroadcast, error) {
+	var broadcasts []*models.EventBroadcast
+	for id := m.nextID - 1; id > 0 && len(broadcasts) < limit; id-- {
+		if broadcast, exists := m.broadcasts[id]; exists && broadcast.EventID == eventID {
+			broadcasts = append(broadcasts, broadcast)
+		}
+	}
+	return broadcasts, nil
+}
+
+func (m *mockEventBroadcastRepository) GetPending() ([]*models.EventBroadcast, error) {
+	var broadcasts []*models.EventBroadcast
+	for id := 1; id < m.nextID; id++ {
+		if broadcast, exists := m.broadcasts[id]; exists && broadcast.Status != models.BroadcastSent {
+			broadcasts = append(broadcasts, broadcast)
+		}
+	}
+	return broadcasts, nil
+}
+
+func (m *mockEventBroadcastRepository) CountRecipients(eventID int) (int, error) {
+	return len(m.recipients[eventID]), nil
+}
+
+func (m *mockEventBroadcastRepository) GetPendingRecipients(broadcast *models.EventBroadcast, limit int) ([]*models.BroadcastRecipient, error) {
+	var recipients []*models.BroadcastRecipient
+	for _, recipient := range m.recipients[broadcast.EventID] {
+		if len(recipients) < limit && !m.delivered[fmt.Sprintf("%d/%d", broadcast.ID, recipient.OrderID)] {
+			recipients = append(recipients, recipient)
+		}
+	}
+	if len(recipients) > 0 {
+		m.batches = append(m.batches, len(recipients))
+	}
+	return recipients, nil
+}
+
+func (m *mockEventBroadcastRepository) RecordDelivery(broadcastID, orderID int) (bool, error) {
+	key := fmt.Sprintf("%d/%d", broadcastID, orderID)
+	if m.delivered[key] {
+		return false, nil
+	}
+	m.delivered[key] = true
+	return true, nil
+}
+
+func (m *mockEventBroadcastRepository) UpdateProgress(broadcastID int, status models.BroadcastStatus, sent, failed int) error {
+	broadcast, exists := m.broadcasts[broadcastID]
+	if !exists {
+		return fmt.Errorf("broadcast with id %d not found", broadcastID)
+	}
+	broadcast.Status = status
+	broadcast.SentCount += sent
+	broadcast.FailedCount += failed
+	return nil
+}
+
+// mockEventBroadcastEmailSender records sent broadcast emails
+type mockEventBroadcastEmailSender struct {
+	emails  []string
+	locales []string
+	failFor string
+}
+
+func (m *mockEventBroadcastEmailSender) SendEventBroadcastEmail(email, userName, subject, locale string, event *models.Event, message, link string) error {
+	if email == m.failFor {
+		return fmt.Errorf("mailbox unavailable")
+	}
+	m.emails = append(m.emails, fmt.Sprintf("%s|%s|%s|%s", email, subject, message, link))
+	m.locales = append(m.locales, locale)
+	return nil
+}
+
+func setupEventBroadcastService(recipients int) (*EventBroadcastService, *mockEventBroadcastRepository, *mockEventBroadcastEmailSender, *[]time.Duration) {
+	eventRepo := newMockEventRepository()
+	eventRepo.events[1] = &models.Event{ID: 1, Title: "Jazz Night", Status: models.StatusPublished}
+
+	repo := newMockEventBroadcastRepository()
+	for i := 1; i <= recipients; i++ {
+		repo.recipients[1] = append(repo.recipients[1], &models.BroadcastRecipient{
+			OrderID: i,
+			Email:   fmt.Sprintf("attendee%d@example.com", i),
+			Name:    "Attendee",
+		})
+	}
+
+	emailSender := &mockEventBroadcastEmailSender{}
+	service := NewEventBroadcastService(repo, eventRepo, emailSender, "https://example.com")
+	service.SetBatching(2, time.Second)
+	var pauses []time.Duration
+	service.sleep = func(d time.Duration) { pauses = append(pauses, d) }
+
+	return service, repo, emailSender, &pauses
+}
+
+func TestEventBroadcastService_QueueBroadcast(t *testing.T) {
+	t.Run("queues a broadcast without sending it", func(t *testing.T) {
+		service, repo, emailSender, _ := setupEventBroadcastService(3)
+
+		broadcast, err := service.QueueBroadcast(&models.EventBroadcastCreateRequest{
+			EventID:     1,
+			OrganizerID: 7,
+			Subject:     "  Venue change  ",
+			Message:     "We have moved to the main hall.",
+		}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if broadcast.Subject != "Venue change" {
+			t.Errorf("expected the subject to be trimmed, got %q", broadcast.Subject)
+		}
+		if broadcast.Status != models.BroadcastQueued || broadcast.RecipientCount != 3 {
+			t.Errorf("expected a queued broadcast to 3 recipients, got %s to %d", broadcast.Status, broadcast.RecipientCount)
+		}
+		if len(repo.broadcasts) != 1 || len(emailSender.emails) != 0 {
+			t.Errorf("expected the broadcast to be queued only, got %d emails", len(emailSender.emails))
+		}
+	})
+
+	t.Run("rejects an empty message", func(t *testing.T) {
+		service, repo, _, _ := setupEventBroadcastService(3)
+
+		_, err := service.QueueBroadcast(&models.EventBroadcastCreateRequest{EventID: 1, Subject: "Update", Message: "   "}, nil)
+		if err == nil {
+			t.Fatal("expected an error for an empty message")
+		}
+		if len(repo.broadcasts) != 0 {
+			t.Error("expected no broadcast to be queued")
+		}
+	})
+
+	t.Run("rejects events without ticket holders", func(t *testing.T) {
+		service, _, _, _ := setupEventBroadcastService(0)
+
+		_, err := service.QueueBroadcast(&models.EventBroadcastCreateRequest{EventID: 1, Subject: "Update", Message: "Hello"}, nil)
+		if err != ErrNoBroadcastRecipients {
+			t.Errorf("expected ErrNoBroadcastRecipients, got %v", err)
+		}
+	})
+}
+
+func TestEventBroadcastService_ProcessQueue(t *testing.T) {
+	t.Run("sends to every ticket holder in batches", func(t *testing.T) {
+		service, repo, emailSender, pauses := setupEventBroadcastService(5)
+		broadcast, err := service.QueueBroadcast(&models.EventBroadcastCreateRequest{EventID: 1, Subject: "Venue change", Message: "Main hall"}, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		sent, err := service.ProcessQueue()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if sent != 5 || len(emailSender.emails) != 5 {
+			t.Errorf("expected 5 emails, got %d: %v", sent, emailSender.emails)
+		}
+		if fmt.Sprint(repo.batches) != "[2 2 1]" {
+			t.Errorf("expected batches of 2, got %v", repo.batches)
+		}
+		if len(*pauses) != 2 {
+			t.Errorf("expected a pause between batches, got %v", *pauses)
+		}
+		if emailSender.emails[0] != "attendee1@example.com|Venue change|Main hall|https://example.com/events/1" {
+			t.Errorf("unexpected email: %s", emailSender.emails[0])
+		}
+		if !broadcast.IsComplete() || broadcast.SentCount != 5 {
+			t.Errorf("expected the broadcast to be sent to 5, got %s to %d", broadcast.Status, broadcast.SentCount)
+		}
+	})
+
+	t.Run("resumes an interrupted broadcast without resending", func(t *testing.T) {
+		service, repo, emailSender, _ := setupEventBroadcastService(3)
+		broadcast, _ := service.QueueBroadcast(&models.EventBroadcastCreateRequest{EventID: 1, Subject: "Update", Message: "Hello"}, nil)
+		repo.delivered[fmt.Sprintf("%d/%d", broadcast.ID, 1)] = true
+		broadcast.Status = models.BroadcastSending
+		broadcast.SentCount = 1
+
+		if _, err := service.ProcessQueue(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if len(emailSender.emails) != 2 {
+			t.Errorf("expected only the remaining 2 attendees to be emailed, got %v", emailSender.emails)
+		}
+		if broadcast.SentCount != 3 {
+			t.Errorf("expected 3 sent in total, got %d", broadcast.SentCount)
+		}
+
+		if sent, _ := service.ProcessQueue(); sent != 0 {
+			t.Errorf("expected a sent broadcast not to be sent again, got %d", sent)
+		}
+	})
+
+	t.Run("counts failed sends without retrying them", func(t *testing.T) {
+		service, _, emailSender, _ := setupEventBroadcastService(3)
+		emailSender.failFor = "attendee2@example.com"
+		broadcast, _ := service.QueueBroadcast(&models.EventBroadcastCreateRequest{EventID: 1, Subject: "Update", Message: "Hello"}, nil)
+
+		sent, err := service.ProcessQueue()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if sent != 2 || broadcast.FailedCount != 1 || !broadcast.IsComplete() {
+			t.Errorf("expected 2 sent and 1 failed, got %d sent, %d failed (%s)", sent, broadcast.FailedCount, broadcast.Status)
+		}
+	})
+
+	t.Run("emails each attendee in their language", func(t *testing.T) {
+		service, repo, emailSender, _ := setupEventBroadcastService(2)
+		repo.recipients[1][0].Locale = "fr"
+		service.QueueBroadcast(&models.EventBroadcastCreateRequest{EventID: 1, Subject: "Update", Message: "Hello"}, nil)
+
+		if _, err := service.ProcessQueue(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if fmt.Sprint(emailSender.locales) != "[fr en]" {
+			t.Errorf("expected [fr en], got %v", emailSender.locales)
+		}
+	})
+}
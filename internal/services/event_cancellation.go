@@ -0,0 +1,259 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"event-ticketing-platform/internal/i18n"
+	"event-ticketing-platform/internal/models"
+)
+
+// ErrEventAlreadyCancelled is returned when cancelling an event twice
+var ErrEventAlreadyCancelled = errors.New("this event has already been cancelled")
+
+// DefaultCancellationBatchSize is how many orders each run refunds per
+// cancelled event, to stay within the payment provider's rate limits
+const DefaultCancellationBatchSize = 25
+
+// EventCancellationRepository defines the data operations for event cancellations
+type EventCancellationRepository interface {
+	Create(req *models.EventCancellationRequest, orderCount, refundTotal int) (*models.EventCancellation, error)
+	GetByEvent(eventID int) (*models.EventCancellation, error)
+	Delete(id int) error
+	GetPending() ([]*models.EventCancellation, error)
+	GetRefundTotals(eventID int) (int, int, error)
+	CancelPendingOrders(eventID int) (int, error)
+	GetOrdersToRefund(cancellation *models.EventCancellation, maxAttempts, limit int) ([]*models.CancellationOrder, error)
+	ClaimRefund(cancellationID, orderID, maxAttempts int) (int, error)
+	RecordRefund(cancellationID, orderID int, refunded bool, refundID, errorMessage string) error
+	UpdateProgress(cancellationID int, complete bool) error
+}
+
+// EventStatusUpdater changes an event's status, checking the user may do so
+type EventStatusUpdater interface {
+	UpdateEventStatus(eventID int, status models.EventStatus, organizerID int) (*models.Event, error)
+}
+
+// CancellationRefunder refunds an order of a cancelled event and voids its tickets
+type CancellationRefunder interface {
+	RefundCancelledOrder(orderID int) (*RefundResult, error)
+}
+
+// EventCancellationEmailSender sends event cancellation notices in the given language
+type EventCancellationEmailSender interface {
+	SendEventCancellationEmail(email, userName, locale string, event *models.Event, reason, refundInfo string) error
+}
+
+// EventCancellationService cancels events and refunds their ticket holders in
+// the background, telling each of them what happened to their order
+type EventCancellationService struct {
+	repo         EventCancellationRepository
+	events       EventStatusUpdater
+	eventRepo    EventRepository
+	refunder     CancellationRefunder
+	emailSender  EventCancellationEmailSender
+	auditService *AuditService
+	batchSize    int
+}
+
+// NewEventCancellationService creates a new event cancellation service
+func NewEventCancellationService(repo EventCancellationRepository, events EventStatusUpdater, eventRepo EventRepository, refunder CancellationRefunder, emailSender EventCancellationEmailSender) *EventCancellationService {
+	return &EventCancellationService{
+		repo:        repo,
+		events:      events,
+		eventRepo:   eventRepo,
+		refunder:    refunder,
+		emailSender: emailSender,
+		batchSize:   DefaultCancellationBatchSize,
+	}
+}
+
+// SetAuditService records cancelled events in the audit log
+func (s *EventCancellationService) SetAuditService(auditService *AuditService) {
+	s.auditService = auditService
+}
+
+// SetBatchSize sets how many orders each run refunds per cancelled event
+func (s *EventCancellationService) SetBatchSize(batchSize int) {
+	if batchSize > 0 {
+		s.batchSize = batchSize
+	}
+}
+
+// GetCancellation returns an event's cancellation, or nil if it has not been cancelled
+func (s *EventCancellationService) GetCancellation(eventID int) (*models.EventCancellation, error) {
+	cancellation, err := s.repo.GetByEvent(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event cancellation: %w", err)
+	}
+	return cancellation, nil
+}
+
+// GetRefundTotals returns how many orders cancelling an event would refund
+// and their total amount in cents
+func (s *EventCancellationService) GetRefundTotals(eventID int) (int, int, error) {
+	orderCount, refundTotal, err := s.repo.GetRefundTotals(eventID)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to get refund totals: %w", err)
+	}
+	return orderCount, refundTotal, nil
+}
+
+// CancelEvent cancels an event and queues refunds for all of its orders,
+// recording who cancelled it in the audit log
+func (s *EventCancellationService) CancelEvent(req *models.EventCancellationRequest, r *http.Request) (*models.EventCancellation, error) {
+	req.Reason = strings.TrimSpace(req.Reason)
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetByEvent(req.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event cancellation: %w", err)
+	}
+	if existing != nil {
+		return nil, ErrEventAlreadyCancelled
+	}
+
+	orderCount, refundTotal, err := s.repo.GetRefundTotals(req.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get refund totals: %w", err)
+	}
+
+	// Record the cancellation before changing the event's status, so a
+	// cancelled event never ends up without its refunds queued. Refunds only
+	// start once the event is cancelled.
+	cancellation, err := s.repo.Create(req, orderCount, refundTotal)
+	if err != nil {
+		return nil, fmt.Errorf("failed to queue refunds: %w", err)
+	}
+
+	if _, err := s.events.UpdateEventStatus(req.EventID, models.StatusCancelled, req.CancelledBy); err != nil {
+		if deleteErr := s.repo.Delete(cancellation.ID); deleteErr != nil {
+			fmt.Printf("Warning: failed to remove cancellation %d: %v\n", cancellation.ID, deleteErr)
+		}
+		return nil, err
+	}
+
+	if s.auditService != nil && r != nil {
+		details := map[string]interface{}{
+			"cancellation_id": cancellation.ID,
+			"reason":          cancellation.Reason,
+			"order_count":     cancellation.OrderCount,
+			"refund_total":    cancellation.RefundTotal,
+		}
+		if err := s.auditService.LogAction(req.CancelledBy, models.AuditActionEventCancel, models.AuditTargetEvent, req.EventID, details, r); err != nil {
+			fmt.Printf("Warning: failed to log cancellation of event %d: %v\n", req.EventID, err)
+		}
+	}
+
+	return cancellation, nil
+}
+
+// ProcessQueue refunds the next batch of orders for every cancelled event
+// and returns how many were refunded. Failed refunds are retried on later
+// runs. It is meant to run periodically.
+func (s *EventCancellationService) ProcessQueue() (int, error) {
+	cancellations, err := s.repo.GetPending()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get event cancellations: %w", err)
+	}
+
+	refunded := 0
+	for _, cancellation := range cancellations {
+		count, err := s.processCancellation(cancellation)
+		refunded += count
+		if err != nil {
+			fmt.Printf("Warning: failed to process refunds for cancelled event %d: %v\n", cancellation.EventID, err)
+		}
+	}
+
+	return refunded, nil
+}
+
+// processCancellation refunds one batch of a cancelled event's orders and
+// marks the cancellation completed once no orders are left to refund
+func (s *EventCancellationService) processCancellation(cancellation *models.EventCancellation) (int, error) {
+	event, err := s.eventRepo.GetByID(cancellation.EventID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if _, err := s.repo.CancelPendingOrders(event.ID); err != nil {
+		return 0, err
+	}
+
+	orders, err := s.repo.GetOrdersToRefund(cancellation, models.MaxCancellationRefundAttempts, s.batchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get orders to refund: %w", err)
+	}
+
+	refunded := 0
+	for _, order := range orders {
+		attempt, err := s.repo.ClaimRefund(cancellation.ID, order.OrderID, models.MaxCancellationRefundAttempts)
+		if err != nil {
+			return refunded, fmt.Errorf("failed to claim refund: %w", err)
+		}
+		if attempt == 0 {
+			continue
+		}
+
+		result, refundErr := s.refunder.RefundCancelledOrder(order.OrderID)
+		if refundErr != nil {
+			fmt.Printf("Warning: failed to refund order %d for cancelled event %d (attempt %d): %v\n", order.OrderID, event.ID, attempt, refundErr)
+			if err := s.repo.RecordRefund(cancellation.ID, order.OrderID, false, "", refundErr.Error()); err != nil {
+				return refunded, err
+			}
+			// Only tell the buyer once the refund has been given up on
+			if attempt >= models.MaxCancellationRefundAttempts {
+				s.notify(event, cancellation, order, "cancellation.refund_failed")
+			}
+			continue
+		}
+
+		if err := s.repo.RecordRefund(cancellation.ID, order.OrderID, true, result.RefundID, ""); err != nil {
+			return refunded, err
+		}
+		refunded++
+
+		if order.TotalAmount > 0 {
+			s.notify(event, cancellation, order, "cancellation.refunded")
+		} else {
+			s.notify(event, cancellation, order, "cancellation.voided")
+		}
+	}
+
+	remaining, err := s.repo.GetOrdersToRefund(cancellation, models.MaxCancellationRefundAttempts, 1)
+	if err != nil {
+		return refunded, fmt.Errorf("failed to get orders to refund: %w", err)
+	}
+
+	if err := s.repo.UpdateProgress(cancellation.ID, len(remaining) == 0); err != nil {
+		return refunded, fmt.Errorf("failed to record refund progress: %w", err)
+	}
+	return refunded, nil
+}
+
+// notify emails a ticket holder that the event is cancelled, with the
+// outcome of their refund described by refundKey
+func (s *EventCancellationService) notify(event *models.Event, cancellation *models.EventCancellation, order *models.CancellationOrder, refundKey string) {
+	if s.emailSender == nil {
+		return
+	}
+
+	locale := i18n.Resolve(order.Locale)
+	amount := fmt.Sprintf("KSh %.2f", float64(order.TotalAmount)/100.0)
+
+	var refundInfo string
+	if refundKey == "cancellation.voided" {
+		refundInfo = i18n.T(locale, refundKey, order.OrderNumber)
+	} else {
+		refundInfo = i18n.T(locale, refundKey, order.OrderNumber, amount)
+	}
+
+	if err := s.emailSender.SendEventCancellationEmail(order.Email, order.Name, locale, event, cancellation.Reason, refundInfo); err != nil {
+		fmt.Printf("Warning: failed to send cancellation email to %s: %v\n", order.Email, err)
+	}
+}
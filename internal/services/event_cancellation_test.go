@@ -0,0 +1,297 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock EventCancellationRepository for testing. Refunded orders stop being
+// returned, as their status is no longer completed.
+type mockEventCancellationRepository struct {
+	cancellations map[int]*models.EventCancellation
+	orders        []*models.CancellationOrder
+	refunds       map[int]*mockCancellationRefund
+	nextID        int
+}
+
+type mockCancellationRefund struct {
+	status   string
+	attempts int
+}
+
+func newMockEventCancellationRepository(orders ...*models.CancellationOrder) *mockEventCancellationRepository {
+	return &mockEventCancellationRepository{
+		cancellations: make(map[int]*models.EventCancellation),
+		orders:        orders,
+		refunds:       make(map[int]*mockCancellationRefund),
+		nextID:        1,
+	}
+}
+
+func (m *mockEventCancellationRepository) Create(req *models.EventCancellationRequest, orderCount, refundTotal int) (*models.EventCancellation, error) {
+	cancellation := &models.EventCancellation{
+		ID:          m.nextID,
+		EventID:     req.EventID,
+		CancelledBy: req.CancelledBy,
+		Reason:      req.Reason,
+		Status:      models.CancellationRefunding,
+		OrderCount:  orderCount,
+		RefundTotal: refundTotal,
+	}
+	m.cancellations[cancellation.ID] = cancellation
+	m.nextID++
+	return cancellation, nil
+}
+
+func (m *mockEventCancellationRepository) GetByEvent(eventID int) (*models.EventCancellation, error) {
+	for _, cancellation := range m.cancellations {
+		if cancellation.EventID == eventID {
+			return cancellation, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockEventCancellationRepository) Delete(id int) error {
+	delete(m.cancellations, id)
+	return nil
+}
+
+func (m *mockEventCancellationRepository) GetPending() ([]*models.EventCancellation, error) {
+	var result []*models.EventCancellation
+	for _, cancellation := range m.cancellations {
+		if !cancellation.IsComplete() {
+			result = append(result, cancellation)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockEventCancellationRepository) GetRefundTotals(eventID int) (int, int, error) {
+	total := 0
+	for _, order := range m.orders {
+		total += order.TotalAmount
+	}
+	return len(m.orders), total, nil
+}
+
+func (m *mockEventCancellationRepository) CancelPendingOrders(eventID int) (int, error) {
+	return 0, nil
+}
+
+func (m *mockEventCancellationRepository) GetOrdersToRefund(cancellation *models.EventCancellation, maxAttempts, limit int) ([]*models.CancellationOrder, error) {
+	var result []*models.CancellationOrder
+	for _, order := range m.orders {
+		if refund, ok := m.refunds[order.OrderID]; ok && (refund.status != "failed" || refund.attempts >= maxAttempts) {
+			continue
+		}
+		if len(result) < limit {
+			result = append(result, order)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockEventCancellationRepository) ClaimRefund(cancellationID, orderID, maxAttempts int) (int, error) {
+	refund, ok := m.refunds[orderID]
+	if !ok {
+		m.refunds[orderID] = &mockCancellationRefund{status: "pending", attempts: 1}
+		return 1, nil
+	}
+	if refund.status != "failed" || refund.attempts >= maxAttempts {
+		return 0, nil
+	}
+	refund.status = "pending"
+	refund.attempts++
+	return refund.attempts, nil
+}
+
+func (m *mockEventCancellationRepository) RecordRefund(cancellationID, orderID int, refunded bool, refundID, errorMessage string) error {
+	m.refunds[orderID].status = "failed"
+	if refunded {
+		m.refunds[orderID].status = "refunded"
+	}
+	return nil
+}
+
+func (m *mockEventCancellationRepository) UpdateProgress(cancellationID int, complete bool) error {
+	cancellation := m.cancellations[cancellationID]
+	cancellation.RefundedCount, cancellation.FailedCount = 0, 0
+	for _, refund := range m.refunds {
+		switch refund.status {
+		case "refunded":
+			cancellation.RefundedCount++
+		case "failed":
+			cancellation.FailedCount++
+		}
+	}
+	if complete {
+		cancellation.Status = models.CancellationCompleted
+	}
+	return nil
+}
+
+// mockEventStatusUpdater cancels events unless told to fail
+type mockEventStatusUpdater struct {
+	err      error
+	statuses map[int]models.EventStatus
+}
+
+func (m *mockEventStatusUpdater) UpdateEventStatus(eventID int, status models.EventStatus, organizerID int) (*models.Event, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	m.statuses[eventID] = status
+	return &models.Event{ID: eventID, Status: status}, nil
+}
+
+// mockCancellationRefunder fails to refund the orders in failing
+type mockCancellationRefunder struct {
+	failing  map[int]bool
+	refunded []int
+}
+
+func (m *mockCancellationRefunder) RefundCancelledOrder(orderID int) (*RefundResult, error) {
+	if m.failing[orderID] {
+		return nil, errors.New("refund failed: provider unavailable")
+	}
+	m.refunded = append(m.refunded, orderID)
+	return &RefundResult{RefundID: fmt.Sprintf("REF-%d", orderID), Status: "success"}, nil
+}
+
+// recordingCancellationEmailSender records the cancellation notices it is asked to send
+type recordingCancellationEmailSender struct {
+	sent map[string]string // Recipient email to refund information
+}
+
+func (m *recordingCancellationEmailSender) SendEventCancellationEmail(email, userName, locale string, event *models.Event, reason, refundInfo string) error {
+	m.sent[email] = refundInfo
+	return nil
+}
+
+func setupEventCancellationService() (*EventCancellationService, *mockEventCancellationRepository, *mockEventStatusUpdater, *mockCancellationRefunder, *recordingCancellationEmailSender) {
+	repo := newMockEventCancellationRepository(
+		&models.CancellationOrder{OrderID: 1, OrderNumber: "ORD-1", TotalAmount: 2500, Email: "paid@example.com", Name: "Paid Buyer", Locale: "en"},
+		&models.CancellationOrder{OrderID: 2, OrderNumber: "ORD-2", TotalAmount: 1500, Email: "failing@example.com", Name: "Failing Buyer", Locale: "en"},
+		&models.CancellationOrder{OrderID: 3, OrderNumber: "ORD-3", TotalAmount: 0, Email: "free@example.com", Name: "Free Buyer", Locale: "sw"},
+	)
+	events := &mockEventStatusUpdater{statuses: make(map[int]models.EventStatus)}
+	eventRepo := newMockEventRepository()
+	eventRepo.events[1] = &models.Event{ID: 1, Title: "Jazz Night", OrganizerID: 7}
+	refunder := &mockCancellationRefunder{failing: map[int]bool{2: true}}
+	emails := &recordingCancellationEmailSender{sent: make(map[string]string)}
+
+	service := NewEventCancellationService(repo, events, eventRepo, refunder, emails)
+	return service, repo, events, refunder, emails
+}
+
+func TestEventCancellationService_CancelEvent(t *testing.T) {
+	service, repo, events, _, _ := setupEventCancellationService()
+
+	if _, err := service.CancelEvent(&models.EventCancellationRequest{EventID: 1, CancelledBy: 7, Reason: "   "}, nil); err == nil {
+		t.Error("expected a reason to be required")
+	}
+
+	events.err = errors.New("invalid status transition: cannot change status of past events")
+	if _, err := service.CancelEvent(&models.EventCancellationRequest{EventID: 1, CancelledBy: 7, Reason: "Venue flooded"}, nil); err == nil {
+		t.Fatal("expected status error")
+	}
+	if len(repo.cancellations) != 0 {
+		t.Errorf("expected cancellation to be removed when the event could not be cancelled, got %d", len(repo.cancellations))
+	}
+
+	events.err = nil
+	cancellation, err := service.CancelEvent(&models.EventCancellationRequest{EventID: 1, CancelledBy: 7, Reason: " Venue flooded "}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if events.statuses[1] != models.StatusCancelled {
+		t.Errorf("expected event to be cancelled, got %s", events.statuses[1])
+	}
+	if cancellation.Reason != "Venue flooded" || cancellation.OrderCount != 3 || cancellation.RefundTotal != 4000 {
+		t.Errorf("unexpected cancellation: %+v", cancellation)
+	}
+
+	if _, err := service.CancelEvent(&models.EventCancellationRequest{EventID: 1, CancelledBy: 7, Reason: "Again"}, nil); !errors.Is(err, ErrEventAlreadyCancelled) {
+		t.Errorf("expected ErrEventAlreadyCancelled, got %v", err)
+	}
+}
+
+func TestEventCancellationService_ProcessQueue(t *testing.T) {
+	service, _, _, refunder, emails := setupEventCancellationService()
+
+	cancellation, err := service.CancelEvent(&models.EventCancellationRequest{EventID: 1, CancelledBy: 7, Reason: "Venue flooded"}, nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	refunded, err := service.ProcessQueue()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if refunded != 2 || len(refunder.refunded) != 2 {
+		t.Fatalf("expected 2 orders refunded, got %d", refunded)
+	}
+	if !strings.Contains(emails.sent["paid@example.com"], "ORD-1 has been refunded in full. KSh 25.00") {
+		t.Errorf("unexpected refund notice: %q", emails.sent["paid@example.com"])
+	}
+	if !strings.Contains(emails.sent["free@example.com"], "Tiketi zako za agizo ORD-3") {
+		t.Errorf("expected free order to be told its tickets are void in Swahili, got %q", emails.sent["free@example.com"])
+	}
+	if _, ok := emails.sent["failing@example.com"]; ok {
+		t.Error("expected no email before the failing refund is given up on")
+	}
+	if cancellation.IsComplete() || cancellation.FailedCount != 1 {
+		t.Fatalf("expected cancellation to keep retrying with 1 failure, got %+v", cancellation)
+	}
+
+	// The failing order is retried until it runs out of attempts
+	for i := 1; i < models.MaxCancellationRefundAttempts; i++ {
+		if _, err := service.ProcessQueue(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if !cancellation.IsComplete() {
+		t.Fatal("expected cancellation to be complete")
+	}
+	if cancellation.RefundedCount != 2 || cancellation.FailedCount != 1 {
+		t.Errorf("expected 2 refunded and 1 failed, got %d and %d", cancellation.RefundedCount, cancellation.FailedCount)
+	}
+	if !strings.Contains(emails.sent["failing@example.com"], "could not refund order ORD-2") {
+		t.Errorf("unexpected failed refund notice: %q", emails.sent["failing@example.com"])
+	}
+}
+
+func TestTicketService_RefundCancelledOrder(t *testing.T) {
+	service, ticketRepo, orderRepo, paymentService, _ := createTestTicketService()
+
+	paid, _ := orderRepo.Create(&models.OrderCreateRequest{UserID: 1, EventID: 1, TotalAmount: 2500, Status: models.OrderCompleted})
+	paid.PaymentID = "PAY-123"
+	used, _ := ticketRepo.CreateTicket(paid.ID, 1, "QR-USED")
+	used.Status = models.TicketUsed
+
+	if _, err := service.RefundCancelledOrder(paid.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if paid.Status != models.OrderRefunded || used.Status != models.TicketRefunded {
+		t.Errorf("expected order and scanned ticket to be refunded, got %s and %s", paid.Status, used.Status)
+	}
+
+	if _, err := service.RefundCancelledOrder(paid.ID); err == nil {
+		t.Error("expected refunded order not to be refunded again")
+	}
+
+	// Free orders are voided without calling the payment provider
+	paymentService.shouldFailOps["RefundPayment"] = true
+	free, _ := orderRepo.Create(&models.OrderCreateRequest{UserID: 1, EventID: 1, Status: models.OrderCompleted})
+	if _, err := service.RefundCancelledOrder(free.ID); err != nil {
+		t.Fatalf("unexpected error for free order: %v", err)
+	}
+	if free.Status != models.OrderRefunded {
+		t.Errorf("expected free order to be refunded, got %s", free.Status)
+	}
+}
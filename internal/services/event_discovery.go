@@ -24,6 +24,7 @@ func NewEventDiscoveryService(eventService EventServiceInterface) *EventDiscover
 type DiscoveryFilters struct {
 	Query        string    `json:"query"`
 	Category     string    `json:"category"`
+	OrganizerID  int       `json:"organizer_id"`
 	Location     string    `json:"location"`
 	DateFrom     string    `json:"date_from"`
 	DateTo       string    `json:"date_to"`
@@ -35,9 +36,12 @@ type DiscoveryFilters struct {
 	Page         int       `json:"page"`
 	PerPage      int       `json:"per_page"`
 	UserID       int       `json:"user_id"`       // For personalized results
-	Radius       int       `json:"radius"`        // Search radius in km
+	Radius       int       `json:"radius"`        // Search radius in km around Near
+	Near         *models.GeoPoint `json:"near,omitempty"` // Requester's position for "near me" searches
 	Tags         []string  `json:"tags"`
 	Availability string    `json:"availability"`  // available, sold_out, all
+	When         string    `json:"when"`          // today, tomorrow, weekend, next_7_days
+	TimeZone     string    `json:"time_zone"`     // IANA time zone used to resolve When
 }
 
 // DiscoveryResult represents the result of event discovery
@@ -70,13 +74,24 @@ type Facet struct {
 func (s *EventDiscoveryService) DiscoverEvents(filters DiscoveryFilters) (*DiscoveryResult, error) {
 	// Convert to basic search filters for now
 	basicFilters := EventSearchFilters{
-		Query:    filters.Query,
-		Category: filters.Category,
-		Location: filters.Location,
-		DateFrom: filters.DateFrom,
-		DateTo:   filters.DateTo,
-		Page:     filters.Page,
-		PerPage:  filters.PerPage,
+		Query:       filters.Query,
+		Category:    filters.Category,
+		Location:    filters.Location,
+		DateFrom:    filters.DateFrom,
+		DateTo:      filters.DateTo,
+		OrganizerID: filters.OrganizerID,
+		Page:        filters.Page,
+		PerPage:     filters.PerPage,
+		When:        filters.When,
+		TimeZone:    filters.TimeZone,
+		Near:        filters.Near,
+		RadiusKm:    filters.Radius,
+	}
+	if filters.SortBy == "relevance" {
+		basicFilters.SortBy = "relevance"
+	}
+	if filters.SortBy == "distance" && filters.Near != nil {
+		basicFilters.SortBy = "distance"
 	}
 
 	// Get events using existing search
@@ -131,7 +146,7 @@ func (s *EventDiscoveryService) applyAdvancedFilters(events []*models.Event, fil
 			}
 		}
 
-		// Event type filtering (online/offline/hybrid)
+		// Event type filtering (online/offline)
 		if filters.EventType != "" && filters.EventType != "all" {
 			if !s.matchesEventType(event, filters.EventType) {
 				continue
@@ -196,14 +211,9 @@ func (s *EventDiscoveryService) applySorting(events []*models.Event, filters Dis
 			return popularityI < popularityJ
 		})
 	case "relevance":
-		// Sort by relevance to search query
-		if filters.Query != "" {
-			sort.Slice(sorted, func(i, j int) bool {
-				relevanceI := s.calculateRelevance(sorted[i], filters.Query)
-				relevanceJ := s.calculateRelevance(sorted[j], filters.Query)
-				return relevanceI > relevanceJ // Higher relevance first
-			})
-		}
+		// Results are already ordered by full-text rank in the database
+	case "distance":
+		// Results are already ordered by distance in the database
 	default:
 		// Default sort by date (upcoming first)
 		sort.Slice(sorted, func(i, j int) bool {
@@ -245,9 +255,14 @@ func (s *EventDiscoveryService) getEventMinPrice(event *models.Event) int {
 }
 
 func (s *EventDiscoveryService) matchesEventType(event *models.Event, eventType string) bool {
-	// This would check if the event matches the type (online/offline/hybrid)
-	// For now, return true as a placeholder
-	return true
+	switch eventType {
+	case "online":
+		return event.IsOnline()
+	case "offline":
+		return !event.IsOnline()
+	default:
+		return true
+	}
 }
 
 func (s *EventDiscoveryService) matchesTags(event *models.Event, tags []string) bool {
@@ -268,37 +283,6 @@ func (s *EventDiscoveryService) getEventPopularity(event *models.Event) int {
 	return 0
 }
 
-func (s *EventDiscoveryService) calculateRelevance(event *models.Event, query string) float64 {
-	if query == "" {
-		return 0
-	}
-
-	query = strings.ToLower(query)
-	relevance := 0.0
-
-	// Title match (highest weight)
-	if strings.Contains(strings.ToLower(event.Title), query) {
-		relevance += 10.0
-	}
-
-	// Description match (medium weight)
-	if strings.Contains(strings.ToLower(event.Description), query) {
-		relevance += 5.0
-	}
-
-	// Location match (medium weight)
-	if strings.Contains(strings.ToLower(event.Location), query) {
-		relevance += 5.0
-	}
-
-	// Category match (low weight)
-	if event.Category != nil && strings.Contains(strings.ToLower(event.Category.Name), query) {
-		relevance += 2.0
-	}
-
-	return relevance
-}
-
 func (s *EventDiscoveryService) generateSuggestions(query string, events []*models.Event) []string {
 	if query == "" {
 		return []string{}
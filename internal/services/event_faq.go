@@ -0,0 +1,124 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// ErrFAQNotFound is returned when an FAQ entry doesn't exist or belongs to
+// another event
+var ErrFAQNotFound = errors.New("FAQ not found")
+
+// EventFAQRepository defines the data operations for events' FAQs and policies
+type EventFAQRepository interface {
+	GetFAQs(eventID int) ([]*models.EventFAQ, error)
+	GetFAQ(id int) (*models.EventFAQ, error)
+	CreateFAQ(faq *models.EventFAQ) error
+	UpdateFAQ(faq *models.EventFAQ) error
+	DeleteFAQ(id, eventID int) error
+	GetPolicies(eventID int) (*models.EventPolicies, error)
+	SavePolicies(policies *models.EventPolicies) error
+}
+
+// EventFAQService manages the FAQs and policies organizers publish for their
+// events
+type EventFAQService struct {
+	repo EventFAQRepository
+}
+
+// NewEventFAQService creates a new event FAQ service
+func NewEventFAQService(repo EventFAQRepository) *EventFAQService {
+	return &EventFAQService{repo: repo}
+}
+
+// GetInfo returns an event's FAQs and policies
+func (s *EventFAQService) GetInfo(eventID int) (*models.EventInfo, error) {
+	faqs, err := s.repo.GetFAQs(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get FAQs: %w", err)
+	}
+	policies, err := s.repo.GetPolicies(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get policies: %w", err)
+	}
+	return &models.EventInfo{FAQs: faqs, Policies: policies}, nil
+}
+
+// GetFAQ returns one of an event's FAQ entries
+func (s *EventFAQService) GetFAQ(eventID, id int) (*models.EventFAQ, error) {
+	faq, err := s.repo.GetFAQ(id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrFAQNotFound
+		}
+		return nil, err
+	}
+	if faq.EventID != eventID {
+		return nil, ErrFAQNotFound
+	}
+	return faq, nil
+}
+
+// AddFAQ validates and adds an FAQ entry to an event
+func (s *EventFAQService) AddFAQ(eventID int, faq *models.EventFAQ) error {
+	faq.EventID = eventID
+	if err := faq.Validate(); err != nil {
+		return err
+	}
+
+	faqs, err := s.repo.GetFAQs(eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get FAQs: %w", err)
+	}
+	if len(faqs) >= models.MaxEventFAQs {
+		return fmt.Errorf("events can have at most %d FAQs", models.MaxEventFAQs)
+	}
+
+	if err := s.repo.CreateFAQ(faq); err != nil {
+		return fmt.Errorf("failed to add FAQ: %w", err)
+	}
+	return nil
+}
+
+// UpdateFAQ validates and saves changes to one of an event's FAQ entries
+func (s *EventFAQService) UpdateFAQ(eventID int, faq *models.EventFAQ) error {
+	if _, err := s.GetFAQ(eventID, faq.ID); err != nil {
+		return err
+	}
+	faq.EventID = eventID
+	if err := faq.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.repo.UpdateFAQ(faq); err != nil {
+		return fmt.Errorf("failed to update FAQ: %w", err)
+	}
+	return nil
+}
+
+// DeleteFAQ removes an FAQ entry from an event
+func (s *EventFAQService) DeleteFAQ(eventID, id int) error {
+	if err := s.repo.DeleteFAQ(id, eventID); err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return ErrFAQNotFound
+		}
+		return fmt.Errorf("failed to delete FAQ: %w", err)
+	}
+	return nil
+}
+
+// SavePolicies validates and saves an event's policies
+func (s *EventFAQService) SavePolicies(eventID int, policies *models.EventPolicies) error {
+	policies.EventID = eventID
+	if err := policies.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.repo.SavePolicies(policies); err != nil {
+		return fmt.Errorf("failed to save policies: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,147 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"testing"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock EventFAQRepository for testing
+type mockEventFAQRepository struct {
+	faqs     map[int]*models.EventFAQ
+	policies map[int]*models.EventPolicies
+	nextID   int
+}
+
+func newMockEventFAQRepository() *mockEventFAQRepository {
+	return &mockEventFAQRepository{
+		faqs:     make(map[int]*models.EventFAQ),
+		policies: make(map[int]*models.EventPolicies),
+		nextID:   1,
+	}
+}
+
+func (m *mockEventFAQRepository) GetFAQs(eventID int) ([]*models.EventFAQ, error) {
+	var faqs []*models.EventFAQ
+	for _, faq := range m.faqs {
+		if faq.EventID == eventID {
+			faqs = append(faqs, faq)
+		}
+	}
+	sort.Slice(faqs, func(i, j int) bool { return faqs[i].ID < faqs[j].ID })
+	return faqs, nil
+}
+
+func (m *mockEventFAQRepository) GetFAQ(id int) (*models.EventFAQ, error) {
+	faq, ok := m.faqs[id]
+	if !ok {
+		return nil, fmt.Errorf("FAQ with id %d not found", id)
+	}
+	return faq, nil
+}
+
+func (m *mockEventFAQRepository) CreateFAQ(faq *models.EventFAQ) error {
+	faq.ID = m.nextID
+	m.nextID++
+	m.faqs[faq.ID] = faq
+	return nil
+}
+
+func (m *mockEventFAQRepository) UpdateFAQ(faq *models.EventFAQ) error {
+	m.faqs[faq.ID] = faq
+	return nil
+}
+
+func (m *mockEventFAQRepository) DeleteFAQ(id, eventID int) error {
+	if faq, ok := m.faqs[id]; !ok || faq.EventID != eventID {
+		return fmt.Errorf("FAQ with id %d not found", id)
+	}
+	delete(m.faqs, id)
+	return nil
+}
+
+func (m *mockEventFAQRepository) GetPolicies(eventID int) (*models.EventPolicies, error) {
+	return m.policies[eventID], nil
+}
+
+func (m *mockEventFAQRepository) SavePolicies(policies *models.EventPolicies) error {
+	m.policies[policies.EventID] = policies
+	return nil
+}
+
+func TestEventFAQService_AddFAQ(t *testing.T) {
+	repo := newMockEventFAQRepository()
+	service := NewEventFAQService(repo)
+
+	if err := service.AddFAQ(1, &models.EventFAQ{Question: "Is there parking?"}); err == nil {
+		t.Error("expected an FAQ without an answer to be refused")
+	}
+
+	faq := &models.EventFAQ{Question: " Is there parking? ", Answer: "Yes"}
+	if err := service.AddFAQ(1, faq); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if faq.EventID != 1 || repo.faqs[faq.ID].Question != "Is there parking?" {
+		t.Errorf("expected the FAQ to be saved for the event, got %+v", faq)
+	}
+
+	for i := 1; i < models.MaxEventFAQs; i++ {
+		if err := service.AddFAQ(1, &models.EventFAQ{Question: "Q", Answer: "A"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := service.AddFAQ(1, &models.EventFAQ{Question: "Q", Answer: "A"}); err == nil {
+		t.Error("expected FAQs over the limit to be refused")
+	}
+}
+
+func TestEventFAQService_OtherEventsFAQs(t *testing.T) {
+	repo := newMockEventFAQRepository()
+	service := NewEventFAQService(repo)
+	repo.faqs[1] = &models.EventFAQ{ID: 1, EventID: 1, Question: "Is there parking?", Answer: "Yes"}
+
+	if _, err := service.GetFAQ(2, 1); !errors.Is(err, ErrFAQNotFound) {
+		t.Errorf("expected another event's FAQ to be hidden, got %v", err)
+	}
+	if err := service.UpdateFAQ(2, &models.EventFAQ{ID: 1, Question: "Mine now", Answer: "Yes"}); !errors.Is(err, ErrFAQNotFound) {
+		t.Errorf("expected another event's FAQ not to be editable, got %v", err)
+	}
+	if err := service.DeleteFAQ(2, 1); !errors.Is(err, ErrFAQNotFound) {
+		t.Errorf("expected another event's FAQ not to be deletable, got %v", err)
+	}
+	if repo.faqs[1].Question != "Is there parking?" {
+		t.Errorf("expected the FAQ to be unchanged, got %+v", repo.faqs[1])
+	}
+}
+
+func TestEventFAQService_GetInfo(t *testing.T) {
+	repo := newMockEventFAQRepository()
+	service := NewEventFAQService(repo)
+
+	info, err := service.GetInfo(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !info.IsEmpty() {
+		t.Errorf("expected no info for a new event, got %+v", info)
+	}
+
+	if err := service.SavePolicies(1, &models.EventPolicies{MinimumAge: 30}); err == nil {
+		t.Error("expected an invalid minimum age to be refused")
+	}
+	if err := service.SavePolicies(1, &models.EventPolicies{RefundPolicy: " No refunds. ", MinimumAge: 18}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	repo.faqs[1] = &models.EventFAQ{ID: 1, EventID: 1, Question: "Is there parking?", Answer: "Yes"}
+
+	info, err = service.GetInfo(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(info.FAQs) != 1 || info.Policies == nil || info.Policies.RefundPolicy != "No refunds." || info.Policies.EventID != 1 {
+		t.Errorf("expected the event's FAQs and policies, got %+v", info)
+	}
+}
@@ -1,8 +1,12 @@
 package services
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
+	"sort"
 
+	"event-ticketing-platform/internal/cache"
 	"event-ticketing-platform/internal/models"
 	"event-ticketing-platform/internal/repositories"
 )
@@ -11,8 +15,31 @@ import (
 type EventModerationService struct {
 	eventRepo *repositories.EventRepository
 	auditService *AuditService
+	cache        cache.Cache
+	changeHooks  []EventChangeHook
+	events       *DomainEventBus
+	contentScreen *ContentScreenService
+	reputation    *OrganizerReputationService
+	reviews       EventReviewRepository
+	userRepo      UserRepository
+	team          TeamAccessChecker
 }
 
+// EventReviewRepository defines the data operations for the event approval
+// queue's reviewer assignments and review comments
+type EventReviewRepository interface {
+	AddComment(comment *models.EventReviewComment) error
+	GetComments(eventIDs []int) (map[int][]*models.EventReviewComment, error)
+	GetUnassignedPendingEvents() ([]int, error)
+	GetLastAssignedReviewer() (int, error)
+	Assign(eventID, reviewerID int) error
+	GetAssignments(eventIDs []int) (map[int]*models.EventReviewAssignment, error)
+}
+
+// errReviewQueueDisabled is returned by review queue operations when no
+// review repository has been set
+var errReviewQueueDisabled = errors.New("the event review queue is not enabled")
+
 // NewEventModerationService creates a new event moderation service
 func NewEventModerationService(eventRepo *repositories.EventRepository, auditService *AuditService) *EventModerationService {
 	return &EventModerationService{
@@ -21,6 +48,285 @@ func NewEventModerationService(eventRepo *repositories.EventRepository, auditSer
 	}
 }
 
+// SetCache sets the event cache to invalidate when moderation publishes or
+// rejects an event
+func (s *EventModerationService) SetCache(c cache.Cache) {
+	s.cache = c
+}
+
+// AddChangeHook registers a hook to run after moderation publishes or rejects an event
+func (s *EventModerationService) AddChangeHook(hook EventChangeHook) {
+	s.changeHooks = append(s.changeHooks, hook)
+}
+
+// SetEventBus sets the bus approved events are published on
+func (s *EventModerationService) SetEventBus(events *DomainEventBus) {
+	s.events = events
+}
+
+// SetContentScreen sets the content screen whose flags explain why events
+// were held for review
+func (s *EventModerationService) SetContentScreen(screen *ContentScreenService) {
+	s.contentScreen = screen
+}
+
+// GetContentFlags returns the latest content flag of each event that was held
+// for review by content screening, keyed by event ID
+func (s *EventModerationService) GetContentFlags(events []*models.Event) (map[int]*models.EventContentFlag, error) {
+	if s.contentScreen == nil {
+		return map[int]*models.EventContentFlag{}, nil
+	}
+
+	eventIDs := make([]int, len(events))
+	for i, event := range events {
+		eventIDs[i] = event.ID
+	}
+	return s.contentScreen.GetFlags(eventIDs)
+}
+
+// SetReputationService sets the reputation service used to show moderators
+// each organizer's track record
+func (s *EventModerationService) SetReputationService(reputation *OrganizerReputationService) {
+	s.reputation = reputation
+}
+
+// GetOrganizerReputations returns the reputation of each event's organizer,
+// keyed by organizer ID
+func (s *EventModerationService) GetOrganizerReputations(events []*models.Event) (map[int]*models.OrganizerReputation, error) {
+	if s.reputation == nil {
+		return map[int]*models.OrganizerReputation{}, nil
+	}
+
+	organizerIDs := make([]int, len(events))
+	for i, event := range events {
+		organizerIDs[i] = event.OrganizerID
+	}
+	return s.reputation.GetReputations(organizerIDs)
+}
+
+// SetReviewQueue enables the approval queue: events waiting for review are
+// assigned to moderators round-robin, and reviewers can leave comments and
+// request changes as well as approve or reject
+func (s *EventModerationService) SetReviewQueue(reviews EventReviewRepository, userRepo UserRepository) {
+	s.reviews = reviews
+	s.userRepo = userRepo
+}
+
+// SetTeamAccess lets organizers' team members who manage events answer
+// review comments on them
+func (s *EventModerationService) SetTeamAccess(team TeamAccessChecker) {
+	s.team = team
+}
+
+// GetReviewers returns the active moderators events are assigned to, in
+// order of ID
+func (s *EventModerationService) GetReviewers() ([]*models.User, error) {
+	if s.userRepo == nil {
+		return nil, nil
+	}
+
+	moderators, err := s.userRepo.GetByRole(models.RoleModerator)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get moderators: %w", err)
+	}
+
+	var reviewers []*models.User
+	for _, moderator := range moderators {
+		if moderator.IsActive {
+			reviewers = append(reviewers, moderator)
+		}
+	}
+	sort.Slice(reviewers, func(i, j int) bool { return reviewers[i].ID < reviewers[j].ID })
+	return reviewers, nil
+}
+
+// AssignPendingEvents assigns each event waiting for review without a
+// reviewer to the next moderator in turn, and returns how many were
+// assigned. Events stay unassigned while there are no moderators.
+func (s *EventModerationService) AssignPendingEvents() (int, error) {
+	if s.reviews == nil {
+		return 0, nil
+	}
+
+	reviewers, err := s.GetReviewers()
+	if err != nil || len(reviewers) == 0 {
+		return 0, err
+	}
+	reviewerIDs := make([]int, len(reviewers))
+	for i, reviewer := range reviewers {
+		reviewerIDs[i] = reviewer.ID
+	}
+
+	eventIDs, err := s.reviews.GetUnassignedPendingEvents()
+	if err != nil {
+		return 0, err
+	}
+	last, err := s.reviews.GetLastAssignedReviewer()
+	if err != nil {
+		return 0, err
+	}
+
+	assigned := 0
+	for _, eventID := range eventIDs {
+		reviewerID := models.NextReviewer(reviewerIDs, last)
+		if err := s.reviews.Assign(eventID, reviewerID); err != nil {
+			return assigned, err
+		}
+		last = reviewerID
+		assigned++
+	}
+	return assigned, nil
+}
+
+// AssignReviewer hands an event's review to a moderator or admin
+func (s *EventModerationService) AssignReviewer(eventID, reviewerID, assignedBy int, r *http.Request) error {
+	if s.reviews == nil {
+		return errReviewQueueDisabled
+	}
+
+	reviewer, err := s.userRepo.GetByID(reviewerID)
+	if err != nil {
+		return fmt.Errorf("reviewer not found: %w", err)
+	}
+	if !s.CanUserModerateEvent(reviewer.ID, reviewer.Role) || !reviewer.IsActive {
+		return fmt.Errorf("events can only be assigned to active moderators and admins")
+	}
+
+	if err := s.reviews.Assign(eventID, reviewerID); err != nil {
+		return err
+	}
+
+	if s.auditService != nil {
+		_ = s.auditService.LogAction(assignedBy, models.AuditActionEventAssignReviewer, models.AuditTargetEvent, eventID, map[string]interface{}{
+			"event_id":    eventID,
+			"reviewer_id": reviewerID,
+		}, r)
+	}
+	return nil
+}
+
+// RequestChanges sends an event waiting for review back to its organizer as
+// a draft, with a comment explaining what needs to change. Publishing it
+// again puts it back in the queue, with the same reviewer.
+func (s *EventModerationService) RequestChanges(eventID int, reviewerID int, comment string, r *http.Request) error {
+	if s.reviews == nil {
+		return errReviewQueueDisabled
+	}
+
+	review := &models.EventReviewComment{EventID: eventID, AuthorID: reviewerID, Decision: models.ReviewChangesRequested, Body: comment}
+	if err := review.Validate(); err != nil {
+		return err
+	}
+
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if err := s.eventRepo.ReturnToDraft(eventID, reviewerID); err != nil {
+		return err
+	}
+	if err := s.reviews.AddComment(review); err != nil {
+		return err
+	}
+	invalidateEventCache(s.cache)
+	notifyEventChangeHooks(s.changeHooks)
+
+	if s.auditService != nil {
+		_ = s.auditService.LogAction(reviewerID, models.AuditActionEventRequestChanges, models.AuditTargetEvent, eventID, map[string]interface{}{
+			"event_id":     eventID,
+			"event_title":  event.Title,
+			"organizer_id": event.OrganizerID,
+			"comment":      review.Body,
+		}, r)
+	}
+	return nil
+}
+
+// AddReviewComment leaves a comment on an event's review. Moderators, admins
+// and the event's organizer can all comment.
+func (s *EventModerationService) AddReviewComment(eventID int, author *models.User, body string) (*models.EventReviewComment, error) {
+	if s.reviews == nil {
+		return nil, errReviewQueueDisabled
+	}
+
+	if !s.CanUserModerateEvent(author.ID, author.Role) {
+		event, err := s.eventRepo.GetByID(eventID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get event: %w", err)
+		}
+		if !s.managesEvent(event, author.ID) {
+			return nil, fmt.Errorf("only reviewers and the event's organizer can comment on its review")
+		}
+	}
+
+	comment := &models.EventReviewComment{EventID: eventID, AuthorID: author.ID, Decision: models.ReviewComment, Body: body}
+	if err := comment.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.reviews.AddComment(comment); err != nil {
+		return nil, err
+	}
+	return comment, nil
+}
+
+// GetReviewComments returns the review comments on each event, oldest first,
+// keyed by event ID
+func (s *EventModerationService) GetReviewComments(events []*models.Event) (map[int][]*models.EventReviewComment, error) {
+	if s.reviews == nil {
+		return map[int][]*models.EventReviewComment{}, nil
+	}
+	return s.reviews.GetComments(eventIDsOf(events))
+}
+
+// GetReviewAssignments returns the reviewer assigned to each event, keyed by
+// event ID
+func (s *EventModerationService) GetReviewAssignments(events []*models.Event) (map[int]*models.EventReviewAssignment, error) {
+	if s.reviews == nil {
+		return map[int]*models.EventReviewAssignment{}, nil
+	}
+	return s.reviews.GetAssignments(eventIDsOf(events))
+}
+
+// recordDecision keeps a reviewer's decision in the event's review history
+func (s *EventModerationService) recordDecision(eventID, reviewerID int, decision models.ReviewDecision, body string) {
+	if s.reviews == nil {
+		return
+	}
+
+	comment := &models.EventReviewComment{EventID: eventID, AuthorID: reviewerID, Decision: decision, Body: body}
+	if err := s.reviews.AddComment(comment); err != nil {
+		fmt.Printf("Warning: failed to record review decision for event %d: %v\n", eventID, err)
+	}
+}
+
+// managesEvent returns true if the user owns the event or manages events on
+// its organizer's team
+func (s *EventModerationService) managesEvent(event *models.Event, userID int) bool {
+	if event.OrganizerID == userID {
+		return true
+	}
+	if s.team == nil {
+		return false
+	}
+
+	ok, err := s.team.HasAccess(event.OrganizerID, userID, models.PermissionManageEvents)
+	if err != nil {
+		fmt.Printf("Warning: failed to check team access to event %d: %v\n", event.ID, err)
+		return false
+	}
+	return ok
+}
+
+// eventIDsOf returns the IDs of the events
+func eventIDsOf(events []*models.Event) []int {
+	eventIDs := make([]int, len(events))
+	for i, event := range events {
+		eventIDs[i] = event.ID
+	}
+	return eventIDs
+}
+
 // GetPendingEvents retrieves events that are pending review
 func (s *EventModerationService) GetPendingEvents(page, limit int) ([]*models.Event, int, error) {
 	offset := (page - 1) * limit
@@ -40,6 +346,14 @@ func (s *EventModerationService) ApproveEvent(eventID int, reviewerID int, r int
 	if err != nil {
 		return err
 	}
+	s.recordDecision(eventID, reviewerID, models.ReviewApproved, "")
+	invalidateEventCache(s.cache)
+	notifyEventChangeHooks(s.changeHooks)
+	if approved, err := s.eventRepo.GetByID(eventID); err != nil {
+		fmt.Printf("Warning: failed to get approved event %d: %v\n", eventID, err)
+	} else {
+		s.events.PublishEventPublished(approved)
+	}
 
 	// Log the action
 	auditDetails := map[string]interface{}{
@@ -69,6 +383,9 @@ func (s *EventModerationService) RejectEvent(eventID int, reviewerID int, reason
 	if err != nil {
 		return err
 	}
+	s.recordDecision(eventID, reviewerID, models.ReviewRejected, reason)
+	invalidateEventCache(s.cache)
+	notifyEventChangeHooks(s.changeHooks)
 
 	// Log the action
 	auditDetails := map[string]interface{}{
@@ -86,6 +403,77 @@ func (s *EventModerationService) RejectEvent(eventID int, reviewerID int, reason
 	return nil
 }
 
+// GetAdminEvents retrieves events of a status for admins, filtered by title
+// and category, newest first
+func (s *EventModerationService) GetAdminEvents(query string, status models.EventStatus, categoryID, page, limit int) ([]*models.Event, int, error) {
+	return s.eventRepo.Search(repositories.EventSearchFilters{
+		Query:      query,
+		Status:     status,
+		CategoryID: categoryID,
+		Limit:      limit,
+		Offset:     (page - 1) * limit,
+		SortBy:     "created_at",
+		SortDesc:   true,
+	})
+}
+
+// UnpublishEvent takes a published event down, returning it to its organizer
+// as a draft
+func (s *EventModerationService) UnpublishEvent(eventID int, adminID int, r *http.Request) (*models.Event, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if err := s.eventRepo.Unpublish(eventID, adminID); err != nil {
+		return event, err
+	}
+	invalidateEventCache(s.cache)
+	notifyEventChangeHooks(s.changeHooks)
+
+	s.logEventAction(adminID, models.AuditActionEventUnpublish, event, nil, r)
+	return event, nil
+}
+
+// AssignCategory moves an event to another category
+func (s *EventModerationService) AssignCategory(eventID, categoryID int, adminID int, r *http.Request) (*models.Event, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	if err := s.eventRepo.UpdateCategory(eventID, categoryID); err != nil {
+		return event, err
+	}
+	invalidateEventCache(s.cache)
+	notifyEventChangeHooks(s.changeHooks)
+
+	details := map[string]interface{}{
+		"previous_category_id": event.CategoryID,
+		"category_id":          categoryID,
+	}
+	s.logEventAction(adminID, models.AuditActionEventCategoryChange, event, details, r)
+	return event, nil
+}
+
+// logEventAction records an admin's change to an event in the audit log
+func (s *EventModerationService) logEventAction(adminID int, action string, event *models.Event, extra map[string]interface{}, r *http.Request) {
+	if s.auditService == nil {
+		return
+	}
+
+	details := map[string]interface{}{
+		"event_title":  event.Title,
+		"organizer_id": event.OrganizerID,
+	}
+	for key, value := range extra {
+		details[key] = value
+	}
+	if err := s.auditService.LogAction(adminID, action, models.AuditTargetEvent, event.ID, details, r); err != nil {
+		fmt.Printf("Warning: failed to log %s of event %d: %v\n", action, event.ID, err)
+	}
+}
+
 // SubmitForReview submits an event for admin review
 func (s *EventModerationService) SubmitForReview(eventID int, organizerID int) error {
 	return s.eventRepo.SubmitForReview(eventID, organizerID)
@@ -98,7 +486,7 @@ func (s *EventModerationService) GetEventModerationHistory(eventID int) (*models
 
 // CanUserModerateEvent checks if a user can moderate a specific event
 func (s *EventModerationService) CanUserModerateEvent(userID int, userRole models.UserRole) bool {
-	return userRole == models.UserRoleAdmin
+	return userRole == models.UserRoleAdmin || userRole == models.UserRoleModerator
 }
 
 // CanUserSubmitForReview checks if a user can submit an event for review
@@ -0,0 +1,142 @@
+package services
+
+import (
+	"testing"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock EventReviewRepository for testing
+type mockEventReviewRepository struct {
+	unassigned  []int
+	assignments map[int]int
+	order       []int
+	comments    []*models.EventReviewComment
+}
+
+func newMockEventReviewRepository(unassigned ...int) *mockEventReviewRepository {
+	return &mockEventReviewRepository{unassigned: unassigned, assignments: make(map[int]int)}
+}
+
+func (m *mockEventReviewRepository) AddComment(comment *models.EventReviewComment) error {
+	comment.ID = len(m.comments) + 1
+	m.comments = append(m.comments, comment)
+	return nil
+}
+
+func (m *mockEventReviewRepository) GetComments(eventIDs []int) (map[int][]*models.EventReviewComment, error) {
+	comments := make(map[int][]*models.EventReviewComment)
+	for _, comment := range m.comments {
+		comments[comment.EventID] = append(comments[comment.EventID], comment)
+	}
+	return comments, nil
+}
+
+func (m *mockEventReviewRepository) GetUnassignedPendingEvents() ([]int, error) {
+	var eventIDs []int
+	for _, id := range m.unassigned {
+		if _, ok := m.assignments[id]; !ok {
+			eventIDs = append(eventIDs, id)
+		}
+	}
+	return eventIDs, nil
+}
+
+func (m *mockEventReviewRepository) GetLastAssignedReviewer() (int, error) {
+	if len(m.order) == 0 {
+		return 0, nil
+	}
+	return m.assignments[m.order[len(m.order)-1]], nil
+}
+
+func (m *mockEventReviewRepository) Assign(eventID, reviewerID int) error {
+	m.assignments[eventID] = reviewerID
+	m.order = append(m.order, eventID)
+	return nil
+}
+
+func (m *mockEventReviewRepository) GetAssignments(eventIDs []int) (map[int]*models.EventReviewAssignment, error) {
+	assignments := make(map[int]*models.EventReviewAssignment)
+	for eventID, reviewerID := range m.assignments {
+		assignments[eventID] = &models.EventReviewAssignment{EventID: eventID, ReviewerID: reviewerID}
+	}
+	return assignments, nil
+}
+
+func newTestModerationService(reviews *mockEventReviewRepository) (*EventModerationService, *MockUserRepository) {
+	users := &MockUserRepository{}
+	users.On("GetByRole", models.RoleModerator).Return([]*models.User{
+		{ID: 8, Role: models.RoleModerator, IsActive: true},
+		{ID: 4, Role: models.RoleModerator, IsActive: true},
+		{ID: 6, Role: models.RoleModerator, IsActive: false},
+	}, nil)
+
+	service := NewEventModerationService(nil, nil)
+	service.SetReviewQueue(reviews, users)
+	return service, users
+}
+
+func TestEventModerationService_AssignPendingEvents(t *testing.T) {
+	reviews := newMockEventReviewRepository(101, 102, 103)
+	service, _ := newTestModerationService(reviews)
+
+	assigned, err := service.AssignPendingEvents()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if assigned != 3 {
+		t.Fatalf("expected 3 events assigned, got %d", assigned)
+	}
+
+	// Suspended moderators are skipped and turns wrap around
+	want := map[int]int{101: 4, 102: 8, 103: 4}
+	for eventID, reviewerID := range want {
+		if reviews.assignments[eventID] != reviewerID {
+			t.Errorf("expected event %d assigned to %d, got %d", eventID, reviewerID, reviews.assignments[eventID])
+		}
+	}
+
+	// The next event continues the rotation
+	reviews.unassigned = append(reviews.unassigned, 104)
+	if _, err := service.AssignPendingEvents(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reviews.assignments[104] != 8 {
+		t.Errorf("expected event 104 assigned to 8, got %d", reviews.assignments[104])
+	}
+}
+
+func TestEventModerationService_AssignReviewer(t *testing.T) {
+	reviews := newMockEventReviewRepository()
+	service, users := newTestModerationService(reviews)
+	users.On("GetByID", 4).Return(&models.User{ID: 4, Role: models.RoleModerator, IsActive: true}, nil)
+	users.On("GetByID", 5).Return(&models.User{ID: 5, Role: models.RoleOrganizer, IsActive: true}, nil)
+
+	if err := service.AssignReviewer(101, 5, 1, nil); err == nil {
+		t.Error("expected events not to be assigned to organizers")
+	}
+	if err := service.AssignReviewer(101, 4, 1, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if reviews.assignments[101] != 4 {
+		t.Errorf("expected event assigned to moderator 4, got %d", reviews.assignments[101])
+	}
+}
+
+func TestEventModerationService_AddReviewComment(t *testing.T) {
+	reviews := newMockEventReviewRepository()
+	service, _ := newTestModerationService(reviews)
+	moderator := &models.User{ID: 4, Role: models.RoleModerator}
+
+	if _, err := service.AddReviewComment(101, moderator, "   "); err == nil {
+		t.Error("expected empty comments to be refused")
+	}
+
+	comment, err := service.AddReviewComment(101, moderator, "Please add the venue address")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if comment.Decision != models.ReviewComment || len(reviews.comments) != 1 {
+		t.Errorf("expected a plain comment to be saved, got %+v", comment)
+	}
+}
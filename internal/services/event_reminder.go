@@ -0,0 +1,157 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/i18n"
+	"event-ticketing-platform/internal/models"
+)
+
+// EventReminderRepository defines the data operations for attendee reminders
+type EventReminderRepository interface {
+	GetSettings(eventID int) (*models.EventReminderSettings, error)
+	SaveSettings(settings *models.EventReminderSettings) error
+	GetEventsStartingBetween(from, to time.Time) ([]int, error)
+	GetReminderRecipients(eventID int, reminder models.ReminderType) ([]*models.ReminderRecipient, error)
+	RecordReminderSent(eventID, orderID int, reminder models.ReminderType) (bool, error)
+}
+
+// EventReminderEmailSender sends attendee reminder emails in the given language
+type EventReminderEmailSender interface {
+	SendEventReminderEmail(email, userName, subject, locale string, event *models.Event, message, link string, access *models.EventAccess) error
+}
+
+// EventReminderService emails ticket holders ahead of their events
+type EventReminderService struct {
+	repo        EventReminderRepository
+	eventRepo   EventRepository
+	emailSender EventReminderEmailSender
+	baseURL     string
+	access      *EventAccessService
+	now         func() time.Time
+}
+
+// NewEventReminderService creates a new event reminder service
+func NewEventReminderService(repo EventReminderRepository, eventRepo EventRepository, emailSender EventReminderEmailSender, baseURL string) *EventReminderService {
+	return &EventReminderService{
+		repo:        repo,
+		eventRepo:   eventRepo,
+		emailSender: emailSender,
+		baseURL:     baseURL,
+		now:         time.Now,
+	}
+}
+
+// SetAccessService includes the access link of online events in their reminders
+func (s *EventReminderService) SetAccessService(access *EventAccessService) {
+	s.access = access
+}
+
+// GetSettings retrieves an event's reminder settings
+func (s *EventReminderService) GetSettings(eventID int) (*models.EventReminderSettings, error) {
+	settings, err := s.repo.GetSettings(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reminder settings: %w", err)
+	}
+	return settings, nil
+}
+
+// UpdateSettings validates and saves an event's reminder settings
+func (s *EventReminderService) UpdateSettings(settings *models.EventReminderSettings) error {
+	settings.Message = strings.TrimSpace(settings.Message)
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.repo.SaveSettings(settings); err != nil {
+		return fmt.Errorf("failed to update reminder settings: %w", err)
+	}
+	return nil
+}
+
+// SendDueReminders emails every reminder that is due and returns how many
+// were sent. A reminder is due once the event is closer than its lead time
+// but not yet within the next reminder's, so tickets bought late only get
+// the reminders that are still relevant. It is meant to run periodically.
+func (s *EventReminderService) SendDueReminders() (int, error) {
+	now := s.now()
+	sent := 0
+
+	for i, reminder := range models.ReminderTypes {
+		var next time.Duration
+		if i+1 < len(models.ReminderTypes) {
+			next = models.ReminderTypes[i+1].Before()
+		}
+
+		eventIDs, err := s.repo.GetEventsStartingBetween(now.Add(next), now.Add(reminder.Before()))
+		if err != nil {
+			return sent, fmt.Errorf("failed to find events due a %s reminder: %w", reminder, err)
+		}
+
+		for _, eventID := range eventIDs {
+			count, err := s.sendEventReminders(eventID, reminder)
+			sent += count
+			if err != nil {
+				fmt.Printf("Warning: failed to send %s reminders for event %d: %v\n", reminder, eventID, err)
+			}
+		}
+	}
+
+	return sent, nil
+}
+
+// sendEventReminders emails the given reminder to the event's ticket holders
+// who have not received it yet
+func (s *EventReminderService) sendEventReminders(eventID int, reminder models.ReminderType) (int, error) {
+	settings, err := s.repo.GetSettings(eventID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get reminder settings: %w", err)
+	}
+	if !settings.Wants(reminder) {
+		return 0, nil
+	}
+
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	recipients, err := s.repo.GetReminderRecipients(eventID, reminder)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get reminder recipients: %w", err)
+	}
+
+	// Every recipient holds a ticket, so they all get the link once it's revealed
+	var access *models.EventAccess
+	if s.access != nil {
+		if access, err = s.access.TicketHolderAccess(event); err != nil {
+			fmt.Printf("Warning: failed to get access link for event %d: %v\n", eventID, err)
+		}
+	}
+
+	sent := 0
+	for _, recipient := range recipients {
+		// Record the reminder before sending it so several instances running
+		// the job never email the same attendee twice
+		isNew, err := s.repo.RecordReminderSent(eventID, recipient.OrderID, reminder)
+		if err != nil {
+			return sent, fmt.Errorf("failed to record reminder: %w", err)
+		}
+		if !isNew {
+			continue
+		}
+
+		locale := i18n.Resolve(recipient.Locale)
+		subject := i18n.T(locale, "reminder.subject", event.Title, i18n.T(locale, "reminder.lead."+string(reminder)))
+		link := fmt.Sprintf("%s/dashboard/orders/%d", s.baseURL, recipient.OrderID)
+		if err := s.emailSender.SendEventReminderEmail(recipient.Email, recipient.Name, subject, locale, event, settings.Message, link, access); err != nil {
+			fmt.Printf("Warning: failed to send reminder email to %s: %v\n", recipient.Email, err)
+			continue
+		}
+		sent++
+	}
+
+	return sent, nil
+}
@@ -0,0 +1,254 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock EventReminderRepository for testing
+type mockEventReminderRepository struct {
+	eventRepo  *mockEventRepository
+	settings   map[int]*models.EventReminderSettings
+	recipients map[int][]*models.ReminderRecipient
+	sent       map[string]bool
+}
+
+func newMockEventReminderRepository(eventRepo *mockEventRepository) *mockEventReminderRepository {
+	return &mockEventReminderRepository{
+		eventRepo:  eventRepo,
+		settings:   make(map[int]*models.EventReminderSettings),
+		recipients: make(map[int][]*models.ReminderRecipient),
+		sent:       make(map[string]bool),
+	}
+}
+
+func (m *mockEventReminderRepository) GetSettings(eventID int) (*models.EventReminderSettings, error) {
+	if settings, exists := m.settings[eventID]; exists {
+		return settings, nil
+	}
+	return models.DefaultEventReminderSettings(eventID), nil
+}
+
+func (m *mockEventReminderRepository) SaveSettings(settings *models.EventReminderSettings) error {
+	m.settings[settings.EventID] = settings
+	return nil
+}
+
+func (m *mockEventReminderRepository) GetEventsStartingBetween(from, to time.Time) ([]int, error) {
+	var eventIDs []int
+	for id, event := range m.eventRepo.events {
+		if event.Status == models.StatusPublished && event.StartDate.After(from) && !event.StartDate.After(to) {
+			eventIDs = append(eventIDs, id)
+		}
+	}
+	return eventIDs, nil
+}
+
+func (m *mockEventReminderRepository) GetReminderRecipients(eventID int, reminder models.ReminderType) ([]*models.ReminderRecipient, error) {
+	var recipients []*models.ReminderRecipient
+	for _, recipient := range m.recipients[eventID] {
+		if !m.sent[fmt.Sprintf("%d/%s", recipient.OrderID, reminder)] {
+			recipients = append(recipients, recipient)
+		}
+	}
+	return recipients, nil
+}
+
+func (m *mockEventReminderRepository) RecordReminderSent(eventID, orderID int, reminder models.ReminderType) (bool, error) {
+	key := fmt.Sprintf("%d/%s", orderID, reminder)
+	if m.sent[key] {
+		return false, nil
+	}
+	m.sent[key] = true
+	return true, nil
+}
+
+// mockEventReminderEmailSender records sent reminder emails
+type mockEventReminderEmailSender struct {
+	emails   []string
+	locales  []string
+	accesses []*models.EventAccess
+}
+
+func (m *mockEventReminderEmailSender) SendEventReminderEmail(email, userName, subject, locale string, event *models.Event, message, link string, access *models.EventAccess) error {
+	m.emails = append(m.emails, fmt.Sprintf("%s|%s|%s|%s", email, subject, message, link))
+	m.locales = append(m.locales, locale)
+	m.accesses = append(m.accesses, access)
+	return nil
+}
+
+func setupEventReminderService() (*EventReminderService, *mockEventReminderRepository, *mockEventReminderEmailSender) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	eventRepo := newMockEventRepository()
+	eventRepo.events[1] = &models.Event{ID: 1, Title: "Jazz Night", Status: models.StatusPublished, StartDate: now.Add(6 * 24 * time.Hour)}
+	eventRepo.events[2] = &models.Event{ID: 2, Title: "Tech Meetup", Status: models.StatusPublished, StartDate: now.Add(20 * time.Hour)}
+	eventRepo.events[3] = &models.Event{ID: 3, Title: "Fun Run", Status: models.StatusPublished, StartDate: now.Add(90 * time.Minute)}
+	eventRepo.events[4] = &models.Event{ID: 4, Title: "Film Festival", Status: models.StatusPublished, StartDate: now.Add(30 * 24 * time.Hour)}
+
+	repo := newMockEventReminderRepository(eventRepo)
+	for eventID := 1; eventID <= 4; eventID++ {
+		repo.recipients[eventID] = []*models.ReminderRecipient{
+			{OrderID: eventID * 10, Email: fmt.Sprintf("attendee%d@example.com", eventID), Name: "Attendee", TicketCount: 2},
+		}
+	}
+
+	emailSender := &mockEventReminderEmailSender{}
+	service := NewEventReminderService(repo, eventRepo, emailSender, "https://example.com")
+	service.now = func() time.Time { return now }
+
+	return service, repo, emailSender
+}
+
+func TestEventReminderService_SendDueReminders(t *testing.T) {
+	t.Run("sends the reminder due for each event once", func(t *testing.T) {
+		service, _, emailSender := setupEventReminderService()
+
+		sent, err := service.SendDueReminders()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if sent != 3 {
+			t.Errorf("expected 3 reminders, got %d: %v", sent, emailSender.emails)
+		}
+
+		expected := []string{
+			"attendee1@example.com|Reminder: Jazz Night starts in 7 days||https://example.com/dashboard/orders/10",
+			"attendee2@example.com|Reminder: Tech Meetup starts in 24 hours||https://example.com/dashboard/orders/20",
+			"attendee3@example.com|Reminder: Fun Run starts in 2 hours||https://example.com/dashboard/orders/30",
+		}
+		for _, email := range expected {
+			found := false
+			for _, sentEmail := range emailSender.emails {
+				if sentEmail == email {
+					found = true
+				}
+			}
+			if !found {
+				t.Errorf("expected email %q, got %v", email, emailSender.emails)
+			}
+		}
+
+		sent, err = service.SendDueReminders()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if sent != 0 {
+			t.Errorf("expected reminders not to be sent twice, got %d", sent)
+		}
+	})
+
+	t.Run("respects reminder settings and includes the custom message", func(t *testing.T) {
+		service, repo, emailSender := setupEventReminderService()
+
+		settings := models.DefaultEventReminderSettings(1)
+		settings.Enabled = false
+		repo.settings[1] = settings
+
+		settings = models.DefaultEventReminderSettings(2)
+		settings.OneDay = false
+		repo.settings[2] = settings
+
+		settings = models.DefaultEventReminderSettings(3)
+		settings.Message = "Parking opens at 6am"
+		repo.settings[3] = settings
+
+		sent, err := service.SendDueReminders()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if sent != 1 || len(emailSender.emails) != 1 {
+			t.Fatalf("expected only the 2 hour reminder, got %v", emailSender.emails)
+		}
+		if !strings.Contains(emailSender.emails[0], "|Parking opens at 6am|") {
+			t.Errorf("expected the custom message, got %q", emailSender.emails[0])
+		}
+	})
+
+	t.Run("includes the access link of online events", func(t *testing.T) {
+		service, repo, emailSender := setupEventReminderService()
+		event, _ := service.eventRepo.GetByID(3)
+		event.Location = models.OnlineEventLocation
+
+		accessRepo := newMockEventAccessRepository()
+		accessRepo.links[3] = &models.EventAccessLink{EventID: 3, URL: "https://meet.example.com/fun-run", RevealMinutesBefore: 60}
+		access := NewEventAccessService(accessRepo)
+		access.now = service.now
+		service.SetAccessService(access)
+		repo.recipients[1] = nil
+		repo.recipients[2] = nil
+
+		if _, err := service.SendDueReminders(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(emailSender.accesses) != 1 || emailSender.accesses[0] == nil {
+			t.Fatalf("expected the reminder to include the event access, got %v", emailSender.accesses)
+		}
+		if accessSent := emailSender.accesses[0]; accessSent.URL != "" || accessSent.RevealAt.IsZero() {
+			t.Errorf("expected the link to stay hidden until an hour before the start, got %+v", accessSent)
+		}
+
+		// By the 2 hour reminder of a link revealed a day ahead, it's in the email
+		accessRepo.links[3].RevealMinutesBefore = 24 * 60
+		repo.sent = map[string]bool{}
+		emailSender.accesses = nil
+		if _, err := service.SendDueReminders(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(emailSender.accesses) != 1 || emailSender.accesses[0].URL != "https://meet.example.com/fun-run" {
+			t.Errorf("expected the reminder to include the link, got %v", emailSender.accesses)
+		}
+	})
+
+	t.Run("reminds each attendee in their language", func(t *testing.T) {
+		service, repo, emailSender := setupEventReminderService()
+		repo.recipients[3] = []*models.ReminderRecipient{
+			{OrderID: 30, Email: "amina@example.com", Name: "Amina", TicketCount: 1, Locale: "sw"},
+			{OrderID: 31, Email: "anna@example.com", Name: "Anna", TicketCount: 1, Locale: "de"},
+		}
+
+		if _, err := service.SendDueReminders(); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		sentLocales := map[string]string{}
+		for i, email := range emailSender.emails {
+			sentLocales[email] = emailSender.locales[i]
+		}
+
+		expected := map[string]string{
+			"amina@example.com|Kikumbusho: Fun Run inaanza baada ya saa 2||https://example.com/dashboard/orders/30": "sw",
+			"anna@example.com|Reminder: Fun Run starts in 2 hours||https://example.com/dashboard/orders/31":         "en",
+		}
+		for email, locale := range expected {
+			if sentLocales[email] != locale {
+				t.Errorf("expected %q to be sent in %q, got %q (sent %v)", email, locale, sentLocales[email], emailSender.emails)
+			}
+		}
+	})
+}
+
+func TestEventReminderService_UpdateSettings(t *testing.T) {
+	service, repo, _ := setupEventReminderService()
+
+	settings := models.DefaultEventReminderSettings(1)
+	settings.Message = "  Doors open at 7pm  "
+	if err := service.UpdateSettings(settings); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.settings[1].Message != "Doors open at 7pm" {
+		t.Errorf("expected message to be trimmed, got %q", repo.settings[1].Message)
+	}
+
+	settings = models.DefaultEventReminderSettings(2)
+	settings.Message = strings.Repeat("a", models.MaxReminderMessageLength+1)
+	if err := service.UpdateSettings(settings); err == nil {
+		t.Error("expected an error for a message that is too long")
+	}
+	if _, exists := repo.settings[2]; exists {
+		t.Error("expected invalid settings not to be saved")
+	}
+}
@@ -9,6 +9,7 @@ import (
 	"testing"
 	"time"
 
+	"event-ticketing-platform/internal/cache"
 	"event-ticketing-platform/internal/models"
 	"event-ticketing-platform/internal/repositories"
 )
@@ -24,6 +25,7 @@ type mockEventRepository struct {
 	searchError     error
 	searchResults   []*models.Event
 	searchTotal     int
+	lastSearch      repositories.EventSearchFilters
 }
 
 func newMockEventRepository() *mockEventRepository {
@@ -129,6 +131,7 @@ func (m *mockEventRepository) GetByOrganizer(organizerID int) ([]*models.Event,
 }
 
 func (m *mockEventRepository) Search(filters repositories.EventSearchFilters) ([]*models.Event, int, error) {
+	m.lastSearch = filters
 	if m.searchError != nil {
 		return nil, 0, m.searchError
 	}
@@ -146,6 +149,29 @@ func (m *mockEventRepository) GetUpcomingEvents(limit int) ([]*models.Event, err
 	return m.searchResults, nil
 }
 
+func (m *mockEventRepository) GetBySlug(slug string) (*models.Event, error) {
+	if m.getError != nil {
+		return nil, m.getError
+	}
+
+	for _, event := range m.events {
+		if event.Slug == slug {
+			return event, nil
+		}
+	}
+	return nil, errors.New("event not found")
+}
+
+func (m *mockEventRepository) GetSitemapEvents() ([]*models.Event, error) {
+	var events []*models.Event
+	for _, event := range m.events {
+		if event.Status == models.StatusPublished {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
 func (m *mockEventRepository) GetEventsByCategory(categoryID int, limit, offset int) ([]*models.Event, int, error) {
 	return m.searchResults, m.searchTotal, m.searchError
 }
@@ -211,7 +237,15 @@ func (m *mockUserRepository) Update(id int, req *models.UserUpdateRequest) (*mod
 func (m *mockUserRepository) UpdatePassword(id int, passwordHash string) error { return nil }
 func (m *mockUserRepository) Delete(id int) error { return nil }
 func (m *mockUserRepository) Search(filters repositories.UserSearchFilters) ([]*models.User, int, error) { return nil, 0, nil }
-func (m *mockUserRepository) GetByRole(role models.UserRole) ([]*models.User, error) { return nil, nil }
+func (m *mockUserRepository) GetByRole(role models.UserRole) ([]*models.User, error) {
+	var users []*models.User
+	for _, user := range m.users {
+		if user.Role == role {
+			users = append(users, user)
+		}
+	}
+	return users, nil
+}
 func (m *mockUserRepository) CreateSession(userID int, sessionID string, expiresAt time.Time) error { return nil }
 func (m *mockUserRepository) GetUserBySession(sessionID string) (*models.User, error) { return nil, nil }
 func (m *mockUserRepository) DeleteSession(sessionID string) error { return nil }
@@ -1009,6 +1043,73 @@ func TestEventService_CanUserEditEvent(t *testing.T) {
 	}
 }
 
+func TestEventService_CanUserManageEvent(t *testing.T) {
+	service, eventRepo, userRepo := setupEventService()
+	defer os.RemoveAll(service.uploadPath)
+
+	organizer := createTestUser(userRepo, 1, models.RoleOrganizer)
+	otherOrganizer := createTestUser(userRepo, 2, models.RoleOrganizer)
+
+	// An event that is under way can no longer be edited but is still run
+	started := createTestEvent(eventRepo, 1, organizer.ID)
+	started.StartDate = time.Now().Add(-time.Hour)
+	started.EndDate = time.Now().Add(2 * time.Hour)
+
+	if canEdit, _ := service.CanUserEditEvent(started.ID, organizer.ID); canEdit {
+		t.Error("expected an event that has started not to be editable")
+	}
+	if canManage, err := service.CanUserManageEvent(started.ID, organizer.ID); err != nil || !canManage {
+		t.Errorf("CanUserManageEvent() = %v, %v, want the organizer to manage their started event", canManage, err)
+	}
+	if canManage, _ := service.CanUserManageEvent(started.ID, otherOrganizer.ID); canManage {
+		t.Error("expected other organizers not to manage the event")
+	}
+}
+
+func TestEventService_TeamAccess(t *testing.T) {
+	service, eventRepo, userRepo := setupEventService()
+	defer os.RemoveAll(service.uploadPath)
+
+	organizer := createTestUser(userRepo, 1, models.RoleOrganizer)
+	manager := createTestUser(userRepo, 2, models.RoleOrganizer)
+	doorStaff := createTestUser(userRepo, 3, models.RoleOrganizer)
+	event := createTestEvent(eventRepo, 1, organizer.ID)
+	ownEvent := createTestEvent(eventRepo, 2, manager.ID)
+	ownEvent.CreatedAt = event.CreatedAt.Add(time.Hour)
+
+	acceptedAt := time.Now()
+	service.SetTeamAccess(NewTeamService(&mockOrganizerMemberRepository{members: []*models.OrganizerMember{
+		{ID: 1, OrganizerID: organizer.ID, UserID: &manager.ID, CanManageEvents: true, AcceptedAt: &acceptedAt},
+		{ID: 2, OrganizerID: organizer.ID, UserID: &doorStaff.ID, CanCheckIn: true, AcceptedAt: &acceptedAt},
+	}}, nil, nil, ""))
+
+	if canEdit, err := service.CanUserEditEvent(event.ID, manager.ID); err != nil || !canEdit {
+		t.Errorf("expected event managers to edit the organizer's events, got %v, %v", canEdit, err)
+	}
+	if canEdit, _ := service.CanUserEditEvent(event.ID, doorStaff.ID); canEdit {
+		t.Error("expected check-in staff not to edit events")
+	}
+	if _, err := service.GetEventStatistics(event.ID, manager.ID); err == nil {
+		t.Error("expected members without analytics access not to view statistics")
+	}
+
+	events, err := service.GetEventsByOrganizer(manager.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 2 || events[0].ID != ownEvent.ID || events[1].ID != event.ID {
+		t.Errorf("expected own and team events newest first, got %v", events)
+	}
+
+	duplicate, err := service.DuplicateEvent(event.ID, manager.ID, "Copy", event.StartDate, event.EndDate)
+	if err != nil {
+		t.Fatalf("expected event managers to duplicate events, got %v", err)
+	}
+	if duplicate.OrganizerID != organizer.ID {
+		t.Errorf("expected duplicate to belong to the organizer, got %d", duplicate.OrganizerID)
+	}
+}
+
 func TestEventService_GetEventStatistics(t *testing.T) {
 	service, eventRepo, userRepo := setupEventService()
 	defer os.RemoveAll(service.uploadPath)
@@ -1355,3 +1456,59 @@ func TestEventService_StatusTransitionValidation(t *testing.T) {
 	}
 }
 
+
+func TestEventService_CachedReads(t *testing.T) {
+	service, eventRepo, userRepo := setupEventService()
+	defer os.RemoveAll(service.uploadPath)
+	service.SetCache(cache.NewMemoryCache())
+
+	organizer := createTestUser(userRepo, 1, models.RoleOrganizer)
+	eventRepo.searchResults = []*models.Event{{ID: 1, Title: "Jazz Night"}}
+
+	events, err := service.GetFeaturedEvents(6)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(events) != 1 {
+		t.Fatalf("expected 1 featured event, got %d", len(events))
+	}
+
+	// Repository changes are not visible until the cache is invalidated
+	eventRepo.searchResults = append(eventRepo.searchResults, &models.Event{ID: 2, Title: "Food Fair"})
+	events, _ = service.GetFeaturedEvents(6)
+	if len(events) != 1 {
+		t.Errorf("expected cached featured events, got %d", len(events))
+	}
+
+	// Creating an event invalidates cached reads
+	_, err = service.CreateEvent(&EventCreateRequest{
+		Title:       "New Event",
+		Description: "Description",
+		StartDate:   time.Now().Add(24 * time.Hour),
+		EndDate:     time.Now().Add(26 * time.Hour),
+		Location:    "Nairobi",
+		CategoryID:  1,
+		OrganizerID: organizer.ID,
+	})
+	if err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+
+	events, _ = service.GetFeaturedEvents(6)
+	if len(events) != 2 {
+		t.Errorf("expected fresh featured events after create, got %d", len(events))
+	}
+	if events[1].Title != "Food Fair" {
+		t.Errorf("expected cached events to round-trip, got %q", events[1].Title)
+	}
+
+	// Repository errors are returned and not cached
+	eventRepo.searchError = errors.New("database down")
+	if _, err := service.GetUpcomingEvents(8); err == nil {
+		t.Error("expected repository error to be returned")
+	}
+	eventRepo.searchError = nil
+	if _, err := service.GetUpcomingEvents(8); err != nil {
+		t.Errorf("expected error not to be cached, got %v", err)
+	}
+}
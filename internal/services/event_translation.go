@@ -0,0 +1,170 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"event-ticketing-platform/internal/i18n"
+	"event-ticketing-platform/internal/models"
+)
+
+// EventTranslationRepository defines the data operations for event translations
+type EventTranslationRepository interface {
+	GetByEvent(eventID int) ([]*models.EventTranslation, error)
+	GetForEvents(eventIDs []int, locale string) (map[int]*models.EventTranslation, error)
+	Upsert(req *models.EventTranslationRequest) (*models.EventTranslation, error)
+	Delete(eventID int, locale string) error
+}
+
+// EventLocaleReader returns the language an event's own content is written in
+type EventLocaleReader interface {
+	GetEventLocale(eventID int) (string, error)
+}
+
+// EventTranslationService manages organizers' translations of their events
+// and shows visitors events in their language where one is available
+type EventTranslationService struct {
+	repo    EventTranslationRepository
+	locales EventLocaleReader
+}
+
+// NewEventTranslationService creates a new event translation service
+func NewEventTranslationService(repo EventTranslationRepository, locales EventLocaleReader) *EventTranslationService {
+	return &EventTranslationService{
+		repo:    repo,
+		locales: locales,
+	}
+}
+
+// GetTranslations returns all of an event's translations
+func (s *EventTranslationService) GetTranslations(eventID int) ([]*models.EventTranslation, error) {
+	translations, err := s.repo.GetByEvent(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get event translations: %w", err)
+	}
+	return translations, nil
+}
+
+// GetDefaultLocale returns the language an event's own title and description
+// are written in
+func (s *EventTranslationService) GetDefaultLocale(eventID int) (string, error) {
+	return s.locales.GetEventLocale(eventID)
+}
+
+// GetLanguages returns the languages an event can be shown in, starting
+// with the one its own content is written in
+func (s *EventTranslationService) GetLanguages(eventID int) ([]string, error) {
+	defaultLocale, err := s.GetDefaultLocale(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	translations, err := s.GetTranslations(eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	translated := make(map[string]bool, len(translations))
+	for _, translation := range translations {
+		translated[translation.Locale] = true
+	}
+
+	languages := []string{defaultLocale}
+	for _, locale := range i18n.SupportedLocales {
+		if locale != defaultLocale && translated[locale] {
+			languages = append(languages, locale)
+		}
+	}
+	return languages, nil
+}
+
+// SaveTranslation creates or replaces an event's translation into another
+// supported language
+func (s *EventTranslationService) SaveTranslation(req *models.EventTranslationRequest) (*models.EventTranslation, error) {
+	locale, err := normalizeLocale(req.Locale, false)
+	if err != nil {
+		return nil, err
+	}
+	req.Locale = locale
+	req.Title = strings.TrimSpace(req.Title)
+	req.Description = strings.TrimSpace(req.Description)
+
+	defaultLocale, err := s.GetDefaultLocale(req.EventID)
+	if err != nil {
+		return nil, err
+	}
+	if locale == defaultLocale {
+		return nil, fmt.Errorf("the event is already written in %s; edit the event to change its title and description", i18n.Name(locale))
+	}
+
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+
+	translation, err := s.repo.Upsert(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save event translation: %w", err)
+	}
+	return translation, nil
+}
+
+// DeleteTranslation removes an event's translation into locale
+func (s *EventTranslationService) DeleteTranslation(eventID int, locale string) error {
+	locale, err := normalizeLocale(locale, false)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(eventID, locale); err != nil {
+		return fmt.Errorf("failed to delete event translation: %w", err)
+	}
+	return nil
+}
+
+// Localize returns the event with its title and description translated into
+// locale, or the event as is if it has no such translation
+func (s *EventTranslationService) Localize(event *models.Event, locale string) *models.Event {
+	if event == nil {
+		return nil
+	}
+	return s.LocalizeEvents([]*models.Event{event}, locale)[0]
+}
+
+// LocalizeEvents returns the events with their titles and descriptions
+// translated into locale where a translation exists. Untranslated events are
+// shown in their default language, and the events passed in are not modified.
+func (s *EventTranslationService) LocalizeEvents(events []*models.Event, locale string) []*models.Event {
+	locale = i18n.Normalize(locale)
+	if locale == "" || len(events) == 0 {
+		return events
+	}
+
+	eventIDs := make([]int, 0, len(events))
+	for _, event := range events {
+		eventIDs = append(eventIDs, event.ID)
+	}
+
+	translations, err := s.repo.GetForEvents(eventIDs, locale)
+	if err != nil {
+		fmt.Printf("Warning: failed to load event translations: %v\n", err)
+		return events
+	}
+	if len(translations) == 0 {
+		return events
+	}
+
+	localized := make([]*models.Event, len(events))
+	for i, event := range events {
+		translation, ok := translations[event.ID]
+		if !ok {
+			localized[i] = event
+			continue
+		}
+		translated := *event
+		translated.Title = translation.Title
+		if translation.Description != "" {
+			translated.Description = translation.Description
+		}
+		localized[i] = &translated
+	}
+	return localized
+}
@@ -0,0 +1,135 @@
+package services
+
+import (
+	"fmt"
+	"testing"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// mockEventTranslationRepository keeps translations in memory, keyed by
+// event ID and locale
+type mockEventTranslationRepository struct {
+	translations map[int]map[string]*models.EventTranslation
+	err          error
+}
+
+func newMockEventTranslationRepository() *mockEventTranslationRepository {
+	return &mockEventTranslationRepository{translations: make(map[int]map[string]*models.EventTranslation)}
+}
+
+func (m *mockEventTranslationRepository) GetByEvent(eventID int) ([]*models.EventTranslation, error) {
+	var result []*models.EventTranslation
+	for _, translation := range m.translations[eventID] {
+		result = append(result, translation)
+	}
+	return result, nil
+}
+
+func (m *mockEventTranslationRepository) GetForEvents(eventIDs []int, locale string) (map[int]*models.EventTranslation, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	result := make(map[int]*models.EventTranslation)
+	for _, eventID := range eventIDs {
+		if translation, ok := m.translations[eventID][locale]; ok {
+			result[eventID] = translation
+		}
+	}
+	return result, nil
+}
+
+func (m *mockEventTranslationRepository) Upsert(req *models.EventTranslationRequest) (*models.EventTranslation, error) {
+	if m.translations[req.EventID] == nil {
+		m.translations[req.EventID] = make(map[string]*models.EventTranslation)
+	}
+	translation := &models.EventTranslation{EventID: req.EventID, Locale: req.Locale, Title: req.Title, Description: req.Description}
+	m.translations[req.EventID][req.Locale] = translation
+	return translation, nil
+}
+
+func (m *mockEventTranslationRepository) Delete(eventID int, locale string) error {
+	if _, ok := m.translations[eventID][locale]; !ok {
+		return fmt.Errorf("translation of event %d into %s not found", eventID, locale)
+	}
+	delete(m.translations[eventID], locale)
+	return nil
+}
+
+func setupEventTranslationService() (*EventTranslationService, *mockEventTranslationRepository) {
+	locales := newMockLocaleRepository()
+	locales.events[1] = "en"
+	locales.events[2] = "sw"
+	repo := newMockEventTranslationRepository()
+	return NewEventTranslationService(repo, NewLocaleService(locales)), repo
+}
+
+func TestEventTranslationService_SaveTranslation(t *testing.T) {
+	service, repo := setupEventTranslationService()
+
+	translation, err := service.SaveTranslation(&models.EventTranslationRequest{EventID: 1, Locale: "sw-KE", Title: " Usiku wa Jazz ", Description: "Muziki mzuri"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if translation.Locale != "sw" || translation.Title != "Usiku wa Jazz" {
+		t.Errorf("unexpected translation: %+v", translation)
+	}
+
+	if _, err := service.SaveTranslation(&models.EventTranslationRequest{EventID: 1, Locale: "en", Title: "Jazz Night"}); err == nil {
+		t.Error("expected a translation into the event's own language to be rejected")
+	}
+	if _, err := service.SaveTranslation(&models.EventTranslationRequest{EventID: 1, Locale: "de", Title: "Jazzabend"}); err == nil {
+		t.Error("expected an unsupported language to be rejected")
+	}
+	if _, err := service.SaveTranslation(&models.EventTranslationRequest{EventID: 1, Locale: "fr", Title: "  "}); err == nil {
+		t.Error("expected a title to be required")
+	}
+
+	languages, err := service.GetLanguages(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(languages) != 2 || languages[0] != "en" || languages[1] != "sw" {
+		t.Errorf("expected the default language followed by Swahili, got %v", languages)
+	}
+
+	if err := service.DeleteTranslation(1, "sw"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.translations[1]) != 0 {
+		t.Error("expected translation to be deleted")
+	}
+}
+
+func TestEventTranslationService_LocalizeEvents(t *testing.T) {
+	service, repo := setupEventTranslationService()
+	repo.Upsert(&models.EventTranslationRequest{EventID: 1, Locale: "fr", Title: "Soirée Jazz", Description: "Bonne musique"})
+	repo.Upsert(&models.EventTranslationRequest{EventID: 2, Locale: "fr", Title: "Marathon de Nairobi"})
+
+	jazz := &models.Event{ID: 1, Title: "Jazz Night", Description: "Great music"}
+	marathon := &models.Event{ID: 2, Title: "Mbio za Nairobi", Description: "Mbio za kilomita 42"}
+	market := &models.Event{ID: 3, Title: "Craft Market", Description: "Local makers"}
+
+	localized := service.LocalizeEvents([]*models.Event{jazz, marathon, market}, "fr-FR")
+	if localized[0].Title != "Soirée Jazz" || localized[0].Description != "Bonne musique" {
+		t.Errorf("expected translated event, got %q: %q", localized[0].Title, localized[0].Description)
+	}
+	if localized[1].Title != "Marathon de Nairobi" || localized[1].Description != "Mbio za kilomita 42" {
+		t.Errorf("expected a missing description to fall back to the default language, got %q: %q", localized[1].Title, localized[1].Description)
+	}
+	if localized[2] != market {
+		t.Error("expected untranslated event to be shown as is")
+	}
+	if jazz.Title != "Jazz Night" {
+		t.Error("expected the original event not to be modified")
+	}
+
+	if got := service.Localize(jazz, "de"); got != jazz {
+		t.Error("expected unsupported language to show the event as is")
+	}
+
+	repo.err = fmt.Errorf("connection refused")
+	if got := service.Localize(jazz, "fr"); got != jazz {
+		t.Error("expected the default language when translations cannot be loaded")
+	}
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// EventViewRepository defines the data operations for event page views
+type EventViewRepository interface {
+	Record(view *models.EventView, dedupe time.Duration) (bool, error)
+	CountVisitors(eventID int, since time.Time) (int, error)
+	GetDaily(eventID int, since time.Time) ([]*models.EventViewDay, error)
+	Rollup(before time.Time) (int, error)
+}
+
+// botUserAgentMarkers identify crawlers and link previewers, whose views
+// are not counted
+var botUserAgentMarkers = []string{
+	"bot", "crawler", "spider", "slurp", "preview", "facebookexternalhit",
+	"headless", "curl", "wget", "python-requests", "go-http-client",
+}
+
+// EventViewService tracks views of event detail pages without relying on
+// external analytics. Views are deduplicated per visitor and rolled up into
+// daily totals once their day is over.
+type EventViewService struct {
+	repo EventViewRepository
+	now  func() time.Time
+}
+
+// NewEventViewService creates a new event view service
+func NewEventViewService(repo EventViewRepository) *EventViewService {
+	return &EventViewService{repo: repo, now: time.Now}
+}
+
+// RecordView records a visitor viewing an event's page and returns whether
+// it counted. Views by bots and repeat views within
+// models.EventViewDedupeWindow do not count.
+func (s *EventViewService) RecordView(view *models.EventView, userAgent, referrer string) (bool, error) {
+	if isBotUserAgent(userAgent) {
+		return false, nil
+	}
+
+	view.ReferrerHost = referrerHost(referrer)
+	if err := view.Validate(); err != nil {
+		return false, err
+	}
+
+	return s.repo.Record(view, models.EventViewDedupeWindow)
+}
+
+// CountEventViews counts the visitors who viewed an event's page since a
+// time. It implements EventViewCounter.
+func (s *EventViewService) CountEventViews(eventID int, since time.Time) (int, error) {
+	return s.repo.CountVisitors(eventID, since)
+}
+
+// GetDailyViews returns an event's views for each of the last days that had any
+func (s *EventViewService) GetDailyViews(eventID int, days int) ([]*models.EventViewDay, error) {
+	return s.repo.GetDaily(eventID, startOfDay(s.now()).AddDate(0, 0, -days))
+}
+
+// RollupViews rolls up the views of days before today into daily totals
+func (s *EventViewService) RollupViews() (int, error) {
+	rolledUp, err := s.repo.Rollup(startOfDay(s.now()))
+	if err != nil {
+		return 0, fmt.Errorf("failed to roll up event views: %w", err)
+	}
+	return rolledUp, nil
+}
+
+// isBotUserAgent reports whether a user agent is missing or belongs to a
+// crawler
+func isBotUserAgent(userAgent string) bool {
+	userAgent = strings.ToLower(strings.TrimSpace(userAgent))
+	if userAgent == "" {
+		return true
+	}
+	for _, marker := range botUserAgentMarkers {
+		if strings.Contains(userAgent, marker) {
+			return true
+		}
+	}
+	return false
+}
+
+// referrerHost returns the host of a referrer URL; only the host is stored
+func referrerHost(referrer string) string {
+	u, err := url.Parse(strings.TrimSpace(referrer))
+	if err != nil {
+		return ""
+	}
+	return strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+}
+
+// startOfDay returns midnight at the start of t's day
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
@@ -0,0 +1,71 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+type mockEventViewRepository struct {
+	views    []*models.EventView
+	rolledUp time.Time
+}
+
+func (m *mockEventViewRepository) Record(view *models.EventView, dedupe time.Duration) (bool, error) {
+	m.views = append(m.views, view)
+	return true, nil
+}
+
+func (m *mockEventViewRepository) CountVisitors(eventID int, since time.Time) (int, error) {
+	return len(m.views), nil
+}
+
+func (m *mockEventViewRepository) GetDaily(eventID int, since time.Time) ([]*models.EventViewDay, error) {
+	return nil, nil
+}
+
+func (m *mockEventViewRepository) Rollup(before time.Time) (int, error) {
+	m.rolledUp = before
+	return len(m.views), nil
+}
+
+func TestEventViewService_RecordView(t *testing.T) {
+	repo := &mockEventViewRepository{}
+	service := NewEventViewService(repo)
+	browser := "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 Chrome/126.0 Safari/537.36"
+
+	counted, err := service.RecordView(&models.EventView{EventID: 1, VisitorID: "v1"}, browser, "https://www.Google.com/search?q=jazz")
+	if err != nil || !counted {
+		t.Fatalf("expected the view to count, got %v, %v", counted, err)
+	}
+	if repo.views[0].ReferrerHost != "google.com" {
+		t.Errorf("expected only the referrer's host to be kept, got %q", repo.views[0].ReferrerHost)
+	}
+
+	for _, userAgent := range []string{"", "Googlebot/2.1 (+http://www.google.com/bot.html)", "facebookexternalhit/1.1", "curl/8.4.0"} {
+		if counted, _ := service.RecordView(&models.EventView{EventID: 1, VisitorID: "v2"}, userAgent, ""); counted {
+			t.Errorf("expected %q not to count", userAgent)
+		}
+	}
+
+	if _, err := service.RecordView(&models.EventView{EventID: 1}, browser, ""); err == nil {
+		t.Error("expected an error for a view without a visitor")
+	}
+	if len(repo.views) != 1 {
+		t.Errorf("expected only the browser view to be recorded, got %d", len(repo.views))
+	}
+}
+
+func TestEventViewService_RollupViews(t *testing.T) {
+	repo := &mockEventViewRepository{}
+	service := NewEventViewService(repo)
+	service.now = func() time.Time { return time.Date(2026, 6, 10, 15, 30, 0, 0, time.UTC) }
+
+	if _, err := service.RollupViews(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if want := time.Date(2026, 6, 10, 0, 0, 0, 0, time.UTC); !repo.rolledUp.Equal(want) {
+		t.Errorf("expected views before today to be rolled up, got %v", repo.rolledUp)
+	}
+}
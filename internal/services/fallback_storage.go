@@ -113,6 +113,80 @@ func (f *FallbackStorageService) Exists(ctx context.Context, key string) (bool,
 	return true, nil
 }
 
+// List returns every stored file whose key starts with prefix
+func (f *FallbackStorageService) List(ctx context.Context, prefix string) ([]StoredObject, error) {
+	prefix = strings.TrimPrefix(prefix, "/")
+
+	var objects []StoredObject
+	err := filepath.WalkDir(f.basePath, func(fullPath string, entry os.DirEntry, err error) error {
+		if err != nil {
+			if os.IsNotExist(err) {
+				return nil
+			}
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+
+		relPath, err := filepath.Rel(f.basePath, fullPath)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(relPath)
+		if !strings.HasPrefix(key, prefix) {
+			return nil
+		}
+
+		info, err := entry.Info()
+		if err != nil {
+			return err
+		}
+		objects = append(objects, StoredObject{
+			Key:          key,
+			Size:         info.Size(),
+			LastModified: info.ModTime(),
+		})
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list files: %w", err)
+	}
+
+	return objects, nil
+}
+
+// Open opens a stored file for reading
+func (f *FallbackStorageService) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	file, err := os.Open(filepath.Join(f.basePath, strings.TrimPrefix(key, "/")))
+	if err != nil {
+		return nil, fmt.Errorf("failed to open file: %w", err)
+	}
+	return file, nil
+}
+
+// Move renames a stored file to a new key
+func (f *FallbackStorageService) Move(ctx context.Context, fromKey, toKey string) error {
+	fromPath := filepath.Join(f.basePath, strings.TrimPrefix(fromKey, "/"))
+	toPath := filepath.Join(f.basePath, strings.TrimPrefix(toKey, "/"))
+
+	if err := os.MkdirAll(filepath.Dir(toPath), 0755); err != nil {
+		return fmt.Errorf("failed to create directory for %s: %w", toPath, err)
+	}
+	if err := os.Rename(fromPath, toPath); err != nil {
+		return fmt.Errorf("failed to move file %s: %w", fromPath, err)
+	}
+
+	// Keep the move's time as the modification time so quarantine ages from now
+	now := time.Now()
+	if err := os.Chtimes(toPath, now, now); err != nil {
+		fmt.Printf("Warning: Failed to update modification time of %s: %v\n", toPath, err)
+	}
+
+	f.cleanupEmptyDirs(filepath.Dir(fromPath))
+	return nil
+}
+
 // cleanupEmptyDirs removes empty directories up to the base path
 func (f *FallbackStorageService) cleanupEmptyDirs(dir string) {
 	// Don't remove the base path itself
@@ -0,0 +1,66 @@
+package services
+
+import (
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// FavoriteRepository defines the data operations for attendees' saved events
+type FavoriteRepository interface {
+	Add(userID, eventID int) error
+	Remove(userID, eventID int) error
+	IsFavorite(userID, eventID int) (bool, error)
+	GetWatchers(eventID int) ([]*models.EventWatcher, error)
+	GetSavedEvents(userID int) ([]*models.Event, error)
+}
+
+// FavoriteService lets attendees save events they are interested in
+type FavoriteService struct {
+	repo FavoriteRepository
+}
+
+// NewFavoriteService creates a new favorite service
+func NewFavoriteService(repo FavoriteRepository) *FavoriteService {
+	return &FavoriteService{repo: repo}
+}
+
+// IsFavorite returns true if the user has saved the event
+func (s *FavoriteService) IsFavorite(userID, eventID int) (bool, error) {
+	favorite, err := s.repo.IsFavorite(userID, eventID)
+	if err != nil {
+		return false, fmt.Errorf("failed to check saved event: %w", err)
+	}
+	return favorite, nil
+}
+
+// ToggleFavorite saves the event for the user, or unsaves it if it was
+// saved, and returns whether it is now saved
+func (s *FavoriteService) ToggleFavorite(userID, eventID int) (bool, error) {
+	favorite, err := s.IsFavorite(userID, eventID)
+	if err != nil {
+		return false, err
+	}
+
+	if favorite {
+		if err := s.repo.Remove(userID, eventID); err != nil {
+			return true, err
+		}
+		return false, nil
+	}
+
+	if err := s.repo.Add(userID, eventID); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// GetSavedEvents returns the upcoming events the user saved
+func (s *FavoriteService) GetSavedEvents(userID int) ([]*models.Event, error) {
+	return s.repo.GetSavedEvents(userID)
+}
+
+// GetWatchers returns the users who saved the event
+func (s *FavoriteService) GetWatchers(eventID int) ([]*models.EventWatcher, error) {
+	return s.repo.GetWatchers(eventID)
+}
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// statementMonths is how many past months of statements the finance page offers
+const statementMonths = 12
+
+// FinanceRepository defines the data operations for settlement statements
+type FinanceRepository interface {
+	GetSettlementLines(organizerID, eventID int, from, to time.Time) ([]*models.SettlementLine, error)
+	GetPaidOutWithdrawals(organizerID int, from, to time.Time) ([]*models.Withdrawal, error)
+}
+
+// FinanceService builds organizers' settlement statements
+type FinanceService struct {
+	repo     FinanceRepository
+	balances OrganizerBalanceReader
+	pdf      *PDFService
+	now      func() time.Time
+}
+
+// NewFinanceService creates a new finance service
+func NewFinanceService(repo FinanceRepository, balances OrganizerBalanceReader, pdf *PDFService) *FinanceService {
+	return &FinanceService{
+		repo:     repo,
+		balances: balances,
+		pdf:      pdf,
+		now:      time.Now,
+	}
+}
+
+// ParseStatementMonth parses a month in YYYY-MM form, defaulting to the
+// current month when empty. Months that have not started are rejected.
+func (s *FinanceService) ParseStatementMonth(value string) (time.Time, error) {
+	now := s.now()
+	if value == "" {
+		return models.StatementMonth(now), nil
+	}
+
+	month, err := time.ParseInLocation(models.StatementMonthLayout, value, now.Location())
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid month: %q", value)
+	}
+	if month.After(now) {
+		return time.Time{}, fmt.Errorf("no statement for %s yet", month.Format("January 2006"))
+	}
+	return month, nil
+}
+
+// StatementMonths returns the months statements can be picked for, latest first
+func (s *FinanceService) StatementMonths() []time.Time {
+	current := models.StatementMonth(s.now())
+	months := make([]time.Time, statementMonths)
+	for i := range months {
+		months[i] = current.AddDate(0, -i, 0)
+	}
+	return months
+}
+
+// MonthlyStatement builds the organizer's statement for the month starting
+// at month, across all of their events
+func (s *FinanceService) MonthlyStatement(organizerID int, month time.Time) (*models.SettlementStatement, error) {
+	start := models.StatementMonth(month)
+	end := start.AddDate(0, 1, 0)
+
+	statement, err := s.newStatement(organizerID, 0, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	statement.Withdrawals, err = s.repo.GetPaidOutWithdrawals(organizerID, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return statement, nil
+}
+
+// EventStatement builds the statement of an event's sales from when it was
+// created until today
+func (s *FinanceService) EventStatement(event *models.Event) (*models.SettlementStatement, error) {
+	statement, err := s.newStatement(event.OrganizerID, event.ID, startOfDay(event.CreatedAt), startOfDay(s.now()).AddDate(0, 0, 1))
+	if err != nil {
+		return nil, err
+	}
+	statement.Event = event
+	return statement, nil
+}
+
+// newStatement loads a statement's lines and the organizer's balance
+func (s *FinanceService) newStatement(organizerID, eventID int, from, to time.Time) (*models.SettlementStatement, error) {
+	lines, err := s.repo.GetSettlementLines(organizerID, eventID, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	balance, err := s.balances.GetOrganizerBalance(organizerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organizer balance: %w", err)
+	}
+
+	return &models.SettlementStatement{
+		OrganizerID:      organizerID,
+		PeriodStart:      from,
+		PeriodEnd:        to,
+		GeneratedAt:      s.now(),
+		Lines:            lines,
+		AvailableBalance: balance,
+	}, nil
+}
+
+// StatementPDF renders a statement as a PDF
+func (s *FinanceService) StatementPDF(statement *models.SettlementStatement) ([]byte, error) {
+	return s.pdf.GenerateStatementPDF(statement)
+}
+
+// StatementFilename returns the name a statement is downloaded as, without
+// its extension
+func StatementFilename(statement *models.SettlementStatement) string {
+	if statement.Event != nil {
+		return fmt.Sprintf("statement_event_%d_%s", statement.Event.ID, statement.LastDay().Format(reportDateLayout))
+	}
+	return "statement_" + statement.PeriodStart.Format(models.StatementMonthLayout)
+}
+
+// ExportStatement writes a statement as CSV: its period, one row per event
+// and the totals, then for monthly statements the withdrawals paid out and
+// how they reconcile with the net payout
+func ExportStatement(w io.Writer, statement *models.SettlementStatement) error {
+	writer := csv.NewWriter(w)
+
+	cents := func(amount int64) string { return strconv.FormatFloat(float64(amount)/100, 'f', 2, 64) }
+	money := func(amount float64) string { return strconv.FormatFloat(amount, 'f', 2, 64) }
+
+	rows := [][]string{
+		{"Statement", statement.Title()},
+		{"From", statement.PeriodStart.Format(reportDateLayout)},
+		{"To", statement.LastDay().Format(reportDateLayout)},
+		{"Generated", statement.GeneratedAt.Format(time.RFC3339)},
+		{},
+		{"Event ID", "Event", "Event Date", "Orders", "Gross Sales", "Refunds", "Refund Amount", "Chargebacks", "Chargeback Amount", "Platform Fees", "Net Payout", "Tax", "Tax Refunded"},
+	}
+	for _, line := range statement.Lines {
+		rows = append(rows, []string{
+			strconv.Itoa(line.EventID),
+			line.EventTitle,
+			line.EventStartDate.Format(reportDateLayout),
+			strconv.Itoa(line.Orders),
+			cents(line.GrossCents),
+			strconv.Itoa(line.Refunds),
+			cents(line.RefundCents),
+			strconv.Itoa(line.Chargebacks),
+			cents(line.ChargebackCents),
+			cents(line.FeeCents()),
+			cents(line.NetCents()),
+			cents(line.TaxCents),
+			cents(line.RefundTaxCents),
+		})
+	}
+	total := statement.Total()
+	rows = append(rows, []string{
+		"", "Total", "",
+		strconv.Itoa(total.Orders),
+		cents(total.GrossCents),
+		strconv.Itoa(total.Refunds),
+		cents(total.RefundCents),
+		strconv.Itoa(total.Chargebacks),
+		cents(total.ChargebackCents),
+		cents(statement.FeeCents()),
+		cents(statement.NetCents()),
+		cents(total.TaxCents),
+		cents(total.RefundTaxCents),
+	})
+	if total.HasTax() {
+		rows = append(rows,
+			[]string{},
+			[]string{"Tax Collected", cents(total.TaxCents)},
+			[]string{"Tax Refunded", cents(total.RefundTaxCents)},
+			[]string{"Net Tax", cents(total.NetTaxCents())},
+		)
+	}
+
+	if statement.IsMonthly() {
+		rows = append(rows, []string{}, []string{"Withdrawal ID", "Status", "Requested", "Processed", "Amount"})
+		for _, withdrawal := range statement.Withdrawals {
+			processed := ""
+			if withdrawal.ProcessedAt != nil {
+				processed = withdrawal.ProcessedAt.Format(reportDateLayout)
+			}
+			rows = append(rows, []string{
+				strconv.Itoa(withdrawal.ID),
+				string(withdrawal.Status),
+				withdrawal.RequestedAt.Format(reportDateLayout),
+				processed,
+				money(withdrawal.Amount),
+			})
+		}
+		rows = append(rows,
+			[]string{},
+			[]string{"Net Payout", cents(statement.NetCents())},
+			[]string{"Withdrawals Paid Out", money(statement.PaidOut())},
+			[]string{"Balance Change", money(statement.BalanceChange())},
+		)
+	}
+	rows = append(rows, []string{"Available Balance", money(statement.AvailableBalance)})
+
+	if err := writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write statement: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,163 @@
+package services
+
+import (
+	"bytes"
+	"encoding/csv"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock FinanceRepository for testing
+type mockFinanceRepository struct {
+	lines       []*models.SettlementLine
+	withdrawals []*models.Withdrawal
+	eventID     int
+	from, to    time.Time
+}
+
+func (m *mockFinanceRepository) GetSettlementLines(organizerID, eventID int, from, to time.Time) ([]*models.SettlementLine, error) {
+	m.eventID, m.from, m.to = eventID, from, to
+	return m.lines, nil
+}
+
+func (m *mockFinanceRepository) GetPaidOutWithdrawals(organizerID int, from, to time.Time) ([]*models.Withdrawal, error) {
+	return m.withdrawals, nil
+}
+
+func newTestFinanceService(repo *mockFinanceRepository, now time.Time) *FinanceService {
+	service := NewFinanceService(repo, &mockOrganizerBalanceReader{balance: 250}, NewPDFService())
+	service.now = func() time.Time { return now }
+	return service
+}
+
+func TestFinanceService_ParseStatementMonth(t *testing.T) {
+	now := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	service := newTestFinanceService(&mockFinanceRepository{}, now)
+
+	month, err := service.ParseStatementMonth("")
+	if err != nil || !month.Equal(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("ParseStatementMonth(\"\") = %v, %v, want October 2026", month, err)
+	}
+	month, err = service.ParseStatementMonth("2026-02")
+	if err != nil || !month.Equal(time.Date(2026, 2, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("ParseStatementMonth(2026-02) = %v, %v, want February 2026", month, err)
+	}
+	for _, value := range []string{"2026-11", "October", "2026-13"} {
+		if _, err := service.ParseStatementMonth(value); err == nil {
+			t.Errorf("ParseStatementMonth(%q) = nil error, want an error", value)
+		}
+	}
+
+	months := service.StatementMonths()
+	if len(months) != statementMonths || !months[0].Equal(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)) ||
+		!months[11].Equal(time.Date(2025, 11, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("StatementMonths() = %v, want October 2026 back to November 2025", months)
+	}
+}
+
+func TestFinanceService_Statements(t *testing.T) {
+	now := time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC)
+	processed := time.Date(2026, 9, 25, 10, 0, 0, 0, time.UTC)
+	repo := &mockFinanceRepository{
+		lines: []*models.SettlementLine{
+			{EventID: 7, EventTitle: "Jazz Night", EventStartDate: now, Orders: 3, GrossCents: 30000, Refunds: 1, RefundCents: 10000, TaxCents: 4138, RefundTaxCents: 1379},
+		},
+		withdrawals: []*models.Withdrawal{{ID: 4, Amount: 100, Status: models.WithdrawalStatusCompleted, RequestedAt: processed, ProcessedAt: &processed}},
+	}
+	service := newTestFinanceService(repo, now)
+
+	statement, err := service.MonthlyStatement(2, time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC))
+	if err != nil {
+		t.Fatalf("MonthlyStatement() error = %v", err)
+	}
+	if repo.eventID != 0 || !repo.from.Equal(time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC)) || !repo.to.Equal(time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("MonthlyStatement() loaded event %d from %v to %v, want all events in September", repo.eventID, repo.from, repo.to)
+	}
+	if statement.AvailableBalance != 250 || len(statement.Withdrawals) != 1 {
+		t.Errorf("statement = %+v, want the balance and the withdrawal", statement)
+	}
+
+	var buffer bytes.Buffer
+	if err := ExportStatement(&buffer, statement); err != nil {
+		t.Fatalf("ExportStatement() error = %v", err)
+	}
+	reader := csv.NewReader(strings.NewReader(buffer.String()))
+	reader.FieldsPerRecord = -1
+	rows, err := reader.ReadAll()
+	if err != nil {
+		t.Fatalf("statement CSV is invalid: %v", err)
+	}
+	want := map[string]string{"Net Payout": "190.00", "Withdrawals Paid Out": "100.00", "Balance Change": "90.00", "Available Balance": "250.00", "Net Tax": "27.59"}
+	for _, row := range rows {
+		if expected, ok := want[row[0]]; ok {
+			if row[1] != expected {
+				t.Errorf("CSV %s = %s, want %s", row[0], row[1], expected)
+			}
+			delete(want, row[0])
+		}
+	}
+	if len(want) != 0 {
+		t.Errorf("CSV is missing %v", want)
+	}
+	if !strings.Contains(buffer.String(), "7,Jazz Night,2026-10-14,3,300.00,1,100.00,0,0.00,10.00,190.00,41.38,13.79") {
+		t.Errorf("CSV is missing the event's line:\n%s", buffer.String())
+	}
+
+	event := &models.Event{ID: 7, OrganizerID: 2, Title: "Jazz Night", CreatedAt: time.Date(2026, 8, 3, 15, 0, 0, 0, time.UTC)}
+	eventStatement, err := service.EventStatement(event)
+	if err != nil {
+		t.Fatalf("EventStatement() error = %v", err)
+	}
+	if repo.eventID != 7 || !repo.from.Equal(time.Date(2026, 8, 3, 0, 0, 0, 0, time.UTC)) || !repo.to.Equal(time.Date(2026, 10, 15, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("EventStatement() loaded event %d from %v to %v, want the event until today", repo.eventID, repo.from, repo.to)
+	}
+	if eventStatement.IsMonthly() || StatementFilename(eventStatement) != "statement_event_7_2026-10-14" {
+		t.Errorf("event statement filename = %q", StatementFilename(eventStatement))
+	}
+}
+
+func TestPDFService_GenerateStatementPDF(t *testing.T) {
+	statement := &models.SettlementStatement{
+		PeriodStart: time.Date(2026, 9, 1, 0, 0, 0, 0, time.UTC),
+		PeriodEnd:   time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC),
+		GeneratedAt: time.Date(2026, 10, 14, 9, 0, 0, 0, time.UTC),
+	}
+	// Enough events to need a second page
+	for i := 1; i <= statementLinesPerPage; i++ {
+		statement.Lines = append(statement.Lines, &models.SettlementLine{EventID: i, EventTitle: fmt.Sprintf("Event (%d)", i), Orders: 1, GrossCents: 1000})
+	}
+
+	pdf, err := NewPDFService().GenerateStatementPDF(statement)
+	if err != nil {
+		t.Fatalf("GenerateStatementPDF() error = %v", err)
+	}
+	content := string(pdf)
+
+	if !strings.HasPrefix(content, "%PDF-1.4") || !strings.HasSuffix(content, "%%EOF\n") {
+		t.Error("statement is not a complete PDF")
+	}
+	if !strings.Contains(content, "/Count 2") {
+		t.Error("statement should span two pages")
+	}
+	if !strings.Contains(content, `Event \(1\)`) || !strings.Contains(content, "RECONCILIATION") {
+		t.Error("statement is missing its events or reconciliation")
+	}
+
+	// Every object the cross-reference table points at starts there
+	xref := strings.Index(content, "\nxref\n") + 1
+	entries := strings.Split(strings.TrimSpace(content[xref:strings.Index(content, "trailer")]), "\n")[3:]
+	for i, entry := range entries {
+		var offset int
+		fmt.Sscanf(entry, "%d", &offset)
+		if !strings.HasPrefix(content[offset:], fmt.Sprintf("%d 0 obj", i+1)) {
+			t.Errorf("xref entry %d points at %q", i+1, content[offset:offset+10])
+		}
+	}
+	if !strings.Contains(content, fmt.Sprintf("startxref\n%d\n", xref)) {
+		t.Error("startxref does not point at the cross-reference table")
+	}
+}
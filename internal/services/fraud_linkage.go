@@ -0,0 +1,266 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/ratelimit"
+)
+
+// DeviceCookie identifies a browser across sign-ins, so accounts used from
+// the same browser can be linked
+const DeviceCookie = "rt_device"
+
+// FraudLinkageRepository defines the data operations for linking accounts
+type FraudLinkageRepository interface {
+	RecordSignal(userID int, kind models.SignalKind, fingerprint, label string) error
+	GetSignals(userID int) ([]*models.AccountSignal, error)
+	GetSharedLinks(maxAccounts int) ([]*models.AccountLink, error)
+	GetLinkedAccounts(userIDs []int) ([]*models.LinkedAccount, error)
+}
+
+// FraudLinkageService links accounts that share payout details, devices or
+// IP addresses, so admins can spot banned organizers signing up again
+type FraudLinkageService struct {
+	repo         FraudLinkageRepository
+	auditService *AuditService
+}
+
+// NewFraudLinkageService creates a new fraud linkage service
+func NewFraudLinkageService(repo FraudLinkageRepository) *FraudLinkageService {
+	return &FraudLinkageService{repo: repo}
+}
+
+// SetAuditService records sign-ins from browsers an account hasn't used
+// before in the audit log
+func (s *FraudLinkageService) SetAuditService(auditService *AuditService) {
+	s.auditService = auditService
+}
+
+// RecordSignIn records the IP address and browser of a sign-in request,
+// giving the browser a device cookie if it has none yet
+func (s *FraudLinkageService) RecordSignIn(w http.ResponseWriter, r *http.Request, userID int) {
+	deviceID := ""
+	if cookie, err := r.Cookie(DeviceCookie); err == nil && len(cookie.Value) == 32 {
+		deviceID = cookie.Value
+	} else {
+		b := make([]byte, 16)
+		if _, err := rand.Read(b); err == nil {
+			deviceID = hex.EncodeToString(b)
+			http.SetCookie(w, &http.Cookie{
+				Name:     DeviceCookie,
+				Value:    deviceID,
+				Path:     "/",
+				MaxAge:   int((2 * 365 * 24 * time.Hour).Seconds()),
+				HttpOnly: true,
+				SameSite: http.SameSiteLaxMode,
+			})
+		}
+	}
+
+	if s.auditService != nil && s.isNewDevice(userID, deviceID) {
+		details := map[string]interface{}{
+			"ip":     ratelimit.ClientIP(r),
+			"device": deviceLabel(r.UserAgent()),
+		}
+		if err := s.auditService.LogAction(userID, models.AuditActionLoginNewDevice, models.AuditTargetUser, userID, details, r); err != nil {
+			fmt.Printf("Warning: failed to log new device sign-in for user %d: %v\n", userID, err)
+		}
+	}
+
+	s.RecordLogin(userID, ratelimit.ClientIP(r), deviceID, r.UserAgent())
+}
+
+// isNewDevice reports whether a user who has signed in before is signing in
+// from a browser they haven't used. First sign-ins aren't new devices.
+func (s *FraudLinkageService) isNewDevice(userID int, deviceID string) bool {
+	fingerprint := models.SignalFingerprint(models.SignalDevice, deviceID)
+	if fingerprint == "" {
+		return false
+	}
+	signals, err := s.repo.GetSignals(userID)
+	if err != nil {
+		return false
+	}
+
+	seenDevices := false
+	for _, signal := range signals {
+		if signal.Kind != models.SignalDevice {
+			continue
+		}
+		if signal.Fingerprint == fingerprint {
+			return false
+		}
+		seenDevices = true
+	}
+	return seenDevices
+}
+
+// RecordLogin records the IP address and device a user signed in from.
+// Failures are only logged, as they must not stop anyone signing in.
+func (s *FraudLinkageService) RecordLogin(userID int, ip, deviceID, userAgent string) {
+	s.record(userID, models.SignalIP, ip, ip)
+	s.record(userID, models.SignalDevice, deviceID, deviceLabel(userAgent))
+}
+
+// RecordPayoutDetails records the bank details an organizer asked to be paid to
+func (s *FraudLinkageService) RecordPayoutDetails(userID int, details string) {
+	s.record(userID, models.SignalPayout, details, models.PayoutLabel(details))
+}
+
+func (s *FraudLinkageService) record(userID int, kind models.SignalKind, value, label string) {
+	fingerprint := models.SignalFingerprint(kind, value)
+	if fingerprint == "" {
+		return
+	}
+	if err := s.repo.RecordSignal(userID, kind, fingerprint, label); err != nil {
+		fmt.Printf("Warning: failed to record %s signal for user %d: %v\n", kind, userID, err)
+	}
+}
+
+// GetSignals returns the signals seen for a user
+func (s *FraudLinkageService) GetSignals(userID int) ([]*models.AccountSignal, error) {
+	return s.repo.GetSignals(userID)
+}
+
+// GetClusters returns the groups of linked accounts that include an
+// organizer, riskiest first
+func (s *FraudLinkageService) GetClusters() ([]*models.AccountCluster, error) {
+	clusters, err := s.buildClusters()
+	if err != nil {
+		return nil, err
+	}
+
+	var organizerClusters []*models.AccountCluster
+	for _, cluster := range clusters {
+		for _, account := range cluster.Accounts {
+			if account.Role == models.RoleOrganizer {
+				organizerClusters = append(organizerClusters, cluster)
+				break
+			}
+		}
+	}
+
+	sort.SliceStable(organizerClusters, func(i, j int) bool {
+		return organizerClusters[i].RiskScore() > organizerClusters[j].RiskScore()
+	})
+	return organizerClusters, nil
+}
+
+// GetCluster returns the accounts linked to a user, or a cluster of just the
+// user if nothing links them to anyone
+func (s *FraudLinkageService) GetCluster(userID int) (*models.AccountCluster, error) {
+	clusters, err := s.buildClusters()
+	if err != nil {
+		return nil, err
+	}
+	for _, cluster := range clusters {
+		if cluster.Account(userID) != nil {
+			return cluster, nil
+		}
+	}
+
+	accounts, err := s.repo.GetLinkedAccounts([]int{userID})
+	if err != nil {
+		return nil, err
+	}
+	if len(accounts) == 0 {
+		return nil, fmt.Errorf("user not found")
+	}
+	return &models.AccountCluster{Accounts: accounts}, nil
+}
+
+// buildClusters groups accounts connected by shared signals
+func (s *FraudLinkageService) buildClusters() ([]*models.AccountCluster, error) {
+	links, err := s.repo.GetSharedLinks(models.MaxAccountsPerSignal)
+	if err != nil {
+		return nil, err
+	}
+
+	parent := make(map[int]int)
+	var find func(id int) int
+	find = func(id int) int {
+		if _, ok := parent[id]; !ok {
+			parent[id] = id
+		}
+		if parent[id] != id {
+			parent[id] = find(parent[id])
+		}
+		return parent[id]
+	}
+	for _, link := range links {
+		a, b := find(link.UserA), find(link.UserB)
+		if a != b {
+			parent[b] = a
+		}
+	}
+
+	var userIDs []int
+	for id := range parent {
+		userIDs = append(userIDs, id)
+	}
+	sort.Ints(userIDs)
+
+	accounts, err := s.repo.GetLinkedAccounts(userIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	byRoot := make(map[int]*models.AccountCluster)
+	var clusters []*models.AccountCluster
+	clusterOf := func(id int) *models.AccountCluster {
+		root := find(id)
+		cluster, ok := byRoot[root]
+		if !ok {
+			cluster = &models.AccountCluster{}
+			byRoot[root] = cluster
+			clusters = append(clusters, cluster)
+		}
+		return cluster
+	}
+	for _, account := range accounts {
+		cluster := clusterOf(account.ID)
+		cluster.Accounts = append(cluster.Accounts, account)
+	}
+	for _, link := range links {
+		cluster := clusterOf(link.UserA)
+		cluster.Links = append(cluster.Links, link)
+	}
+
+	return clusters, nil
+}
+
+// deviceLabel describes a browser from its user agent for admins
+func deviceLabel(userAgent string) string {
+	browser := "Unknown browser"
+	switch {
+	case strings.Contains(userAgent, "Edg/"):
+		browser = "Edge"
+	case strings.Contains(userAgent, "Chrome/"):
+		browser = "Chrome"
+	case strings.Contains(userAgent, "Firefox/"):
+		browser = "Firefox"
+	case strings.Contains(userAgent, "Safari/"):
+		browser = "Safari"
+	}
+
+	switch {
+	case strings.Contains(userAgent, "Android"):
+		return browser + " on Android"
+	case strings.Contains(userAgent, "iPhone"), strings.Contains(userAgent, "iPad"):
+		return browser + " on iOS"
+	case strings.Contains(userAgent, "Windows"):
+		return browser + " on Windows"
+	case strings.Contains(userAgent, "Mac OS"):
+		return browser + " on macOS"
+	case strings.Contains(userAgent, "Linux"):
+		return browser + " on Linux"
+	}
+	return browser
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock FraudLinkageRepository for testing
+type mockFraudLinkageRepository struct {
+	signals  []*models.AccountSignal
+	accounts map[int]*models.LinkedAccount
+}
+
+func (m *mockFraudLinkageRepository) RecordSignal(userID int, kind models.SignalKind, fingerprint, label string) error {
+	for _, signal := range m.signals {
+		if signal.UserID == userID && signal.Kind == kind && signal.Fingerprint == fingerprint {
+			signal.SeenCount++
+			return nil
+		}
+	}
+	m.signals = append(m.signals, &models.AccountSignal{UserID: userID, Kind: kind, Fingerprint: fingerprint, Label: label, SeenCount: 1})
+	return nil
+}
+
+func (m *mockFraudLinkageRepository) GetSignals(userID int) ([]*models.AccountSignal, error) {
+	var signals []*models.AccountSignal
+	for _, signal := range m.signals {
+		if signal.UserID == userID {
+			signals = append(signals, signal)
+		}
+	}
+	return signals, nil
+}
+
+func (m *mockFraudLinkageRepository) GetSharedLinks(maxAccounts int) ([]*models.AccountLink, error) {
+	shared := make(map[string]int)
+	for _, signal := range m.signals {
+		shared[string(signal.Kind)+signal.Fingerprint]++
+	}
+
+	var links []*models.AccountLink
+	for _, a := range m.signals {
+		if shared[string(a.Kind)+a.Fingerprint] > maxAccounts {
+			continue
+		}
+		for _, b := range m.signals {
+			if b.UserID > a.UserID && b.Kind == a.Kind && b.Fingerprint == a.Fingerprint {
+				links = append(links, &models.AccountLink{UserA: a.UserID, UserB: b.UserID, Kind: a.Kind, Label: a.Label})
+			}
+		}
+	}
+	return links, nil
+}
+
+func (m *mockFraudLinkageRepository) GetLinkedAccounts(userIDs []int) ([]*models.LinkedAccount, error) {
+	var accounts []*models.LinkedAccount
+	for _, id := range userIDs {
+		if account, ok := m.accounts[id]; ok {
+			accounts = append(accounts, account)
+		}
+	}
+	return accounts, nil
+}
+
+func TestFraudLinkageService_GetClusters(t *testing.T) {
+	repo := &mockFraudLinkageRepository{accounts: map[int]*models.LinkedAccount{
+		1: {ID: 1, Role: models.RoleOrganizer, IsActive: false, RefundedOrders: 12},
+		2: {ID: 2, Role: models.RoleOrganizer, IsActive: true},
+		3: {ID: 3, Role: models.RoleAttendee, IsActive: true},
+		4: {ID: 4, Role: models.RoleAttendee, IsActive: true},
+		5: {ID: 5, Role: models.RoleAttendee, IsActive: true},
+		6: {ID: 6, Role: models.RoleOrganizer, IsActive: true},
+	}}
+	service := NewFraudLinkageService(repo)
+
+	// The banned organizer returns with the same bank account, and an
+	// attendee account shares a browser with the new one
+	service.RecordPayoutDetails(1, "KCB 1100-2233-44")
+	service.RecordPayoutDetails(2, "kcb 1100 2233 44")
+	service.RecordLogin(2, "", "device-a", "")
+	service.RecordLogin(3, "", "device-a", "")
+
+	// Attendees sharing an IP address are not an organizer cluster
+	service.RecordLogin(4, "203.0.113.9", "", "")
+	service.RecordLogin(5, "203.0.113.9", "", "")
+
+	// Too many accounts behind one IP address to mean anything
+	for id := 1; id <= models.MaxAccountsPerSignal+1; id++ {
+		service.RecordLogin(id, "198.51.100.1", "", "")
+	}
+	service.RecordLogin(6, "", "device-b", "")
+
+	clusters, err := service.GetClusters()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(clusters) != 1 {
+		t.Fatalf("expected 1 organizer cluster, got %d", len(clusters))
+	}
+
+	cluster := clusters[0]
+	if len(cluster.Accounts) != 3 || cluster.Account(1) == nil || cluster.Account(2) == nil || cluster.Account(3) == nil {
+		t.Errorf("expected accounts 1, 2 and 3 to be linked, got %+v", cluster.Accounts)
+	}
+	if len(cluster.Links) != 2 || !cluster.IsBanEvasion() {
+		t.Errorf("expected a payout and a device link flagged as ban evasion, got %+v", cluster.Links)
+	}
+	if cluster.Links[0].Label != "•••• 3344" && cluster.Links[1].Label != "•••• 3344" {
+		t.Errorf("expected the payout link to show masked details, got %+v", cluster.Links)
+	}
+
+	single, err := service.GetCluster(6)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(single.Accounts) != 1 || len(single.Links) != 0 {
+		t.Errorf("expected an unlinked account on its own, got %+v", single)
+	}
+}
+
+func TestFraudLinkageService_RecordSignIn(t *testing.T) {
+	repo := &mockFraudLinkageRepository{}
+	service := NewFraudLinkageService(repo)
+
+	req := httptest.NewRequest(http.MethodPost, "/auth/login", nil)
+	req.RemoteAddr = "192.0.2.10:5000"
+	req.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0) Chrome/120.0 Safari/537.36")
+	w := httptest.NewRecorder()
+	service.RecordSignIn(w, req, 7)
+
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 || cookies[0].Name != DeviceCookie || len(cookies[0].Value) != 32 {
+		t.Fatalf("expected a device cookie, got %+v", cookies)
+	}
+
+	// The same browser signing in again keeps its cookie
+	req.AddCookie(cookies[0])
+	w = httptest.NewRecorder()
+	service.RecordSignIn(w, req, 7)
+	if len(w.Result().Cookies()) != 0 {
+		t.Error("expected the device cookie to be reused")
+	}
+
+	signals, _ := service.GetSignals(7)
+	if len(signals) != 2 {
+		t.Fatalf("expected an IP and a device signal, got %d", len(signals))
+	}
+	for _, signal := range signals {
+		if signal.SeenCount != 2 {
+			t.Errorf("expected %s to be seen twice, got %d", signal.Kind, signal.SeenCount)
+		}
+		if signal.Kind == models.SignalDevice && signal.Label != "Chrome on Windows" {
+			t.Errorf("expected the browser to be described, got %q", signal.Label)
+		}
+		if signal.Kind == models.SignalIP && signal.Label != "192.0.2.10" {
+			t.Errorf("expected the IP address as label, got %q", signal.Label)
+		}
+	}
+}
+
+func TestFraudLinkageService_IsNewDevice(t *testing.T) {
+	repo := &mockFraudLinkageRepository{}
+	service := NewFraudLinkageService(repo)
+
+	laptop := "0123456789abcdef0123456789abcdef"
+	phone := "fedcba9876543210fedcba9876543210"
+
+	if service.isNewDevice(7, laptop) {
+		t.Error("expected a first sign-in not to be a new device")
+	}
+
+	service.RecordLogin(7, "192.0.2.10", laptop, "Mozilla/5.0 (Windows NT 10.0) Chrome/120.0 Safari/537.36")
+	if service.isNewDevice(7, laptop) {
+		t.Error("expected a browser the user has signed in from not to be a new device")
+	}
+	if !service.isNewDevice(7, phone) {
+		t.Error("expected another browser to be a new device")
+	}
+	if service.isNewDevice(8, phone) {
+		t.Error("expected another user's first sign-in not to be a new device")
+	}
+}
@@ -0,0 +1,230 @@
+package services
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"event-ticketing-platform/internal/cache"
+	"event-ticketing-platform/internal/models"
+)
+
+// Geocoder looks up the coordinates of free-text addresses
+type Geocoder interface {
+	// Geocode returns the coordinates of an address, or nil if it couldn't
+	// be found
+	Geocode(address string) (*models.GeoPoint, error)
+}
+
+// EventGeocodeRepository defines the data operations for event coordinates
+type EventGeocodeRepository interface {
+	UpdateCoordinates(eventID int, location string, point *models.GeoPoint) error
+	GetPending(limit int) ([]models.EventLocation, error)
+}
+
+// geocodeCacheTTL is how long geocoded addresses are cached. Addresses
+// rarely move, and providers such as Nominatim ask for results to be cached.
+const geocodeCacheTTL = 30 * 24 * time.Hour
+
+// ungeocodableLocations are locations, in lowercase, that aren't places
+var ungeocodableLocations = map[string]bool{
+	"online":          true,
+	"virtual":         true,
+	"tba":             true,
+	"tbd":             true,
+	"to be announced": true,
+}
+
+// GeocodingService geocodes event locations so events can be found by
+// distance and shown on a map
+type GeocodingService struct {
+	geocoder Geocoder
+	repo     EventGeocodeRepository
+	cache    cache.Cache
+}
+
+// NewGeocodingService creates a new geocoding service
+func NewGeocodingService(geocoder Geocoder, repo EventGeocodeRepository) *GeocodingService {
+	return &GeocodingService{
+		geocoder: geocoder,
+		repo:     repo,
+	}
+}
+
+// SetCache caches geocoded addresses, so events at the same venue are only
+// looked up once
+func (s *GeocodingService) SetCache(c cache.Cache) {
+	s.cache = c
+}
+
+// Geocode returns the coordinates of a free-text location, or nil if it
+// couldn't be found or isn't a place
+func (s *GeocodingService) Geocode(location string) (*models.GeoPoint, error) {
+	address := normalizeAddress(location)
+	if address == "" || ungeocodableLocations[address] {
+		return nil, nil
+	}
+
+	sum := sha1.Sum([]byte(address))
+	key := cache.Key("geocode", hex.EncodeToString(sum[:]))
+	return cache.Remember(s.cache, key, geocodeCacheTTL, func() (*models.GeoPoint, error) {
+		point, err := s.geocoder.Geocode(address)
+		if err != nil {
+			return nil, fmt.Errorf("failed to geocode %q: %w", location, err)
+		}
+		if point != nil && !point.Valid() {
+			return nil, nil
+		}
+		return point, nil
+	})
+}
+
+// GeocodeEvent geocodes an event's location and stores its coordinates.
+// When the geocoder fails nothing is stored, leaving the event for
+// GeocodePending to retry.
+func (s *GeocodingService) GeocodeEvent(eventID int, location string) (*models.GeoPoint, error) {
+	point, err := s.Geocode(location)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.repo.UpdateCoordinates(eventID, location, point); err != nil {
+		return nil, err
+	}
+	return point, nil
+}
+
+// GeocodePending geocodes up to limit upcoming events that haven't been
+// geocoded since their location changed, such as events saved while the
+// geocoder was down. It stops at the first geocoder failure and returns how
+// many events were geocoded.
+func (s *GeocodingService) GeocodePending(ctx context.Context, limit int) (int, error) {
+	locations, err := s.repo.GetPending(limit)
+	if err != nil {
+		return 0, err
+	}
+
+	geocoded := 0
+	for _, location := range locations {
+		if ctx.Err() != nil {
+			return geocoded, ctx.Err()
+		}
+		if _, err := s.GeocodeEvent(location.EventID, location.Location); err != nil {
+			return geocoded, err
+		}
+		geocoded++
+	}
+	return geocoded, nil
+}
+
+// normalizeAddress lowercases an address and collapses its whitespace, so
+// the same address is cached once however it was typed
+func normalizeAddress(address string) string {
+	return strings.ToLower(strings.Join(strings.Fields(address), " "))
+}
+
+// nominatimRequestInterval is the minimum time between requests to the
+// geocoding API, following the public Nominatim usage policy
+const nominatimRequestInterval = time.Second
+
+// NominatimGeocoder geocodes addresses with a geocoding API that follows the
+// request and response format of OpenStreetMap's Nominatim search endpoint
+type NominatimGeocoder struct {
+	apiURL       string
+	apiKey       string
+	userAgent    string
+	countryCodes []string
+	httpClient   *http.Client
+
+	mu          sync.Mutex
+	lastRequest time.Time
+	interval    time.Duration
+}
+
+// NewNominatimGeocoder creates a new geocoding API client. Results are
+// limited to the ISO 3166-1 alpha-2 country codes when any are given.
+func NewNominatimGeocoder(apiURL, apiKey, userAgent string, countryCodes []string) *NominatimGeocoder {
+	return &NominatimGeocoder{
+		apiURL:       apiURL,
+		apiKey:       apiKey,
+		userAgent:    userAgent,
+		countryCodes: countryCodes,
+		httpClient:   &http.Client{Timeout: 5 * time.Second},
+		interval:     nominatimRequestInterval,
+	}
+}
+
+// Geocode returns the coordinates of the best match for an address, or nil
+// if nothing matched
+func (c *NominatimGeocoder) Geocode(address string) (*models.GeoPoint, error) {
+	params := url.Values{}
+	params.Set("q", address)
+	params.Set("format", "jsonv2")
+	params.Set("limit", "1")
+	if len(c.countryCodes) > 0 {
+		params.Set("countrycodes", strings.ToLower(strings.Join(c.countryCodes, ",")))
+	}
+	if c.apiKey != "" {
+		params.Set("key", c.apiKey)
+	}
+
+	req, err := http.NewRequest(http.MethodGet, c.apiURL+"?"+params.Encode(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create geocoding request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	c.wait()
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("geocoding request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("geocoding API returned status %d", resp.StatusCode)
+	}
+
+	var results []struct {
+		Lat string `json:"lat"`
+		Lon string `json:"lon"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&results); err != nil {
+		return nil, fmt.Errorf("failed to decode geocoding response: %w", err)
+	}
+	if len(results) == 0 {
+		return nil, nil
+	}
+
+	latitude, err := strconv.ParseFloat(results[0].Lat, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid latitude in geocoding response: %w", err)
+	}
+	longitude, err := strconv.ParseFloat(results[0].Lon, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid longitude in geocoding response: %w", err)
+	}
+
+	return &models.GeoPoint{Latitude: latitude, Longitude: longitude}, nil
+}
+
+// wait blocks until the request interval has passed since the last request
+func (c *NominatimGeocoder) wait() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if next := c.lastRequest.Add(c.interval); time.Now().Before(next) {
+		time.Sleep(time.Until(next))
+	}
+	c.lastRequest = time.Now()
+}
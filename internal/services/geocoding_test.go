@@ -0,0 +1,296 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/cache"
+	"event-ticketing-platform/internal/models"
+)
+
+type mockGeocoder struct {
+	points map[string]*models.GeoPoint
+	err    error
+	calls  []string
+}
+
+func (m *mockGeocoder) Geocode(address string) (*models.GeoPoint, error) {
+	m.calls = append(m.calls, address)
+	if m.err != nil {
+		return nil, m.err
+	}
+	return m.points[address], nil
+}
+
+type storedCoordinates struct {
+	location string
+	point    *models.GeoPoint
+}
+
+type mockEventGeocodeRepository struct {
+	pending []models.EventLocation
+	stored  map[int]storedCoordinates
+}
+
+func newMockEventGeocodeRepository() *mockEventGeocodeRepository {
+	return &mockEventGeocodeRepository{stored: make(map[int]storedCoordinates)}
+}
+
+func (m *mockEventGeocodeRepository) UpdateCoordinates(eventID int, location string, point *models.GeoPoint) error {
+	m.stored[eventID] = storedCoordinates{location: location, point: point}
+	return nil
+}
+
+func (m *mockEventGeocodeRepository) GetPending(limit int) ([]models.EventLocation, error) {
+	if len(m.pending) > limit {
+		return m.pending[:limit], nil
+	}
+	return m.pending, nil
+}
+
+var kicc = &models.GeoPoint{Latitude: -1.2884, Longitude: 36.8233}
+
+func TestGeocodingService_GeocodeEvent(t *testing.T) {
+	geocoder := &mockGeocoder{points: map[string]*models.GeoPoint{"kicc, nairobi": kicc}}
+	repo := newMockEventGeocodeRepository()
+	service := NewGeocodingService(geocoder, repo)
+	service.SetCache(cache.NewMemoryCache())
+
+	point, err := service.GeocodeEvent(1, "KICC, Nairobi")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if point == nil || *point != *kicc {
+		t.Fatalf("expected %v, got %v", kicc, point)
+	}
+	if stored := repo.stored[1]; stored.location != "KICC, Nairobi" || stored.point == nil || *stored.point != *kicc {
+		t.Errorf("unexpected stored coordinates: %+v", stored)
+	}
+
+	// The same address typed differently is served from the cache
+	if _, err := service.GeocodeEvent(2, "  kicc,   Nairobi "); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(geocoder.calls) != 1 {
+		t.Errorf("expected 1 geocoder call, got %v", geocoder.calls)
+	}
+	if repo.stored[2].point == nil {
+		t.Error("expected the cached coordinates to be stored for the second event")
+	}
+
+	// Addresses that can't be found are stored without coordinates
+	point, err = service.GeocodeEvent(3, "Nowhere In Particular")
+	if err != nil || point != nil {
+		t.Fatalf("expected no coordinates, got %v, %v", point, err)
+	}
+	if stored, ok := repo.stored[3]; !ok || stored.point != nil {
+		t.Errorf("expected the event to be stored without coordinates, got %+v", stored)
+	}
+
+	// Online events aren't looked up
+	calls := len(geocoder.calls)
+	if _, err := service.GeocodeEvent(4, "Online"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(geocoder.calls) != calls {
+		t.Error("expected online events not to be geocoded")
+	}
+}
+
+func TestGeocodingService_GeocoderFailure(t *testing.T) {
+	geocoder := &mockGeocoder{err: errors.New("timeout")}
+	repo := newMockEventGeocodeRepository()
+	service := NewGeocodingService(geocoder, repo)
+
+	if _, err := service.GeocodeEvent(1, "KICC, Nairobi"); err == nil {
+		t.Fatal("expected an error")
+	}
+	if _, ok := repo.stored[1]; ok {
+		t.Error("expected nothing to be stored so the event is retried")
+	}
+}
+
+func TestGeocodingService_GeocodePending(t *testing.T) {
+	geocoder := &mockGeocoder{points: map[string]*models.GeoPoint{"kicc, nairobi": kicc}}
+	repo := newMockEventGeocodeRepository()
+	repo.pending = []models.EventLocation{
+		{EventID: 1, Location: "KICC, Nairobi"},
+		{EventID: 2, Location: "Online"},
+		{EventID: 3, Location: "Somewhere"},
+	}
+	service := NewGeocodingService(geocoder, repo)
+
+	geocoded, err := service.GeocodePending(context.Background(), 2)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if geocoded != 2 || len(repo.stored) != 2 {
+		t.Errorf("expected 2 events geocoded, got %d with %d stored", geocoded, len(repo.stored))
+	}
+
+	// A geocoder failure stops the run
+	geocoder.err = errors.New("rate limited")
+	repo.stored = make(map[int]storedCoordinates)
+	geocoded, err = service.GeocodePending(context.Background(), 10)
+	if err == nil {
+		t.Fatal("expected an error")
+	}
+	if geocoded != 0 {
+		t.Errorf("expected no events geocoded before the failure, got %d", geocoded)
+	}
+}
+
+func TestNominatimGeocoder_Geocode(t *testing.T) {
+	var query map[string]string
+	var userAgent string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query = map[string]string{}
+		for key := range r.URL.Query() {
+			query[key] = r.URL.Query().Get(key)
+		}
+		userAgent = r.Header.Get("User-Agent")
+
+		switch r.URL.Query().Get("q") {
+		case "kicc, nairobi":
+			w.Write([]byte(`[{"lat": "-1.2884", "lon": "36.8233", "display_name": "KICC"}]`))
+		case "broken":
+			w.WriteHeader(http.StatusServiceUnavailable)
+		default:
+			w.Write([]byte(`[]`))
+		}
+	}))
+	defer server.Close()
+
+	geocoder := NewNominatimGeocoder(server.URL, "secret", "tickets-test", []string{"KE"})
+	geocoder.interval = 0
+
+	point, err := geocoder.Geocode("kicc, nairobi")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if point == nil || *point != *kicc {
+		t.Errorf("expected %v, got %v", kicc, point)
+	}
+	if query["format"] != "jsonv2" || query["limit"] != "1" || query["countrycodes"] != "ke" || query["key"] != "secret" {
+		t.Errorf("unexpected query: %v", query)
+	}
+	if userAgent != "tickets-test" {
+		t.Errorf("expected the configured User-Agent, got %q", userAgent)
+	}
+
+	point, err = geocoder.Geocode("atlantis")
+	if err != nil || point != nil {
+		t.Errorf("expected no match, got %v, %v", point, err)
+	}
+
+	if _, err := geocoder.Geocode("broken"); err == nil {
+		t.Error("expected an error for a failed request")
+	}
+}
+
+func TestNominatimGeocoder_WaitsBetweenRequests(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	geocoder := NewNominatimGeocoder(server.URL, "", "", nil)
+	geocoder.interval = 50 * time.Millisecond
+
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if _, err := geocoder.Geocode("somewhere"); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if elapsed := time.Since(start); elapsed < 100*time.Millisecond {
+		t.Errorf("expected requests to be spaced out, took %v", elapsed)
+	}
+}
+
+func TestEventService_GeocodesSavedEvents(t *testing.T) {
+	service, _, userRepo := setupEventService()
+	defer os.RemoveAll(service.uploadPath)
+
+	geocoder := &mockGeocoder{points: map[string]*models.GeoPoint{"kicc, nairobi": kicc}}
+	repo := newMockEventGeocodeRepository()
+	service.SetGeocoding(NewGeocodingService(geocoder, repo))
+	organizer := createTestUser(userRepo, 1, models.RoleOrganizer)
+
+	event, err := service.CreateEvent(&EventCreateRequest{
+		Title:       "Tech Summit",
+		Description: "Description",
+		StartDate:   time.Now().Add(24 * time.Hour),
+		EndDate:     time.Now().Add(26 * time.Hour),
+		Location:    "KICC, Nairobi",
+		CategoryID:  1,
+		OrganizerID: organizer.ID,
+	})
+	if err != nil {
+		t.Fatalf("failed to create event: %v", err)
+	}
+	if event.Coordinates == nil || *event.Coordinates != *kicc {
+		t.Errorf("expected the event to be geocoded, got %v", event.Coordinates)
+	}
+	if repo.stored[event.ID].point == nil {
+		t.Error("expected the coordinates to be stored")
+	}
+
+	// Saving doesn't fail when the geocoder does
+	geocoder.err = errors.New("timeout")
+	event, err = service.CreateEvent(&EventCreateRequest{
+		Title:       "Food Fair",
+		Description: "Description",
+		StartDate:   time.Now().Add(24 * time.Hour),
+		EndDate:     time.Now().Add(26 * time.Hour),
+		Location:    "Uhuru Gardens, Nairobi",
+		CategoryID:  1,
+		OrganizerID: organizer.ID,
+	})
+	if err != nil {
+		t.Fatalf("expected the event to be created, got %v", err)
+	}
+	if event.Coordinates != nil {
+		t.Errorf("expected no coordinates, got %v", event.Coordinates)
+	}
+}
+
+func TestEventService_SearchEventsNearby(t *testing.T) {
+	service, eventRepo, _ := setupEventService()
+	defer os.RemoveAll(service.uploadPath)
+
+	near := &models.GeoPoint{Latitude: -1.2864, Longitude: 36.8172}
+	tests := []struct {
+		radius int
+		want   float64
+	}{
+		{0, models.DefaultSearchRadiusKm},
+		{10, 10},
+		{5000, models.MaxSearchRadiusKm},
+	}
+
+	for _, tt := range tests {
+		if _, _, err := service.SearchEvents(EventSearchFilters{Near: near, RadiusKm: tt.radius, SortBy: "distance"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if eventRepo.lastSearch.Near != near || eventRepo.lastSearch.RadiusKm != tt.want {
+			t.Errorf("radius %d: expected a search within %v km, got %+v", tt.radius, tt.want, eventRepo.lastSearch)
+		}
+		if eventRepo.lastSearch.SortBy != "distance" {
+			t.Errorf("expected results sorted by distance, got %q", eventRepo.lastSearch.SortBy)
+		}
+	}
+
+	// Without a position the radius is ignored
+	if _, _, err := service.SearchEvents(EventSearchFilters{RadiusKm: 10}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if eventRepo.lastSearch.Near != nil || eventRepo.lastSearch.RadiusKm != 0 {
+		t.Errorf("expected no radius search, got %+v", eventRepo.lastSearch)
+	}
+}
@@ -0,0 +1,179 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Help center errors
+var (
+	ErrHelpArticleNotFound = errors.New("help article not found")
+	ErrHelpSlugTaken       = errors.New("another help article already uses this URL slug")
+)
+
+// Help center limits
+const (
+	helpSearchLimit  = 20
+	helpRelatedLimit = 5
+)
+
+// HelpArticleRepository defines the data operations for help articles
+type HelpArticleRepository interface {
+	List(filter models.HelpArticleFilter) ([]*models.HelpArticle, error)
+	Search(query string, limit int) ([]*models.HelpArticle, error)
+	GetBySlug(slug string) (*models.HelpArticle, error)
+	GetByID(id int) (*models.HelpArticle, error)
+	Create(req *models.HelpArticleRequest, adminID int) (*models.HelpArticle, error)
+	Update(id int, req *models.HelpArticleRequest, adminID int) (*models.HelpArticle, error)
+	Delete(id int) error
+}
+
+// HelpService manages the help center's articles
+type HelpService struct {
+	repo         HelpArticleRepository
+	auditService *AuditService
+}
+
+// NewHelpService creates a new help center service
+func NewHelpService(repo HelpArticleRepository) *HelpService {
+	return &HelpService{repo: repo}
+}
+
+// SetAuditService records help article changes in the audit log
+func (s *HelpService) SetAuditService(auditService *AuditService) {
+	s.auditService = auditService
+}
+
+// List returns the articles matching the filter, drafts included
+func (s *HelpService) List(filter models.HelpArticleFilter) ([]*models.HelpArticle, error) {
+	return s.repo.List(filter)
+}
+
+// Get returns a help article
+func (s *HelpService) Get(id int) (*models.HelpArticle, error) {
+	return s.repo.GetByID(id)
+}
+
+// Create writes a help article on behalf of an admin
+func (s *HelpService) Create(adminID int, req *models.HelpArticleRequest, r *http.Request) (*models.HelpArticle, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.checkSlugAvailable(req.Slug, 0); err != nil {
+		return nil, err
+	}
+
+	article, err := s.repo.Create(req, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logAction(adminID, models.AuditActionHelpArticleCreate, article, r)
+	return article, nil
+}
+
+// Update changes a help article on behalf of an admin
+func (s *HelpService) Update(adminID, id int, req *models.HelpArticleRequest, r *http.Request) (*models.HelpArticle, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	if err := s.checkSlugAvailable(req.Slug, id); err != nil {
+		return nil, err
+	}
+
+	article, err := s.repo.Update(id, req, adminID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.logAction(adminID, models.AuditActionHelpArticleUpdate, article, r)
+	return article, nil
+}
+
+// Delete removes a help article on behalf of an admin
+func (s *HelpService) Delete(adminID, id int, r *http.Request) error {
+	article, err := s.repo.GetByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.Delete(id); err != nil {
+		return err
+	}
+
+	s.logAction(adminID, models.AuditActionHelpArticleDelete, article, r)
+	return nil
+}
+
+// Sections returns the published articles grouped by category
+func (s *HelpService) Sections() ([]*models.HelpSection, error) {
+	articles, err := s.repo.List(models.HelpArticleFilter{Status: models.HelpArticlePublished})
+	if err != nil {
+		return nil, err
+	}
+	return models.GroupHelpArticles(articles), nil
+}
+
+// Search returns the published articles matching a query, best matches first
+func (s *HelpService) Search(query string) ([]*models.HelpArticle, error) {
+	return s.repo.Search(query, helpSearchLimit)
+}
+
+// Article returns the article with the slug. Drafts are only returned when
+// includeDrafts is set, for admins previewing them.
+func (s *HelpService) Article(slug string, includeDrafts bool) (*models.HelpArticle, error) {
+	article, err := s.repo.GetBySlug(slug)
+	if err != nil {
+		return nil, ErrHelpArticleNotFound
+	}
+	if !article.IsPublished() && !includeDrafts {
+		return nil, ErrHelpArticleNotFound
+	}
+	return article, nil
+}
+
+// Related returns other published articles in the same category. It never
+// fails: if they can't be loaded none are shown.
+func (s *HelpService) Related(article *models.HelpArticle) []*models.HelpArticle {
+	articles, err := s.repo.List(models.HelpArticleFilter{Category: article.Category, Status: models.HelpArticlePublished})
+	if err != nil {
+		fmt.Printf("Warning: failed to load related help articles: %v\n", err)
+		return nil
+	}
+
+	var related []*models.HelpArticle
+	for _, other := range articles {
+		if other.ID != article.ID && len(related) < helpRelatedLimit {
+			related = append(related, other)
+		}
+	}
+	return related
+}
+
+// checkSlugAvailable returns ErrHelpSlugTaken if an article other than the
+// one with the ID uses the slug
+func (s *HelpService) checkSlugAvailable(slug string, id int) error {
+	existing, err := s.repo.GetBySlug(slug)
+	if err == nil && existing.ID != id {
+		return ErrHelpSlugTaken
+	}
+	return nil
+}
+
+// logAction records a help article change in the audit log
+func (s *HelpService) logAction(adminID int, action string, article *models.HelpArticle, r *http.Request) {
+	if s.auditService == nil {
+		return
+	}
+	details := map[string]interface{}{
+		"slug":   article.Slug,
+		"title":  article.Title,
+		"status": string(article.Status),
+	}
+	if err := s.auditService.LogAction(adminID, action, models.AuditTargetHelpArticle, article.ID, details, r); err != nil {
+		fmt.Printf("Warning: failed to log help article change: %v\n", err)
+	}
+}
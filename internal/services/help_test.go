@@ -0,0 +1,120 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"event-ticketing-platform/internal/models"
+)
+
+type mockHelpArticleRepository struct {
+	articles []*models.HelpArticle
+}
+
+func (m *mockHelpArticleRepository) List(filter models.HelpArticleFilter) ([]*models.HelpArticle, error) {
+	var articles []*models.HelpArticle
+	for _, article := range m.articles {
+		if (filter.Category == "" || article.Category == filter.Category) && (filter.Status == "" || article.Status == filter.Status) {
+			articles = append(articles, article)
+		}
+	}
+	return articles, nil
+}
+
+func (m *mockHelpArticleRepository) Search(query string, limit int) ([]*models.HelpArticle, error) {
+	return nil, nil
+}
+
+func (m *mockHelpArticleRepository) GetBySlug(slug string) (*models.HelpArticle, error) {
+	for _, article := range m.articles {
+		if article.Slug == slug {
+			return article, nil
+		}
+	}
+	return nil, errors.New("help article not found")
+}
+
+func (m *mockHelpArticleRepository) GetByID(id int) (*models.HelpArticle, error) {
+	for _, article := range m.articles {
+		if article.ID == id {
+			return article, nil
+		}
+	}
+	return nil, errors.New("help article not found")
+}
+
+func (m *mockHelpArticleRepository) Create(req *models.HelpArticleRequest, adminID int) (*models.HelpArticle, error) {
+	article := &models.HelpArticle{
+		ID:       len(m.articles) + 1,
+		Slug:     req.Slug,
+		Title:    req.Title,
+		Body:     req.Body,
+		Category: req.Category,
+		Status:   req.Status,
+	}
+	m.articles = append(m.articles, article)
+	return article, nil
+}
+
+func (m *mockHelpArticleRepository) Update(id int, req *models.HelpArticleRequest, adminID int) (*models.HelpArticle, error) {
+	article, err := m.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+	article.Slug = req.Slug
+	article.Title = req.Title
+	article.Status = req.Status
+	return article, nil
+}
+
+func (m *mockHelpArticleRepository) Delete(id int) error {
+	return nil
+}
+
+func TestHelpService_SlugsAreUnique(t *testing.T) {
+	repo := &mockHelpArticleRepository{articles: []*models.HelpArticle{
+		{ID: 1, Slug: "refunds", Title: "Refunds", Category: models.HelpBuyingTickets, Status: models.HelpArticlePublished},
+	}}
+	service := NewHelpService(repo)
+
+	req := &models.HelpArticleRequest{Title: "Refunds", Body: "Again", Category: models.HelpBuyingTickets, Status: models.HelpArticleDraft}
+	if _, err := service.Create(1, req, nil); !errors.Is(err, ErrHelpSlugTaken) {
+		t.Errorf("expected ErrHelpSlugTaken for a slug in use, got %v", err)
+	}
+
+	// An article keeps its own slug when it is changed
+	req = &models.HelpArticleRequest{Slug: "refunds", Title: "Refunds and cancellations", Body: "Updated", Category: models.HelpBuyingTickets, Status: models.HelpArticlePublished}
+	if _, err := service.Update(1, 1, req, nil); err != nil {
+		t.Errorf("Update() error = %v", err)
+	}
+}
+
+func TestHelpService_DraftsAreHidden(t *testing.T) {
+	repo := &mockHelpArticleRepository{articles: []*models.HelpArticle{
+		{ID: 1, Slug: "refunds", Category: models.HelpBuyingTickets, Status: models.HelpArticlePublished},
+		{ID: 2, Slug: "transfers", Category: models.HelpBuyingTickets, Status: models.HelpArticleDraft},
+		{ID: 3, Slug: "your-tickets", Category: models.HelpBuyingTickets, Status: models.HelpArticlePublished},
+		{ID: 4, Slug: "getting-paid", Category: models.HelpPayments, Status: models.HelpArticlePublished},
+	}}
+	service := NewHelpService(repo)
+
+	if _, err := service.Article("transfers", false); !errors.Is(err, ErrHelpArticleNotFound) {
+		t.Errorf("expected a draft hidden from the public, got %v", err)
+	}
+	if _, err := service.Article("transfers", true); err != nil {
+		t.Errorf("expected admins to preview drafts, got %v", err)
+	}
+
+	sections, err := service.Sections()
+	if err != nil {
+		t.Fatalf("Sections() error = %v", err)
+	}
+	if len(sections) != 2 || len(sections[0].Articles) != 2 {
+		t.Errorf("expected only published articles in the sections, got %+v", sections)
+	}
+
+	related := service.Related(repo.articles[0])
+	if len(related) != 1 || related[0].ID != 3 {
+		t.Errorf("expected the other published article in the category, got %+v", related)
+	}
+}
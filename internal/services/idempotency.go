@@ -0,0 +1,93 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+const (
+	// idempotencyKeyTTL is how long a request's result is replayed to
+	// retries with its key
+	idempotencyKeyTTL = 24 * time.Hour
+	// idempotencyLockTimeout is how long a request may hold its key before
+	// a retry takes it over, as the request must have died mid-way
+	idempotencyLockTimeout = 5 * time.Minute
+)
+
+// ErrInvalidIdempotencyKey is returned for keys that are empty, too long or
+// not printable ASCII
+var ErrInvalidIdempotencyKey = errors.New("invalid idempotency key")
+
+// IdempotencyRepository defines the data operations for idempotency keys
+type IdempotencyRepository interface {
+	Claim(record *models.IdempotencyRecord, staleBefore time.Time) (bool, error)
+	Get(scope string, userID int, key string) (*models.IdempotencyRecord, error)
+	Complete(scope string, userID int, key string, status int, location string) error
+	Release(scope string, userID int, key string) error
+	DeleteExpired() (int64, error)
+}
+
+// IdempotencyService makes requests sent with an idempotency key safe to
+// retry: the first claims the key, and the rest are given its result rather
+// than repeating it. Checkouts and payment callbacks use it so a double
+// submission or a replayed callback can't create a second order.
+type IdempotencyService struct {
+	repo IdempotencyRepository
+	now  func() time.Time
+}
+
+// NewIdempotencyService creates a new idempotency service
+func NewIdempotencyService(repo IdempotencyRepository) *IdempotencyService {
+	return &IdempotencyService{repo: repo, now: time.Now}
+}
+
+// Begin claims a key for a request, reporting whether it was claimed.
+// Otherwise the key's record is returned: completed ones are to be replayed,
+// and the rest are still being processed by an earlier request.
+func (s *IdempotencyService) Begin(scope string, userID int, key string) (*models.IdempotencyRecord, bool, error) {
+	if !models.ValidIdempotencyKey(key) {
+		return nil, false, ErrInvalidIdempotencyKey
+	}
+
+	now := s.now()
+	record := &models.IdempotencyRecord{
+		Scope:     scope,
+		UserID:    userID,
+		Key:       key,
+		ExpiresAt: now.Add(idempotencyKeyTTL),
+	}
+	claimed, err := s.repo.Claim(record, now.Add(-idempotencyLockTimeout))
+	if err != nil {
+		return nil, false, err
+	}
+	if claimed {
+		return record, true, nil
+	}
+
+	existing, err := s.repo.Get(scope, userID, key)
+	if err != nil {
+		return nil, false, fmt.Errorf("failed to load idempotency key: %w", err)
+	}
+	return existing, false, nil
+}
+
+// Complete records the redirect a claimed request answered with, which is
+// replayed to its retries
+func (s *IdempotencyService) Complete(scope string, userID int, key string, status int, location string) error {
+	return s.repo.Complete(scope, userID, key, status, location)
+}
+
+// Release gives up a claimed key whose request didn't complete, so a retry
+// runs it again
+func (s *IdempotencyService) Release(scope string, userID int, key string) error {
+	return s.repo.Release(scope, userID, key)
+}
+
+// DeleteExpired deletes keys past their TTL, returning how many were
+// deleted. It is run periodically in the background.
+func (s *IdempotencyService) DeleteExpired() (int64, error) {
+	return s.repo.DeleteExpired()
+}
@@ -0,0 +1,149 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// memoryIdempotencyRepository keeps idempotency keys in memory, claiming
+// them the way the database does
+type memoryIdempotencyRepository struct {
+	now     func() time.Time
+	records map[string]*models.IdempotencyRecord
+}
+
+func newMemoryIdempotencyRepository(now func() time.Time) *memoryIdempotencyRepository {
+	return &memoryIdempotencyRepository{now: now, records: make(map[string]*models.IdempotencyRecord)}
+}
+
+func idempotencyID(scope string, userID int, key string) string {
+	return fmt.Sprintf("%s/%d/%s", scope, userID, key)
+}
+
+func (m *memoryIdempotencyRepository) Claim(record *models.IdempotencyRecord, staleBefore time.Time) (bool, error) {
+	id := idempotencyID(record.Scope, record.UserID, record.Key)
+	if existing, ok := m.records[id]; ok {
+		expired := !existing.ExpiresAt.After(m.now())
+		stale := existing.Status == models.IdempotencyProcessing && existing.CreatedAt.Before(staleBefore)
+		if !expired && !stale {
+			return false, nil
+		}
+	}
+	claimed := *record
+	claimed.Status = models.IdempotencyProcessing
+	claimed.CreatedAt = m.now()
+	m.records[id] = &claimed
+	return true, nil
+}
+
+func (m *memoryIdempotencyRepository) Get(scope string, userID int, key string) (*models.IdempotencyRecord, error) {
+	record, ok := m.records[idempotencyID(scope, userID, key)]
+	if !ok {
+		return nil, errors.New("idempotency key not found")
+	}
+	copied := *record
+	return &copied, nil
+}
+
+func (m *memoryIdempotencyRepository) Complete(scope string, userID int, key string, status int, location string) error {
+	record := m.records[idempotencyID(scope, userID, key)]
+	record.Status = models.IdempotencyCompleted
+	record.ResponseStatus = status
+	record.ResponseLocation = location
+	return nil
+}
+
+func (m *memoryIdempotencyRepository) Release(scope string, userID int, key string) error {
+	delete(m.records, idempotencyID(scope, userID, key))
+	return nil
+}
+
+func (m *memoryIdempotencyRepository) DeleteExpired() (int64, error) {
+	var deleted int64
+	for id, record := range m.records {
+		if !record.ExpiresAt.After(m.now()) {
+			delete(m.records, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func newTestIdempotencyService() (*IdempotencyService, *time.Time) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+	service := NewIdempotencyService(newMemoryIdempotencyRepository(clock))
+	service.now = clock
+	return service, &now
+}
+
+func TestIdempotencyService_Begin(t *testing.T) {
+	service, _ := newTestIdempotencyService()
+
+	if _, claimed, err := service.Begin(models.IdempotencyScopeCheckout, 1, "abc"); err != nil || !claimed {
+		t.Fatalf("expected the first request to claim the key, got claimed=%v err=%v", claimed, err)
+	}
+
+	record, claimed, err := service.Begin(models.IdempotencyScopeCheckout, 1, "abc")
+	if err != nil || claimed || record.IsCompleted() {
+		t.Fatalf("expected a retry to find the request in progress, got claimed=%v record=%+v err=%v", claimed, record, err)
+	}
+
+	if err := service.Complete(models.IdempotencyScopeCheckout, 1, "abc", http.StatusSeeOther, "/orders/1/confirmation"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	record, claimed, _ = service.Begin(models.IdempotencyScopeCheckout, 1, "abc")
+	if claimed || !record.IsCompleted() || record.ResponseLocation != "/orders/1/confirmation" {
+		t.Errorf("expected the result to be replayed, got claimed=%v record=%+v", claimed, record)
+	}
+
+	// Keys belong to a user and a scope
+	if _, claimed, _ := service.Begin(models.IdempotencyScopeCheckout, 2, "abc"); !claimed {
+		t.Error("expected another user's key to be separate")
+	}
+	if _, claimed, _ := service.Begin(models.IdempotencyScopePaymentCallback, 1, "abc"); !claimed {
+		t.Error("expected another scope's key to be separate")
+	}
+}
+
+func TestIdempotencyService_ReleaseAndTakeover(t *testing.T) {
+	service, now := newTestIdempotencyService()
+
+	service.Begin(models.IdempotencyScopeCheckout, 1, "abc")
+	service.Release(models.IdempotencyScopeCheckout, 1, "abc")
+	if _, claimed, _ := service.Begin(models.IdempotencyScopeCheckout, 1, "abc"); !claimed {
+		t.Error("expected a released key to be claimed again")
+	}
+
+	// A request that never finished gives up its key after the lock timeout
+	*now = now.Add(idempotencyLockTimeout - time.Second)
+	if _, claimed, _ := service.Begin(models.IdempotencyScopeCheckout, 1, "abc"); claimed {
+		t.Error("expected the key to stay held within the lock timeout")
+	}
+	*now = now.Add(2 * time.Second)
+	if _, claimed, _ := service.Begin(models.IdempotencyScopeCheckout, 1, "abc"); !claimed {
+		t.Error("expected a stale key to be taken over")
+	}
+
+	// Completed results are replayed until they expire
+	service.Complete(models.IdempotencyScopeCheckout, 1, "abc", http.StatusSeeOther, "/orders/1/confirmation")
+	*now = now.Add(idempotencyKeyTTL + time.Second)
+	if _, claimed, _ := service.Begin(models.IdempotencyScopeCheckout, 1, "abc"); !claimed {
+		t.Error("expected an expired key to be claimed again")
+	}
+}
+
+func TestIdempotencyService_InvalidKeys(t *testing.T) {
+	service, _ := newTestIdempotencyService()
+
+	for _, key := range []string{"", "has space", string(make([]byte, models.MaxIdempotencyKeyLength+1))} {
+		if _, _, err := service.Begin(models.IdempotencyScopeCheckout, 1, key); !errors.Is(err, ErrInvalidIdempotencyKey) {
+			t.Errorf("expected %q to be rejected, got %v", key, err)
+		}
+	}
+}
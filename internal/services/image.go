@@ -11,39 +11,125 @@ import (
 	"io"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
+	"event-ticketing-platform/internal/models"
+
 	"github.com/disintegration/imaging"
 	"github.com/google/uuid"
+	_ "golang.org/x/image/webp"
 )
 
 // ImageService handles image processing and storage operations
 type ImageService struct {
 	storage StorageService
+	limits  ImageUploadLimits
+	scanner UploadScanner
 }
 
 // NewImageService creates a new image service
 func NewImageService(storage StorageService) *ImageService {
 	return &ImageService{
 		storage: storage,
+		limits:  DefaultImageUploadLimits,
 	}
 }
 
-// ImageVariantConfig defines the configuration for image variants
-type ImageVariantConfig struct {
-	Name   string
-	Width  int
-	Height int
-	Fit    imaging.ResampleFilter
+// SetUploadLimits sets the largest dimensions accepted for uploaded images
+func (s *ImageService) SetUploadLimits(limits ImageUploadLimits) {
+	s.limits = limits
 }
 
+// SetUploadScanner scans every upload for malware before it is processed.
+// Without a scanner uploads are only checked for their type and dimensions.
+func (s *ImageService) SetUploadScanner(scanner UploadScanner) {
+	s.scanner = scanner
+}
 
+// ImageVariantConfig defines the configuration for image variants. Each
+// variant is cropped to its aspect ratio and generated at every width in
+// Widths, for srcset.
+type ImageVariantConfig struct {
+	Name         string
+	Width        int
+	Height       int
+	Fit          imaging.ResampleFilter
+	Widths       []int // Widths to generate, the largest being Width
+	FallbackOnly bool  // Only generate the JPEG fallback, for crawlers that don't read WebP or AVIF
+}
 
 // Default image variants
 var DefaultImageVariants = []ImageVariantConfig{
-	{Name: "thumbnail", Width: 150, Height: 150, Fit: imaging.Lanczos},
-	{Name: "medium", Width: 400, Height: 300, Fit: imaging.Lanczos},
-	{Name: "large", Width: 800, Height: 600, Fit: imaging.Lanczos},
+	{Name: models.ImageVariantThumbnail, Width: 300, Height: 300, Fit: imaging.Lanczos, Widths: []int{150, 300}},
+	{Name: models.ImageVariantCard, Width: 800, Height: 450, Fit: imaging.Lanczos, Widths: []int{400, 800}},
+	{Name: models.ImageVariantHero, Width: 1920, Height: 1080, Fit: imaging.Lanczos, Widths: []int{800, 1280, 1920}},
+	{Name: models.ImageVariantOG, Width: 1200, Height: 630, Fit: imaging.Lanczos, Widths: []int{1200}, FallbackOnly: true},
+}
+
+// modernImageFormats are the formats generated alongside the JPEG fallback
+// when an encoder is registered for them, most compact first
+var modernImageFormats = []string{"avif", "webp"}
+
+// ImageEncoder encodes an image in one format at a quality from 1 to 100
+type ImageEncoder func(w io.Writer, img image.Image, quality int) error
+
+var (
+	imageEncodersMu sync.RWMutex
+	imageEncoders   = map[string]ImageEncoder{}
+)
+
+// RegisterImageEncoder adds an encoder for a format such as webp or avif.
+// The standard library only encodes JPEG and PNG, so variants in the modern
+// formats are generated once a build registers an encoder for them.
+func RegisterImageEncoder(format string, encoder ImageEncoder) {
+	imageEncodersMu.Lock()
+	defer imageEncodersMu.Unlock()
+	imageEncoders[format] = encoder
+}
+
+// imageEncoder returns the registered encoder for a format
+func imageEncoder(format string) (ImageEncoder, bool) {
+	imageEncodersMu.RLock()
+	defer imageEncodersMu.RUnlock()
+	encoder, ok := imageEncoders[format]
+	return encoder, ok
+}
+
+// variantFormats returns the formats to generate a variant in: the JPEG
+// fallback, then each enabled modern format with a registered encoder
+func variantFormats(config ImageVariantConfig, options ImageProcessingOptions) []string {
+	formats := []string{models.ImageFallbackFormat}
+	if config.FallbackOnly {
+		return formats
+	}
+	for _, format := range modernImageFormats {
+		enabled := (format == "webp" && options.EnableWebP) || (format == "avif" && options.EnableAVIF)
+		if _, ok := imageEncoder(format); enabled && ok {
+			formats = append(formats, format)
+		}
+	}
+	return formats
+}
+
+// variantWidths returns the widths to generate a variant at, leaving out
+// those that would need the image upscaled. The smallest width is always
+// kept so every variant exists.
+func variantWidths(config ImageVariantConfig, bounds image.Rectangle) []int {
+	var widths []int
+	for i, width := range config.Widths {
+		height := width * config.Height / config.Width
+		if i > 0 && (width > bounds.Dx() || height > bounds.Dy()) {
+			break
+		}
+		widths = append(widths, width)
+	}
+	return widths
+}
+
+// variantKey returns the storage key of a variant at a width and format
+func variantKey(keyPrefix, name string, width int, format string) string {
+	return fmt.Sprintf("%s/%s-%d.%s", keyPrefix, name, width, fileExtension(format))
 }
 
 // UploadImage processes and uploads an image with multiple variants
@@ -51,6 +137,7 @@ func (s *ImageService) UploadImage(ctx context.Context, reader io.Reader, filena
 	return s.UploadImageWithOptions(ctx, reader, filename, ImageProcessingOptions{
 		Quality:         85,
 		EnableWebP:      true,
+		EnableAVIF:      true,
 		CompressionLevel: 6,
 	})
 }
@@ -63,15 +150,17 @@ func (s *ImageService) UploadImageWithOptions(ctx context.Context, reader io.Rea
 		return nil, fmt.Errorf("failed to read image data: %w", err)
 	}
 
-	// Decode the image
-	img, format, err := image.Decode(bytes.NewReader(imageData))
+	// Check the upload before decoding it in full
+	format, err := s.validateUpload(ctx, imageData)
 	if err != nil {
-		return nil, fmt.Errorf("failed to decode image: %w", err)
+		return nil, err
 	}
 
-	// Validate image format
-	if !isValidImageFormat(format) {
-		return nil, fmt.Errorf("unsupported image format: %s", format)
+	// Decode the image, turning it upright. Everything stored is re-encoded
+	// from the pixels, which drops EXIF metadata such as GPS location.
+	img, err := imaging.Decode(bytes.NewReader(imageData), imaging.AutoOrientation(true))
+	if err != nil {
+		return nil, fmt.Errorf("%w: failed to decode image: %v", ErrInvalidImage, err)
 	}
 
 	// Apply cropping if specified
@@ -93,8 +182,12 @@ func (s *ImageService) UploadImageWithOptions(ctx context.Context, reader io.Rea
 	originalWidth := bounds.Dx()
 	originalHeight := bounds.Dy()
 
-	// For now, keep original format for storage and create WebP variants separately
+	// Keep the original in its own format, or as a JPEG when it can't be
+	// encoded in it
 	storageFormat := format
+	if _, ok := imageEncoder(format); !ok && format != "png" && format != "jpeg" {
+		storageFormat = models.ImageFallbackFormat
+	}
 
 	// Process and upload original image with optimization
 	originalData, err := s.processImageData(img, storageFormat, options)
@@ -102,7 +195,7 @@ func (s *ImageService) UploadImageWithOptions(ctx context.Context, reader io.Rea
 		return nil, fmt.Errorf("failed to process original image: %w", err)
 	}
 
-	originalKey := fmt.Sprintf("%s/original.%s", keyPrefix, storageFormat)
+	originalKey := fmt.Sprintf("%s/original", keyPrefix)
 	originalURL, err := s.uploadImageDataWithHeaders(ctx, originalKey, originalData, getContentType(storageFormat))
 	if err != nil {
 		return nil, fmt.Errorf("failed to upload original image: %w", err)
@@ -119,28 +212,23 @@ func (s *ImageService) UploadImageWithOptions(ctx context.Context, reader io.Rea
 		UploadedAt:  time.Now(),
 	}
 
-	// Create variants with format optimization
-	variants := make([]ImageVariant, 0, len(DefaultImageVariants)*2) // *2 for potential WebP variants
-	
+	// Create each variant at each width, as a JPEG fallback and in the
+	// modern formats
+	var variants []ImageVariant
 	for _, config := range DefaultImageVariants {
-		// Create variant in original format
-		variant, err := s.createImageVariantWithOptions(ctx, img, keyPrefix, config, storageFormat, options)
-		if err != nil {
-			// Log error but continue with other variants
-			fmt.Printf("Failed to create variant %s: %v\n", config.Name, err)
-			continue
-		}
-		variants = append(variants, *variant)
-
-		// Create WebP variant if enabled and format is not already WebP
-		if options.EnableWebP && storageFormat != "webp" {
-			webpVariant, err := s.createImageVariantWithOptions(ctx, img, keyPrefix, config, "webp", options)
-			if err != nil {
-				fmt.Printf("Failed to create WebP variant %s: %v\n", config.Name, err)
-				continue
+		for _, width := range variantWidths(config, bounds) {
+			height := width * config.Height / config.Width
+			resized := imaging.Fill(img, width, height, imaging.Center, config.Fit)
+
+			for _, variantFormat := range variantFormats(config, options) {
+				variant, err := s.createImageVariantWithOptions(ctx, resized, keyPrefix, config.Name, variantFormat, options)
+				if err != nil {
+					// Log error but continue with other variants
+					fmt.Printf("Failed to create %s variant %s at %dpx: %v\n", variantFormat, config.Name, width, err)
+					continue
+				}
+				variants = append(variants, *variant)
 			}
-			webpVariant.Name = config.Name + "-webp"
-			variants = append(variants, *webpVariant)
 		}
 	}
 
@@ -150,19 +238,8 @@ func (s *ImageService) UploadImageWithOptions(ctx context.Context, reader io.Rea
 	}, nil
 }
 
-// createImageVariant creates a resized variant of the image (legacy method)
-func (s *ImageService) createImageVariant(ctx context.Context, img image.Image, keyPrefix string, config ImageVariantConfig, format string) (*ImageVariant, error) {
-	return s.createImageVariantWithOptions(ctx, img, keyPrefix, config, format, ImageProcessingOptions{
-		Quality:         85,
-		CompressionLevel: 6,
-	})
-}
-
-// createImageVariantWithOptions creates a resized variant of the image with custom options
-func (s *ImageService) createImageVariantWithOptions(ctx context.Context, img image.Image, keyPrefix string, config ImageVariantConfig, format string, options ImageProcessingOptions) (*ImageVariant, error) {
-	// Resize image maintaining aspect ratio
-	resized := imaging.Fit(img, config.Width, config.Height, config.Fit)
-	
+// createImageVariantWithOptions encodes and uploads a resized variant of the image
+func (s *ImageService) createImageVariantWithOptions(ctx context.Context, resized image.Image, keyPrefix, name, format string, options ImageProcessingOptions) (*ImageVariant, error) {
 	// Process the resized image with optimization
 	imageData, err := s.processImageData(resized, format, options)
 	if err != nil {
@@ -170,20 +247,19 @@ func (s *ImageService) createImageVariantWithOptions(ctx context.Context, img im
 	}
 
 	// Upload variant
-	variantKey := fmt.Sprintf("%s/%s.%s", keyPrefix, config.Name, format)
-	variantURL, err := s.uploadImageDataWithHeaders(ctx, variantKey, imageData, getContentType(format))
+	bounds := resized.Bounds()
+	key := variantKey(keyPrefix, name, bounds.Dx(), format)
+	variantURL, err := s.uploadImageDataWithHeaders(ctx, key, imageData, getContentType(format))
 	if err != nil {
 		return nil, fmt.Errorf("failed to upload variant: %w", err)
 	}
 
-	// Get variant dimensions
-	bounds := resized.Bounds()
-	
 	return &ImageVariant{
-		Name:   config.Name,
+		Name:   name,
+		Format: format,
 		Width:  bounds.Dx(),
 		Height: bounds.Dy(),
-		Key:    variantKey,
+		Key:    key,
 		URL:    variantURL,
 	}, nil
 }
@@ -250,11 +326,10 @@ func (s *ImageService) processImageData(img image.Image, format string, options
 	
 	switch format {
 	case "jpeg", "jpg":
-		quality := options.Quality
-		if quality <= 0 || quality > 100 {
-			quality = 85
-		}
-		err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: quality})
+		// JPEG has no transparency, so transparent areas become white
+		// rather than black
+		flattened := imaging.Overlay(imaging.New(img.Bounds().Dx(), img.Bounds().Dy(), color.White), img, image.Point{}, 1)
+		err := jpeg.Encode(&buf, flattened, &jpeg.Options{Quality: imageQuality(options)})
 		if err != nil {
 			return nil, fmt.Errorf("failed to encode JPEG: %w", err)
 		}
@@ -267,20 +342,27 @@ func (s *ImageService) processImageData(img image.Image, format string, options
 		if err != nil {
 			return nil, fmt.Errorf("failed to encode PNG: %w", err)
 		}
-	case "webp":
-		// For WebP, we'll use a simple approach since golang.org/x/image/webp only supports decoding
-		// Convert to JPEG with high quality as fallback
-		err := jpeg.Encode(&buf, img, &jpeg.Options{Quality: 90})
-		if err != nil {
-			return nil, fmt.Errorf("failed to encode WebP fallback: %w", err)
-		}
 	default:
-		return nil, fmt.Errorf("unsupported format for processing: %s", format)
+		encoder, ok := imageEncoder(format)
+		if !ok {
+			return nil, fmt.Errorf("unsupported format for processing: %s", format)
+		}
+		if err := encoder(&buf, img, imageQuality(options)); err != nil {
+			return nil, fmt.Errorf("failed to encode %s: %w", format, err)
+		}
 	}
 
 	return buf.Bytes(), nil
 }
 
+// imageQuality returns the encoding quality, defaulting to 85
+func imageQuality(options ImageProcessingOptions) int {
+	if options.Quality <= 0 || options.Quality > 100 {
+		return 85
+	}
+	return options.Quality
+}
+
 // uploadImageData uploads image data to storage (legacy method)
 func (s *ImageService) uploadImageData(ctx context.Context, key string, data []byte, contentType string) (string, error) {
 	reader := bytes.NewReader(data)
@@ -330,16 +412,29 @@ func (s *ImageService) DeleteImage(ctx context.Context, keyPrefix string) error
 	}
 
 	// Delete variants
-	for _, config := range DefaultImageVariants {
-		variantKey := fmt.Sprintf("%s/%s", keyPrefix, config.Name)
-		if err := s.storage.Delete(ctx, variantKey); err != nil {
-			fmt.Printf("Failed to delete variant %s: %v\n", variantKey, err)
+	for _, key := range s.variantKeys(keyPrefix) {
+		if err := s.storage.Delete(ctx, key); err != nil {
+			fmt.Printf("Failed to delete variant %s: %v\n", key, err)
 		}
 	}
 
 	return nil
 }
 
+// variantKeys returns the keys every variant of an image may be stored at
+func (s *ImageService) variantKeys(keyPrefix string) []string {
+	var keys []string
+	all := ImageProcessingOptions{EnableWebP: true, EnableAVIF: true}
+	for _, config := range DefaultImageVariants {
+		for _, width := range config.Widths {
+			for _, format := range variantFormats(config, all) {
+				keys = append(keys, variantKey(keyPrefix, config.Name, width, format))
+			}
+		}
+	}
+	return keys
+}
+
 // ValidateImage validates image file before processing
 func (s *ImageService) ValidateImage(reader io.Reader, maxSize int64) error {
 	// Check file size
@@ -402,55 +497,62 @@ func getContentType(format string) string {
 		return "image/png"
 	case "webp":
 		return "image/webp"
+	case "avif":
+		return "image/avif"
 	default:
 		return "application/octet-stream"
 	}
 }
 
-// GetImageURL returns the URL for a specific image variant
-func (s *ImageService) GetImageURL(keyPrefix, variant string) string {
-	if variant == "original" {
-		return s.storage.GetURL(fmt.Sprintf("%s/original", keyPrefix))
-	}
-	
-	// Check if variant exists in our default variants
-	for _, config := range DefaultImageVariants {
-		if config.Name == variant {
-			return s.storage.GetURL(fmt.Sprintf("%s/%s", keyPrefix, variant))
-		}
+// fileExtension returns the file extension for the image format
+func fileExtension(format string) string {
+	if format == "jpeg" {
+		return "jpg"
 	}
-	
-	// Default to original if variant not found
-	return s.storage.GetURL(fmt.Sprintf("%s/original", keyPrefix))
+	return format
+}
+
+// GetImageURL returns the URL for a specific image variant, at its largest
+// width in the JPEG fallback format
+func (s *ImageService) GetImageURL(keyPrefix, variant string) string {
+	return s.variantURL(keyPrefix, variant, models.ImageFallbackFormat)
 }
 
 // GetOptimalImageURL returns the best image URL based on browser support
 func (s *ImageService) GetOptimalImageURL(keyPrefix, variant string, acceptHeader string) string {
-	// Check if browser supports WebP
-	supportsWebP := strings.Contains(strings.ToLower(acceptHeader), "image/webp")
-	
-	if supportsWebP {
-		// Try to get WebP variant first
-		webpVariant := variant + "-webp"
-		webpURL := s.storage.GetURL(fmt.Sprintf("%s/%s", keyPrefix, webpVariant))
-		
-		// In a real implementation, we'd check if the WebP variant exists
-		// For now, we'll assume it exists if WebP is supported
-		return webpURL
+	accept := strings.ToLower(acceptHeader)
+	for _, format := range modernImageFormats {
+		if _, ok := imageEncoder(format); ok && strings.Contains(accept, "image/"+format) {
+			return s.variantURL(keyPrefix, variant, format)
+		}
 	}
-	
+
 	// Fall back to regular variant
 	return s.GetImageURL(keyPrefix, variant)
 }
 
+// variantURL returns the URL of a variant's largest width in a format, or of
+// the original when the variant is unknown
+func (s *ImageService) variantURL(keyPrefix, variant, format string) string {
+	for _, config := range DefaultImageVariants {
+		if config.Name == variant {
+			if config.FallbackOnly {
+				format = models.ImageFallbackFormat
+			}
+			return s.storage.GetURL(variantKey(keyPrefix, config.Name, config.Width, format))
+		}
+	}
+
+	// Default to original if variant not found
+	return s.storage.GetURL(fmt.Sprintf("%s/original", keyPrefix))
+}
+
 // GetImageVariants returns all available variants for an image
 func (s *ImageService) GetImageVariants(keyPrefix string) []string {
 	variants := []string{"original"}
 	
 	for _, config := range DefaultImageVariants {
 		variants = append(variants, config.Name)
-		// Add WebP variant if it exists
-		variants = append(variants, config.Name+"-webp")
 	}
 	
 	return variants
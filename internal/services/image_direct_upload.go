@@ -0,0 +1,116 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+
+	"github.com/google/uuid"
+)
+
+// Limits on images browsers upload straight to storage
+const (
+	DirectUploadMaxSize = 5 << 20 // 5MB
+	DirectUploadExpiry  = 15 * time.Minute
+)
+
+// directUploadTypes maps the image types that may be uploaded directly to
+// their file extension
+var directUploadTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/webp": ".webp",
+}
+
+// ErrInvalidDirectUpload is returned when a direct upload is refused or its
+// uploaded file fails validation
+var ErrInvalidDirectUpload = errors.New("invalid direct upload")
+
+// EventImageRecorder stores a processed image as an event's image
+type EventImageRecorder interface {
+	SetEventImage(eventID, userID int, result *ImageUploadResult) (*models.Event, error)
+}
+
+// DirectUpload is a presigned URL a browser puts an image to, bypassing the
+// server. The upload is registered by completing it with its key.
+type DirectUpload struct {
+	Key         string    `json:"key"`
+	URL         string    `json:"presigned_url"`
+	ContentType string    `json:"content_type"`
+	MaxSize     int64     `json:"max_size"`
+	ExpiresAt   time.Time `json:"expires_at"`
+}
+
+// directUploadPrefix is where an event's uploads wait to be completed.
+// Uploads that never are get removed by storage garbage collection.
+func directUploadPrefix(eventID int) string {
+	return fmt.Sprintf("events/%d/pending/", eventID)
+}
+
+// CreateDirectUpload presigns an upload of an image for an event. The key is
+// generated so browsers can't choose where in the bucket they write.
+func (s *ImageService) CreateDirectUpload(ctx context.Context, eventID int, contentType string, size int64) (*DirectUpload, error) {
+	ext, ok := directUploadTypes[contentType]
+	if !ok {
+		return nil, fmt.Errorf("%w: only JPEG, PNG and WebP images can be uploaded", ErrInvalidDirectUpload)
+	}
+	if size <= 0 || size > DirectUploadMaxSize {
+		return nil, fmt.Errorf("%w: image size must be between 1 byte and %d bytes", ErrInvalidDirectUpload, DirectUploadMaxSize)
+	}
+
+	key := directUploadPrefix(eventID) + uuid.New().String() + ext
+	url, err := s.storage.GeneratePresignedURL(ctx, key, contentType, DirectUploadExpiry)
+	if err != nil {
+		return nil, err
+	}
+
+	return &DirectUpload{
+		Key:         key,
+		URL:         url,
+		ContentType: contentType,
+		MaxSize:     DirectUploadMaxSize,
+		ExpiresAt:   time.Now().Add(DirectUploadExpiry),
+	}, nil
+}
+
+// CompleteDirectUpload validates an image a browser uploaded for an event and
+// generates its variants. The type is sniffed from the uploaded bytes rather
+// than trusted from the browser. The pending upload is removed afterwards,
+// whether or not it was valid.
+func (s *ImageService) CompleteDirectUpload(ctx context.Context, eventID int, key string, options ImageProcessingOptions) (*ImageUploadResult, error) {
+	name := strings.TrimPrefix(key, directUploadPrefix(eventID))
+	if name == key || name == "" || strings.Contains(name, "/") {
+		return nil, fmt.Errorf("%w: unknown upload key", ErrInvalidDirectUpload)
+	}
+
+	objects, ok := s.storage.(ObjectReader)
+	if !ok {
+		return nil, fmt.Errorf("storage does not support direct uploads")
+	}
+	object, err := objects.Open(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("%w: upload not found: %v", ErrInvalidDirectUpload, err)
+	}
+	defer s.storage.Delete(context.Background(), key)
+
+	data, err := io.ReadAll(io.LimitReader(object, DirectUploadMaxSize+1))
+	object.Close()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read uploaded image: %w", err)
+	}
+	if int64(len(data)) > DirectUploadMaxSize {
+		return nil, fmt.Errorf("%w: image exceeds %d bytes", ErrInvalidDirectUpload, DirectUploadMaxSize)
+	}
+	if _, ok := sniffImageFormat(data); !ok {
+		return nil, fmt.Errorf("%w: uploaded file is not a JPEG, PNG or WebP image", ErrInvalidDirectUpload)
+	}
+
+	return s.UploadImageWithOptions(ctx, bytes.NewReader(data), path.Base(key), options)
+}
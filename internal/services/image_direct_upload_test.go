@@ -0,0 +1,96 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImageService_CreateDirectUpload(t *testing.T) {
+	mockStorage := &MockStorageService{}
+	service := NewImageService(mockStorage)
+	ctx := context.Background()
+
+	mockStorage.On("GeneratePresignedURL", ctx, mock.MatchedBy(func(key string) bool {
+		return strings.HasPrefix(key, "events/7/pending/") && strings.HasSuffix(key, ".png")
+	}), "image/png", DirectUploadExpiry).Return("https://r2.example.com/presigned", nil)
+
+	upload, err := service.CreateDirectUpload(ctx, 7, "image/png", 1024)
+	require.NoError(t, err)
+	assert.Equal(t, "https://r2.example.com/presigned", upload.URL)
+	assert.Equal(t, int64(DirectUploadMaxSize), upload.MaxSize)
+	mockStorage.AssertExpectations(t)
+
+	_, err = service.CreateDirectUpload(ctx, 7, "image/svg+xml", 1024)
+	assert.True(t, errors.Is(err, ErrInvalidDirectUpload))
+
+	_, err = service.CreateDirectUpload(ctx, 7, "image/jpeg", DirectUploadMaxSize+1)
+	assert.True(t, errors.Is(err, ErrInvalidDirectUpload))
+}
+
+func TestImageService_CompleteDirectUpload(t *testing.T) {
+	storage := newFakeBlobStorage("https://cdn.example.com")
+	service := NewImageService(storage)
+	ctx := context.Background()
+
+	key := directUploadPrefix(7) + "upload.png"
+	storage.blobs[key] = createTestPNG(1000, 800)
+
+	result, err := service.CompleteDirectUpload(ctx, 7, key, ImageProcessingOptions{Quality: 85})
+	require.NoError(t, err)
+	assert.Equal(t, "image/png", result.Original.ContentType)
+	assert.NotEmpty(t, result.Variants)
+
+	_, pending := storage.blobs[key]
+	assert.False(t, pending, "the pending upload should be removed")
+	_, stored := storage.blobs[result.Original.Key]
+	assert.True(t, stored)
+}
+
+func TestImageService_CompleteDirectUpload_Rejects(t *testing.T) {
+	storage := newFakeBlobStorage("https://cdn.example.com")
+	service := NewImageService(storage)
+	ctx := context.Background()
+
+	tests := []struct {
+		name string
+		key  string
+		data []byte
+	}{
+		{"another event's upload", directUploadPrefix(8) + "upload.png", createTestPNG(10, 10)},
+		{"key outside pending uploads", "events/7/original", createTestPNG(10, 10)},
+		{"nested key", directUploadPrefix(7) + "a/upload.png", createTestPNG(10, 10)},
+		{"not an image", directUploadPrefix(7) + "upload.jpg", []byte("<svg onload=alert(1)>")},
+		{"too large", directUploadPrefix(7) + "large.jpg", append(createTestJPEG(10, 10), bytes.Repeat([]byte{0}, DirectUploadMaxSize)...)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage.blobs[tt.key] = tt.data
+
+			_, err := service.CompleteDirectUpload(ctx, 7, tt.key, ImageProcessingOptions{})
+			assert.True(t, errors.Is(err, ErrInvalidDirectUpload), "got %v", err)
+		})
+	}
+
+	// Rejected uploads for the event are removed, others are left alone
+	_, kept := storage.blobs[directUploadPrefix(8)+"upload.png"]
+	assert.True(t, kept)
+	_, left := storage.blobs[directUploadPrefix(7)+"upload.jpg"]
+	assert.False(t, left)
+	assert.Equal(t, 0, storage.uploads)
+}
+
+func TestImageService_CompleteDirectUpload_RequiresReadableStorage(t *testing.T) {
+	service := NewImageService(&MockStorageService{})
+
+	_, err := service.CompleteDirectUpload(context.Background(), 7, directUploadPrefix(7)+"upload.png", ImageProcessingOptions{})
+	assert.Error(t, err)
+	assert.False(t, errors.Is(err, ErrInvalidDirectUpload))
+}
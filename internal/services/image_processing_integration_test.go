@@ -58,11 +58,11 @@ func TestImageProcessingIntegration(t *testing.T) {
 		assert.Equal(t, 1080, result.Original.Height)
 
 		// Verify all variants were created
-		expectedVariants := []string{"thumbnail", "medium", "large"}
+		expectedVariants := []string{"thumbnail", "card", "hero", "og"}
 		for _, expectedVariant := range expectedVariants {
 			found := false
 			for _, variant := range result.Variants {
-				if variant.Name == expectedVariant || variant.Name == expectedVariant+"-webp" {
+				if variant.Name == expectedVariant {
 					found = true
 					break
 				}
@@ -109,6 +109,7 @@ func TestImageProcessingIntegration(t *testing.T) {
 	t.Run("OptimalFormatSelection", func(t *testing.T) {
 		jpegImage := createTestJPEG(600, 400)
 		filename := "photo.jpg"
+		registerTestEncoder(t, "webp")
 
 		// Test with WebP support
 		optionsWebP := ImageProcessingOptions{
@@ -124,7 +125,7 @@ func TestImageProcessingIntegration(t *testing.T) {
 		// Should have WebP variants
 		webpVariants := 0
 		for _, variant := range resultWebP.Variants {
-			if strings.Contains(variant.Name, "webp") {
+			if variant.Format == "webp" {
 				webpVariants++
 			}
 		}
@@ -143,7 +144,7 @@ func TestImageProcessingIntegration(t *testing.T) {
 		// Should not have WebP variants
 		webpVariants = 0
 		for _, variant := range resultNoWebP.Variants {
-			if strings.Contains(variant.Name, "webp") {
+			if variant.Format == "webp" {
 				webpVariants++
 			}
 		}
@@ -187,7 +188,7 @@ func TestImageProcessingIntegration(t *testing.T) {
 		result, err := service.UploadImage(ctx, reader, "invalid.jpg")
 		assert.Error(t, err)
 		assert.Nil(t, result)
-		assert.Contains(t, err.Error(), "failed to decode image")
+		assert.ErrorIs(t, err, ErrInvalidImage)
 	})
 
 	t.Run("ImageValidation", func(t *testing.T) {
@@ -231,15 +232,12 @@ func TestImageProcessingIntegration(t *testing.T) {
 		assert.Equal(t, 200, result.Original.Width)
 		assert.Equal(t, 150, result.Original.Height)
 
-		// Verify variants are also properly sized
+		// Verify variants are cut from the cropped image, at their own shape
 		for _, variant := range result.Variants {
 			assert.True(t, variant.Width > 0)
 			assert.True(t, variant.Height > 0)
-			// Variants should maintain aspect ratio of cropped image
-			aspectRatio := float64(variant.Width) / float64(variant.Height)
-			expectedRatio := float64(200) / float64(150) // 4:3
-			assert.InDelta(t, expectedRatio, aspectRatio, 0.1, "Variant should maintain aspect ratio")
 		}
+		assert.Len(t, result.Variants, len(DefaultImageVariants), "Only the smallest width of each variant fits a 200px crop")
 	})
 
 	t.Run("InvalidCropData", func(t *testing.T) {
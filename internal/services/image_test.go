@@ -62,6 +62,19 @@ func createTestJPEG(width, height int) []byte {
 	return buf.Bytes()
 }
 
+// registerTestEncoder registers a stand-in encoder for a modern format for
+// the duration of a test
+func registerTestEncoder(t testing.TB, format string) {
+	RegisterImageEncoder(format, func(w io.Writer, img image.Image, quality int) error {
+		return png.Encode(w, img)
+	})
+	t.Cleanup(func() {
+		imageEncodersMu.Lock()
+		defer imageEncodersMu.Unlock()
+		delete(imageEncoders, format)
+	})
+}
+
 // Helper function to create a test PNG image
 func createTestPNG(width, height int) []byte {
 	img := image.NewRGBA(image.Rect(0, 0, width, height))
@@ -142,9 +155,10 @@ func TestImageService_UploadImage(t *testing.T) {
 	testImage := createTestJPEG(200, 200)
 	filename := "test-image.jpg"
 
-	// Mock storage calls - original + 3 variants + 3 WebP variants = 7 calls
+	// Mock storage calls - original + thumbnail, card, hero and og at their
+	// smallest width, as no larger one fits a 200px image = 5 calls
 	mockStorage.On("Upload", mock.Anything, mock.AnythingOfType("string"), mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("int64")).
-		Return("https://example.com/image.jpg", nil).Times(7)
+		Return("https://example.com/image.jpg", nil).Times(5)
 
 	reader := bytes.NewReader(testImage)
 	result, err := service.UploadImage(ctx, reader, filename)
@@ -160,27 +174,24 @@ func TestImageService_UploadImage(t *testing.T) {
 	assert.Equal(t, 200, result.Original.Height)
 	assert.True(t, result.Original.Size > 0)
 	
-	// Check variants - should have both regular and WebP variants
-	assert.True(t, len(result.Variants) >= 3) // At least 3 regular variants
+	// Check variants - only JPEG fallbacks without WebP or AVIF encoders
+	assert.Len(t, result.Variants, 4)
 	
 	variantNames := make(map[string]bool)
 	for _, variant := range result.Variants {
 		variantNames[variant.Name] = true
-		assert.NotEmpty(t, variant.Key)
+		assert.Equal(t, "jpeg", variant.Format)
+		assert.True(t, strings.HasSuffix(variant.Key, ".jpg"))
 		assert.NotEmpty(t, variant.URL)
 		assert.True(t, variant.Width > 0)
 		assert.True(t, variant.Height > 0)
 	}
 	
-	// Check that basic variants exist
+	// Check that the standard variants exist
 	assert.True(t, variantNames["thumbnail"])
-	assert.True(t, variantNames["medium"])
-	assert.True(t, variantNames["large"])
-	
-	// Check that WebP variants exist
-	assert.True(t, variantNames["thumbnail-webp"])
-	assert.True(t, variantNames["medium-webp"])
-	assert.True(t, variantNames["large-webp"])
+	assert.True(t, variantNames["card"])
+	assert.True(t, variantNames["hero"])
+	assert.True(t, variantNames["og"])
 	
 	mockStorage.AssertExpectations(t)
 }
@@ -198,7 +209,7 @@ func TestImageService_UploadImage_InvalidFormat(t *testing.T) {
 	
 	assert.Error(t, err)
 	assert.Nil(t, result)
-	assert.Contains(t, err.Error(), "failed to decode image")
+	assert.ErrorIs(t, err, ErrInvalidImage)
 }
 
 func TestImageService_DeleteImage(t *testing.T) {
@@ -208,16 +219,17 @@ func TestImageService_DeleteImage(t *testing.T) {
 	ctx := context.Background()
 	keyPrefix := "test/image-123"
 
-	// Mock delete calls for original and variants
-	mockStorage.On("Delete", ctx, keyPrefix+"/original").Return(nil)
-	mockStorage.On("Delete", ctx, keyPrefix+"/thumbnail").Return(nil)
-	mockStorage.On("Delete", ctx, keyPrefix+"/medium").Return(nil)
-	mockStorage.On("Delete", ctx, keyPrefix+"/large").Return(nil)
+	// Mock delete calls for original and every width of every variant
+	mockStorage.On("Delete", ctx, mock.AnythingOfType("string")).Return(nil)
 
 	err := service.DeleteImage(ctx, keyPrefix)
 	
 	assert.NoError(t, err)
-	mockStorage.AssertExpectations(t)
+	mockStorage.AssertNumberOfCalls(t, "Delete", 9)
+	mockStorage.AssertCalled(t, "Delete", ctx, keyPrefix+"/original")
+	mockStorage.AssertCalled(t, "Delete", ctx, keyPrefix+"/thumbnail-150.jpg")
+	mockStorage.AssertCalled(t, "Delete", ctx, keyPrefix+"/hero-1920.jpg")
+	mockStorage.AssertCalled(t, "Delete", ctx, keyPrefix+"/og-1200.jpg")
 }
 
 func TestImageService_GetImageURL(t *testing.T) {
@@ -239,17 +251,17 @@ func TestImageService_GetImageURL(t *testing.T) {
 		{
 			name:     "thumbnail variant",
 			variant:  "thumbnail",
-			expected: keyPrefix + "/thumbnail",
+			expected: keyPrefix + "/thumbnail-300.jpg",
 		},
 		{
-			name:     "medium variant",
-			variant:  "medium",
-			expected: keyPrefix + "/medium",
+			name:     "card variant",
+			variant:  "card",
+			expected: keyPrefix + "/card-800.jpg",
 		},
 		{
-			name:     "large variant",
-			variant:  "large",
-			expected: keyPrefix + "/large",
+			name:     "hero variant",
+			variant:  "hero",
+			expected: keyPrefix + "/hero-1920.jpg",
 		},
 		{
 			name:     "unknown variant defaults to original",
@@ -362,10 +374,13 @@ func
 		CompressionLevel: 6,
 		SupportedFormats: []string{"image/webp", "image/jpeg"},
 	}
+	registerTestEncoder(t, "webp")
+	registerTestEncoder(t, "avif")
 
-	// Mock storage calls - original + 3 variants + 3 WebP variants = 7 calls
+	// Mock storage calls - original + 4 JPEG variants + WebP thumbnail, card
+	// and hero = 8 calls; AVIF isn't enabled and og is JPEG only
 	mockStorage.On("Upload", mock.Anything, mock.AnythingOfType("string"), mock.Anything, mock.AnythingOfType("string"), mock.AnythingOfType("int64")).
-		Return("https://example.com/image.jpg", nil).Times(7)
+		Return("https://example.com/image.jpg", nil).Times(8)
 
 	reader := bytes.NewReader(testImage)
 	result, err := service.UploadImageWithOptions(ctx, reader, filename, options)
@@ -379,14 +394,13 @@ func
 	assert.Equal(t, 200, result.Original.Width)
 	assert.Equal(t, 200, result.Original.Height)
 	
-	// Check variants - should have both regular and WebP variants
-	assert.True(t, len(result.Variants) >= 3) // At least 3 regular variants
-	
 	// Check that WebP variants are created
 	webpVariants := 0
 	for _, variant := range result.Variants {
-		if strings.HasSuffix(variant.Name, "-webp") {
+		if variant.Format == "webp" {
 			webpVariants++
+			assert.True(t, strings.HasSuffix(variant.Key, ".webp"))
+			assert.NotEqual(t, "og", variant.Name)
 		}
 	}
 	assert.Equal(t, 3, webpVariants) // Should have 3 WebP variants
@@ -399,7 +413,8 @@ func TestImageService_GetOptimalImageURL(t *testing.T) {
 	service := NewImageService(mockStorage)
 	
 	keyPrefix := "test/image-123"
-	variant := "medium"
+	variant := "card"
+	registerTestEncoder(t, "webp")
 	
 	tests := []struct {
 		name         string
@@ -409,17 +424,22 @@ func TestImageService_GetOptimalImageURL(t *testing.T) {
 		{
 			name:         "WebP supported",
 			acceptHeader: "image/webp,image/jpeg,*/*",
-			expectedKey:  keyPrefix + "/medium-webp",
+			expectedKey:  keyPrefix + "/card-800.webp",
+		},
+		{
+			name:         "AVIF supported without an encoder",
+			acceptHeader: "image/avif,image/jpeg,*/*",
+			expectedKey:  keyPrefix + "/card-800.jpg",
 		},
 		{
 			name:         "WebP not supported",
 			acceptHeader: "image/jpeg,image/png,*/*",
-			expectedKey:  keyPrefix + "/medium",
+			expectedKey:  keyPrefix + "/card-800.jpg",
 		},
 		{
 			name:         "Empty accept header",
 			acceptHeader: "",
-			expectedKey:  keyPrefix + "/medium",
+			expectedKey:  keyPrefix + "/card-800.jpg",
 		},
 	}
 
@@ -442,13 +462,8 @@ func TestImageService_GetImageVariants(t *testing.T) {
 	keyPrefix := "test/image-123"
 	variants := service.GetImageVariants(keyPrefix)
 	
-	// Should include original, all default variants, and WebP variants
-	expectedVariants := []string{
-		"original",
-		"thumbnail", "thumbnail-webp",
-		"medium", "medium-webp",
-		"large", "large-webp",
-	}
+	// Should include original and all default variants
+	expectedVariants := []string{"original", "thumbnail", "card", "hero", "og"}
 	
 	assert.Len(t, variants, len(expectedVariants))
 	
@@ -534,12 +549,12 @@ func TestProcessImageData(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:   "WebP fallback to JPEG",
+			name:   "WebP without a registered encoder",
 			format: "webp",
 			options: ImageProcessingOptions{
 				Quality: 90,
 			},
-			wantErr: false,
+			wantErr: true,
 		},
 		{
 			name:    "Unsupported format",
@@ -565,6 +580,37 @@ func TestProcessImageData(t *testing.T) {
 	}
 }
 
+func TestProcessImageData_RegisteredEncoder(t *testing.T) {
+	service := NewImageService(&MockStorageService{})
+	registerTestEncoder(t, "avif")
+
+	data, err := service.processImageData(image.NewRGBA(image.Rect(0, 0, 10, 10)), "avif", ImageProcessingOptions{})
+	require.NoError(t, err)
+	_, format, err := image.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	assert.Equal(t, "png", format) // The stand-in encoder writes PNG
+}
+
+func TestProcessImageData_FlattensTransparencyForJPEG(t *testing.T) {
+	service := NewImageService(&MockStorageService{})
+	transparent := image.NewNRGBA(image.Rect(0, 0, 10, 10))
+
+	data, err := service.processImageData(transparent, "jpeg", ImageProcessingOptions{})
+	require.NoError(t, err)
+	decoded, err := jpeg.Decode(bytes.NewReader(data))
+	require.NoError(t, err)
+	r, g, b, _ := decoded.At(5, 5).RGBA()
+	assert.True(t, r > 0xf000 && g > 0xf000 && b > 0xf000, "transparent pixels should become white")
+}
+
+func TestVariantWidths(t *testing.T) {
+	hero := DefaultImageVariants[2]
+	assert.Equal(t, []int{800, 1280, 1920}, variantWidths(hero, image.Rect(0, 0, 4000, 3000)))
+	assert.Equal(t, []int{800, 1280}, variantWidths(hero, image.Rect(0, 0, 1600, 900)))
+	// The smallest width is kept even when it needs upscaling
+	assert.Equal(t, []int{800}, variantWidths(hero, image.Rect(0, 0, 300, 200)))
+}
+
 func TestExtractImageMetadata(t *testing.T) {
 	service := NewImageService(&MockStorageService{})
 	
@@ -645,38 +691,31 @@ func TestImageProcessingPipeline_Integration(t *testing.T) {
 	assert.Equal(t, 600, result.Original.Height)
 	assert.Contains(t, result.Original.Key, "original")
 	
-	// Verify variants are properly sized
+	// Verify variants are cropped to their shape and never wider than it
+	configs := make(map[string]ImageVariantConfig)
+	for _, config := range DefaultImageVariants {
+		configs[config.Name] = config
+	}
 	for _, variant := range result.Variants {
-		switch {
-		case strings.Contains(variant.Name, "thumbnail"):
-			// Thumbnail should be no larger than 150x150
-			assert.True(t, variant.Width <= 150)
-			assert.True(t, variant.Height <= 150)
-		case strings.Contains(variant.Name, "medium"):
-			// Medium should be no larger than 400x300
-			assert.True(t, variant.Width <= 400)
-			assert.True(t, variant.Height <= 300)
-		case strings.Contains(variant.Name, "large"):
-			// Large should be no larger than 800x600
-			assert.True(t, variant.Width <= 800)
-			assert.True(t, variant.Height <= 600)
-		}
+		config, ok := configs[variant.Name]
+		require.True(t, ok, "unexpected variant %s", variant.Name)
+		assert.True(t, variant.Width <= config.Width)
 		
-		// All variants should maintain aspect ratio
-		originalRatio := float64(result.Original.Width) / float64(result.Original.Height)
+		expectedRatio := float64(config.Width) / float64(config.Height)
 		variantRatio := float64(variant.Width) / float64(variant.Height)
-		assert.InDelta(t, originalRatio, variantRatio, 0.1) // Allow small difference due to rounding
+		assert.InDelta(t, expectedRatio, variantRatio, 0.01) // Allow small difference due to rounding
 	}
 	
 	// Test optimal URL selection
 	keyPrefix := "test/image-123"
+	registerTestEncoder(t, "webp")
 	
 	// Mock different URLs for WebP and regular variants
-	mockStorage.On("GetURL", keyPrefix+"/medium-webp").Return("https://cdn.example.com/medium.webp").Maybe()
-	mockStorage.On("GetURL", keyPrefix+"/medium").Return("https://cdn.example.com/medium.jpg").Maybe()
+	mockStorage.On("GetURL", keyPrefix+"/card-800.webp").Return("https://cdn.example.com/card.webp").Maybe()
+	mockStorage.On("GetURL", keyPrefix+"/card-800.jpg").Return("https://cdn.example.com/card.jpg").Maybe()
 	
-	webpURL := service.GetOptimalImageURL(keyPrefix, "medium", "image/webp,image/jpeg,*/*")
-	jpegURL := service.GetOptimalImageURL(keyPrefix, "medium", "image/jpeg,image/png,*/*")
+	webpURL := service.GetOptimalImageURL(keyPrefix, "card", "image/webp,image/jpeg,*/*")
+	jpegURL := service.GetOptimalImageURL(keyPrefix, "card", "image/jpeg,image/png,*/*")
 	
 	assert.NotEqual(t, webpURL, jpegURL) // Should return different URLs based on support
 }
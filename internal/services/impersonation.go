@@ -0,0 +1,125 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// ErrImpersonationExpired is returned when an impersonation has run past its timeout
+var ErrImpersonationExpired = errors.New("impersonation has expired")
+
+// ImpersonationAuditLogger records impersonations in the audit log
+type ImpersonationAuditLogger interface {
+	LogAction(adminUserID int, action, targetType string, targetID int, details interface{}, r *http.Request) error
+}
+
+// ImpersonationUserLookup loads the users taking part in an impersonation
+type ImpersonationUserLookup interface {
+	GetByID(id int) (*models.User, error)
+}
+
+// ImpersonationService lets admins act as other users for support
+// debugging, recording every step in the audit log
+type ImpersonationService struct {
+	users ImpersonationUserLookup
+	audit ImpersonationAuditLogger
+	now   func() time.Time
+}
+
+// NewImpersonationService creates a new impersonation service
+func NewImpersonationService(users ImpersonationUserLookup, audit ImpersonationAuditLogger) *ImpersonationService {
+	return &ImpersonationService{
+		users: users,
+		audit: audit,
+		now:   time.Now,
+	}
+}
+
+// Start begins an admin acting as another user. Admins cannot impersonate
+// themselves or other admins, and must give a reason for the audit log.
+func (s *ImpersonationService) Start(admin *models.User, targetID int, reason string, r *http.Request) (*models.Impersonation, error) {
+	if admin == nil || admin.Role != models.UserRoleAdmin {
+		return nil, fmt.Errorf("only admins can impersonate users")
+	}
+	if targetID == admin.ID {
+		return nil, fmt.Errorf("you cannot impersonate yourself")
+	}
+
+	reason = strings.TrimSpace(reason)
+	if reason == "" {
+		return nil, fmt.Errorf("a reason is required to impersonate a user")
+	}
+	if len(reason) > models.MaxImpersonationReasonLength {
+		return nil, fmt.Errorf("reason must be %d characters or less", models.MaxImpersonationReasonLength)
+	}
+
+	target, err := s.users.GetByID(targetID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+	if target.Role == models.UserRoleAdmin {
+		return nil, fmt.Errorf("admins cannot be impersonated")
+	}
+
+	impersonation := &models.Impersonation{Admin: admin, User: target, StartedAt: s.now()}
+	details := map[string]interface{}{
+		"user_email": target.Email,
+		"reason":     reason,
+	}
+	if err := s.audit.LogAction(admin.ID, models.AuditActionImpersonationStart, models.AuditTargetUser, target.ID, details, r); err != nil {
+		// Impersonation without an audit trail is not allowed
+		return nil, fmt.Errorf("failed to record impersonation: %w", err)
+	}
+	return impersonation, nil
+}
+
+// Resume reloads an impersonation on a later request. It fails once the
+// impersonation has expired or the admin has lost their role.
+func (s *ImpersonationService) Resume(admin *models.User, targetID int, startedAt time.Time) (*models.Impersonation, error) {
+	if admin == nil || admin.Role != models.UserRoleAdmin {
+		return nil, fmt.Errorf("only admins can impersonate users")
+	}
+
+	impersonation := &models.Impersonation{Admin: admin, StartedAt: startedAt}
+	if impersonation.IsExpired(s.now()) {
+		return nil, ErrImpersonationExpired
+	}
+
+	target, err := s.users.GetByID(targetID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+	if target.Role == models.UserRoleAdmin {
+		return nil, fmt.Errorf("admins cannot be impersonated")
+	}
+	impersonation.User = target
+	return impersonation, nil
+}
+
+// Stop ends an impersonation
+func (s *ImpersonationService) Stop(impersonation *models.Impersonation, r *http.Request) {
+	details := map[string]interface{}{
+		"user_email":       impersonation.User.Email,
+		"duration_seconds": int(s.now().Sub(impersonation.StartedAt).Seconds()),
+	}
+	if err := s.audit.LogAction(impersonation.Admin.ID, models.AuditActionImpersonationStop, models.AuditTargetUser, impersonation.User.ID, details, r); err != nil {
+		fmt.Printf("Warning: failed to log end of impersonation: %v\n", err)
+	}
+}
+
+// LogRequest records a change an admin made while impersonating a user
+func (s *ImpersonationService) LogRequest(impersonation *models.Impersonation, r *http.Request) {
+	details := map[string]interface{}{
+		"user_email": impersonation.User.Email,
+		"method":     r.Method,
+		"path":       r.URL.Path,
+	}
+	if err := s.audit.LogAction(impersonation.Admin.ID, models.AuditActionImpersonatedRequest, models.AuditTargetUser, impersonation.User.ID, details, r); err != nil {
+		fmt.Printf("Warning: failed to log impersonated request: %v\n", err)
+	}
+}
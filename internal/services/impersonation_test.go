@@ -0,0 +1,144 @@
+package services
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+type impersonationAuditEntry struct {
+	adminID  int
+	action   string
+	targetID int
+	details  interface{}
+}
+
+type recordingAuditLogger struct {
+	entries []impersonationAuditEntry
+	err     error
+}
+
+func (m *recordingAuditLogger) LogAction(adminUserID int, action, targetType string, targetID int, details interface{}, r *http.Request) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.entries = append(m.entries, impersonationAuditEntry{adminID: adminUserID, action: action, targetID: targetID, details: details})
+	return nil
+}
+
+func setupImpersonationService() (*ImpersonationService, *recordingAuditLogger, time.Time) {
+	users := &MockUserRepository{}
+	users.On("GetByID", 7).Return(&models.User{ID: 7, Email: "buyer@example.com", FirstName: "Ann", Role: models.UserRoleUser}, nil)
+	users.On("GetByID", 8).Return(&models.User{ID: 8, Email: "other-admin@example.com", Role: models.UserRoleAdmin}, nil)
+	users.On("GetByID", 404).Return(nil, errors.New("user not found"))
+
+	audit := &recordingAuditLogger{}
+	now := time.Date(2025, 3, 1, 12, 0, 0, 0, time.UTC)
+	service := NewImpersonationService(users, audit)
+	service.now = func() time.Time { return now }
+	return service, audit, now
+}
+
+func TestImpersonationService_Start(t *testing.T) {
+	service, audit, now := setupImpersonationService()
+	admin := &models.User{ID: 1, Role: models.UserRoleAdmin}
+	r := httptest.NewRequest("POST", "/admin/users/7/impersonate", nil)
+
+	impersonation, err := service.Start(admin, 7, " Checkout bug report ", r)
+	if err != nil {
+		t.Fatalf("Start failed: %v", err)
+	}
+	if impersonation.User.ID != 7 || impersonation.Admin.ID != 1 || !impersonation.StartedAt.Equal(now) {
+		t.Errorf("unexpected impersonation %+v", impersonation)
+	}
+
+	if len(audit.entries) != 1 || audit.entries[0].action != models.AuditActionImpersonationStart || audit.entries[0].adminID != 1 || audit.entries[0].targetID != 7 {
+		t.Fatalf("expected start to be audited, got %+v", audit.entries)
+	}
+	if details := audit.entries[0].details.(map[string]interface{}); details["reason"] != "Checkout bug report" {
+		t.Errorf("expected trimmed reason in audit log, got %v", details["reason"])
+	}
+}
+
+func TestImpersonationService_StartRejects(t *testing.T) {
+	service, audit, _ := setupImpersonationService()
+	admin := &models.User{ID: 1, Role: models.UserRoleAdmin}
+	r := httptest.NewRequest("POST", "/", nil)
+
+	tests := []struct {
+		name     string
+		admin    *models.User
+		targetID int
+		reason   string
+	}{
+		{"non-admin", &models.User{ID: 2, Role: models.UserRoleOrganizer}, 7, "debugging"},
+		{"self", admin, 1, "debugging"},
+		{"other admin", admin, 8, "debugging"},
+		{"missing user", admin, 404, "debugging"},
+		{"no reason", admin, 7, "  "},
+		{"long reason", admin, 7, strings.Repeat("a", models.MaxImpersonationReasonLength+1)},
+	}
+	for _, tt := range tests {
+		if _, err := service.Start(tt.admin, tt.targetID, tt.reason, r); err == nil {
+			t.Errorf("%s: expected error", tt.name)
+		}
+	}
+	if len(audit.entries) != 0 {
+		t.Errorf("expected rejected impersonations not to be audited, got %d", len(audit.entries))
+	}
+
+	// Impersonating without an audit trail is refused
+	audit.err = errors.New("database down")
+	if _, err := service.Start(admin, 7, "debugging", r); err == nil {
+		t.Error("expected error when the audit log cannot be written")
+	}
+}
+
+func TestImpersonationService_Resume(t *testing.T) {
+	service, _, now := setupImpersonationService()
+	admin := &models.User{ID: 1, Role: models.UserRoleAdmin}
+
+	impersonation, err := service.Resume(admin, 7, now.Add(-30*time.Minute))
+	if err != nil {
+		t.Fatalf("Resume failed: %v", err)
+	}
+	if impersonation.User.Email != "buyer@example.com" {
+		t.Errorf("expected the impersonated user, got %+v", impersonation.User)
+	}
+
+	if _, err := service.Resume(admin, 7, now.Add(-models.ImpersonationTimeout)); err != ErrImpersonationExpired {
+		t.Errorf("expected ErrImpersonationExpired, got %v", err)
+	}
+	if _, err := service.Resume(&models.User{ID: 1, Role: models.UserRoleUser}, 7, now); err == nil {
+		t.Error("expected error once the admin has lost their role")
+	}
+}
+
+func TestImpersonationService_StopAndLogRequest(t *testing.T) {
+	service, audit, now := setupImpersonationService()
+	impersonation := &models.Impersonation{
+		Admin:     &models.User{ID: 1, Role: models.UserRoleAdmin},
+		User:      &models.User{ID: 7, Email: "buyer@example.com"},
+		StartedAt: now.Add(-10 * time.Minute),
+	}
+
+	service.LogRequest(impersonation, httptest.NewRequest("POST", "/cart/add", nil))
+	service.Stop(impersonation, httptest.NewRequest("POST", "/impersonation/stop", nil))
+
+	if len(audit.entries) != 2 {
+		t.Fatalf("expected 2 audit entries, got %d", len(audit.entries))
+	}
+	request := audit.entries[0]
+	if request.action != models.AuditActionImpersonatedRequest || request.details.(map[string]interface{})["path"] != "/cart/add" {
+		t.Errorf("expected impersonated request to be audited, got %+v", request)
+	}
+	stop := audit.entries[1]
+	if stop.action != models.AuditActionImpersonationStop || stop.details.(map[string]interface{})["duration_seconds"] != 600 {
+		t.Errorf("expected stop to be audited with its duration, got %+v", stop)
+	}
+}
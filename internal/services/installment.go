@@ -0,0 +1,380 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"event-ticketing-platform/internal/i18n"
+	"event-ticketing-platform/internal/models"
+)
+
+const (
+	// installmentClaimLease is how long a claimed installment is kept from
+	// other runs while it is charged; a charge still pending at the provider
+	// is checked again once it lapses
+	installmentClaimLease = 30 * time.Minute
+	// installmentBatchSize is how many installments each run charges
+	installmentBatchSize = 50
+)
+
+// InstallmentRepositoryInterface defines the data operations for installment plans
+type InstallmentRepositoryInterface interface {
+	GetSettings(eventID int) (*models.InstallmentSettings, error)
+	SaveSettings(settings *models.InstallmentSettings) error
+	CreatePlan(plan *models.InstallmentPlan) error
+	GetPlan(id int) (*models.InstallmentPlan, error)
+	GetPlanByOrder(orderID int) (*models.InstallmentPlan, error)
+	ClaimDueInstallments(now time.Time, lease time.Duration, limit int) ([]*models.Installment, error)
+	StartInstallmentAttempt(id int, reference string) error
+	MarkInstallmentPaid(id int) error
+	RecordInstallmentFailure(id int, message string, retryAt *time.Time) error
+	CompletePlan(planID int) error
+	IssuePlanTickets(orderID int, ticketData []struct {
+		TicketTypeID int
+		QRCode       string
+	}) error
+	DefaultPlan(plan *models.InstallmentPlan) error
+}
+
+// InstallmentChargeProvider is a payment provider that can charge a card
+// again without the buyer, using the authorization an earlier payment left
+type InstallmentChargeProvider interface {
+	GetReusableAuthorization(reference string) (*models.PaymentAuthorization, error)
+	ChargeAuthorization(authorizationCode, email string, amount int, reference string) (*PaymentStatus, error)
+	GetPaymentStatus(paymentID string) (*PaymentStatus, error)
+}
+
+// InstallmentOrderReader retrieves the orders installment plans pay for
+type InstallmentOrderReader interface {
+	GetByID(id int) (*models.Order, error)
+}
+
+// InstallmentOrderNotifier emails and publishes an order once its tickets
+// have been issued
+type InstallmentOrderNotifier interface {
+	NotifyOrderCompleted(orderID int, attendees []models.TicketAttendee) error
+}
+
+// InstallmentEmailSender sends installment charge notices in the given language
+type InstallmentEmailSender interface {
+	SendInstallmentEmail(email, userName, subject, locale, message, link string) error
+}
+
+// InstallmentService lets buyers pay for an order in installments. The
+// first is paid at checkout and the rest are charged to the same card as
+// they fall due, retrying failed charges before the order is cancelled.
+// Tickets are issued once the order is paid in full, unless the organizer
+// issues them up front.
+type InstallmentService struct {
+	repo        InstallmentRepositoryInterface
+	orders      InstallmentOrderReader
+	notifier    InstallmentOrderNotifier
+	provider    InstallmentChargeProvider
+	emailSender InstallmentEmailSender
+	baseURL     string
+	now         func() time.Time
+}
+
+// NewInstallmentService creates a new installment service
+func NewInstallmentService(repo InstallmentRepositoryInterface, orders InstallmentOrderReader, notifier InstallmentOrderNotifier, provider InstallmentChargeProvider, emailSender InstallmentEmailSender, baseURL string) *InstallmentService {
+	return &InstallmentService{
+		repo:        repo,
+		orders:      orders,
+		notifier:    notifier,
+		provider:    provider,
+		emailSender: emailSender,
+		baseURL:     baseURL,
+		now:         time.Now,
+	}
+}
+
+// GetSettings retrieves an event's installment settings
+func (s *InstallmentService) GetSettings(eventID int) (*models.InstallmentSettings, error) {
+	return s.repo.GetSettings(eventID)
+}
+
+// UpdateSettings validates and saves an event's installment settings
+func (s *InstallmentService) UpdateSettings(settings *models.InstallmentSettings) error {
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+	return s.repo.SaveSettings(settings)
+}
+
+// Offer returns the installment plan buyers of an order of the total can
+// choose at checkout, or nil if the event doesn't offer one for it
+func (s *InstallmentService) Offer(eventID, total int) *models.InstallmentOffer {
+	settings, err := s.repo.GetSettings(eventID)
+	if err != nil {
+		fmt.Printf("Warning: failed to get installment settings for event %d: %v\n", eventID, err)
+		return nil
+	}
+	return settings.Offer(total)
+}
+
+// Plan retrieves an order's payment schedule, or nil if the order isn't
+// being paid in installments
+func (s *InstallmentService) Plan(orderID int) (*models.InstallmentPlan, error) {
+	return s.repo.GetPlanByOrder(orderID)
+}
+
+// StartPlan creates the payment schedule of an order whose first
+// installment was paid with paymentReference, saving the card it was paid
+// with for the rest
+func (s *InstallmentService) StartPlan(order *models.Order, offer *models.InstallmentOffer, paymentReference string, items []models.InstallmentItem, attendees []models.TicketAttendee) (*models.InstallmentPlan, error) {
+	authorization, err := s.provider.GetReusableAuthorization(paymentReference)
+	if err != nil {
+		return nil, fmt.Errorf("failed to save card for installments: %w", err)
+	}
+
+	email := authorization.Email
+	if email == "" {
+		email = order.BillingEmail
+	}
+
+	now := s.now()
+	plan := &models.InstallmentPlan{
+		OrderID:             order.ID,
+		UserID:              order.UserID,
+		EventID:             order.EventID,
+		TotalAmount:         order.TotalAmount,
+		Status:              models.InstallmentPlanActive,
+		IssueTicketsUpfront: offer.IssueTicketsUpfront,
+		AuthorizationCode:   authorization.Code,
+		CustomerEmail:       email,
+		Items:               items,
+		Attendees:           attendees,
+	}
+	for i, amount := range offer.Amounts {
+		dueAt := now.AddDate(0, 0, i*offer.IntervalDays)
+		installment := &models.Installment{
+			Sequence:      i + 1,
+			Amount:        amount,
+			DueAt:         dueAt,
+			Status:        models.InstallmentPending,
+			NextAttemptAt: dueAt,
+		}
+		if i == 0 {
+			installment.Status = models.InstallmentPaid
+			installment.PaymentReference = paymentReference
+			installment.Attempts = 1
+			installment.PaidAt = &now
+		}
+		plan.Installments = append(plan.Installments, installment)
+	}
+
+	if err := s.repo.CreatePlan(plan); err != nil {
+		return nil, fmt.Errorf("failed to create installment plan: %w", err)
+	}
+
+	s.notifyPaid(order, plan, plan.Installments[0])
+	return plan, nil
+}
+
+// ProcessDue charges the installments that have fallen due, returning how
+// many were paid. Failed charges are retried after each of
+// models.InstallmentRetryDelays, and once they run out the plan defaults.
+func (s *InstallmentService) ProcessDue(ctx context.Context) (int, error) {
+	installments, err := s.repo.ClaimDueInstallments(s.now(), installmentClaimLease, installmentBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("failed to claim due installments: %w", err)
+	}
+
+	paid := 0
+	for _, installment := range installments {
+		if err := ctx.Err(); err != nil {
+			return paid, err
+		}
+
+		charged, err := s.charge(installment)
+		if err != nil {
+			fmt.Printf("Warning: failed to charge installment %d of plan %d: %v\n", installment.Sequence, installment.PlanID, err)
+			continue
+		}
+		if charged {
+			paid++
+		}
+	}
+
+	return paid, nil
+}
+
+// charge charges an installment to its plan's card, returning whether it
+// was paid. A retry first checks the previous attempt, whose outcome may
+// not have been known, so the buyer is never charged twice.
+func (s *InstallmentService) charge(installment *models.Installment) (bool, error) {
+	plan, err := s.repo.GetPlan(installment.PlanID)
+	if err != nil {
+		return false, err
+	}
+	if plan.Status != models.InstallmentPlanActive {
+		return false, nil
+	}
+	for _, scheduled := range plan.Installments {
+		if scheduled.ID == installment.ID {
+			installment = scheduled
+		}
+	}
+	order, err := s.orders.GetByID(plan.OrderID)
+	if err != nil {
+		return false, fmt.Errorf("failed to get order: %w", err)
+	}
+
+	if installment.PaymentReference != "" {
+		status, err := s.provider.GetPaymentStatus(installment.PaymentReference)
+		if err == nil && status.Status == "success" {
+			return true, s.paid(order, plan, installment)
+		}
+		if err == nil && status.Status == "pending" {
+			return false, nil
+		}
+	}
+
+	reference := fmt.Sprintf("INS-%d-%d-%d", plan.ID, installment.Sequence, installment.Attempts+1)
+	if err := s.repo.StartInstallmentAttempt(installment.ID, reference); err != nil {
+		return false, err
+	}
+	installment.PaymentReference = reference
+	installment.Attempts++
+
+	status, err := s.provider.ChargeAuthorization(plan.AuthorizationCode, plan.CustomerEmail, installment.Amount, reference)
+	switch {
+	case err != nil:
+		return false, s.failed(order, plan, installment, err.Error())
+	case status.Status == "success":
+		return true, s.paid(order, plan, installment)
+	case status.Status == "failed":
+		return false, s.failed(order, plan, installment, "The card was declined")
+	default:
+		// Still being processed; checked by its reference once the claim lapses
+		return false, nil
+	}
+}
+
+// paid records an installment as paid, completing its plan and issuing the
+// order's tickets once it is paid in full
+func (s *InstallmentService) paid(order *models.Order, plan *models.InstallmentPlan, installment *models.Installment) error {
+	if err := s.repo.MarkInstallmentPaid(installment.ID); err != nil {
+		return err
+	}
+	now := s.now()
+	installment.Status = models.InstallmentPaid
+	installment.PaidAt = &now
+
+	if !plan.IsPaidInFull() {
+		s.notifyPaid(order, plan, installment)
+		return nil
+	}
+
+	if !plan.IssueTicketsUpfront {
+		if err := s.issueTickets(plan); err != nil {
+			return err
+		}
+	}
+	if err := s.repo.CompletePlan(plan.ID); err != nil {
+		return err
+	}
+
+	s.notify(order, i18n.T(i18n.Resolve(order.Locale), "installment.completed.subject", order.OrderNumber),
+		"installment.completed.message", formatInstallmentAmount(installment.Amount), order.OrderNumber)
+	return nil
+}
+
+// issueTickets completes the order of a plan paid in full with its tickets
+// and emails them
+func (s *InstallmentService) issueTickets(plan *models.InstallmentPlan) error {
+	var ticketData []struct {
+		TicketTypeID int
+		QRCode       string
+	}
+	for _, item := range plan.Items {
+		for i := 0; i < item.Quantity; i++ {
+			qrCode, err := generateTicketQRCode(plan.OrderID, item.TicketTypeID)
+			if err != nil {
+				return fmt.Errorf("failed to generate QR code: %w", err)
+			}
+			ticketData = append(ticketData, struct {
+				TicketTypeID int
+				QRCode       string
+			}{TicketTypeID: item.TicketTypeID, QRCode: qrCode})
+		}
+	}
+
+	if err := s.repo.IssuePlanTickets(plan.OrderID, ticketData); err != nil {
+		return fmt.Errorf("failed to issue tickets: %w", err)
+	}
+
+	if s.notifier != nil {
+		if err := s.notifier.NotifyOrderCompleted(plan.OrderID, plan.Attendees); err != nil {
+			fmt.Printf("Warning: failed to send tickets for order %d: %v\n", plan.OrderID, err)
+		}
+	}
+	return nil
+}
+
+// failed records a failed charge of an installment, scheduling its next
+// attempt or defaulting the plan once the retries have run out
+func (s *InstallmentService) failed(order *models.Order, plan *models.InstallmentPlan, installment *models.Installment, message string) error {
+	locale := i18n.Resolve(order.Locale)
+
+	// The attempt at checkout or when it fell due isn't a retry
+	retry := installment.Attempts - 1
+	if retry < len(models.InstallmentRetryDelays) {
+		retryAt := s.now().Add(models.InstallmentRetryDelays[retry])
+		if err := s.repo.RecordInstallmentFailure(installment.ID, message, &retryAt); err != nil {
+			return err
+		}
+		s.notify(order, i18n.T(locale, "installment.failed.subject", installment.Sequence, order.OrderNumber),
+			"installment.failed.message", formatInstallmentAmount(installment.Amount), order.OrderNumber, i18n.FormatLongDateTime(locale, retryAt))
+		return nil
+	}
+
+	if err := s.repo.RecordInstallmentFailure(installment.ID, message, nil); err != nil {
+		return err
+	}
+	if err := s.repo.DefaultPlan(plan); err != nil {
+		return err
+	}
+	s.notify(order, i18n.T(locale, "installment.defaulted.subject", order.OrderNumber),
+		"installment.defaulted.message", installment.Sequence, order.OrderNumber)
+	return nil
+}
+
+// notifyPaid tells the buyer an installment before the last was paid, what
+// is left and when the next is due
+func (s *InstallmentService) notifyPaid(order *models.Order, plan *models.InstallmentPlan, installment *models.Installment) {
+	var next *models.Installment
+	for _, candidate := range plan.Installments {
+		if candidate.Status != models.InstallmentPaid {
+			next = candidate
+			break
+		}
+	}
+	if next == nil {
+		return
+	}
+
+	locale := i18n.Resolve(order.Locale)
+	s.notify(order, i18n.T(locale, "installment.paid.subject", installment.Sequence, len(plan.Installments), order.OrderNumber),
+		"installment.paid.message", formatInstallmentAmount(installment.Amount), order.OrderNumber,
+		formatInstallmentAmount(plan.TotalAmount-plan.PaidAmount()), i18n.FormatLongDateTime(locale, next.DueAt))
+}
+
+// notify emails the buyer of an installment order the message with the key
+func (s *InstallmentService) notify(order *models.Order, subject, key string, args ...interface{}) {
+	if s.emailSender == nil {
+		return
+	}
+
+	locale := i18n.Resolve(order.Locale)
+	link := fmt.Sprintf("%s/dashboard/orders/%d/installments", s.baseURL, order.ID)
+	if err := s.emailSender.SendInstallmentEmail(order.BillingEmail, order.BillingName, subject, locale, i18n.T(locale, key, args...), link); err != nil {
+		fmt.Printf("Warning: failed to send installment email to %s: %v\n", order.BillingEmail, err)
+	}
+}
+
+// formatInstallmentAmount formats an amount in cents for emails
+func formatInstallmentAmount(amount int) string {
+	return fmt.Sprintf("KSh %.2f", float64(amount)/100.0)
+}
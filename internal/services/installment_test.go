@@ -0,0 +1,381 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// memoryInstallmentRepository keeps installment settings and plans in memory
+type memoryInstallmentRepository struct {
+	settings  map[int]*models.InstallmentSettings
+	plans     []*models.InstallmentPlan
+	issued    map[int]int
+	defaulted []int
+}
+
+func newMemoryInstallmentRepository() *memoryInstallmentRepository {
+	return &memoryInstallmentRepository{
+		settings: make(map[int]*models.InstallmentSettings),
+		issued:   make(map[int]int),
+	}
+}
+
+func (m *memoryInstallmentRepository) GetSettings(eventID int) (*models.InstallmentSettings, error) {
+	if settings, ok := m.settings[eventID]; ok {
+		copied := *settings
+		return &copied, nil
+	}
+	return models.DefaultInstallmentSettings(eventID), nil
+}
+
+func (m *memoryInstallmentRepository) SaveSettings(settings *models.InstallmentSettings) error {
+	saved := *settings
+	m.settings[settings.EventID] = &saved
+	return nil
+}
+
+func (m *memoryInstallmentRepository) CreatePlan(plan *models.InstallmentPlan) error {
+	plan.ID = len(m.plans) + 1
+	for i, installment := range plan.Installments {
+		installment.ID = plan.ID*100 + i
+		installment.PlanID = plan.ID
+	}
+	m.plans = append(m.plans, plan)
+	return nil
+}
+
+func (m *memoryInstallmentRepository) GetPlan(id int) (*models.InstallmentPlan, error) {
+	for _, plan := range m.plans {
+		if plan.ID == id {
+			copied := *plan
+			copied.Installments = nil
+			for _, installment := range plan.Installments {
+				copiedInstallment := *installment
+				copied.Installments = append(copied.Installments, &copiedInstallment)
+			}
+			return &copied, nil
+		}
+	}
+	return nil, errors.New("plan not found")
+}
+
+func (m *memoryInstallmentRepository) plan(id int) *models.InstallmentPlan {
+	for _, plan := range m.plans {
+		if plan.ID == id {
+			return plan
+		}
+	}
+	return nil
+}
+
+func (m *memoryInstallmentRepository) GetPlanByOrder(orderID int) (*models.InstallmentPlan, error) {
+	for _, plan := range m.plans {
+		if plan.OrderID == orderID {
+			return plan, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryInstallmentRepository) installment(id int) *models.Installment {
+	for _, plan := range m.plans {
+		for _, installment := range plan.Installments {
+			if installment.ID == id {
+				return installment
+			}
+		}
+	}
+	return nil
+}
+
+func (m *memoryInstallmentRepository) ClaimDueInstallments(now time.Time, lease time.Duration, limit int) ([]*models.Installment, error) {
+	var due []*models.Installment
+	for _, plan := range m.plans {
+		if plan.Status != models.InstallmentPlanActive {
+			continue
+		}
+		for _, installment := range plan.Installments {
+			if installment.Status == models.InstallmentPending && !installment.NextAttemptAt.After(now) && len(due) < limit {
+				installment.NextAttemptAt = now.Add(lease)
+				copied := *installment
+				due = append(due, &copied)
+			}
+		}
+	}
+	return due, nil
+}
+
+func (m *memoryInstallmentRepository) StartInstallmentAttempt(id int, reference string) error {
+	installment := m.installment(id)
+	installment.PaymentReference = reference
+	installment.Attempts++
+	return nil
+}
+
+func (m *memoryInstallmentRepository) MarkInstallmentPaid(id int) error {
+	m.installment(id).Status = models.InstallmentPaid
+	return nil
+}
+
+func (m *memoryInstallmentRepository) RecordInstallmentFailure(id int, message string, retryAt *time.Time) error {
+	installment := m.installment(id)
+	installment.LastError = message
+	if retryAt != nil {
+		installment.NextAttemptAt = *retryAt
+	} else {
+		installment.Status = models.InstallmentFailed
+	}
+	return nil
+}
+
+func (m *memoryInstallmentRepository) CompletePlan(planID int) error {
+	m.plan(planID).Status = models.InstallmentPlanCompleted
+	return nil
+}
+
+func (m *memoryInstallmentRepository) IssuePlanTickets(orderID int, ticketData []struct {
+	TicketTypeID int
+	QRCode       string
+}) error {
+	m.issued[orderID] += len(ticketData)
+	return nil
+}
+
+func (m *memoryInstallmentRepository) DefaultPlan(plan *models.InstallmentPlan) error {
+	m.plan(plan.ID).Status = models.InstallmentPlanDefaulted
+	m.defaulted = append(m.defaulted, plan.ID)
+	return nil
+}
+
+// fakeChargeProvider charges saved cards with the statuses it is given
+type fakeChargeProvider struct {
+	statuses map[string]string // payment status by reference
+	charges  []string
+	decline  bool
+}
+
+func (f *fakeChargeProvider) GetReusableAuthorization(reference string) (*models.PaymentAuthorization, error) {
+	return &models.PaymentAuthorization{Code: "AUTH_" + reference, Email: "buyer@example.com"}, nil
+}
+
+func (f *fakeChargeProvider) ChargeAuthorization(authorizationCode, email string, amount int, reference string) (*PaymentStatus, error) {
+	f.charges = append(f.charges, reference)
+	status := "success"
+	if f.decline {
+		status = "failed"
+	}
+	f.statuses[reference] = status
+	return &PaymentStatus{PaymentID: reference, Status: status, Amount: amount}, nil
+}
+
+func (f *fakeChargeProvider) GetPaymentStatus(paymentID string) (*PaymentStatus, error) {
+	status, ok := f.statuses[paymentID]
+	if !ok {
+		return nil, errors.New("transaction not found")
+	}
+	return &PaymentStatus{PaymentID: paymentID, Status: status}, nil
+}
+
+// fakeInstallmentOrders returns one order and records when it is completed
+type fakeInstallmentOrders struct {
+	order     *models.Order
+	completed []int
+}
+
+func (f *fakeInstallmentOrders) GetByID(id int) (*models.Order, error) {
+	return f.order, nil
+}
+
+func (f *fakeInstallmentOrders) NotifyOrderCompleted(orderID int, attendees []models.TicketAttendee) error {
+	f.completed = append(f.completed, orderID)
+	return nil
+}
+
+// recordingInstallmentEmails records the subjects of the notices it is sent
+type recordingInstallmentEmails struct {
+	subjects []string
+}
+
+func (r *recordingInstallmentEmails) SendInstallmentEmail(email, userName, subject, locale, message, link string) error {
+	r.subjects = append(r.subjects, subject)
+	return nil
+}
+
+func newTestInstallmentService(now *time.Time) (*InstallmentService, *memoryInstallmentRepository, *fakeChargeProvider, *fakeInstallmentOrders, *recordingInstallmentEmails) {
+	repo := newMemoryInstallmentRepository()
+	provider := &fakeChargeProvider{statuses: map[string]string{"TXN-1": "success"}}
+	orders := &fakeInstallmentOrders{order: &models.Order{ID: 7, UserID: 3, EventID: 5, OrderNumber: "ORD-20260101-000007", TotalAmount: 30001, BillingEmail: "buyer@example.com", BillingName: "Buyer"}}
+	emails := &recordingInstallmentEmails{}
+	service := NewInstallmentService(repo, orders, orders, provider, emails, "https://tickets.example.com")
+	service.now = func() time.Time { return *now }
+	return service, repo, provider, orders, emails
+}
+
+func startTestPlan(t *testing.T, service *InstallmentService, orders *fakeInstallmentOrders, upfront bool) *models.InstallmentPlan {
+	t.Helper()
+	offer := &models.InstallmentOffer{Amounts: models.SplitInstallments(30001, 3), IntervalDays: 30, IssueTicketsUpfront: upfront}
+	plan, err := service.StartPlan(orders.order, offer, "TXN-1", []models.InstallmentItem{{TicketTypeID: 2, Quantity: 2}}, nil)
+	if err != nil {
+		t.Fatalf("StartPlan failed: %v", err)
+	}
+	return plan
+}
+
+func TestInstallmentSettingsOffer(t *testing.T) {
+	settings := models.DefaultInstallmentSettings(5)
+	if settings.Offer(100000) != nil {
+		t.Fatal("disabled settings should not offer installments")
+	}
+
+	settings.Enabled = true
+	settings.MinOrderAmount = 50000
+	if settings.Offer(49999) != nil {
+		t.Error("orders below the minimum should not be offered installments")
+	}
+
+	offer := settings.Offer(100001)
+	if offer == nil || offer.Count() != 3 {
+		t.Fatalf("expected 3 installments, got %+v", offer)
+	}
+	if offer.Amounts[0] != 33335 || offer.Amounts[1] != 33333 || offer.Amounts[2] != 33333 {
+		t.Errorf("expected the remainder paid up front, got %v", offer.Amounts)
+	}
+}
+
+func TestInstallmentService_StartPlan(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	service, _, _, orders, emails := newTestInstallmentService(&now)
+
+	plan := startTestPlan(t, service, orders, false)
+
+	if plan.AuthorizationCode != "AUTH_TXN-1" {
+		t.Errorf("expected the first payment's card to be saved, got %q", plan.AuthorizationCode)
+	}
+	if len(plan.Installments) != 3 {
+		t.Fatalf("expected 3 installments, got %d", len(plan.Installments))
+	}
+	if first := plan.Installments[0]; first.Status != models.InstallmentPaid || first.PaymentReference != "TXN-1" || first.Amount != 10001 {
+		t.Errorf("expected the first installment paid at checkout, got %+v", first)
+	}
+	if due := plan.Installments[2].DueAt; !due.Equal(now.AddDate(0, 0, 60)) {
+		t.Errorf("expected the last installment due in 60 days, got %v", due)
+	}
+	if plan.PaidAmount() != 10001 {
+		t.Errorf("expected 10001 paid, got %d", plan.PaidAmount())
+	}
+	if len(emails.subjects) != 1 || emails.subjects[0] != "Installment 1 of 3 paid for order ORD-20260101-000007" {
+		t.Errorf("expected a payment notice, got %v", emails.subjects)
+	}
+}
+
+func TestInstallmentService_ProcessDueIssuesTicketsWhenPaidInFull(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	service, repo, provider, orders, _ := newTestInstallmentService(&now)
+	plan := startTestPlan(t, service, orders, false)
+
+	if paid, err := service.ProcessDue(context.Background()); err != nil || paid != 0 {
+		t.Fatalf("expected nothing due yet, got %d, %v", paid, err)
+	}
+
+	now = now.AddDate(0, 0, 30)
+	if paid, err := service.ProcessDue(context.Background()); err != nil || paid != 1 {
+		t.Fatalf("expected the second installment charged, got %d, %v", paid, err)
+	}
+	if repo.issued[7] != 0 || plan.Status != models.InstallmentPlanActive {
+		t.Fatal("tickets should not be issued before the order is paid in full")
+	}
+
+	now = now.AddDate(0, 0, 30)
+	if paid, err := service.ProcessDue(context.Background()); err != nil || paid != 1 {
+		t.Fatalf("expected the last installment charged, got %d, %v", paid, err)
+	}
+	if plan.Status != models.InstallmentPlanCompleted {
+		t.Errorf("expected the plan completed, got %s", plan.Status)
+	}
+	if repo.issued[7] != 2 {
+		t.Errorf("expected 2 tickets issued, got %d", repo.issued[7])
+	}
+	if len(orders.completed) != 1 {
+		t.Errorf("expected the order's tickets sent once, got %v", orders.completed)
+	}
+	if len(provider.charges) != 2 {
+		t.Errorf("expected 2 charges, got %v", provider.charges)
+	}
+}
+
+func TestInstallmentService_UpfrontPlansDontIssueTicketsAgain(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	service, repo, _, orders, _ := newTestInstallmentService(&now)
+	plan := startTestPlan(t, service, orders, true)
+
+	now = now.AddDate(0, 0, 61)
+	if paid, err := service.ProcessDue(context.Background()); err != nil || paid != 2 {
+		t.Fatalf("expected both remaining installments charged, got %d, %v", paid, err)
+	}
+	if plan.Status != models.InstallmentPlanCompleted {
+		t.Errorf("expected the plan completed, got %s", plan.Status)
+	}
+	if repo.issued[7] != 0 || len(orders.completed) != 0 {
+		t.Error("tickets issued up front should not be issued again")
+	}
+}
+
+func TestInstallmentService_RetriesThenDefaults(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	service, repo, provider, orders, emails := newTestInstallmentService(&now)
+	plan := startTestPlan(t, service, orders, false)
+	provider.decline = true
+
+	now = now.AddDate(0, 0, 30)
+	for attempt := 0; attempt <= len(models.InstallmentRetryDelays); attempt++ {
+		if _, err := service.ProcessDue(context.Background()); err != nil {
+			t.Fatalf("ProcessDue failed: %v", err)
+		}
+		if attempt < len(models.InstallmentRetryDelays) {
+			if plan.Status != models.InstallmentPlanActive {
+				t.Fatalf("plan defaulted after %d attempts", attempt+1)
+			}
+			now = now.Add(models.InstallmentRetryDelays[attempt])
+		}
+	}
+
+	if plan.Status != models.InstallmentPlanDefaulted || len(repo.defaulted) != 1 {
+		t.Fatalf("expected the plan to default once the retries ran out, got %s", plan.Status)
+	}
+	if status := plan.Installments[1].Status; status != models.InstallmentFailed {
+		t.Errorf("expected the installment failed, got %s", status)
+	}
+	if len(provider.charges) != len(models.InstallmentRetryDelays)+1 {
+		t.Errorf("expected %d charges, got %v", len(models.InstallmentRetryDelays)+1, provider.charges)
+	}
+	if last := emails.subjects[len(emails.subjects)-1]; last != "Order ORD-20260101-000007 has been cancelled" {
+		t.Errorf("expected a cancellation notice, got %q", last)
+	}
+}
+
+func TestInstallmentService_RetryChecksPreviousCharge(t *testing.T) {
+	now := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	service, _, provider, orders, _ := newTestInstallmentService(&now)
+	plan := startTestPlan(t, service, orders, false)
+
+	// A charge whose response was lost went through at the provider
+	second := plan.Installments[1]
+	second.PaymentReference = "INS-1-2-1"
+	second.Attempts = 1
+	provider.statuses["INS-1-2-1"] = "success"
+
+	now = now.AddDate(0, 0, 30)
+	if paid, err := service.ProcessDue(context.Background()); err != nil || paid != 1 {
+		t.Fatalf("expected the earlier charge recorded as paid, got %d, %v", paid, err)
+	}
+	if len(provider.charges) != 0 {
+		t.Errorf("expected no new charge, got %v", provider.charges)
+	}
+	if second.Status != models.InstallmentPaid {
+		t.Errorf("expected the installment paid, got %s", second.Status)
+	}
+}
@@ -36,6 +36,7 @@ type EventServiceInterface interface {
 	SearchEvents(filters EventSearchFilters) ([]*models.Event, int, error)
 	GetCategories() ([]*models.Category, error)
 	GetEventByID(id int) (*models.Event, error)
+	GetEventBySlug(slug string) (*models.Event, error)
 	GetEventOrganizer(eventID int) (*models.User, error)
 	CreateEvent(req *EventCreateRequest) (*models.Event, error)
 	UpdateEvent(id int, req *EventUpdateRequest) (*models.Event, error)
@@ -43,6 +44,7 @@ type EventServiceInterface interface {
 	GetEventsByOrganizer(organizerID int) ([]*models.Event, error)
 	CanUserEditEvent(eventID int, userID int) (bool, error)
 	CanUserDeleteEvent(eventID int, userID int) (bool, error)
+	CanUserManageEvent(eventID int, userID int) (bool, error)
 	UpdateEventStatus(eventID int, status models.EventStatus, organizerID int) (*models.Event, error)
 	DuplicateEvent(eventID int, organizerID int, newTitle string, newStartDate, newEndDate time.Time) (*models.Event, error)
 
@@ -111,17 +113,25 @@ type ImageServiceInterface interface {
 	GetImageURL(keyPrefix, variant string) string
 	GetOptimalImageURL(keyPrefix, variant string, acceptHeader string) string
 	GetImageVariants(keyPrefix string) []string
+	CreateDirectUpload(ctx context.Context, eventID int, contentType string, size int64) (*DirectUpload, error)
+	CompleteDirectUpload(ctx context.Context, eventID int, key string, options ImageProcessingOptions) (*ImageUploadResult, error)
 }
 
 // EventSearchFilters represents search filters for events
 type EventSearchFilters struct {
-	Query    string
-	Category string
-	Location string
-	DateFrom string
-	DateTo   string
-	Page     int
-	PerPage  int
+	Query       string
+	Category    string
+	Location    string
+	DateFrom    string
+	DateTo      string
+	OrganizerID int
+	Page        int
+	PerPage     int
+	SortBy      string           // "relevance" orders results by full-text rank, "distance" by distance from Near
+	When        string           // Date preset: today, tomorrow, weekend, next_7_days
+	TimeZone    string           // IANA time zone used to resolve When
+	Near        *models.GeoPoint // Only events within RadiusKm of this point
+	RadiusKm    int
 }
 
 // TicketReservation represents a ticket reservation
@@ -153,7 +163,14 @@ type OrderTicketTypeItem struct {
 type AnalyticsServiceInterface interface {
 	GetOrganizerDashboard(organizerID int) (*OrganizerDashboardData, error)
 	GetEventAnalytics(eventID int, organizerID int) (*EventAnalyticsData, error)
-	ExportAttendeeData(eventID int, organizerID int) ([]byte, error)
+	ExportAttendees(w io.Writer, eventID int, organizerID int, opts AttendeeExportOptions) error
+	GetOrganizerSalesSeries(organizerID int, days int) ([]*DailySales, error)
+	GetEventSalesSeries(eventID int, organizerID int, days int) ([]*DailySales, error)
+	GetEventRevenueByTicketType(eventID int, organizerID int) ([]*TicketTypeAnalytics, error)
+	GetEventFunnel(eventID int, organizerID int, since time.Time) (*SalesFunnel, error)
+	GetEventComparison(eventID int, organizerID int) (*EventComparison, error)
+	GetOrganizerSalesBySource(organizerID int) ([]*SalesBySource, error)
+	GetEventSalesBySource(eventID int, organizerID int) ([]*SalesBySource, error)
 	GetOrganizerBalance(organizerID int) (float64, error)
 }
 
@@ -169,6 +186,8 @@ type OrganizerDashboardData struct {
 	TopEvents        []*EventPerformance `json:"top_events"`
 	RevenueByMonth   []*MonthlyRevenue   `json:"revenue_by_month"`
 	SalesOverTime    []*DailySales       `json:"sales_over_time"`
+	SalesSeries      []*DailySales       `json:"sales_series"` // SalesOverTime oldest first, with days without sales
+	SalesBySource    []*SalesBySource    `json:"sales_by_source"`
 }
 
 type EventAnalyticsData struct {
@@ -183,6 +202,12 @@ type EventAnalyticsData struct {
 	OrderStatusBreakdown  map[string]int                   `json:"order_status_breakdown"`
 	RecentOrders          []*repositories.OrderWithDetails `json:"recent_orders"`
 	AttendeeData          []*AttendeeInfo                  `json:"attendee_data"`
+	SalesSeries           []*DailySales                    `json:"sales_series"` // SalesByDay oldest first, with days without sales
+	Funnel                *SalesFunnel                     `json:"funnel"`
+	Comparison            *EventComparison                 `json:"comparison"`
+	SalesBySource         []*SalesBySource                 `json:"sales_by_source"`
+	DonationRevenue       float64                          `json:"donation_revenue"` // Included in TotalRevenue
+	Donations             int                              `json:"donations"`
 }
 
 type EventSummary struct {
@@ -207,6 +232,22 @@ type EventPerformance struct {
 	AverageOrderValue float64   `json:"average_order_value"`
 }
 
+// CategoryPerformance summarizes event and sales performance for a category
+type CategoryPerformance struct {
+	CategoryID       int     `json:"category_id"`
+	Name             string  `json:"name"`
+	Slug             string  `json:"slug"`
+	EventCount       int     `json:"event_count"`
+	PublishedEvents  int     `json:"published_events"`
+	TicketsAvailable int     `json:"tickets_available"`
+	TicketsSold      int     `json:"tickets_sold"`
+	Revenue          float64 `json:"revenue"`
+	TotalOrders      int     `json:"total_orders"`
+	CompletedOrders  int     `json:"completed_orders"`
+	ConversionRate   float64 `json:"conversion_rate"`   // completed orders as a percentage of all orders
+	SellThroughRate  float64 `json:"sell_through_rate"` // tickets sold as a percentage of tickets available
+}
+
 type MonthlyRevenue struct {
 	Month   string  `json:"month"`
 	Year    int     `json:"year"`
@@ -230,6 +271,8 @@ type TicketTypeAnalytics struct {
 	TicketsSold       int     `json:"tickets_sold"`
 	Revenue           float64 `json:"revenue"`
 	SoldOutPercentage float64 `json:"sold_out_percentage"`
+	PayWhatYouWant    bool    `json:"pay_what_you_want"` // Price is the minimum buyers paid
+	AveragePrice      float64 `json:"average_price"`
 }
 
 type AttendeeInfo struct {
@@ -0,0 +1,73 @@
+package services
+
+import (
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// LedgerRepositoryInterface defines the data operations for organizers'
+// ledgers
+type LedgerRepositoryInterface interface {
+	Sync(organizerID int) (int, error)
+	GetBalances(organizerID int) (models.LedgerBalances, error)
+	GetTransactions(organizerID, limit, offset int) ([]*models.LedgerTransaction, int, error)
+}
+
+// LedgerService keeps organizers' balances as a double-entry ledger, so each
+// balance can be traced to the sales, refunds, fees and payouts behind it.
+// Ledgers are synced before they are read, which posts what happened since.
+type LedgerService struct {
+	repo LedgerRepositoryInterface
+}
+
+// NewLedgerService creates a new ledger service
+func NewLedgerService(repo LedgerRepositoryInterface) *LedgerService {
+	return &LedgerService{repo: repo}
+}
+
+// Balances syncs the organizer's ledger and returns the balance of each of
+// its accounts
+func (s *LedgerService) Balances(organizerID int) (models.LedgerBalances, error) {
+	if _, err := s.repo.Sync(organizerID); err != nil {
+		return nil, fmt.Errorf("failed to sync ledger: %w", err)
+	}
+
+	balances, err := s.repo.GetBalances(organizerID)
+	if err != nil {
+		return nil, err
+	}
+	if total := balances.Total(); total != 0 {
+		return nil, fmt.Errorf("ledger of organizer %d is unbalanced by %d cents", organizerID, total)
+	}
+
+	return balances, nil
+}
+
+// GetOrganizerBalance returns what the organizer can withdraw. A balance
+// refunds have taken below zero can't be withdrawn until sales cover it.
+func (s *LedgerService) GetOrganizerBalance(organizerID int) (float64, error) {
+	balances, err := s.Balances(organizerID)
+	if err != nil {
+		return 0, err
+	}
+
+	balance := balances[models.LedgerOrganizer]
+	if balance < 0 {
+		balance = 0
+	}
+	return float64(balance) / 100.0, nil
+}
+
+// History syncs the organizer's ledger and returns a page of its
+// transactions, newest first, and how many it has in total
+func (s *LedgerService) History(organizerID, page, limit int) ([]*models.LedgerTransaction, int, error) {
+	if _, err := s.repo.Sync(organizerID); err != nil {
+		return nil, 0, fmt.Errorf("failed to sync ledger: %w", err)
+	}
+
+	if page < 1 {
+		page = 1
+	}
+	return s.repo.GetTransactions(organizerID, limit, (page-1)*limit)
+}
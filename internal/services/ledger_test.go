@@ -0,0 +1,78 @@
+package services
+
+import (
+	"testing"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock LedgerRepository for testing
+type mockLedgerRepository struct {
+	balances     models.LedgerBalances
+	transactions []*models.LedgerTransaction
+	syncs        int
+	limit        int
+	offset       int
+}
+
+func (m *mockLedgerRepository) Sync(organizerID int) (int, error) {
+	m.syncs++
+	return 0, nil
+}
+
+func (m *mockLedgerRepository) GetBalances(organizerID int) (models.LedgerBalances, error) {
+	return m.balances, nil
+}
+
+func (m *mockLedgerRepository) GetTransactions(organizerID, limit, offset int) ([]*models.LedgerTransaction, int, error) {
+	m.limit, m.offset = limit, offset
+	return m.transactions, len(m.transactions), nil
+}
+
+func TestLedgerService_GetOrganizerBalance(t *testing.T) {
+	repo := &mockLedgerRepository{balances: models.LedgerBalances{
+		models.LedgerOrganizer:     12345,
+		models.LedgerBuyerPayments: -13000,
+		models.LedgerPlatformFees:  655,
+	}}
+	service := NewLedgerService(repo)
+
+	balance, err := service.GetOrganizerBalance(2)
+	if err != nil || balance != 123.45 {
+		t.Errorf("GetOrganizerBalance() = %v, %v, want 123.45", balance, err)
+	}
+	if repo.syncs != 1 {
+		t.Errorf("ledger synced %d times, want once", repo.syncs)
+	}
+
+	// Refunds after a payout can take the balance below zero
+	repo.balances = models.LedgerBalances{models.LedgerOrganizer: -500, models.LedgerPayouts: 500}
+	if balance, err := service.GetOrganizerBalance(2); err != nil || balance != 0 {
+		t.Errorf("GetOrganizerBalance() of a negative balance = %v, %v, want 0", balance, err)
+	}
+}
+
+func TestLedgerService_UnbalancedLedger(t *testing.T) {
+	repo := &mockLedgerRepository{balances: models.LedgerBalances{
+		models.LedgerOrganizer:     9500,
+		models.LedgerBuyerPayments: -10000,
+	}}
+	service := NewLedgerService(repo)
+
+	if _, err := service.GetOrganizerBalance(2); err == nil {
+		t.Error("GetOrganizerBalance() of an unbalanced ledger = nil error, want an error")
+	}
+}
+
+func TestLedgerService_History(t *testing.T) {
+	repo := &mockLedgerRepository{transactions: []*models.LedgerTransaction{{ID: 1}}}
+	service := NewLedgerService(repo)
+
+	transactions, total, err := service.History(2, 3, 25)
+	if err != nil || len(transactions) != 1 || total != 1 {
+		t.Fatalf("History() = %v, %d, %v", transactions, total, err)
+	}
+	if repo.syncs != 1 || repo.limit != 25 || repo.offset != 50 {
+		t.Errorf("History() synced %d times and read %d from %d, want a sync and 25 from 50", repo.syncs, repo.limit, repo.offset)
+	}
+}
@@ -0,0 +1,112 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"event-ticketing-platform/internal/i18n"
+	"event-ticketing-platform/internal/models"
+)
+
+// LocaleRepository defines the data operations for email language preferences
+type LocaleRepository interface {
+	GetUserLocale(userID int) (string, error)
+	SetUserLocale(userID int, locale string) error
+	GetEventLocale(eventID int) (string, error)
+	SetEventLocale(eventID int, locale string) error
+}
+
+// LocaleService decides which language a buyer's emails are sent in. The
+// language chosen at checkout wins, then the buyer's account setting, then
+// the event's language, then the platform default.
+type LocaleService struct {
+	repo LocaleRepository
+}
+
+// NewLocaleService creates a new locale service
+func NewLocaleService(repo LocaleRepository) *LocaleService {
+	return &LocaleService{repo: repo}
+}
+
+// GetUserLocale returns a user's preferred language, empty if they use the event's
+func (s *LocaleService) GetUserLocale(userID int) (string, error) {
+	locale, err := s.repo.GetUserLocale(userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get language preference: %w", err)
+	}
+	return i18n.Normalize(locale), nil
+}
+
+// UpdateUserLocale sets a user's preferred language. An empty locale clears
+// the preference so emails follow each event's language.
+func (s *LocaleService) UpdateUserLocale(userID int, locale string) error {
+	locale, err := normalizeLocale(locale, true)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.SetUserLocale(userID, locale); err != nil {
+		return fmt.Errorf("failed to update language preference: %w", err)
+	}
+	return nil
+}
+
+// GetEventLocale returns the language an event's emails default to
+func (s *LocaleService) GetEventLocale(eventID int) (string, error) {
+	locale, err := s.repo.GetEventLocale(eventID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get event language: %w", err)
+	}
+	return i18n.Resolve(locale), nil
+}
+
+// UpdateEventLocale sets the language an event's emails default to
+func (s *LocaleService) UpdateEventLocale(eventID int, locale string) error {
+	locale, err := normalizeLocale(locale, false)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.SetEventLocale(eventID, locale); err != nil {
+		return fmt.Errorf("failed to update event language: %w", err)
+	}
+	return nil
+}
+
+// ResolveLocale returns the language to email a buyer in, given the
+// language they chose at checkout (if any). Preferences that fail to load
+// are skipped so an email is never held up by them.
+func (s *LocaleService) ResolveLocale(checkoutLocale string, userID, eventID int) string {
+	if locale := i18n.Normalize(checkoutLocale); locale != "" {
+		return locale
+	}
+
+	userLocale, err := s.repo.GetUserLocale(userID)
+	if err != nil {
+		fmt.Printf("Warning: failed to get language preference for user %d: %v\n", userID, err)
+	}
+
+	eventLocale, err := s.repo.GetEventLocale(eventID)
+	if err != nil {
+		fmt.Printf("Warning: failed to get language for event %d: %v\n", eventID, err)
+	}
+
+	return i18n.Resolve(userLocale, eventLocale)
+}
+
+// ResolveOrderLocale returns the language to send an order's emails in
+func (s *LocaleService) ResolveOrderLocale(order *models.Order) string {
+	return s.ResolveLocale(order.Locale, order.UserID, order.EventID)
+}
+
+// normalizeLocale validates a locale chosen in a form, returning it in its
+// canonical form
+func normalizeLocale(locale string, allowEmpty bool) (string, error) {
+	locale = strings.TrimSpace(locale)
+	if locale == "" && allowEmpty {
+		return "", nil
+	}
+	normalized := i18n.Normalize(locale)
+	if normalized == "" {
+		return "", fmt.Errorf("unsupported language: %q", locale)
+	}
+	return normalized, nil
+}
@@ -0,0 +1,219 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// mockLocaleRepository keeps user and event locales in memory
+type mockLocaleRepository struct {
+	users  map[int]string
+	events map[int]string
+}
+
+func newMockLocaleRepository() *mockLocaleRepository {
+	return &mockLocaleRepository{
+		users:  make(map[int]string),
+		events: make(map[int]string),
+	}
+}
+
+func (m *mockLocaleRepository) GetUserLocale(userID int) (string, error) {
+	locale, ok := m.users[userID]
+	if !ok {
+		return "", fmt.Errorf("user with id %d not found", userID)
+	}
+	return locale, nil
+}
+
+func (m *mockLocaleRepository) SetUserLocale(userID int, locale string) error {
+	m.users[userID] = locale
+	return nil
+}
+
+func (m *mockLocaleRepository) GetEventLocale(eventID int) (string, error) {
+	locale, ok := m.events[eventID]
+	if !ok {
+		return "", fmt.Errorf("event with id %d not found", eventID)
+	}
+	return locale, nil
+}
+
+func (m *mockLocaleRepository) SetEventLocale(eventID int, locale string) error {
+	m.events[eventID] = locale
+	return nil
+}
+
+// recordingOrderEmailService records order confirmation and status emails
+type recordingOrderEmailService struct {
+	subjects []string
+	bodies   []string
+	locales  []string
+}
+
+func (m *recordingOrderEmailService) SendPasswordResetEmail(email, token string) error { return nil }
+func (m *recordingOrderEmailService) SendWelcomeEmail(email, userName string) error    { return nil }
+func (m *recordingOrderEmailService) SendVerificationEmail(email, userName, token string) error {
+	return nil
+}
+
+func (m *recordingOrderEmailService) SendOrderConfirmationWithTickets(email, userName, subject, htmlContent, textContent string, order *models.Order, tickets []*models.Ticket) error {
+	m.subjects = append(m.subjects, subject)
+	m.bodies = append(m.bodies, htmlContent+"\n"+textContent)
+	m.locales = append(m.locales, order.Locale)
+	return nil
+}
+
+func (m *recordingOrderEmailService) SendOrderStatusEmail(email, userName, subject, htmlContent, textContent string, order *models.Order) error {
+	m.subjects = append(m.subjects, subject)
+	m.bodies = append(m.bodies, htmlContent+"\n"+textContent)
+	m.locales = append(m.locales, order.Locale)
+	return nil
+}
+
+func TestLocaleService_ResolveLocale(t *testing.T) {
+	repo := newMockLocaleRepository()
+	repo.users[1] = "fr"
+	repo.users[2] = ""
+	repo.events[10] = "sw"
+	repo.events[11] = "en"
+	service := NewLocaleService(repo)
+
+	tests := []struct {
+		name           string
+		checkoutLocale string
+		userID         int
+		eventID        int
+		want           string
+	}{
+		{"language chosen at checkout", "sw-KE", 1, 11, "sw"},
+		{"account preference", "", 1, 10, "fr"},
+		{"unsupported checkout language", "de", 1, 10, "fr"},
+		{"event language", "", 2, 10, "sw"},
+		{"preferences that fail to load", "", 99, 99, "en"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := service.ResolveLocale(tt.checkoutLocale, tt.userID, tt.eventID); got != tt.want {
+				t.Errorf("expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestLocaleService_UpdateLocales(t *testing.T) {
+	repo := newMockLocaleRepository()
+	service := NewLocaleService(repo)
+
+	if err := service.UpdateUserLocale(1, "sw-KE"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if repo.users[1] != "sw" {
+		t.Errorf("expected the locale to be normalized, got %q", repo.users[1])
+	}
+
+	if err := service.UpdateUserLocale(1, ""); err != nil {
+		t.Fatalf("unexpected error clearing the preference: %v", err)
+	}
+	if repo.users[1] != "" {
+		t.Errorf("expected the preference to be cleared, got %q", repo.users[1])
+	}
+
+	if err := service.UpdateUserLocale(2, "de"); err == nil {
+		t.Error("expected an error for an unsupported language")
+	}
+	if _, saved := repo.users[2]; saved {
+		t.Error("expected an unsupported language not to be saved")
+	}
+
+	if err := service.UpdateEventLocale(10, ""); err == nil {
+		t.Error("expected events to require a language")
+	}
+	if err := service.UpdateEventLocale(10, "fr"); err != nil || repo.events[10] != "fr" {
+		t.Errorf("expected the event language to be saved, got %q (%v)", repo.events[10], err)
+	}
+}
+
+func setupLocalizedOrderService() (*OrderService, *recordingOrderEmailService, *mockLocaleRepository) {
+	userRepo := newMockUserRepository()
+	userRepo.users[1] = &models.User{ID: 1, FirstName: "Amina", LastName: "Otieno", Email: "amina@example.com"}
+
+	localeRepo := newMockLocaleRepository()
+	localeRepo.users[1] = ""
+	localeRepo.events[5] = "en"
+
+	emailService := &recordingOrderEmailService{}
+	service := NewOrderService(NewMockOrderRepository(), NewMockTicketRepository(), userRepo, nil, emailService)
+	service.SetLocaleResolver(NewLocaleService(localeRepo))
+
+	return service, emailService, localeRepo
+}
+
+func TestOrderService_LocalizedEmails(t *testing.T) {
+	order := &models.Order{
+		ID:           1,
+		UserID:       1,
+		EventID:      5,
+		OrderNumber:  "ORD-20250804-123456",
+		TotalAmount:  150000,
+		Status:       models.OrderCompleted,
+		BillingEmail: "amina@example.com",
+		CreatedAt:    time.Date(2025, time.August, 4, 18, 30, 0, 0, time.UTC),
+	}
+	user := &models.User{ID: 1, FirstName: "Amina", LastName: "Otieno"}
+
+	t.Run("confirmation uses the language chosen at checkout", func(t *testing.T) {
+		service, emailService, _ := setupLocalizedOrderService()
+
+		checkoutOrder := *order
+		checkoutOrder.Locale = "sw"
+		if err := service.sendOrderConfirmationEmail(&checkoutOrder, user, []*models.Ticket{{ID: 1, QRCode: "TKT-1"}}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		if emailService.subjects[0] != "Uthibitisho wa Agizo - ORD-20250804-123456" {
+			t.Errorf("unexpected subject: %q", emailService.subjects[0])
+		}
+		for _, content := range []string{`<html lang="sw">`, "Agizo Limethibitishwa!", "Mpendwa Amina Otieno,", "4 Agosti 2025 saa 18:30", "MAELEZO YA AGIZO"} {
+			if !strings.Contains(emailService.bodies[0], content) {
+				t.Errorf("expected the email to contain %q", content)
+			}
+		}
+	})
+
+	t.Run("refund falls back to the buyer's account language", func(t *testing.T) {
+		service, emailService, localeRepo := setupLocalizedOrderService()
+		localeRepo.users[1] = "fr"
+
+		service.OrderRefunded(order)
+
+		if len(emailService.subjects) != 1 {
+			t.Fatalf("expected a refund email, got %v", emailService.subjects)
+		}
+		if emailService.subjects[0] != "Remboursement de la commande ORD-20250804-123456" {
+			t.Errorf("unexpected subject: %q", emailService.subjects[0])
+		}
+		if emailService.locales[0] != "fr" {
+			t.Errorf("expected the order to be sent in French, got %q", emailService.locales[0])
+		}
+		if !strings.Contains(emailService.bodies[0], "Le montant de KSh 1500.00 sera reversé") {
+			t.Errorf("expected the refund amount in French, got %q", emailService.bodies[0])
+		}
+	})
+
+	t.Run("cancellation falls back to the event's language", func(t *testing.T) {
+		service, emailService, localeRepo := setupLocalizedOrderService()
+		localeRepo.events[5] = "sw"
+
+		if err := service.sendStatusUpdateNotification(order, models.OrderCancelled); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(emailService.subjects) != 1 || emailService.subjects[0] != "Agizo ORD-20250804-123456 limeghairiwa" {
+			t.Errorf("unexpected subjects: %v", emailService.subjects)
+		}
+	})
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// LocationSuggestRepository defines the data operations for location
+// autocomplete
+type LocationSuggestRepository interface {
+	GetCities(limit int) ([]*models.City, error)
+	GetVenues(citySlug, query string, organizerID, limit int) ([]string, error)
+}
+
+// DefaultLocationSuggestionLimit is the number of suggestions returned
+const DefaultLocationSuggestionLimit = 8
+
+// CitySuggestion is a city suggested for an event's location
+type CitySuggestion struct {
+	Name       string `json:"name"`
+	Region     string `json:"region,omitempty"`
+	Slug       string `json:"slug"`
+	EventCount int    `json:"event_count"` // Upcoming published events in the city
+}
+
+// LocationSuggestService suggests cities and venues as organizers enter
+// event locations, from the places dataset and the locations of past events
+type LocationSuggestService struct {
+	repo LocationSuggestRepository
+}
+
+// NewLocationSuggestService creates a new location suggestion service
+func NewLocationSuggestService(repo LocationSuggestRepository) *LocationSuggestService {
+	return &LocationSuggestService{
+		repo: repo,
+	}
+}
+
+// SuggestCities returns up to limit cities with a word starting with the
+// query, from the places dataset and cities that already have events. Cities
+// with the most upcoming events come first.
+func (s *LocationSuggestService) SuggestCities(query string, limit int) ([]CitySuggestion, error) {
+	if limit <= 0 {
+		limit = DefaultLocationSuggestionLimit
+	}
+
+	cities, err := s.repo.GetCities(100)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get cities: %w", err)
+	}
+
+	candidates := make([]CitySuggestion, 0, len(models.Places)+len(cities))
+	index := map[string]int{}
+	for _, place := range models.Places {
+		index[place.Slug()] = len(candidates)
+		candidates = append(candidates, CitySuggestion{Name: place.Name, Region: place.Region, Slug: place.Slug()})
+	}
+	for _, city := range cities {
+		if i, ok := index[city.Slug]; ok {
+			candidates[i].EventCount = city.EventCount
+			continue
+		}
+		candidates = append(candidates, CitySuggestion{Name: city.Name, Slug: city.Slug, EventCount: city.EventCount})
+	}
+
+	prefix := models.CitySlug(query)
+	suggestions := []CitySuggestion{}
+	for _, candidate := range candidates {
+		if prefix == "" || strings.HasPrefix(candidate.Slug, prefix) || strings.Contains(candidate.Slug, "-"+prefix) {
+			suggestions = append(suggestions, candidate)
+		}
+	}
+
+	sort.SliceStable(suggestions, func(i, j int) bool {
+		return suggestions[i].EventCount > suggestions[j].EventCount
+	})
+	if len(suggestions) > limit {
+		suggestions = suggestions[:limit]
+	}
+	return suggestions, nil
+}
+
+// SuggestVenues returns up to limit venues in a city whose names contain the
+// query, from published events and the organizer's own events
+func (s *LocationSuggestService) SuggestVenues(city, query string, organizerID, limit int) ([]string, error) {
+	if limit <= 0 {
+		limit = DefaultLocationSuggestionLimit
+	}
+
+	venues, err := s.repo.GetVenues(models.CitySlug(city), strings.TrimSpace(query), organizerID, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get venues: %w", err)
+	}
+	return venues, nil
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"testing"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock LocationSuggestRepository for testing
+type mockLocationSuggestRepository struct {
+	cities     []*models.City
+	venues     []string
+	lastVenues struct {
+		citySlug, query string
+		organizerID     int
+	}
+}
+
+func (m *mockLocationSuggestRepository) GetCities(limit int) ([]*models.City, error) {
+	return m.cities, nil
+}
+
+func (m *mockLocationSuggestRepository) GetVenues(citySlug, query string, organizerID, limit int) ([]string, error) {
+	m.lastVenues.citySlug = citySlug
+	m.lastVenues.query = query
+	m.lastVenues.organizerID = organizerID
+	return m.venues, nil
+}
+
+func TestLocationSuggestService_SuggestCities(t *testing.T) {
+	repo := &mockLocationSuggestRepository{
+		cities: []*models.City{
+			{Slug: "naivasha", Name: "Naivasha", EventCount: 4},
+			{Slug: "nairobi", Name: "Nairobi", EventCount: 12},
+			{Slug: "nanyuki-town", Name: "Nanyuki Town", EventCount: 1},
+		},
+	}
+	service := NewLocationSuggestService(repo)
+
+	suggestions, err := service.SuggestCities("na", 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(suggestions) < 4 {
+		t.Fatalf("expected dataset and event cities, got %+v", suggestions)
+	}
+	// Cities with the most upcoming events come first
+	if suggestions[0].Name != "Nairobi" || suggestions[0].EventCount != 12 || suggestions[0].Region != "Nairobi" {
+		t.Errorf("unexpected first suggestion %+v", suggestions[0])
+	}
+	if suggestions[1].Name != "Naivasha" || suggestions[1].Region != "Nakuru" {
+		t.Errorf("unexpected second suggestion %+v", suggestions[1])
+	}
+
+	found := false
+	for _, suggestion := range suggestions {
+		if suggestion.Slug == "nanyuki-town" {
+			found = true
+			if suggestion.Region != "" {
+				t.Errorf("expected no region for a city outside the dataset, got %q", suggestion.Region)
+			}
+		}
+	}
+	if !found {
+		t.Error("expected cities outside the dataset to be suggested")
+	}
+
+	// Words after the first match too
+	suggestions, _ = service.SuggestCities("beach", 0)
+	if len(suggestions) != 1 || suggestions[0].Name != "Diani Beach" {
+		t.Errorf("expected Diani Beach, got %+v", suggestions)
+	}
+
+	// Limits are applied
+	suggestions, _ = service.SuggestCities("", 3)
+	if len(suggestions) != 3 {
+		t.Errorf("expected 3 suggestions, got %d", len(suggestions))
+	}
+}
+
+func TestLocationSuggestService_SuggestVenues(t *testing.T) {
+	repo := &mockLocationSuggestRepository{venues: []string{"KICC", "Kasarani Stadium"}}
+	service := NewLocationSuggestService(repo)
+
+	venues, err := service.SuggestVenues("Diani Beach", " ka ", 7, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(venues) != 2 {
+		t.Errorf("expected 2 venues, got %v", venues)
+	}
+	if repo.lastVenues.citySlug != "diani-beach" || repo.lastVenues.query != "ka" || repo.lastVenues.organizerID != 7 {
+		t.Errorf("unexpected venue lookup %+v", repo.lastVenues)
+	}
+}
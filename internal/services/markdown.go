@@ -0,0 +1,172 @@
+package services
+
+import (
+	"html"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// The Markdown RenderMarkdown understands, applied to already escaped text
+var (
+	markdownOrderedItemPattern = regexp.MustCompile(`^\d+[.)]\s+`)
+	markdownBoldPattern        = regexp.MustCompile(`\*\*([^*]+)\*\*`)
+	markdownItalicPattern      = regexp.MustCompile(`\*([^*\s][^*]*)\*`)
+	markdownInlineLinkPattern  = regexp.MustCompile(`\[([^\]]+)\]\(([^)\s]+)\)`)
+)
+
+// RenderMarkdown converts the Markdown of a help article to HTML. It covers
+// headings, paragraphs, lists, quotes, fenced code, bold, italics, inline
+// code and links. Raw HTML is escaped and links other than site paths,
+// anchors, http(s) and mailto are shown as plain text, so the output is
+// safe to embed in a page.
+func RenderMarkdown(source string) string {
+	lines := strings.Split(strings.ReplaceAll(source, "\r\n", "\n"), "\n")
+
+	var b strings.Builder
+	var paragraph []string
+	listTag := ""
+
+	flushParagraph := func() {
+		if len(paragraph) > 0 {
+			b.WriteString("<p>" + renderMarkdownInline(strings.Join(paragraph, " ")) + "</p>\n")
+			paragraph = nil
+		}
+	}
+	closeList := func() {
+		if listTag != "" {
+			b.WriteString("</" + listTag + ">\n")
+			listTag = ""
+		}
+	}
+	openList := func(tag string) {
+		if listTag != tag {
+			closeList()
+			b.WriteString("<" + tag + ">\n")
+			listTag = tag
+		}
+	}
+
+	for i := 0; i < len(lines); i++ {
+		line := strings.TrimRight(lines[i], " \t")
+		trimmed := strings.TrimSpace(line)
+
+		switch {
+		case trimmed == "":
+			flushParagraph()
+			closeList()
+
+		case strings.HasPrefix(trimmed, "```"):
+			flushParagraph()
+			closeList()
+			var code []string
+			for i++; i < len(lines) && !strings.HasPrefix(strings.TrimSpace(lines[i]), "```"); i++ {
+				code = append(code, strings.TrimRight(lines[i], "\r"))
+			}
+			b.WriteString("<pre><code>" + html.EscapeString(strings.Join(code, "\n")) + "</code></pre>\n")
+
+		case strings.HasPrefix(trimmed, "#"):
+			level := len(trimmed) - len(strings.TrimLeft(trimmed, "#"))
+			text := strings.TrimSpace(trimmed[level:])
+			if level > 6 || text == "" || trimmed[level] != ' ' {
+				paragraph = append(paragraph, trimmed)
+				continue
+			}
+			flushParagraph()
+			closeList()
+			// The page title is the h1, so # headings are shown as h2 too
+			tag := "h" + string(rune('0'+max(level, 2)))
+			b.WriteString("<" + tag + ">" + renderMarkdownInline(text) + "</" + tag + ">\n")
+
+		case strings.HasPrefix(trimmed, "- ") || strings.HasPrefix(trimmed, "* "):
+			flushParagraph()
+			openList("ul")
+			b.WriteString("<li>" + renderMarkdownInline(strings.TrimSpace(trimmed[2:])) + "</li>\n")
+
+		case markdownOrderedItemPattern.MatchString(trimmed):
+			flushParagraph()
+			openList("ol")
+			text := markdownOrderedItemPattern.ReplaceAllString(trimmed, "")
+			b.WriteString("<li>" + renderMarkdownInline(text) + "</li>\n")
+
+		case strings.HasPrefix(trimmed, ">"):
+			flushParagraph()
+			closeList()
+			var quote []string
+			for ; i < len(lines) && strings.HasPrefix(strings.TrimSpace(lines[i]), ">"); i++ {
+				quote = append(quote, strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(lines[i]), ">")))
+			}
+			i--
+			b.WriteString("<blockquote><p>" + renderMarkdownInline(strings.Join(quote, " ")) + "</p></blockquote>\n")
+
+		default:
+			closeList()
+			paragraph = append(paragraph, trimmed)
+		}
+	}
+	flushParagraph()
+	closeList()
+
+	return b.String()
+}
+
+// renderMarkdownInline escapes a line of text and renders its inline code,
+// links, bold and italics
+func renderMarkdownInline(text string) string {
+	// Code spans are taken literally, so only the text between them is
+	// formatted
+	parts := strings.Split(text, "`")
+	var b strings.Builder
+	for i, part := range parts {
+		switch {
+		case i%2 == 1 && i < len(parts)-1:
+			b.WriteString("<code>" + html.EscapeString(part) + "</code>")
+		case i%2 == 1:
+			// An unmatched backtick
+			b.WriteString("`" + formatMarkdownText(part))
+		default:
+			b.WriteString(formatMarkdownText(part))
+		}
+	}
+	return b.String()
+}
+
+// formatMarkdownText escapes text and renders its links, bold and italics
+func formatMarkdownText(text string) string {
+	escaped := html.EscapeString(text)
+	escaped = markdownInlineLinkPattern.ReplaceAllStringFunc(escaped, func(match string) string {
+		parts := markdownInlineLinkPattern.FindStringSubmatch(match)
+		label, href := parts[1], html.UnescapeString(parts[2])
+		if !isSafeMarkdownLink(href) {
+			return label
+		}
+		attrs := ""
+		if strings.HasPrefix(href, "http://") || strings.HasPrefix(href, "https://") {
+			attrs = ` rel="noopener noreferrer" target="_blank"`
+		}
+		return `<a href="` + html.EscapeString(href) + `"` + attrs + `>` + label + `</a>`
+	})
+	escaped = markdownBoldPattern.ReplaceAllString(escaped, "<strong>$1</strong>")
+	escaped = markdownItalicPattern.ReplaceAllString(escaped, "<em>$1</em>")
+	return escaped
+}
+
+// isSafeMarkdownLink returns true for site paths, anchors and http(s) and
+// mailto URLs
+func isSafeMarkdownLink(href string) bool {
+	if strings.HasPrefix(href, "#") || (strings.HasPrefix(href, "/") && !strings.HasPrefix(href, "//")) {
+		return true
+	}
+	parsed, err := url.Parse(href)
+	if err != nil {
+		return false
+	}
+	switch parsed.Scheme {
+	case "http", "https":
+		return parsed.Host != ""
+	case "mailto":
+		return parsed.Opaque != ""
+	default:
+		return false
+	}
+}
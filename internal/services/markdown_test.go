@@ -0,0 +1,50 @@
+package services
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestRenderMarkdown(t *testing.T) {
+	tests := []struct {
+		name     string
+		markdown string
+		want     string
+	}{
+		{"heading", "## Before you start", "<h2>Before you start</h2>\n"},
+		{"paragraph lines joined", "First line\r\nsecond line", "<p>First line second line</p>\n"},
+		{"bold and italics", "**Dashboard** then *Create*", "<p><strong>Dashboard</strong> then <em>Create</em></p>\n"},
+		{"inline code", "Run `**not bold**`", "<p>Run <code>**not bold**</code></p>\n"},
+		{"site link", "See [refunds](/help/refunds)", `<p>See <a href="/help/refunds">refunds</a></p>` + "\n"},
+		{"external link", "[Paystack](https://paystack.com)", `<p><a href="https://paystack.com" rel="noopener noreferrer" target="_blank">Paystack</a></p>` + "\n"},
+		{"unordered list", "- one\n- two", "<ul>\n<li>one</li>\n<li>two</li>\n</ul>\n"},
+		{"ordered list", "1. one\n2. two", "<ol>\n<li>one</li>\n<li>two</li>\n</ol>\n"},
+		{"quote", "> Note\n> this", "<blockquote><p>Note this</p></blockquote>\n"},
+		{"fenced code", "```\n<b>x</b>\n```", "<pre><code>&lt;b&gt;x&lt;/b&gt;</code></pre>\n"},
+		{"hashtag is not a heading", "#1 choice", "<p>#1 choice</p>\n"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := RenderMarkdown(tt.markdown); got != tt.want {
+				t.Errorf("RenderMarkdown(%q) = %q, want %q", tt.markdown, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRenderMarkdown_Unsafe(t *testing.T) {
+	tests := []struct {
+		markdown  string
+		forbidden string
+	}{
+		{`<script>alert(1)</script>`, "<script"},
+		{`[click](javascript:alert(1))`, "href"},
+		{`[click](//evil.example.com)`, "href"},
+		{`[x](/a" onmouseover="alert(1))`, `" onmouseover`},
+	}
+	for _, tt := range tests {
+		if got := RenderMarkdown(tt.markdown); strings.Contains(got, tt.forbidden) {
+			t.Errorf("RenderMarkdown(%q) = %q, want it without %q", tt.markdown, got, tt.forbidden)
+		}
+	}
+}
@@ -170,6 +170,20 @@ func (m *MockEventService) GetEventByID(id int) (*models.Event, error) {
 	return nil, models.ErrEventNotFound
 }
 
+func (m *MockEventService) GetEventBySlug(slug string) (*models.Event, error) {
+	allEvents, _ := m.GetFeaturedEvents(0)
+	upcomingEvents, _ := m.GetUpcomingEvents(0)
+	allEvents = append(allEvents, upcomingEvents...)
+
+	for _, event := range allEvents {
+		if event.Slug == slug {
+			return event, nil
+		}
+	}
+
+	return nil, models.ErrEventNotFound
+}
+
 func (m *MockEventService) GetEventOrganizer(eventID int) (*models.User, error) {
 	return &models.User{
 		ID:        1,
@@ -208,6 +222,11 @@ func (m *MockEventService) CanUserDeleteEvent(eventID int, userID int) (bool, er
 	return true, nil
 }
 
+func (m *MockEventService) CanUserManageEvent(eventID int, userID int) (bool, error) {
+	// Mock implementation - allow managing for demo
+	return true, nil
+}
+
 func (m *MockEventService) UpdateEventStatus(eventID int, status models.EventStatus, organizerID int) (*models.Event, error) {
 	// Mock implementation - not implemented
 	return nil, models.ErrNotImplemented
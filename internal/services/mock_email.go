@@ -4,6 +4,7 @@ import (
 	"event-ticketing-platform/internal/config"
 	"event-ticketing-platform/internal/models"
 	"log"
+	"time"
 )
 
 // MockEmailService provides a mock email service that can optionally use Resend
@@ -47,6 +48,28 @@ func (s *MockEmailService) SendPasswordResetEmail(email, token string) error {
 	return nil
 }
 
+// SendMagicLinkEmail sends a passwordless sign-in link
+func (s *MockEmailService) SendMagicLinkEmail(email, userName, link string, expiresIn time.Duration) error {
+	if s.useResend && s.resendService != nil {
+		return s.resendService.SendMagicLinkEmail(email, userName, link, expiresIn)
+	}
+
+	// Mock implementation - just log
+	log.Printf("Mock Email: Sign-in link sent to %s (%s): %s", email, userName, link)
+	return nil
+}
+
+// SendAccountUnlockEmail sends a link that unlocks a locked account
+func (s *MockEmailService) SendAccountUnlockEmail(email, userName, link string, lockedUntil time.Time) error {
+	if s.useResend && s.resendService != nil {
+		return s.resendService.SendAccountUnlockEmail(email, userName, link, lockedUntil)
+	}
+
+	// Mock implementation - just log
+	log.Printf("Mock Email: Unlock link sent to %s (%s), locked until %s: %s", email, userName, lockedUntil.Format(time.RFC3339), link)
+	return nil
+}
+
 // SendWelcomeEmail sends a welcome email to new users
 func (s *MockEmailService) SendWelcomeEmail(email, userName string) error {
 	if s.useResend && s.resendService != nil {
@@ -111,6 +134,106 @@ func (s *MockEmailService) SendOrderConfirmationWithTickets(email, userName, sub
 	return nil
 }
 
+// SendNotificationEmail sends a notification email
+func (s *MockEmailService) SendNotificationEmail(email, userName, subject, message, link string) error {
+	if s.useResend && s.resendService != nil {
+		return s.resendService.SendNotificationEmail(email, userName, subject, message, link)
+	}
+
+	log.Printf("Mock Email: Notification '%s' sent to %s (%s): %s", subject, email, link, message)
+	return nil
+}
+
+// SendEventReminderEmail sends an event reminder email
+func (s *MockEmailService) SendEventReminderEmail(email, userName, subject, locale string, event *models.Event, message, link string, access *models.EventAccess) error {
+	if s.useResend && s.resendService != nil {
+		return s.resendService.SendEventReminderEmail(email, userName, subject, locale, event, message, link, access)
+	}
+
+	log.Printf("Mock Email: Reminder '%s' (%s) sent to %s (%s): %s", subject, locale, email, link, message)
+	return nil
+}
+
+// SendEventBroadcastEmail sends an organizer broadcast email
+func (s *MockEmailService) SendEventBroadcastEmail(email, userName, subject, locale string, event *models.Event, message, link string) error {
+	if s.useResend && s.resendService != nil {
+		return s.resendService.SendEventBroadcastEmail(email, userName, subject, locale, event, message, link)
+	}
+
+	log.Printf("Mock Email: Broadcast '%s' (%s) sent to %s (%s): %s", subject, locale, email, link, message)
+	return nil
+}
+
+// SendEventCancellationEmail sends an event cancellation notice
+func (s *MockEmailService) SendEventCancellationEmail(email, userName, locale string, event *models.Event, reason, refundInfo string) error {
+	if s.useResend && s.resendService != nil {
+		return s.resendService.SendEventCancellationEmail(email, userName, locale, event, reason, refundInfo)
+	}
+
+	log.Printf("Mock Email: Cancellation of '%s' (%s) sent to %s: %s", event.Title, locale, email, refundInfo)
+	return nil
+}
+
+// SendInstallmentEmail sends an installment charge notice
+func (s *MockEmailService) SendInstallmentEmail(email, userName, subject, locale, message, link string) error {
+	if s.useResend && s.resendService != nil {
+		return s.resendService.SendInstallmentEmail(email, userName, subject, locale, message, link)
+	}
+
+	log.Printf("Mock Email: Installment notice '%s' (%s) sent to %s (%s): %s", subject, locale, email, link, message)
+	return nil
+}
+
+// SendPriceAlertEmail sends a price alert to an attendee who saved an event
+func (s *MockEmailService) SendPriceAlertEmail(email, userName, subject, locale string, event *models.Event, message, link string) error {
+	if s.useResend && s.resendService != nil {
+		return s.resendService.SendPriceAlertEmail(email, userName, subject, locale, event, message, link)
+	}
+
+	log.Printf("Mock Email: Price alert '%s' (%s) sent to %s (%s): %s", subject, locale, email, link, message)
+	return nil
+}
+
+// SendNewEventEmail tells a follower about an organizer's new event
+func (s *MockEmailService) SendNewEventEmail(email, userName, locale, organizerName string, event *models.Event, link string) error {
+	if s.useResend && s.resendService != nil {
+		return s.resendService.SendNewEventEmail(email, userName, locale, organizerName, event, link)
+	}
+
+	log.Printf("Mock Email: New event '%s' by %s (%s) sent to %s (%s)", event.Title, organizerName, locale, email, link)
+	return nil
+}
+
+// SendSavedSearchEmail tells an attendee about a new event matching their saved search
+func (s *MockEmailService) SendSavedSearchEmail(email, userName, locale, searchLabel string, event *models.Event, link string) error {
+	if s.useResend && s.resendService != nil {
+		return s.resendService.SendSavedSearchEmail(email, userName, locale, searchLabel, event, link)
+	}
+
+	log.Printf("Mock Email: New event '%s' for saved search %s (%s) sent to %s (%s)", event.Title, searchLabel, locale, email, link)
+	return nil
+}
+
+// SendTeamInvitationEmail invites someone to join an organizer's team
+func (s *MockEmailService) SendTeamInvitationEmail(email, locale, organizerName string, permissions []models.TeamPermission, link string) error {
+	if s.useResend && s.resendService != nil {
+		return s.resendService.SendTeamInvitationEmail(email, locale, organizerName, permissions, link)
+	}
+
+	log.Printf("Mock Email: Team invitation from %s (%s) sent to %s with %v (%s)", organizerName, locale, email, permissions, link)
+	return nil
+}
+
+// SendOrderStatusEmail sends an order status update email
+func (s *MockEmailService) SendOrderStatusEmail(email, userName, subject, htmlContent, textContent string, order *models.Order) error {
+	if s.useResend && s.resendService != nil {
+		return s.resendService.SendOrderStatusEmail(email, userName, subject, htmlContent, textContent, order)
+	}
+
+	log.Printf("Mock Email: Order status '%s' (%s) sent to %s for order %s", subject, order.Locale, email, order.OrderNumber)
+	return nil
+}
+
 // TestConnection tests the email service connection
 func (s *MockEmailService) TestConnection() error {
 	if s.useResend && s.resendService != nil {
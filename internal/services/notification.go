@@ -0,0 +1,371 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// NotificationRepository interface for notification data operations
+type NotificationRepository interface {
+	Create(notification *models.Notification) error
+	GetByUser(userID int, limit, offset int) ([]*models.Notification, int, error)
+	CountUnread(userID int) (int, error)
+	MarkRead(id, userID int) error
+	MarkAllRead(userID int) error
+	GetPreferences(userID int) (*models.NotificationPreferences, error)
+	SavePreferences(prefs *models.NotificationPreferences) error
+	RecordMilestone(eventID int, milestone models.NotificationType) (bool, error)
+	GetEventsWithSalesEndingBetween(from, to time.Time) ([]int, error)
+}
+
+// NotificationEmailSender sends notification emails
+type NotificationEmailSender interface {
+	SendNotificationEmail(email, userName, subject, message, link string) error
+}
+
+// saleEndingWindow is how far ahead of the end of ticket sales organizers are warned
+const saleEndingWindow = 24 * time.Hour
+
+// NotificationList represents a page of notifications
+type NotificationList struct {
+	Notifications []*models.Notification `json:"notifications"`
+	Total         int                    `json:"total"`
+	Unread        int                    `json:"unread"`
+	Page          int                    `json:"page"`
+	PageSize      int                    `json:"page_size"`
+	TotalPages    int                    `json:"total_pages"`
+}
+
+// NotificationService handles organizer notifications, including sales milestones
+type NotificationService struct {
+	notificationRepo NotificationRepository
+	eventRepo        EventRepository
+	ticketRepo       TicketRepository
+	userRepo         UserRepository
+	emailSender      NotificationEmailSender
+	baseURL          string
+	now              func() time.Time
+}
+
+// NewNotificationService creates a new notification service
+func NewNotificationService(
+	notificationRepo NotificationRepository,
+	eventRepo EventRepository,
+	ticketRepo TicketRepository,
+	userRepo UserRepository,
+	emailSender NotificationEmailSender,
+	baseURL string,
+) *NotificationService {
+	return &NotificationService{
+		notificationRepo: notificationRepo,
+		eventRepo:        eventRepo,
+		ticketRepo:       ticketRepo,
+		userRepo:         userRepo,
+		emailSender:      emailSender,
+		baseURL:          baseURL,
+		now:              time.Now,
+	}
+}
+
+// GetNotifications retrieves a page of a user's notifications
+func (s *NotificationService) GetNotifications(userID int, page, pageSize int) (*NotificationList, error) {
+	if page <= 0 {
+		page = 1
+	}
+	if pageSize <= 0 {
+		pageSize = 20
+	}
+
+	notifications, total, err := s.notificationRepo.GetByUser(userID, pageSize, (page-1)*pageSize)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notifications: %w", err)
+	}
+
+	unread, err := s.notificationRepo.CountUnread(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count unread notifications: %w", err)
+	}
+
+	return &NotificationList{
+		Notifications: notifications,
+		Total:         total,
+		Unread:        unread,
+		Page:          page,
+		PageSize:      pageSize,
+		TotalPages:    (total + pageSize - 1) / pageSize,
+	}, nil
+}
+
+// CountUnread returns the number of unread notifications for a user
+func (s *NotificationService) CountUnread(userID int) (int, error) {
+	return s.notificationRepo.CountUnread(userID)
+}
+
+// MarkRead marks one of the user's notifications as read
+func (s *NotificationService) MarkRead(notificationID, userID int) error {
+	return s.notificationRepo.MarkRead(notificationID, userID)
+}
+
+// MarkAllRead marks all of the user's notifications as read
+func (s *NotificationService) MarkAllRead(userID int) error {
+	return s.notificationRepo.MarkAllRead(userID)
+}
+
+// GetPreferences retrieves a user's notification preferences
+func (s *NotificationService) GetPreferences(userID int) (*models.NotificationPreferences, error) {
+	prefs, err := s.notificationRepo.GetPreferences(userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+	return prefs, nil
+}
+
+// UpdatePreferences saves a user's notification preferences
+func (s *NotificationService) UpdatePreferences(prefs *models.NotificationPreferences) error {
+	if err := s.notificationRepo.SavePreferences(prefs); err != nil {
+		return fmt.Errorf("failed to update notification preferences: %w", err)
+	}
+	return nil
+}
+
+// OrderCompleted checks the order's event for newly reached sales milestones.
+// It implements OrderCompletionHook.
+func (s *NotificationService) OrderCompleted(order *models.Order) {
+	if err := s.CheckSalesMilestones(order.EventID); err != nil {
+		fmt.Printf("Warning: failed to check sales milestones for event %d: %v\n", order.EventID, err)
+	}
+}
+
+// PaymentHealthChanged emails the admins when a payment provider becomes
+// degraded or recovers. It implements PaymentHealthAlerter.
+func (s *NotificationService) PaymentHealthChanged(health *models.PaymentProviderHealth) {
+	title, message := paymentHealthMessage(health)
+	fmt.Printf("Payment alert: %s\n", title)
+
+	if s.emailSender == nil {
+		return
+	}
+
+	admins, err := s.userRepo.GetByRole(models.RoleAdmin)
+	if err != nil {
+		fmt.Printf("Warning: failed to get admins for payment alert: %v\n", err)
+		return
+	}
+
+	link := s.baseURL + "/admin/settings#payment-health"
+	for _, admin := range admins {
+		if err := s.emailSender.SendNotificationEmail(admin.Email, admin.FullName(), title, message, link); err != nil {
+			fmt.Printf("Warning: failed to send payment alert to %s: %v\n", admin.Email, err)
+		}
+	}
+}
+
+// DisputeOpened tells the organizer a buyer disputed an order's payment and
+// asks for their evidence. It implements DisputeNotifier.
+func (s *NotificationService) DisputeOpened(dispute *models.PaymentDispute) {
+	title := fmt.Sprintf("Payment disputed for %s", dispute.EventTitle)
+	message := fmt.Sprintf("The buyer of order %s disputed their payment of %.2f. It is held back from your balance until the dispute is decided.", dispute.OrderNumber, dispute.AmountInCurrency())
+	if dispute.DueAt != nil {
+		message += fmt.Sprintf(" Submit your evidence by %s.", dispute.DueAt.Format("January 2, 2006"))
+	} else {
+		message += " Submit your evidence as soon as possible."
+	}
+	s.notifyDispute(dispute, models.NotificationDisputeOpened, title, message)
+}
+
+// DisputeResolved tells the organizer how a dispute of their order was
+// decided. It implements DisputeNotifier.
+func (s *NotificationService) DisputeResolved(dispute *models.PaymentDispute) {
+	title := fmt.Sprintf("Dispute of order %s lost", dispute.OrderNumber)
+	message := fmt.Sprintf("The buyer was refunded %.2f and the order's tickets are no longer valid.", dispute.AmountInCurrency())
+	if dispute.Status == models.DisputeWon {
+		title = fmt.Sprintf("Dispute of order %s won", dispute.OrderNumber)
+		message = "The payment stands and has been returned to your balance."
+	}
+	s.notifyDispute(dispute, models.NotificationDisputeResolved, title, message)
+}
+
+// notifyDispute sends a dispute notification in the app and by email,
+// whatever the organizer's preferences
+func (s *NotificationService) notifyDispute(dispute *models.PaymentDispute, notificationType models.NotificationType, title, message string) {
+	link := "/organizer/disputes"
+
+	eventID := dispute.EventID
+	notification := &models.Notification{
+		UserID:  dispute.OrganizerID,
+		EventID: &eventID,
+		Type:    notificationType,
+		Title:   title,
+		Message: message,
+		Link:    link,
+	}
+	if err := s.notificationRepo.Create(notification); err != nil {
+		fmt.Printf("Warning: failed to create dispute notification: %v\n", err)
+	}
+
+	if s.emailSender == nil {
+		return
+	}
+	organizer, err := s.userRepo.GetByID(dispute.OrganizerID)
+	if err != nil {
+		fmt.Printf("Warning: failed to get organizer for dispute %d: %v\n", dispute.ID, err)
+		return
+	}
+	if err := s.emailSender.SendNotificationEmail(organizer.Email, organizer.FullName(), title, message, s.baseURL+link); err != nil {
+		fmt.Printf("Warning: failed to send dispute email to %s: %v\n", organizer.Email, err)
+	}
+}
+
+// CheckSalesEnding notifies organizers of published events whose ticket sales
+// close within the next 24 hours. It is meant to run periodically so events
+// without recent orders are still covered.
+func (s *NotificationService) CheckSalesEnding() error {
+	now := s.now()
+	eventIDs, err := s.notificationRepo.GetEventsWithSalesEndingBetween(now, now.Add(saleEndingWindow))
+	if err != nil {
+		return fmt.Errorf("failed to find events with sales ending: %w", err)
+	}
+
+	for _, eventID := range eventIDs {
+		if err := s.CheckSalesMilestones(eventID); err != nil {
+			fmt.Printf("Warning: failed to check sales milestones for event %d: %v\n", eventID, err)
+		}
+	}
+
+	return nil
+}
+
+// CheckSalesMilestones announces any sales milestones the event has reached
+// that have not been announced before
+func (s *NotificationService) CheckSalesMilestones(eventID int) error {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get event: %w", err)
+	}
+
+	ticketTypes, err := s.ticketRepo.GetTicketTypesByEvent(eventID)
+	if err != nil {
+		return fmt.Errorf("failed to get ticket types: %w", err)
+	}
+
+	milestones := DetectSalesMilestones(ticketTypes, s.now())
+	soldOut := false
+	for _, milestone := range milestones {
+		if milestone == models.NotificationSoldOut {
+			soldOut = true
+		}
+	}
+
+	for _, milestone := range milestones {
+		// Selling out in one go makes "half sold" redundant, so record it without announcing it
+		silent := soldOut && milestone == models.NotificationHalfSold
+		if err := s.notifyMilestone(event, milestone, silent); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DetectSalesMilestones returns the milestones currently reached by an event's ticket types
+func DetectSalesMilestones(ticketTypes []*models.TicketType, now time.Time) []models.NotificationType {
+	var sold, quantity int
+	var lastSaleEnd time.Time
+	for _, ticketType := range ticketTypes {
+		sold += ticketType.Sold
+		quantity += ticketType.Quantity
+		if ticketType.SaleEnd.After(lastSaleEnd) {
+			lastSaleEnd = ticketType.SaleEnd
+		}
+	}
+	if quantity == 0 {
+		return nil
+	}
+
+	var milestones []models.NotificationType
+	if sold > 0 {
+		milestones = append(milestones, models.NotificationFirstSale)
+	}
+	if sold*2 >= quantity {
+		milestones = append(milestones, models.NotificationHalfSold)
+	}
+	if sold >= quantity {
+		milestones = append(milestones, models.NotificationSoldOut)
+	} else if lastSaleEnd.After(now) && !lastSaleEnd.After(now.Add(saleEndingWindow)) {
+		milestones = append(milestones, models.NotificationSaleEnding)
+	}
+
+	return milestones
+}
+
+// notifyMilestone records a milestone and, the first time it is reached,
+// notifies the organizer through the channels they have enabled
+func (s *NotificationService) notifyMilestone(event *models.Event, milestone models.NotificationType, silent bool) error {
+	isNew, err := s.notificationRepo.RecordMilestone(event.ID, milestone)
+	if err != nil {
+		return fmt.Errorf("failed to record milestone: %w", err)
+	}
+	if !isNew || silent {
+		return nil
+	}
+
+	prefs, err := s.notificationRepo.GetPreferences(event.OrganizerID)
+	if err != nil {
+		return fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+	if !prefs.Wants(milestone) {
+		return nil
+	}
+
+	title, message := milestoneMessage(event, milestone)
+	link := fmt.Sprintf("/organizer/events/%d/analytics", event.ID)
+
+	if prefs.InAppEnabled {
+		eventID := event.ID
+		notification := &models.Notification{
+			UserID:  event.OrganizerID,
+			EventID: &eventID,
+			Type:    milestone,
+			Title:   title,
+			Message: message,
+			Link:    link,
+		}
+		if err := s.notificationRepo.Create(notification); err != nil {
+			return fmt.Errorf("failed to create notification: %w", err)
+		}
+	}
+
+	if prefs.EmailEnabled && s.emailSender != nil {
+		organizer, err := s.userRepo.GetByID(event.OrganizerID)
+		if err != nil {
+			return fmt.Errorf("failed to get organizer: %w", err)
+		}
+		if err := s.emailSender.SendNotificationEmail(organizer.Email, organizer.FullName(), title, message, s.baseURL+link); err != nil {
+			// Log error but don't fail, the in-app notification has been stored
+			fmt.Printf("Warning: failed to send milestone email to %s: %v\n", organizer.Email, err)
+		}
+	}
+
+	return nil
+}
+
+// milestoneMessage returns the title and message announcing a milestone
+func milestoneMessage(event *models.Event, milestone models.NotificationType) (string, string) {
+	switch milestone {
+	case models.NotificationFirstSale:
+		return fmt.Sprintf("First sale for %s", event.Title),
+			fmt.Sprintf("Congratulations! The first ticket for %s has been sold.", event.Title)
+	case models.NotificationHalfSold:
+		return fmt.Sprintf("%s is 50%% sold", event.Title),
+			fmt.Sprintf("Half of the tickets for %s have been sold.", event.Title)
+	case models.NotificationSoldOut:
+		return fmt.Sprintf("%s is sold out", event.Title),
+			fmt.Sprintf("All tickets for %s have been sold.", event.Title)
+	case models.NotificationSaleEnding:
+		return fmt.Sprintf("Ticket sales for %s end soon", event.Title),
+			fmt.Sprintf("Ticket sales for %s close within 24 hours.", event.Title)
+	}
+	return event.Title, ""
+}
@@ -0,0 +1,278 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock NotificationRepository for testing
+type mockNotificationRepository struct {
+	notifications []*models.Notification
+	preferences   map[int]*models.NotificationPreferences
+	milestones    map[int]map[models.NotificationType]bool
+	endingEvents  []int
+}
+
+func newMockNotificationRepository() *mockNotificationRepository {
+	return &mockNotificationRepository{
+		preferences: make(map[int]*models.NotificationPreferences),
+		milestones:  make(map[int]map[models.NotificationType]bool),
+	}
+}
+
+func (m *mockNotificationRepository) Create(notification *models.Notification) error {
+	notification.ID = len(m.notifications) + 1
+	m.notifications = append(m.notifications, notification)
+	return nil
+}
+
+func (m *mockNotificationRepository) GetByUser(userID int, limit, offset int) ([]*models.Notification, int, error) {
+	var result []*models.Notification
+	for _, n := range m.notifications {
+		if n.UserID == userID {
+			result = append(result, n)
+		}
+	}
+	return result, len(result), nil
+}
+
+func (m *mockNotificationRepository) CountUnread(userID int) (int, error) {
+	count := 0
+	for _, n := range m.notifications {
+		if n.UserID == userID && !n.IsRead() {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockNotificationRepository) MarkRead(id, userID int) error {
+	now := time.Now()
+	for _, n := range m.notifications {
+		if n.ID == id && n.UserID == userID {
+			n.ReadAt = &now
+		}
+	}
+	return nil
+}
+
+func (m *mockNotificationRepository) MarkAllRead(userID int) error {
+	now := time.Now()
+	for _, n := range m.notifications {
+		if n.UserID == userID {
+			n.ReadAt = &now
+		}
+	}
+	return nil
+}
+
+func (m *mockNotificationRepository) GetPreferences(userID int) (*models.NotificationPreferences, error) {
+	if prefs, exists := m.preferences[userID]; exists {
+		return prefs, nil
+	}
+	return models.DefaultNotificationPreferences(userID), nil
+}
+
+func (m *mockNotificationRepository) SavePreferences(prefs *models.NotificationPreferences) error {
+	m.preferences[prefs.UserID] = prefs
+	return nil
+}
+
+func (m *mockNotificationRepository) RecordMilestone(eventID int, milestone models.NotificationType) (bool, error) {
+	if m.milestones[eventID] == nil {
+		m.milestones[eventID] = make(map[models.NotificationType]bool)
+	}
+	if m.milestones[eventID][milestone] {
+		return false, nil
+	}
+	m.milestones[eventID][milestone] = true
+	return true, nil
+}
+
+func (m *mockNotificationRepository) GetEventsWithSalesEndingBetween(from, to time.Time) ([]int, error) {
+	return m.endingEvents, nil
+}
+
+// mockNotificationEmailSender records sent notification emails
+type mockNotificationEmailSender struct {
+	subjects []string
+}
+
+func (m *mockNotificationEmailSender) SendNotificationEmail(email, userName, subject, message, link string) error {
+	m.subjects = append(m.subjects, subject)
+	return nil
+}
+
+func setupNotificationService() (*NotificationService, *mockNotificationRepository, *mockTicketRepository, *mockNotificationEmailSender) {
+	notificationRepo := newMockNotificationRepository()
+	eventRepo := newMockEventRepository()
+	ticketRepo := newMockTicketRepository()
+	userRepo := newMockUserRepository()
+	emailSender := &mockNotificationEmailSender{}
+
+	createTestUser(userRepo, 1, models.RoleOrganizer)
+	eventRepo.events[10] = &models.Event{ID: 10, Title: "Jazz Night", OrganizerID: 1, Status: models.StatusPublished}
+
+	service := NewNotificationService(notificationRepo, eventRepo, ticketRepo, userRepo, emailSender, "https://example.com")
+	service.now = func() time.Time { return time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC) }
+
+	return service, notificationRepo, ticketRepo, emailSender
+}
+
+func TestDetectSalesMilestones(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	later := now.Add(7 * 24 * time.Hour)
+
+	tests := []struct {
+		name        string
+		ticketTypes []*models.TicketType
+		expected    []models.NotificationType
+	}{
+		{
+			name:        "no sales",
+			ticketTypes: []*models.TicketType{{Quantity: 100, Sold: 0, SaleEnd: later}},
+			expected:    nil,
+		},
+		{
+			name:        "first sale",
+			ticketTypes: []*models.TicketType{{Quantity: 100, Sold: 1, SaleEnd: later}},
+			expected:    []models.NotificationType{models.NotificationFirstSale},
+		},
+		{
+			name: "half sold across ticket types",
+			ticketTypes: []*models.TicketType{
+				{Quantity: 50, Sold: 40, SaleEnd: later},
+				{Quantity: 50, Sold: 10, SaleEnd: later},
+			},
+			expected: []models.NotificationType{models.NotificationFirstSale, models.NotificationHalfSold},
+		},
+		{
+			name:        "sold out",
+			ticketTypes: []*models.TicketType{{Quantity: 10, Sold: 10, SaleEnd: now.Add(time.Hour)}},
+			expected:    []models.NotificationType{models.NotificationFirstSale, models.NotificationHalfSold, models.NotificationSoldOut},
+		},
+		{
+			name:        "sales ending within 24 hours",
+			ticketTypes: []*models.TicketType{{Quantity: 100, Sold: 0, SaleEnd: now.Add(23 * time.Hour)}},
+			expected:    []models.NotificationType{models.NotificationSaleEnding},
+		},
+		{
+			name:        "sales already ended",
+			ticketTypes: []*models.TicketType{{Quantity: 100, Sold: 0, SaleEnd: now.Add(-time.Hour)}},
+			expected:    nil,
+		},
+		{
+			name:        "no ticket types",
+			ticketTypes: nil,
+			expected:    nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			milestones := DetectSalesMilestones(tt.ticketTypes, now)
+			if len(milestones) != len(tt.expected) {
+				t.Fatalf("expected %v, got %v", tt.expected, milestones)
+			}
+			for i := range milestones {
+				if milestones[i] != tt.expected[i] {
+					t.Errorf("expected %v, got %v", tt.expected, milestones)
+				}
+			}
+		})
+	}
+}
+
+func TestNotificationService_OrderCompleted(t *testing.T) {
+	service, notificationRepo, ticketRepo, emailSender := setupNotificationService()
+	ticketType := &models.TicketType{ID: 1, EventID: 10, Quantity: 4, Sold: 1, SaleEnd: service.now().Add(7 * 24 * time.Hour)}
+	ticketRepo.ticketTypes[1] = ticketType
+
+	service.OrderCompleted(&models.Order{ID: 1, EventID: 10})
+	if len(notificationRepo.notifications) != 1 || notificationRepo.notifications[0].Type != models.NotificationFirstSale {
+		t.Fatalf("expected a first sale notification, got %v", notificationRepo.notifications)
+	}
+	if len(emailSender.subjects) != 1 || emailSender.subjects[0] != "First sale for Jazz Night" {
+		t.Errorf("expected a first sale email, got %v", emailSender.subjects)
+	}
+
+	// A second order does not repeat the first sale milestone
+	ticketType.Sold = 2
+	service.OrderCompleted(&models.Order{ID: 2, EventID: 10})
+	if len(notificationRepo.notifications) != 2 || notificationRepo.notifications[1].Type != models.NotificationHalfSold {
+		t.Fatalf("expected a half sold notification, got %d notifications", len(notificationRepo.notifications))
+	}
+
+	ticketType.Sold = 4
+	service.OrderCompleted(&models.Order{ID: 3, EventID: 10})
+	if len(notificationRepo.notifications) != 3 || notificationRepo.notifications[2].Type != models.NotificationSoldOut {
+		t.Fatalf("expected a sold out notification, got %d notifications", len(notificationRepo.notifications))
+	}
+	if link := notificationRepo.notifications[2].Link; link != "/organizer/events/10/analytics" {
+		t.Errorf("unexpected notification link: %s", link)
+	}
+}
+
+func TestNotificationService_SoldOutInOneOrder(t *testing.T) {
+	service, notificationRepo, ticketRepo, _ := setupNotificationService()
+	ticketRepo.ticketTypes[1] = &models.TicketType{ID: 1, EventID: 10, Quantity: 2, Sold: 2, SaleEnd: service.now().Add(time.Hour)}
+
+	service.OrderCompleted(&models.Order{ID: 1, EventID: 10})
+
+	var types []models.NotificationType
+	for _, n := range notificationRepo.notifications {
+		types = append(types, n.Type)
+	}
+	if len(types) != 2 || types[0] != models.NotificationFirstSale || types[1] != models.NotificationSoldOut {
+		t.Errorf("expected first sale and sold out only, got %v", types)
+	}
+	if !notificationRepo.milestones[10][models.NotificationHalfSold] {
+		t.Error("expected half sold milestone to be recorded")
+	}
+}
+
+func TestNotificationService_Preferences(t *testing.T) {
+	service, notificationRepo, ticketRepo, emailSender := setupNotificationService()
+	ticketRepo.ticketTypes[1] = &models.TicketType{ID: 1, EventID: 10, Quantity: 10, Sold: 1, SaleEnd: service.now().Add(7 * 24 * time.Hour)}
+
+	prefs := models.DefaultNotificationPreferences(1)
+	prefs.EmailEnabled = false
+	prefs.HalfSold = false
+	service.UpdatePreferences(prefs)
+
+	service.OrderCompleted(&models.Order{ID: 1, EventID: 10})
+	if len(notificationRepo.notifications) != 1 {
+		t.Fatalf("expected in-app notification, got %d", len(notificationRepo.notifications))
+	}
+	if len(emailSender.subjects) != 0 {
+		t.Errorf("expected no email when email is disabled, got %v", emailSender.subjects)
+	}
+
+	// Disabled milestones are not announced
+	ticketRepo.ticketTypes[1].Sold = 5
+	service.OrderCompleted(&models.Order{ID: 2, EventID: 10})
+	if len(notificationRepo.notifications) != 1 {
+		t.Errorf("expected half sold to be skipped, got %d notifications", len(notificationRepo.notifications))
+	}
+}
+
+func TestNotificationService_CheckSalesEnding(t *testing.T) {
+	service, notificationRepo, ticketRepo, _ := setupNotificationService()
+	ticketRepo.ticketTypes[1] = &models.TicketType{ID: 1, EventID: 10, Quantity: 10, Sold: 0, SaleEnd: service.now().Add(6 * time.Hour)}
+	notificationRepo.endingEvents = []int{10}
+
+	if err := service.CheckSalesEnding(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(notificationRepo.notifications) != 1 || notificationRepo.notifications[0].Type != models.NotificationSaleEnding {
+		t.Fatalf("expected a sale ending notification, got %v", notificationRepo.notifications)
+	}
+
+	// Running the check again does not notify twice
+	service.CheckSalesEnding()
+	if len(notificationRepo.notifications) != 1 {
+		t.Errorf("expected a single sale ending notification, got %d", len(notificationRepo.notifications))
+	}
+}
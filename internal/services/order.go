@@ -3,8 +3,10 @@ package services
 import (
 	"fmt"
 
+	"event-ticketing-platform/internal/i18n"
 	"event-ticketing-platform/internal/models"
 	"event-ticketing-platform/internal/repositories"
+	"event-ticketing-platform/web/templates/emails"
 )
 
 // OrderService handles order-related business logic
@@ -14,6 +16,36 @@ type OrderService struct {
 	userRepo       UserRepository
 	paymentService PaymentService
 	emailService   EmailService
+	locales        OrderLocaleResolver
+	attendees      TicketAttendeeAssigner
+	eventInfo      EventInfoSource
+	events         *DomainEventBus
+}
+
+// OrderCompletionHook is notified after an order has been completed
+type OrderCompletionHook interface {
+	OrderCompleted(order *models.Order)
+}
+
+// OrderRefundHook is notified after an order has been refunded
+type OrderRefundHook interface {
+	OrderRefunded(order *models.Order)
+}
+
+// OrderLocaleResolver decides which language to send an order's emails in
+type OrderLocaleResolver interface {
+	ResolveOrderLocale(order *models.Order) string
+}
+
+// EventInfoSource looks up the FAQs and policies of an order's event
+type EventInfoSource interface {
+	GetInfo(eventID int) (*models.EventInfo, error)
+}
+
+// OrderStatusEmailSender is implemented by email services that can send
+// order status update emails
+type OrderStatusEmailSender interface {
+	SendOrderStatusEmail(email, userName, subject, htmlContent, textContent string, order *models.Order) error
 }
 
 // OrderRepository interface for order data operations
@@ -32,6 +64,7 @@ type OrderRepository interface {
 		QRCode       string
 	}) error
 	GetOrderStatistics(eventID *int, userID *int) (map[string]interface{}, error)
+	GetItemPrices(orderID int) (map[int]int, error)
 
 	// Admin-specific methods
 	GetOrderCount() (int, error)
@@ -55,6 +88,29 @@ func NewOrderService(
 	}
 }
 
+// SetLocaleResolver sets how the language of order emails is chosen. Without
+// one, emails use the language chosen at checkout or the default.
+func (s *OrderService) SetLocaleResolver(locales OrderLocaleResolver) {
+	s.locales = locales
+}
+
+// SetEventBus sets the bus completed orders are published on
+func (s *OrderService) SetEventBus(events *DomainEventBus) {
+	s.events = events
+}
+
+// SetTicketAttendees names completed orders' tickets with the attendees
+// given at checkout
+func (s *OrderService) SetTicketAttendees(attendees TicketAttendeeAssigner) {
+	s.attendees = attendees
+}
+
+// SetEventInfo includes the event's policies and a link to its FAQs in order
+// confirmation emails
+func (s *OrderService) SetEventInfo(eventInfo EventInfoSource) {
+	s.eventInfo = eventInfo
+}
+
 // CreateOrder creates a new order
 func (s *OrderService) CreateOrder(req *models.OrderCreateRequest) (*models.Order, error) {
 	return s.orderRepo.Create(req)
@@ -65,12 +121,36 @@ func (s *OrderService) CompleteOrder(orderID int, paymentID string, ticketData [
 	TicketTypeID int
 	QRCode       string
 }) error {
+	return s.CompleteOrderWithAttendees(orderID, paymentID, ticketData, nil)
+}
+
+// CompleteOrderWithAttendees completes an order like CompleteOrder, naming
+// its tickets with the attendees given at checkout, in cart order, before the
+// tickets are emailed
+func (s *OrderService) CompleteOrderWithAttendees(orderID int, paymentID string, ticketData []struct {
+	TicketTypeID int
+	QRCode       string
+}, attendees []models.TicketAttendee) error {
 	// Complete the order in the repository
 	err := s.orderRepo.ProcessOrderCompletion(orderID, paymentID, ticketData)
 	if err != nil {
 		return fmt.Errorf("failed to complete order: %w", err)
 	}
 
+	return s.NotifyOrderCompleted(orderID, attendees)
+}
+
+// NotifyOrderCompleted names an order whose tickets have just been issued
+// with the attendees given at checkout, emails its tickets and publishes it
+// as completed
+func (s *OrderService) NotifyOrderCompleted(orderID int, attendees []models.TicketAttendee) error {
+	// The tickets are paid for, so failing to name them does not fail the order
+	if s.attendees != nil {
+		if err := s.attendees.AssignAttendees(orderID, attendees); err != nil {
+			fmt.Printf("Warning: failed to name tickets for order %d: %v\n", orderID, err)
+		}
+	}
+
 	// Get the completed order
 	order, err := s.orderRepo.GetByID(orderID)
 	if err != nil {
@@ -96,31 +176,61 @@ func (s *OrderService) CompleteOrder(orderID int, paymentID string, ticketData [
 		fmt.Printf("Warning: failed to send order confirmation email for order %s: %v\n", order.OrderNumber, err)
 	}
 
+	s.events.PublishOrderCompleted(order)
+
 	return nil
 }
 
+// ResendOrderTickets emails an order's valid tickets to its buyer again, as
+// after the order was changed and some of its tickets were replaced
+func (s *OrderService) ResendOrderTickets(orderID int) error {
+	order, err := s.orderRepo.GetByID(orderID)
+	if err != nil {
+		return fmt.Errorf("failed to get order: %w", err)
+	}
+
+	user, err := s.userRepo.GetByID(order.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	tickets, err := s.ticketRepo.GetTicketsByOrder(orderID)
+	if err != nil {
+		return fmt.Errorf("failed to get order tickets: %w", err)
+	}
+
+	// Voided tickets are left out
+	var validTickets []*models.Ticket
+	for _, ticket := range tickets {
+		if ticket.Status != models.TicketRefunded {
+			validTickets = append(validTickets, ticket)
+		}
+	}
+
+	return s.sendOrderConfirmationEmail(order, user, validTickets)
+}
+
 // sendOrderConfirmationEmail sends order confirmation email with ticket attachments
 func (s *OrderService) sendOrderConfirmationEmail(order *models.Order, user *models.User, tickets []*models.Ticket) error {
 	if s.emailService == nil {
 		return fmt.Errorf("email service not available")
 	}
 
-	// Create email content with order and ticket details
-	subject := fmt.Sprintf("Order Confirmation - %s", order.OrderNumber)
-
-	// Generate HTML content
-	htmlContent := s.generateOrderConfirmationHTML(order, user, tickets)
+	// Render the email in the buyer's language
+	order = s.localizedOrder(order)
 
-	// Generate text content
-	textContent := s.generateOrderConfirmationText(order, user, tickets)
+	email, err := s.orderConfirmationEmail(order, user, tickets)
+	if err != nil {
+		return err
+	}
 
 	// Send email
-	err := s.emailService.SendOrderConfirmationWithTickets(
+	err = s.emailService.SendOrderConfirmationWithTickets(
 		order.BillingEmail,
 		user.FullName(),
-		subject,
-		htmlContent,
-		textContent,
+		email.Subject,
+		email.HTML,
+		email.Text,
 		order,
 		tickets,
 	)
@@ -132,132 +242,40 @@ func (s *OrderService) sendOrderConfirmationEmail(order *models.Order, user *mod
 	return nil
 }
 
-// generateOrderConfirmationHTML generates HTML content for order confirmation email
-func (s *OrderService) generateOrderConfirmationHTML(order *models.Order, user *models.User, tickets []*models.Ticket) string {
-	html := fmt.Sprintf(`
-<!DOCTYPE html>
-<html>
-<head>
-    <meta charset="UTF-8">
-    <title>Order Confirmation</title>
-    <style>
-        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
-        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
-        .header { background-color: #4F46E5; color: white; padding: 20px; text-align: center; border-radius: 8px 8px 0 0; }
-        .content { padding: 20px; background-color: #f9f9f9; }
-        .order-details { background-color: #EEF2FF; padding: 15px; border-left: 4px solid #4F46E5; margin: 20px 0; border-radius: 4px; }
-        .ticket-item { background-color: white; padding: 15px; margin: 10px 0; border-radius: 4px; border: 1px solid #e5e7eb; }
-        .button { display: inline-block; padding: 12px 24px; background-color: #4F46E5; color: white; text-decoration: none; border-radius: 4px; margin: 20px 0; }
-        .footer { text-align: center; padding: 20px; color: #666; font-size: 12px; }
-        .success-icon { color: #10B981; font-size: 48px; text-align: center; margin-bottom: 20px; }
-    </style>
-</head>
-<body>
-    <div class="container">
-        <div class="header">
-            <div class="success-icon">✓</div>
-            <h1>Order Confirmed!</h1>
-            <p>Thank you for your purchase</p>
-        </div>
-        <div class="content">
-            <p>Dear %s,</p>
-            <p>Your order has been successfully processed and your tickets are ready!</p>
-            
-            <div class="order-details">
-                <h3>Order Details</h3>
-                <p><strong>Order Number:</strong> %s</p>
-                <p><strong>Order Date:</strong> %s</p>
-                <p><strong>Total Amount:</strong> KSh %.2f</p>
-                <p><strong>Payment Status:</strong> %s</p>
-            </div>
-            
-            <h3>Your Tickets (%d tickets)</h3>
-            <p>Please find your tickets attached to this email as a PDF. You can also download them from your account dashboard.</p>
-            
-            <div style="text-align: center; margin: 30px 0;">
-                <a href="http://localhost:8080/dashboard/orders/%d" class="button">View Order Details</a>
-            </div>
-            
-            <div style="background-color: #FEF3C7; padding: 15px; border-left: 4px solid #F59E0B; margin: 20px 0; border-radius: 4px;">
-                <h4 style="margin-top: 0; color: #92400E;">Important Information:</h4>
-                <ul style="color: #92400E; margin-bottom: 0;">
-                    <li>Please bring your tickets (printed or on mobile) to the event</li>
-                    <li>Arrive early to avoid queues at the entrance</li>
-                    <li>Each ticket contains a unique QR code for entry</li>
-                    <li>Tickets are non-transferable and non-refundable</li>
-                </ul>
-            </div>
-            
-            <p>If you have any questions about your order or need assistance, please don't hesitate to contact our support team.</p>
-            
-            <p>Thank you for choosing Event Ticketing Platform!</p>
-        </div>
-        <div class="footer">
-            <p>Event Ticketing Platform</p>
-            <p>This email was sent to %s</p>
-        </div>
-    </div>
-</body>
-</html>`,
-		user.FullName(),
-		order.OrderNumber,
-		order.CreatedAt.Format("January 2, 2006 at 3:04 PM"),
-		order.TotalAmountInCurrency(),
-		order.GetStatusDisplayName(),
-		len(tickets),
-		order.ID,
-		order.BillingEmail,
-	)
-
-	return html
+// localizedOrder returns a copy of the order whose Locale is the language to
+// email the buyer in
+func (s *OrderService) localizedOrder(order *models.Order) *models.Order {
+	localized := *order
+	if s.locales != nil {
+		localized.Locale = s.locales.ResolveOrderLocale(order)
+	} else {
+		localized.Locale = i18n.Resolve(order.Locale)
+	}
+	return &localized
 }
 
-// generateOrderConfirmationText generates text content for order confirmation email
-func (s *OrderService) generateOrderConfirmationText(order *models.Order, user *models.User, tickets []*models.Ticket) string {
-	text := fmt.Sprintf(`Order Confirmed!
-
-Dear %s,
-
-Your order has been successfully processed and your tickets are ready!
-
-ORDER DETAILS
-=============
-Order Number: %s
-Order Date: %s
-Total Amount: KSh %.2f
-Payment Status: %s
-
-YOUR TICKETS
-============
-You have %d ticket(s) for this order.
-Please find your tickets attached to this email as a PDF.
-You can also download them from your account dashboard at:
-http://localhost:8080/dashboard/orders/%d
-
-IMPORTANT INFORMATION
-====================
-• Please bring your tickets (printed or on mobile) to the event
-• Arrive early to avoid queues at the entrance
-• Each ticket contains a unique QR code for entry
-• Tickets are non-transferable and non-refundable
-
-If you have any questions about your order or need assistance, please don't hesitate to contact our support team.
-
-Thank you for choosing Event Ticketing Platform!
-
-Event Ticketing Platform
-This email was sent to %s`,
-		user.FullName(),
-		order.OrderNumber,
-		order.CreatedAt.Format("January 2, 2006 at 3:04 PM"),
-		order.TotalAmountInCurrency(),
-		order.GetStatusDisplayName(),
-		len(tickets),
-		order.ID,
-		order.BillingEmail,
-	)
+// orderConfirmationEmail renders the order confirmation email, in the
+// order's language
+func (s *OrderService) orderConfirmationEmail(order *models.Order, user *models.User, tickets []*models.Ticket) (*emails.Email, error) {
+	data := emails.OrderConfirmationData{
+		Locale:   order.Locale,
+		Name:     user.FullName(),
+		Order:    order,
+		Tickets:  tickets,
+		OrderURL: fmt.Sprintf("http://localhost:8080/dashboard/orders/%d", order.ID),
+	}
+
+	// The email is still sent without the event's policies if they can't be loaded
+	if s.eventInfo != nil {
+		if info, err := s.eventInfo.GetInfo(order.EventID); err == nil {
+			data.Policies = info.Policies
+			if len(info.FAQs) > 0 {
+				data.FAQURL = fmt.Sprintf("http://localhost:8080/events/%d#faqs", order.EventID)
+			}
+		}
+	}
 
-	return text
+	return emails.OrderConfirmation(data)
 }
 
 // GetUserOrders retrieves orders for a user with pagination
@@ -522,94 +540,135 @@ func (s *OrderService) shouldSendStatusNotification(oldStatus, newStatus models.
 	return notificationTransitions[transitionKey]
 }
 
-// sendStatusUpdateNotification sends an email notification for status updates
+// OrderRefunded emails the buyer that their order has been refunded. It
+// implements OrderRefundHook.
+func (s *OrderService) OrderRefunded(order *models.Order) {
+	if err := s.sendStatusUpdateNotification(order, models.OrderRefunded); err != nil {
+		fmt.Printf("Warning: failed to send refund notification for order %s: %v\n", order.OrderNumber, err)
+	}
+}
+
+// sendStatusUpdateNotification sends an email notification for status
+// updates, in the buyer's language
 func (s *OrderService) sendStatusUpdateNotification(order *models.Order, newStatus models.OrderStatus) error {
 	if s.emailService == nil {
 		return fmt.Errorf("email service not available")
 	}
 
-	// For now, just log the status change notification
-	// In a real implementation, you'd send specific notification emails
-	fmt.Printf("Status update notification for order %s: %s -> %s\n",
-		order.OrderNumber, order.Status, newStatus)
+	sender, ok := s.emailService.(OrderStatusEmailSender)
+	if !ok {
+		// The email service cannot send status emails, so just log the change
+		fmt.Printf("Status update notification for order %s: %s -> %s\n",
+			order.OrderNumber, order.Status, newStatus)
+		return nil
+	}
+
+	user, err := s.userRepo.GetByID(order.UserID)
+	if err != nil {
+		return fmt.Errorf("failed to get user: %w", err)
+	}
+
+	order = s.localizedOrder(order)
+
+	var subject, htmlContent, textContent string
+	switch newStatus {
+	case models.OrderCompleted:
+		subject = i18n.T(order.Locale, "order_completed.subject", order.OrderNumber)
+		htmlContent = s.generateCompletionNotificationHTML(order, user)
+		textContent = s.generateCompletionNotificationText(order, user)
+	case models.OrderRefunded:
+		email, err := emails.Refund(emails.RefundData{Locale: order.Locale, Name: user.FullName(), Order: order})
+		if err != nil {
+			return err
+		}
+		subject, htmlContent, textContent = email.Subject, email.HTML, email.Text
+	case models.OrderCancelled:
+		subject = i18n.T(order.Locale, "order_cancelled.subject", order.OrderNumber)
+		htmlContent = s.generateCancellationNotificationHTML(order, user)
+		textContent = s.generateCancellationNotificationText(order, user)
+	default:
+		return nil
+	}
+
+	if err := sender.SendOrderStatusEmail(order.BillingEmail, user.FullName(), subject, htmlContent, textContent, order); err != nil {
+		return fmt.Errorf("failed to send order status email: %w", err)
+	}
 
 	return nil
 }
 
 // generateCompletionNotificationHTML generates HTML for order completion notification
 func (s *OrderService) generateCompletionNotificationHTML(order *models.Order, user *models.User) string {
+	locale := order.Locale
 	return fmt.Sprintf(`
 <div style="font-family: Arial, sans-serif; max-width: 600px; margin: 0 auto;">
-	<h2 style="color: #10B981;">Order Completed Successfully!</h2>
-	<p>Dear %s,</p>
-	<p>Great news! Your order <strong>%s</strong> has been completed and your tickets are ready.</p>
-	<p>You can download your tickets from your account dashboard.</p>
-	<p>Thank you for your purchase!</p>
-</div>`, user.FullName(), order.OrderNumber)
+	<h2 style="color: #10B981;">%s</h2>
+	<p>%s</p>
+	<p>%s</p>
+	<p>%s</p>
+	<p>%s</p>
+</div>`,
+		i18n.T(locale, "order_completed.heading"),
+		i18n.T(locale, "email.greeting", user.FullName()),
+		i18n.T(locale, "order_completed.body", "<strong>"+order.OrderNumber+"</strong>"),
+		i18n.T(locale, "order_completed.dashboard"),
+		i18n.T(locale, "order_completed.thanks"))
 }
 
 // generateCompletionNotificationText generates text for order completion notification
 func (s *OrderService) generateCompletionNotificationText(order *models.Order, user *models.User) string {
-	return fmt.Sprintf(`Order Completed Successfully!
+	locale := order.Locale
+	return fmt.Sprintf(`%s
 
-Dear %s,
+%s
 
-Great news! Your order %s has been completed and your tickets are ready.
+%s
 
-You can download your tickets from your account dashboard.
+%s
 
-Thank you for your purchase!`, user.FullName(), order.OrderNumber)
-}
-
-// generateRefundNotificationHTML generates HTML for refund notification
-func (s *OrderService) generateRefundNotificationHTML(order *models.Order, user *models.User) string {
-	return fmt.Sprintf(`
-<div style="font-family: Arial, sans-serif; max-width: 600px; margin: 0 auto;">
-	<h2 style="color: #EF4444;">Order Refunded</h2>
-	<p>Dear %s,</p>
-	<p>Your order <strong>%s</strong> has been refunded.</p>
-	<p>The refund amount of <strong>KSh %.2f</strong> will be processed back to your original payment method within 3-5 business days.</p>
-	<p>If you have any questions, please contact our support team.</p>
-</div>`, user.FullName(), order.OrderNumber, order.TotalAmountInCurrency())
-}
-
-// generateRefundNotificationText generates text for refund notification
-func (s *OrderService) generateRefundNotificationText(order *models.Order, user *models.User) string {
-	return fmt.Sprintf(`Order Refunded
-
-Dear %s,
-
-Your order %s has been refunded.
-
-The refund amount of KSh %.2f will be processed back to your original payment method within 3-5 business days.
-
-If you have any questions, please contact our support team.`,
-		user.FullName(), order.OrderNumber, order.TotalAmountInCurrency())
+%s`,
+		i18n.T(locale, "order_completed.heading"),
+		i18n.T(locale, "email.greeting", user.FullName()),
+		i18n.T(locale, "order_completed.body", order.OrderNumber),
+		i18n.T(locale, "order_completed.dashboard"),
+		i18n.T(locale, "order_completed.thanks"))
 }
 
 // generateCancellationNotificationHTML generates HTML for cancellation notification
 func (s *OrderService) generateCancellationNotificationHTML(order *models.Order, user *models.User) string {
+	locale := order.Locale
 	return fmt.Sprintf(`
 <div style="font-family: Arial, sans-serif; max-width: 600px; margin: 0 auto;">
-	<h2 style="color: #F59E0B;">Order Cancelled</h2>
-	<p>Dear %s,</p>
-	<p>Your order <strong>%s</strong> has been cancelled.</p>
-	<p>If this was not requested by you, please contact our support team immediately.</p>
-	<p>You can place a new order anytime from our website.</p>
-</div>`, user.FullName(), order.OrderNumber)
+	<h2 style="color: #F59E0B;">%s</h2>
+	<p>%s</p>
+	<p>%s</p>
+	<p>%s</p>
+	<p>%s</p>
+</div>`,
+		i18n.T(locale, "order_cancelled.heading"),
+		i18n.T(locale, "email.greeting", user.FullName()),
+		i18n.T(locale, "order_cancelled.body", "<strong>"+order.OrderNumber+"</strong>"),
+		i18n.T(locale, "order_cancelled.warning"),
+		i18n.T(locale, "order_cancelled.reorder"))
 }
 
 // generateCancellationNotificationText generates text for cancellation notification
 func (s *OrderService) generateCancellationNotificationText(order *models.Order, user *models.User) string {
-	return fmt.Sprintf(`Order Cancelled
+	locale := order.Locale
+	return fmt.Sprintf(`%s
 
-Dear %s,
+%s
 
-Your order %s has been cancelled.
+%s
 
-If this was not requested by you, please contact our support team immediately.
+%s
 
-You can place a new order anytime from our website.`, user.FullName(), order.OrderNumber)
+%s`,
+		i18n.T(locale, "order_cancelled.heading"),
+		i18n.T(locale, "email.greeting", user.FullName()),
+		i18n.T(locale, "order_cancelled.body", order.OrderNumber),
+		i18n.T(locale, "order_cancelled.warning"),
+		i18n.T(locale, "order_cancelled.reorder"))
 }
 
 // Admin-specific methods
@@ -623,3 +682,9 @@ func (s *OrderService) GetOrderCount() (int, error) {
 func (s *OrderService) GetTotalRevenue() (float64, error) {
 	return s.orderRepo.GetTotalRevenue()
 }
+
+// GetItemPrices returns the price per ticket each ticket type of an order was
+// bought at, keyed by ticket type ID
+func (s *OrderService) GetItemPrices(orderID int) (map[int]int, error) {
+	return s.orderRepo.GetItemPrices(orderID)
+}
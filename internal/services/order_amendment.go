@@ -0,0 +1,617 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/repositories"
+)
+
+// amendmentHoldMinutes is how long the new tickets of an amendment are held
+// while the buyer pays the difference
+const amendmentHoldMinutes = 15
+
+// ErrAmendmentClosed is returned for orders that can no longer be changed
+var ErrAmendmentClosed = errors.New("this order can no longer be changed")
+
+// OrderAmendmentRepositoryInterface defines the data operations for order amendments
+type OrderAmendmentRepositoryInterface interface {
+	CreateAmendment(amendment *models.OrderAmendment) error
+	GetAmendment(id int) (*models.OrderAmendment, error)
+	GetAmendmentByPaymentReference(reference string) (*models.OrderAmendment, error)
+	GetPendingAmendment(orderID int) (*models.OrderAmendment, error)
+	SetPaymentReference(id int, reference string) error
+	CancelAmendment(id int) error
+	CompleteRefund(id int, reference string) error
+	ApplyAmendment(amendment *models.OrderAmendment, voidTicketIDs []int, ticketData []struct {
+		TicketTypeID int
+		QRCode       string
+	}) error
+}
+
+// AmendmentOrderReader retrieves the orders being changed and the prices
+// their tickets were sold at
+type AmendmentOrderReader interface {
+	GetByID(id int) (*models.Order, error)
+	GetItemPrices(orderID int) (map[int]int, error)
+}
+
+// AmendmentTicketStore retrieves an order's tickets and the event's ticket
+// types, and holds the tickets an amendment adds while it is paid for
+type AmendmentTicketStore interface {
+	GetTicketsByOrder(orderID int) ([]*models.Ticket, error)
+	GetTicketTypesByEvent(eventID int) ([]*models.TicketType, error)
+	HoldTickets(userID int, holds []repositories.TicketHold, expirationMinutes int) ([]*repositories.TicketReservation, error)
+	ReleaseUserReservations(userID int, ticketTypeIDs []int) error
+}
+
+// AmendmentEventReader retrieves the events of the orders being changed
+type AmendmentEventReader interface {
+	GetByID(id int) (*models.Event, error)
+}
+
+// AmendmentTicketSender emails an order's tickets again once it has changed
+type AmendmentTicketSender interface {
+	ResendOrderTickets(orderID int) error
+}
+
+// AmendmentPlanLookup finds the installment plan an order is paid with, if any
+type AmendmentPlanLookup interface {
+	Plan(orderID int) (*models.InstallmentPlan, error)
+}
+
+// AvailabilityInvalidator drops the cached ticket availability of an event
+type AvailabilityInvalidator interface {
+	InvalidateAvailability(eventID int)
+}
+
+// OrderAmendmentOptions is what a buyer can change an order to
+type OrderAmendmentOptions struct {
+	Order       *models.Order
+	Event       *models.Event
+	TicketTypes []*models.TicketType
+	Quantities  map[int]int // Valid tickets of each ticket type the order has
+	Prices      map[int]int // What each ticket type is paid or refunded at, in cents
+	Deadline    time.Time
+	Pending     *models.OrderAmendment // The amendment awaiting confirmation or payment, if any
+}
+
+// AmendmentConfirmation is the result of confirming an amendment: either it
+// was applied, or the buyer is sent to AuthorizationURL to pay the difference
+type AmendmentConfirmation struct {
+	Amendment        *models.OrderAmendment
+	AuthorizationURL string
+}
+
+// OrderAmendmentService lets buyers change the ticket types and quantities
+// of their orders until shortly before the event, instead of cancelling and
+// buying again. Buyers pay the difference of amendments that cost more and
+// are refunded that of ones that cost less; removed tickets are voided, new
+// ones issued and the updated tickets emailed.
+type OrderAmendmentService struct {
+	repo         OrderAmendmentRepositoryInterface
+	orders       AmendmentOrderReader
+	tickets      AmendmentTicketStore
+	events       AmendmentEventReader
+	payments     PaymentService
+	sender       AmendmentTicketSender
+	installments AmendmentPlanLookup
+	availability AvailabilityInvalidator
+	now          func() time.Time
+}
+
+// NewOrderAmendmentService creates a new order amendment service
+func NewOrderAmendmentService(repo OrderAmendmentRepositoryInterface, orders AmendmentOrderReader, tickets AmendmentTicketStore, events AmendmentEventReader, payments PaymentService, sender AmendmentTicketSender) *OrderAmendmentService {
+	return &OrderAmendmentService{
+		repo:     repo,
+		orders:   orders,
+		tickets:  tickets,
+		events:   events,
+		payments: payments,
+		sender:   sender,
+		now:      time.Now,
+	}
+}
+
+// SetInstallments keeps orders still being paid in installments from being
+// changed
+func (s *OrderAmendmentService) SetInstallments(installments AmendmentPlanLookup) {
+	s.installments = installments
+}
+
+// SetAvailability drops cached ticket availability when an amendment
+// returns tickets to sale or takes them out
+func (s *OrderAmendmentService) SetAvailability(availability AvailabilityInvalidator) {
+	s.availability = availability
+}
+
+// Options returns what the buyer can change their order to
+func (s *OrderAmendmentService) Options(orderID, userID int) (*OrderAmendmentOptions, error) {
+	order, event, err := s.amendableOrder(orderID, userID)
+	if err != nil {
+		return nil, err
+	}
+	options, _, err := s.loadOptions(order, event)
+	if err != nil {
+		return nil, err
+	}
+
+	options.Pending, err = s.repo.GetPendingAmendment(order.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pending amendment: %w", err)
+	}
+	return options, nil
+}
+
+// RequestAmendment prices a change of the order to the quantities of each
+// ticket type and stores it for the buyer to confirm. Ticket types left out
+// of the quantities are unchanged.
+func (s *OrderAmendmentService) RequestAmendment(orderID, userID int, quantities map[int]int) (*models.OrderAmendment, error) {
+	order, event, err := s.amendableOrder(orderID, userID)
+	if err != nil {
+		return nil, err
+	}
+	options, active, err := s.loadOptions(order, event)
+	if err != nil {
+		return nil, err
+	}
+
+	amendment, err := priceAmendment(options, active, quantities)
+	if err != nil {
+		return nil, err
+	}
+	amendment.OrderID = order.ID
+	amendment.UserID = userID
+
+	if err := s.repo.CreateAmendment(amendment); err != nil {
+		return nil, err
+	}
+	return amendment, nil
+}
+
+// ConfirmAmendment applies a pending amendment the buyer has reviewed. Ones
+// that cost more are applied once the difference is paid, which may first
+// need the buyer to authorize the payment; ones that cost less are refunded
+// the difference.
+func (s *OrderAmendmentService) ConfirmAmendment(amendmentID, userID int) (*AmendmentConfirmation, error) {
+	amendment, order, err := s.pendingAmendment(amendmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	// The order may have become unchangeable since the amendment was priced
+	if _, _, err := s.amendableOrder(order.ID, userID); err != nil {
+		return nil, err
+	}
+
+	if !amendment.RequiresPayment() {
+		if err := s.apply(amendment, order); err != nil {
+			return nil, err
+		}
+		return &AmendmentConfirmation{Amendment: amendment}, nil
+	}
+
+	// A difference already being paid isn't charged again unless that
+	// payment failed
+	if amendment.PaymentReference != "" {
+		status, err := s.payments.GetPaymentStatus(amendment.PaymentReference)
+		if err != nil {
+			return nil, fmt.Errorf("failed to verify payment: %w", err)
+		}
+		switch status.Status {
+		case "success":
+			if err := s.applyPaid(amendment, order); err != nil {
+				return nil, err
+			}
+			return &AmendmentConfirmation{Amendment: amendment}, nil
+		case "pending":
+			return nil, fmt.Errorf("your payment of the difference is still being processed")
+		}
+	}
+
+	// Hold the new tickets while the buyer pays for them
+	var holds []repositories.TicketHold
+	var heldTypeIDs []int
+	for _, item := range amendment.Items {
+		if item.To > item.From {
+			holds = append(holds, repositories.TicketHold{TicketTypeID: item.TicketTypeID, Quantity: item.To - item.From})
+			heldTypeIDs = append(heldTypeIDs, item.TicketTypeID)
+		}
+	}
+	if len(holds) > 0 {
+		if _, err := s.tickets.HoldTickets(userID, holds, amendmentHoldMinutes); err != nil {
+			return nil, fmt.Errorf("tickets could not be reserved: %w", err)
+		}
+		s.invalidateAvailability(order.EventID)
+	}
+
+	payment, err := s.payments.ProcessPayment(amendment.PriceDelta, "", PaymentBillingInfo{
+		Email: order.BillingEmail,
+		Name:  order.BillingName,
+	})
+	if err == nil && payment.Status == "failed" {
+		err = errors.New(payment.ErrorMessage)
+	}
+	if err != nil {
+		s.releaseHolds(order.EventID, userID, heldTypeIDs)
+		return nil, fmt.Errorf("payment failed: %w", err)
+	}
+
+	amendment.PaymentReference = payment.PaymentID
+	if err := s.repo.SetPaymentReference(amendment.ID, payment.PaymentID); err != nil {
+		return nil, err
+	}
+
+	if payment.Status == "success" {
+		if err := s.applyPaid(amendment, order); err != nil {
+			return nil, err
+		}
+		return &AmendmentConfirmation{Amendment: amendment}, nil
+	}
+	return &AmendmentConfirmation{Amendment: amendment, AuthorizationURL: payment.AuthorizationURL}, nil
+}
+
+// CheckAmendmentPayment applies a pending amendment once the payment of its
+// difference has gone through
+func (s *OrderAmendmentService) CheckAmendmentPayment(amendmentID, userID int) (*models.OrderAmendment, error) {
+	amendment, order, err := s.pendingAmendment(amendmentID, userID)
+	if err != nil {
+		return nil, err
+	}
+	if amendment.PaymentReference == "" {
+		return nil, fmt.Errorf("the difference has not been paid yet")
+	}
+	if err := s.completePayment(amendment, order); err != nil {
+		return nil, err
+	}
+	return amendment, nil
+}
+
+// CompletePaidAmendment applies the pending amendment a successful payment
+// is for, as the payment provider redirects the buyer back. It returns nil
+// if the payment isn't for an amendment, and the amendment with the error
+// if it couldn't be applied.
+func (s *OrderAmendmentService) CompletePaidAmendment(paymentReference string) (*models.OrderAmendment, error) {
+	amendment, err := s.repo.GetAmendmentByPaymentReference(paymentReference)
+	if err != nil || amendment == nil {
+		return nil, err
+	}
+	if !amendment.IsPending() {
+		return amendment, nil
+	}
+
+	order, err := s.orders.GetByID(amendment.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("order not found: %w", err)
+	}
+	if err := s.completePayment(amendment, order); err != nil {
+		return amendment, err
+	}
+	return amendment, nil
+}
+
+// completePayment applies an amendment if the payment of its difference
+// succeeded
+func (s *OrderAmendmentService) completePayment(amendment *models.OrderAmendment, order *models.Order) error {
+	status, err := s.payments.GetPaymentStatus(amendment.PaymentReference)
+	if err != nil {
+		return fmt.Errorf("failed to verify payment: %w", err)
+	}
+	if status.Status != "success" {
+		return fmt.Errorf("the payment of the difference is %s", status.Status)
+	}
+	return s.applyPaid(amendment, order)
+}
+
+// applyPaid applies an amendment whose difference has been paid, refunding
+// the payment if its tickets can no longer be issued. The amendment is
+// cancelled before the refund, so a payment is only refunded if no other
+// request has applied the amendment in the meantime.
+func (s *OrderAmendmentService) applyPaid(amendment *models.OrderAmendment, order *models.Order) error {
+	if err := s.apply(amendment, order); err != nil {
+		if cancelErr := s.repo.CancelAmendment(amendment.ID); cancelErr != nil {
+			if errors.Is(cancelErr, repositories.ErrAmendmentNotPending) {
+				return s.alreadyHandled(amendment)
+			}
+			fmt.Printf("Warning: failed to cancel order amendment %d: %v\n", amendment.ID, cancelErr)
+			return err
+		}
+		amendment.Status = models.OrderAmendmentCancelled
+		if _, refundErr := s.payments.RefundPayment(amendment.PaymentReference, amendment.PriceDelta); refundErr != nil {
+			fmt.Printf("Warning: failed to refund payment %s of order amendment %d: %v\n", amendment.PaymentReference, amendment.ID, refundErr)
+		}
+		return err
+	}
+	return nil
+}
+
+// apply voids the tickets an amendment removes, issues those it adds,
+// emails the buyer their updated tickets and refunds the difference of
+// amendments that cost less. The order is changed before any refund, so
+// the difference is only refunded by the request that applied the
+// amendment.
+func (s *OrderAmendmentService) apply(amendment *models.OrderAmendment, order *models.Order) error {
+	tickets, err := s.tickets.GetTicketsByOrder(order.ID)
+	if err != nil {
+		return fmt.Errorf("failed to get order tickets: %w", err)
+	}
+
+	var voidTicketIDs []int
+	var ticketData []struct {
+		TicketTypeID int
+		QRCode       string
+	}
+	for _, item := range amendment.Items {
+		// The latest unused tickets of the type are the ones removed
+		remove := item.From - item.To
+		for i := len(tickets) - 1; i >= 0 && remove > 0; i-- {
+			if tickets[i].TicketTypeID == item.TicketTypeID && tickets[i].IsActive() {
+				voidTicketIDs = append(voidTicketIDs, tickets[i].ID)
+				remove--
+			}
+		}
+		if remove > 0 {
+			return fmt.Errorf("tickets that have been scanned cannot be removed from the order")
+		}
+
+		for i := item.From; i < item.To; i++ {
+			qrCode, err := generateTicketQRCode(order.ID, item.TicketTypeID)
+			if err != nil {
+				return fmt.Errorf("failed to generate QR code: %w", err)
+			}
+			ticketData = append(ticketData, struct {
+				TicketTypeID int
+				QRCode       string
+			}{TicketTypeID: item.TicketTypeID, QRCode: qrCode})
+		}
+	}
+
+	if err := s.repo.ApplyAmendment(amendment, voidTicketIDs, ticketData); err != nil {
+		if errors.Is(err, repositories.ErrAmendmentNotPending) {
+			return s.alreadyHandled(amendment)
+		}
+		return fmt.Errorf("failed to change order: %w", err)
+	}
+
+	s.invalidateAvailability(order.EventID)
+
+	if s.sender != nil {
+		if err := s.sender.ResendOrderTickets(order.ID); err != nil {
+			fmt.Printf("Warning: failed to send changed tickets for order %s: %v\n", order.OrderNumber, err)
+		}
+	}
+
+	// Amendments that cost less stay processing until the difference is
+	// refunded. Refunds are keyed by the refund rather than by a payment of
+	// the difference.
+	if refund := amendment.Refund(); refund > 0 {
+		amendment.Status = models.OrderAmendmentProcessing
+		result, err := s.payments.RefundPayment(order.PaymentID, refund)
+		if err == nil && result.Status == "failed" {
+			err = errors.New(result.ErrorMessage)
+		}
+		if err != nil {
+			fmt.Printf("Warning: failed to refund order amendment %d of order %s: %v\n", amendment.ID, order.OrderNumber, err)
+			return fmt.Errorf("your tickets have been changed but the refund failed: %w", err)
+		}
+		amendment.PaymentReference = result.RefundID
+		if err := s.repo.CompleteRefund(amendment.ID, result.RefundID); err != nil {
+			fmt.Printf("Warning: failed to record refund %s of order amendment %d: %v\n", result.RefundID, amendment.ID, err)
+		}
+	}
+
+	now := s.now()
+	amendment.Status = models.OrderAmendmentCompleted
+	amendment.CompletedAt = &now
+	return nil
+}
+
+// alreadyHandled reloads an amendment that another request has applied or
+// cancelled since it was read. Applied amendments aren't an error.
+func (s *OrderAmendmentService) alreadyHandled(amendment *models.OrderAmendment) error {
+	current, err := s.repo.GetAmendment(amendment.ID)
+	if err != nil {
+		return err
+	}
+	if current == nil {
+		return fmt.Errorf("order amendment not found")
+	}
+	*amendment = *current
+	if amendment.Status == models.OrderAmendmentCancelled {
+		return fmt.Errorf("this change has already been %s", amendment.Status)
+	}
+	return nil
+}
+
+// pendingAmendment retrieves one of the user's pending amendments with its order
+func (s *OrderAmendmentService) pendingAmendment(amendmentID, userID int) (*models.OrderAmendment, *models.Order, error) {
+	amendment, err := s.repo.GetAmendment(amendmentID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if amendment == nil || amendment.UserID != userID {
+		return nil, nil, fmt.Errorf("order amendment not found")
+	}
+	if !amendment.IsPending() {
+		return nil, nil, fmt.Errorf("this change has already been %s", amendment.Status)
+	}
+
+	order, err := s.orders.GetByID(amendment.OrderID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("order not found: %w", err)
+	}
+	return amendment, order, nil
+}
+
+// amendableOrder retrieves one of the user's orders with its event, if the
+// order can still be changed
+func (s *OrderAmendmentService) amendableOrder(orderID, userID int) (*models.Order, *models.Event, error) {
+	order, err := s.orders.GetByID(orderID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("order not found: %w", err)
+	}
+	if order.UserID != userID {
+		return nil, nil, fmt.Errorf("insufficient permissions to change this order")
+	}
+	if !order.IsCompleted() {
+		return nil, nil, fmt.Errorf("%w: it is %s", ErrAmendmentClosed, order.Status)
+	}
+	// Box office sales were paid at the door, so are changed there too
+	if models.IsBoxOfficePayment(order.PaymentID) {
+		return nil, nil, fmt.Errorf("%w: it was bought at the box office", ErrAmendmentClosed)
+	}
+	if s.installments != nil {
+		plan, err := s.installments.Plan(order.ID)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get installment plan: %w", err)
+		}
+		if plan != nil && plan.Status == models.InstallmentPlanActive {
+			return nil, nil, fmt.Errorf("%w until it is paid in full", ErrAmendmentClosed)
+		}
+	}
+
+	event, err := s.events.GetByID(order.EventID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("event not found: %w", err)
+	}
+	if event.IsCancelled() {
+		return nil, nil, fmt.Errorf("%w: the event was cancelled", ErrAmendmentClosed)
+	}
+	if !s.now().Before(models.AmendmentDeadline(event)) {
+		return nil, nil, fmt.Errorf("%w: changes close %d hours before the event", ErrAmendmentClosed, int(models.OrderAmendmentCutoff.Hours()))
+	}
+	return order, event, nil
+}
+
+// loadOptions loads the ticket types an order can be changed to, how many
+// valid tickets of each it has and what each is priced at, with how many of
+// its tickets of each type are unused
+func (s *OrderAmendmentService) loadOptions(order *models.Order, event *models.Event) (*OrderAmendmentOptions, map[int]int, error) {
+	ticketTypes, err := s.tickets.GetTicketTypesByEvent(event.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get ticket types: %w", err)
+	}
+	tickets, err := s.tickets.GetTicketsByOrder(order.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get order tickets: %w", err)
+	}
+	paid, err := s.orders.GetItemPrices(order.ID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get order prices: %w", err)
+	}
+
+	quantities := make(map[int]int)
+	active := make(map[int]int)
+	for _, ticket := range tickets {
+		if ticket.Status == models.TicketRefunded {
+			continue
+		}
+		quantities[ticket.TicketTypeID]++
+		if ticket.IsActive() {
+			active[ticket.TicketTypeID]++
+		}
+	}
+
+	// Tickets of a type the order has are priced at what was paid for them,
+	// so adding more of it costs the same; other types sell at their price
+	prices := make(map[int]int, len(ticketTypes))
+	for _, ticketType := range ticketTypes {
+		if price, ok := paid[ticketType.ID]; ok {
+			prices[ticketType.ID] = price
+		} else {
+			prices[ticketType.ID] = ticketType.Price
+		}
+	}
+
+	return &OrderAmendmentOptions{
+		Order:       order,
+		Event:       event,
+		TicketTypes: ticketTypes,
+		Quantities:  quantities,
+		Prices:      prices,
+		Deadline:    models.AmendmentDeadline(event),
+	}, active, nil
+}
+
+// priceAmendment builds the amendment changing an order to the quantities,
+// checking that removed tickets are unused and added ones are on sale
+func priceAmendment(options *OrderAmendmentOptions, active map[int]int, quantities map[int]int) (*models.OrderAmendment, error) {
+	ticketTypes := make(map[int]*models.TicketType, len(options.TicketTypes))
+	for _, ticketType := range options.TicketTypes {
+		ticketTypes[ticketType.ID] = ticketType
+	}
+	for ticketTypeID := range quantities {
+		if _, ok := ticketTypes[ticketTypeID]; !ok {
+			return nil, fmt.Errorf("ticket type %d is not sold at this event", ticketTypeID)
+		}
+	}
+
+	amendment := &models.OrderAmendment{}
+	total := 0
+	for _, ticketType := range options.TicketTypes {
+		from := options.Quantities[ticketType.ID]
+		to, ok := quantities[ticketType.ID]
+		if !ok {
+			to = from
+		}
+		if to < 0 {
+			return nil, fmt.Errorf("quantity of '%s' cannot be negative", ticketType.Name)
+		}
+		total += to
+		if to == from {
+			continue
+		}
+
+		if to < from && from-to > active[ticketType.ID] {
+			return nil, fmt.Errorf("tickets of '%s' that have been scanned cannot be removed", ticketType.Name)
+		}
+		if to > from {
+			if !ticketType.IsOnSale() {
+				return nil, fmt.Errorf("ticket type '%s' is not on sale", ticketType.Name)
+			}
+			if to-from > ticketType.Available() {
+				return nil, fmt.Errorf("insufficient tickets available for '%s' (requested: %d, available: %d)",
+					ticketType.Name, to-from, ticketType.Available())
+			}
+		}
+
+		item := models.OrderAmendmentItem{
+			TicketTypeID:   ticketType.ID,
+			TicketTypeName: ticketType.Name,
+			From:           from,
+			To:             to,
+			UnitPrice:      options.Prices[ticketType.ID],
+		}
+		amendment.Items = append(amendment.Items, item)
+		amendment.PriceDelta += item.Delta()
+	}
+
+	if len(amendment.Items) == 0 {
+		return nil, fmt.Errorf("the order is unchanged")
+	}
+	if total == 0 {
+		return nil, fmt.Errorf("an order must keep at least one ticket; request a refund to cancel it")
+	}
+	return amendment, nil
+}
+
+// releaseHolds gives back the tickets held for an amendment that couldn't be
+// paid for
+func (s *OrderAmendmentService) releaseHolds(eventID, userID int, ticketTypeIDs []int) {
+	if len(ticketTypeIDs) == 0 {
+		return
+	}
+	if err := s.tickets.ReleaseUserReservations(userID, ticketTypeIDs); err != nil {
+		fmt.Printf("Warning: failed to release held tickets for user %d: %v\n", userID, err)
+		return
+	}
+	s.invalidateAvailability(eventID)
+}
+
+// invalidateAvailability drops the cached availability of an event, if set
+func (s *OrderAmendmentService) invalidateAvailability(eventID int) {
+	if s.availability != nil {
+		s.availability.InvalidateAvailability(eventID)
+	}
+}
@@ -0,0 +1,367 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/repositories"
+)
+
+// memoryAmendments keeps an order, its tickets and its amendments in memory
+type memoryAmendments struct {
+	order       *models.Order
+	event       *models.Event
+	ticketTypes []*models.TicketType
+	tickets     []*models.Ticket
+	prices      map[int]int
+	amendments  map[int]*models.OrderAmendment
+	issued      int
+	resent      []int
+	failApply   error // returned by ApplyAmendment, as when a ticket is scanned mid-change
+}
+
+func newMemoryAmendments() *memoryAmendments {
+	general := &models.TicketType{ID: 1, Name: "General", Price: 1000, Quantity: 100, Sold: 2,
+		SaleStart: time.Now().Add(-time.Hour), SaleEnd: time.Now().Add(48 * time.Hour)}
+	vip := &models.TicketType{ID: 2, Name: "VIP", Price: 2500, Quantity: 10,
+		SaleStart: time.Now().Add(-time.Hour), SaleEnd: time.Now().Add(48 * time.Hour)}
+	return &memoryAmendments{
+		order: &models.Order{ID: 5, OrderNumber: "ORD-20260101-000005", UserID: 9, EventID: 3,
+			TotalAmount: 1600, Status: models.OrderCompleted, PaymentID: "PAY-1"},
+		event:       &models.Event{ID: 3, Title: "Gala", StartDate: time.Now().Add(72 * time.Hour)},
+		ticketTypes: []*models.TicketType{general, vip},
+		tickets: []*models.Ticket{
+			{ID: 11, OrderID: 5, TicketTypeID: 1, Status: models.TicketActive},
+			{ID: 12, OrderID: 5, TicketTypeID: 1, Status: models.TicketActive},
+		},
+		// The general tickets were bought on an early bird discount
+		prices:     map[int]int{1: 800},
+		amendments: make(map[int]*models.OrderAmendment),
+	}
+}
+
+func (m *memoryAmendments) CreateAmendment(amendment *models.OrderAmendment) error {
+	for _, pending := range m.amendments {
+		if pending.OrderID == amendment.OrderID && pending.IsPending() {
+			pending.Status = models.OrderAmendmentCancelled
+		}
+	}
+	amendment.ID = len(m.amendments) + 1
+	amendment.Status = models.OrderAmendmentPending
+	stored := *amendment
+	m.amendments[amendment.ID] = &stored
+	return nil
+}
+
+func (m *memoryAmendments) GetAmendment(id int) (*models.OrderAmendment, error) {
+	if amendment, ok := m.amendments[id]; ok {
+		copied := *amendment
+		return &copied, nil
+	}
+	return nil, nil
+}
+
+func (m *memoryAmendments) GetAmendmentByPaymentReference(reference string) (*models.OrderAmendment, error) {
+	for _, amendment := range m.amendments {
+		if reference != "" && amendment.PaymentReference == reference {
+			copied := *amendment
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryAmendments) GetPendingAmendment(orderID int) (*models.OrderAmendment, error) {
+	for _, amendment := range m.amendments {
+		if amendment.OrderID == orderID && amendment.IsPending() {
+			copied := *amendment
+			return &copied, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *memoryAmendments) SetPaymentReference(id int, reference string) error {
+	m.amendments[id].PaymentReference = reference
+	return nil
+}
+
+func (m *memoryAmendments) CancelAmendment(id int) error {
+	if !m.amendments[id].IsPending() {
+		return repositories.ErrAmendmentNotPending
+	}
+	m.amendments[id].Status = models.OrderAmendmentCancelled
+	return nil
+}
+
+func (m *memoryAmendments) CompleteRefund(id int, reference string) error {
+	if m.amendments[id].Status != models.OrderAmendmentProcessing {
+		return errors.New("amendment is not processing")
+	}
+	m.amendments[id].Status = models.OrderAmendmentCompleted
+	m.amendments[id].PaymentReference = reference
+	return nil
+}
+
+func (m *memoryAmendments) ApplyAmendment(amendment *models.OrderAmendment, voidTicketIDs []int, ticketData []struct {
+	TicketTypeID int
+	QRCode       string
+}) error {
+	if m.failApply != nil {
+		return m.failApply
+	}
+	stored := m.amendments[amendment.ID]
+	if !stored.IsPending() {
+		return repositories.ErrAmendmentNotPending
+	}
+	stored.Status = models.OrderAmendmentCompleted
+	if amendment.Refund() > 0 {
+		stored.Status = models.OrderAmendmentProcessing
+	}
+	stored.PaymentReference = amendment.PaymentReference
+
+	for _, id := range voidTicketIDs {
+		for _, ticket := range m.tickets {
+			if ticket.ID == id {
+				ticket.Status = models.TicketRefunded
+			}
+		}
+	}
+	for _, data := range ticketData {
+		m.tickets = append(m.tickets, &models.Ticket{ID: 100 + len(m.tickets), OrderID: m.order.ID, TicketTypeID: data.TicketTypeID, Status: models.TicketActive})
+	}
+	m.issued += len(ticketData)
+	m.order.TotalAmount += amendment.PriceDelta
+	return nil
+}
+
+func (m *memoryAmendments) GetByID(id int) (*models.Order, error) {
+	if id == m.order.ID {
+		return m.order, nil
+	}
+	return nil, errors.New("order not found")
+}
+
+func (m *memoryAmendments) GetItemPrices(orderID int) (map[int]int, error) {
+	return m.prices, nil
+}
+
+func (m *memoryAmendments) GetTicketsByOrder(orderID int) ([]*models.Ticket, error) {
+	return m.tickets, nil
+}
+
+func (m *memoryAmendments) GetTicketTypesByEvent(eventID int) ([]*models.TicketType, error) {
+	return m.ticketTypes, nil
+}
+
+func (m *memoryAmendments) HoldTickets(userID int, holds []repositories.TicketHold, expirationMinutes int) ([]*repositories.TicketReservation, error) {
+	return nil, nil
+}
+
+func (m *memoryAmendments) ReleaseUserReservations(userID int, ticketTypeIDs []int) error {
+	return nil
+}
+
+func (m *memoryAmendments) ResendOrderTickets(orderID int) error {
+	m.resent = append(m.resent, orderID)
+	return nil
+}
+
+// amendmentEvents serves the event of the order being changed
+type amendmentEvents struct {
+	event *models.Event
+}
+
+func (e amendmentEvents) GetByID(id int) (*models.Event, error) {
+	return e.event, nil
+}
+
+// recordingPayments records the payments and refunds of order changes
+type recordingPayments struct {
+	status   string
+	charged  []int
+	refunded []int
+}
+
+func (p *recordingPayments) ProcessPayment(amount int, paymentMethod string, billingInfo PaymentBillingInfo) (*PaymentResult, error) {
+	p.charged = append(p.charged, amount)
+	return &PaymentResult{PaymentID: "PAY-DIFF", Status: p.status, Amount: amount, AuthorizationURL: "https://pay.example.com/diff"}, nil
+}
+
+func (p *recordingPayments) RefundPayment(paymentID string, amount int) (*RefundResult, error) {
+	p.refunded = append(p.refunded, amount)
+	return &RefundResult{RefundID: "REF-DIFF", Status: "success", Amount: amount}, nil
+}
+
+func (p *recordingPayments) GetPaymentStatus(paymentID string) (*PaymentStatus, error) {
+	return &PaymentStatus{PaymentID: paymentID, Status: p.status}, nil
+}
+
+func newTestAmendmentService(status string) (*OrderAmendmentService, *memoryAmendments, *recordingPayments) {
+	store := newMemoryAmendments()
+	payments := &recordingPayments{status: status}
+	service := NewOrderAmendmentService(store, store, store, amendmentEvents{store.event}, payments, store)
+	return service, store, payments
+}
+
+func TestOrderAmendmentService_Upgrade(t *testing.T) {
+	service, store, payments := newTestAmendmentService("success")
+
+	// Upgrade one of the two general tickets to VIP
+	amendment, err := service.RequestAmendment(5, 9, map[int]int{1: 1, 2: 1})
+	if err != nil {
+		t.Fatalf("RequestAmendment() error = %v", err)
+	}
+	// The general ticket is refunded at the discounted price paid for it
+	if amendment.PriceDelta != 2500-800 {
+		t.Errorf("PriceDelta = %d, want %d", amendment.PriceDelta, 2500-800)
+	}
+
+	confirmation, err := service.ConfirmAmendment(amendment.ID, 9)
+	if err != nil {
+		t.Fatalf("ConfirmAmendment() error = %v", err)
+	}
+	if confirmation.Amendment.IsPending() || store.amendments[amendment.ID].Status != models.OrderAmendmentCompleted {
+		t.Errorf("amendment status = %s, want completed", store.amendments[amendment.ID].Status)
+	}
+	if len(payments.charged) != 1 || payments.charged[0] != 1700 {
+		t.Errorf("charged %v, want [1700]", payments.charged)
+	}
+	if store.tickets[1].Status != models.TicketRefunded || store.issued != 1 {
+		t.Errorf("voided ticket status = %s and issued %d, want refunded and 1", store.tickets[1].Status, store.issued)
+	}
+	if store.order.TotalAmount != 3300 {
+		t.Errorf("order total = %d, want 3300", store.order.TotalAmount)
+	}
+	if len(store.resent) != 1 {
+		t.Errorf("resent tickets %d times, want once", len(store.resent))
+	}
+}
+
+func TestOrderAmendmentService_AwaitsPayment(t *testing.T) {
+	service, store, payments := newTestAmendmentService("pending")
+
+	amendment, err := service.RequestAmendment(5, 9, map[int]int{1: 3})
+	if err != nil {
+		t.Fatalf("RequestAmendment() error = %v", err)
+	}
+	confirmation, err := service.ConfirmAmendment(amendment.ID, 9)
+	if err != nil {
+		t.Fatalf("ConfirmAmendment() error = %v", err)
+	}
+	if confirmation.AuthorizationURL == "" || store.issued != 0 {
+		t.Fatalf("confirmation = %+v with %d tickets issued, want a redirect to pay first", confirmation, store.issued)
+	}
+
+	// The provider redirects the buyer back once they have paid
+	payments.status = "success"
+	completed, err := service.CompletePaidAmendment("PAY-DIFF")
+	if err != nil {
+		t.Fatalf("CompletePaidAmendment() error = %v", err)
+	}
+	if completed == nil || completed.IsPending() || store.issued != 1 {
+		t.Errorf("completed = %+v with %d tickets issued, want the amendment applied", completed, store.issued)
+	}
+	if len(payments.charged) != 1 {
+		t.Errorf("charged %d times, want once", len(payments.charged))
+	}
+
+	if other, err := service.CompletePaidAmendment("PAY-CHECKOUT"); other != nil || err != nil {
+		t.Errorf("CompletePaidAmendment() of a checkout payment = %+v, %v, want nil, nil", other, err)
+	}
+}
+
+func TestOrderAmendmentService_RefundsRemovedTickets(t *testing.T) {
+	service, store, payments := newTestAmendmentService("success")
+
+	amendment, err := service.RequestAmendment(5, 9, map[int]int{1: 1})
+	if err != nil {
+		t.Fatalf("RequestAmendment() error = %v", err)
+	}
+	if _, err := service.ConfirmAmendment(amendment.ID, 9); err != nil {
+		t.Fatalf("ConfirmAmendment() error = %v", err)
+	}
+
+	if len(payments.charged) != 0 || len(payments.refunded) != 1 || payments.refunded[0] != 800 {
+		t.Errorf("charged %v and refunded %v, want a refund of 800", payments.charged, payments.refunded)
+	}
+	if store.amendments[amendment.ID].PaymentReference != "REF-DIFF" {
+		t.Errorf("PaymentReference = %q, want the refund", store.amendments[amendment.ID].PaymentReference)
+	}
+}
+
+func TestOrderAmendmentService_AppliedOnce(t *testing.T) {
+	service, store, payments := newTestAmendmentService("pending")
+
+	amendment, err := service.RequestAmendment(5, 9, map[int]int{1: 3})
+	if err != nil {
+		t.Fatalf("RequestAmendment() error = %v", err)
+	}
+	if _, err := service.ConfirmAmendment(amendment.ID, 9); err != nil {
+		t.Fatalf("ConfirmAmendment() error = %v", err)
+	}
+	payments.status = "success"
+	stale, _ := store.GetAmendment(amendment.ID)
+	if _, err := service.CompletePaidAmendment("PAY-DIFF"); err != nil {
+		t.Fatalf("CompletePaidAmendment() error = %v", err)
+	}
+
+	// A request that read the amendment before it was applied doesn't refund
+	// the payment or issue the tickets again
+	order, _ := store.GetByID(5)
+	if err := service.applyPaid(stale, order); err != nil {
+		t.Fatalf("applyPaid() of an applied amendment error = %v", err)
+	}
+	if stale.Status != models.OrderAmendmentCompleted || store.amendments[amendment.ID].Status != models.OrderAmendmentCompleted {
+		t.Errorf("amendment status = %s, want completed", store.amendments[amendment.ID].Status)
+	}
+	if len(payments.refunded) != 0 || store.issued != 1 {
+		t.Errorf("refunded %v and issued %d, want no refund and 1", payments.refunded, store.issued)
+	}
+}
+
+func TestOrderAmendmentService_RefundsOnlyChangedOrders(t *testing.T) {
+	service, store, payments := newTestAmendmentService("success")
+
+	amendment, err := service.RequestAmendment(5, 9, map[int]int{1: 1})
+	if err != nil {
+		t.Fatalf("RequestAmendment() error = %v", err)
+	}
+	store.failApply = errors.New("tickets have been scanned")
+	if _, err := service.ConfirmAmendment(amendment.ID, 9); err == nil {
+		t.Fatal("ConfirmAmendment() = nil error, want an error")
+	}
+	if len(payments.refunded) != 0 || !store.amendments[amendment.ID].IsPending() {
+		t.Errorf("refunded %v with amendment %s, want no refund and still pending", payments.refunded, store.amendments[amendment.ID].Status)
+	}
+}
+
+func TestOrderAmendmentService_Rejects(t *testing.T) {
+	service, store, _ := newTestAmendmentService("success")
+	store.tickets[1].Status = models.TicketUsed
+
+	tests := map[string]map[int]int{
+		"unchanged":          {1: 2},
+		"no tickets left":    {1: 0},
+		"scanned ticket":     {1: 0, 2: 2},
+		"unknown type":       {99: 1},
+		"negative quantity":  {2: -1},
+		"more than are left": {2: 11},
+	}
+	for name, quantities := range tests {
+		if _, err := service.RequestAmendment(5, 9, quantities); err == nil {
+			t.Errorf("%s: RequestAmendment() = nil error, want an error", name)
+		}
+	}
+
+	if _, err := service.RequestAmendment(5, 10, map[int]int{2: 1}); err == nil {
+		t.Error("RequestAmendment() let another user change the order")
+	}
+
+	store.event.StartDate = time.Now().Add(models.OrderAmendmentCutoff / 2)
+	if _, err := service.RequestAmendment(5, 9, map[int]int{2: 1}); !errors.Is(err, ErrAmendmentClosed) {
+		t.Errorf("RequestAmendment() after the cutoff error = %v, want ErrAmendmentClosed", err)
+	}
+}
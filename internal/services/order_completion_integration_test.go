@@ -66,8 +66,13 @@ func TestOrderCompletionIntegration(t *testing.T) {
 
 		service := NewOrderService(orderRepo, ticketRepo, userRepo, paymentService, emailService)
 
+		email, err := service.orderConfirmationEmail(order, user, tickets)
+		if err != nil {
+			t.Fatalf("Failed to render order confirmation: %v", err)
+		}
+
 		// Test HTML email generation
-		htmlContent := service.generateOrderConfirmationHTML(order, user, tickets)
+		htmlContent := email.HTML
 		
 		// Verify HTML contains expected elements
 		expectedHTMLElements := []string{
@@ -88,7 +93,7 @@ func TestOrderCompletionIntegration(t *testing.T) {
 		t.Logf("Generated HTML content: %s", htmlContent)
 
 		// Test text email generation
-		textContent := service.generateOrderConfirmationText(order, user, tickets)
+		textContent := email.Text
 		
 		// Verify text contains expected elements
 		expectedTextElements := []string{
@@ -209,8 +214,11 @@ func TestEmailContentGeneration(t *testing.T) {
 			{ID: 1, QRCode: "TKT-SINGLE-123"},
 		}
 
-		htmlSingle := service.generateOrderConfirmationHTML(singleTicketOrder, user, singleTicket)
-		textSingle := service.generateOrderConfirmationText(singleTicketOrder, user, singleTicket)
+		single, err := service.orderConfirmationEmail(singleTicketOrder, user, singleTicket)
+		if err != nil {
+			t.Fatalf("Failed to render order confirmation: %v", err)
+		}
+		htmlSingle, textSingle := single.HTML, single.Text
 
 		if !containsString(htmlSingle, "1 tickets") {
 			t.Error("Single ticket HTML should mention '1 tickets'")
@@ -236,8 +244,11 @@ func TestEmailContentGeneration(t *testing.T) {
 			{ID: 3, QRCode: "TKT-MULTI-789"},
 		}
 
-		htmlMulti := service.generateOrderConfirmationHTML(multiTicketOrder, user, multiTickets)
-		textMulti := service.generateOrderConfirmationText(multiTicketOrder, user, multiTickets)
+		multi, err := service.orderConfirmationEmail(multiTicketOrder, user, multiTickets)
+		if err != nil {
+			t.Fatalf("Failed to render order confirmation: %v", err)
+		}
+		htmlMulti, textMulti := multi.HTML, multi.Text
 
 		if !containsString(htmlMulti, "3 tickets") {
 			t.Error("Multi ticket HTML should mention '3 tickets'")
@@ -38,6 +38,9 @@ func (m *MockTicketRepository) UpdateTicketType(id int, req *models.TicketTypeUp
 func (m *MockTicketRepository) DeleteTicketType(id int) error { return nil }
 func (m *MockTicketRepository) ReserveTickets(ticketTypeID, quantity, userID int, expirationMinutes int) (*repositories.TicketReservation, error) { return nil, nil }
 func (m *MockTicketRepository) ReleaseReservation(reservationID string, ticketTypeID, quantity int) error { return nil }
+func (m *MockTicketRepository) HoldTickets(userID int, holds []repositories.TicketHold, expirationMinutes int) ([]*repositories.TicketReservation, error) { return nil, nil }
+func (m *MockTicketRepository) ReleaseUserReservations(userID int, ticketTypeIDs []int) error { return nil }
+func (m *MockTicketRepository) DeleteExpiredReservations() (int64, error) { return 0, nil }
 func (m *MockTicketRepository) CreateTicket(orderID, ticketTypeID int, qrCode string) (*models.Ticket, error) { return nil, nil }
 func (m *MockTicketRepository) GetTicketByID(id int) (*models.Ticket, error) { return nil, nil }
 func (m *MockTicketRepository) GetTicketByQRCode(qrCode string) (*models.Ticket, error) { return nil, nil }
@@ -95,6 +98,7 @@ func (m *MockOrderRepository) GetOrdersWithDetails(filters repositories.OrderSea
 func (m *MockOrderRepository) GetOrderStatistics(eventID *int, userID *int) (map[string]interface{}, error) { return nil, nil }
 func (m *MockOrderRepository) GetOrderCount() (int, error) { return len(m.orders), nil }
 func (m *MockOrderRepository) GetTotalRevenue() (float64, error) { return 0.0, nil }
+func (m *MockOrderRepository) GetItemPrices(orderID int) (map[int]int, error) { return nil, nil }
 
 // Use existing MockPaymentService from mock_payment.go
 
@@ -179,7 +183,11 @@ func TestOrderService_generateOrderConfirmationHTML(t *testing.T) {
 		{ID: 2, QRCode: "TKT-456"},
 	}
 
-	html := service.generateOrderConfirmationHTML(order, user, tickets)
+	email, err := service.orderConfirmationEmail(order, user, tickets)
+	if err != nil {
+		t.Fatalf("Failed to render order confirmation: %v", err)
+	}
+	html := email.HTML
 
 	// Verify HTML contains expected content
 	expectedContent := []string{
@@ -228,7 +236,11 @@ func TestOrderService_generateOrderConfirmationText(t *testing.T) {
 		{ID: 2, QRCode: "TKT-456"},
 	}
 
-	text := service.generateOrderConfirmationText(order, user, tickets)
+	email, err := service.orderConfirmationEmail(order, user, tickets)
+	if err != nil {
+		t.Fatalf("Failed to render order confirmation: %v", err)
+	}
+	text := email.Text
 
 	// Verify text contains expected content
 	expectedContent := []string{
@@ -249,3 +261,34 @@ func TestOrderService_generateOrderConfirmationText(t *testing.T) {
 	}
 }
 
+func TestOrderService_orderConfirmationEmailEventInfo(t *testing.T) {
+	service := NewOrderService(NewMockOrderRepository(), NewMockTicketRepository(), &MockUserRepository{}, NewMockPaymentService(nil, nil), NewMockEmailService(nil))
+
+	faqRepo := newMockEventFAQRepository()
+	faqRepo.policies[7] = &models.EventPolicies{EventID: 7, RefundPolicy: "Refunds until June 1", MinimumAge: 18}
+	faqRepo.faqs[1] = &models.EventFAQ{ID: 1, EventID: 7, Question: "Is there parking?", Answer: "Yes"}
+	service.SetEventInfo(NewEventFAQService(faqRepo))
+
+	user := &models.User{ID: 1, FirstName: "John", LastName: "Doe"}
+	order := &models.Order{ID: 1, EventID: 7, OrderNumber: "ORD-1", Status: models.OrderCompleted, BillingEmail: "test@example.com"}
+
+	email, err := service.orderConfirmationEmail(order, user, nil)
+	if err != nil {
+		t.Fatalf("Failed to render order confirmation: %v", err)
+	}
+	for _, content := range []string{"Refund policy: Refunds until June 1", "ages 18 and over", "/events/7#faqs"} {
+		if !containsString(email.Text, content) {
+			t.Errorf("Text does not contain expected content: %s", content)
+		}
+	}
+
+	// Events without FAQs or policies keep the default notice
+	order.EventID = 8
+	email, err = service.orderConfirmationEmail(order, user, nil)
+	if err != nil {
+		t.Fatalf("Failed to render order confirmation: %v", err)
+	}
+	if !containsString(email.Text, "non-refundable") || containsString(email.Text, "#faqs") {
+		t.Errorf("expected the default notice without an FAQ link, got %s", email.Text)
+	}
+}
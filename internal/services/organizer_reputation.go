@@ -0,0 +1,142 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// OrganizerReputationRepository defines the data operations for organizer reputation
+type OrganizerReputationRepository interface {
+	GetHistory(organizerID int) (*models.OrganizerHistory, error)
+	CreateReport(report *models.EventReport) (bool, error)
+}
+
+// ModerationSettingsProvider provides the moderation settings reputation is
+// checked against
+type ModerationSettingsProvider interface {
+	GetSettings() (*models.SystemSettings, error)
+}
+
+// Reputation score weights. Scores start neutral and move up with approved
+// events and sales, and down with rejections, refunds and reports.
+const (
+	reputationBaseScore       = 50
+	reputationPerApproved     = 5
+	reputationMaxApproved     = 30
+	reputationOrdersPerPoint  = 10
+	reputationMaxOrders       = 20
+	reputationPerRejected     = 10
+	reputationMaxRejected     = 30
+	reputationRefundRateScale = 50
+	reputationPerReport       = 5
+	reputationMaxReports      = 30
+)
+
+// OrganizerReputationService scores organizers on their track record and
+// decides whether their events may skip manual moderation
+type OrganizerReputationService struct {
+	repo     OrganizerReputationRepository
+	settings ModerationSettingsProvider
+}
+
+// NewOrganizerReputationService creates a new organizer reputation service
+func NewOrganizerReputationService(repo OrganizerReputationRepository, settings ModerationSettingsProvider) *OrganizerReputationService {
+	return &OrganizerReputationService{
+		repo:     repo,
+		settings: settings,
+	}
+}
+
+// ComputeReputationScore scores an organizer's history from 0 to 100
+func ComputeReputationScore(history *models.OrganizerHistory) int {
+	score := reputationBaseScore
+	score += min(history.ApprovedEvents*reputationPerApproved, reputationMaxApproved)
+	score += min(history.CompletedOrders/reputationOrdersPerPoint, reputationMaxOrders)
+	score -= min(history.RejectedEvents*reputationPerRejected, reputationMaxRejected)
+	score -= int(history.RefundRate()*reputationRefundRateScale + 0.5)
+	score -= min(history.Reports*reputationPerReport, reputationMaxReports)
+	return max(0, min(100, score))
+}
+
+// GetReputation returns an organizer's reputation and whether it currently
+// lets them publish without moderation
+func (s *OrganizerReputationService) GetReputation(organizerID int) (*models.OrganizerReputation, error) {
+	history, err := s.repo.GetHistory(organizerID)
+	if err != nil {
+		return nil, err
+	}
+
+	settings, err := s.settings.GetSettings()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get moderation settings: %w", err)
+	}
+
+	reputation := &models.OrganizerReputation{
+		History: history,
+		Score:   ComputeReputationScore(history),
+	}
+	reputation.FastTrack = settings.ReputationFastTrack &&
+		history.ApprovedEvents >= settings.ReputationMinApprovedEvents &&
+		reputation.Score >= settings.ReputationAutoPublishScore
+
+	return reputation, nil
+}
+
+// RequiresModeration returns true if the organizer's events must be approved
+// by a moderator before they are published. When moderation is disabled no
+// one needs approval; otherwise only fast-tracked organizers skip it.
+func (s *OrganizerReputationService) RequiresModeration(organizerID int) (bool, error) {
+	settings, err := s.settings.GetSettings()
+	if err != nil {
+		return true, fmt.Errorf("failed to get moderation settings: %w", err)
+	}
+	if !settings.EventModerationEnabled {
+		return false, nil
+	}
+	if !settings.ReputationFastTrack {
+		return true, nil
+	}
+
+	reputation, err := s.GetReputation(organizerID)
+	if err != nil {
+		return true, err
+	}
+	return !reputation.FastTrack, nil
+}
+
+// GetReputations returns the reputation of each organizer, keyed by organizer ID
+func (s *OrganizerReputationService) GetReputations(organizerIDs []int) (map[int]*models.OrganizerReputation, error) {
+	reputations := make(map[int]*models.OrganizerReputation)
+	for _, organizerID := range organizerIDs {
+		if _, ok := reputations[organizerID]; ok {
+			continue
+		}
+		reputation, err := s.GetReputation(organizerID)
+		if err != nil {
+			return nil, err
+		}
+		reputations[organizerID] = reputation
+	}
+	return reputations, nil
+}
+
+// ReportEvent records an attendee's report of an event. Reporting the same
+// event twice is not an error but only counts once.
+func (s *OrganizerReputationService) ReportEvent(eventID, reporterID int, reason models.EventReportReason, details string) error {
+	report := &models.EventReport{
+		EventID:    eventID,
+		ReporterID: reporterID,
+		Reason:     reason,
+		Details:    strings.TrimSpace(details),
+	}
+	if err := report.Validate(); err != nil {
+		return err
+	}
+
+	if _, err := s.repo.CreateReport(report); err != nil {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,204 @@
+package services
+
+import (
+	"errors"
+	"os"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock OrganizerReputationRepository for testing
+type mockOrganizerReputationRepository struct {
+	histories map[int]*models.OrganizerHistory
+	reports   []*models.EventReport
+	err       error
+}
+
+func (m *mockOrganizerReputationRepository) GetHistory(organizerID int) (*models.OrganizerHistory, error) {
+	if m.err != nil {
+		return nil, m.err
+	}
+	if history, ok := m.histories[organizerID]; ok {
+		return history, nil
+	}
+	return &models.OrganizerHistory{OrganizerID: organizerID}, nil
+}
+
+func (m *mockOrganizerReputationRepository) CreateReport(report *models.EventReport) (bool, error) {
+	for _, existing := range m.reports {
+		if existing.EventID == report.EventID && existing.ReporterID == report.ReporterID {
+			return false, nil
+		}
+	}
+	report.ID = len(m.reports) + 1
+	m.reports = append(m.reports, report)
+	return true, nil
+}
+
+// mockModerationSettingsProvider returns fixed system settings
+type mockModerationSettingsProvider struct {
+	settings *models.SystemSettings
+}
+
+func (m *mockModerationSettingsProvider) GetSettings() (*models.SystemSettings, error) {
+	return m.settings, nil
+}
+
+func setupOrganizerReputationService() (*OrganizerReputationService, *mockOrganizerReputationRepository, *models.SystemSettings) {
+	repo := &mockOrganizerReputationRepository{histories: map[int]*models.OrganizerHistory{}}
+	settings := models.DefaultSettings()
+	return NewOrganizerReputationService(repo, &mockModerationSettingsProvider{settings: settings}), repo, settings
+}
+
+func TestComputeReputationScore(t *testing.T) {
+	tests := []struct {
+		name    string
+		history models.OrganizerHistory
+		want    int
+	}{
+		{"new organizer", models.OrganizerHistory{}, 50},
+		{"approved events", models.OrganizerHistory{ApprovedEvents: 3}, 65},
+		{"approved events capped", models.OrganizerHistory{ApprovedEvents: 20}, 80},
+		{"sales", models.OrganizerHistory{ApprovedEvents: 4, CompletedOrders: 95}, 79},
+		{"rejections", models.OrganizerHistory{ApprovedEvents: 2, RejectedEvents: 2}, 40},
+		{"refunds", models.OrganizerHistory{ApprovedEvents: 6, CompletedOrders: 60, RefundedOrders: 40}, 66},
+		{"reports", models.OrganizerHistory{ApprovedEvents: 4, Reports: 3}, 55},
+		{"clamped at zero", models.OrganizerHistory{RejectedEvents: 5, Reports: 10, RefundedOrders: 10}, 0},
+		{"clamped at 100", models.OrganizerHistory{ApprovedEvents: 10, CompletedOrders: 1000}, 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ComputeReputationScore(&tt.history); got != tt.want {
+				t.Errorf("expected score %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestOrganizerReputationService_RequiresModeration(t *testing.T) {
+	service, repo, settings := setupOrganizerReputationService()
+	repo.histories[1] = &models.OrganizerHistory{OrganizerID: 1, ApprovedEvents: 5, CompletedOrders: 200}
+	repo.histories[2] = &models.OrganizerHistory{OrganizerID: 2, ApprovedEvents: 1, CompletedOrders: 400}
+	repo.histories[3] = &models.OrganizerHistory{OrganizerID: 3, ApprovedEvents: 5, RejectedEvents: 2, Reports: 4}
+
+	check := func(organizerID int, want bool) {
+		t.Helper()
+		got, err := service.RequiresModeration(organizerID)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got != want {
+			t.Errorf("organizer %d: expected RequiresModeration %v, got %v", organizerID, want, got)
+		}
+	}
+
+	check(1, false) // trusted organizer
+	check(2, true)  // high score but too few approved events
+	check(3, true)  // enough events but low score
+	check(4, true)  // new organizer
+
+	settings.ReputationAutoPublishScore = 30
+	check(3, false)
+
+	settings.ReputationFastTrack = false
+	check(1, true)
+
+	settings.EventModerationEnabled = false
+	check(4, false)
+}
+
+func TestOrganizerReputationService_RequiresModerationFailsSafe(t *testing.T) {
+	service, repo, _ := setupOrganizerReputationService()
+	repo.err = errors.New("database unavailable")
+
+	requiresModeration, err := service.RequiresModeration(1)
+	if err == nil {
+		t.Error("expected error")
+	}
+	if !requiresModeration {
+		t.Error("expected moderation to be required when reputation cannot be checked")
+	}
+}
+
+func TestOrganizerReputationService_ReportEvent(t *testing.T) {
+	service, repo, _ := setupOrganizerReputationService()
+
+	if err := service.ReportEvent(1, 2, models.ReportScam, "  Asked me to pay by M-Pesa to a personal number  "); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.reports) != 1 || repo.reports[0].Details != "Asked me to pay by M-Pesa to a personal number" {
+		t.Fatalf("expected a trimmed report, got %+v", repo.reports)
+	}
+
+	if err := service.ReportEvent(1, 2, models.ReportMisleading, ""); err != nil {
+		t.Errorf("expected reporting twice not to fail, got %v", err)
+	}
+	if len(repo.reports) != 1 {
+		t.Errorf("expected duplicate report to be ignored, got %d reports", len(repo.reports))
+	}
+
+	if err := service.ReportEvent(1, 3, "spam", ""); err == nil {
+		t.Error("expected error for unknown reason")
+	}
+}
+
+func TestEventService_ReputationModeration(t *testing.T) {
+	service, eventRepo, userRepo := setupEventService()
+	defer os.RemoveAll(service.uploadPath)
+
+	reputation, repo, _ := setupOrganizerReputationService()
+	service.SetReputationService(reputation)
+	createTestUser(userRepo, 1, models.RoleOrganizer)
+	createTestUser(userRepo, 2, models.RoleOrganizer)
+	createTestUser(userRepo, 3, models.RoleAdmin)
+	repo.histories[2] = &models.OrganizerHistory{OrganizerID: 2, ApprovedEvents: 6, CompletedOrders: 150}
+
+	request := func(organizerID int) *EventCreateRequest {
+		return &EventCreateRequest{
+			Title:       "Jazz Night",
+			Description: "An evening of live jazz",
+			StartDate:   time.Now().Add(24 * time.Hour),
+			EndDate:     time.Now().Add(26 * time.Hour),
+			Location:    "Nairobi",
+			CategoryID:  1,
+			Status:      models.StatusPublished,
+			OrganizerID: organizerID,
+		}
+	}
+
+	event, err := service.CreateEvent(request(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.Status != models.StatusPendingReview {
+		t.Errorf("expected new organizer's event to be held for review, got %s", event.Status)
+	}
+
+	event, err = service.CreateEvent(request(2))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.Status != models.StatusPublished {
+		t.Errorf("expected trusted organizer's event to be published, got %s", event.Status)
+	}
+
+	event, err = service.CreateEvent(request(3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.Status != models.StatusPublished {
+		t.Errorf("expected admin's event to be published, got %s", event.Status)
+	}
+
+	draft := createTestEvent(eventRepo, 50, 1)
+	event, err = service.UpdateEventStatus(draft.ID, models.StatusPublished, 1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if event.Status != models.StatusPendingReview {
+		t.Errorf("expected publishing a draft to hold it for review, got %s", event.Status)
+	}
+}
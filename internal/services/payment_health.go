@@ -0,0 +1,168 @@
+package services
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// PaymentHealthOptions controls how payment provider health is judged
+type PaymentHealthOptions struct {
+	// Window is how far back payment attempts count towards the success rate
+	Window time.Duration
+	// MinAttempts is how many attempts the window needs before a provider can be degraded
+	MinAttempts int
+	// DegradedBelow is the success rate, between 0 and 1, below which a provider is degraded
+	DegradedBelow float64
+	// AutoFailover preselects a healthy provider for new checkouts while the default is degraded
+	AutoFailover bool
+}
+
+// PaymentHealthAlerter is notified when a payment provider becomes degraded
+// or recovers
+type PaymentHealthAlerter interface {
+	PaymentHealthChanged(health *models.PaymentProviderHealth)
+}
+
+// paymentAttempt is the outcome of a single payment attempt
+type paymentAttempt struct {
+	at      time.Time
+	success bool
+}
+
+// PaymentHealthService tracks rolling success rates of the payment providers
+// offered at checkout. Alerts are sent when a provider's health changes, and
+// checkout uses the rates to warn buyers away from failing providers.
+type PaymentHealthService struct {
+	options PaymentHealthOptions
+	alerter PaymentHealthAlerter
+	now     func() time.Time
+
+	mu       sync.Mutex
+	attempts map[string][]paymentAttempt
+	degraded map[string]bool // Last health reported to the alerter
+}
+
+// NewPaymentHealthService creates a new payment health service
+func NewPaymentHealthService(options PaymentHealthOptions) *PaymentHealthService {
+	return &PaymentHealthService{
+		options:  options,
+		now:      time.Now,
+		attempts: make(map[string][]paymentAttempt),
+		degraded: make(map[string]bool),
+	}
+}
+
+// SetAlerter sets who is alerted when a provider becomes degraded or recovers
+func (s *PaymentHealthService) SetAlerter(alerter PaymentHealthAlerter) {
+	s.alerter = alerter
+}
+
+// RecordAttempt records whether a payment attempt with a provider succeeded
+func (s *PaymentHealthService) RecordAttempt(provider string, success bool) {
+	s.mu.Lock()
+	s.attempts[provider] = append(s.attempts[provider], paymentAttempt{at: s.now(), success: success})
+	health := s.healthLocked(provider)
+	changed := health.Degraded != s.degraded[provider]
+	s.degraded[provider] = health.Degraded
+	s.mu.Unlock()
+
+	if changed && s.alerter != nil {
+		s.alerter.PaymentHealthChanged(health)
+	}
+}
+
+// GetHealth returns the health of each checkout payment provider
+func (s *PaymentHealthService) GetHealth() []*models.PaymentProviderHealth {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	health := make([]*models.PaymentProviderHealth, len(models.PaymentMethods))
+	for i, provider := range models.PaymentMethods {
+		health[i] = s.healthLocked(provider)
+	}
+	return health
+}
+
+// CheckoutStatus returns the payment method to preselect for a new checkout,
+// the methods that are currently failing and a healthy one to suggest instead
+func (s *PaymentHealthService) CheckoutStatus() *models.CheckoutPaymentStatus {
+	status := &models.CheckoutPaymentStatus{DefaultMethod: models.DefaultPaymentMethod}
+	for _, health := range s.GetHealth() {
+		if health.Degraded {
+			status.Degraded = append(status.Degraded, health.Provider)
+		} else if status.Suggested == "" {
+			status.Suggested = health.Provider
+		}
+	}
+
+	if len(status.Degraded) == 0 {
+		status.Suggested = ""
+	} else if s.options.AutoFailover && status.Suggested != "" && status.IsDegraded(models.DefaultPaymentMethod) {
+		status.DefaultMethod = status.Suggested
+	}
+	return status
+}
+
+// healthLocked drops attempts that have left the window and returns the
+// provider's current health. The caller must hold s.mu.
+func (s *PaymentHealthService) healthLocked(provider string) *models.PaymentProviderHealth {
+	cutoff := s.now().Add(-s.options.Window)
+	attempts := s.attempts[provider]
+	for len(attempts) > 0 && attempts[0].at.Before(cutoff) {
+		attempts = attempts[1:]
+	}
+	s.attempts[provider] = attempts
+
+	health := &models.PaymentProviderHealth{Provider: provider, Attempts: len(attempts), SuccessRate: 1}
+	for _, attempt := range attempts {
+		if !attempt.success {
+			health.Failures++
+			at := attempt.at
+			health.LastFailure = &at
+		}
+	}
+	if health.Attempts > 0 {
+		health.SuccessRate = float64(health.Attempts-health.Failures) / float64(health.Attempts)
+	}
+	health.Degraded = health.Attempts >= s.options.MinAttempts && health.SuccessRate < s.options.DegradedBelow
+	return health
+}
+
+// MonitoredPaymentService records the outcome of each payment it processes
+// with a PaymentHealthService. Payments that are still pending, like
+// redirect-based checkouts, count as successful once they were accepted by
+// the provider.
+type MonitoredPaymentService struct {
+	PaymentService
+	health *PaymentHealthService
+}
+
+// NewMonitoredPaymentService wraps a payment service to monitor its health
+func NewMonitoredPaymentService(payments PaymentService, health *PaymentHealthService) *MonitoredPaymentService {
+	return &MonitoredPaymentService{
+		PaymentService: payments,
+		health:         health,
+	}
+}
+
+// ProcessPayment processes a payment and records whether it succeeded
+func (s *MonitoredPaymentService) ProcessPayment(amount int, paymentMethod string, billingInfo PaymentBillingInfo) (*PaymentResult, error) {
+	result, err := s.PaymentService.ProcessPayment(amount, paymentMethod, billingInfo)
+	s.health.RecordAttempt(paymentMethod, err == nil && result != nil && result.Status != "failed")
+	return result, err
+}
+
+// paymentHealthMessage returns the title and message announcing a change in
+// a provider's health
+func paymentHealthMessage(health *models.PaymentProviderHealth) (string, string) {
+	if health.Degraded {
+		return fmt.Sprintf("%s payments are failing", health.Label()),
+			fmt.Sprintf("Only %.0f%% of the last %d %s payment attempts succeeded. Buyers are being pointed to other payment methods.",
+				health.SuccessRate*100, health.Attempts, health.Label())
+	}
+	return fmt.Sprintf("%s payments have recovered", health.Label()),
+		fmt.Sprintf("%s payments are succeeding again.", health.Label())
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// mockPaymentHealthAlerter records provider health changes
+type mockPaymentHealthAlerter struct {
+	changes []*models.PaymentProviderHealth
+}
+
+func (m *mockPaymentHealthAlerter) PaymentHealthChanged(health *models.PaymentProviderHealth) {
+	m.changes = append(m.changes, health)
+}
+
+func setupPaymentHealthService(autoFailover bool) (*PaymentHealthService, *mockPaymentHealthAlerter, *time.Time) {
+	service := NewPaymentHealthService(PaymentHealthOptions{
+		Window:        10 * time.Minute,
+		MinAttempts:   4,
+		DegradedBelow: 0.8,
+		AutoFailover:  autoFailover,
+	})
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	alerter := &mockPaymentHealthAlerter{}
+	service.SetAlerter(alerter)
+	return service, alerter, &now
+}
+
+func recordAttempts(service *PaymentHealthService, provider string, successes, failures int) {
+	for i := 0; i < successes; i++ {
+		service.RecordAttempt(provider, true)
+	}
+	for i := 0; i < failures; i++ {
+		service.RecordAttempt(provider, false)
+	}
+}
+
+func TestPaymentHealthService_Degraded(t *testing.T) {
+	service, alerter, now := setupPaymentHealthService(false)
+
+	recordAttempts(service, models.PaymentMethodPaystack, 0, 3)
+	if service.GetHealth()[0].Degraded {
+		t.Error("expected provider with too few attempts not to be degraded")
+	}
+
+	recordAttempts(service, models.PaymentMethodPaystack, 1, 0)
+	health := service.GetHealth()[0]
+	if !health.Degraded || health.Attempts != 4 || health.Failures != 3 || health.SuccessRate != 0.25 {
+		t.Fatalf("expected degraded provider with 25%% success rate, got %+v", health)
+	}
+	if len(alerter.changes) != 1 || !alerter.changes[0].Degraded {
+		t.Fatalf("expected one degraded alert, got %+v", alerter.changes)
+	}
+
+	recordAttempts(service, models.PaymentMethodPaystack, 0, 1)
+	if len(alerter.changes) != 1 {
+		t.Errorf("expected no new alert while still degraded, got %d", len(alerter.changes))
+	}
+
+	// Failures leave the window and the provider recovers
+	*now = now.Add(11 * time.Minute)
+	recordAttempts(service, models.PaymentMethodPaystack, 4, 0)
+	health = service.GetHealth()[0]
+	if health.Degraded || health.Attempts != 4 {
+		t.Errorf("expected recovered provider with only recent attempts, got %+v", health)
+	}
+	if len(alerter.changes) != 2 || alerter.changes[1].Degraded {
+		t.Errorf("expected a recovery alert, got %+v", alerter.changes)
+	}
+}
+
+func TestPaymentHealthService_CheckoutStatus(t *testing.T) {
+	service, _, _ := setupPaymentHealthService(false)
+
+	status := service.CheckoutStatus()
+	if status.DefaultMethod != models.DefaultPaymentMethod || len(status.Degraded) != 0 || status.Suggested != "" {
+		t.Errorf("expected default checkout status while healthy, got %+v", status)
+	}
+
+	recordAttempts(service, models.PaymentMethodPaystack, 1, 4)
+	status = service.CheckoutStatus()
+	if !status.IsDegraded(models.PaymentMethodPaystack) || status.Suggested != models.PaymentMethodStripe {
+		t.Errorf("expected Paystack degraded with Stripe suggested, got %+v", status)
+	}
+	if status.DefaultMethod != models.DefaultPaymentMethod {
+		t.Errorf("expected default method to stay without auto failover, got %s", status.DefaultMethod)
+	}
+
+	recordAttempts(service, models.PaymentMethodStripe, 0, 4)
+	status = service.CheckoutStatus()
+	if status.Suggested != models.PaymentMethodPayPal {
+		t.Errorf("expected PayPal suggested when Stripe is also degraded, got %s", status.Suggested)
+	}
+}
+
+func TestPaymentHealthService_AutoFailover(t *testing.T) {
+	service, _, _ := setupPaymentHealthService(true)
+
+	recordAttempts(service, models.PaymentMethodStripe, 0, 4)
+	if status := service.CheckoutStatus(); status.DefaultMethod != models.DefaultPaymentMethod {
+		t.Errorf("expected healthy default to stay preselected, got %s", status.DefaultMethod)
+	}
+
+	recordAttempts(service, models.PaymentMethodPaystack, 0, 4)
+	if status := service.CheckoutStatus(); status.DefaultMethod != models.PaymentMethodPayPal {
+		t.Errorf("expected failover to PayPal, got %s", status.DefaultMethod)
+	}
+
+	recordAttempts(service, models.PaymentMethodPayPal, 0, 4)
+	if status := service.CheckoutStatus(); status.DefaultMethod != models.DefaultPaymentMethod || status.Suggested != "" {
+		t.Errorf("expected default to stay when every provider is degraded, got %+v", status)
+	}
+}
+
+func TestMonitoredPaymentService_ProcessPayment(t *testing.T) {
+	health, _, _ := setupPaymentHealthService(false)
+	payments := newMockPaymentService()
+	monitored := NewMonitoredPaymentService(payments, health)
+
+	if _, err := monitored.ProcessPayment(1000, models.PaymentMethodPaystack, PaymentBillingInfo{}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	payments.shouldFailOps["ProcessPayment"] = true
+	for i := 0; i < 2; i++ {
+		if _, err := monitored.ProcessPayment(1000, models.PaymentMethodPaystack, PaymentBillingInfo{}); err == nil {
+			t.Error("expected the provider's error to be returned")
+		}
+	}
+
+	paystack := health.GetHealth()[0]
+	if paystack.Attempts != 3 || paystack.Failures != 2 {
+		t.Errorf("expected 3 attempts with 2 failures, got %+v", paystack)
+	}
+}
+
+func TestNotificationService_PaymentHealthChanged(t *testing.T) {
+	service, _, _, emailSender := setupNotificationService()
+	createTestUser(service.userRepo.(*mockUserRepository), 2, models.RoleAdmin)
+
+	service.PaymentHealthChanged(&models.PaymentProviderHealth{Provider: models.PaymentMethodPaystack, Attempts: 10, Failures: 6, SuccessRate: 0.4, Degraded: true})
+	if len(emailSender.subjects) != 1 || emailSender.subjects[0] != "Paystack payments are failing" {
+		t.Errorf("expected admin to be alerted, got %v", emailSender.subjects)
+	}
+}
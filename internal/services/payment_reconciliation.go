@@ -0,0 +1,227 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+const (
+	// reconciliationWindow is how far back each run looks for transactions,
+	// so a run that fails is covered by the next
+	reconciliationWindow = 48 * time.Hour
+	// reconciliationSettlePeriod is how old a transaction must be before it
+	// is reconciled, giving buyers time to return from the payment page and
+	// complete their order
+	reconciliationSettlePeriod = 30 * time.Minute
+	// resolvedIssuesShown is how many closed issues the dashboard lists
+	resolvedIssuesShown = 20
+)
+
+// PaymentReconciliationRepositoryInterface defines the data operations for payment reconciliation
+type PaymentReconciliationRepositoryInterface interface {
+	FindOrdersByPaymentIDs(references []string) (map[string]*models.Order, error)
+	SaveIssue(issue *models.ReconciliationIssue) error
+	ListOpenIssues() ([]*models.ReconciliationIssue, error)
+	ListResolvedIssues(limit int) ([]*models.ReconciliationIssue, error)
+	ResolveIssue(id int, resolution models.ReconciliationResolution, resolvedBy *int, note string) (*models.ReconciliationIssue, error)
+}
+
+// TransactionLister is a payment provider that can list its transactions
+type TransactionLister interface {
+	ListTransactions(from, to time.Time) ([]models.PaymentTransaction, error)
+}
+
+// PaymentReconciliationService compares the payment provider's transactions
+// against local orders and records where they disagree, so buyers who paid
+// without getting tickets, or got tickets without paying, are followed up
+type PaymentReconciliationService struct {
+	repo         PaymentReconciliationRepositoryInterface
+	provider     TransactionLister
+	auditService *AuditService
+	now          func() time.Time
+
+	runMu   sync.Mutex
+	mu      sync.RWMutex
+	lastRun *models.ReconciliationRun
+}
+
+// NewPaymentReconciliationService creates a new payment reconciliation service
+func NewPaymentReconciliationService(repo PaymentReconciliationRepositoryInterface, provider TransactionLister, auditService *AuditService) *PaymentReconciliationService {
+	return &PaymentReconciliationService{
+		repo:         repo,
+		provider:     provider,
+		auditService: auditService,
+		now:          time.Now,
+	}
+}
+
+// Run reconciles the provider's settled transactions from the last
+// reconciliationWindow and stores the run for the dashboard. Mismatches are
+// recorded as issues, and open issues for transactions that now match are
+// cleared. A failed run is recorded in its Error rather than returned.
+func (s *PaymentReconciliationService) Run(ctx context.Context) *models.ReconciliationRun {
+	s.runMu.Lock()
+	defer s.runMu.Unlock()
+
+	now := s.now()
+	run := &models.ReconciliationRun{
+		WindowStart: now.Add(-reconciliationWindow),
+		WindowEnd:   now.Add(-reconciliationSettlePeriod),
+		StartedAt:   now,
+	}
+
+	if err := s.reconcile(ctx, run); err != nil {
+		run.Error = err.Error()
+	}
+	run.CompletedAt = s.now()
+
+	s.mu.Lock()
+	s.lastRun = run
+	s.mu.Unlock()
+
+	return run
+}
+
+func (s *PaymentReconciliationService) reconcile(ctx context.Context, run *models.ReconciliationRun) error {
+	transactions, err := s.provider.ListTransactions(run.WindowStart, run.WindowEnd)
+	if err != nil {
+		return fmt.Errorf("failed to list transactions: %w", err)
+	}
+	run.Transactions = len(transactions)
+
+	references := make([]string, 0, len(transactions))
+	for _, transaction := range transactions {
+		references = append(references, transaction.Reference)
+	}
+	orders, err := s.repo.FindOrdersByPaymentIDs(references)
+	if err != nil {
+		return err
+	}
+
+	// Transactions still in progress can't be reconciled yet, so only the
+	// references of settled ones may have their issues cleared
+	checked := make(map[string]bool)
+	flagged := make(map[string]bool)
+	for _, transaction := range transactions {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		if transaction.Status != "success" && transaction.Status != "failed" {
+			continue
+		}
+		checked[transaction.Reference] = true
+
+		issue := reconcileTransaction(transaction, orders[transaction.Reference])
+		if issue == nil {
+			continue
+		}
+		if err := s.repo.SaveIssue(issue); err != nil {
+			return err
+		}
+		flagged[issueKey(issue.Kind, issue.PaymentReference)] = true
+		run.Flagged++
+	}
+
+	open, err := s.repo.ListOpenIssues()
+	if err != nil {
+		return err
+	}
+	for _, issue := range open {
+		if !checked[issue.PaymentReference] || flagged[issueKey(issue.Kind, issue.PaymentReference)] {
+			continue
+		}
+		if _, err := s.repo.ResolveIssue(issue.ID, models.ReconciliationCleared, nil, "No longer mismatched"); err != nil {
+			return err
+		}
+		run.Cleared++
+	}
+
+	return nil
+}
+
+func issueKey(kind models.ReconciliationMismatch, reference string) string {
+	return string(kind) + "/" + reference
+}
+
+// reconcileTransaction compares a settled transaction with the order paid
+// with it, if any, returning the mismatch between them or nil if they agree.
+// Completed orders are only checked against transactions the provider
+// listed, as orders paid through other providers are never listed.
+func reconcileTransaction(transaction models.PaymentTransaction, order *models.Order) *models.ReconciliationIssue {
+	issue := &models.ReconciliationIssue{
+		PaymentReference: transaction.Reference,
+		ProviderStatus:   transaction.Status,
+		ProviderAmount:   transaction.Amount,
+		Currency:         transaction.Currency,
+		CustomerEmail:    transaction.Email,
+	}
+	if order != nil {
+		issue.OrderID = &order.ID
+		issue.OrderStatus = order.Status
+		issue.OrderAmount = order.TotalAmount
+	}
+
+	switch {
+	case transaction.Status == "success" && order == nil:
+		issue.Kind = models.MismatchPaidWithoutOrder
+	case transaction.Status == "success" && (order.Status == models.OrderPending || order.Status == models.OrderCancelled):
+		issue.Kind = models.MismatchPaidNotCompleted
+	case transaction.Status == "success" && order.TotalAmount != transaction.Amount:
+		issue.Kind = models.MismatchAmount
+	case transaction.Status == "failed" && order != nil && order.Status == models.OrderCompleted:
+		issue.Kind = models.MismatchCompletedNotPaid
+	default:
+		return nil
+	}
+
+	return issue
+}
+
+// LastRun returns the most recent run, or nil if reconciliation hasn't run yet
+func (s *PaymentReconciliationService) LastRun() *models.ReconciliationRun {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return s.lastRun
+}
+
+// OpenIssues returns the issues awaiting an admin, oldest first
+func (s *PaymentReconciliationService) OpenIssues() ([]*models.ReconciliationIssue, error) {
+	return s.repo.ListOpenIssues()
+}
+
+// ResolvedIssues returns the most recently closed issues
+func (s *PaymentReconciliationService) ResolvedIssues() ([]*models.ReconciliationIssue, error) {
+	return s.repo.ListResolvedIssues(resolvedIssuesShown)
+}
+
+// Resolve closes an issue on an admin's behalf, as resolved once they have
+// fixed it or ignored when it needs no action, and records it in the audit log
+func (s *PaymentReconciliationService) Resolve(issueID int, resolution models.ReconciliationResolution, adminUserID int, note string, r *http.Request) (*models.ReconciliationIssue, error) {
+	if resolution != models.ReconciliationResolved && resolution != models.ReconciliationIgnored {
+		return nil, fmt.Errorf("invalid resolution: %s", resolution)
+	}
+
+	issue, err := s.repo.ResolveIssue(issueID, resolution, &adminUserID, note)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.auditService != nil && r != nil {
+		details := map[string]interface{}{
+			"kind":       issue.Kind,
+			"reference":  issue.PaymentReference,
+			"resolution": resolution,
+			"note":       note,
+		}
+		if err := s.auditService.LogAction(adminUserID, models.AuditActionPaymentReconcile, models.AuditTargetPayment, issue.ID, details, r); err != nil {
+			fmt.Printf("Warning: failed to log payment reconciliation: %v\n", err)
+		}
+	}
+
+	return issue, nil
+}
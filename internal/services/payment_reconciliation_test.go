@@ -0,0 +1,295 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// memoryReconciliationRepository keeps orders and reconciliation issues in memory
+type memoryReconciliationRepository struct {
+	orders map[string]*models.Order
+	issues []*models.ReconciliationIssue
+}
+
+func newMemoryReconciliationRepository(orders ...*models.Order) *memoryReconciliationRepository {
+	repo := &memoryReconciliationRepository{orders: make(map[string]*models.Order)}
+	for _, order := range orders {
+		repo.orders[order.PaymentID] = order
+	}
+	return repo
+}
+
+func (m *memoryReconciliationRepository) FindOrdersByPaymentIDs(references []string) (map[string]*models.Order, error) {
+	found := make(map[string]*models.Order)
+	for _, reference := range references {
+		if order, ok := m.orders[reference]; ok {
+			found[reference] = order
+		}
+	}
+	return found, nil
+}
+
+func (m *memoryReconciliationRepository) SaveIssue(issue *models.ReconciliationIssue) error {
+	for _, existing := range m.issues {
+		if existing.Kind == issue.Kind && existing.PaymentReference == issue.PaymentReference {
+			existing.LastSeenAt = time.Now()
+			if existing.Resolution == models.ReconciliationCleared {
+				existing.ResolvedAt = nil
+				existing.Resolution = ""
+			}
+			*issue = *existing
+			return nil
+		}
+	}
+	issue.ID = len(m.issues) + 1
+	issue.DetectedAt = time.Now()
+	issue.LastSeenAt = issue.DetectedAt
+	saved := *issue
+	m.issues = append(m.issues, &saved)
+	return nil
+}
+
+func (m *memoryReconciliationRepository) ListOpenIssues() ([]*models.ReconciliationIssue, error) {
+	var open []*models.ReconciliationIssue
+	for _, issue := range m.issues {
+		if issue.IsOpen() {
+			open = append(open, issue)
+		}
+	}
+	return open, nil
+}
+
+func (m *memoryReconciliationRepository) ListResolvedIssues(limit int) ([]*models.ReconciliationIssue, error) {
+	var resolved []*models.ReconciliationIssue
+	for _, issue := range m.issues {
+		if !issue.IsOpen() && len(resolved) < limit {
+			resolved = append(resolved, issue)
+		}
+	}
+	return resolved, nil
+}
+
+func (m *memoryReconciliationRepository) ResolveIssue(id int, resolution models.ReconciliationResolution, resolvedBy *int, note string) (*models.ReconciliationIssue, error) {
+	for _, issue := range m.issues {
+		if issue.ID == id && issue.IsOpen() {
+			now := time.Now()
+			issue.ResolvedAt = &now
+			issue.Resolution = resolution
+			issue.ResolvedBy = resolvedBy
+			issue.ResolutionNote = note
+			return issue, nil
+		}
+	}
+	return nil, fmt.Errorf("reconciliation issue %d not found or already resolved", id)
+}
+
+// fakeTransactionLister returns the same transactions for any window
+type fakeTransactionLister struct {
+	transactions []models.PaymentTransaction
+	err          error
+	from, to     time.Time
+}
+
+func (f *fakeTransactionLister) ListTransactions(from, to time.Time) ([]models.PaymentTransaction, error) {
+	f.from, f.to = from, to
+	return f.transactions, f.err
+}
+
+func issueKinds(issues []*models.ReconciliationIssue) map[string]models.ReconciliationMismatch {
+	kinds := make(map[string]models.ReconciliationMismatch)
+	for _, issue := range issues {
+		kinds[issue.PaymentReference] = issue.Kind
+	}
+	return kinds
+}
+
+func TestPaymentReconciliationService_FlagsMismatches(t *testing.T) {
+	repo := newMemoryReconciliationRepository(
+		&models.Order{ID: 1, PaymentID: "TXN-ok", Status: models.OrderCompleted, TotalAmount: 5000},
+		&models.Order{ID: 2, PaymentID: "TXN-pending", Status: models.OrderPending, TotalAmount: 5000},
+		&models.Order{ID: 3, PaymentID: "TXN-short", Status: models.OrderCompleted, TotalAmount: 5000},
+		&models.Order{ID: 4, PaymentID: "TXN-failed", Status: models.OrderCompleted, TotalAmount: 5000},
+		&models.Order{ID: 5, PaymentID: "TXN-abandoned", Status: models.OrderCancelled, TotalAmount: 5000},
+		&models.Order{ID: 6, PaymentID: "TXN-ongoing", Status: models.OrderPending, TotalAmount: 5000},
+	)
+	provider := &fakeTransactionLister{transactions: []models.PaymentTransaction{
+		{Reference: "TXN-ok", Status: "success", Amount: 5000},
+		{Reference: "TXN-pending", Status: "success", Amount: 5000},
+		{Reference: "TXN-short", Status: "success", Amount: 4000},
+		{Reference: "TXN-failed", Status: "failed", Amount: 5000},
+		{Reference: "TXN-abandoned", Status: "failed", Amount: 5000},
+		{Reference: "TXN-ongoing", Status: "pending", Amount: 5000},
+		{Reference: "TXN-orphan", Status: "success", Amount: 5000},
+	}}
+	service := NewPaymentReconciliationService(repo, provider, nil)
+
+	run := service.Run(context.Background())
+	if run.Error != "" {
+		t.Fatalf("unexpected error: %s", run.Error)
+	}
+	if run.Transactions != 7 || run.Flagged != 4 {
+		t.Errorf("expected 4 of 7 transactions flagged, got %d of %d", run.Flagged, run.Transactions)
+	}
+	if service.LastRun() != run {
+		t.Error("expected the run to be kept for the dashboard")
+	}
+
+	open, _ := service.OpenIssues()
+	expected := map[string]models.ReconciliationMismatch{
+		"TXN-pending": models.MismatchPaidNotCompleted,
+		"TXN-short":   models.MismatchAmount,
+		"TXN-failed":  models.MismatchCompletedNotPaid,
+		"TXN-orphan":  models.MismatchPaidWithoutOrder,
+	}
+	kinds := issueKinds(open)
+	if len(kinds) != len(expected) {
+		t.Errorf("expected %d issues, got %v", len(expected), kinds)
+	}
+	for reference, kind := range expected {
+		if kinds[reference] != kind {
+			t.Errorf("expected %s to be flagged %s, got %q", reference, kind, kinds[reference])
+		}
+	}
+
+	// Only settled transactions are reconciled
+	if window := provider.to.Sub(provider.from); window != reconciliationWindow-reconciliationSettlePeriod {
+		t.Errorf("expected the window to end a settle period ago, got %s", window)
+	}
+}
+
+func TestPaymentReconciliationService_ClearsFixedIssues(t *testing.T) {
+	order := &models.Order{ID: 1, PaymentID: "TXN-1", Status: models.OrderPending, TotalAmount: 5000}
+	repo := newMemoryReconciliationRepository(order)
+	provider := &fakeTransactionLister{transactions: []models.PaymentTransaction{
+		{Reference: "TXN-1", Status: "success", Amount: 5000},
+		{Reference: "TXN-2", Status: "success", Amount: 5000},
+	}}
+	service := NewPaymentReconciliationService(repo, provider, nil)
+
+	service.Run(context.Background())
+	service.Run(context.Background())
+	if open, _ := service.OpenIssues(); len(open) != 2 {
+		t.Fatalf("expected re-runs to keep one issue per mismatch, got %d", len(open))
+	}
+
+	// Completing the order clears its issue; the orphaned payment stays open
+	order.Status = models.OrderCompleted
+	provider.transactions = provider.transactions[:1]
+	run := service.Run(context.Background())
+	if run.Cleared != 1 {
+		t.Errorf("expected 1 issue cleared, got %d", run.Cleared)
+	}
+	open, _ := service.OpenIssues()
+	if len(open) != 1 || open[0].PaymentReference != "TXN-2" {
+		t.Errorf("expected only the payment outside the window to stay open, got %v", issueKinds(open))
+	}
+}
+
+func TestPaymentReconciliationService_Resolve(t *testing.T) {
+	repo := newMemoryReconciliationRepository()
+	provider := &fakeTransactionLister{transactions: []models.PaymentTransaction{
+		{Reference: "TXN-1", Status: "success", Amount: 5000},
+	}}
+	service := NewPaymentReconciliationService(repo, provider, nil)
+	service.Run(context.Background())
+
+	if _, err := service.Resolve(1, models.ReconciliationCleared, 9, "", nil); err == nil {
+		t.Error("expected admins to be unable to clear issues")
+	}
+
+	issue, err := service.Resolve(1, models.ReconciliationResolved, 9, "Refunded the buyer", nil)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if issue.IsOpen() || issue.ResolvedBy == nil || *issue.ResolvedBy != 9 || issue.ResolutionNote != "Refunded the buyer" {
+		t.Errorf("expected the issue to be resolved by the admin, got %+v", issue)
+	}
+
+	// An issue an admin resolved isn't reopened by later runs
+	service.Run(context.Background())
+	if open, _ := service.OpenIssues(); len(open) != 0 {
+		t.Errorf("expected the resolved issue to stay closed, got %d open", len(open))
+	}
+	if _, err := service.Resolve(1, models.ReconciliationIgnored, 9, "", nil); err == nil {
+		t.Error("expected resolving a closed issue to fail")
+	}
+}
+
+func TestPaymentReconciliationService_RecordsProviderErrors(t *testing.T) {
+	provider := &fakeTransactionLister{err: errors.New("unauthorized")}
+	service := NewPaymentReconciliationService(newMemoryReconciliationRepository(), provider, nil)
+
+	if run := service.Run(context.Background()); run.Error == "" {
+		t.Error("expected the provider error to be recorded on the run")
+	}
+}
+
+func TestPaystackService_ListTransactions(t *testing.T) {
+	var pages []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "Bearer sk_test" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"status":false,"message":"Invalid key"}`))
+			return
+		}
+		page := r.URL.Query().Get("page")
+		pages = append(pages, page)
+
+		var data []map[string]interface{}
+		count := transactionListPageSize
+		if page == "2" {
+			count = 1
+		}
+		for i := 0; i < count; i++ {
+			data = append(data, map[string]interface{}{
+				"reference":  fmt.Sprintf("TXN-%s-%d", page, i),
+				"status":     "abandoned",
+				"amount":     5000,
+				"currency":   "KES",
+				"created_at": "2026-03-01T12:00:00.000Z",
+				"paid_at":    nil,
+				"customer":   map[string]interface{}{"email": "buyer@example.com"},
+			})
+		}
+		if page == "2" {
+			data[0]["status"] = "success"
+			data[0]["paid_at"] = "2026-03-01T12:05:00.000Z"
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"status": true,
+			"data":   data,
+			"meta":   map[string]interface{}{"pageCount": 2},
+		})
+	}))
+	defer server.Close()
+
+	service := NewPaystackService(PaystackConfig{SecretKey: "sk_test"})
+	service.baseURL = server.URL
+
+	transactions, err := service.ListTransactions(time.Now().Add(-time.Hour), time.Now())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(transactions) != transactionListPageSize+1 || len(pages) != 2 {
+		t.Fatalf("expected both pages to be fetched, got %d transactions from pages %v", len(transactions), pages)
+	}
+	if transactions[0].Status != "failed" || transactions[0].PaidAt != nil || transactions[0].Email != "buyer@example.com" {
+		t.Errorf("unexpected abandoned transaction: %+v", transactions[0])
+	}
+	if last := transactions[len(transactions)-1]; last.Status != "success" || last.PaidAt == nil {
+		t.Errorf("unexpected paid transaction: %+v", last)
+	}
+
+	service.config.SecretKey = "wrong"
+	if _, err := service.ListTransactions(time.Now().Add(-time.Hour), time.Now()); err == nil {
+		t.Error("expected an API error for a bad key")
+	}
+}
@@ -9,8 +9,11 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
+
+	"event-ticketing-platform/internal/models"
 )
 
 // PaystackConfig represents Paystack payment service configuration
@@ -257,9 +260,10 @@ func (s *PaystackService) ProcessPayment(amount int, paymentMethod string, billi
 	// Generate unique reference
 	reference := s.generateReference()
 
-	// Determine supported channels based on payment method
+	// Determine supported channels based on payment method. Payments whose
+	// card is charged again later are made by card.
 	channels := []string{"card", "bank", "ussd", "mobile_money"}
-	if paymentMethod == "card" {
+	if paymentMethod == "card" || billingInfo.PaymentType == "card" {
 		channels = []string{"card"}
 	} else if paymentMethod == "mobile_money" {
 		channels = []string{"mobile_money"}
@@ -303,22 +307,9 @@ func (s *PaystackService) GetPaymentStatus(paymentID string) (*PaymentStatus, er
 		return nil, fmt.Errorf("failed to verify transaction: %w", err)
 	}
 
-	// Map Paystack status to our status
-	var status string
-	switch verification.Data.Status {
-	case "success":
-		status = "success"
-	case "failed":
-		status = "failed"
-	case "abandoned":
-		status = "failed"
-	default:
-		status = "pending"
-	}
-
 	return &PaymentStatus{
 		PaymentID:     paymentID,
-		Status:        status,
+		Status:        mapPaystackStatus(verification.Data.Status),
 		Amount:        verification.Data.Amount,
 		TransactionID: fmt.Sprintf("%d", verification.Data.ID),
 		CreatedAt:     parsePaystackTime(verification.Data.CreatedAt),
@@ -326,6 +317,180 @@ func (s *PaystackService) GetPaymentStatus(paymentID string) (*PaymentStatus, er
 	}, nil
 }
 
+// transactionListPageSize is how many transactions are fetched per page
+// when listing, the most Paystack allows
+const transactionListPageSize = 100
+
+// transactionListResponse represents a page of listed transactions
+type transactionListResponse struct {
+	Status  bool   `json:"status"`
+	Message string `json:"message"`
+	Data    []struct {
+		Status    string       `json:"status"`
+		Reference string       `json:"reference"`
+		Amount    int          `json:"amount"`
+		Currency  string       `json:"currency"`
+		PaidAt    string       `json:"paid_at"`
+		CreatedAt string       `json:"created_at"`
+		Customer  CustomerData `json:"customer"`
+	} `json:"data"`
+	Meta struct {
+		Page      int `json:"page"`
+		PageCount int `json:"pageCount"`
+	} `json:"meta"`
+}
+
+// ListTransactions lists the transactions created between from and to,
+// following Paystack's pages until all of them have been fetched
+func (s *PaystackService) ListTransactions(from, to time.Time) ([]models.PaymentTransaction, error) {
+	var transactions []models.PaymentTransaction
+	for page := 1; ; page++ {
+		params := url.Values{}
+		params.Set("from", from.UTC().Format(time.RFC3339))
+		params.Set("to", to.UTC().Format(time.RFC3339))
+		params.Set("perPage", fmt.Sprintf("%d", transactionListPageSize))
+		params.Set("page", fmt.Sprintf("%d", page))
+
+		httpReq, err := http.NewRequest("GET", s.baseURL+"/transaction?"+params.Encode(), nil)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create transaction list request: %w", err)
+		}
+		httpReq.Header.Set("Authorization", "Bearer "+s.config.SecretKey)
+		httpReq.Header.Set("Accept", "application/json")
+
+		resp, err := s.client.Do(httpReq)
+		if err != nil {
+			return nil, fmt.Errorf("failed to send transaction list request: %w", err)
+		}
+		bodyBytes, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, fmt.Errorf("failed to read transaction list response body: %w", err)
+		}
+
+		if resp.StatusCode != http.StatusOK {
+			return nil, s.handleAPIError(resp.StatusCode, bodyBytes)
+		}
+
+		var list transactionListResponse
+		if err := json.Unmarshal(bodyBytes, &list); err != nil {
+			return nil, fmt.Errorf("failed to decode transaction list response: %w", err)
+		}
+		if !list.Status {
+			return nil, fmt.Errorf("transaction listing failed: %s", list.Message)
+		}
+
+		for _, tx := range list.Data {
+			transaction := models.PaymentTransaction{
+				Reference: tx.Reference,
+				Status:    mapPaystackStatus(tx.Status),
+				Amount:    tx.Amount,
+				Currency:  tx.Currency,
+				Email:     tx.Customer.Email,
+				CreatedAt: parsePaystackTime(tx.CreatedAt),
+			}
+			if tx.PaidAt != "" {
+				paidAt := parsePaystackTime(tx.PaidAt)
+				transaction.PaidAt = &paidAt
+			}
+			transactions = append(transactions, transaction)
+		}
+
+		if len(list.Data) < transactionListPageSize || page >= list.Meta.PageCount {
+			return transactions, nil
+		}
+	}
+}
+
+// GetReusableAuthorization returns the card authorization a successful
+// transaction left, for charging later payments to the same card. It fails
+// if the buyer paid in a way that can't be charged again.
+func (s *PaystackService) GetReusableAuthorization(reference string) (*models.PaymentAuthorization, error) {
+	verification, err := s.VerifyTransaction(reference)
+	if err != nil {
+		return nil, fmt.Errorf("failed to verify transaction: %w", err)
+	}
+
+	if verification.Data.Status != "success" {
+		return nil, fmt.Errorf("transaction %s has not succeeded", reference)
+	}
+	authorization := verification.Data.Authorization
+	if !authorization.Reusable || authorization.AuthorizationCode == "" {
+		return nil, fmt.Errorf("transaction %s was not paid with a reusable card", reference)
+	}
+
+	return &models.PaymentAuthorization{
+		Code:  authorization.AuthorizationCode,
+		Email: verification.Data.Customer.Email,
+		Last4: authorization.Last4,
+		Brand: authorization.Brand,
+	}, nil
+}
+
+// chargeAuthorizationRequest represents a charge of a saved authorization
+type chargeAuthorizationRequest struct {
+	AuthorizationCode string `json:"authorization_code"`
+	Email             string `json:"email"`
+	Amount            int    `json:"amount"`
+	Currency          string `json:"currency"`
+	Reference         string `json:"reference"`
+}
+
+// ChargeAuthorization charges a saved card authorization without the buyer,
+// returning the charge's status. A charge still being processed is pending
+// and can be checked later by its reference.
+func (s *PaystackService) ChargeAuthorization(authorizationCode, email string, amount int, reference string) (*PaymentStatus, error) {
+	jsonData, err := json.Marshal(chargeAuthorizationRequest{
+		AuthorizationCode: authorizationCode,
+		Email:             email,
+		Amount:            amount,
+		Currency:          s.getSupportedCurrency(),
+		Reference:         reference,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal charge request: %w", err)
+	}
+
+	httpReq, err := http.NewRequest("POST", s.baseURL+"/transaction/charge_authorization", bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create charge request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", "Bearer "+s.config.SecretKey)
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := s.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send charge request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	bodyBytes, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read charge response body: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, s.handleAPIError(resp.StatusCode, bodyBytes)
+	}
+
+	var charge TransactionVerification
+	if err := json.Unmarshal(bodyBytes, &charge); err != nil {
+		return nil, fmt.Errorf("failed to decode charge response: %w", err)
+	}
+	if !charge.Status {
+		return nil, fmt.Errorf("charge failed: %s", charge.Message)
+	}
+
+	return &PaymentStatus{
+		PaymentID:     reference,
+		Status:        mapPaystackStatus(charge.Data.Status),
+		Amount:        charge.Data.Amount,
+		TransactionID: fmt.Sprintf("%d", charge.Data.ID),
+		CreatedAt:     parsePaystackTime(charge.Data.CreatedAt),
+		UpdatedAt:     time.Now(),
+	}, nil
+}
+
 // TestConnection tests the Paystack API connection
 func (s *PaystackService) TestConnection() error {
 	// Test by trying to initialize a transaction with currency fallback
@@ -374,6 +539,18 @@ func (s *PaystackService) handleAPIError(statusCode int, body []byte) error {
 	}
 }
 
+// mapPaystackStatus maps a Paystack transaction status to our payment status
+func mapPaystackStatus(status string) string {
+	switch status {
+	case "success":
+		return "success"
+	case "failed", "abandoned":
+		return "failed"
+	default:
+		return "pending"
+	}
+}
+
 // parsePaystackTime parses Paystack timestamp format
 func parsePaystackTime(timeStr string) time.Time {
 	if timeStr == "" {
@@ -3,6 +3,7 @@ package services
 import (
 	"bytes"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -78,6 +79,9 @@ func (s *PDFService) generateTicketContent(tickets []*models.Ticket, event *mode
 	content.WriteString(fmt.Sprintf("Event: %s\n", event.Title))
 	content.WriteString(fmt.Sprintf("Date: %s\n", event.StartDate.Format("Monday, January 2, 2006 at 3:04 PM")))
 	content.WriteString(fmt.Sprintf("Location: %s\n", event.Location))
+	if order.ArrivalSlot != nil {
+		content.WriteString(fmt.Sprintf("Arrival Time: %s (please arrive during this window)\n", order.ArrivalSlot.Label()))
+	}
 	if event.Description != "" {
 		content.WriteString(fmt.Sprintf("Description: %s\n", s.truncateString(event.Description, 200)))
 	}
@@ -100,6 +104,9 @@ func (s *PDFService) generateTicketContent(tickets []*models.Ticket, event *mode
 	for i, ticket := range tickets {
 		content.WriteString(fmt.Sprintf("TICKET #%d\n", i+1))
 		content.WriteString(fmt.Sprintf("Ticket ID: %d\n", ticket.ID))
+		if ticket.AttendeeName != "" {
+			content.WriteString(fmt.Sprintf("Attendee: %s\n", ticket.AttendeeName))
+		}
 		content.WriteString(fmt.Sprintf("QR Code: %s\n", ticket.QRCode))
 		content.WriteString(fmt.Sprintf("Status: %s\n", s.getTicketStatusDisplay(ticket.Status)))
 		content.WriteString(fmt.Sprintf("Generated: %s\n", ticket.CreatedAt.Format("Jan 2, 2006 at 3:04 PM")))
@@ -260,3 +267,174 @@ func (s *PDFService) GenerateTicketQRCode(ticket *models.Ticket, event *models.E
 
 	return qrData, nil
 }
+
+// statementLinesPerPage is how many lines of a settlement statement fit on a
+// page of its PDF
+const statementLinesPerPage = 58
+
+// statementLine is a line of a settlement statement's PDF
+type statementLine struct {
+	text    string
+	heading bool
+}
+
+// GenerateStatementPDF generates a PDF of a settlement statement, over as
+// many pages as its events need
+func (s *PDFService) GenerateStatementPDF(statement *models.SettlementStatement) ([]byte, error) {
+	lines := s.generateStatementContent(statement)
+
+	var pages []string
+	for start := 0; start < len(lines); start += statementLinesPerPage {
+		pages = append(pages, s.formatStatementPage(lines[start:min(start+statementLinesPerPage, len(lines))]))
+	}
+
+	return s.buildDocument(pages), nil
+}
+
+// generateStatementContent lays out a settlement statement as lines of text.
+// Tables are set in a fixed-width font so their columns line up.
+func (s *PDFService) generateStatementContent(statement *models.SettlementStatement) []statementLine {
+	money := func(amount float64) string {
+		if amount < 0 {
+			return fmt.Sprintf("-$%.2f", -amount)
+		}
+		return fmt.Sprintf("$%.2f", amount)
+	}
+	cents := func(amount int64) string { return money(float64(amount) / 100) }
+	row := func(event, orders, gross, refunds, chargebacks, fees, net string) statementLine {
+		return statementLine{text: fmt.Sprintf("%-28s %6s %12s %12s %12s %10s %12s", event, orders, gross, refunds, chargebacks, fees, net)}
+	}
+	text := func(format string, args ...interface{}) statementLine {
+		return statementLine{text: fmt.Sprintf(format, args...)}
+	}
+	figure := func(label, amount string) statementLine {
+		return statementLine{text: fmt.Sprintf("%-26s %14s", label, amount)}
+	}
+
+	lines := []statementLine{
+		{text: strings.ToUpper(statement.Title()), heading: true},
+		text("Period: %s - %s", statement.PeriodStart.Format("January 2, 2006"), statement.LastDay().Format("January 2, 2006")),
+		text("Generated: %s", statement.GeneratedAt.Format("January 2, 2006 at 3:04 PM")),
+		{},
+		{text: "SALES BY EVENT", heading: true},
+		row("Event", "Orders", "Gross", "Refunds", "Chargebacks", "Fees", "Net"),
+		{text: strings.Repeat("-", 98)},
+	}
+	if len(statement.Lines) == 0 {
+		lines = append(lines, text("No sales or refunds in this period"))
+	}
+	for _, line := range statement.Lines {
+		lines = append(lines, row(s.truncateString(line.EventTitle, 28), strconv.Itoa(line.Orders), cents(line.GrossCents),
+			cents(line.RefundCents), cents(line.ChargebackCents), cents(line.FeeCents()), cents(line.NetCents())))
+	}
+
+	total := statement.Total()
+	lines = append(lines,
+		statementLine{text: strings.Repeat("-", 98)},
+		row("Total", strconv.Itoa(total.Orders), cents(total.GrossCents), cents(total.RefundCents),
+			cents(total.ChargebackCents), cents(statement.FeeCents()), cents(statement.NetCents())),
+		statementLine{},
+		statementLine{text: "SUMMARY", heading: true},
+		figure("Gross sales:", cents(total.GrossCents)),
+		figure(fmt.Sprintf("Refunds (%d):", total.Refunds), cents(-total.RefundCents)),
+		figure(fmt.Sprintf("Chargebacks (%d):", total.Chargebacks), cents(-total.ChargebackCents)),
+		figure(fmt.Sprintf("Platform fees (%.0f%%):", models.PlatformFeeRate*100), cents(-statement.FeeCents())),
+		figure("Net payout:", cents(statement.NetCents())),
+	)
+
+	// Tax is part of the sales above, so it is summarized on its own for
+	// the organizer to file
+	if total.HasTax() {
+		lines = append(lines,
+			statementLine{},
+			statementLine{text: "TAX", heading: true},
+			figure("Tax on sales:", cents(total.TaxCents)),
+			figure("Tax on refunds:", cents(-total.RefundTaxCents)),
+			figure("Net tax collected:", cents(total.NetTaxCents())),
+		)
+	}
+
+	if statement.IsMonthly() {
+		lines = append(lines, statementLine{}, statementLine{text: "WITHDRAWALS PAID OUT", heading: true})
+		if len(statement.Withdrawals) == 0 {
+			lines = append(lines, text("No withdrawals were paid out in this period"))
+		}
+		for _, withdrawal := range statement.Withdrawals {
+			processed := ""
+			if withdrawal.ProcessedAt != nil {
+				processed = withdrawal.ProcessedAt.Format("Jan 2, 2006")
+			}
+			lines = append(lines, text("#%-8d %-14s %-10s %14s", withdrawal.ID, processed, withdrawal.Status, money(withdrawal.Amount)))
+		}
+		lines = append(lines,
+			statementLine{},
+			statementLine{text: "RECONCILIATION", heading: true},
+			figure("Net payout:", cents(statement.NetCents())),
+			figure("Withdrawals paid out:", money(-statement.PaidOut())),
+			figure("Balance change:", money(statement.BalanceChange())),
+		)
+	}
+
+	lines = append(lines, text("Available balance on %s: %s", statement.GeneratedAt.Format("January 2, 2006"), money(statement.AvailableBalance)))
+	return lines
+}
+
+// formatStatementPage formats a page of statement lines for a PDF stream.
+// Headings are set in Helvetica-Bold and everything else in Courier.
+func (s *PDFService) formatStatementPage(lines []statementLine) string {
+	var stream strings.Builder
+
+	stream.WriteString("BT\n")
+	stream.WriteString("50 750 Td\n")
+	for _, line := range lines {
+		if line.heading {
+			stream.WriteString("/F2 11 Tf\n")
+		} else {
+			stream.WriteString("/F3 8 Tf\n")
+		}
+		stream.WriteString(fmt.Sprintf("(%s) Tj\n", s.escapePDFString(line.text)))
+		stream.WriteString("0 -12 Td\n")
+	}
+	stream.WriteString("ET\n")
+
+	return stream.String()
+}
+
+// buildDocument assembles a PDF of Letter pages from their content streams,
+// with Helvetica as F1, Helvetica-Bold as F2 and Courier as F3
+func (s *PDFService) buildDocument(pages []string) []byte {
+	var buffer bytes.Buffer
+	var offsets []int
+	object := func(body string) {
+		offsets = append(offsets, buffer.Len())
+		buffer.WriteString(fmt.Sprintf("%d 0 obj\n%s\nendobj\n\n", len(offsets), body))
+	}
+
+	buffer.WriteString("%PDF-1.4\n")
+
+	// Each page is followed by its content stream, after the catalog, the
+	// page tree and the fonts
+	kids := make([]string, len(pages))
+	for i := range pages {
+		kids[i] = fmt.Sprintf("%d 0 R", 6+2*i)
+	}
+	object("<<\n/Type /Catalog\n/Pages 2 0 R\n>>")
+	object(fmt.Sprintf("<<\n/Type /Pages\n/Kids [%s]\n/Count %d\n>>", strings.Join(kids, " "), len(pages)))
+	object("<<\n/Type /Font\n/Subtype /Type1\n/BaseFont /Helvetica\n>>")
+	object("<<\n/Type /Font\n/Subtype /Type1\n/BaseFont /Helvetica-Bold\n>>")
+	object("<<\n/Type /Font\n/Subtype /Type1\n/BaseFont /Courier\n>>")
+	for i, content := range pages {
+		object(fmt.Sprintf("<<\n/Type /Page\n/Parent 2 0 R\n/MediaBox [0 0 612 792]\n/Contents %d 0 R\n/Resources <<\n/Font <<\n/F1 3 0 R\n/F2 4 0 R\n/F3 5 0 R\n>>\n>>\n>>", 7+2*i))
+		object(fmt.Sprintf("<<\n/Length %d\n>>\nstream\n%s\nendstream", len(content), content))
+	}
+
+	xref := buffer.Len()
+	buffer.WriteString(fmt.Sprintf("xref\n0 %d\n", len(offsets)+1))
+	buffer.WriteString("0000000000 65535 f \n")
+	for _, offset := range offsets {
+		buffer.WriteString(fmt.Sprintf("%010d 00000 n \n", offset))
+	}
+	buffer.WriteString(fmt.Sprintf("trailer\n<<\n/Size %d\n/Root 1 0 R\n>>\nstartxref\n%d\n%%%%EOF\n", len(offsets)+1, xref))
+
+	return buffer.Bytes()
+}
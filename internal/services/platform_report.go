@@ -0,0 +1,272 @@
+package services
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+
+	"event-ticketing-platform/internal/cache"
+	"event-ticketing-platform/internal/models"
+)
+
+// Report range presets selectable on the admin reports page
+const (
+	ReportPresetLast7Days  = "7d"
+	ReportPresetLast30Days = "30d"
+	ReportPresetLast90Days = "90d"
+	ReportPresetThisMonth  = "month"
+	ReportPresetThisYear   = "year"
+	ReportPresetCustom     = "custom"
+)
+
+// ReportPresetOption describes a report range preset for display in filters
+type ReportPresetOption struct {
+	Value string `json:"value"`
+	Label string `json:"label"`
+}
+
+// ReportPresetOptions lists the report range presets in display order
+var ReportPresetOptions = []ReportPresetOption{
+	{Value: ReportPresetLast7Days, Label: "Last 7 days"},
+	{Value: ReportPresetLast30Days, Label: "Last 30 days"},
+	{Value: ReportPresetLast90Days, Label: "Last 90 days"},
+	{Value: ReportPresetThisMonth, Label: "This month"},
+	{Value: ReportPresetThisYear, Label: "This year"},
+	{Value: ReportPresetCustom, Label: "Custom range"},
+}
+
+// reportDateLayout is the format of custom report range dates
+const reportDateLayout = "2006-01-02"
+
+// maxReportRangeDays bounds custom report ranges so a report stays one
+// reasonably sized set of aggregate queries
+const maxReportRangeDays = 366
+
+// platformReportTopEvents is how many top events a platform report lists
+const platformReportTopEvents = 10
+
+// ReportRange is the period a platform report covers. From is the start of
+// its first day and To the start of the day after its last.
+type ReportRange struct {
+	Preset string    `json:"preset"`
+	From   time.Time `json:"from"`
+	To     time.Time `json:"to"`
+}
+
+// LastDay returns the last day the range covers
+func (r ReportRange) LastDay() time.Time {
+	return r.To.AddDate(0, 0, -1)
+}
+
+// PlatformReport summarizes the platform's sales and growth over a range.
+// Amounts are in the platform currency rather than cents.
+type PlatformReport struct {
+	Range         ReportRange         `json:"range"`
+	GMV           float64             `json:"gmv"`            // completed and later refunded orders placed in the range
+	FeesCollected float64             `json:"fees_collected"` // the platform's share of completed orders placed in the range
+	Refunds       float64             `json:"refunds"`        // orders refunded in the range
+	RefundCount   int                 `json:"refund_count"`
+	OrderCount    int                 `json:"order_count"`
+	TicketsSold   int                 `json:"tickets_sold"`
+	NewUsers      int                 `json:"new_users"`
+	NewOrganizers int                 `json:"new_organizers"`
+	TopEvents     []*PlatformTopEvent `json:"top_events"`
+}
+
+// PlatformTopEvent is one of the events that sold the most over a report's range
+type PlatformTopEvent struct {
+	EventID       int     `json:"event_id"`
+	Title         string  `json:"title"`
+	OrganizerName string  `json:"organizer_name"`
+	TicketsSold   int     `json:"tickets_sold"`
+	OrderCount    int     `json:"order_count"`
+	Revenue       float64 `json:"revenue"`
+}
+
+// ParseReportRange resolves a report range from a preset or, for custom
+// ranges, from and to dates in YYYY-MM-DD form. An empty preset defaults to
+// the last 30 days. Preset ranges end today.
+func ParseReportRange(preset, from, to string, now time.Time) (ReportRange, error) {
+	today := startOfDay(now)
+	tomorrow := today.AddDate(0, 0, 1)
+
+	switch preset {
+	case "", ReportPresetLast30Days:
+		return ReportRange{Preset: ReportPresetLast30Days, From: today.AddDate(0, 0, -29), To: tomorrow}, nil
+	case ReportPresetLast7Days:
+		return ReportRange{Preset: preset, From: today.AddDate(0, 0, -6), To: tomorrow}, nil
+	case ReportPresetLast90Days:
+		return ReportRange{Preset: preset, From: today.AddDate(0, 0, -89), To: tomorrow}, nil
+	case ReportPresetThisMonth:
+		return ReportRange{Preset: preset, From: today.AddDate(0, 0, 1-today.Day()), To: tomorrow}, nil
+	case ReportPresetThisYear:
+		return ReportRange{Preset: preset, From: today.AddDate(0, 0, 1-today.YearDay()), To: tomorrow}, nil
+	case ReportPresetCustom:
+	default:
+		return ReportRange{}, fmt.Errorf("unknown report range %q", preset)
+	}
+
+	start, err := time.ParseInLocation(reportDateLayout, from, now.Location())
+	if err != nil {
+		return ReportRange{}, fmt.Errorf("invalid start date: %q", from)
+	}
+	end, err := time.ParseInLocation(reportDateLayout, to, now.Location())
+	if err != nil {
+		return ReportRange{}, fmt.Errorf("invalid end date: %q", to)
+	}
+	if end.Before(start) {
+		return ReportRange{}, fmt.Errorf("end date must not be before start date")
+	}
+	end = end.AddDate(0, 0, 1)
+	if end.Sub(start) > maxReportRangeDays*24*time.Hour {
+		return ReportRange{}, fmt.Errorf("report range cannot be longer than %d days", maxReportRangeDays)
+	}
+
+	return ReportRange{Preset: preset, From: start, To: end}, nil
+}
+
+// GetPlatformReport retrieves platform-wide sales and growth over a range
+func (s *AnalyticsService) GetPlatformReport(r ReportRange) (*PlatformReport, error) {
+	key := cache.Key(analyticsCachePrefix+"platform", r.From.Format(reportDateLayout), r.To.Format(reportDateLayout))
+	return cache.RememberStale(s.cache, key, analyticsCacheTTL, analyticsCacheStaleTTL, func() (*PlatformReport, error) {
+		return s.loadPlatformReport(r)
+	})
+}
+
+func (s *AnalyticsService) loadPlatformReport(r ReportRange) (*PlatformReport, error) {
+	report := &PlatformReport{Range: r}
+
+	// Sales at the door count towards GMV, but the platform takes no fee on
+	// money the organizer collected themselves
+	var gmv, completed, refunds int64
+	err := s.db.QueryRow(fmt.Sprintf(`
+		SELECT
+			COALESCE(SUM(CASE WHEN status IN ('completed', 'refunded') AND created_at >= $1 AND created_at < $2 THEN total_amount END), 0),
+			COALESCE(SUM(CASE WHEN status = 'completed' AND created_at >= $1 AND created_at < $2 AND %s THEN total_amount END), 0),
+			COUNT(CASE WHEN status IN ('completed', 'refunded') AND created_at >= $1 AND created_at < $2 THEN 1 END),
+			COALESCE(SUM(CASE WHEN status = 'refunded' AND updated_at >= $1 AND updated_at < $2 THEN total_amount END), 0),
+			COUNT(CASE WHEN status = 'refunded' AND updated_at >= $1 AND updated_at < $2 THEN 1 END)
+		FROM orders
+		WHERE (created_at >= $1 AND created_at < $2) OR (updated_at >= $1 AND updated_at < $2)`, models.OnlinePaymentCondition("payment_id")),
+		r.From, r.To).Scan(&gmv, &completed, &report.OrderCount, &refunds, &report.RefundCount)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get platform sales: %w", err)
+	}
+	report.GMV = float64(gmv) / 100.0
+	report.FeesCollected = platformFee(completed)
+	report.Refunds = float64(refunds) / 100.0
+
+	err = s.db.QueryRow(`
+		SELECT COUNT(*)
+		FROM tickets t
+		JOIN orders o ON o.id = t.order_id
+		WHERE o.status = 'completed' AND o.created_at >= $1 AND o.created_at < $2`,
+		r.From, r.To).Scan(&report.TicketsSold)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count platform tickets sold: %w", err)
+	}
+
+	err = s.db.QueryRow(`
+		SELECT COUNT(*), COUNT(CASE WHEN role = $3 THEN 1 END)
+		FROM users
+		WHERE created_at >= $1 AND created_at < $2`,
+		r.From, r.To, string(models.UserRoleOrganizer)).Scan(&report.NewUsers, &report.NewOrganizers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count new users: %w", err)
+	}
+
+	report.TopEvents, err = s.getPlatformTopEvents(r, platformReportTopEvents)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get top events: %w", err)
+	}
+
+	return report, nil
+}
+
+func (s *AnalyticsService) getPlatformTopEvents(r ReportRange, limit int) ([]*PlatformTopEvent, error) {
+	query := `
+		SELECT
+			e.id, e.title,
+			COALESCE(NULLIF(TRIM(u.first_name || ' ' || u.last_name), ''), u.email) as organizer_name,
+			COALESCE(SUM(tc.ticket_count), 0) as tickets_sold,
+			COUNT(o.id) as order_count,
+			COALESCE(SUM(o.total_amount), 0) as revenue
+		FROM orders o
+		JOIN events e ON e.id = o.event_id
+		JOIN users u ON u.id = e.organizer_id
+		LEFT JOIN (
+			SELECT order_id, COUNT(*) as ticket_count
+			FROM tickets
+			GROUP BY order_id
+		) tc ON tc.order_id = o.id
+		WHERE o.status = 'completed' AND o.created_at >= $1 AND o.created_at < $2
+		GROUP BY e.id, e.title, u.first_name, u.last_name, u.email
+		ORDER BY revenue DESC, tickets_sold DESC
+		LIMIT $3`
+
+	rows, err := s.db.Query(query, r.From, r.To, limit)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var events []*PlatformTopEvent
+	for rows.Next() {
+		event := &PlatformTopEvent{}
+		var revenue int64
+		if err := rows.Scan(&event.EventID, &event.Title, &event.OrganizerName, &event.TicketsSold, &event.OrderCount, &revenue); err != nil {
+			return nil, err
+		}
+		event.Revenue = float64(revenue) / 100.0
+		events = append(events, event)
+	}
+
+	return events, rows.Err()
+}
+
+// ExportPlatformReport writes a platform report as CSV: its summary
+// figures, then its top events
+func ExportPlatformReport(w io.Writer, report *PlatformReport) error {
+	writer := csv.NewWriter(w)
+
+	money := func(amount float64) string { return strconv.FormatFloat(amount, 'f', 2, 64) }
+	rows := [][]string{
+		{"Metric", "Value"},
+		{"From", report.Range.From.Format(reportDateLayout)},
+		{"To", report.Range.LastDay().Format(reportDateLayout)},
+		{"GMV", money(report.GMV)},
+		{"Fees Collected", money(report.FeesCollected)},
+		{"Refunds", money(report.Refunds)},
+		{"Refunded Orders", strconv.Itoa(report.RefundCount)},
+		{"Orders", strconv.Itoa(report.OrderCount)},
+		{"Tickets Sold", strconv.Itoa(report.TicketsSold)},
+		{"New Users", strconv.Itoa(report.NewUsers)},
+		{"New Organizers", strconv.Itoa(report.NewOrganizers)},
+		{},
+		{"Event ID", "Event", "Organizer", "Tickets Sold", "Orders", "Revenue"},
+	}
+	for _, event := range report.TopEvents {
+		rows = append(rows, []string{
+			strconv.Itoa(event.EventID),
+			event.Title,
+			event.OrganizerName,
+			strconv.Itoa(event.TicketsSold),
+			strconv.Itoa(event.OrderCount),
+			money(event.Revenue),
+		})
+	}
+
+	if err := writer.WriteAll(rows); err != nil {
+		return fmt.Errorf("failed to write platform report: %w", err)
+	}
+	return nil
+}
+
+// platformFee returns the platform's share of an amount in cents, in the
+// platform currency
+func platformFee(cents int64) float64 {
+	return math.Round(float64(cents)*models.PlatformFeeRate) / 100.0
+}
@@ -0,0 +1,157 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+func TestParseReportRange(t *testing.T) {
+	now := time.Date(2026, 6, 10, 15, 30, 0, 0, time.UTC)
+	tomorrow := time.Date(2026, 6, 11, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		preset string
+		from   time.Time
+	}{
+		{"", time.Date(2026, 5, 12, 0, 0, 0, 0, time.UTC)},
+		{ReportPresetLast7Days, time.Date(2026, 6, 4, 0, 0, 0, 0, time.UTC)},
+		{ReportPresetThisMonth, time.Date(2026, 6, 1, 0, 0, 0, 0, time.UTC)},
+		{ReportPresetThisYear, time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	for _, tt := range tests {
+		r, err := ParseReportRange(tt.preset, "", "", now)
+		if err != nil {
+			t.Fatalf("unexpected error for %q: %v", tt.preset, err)
+		}
+		if !r.From.Equal(tt.from) || !r.To.Equal(tomorrow) {
+			t.Errorf("expected %q to cover %v to %v, got %v to %v", tt.preset, tt.from, tomorrow, r.From, r.To)
+		}
+	}
+
+	custom, err := ParseReportRange(ReportPresetCustom, "2026-03-01", "2026-03-31", now)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !custom.To.Equal(time.Date(2026, 4, 1, 0, 0, 0, 0, time.UTC)) || custom.LastDay().Day() != 31 {
+		t.Errorf("expected a custom range to include its last day, got %v", custom.To)
+	}
+
+	invalid := [][3]string{
+		{"forever", "", ""},
+		{ReportPresetCustom, "March", "2026-03-31"},
+		{ReportPresetCustom, "2026-03-31", "2026-03-01"},
+		{ReportPresetCustom, "2024-01-01", "2026-01-01"},
+	}
+	for _, args := range invalid {
+		if _, err := ParseReportRange(args[0], args[1], args[2], now); err == nil {
+			t.Errorf("expected an error for %v", args)
+		}
+	}
+}
+
+func TestExportPlatformReport(t *testing.T) {
+	r, _ := ParseReportRange(ReportPresetCustom, "2026-03-01", "2026-03-31", time.Now())
+	report := &PlatformReport{
+		Range:         r,
+		GMV:           1500,
+		FeesCollected: platformFee(120000),
+		NewOrganizers: 2,
+		TopEvents: []*PlatformTopEvent{
+			{EventID: 7, Title: "Jazz, Live", OrganizerName: "Amani", TicketsSold: 12, OrderCount: 5, Revenue: 1200},
+		},
+	}
+
+	var buf bytes.Buffer
+	if err := ExportPlatformReport(&buf, report); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	csvData := buf.String()
+	for _, want := range []string{"To,2026-03-31", "Fees Collected,60.00", "New Organizers,2", `7,"Jazz, Live",Amani,12,5,1200.00`} {
+		if !strings.Contains(csvData, want) {
+			t.Errorf("expected the export to contain %q, got:\n%s", want, csvData)
+		}
+	}
+}
+
+func TestPlatformReport_LeavesBoxOfficeSalesOutOfFees(t *testing.T) {
+	db, recorded := newRecordingDB()
+	defer db.Close()
+	service := NewAnalyticsService(db, nil, nil, nil, nil)
+
+	service.loadPlatformReport(ReportRange{From: time.Now().AddDate(0, -1, 0), To: time.Now()})
+	if len(recorded.queries) == 0 || !strings.Contains(recorded.queries[0], models.OnlinePaymentCondition("payment_id")) {
+		t.Errorf("expected platform fees to leave out box office sales, got %q", recorded.queries)
+	}
+}
+
+// recordingConnector opens database connections that record the SQL sent to
+// them and return no rows, so tests can check what a query asks for without
+// a database
+type recordingConnector struct {
+	queries []string
+}
+
+func (c *recordingConnector) Connect(ctx context.Context) (driver.Conn, error) {
+	return &recordingConn{connector: c}, nil
+}
+
+func (c *recordingConnector) Driver() driver.Driver {
+	return recordingDriver{connector: c}
+}
+
+type recordingDriver struct {
+	connector *recordingConnector
+}
+
+func (d recordingDriver) Open(name string) (driver.Conn, error) {
+	return d.connector.Connect(context.Background())
+}
+
+type recordingConn struct {
+	connector *recordingConnector
+}
+
+func (c *recordingConn) Prepare(query string) (driver.Stmt, error) {
+	c.connector.queries = append(c.connector.queries, query)
+	return recordingStmt{}, nil
+}
+
+func (c *recordingConn) Close() error              { return nil }
+func (c *recordingConn) Begin() (driver.Tx, error) { return recordingTx{}, nil }
+
+type recordingStmt struct{}
+
+func (recordingStmt) Close() error  { return nil }
+func (recordingStmt) NumInput() int { return -1 }
+func (recordingStmt) Exec(args []driver.Value) (driver.Result, error) {
+	return driver.RowsAffected(0), nil
+}
+func (recordingStmt) Query(args []driver.Value) (driver.Rows, error) {
+	return noRows{}, nil
+}
+
+type recordingTx struct{}
+
+func (recordingTx) Commit() error   { return nil }
+func (recordingTx) Rollback() error { return nil }
+
+type noRows struct{}
+
+func (noRows) Columns() []string              { return nil }
+func (noRows) Close() error                   { return nil }
+func (noRows) Next(dest []driver.Value) error { return io.EOF }
+
+// newRecordingDB returns a database that records the queries run on it
+func newRecordingDB() (*sql.DB, *recordingConnector) {
+	connector := &recordingConnector{}
+	return sql.OpenDB(connector), connector
+}
@@ -0,0 +1,180 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"event-ticketing-platform/internal/i18n"
+	"event-ticketing-platform/internal/models"
+)
+
+// PriceHistoryRepository defines the data operations for ticket price history
+type PriceHistoryRepository interface {
+	RecordPriceChange(ticketType *models.TicketType, oldPrice *int) error
+	GetByEvent(eventID int) ([]*models.TicketPriceChange, error)
+	GetPendingDrops() ([]*models.PriceDrop, error)
+	ClaimPriceDrop(changeID int) (bool, error)
+	GetEndingTiers(from, to time.Time) ([]*models.EndingTier, error)
+	ClaimTierEndingNotice(ticketTypeID int) (bool, error)
+}
+
+// EventWatcherReader returns the users who saved an event
+type EventWatcherReader interface {
+	GetWatchers(eventID int) ([]*models.EventWatcher, error)
+}
+
+// PriceAlertEmailSender sends price alerts in the given language
+type PriceAlertEmailSender interface {
+	SendPriceAlertEmail(email, userName, subject, locale string, event *models.Event, message, link string) error
+}
+
+// PriceHistoryService records ticket price changes and tells attendees who
+// saved an event when its prices drop or an early-bird tier is about to end
+type PriceHistoryService struct {
+	repo        PriceHistoryRepository
+	watchers    EventWatcherReader
+	eventRepo   EventRepository
+	emailSender PriceAlertEmailSender
+	baseURL     string
+	now         func() time.Time
+}
+
+// NewPriceHistoryService creates a new price history service
+func NewPriceHistoryService(repo PriceHistoryRepository, watchers EventWatcherReader, eventRepo EventRepository, emailSender PriceAlertEmailSender, baseURL string) *PriceHistoryService {
+	return &PriceHistoryService{
+		repo:        repo,
+		watchers:    watchers,
+		eventRepo:   eventRepo,
+		emailSender: emailSender,
+		baseURL:     baseURL,
+		now:         time.Now,
+	}
+}
+
+// TicketPriceChanged records a ticket type's new price. oldPrice is nil for
+// new ticket types. It implements PriceChangeRecorder.
+func (s *PriceHistoryService) TicketPriceChanged(ticketType *models.TicketType, oldPrice *int) {
+	if err := s.repo.RecordPriceChange(ticketType, oldPrice); err != nil {
+		fmt.Printf("Warning: failed to record price of ticket type %d: %v\n", ticketType.ID, err)
+	}
+}
+
+// GetEventHistory returns the price changes of an event's ticket types, newest first
+func (s *PriceHistoryService) GetEventHistory(eventID int) ([]*models.TicketPriceChange, error) {
+	changes, err := s.repo.GetByEvent(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get price history: %w", err)
+	}
+	return changes, nil
+}
+
+// SendPriceAlerts emails the watchers of events whose prices dropped or
+// whose early-bird tiers end within PriceIncreaseNoticeWindow, and returns
+// how many emails were sent. It is meant to run periodically.
+func (s *PriceHistoryService) SendPriceAlerts() (int, error) {
+	now := s.now()
+	sent := 0
+
+	drops, err := s.repo.GetPendingDrops()
+	if err != nil {
+		return sent, fmt.Errorf("failed to get price drops: %w", err)
+	}
+	for _, drop := range drops {
+		count, err := s.announceDrop(drop, now)
+		sent += count
+		if err != nil {
+			fmt.Printf("Warning: failed to announce price drop %d: %v\n", drop.ChangeID, err)
+		}
+	}
+
+	tiers, err := s.repo.GetEndingTiers(now, now.Add(models.PriceIncreaseNoticeWindow))
+	if err != nil {
+		return sent, fmt.Errorf("failed to get ending tiers: %w", err)
+	}
+	for _, tier := range tiers {
+		count, err := s.announceTierEnding(tier)
+		sent += count
+		if err != nil {
+			fmt.Printf("Warning: failed to announce end of tier %d: %v\n", tier.TicketTypeID, err)
+		}
+	}
+
+	return sent, nil
+}
+
+// announceDrop emails a price drop to the event's watchers, unless the price
+// has gone back up or the tickets can no longer be bought
+func (s *PriceHistoryService) announceDrop(drop *models.PriceDrop, now time.Time) (int, error) {
+	claimed, err := s.repo.ClaimPriceDrop(drop.ChangeID)
+	if err != nil || !claimed {
+		return 0, err
+	}
+	if drop.CurrentPrice >= drop.OldPrice || !drop.SaleEnd.After(now) {
+		return 0, nil
+	}
+
+	event, err := s.eventRepo.GetByID(drop.EventID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get event: %w", err)
+	}
+	if event.Status != models.StatusPublished || !event.StartDate.After(now) {
+		return 0, nil
+	}
+
+	return s.notifyWatchers(event, func(locale string) (string, string) {
+		return i18n.T(locale, "price_alert.drop.subject", event.Title),
+			i18n.T(locale, "price_alert.drop.message", drop.TicketTypeName, event.Title, priceAlertAmount(drop.CurrentPrice), priceAlertAmount(drop.OldPrice))
+	})
+}
+
+// announceTierEnding warns the event's watchers that an early-bird tier is
+// about to be replaced by a more expensive one
+func (s *PriceHistoryService) announceTierEnding(tier *models.EndingTier) (int, error) {
+	claimed, err := s.repo.ClaimTierEndingNotice(tier.TicketTypeID)
+	if err != nil || !claimed {
+		return 0, err
+	}
+
+	event, err := s.eventRepo.GetByID(tier.EventID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get event: %w", err)
+	}
+
+	return s.notifyWatchers(event, func(locale string) (string, string) {
+		return i18n.T(locale, "price_alert.ending.subject", event.Title),
+			i18n.T(locale, "price_alert.ending.message", tier.Name, event.Title, priceAlertAmount(tier.Price),
+				i18n.FormatLongDateTime(locale, tier.SaleEnd), tier.NextName, priceAlertAmount(tier.NextPrice))
+	})
+}
+
+// notifyWatchers emails everyone who saved the event the subject and message
+// built for their language
+func (s *PriceHistoryService) notifyWatchers(event *models.Event, message func(locale string) (string, string)) (int, error) {
+	if s.emailSender == nil {
+		return 0, nil
+	}
+
+	watchers, err := s.watchers.GetWatchers(event.ID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get event watchers: %w", err)
+	}
+
+	link := fmt.Sprintf("%s/events/%d", s.baseURL, event.ID)
+	sent := 0
+	for _, watcher := range watchers {
+		locale := i18n.Resolve(watcher.Locale)
+		subject, body := message(locale)
+		if err := s.emailSender.SendPriceAlertEmail(watcher.Email, watcher.Name, subject, locale, event, body, link); err != nil {
+			fmt.Printf("Warning: failed to send price alert to %s: %v\n", watcher.Email, err)
+			continue
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+// priceAlertAmount formats a price in cents for price alerts
+func priceAlertAmount(cents int) string {
+	return fmt.Sprintf("KSh %.2f", float64(cents)/100.0)
+}
@@ -0,0 +1,278 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock PriceHistoryRepository for testing
+type mockPriceHistoryRepository struct {
+	changes     []*models.TicketPriceChange
+	drops       []*models.PriceDrop
+	tiers       []*models.EndingTier
+	claimed     map[int]bool
+	tierNotices map[int]bool
+}
+
+func newMockPriceHistoryRepository() *mockPriceHistoryRepository {
+	return &mockPriceHistoryRepository{
+		claimed:     make(map[int]bool),
+		tierNotices: make(map[int]bool),
+	}
+}
+
+func (m *mockPriceHistoryRepository) RecordPriceChange(ticketType *models.TicketType, oldPrice *int) error {
+	m.changes = append(m.changes, &models.TicketPriceChange{
+		ID:           len(m.changes) + 1,
+		TicketTypeID: ticketType.ID,
+		EventID:      ticketType.EventID,
+		OldPrice:     oldPrice,
+		NewPrice:     ticketType.Price,
+	})
+	return nil
+}
+
+func (m *mockPriceHistoryRepository) GetByEvent(eventID int) ([]*models.TicketPriceChange, error) {
+	var changes []*models.TicketPriceChange
+	for _, change := range m.changes {
+		if change.EventID == eventID {
+			changes = append(changes, change)
+		}
+	}
+	return changes, nil
+}
+
+func (m *mockPriceHistoryRepository) GetPendingDrops() ([]*models.PriceDrop, error) {
+	var drops []*models.PriceDrop
+	for _, drop := range m.drops {
+		if !m.claimed[drop.ChangeID] {
+			drops = append(drops, drop)
+		}
+	}
+	return drops, nil
+}
+
+func (m *mockPriceHistoryRepository) ClaimPriceDrop(changeID int) (bool, error) {
+	if m.claimed[changeID] {
+		return false, nil
+	}
+	m.claimed[changeID] = true
+	return true, nil
+}
+
+func (m *mockPriceHistoryRepository) GetEndingTiers(from, to time.Time) ([]*models.EndingTier, error) {
+	var tiers []*models.EndingTier
+	for _, tier := range m.tiers {
+		if !m.tierNotices[tier.TicketTypeID] && tier.SaleEnd.After(from) && !tier.SaleEnd.After(to) {
+			tiers = append(tiers, tier)
+		}
+	}
+	return tiers, nil
+}
+
+func (m *mockPriceHistoryRepository) ClaimTierEndingNotice(ticketTypeID int) (bool, error) {
+	if m.tierNotices[ticketTypeID] {
+		return false, nil
+	}
+	m.tierNotices[ticketTypeID] = true
+	return true, nil
+}
+
+// Mock FavoriteRepository for testing
+type mockFavoriteRepository struct {
+	favorites map[string]bool
+	watchers  map[int][]*models.EventWatcher
+}
+
+func newMockFavoriteRepository() *mockFavoriteRepository {
+	return &mockFavoriteRepository{
+		favorites: make(map[string]bool),
+		watchers:  make(map[int][]*models.EventWatcher),
+	}
+}
+
+func (m *mockFavoriteRepository) Add(userID, eventID int) error {
+	m.favorites[fmt.Sprintf("%d/%d", userID, eventID)] = true
+	return nil
+}
+
+func (m *mockFavoriteRepository) Remove(userID, eventID int) error {
+	delete(m.favorites, fmt.Sprintf("%d/%d", userID, eventID))
+	return nil
+}
+
+func (m *mockFavoriteRepository) IsFavorite(userID, eventID int) (bool, error) {
+	return m.favorites[fmt.Sprintf("%d/%d", userID, eventID)], nil
+}
+
+func (m *mockFavoriteRepository) GetWatchers(eventID int) ([]*models.EventWatcher, error) {
+	return m.watchers[eventID], nil
+}
+
+func (m *mockFavoriteRepository) GetSavedEvents(userID int) ([]*models.Event, error) {
+	return nil, nil
+}
+
+// mockPriceAlertEmailSender records sent price alerts
+type mockPriceAlertEmailSender struct {
+	emails  []string
+	locales []string
+}
+
+func (m *mockPriceAlertEmailSender) SendPriceAlertEmail(email, userName, subject, locale string, event *models.Event, message, link string) error {
+	m.emails = append(m.emails, fmt.Sprintf("%s|%s|%s|%s", email, subject, message, link))
+	m.locales = append(m.locales, locale)
+	return nil
+}
+
+func setupPriceHistoryService() (*PriceHistoryService, *mockPriceHistoryRepository, *mockFavoriteRepository, *mockPriceAlertEmailSender) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	eventRepo := newMockEventRepository()
+	eventRepo.events[1] = &models.Event{ID: 1, Title: "Jazz Night", Status: models.StatusPublished, StartDate: now.Add(14 * 24 * time.Hour)}
+	eventRepo.events[2] = &models.Event{ID: 2, Title: "Tech Meetup", Status: models.StatusDraft, StartDate: now.Add(14 * 24 * time.Hour)}
+
+	repo := newMockPriceHistoryRepository()
+	favorites := newMockFavoriteRepository()
+	for eventID := 1; eventID <= 2; eventID++ {
+		favorites.watchers[eventID] = []*models.EventWatcher{
+			{UserID: eventID, Email: fmt.Sprintf("fan%d@example.com", eventID), Name: "Fan"},
+		}
+	}
+
+	emailSender := &mockPriceAlertEmailSender{}
+	service := NewPriceHistoryService(repo, NewFavoriteService(favorites), eventRepo, emailSender, "https://example.com")
+	service.now = func() time.Time { return now }
+
+	return service, repo, favorites, emailSender
+}
+
+func TestPriceHistoryService_SendPriceAlerts(t *testing.T) {
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+
+	t.Run("announces each price drop once", func(t *testing.T) {
+		service, repo, _, emailSender := setupPriceHistoryService()
+		repo.drops = []*models.PriceDrop{
+			{ChangeID: 1, EventID: 1, TicketTypeName: "VIP", OldPrice: 500000, CurrentPrice: 350000, SaleStart: now.Add(-time.Hour), SaleEnd: now.Add(48 * time.Hour)},
+		}
+
+		sent, err := service.SendPriceAlerts()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if sent != 1 || len(emailSender.emails) != 1 {
+			t.Fatalf("expected 1 price alert, got %v", emailSender.emails)
+		}
+
+		expected := "fan1@example.com|Price drop: Jazz Night|VIP tickets for Jazz Night are now KSh 3500.00, down from KSh 5000.00.|https://example.com/events/1"
+		if emailSender.emails[0] != expected {
+			t.Errorf("expected %q, got %q", expected, emailSender.emails[0])
+		}
+
+		sent, err = service.SendPriceAlerts()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if sent != 0 {
+			t.Errorf("expected the drop not to be announced twice, got %d", sent)
+		}
+	})
+
+	t.Run("skips drops that no longer apply", func(t *testing.T) {
+		service, repo, _, emailSender := setupPriceHistoryService()
+		repo.drops = []*models.PriceDrop{
+			// The price went back up before the job ran
+			{ChangeID: 1, EventID: 1, TicketTypeName: "VIP", OldPrice: 500000, CurrentPrice: 500000, SaleEnd: now.Add(48 * time.Hour)},
+			// The tickets are no longer on sale
+			{ChangeID: 2, EventID: 1, TicketTypeName: "Early Bird", OldPrice: 200000, CurrentPrice: 150000, SaleEnd: now.Add(-time.Hour)},
+			// The event is not published
+			{ChangeID: 3, EventID: 2, TicketTypeName: "General", OldPrice: 100000, CurrentPrice: 80000, SaleEnd: now.Add(48 * time.Hour)},
+		}
+
+		sent, err := service.SendPriceAlerts()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if sent != 0 {
+			t.Errorf("expected no price alerts, got %v", emailSender.emails)
+		}
+		if len(repo.claimed) != 3 {
+			t.Errorf("expected skipped drops to be marked as handled, got %v", repo.claimed)
+		}
+	})
+
+	t.Run("warns watchers once before an early-bird tier ends", func(t *testing.T) {
+		service, repo, favorites, emailSender := setupPriceHistoryService()
+		favorites.watchers[1] = append(favorites.watchers[1], &models.EventWatcher{UserID: 3, Email: "amina@example.com", Name: "Amina", Locale: "sw"})
+		repo.tiers = []*models.EndingTier{
+			{TicketTypeID: 7, EventID: 1, Name: "Early Bird", Price: 150000, SaleEnd: now.Add(48 * time.Hour), NextName: "Regular", NextPrice: 250000},
+			{TicketTypeID: 8, EventID: 1, Name: "Super Early", Price: 100000, SaleEnd: now.Add(10 * 24 * time.Hour), NextName: "Early Bird", NextPrice: 150000},
+		}
+
+		sent, err := service.SendPriceAlerts()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if sent != 2 {
+			t.Fatalf("expected 2 price alerts, got %v", emailSender.emails)
+		}
+		if !strings.HasPrefix(emailSender.emails[0], "fan1@example.com|Prices for Jazz Night go up soon|Early Bird tickets for Jazz Night cost KSh 1500.00 until ") ||
+			!strings.Contains(emailSender.emails[0], "After that, Regular tickets cost KSh 2500.00.") {
+			t.Errorf("unexpected price alert %q", emailSender.emails[0])
+		}
+		if emailSender.locales[1] != "sw" {
+			t.Errorf("expected the second watcher to be emailed in sw, got %q", emailSender.locales[1])
+		}
+
+		sent, _ = service.SendPriceAlerts()
+		if sent != 0 {
+			t.Errorf("expected the tier not to be announced twice, got %d", sent)
+		}
+	})
+}
+
+func TestFavoriteService_ToggleFavorite(t *testing.T) {
+	service := NewFavoriteService(newMockFavoriteRepository())
+
+	favorited, err := service.ToggleFavorite(1, 5)
+	if err != nil || !favorited {
+		t.Fatalf("expected the event to be saved, got %v (%v)", favorited, err)
+	}
+	if saved, _ := service.IsFavorite(1, 5); !saved {
+		t.Error("expected the event to be saved")
+	}
+
+	favorited, err = service.ToggleFavorite(1, 5)
+	if err != nil || favorited {
+		t.Fatalf("expected the event to be unsaved, got %v (%v)", favorited, err)
+	}
+	if saved, _ := service.IsFavorite(1, 5); saved {
+		t.Error("expected the event to be unsaved")
+	}
+}
+
+func TestTicketService_RecordsPriceChanges(t *testing.T) {
+	service, ticketRepo, _, _, _ := createTestTicketService()
+	priceHistory, repo, _, _ := setupPriceHistoryService()
+	service.SetPriceHistory(priceHistory)
+
+	ticketType := createTestTicketType(ticketRepo, 1)
+
+	// Edits that keep the price are not recorded
+	service.UpdateTicketType(ticketType.ID, &models.TicketTypeUpdateRequest{Name: "General", Price: 2500, Quantity: 150})
+	if len(repo.changes) != 0 {
+		t.Fatalf("expected no price change, got %d", len(repo.changes))
+	}
+
+	service.UpdateTicketType(ticketType.ID, &models.TicketTypeUpdateRequest{Name: "General", Price: 2000, Quantity: 150})
+	if len(repo.changes) != 1 {
+		t.Fatalf("expected 1 price change, got %d", len(repo.changes))
+	}
+	change := repo.changes[0]
+	if change.OldPrice == nil || *change.OldPrice != 2500 || change.NewPrice != 2000 || !change.IsDrop() {
+		t.Errorf("unexpected price change: %+v", change)
+	}
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"errors"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// PrivacyRepositoryInterface defines the privacy preference data operations
+type PrivacyRepositoryInterface interface {
+	GetByUserID(userID int) (*models.PrivacyPreferences, error)
+	GetByConsentID(consentID string) (*models.PrivacyPreferences, error)
+	Save(prefs *models.PrivacyPreferences) error
+}
+
+// PrivacyService stores the analytics and marketing consent visitors give
+type PrivacyService struct {
+	repo PrivacyRepositoryInterface
+}
+
+// NewPrivacyService creates a new privacy service
+func NewPrivacyService(repo PrivacyRepositoryInterface) *PrivacyService {
+	return &PrivacyService{repo: repo}
+}
+
+// Preferences returns a visitor's preferences: the signed-in user's latest
+// choice, else the one saved under their consent cookie. It returns nil if
+// they haven't chosen yet. userID is 0 and consentID empty when unknown.
+func (s *PrivacyService) Preferences(userID int, consentID string) (*models.PrivacyPreferences, error) {
+	if userID > 0 {
+		prefs, err := s.repo.GetByUserID(userID)
+		if err != nil || prefs != nil {
+			return prefs, err
+		}
+	}
+	if consentID == "" {
+		return nil, nil
+	}
+	return s.repo.GetByConsentID(consentID)
+}
+
+// Save records a visitor's choice under their consent cookie, and against
+// their account when they are signed in
+func (s *PrivacyService) Save(userID int, consentID string, analytics, marketing bool) (*models.PrivacyPreferences, error) {
+	if consentID == "" {
+		return nil, errors.New("consent ID is required")
+	}
+
+	prefs := &models.PrivacyPreferences{
+		ConsentID: consentID,
+		Analytics: analytics,
+		Marketing: marketing,
+	}
+	if userID > 0 {
+		prefs.UserID = &userID
+	}
+
+	if err := s.repo.Save(prefs); err != nil {
+		return nil, err
+	}
+	return prefs, nil
+}
+
+// MarketingAllowed reports whether a user agreed to marketing emails. It
+// implements MarketingConsent.
+func (s *PrivacyService) MarketingAllowed(userID int) (bool, error) {
+	prefs, err := s.repo.GetByUserID(userID)
+	if err != nil {
+		return false, err
+	}
+	return prefs.AllowsMarketing(), nil
+}
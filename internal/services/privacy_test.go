@@ -0,0 +1,92 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock PrivacyRepository for testing
+type mockPrivacyRepository struct {
+	byConsent map[string]*models.PrivacyPreferences
+}
+
+func newMockPrivacyRepository() *mockPrivacyRepository {
+	return &mockPrivacyRepository{byConsent: make(map[string]*models.PrivacyPreferences)}
+}
+
+func (m *mockPrivacyRepository) GetByUserID(userID int) (*models.PrivacyPreferences, error) {
+	var latest *models.PrivacyPreferences
+	for _, prefs := range m.byConsent {
+		if prefs.UserID != nil && *prefs.UserID == userID && (latest == nil || prefs.UpdatedAt.After(latest.UpdatedAt)) {
+			latest = prefs
+		}
+	}
+	return latest, nil
+}
+
+func (m *mockPrivacyRepository) GetByConsentID(consentID string) (*models.PrivacyPreferences, error) {
+	return m.byConsent[consentID], nil
+}
+
+func (m *mockPrivacyRepository) Save(prefs *models.PrivacyPreferences) error {
+	if existing, ok := m.byConsent[prefs.ConsentID]; ok && prefs.UserID == nil {
+		prefs.UserID = existing.UserID
+	}
+	prefs.UpdatedAt = time.Now()
+	m.byConsent[prefs.ConsentID] = prefs
+	return nil
+}
+
+func TestPrivacyService_Preferences(t *testing.T) {
+	repo := newMockPrivacyRepository()
+	service := NewPrivacyService(repo)
+
+	if prefs, err := service.Preferences(0, "browser-a"); err != nil || prefs != nil {
+		t.Fatalf("expected no preferences before choosing, got %+v, %v", prefs, err)
+	}
+
+	// An anonymous choice is found by its cookie
+	if _, err := service.Save(0, "browser-a", true, false); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	prefs, _ := service.Preferences(0, "browser-a")
+	if !prefs.AllowsAnalytics() || prefs.AllowsMarketing() {
+		t.Errorf("unexpected preferences: %+v", prefs)
+	}
+
+	// Once signed in, the user's choice applies on every browser
+	if _, err := service.Save(5, "browser-b", false, true); err != nil {
+		t.Fatalf("Save() error = %v", err)
+	}
+	prefs, _ = service.Preferences(5, "browser-a")
+	if prefs.ConsentID != "browser-b" || prefs.AllowsAnalytics() || !prefs.AllowsMarketing() {
+		t.Errorf("expected the user's choice to win over the browser's, got %+v", prefs)
+	}
+
+	if _, err := service.Save(5, "", true, true); err == nil {
+		t.Error("expected saving without a consent ID to fail")
+	}
+}
+
+func TestPrivacyService_MarketingAllowed(t *testing.T) {
+	repo := newMockPrivacyRepository()
+	service := NewPrivacyService(repo)
+
+	// Users who never chose get no marketing
+	if allowed, _ := service.MarketingAllowed(5); allowed {
+		t.Error("expected marketing to be off until the user agrees")
+	}
+
+	service.Save(5, "browser-a", false, true)
+	if allowed, _ := service.MarketingAllowed(5); !allowed {
+		t.Error("expected marketing to be allowed once the user agreed")
+	}
+
+	// Anonymous choices don't reach the account
+	service.Save(0, "browser-c", true, true)
+	if allowed, _ := service.MarketingAllowed(6); allowed {
+		t.Error("expected another user's marketing to stay off")
+	}
+}
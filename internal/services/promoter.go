@@ -0,0 +1,53 @@
+package services
+
+import (
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// PromoterRepository defines the data operations for promoter links
+type PromoterRepository interface {
+	Create(req *models.PromoterLinkCreateRequest) (*models.PromoterLink, error)
+	GetStatsByOrganizer(organizerID int) ([]*models.PromoterLinkStats, error)
+	Deactivate(id, organizerID int) error
+}
+
+// PromoterService manages the tracking links organizers give promoters and
+// the commission the links' sales earn them. Sales are attributed through
+// the ref parameter of the link, which buyers' sessions remember.
+type PromoterService struct {
+	repo PromoterRepository
+}
+
+// NewPromoterService creates a new promoter service
+func NewPromoterService(repo PromoterRepository) *PromoterService {
+	return &PromoterService{repo: repo}
+}
+
+// CreateLink validates and creates a promoter link for an event
+func (s *PromoterService) CreateLink(req *models.PromoterLinkCreateRequest) (*models.PromoterLink, error) {
+	if err := req.Validate(); err != nil {
+		return nil, err
+	}
+	return s.repo.Create(req)
+}
+
+// GetPayoutSummary returns an organizer's promoter links with their sales
+// and the commission owed to each promoter
+func (s *PromoterService) GetPayoutSummary(organizerID int) (*models.PromoterPayoutSummary, error) {
+	links, err := s.repo.GetStatsByOrganizer(organizerID)
+	if err != nil {
+		return nil, err
+	}
+	return models.NewPromoterPayoutSummary(links), nil
+}
+
+// DeactivateLink stops a promoter link earning new sales. Its past sales and
+// commission are kept.
+func (s *PromoterService) DeactivateLink(id, organizerID int) error {
+	if err := s.repo.Deactivate(id, organizerID); err != nil {
+		return fmt.Errorf("failed to deactivate promoter link: %w", err)
+	}
+	return nil
+}
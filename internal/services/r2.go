@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net/url"
 	"strings"
 	"time"
 
@@ -110,6 +111,61 @@ func (r *R2Service) Delete(ctx context.Context, key string) error {
 	return nil
 }
 
+// List returns every object in the bucket whose key starts with prefix
+func (r *R2Service) List(ctx context.Context, prefix string) ([]StoredObject, error) {
+	input := &s3.ListObjectsV2Input{
+		Bucket: aws.String(r.config.BucketName),
+		Prefix: aws.String(strings.TrimPrefix(prefix, "/")),
+	}
+
+	var objects []StoredObject
+	paginator := s3.NewListObjectsV2Paginator(r.client, input)
+	for paginator.HasMorePages() {
+		page, err := paginator.NextPage(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list R2 objects: %w", err)
+		}
+		for _, object := range page.Contents {
+			objects = append(objects, StoredObject{
+				Key:          aws.ToString(object.Key),
+				Size:         aws.ToInt64(object.Size),
+				LastModified: aws.ToTime(object.LastModified),
+			})
+		}
+	}
+
+	return objects, nil
+}
+
+// Open streams an object's contents from R2
+func (r *R2Service) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	output, err := r.client.GetObject(ctx, &s3.GetObjectInput{
+		Bucket: aws.String(r.config.BucketName),
+		Key:    aws.String(strings.TrimPrefix(key, "/")),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get R2 object: %w", err)
+	}
+	return output.Body, nil
+}
+
+// Move copies an object to a new key within the bucket and deletes the original
+func (r *R2Service) Move(ctx context.Context, fromKey, toKey string) error {
+	fromKey = strings.TrimPrefix(fromKey, "/")
+	toKey = strings.TrimPrefix(toKey, "/")
+
+	_, err := r.client.CopyObject(ctx, &s3.CopyObjectInput{
+		Bucket:     aws.String(r.config.BucketName),
+		CopySource: aws.String(url.PathEscape(r.config.BucketName + "/" + fromKey)),
+		Key:        aws.String(toKey),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to copy R2 object: %w", err)
+	}
+
+	return r.Delete(ctx, fromKey)
+}
+
 // GetURL returns the public URL for a file
 func (r *R2Service) GetURL(key string) string {
 	key = strings.TrimPrefix(key, "/")
@@ -1,14 +1,16 @@
 package services
 
 import (
-	"bytes"
 	"encoding/json"
 	"fmt"
-	"net/http"
+	"html"
+	"log/slog"
 	"strings"
 	"time"
 	
+	"event-ticketing-platform/internal/i18n"
 	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/web/templates/emails"
 )
 
 // ResendConfig represents Resend email service configuration
@@ -18,37 +20,37 @@ type ResendConfig struct {
 	FromName  string
 }
 
-// ResendEmailService handles email sending via Resend API
+// ResendEmailService composes the platform's emails and sends them through
+// an email provider, Resend unless another is set
 type ResendEmailService struct {
-	config ResendConfig
-	client *http.Client
+	config      ResendConfig
+	provider    EmailProvider
+	snippets    EmailSnippetProvider
+	deliveryLog EmailDeliveryLog
+	settings    EmailSettings
 }
 
-// NewResendEmailService creates a new Resend email service
-func NewResendEmailService(config ResendConfig) *ResendEmailService {
-	return &ResendEmailService{
-		config: config,
-		client: &http.Client{
-			Timeout: 30 * time.Second,
-		},
-	}
+// EmailSettings provides the system settings that apply to every email
+type EmailSettings interface {
+	GetSettings() (*models.SystemSettings, error)
+}
+
+// EmailSnippetProvider returns the current content of an admin-editable snippet
+type EmailSnippetProvider interface {
+	Get(key models.SnippetKey) string
 }
 
-// ResendEmailRequest represents the request structure for Resend API
-type ResendEmailRequest struct {
-	From     string            `json:"from"`
-	To       []string          `json:"to"`
-	Subject  string            `json:"subject"`
-	HTML     string            `json:"html,omitempty"`
-	Text     string            `json:"text,omitempty"`
-	Headers  map[string]string `json:"headers,omitempty"`
-	Tags     []ResendTag       `json:"tags,omitempty"`
+// EmailDeliveryLog records every email handed to the email provider
+type EmailDeliveryLog interface {
+	Create(entry *models.EmailLog) error
 }
 
-// ResendTag represents a tag for email categorization
-type ResendTag struct {
-	Name  string `json:"name"`
-	Value string `json:"value"`
+// NewResendEmailService creates a new Resend email service
+func NewResendEmailService(config ResendConfig) *ResendEmailService {
+	return &ResendEmailService{
+		config:   config,
+		provider: NewResendProvider(config),
+	}
 }
 
 // ResendEmailResponse represents the response from Resend API
@@ -62,12 +64,59 @@ type ResendErrorResponse struct {
 	Name    string `json:"name"`
 }
 
+// SetProvider sets the provider emails are sent through, such as SMTP, SES
+// or a failover between two providers
+func (s *ResendEmailService) SetProvider(provider EmailProvider) {
+	s.provider = provider
+}
+
+// SetDeliveryLog records every email sent, and each failure to send one, so
+// admins can follow deliveries and retry failures
+func (s *ResendEmailService) SetDeliveryLog(deliveryLog EmailDeliveryLog) {
+	s.deliveryLog = deliveryLog
+}
+
+// SetSnippets adds the admin-editable footer text and support contact to
+// every email, and the refund policy to order confirmations
+func (s *ResendEmailService) SetSnippets(snippets EmailSnippetProvider) {
+	s.snippets = snippets
+}
+
+// SetSettings sends every email with the Reply-To address the system
+// settings name, if any
+func (s *ResendEmailService) SetSettings(settings EmailSettings) {
+	s.settings = settings
+}
+
+// addReplyTo sets the email's Reply-To to the address the system settings name
+func (s *ResendEmailService) addReplyTo(request *EmailMessage) {
+	if s.settings == nil {
+		return
+	}
+	settings, err := s.settings.GetSettings()
+	if err != nil || settings.EmailReplyTo == "" {
+		return
+	}
+	if _, ok := request.Headers["Reply-To"]; ok {
+		return
+	}
+	if request.Headers == nil {
+		request.Headers = make(map[string]string)
+	}
+	request.Headers["Reply-To"] = settings.EmailReplyTo
+}
+
 // getFromField constructs the from field properly
 func (s *ResendEmailService) getFromField() string {
-	if s.config.FromName != "" {
-		return fmt.Sprintf("%s <%s>", s.config.FromName, s.config.FromEmail)
+	return fromAddress(s.config)
+}
+
+// fromAddress formats the configured sender as "Name <email>"
+func fromAddress(config ResendConfig) string {
+	if config.FromName != "" {
+		return fmt.Sprintf("%s <%s>", config.FromName, config.FromEmail)
 	}
-	return s.config.FromEmail
+	return config.FromEmail
 }
 
 // SendPasswordResetEmail sends a password reset email via Resend
@@ -128,13 +177,13 @@ For security reasons, please do not share this link with anyone.
 
 Runtown Security Team`, resetLink)
 
-	request := ResendEmailRequest{
+	request := EmailMessage{
 		From:    s.getFromField(),
 		To:      []string{email},
 		Subject: "Password Reset Request",
 		HTML:    htmlContent,
 		Text:    textContent,
-		Tags: []ResendTag{
+		Tags: []EmailTag{
 			{Name: "category", Value: "password_reset"},
 		},
 	}
@@ -142,175 +191,241 @@ Runtown Security Team`, resetLink)
 	return s.sendEmail(request)
 }
 
-// SendWelcomeEmail sends a welcome email to new users
-func (s *ResendEmailService) SendWelcomeEmail(email, userName string) error {
+// SendMagicLinkEmail sends a single-use passwordless sign-in link via Resend
+func (s *ResendEmailService) SendMagicLinkEmail(email, userName, link string, expiresIn time.Duration) error {
+	minutes := int(expiresIn.Minutes())
+
 	htmlContent := fmt.Sprintf(`
 <!DOCTYPE html>
 <html>
 <head>
     <meta charset="UTF-8">
-    <title>Welcome</title>
+    <title>Your sign-in link</title>
     <style>
         body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
         .container { max-width: 600px; margin: 0 auto; padding: 20px; }
-        .header { background-color: #7C3AED; color: white; padding: 20px; text-align: center; }
+        .header { background-color: #2563EB; color: white; padding: 20px; text-align: center; }
         .content { padding: 20px; background-color: #f9f9f9; }
-        .button { display: inline-block; padding: 12px 24px; background-color: #7C3AED; color: white; text-decoration: none; border-radius: 4px; margin: 20px 0; }
+        .button { display: inline-block; padding: 12px 24px; background-color: #2563EB; color: white; text-decoration: none; border-radius: 4px; margin: 20px 0; }
         .footer { text-align: center; padding: 20px; color: #666; font-size: 12px; }
     </style>
 </head>
 <body>
     <div class="container">
         <div class="header">
-            <h1>Welcome to Runtown!</h1>
+            <h1>Sign in to Runtown</h1>
         </div>
         <div class="content">
-            <p>Dear %s,</p>
-            <p>Welcome to Runtown! We're excited to have you join our community.</p>
-            
-            <p>With your new account, you can:</p>
-            <ul>
-                <li>Browse and discover amazing events</li>
-                <li>Purchase tickets securely</li>
-                <li>Manage your orders and tickets</li>
-                <li>Get notified about upcoming events</li>
-            </ul>
+            <p>Hi %s,</p>
+            <p>Click the button below to sign in to your account. No password needed.</p>
             
-            <a href="https://runtown.onrender.com/events" class="button">Start Exploring Events</a>
+            <a href="%s" class="button">Sign In</a>
             
-            <p>If you have any questions, feel free to contact our support team.</p>
+            <p>This link will expire in %d minutes and can only be used once.</p>
+            <p>If you didn't request this link, you can safely ignore this email.</p>
             
-            <p>Happy event hunting!</p>
+            <p>For security reasons, please do not share this link with anyone.</p>
         </div>
         <div class="footer">
-            <p>Runtown Team</p>
+            <p>Runtown Security Team</p>
         </div>
     </div>
 </body>
-</html>`, userName)
-
-	textContent := fmt.Sprintf(`Welcome to Runtown!
+</html>`, userName, link, minutes)
 
-Dear %s,
+	textContent := fmt.Sprintf(`Sign in to Runtown
 
-Welcome to Runtown! We're excited to have you join our community.
+Hi %s,
 
-With your new account, you can:
-- Browse and discover amazing events
-- Purchase tickets securely
-- Manage your orders and tickets
-- Get notified about upcoming events
+Visit the following link to sign in to your account. No password needed.
 
-Start exploring events: https://runtown.onrender.com/events
+%s
 
-If you have any questions, feel free to contact our support team.
+This link will expire in %d minutes and can only be used once.
 
-Happy event hunting!
+If you didn't request this link, you can safely ignore this email.
 
-Runtown Team`, userName)
+Runtown Security Team`, userName, link, minutes)
 
-	request := ResendEmailRequest{
+	request := EmailMessage{
 		From:    s.getFromField(),
 		To:      []string{email},
-		Subject: "Welcome to Runtown!",
+		Subject: "Your sign-in link",
 		HTML:    htmlContent,
 		Text:    textContent,
-		Tags: []ResendTag{
-			{Name: "category", Value: "welcome"},
+		Tags: []EmailTag{
+			{Name: "category", Value: "magic_link"},
 		},
 	}
 
 	return s.sendEmail(request)
 }
 
-// SendVerificationEmail sends an email verification link to new users
-func (s *ResendEmailService) SendVerificationEmail(email, userName, token string) error {
-	verificationLink := fmt.Sprintf("https://runtown.onrender.com/auth/verify?token=%s", token)
-	
+// SendAccountUnlockEmail tells a user their account was locked after too many
+// failed logins and sends a single-use link that unlocks it via Resend
+func (s *ResendEmailService) SendAccountUnlockEmail(email, userName, link string, lockedUntil time.Time) error {
+	minutes := int(time.Until(lockedUntil).Round(time.Minute).Minutes())
+
 	htmlContent := fmt.Sprintf(`
 <!DOCTYPE html>
 <html>
 <head>
     <meta charset="UTF-8">
-    <title>Verify Your Email</title>
+    <title>Your account was locked</title>
     <style>
         body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
         .container { max-width: 600px; margin: 0 auto; padding: 20px; }
-        .header { background-color: #059669; color: white; padding: 20px; text-align: center; }
+        .header { background-color: #DC2626; color: white; padding: 20px; text-align: center; }
         .content { padding: 20px; background-color: #f9f9f9; }
-        .button { display: inline-block; padding: 12px 24px; background-color: #059669; color: white; text-decoration: none; border-radius: 4px; margin: 20px 0; }
+        .button { display: inline-block; padding: 12px 24px; background-color: #2563EB; color: white; text-decoration: none; border-radius: 4px; margin: 20px 0; }
         .footer { text-align: center; padding: 20px; color: #666; font-size: 12px; }
-        .warning { background-color: #FEF3C7; padding: 15px; border-left: 4px solid #F59E0B; margin: 20px 0; }
     </style>
 </head>
 <body>
     <div class="container">
         <div class="header">
-            <h1>Verify Your Email Address</h1>
+            <h1>Your account was locked</h1>
         </div>
         <div class="content">
-            <p>Dear %s,</p>
-            <p>Thank you for creating an account with Runtown! To complete your registration and access all features, please verify your email address by clicking the button below:</p>
+            <p>Hi %s,</p>
+            <p>We locked your Runtown account after several failed sign-in attempts. It unlocks by itself in %d minutes, or you can unlock it now:</p>
             
-            <div style="text-align: center;">
-                <a href="%s" class="button">Verify My Email</a>
-            </div>
-            
-            <p>Or copy and paste this link into your browser:</p>
-            <p style="word-break: break-all; background-color: #f0f0f0; padding: 10px; border-radius: 4px;">%s</p>
+            <a href="%s" class="button">Unlock My Account</a>
             
-            <div class="warning">
-                <p><strong>Important:</strong> This verification link will expire in 24 hours for security reasons.</p>
-            </div>
+            <p>If these attempts weren't you, someone may know your email address. Unlock your account and change your password to keep it safe.</p>
             
-            <p>If you did not create an account with Runtown, please ignore this email.</p>
+            <p>For security reasons, please do not share this link with anyone.</p>
+        </div>
+        <div class="footer">
+            <p>Runtown Security Team</p>
+        </div>
+    </div>
+</body>
+</html>`, userName, minutes, link)
+
+	textContent := fmt.Sprintf(`Your account was locked
+
+Hi %s,
+
+We locked your Runtown account after several failed sign-in attempts. It unlocks by itself in %d minutes, or you can unlock it now by visiting the following link:
+
+%s
+
+If these attempts weren't you, someone may know your email address. Unlock your account and change your password to keep it safe.
+
+Runtown Security Team`, userName, minutes, link)
+
+	request := EmailMessage{
+		From:    s.getFromField(),
+		To:      []string{email},
+		Subject: "Your account was locked",
+		HTML:    htmlContent,
+		Text:    textContent,
+		Tags: []EmailTag{
+			{Name: "category", Value: "account_unlock"},
+		},
+	}
+
+	return s.sendEmail(request)
+}
+
+// SendWelcomeEmail sends a welcome email to new users
+func (s *ResendEmailService) SendWelcomeEmail(email, userName string) error {
+	htmlContent := fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>Welcome</title>
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background-color: #7C3AED; color: white; padding: 20px; text-align: center; }
+        .content { padding: 20px; background-color: #f9f9f9; }
+        .button { display: inline-block; padding: 12px 24px; background-color: #7C3AED; color: white; text-decoration: none; border-radius: 4px; margin: 20px 0; }
+        .footer { text-align: center; padding: 20px; color: #666; font-size: 12px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>Welcome to Runtown!</h1>
+        </div>
+        <div class="content">
+            <p>Dear %s,</p>
+            <p>Welcome to Runtown! We're excited to have you join our community.</p>
             
-            <p>Once your email is verified, you'll be able to:</p>
+            <p>With your new account, you can:</p>
             <ul>
                 <li>Browse and discover amazing events</li>
                 <li>Purchase tickets securely</li>
                 <li>Manage your orders and tickets</li>
-                <li>Receive important event updates</li>
+                <li>Get notified about upcoming events</li>
             </ul>
             
-            <p>Welcome to the community!</p>
+            <a href="https://runtown.onrender.com/events" class="button">Start Exploring Events</a>
+            
+            <p>If you have any questions, feel free to contact our support team.</p>
+            
+            <p>Happy event hunting!</p>
         </div>
         <div class="footer">
             <p>Runtown Team</p>
         </div>
     </div>
 </body>
-</html>`, userName, verificationLink, verificationLink)
+</html>`, userName)
 
-	textContent := fmt.Sprintf(`Verify Your Email Address
+	textContent := fmt.Sprintf(`Welcome to Runtown!
 
 Dear %s,
 
-Thank you for creating an account with Runtown! To complete your registration and access all features, please verify your email address by visiting the following link:
-
-%s
-
-Important: This verification link will expire in 24 hours for security reasons.
-
-If you did not create an account with Runtown, please ignore this email.
+Welcome to Runtown! We're excited to have you join our community.
 
-Once your email is verified, you'll be able to:
+With your new account, you can:
 - Browse and discover amazing events
 - Purchase tickets securely
 - Manage your orders and tickets
-- Receive important event updates
+- Get notified about upcoming events
+
+Start exploring events: https://runtown.onrender.com/events
+
+If you have any questions, feel free to contact our support team.
 
-Welcome to the community!
+Happy event hunting!
 
-The Runtown Team`, userName, verificationLink)
+Runtown Team`, userName)
 
-	request := ResendEmailRequest{
+	request := EmailMessage{
 		From:    s.getFromField(),
 		To:      []string{email},
-		Subject: "Verify your email address - Runtown",
+		Subject: "Welcome to Runtown!",
 		HTML:    htmlContent,
 		Text:    textContent,
-		Tags: []ResendTag{
+		Tags: []EmailTag{
+			{Name: "category", Value: "welcome"},
+		},
+	}
+
+	return s.sendEmail(request)
+}
+
+// SendVerificationEmail sends an email verification link to new users
+func (s *ResendEmailService) SendVerificationEmail(email, userName, token string) error {
+	verificationLink := fmt.Sprintf("https://runtown.onrender.com/auth/verify?token=%s", token)
+
+	message, err := emails.Verify(emails.VerifyData{Locale: i18n.English, Name: userName, Link: verificationLink})
+	if err != nil {
+		return err
+	}
+
+	request := EmailMessage{
+		From:    s.getFromField(),
+		To:      []string{email},
+		Subject: message.Subject,
+		HTML:    message.HTML,
+		Text:    message.Text,
+		Tags: []EmailTag{
 			{Name: "category", Value: "email_verification"},
 		},
 	}
@@ -379,13 +494,13 @@ Please bring your tickets (printed or on your mobile device) to the event.
 
 Thank you for choosing Runtown!`, userName, eventTitle, eventDate, orderNumber, totalAmount)
 
-	request := ResendEmailRequest{
+	request := EmailMessage{
 		From:    s.getFromField(),
 		To:      []string{email},
 		Subject: fmt.Sprintf("Order Confirmation - %s", eventTitle),
 		HTML:    htmlContent,
 		Text:    textContent,
-		Tags: []ResendTag{
+		Tags: []EmailTag{
 			{Name: "category", Value: "order_confirmation"},
 		},
 	}
@@ -399,187 +514,895 @@ func (s *ResendEmailService) SendOrderConfirmationWithTickets(email, userName, s
 	enhancedHTMLContent := s.enhanceOrderConfirmationHTML(htmlContent, order, tickets)
 	enhancedTextContent := s.enhanceOrderConfirmationText(textContent, order, tickets)
 	
-	request := ResendEmailRequest{
+	request := EmailMessage{
 		From:    s.getFromField(),
 		To:      []string{email},
 		Subject: subject,
 		HTML:    enhancedHTMLContent,
 		Text:    enhancedTextContent,
-		Tags: []ResendTag{
+		Tags: []EmailTag{
 			{Name: "category", Value: "order_confirmation_with_tickets"},
 			{Name: "order_number", Value: order.OrderNumber},
 			{Name: "ticket_count", Value: fmt.Sprintf("%d", len(tickets))},
+			{Name: "locale", Value: i18n.Resolve(order.Locale)},
 		},
 	}
 
 	return s.sendEmail(request)
 }
 
-// enhanceOrderConfirmationHTML enhances the HTML content with additional ticket information
-func (s *ResendEmailService) enhanceOrderConfirmationHTML(originalHTML string, order *models.Order, tickets []*models.Ticket) string {
-	// Add ticket details section to the HTML
-	ticketDetailsHTML := `
-		<div style="margin: 30px 0; padding: 20px; background-color: #f8fafc; border-radius: 8px; border: 1px solid #e2e8f0;">
-			<h3 style="margin-top: 0; color: #1e293b; font-size: 18px;">Ticket Details</h3>
-			<div style="margin: 15px 0;">
-				<table style="width: 100%; border-collapse: collapse;">
-					<thead>
-						<tr style="background-color: #e2e8f0;">
-							<th style="padding: 10px; text-align: left; border: 1px solid #cbd5e1; font-size: 14px; color: #475569;">Ticket #</th>
-							<th style="padding: 10px; text-align: left; border: 1px solid #cbd5e1; font-size: 14px; color: #475569;">QR Code</th>
-							<th style="padding: 10px; text-align: left; border: 1px solid #cbd5e1; font-size: 14px; color: #475569;">Status</th>
-						</tr>
-					</thead>
-					<tbody>`
-
-	for i, ticket := range tickets {
-		ticketDetailsHTML += fmt.Sprintf(`
-						<tr>
-							<td style="padding: 10px; border: 1px solid #cbd5e1; font-size: 14px;">Ticket #%d</td>
-							<td style="padding: 10px; border: 1px solid #cbd5e1; font-size: 12px; font-family: monospace;">%s</td>
-							<td style="padding: 10px; border: 1px solid #cbd5e1; font-size: 14px;">
-								<span style="background-color: #dcfce7; color: #166534; padding: 4px 8px; border-radius: 4px; font-size: 12px;">%s</span>
-							</td>
-						</tr>`, i+1, ticket.QRCode, string(ticket.Status))
-	}
-
-	ticketDetailsHTML += `
-					</tbody>
-				</table>
-			</div>
-			<div style="margin-top: 20px; padding: 15px; background-color: #dbeafe; border-radius: 6px; border-left: 4px solid #3b82f6;">
-				<p style="margin: 0; font-size: 14px; color: #1e40af;">
-					<strong>📱 Mobile Access:</strong> You can also access your tickets anytime from your account dashboard at 
-					<a href="https://runtown.onrender.com/dashboard/orders/%d" style="color: #2563eb; text-decoration: none;">https://runtown.onrender.com/dashboard/orders/%d</a>
-				</p>
-			</div>
-		</div>`
-
-	ticketDetailsHTML = fmt.Sprintf(ticketDetailsHTML, order.ID, order.ID)
-
-	// Insert ticket details before the footer
-	footerIndex := strings.Index(originalHTML, `<div class="footer">`)
-	if footerIndex != -1 {
-		return originalHTML[:footerIndex] + ticketDetailsHTML + originalHTML[footerIndex:]
-	}
-
-	// If no footer found, append to the end
-	return originalHTML + ticketDetailsHTML
-}
-
-// enhanceOrderConfirmationText enhances the text content with additional ticket information
-func (s *ResendEmailService) enhanceOrderConfirmationText(originalText string, order *models.Order, tickets []*models.Ticket) string {
-	ticketDetailsText := fmt.Sprintf(`
-
-TICKET DETAILS
-==============
-You have %d ticket(s) for this order:
+// SendNotificationEmail sends a short notification email linking back to the platform
+func (s *ResendEmailService) SendNotificationEmail(email, userName, subject, message, link string) error {
+	htmlContent := fmt.Sprintf(`
+<!DOCTYPE html>
+<html>
+<head>
+    <meta charset="UTF-8">
+    <title>%s</title>
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background-color: #7C3AED; color: white; padding: 20px; text-align: center; }
+        .content { padding: 20px; background-color: #f9f9f9; }
+        .button { display: inline-block; padding: 12px 24px; background-color: #7C3AED; color: white; text-decoration: none; border-radius: 4px; margin: 20px 0; }
+        .footer { text-align: center; padding: 20px; color: #666; font-size: 12px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>%s</h1>
+        </div>
+        <div class="content">
+            <p>Dear %s,</p>
+            <p>%s</p>
+            <a href="%s" class="button">View Details</a>
+            <p>You can change which notifications you receive in your notification settings.</p>
+        </div>
+        <div class="footer">
+            <p>Runtown Team</p>
+        </div>
+    </div>
+</body>
+</html>`, html.EscapeString(subject), html.EscapeString(subject), html.EscapeString(userName), html.EscapeString(message), html.EscapeString(link))
 
-`, len(tickets))
+	textContent := fmt.Sprintf(`%s
 
-	for i, ticket := range tickets {
-		ticketDetailsText += fmt.Sprintf(`Ticket #%d
-QR Code: %s
-Status: %s
-Generated: %s
+Dear %s,
 
-`, i+1, ticket.QRCode, string(ticket.Status), ticket.CreatedAt.Format("Jan 2, 2006 at 3:04 PM"))
-	}
+%s
 
-	ticketDetailsText += fmt.Sprintf(`MOBILE ACCESS
-=============
-You can access your tickets anytime from your account dashboard:
-https://runtown.onrender.com/dashboard/orders/%d
+View details: %s
 
-NEXT STEPS
-==========
-1. Save this email for your records
-2. Download the tickets from your account dashboard
-3. Bring your tickets (printed or on mobile) to the event
-4. Arrive early to avoid entrance queues
+You can change which notifications you receive in your notification settings.
 
-`, order.ID)
+Runtown Team`, subject, userName, message, link)
 
-	// Insert ticket details before the footer
-	footerIndex := strings.Index(originalText, "Runtown")
-	if footerIndex != -1 {
-		return originalText[:footerIndex] + ticketDetailsText + originalText[footerIndex:]
+	request := EmailMessage{
+		From:    s.getFromField(),
+		To:      []string{email},
+		Subject: subject,
+		HTML:    htmlContent,
+		Text:    textContent,
+		Tags: []EmailTag{
+			{Name: "category", Value: "notification"},
+		},
 	}
 
-	// If no footer found, append to the end
-	return originalText + ticketDetailsText
+	return s.sendEmail(request)
 }
 
-// sendEmail sends an email via Resend API
-func (s *ResendEmailService) sendEmail(request ResendEmailRequest) error {
-	jsonData, err := json.Marshal(request)
+// SendEventReminderEmail reminds a ticket holder about an upcoming event, in
+// the given language, including the organizer's custom message when there is
+// one and, for online events, the link to join
+func (s *ResendEmailService) SendEventReminderEmail(email, userName, subject, locale string, event *models.Event, message, link string, access *models.EventAccess) error {
+	reminder, err := emails.Reminder(emails.ReminderData{
+		Locale:  locale,
+		Name:    userName,
+		Subject: subject,
+		Event:   event,
+		Message: message,
+		Link:    link,
+		Access:  access,
+	})
 	if err != nil {
-		return fmt.Errorf("failed to marshal request: %w", err)
+		return err
 	}
 
-	req, err := http.NewRequest("POST", "https://api.resend.com/emails", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return fmt.Errorf("failed to create request: %w", err)
+	request := EmailMessage{
+		From:    s.getFromField(),
+		To:      []string{email},
+		Subject: reminder.Subject,
+		HTML:    reminder.HTML,
+		Text:    reminder.Text,
+		Tags: []EmailTag{
+			{Name: "category", Value: "event_reminder"},
+			{Name: "locale", Value: i18n.Resolve(locale)},
+		},
 	}
 
-	req.Header.Set("Authorization", "Bearer "+s.config.APIKey)
-	req.Header.Set("Content-Type", "application/json")
+	return s.sendEmail(request)
+}
 
-	resp, err := s.client.Do(req)
-	if err != nil {
-		return fmt.Errorf("failed to send request: %w", err)
+// SendEventBroadcastEmail sends an organizer's message to a ticket holder,
+// with the surrounding text in the given language
+func (s *ResendEmailService) SendEventBroadcastEmail(email, userName, subject, locale string, event *models.Event, message, link string) error {
+	eventDate := i18n.FormatLongDateTime(locale, event.StartDate)
+
+	htmlContent := fmt.Sprintf(`
+<!DOCTYPE html>
+<html lang="%s">
+<head>
+    <meta charset="UTF-8">
+    <title>%s</title>
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background-color: #7C3AED; color: white; padding: 20px; text-align: center; }
+        .content { padding: 20px; background-color: #f9f9f9; }
+        .message { margin: 20px 0; padding: 15px; background-color: white; border-left: 4px solid #7C3AED; }
+        .button { display: inline-block; padding: 12px 24px; background-color: #7C3AED; color: white; text-decoration: none; border-radius: 4px; margin: 20px 0; }
+        .footer { text-align: center; padding: 20px; color: #666; font-size: 12px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>%s</h1>
+        </div>
+        <div class="content">
+            <p>%s</p>
+            <p>%s</p>
+            <div class="message"><p>%s</p></div>
+            <p><strong>%s:</strong> %s<br><strong>%s:</strong> %s</p>
+            <a href="%s" class="button">%s</a>
+        </div>
+        <div class="footer">
+            <p>%s</p>
+            <p>%s</p>
+        </div>
+    </div>
+</body>
+</html>`, i18n.Resolve(locale), html.EscapeString(subject), html.EscapeString(subject),
+		i18n.T(locale, "email.greeting", html.EscapeString(userName)),
+		i18n.T(locale, "broadcast.intro", "<strong>"+html.EscapeString(event.Title)+"</strong>"),
+		strings.ReplaceAll(html.EscapeString(message), "\n", "<br>"),
+		i18n.T(locale, "reminder.date"), eventDate, i18n.T(locale, "reminder.location"), html.EscapeString(event.Location),
+		html.EscapeString(link), i18n.T(locale, "broadcast.view_event"),
+		i18n.T(locale, "broadcast.reason"), i18n.T(locale, "email.team"))
+
+	textContent := fmt.Sprintf(`%s
+
+%s
+
+%s
+
+%s
+
+%s: %s
+%s: %s
+
+%s: %s
+
+%s
+%s`, subject, i18n.T(locale, "email.greeting", userName), i18n.T(locale, "broadcast.intro", event.Title), message,
+		i18n.T(locale, "reminder.date"), eventDate, i18n.T(locale, "reminder.location"), event.Location,
+		i18n.T(locale, "broadcast.view_event_text"), link, i18n.T(locale, "broadcast.reason"), i18n.T(locale, "email.team"))
+
+	request := EmailMessage{
+		From:    s.getFromField(),
+		To:      []string{email},
+		Subject: subject,
+		HTML:    htmlContent,
+		Text:    textContent,
+		Tags: []EmailTag{
+			{Name: "category", Value: "event_broadcast"},
+			{Name: "locale", Value: i18n.Resolve(locale)},
+		},
 	}
-	defer resp.Body.Close()
 
-	if resp.StatusCode >= 400 {
-		var errorResp ResendErrorResponse
-		if err := json.NewDecoder(resp.Body).Decode(&errorResp); err != nil {
-			return fmt.Errorf("failed to send email, status: %d", resp.StatusCode)
-		}
-		return fmt.Errorf("failed to send email: %s", errorResp.Message)
+	return s.sendEmail(request)
+}
+
+// SendEventCancellationEmail tells a ticket holder that their event has been
+// cancelled, with the organizer's explanation and what happened to their
+// refund, in the given language
+func (s *ResendEmailService) SendEventCancellationEmail(email, userName, locale string, event *models.Event, reason, refundInfo string) error {
+	subject := i18n.T(locale, "cancellation.subject", event.Title)
+	eventDate := i18n.FormatLongDateTime(locale, event.StartDate)
+
+	htmlContent := fmt.Sprintf(`
+<!DOCTYPE html>
+<html lang="%s">
+<head>
+    <meta charset="UTF-8">
+    <title>%s</title>
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background-color: #DC2626; color: white; padding: 20px; text-align: center; }
+        .content { padding: 20px; background-color: #f9f9f9; }
+        .message { margin: 20px 0; padding: 15px; background-color: white; border-left: 4px solid #DC2626; }
+        .footer { text-align: center; padding: 20px; color: #666; font-size: 12px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>%s</h1>
+        </div>
+        <div class="content">
+            <p>%s</p>
+            <p>%s</p>
+            <p><strong>%s</strong></p>
+            <div class="message"><p>%s</p></div>
+            <p>%s</p>
+            <p>%s</p>
+        </div>
+        <div class="footer">
+            <p>%s</p>
+        </div>
+    </div>
+</body>
+</html>`, i18n.Resolve(locale), html.EscapeString(subject), i18n.T(locale, "cancellation.heading"),
+		i18n.T(locale, "email.greeting", html.EscapeString(userName)),
+		i18n.T(locale, "cancellation.intro", "<strong>"+html.EscapeString(event.Title)+"</strong>", eventDate),
+		i18n.T(locale, "cancellation.reason"),
+		strings.ReplaceAll(html.EscapeString(reason), "\n", "<br>"),
+		html.EscapeString(refundInfo),
+		i18n.T(locale, "email.contact_support"), i18n.T(locale, "email.team"))
+
+	textContent := fmt.Sprintf(`%s
+
+%s
+
+%s
+
+%s
+%s
+
+%s
+
+%s
+
+%s`, i18n.T(locale, "cancellation.heading"), i18n.T(locale, "email.greeting", userName),
+		i18n.T(locale, "cancellation.intro", event.Title, eventDate),
+		i18n.T(locale, "cancellation.reason"), reason, refundInfo,
+		i18n.T(locale, "email.contact_support"), i18n.T(locale, "email.team"))
+
+	request := EmailMessage{
+		From:    s.getFromField(),
+		To:      []string{email},
+		Subject: subject,
+		HTML:    htmlContent,
+		Text:    textContent,
+		Tags: []EmailTag{
+			{Name: "category", Value: "event_cancellation"},
+			{Name: "locale", Value: i18n.Resolve(locale)},
+		},
 	}
 
-	var response ResendEmailResponse
-	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
-		return fmt.Errorf("failed to decode response: %w", err)
+	return s.sendEmail(request)
+}
+
+// SendPriceAlertEmail tells an attendee who saved an event that its prices
+// are changing, with the surrounding text in the given language
+func (s *ResendEmailService) SendPriceAlertEmail(email, userName, subject, locale string, event *models.Event, message, link string) error {
+	eventDate := i18n.FormatLongDateTime(locale, event.StartDate)
+
+	htmlContent := fmt.Sprintf(`
+<!DOCTYPE html>
+<html lang="%s">
+<head>
+    <meta charset="UTF-8">
+    <title>%s</title>
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background-color: #059669; color: white; padding: 20px; text-align: center; }
+        .content { padding: 20px; background-color: #f9f9f9; }
+        .button { display: inline-block; padding: 12px 24px; background-color: #059669; color: white; text-decoration: none; border-radius: 4px; margin: 20px 0; }
+        .footer { text-align: center; padding: 20px; color: #666; font-size: 12px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>%s</h1>
+        </div>
+        <div class="content">
+            <p>%s</p>
+            <p>%s</p>
+            <p><strong>%s:</strong> %s<br><strong>%s:</strong> %s</p>
+            <a href="%s" class="button">%s</a>
+        </div>
+        <div class="footer">
+            <p>%s</p>
+            <p>%s</p>
+        </div>
+    </div>
+</body>
+</html>`, i18n.Resolve(locale), html.EscapeString(subject), html.EscapeString(subject),
+		i18n.T(locale, "email.greeting", html.EscapeString(userName)),
+		html.EscapeString(message),
+		i18n.T(locale, "reminder.date"), eventDate, i18n.T(locale, "reminder.location"), html.EscapeString(event.Location),
+		html.EscapeString(link), i18n.T(locale, "broadcast.view_event"),
+		i18n.T(locale, "price_alert.reason"), i18n.T(locale, "email.team"))
+
+	textContent := fmt.Sprintf(`%s
+
+%s
+
+%s
+
+%s: %s
+%s: %s
+
+%s: %s
+
+%s
+%s`, subject, i18n.T(locale, "email.greeting", userName), message,
+		i18n.T(locale, "reminder.date"), eventDate, i18n.T(locale, "reminder.location"), event.Location,
+		i18n.T(locale, "broadcast.view_event_text"), link, i18n.T(locale, "price_alert.reason"), i18n.T(locale, "email.team"))
+
+	request := EmailMessage{
+		From:    s.getFromField(),
+		To:      []string{email},
+		Subject: subject,
+		HTML:    htmlContent,
+		Text:    textContent,
+		Tags: []EmailTag{
+			{Name: "category", Value: "price_alert"},
+			{Name: "locale", Value: i18n.Resolve(locale)},
+		},
 	}
 
-	return nil
+	return s.sendEmail(request)
 }
 
-// TestConnection tests the Resend API connection
-func (s *ResendEmailService) TestConnection() error {
-	// Send a test request to validate API key
-	request := ResendEmailRequest{
+// SendInstallmentEmail tells a buyer paying in installments how an
+// installment's charge went, in the given language
+func (s *ResendEmailService) SendInstallmentEmail(email, userName, subject, locale, message, link string) error {
+	htmlContent := fmt.Sprintf(`
+<!DOCTYPE html>
+<html lang="%s">
+<head>
+    <meta charset="UTF-8">
+    <title>%s</title>
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background-color: #4F46E5; color: white; padding: 20px; text-align: center; }
+        .content { padding: 20px; background-color: #f9f9f9; }
+        .button { display: inline-block; padding: 12px 24px; background-color: #4F46E5; color: white; text-decoration: none; border-radius: 4px; margin: 20px 0; }
+        .footer { text-align: center; padding: 20px; color: #666; font-size: 12px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>%s</h1>
+        </div>
+        <div class="content">
+            <p>%s</p>
+            <p>%s</p>
+            <a href="%s" class="button">%s</a>
+            <p>%s</p>
+        </div>
+        <div class="footer">
+            <p>%s</p>
+            <p>%s</p>
+        </div>
+    </div>
+</body>
+</html>`, i18n.Resolve(locale), html.EscapeString(subject), html.EscapeString(subject),
+		i18n.T(locale, "email.greeting", html.EscapeString(userName)),
+		html.EscapeString(message),
+		html.EscapeString(link), i18n.T(locale, "installment.view"),
+		i18n.T(locale, "email.contact_support"),
+		i18n.T(locale, "installment.reason"), i18n.T(locale, "email.team"))
+
+	textContent := fmt.Sprintf(`%s
+
+%s
+
+%s
+
+%s: %s
+
+%s
+
+%s
+%s`, subject, i18n.T(locale, "email.greeting", userName), message,
+		i18n.T(locale, "installment.view_text"), link, i18n.T(locale, "email.contact_support"),
+		i18n.T(locale, "installment.reason"), i18n.T(locale, "email.team"))
+
+	request := EmailMessage{
 		From:    s.getFromField(),
-		To:      []string{"test@example.com"}, // This won't actually send
-		Subject: "Test Connection",
-		Text:    "This is a test email to validate API connection",
+		To:      []string{email},
+		Subject: subject,
+		HTML:    htmlContent,
+		Text:    textContent,
+		Tags: []EmailTag{
+			{Name: "category", Value: "installment"},
+			{Name: "locale", Value: i18n.Resolve(locale)},
+		},
 	}
 
-	jsonData, err := json.Marshal(request)
-	if err != nil {
-		return fmt.Errorf("failed to marshal test request: %w", err)
+	return s.sendEmail(request)
+}
+
+// SendNewEventEmail tells a follower that an organizer they follow has
+// published a new event, in the given language
+func (s *ResendEmailService) SendNewEventEmail(email, userName, locale, organizerName string, event *models.Event, link string) error {
+	subject := i18n.T(locale, "follow.new_event.subject", organizerName, event.Title)
+	message := i18n.T(locale, "follow.new_event.message", organizerName, event.Title)
+	reason := i18n.T(locale, "follow.reason", organizerName)
+	eventDate := i18n.FormatLongDateTime(locale, event.StartDate)
+
+	htmlContent := fmt.Sprintf(`
+<!DOCTYPE html>
+<html lang="%s">
+<head>
+    <meta charset="UTF-8">
+    <title>%s</title>
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background-color: #2563eb; color: white; padding: 20px; text-align: center; }
+        .content { padding: 20px; background-color: #f9f9f9; }
+        .button { display: inline-block; padding: 12px 24px; background-color: #2563eb; color: white; text-decoration: none; border-radius: 4px; margin: 20px 0; }
+        .footer { text-align: center; padding: 20px; color: #666; font-size: 12px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>%s</h1>
+        </div>
+        <div class="content">
+            <p>%s</p>
+            <p>%s</p>
+            <p><strong>%s:</strong> %s<br><strong>%s:</strong> %s</p>
+            <a href="%s" class="button">%s</a>
+        </div>
+        <div class="footer">
+            <p>%s</p>
+            <p>%s</p>
+        </div>
+    </div>
+</body>
+</html>`, i18n.Resolve(locale), html.EscapeString(subject), html.EscapeString(event.Title),
+		i18n.T(locale, "email.greeting", html.EscapeString(userName)),
+		html.EscapeString(message),
+		i18n.T(locale, "reminder.date"), eventDate, i18n.T(locale, "reminder.location"), html.EscapeString(event.Location),
+		html.EscapeString(link), i18n.T(locale, "broadcast.view_event"),
+		html.EscapeString(reason), i18n.T(locale, "email.team"))
+
+	textContent := fmt.Sprintf(`%s
+
+%s
+
+%s
+
+%s: %s
+%s: %s
+
+%s: %s
+
+%s
+%s`, subject, i18n.T(locale, "email.greeting", userName), message,
+		i18n.T(locale, "reminder.date"), eventDate, i18n.T(locale, "reminder.location"), event.Location,
+		i18n.T(locale, "broadcast.view_event_text"), link, reason, i18n.T(locale, "email.team"))
+
+	request := EmailMessage{
+		From:    s.getFromField(),
+		To:      []string{email},
+		Subject: subject,
+		HTML:    htmlContent,
+		Text:    textContent,
+		Tags: []EmailTag{
+			{Name: "category", Value: "followed_organizer"},
+			{Name: "locale", Value: i18n.Resolve(locale)},
+		},
 	}
 
-	req, err := http.NewRequest("POST", "https://api.resend.com/emails", bytes.NewBuffer(jsonData))
-	if err != nil {
-		return fmt.Errorf("failed to create test request: %w", err)
+	return s.sendEmail(request)
+}
+
+// SendSavedSearchEmail tells an attendee that a newly published event
+// matches a search they saved, in the given language
+func (s *ResendEmailService) SendSavedSearchEmail(email, userName, locale, searchLabel string, event *models.Event, link string) error {
+	subject := i18n.T(locale, "saved_search.subject", searchLabel, event.Title)
+	message := i18n.T(locale, "saved_search.message", event.Title, searchLabel)
+	reason := i18n.T(locale, "saved_search.reason", searchLabel)
+	eventDate := i18n.FormatLongDateTime(locale, event.StartDate)
+
+	htmlContent := fmt.Sprintf(`
+<!DOCTYPE html>
+<html lang="%s">
+<head>
+    <meta charset="UTF-8">
+    <title>%s</title>
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background-color: #2563eb; color: white; padding: 20px; text-align: center; }
+        .content { padding: 20px; background-color: #f9f9f9; }
+        .button { display: inline-block; padding: 12px 24px; background-color: #2563eb; color: white; text-decoration: none; border-radius: 4px; margin: 20px 0; }
+        .footer { text-align: center; padding: 20px; color: #666; font-size: 12px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>%s</h1>
+        </div>
+        <div class="content">
+            <p>%s</p>
+            <p>%s</p>
+            <p><strong>%s:</strong> %s<br><strong>%s:</strong> %s</p>
+            <a href="%s" class="button">%s</a>
+        </div>
+        <div class="footer">
+            <p>%s</p>
+            <p>%s</p>
+        </div>
+    </div>
+</body>
+</html>`, i18n.Resolve(locale), html.EscapeString(subject), html.EscapeString(event.Title),
+		i18n.T(locale, "email.greeting", html.EscapeString(userName)),
+		html.EscapeString(message),
+		i18n.T(locale, "reminder.date"), eventDate, i18n.T(locale, "reminder.location"), html.EscapeString(event.Location),
+		html.EscapeString(link), i18n.T(locale, "broadcast.view_event"),
+		html.EscapeString(reason), i18n.T(locale, "email.team"))
+
+	textContent := fmt.Sprintf(`%s
+
+%s
+
+%s
+
+%s: %s
+%s: %s
+
+%s: %s
+
+%s
+%s`, subject, i18n.T(locale, "email.greeting", userName), message,
+		i18n.T(locale, "reminder.date"), eventDate, i18n.T(locale, "reminder.location"), event.Location,
+		i18n.T(locale, "broadcast.view_event_text"), link, reason, i18n.T(locale, "email.team"))
+
+	request := EmailMessage{
+		From:    s.getFromField(),
+		To:      []string{email},
+		Subject: subject,
+		HTML:    htmlContent,
+		Text:    textContent,
+		Tags: []EmailTag{
+			{Name: "category", Value: "saved_search"},
+			{Name: "locale", Value: i18n.Resolve(locale)},
+		},
+	}
+
+	return s.sendEmail(request)
+}
+
+// SendTeamInvitationEmail invites someone to join an organizer's team with
+// the given permissions, in the given language
+func (s *ResendEmailService) SendTeamInvitationEmail(email, locale, organizerName string, permissions []models.TeamPermission, link string) error {
+	subject := i18n.T(locale, "team.invite.subject", organizerName)
+	message := i18n.T(locale, "team.invite.message", organizerName)
+	expiry := i18n.T(locale, "team.invite.expiry", int(models.TeamInvitationTTL.Hours()/24))
+
+	var htmlPermissions, textPermissions strings.Builder
+	for _, permission := range permissions {
+		label := i18n.T(locale, "team.permission."+string(permission))
+		htmlPermissions.WriteString("<li>" + html.EscapeString(label) + "</li>")
+		textPermissions.WriteString("- " + label + "\n")
+	}
+
+	htmlContent := fmt.Sprintf(`
+<!DOCTYPE html>
+<html lang="%s">
+<head>
+    <meta charset="UTF-8">
+    <title>%s</title>
+    <style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background-color: #2563eb; color: white; padding: 20px; text-align: center; }
+        .content { padding: 20px; background-color: #f9f9f9; }
+        .button { display: inline-block; padding: 12px 24px; background-color: #2563eb; color: white; text-decoration: none; border-radius: 4px; margin: 20px 0; }
+        .footer { text-align: center; padding: 20px; color: #666; font-size: 12px; }
+    </style>
+</head>
+<body>
+    <div class="container">
+        <div class="header">
+            <h1>%s</h1>
+        </div>
+        <div class="content">
+            <p>%s</p>
+            <ul>%s</ul>
+            <a href="%s" class="button">%s</a>
+        </div>
+        <div class="footer">
+            <p>%s</p>
+            <p>%s</p>
+        </div>
+    </div>
+</body>
+</html>`, i18n.Resolve(locale), html.EscapeString(subject), html.EscapeString(subject),
+		html.EscapeString(message), htmlPermissions.String(),
+		html.EscapeString(link), i18n.T(locale, "team.invite.accept"),
+		html.EscapeString(expiry), i18n.T(locale, "email.team"))
+
+	textContent := fmt.Sprintf(`%s
+
+%s
+%s
+%s: %s
+
+%s
+%s`, subject, message, textPermissions.String(),
+		i18n.T(locale, "team.invite.accept_text"), link, expiry, i18n.T(locale, "email.team"))
+
+	request := EmailMessage{
+		From:    s.getFromField(),
+		To:      []string{email},
+		Subject: subject,
+		HTML:    htmlContent,
+		Text:    textContent,
+		Tags: []EmailTag{
+			{Name: "category", Value: "team_invitation"},
+			{Name: "locale", Value: i18n.Resolve(locale)},
+		},
+	}
+
+	return s.sendEmail(request)
+}
+
+// SendOrderStatusEmail sends an order status update, such as a refund
+// notice, with content already rendered in the buyer's language
+func (s *ResendEmailService) SendOrderStatusEmail(email, userName, subject, htmlContent, textContent string, order *models.Order) error {
+	request := EmailMessage{
+		From:    s.getFromField(),
+		To:      []string{email},
+		Subject: subject,
+		HTML:    htmlContent,
+		Text:    textContent,
+		Tags: []EmailTag{
+			{Name: "category", Value: "order_status"},
+			{Name: "order_number", Value: order.OrderNumber},
+			{Name: "locale", Value: i18n.Resolve(order.Locale)},
+		},
 	}
 
-	req.Header.Set("Authorization", "Bearer "+s.config.APIKey)
-	req.Header.Set("Content-Type", "application/json")
+	return s.sendEmail(request)
+}
+
+// enhanceOrderConfirmationHTML enhances the HTML content with additional
+// ticket information, in the order's language
+func (s *ResendEmailService) enhanceOrderConfirmationHTML(originalHTML string, order *models.Order, tickets []*models.Ticket) string {
+	locale := order.Locale
+
+	// Add ticket details section to the HTML
+	ticketDetailsHTML := fmt.Sprintf(`
+		<div style="margin: 30px 0; padding: 20px; background-color: #f8fafc; border-radius: 8px; border: 1px solid #e2e8f0;">
+			<h3 style="margin-top: 0; color: #1e293b; font-size: 18px;">%s</h3>
+			<div style="margin: 15px 0;">
+				<table style="width: 100%%; border-collapse: collapse;">
+					<thead>
+						<tr style="background-color: #e2e8f0;">
+							<th style="padding: 10px; text-align: left; border: 1px solid #cbd5e1; font-size: 14px; color: #475569;">%s</th>
+							<th style="padding: 10px; text-align: left; border: 1px solid #cbd5e1; font-size: 14px; color: #475569;">%s</th>
+							<th style="padding: 10px; text-align: left; border: 1px solid #cbd5e1; font-size: 14px; color: #475569;">%s</th>
+						</tr>
+					</thead>
+					<tbody>`, i18n.T(locale, "tickets.details"), i18n.T(locale, "tickets.number"), i18n.T(locale, "tickets.qr_code"), i18n.T(locale, "tickets.status"))
+
+	for i, ticket := range tickets {
+		ticketDetailsHTML += fmt.Sprintf(`
+						<tr>
+							<td style="padding: 10px; border: 1px solid #cbd5e1; font-size: 14px;">%s</td>
+							<td style="padding: 10px; border: 1px solid #cbd5e1; font-size: 12px; font-family: monospace;">%s</td>
+							<td style="padding: 10px; border: 1px solid #cbd5e1; font-size: 14px;">
+								<span style="background-color: #dcfce7; color: #166534; padding: 4px 8px; border-radius: 4px; font-size: 12px;">%s</span>
+							</td>
+						</tr>`, i18n.T(locale, "tickets.ticket", i+1), ticket.QRCode, ticketStatusName(locale, ticket))
+	}
+
+	ticketDetailsHTML += fmt.Sprintf(`
+					</tbody>
+				</table>
+			</div>
+			<div style="margin-top: 20px; padding: 15px; background-color: #dbeafe; border-radius: 6px; border-left: 4px solid #3b82f6;">
+				<p style="margin: 0; font-size: 14px; color: #1e40af;">
+					<strong>📱 %s:</strong> %s 
+					<a href="https://runtown.onrender.com/dashboard/orders/%d" style="color: #2563eb; text-decoration: none;">https://runtown.onrender.com/dashboard/orders/%d</a>
+				</p>
+			</div>
+		</div>`, i18n.T(locale, "tickets.mobile_access"), i18n.T(locale, "tickets.mobile_access_html"), order.ID, order.ID)
+
+	// Insert ticket details before the footer
+	footerIndex := strings.Index(originalHTML, `<div class="footer">`)
+	if footerIndex != -1 {
+		return originalHTML[:footerIndex] + ticketDetailsHTML + originalHTML[footerIndex:]
+	}
+
+	// If no footer found, append to the end
+	return originalHTML + ticketDetailsHTML
+}
+
+// enhanceOrderConfirmationText enhances the text content with additional
+// ticket information, in the order's language
+func (s *ResendEmailService) enhanceOrderConfirmationText(originalText string, order *models.Order, tickets []*models.Ticket) string {
+	locale := order.Locale
+
+	ticketDetailsText := fmt.Sprintf(`
+
+%s
+%s
+
+`, emails.TextHeading(i18n.T(locale, "tickets.details")), i18n.T(locale, "tickets.count_intro", len(tickets)))
+
+	for i, ticket := range tickets {
+		ticketDetailsText += fmt.Sprintf(`%s
+%s: %s
+%s: %s
+%s: %s
+
+`, i18n.T(locale, "tickets.ticket", i+1),
+			i18n.T(locale, "tickets.qr_code"), ticket.QRCode,
+			i18n.T(locale, "tickets.status"), ticketStatusName(locale, ticket),
+			i18n.T(locale, "tickets.generated"), i18n.FormatDateTime(locale, ticket.CreatedAt))
+	}
 
-	resp, err := s.client.Do(req)
+	ticketDetailsText += fmt.Sprintf(`%s
+%s
+https://runtown.onrender.com/dashboard/orders/%d
+
+%s
+1. %s
+2. %s
+3. %s
+4. %s
+
+`, emails.TextHeading(i18n.T(locale, "tickets.mobile_access")), i18n.T(locale, "tickets.mobile_access_text"), order.ID,
+		emails.TextHeading(i18n.T(locale, "tickets.next_steps")),
+		i18n.T(locale, "tickets.step_save"),
+		i18n.T(locale, "tickets.step_download"),
+		i18n.T(locale, "tickets.step_bring"),
+		i18n.T(locale, "tickets.step_arrive"))
+
+	// Insert ticket details before the footer
+	footerIndex := strings.Index(originalText, "Runtown")
+	if footerIndex != -1 {
+		return originalText[:footerIndex] + ticketDetailsText + originalText[footerIndex:]
+	}
+
+	// If no footer found, append to the end
+	return originalText + ticketDetailsText
+}
+
+// ticketStatusName returns the ticket's status in the given language
+func ticketStatusName(locale string, ticket *models.Ticket) string {
+	switch ticket.Status {
+	case models.TicketActive, models.TicketUsed, models.TicketRefunded:
+		return i18n.T(locale, "tickets.status."+string(ticket.Status))
+	}
+	return string(ticket.Status)
+}
+
+// addSnippets adds the content snippets to the end of the email's footer
+func (s *ResendEmailService) addSnippets(request *EmailMessage) {
+	if s.snippets == nil {
+		return
+	}
+
+	keys := []models.SnippetKey{models.SnippetFooterText, models.SnippetSupportContact}
+	for _, tag := range request.Tags {
+		if tag.Name == "category" && strings.HasPrefix(tag.Value, "order_confirmation") {
+			keys = append([]models.SnippetKey{models.SnippetRefundPolicy}, keys...)
+		}
+	}
+
+	var htmlParts, textParts []string
+	for _, key := range keys {
+		content := s.snippets.Get(key)
+		if content == "" {
+			continue
+		}
+		htmlParts = append(htmlParts, "<p>"+strings.ReplaceAll(html.EscapeString(content), "\n", "<br>")+"</p>")
+		textParts = append(textParts, content)
+	}
+	if len(textParts) == 0 {
+		return
+	}
+
+	if request.HTML != "" {
+		snippetsHTML := strings.Join(htmlParts, "\n")
+		if footerIndex := strings.Index(request.HTML, `<div class="footer">`); footerIndex != -1 {
+			if endIndex := strings.Index(request.HTML[footerIndex:], "</div>"); endIndex != -1 {
+				insertAt := footerIndex + endIndex
+				request.HTML = request.HTML[:insertAt] + snippetsHTML + "\n        " + request.HTML[insertAt:]
+			}
+		} else if bodyIndex := strings.LastIndex(request.HTML, "</body>"); bodyIndex != -1 {
+			request.HTML = request.HTML[:bodyIndex] + snippetsHTML + "\n" + request.HTML[bodyIndex:]
+		} else {
+			request.HTML += snippetsHTML
+		}
+	}
+	if request.Text != "" {
+		request.Text += "\n\n" + strings.Join(textParts, "\n\n")
+	}
+}
+
+// sendEmail sends an email via Resend API
+func (s *ResendEmailService) sendEmail(request EmailMessage) error {
+	s.addSnippets(&request)
+	s.addReplyTo(&request)
+
+	receipt, err := s.provider.Send(&request)
+	s.logDelivery(request, receipt, err)
+	return err
+}
+
+// Redeliver sends a previously logged email again
+func (s *ResendEmailService) Redeliver(request json.RawMessage) (*EmailReceipt, error) {
+	var message EmailMessage
+	if err := json.Unmarshal(request, &message); err != nil {
+		return nil, fmt.Errorf("failed to decode logged email: %w", err)
+	}
+	return s.provider.Send(&message)
+}
+
+// logDelivery records the outcome of sending an email. Failing to record it
+// does not fail the send.
+func (s *ResendEmailService) logDelivery(request EmailMessage, receipt *EmailReceipt, sendErr error) {
+	if s.deliveryLog == nil {
+		return
+	}
+
+	jsonData, err := json.Marshal(request)
 	if err != nil {
-		return fmt.Errorf("failed to send test request: %w", err)
+		slog.Error("failed to encode email for the log", "recipient", strings.Join(request.To, ", "), "error", err)
+		return
 	}
-	defer resp.Body.Close()
 
-	// Check if we get a valid response (even if it's an error about the test email)
-	if resp.StatusCode == 401 {
-		return fmt.Errorf("invalid API key")
+	entry := &models.EmailLog{
+		EmailType: "other",
+		Recipient: strings.Join(request.To, ", "),
+		Subject:   request.Subject,
+		Provider:  s.provider.Name(),
+		Status:    models.EmailSent,
+		Request:   jsonData,
+	}
+	if receipt != nil {
+		entry.Provider = receipt.Provider
+		entry.ProviderMessageID = receipt.MessageID
+	}
+	for _, tag := range request.Tags {
+		if tag.Name == "category" {
+			entry.EmailType = tag.Value
+		}
+	}
+	if sendErr != nil {
+		entry.Status = models.EmailFailed
+		entry.Error = sendErr.Error()
 	}
 
+	if err := s.deliveryLog.Create(entry); err != nil {
+		slog.Error("failed to log email", "recipient", entry.Recipient, "type", entry.EmailType, "error", err)
+	}
+}
+
+// TestConnection checks the email provider's credentials, when the provider
+// supports checking them without sending an email
+func (s *ResendEmailService) TestConnection() error {
+	if tester, ok := s.provider.(emailConnectionTester); ok {
+		return tester.TestConnection()
+	}
 	return nil
-}
\ No newline at end of file
+}
@@ -0,0 +1,103 @@
+package services
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// resendAPIURL is the Resend endpoint that sends an email
+const resendAPIURL = "https://api.resend.com/emails"
+
+// ResendProvider sends emails through the Resend API
+type ResendProvider struct {
+	config ResendConfig
+	client *http.Client
+}
+
+// NewResendProvider creates a new Resend email provider
+func NewResendProvider(config ResendConfig) *ResendProvider {
+	return &ResendProvider{
+		config: config,
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+	}
+}
+
+// Name returns the provider's name
+func (p *ResendProvider) Name() string {
+	return EmailProviderResend
+}
+
+// Send posts the email to the Resend API
+func (p *ResendProvider) Send(message *EmailMessage) (*EmailReceipt, error) {
+	jsonData, err := json.Marshal(message)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	resp, err := p.post(jsonData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		var errorResp ResendErrorResponse
+		if err := json.NewDecoder(resp.Body).Decode(&errorResp); err != nil {
+			return nil, fmt.Errorf("failed to send email, status: %d", resp.StatusCode)
+		}
+		return nil, fmt.Errorf("failed to send email: %s", errorResp.Message)
+	}
+
+	var response ResendEmailResponse
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &EmailReceipt{Provider: p.Name(), MessageID: response.ID}, nil
+}
+
+// TestConnection tests the Resend API connection
+func (p *ResendProvider) TestConnection() error {
+	// Send a test request to validate API key
+	request := EmailMessage{
+		From:    fromAddress(p.config),
+		To:      []string{"test@example.com"}, // This won't actually send
+		Subject: "Test Connection",
+		Text:    "This is a test email to validate API connection",
+	}
+
+	jsonData, err := json.Marshal(request)
+	if err != nil {
+		return fmt.Errorf("failed to marshal test request: %w", err)
+	}
+
+	resp, err := p.post(jsonData)
+	if err != nil {
+		return fmt.Errorf("failed to send test request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	// Check if we get a valid response (even if it's an error about the test email)
+	if resp.StatusCode == 401 {
+		return fmt.Errorf("invalid API key")
+	}
+
+	return nil
+}
+
+func (p *ResendProvider) post(jsonData []byte) (*http.Response, error) {
+	req, err := http.NewRequest("POST", resendAPIURL, bytes.NewBuffer(jsonData))
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Set("Authorization", "Bearer "+p.config.APIKey)
+	req.Header.Set("Content-Type", "application/json")
+
+	return p.client.Do(req)
+}
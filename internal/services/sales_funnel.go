@@ -0,0 +1,343 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"event-ticketing-platform/internal/cache"
+)
+
+// Funnel steps, in the order buyers reach them
+const (
+	FunnelStepViews     = "views"
+	FunnelStepAddToCart = "add_to_cart"
+	FunnelStepCheckout  = "checkout"
+	FunnelStepPaid      = "paid"
+)
+
+// comparedPreviousEvents is how many of an organizer's earlier events an
+// event is compared to
+const comparedPreviousEvents = 5
+
+// CartAdditionRecorder records tickets added to carts, the funnel's
+// add-to-cart step
+type CartAdditionRecorder interface {
+	RecordCartAddition(eventID, ticketTypeID, userID, quantity int) error
+}
+
+// EventViewCounter counts the visitors who viewed an event's page since a
+// time, the top of the sales funnel
+type EventViewCounter interface {
+	CountEventViews(eventID int, since time.Time) (int, error)
+}
+
+// FunnelStep is one step of a sales funnel
+type FunnelStep struct {
+	Step        string  `json:"step"`
+	Count       int     `json:"count"`
+	StepRate    float64 `json:"step_rate"`    // percentage of the previous step
+	OverallRate float64 `json:"overall_rate"` // percentage of the first step
+}
+
+// SalesFunnel follows an event's buyers from its page to a paid order.
+// Steps after views count people rather than actions.
+type SalesFunnel struct {
+	EventID      int           `json:"event_id"`
+	Since        time.Time     `json:"since"`
+	ViewsTracked bool          `json:"views_tracked"`
+	Steps        []*FunnelStep `json:"steps"`
+}
+
+// EventComparison compares an event's sales to the organizer's previous events
+type EventComparison struct {
+	Current            *EventPerformance   `json:"current"`
+	Previous           []*EventPerformance `json:"previous"`
+	AverageTicketsSold float64             `json:"average_tickets_sold"`
+	AverageRevenue     float64             `json:"average_revenue"`
+	TicketsSoldChange  float64             `json:"tickets_sold_change"` // percentage against the previous average
+	RevenueChange      float64             `json:"revenue_change"`      // percentage against the previous average
+}
+
+// SetEventViewCounter adds event page views to the top of sales funnels.
+// Without one, funnels start at add-to-cart.
+func (s *AnalyticsService) SetEventViewCounter(views EventViewCounter) {
+	s.views = views
+}
+
+// RecordCartAddition records tickets a buyer added to their cart. It
+// implements CartAdditionRecorder.
+func (s *AnalyticsService) RecordCartAddition(eventID, ticketTypeID, userID, quantity int) error {
+	_, err := s.db.Exec(`
+		INSERT INTO cart_additions (event_id, ticket_type_id, user_id, quantity)
+		VALUES ($1, $2, $3, $4)`, eventID, ticketTypeID, userID, quantity)
+	if err != nil {
+		return fmt.Errorf("failed to record cart addition: %w", err)
+	}
+	return nil
+}
+
+// GetOrganizerSalesSeries returns an organizer's daily sales over the last
+// days, oldest first, including days without sales
+func (s *AnalyticsService) GetOrganizerSalesSeries(organizerID int, days int) ([]*DailySales, error) {
+	key := cache.Key(analyticsCachePrefix+"organizer", organizerID, "series", days)
+	return cache.RememberStale(s.cache, key, analyticsCacheTTL, analyticsCacheStaleTTL, func() ([]*DailySales, error) {
+		sales, err := s.getSalesOverTime(organizerID, days)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get sales over time: %w", err)
+		}
+		return fillDailySales(sales, time.Now(), days), nil
+	})
+}
+
+// GetEventSalesSeries returns an event's daily sales over the last days,
+// oldest first, including days without sales
+func (s *AnalyticsService) GetEventSalesSeries(eventID int, organizerID int, days int) ([]*DailySales, error) {
+	if err := s.checkEventAccess(eventID, organizerID); err != nil {
+		return nil, err
+	}
+
+	key := cache.Key(analyticsCachePrefix+"event", eventID, "series", days)
+	return cache.RememberStale(s.cache, key, analyticsCacheTTL, analyticsCacheStaleTTL, func() ([]*DailySales, error) {
+		sales, err := s.getEventSalesByDay(eventID, days)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get sales by day: %w", err)
+		}
+		return fillDailySales(sales, time.Now(), days), nil
+	})
+}
+
+// GetEventRevenueByTicketType returns an event's sales and revenue for each
+// ticket type
+func (s *AnalyticsService) GetEventRevenueByTicketType(eventID int, organizerID int) ([]*TicketTypeAnalytics, error) {
+	if err := s.checkEventAccess(eventID, organizerID); err != nil {
+		return nil, err
+	}
+
+	ticketTypes, err := s.getTicketTypeAnalytics(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ticket type analytics: %w", err)
+	}
+	return ticketTypes, nil
+}
+
+// GetEventFunnel returns an event's sales funnel since a time; the zero
+// time covers the event's whole sale
+func (s *AnalyticsService) GetEventFunnel(eventID int, organizerID int, since time.Time) (*SalesFunnel, error) {
+	if err := s.checkEventAccess(eventID, organizerID); err != nil {
+		return nil, err
+	}
+
+	key := cache.Key(analyticsCachePrefix+"event", eventID, "funnel", since.Unix())
+	return cache.RememberStale(s.cache, key, analyticsCacheTTL, analyticsCacheStaleTTL, func() (*SalesFunnel, error) {
+		return s.loadEventFunnel(eventID, since)
+	})
+}
+
+// loadEventFunnel counts the people at each step of an event's funnel
+func (s *AnalyticsService) loadEventFunnel(eventID int, since time.Time) (*SalesFunnel, error) {
+	var carts, checkouts, paid int
+	err := s.db.QueryRow(`
+		SELECT
+			(SELECT COUNT(DISTINCT user_id) FROM cart_additions
+				WHERE event_id = $1 AND created_at >= $2),
+			COUNT(DISTINCT o.user_id),
+			COUNT(DISTINCT CASE WHEN o.status IN ('completed', 'refunded') THEN o.user_id END)
+		FROM orders o
+		WHERE o.event_id = $1 AND o.created_at >= $2`, eventID, since).Scan(&carts, &checkouts, &paid)
+	if err != nil {
+		return nil, fmt.Errorf("failed to count funnel steps: %w", err)
+	}
+
+	views := -1
+	if s.views != nil {
+		if views, err = s.views.CountEventViews(eventID, since); err != nil {
+			return nil, fmt.Errorf("failed to count event views: %w", err)
+		}
+	}
+
+	funnel := buildSalesFunnel(views, carts, checkouts, paid)
+	funnel.EventID = eventID
+	funnel.Since = since
+	return funnel, nil
+}
+
+// buildSalesFunnel builds a funnel from the count of each step. Views are
+// left out when they are negative, meaning they are not tracked.
+func buildSalesFunnel(views, carts, checkouts, paid int) *SalesFunnel {
+	funnel := &SalesFunnel{ViewsTracked: views >= 0}
+
+	counts := []struct {
+		step  string
+		count int
+	}{
+		{FunnelStepViews, views},
+		{FunnelStepAddToCart, carts},
+		{FunnelStepCheckout, checkouts},
+		{FunnelStepPaid, paid},
+	}
+	if !funnel.ViewsTracked {
+		counts = counts[1:]
+	}
+
+	for i, c := range counts {
+		step := &FunnelStep{Step: c.step, Count: c.count, StepRate: 100, OverallRate: 100}
+		if i > 0 {
+			step.StepRate = percentOf(c.count, counts[i-1].count)
+			step.OverallRate = percentOf(c.count, counts[0].count)
+		}
+		funnel.Steps = append(funnel.Steps, step)
+	}
+	return funnel
+}
+
+// GetEventComparison compares an event's sales to the organizer's events
+// that started before it
+func (s *AnalyticsService) GetEventComparison(eventID int, organizerID int) (*EventComparison, error) {
+	if err := s.checkEventAccess(eventID, organizerID); err != nil {
+		return nil, err
+	}
+
+	key := cache.Key(analyticsCachePrefix+"event", eventID, "comparison")
+	return cache.RememberStale(s.cache, key, analyticsCacheTTL, analyticsCacheStaleTTL, func() (*EventComparison, error) {
+		return s.loadEventComparison(eventID)
+	})
+}
+
+// loadEventComparison queries the performance of an event and the
+// organizer's previous events
+func (s *AnalyticsService) loadEventComparison(eventID int) (*EventComparison, error) {
+	query := `
+		WITH current_event AS (
+			SELECT id, organizer_id, start_date FROM events WHERE id = $1
+		)
+		SELECT
+			e.id, e.title, e.start_date,
+			COALESCE((SELECT SUM(tt.quantity) FROM ticket_types tt WHERE tt.event_id = e.id), 0) as total_tickets,
+			(SELECT COUNT(*) FROM tickets t JOIN orders o ON o.id = t.order_id
+				WHERE o.event_id = e.id AND o.status = 'completed') as tickets_sold,
+			COALESCE((SELECT SUM(o.total_amount) FROM orders o
+				WHERE o.event_id = e.id AND o.status = 'completed'), 0) as revenue,
+			(SELECT COUNT(*) FROM orders o WHERE o.event_id = e.id AND o.status = 'completed') as order_count
+		FROM events e, current_event c
+		WHERE e.id = c.id OR (e.organizer_id = c.organizer_id AND e.start_date < c.start_date AND e.status = 'published')
+		ORDER BY e.id = c.id DESC, e.start_date DESC
+		LIMIT $2`
+
+	rows, err := s.db.Query(query, eventID, comparedPreviousEvents+1)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query event comparison: %w", err)
+	}
+	defer rows.Close()
+
+	var events []*EventPerformance
+	for rows.Next() {
+		event := &EventPerformance{}
+		var revenue int64
+		err := rows.Scan(
+			&event.ID,
+			&event.Title,
+			&event.StartDate,
+			&event.TotalTickets,
+			&event.TicketsSold,
+			&revenue,
+			&event.OrderCount,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to scan event performance: %w", err)
+		}
+		event.Revenue = float64(revenue) / 100.0
+		event.ConversionRate = percentOf(event.TicketsSold, event.TotalTickets)
+		if event.OrderCount > 0 {
+			event.AverageOrderValue = event.Revenue / float64(event.OrderCount)
+		}
+		events = append(events, event)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	if len(events) == 0 || events[0].ID != eventID {
+		return nil, fmt.Errorf("event not found")
+	}
+
+	return compareEvents(events[0], events[1:]), nil
+}
+
+// compareEvents compares an event to the average of previous events
+func compareEvents(current *EventPerformance, previous []*EventPerformance) *EventComparison {
+	comparison := &EventComparison{Current: current, Previous: previous}
+	if len(previous) == 0 {
+		return comparison
+	}
+
+	var tickets, revenue float64
+	for _, event := range previous {
+		tickets += float64(event.TicketsSold)
+		revenue += event.Revenue
+	}
+	comparison.AverageTicketsSold = tickets / float64(len(previous))
+	comparison.AverageRevenue = revenue / float64(len(previous))
+
+	if comparison.AverageTicketsSold > 0 {
+		comparison.TicketsSoldChange = roundPercent((float64(current.TicketsSold) - comparison.AverageTicketsSold) / comparison.AverageTicketsSold * 100)
+	}
+	if comparison.AverageRevenue > 0 {
+		comparison.RevenueChange = roundPercent((current.Revenue - comparison.AverageRevenue) / comparison.AverageRevenue * 100)
+	}
+	return comparison
+}
+
+// fillDailySales orders daily sales oldest first and adds the days without
+// sales, covering the days up to and including the day of now
+func fillDailySales(sales []*DailySales, now time.Time, days int) []*DailySales {
+	byDate := make(map[string]*DailySales, len(sales))
+	for _, day := range sales {
+		byDate[day.Date] = day
+	}
+
+	today := startOfDay(now)
+	series := make([]*DailySales, 0, days+1)
+	for i := days; i >= 0; i-- {
+		date := today.AddDate(0, 0, -i).Format("2006-01-02")
+		if day, ok := byDate[date]; ok {
+			series = append(series, day)
+			delete(byDate, date)
+			continue
+		}
+		series = append(series, &DailySales{Date: date})
+	}
+
+	// Days outside the window, e.g. from a clock difference with the
+	// database, are kept rather than dropped
+	for _, day := range byDate {
+		series = append(series, day)
+	}
+	sort.SliceStable(series, func(i, j int) bool { return series[i].Date < series[j].Date })
+	return series
+}
+
+// checkEventAccess verifies the organizer can see the event's analytics
+func (s *AnalyticsService) checkEventAccess(eventID int, organizerID int) error {
+	canAccess, err := s.canOrganizerAccessEvent(eventID, organizerID)
+	if err != nil {
+		return fmt.Errorf("failed to verify event access: %w", err)
+	}
+	if !canAccess {
+		return fmt.Errorf("organizer does not have access to this event")
+	}
+	return nil
+}
+
+// percentOf returns part as a percentage of total, rounded to one decimal
+func percentOf(part, total int) float64 {
+	if total <= 0 {
+		return 0
+	}
+	return roundPercent(float64(part) / float64(total) * 100)
+}
+
+// roundPercent rounds a percentage to one decimal
+func roundPercent(p float64) float64 {
+	return math.Round(p*10) / 10
+}
@@ -0,0 +1,72 @@
+package services
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBuildSalesFunnel(t *testing.T) {
+	funnel := buildSalesFunnel(200, 50, 20, 10)
+	if !funnel.ViewsTracked || len(funnel.Steps) != 4 {
+		t.Fatalf("expected four steps starting at views, got %d", len(funnel.Steps))
+	}
+
+	paid := funnel.Steps[3]
+	if paid.Step != FunnelStepPaid || paid.StepRate != 50 || paid.OverallRate != 5 {
+		t.Errorf("expected paid to be 50%% of checkouts and 5%% of views, got %+v", paid)
+	}
+	if funnel.Steps[0].StepRate != 100 || funnel.Steps[0].OverallRate != 100 {
+		t.Errorf("expected the first step to be 100%%, got %+v", funnel.Steps[0])
+	}
+
+	untracked := buildSalesFunnel(-1, 0, 0, 0)
+	if untracked.ViewsTracked || untracked.Steps[0].Step != FunnelStepAddToCart {
+		t.Errorf("expected the funnel to start at add to cart without views, got %+v", untracked.Steps[0])
+	}
+	if untracked.Steps[1].StepRate != 0 {
+		t.Errorf("expected empty steps not to divide by zero, got %v", untracked.Steps[1].StepRate)
+	}
+}
+
+func TestCompareEvents(t *testing.T) {
+	current := &EventPerformance{ID: 3, TicketsSold: 150, Revenue: 300}
+	previous := []*EventPerformance{
+		{ID: 2, TicketsSold: 100, Revenue: 200},
+		{ID: 1, TicketsSold: 100, Revenue: 400},
+	}
+
+	comparison := compareEvents(current, previous)
+	if comparison.AverageTicketsSold != 100 || comparison.AverageRevenue != 300 {
+		t.Errorf("expected averages of 100 tickets and 300 revenue, got %v and %v", comparison.AverageTicketsSold, comparison.AverageRevenue)
+	}
+	if comparison.TicketsSoldChange != 50 || comparison.RevenueChange != 0 {
+		t.Errorf("expected +50%% tickets and no revenue change, got %v and %v", comparison.TicketsSoldChange, comparison.RevenueChange)
+	}
+
+	if first := compareEvents(current, nil); first.TicketsSoldChange != 0 || first.AverageRevenue != 0 {
+		t.Errorf("expected no comparison for a first event, got %+v", first)
+	}
+}
+
+func TestFillDailySales(t *testing.T) {
+	now := time.Date(2026, 6, 10, 15, 0, 0, 0, time.UTC)
+	sales := []*DailySales{
+		{Date: "2026-06-10", Revenue: 50, Orders: 1},
+		{Date: "2026-06-08", Revenue: 20, Orders: 1},
+	}
+
+	series := fillDailySales(sales, now, 3)
+	if len(series) != 4 {
+		t.Fatalf("expected 4 days, got %d", len(series))
+	}
+
+	want := []string{"2026-06-07", "2026-06-08", "2026-06-09", "2026-06-10"}
+	for i, day := range series {
+		if day.Date != want[i] {
+			t.Errorf("expected day %d to be %s, got %s", i, want[i], day.Date)
+		}
+	}
+	if series[1].Revenue != 20 || series[2].Revenue != 0 || series[3].Revenue != 50 {
+		t.Errorf("expected sales on their days and zero elsewhere, got %v %v %v", series[1].Revenue, series[2].Revenue, series[3].Revenue)
+	}
+}
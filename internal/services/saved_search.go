@@ -0,0 +1,154 @@
+package services
+
+import (
+	"fmt"
+	"time"
+
+	"event-ticketing-platform/internal/i18n"
+	"event-ticketing-platform/internal/models"
+)
+
+// ErrTooManySavedSearches is returned when an attendee has saved as many
+// searches as they can
+var ErrTooManySavedSearches = fmt.Errorf("you can save up to %d searches", models.MaxSavedSearchesPerUser)
+
+// SavedSearchRepository defines the data operations for saved searches
+type SavedSearchRepository interface {
+	Create(search *models.SavedSearch) error
+	Delete(id, userID int) error
+	CountByUser(userID int) (int, error)
+	GetByUser(userID int) ([]*models.SavedSearch, error)
+	GetOwners() ([]*models.SavedSearchOwner, error)
+	GetUnmatchedEvents() ([]int, error)
+	ClaimEventMatch(eventID int) (bool, error)
+}
+
+// SavedSearchEmailSender tells attendees about new events matching a search
+// they saved
+type SavedSearchEmailSender interface {
+	SendSavedSearchEmail(email, userName, locale, searchLabel string, event *models.Event, link string) error
+}
+
+// SavedSearchService lets attendees save searches and emails them when a
+// newly published event matches one
+type SavedSearchService struct {
+	repo        SavedSearchRepository
+	eventRepo   EventRepository
+	emailSender SavedSearchEmailSender
+	baseURL     string
+	now         func() time.Time
+}
+
+// NewSavedSearchService creates a new saved search service
+func NewSavedSearchService(repo SavedSearchRepository, eventRepo EventRepository, emailSender SavedSearchEmailSender, baseURL string) *SavedSearchService {
+	return &SavedSearchService{
+		repo:        repo,
+		eventRepo:   eventRepo,
+		emailSender: emailSender,
+		baseURL:     baseURL,
+		now:         time.Now,
+	}
+}
+
+// SaveSearch saves a search for its user
+func (s *SavedSearchService) SaveSearch(search *models.SavedSearch) error {
+	search.Normalize()
+	if err := search.Validate(); err != nil {
+		return err
+	}
+
+	count, err := s.repo.CountByUser(search.UserID)
+	if err != nil {
+		return err
+	}
+	if count >= models.MaxSavedSearchesPerUser {
+		return ErrTooManySavedSearches
+	}
+	return s.repo.Create(search)
+}
+
+// DeleteSearch removes one of the user's saved searches
+func (s *SavedSearchService) DeleteSearch(id, userID int) error {
+	return s.repo.Delete(id, userID)
+}
+
+// GetSearches returns the user's saved searches
+func (s *SavedSearchService) GetSearches(userID int) ([]*models.SavedSearch, error) {
+	return s.repo.GetByUser(userID)
+}
+
+// MatchNewEvents emails attendees about events published since the last
+// run that match their saved searches, and returns how many emails were
+// sent. It is meant to run periodically to catch events EventPublished
+// missed.
+func (s *SavedSearchService) MatchNewEvents() (int, error) {
+	eventIDs, err := s.repo.GetUnmatchedEvents()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get unmatched events: %w", err)
+	}
+
+	sent := 0
+	for _, eventID := range eventIDs {
+		count, err := s.matchEvent(eventID)
+		sent += count
+		if err != nil {
+			fmt.Printf("Warning: failed to match event %d against saved searches: %v\n", eventID, err)
+		}
+	}
+	return sent, nil
+}
+
+// EventPublished emails attendees whose saved searches match the event in
+// the background. It implements EventPublishedHook.
+func (s *SavedSearchService) EventPublished(event *models.Event) {
+	go func() {
+		if _, err := s.matchEvent(event.ID); err != nil {
+			fmt.Printf("Warning: failed to match event %d against saved searches: %v\n", event.ID, err)
+		}
+	}()
+}
+
+// matchEvent emails each attendee with a saved search matching the event
+// once, naming the first search it matched. Events that have already started
+// are not matched, nor are organizers told about their own events.
+func (s *SavedSearchService) matchEvent(eventID int) (int, error) {
+	claimed, err := s.repo.ClaimEventMatch(eventID)
+	if err != nil || !claimed {
+		return 0, err
+	}
+
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get event: %w", err)
+	}
+	if event.Status != models.StatusPublished || !event.StartDate.After(s.now()) || s.emailSender == nil {
+		return 0, nil
+	}
+
+	owners, err := s.repo.GetOwners()
+	if err != nil {
+		return 0, err
+	}
+
+	link := s.baseURL + event.Path()
+	sent := 0
+	for _, owner := range owners {
+		if owner.UserID == event.OrganizerID {
+			continue
+		}
+		for _, search := range owner.Searches {
+			if !search.Matches(event) {
+				continue
+			}
+
+			locale := i18n.Resolve(owner.Locale)
+			if err := s.emailSender.SendSavedSearchEmail(owner.Email, owner.Name, locale, search.Label(), event, link); err != nil {
+				fmt.Printf("Warning: failed to send saved search email to %s: %v\n", owner.Email, err)
+			} else {
+				sent++
+			}
+			break
+		}
+	}
+	return sent, nil
+}
@@ -0,0 +1,128 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock SavedSearchRepository for testing
+type mockSavedSearchRepository struct {
+	searches []*models.SavedSearch
+	owners   []*models.SavedSearchOwner
+	matched  map[int]bool
+}
+
+func newMockSavedSearchRepository() *mockSavedSearchRepository {
+	return &mockSavedSearchRepository{matched: make(map[int]bool)}
+}
+
+func (m *mockSavedSearchRepository) Create(search *models.SavedSearch) error {
+	search.ID = len(m.searches) + 1
+	m.searches = append(m.searches, search)
+	return nil
+}
+
+func (m *mockSavedSearchRepository) Delete(id, userID int) error {
+	return nil
+}
+
+func (m *mockSavedSearchRepository) CountByUser(userID int) (int, error) {
+	count := 0
+	for _, search := range m.searches {
+		if search.UserID == userID {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (m *mockSavedSearchRepository) GetByUser(userID int) ([]*models.SavedSearch, error) {
+	return m.searches, nil
+}
+
+func (m *mockSavedSearchRepository) GetOwners() ([]*models.SavedSearchOwner, error) {
+	return m.owners, nil
+}
+
+func (m *mockSavedSearchRepository) GetUnmatchedEvents() ([]int, error) {
+	return []int{1, 2}, nil
+}
+
+func (m *mockSavedSearchRepository) ClaimEventMatch(eventID int) (bool, error) {
+	if m.matched[eventID] {
+		return false, nil
+	}
+	m.matched[eventID] = true
+	return true, nil
+}
+
+// Mock SavedSearchEmailSender for testing
+type mockSavedSearchEmailSender struct {
+	sent []string
+}
+
+func (m *mockSavedSearchEmailSender) SendSavedSearchEmail(email, userName, locale, searchLabel string, event *models.Event, link string) error {
+	m.sent = append(m.sent, email+" "+locale+" "+searchLabel+" "+link)
+	return nil
+}
+
+func TestSavedSearchService_SaveSearch(t *testing.T) {
+	repo := newMockSavedSearchRepository()
+	service := NewSavedSearchService(repo, newMockEventRepository(), &mockSavedSearchEmailSender{}, "")
+
+	if err := service.SaveSearch(&models.SavedSearch{UserID: 1, Query: "   "}); err == nil {
+		t.Error("expected a search without criteria to be refused")
+	}
+
+	for i := 0; i < models.MaxSavedSearchesPerUser; i++ {
+		if err := service.SaveSearch(&models.SavedSearch{UserID: 1, Query: "jazz"}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if err := service.SaveSearch(&models.SavedSearch{UserID: 1, Query: "comedy"}); !errors.Is(err, ErrTooManySavedSearches) {
+		t.Errorf("expected the limit to be enforced, got %v", err)
+	}
+	if err := service.SaveSearch(&models.SavedSearch{UserID: 2, Query: "comedy"}); err != nil {
+		t.Errorf("expected other users to be unaffected, got %v", err)
+	}
+}
+
+func TestSavedSearchService_MatchNewEvents(t *testing.T) {
+	now := time.Date(2025, 6, 4, 12, 0, 0, 0, time.UTC)
+	repo := newMockSavedSearchRepository()
+	eventRepo := newMockEventRepository()
+	sender := &mockSavedSearchEmailSender{}
+
+	service := NewSavedSearchService(repo, eventRepo, sender, "https://tickets.example.com")
+	service.now = func() time.Time { return now }
+
+	eventRepo.events[1] = &models.Event{ID: 1, OrganizerID: 7, Slug: "jazz-night", Title: "Jazz Night", Location: "Nairobi", Status: models.StatusPublished, StartDate: now.AddDate(0, 0, 10)}
+	// Published after it started, too late to be of use
+	eventRepo.events[2] = &models.Event{ID: 2, OrganizerID: 7, Title: "Jazz Brunch", Status: models.StatusPublished, StartDate: now.Add(-time.Hour)}
+
+	repo.owners = []*models.SavedSearchOwner{
+		// Two matching searches, one email
+		{UserID: 20, Email: "amina@example.com", Locale: "sw", Searches: []*models.SavedSearch{
+			{Query: "comedy"}, {Query: "jazz"}, {Location: "Nairobi"},
+		}},
+		{UserID: 21, Email: "tom@example.com", Searches: []*models.SavedSearch{{Location: "Mombasa"}}},
+		// The event's own organizer
+		{UserID: 7, Email: "organizer@example.com", Searches: []*models.SavedSearch{{Query: "jazz"}}},
+	}
+
+	sent, err := service.MatchNewEvents()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sent != 1 || len(sender.sent) != 1 || sender.sent[0] != `amina@example.com sw "jazz" https://tickets.example.com/events/jazz-night` {
+		t.Fatalf("expected one email for the first matching search, got %d: %v", sent, sender.sent)
+	}
+
+	// Each event is only matched once
+	if sent, _ := service.MatchNewEvents(); sent != 0 {
+		t.Errorf("expected no emails on the second run, got %d", sent)
+	}
+}
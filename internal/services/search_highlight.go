@@ -0,0 +1,121 @@
+package services
+
+import (
+	"html"
+	"strings"
+	"unicode"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/repositories"
+)
+
+// snippetLength is the approximate number of characters shown in a highlighted snippet
+const snippetLength = 160
+
+// EventHighlight holds HTML-escaped event fields with matched terms wrapped in <mark> tags
+type EventHighlight struct {
+	Title   string `json:"title"`
+	Snippet string `json:"snippet"`
+}
+
+// buildHighlights computes highlights for each event keyed by event ID
+func buildHighlights(events []*models.Event, query string) map[int]*EventHighlight {
+	terms := repositories.SearchTerms(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	highlights := make(map[int]*EventHighlight, len(events))
+	for _, event := range events {
+		highlights[event.ID] = &EventHighlight{
+			Title:   HighlightTerms(event.Title, terms),
+			Snippet: HighlightTerms(snippetAround(event.Description, terms), terms),
+		}
+	}
+	return highlights
+}
+
+// HighlightTerms escapes text and wraps every word starting with one of the
+// terms in <mark> tags, mirroring the prefix matching used by search
+func HighlightTerms(text string, terms []string) string {
+	var b strings.Builder
+	runes := []rune(text)
+	for i := 0; i < len(runes); {
+		if !isWordRune(runes[i]) {
+			j := i
+			for j < len(runes) && !isWordRune(runes[j]) {
+				j++
+			}
+			b.WriteString(html.EscapeString(string(runes[i:j])))
+			i = j
+			continue
+		}
+
+		j := i
+		for j < len(runes) && isWordRune(runes[j]) {
+			j++
+		}
+		word := string(runes[i:j])
+		if matchesAnyPrefix(strings.ToLower(word), terms) {
+			b.WriteString("<mark>")
+			b.WriteString(html.EscapeString(word))
+			b.WriteString("</mark>")
+		} else {
+			b.WriteString(html.EscapeString(word))
+		}
+		i = j
+	}
+	return b.String()
+}
+
+// snippetAround returns a window of text around the first matching term
+func snippetAround(text string, terms []string) string {
+	runes := []rune(text)
+	if len(runes) <= snippetLength {
+		return text
+	}
+
+	lower := strings.ToLower(text)
+	matchAt := -1
+	for _, term := range terms {
+		if idx := strings.Index(lower, term); idx >= 0 && (matchAt < 0 || idx < matchAt) {
+			matchAt = idx
+		}
+	}
+
+	start := 0
+	if matchAt > 0 {
+		// Convert the byte offset to a rune offset and keep some leading context
+		start = len([]rune(lower[:matchAt])) - snippetLength/4
+		if start < 0 {
+			start = 0
+		}
+	}
+	end := start + snippetLength
+	if end > len(runes) {
+		end = len(runes)
+		start = end - snippetLength
+	}
+
+	snippet := strings.TrimSpace(string(runes[start:end]))
+	if start > 0 {
+		snippet = "…" + snippet
+	}
+	if end < len(runes) {
+		snippet += "…"
+	}
+	return snippet
+}
+
+func isWordRune(r rune) bool {
+	return unicode.IsLetter(r) || unicode.IsDigit(r)
+}
+
+func matchesAnyPrefix(word string, terms []string) bool {
+	for _, term := range terms {
+		if strings.HasPrefix(word, term) {
+			return true
+		}
+	}
+	return false
+}
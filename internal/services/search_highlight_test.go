@@ -0,0 +1,97 @@
+package services
+
+import (
+	"os"
+	"strings"
+	"testing"
+
+	"event-ticketing-platform/internal/models"
+)
+
+func TestHighlightTerms(t *testing.T) {
+	tests := []struct {
+		name     string
+		text     string
+		terms    []string
+		expected string
+	}{
+		{
+			name:     "prefix match",
+			text:     "Nairobi Jazz Festival",
+			terms:    []string{"jazz", "fest"},
+			expected: "Nairobi <mark>Jazz</mark> <mark>Festival</mark>",
+		},
+		{
+			name:     "no match",
+			text:     "Tech Summit",
+			terms:    []string{"jazz"},
+			expected: "Tech Summit",
+		},
+		{
+			name:     "escapes html",
+			text:     "<b>Rock</b> & Roll",
+			terms:    []string{"rock"},
+			expected: "&lt;b&gt;<mark>Rock</mark>&lt;/b&gt; &amp; Roll",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := HighlightTerms(tt.text, tt.terms)
+			if result != tt.expected {
+				t.Errorf("expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestSnippetAround(t *testing.T) {
+	description := strings.Repeat("filler words here ", 20) + "the headline act is a jazz quartet " + strings.Repeat("more text ", 20)
+
+	snippet := snippetAround(description, []string{"jazz"})
+	if !strings.Contains(snippet, "jazz") {
+		t.Errorf("expected snippet to contain the matched term, got %q", snippet)
+	}
+	if !strings.HasPrefix(snippet, "…") || !strings.HasSuffix(snippet, "…") {
+		t.Errorf("expected snippet to be truncated on both sides, got %q", snippet)
+	}
+
+	short := "Short description"
+	if got := snippetAround(short, []string{"jazz"}); got != short {
+		t.Errorf("expected short text to be returned unchanged, got %q", got)
+	}
+}
+
+func TestEventService_SearchEventsDetailed_Highlights(t *testing.T) {
+	service, eventRepo, _ := setupEventService()
+	defer os.RemoveAll(service.uploadPath)
+
+	eventRepo.searchResults = []*models.Event{
+		{ID: 1, Title: "Jazz Night", Description: "Live jazz every Friday"},
+	}
+	eventRepo.searchTotal = 1
+
+	response, err := service.SearchEventsDetailed(&EventSearchRequest{Query: "jazz", SortBy: "relevance"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	highlight := response.Highlights[1]
+	if highlight == nil {
+		t.Fatalf("expected highlight for event 1")
+	}
+	if highlight.Title != "<mark>Jazz</mark> Night" {
+		t.Errorf("unexpected title highlight %q", highlight.Title)
+	}
+	if highlight.Snippet != "Live <mark>jazz</mark> every Friday" {
+		t.Errorf("unexpected snippet highlight %q", highlight.Snippet)
+	}
+
+	response, err = service.SearchEventsDetailed(&EventSearchRequest{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if response.Highlights != nil {
+		t.Errorf("expected no highlights without a query")
+	}
+}
@@ -0,0 +1,259 @@
+package services
+
+import (
+	"fmt"
+	"math"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/repositories"
+)
+
+// SearchSuggestionType identifies what a search suggestion points to
+type SearchSuggestionType string
+
+const (
+	SuggestionEvent     SearchSuggestionType = "event"
+	SuggestionOrganizer SearchSuggestionType = "organizer"
+	SuggestionVenue     SearchSuggestionType = "venue"
+	SuggestionCategory  SearchSuggestionType = "category"
+)
+
+// SearchSuggestionTypes lists suggestion types in the order they are shown
+var SearchSuggestionTypes = []SearchSuggestionType{SuggestionEvent, SuggestionOrganizer, SuggestionVenue, SuggestionCategory}
+
+// Suggestion limits
+const (
+	// DefaultSuggestionLimit is the number of suggestions returned per type
+	DefaultSuggestionLimit = 5
+	// MaxSuggestionLimit caps the per-type limit callers may request
+	MaxSuggestionLimit = 10
+)
+
+// SearchSuggestion is a single search-as-you-type suggestion
+type SearchSuggestion struct {
+	Type   SearchSuggestionType `json:"type"`
+	Label  string               `json:"label"`
+	Detail string               `json:"detail,omitempty"`
+	URL    string               `json:"url"`
+}
+
+// SearchSuggestRepository defines the data operations for search suggestions
+type SearchSuggestRepository interface {
+	GetSuggestionSources() ([]*models.SearchSuggestionSource, error)
+}
+
+// suggestionEntry is an indexed suggestion with its search terms and weight
+type suggestionEntry struct {
+	suggestion SearchSuggestion
+	label      string   // lowercase label for whole-prefix matches
+	terms      []string // lowercase words of the label
+	weight     float64  // popularity weight
+}
+
+// SearchSuggestService serves search suggestions from an in-memory index of
+// upcoming events, organizers, venues and categories. The index is rebuilt
+// when events change, so lookups never query the database.
+type SearchSuggestService struct {
+	repo SearchSuggestRepository
+
+	mu      sync.RWMutex
+	entries []*suggestionEntry
+
+	refreshOnce     sync.Once
+	refreshRequests chan struct{}
+}
+
+// NewSearchSuggestService creates a new search suggestion service. The index
+// is empty until Refresh is first called.
+func NewSearchSuggestService(repo SearchSuggestRepository) *SearchSuggestService {
+	return &SearchSuggestService{
+		repo:            repo,
+		refreshRequests: make(chan struct{}, 1),
+	}
+}
+
+// Refresh rebuilds the suggestion index from the repository
+func (s *SearchSuggestService) Refresh() error {
+	sources, err := s.repo.GetSuggestionSources()
+	if err != nil {
+		return fmt.Errorf("failed to load search suggestions: %w", err)
+	}
+
+	entries := buildSuggestionIndex(sources)
+
+	s.mu.Lock()
+	s.entries = entries
+	s.mu.Unlock()
+
+	return nil
+}
+
+// EventsChanged schedules an index rebuild in the background. Changes made
+// while a rebuild is running are picked up by a single follow-up rebuild.
+// It implements EventChangeHook.
+func (s *SearchSuggestService) EventsChanged() {
+	s.refreshOnce.Do(func() {
+		go func() {
+			for range s.refreshRequests {
+				if err := s.Refresh(); err != nil {
+					fmt.Printf("Warning: failed to refresh search suggestions: %v\n", err)
+				}
+			}
+		}()
+	})
+
+	select {
+	case s.refreshRequests <- struct{}{}:
+	default: // a rebuild is already queued
+	}
+}
+
+// Suggest returns up to limit suggestions of each type whose words start
+// with the query's words, most popular first. Suggestions are grouped in
+// SearchSuggestionTypes order.
+func (s *SearchSuggestService) Suggest(query string, limit int) []SearchSuggestion {
+	if limit <= 0 {
+		limit = DefaultSuggestionLimit
+	}
+	if limit > MaxSuggestionLimit {
+		limit = MaxSuggestionLimit
+	}
+
+	queryTerms := repositories.SearchTerms(query)
+	if len(queryTerms) == 0 {
+		return []SearchSuggestion{}
+	}
+	normalized := strings.Join(queryTerms, " ")
+
+	s.mu.RLock()
+	entries := s.entries
+	s.mu.RUnlock()
+
+	type match struct {
+		entry *suggestionEntry
+		score float64
+	}
+	matches := map[SearchSuggestionType][]match{}
+	for _, entry := range entries {
+		if !matchesAllPrefixes(entry.terms, queryTerms) {
+			continue
+		}
+		score := entry.weight
+		if strings.HasPrefix(entry.label, normalized) {
+			score *= 2 // the label itself starts with the query
+		}
+		matches[entry.suggestion.Type] = append(matches[entry.suggestion.Type], match{entry, score})
+	}
+
+	suggestions := []SearchSuggestion{}
+	for _, suggestionType := range SearchSuggestionTypes {
+		group := matches[suggestionType]
+		sort.SliceStable(group, func(i, j int) bool {
+			if group[i].score != group[j].score {
+				return group[i].score > group[j].score
+			}
+			return group[i].entry.label < group[j].entry.label
+		})
+		for i := 0; i < len(group) && i < limit; i++ {
+			suggestions = append(suggestions, group[i].entry.suggestion)
+		}
+	}
+
+	return suggestions
+}
+
+// matchesAllPrefixes returns true if every query term is a prefix of a term
+func matchesAllPrefixes(terms, queryTerms []string) bool {
+	for _, queryTerm := range queryTerms {
+		found := false
+		for _, term := range terms {
+			if strings.HasPrefix(term, queryTerm) {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return false
+		}
+	}
+	return true
+}
+
+// buildSuggestionIndex builds one entry per event and one per distinct
+// organizer, venue and category. An event's weight grows with tickets sold;
+// the others add up the weights of their events.
+func buildSuggestionIndex(sources []*models.SearchSuggestionSource) []*suggestionEntry {
+	var entries []*suggestionEntry
+	organizers := map[string]*suggestionEntry{}
+	venues := map[string]*suggestionEntry{}
+	categories := map[string]*suggestionEntry{}
+	eventCounts := map[*suggestionEntry]int{}
+
+	aggregate := func(index map[string]*suggestionEntry, key string, create func() SearchSuggestion, weight float64) {
+		entry, ok := index[key]
+		if !ok {
+			entry = newSuggestionEntry(create(), 0)
+			index[key] = entry
+			entries = append(entries, entry)
+		}
+		entry.weight += weight
+		eventCounts[entry]++
+	}
+
+	for _, source := range sources {
+		weight := 1 + math.Log1p(float64(source.TicketsSold))
+
+		entries = append(entries, newSuggestionEntry(SearchSuggestion{
+			Type:   SuggestionEvent,
+			Label:  source.Title,
+			Detail: source.StartDate.Format("Jan 2, 2006"),
+			URL:    fmt.Sprintf("/events/%d", source.EventID),
+		}, weight))
+
+		if organizer := strings.TrimSpace(source.OrganizerName); organizer != "" {
+			aggregate(organizers, fmt.Sprintf("%d", source.OrganizerID), func() SearchSuggestion {
+				return SearchSuggestion{Type: SuggestionOrganizer, Label: organizer, URL: fmt.Sprintf("/events?organizer=%d", source.OrganizerID)}
+			}, weight)
+		}
+
+		if location := strings.TrimSpace(source.Location); location != "" {
+			aggregate(venues, strings.ToLower(location), func() SearchSuggestion {
+				return SearchSuggestion{Type: SuggestionVenue, Label: location, URL: "/events?location=" + url.QueryEscape(location)}
+			}, weight)
+		}
+
+		if source.CategoryName != "" {
+			aggregate(categories, strings.ToLower(source.CategoryName), func() SearchSuggestion {
+				value := source.CategorySlug
+				if value == "" {
+					value = source.CategoryName
+				}
+				return SearchSuggestion{Type: SuggestionCategory, Label: source.CategoryName, URL: "/events?category=" + url.QueryEscape(value)}
+			}, weight)
+		}
+	}
+
+	for entry, count := range eventCounts {
+		if count == 1 {
+			entry.suggestion.Detail = "1 upcoming event"
+		} else {
+			entry.suggestion.Detail = fmt.Sprintf("%d upcoming events", count)
+		}
+	}
+
+	return entries
+}
+
+func newSuggestionEntry(suggestion SearchSuggestion, weight float64) *suggestionEntry {
+	terms := repositories.SearchTerms(suggestion.Label)
+	return &suggestionEntry{
+		suggestion: suggestion,
+		label:      strings.Join(terms, " "),
+		terms:      terms,
+		weight:     weight,
+	}
+}
@@ -0,0 +1,126 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock SearchSuggestRepository for testing
+type mockSearchSuggestRepository struct {
+	sources []*models.SearchSuggestionSource
+	err     error
+}
+
+func (m *mockSearchSuggestRepository) GetSuggestionSources() ([]*models.SearchSuggestionSource, error) {
+	return m.sources, m.err
+}
+
+func setupSearchSuggestService(t *testing.T) (*SearchSuggestService, *mockSearchSuggestRepository) {
+	start := time.Date(2025, 7, 1, 18, 0, 0, 0, time.UTC)
+	repo := &mockSearchSuggestRepository{
+		sources: []*models.SearchSuggestionSource{
+			{EventID: 1, Title: "Nairobi Jazz Festival", StartDate: start, OrganizerID: 10, OrganizerName: "Jane Wanjiru", Location: "Uhuru Gardens", CategoryName: "Music", CategorySlug: "music", TicketsSold: 5},
+			{EventID: 2, Title: "Jazz Brunch", StartDate: start, OrganizerID: 10, OrganizerName: "Jane Wanjiru", Location: "uhuru gardens", CategoryName: "Music", CategorySlug: "music", TicketsSold: 400},
+			{EventID: 3, Title: "Tech Meetup", StartDate: start, OrganizerID: 11, OrganizerName: "Jazzy Events", Location: "iHub", CategoryName: "Technology", CategorySlug: "technology"},
+		},
+	}
+	service := NewSearchSuggestService(repo)
+	if err := service.Refresh(); err != nil {
+		t.Fatalf("failed to refresh index: %v", err)
+	}
+	return service, repo
+}
+
+func TestSearchSuggestService_Suggest(t *testing.T) {
+	service, _ := setupSearchSuggestService(t)
+
+	t.Run("prefix matching groups types and ranks by popularity", func(t *testing.T) {
+		suggestions := service.Suggest("jaz", 0)
+
+		expected := []SearchSuggestion{
+			{Type: SuggestionEvent, Label: "Jazz Brunch", Detail: "Jul 1, 2025", URL: "/events/2"},
+			{Type: SuggestionEvent, Label: "Nairobi Jazz Festival", Detail: "Jul 1, 2025", URL: "/events/1"},
+			{Type: SuggestionOrganizer, Label: "Jazzy Events", Detail: "1 upcoming event", URL: "/events?organizer=11"},
+		}
+		if len(suggestions) != len(expected) {
+			t.Fatalf("expected %d suggestions, got %d: %+v", len(expected), len(suggestions), suggestions)
+		}
+		for i := range expected {
+			if suggestions[i] != expected[i] {
+				t.Errorf("suggestion %d: expected %+v, got %+v", i, expected[i], suggestions[i])
+			}
+		}
+	})
+
+	t.Run("every query word must match", func(t *testing.T) {
+		suggestions := service.Suggest("fest nai", 0)
+		if len(suggestions) != 1 || suggestions[0].URL != "/events/1" {
+			t.Errorf("expected only the festival, got %+v", suggestions)
+		}
+
+		if suggestions := service.Suggest("jazz meetup", 0); len(suggestions) != 0 {
+			t.Errorf("expected no suggestions, got %+v", suggestions)
+		}
+	})
+
+	t.Run("organizers venues and categories aggregate events", func(t *testing.T) {
+		organizers := service.Suggest("wanjiru", 0)
+		if len(organizers) != 1 || organizers[0].Detail != "2 upcoming events" || organizers[0].URL != "/events?organizer=10" {
+			t.Errorf("unexpected organizer suggestions: %+v", organizers)
+		}
+
+		venues := service.Suggest("uhuru", 0)
+		if len(venues) != 1 || venues[0].Label != "Uhuru Gardens" || venues[0].URL != "/events?location=Uhuru+Gardens" {
+			t.Errorf("unexpected venue suggestions: %+v", venues)
+		}
+
+		categories := service.Suggest("tech", 0)
+		if len(categories) != 2 || categories[1].Type != SuggestionCategory || categories[1].URL != "/events?category=technology" {
+			t.Errorf("unexpected category suggestions: %+v", categories)
+		}
+	})
+
+	t.Run("limit applies per type", func(t *testing.T) {
+		suggestions := service.Suggest("jazz", 1)
+		if len(suggestions) != 2 || suggestions[0].Label != "Jazz Brunch" || suggestions[1].Type != SuggestionOrganizer {
+			t.Errorf("expected one event and one organizer, got %+v", suggestions)
+		}
+	})
+
+	t.Run("empty query", func(t *testing.T) {
+		if suggestions := service.Suggest("  ", 0); len(suggestions) != 0 {
+			t.Errorf("expected no suggestions, got %+v", suggestions)
+		}
+	})
+}
+
+func TestSearchSuggestService_Refresh(t *testing.T) {
+	service, repo := setupSearchSuggestService(t)
+
+	repo.err = errors.New("database unavailable")
+	if err := service.Refresh(); err == nil {
+		t.Error("expected refresh to fail")
+	}
+	if suggestions := service.Suggest("jazz", 0); len(suggestions) == 0 {
+		t.Error("expected the previous index to be kept after a failed refresh")
+	}
+
+	repo.err = nil
+	repo.sources = []*models.SearchSuggestionSource{{EventID: 4, Title: "Jazz Picnic"}}
+	service.EventsChanged()
+
+	deadline := time.Now().Add(time.Second)
+	for {
+		suggestions := service.Suggest("jazz", 0)
+		if len(suggestions) == 1 && suggestions[0].URL == "/events/4" {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected index to be rebuilt after events changed, got %+v", suggestions)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
@@ -0,0 +1,197 @@
+package services
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// maxMetaDescriptionLength is the longest meta description search engines show
+const maxMetaDescriptionLength = 160
+
+// EventSEO holds the search engine metadata of an event page
+type EventSEO struct {
+	CanonicalURL    string
+	MetaDescription string
+	ImageURL        string
+	StructuredData  string // schema.org Event as JSON-LD
+}
+
+// SEOService builds canonical URLs and structured data for public pages
+type SEOService struct {
+	baseURL string
+}
+
+// NewSEOService creates a new SEO service
+func NewSEOService(baseURL string) *SEOService {
+	return &SEOService{baseURL: baseURL}
+}
+
+// EventURL returns the absolute canonical URL of an event
+func (s *SEOService) EventURL(event *models.Event) string {
+	return s.baseURL + event.Path()
+}
+
+// CategoryURL returns the absolute URL of a category's landing page
+func (s *SEOService) CategoryURL(category *models.Category) string {
+	return s.baseURL + category.Path()
+}
+
+// schema.org types used in event structured data
+type schemaEvent struct {
+	Context             string        `json:"@context"`
+	Type                string        `json:"@type"`
+	Name                string        `json:"name"`
+	Description         string        `json:"description,omitempty"`
+	URL                 string        `json:"url"`
+	Image               []string      `json:"image,omitempty"`
+	StartDate           string        `json:"startDate"`
+	EndDate             string        `json:"endDate"`
+	EventStatus         string        `json:"eventStatus"`
+	EventAttendanceMode string        `json:"eventAttendanceMode"`
+	Location            interface{}   `json:"location"` // schemaPlace, or schemaVirtualLocation for online events
+	Organizer           *schemaPerson `json:"organizer,omitempty"`
+	Offers              []schemaOffer `json:"offers,omitempty"`
+}
+
+type schemaPlace struct {
+	Type    string        `json:"@type"`
+	Name    string        `json:"name"`
+	Address schemaAddress `json:"address"`
+}
+
+type schemaVirtualLocation struct {
+	Type string `json:"@type"`
+	URL  string `json:"url"`
+}
+
+type schemaAddress struct {
+	Type            string `json:"@type"`
+	StreetAddress   string `json:"streetAddress,omitempty"`
+	AddressLocality string `json:"addressLocality,omitempty"`
+	AddressCountry  string `json:"addressCountry"`
+}
+
+type schemaPerson struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+type schemaOffer struct {
+	Type          string `json:"@type"`
+	Name          string `json:"name"`
+	Price         string `json:"price"`
+	PriceCurrency string `json:"priceCurrency"`
+	Availability  string `json:"availability"`
+	ValidFrom     string `json:"validFrom"`
+	URL           string `json:"url"`
+}
+
+// EventSEO builds the canonical URL, meta description and schema.org Event
+// JSON-LD of an event page
+func (s *SEOService) EventSEO(event *models.Event, ticketTypes []*models.TicketType, organizer *models.User) *EventSEO {
+	url := s.EventURL(event)
+	description := metaDescription(event.Description)
+
+	data := schemaEvent{
+		Context:             "https://schema.org",
+		Type:                "Event",
+		Name:                event.Title,
+		Description:         description,
+		URL:                 url,
+		StartDate:           event.StartDate.Format(time.RFC3339),
+		EndDate:             event.EndDate.Format(time.RFC3339),
+		EventStatus:         "https://schema.org/EventScheduled",
+		EventAttendanceMode: "https://schema.org/OfflineEventAttendanceMode",
+		Location:            eventPlace(event.Location),
+	}
+	if event.IsCancelled() {
+		data.EventStatus = "https://schema.org/EventCancelled"
+	}
+	if event.IsOnline() {
+		// The access link is for ticket holders only, so point at the event page
+		data.EventAttendanceMode = "https://schema.org/OnlineEventAttendanceMode"
+		data.Location = schemaVirtualLocation{Type: "VirtualLocation", URL: url}
+	}
+	if event.ImageURL != "" {
+		data.Image = []string{event.ImageURL}
+	}
+	if organizer != nil {
+		data.Organizer = &schemaPerson{Type: "Person", Name: organizer.FullName()}
+	}
+
+	now := time.Now()
+	for _, ticketType := range ticketTypes {
+		availability := "https://schema.org/InStock"
+		switch {
+		case ticketType.IsSoldOut():
+			availability = "https://schema.org/SoldOut"
+		case ticketType.SaleStart.After(now):
+			availability = "https://schema.org/PreOrder"
+		case ticketType.SaleEnd.Before(now):
+			availability = "https://schema.org/Discontinued"
+		}
+
+		data.Offers = append(data.Offers, schemaOffer{
+			Type:          "Offer",
+			Name:          ticketType.Name,
+			Price:         fmt.Sprintf("%.2f", float64(ticketType.Price)/100.0),
+			PriceCurrency: "KES",
+			Availability:  availability,
+			ValidFrom:     ticketType.SaleStart.Format(time.RFC3339),
+			URL:           url,
+		})
+	}
+
+	seo := &EventSEO{
+		CanonicalURL:    url,
+		MetaDescription: description,
+		ImageURL:        event.ImageVariantURL(models.ImageVariantOG),
+	}
+
+	// json.Marshal escapes <, > and &, so the JSON is safe inside a script tag
+	if structured, err := json.Marshal(data); err == nil {
+		seo.StructuredData = string(structured)
+	} else {
+		fmt.Printf("Warning: failed to build structured data of event %d: %v\n", event.ID, err)
+	}
+
+	return seo
+}
+
+// eventPlace turns a free-text location into a schema.org Place, treating its
+// last comma-separated component as the city like city landing pages do
+func eventPlace(location string) schemaPlace {
+	place := schemaPlace{
+		Type: "Place",
+		Name: location,
+		Address: schemaAddress{
+			Type:            "PostalAddress",
+			AddressLocality: models.CityName(location),
+			AddressCountry:  "KE",
+		},
+	}
+	if idx := strings.LastIndex(location, ","); idx >= 0 {
+		place.Name = strings.TrimSpace(location[:strings.Index(location, ",")])
+		place.Address.StreetAddress = strings.TrimSpace(location[:idx])
+	}
+	return place
+}
+
+// metaDescription collapses whitespace in a description and shortens it to
+// a length search engines show in full
+func metaDescription(description string) string {
+	runes := []rune(strings.Join(strings.Fields(description), " "))
+	if len(runes) <= maxMetaDescriptionLength {
+		return string(runes)
+	}
+
+	short := string(runes[:maxMetaDescriptionLength-3])
+	if cut := strings.LastIndex(short, " "); cut > 0 {
+		short = short[:cut]
+	}
+	return strings.TrimRight(short, " ,.;:") + "..."
+}
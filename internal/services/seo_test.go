@@ -0,0 +1,95 @@
+package services
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+func TestSEOService_EventSEO(t *testing.T) {
+	service := NewSEOService("https://example.com")
+	start := time.Now().Add(7 * 24 * time.Hour).Truncate(time.Second)
+
+	event := &models.Event{
+		ID:          42,
+		Slug:        "jazz-night",
+		Title:       "Jazz Night </script>",
+		Description: "An evening of live jazz\n\nwith the best bands in town.",
+		StartDate:   start,
+		EndDate:     start.Add(4 * time.Hour),
+		Location:    "Alliance Française, Loita Street, Nairobi",
+		ImageURL:    "https://cdn.example.com/jazz.jpg",
+		Status:      models.StatusPublished,
+	}
+	ticketTypes := []*models.TicketType{
+		{Name: "Regular", Price: 250000, Quantity: 100, Sold: 10, SaleStart: time.Now().Add(-time.Hour), SaleEnd: start},
+		{Name: "VIP", Price: 500000, Quantity: 10, Sold: 10, SaleStart: time.Now().Add(-time.Hour), SaleEnd: start},
+	}
+	organizer := &models.User{FirstName: "Amina", LastName: "Otieno"}
+
+	seo := service.EventSEO(event, ticketTypes, organizer)
+
+	if seo.CanonicalURL != "https://example.com/events/jazz-night" {
+		t.Errorf("unexpected canonical URL %q", seo.CanonicalURL)
+	}
+	if seo.MetaDescription != "An evening of live jazz with the best bands in town." {
+		t.Errorf("unexpected meta description %q", seo.MetaDescription)
+	}
+	if strings.Contains(seo.StructuredData, "</script>") {
+		t.Fatalf("expected structured data to be safe inside a script tag, got %s", seo.StructuredData)
+	}
+
+	var data map[string]interface{}
+	if err := json.Unmarshal([]byte(seo.StructuredData), &data); err != nil {
+		t.Fatalf("invalid JSON-LD: %v", err)
+	}
+	if data["@type"] != "Event" || data["name"] != event.Title || data["startDate"] != start.Format(time.RFC3339) {
+		t.Errorf("unexpected event data: %v", data)
+	}
+
+	location := data["location"].(map[string]interface{})
+	address := location["address"].(map[string]interface{})
+	if location["name"] != "Alliance Française" || address["addressLocality"] != "Nairobi" || address["streetAddress"] != "Alliance Française, Loita Street" {
+		t.Errorf("unexpected location: %v", location)
+	}
+
+	offers := data["offers"].([]interface{})
+	if len(offers) != 2 {
+		t.Fatalf("expected 2 offers, got %v", offers)
+	}
+	regular := offers[0].(map[string]interface{})
+	vip := offers[1].(map[string]interface{})
+	if regular["price"] != "2500.00" || regular["priceCurrency"] != "KES" || regular["availability"] != "https://schema.org/InStock" {
+		t.Errorf("unexpected offer: %v", regular)
+	}
+	if vip["availability"] != "https://schema.org/SoldOut" {
+		t.Errorf("expected sold out tickets to be marked as such, got %v", vip)
+	}
+
+	event.Status = models.StatusCancelled
+	seo = service.EventSEO(event, nil, nil)
+	if !strings.Contains(seo.StructuredData, "https://schema.org/EventCancelled") {
+		t.Errorf("expected cancelled events to be marked as such, got %s", seo.StructuredData)
+	}
+
+	event.Location = models.OnlineEventLocation
+	seo = service.EventSEO(event, nil, nil)
+	if !strings.Contains(seo.StructuredData, `"location":{"@type":"VirtualLocation","url":"https://example.com/events/jazz-night"}`) ||
+		!strings.Contains(seo.StructuredData, "https://schema.org/OnlineEventAttendanceMode") {
+		t.Errorf("expected online events to have a virtual location at the event page, got %s", seo.StructuredData)
+	}
+}
+
+func TestMetaDescription(t *testing.T) {
+	long := strings.Repeat("Live music and food. ", 20)
+	description := metaDescription(long)
+	if len([]rune(description)) > maxMetaDescriptionLength || !strings.HasSuffix(description, "...") {
+		t.Errorf("expected long descriptions to be shortened, got %q", description)
+	}
+	if strings.Contains(description, "  ") || strings.HasSuffix(description, "....") {
+		t.Errorf("expected a clean cut, got %q", description)
+	}
+}
@@ -0,0 +1,183 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/aws/aws-sdk-go-v2/aws"
+	v4 "github.com/aws/aws-sdk-go-v2/aws/signer/v4"
+)
+
+// SESConfig represents Amazon SES configuration
+type SESConfig struct {
+	Region          string
+	AccessKeyID     string
+	SecretAccessKey string
+}
+
+// SESProvider sends emails through the Amazon SES v2 API
+type SESProvider struct {
+	config SESConfig
+	client *http.Client
+	signer *v4.Signer
+}
+
+// NewSESProvider creates a new Amazon SES email provider
+func NewSESProvider(config SESConfig) *SESProvider {
+	return &SESProvider{
+		config: config,
+		client: &http.Client{
+			Timeout: 30 * time.Second,
+		},
+		signer: v4.NewSigner(),
+	}
+}
+
+// Name returns the provider's name
+func (p *SESProvider) Name() string {
+	return EmailProviderSES
+}
+
+// sesContent is a text or HTML part of an SES email
+type sesContent struct {
+	Data    string `json:"Data"`
+	Charset string `json:"Charset"`
+}
+
+type sesHeader struct {
+	Name  string `json:"Name"`
+	Value string `json:"Value"`
+}
+
+type sesTag struct {
+	Name  string `json:"Name"`
+	Value string `json:"Value"`
+}
+
+// sesSendEmailRequest is the body of an SES v2 SendEmail request
+type sesSendEmailRequest struct {
+	FromEmailAddress string `json:"FromEmailAddress"`
+	Destination      struct {
+		ToAddresses []string `json:"ToAddresses"`
+	} `json:"Destination"`
+	Content struct {
+		Simple struct {
+			Subject sesContent `json:"Subject"`
+			Body    struct {
+				Text *sesContent `json:"Text,omitempty"`
+				HTML *sesContent `json:"Html,omitempty"`
+			} `json:"Body"`
+			Headers []sesHeader `json:"Headers,omitempty"`
+		} `json:"Simple"`
+	} `json:"Content"`
+	EmailTags []sesTag `json:"EmailTags,omitempty"`
+}
+
+// Send posts the email to the SES SendEmail API
+func (p *SESProvider) Send(message *EmailMessage) (*EmailReceipt, error) {
+	var request sesSendEmailRequest
+	request.FromEmailAddress = message.From
+	request.Destination.ToAddresses = message.To
+	request.Content.Simple.Subject = sesContent{Data: message.Subject, Charset: "UTF-8"}
+	if message.Text != "" {
+		request.Content.Simple.Body.Text = &sesContent{Data: message.Text, Charset: "UTF-8"}
+	}
+	if message.HTML != "" {
+		request.Content.Simple.Body.HTML = &sesContent{Data: message.HTML, Charset: "UTF-8"}
+	}
+	for name, value := range message.Headers {
+		request.Content.Simple.Headers = append(request.Content.Simple.Headers, sesHeader{Name: name, Value: value})
+	}
+	sort.Slice(request.Content.Simple.Headers, func(i, j int) bool {
+		return request.Content.Simple.Headers[i].Name < request.Content.Simple.Headers[j].Name
+	})
+	for _, tag := range message.Tags {
+		request.EmailTags = append(request.EmailTags, sesTag{Name: tag.Name, Value: tag.Value})
+	}
+
+	jsonData, err := json.Marshal(request)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal request: %w", err)
+	}
+
+	resp, err := p.post("/v2/email/outbound-emails", jsonData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to send request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		var errorResp struct {
+			Message string `json:"message"`
+		}
+		if err := json.NewDecoder(resp.Body).Decode(&errorResp); err != nil || errorResp.Message == "" {
+			return nil, fmt.Errorf("failed to send email, status: %d", resp.StatusCode)
+		}
+		return nil, fmt.Errorf("failed to send email: %s", errorResp.Message)
+	}
+
+	var response struct {
+		MessageID string `json:"MessageId"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&response); err != nil {
+		return nil, fmt.Errorf("failed to decode response: %w", err)
+	}
+
+	return &EmailReceipt{Provider: p.Name(), MessageID: response.MessageID}, nil
+}
+
+// TestConnection checks the SES credentials by reading the account's
+// sending status
+func (p *SESProvider) TestConnection() error {
+	req, err := p.newRequest("GET", "/v2/email/account", nil)
+	if err != nil {
+		return err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to send test request: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusForbidden || resp.StatusCode == http.StatusUnauthorized {
+		return fmt.Errorf("invalid SES credentials")
+	}
+	return nil
+}
+
+func (p *SESProvider) post(path string, jsonData []byte) (*http.Response, error) {
+	req, err := p.newRequest("POST", path, jsonData)
+	if err != nil {
+		return nil, err
+	}
+	return p.client.Do(req)
+}
+
+// newRequest builds an SES API request signed with Signature Version 4
+func (p *SESProvider) newRequest(method, path string, body []byte) (*http.Request, error) {
+	url := fmt.Sprintf("https://email.%s.amazonaws.com%s", p.config.Region, path)
+	req, err := http.NewRequest(method, url, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	hash := sha256.Sum256(body)
+	credentials := aws.Credentials{
+		AccessKeyID:     p.config.AccessKeyID,
+		SecretAccessKey: p.config.SecretAccessKey,
+	}
+	if err := p.signer.SignHTTP(context.Background(), credentials, req, hex.EncodeToString(hash[:]), "ses", p.config.Region, time.Now()); err != nil {
+		return nil, fmt.Errorf("failed to sign request: %w", err)
+	}
+	return req, nil
+}
@@ -0,0 +1,233 @@
+package services
+
+import (
+	"encoding/base32"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/ratelimit"
+
+	"github.com/gorilla/securecookie"
+	"github.com/gorilla/sessions"
+)
+
+// sessionTouchInterval is how often a session's last use is written back
+// when a request reads it without saving it
+const sessionTouchInterval = 5 * time.Minute
+
+// defaultSessionLifetime is how long sessions saved with a browser-session
+// cookie (MaxAge 0) are kept server-side
+const defaultSessionLifetime = 24 * time.Hour
+
+// The longest address and user agent stored with a session
+const (
+	maxSessionIPLength        = 64
+	maxSessionUserAgentLength = 512
+)
+
+// legacySessionIDKey holds the ID of the login session AuthService creates,
+// which is kept alongside the session store's own row
+const legacySessionIDKey = "session_id"
+
+// renewSessionIDKey marks a session to be given a new ID when it's next saved
+const renewSessionIDKey = "_renew_session_id"
+
+var sessionIDEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// SessionRepository stores browser sessions
+type SessionRepository interface {
+	GetByID(id string) (*models.Session, error)
+	Save(session *models.Session) error
+	Touch(id string) error
+	Delete(id string) error
+	GetActiveByUser(userID int) ([]*models.Session, error)
+	DeleteUserSession(userID int, id string) error
+	DeleteUserSessionsExcept(userID int, keep []string) (int, error)
+	DeleteExpired() (int64, error)
+}
+
+// SessionStore is a sessions.Store that keeps session values in the database
+// and only a signed session ID in the cookie, so sessions can be listed and
+// revoked
+type SessionStore struct {
+	Codecs  []securecookie.Codec
+	Options *sessions.Options
+
+	repo SessionRepository
+	now  func() time.Time
+}
+
+// NewSessionStore creates a session store. keyPairs sign (and optionally
+// encrypt) the session ID cookie, as for sessions.NewCookieStore.
+func NewSessionStore(repo SessionRepository, keyPairs ...[]byte) *SessionStore {
+	store := &SessionStore{
+		Codecs: securecookie.CodecsFromPairs(keyPairs...),
+		Options: &sessions.Options{
+			Path:   "/",
+			MaxAge: 86400 * 30,
+		},
+		repo: repo,
+		now:  time.Now,
+	}
+	for _, codec := range store.Codecs {
+		if cookie, ok := codec.(*securecookie.SecureCookie); ok {
+			// Values are stored server-side, so aren't limited to a cookie's size
+			cookie.MaxLength(0)
+		}
+	}
+	return store
+}
+
+// Get returns the named session, cached for the rest of the request
+func (s *SessionStore) Get(r *http.Request, name string) (*sessions.Session, error) {
+	return sessions.GetRegistry(r).Get(s, name)
+}
+
+// New loads the named session for the request's cookie. A cookie that can't
+// be decoded, such as one left by the cookie store this replaced, or that
+// names an expired or revoked session starts a new session rather than
+// failing the request.
+func (s *SessionStore) New(r *http.Request, name string) (*sessions.Session, error) {
+	session := sessions.NewSession(s, name)
+	options := *s.Options
+	session.Options = &options
+	session.IsNew = true
+
+	cookie, err := r.Cookie(name)
+	if err != nil {
+		return session, nil
+	}
+	var id string
+	if err := securecookie.DecodeMulti(name, cookie.Value, &id, s.Codecs...); err != nil {
+		return session, nil
+	}
+
+	stored, err := s.repo.GetByID(models.HashSessionID(id))
+	if errors.Is(err, models.ErrSessionNotFound) {
+		return session, nil
+	}
+	if err != nil {
+		return session, err
+	}
+	if err := securecookie.DecodeMulti(name, stored.Data, &session.Values, s.Codecs...); err != nil {
+		return session, nil
+	}
+
+	session.ID = id
+	session.IsNew = false
+	if s.now().Sub(stored.LastSeenAt) > sessionTouchInterval {
+		if err := s.repo.Touch(stored.ID); err != nil {
+			return session, err
+		}
+	}
+	return session, nil
+}
+
+// Save writes the session's values to the database, with the address and
+// browser the request came from, and its ID to the response's cookie. A
+// negative MaxAge deletes the session, and sessions marked by RenewSessionID
+// move to a new ID.
+func (s *SessionStore) Save(r *http.Request, w http.ResponseWriter, session *sessions.Session) error {
+	if session.Options.MaxAge < 0 {
+		if session.ID != "" {
+			if err := s.repo.Delete(models.HashSessionID(session.ID)); err != nil {
+				return err
+			}
+		}
+		http.SetCookie(w, sessions.NewCookie(session.Name(), "", session.Options))
+		return nil
+	}
+
+	if renew, _ := session.Values[renewSessionIDKey].(bool); renew {
+		delete(session.Values, renewSessionIDKey)
+		if session.ID != "" {
+			if err := s.repo.Delete(models.HashSessionID(session.ID)); err != nil {
+				return err
+			}
+			session.ID = ""
+		}
+	}
+	if session.ID == "" {
+		session.ID = sessionIDEncoding.EncodeToString(securecookie.GenerateRandomKey(32))
+	}
+	data, err := securecookie.EncodeMulti(session.Name(), session.Values, s.Codecs...)
+	if err != nil {
+		return fmt.Errorf("failed to encode session: %w", err)
+	}
+
+	lifetime := defaultSessionLifetime
+	if session.Options.MaxAge > 0 {
+		lifetime = time.Duration(session.Options.MaxAge) * time.Second
+	}
+	if err := s.repo.Save(&models.Session{
+		ID:        models.HashSessionID(session.ID),
+		UserID:    sessionUserID(session.Values),
+		Data:      data,
+		IPAddress: strings.ToValidUTF8(truncateField(ratelimit.ClientIP(r), maxSessionIPLength), ""),
+		UserAgent: strings.ToValidUTF8(truncateField(r.UserAgent(), maxSessionUserAgentLength), ""),
+		ExpiresAt: s.now().Add(lifetime),
+	}); err != nil {
+		return err
+	}
+
+	encoded, err := securecookie.EncodeMulti(session.Name(), session.ID, s.Codecs...)
+	if err != nil {
+		return fmt.Errorf("failed to encode session cookie: %w", err)
+	}
+	http.SetCookie(w, sessions.NewCookie(session.Name(), encoded, session.Options))
+	return nil
+}
+
+// RenewSessionID gives a session a new ID when it's next saved, deleting what
+// was stored under the old one. Call it whenever the session's user changes,
+// such as on login, so that an ID planted in the browser beforehand can't be
+// used to act as them.
+func RenewSessionID(session *sessions.Session) {
+	session.Values[renewSessionIDKey] = true
+}
+
+// UserSessions lists a user's active sessions, most recently used first
+func (s *SessionStore) UserSessions(userID int) ([]*models.Session, error) {
+	return s.repo.GetActiveByUser(userID)
+}
+
+// RevokeSession ends one of a user's sessions, logging that device out. id
+// is the session's stored (hashed) ID, as listed by UserSessions.
+func (s *SessionStore) RevokeSession(userID int, id string) error {
+	return s.repo.DeleteUserSession(userID, id)
+}
+
+// LogOutOtherSessions ends every session of the user except current, so the
+// user is logged out on every other device. It returns how many sessions were
+// ended.
+func (s *SessionStore) LogOutOtherSessions(userID int, current *sessions.Session) (int, error) {
+	keep := []string{models.HashSessionID(current.ID)}
+	if loginSessionID, ok := current.Values[legacySessionIDKey].(string); ok {
+		keep = append(keep, loginSessionID)
+	}
+	return s.repo.DeleteUserSessionsExcept(userID, keep)
+}
+
+// DeleteExpired deletes sessions that have expired
+func (s *SessionStore) DeleteExpired() (int64, error) {
+	return s.repo.DeleteExpired()
+}
+
+// sessionUserID returns the signed-in user of a session, from the user_id
+// value the login handlers set or the ID Authboss stores as its PID
+func sessionUserID(values map[interface{}]interface{}) *int {
+	if userID, ok := values["user_id"].(int); ok && userID > 0 {
+		return &userID
+	}
+	if pid, ok := values["uid"].(string); ok {
+		if userID, err := strconv.Atoi(pid); err == nil && userID > 0 {
+			return &userID
+		}
+	}
+	return nil
+}
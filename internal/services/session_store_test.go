@@ -0,0 +1,303 @@
+package services
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+
+	"github.com/gorilla/sessions"
+)
+
+type memorySessionRepository struct {
+	sessions map[string]*models.Session
+	touched  []string
+	kept     []string
+}
+
+func newMemorySessionRepository() *memorySessionRepository {
+	return &memorySessionRepository{sessions: make(map[string]*models.Session)}
+}
+
+func (r *memorySessionRepository) GetByID(id string) (*models.Session, error) {
+	session, ok := r.sessions[id]
+	if !ok {
+		return nil, models.ErrSessionNotFound
+	}
+	return session, nil
+}
+
+func (r *memorySessionRepository) Save(session *models.Session) error {
+	session.LastSeenAt = time.Now()
+	r.sessions[session.ID] = session
+	return nil
+}
+
+func (r *memorySessionRepository) Touch(id string) error {
+	r.touched = append(r.touched, id)
+	return nil
+}
+
+func (r *memorySessionRepository) Delete(id string) error {
+	delete(r.sessions, id)
+	return nil
+}
+
+func (r *memorySessionRepository) GetActiveByUser(userID int) ([]*models.Session, error) {
+	var sessions []*models.Session
+	for _, session := range r.sessions {
+		if session.UserID != nil && *session.UserID == userID {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions, nil
+}
+
+func (r *memorySessionRepository) DeleteUserSession(userID int, id string) error {
+	session, ok := r.sessions[id]
+	if !ok || session.UserID == nil || *session.UserID != userID {
+		return models.ErrSessionNotFound
+	}
+	delete(r.sessions, id)
+	return nil
+}
+
+func (r *memorySessionRepository) DeleteUserSessionsExcept(userID int, keep []string) (int, error) {
+	r.kept = keep
+	deleted := 0
+	for id, session := range r.sessions {
+		if session.UserID != nil && *session.UserID == userID && !slices.Contains(keep, id) {
+			delete(r.sessions, id)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+func (r *memorySessionRepository) DeleteExpired() (int64, error) {
+	return 0, nil
+}
+
+var testSessionKey = []byte("0123456789abcdef0123456789abcdef")
+
+// saveSession saves values in a new session and returns the cookie sent back
+func saveSession(t *testing.T, store *SessionStore, values map[interface{}]interface{}) *http.Cookie {
+	t.Helper()
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.Header.Set("User-Agent", "Mozilla/5.0 (Windows NT 10.0; Win64; x64) AppleWebKit/537.36 (KHTML, like Gecko) Chrome/126.0.0.0 Safari/537.36")
+	r.Header.Set("X-Forwarded-For", "203.0.113.7, 10.0.0.1")
+	w := httptest.NewRecorder()
+	session, err := store.Get(r, "session")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for key, value := range values {
+		session.Values[key] = value
+	}
+	if err := session.Save(r, w); err != nil {
+		t.Fatalf("failed to save session: %v", err)
+	}
+	cookies := w.Result().Cookies()
+	if len(cookies) != 1 {
+		t.Fatalf("expected a session cookie, got %v", cookies)
+	}
+	return cookies[0]
+}
+
+func requestWithCookie(cookie *http.Cookie) *http.Request {
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	r.AddCookie(cookie)
+	return r
+}
+
+func TestSessionStore_KeepsValuesServerSide(t *testing.T) {
+	repo := newMemorySessionRepository()
+	store := NewSessionStore(repo, testSessionKey)
+	cookie := saveSession(t, store, map[interface{}]interface{}{"user_id": 42, "cart": strings.Repeat("ticket ", 1000)})
+
+	if len(cookie.Value) > 200 {
+		t.Errorf("expected the cookie to only carry the session ID, got %d bytes", len(cookie.Value))
+	}
+	if len(repo.sessions) != 1 {
+		t.Fatalf("expected 1 stored session, got %d", len(repo.sessions))
+	}
+	for id, stored := range repo.sessions {
+		if stored.UserID == nil || *stored.UserID != 42 {
+			t.Errorf("expected the session to belong to user 42, got %v", stored.UserID)
+		}
+		if strings.Contains(cookie.Value, id) {
+			t.Error("expected the stored ID to be a hash of the cookie's session ID")
+		}
+		if stored.IPAddress != "203.0.113.7" || stored.Device() != "Chrome on Windows" {
+			t.Errorf("expected the device to be recorded, got %q from %q", stored.Device(), stored.IPAddress)
+		}
+	}
+
+	session, err := NewSessionStore(repo, testSessionKey).Get(requestWithCookie(cookie), "session")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if session.IsNew || session.Values["user_id"] != 42 {
+		t.Errorf("expected the saved session to load, got new=%v values=%v", session.IsNew, session.Values)
+	}
+}
+
+func TestSessionStore_StaleCookiesStartNewSessions(t *testing.T) {
+	repo := newMemorySessionRepository()
+	store := NewSessionStore(repo, testSessionKey)
+
+	// A cookie left by the cookie store used before
+	cookieStore := sessions.NewCookieStore(testSessionKey)
+	r := httptest.NewRequest(http.MethodGet, "/", nil)
+	w := httptest.NewRecorder()
+	old, _ := cookieStore.Get(r, "session")
+	old.Values["user_id"] = 42
+	if err := old.Save(r, w); err != nil {
+		t.Fatalf("failed to save cookie session: %v", err)
+	}
+
+	// A session that was revoked
+	revoked := saveSession(t, store, map[interface{}]interface{}{"user_id": 42})
+	repo.sessions = make(map[string]*models.Session)
+
+	for name, cookie := range map[string]*http.Cookie{"cookie store": w.Result().Cookies()[0], "revoked": revoked} {
+		session, err := NewSessionStore(repo, testSessionKey).Get(requestWithCookie(cookie), "session")
+		if err != nil {
+			t.Errorf("%s: unexpected error: %v", name, err)
+			continue
+		}
+		if !session.IsNew || len(session.Values) != 0 {
+			t.Errorf("%s: expected a new empty session, got %v", name, session.Values)
+		}
+	}
+}
+
+func TestSessionStore_DeletesSessionsWithNegativeMaxAge(t *testing.T) {
+	repo := newMemorySessionRepository()
+	store := NewSessionStore(repo, testSessionKey)
+	cookie := saveSession(t, store, map[interface{}]interface{}{"user_id": 42})
+
+	r := requestWithCookie(cookie)
+	w := httptest.NewRecorder()
+	session, _ := store.Get(r, "session")
+	session.Options.MaxAge = -1
+	if err := session.Save(r, w); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.sessions) != 0 {
+		t.Errorf("expected the session to be deleted, got %d", len(repo.sessions))
+	}
+}
+
+func TestSessionStore_RenewSessionID(t *testing.T) {
+	repo := newMemorySessionRepository()
+	store := NewSessionStore(repo, testSessionKey)
+	// A session started before login, such as one an attacker planted
+	planted := saveSession(t, store, map[interface{}]interface{}{"cart": "2 tickets"})
+
+	r := requestWithCookie(planted)
+	w := httptest.NewRecorder()
+	session, _ := store.Get(r, "session")
+	oldID := session.ID
+	RenewSessionID(session)
+	session.Values["user_id"] = 42
+	if err := session.Save(r, w); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if session.ID == oldID {
+		t.Fatal("expected the session to get a new ID")
+	}
+	if _, ok := repo.sessions[models.HashSessionID(oldID)]; ok || len(repo.sessions) != 1 {
+		t.Errorf("expected only the renewed session to be stored, got %d", len(repo.sessions))
+	}
+
+	loaded, _ := store.New(requestWithCookie(planted), "session")
+	if !loaded.IsNew {
+		t.Error("expected the old cookie to start a new session")
+	}
+	loaded, _ = store.New(requestWithCookie(w.Result().Cookies()[0]), "session")
+	if loaded.Values["user_id"] != 42 || loaded.Values["cart"] != "2 tickets" {
+		t.Errorf("expected the values to move to the new session, got %v", loaded.Values)
+	}
+	if _, ok := loaded.Values[renewSessionIDKey]; ok {
+		t.Error("expected the renewal mark not to be stored")
+	}
+}
+
+func TestSessionStore_TouchesIdleSessions(t *testing.T) {
+	repo := newMemorySessionRepository()
+	store := NewSessionStore(repo, testSessionKey)
+	cookie := saveSession(t, store, map[interface{}]interface{}{"user_id": 42})
+
+	if _, err := NewSessionStore(repo, testSessionKey).Get(requestWithCookie(cookie), "session"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.touched) != 0 {
+		t.Errorf("expected a recently used session not to be touched, got %v", repo.touched)
+	}
+
+	later := NewSessionStore(repo, testSessionKey)
+	later.now = func() time.Time { return time.Now().Add(time.Hour) }
+	if _, err := later.Get(requestWithCookie(cookie), "session"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(repo.touched) != 1 {
+		t.Errorf("expected an idle session to be touched, got %v", repo.touched)
+	}
+}
+
+func TestSessionStore_LogOutOtherSessions(t *testing.T) {
+	repo := newMemorySessionRepository()
+	store := NewSessionStore(repo, testSessionKey)
+	current := saveSession(t, store, map[interface{}]interface{}{"user_id": 42, "session_id": "login-session"})
+	saveSession(t, store, map[interface{}]interface{}{"user_id": 42})
+	saveSession(t, store, map[interface{}]interface{}{"uid": "42"}) // Signed in through Authboss
+	saveSession(t, store, map[interface{}]interface{}{"user_id": 7})
+
+	listed, err := store.UserSessions(42)
+	if err != nil || len(listed) != 3 {
+		t.Fatalf("expected 3 sessions for user 42, got %d, %v", len(listed), err)
+	}
+
+	session, _ := NewSessionStore(repo, testSessionKey).Get(requestWithCookie(current), "session")
+	ended, err := store.LogOutOtherSessions(42, session)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ended != 2 {
+		t.Errorf("expected 2 sessions to be ended, got %d", ended)
+	}
+	if !slices.Contains(repo.kept, models.HashSessionID(session.ID)) || !slices.Contains(repo.kept, "login-session") {
+		t.Errorf("expected the current session and its login session to be kept, got %v", repo.kept)
+	}
+	if len(repo.sessions) != 2 {
+		t.Errorf("expected the current session and the other user's to remain, got %d", len(repo.sessions))
+	}
+}
+
+func TestSessionStore_RevokeSession(t *testing.T) {
+	repo := newMemorySessionRepository()
+	store := NewSessionStore(repo, testSessionKey)
+	cookie := saveSession(t, store, map[interface{}]interface{}{"user_id": 42})
+	saveSession(t, store, map[interface{}]interface{}{"user_id": 7})
+
+	session, _ := NewSessionStore(repo, testSessionKey).Get(requestWithCookie(cookie), "session")
+	id := models.HashSessionID(session.ID)
+
+	if err := store.RevokeSession(7, id); err == nil {
+		t.Error("expected users not to be able to revoke each other's sessions")
+	}
+	if err := store.RevokeSession(42, id); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	session, err := NewSessionStore(repo, testSessionKey).Get(requestWithCookie(cookie), "session")
+	if err != nil || !session.IsNew {
+		t.Errorf("expected the revoked session's cookie to start a new session, got new=%v, %v", session.IsNew, err)
+	}
+}
@@ -1,37 +1,131 @@
 package services
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/http"
+	"reflect"
+	"sync"
+	"time"
 
+	"event-ticketing-platform/internal/cache"
 	"event-ticketing-platform/internal/models"
-	"event-ticketing-platform/internal/repositories"
 )
 
+const (
+	settingsCacheKey     = "settings:current"
+	featureFlagsCacheKey = "settings:feature_flags"
+	settingsCacheTTL     = 5 * time.Minute
+
+	// featureFlagsLocalTTL is how long each instance keeps the feature flags
+	// in memory, so checking a flag doesn't reach the shared cache. Other
+	// instances see a flag an admin switches within this long.
+	featureFlagsLocalTTL = 30 * time.Second
+)
+
+// SettingsRepository interface for system settings data operations
+type SettingsRepository interface {
+	GetSettings() (*models.SystemSettings, error)
+	UpdateSettings(req *models.SettingsUpdateRequest) (*models.SystemSettings, error)
+	InitializeDefaultSettings() error
+	GetFeatureFlagOverrides() ([]*models.FeatureFlagOverride, error)
+	SetFeatureFlag(flag models.FeatureFlag, enabled bool, adminID int) error
+}
+
 // SettingsService handles system settings business logic
 type SettingsService struct {
-	settingsRepo *repositories.SettingsRepository
+	settingsRepo SettingsRepository
+	auditService *AuditService
+	cache        cache.Cache
+
+	flagsMu       sync.RWMutex
+	flags         map[models.FeatureFlag]bool
+	flagsLoadedAt time.Time
 }
 
 // NewSettingsService creates a new settings service
-func NewSettingsService(settingsRepo *repositories.SettingsRepository) *SettingsService {
+func NewSettingsService(settingsRepo SettingsRepository) *SettingsService {
 	return &SettingsService{
 		settingsRepo: settingsRepo,
 	}
 }
 
+// SetAuditService records which settings admins change in the audit log
+func (s *SettingsService) SetAuditService(auditService *AuditService) {
+	s.auditService = auditService
+}
+
+// SetCache enables caching of the settings and feature flags, shared by
+// every instance. Without a cache every read goes to the repository.
+func (s *SettingsService) SetCache(c cache.Cache) {
+	s.cache = c
+}
+
 // GetSettings retrieves the current system settings
 func (s *SettingsService) GetSettings() (*models.SystemSettings, error) {
-	return s.settingsRepo.GetSettings()
+	return cache.Remember(s.cache, settingsCacheKey, settingsCacheTTL, s.settingsRepo.GetSettings)
 }
 
-// UpdateSettings updates the system settings with validation
-func (s *SettingsService) UpdateSettings(req *models.SettingsUpdateRequest) (*models.SystemSettings, error) {
+// UpdateSettings updates the system settings with validation on behalf of
+// an admin
+func (s *SettingsService) UpdateSettings(adminID int, req *models.SettingsUpdateRequest, r *http.Request) (*models.SystemSettings, error) {
 	// Validate the request
-	if err := s.validateSettingsUpdate(req); err != nil {
+	if err := req.Validate(); err != nil {
 		return nil, fmt.Errorf("validation failed: %w", err)
 	}
 
-	return s.settingsRepo.UpdateSettings(req)
+	// Without the previous settings every setting is logged as changed
+	before, _ := s.settingsRepo.GetSettings()
+
+	settings, err := s.settingsRepo.UpdateSettings(req)
+	if err != nil {
+		return nil, err
+	}
+	s.invalidateCache(settingsCacheKey)
+
+	if s.auditService != nil {
+		if changes := settingsChanges(before, settings); len(changes) > 0 {
+			if err := s.auditService.LogAction(adminID, models.AuditActionSettingsUpdate, models.AuditTargetSettings, settings.ID, changes, r); err != nil {
+				fmt.Printf("Warning: failed to log settings update: %v\n", err)
+			}
+		}
+	}
+
+	return settings, nil
+}
+
+// settingsChanges returns the previous and new value of each setting that
+// differs between before and after, keyed by its JSON name. Every setting is
+// reported when the previous settings aren't known.
+func settingsChanges(before, after *models.SystemSettings) map[string]interface{} {
+	oldValues := settingsValues(before)
+	changes := make(map[string]interface{})
+	for key, value := range settingsValues(after) {
+		switch key {
+		case "id", "created_at", "updated_at":
+			continue
+		}
+		previous, ok := oldValues[key]
+		if ok && reflect.DeepEqual(previous, value) {
+			continue
+		}
+		changes[key] = map[string]interface{}{"from": previous, "to": value}
+	}
+	return changes
+}
+
+// settingsValues returns settings as a map of JSON names to values
+func settingsValues(settings *models.SystemSettings) map[string]interface{} {
+	values := make(map[string]interface{})
+	if settings == nil {
+		return values
+	}
+	data, err := json.Marshal(settings)
+	if err != nil {
+		return values
+	}
+	_ = json.Unmarshal(data, &values)
+	return values
 }
 
 // GetPlatformFeePercentage returns the current platform fee percentage
@@ -66,35 +160,96 @@ func (s *SettingsService) InitializeDefaultSettings() error {
 	return s.settingsRepo.InitializeDefaultSettings()
 }
 
-// validateSettingsUpdate validates the settings update request
-func (s *SettingsService) validateSettingsUpdate(req *models.SettingsUpdateRequest) error {
-	if req.PlatformFeePercentage != nil {
-		if *req.PlatformFeePercentage < 0 || *req.PlatformFeePercentage > 50 {
-			return fmt.Errorf("platform fee percentage must be between 0 and 50")
-		}
+// invalidateCache drops cached settings or feature flags after they change
+func (s *SettingsService) invalidateCache(key string) {
+	if s.cache == nil {
+		return
+	}
+	if err := s.cache.Delete(key); err != nil {
+		fmt.Printf("Warning: failed to invalidate cached %s: %v\n", key, err)
 	}
+}
 
-	if req.MinWithdrawalAmount != nil {
-		if *req.MinWithdrawalAmount < 1 {
-			return fmt.Errorf("minimum withdrawal amount must be at least $1")
-		}
+// FeatureEnabled returns whether a feature is switched on. It is cheap
+// enough to call on every request: flags are kept in memory for
+// featureFlagsLocalTTL. Unknown flags are off, and a flag whose state can't
+// be loaded has its default.
+func (s *SettingsService) FeatureEnabled(flag models.FeatureFlag) bool {
+	definition, ok := models.LookupFeatureFlag(flag)
+	if !ok {
+		return false
 	}
 
-	if req.MaxWithdrawalAmount != nil {
-		if *req.MaxWithdrawalAmount < 1 {
-			return fmt.Errorf("maximum withdrawal amount must be at least $1")
+	s.flagsMu.RLock()
+	flags, loadedAt := s.flags, s.flagsLoadedAt
+	s.flagsMu.RUnlock()
+
+	if flags == nil || time.Since(loadedAt) > featureFlagsLocalTTL {
+		loaded, err := s.loadFeatureFlags()
+		if err != nil {
+			fmt.Printf("Warning: failed to load feature flags: %v\n", err)
+			return definition.Default
 		}
+		flags = loaded
 	}
 
-	if req.MinWithdrawalAmount != nil && req.MaxWithdrawalAmount != nil {
-		if *req.MinWithdrawalAmount >= *req.MaxWithdrawalAmount {
-			return fmt.Errorf("minimum withdrawal amount must be less than maximum")
+	return flags[flag]
+}
+
+// loadFeatureFlags loads whether each feature is on and keeps it in memory
+func (s *SettingsService) loadFeatureFlags() (map[models.FeatureFlag]bool, error) {
+	flags, err := cache.Remember(s.cache, featureFlagsCacheKey, settingsCacheTTL, func() (map[models.FeatureFlag]bool, error) {
+		overrides, err := s.settingsRepo.GetFeatureFlagOverrides()
+		if err != nil {
+			return nil, err
+		}
+		flags := make(map[models.FeatureFlag]bool)
+		for _, state := range models.ResolveFeatureFlags(overrides) {
+			flags[state.Flag] = state.Enabled
 		}
+		return flags, nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
-	if req.WithdrawalProcessingDays != nil {
-		if *req.WithdrawalProcessingDays < 1 || *req.WithdrawalProcessingDays > 30 {
-			return fmt.Errorf("withdrawal processing days must be between 1 and 30")
+	s.flagsMu.Lock()
+	s.flags = flags
+	s.flagsLoadedAt = time.Now()
+	s.flagsMu.Unlock()
+
+	return flags, nil
+}
+
+// GetFeatureFlags returns the state of every feature flag, read from the
+// repository so admins always see the current state
+func (s *SettingsService) GetFeatureFlags() ([]*models.FeatureFlagState, error) {
+	overrides, err := s.settingsRepo.GetFeatureFlagOverrides()
+	if err != nil {
+		return nil, err
+	}
+	return models.ResolveFeatureFlags(overrides), nil
+}
+
+// SetFeatureFlag switches a feature on or off on behalf of an admin
+func (s *SettingsService) SetFeatureFlag(adminID int, flag models.FeatureFlag, enabled bool, r *http.Request) error {
+	if _, ok := models.LookupFeatureFlag(flag); !ok {
+		return fmt.Errorf("unknown feature flag %q", flag)
+	}
+
+	if err := s.settingsRepo.SetFeatureFlag(flag, enabled, adminID); err != nil {
+		return err
+	}
+
+	s.invalidateCache(featureFlagsCacheKey)
+	s.flagsMu.Lock()
+	s.flags = nil
+	s.flagsMu.Unlock()
+
+	if s.auditService != nil {
+		details := map[string]interface{}{"flag": string(flag), "enabled": enabled}
+		if err := s.auditService.LogAction(adminID, models.AuditActionFeatureFlagUpdate, models.AuditTargetSettings, 0, details, r); err != nil {
+			fmt.Printf("Warning: failed to log feature flag update: %v\n", err)
 		}
 	}
 
@@ -0,0 +1,132 @@
+package services
+
+import (
+	"testing"
+
+	"event-ticketing-platform/internal/cache"
+	"event-ticketing-platform/internal/models"
+)
+
+func TestSettingsChanges(t *testing.T) {
+	before := models.DefaultSettings()
+	after := *before
+	after.PlatformFeePercentage = before.PlatformFeePercentage + 1
+	after.MaintenanceMode = !before.MaintenanceMode
+	after.UpdatedAt = before.UpdatedAt.Add(1)
+
+	changes := settingsChanges(before, &after)
+	if len(changes) != 2 {
+		t.Fatalf("expected 2 changed settings, got %v", changes)
+	}
+	fee, ok := changes["platform_fee_percentage"].(map[string]interface{})
+	if !ok || fee["from"] != before.PlatformFeePercentage || fee["to"] != after.PlatformFeePercentage {
+		t.Errorf("unexpected platform fee change %v", changes["platform_fee_percentage"])
+	}
+	if _, ok := changes["maintenance_mode"]; !ok {
+		t.Error("expected maintenance mode to be reported")
+	}
+
+	if changes := settingsChanges(before, before); len(changes) != 0 {
+		t.Errorf("expected no changes, got %v", changes)
+	}
+	if changes := settingsChanges(nil, &after); changes["maintenance_mode"] == nil {
+		t.Error("expected every setting to be reported without the previous settings")
+	}
+}
+
+// Mock SettingsRepository for testing
+type mockSettingsRepository struct {
+	settings      *models.SystemSettings
+	overrides     []*models.FeatureFlagOverride
+	settingsLoads int
+	flagLoads     int
+}
+
+func (m *mockSettingsRepository) GetSettings() (*models.SystemSettings, error) {
+	m.settingsLoads++
+	settings := *m.settings
+	return &settings, nil
+}
+
+func (m *mockSettingsRepository) UpdateSettings(req *models.SettingsUpdateRequest) (*models.SystemSettings, error) {
+	if req.MaxTicketsPerOrder != nil {
+		m.settings.MaxTicketsPerOrder = *req.MaxTicketsPerOrder
+	}
+	settings := *m.settings
+	return &settings, nil
+}
+
+func (m *mockSettingsRepository) InitializeDefaultSettings() error {
+	return nil
+}
+
+func (m *mockSettingsRepository) GetFeatureFlagOverrides() ([]*models.FeatureFlagOverride, error) {
+	m.flagLoads++
+	return m.overrides, nil
+}
+
+func (m *mockSettingsRepository) SetFeatureFlag(flag models.FeatureFlag, enabled bool, adminID int) error {
+	m.overrides = append(m.overrides, &models.FeatureFlagOverride{Flag: flag, Enabled: enabled, UpdatedBy: &adminID})
+	return nil
+}
+
+func TestSettingsService_CachesSettings(t *testing.T) {
+	repo := &mockSettingsRepository{settings: models.DefaultSettings()}
+	service := NewSettingsService(repo)
+	service.SetCache(cache.NewMemoryCache())
+
+	for i := 0; i < 3; i++ {
+		if _, err := service.GetSettings(); err != nil {
+			t.Fatalf("GetSettings() error = %v", err)
+		}
+	}
+	if repo.settingsLoads != 1 {
+		t.Errorf("expected settings loaded once, got %d", repo.settingsLoads)
+	}
+
+	maxTickets := 25
+	if _, err := service.UpdateSettings(1, &models.SettingsUpdateRequest{MaxTicketsPerOrder: &maxTickets}, nil); err != nil {
+		t.Fatalf("UpdateSettings() error = %v", err)
+	}
+	settings, err := service.GetSettings()
+	if err != nil {
+		t.Fatalf("GetSettings() error = %v", err)
+	}
+	if settings.MaxTicketsPerOrder != 25 {
+		t.Errorf("expected the update to invalidate the cached settings, got %d tickets per order", settings.MaxTicketsPerOrder)
+	}
+
+	tooMany := 1000
+	if _, err := service.UpdateSettings(1, &models.SettingsUpdateRequest{MaxTicketsPerOrder: &tooMany}, nil); err == nil {
+		t.Error("expected an error for a setting out of range")
+	}
+}
+
+func TestSettingsService_FeatureEnabled(t *testing.T) {
+	repo := &mockSettingsRepository{settings: models.DefaultSettings()}
+	service := NewSettingsService(repo)
+	service.SetCache(cache.NewMemoryCache())
+
+	for i := 0; i < 3; i++ {
+		if service.FeatureEnabled(models.FeatureGuestCheckout) {
+			t.Fatal("expected guest checkout off by default")
+		}
+	}
+	if repo.flagLoads != 1 {
+		t.Errorf("expected feature flags loaded once, got %d", repo.flagLoads)
+	}
+
+	if err := service.SetFeatureFlag(1, models.FeatureGuestCheckout, true, nil); err != nil {
+		t.Fatalf("SetFeatureFlag() error = %v", err)
+	}
+	if !service.FeatureEnabled(models.FeatureGuestCheckout) {
+		t.Error("expected switching a flag to take effect immediately")
+	}
+
+	if err := service.SetFeatureFlag(1, "enable_teleportation", true, nil); err == nil {
+		t.Error("expected an error for an unknown flag")
+	}
+	if service.FeatureEnabled("enable_teleportation") {
+		t.Error("expected an unknown flag to be off")
+	}
+}
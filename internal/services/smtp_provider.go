@@ -0,0 +1,237 @@
+package services
+
+import (
+	"bytes"
+	"crypto/tls"
+	"fmt"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"net/textproto"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// smtpTimeout bounds connecting to and talking with the SMTP server
+const smtpTimeout = 30 * time.Second
+
+// SMTPConfig represents SMTP server configuration
+type SMTPConfig struct {
+	Host     string
+	Port     int
+	Username string
+	Password string
+}
+
+// SMTPProvider sends emails through an SMTP server. Port 465 uses implicit
+// TLS; other ports upgrade with STARTTLS when the server offers it.
+type SMTPProvider struct {
+	config SMTPConfig
+	now    func() time.Time
+}
+
+// NewSMTPProvider creates a new SMTP email provider
+func NewSMTPProvider(config SMTPConfig) *SMTPProvider {
+	return &SMTPProvider{config: config, now: time.Now}
+}
+
+// Name returns the provider's name
+func (p *SMTPProvider) Name() string {
+	return EmailProviderSMTP
+}
+
+// Send delivers the email to the SMTP server
+func (p *SMTPProvider) Send(message *EmailMessage) (*EmailReceipt, error) {
+	from, err := mail.ParseAddress(message.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid from address %q: %w", message.From, err)
+	}
+	recipients := make([]string, len(message.To))
+	for i, to := range message.To {
+		address, err := mail.ParseAddress(to)
+		if err != nil {
+			return nil, fmt.Errorf("invalid recipient %q: %w", to, err)
+		}
+		recipients[i] = address.Address
+	}
+
+	messageID := fmt.Sprintf("<%s@%s>", uuid.New().String(), domainOf(from.Address))
+	data, err := buildMIMEMessage(message, messageID, p.now())
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := p.connect()
+	if err != nil {
+		return nil, err
+	}
+	defer client.Close()
+
+	if err := client.Mail(from.Address); err != nil {
+		return nil, fmt.Errorf("SMTP server rejected sender: %w", err)
+	}
+	for _, recipient := range recipients {
+		if err := client.Rcpt(recipient); err != nil {
+			return nil, fmt.Errorf("SMTP server rejected recipient %s: %w", recipient, err)
+		}
+	}
+	writer, err := client.Data()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start SMTP data: %w", err)
+	}
+	if _, err := writer.Write(data); err != nil {
+		return nil, fmt.Errorf("failed to write email: %w", err)
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("SMTP server rejected email: %w", err)
+	}
+	if err := client.Quit(); err != nil {
+		return nil, fmt.Errorf("failed to close SMTP session: %w", err)
+	}
+
+	return &EmailReceipt{Provider: p.Name(), MessageID: messageID}, nil
+}
+
+// TestConnection connects and authenticates to the SMTP server
+func (p *SMTPProvider) TestConnection() error {
+	client, err := p.connect()
+	if err != nil {
+		return err
+	}
+	defer client.Close()
+	return client.Quit()
+}
+
+// connect opens an authenticated session with the SMTP server
+func (p *SMTPProvider) connect() (*smtp.Client, error) {
+	addr := net.JoinHostPort(p.config.Host, strconv.Itoa(p.config.Port))
+	tlsConfig := &tls.Config{ServerName: p.config.Host}
+
+	var conn net.Conn
+	var err error
+	dialer := &net.Dialer{Timeout: smtpTimeout}
+	if p.config.Port == 465 {
+		conn, err = tls.DialWithDialer(dialer, "tcp", addr, tlsConfig)
+	} else {
+		conn, err = dialer.Dial("tcp", addr)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to connect to SMTP server: %w", err)
+	}
+	conn.SetDeadline(time.Now().Add(smtpTimeout))
+
+	client, err := smtp.NewClient(conn, p.config.Host)
+	if err != nil {
+		conn.Close()
+		return nil, fmt.Errorf("failed to start SMTP session: %w", err)
+	}
+
+	if ok, _ := client.Extension("STARTTLS"); ok && p.config.Port != 465 {
+		if err := client.StartTLS(tlsConfig); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("failed to start TLS: %w", err)
+		}
+	}
+
+	if p.config.Username != "" {
+		auth := smtp.PlainAuth("", p.config.Username, p.config.Password, p.config.Host)
+		if err := client.Auth(auth); err != nil {
+			client.Close()
+			return nil, fmt.Errorf("SMTP authentication failed: %w", err)
+		}
+	}
+
+	return client, nil
+}
+
+// buildMIMEMessage encodes the email as a MIME message, with the plain text
+// and HTML bodies as alternatives when it has both
+func buildMIMEMessage(message *EmailMessage, messageID string, date time.Time) ([]byte, error) {
+	var buf bytes.Buffer
+	header := func(name, value string) {
+		fmt.Fprintf(&buf, "%s: %s\r\n", name, value)
+	}
+
+	header("From", message.From)
+	header("To", strings.Join(message.To, ", "))
+	header("Subject", mime.QEncoding.Encode("utf-8", message.Subject))
+	header("Date", date.Format(time.RFC1123Z))
+	header("Message-ID", messageID)
+	header("MIME-Version", "1.0")
+
+	names := make([]string, 0, len(message.Headers))
+	for name := range message.Headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		header(textproto.CanonicalMIMEHeaderKey(name), mime.QEncoding.Encode("utf-8", message.Headers[name]))
+	}
+
+	var parts []struct{ contentType, body string }
+	if message.Text != "" {
+		parts = append(parts, struct{ contentType, body string }{"text/plain; charset=UTF-8", message.Text})
+	}
+	if message.HTML != "" {
+		parts = append(parts, struct{ contentType, body string }{"text/html; charset=UTF-8", message.HTML})
+	}
+	if len(parts) == 0 {
+		return nil, fmt.Errorf("email has no body")
+	}
+
+	if len(parts) == 1 {
+		header("Content-Type", parts[0].contentType)
+		header("Content-Transfer-Encoding", "quoted-printable")
+		buf.WriteString("\r\n")
+		if err := writeQuotedPrintable(&buf, parts[0].body); err != nil {
+			return nil, err
+		}
+		return buf.Bytes(), nil
+	}
+
+	writer := multipart.NewWriter(&buf)
+	header("Content-Type", `multipart/alternative; boundary="`+writer.Boundary()+`"`)
+	buf.WriteString("\r\n")
+	for _, part := range parts {
+		partWriter, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode email: %w", err)
+		}
+		if err := writeQuotedPrintable(partWriter, part.body); err != nil {
+			return nil, err
+		}
+	}
+	if err := writer.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode email: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+func writeQuotedPrintable(w interface{ Write([]byte) (int, error) }, body string) error {
+	encoder := quotedprintable.NewWriter(w)
+	if _, err := encoder.Write([]byte(body)); err != nil {
+		return fmt.Errorf("failed to encode email: %w", err)
+	}
+	if err := encoder.Close(); err != nil {
+		return fmt.Errorf("failed to encode email: %w", err)
+	}
+	return nil
+}
+
+// domainOf returns the domain of an email address
+func domainOf(address string) string {
+	if at := strings.LastIndex(address, "@"); at != -1 {
+		return address[at+1:]
+	}
+	return "localhost"
+}
@@ -0,0 +1,146 @@
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/cache"
+	"event-ticketing-platform/internal/models"
+)
+
+// Cache settings for content snippets, which are read on every page
+const (
+	snippetCacheKey = "snippets:all"
+	snippetCacheTTL = 5 * time.Minute
+)
+
+// SnippetRepository defines the data operations for content snippets
+type SnippetRepository interface {
+	GetAll() ([]*models.ContentSnippet, error)
+	Upsert(req *models.SnippetUpdateRequest) error
+	Delete(key models.SnippetKey) error
+}
+
+// SnippetService manages the admin-editable copy shown on pages and in
+// emails, falling back to each snippet's default until an admin changes it
+type SnippetService struct {
+	repo         SnippetRepository
+	cache        cache.Cache
+	auditService *AuditService
+}
+
+// NewSnippetService creates a new content snippet service
+func NewSnippetService(repo SnippetRepository) *SnippetService {
+	return &SnippetService{repo: repo}
+}
+
+// SetCache enables caching of content snippets
+func (s *SnippetService) SetCache(c cache.Cache) {
+	s.cache = c
+}
+
+// SetAuditService records snippet changes in the audit log
+func (s *SnippetService) SetAuditService(auditService *AuditService) {
+	s.auditService = auditService
+}
+
+// GetSaved returns the snippets an admin has saved, by key
+func (s *SnippetService) GetSaved() (map[models.SnippetKey]*models.ContentSnippet, error) {
+	snippets, err := s.repo.GetAll()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get content snippets: %w", err)
+	}
+
+	saved := make(map[models.SnippetKey]*models.ContentSnippet, len(snippets))
+	for _, snippet := range snippets {
+		saved[snippet.Key] = snippet
+	}
+	return saved, nil
+}
+
+// All returns the current content of every snippet, by key. It never fails:
+// if the snippets cannot be loaded their defaults are used.
+func (s *SnippetService) All() map[models.SnippetKey]string {
+	contents, err := cache.Remember(s.cache, snippetCacheKey, snippetCacheTTL, func() (map[models.SnippetKey]string, error) {
+		saved, err := s.GetSaved()
+		if err != nil {
+			return nil, err
+		}
+
+		contents := make(map[models.SnippetKey]string, len(models.SnippetDefinitions))
+		for _, definition := range models.SnippetDefinitions {
+			contents[definition.Key] = definition.Default
+			if snippet, ok := saved[definition.Key]; ok {
+				contents[definition.Key] = snippet.Content
+			}
+		}
+		return contents, nil
+	})
+	if err != nil {
+		fmt.Printf("Warning: failed to load content snippets, using defaults: %v\n", err)
+		contents = make(map[models.SnippetKey]string, len(models.SnippetDefinitions))
+		for _, definition := range models.SnippetDefinitions {
+			contents[definition.Key] = definition.Default
+		}
+	}
+	return contents
+}
+
+// Get returns the current content of a snippet
+func (s *SnippetService) Get(key models.SnippetKey) string {
+	return s.All()[key]
+}
+
+// UpdateSnippet saves an admin's change to a snippet, recording it in the
+// audit log
+func (s *SnippetService) UpdateSnippet(req *models.SnippetUpdateRequest, r *http.Request) error {
+	req.Content = strings.TrimSpace(strings.ReplaceAll(req.Content, "\r\n", "\n"))
+	if err := req.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.repo.Upsert(req); err != nil {
+		return err
+	}
+	s.invalidateCache()
+
+	s.logChange(req.UpdatedBy, req.Key, map[string]interface{}{"key": req.Key, "content": req.Content}, r)
+	return nil
+}
+
+// ResetSnippet removes an admin's change to a snippet so its default is used again
+func (s *SnippetService) ResetSnippet(key models.SnippetKey, adminID int, r *http.Request) error {
+	if _, ok := models.LookupSnippet(key); !ok {
+		return fmt.Errorf("unknown snippet %q", key)
+	}
+
+	if err := s.repo.Delete(key); err != nil {
+		return err
+	}
+	s.invalidateCache()
+
+	s.logChange(adminID, key, map[string]interface{}{"key": key, "reset": true}, r)
+	return nil
+}
+
+// invalidateCache drops the cached snippets so changes show straight away
+func (s *SnippetService) invalidateCache() {
+	if s.cache == nil {
+		return
+	}
+	if err := s.cache.Delete(snippetCacheKey); err != nil {
+		fmt.Printf("Warning: failed to invalidate snippet cache: %v\n", err)
+	}
+}
+
+// logChange records a snippet change in the audit log
+func (s *SnippetService) logChange(adminID int, key models.SnippetKey, details map[string]interface{}, r *http.Request) {
+	if s.auditService == nil || r == nil {
+		return
+	}
+	if err := s.auditService.LogAction(adminID, models.AuditActionSnippetUpdate, models.AuditTargetSnippet, 0, details, r); err != nil {
+		fmt.Printf("Warning: failed to log change to snippet %s: %v\n", key, err)
+	}
+}
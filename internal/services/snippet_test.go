@@ -0,0 +1,98 @@
+package services
+
+import (
+	"errors"
+	"testing"
+
+	"event-ticketing-platform/internal/cache"
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock SnippetRepository for testing
+type mockSnippetRepository struct {
+	snippets map[models.SnippetKey]*models.ContentSnippet
+	err      error
+	reads    int
+}
+
+func newMockSnippetRepository() *mockSnippetRepository {
+	return &mockSnippetRepository{snippets: make(map[models.SnippetKey]*models.ContentSnippet)}
+}
+
+func (m *mockSnippetRepository) GetAll() ([]*models.ContentSnippet, error) {
+	m.reads++
+	if m.err != nil {
+		return nil, m.err
+	}
+	var result []*models.ContentSnippet
+	for _, snippet := range m.snippets {
+		result = append(result, snippet)
+	}
+	return result, nil
+}
+
+func (m *mockSnippetRepository) Upsert(req *models.SnippetUpdateRequest) error {
+	updatedBy := req.UpdatedBy
+	m.snippets[req.Key] = &models.ContentSnippet{Key: req.Key, Content: req.Content, UpdatedBy: &updatedBy}
+	return nil
+}
+
+func (m *mockSnippetRepository) Delete(key models.SnippetKey) error {
+	delete(m.snippets, key)
+	return nil
+}
+
+func TestSnippetService_All(t *testing.T) {
+	repo := newMockSnippetRepository()
+	service := NewSnippetService(repo)
+	service.SetCache(cache.NewMemoryCache())
+
+	refundPolicy, _ := models.LookupSnippet(models.SnippetRefundPolicy)
+	if got := service.Get(models.SnippetRefundPolicy); got != refundPolicy.Default {
+		t.Errorf("expected default refund policy, got %q", got)
+	}
+
+	if err := service.UpdateSnippet(&models.SnippetUpdateRequest{Key: models.SnippetRefundPolicy, Content: "  No refunds.\r\nSorry.  ", UpdatedBy: 1}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := service.Get(models.SnippetRefundPolicy); got != "No refunds.\nSorry." {
+		t.Errorf("expected the saved refund policy straight after saving, got %q", got)
+	}
+
+	reads := repo.reads
+	service.All()
+	if repo.reads != reads {
+		t.Error("expected snippets to be cached")
+	}
+
+	if err := service.UpdateSnippet(&models.SnippetUpdateRequest{Key: models.SnippetCheckoutDisclaimer, UpdatedBy: 1}, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got, ok := service.All()[models.SnippetCheckoutDisclaimer]; !ok || got != "" {
+		t.Errorf("expected an emptied snippet to stay empty, got %q", got)
+	}
+
+	if err := service.ResetSnippet(models.SnippetRefundPolicy, 1, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := service.Get(models.SnippetRefundPolicy); got != refundPolicy.Default {
+		t.Errorf("expected reset refund policy to use its default, got %q", got)
+	}
+
+	if err := service.UpdateSnippet(&models.SnippetUpdateRequest{Key: "banner", Content: "Hello", UpdatedBy: 1}, nil); err == nil {
+		t.Error("expected unknown snippet to be rejected")
+	}
+}
+
+func TestSnippetService_AllFallsBackToDefaults(t *testing.T) {
+	repo := newMockSnippetRepository()
+	repo.err = errors.New("connection refused")
+	service := NewSnippetService(repo)
+
+	snippets := service.All()
+	for _, definition := range models.SnippetDefinitions {
+		if snippets[definition.Key] != definition.Default {
+			t.Errorf("expected default for %s, got %q", definition.Key, snippets[definition.Key])
+		}
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"io"
 	"time"
+
+	"event-ticketing-platform/internal/models"
 )
 
 // StorageService defines the interface for file storage operations
@@ -24,6 +26,31 @@ type StorageService interface {
 	Exists(ctx context.Context, key string) (bool, error)
 }
 
+// StoredObject describes an object held in storage
+type StoredObject struct {
+	Key          string    `json:"key"`
+	Size         int64     `json:"size"`
+	LastModified time.Time `json:"last_modified"`
+}
+
+// ObjectLister is implemented by storage backends that can enumerate their objects
+type ObjectLister interface {
+	// List returns every object whose key starts with prefix
+	List(ctx context.Context, prefix string) ([]StoredObject, error)
+}
+
+// ObjectMover is implemented by storage backends that can move objects between keys
+type ObjectMover interface {
+	// Move moves an object to a new key, replacing anything already there
+	Move(ctx context.Context, fromKey, toKey string) error
+}
+
+// ObjectReader is implemented by storage backends that can read objects back
+type ObjectReader interface {
+	// Open returns the contents of an object; the caller must close it
+	Open(ctx context.Context, key string) (io.ReadCloser, error)
+}
+
 // ImageMetadata contains metadata about uploaded images
 type ImageMetadata struct {
 	Key         string    `json:"key"`
@@ -37,7 +64,8 @@ type ImageMetadata struct {
 
 // ImageVariant represents different sizes of the same image
 type ImageVariant struct {
-	Name   string `json:"name"`   // thumbnail, medium, large
+	Name   string `json:"name"`   // thumbnail, card, hero, og
+	Format string `json:"format"` // jpeg, webp, avif
 	Width  int    `json:"width"`
 	Height int    `json:"height"`
 	Key    string `json:"key"`
@@ -50,11 +78,28 @@ type ImageUploadResult struct {
 	Variants []ImageVariant  `json:"variants"`
 }
 
+// EventImageVariants returns the variants to store with an event
+func (r *ImageUploadResult) EventImageVariants() models.EventImageVariants {
+	variants := make(models.EventImageVariants, len(r.Variants))
+	for i, variant := range r.Variants {
+		variants[i] = models.EventImageVariant{
+			Name:   variant.Name,
+			Format: variant.Format,
+			Width:  variant.Width,
+			Height: variant.Height,
+			Key:    variant.Key,
+			URL:    variant.URL,
+		}
+	}
+	return variants
+}
+
 // ImageProcessingOptions defines options for image processing
 type ImageProcessingOptions struct {
 	Quality         int      // JPEG quality (1-100)
 	SupportedFormats []string // Supported formats by client (from Accept header)
 	EnableWebP      bool     // Whether to generate WebP variants
+	EnableAVIF      bool     // Whether to generate AVIF variants
 	CompressionLevel int     // PNG compression level (0-9)
 	CropData        *CropData // Optional crop information
 }
@@ -0,0 +1,237 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Storage key prefixes scanned and used by garbage collection
+const (
+	storageGCImagePrefix      = "events/"
+	storageGCQuarantinePrefix = "quarantine/"
+)
+
+// StorageGCRepositoryInterface defines the storage garbage collection data operations
+type StorageGCRepositoryInterface interface {
+	GetImageReferences() ([]string, error)
+	CreateRun(run *models.StorageGCRun) error
+	GetLatestRun() (*models.StorageGCRun, error)
+	GetTotals() (int64, int, error)
+}
+
+// StorageGCOptions controls how orphaned uploads are collected
+type StorageGCOptions struct {
+	// GracePeriod keeps recent uploads, which may belong to an event form that hasn't been saved yet
+	GracePeriod time.Duration
+	// Quarantine moves orphans under quarantine/ instead of deleting them
+	Quarantine bool
+	// QuarantineRetention is how long quarantined objects are kept before deletion
+	QuarantineRetention time.Duration
+}
+
+// StorageGCResult is the outcome of a garbage collection pass
+type StorageGCResult struct {
+	Run     *models.StorageGCRun
+	Orphans []StoredObject
+}
+
+// StorageGCService deletes or quarantines uploaded images that no event references
+type StorageGCService struct {
+	storage StorageService
+	repo    StorageGCRepositoryInterface
+	options StorageGCOptions
+	now     func() time.Time
+}
+
+// NewStorageGCService creates a new storage garbage collection service
+func NewStorageGCService(storage StorageService, repo StorageGCRepositoryInterface, options StorageGCOptions) *StorageGCService {
+	return &StorageGCService{
+		storage: storage,
+		repo:    repo,
+		options: options,
+		now:     time.Now,
+	}
+}
+
+// Mode returns the configured garbage collection mode
+func (s *StorageGCService) Mode() string {
+	if s.options.Quarantine {
+		return models.StorageGCModeQuarantine
+	}
+	return models.StorageGCModeDelete
+}
+
+// FindOrphans lists uploaded images that no event references and that are
+// older than the grace period, without changing anything
+func (s *StorageGCService) FindOrphans(ctx context.Context) (*StorageGCResult, error) {
+	return s.collect(ctx, true)
+}
+
+// Run deletes or quarantines orphaned uploads, purges expired quarantined
+// objects and records the run
+func (s *StorageGCService) Run(ctx context.Context) (*StorageGCResult, error) {
+	result, err := s.collect(ctx, false)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.CreateRun(result.Run); err != nil {
+		return nil, err
+	}
+
+	return result, nil
+}
+
+// GetSummary returns the latest run and totals for the admin settings page
+func (s *StorageGCService) GetSummary() (*models.StorageGCSummary, error) {
+	lastRun, err := s.repo.GetLatestRun()
+	if err != nil {
+		return nil, err
+	}
+
+	reclaimedBytes, deletedObjects, err := s.repo.GetTotals()
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.StorageGCSummary{
+		LastRun:             lastRun,
+		TotalReclaimedBytes: reclaimedBytes,
+		TotalDeletedObjects: deletedObjects,
+		Mode:                s.Mode(),
+		GracePeriod:         s.options.GracePeriod,
+	}, nil
+}
+
+func (s *StorageGCService) collect(ctx context.Context, dryRun bool) (*StorageGCResult, error) {
+	lister, ok := s.storage.(ObjectLister)
+	if !ok {
+		return nil, fmt.Errorf("storage backend does not support listing objects")
+	}
+
+	mover, canMove := s.storage.(ObjectMover)
+	if s.options.Quarantine && !canMove && !dryRun {
+		return nil, fmt.Errorf("storage backend does not support quarantining objects")
+	}
+
+	run := &models.StorageGCRun{
+		Mode:      s.Mode(),
+		Errors:    []string{},
+		StartedAt: s.now(),
+	}
+
+	references, err := s.repo.GetImageReferences()
+	if err != nil {
+		return nil, err
+	}
+	referenced := newImageReferenceSet(references)
+
+	objects, err := lister.List(ctx, storageGCImagePrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &StorageGCResult{Run: run}
+	for _, object := range objects {
+		run.ScannedObjects++
+		if referenced.contains(object.Key) {
+			continue
+		}
+		if s.now().Sub(object.LastModified) < s.options.GracePeriod {
+			run.SkippedRecent++
+			continue
+		}
+
+		run.OrphanedObjects++
+		result.Orphans = append(result.Orphans, object)
+		if dryRun {
+			continue
+		}
+
+		if s.options.Quarantine {
+			if err := mover.Move(ctx, object.Key, storageGCQuarantinePrefix+object.Key); err != nil {
+				run.Errors = append(run.Errors, fmt.Sprintf("quarantine %s: %v", object.Key, err))
+				continue
+			}
+			run.QuarantinedObjects++
+		} else {
+			if err := s.storage.Delete(ctx, object.Key); err != nil {
+				run.Errors = append(run.Errors, fmt.Sprintf("delete %s: %v", object.Key, err))
+				continue
+			}
+			run.DeletedObjects++
+			run.ReclaimedBytes += object.Size
+		}
+	}
+
+	if !dryRun {
+		s.purgeQuarantine(ctx, lister, run)
+	}
+
+	run.CompletedAt = s.now()
+	return result, nil
+}
+
+// purgeQuarantine deletes quarantined objects older than the retention period
+func (s *StorageGCService) purgeQuarantine(ctx context.Context, lister ObjectLister, run *models.StorageGCRun) {
+	objects, err := lister.List(ctx, storageGCQuarantinePrefix)
+	if err != nil {
+		run.Errors = append(run.Errors, fmt.Sprintf("list quarantine: %v", err))
+		return
+	}
+
+	for _, object := range objects {
+		if s.now().Sub(object.LastModified) < s.options.QuarantineRetention {
+			continue
+		}
+		if err := s.storage.Delete(ctx, object.Key); err != nil {
+			run.Errors = append(run.Errors, fmt.Sprintf("delete %s: %v", object.Key, err))
+			continue
+		}
+		run.DeletedObjects++
+		run.ReclaimedBytes += object.Size
+	}
+}
+
+// imageReferenceSet matches storage keys against the image keys and URLs stored
+// on events. An image is stored as a key prefix holding the original and its
+// variants, so an object is referenced when its key or its directory is.
+type imageReferenceSet struct {
+	keys map[string]bool
+}
+
+func newImageReferenceSet(references []string) *imageReferenceSet {
+	set := &imageReferenceSet{keys: make(map[string]bool)}
+
+	for _, reference := range references {
+		key := reference
+		if parsed, err := url.Parse(reference); err == nil && parsed.Path != "" {
+			key = parsed.Path
+		}
+		// URLs may include a CDN or uploads path before the key
+		if i := strings.Index(key, storageGCImagePrefix); i >= 0 {
+			key = key[i:]
+		}
+		key = strings.TrimPrefix(key, "/")
+		if key == "" {
+			continue
+		}
+
+		set.keys[key] = true
+		if strings.HasPrefix(path.Base(key), "original") {
+			set.keys[path.Dir(key)] = true
+		}
+	}
+
+	return set
+}
+
+func (s *imageReferenceSet) contains(key string) bool {
+	return s.keys[key] || s.keys[path.Dir(key)]
+}
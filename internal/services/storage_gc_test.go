@@ -0,0 +1,199 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// fakeListingStorage is an in-memory storage backend that supports listing and moving
+type fakeListingStorage struct {
+	objects map[string]StoredObject
+}
+
+func (f *fakeListingStorage) Upload(ctx context.Context, key string, reader io.Reader, contentType string, size int64) (string, error) {
+	return f.GetURL(key), nil
+}
+
+func (f *fakeListingStorage) Delete(ctx context.Context, key string) error {
+	delete(f.objects, key)
+	return nil
+}
+
+func (f *fakeListingStorage) GetURL(key string) string {
+	return "https://cdn.example.com/" + key
+}
+
+func (f *fakeListingStorage) GeneratePresignedURL(ctx context.Context, key string, contentType string, expiration time.Duration) (string, error) {
+	return "", fmt.Errorf("not supported")
+}
+
+func (f *fakeListingStorage) Exists(ctx context.Context, key string) (bool, error) {
+	_, ok := f.objects[key]
+	return ok, nil
+}
+
+func (f *fakeListingStorage) List(ctx context.Context, prefix string) ([]StoredObject, error) {
+	var objects []StoredObject
+	for key, object := range f.objects {
+		if strings.HasPrefix(key, prefix) {
+			objects = append(objects, object)
+		}
+	}
+	return objects, nil
+}
+
+func (f *fakeListingStorage) Move(ctx context.Context, fromKey, toKey string) error {
+	object := f.objects[fromKey]
+	delete(f.objects, fromKey)
+	object.Key = toKey
+	object.LastModified = time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+	f.objects[toKey] = object
+	return nil
+}
+
+// Mock StorageGCRepository for testing
+type mockStorageGCRepository struct {
+	references []string
+	runs       []*models.StorageGCRun
+}
+
+func (m *mockStorageGCRepository) GetImageReferences() ([]string, error) {
+	return m.references, nil
+}
+
+func (m *mockStorageGCRepository) CreateRun(run *models.StorageGCRun) error {
+	run.ID = len(m.runs) + 1
+	m.runs = append(m.runs, run)
+	return nil
+}
+
+func (m *mockStorageGCRepository) GetLatestRun() (*models.StorageGCRun, error) {
+	if len(m.runs) == 0 {
+		return nil, nil
+	}
+	return m.runs[len(m.runs)-1], nil
+}
+
+func (m *mockStorageGCRepository) GetTotals() (int64, int, error) {
+	var reclaimed int64
+	var deleted int
+	for _, run := range m.runs {
+		reclaimed += run.ReclaimedBytes
+		deleted += run.DeletedObjects
+	}
+	return reclaimed, deleted, nil
+}
+
+func setupStorageGC(quarantine bool) (*StorageGCService, *fakeListingStorage, *mockStorageGCRepository) {
+	now := time.Date(2025, 6, 10, 0, 0, 0, 0, time.UTC)
+	old := now.Add(-72 * time.Hour)
+
+	storage := &fakeListingStorage{objects: map[string]StoredObject{}}
+	for key, modified := range map[string]time.Time{
+		"events/2025/06/01/jazz-1a2b3c4d/original.jpeg":  old,
+		"events/2025/06/01/jazz-1a2b3c4d/thumbnail.webp": old,
+		"events/2025/06/02/rock-5e6f7a8b/original.png":   old,
+		"events/2025/06/03/stale-0a0b0c0d/original.png":  old,
+		"events/2025/06/03/stale-0a0b0c0d/medium.png":    old,
+		"events/2025/06/09/fresh-9a9b9c9d/original.png":  now.Add(-time.Hour),
+		"quarantine/events/2025/04/01/old/original.png":  now.Add(-40 * 24 * time.Hour),
+		"quarantine/events/2025/06/05/recent/medium.png": now.Add(-5 * 24 * time.Hour),
+	} {
+		storage.objects[key] = StoredObject{Key: key, Size: 1000, LastModified: modified}
+	}
+
+	repo := &mockStorageGCRepository{references: []string{
+		"events/2025/06/01/jazz-1a2b3c4d",
+		"https://cdn.example.com/events/2025/06/02/rock-5e6f7a8b/original.png",
+	}}
+
+	service := NewStorageGCService(storage, repo, StorageGCOptions{
+		GracePeriod:         48 * time.Hour,
+		Quarantine:          quarantine,
+		QuarantineRetention: 30 * 24 * time.Hour,
+	})
+	service.now = func() time.Time { return now }
+
+	return service, storage, repo
+}
+
+func TestStorageGCService_FindOrphans(t *testing.T) {
+	service, storage, repo := setupStorageGC(false)
+
+	result, err := service.FindOrphans(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if len(result.Orphans) != 2 {
+		t.Fatalf("expected 2 orphans, got %d: %+v", len(result.Orphans), result.Orphans)
+	}
+	for _, orphan := range result.Orphans {
+		if !strings.HasPrefix(orphan.Key, "events/2025/06/03/stale-0a0b0c0d/") {
+			t.Errorf("unexpected orphan %s", orphan.Key)
+		}
+	}
+	if result.Run.SkippedRecent != 1 {
+		t.Errorf("expected the fresh upload to be skipped, got %d", result.Run.SkippedRecent)
+	}
+	if len(storage.objects) != 8 || len(repo.runs) != 0 {
+		t.Error("expected a dry run not to change storage or record a run")
+	}
+}
+
+func TestStorageGCService_Run_Delete(t *testing.T) {
+	service, storage, repo := setupStorageGC(false)
+
+	result, err := service.Run(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Two orphans plus the expired quarantined object
+	if result.Run.DeletedObjects != 3 || result.Run.ReclaimedBytes != 3000 {
+		t.Errorf("expected 3 objects and 3000 bytes reclaimed, got %d and %d", result.Run.DeletedObjects, result.Run.ReclaimedBytes)
+	}
+	if _, ok := storage.objects["events/2025/06/03/stale-0a0b0c0d/original.png"]; ok {
+		t.Error("expected orphan to be deleted")
+	}
+	if _, ok := storage.objects["events/2025/06/01/jazz-1a2b3c4d/thumbnail.webp"]; !ok {
+		t.Error("expected variant of a referenced image to be kept")
+	}
+	if _, ok := storage.objects["quarantine/events/2025/06/05/recent/medium.png"]; !ok {
+		t.Error("expected recently quarantined object to be kept")
+	}
+
+	summary, err := service.GetSummary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if summary.LastRun != repo.runs[0] || summary.TotalReclaimedBytes != 3000 || summary.Mode != models.StorageGCModeDelete {
+		t.Errorf("unexpected summary: %+v", summary)
+	}
+}
+
+func TestStorageGCService_Run_Quarantine(t *testing.T) {
+	service, storage, _ := setupStorageGC(true)
+
+	result, err := service.Run(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result.Run.QuarantinedObjects != 2 {
+		t.Errorf("expected 2 quarantined objects, got %d", result.Run.QuarantinedObjects)
+	}
+	if _, ok := storage.objects["quarantine/events/2025/06/03/stale-0a0b0c0d/medium.png"]; !ok {
+		t.Error("expected orphan to be moved to quarantine")
+	}
+	// Only the expired quarantined object frees space; quarantined orphans still take space
+	if result.Run.ReclaimedBytes != 1000 {
+		t.Errorf("expected 1000 bytes reclaimed, got %d", result.Run.ReclaimedBytes)
+	}
+}
@@ -0,0 +1,302 @@
+package services
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"mime"
+	"net/url"
+	"path"
+	"sort"
+	"strings"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// StorageMigrationRepositoryInterface defines the storage migration data operations
+type StorageMigrationRepositoryInterface interface {
+	GetItem(key string) (*models.StorageMigrationItem, error)
+	SaveItem(item *models.StorageMigrationItem) error
+	GetVerifiedKeys() ([]string, error)
+	GetEventImages() ([]*models.EventImageReference, error)
+	RewriteEventImages(rewrites []*models.EventImageRewrite) (int, error)
+}
+
+// StorageMigrationSource is the storage uploads are migrated from
+type StorageMigrationSource interface {
+	ObjectLister
+	ObjectReader
+}
+
+// StorageMigrationDestination is the storage uploads are migrated to. It must
+// be readable so every copy can be checksummed after upload.
+type StorageMigrationDestination interface {
+	StorageService
+	ObjectReader
+}
+
+// StorageMigrationOptions controls a storage migration run
+type StorageMigrationOptions struct {
+	// DryRun reports what would be migrated without uploading or changing events
+	DryRun bool
+	// SourceURLs are the URL prefixes local uploads were served from, such as
+	// "/uploads" and "http://localhost:8080/uploads"
+	SourceURLs []string
+}
+
+// StorageMigrationService copies local uploads to R2 and points events at the
+// copies. Every copy is verified by checksum and recorded, so an interrupted
+// migration resumes without uploading verified files again.
+type StorageMigrationService struct {
+	source      StorageMigrationSource
+	destination StorageMigrationDestination
+	repo        StorageMigrationRepositoryInterface
+	options     StorageMigrationOptions
+}
+
+// NewStorageMigrationService creates a new storage migration service
+func NewStorageMigrationService(source StorageMigrationSource, destination StorageMigrationDestination, repo StorageMigrationRepositoryInterface, options StorageMigrationOptions) *StorageMigrationService {
+	return &StorageMigrationService{
+		source:      source,
+		destination: destination,
+		repo:        repo,
+		options:     options,
+	}
+}
+
+// Migrate uploads every local file that hasn't been verified yet, then rewrites
+// the image URLs and keys of events whose images are all safely in R2
+func (s *StorageMigrationService) Migrate(ctx context.Context) (*models.StorageMigrationReport, error) {
+	report := &models.StorageMigrationReport{DryRun: s.options.DryRun}
+
+	objects, err := s.source.List(ctx, "")
+	if err != nil {
+		return nil, fmt.Errorf("failed to list local uploads: %w", err)
+	}
+	sort.Slice(objects, func(i, j int) bool { return objects[i].Key < objects[j].Key })
+
+	verifiedKeys, err := s.repo.GetVerifiedKeys()
+	if err != nil {
+		return nil, err
+	}
+	migrated := make(map[string]bool, len(verifiedKeys))
+	for _, key := range verifiedKeys {
+		migrated[key] = true
+	}
+	failed := make(map[string]bool)
+
+	for _, object := range objects {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		// Quarantined orphans are waiting to be deleted, not migrated
+		if strings.HasPrefix(object.Key, storageGCQuarantinePrefix) {
+			continue
+		}
+		report.ScannedObjects++
+
+		if err := s.migrateObject(ctx, object, report); err != nil {
+			report.FailedObjects++
+			report.Errors = append(report.Errors, fmt.Sprintf("%s: %v", object.Key, err))
+			delete(migrated, object.Key)
+			failed[object.Key] = true
+			continue
+		}
+		migrated[object.Key] = true
+	}
+
+	if err := s.rewriteEventImages(migrated, failed, report); err != nil {
+		return report, err
+	}
+
+	return report, nil
+}
+
+// migrateObject uploads a single file unless an identical copy was already verified
+func (s *StorageMigrationService) migrateObject(ctx context.Context, object StoredObject, report *models.StorageMigrationReport) error {
+	checksum, size, err := objectChecksum(ctx, s.source, object.Key)
+	if err != nil {
+		return fmt.Errorf("failed to checksum local file: %w", err)
+	}
+
+	item, err := s.repo.GetItem(object.Key)
+	if err != nil {
+		return err
+	}
+	if item != nil && item.Status == models.StorageMigrationVerified && item.Checksum == checksum && item.Size == size {
+		report.ResumedObjects++
+		return nil
+	}
+
+	if s.options.DryRun {
+		report.UploadedObjects++
+		report.UploadedBytes += size
+		return nil
+	}
+
+	destinationURL, uploadErr := s.upload(ctx, object.Key, size, checksum)
+
+	item = &models.StorageMigrationItem{
+		Key:            object.Key,
+		Size:           size,
+		Checksum:       checksum,
+		DestinationURL: destinationURL,
+		Status:         models.StorageMigrationVerified,
+	}
+	if uploadErr != nil {
+		item.Status = models.StorageMigrationFailed
+		item.Error = uploadErr.Error()
+	}
+	if err := s.repo.SaveItem(item); err != nil {
+		if uploadErr != nil {
+			return uploadErr
+		}
+		return err
+	}
+	if uploadErr != nil {
+		return uploadErr
+	}
+
+	report.UploadedObjects++
+	report.UploadedBytes += size
+	return nil
+}
+
+// upload copies a file to the destination and checks the copy against the local checksum
+func (s *StorageMigrationService) upload(ctx context.Context, key string, size int64, checksum string) (string, error) {
+	reader, err := s.source.Open(ctx, key)
+	if err != nil {
+		return "", err
+	}
+	defer reader.Close()
+
+	contentType := mime.TypeByExtension(path.Ext(key))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	destinationURL, err := s.destination.Upload(ctx, key, reader, contentType, size)
+	if err != nil {
+		return "", err
+	}
+
+	uploadedChecksum, uploadedSize, err := objectChecksum(ctx, s.destination, key)
+	if err != nil {
+		return destinationURL, fmt.Errorf("failed to checksum uploaded copy: %w", err)
+	}
+	if uploadedChecksum != checksum || uploadedSize != size {
+		return destinationURL, fmt.Errorf("checksum mismatch: local %s (%d bytes), uploaded %s (%d bytes)", checksum, size, uploadedChecksum, uploadedSize)
+	}
+
+	return destinationURL, nil
+}
+
+// rewriteEventImages points events with local images at their migrated copies
+func (s *StorageMigrationService) rewriteEventImages(migrated, failed map[string]bool, report *models.StorageMigrationReport) error {
+	images, err := s.repo.GetEventImages()
+	if err != nil {
+		return err
+	}
+
+	var rewrites []*models.EventImageRewrite
+	for _, image := range images {
+		urlKey, ok := s.localKey(image.ImageURL)
+		if !ok {
+			continue
+		}
+
+		imageKey := strings.TrimPrefix(image.ImageKey, "/")
+		if imageKey == "" {
+			imageKey = urlKey
+		}
+
+		if !migrated[urlKey] || !keyMigrated(migrated, failed, imageKey) {
+			report.EventsUnresolved++
+			report.Errors = append(report.Errors, fmt.Sprintf("event %d: image %s has not been migrated", image.EventID, image.ImageURL))
+			continue
+		}
+
+		rewrites = append(rewrites, &models.EventImageRewrite{
+			EventID:     image.EventID,
+			PreviousURL: image.ImageURL,
+			ImageURL:    s.destination.GetURL(urlKey),
+			ImageKey:    imageKey,
+		})
+	}
+
+	if len(rewrites) == 0 {
+		return nil
+	}
+	if s.options.DryRun {
+		report.EventsRewritten = len(rewrites)
+		return nil
+	}
+
+	updated, err := s.repo.RewriteEventImages(rewrites)
+	if err != nil {
+		return err
+	}
+	report.EventsRewritten = updated
+
+	return nil
+}
+
+// localKey returns the storage key of an image URL served from local uploads
+func (s *StorageMigrationService) localKey(imageURL string) (string, bool) {
+	for _, prefix := range s.options.SourceURLs {
+		prefix = strings.TrimSuffix(prefix, "/") + "/"
+		if !strings.HasPrefix(imageURL, prefix) {
+			continue
+		}
+
+		key := strings.TrimPrefix(imageURL, prefix)
+		if i := strings.IndexAny(key, "?#"); i >= 0 {
+			key = key[:i]
+		}
+		if unescaped, err := url.PathUnescape(key); err == nil {
+			key = unescaped
+		}
+		return key, key != ""
+	}
+
+	return "", false
+}
+
+// keyMigrated reports whether a key, or every file under it when the key is
+// an image directory holding the original and its variants, has been migrated
+func keyMigrated(migrated, failed map[string]bool, key string) bool {
+	if migrated[key] {
+		return true
+	}
+
+	for failedKey := range failed {
+		if strings.HasPrefix(failedKey, key+"/") {
+			return false
+		}
+	}
+	for migratedKey := range migrated {
+		if strings.HasPrefix(migratedKey, key+"/") {
+			return true
+		}
+	}
+	return false
+}
+
+// objectChecksum returns the SHA-256 checksum and size of a stored object
+func objectChecksum(ctx context.Context, storage ObjectReader, key string) (string, int64, error) {
+	reader, err := storage.Open(ctx, key)
+	if err != nil {
+		return "", 0, err
+	}
+	defer reader.Close()
+
+	hash := sha256.New()
+	size, err := io.Copy(hash, reader)
+	if err != nil {
+		return "", 0, fmt.Errorf("failed to read %s: %w", key, err)
+	}
+
+	return hex.EncodeToString(hash.Sum(nil)), size, nil
+}
@@ -0,0 +1,247 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// fakeBlobStorage is an in-memory storage backend that keeps object contents
+type fakeBlobStorage struct {
+	baseURL string
+	blobs   map[string][]byte
+	uploads int
+	// corrupt truncates uploaded contents, as an interrupted upload would
+	corrupt bool
+}
+
+func newFakeBlobStorage(baseURL string) *fakeBlobStorage {
+	return &fakeBlobStorage{baseURL: baseURL, blobs: make(map[string][]byte)}
+}
+
+func (f *fakeBlobStorage) Upload(ctx context.Context, key string, reader io.Reader, contentType string, size int64) (string, error) {
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return "", err
+	}
+	if f.corrupt {
+		data = data[:len(data)/2]
+	}
+	f.blobs[key] = data
+	f.uploads++
+	return f.GetURL(key), nil
+}
+
+func (f *fakeBlobStorage) Delete(ctx context.Context, key string) error {
+	delete(f.blobs, key)
+	return nil
+}
+
+func (f *fakeBlobStorage) GetURL(key string) string {
+	return f.baseURL + "/" + key
+}
+
+func (f *fakeBlobStorage) GeneratePresignedURL(ctx context.Context, key string, contentType string, expiration time.Duration) (string, error) {
+	return "", fmt.Errorf("not supported")
+}
+
+func (f *fakeBlobStorage) Exists(ctx context.Context, key string) (bool, error) {
+	_, ok := f.blobs[key]
+	return ok, nil
+}
+
+func (f *fakeBlobStorage) List(ctx context.Context, prefix string) ([]StoredObject, error) {
+	var objects []StoredObject
+	for key, data := range f.blobs {
+		if strings.HasPrefix(key, prefix) {
+			objects = append(objects, StoredObject{Key: key, Size: int64(len(data))})
+		}
+	}
+	return objects, nil
+}
+
+func (f *fakeBlobStorage) Open(ctx context.Context, key string) (io.ReadCloser, error) {
+	data, ok := f.blobs[key]
+	if !ok {
+		return nil, fmt.Errorf("not found: %s", key)
+	}
+	return io.NopCloser(bytes.NewReader(data)), nil
+}
+
+// Mock StorageMigrationRepository for testing
+type mockStorageMigrationRepository struct {
+	items    map[string]*models.StorageMigrationItem
+	images   []*models.EventImageReference
+	rewrites []*models.EventImageRewrite
+}
+
+func (m *mockStorageMigrationRepository) GetItem(key string) (*models.StorageMigrationItem, error) {
+	return m.items[key], nil
+}
+
+func (m *mockStorageMigrationRepository) SaveItem(item *models.StorageMigrationItem) error {
+	m.items[item.Key] = item
+	return nil
+}
+
+func (m *mockStorageMigrationRepository) GetVerifiedKeys() ([]string, error) {
+	var keys []string
+	for key, item := range m.items {
+		if item.Status == models.StorageMigrationVerified {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
+
+func (m *mockStorageMigrationRepository) GetEventImages() ([]*models.EventImageReference, error) {
+	return m.images, nil
+}
+
+func (m *mockStorageMigrationRepository) RewriteEventImages(rewrites []*models.EventImageRewrite) (int, error) {
+	m.rewrites = append(m.rewrites, rewrites...)
+	for _, rewrite := range rewrites {
+		for _, image := range m.images {
+			if image.EventID == rewrite.EventID && image.ImageURL == rewrite.PreviousURL {
+				image.ImageURL = rewrite.ImageURL
+				image.ImageKey = rewrite.ImageKey
+			}
+		}
+	}
+	return len(rewrites), nil
+}
+
+func setupStorageMigrationService(dryRun bool) (*StorageMigrationService, *fakeBlobStorage, *mockStorageMigrationRepository) {
+	source := newFakeBlobStorage("http://localhost:8080/uploads")
+	source.blobs["events/legacy.jpg"] = []byte("legacy image")
+	source.blobs["events/7/original.jpg"] = []byte("original image")
+	source.blobs["events/7/thumbnail.jpg"] = []byte("thumbnail image")
+	source.blobs["quarantine/events/old.jpg"] = []byte("orphan")
+
+	repo := &mockStorageMigrationRepository{
+		items: make(map[string]*models.StorageMigrationItem),
+		images: []*models.EventImageReference{
+			{EventID: 1, ImageURL: "/uploads/events/legacy.jpg", ImageKey: "events/legacy.jpg"},
+			{EventID: 2, ImageURL: "http://localhost:8080/uploads/events/7/original.jpg", ImageKey: "events/7"},
+			{EventID: 3, ImageURL: "https://cdn.example.com/events/9/original.jpg", ImageKey: "events/9"},
+			{EventID: 4, ImageURL: "/uploads/events/missing.jpg"},
+		},
+	}
+
+	destination := newFakeBlobStorage("https://cdn.example.com")
+	service := NewStorageMigrationService(source, destination, repo, StorageMigrationOptions{
+		DryRun:     dryRun,
+		SourceURLs: []string{"/uploads", "http://localhost:8080/uploads/"},
+	})
+	return service, destination, repo
+}
+
+func TestStorageMigrationService_Migrate(t *testing.T) {
+	service, destination, repo := setupStorageMigrationService(false)
+
+	report, err := service.Migrate(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.UploadedObjects != 3 || report.ScannedObjects != 3 {
+		t.Errorf("expected 3 scanned and uploaded objects, got %d and %d", report.ScannedObjects, report.UploadedObjects)
+	}
+	if _, ok := destination.blobs["quarantine/events/old.jpg"]; ok {
+		t.Error("expected quarantined files not to be migrated")
+	}
+	if string(destination.blobs["events/7/original.jpg"]) != "original image" {
+		t.Errorf("unexpected uploaded contents: %q", destination.blobs["events/7/original.jpg"])
+	}
+
+	if report.EventsRewritten != 2 || report.EventsUnresolved != 1 {
+		t.Errorf("expected 2 rewritten and 1 unresolved events, got %d and %d", report.EventsRewritten, report.EventsUnresolved)
+	}
+	if repo.images[0].ImageURL != "https://cdn.example.com/events/legacy.jpg" {
+		t.Errorf("unexpected rewritten URL: %s", repo.images[0].ImageURL)
+	}
+	if repo.images[1].ImageURL != "https://cdn.example.com/events/7/original.jpg" || repo.images[1].ImageKey != "events/7" {
+		t.Errorf("unexpected rewritten image: %+v", repo.images[1])
+	}
+	if repo.images[3].ImageURL != "/uploads/events/missing.jpg" {
+		t.Errorf("expected missing image to be left alone, got %s", repo.images[3].ImageURL)
+	}
+}
+
+func TestStorageMigrationService_Migrate_Resume(t *testing.T) {
+	service, destination, repo := setupStorageMigrationService(false)
+
+	if _, err := service.Migrate(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	uploads := destination.uploads
+
+	report, err := service.Migrate(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if destination.uploads != uploads {
+		t.Errorf("expected verified files not to be uploaded again, got %d more uploads", destination.uploads-uploads)
+	}
+	if report.ResumedObjects != 3 || report.UploadedObjects != 0 {
+		t.Errorf("expected 3 resumed objects, got %d resumed and %d uploaded", report.ResumedObjects, report.UploadedObjects)
+	}
+	if report.EventsRewritten != 0 {
+		t.Errorf("expected already rewritten events to be skipped, got %d", report.EventsRewritten)
+	}
+	if len(repo.rewrites) != 2 {
+		t.Errorf("expected 2 rewrites in total, got %d", len(repo.rewrites))
+	}
+}
+
+func TestStorageMigrationService_Migrate_ChecksumMismatch(t *testing.T) {
+	service, destination, repo := setupStorageMigrationService(false)
+	destination.corrupt = true
+
+	report, err := service.Migrate(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.FailedObjects != 3 || report.UploadedObjects != 0 {
+		t.Errorf("expected every upload to fail verification, got %d failed and %d uploaded", report.FailedObjects, report.UploadedObjects)
+	}
+	if item := repo.items["events/legacy.jpg"]; item == nil || item.Status != models.StorageMigrationFailed || !strings.Contains(item.Error, "checksum mismatch") {
+		t.Errorf("expected a failed item recording the mismatch, got %+v", item)
+	}
+	if len(repo.rewrites) != 0 {
+		t.Errorf("expected no events to be rewritten, got %d", len(repo.rewrites))
+	}
+
+	// The next run retries the failed files
+	destination.corrupt = false
+	report, err = service.Migrate(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if report.UploadedObjects != 3 || report.EventsRewritten != 2 {
+		t.Errorf("expected retry to upload 3 objects and rewrite 2 events, got %d and %d", report.UploadedObjects, report.EventsRewritten)
+	}
+}
+
+func TestStorageMigrationService_Migrate_DryRun(t *testing.T) {
+	service, destination, repo := setupStorageMigrationService(true)
+
+	report, err := service.Migrate(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if report.UploadedObjects != 3 || report.EventsRewritten != 2 {
+		t.Errorf("expected dry run to report 3 uploads and 2 rewrites, got %d and %d", report.UploadedObjects, report.EventsRewritten)
+	}
+	if len(destination.blobs) != 0 || len(repo.items) != 0 || len(repo.rewrites) != 0 {
+		t.Error("expected dry run not to upload, record or rewrite anything")
+	}
+}
@@ -0,0 +1,395 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/i18n"
+	"event-ticketing-platform/internal/models"
+)
+
+// ErrStorefrontSlugTaken is returned when an organizer picks a storefront
+// address another organizer already has
+var ErrStorefrontSlugTaken = errors.New("that storefront address is already taken")
+
+// ErrCannotFollowSelf is returned when organizers try to follow themselves
+var ErrCannotFollowSelf = errors.New("you cannot follow yourself")
+
+// maxStorefrontImageSize is the largest logo or banner organizers can upload
+const maxStorefrontImageSize = 5 * 1024 * 1024
+
+// StorefrontRepository defines the data operations for organizer storefronts
+type StorefrontRepository interface {
+	GetByUserID(userID int) (*models.OrganizerProfile, error)
+	GetBySlug(slug string) (*models.OrganizerProfile, error)
+	Create(profile *models.OrganizerProfile) error
+	SlugTaken(slug string, userID int) (bool, error)
+	Update(profile *models.OrganizerProfile) error
+	Follow(organizerID, followerID int) error
+	Unfollow(organizerID, followerID int) error
+	IsFollowing(organizerID, followerID int) (bool, error)
+	CountFollowers(organizerID int) (int, error)
+	GetFollowers(organizerID int) ([]*models.OrganizerFollower, error)
+	GetUnannouncedEvents() ([]int, error)
+	ClaimEventAnnouncement(eventID int) (bool, error)
+}
+
+// NewEventEmailSender tells followers about an organizer's new event
+type NewEventEmailSender interface {
+	SendNewEventEmail(email, userName, locale, organizerName string, event *models.Event, link string) error
+}
+
+// MarketingConsent tells whether users agreed to marketing emails
+type MarketingConsent interface {
+	MarketingAllowed(userID int) (bool, error)
+}
+
+// StorefrontUpdateRequest is an organizer's edit of their storefront
+type StorefrontUpdateRequest struct {
+	Slug         string
+	DisplayName  string
+	Description  string
+	WebsiteURL   string
+	Logo         *multipart.FileHeader
+	Banner       *multipart.FileHeader
+	RemoveLogo   bool
+	RemoveBanner bool
+}
+
+// StorefrontService handles organizers' public storefronts, their followers
+// and telling followers about new events
+type StorefrontService struct {
+	repo        StorefrontRepository
+	eventRepo   EventRepository
+	userRepo    UserRepository
+	emailSender NewEventEmailSender
+	consent     MarketingConsent
+	baseURL     string
+	uploadPath  string
+	now         func() time.Time
+}
+
+// NewStorefrontService creates a new storefront service. Logos and banners
+// are saved under uploadPath.
+func NewStorefrontService(repo StorefrontRepository, eventRepo EventRepository, userRepo UserRepository, emailSender NewEventEmailSender, baseURL, uploadPath string) *StorefrontService {
+	return &StorefrontService{
+		repo:        repo,
+		eventRepo:   eventRepo,
+		userRepo:    userRepo,
+		emailSender: emailSender,
+		baseURL:     baseURL,
+		uploadPath:  uploadPath,
+		now:         time.Now,
+	}
+}
+
+// SetMarketingConsent only announces new events to followers who agreed to
+// marketing emails
+func (s *StorefrontService) SetMarketingConsent(consent MarketingConsent) {
+	s.consent = consent
+}
+
+// GetProfile returns an organizer's profile, creating it from their name the
+// first time it is needed
+func (s *StorefrontService) GetProfile(organizerID int) (*models.OrganizerProfile, error) {
+	profile, err := s.repo.GetByUserID(organizerID)
+	if err != nil || profile != nil {
+		return profile, err
+	}
+
+	organizer, err := s.userRepo.GetByID(organizerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organizer: %w", err)
+	}
+	if organizer.Role != models.RoleOrganizer && organizer.Role != models.RoleAdmin {
+		return nil, fmt.Errorf("user %d is not an organizer", organizerID)
+	}
+
+	profile = &models.OrganizerProfile{
+		UserID:      organizerID,
+		Slug:        models.OrganizerSlug(organizer.FullName()),
+		DisplayName: organizer.FullName(),
+	}
+	if err := s.repo.Create(profile); err != nil {
+		return nil, err
+	}
+	return profile, nil
+}
+
+// GetStorefront returns an organizer's public page. viewerID is 0 for
+// visitors who are not signed in.
+func (s *StorefrontService) GetStorefront(slug string, viewerID int) (*models.OrganizerStorefront, error) {
+	profile, err := s.repo.GetBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.eventRepo.GetByOrganizer(profile.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organizer events: %w", err)
+	}
+
+	storefront := &models.OrganizerStorefront{Profile: profile}
+	now := s.now()
+	for _, event := range events {
+		if event.Status != models.StatusPublished {
+			continue
+		}
+		if event.EndDate.After(now) {
+			storefront.UpcomingEvents = append(storefront.UpcomingEvents, event)
+		} else {
+			storefront.PastEvents = append(storefront.PastEvents, event)
+		}
+	}
+	sortEventsByStart(storefront.UpcomingEvents, false)
+	sortEventsByStart(storefront.PastEvents, true)
+
+	if storefront.Followers, err = s.repo.CountFollowers(profile.UserID); err != nil {
+		return nil, err
+	}
+	if viewerID != 0 {
+		if storefront.IsFollowing, err = s.repo.IsFollowing(profile.UserID, viewerID); err != nil {
+			return nil, err
+		}
+	}
+
+	return storefront, nil
+}
+
+// UpdateProfile saves an organizer's edited storefront, storing any new logo
+// or banner
+func (s *StorefrontService) UpdateProfile(organizerID int, req *StorefrontUpdateRequest) (*models.OrganizerProfile, error) {
+	current, err := s.GetProfile(organizerID)
+	if err != nil {
+		return nil, err
+	}
+
+	profile := *current
+	profile.Slug = req.Slug
+	profile.DisplayName = req.DisplayName
+	profile.Description = strings.TrimSpace(req.Description)
+	profile.WebsiteURL = req.WebsiteURL
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+
+	if profile.Slug != current.Slug {
+		taken, err := s.repo.SlugTaken(profile.Slug, organizerID)
+		if err != nil {
+			return nil, err
+		}
+		if taken {
+			return nil, ErrStorefrontSlugTaken
+		}
+	}
+
+	var saved []string
+	if req.RemoveLogo {
+		profile.LogoURL = ""
+	}
+	if req.Logo != nil {
+		if profile.LogoURL, err = s.saveImage(req.Logo, organizerID, "logo"); err != nil {
+			return nil, err
+		}
+		saved = append(saved, profile.LogoURL)
+	}
+	if req.RemoveBanner {
+		profile.BannerURL = ""
+	}
+	if req.Banner != nil {
+		if profile.BannerURL, err = s.saveImage(req.Banner, organizerID, "banner"); err != nil {
+			s.removeImages(saved...)
+			return nil, err
+		}
+		saved = append(saved, profile.BannerURL)
+	}
+
+	if err := s.repo.Update(&profile); err != nil {
+		s.removeImages(saved...)
+		return nil, err
+	}
+
+	if profile.LogoURL != current.LogoURL {
+		s.removeImages(current.LogoURL)
+	}
+	if profile.BannerURL != current.BannerURL {
+		s.removeImages(current.BannerURL)
+	}
+	return &profile, nil
+}
+
+// ToggleFollow makes the user follow the organizer, or unfollow them if they
+// already did. It returns whether the user now follows the organizer.
+func (s *StorefrontService) ToggleFollow(organizerID, followerID int) (bool, error) {
+	if organizerID == followerID {
+		return false, ErrCannotFollowSelf
+	}
+
+	following, err := s.repo.IsFollowing(organizerID, followerID)
+	if err != nil {
+		return false, err
+	}
+	if following {
+		return false, s.repo.Unfollow(organizerID, followerID)
+	}
+	return true, s.repo.Follow(organizerID, followerID)
+}
+
+// AnnounceNewEvents emails the followers of organizers who have published
+// events since the last run, and returns how many emails were sent. Events
+// that have already started are not announced. It is meant to run
+// periodically to catch events EventPublished missed.
+func (s *StorefrontService) AnnounceNewEvents() (int, error) {
+	eventIDs, err := s.repo.GetUnannouncedEvents()
+	if err != nil {
+		return 0, fmt.Errorf("failed to get unannounced events: %w", err)
+	}
+
+	sent := 0
+	for _, eventID := range eventIDs {
+		count, err := s.announceEvent(eventID)
+		sent += count
+		if err != nil {
+			fmt.Printf("Warning: failed to announce event %d to followers: %v\n", eventID, err)
+		}
+	}
+	return sent, nil
+}
+
+// EventPublished emails the event to its organizer's followers in the
+// background. It implements EventPublishedHook.
+func (s *StorefrontService) EventPublished(event *models.Event) {
+	go func() {
+		if _, err := s.announceEvent(event.ID); err != nil {
+			fmt.Printf("Warning: failed to announce event %d to followers: %v\n", event.ID, err)
+		}
+	}()
+}
+
+// announceEvent emails a new event to its organizer's followers
+func (s *StorefrontService) announceEvent(eventID int) (int, error) {
+	claimed, err := s.repo.ClaimEventAnnouncement(eventID)
+	if err != nil || !claimed {
+		return 0, err
+	}
+
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get event: %w", err)
+	}
+	if event.Status != models.StatusPublished || !event.StartDate.After(s.now()) || s.emailSender == nil {
+		return 0, nil
+	}
+
+	profile, err := s.GetProfile(event.OrganizerID)
+	if err != nil {
+		return 0, err
+	}
+	followers, err := s.repo.GetFollowers(event.OrganizerID)
+	if err != nil {
+		return 0, err
+	}
+
+	link := s.baseURL + event.Path()
+	sent := 0
+	for _, follower := range followers {
+		if s.consent != nil {
+			allowed, err := s.consent.MarketingAllowed(follower.UserID)
+			if err != nil {
+				fmt.Printf("Warning: failed to check marketing consent of user %d: %v\n", follower.UserID, err)
+				continue
+			}
+			if !allowed {
+				continue
+			}
+		}
+		locale := i18n.Resolve(follower.Locale)
+		if err := s.emailSender.SendNewEventEmail(follower.Email, follower.Name, locale, profile.DisplayName, event, link); err != nil {
+			fmt.Printf("Warning: failed to send new event email to %s: %v\n", follower.Email, err)
+			continue
+		}
+		sent++
+	}
+	return sent, nil
+}
+
+// sortEventsByStart sorts events by start date, latest first if desc
+func sortEventsByStart(events []*models.Event, desc bool) {
+	sort.SliceStable(events, func(i, j int) bool {
+		if desc {
+			return events[i].StartDate.After(events[j].StartDate)
+		}
+		return events[i].StartDate.Before(events[j].StartDate)
+	})
+}
+
+// uploadImageTypes are the image formats accepted for logos, banners and
+// venue images, by sniffed content type
+var uploadImageTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
+// saveImage stores an uploaded logo or banner and returns its URL
+func (s *StorefrontService) saveImage(fileHeader *multipart.FileHeader, organizerID int, kind string) (string, error) {
+	if fileHeader.Size > maxStorefrontImageSize {
+		return "", fmt.Errorf("%s image too large (max 5MB)", kind)
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer file.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return "", fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+	ext, ok := uploadImageTypes[http.DetectContentType(head[:n])]
+	if !ok {
+		return "", fmt.Errorf("invalid %s image type (only JPEG, PNG, GIF and WebP allowed)", kind)
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+
+	if err := os.MkdirAll(s.uploadPath, 0755); err != nil {
+		return "", fmt.Errorf("failed to create upload directory: %w", err)
+	}
+
+	filename := fmt.Sprintf("organizer_%d_%s_%d%s", organizerID, kind, s.now().UnixNano(), ext)
+	destFile, err := os.Create(filepath.Join(s.uploadPath, filename))
+	if err != nil {
+		return "", fmt.Errorf("failed to create destination file: %w", err)
+	}
+	defer destFile.Close()
+
+	if _, err := io.Copy(destFile, file); err != nil {
+		return "", fmt.Errorf("failed to copy file content: %w", err)
+	}
+
+	return "/uploads/organizers/" + filename, nil
+}
+
+// removeImages deletes uploaded logos and banners that are no longer used
+func (s *StorefrontService) removeImages(urls ...string) {
+	for _, url := range urls {
+		filename := strings.TrimPrefix(url, "/uploads/organizers/")
+		if filename == url || filename == "" || strings.Contains(filename, "/") {
+			continue // Not one of our uploads
+		}
+		os.Remove(filepath.Join(s.uploadPath, filename)) // Ignore errors for cleanup
+	}
+}
@@ -0,0 +1,257 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock StorefrontRepository for testing
+type mockStorefrontRepository struct {
+	profiles  map[int]*models.OrganizerProfile
+	followers map[int]map[int]bool
+	users     map[int]*models.OrganizerFollower
+	announced map[int]bool
+}
+
+func newMockStorefrontRepository() *mockStorefrontRepository {
+	return &mockStorefrontRepository{
+		profiles:  make(map[int]*models.OrganizerProfile),
+		followers: make(map[int]map[int]bool),
+		users:     make(map[int]*models.OrganizerFollower),
+		announced: make(map[int]bool),
+	}
+}
+
+func (m *mockStorefrontRepository) GetByUserID(userID int) (*models.OrganizerProfile, error) {
+	return m.profiles[userID], nil
+}
+
+func (m *mockStorefrontRepository) GetBySlug(slug string) (*models.OrganizerProfile, error) {
+	for _, profile := range m.profiles {
+		if profile.Slug == slug {
+			return profile, nil
+		}
+	}
+	return nil, errors.New("organizer not found")
+}
+
+func (m *mockStorefrontRepository) Create(profile *models.OrganizerProfile) error {
+	taken := make(map[string]bool)
+	for _, other := range m.profiles {
+		taken[other.Slug] = true
+	}
+	profile.Slug = models.UniqueEventSlug(profile.Slug, taken)
+	m.profiles[profile.UserID] = profile
+	return nil
+}
+
+func (m *mockStorefrontRepository) SlugTaken(slug string, userID int) (bool, error) {
+	for _, profile := range m.profiles {
+		if profile.Slug == slug && profile.UserID != userID {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (m *mockStorefrontRepository) Update(profile *models.OrganizerProfile) error {
+	saved := *profile
+	m.profiles[profile.UserID] = &saved
+	return nil
+}
+
+func (m *mockStorefrontRepository) Follow(organizerID, followerID int) error {
+	if m.followers[organizerID] == nil {
+		m.followers[organizerID] = make(map[int]bool)
+	}
+	m.followers[organizerID][followerID] = true
+	return nil
+}
+
+func (m *mockStorefrontRepository) Unfollow(organizerID, followerID int) error {
+	delete(m.followers[organizerID], followerID)
+	return nil
+}
+
+func (m *mockStorefrontRepository) IsFollowing(organizerID, followerID int) (bool, error) {
+	return m.followers[organizerID][followerID], nil
+}
+
+func (m *mockStorefrontRepository) CountFollowers(organizerID int) (int, error) {
+	return len(m.followers[organizerID]), nil
+}
+
+func (m *mockStorefrontRepository) GetFollowers(organizerID int) ([]*models.OrganizerFollower, error) {
+	var followers []*models.OrganizerFollower
+	for id := range m.followers[organizerID] {
+		followers = append(followers, m.users[id])
+	}
+	return followers, nil
+}
+
+func (m *mockStorefrontRepository) GetUnannouncedEvents() ([]int, error) {
+	return []int{1, 2, 3}, nil
+}
+
+func (m *mockStorefrontRepository) ClaimEventAnnouncement(eventID int) (bool, error) {
+	if m.announced[eventID] {
+		return false, nil
+	}
+	m.announced[eventID] = true
+	return true, nil
+}
+
+// Mock NewEventEmailSender for testing
+type mockNewEventEmailSender struct {
+	sent []string
+}
+
+func (m *mockNewEventEmailSender) SendNewEventEmail(email, userName, locale, organizerName string, event *models.Event, link string) error {
+	m.sent = append(m.sent, fmt.Sprintf("%s %s %s %s", email, locale, organizerName, link))
+	return nil
+}
+
+func setupStorefrontService(now time.Time) (*StorefrontService, *mockStorefrontRepository, *mockEventRepository, *mockNewEventEmailSender) {
+	repo := newMockStorefrontRepository()
+	eventRepo := newMockEventRepository()
+	userRepo := new(MockUserRepository)
+	userRepo.On("GetByID", 7).Return(&models.User{ID: 7, FirstName: "Nairobi", LastName: "Jazz Club", Role: models.RoleOrganizer}, nil)
+	sender := &mockNewEventEmailSender{}
+
+	service := NewStorefrontService(repo, eventRepo, userRepo, sender, "https://tickets.example.com", "")
+	service.now = func() time.Time { return now }
+	return service, repo, eventRepo, sender
+}
+
+func TestStorefrontService_GetStorefront(t *testing.T) {
+	now := time.Date(2025, 6, 4, 12, 0, 0, 0, time.UTC)
+	service, _, eventRepo, _ := setupStorefrontService(now)
+
+	eventRepo.events[1] = &models.Event{ID: 1, OrganizerID: 7, Status: models.StatusPublished, StartDate: now.AddDate(0, 0, 9), EndDate: now.AddDate(0, 0, 10)}
+	eventRepo.events[2] = &models.Event{ID: 2, OrganizerID: 7, Status: models.StatusPublished, StartDate: now.AddDate(0, 0, 2), EndDate: now.AddDate(0, 0, 3)}
+	eventRepo.events[3] = &models.Event{ID: 3, OrganizerID: 7, Status: models.StatusPublished, StartDate: now.AddDate(0, -1, 0), EndDate: now.AddDate(0, -1, 1)}
+	eventRepo.events[4] = &models.Event{ID: 4, OrganizerID: 7, Status: models.StatusDraft, StartDate: now.AddDate(0, 0, 5), EndDate: now.AddDate(0, 0, 6)}
+
+	profile, err := service.GetProfile(7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if profile.Slug != "nairobi-jazz-club" || profile.DisplayName != "Nairobi Jazz Club" {
+		t.Errorf("expected a profile created from the organizer's name, got %+v", profile)
+	}
+
+	if _, err := service.ToggleFollow(7, 7); !errors.Is(err, ErrCannotFollowSelf) {
+		t.Errorf("expected organizers not to follow themselves, got %v", err)
+	}
+	if following, _ := service.ToggleFollow(7, 20); !following {
+		t.Error("expected the user to follow the organizer")
+	}
+
+	storefront, err := service.GetStorefront("nairobi-jazz-club", 20)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(storefront.UpcomingEvents) != 2 || storefront.UpcomingEvents[0].ID != 2 || storefront.UpcomingEvents[1].ID != 1 {
+		t.Errorf("expected published upcoming events soonest first, got %v", storefront.UpcomingEvents)
+	}
+	if len(storefront.PastEvents) != 1 || storefront.PastEvents[0].ID != 3 {
+		t.Errorf("expected the past event, got %v", storefront.PastEvents)
+	}
+	if storefront.Followers != 1 || !storefront.IsFollowing {
+		t.Errorf("expected one follower, the viewer, got %d (%v)", storefront.Followers, storefront.IsFollowing)
+	}
+
+	if following, _ := service.ToggleFollow(7, 20); following {
+		t.Error("expected a second toggle to unfollow")
+	}
+}
+
+func TestStorefrontService_UpdateProfile(t *testing.T) {
+	service, repo, _, _ := setupStorefrontService(time.Now())
+	repo.profiles[8] = &models.OrganizerProfile{UserID: 8, Slug: "taken-name", DisplayName: "Other"}
+
+	_, err := service.UpdateProfile(7, &StorefrontUpdateRequest{Slug: "taken-name", DisplayName: "Jazz Club"})
+	if !errors.Is(err, ErrStorefrontSlugTaken) {
+		t.Errorf("expected a taken slug to be refused, got %v", err)
+	}
+
+	_, err = service.UpdateProfile(7, &StorefrontUpdateRequest{Slug: "jazz-club", DisplayName: "Jazz Club", WebsiteURL: "javascript:alert(1)"})
+	if err == nil {
+		t.Error("expected a non-http website to be refused")
+	}
+
+	profile, err := service.UpdateProfile(7, &StorefrontUpdateRequest{Slug: " Jazz-Club ", DisplayName: "Jazz Club", Description: "Live music every week", WebsiteURL: "https://jazz.example.com"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if profile.Slug != "jazz-club" || repo.profiles[7].Description != "Live music every week" {
+		t.Errorf("expected the profile to be saved, got %+v", repo.profiles[7])
+	}
+}
+
+func TestStorefrontService_AnnounceNewEvents(t *testing.T) {
+	now := time.Date(2025, 6, 4, 12, 0, 0, 0, time.UTC)
+	service, repo, eventRepo, sender := setupStorefrontService(now)
+
+	eventRepo.events[1] = &models.Event{ID: 1, OrganizerID: 7, Slug: "summer-jam", Title: "Summer Jam", Status: models.StatusPublished, StartDate: now.AddDate(0, 1, 0)}
+	// Published after it started, too late to announce
+	eventRepo.events[2] = &models.Event{ID: 2, OrganizerID: 7, Title: "Tonight", Status: models.StatusPublished, StartDate: now.Add(-time.Hour)}
+	// Unpublished again before the job ran
+	eventRepo.events[3] = &models.Event{ID: 3, OrganizerID: 7, Title: "Draft", Status: models.StatusDraft, StartDate: now.AddDate(0, 1, 0)}
+
+	repo.users[20] = &models.OrganizerFollower{UserID: 20, Email: "amina@example.com", Name: "Amina", Locale: "sw"}
+	repo.users[21] = &models.OrganizerFollower{UserID: 21, Email: "tom@example.com", Name: "Tom"}
+	repo.Follow(7, 20)
+	repo.Follow(7, 21)
+
+	sent, err := service.AnnounceNewEvents()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sent != 2 || len(sender.sent) != 2 {
+		t.Fatalf("expected Summer Jam to be emailed to both followers, got %d: %v", sent, sender.sent)
+	}
+	for _, email := range sender.sent {
+		if email != "amina@example.com sw Nairobi Jazz Club https://tickets.example.com/events/summer-jam" &&
+			email != "tom@example.com en Nairobi Jazz Club https://tickets.example.com/events/summer-jam" {
+			t.Errorf("unexpected email: %s", email)
+		}
+	}
+
+	// Each event is only announced once
+	if sent, _ := service.AnnounceNewEvents(); sent != 0 {
+		t.Errorf("expected no emails on the second run, got %d", sent)
+	}
+}
+
+func TestStorefrontService_AnnounceNewEventsMarketingConsent(t *testing.T) {
+	now := time.Date(2025, 6, 4, 12, 0, 0, 0, time.UTC)
+	service, repo, eventRepo, sender := setupStorefrontService(now)
+
+	privacy := NewPrivacyService(newMockPrivacyRepository())
+	privacy.Save(20, "browser-a", false, true)
+	privacy.Save(21, "browser-b", true, false)
+	service.SetMarketingConsent(privacy)
+
+	eventRepo.events[1] = &models.Event{ID: 1, OrganizerID: 7, Slug: "summer-jam", Title: "Summer Jam", Status: models.StatusPublished, StartDate: now.AddDate(0, 1, 0)}
+	repo.users[20] = &models.OrganizerFollower{UserID: 20, Email: "amina@example.com", Name: "Amina"}
+	repo.users[21] = &models.OrganizerFollower{UserID: 21, Email: "tom@example.com", Name: "Tom"}
+	// Never chose, so gets no marketing
+	repo.users[22] = &models.OrganizerFollower{UserID: 22, Email: "wanjiru@example.com", Name: "Wanjiru"}
+	repo.Follow(7, 20)
+	repo.Follow(7, 21)
+	repo.Follow(7, 22)
+
+	sent, err := service.AnnounceNewEvents()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if sent != 1 || len(sender.sent) != 1 || !strings.HasPrefix(sender.sent[0], "amina@example.com ") {
+		t.Errorf("expected only the follower who agreed to marketing to be emailed, got %d: %v", sent, sender.sent)
+	}
+}
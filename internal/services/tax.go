@@ -0,0 +1,73 @@
+package services
+
+import (
+	"event-ticketing-platform/internal/models"
+)
+
+// TaxRepositoryInterface defines the data operations for tax settings
+type TaxRepositoryInterface interface {
+	GetSettings(organizerID int) (*models.TaxSettings, error)
+	SaveSettings(settings *models.TaxSettings) error
+	GetOverride(eventID int) (*models.EventTaxOverride, error)
+	SaveOverride(override *models.EventTaxOverride) error
+	DeleteOverride(eventID int) error
+	GetEventTax(eventID int) (*models.TaxSettings, *models.EventTaxOverride, error)
+}
+
+// TaxRateLookup returns the tax rate an event's tickets are sold with
+type TaxRateLookup interface {
+	EventTaxRate(eventID int) (*models.TaxRate, error)
+}
+
+// TaxService lets organizers charge tax on their tickets, at a rate of
+// their own that their events can override
+type TaxService struct {
+	repo TaxRepositoryInterface
+}
+
+// NewTaxService creates a new tax service
+func NewTaxService(repo TaxRepositoryInterface) *TaxService {
+	return &TaxService{repo: repo}
+}
+
+// GetSettings retrieves an organizer's tax settings
+func (s *TaxService) GetSettings(organizerID int) (*models.TaxSettings, error) {
+	return s.repo.GetSettings(organizerID)
+}
+
+// UpdateSettings validates and saves an organizer's tax settings
+func (s *TaxService) UpdateSettings(settings *models.TaxSettings) error {
+	if err := settings.Validate(); err != nil {
+		return err
+	}
+	return s.repo.SaveSettings(settings)
+}
+
+// GetOverride retrieves an event's tax override, or nil if it is taxed at
+// its organizer's rate
+func (s *TaxService) GetOverride(eventID int) (*models.EventTaxOverride, error) {
+	return s.repo.GetOverride(eventID)
+}
+
+// UpdateOverride validates and saves an event's tax override. A nil
+// override taxes the event at its organizer's rate again.
+func (s *TaxService) UpdateOverride(eventID int, override *models.EventTaxOverride) error {
+	if override == nil {
+		return s.repo.DeleteOverride(eventID)
+	}
+	override.EventID = eventID
+	if err := override.Validate(); err != nil {
+		return err
+	}
+	return s.repo.SaveOverride(override)
+}
+
+// EventTaxRate returns the tax rate an event's tickets are sold with, or nil
+// if they aren't taxed
+func (s *TaxService) EventTaxRate(eventID int) (*models.TaxRate, error) {
+	settings, override, err := s.repo.GetEventTax(eventID)
+	if err != nil {
+		return nil, err
+	}
+	return models.EffectiveTaxRate(settings, override), nil
+}
@@ -0,0 +1,98 @@
+package services
+
+import (
+	"testing"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock TaxRepository for testing
+type mockTaxRepository struct {
+	settings  map[int]*models.TaxSettings
+	overrides map[int]*models.EventTaxOverride
+	organizer map[int]int // event ID to organizer ID
+}
+
+func newMockTaxRepository() *mockTaxRepository {
+	return &mockTaxRepository{
+		settings:  make(map[int]*models.TaxSettings),
+		overrides: make(map[int]*models.EventTaxOverride),
+		organizer: make(map[int]int),
+	}
+}
+
+func (m *mockTaxRepository) GetSettings(organizerID int) (*models.TaxSettings, error) {
+	if settings, ok := m.settings[organizerID]; ok {
+		return settings, nil
+	}
+	return models.DefaultTaxSettings(organizerID), nil
+}
+
+func (m *mockTaxRepository) SaveSettings(settings *models.TaxSettings) error {
+	m.settings[settings.OrganizerID] = settings
+	return nil
+}
+
+func (m *mockTaxRepository) GetOverride(eventID int) (*models.EventTaxOverride, error) {
+	return m.overrides[eventID], nil
+}
+
+func (m *mockTaxRepository) SaveOverride(override *models.EventTaxOverride) error {
+	m.overrides[override.EventID] = override
+	return nil
+}
+
+func (m *mockTaxRepository) DeleteOverride(eventID int) error {
+	delete(m.overrides, eventID)
+	return nil
+}
+
+func (m *mockTaxRepository) GetEventTax(eventID int) (*models.TaxSettings, *models.EventTaxOverride, error) {
+	organizerID, ok := m.organizer[eventID]
+	if !ok {
+		return nil, nil, nil
+	}
+	settings, _ := m.GetSettings(organizerID)
+	return settings, m.overrides[eventID], nil
+}
+
+func TestTaxService_EventTaxRate(t *testing.T) {
+	repo := newMockTaxRepository()
+	repo.organizer[7] = 2
+	service := NewTaxService(repo)
+
+	if rate, err := service.EventTaxRate(7); err != nil || rate != nil {
+		t.Errorf("EventTaxRate() before tax is set up = %+v, %v, want nil", rate, err)
+	}
+
+	if err := service.UpdateSettings(&models.TaxSettings{OrganizerID: 2, Enabled: true}); err == nil {
+		t.Error("UpdateSettings() without a rate = nil, want an error")
+	}
+	if err := service.UpdateSettings(&models.TaxSettings{OrganizerID: 2, Enabled: true, RateBasisPoints: 1600}); err != nil {
+		t.Fatalf("UpdateSettings() error = %v", err)
+	}
+	rate, err := service.EventTaxRate(7)
+	if err != nil || rate == nil || rate.RateBasisPoints != 1600 || rate.Label != models.DefaultTaxLabel {
+		t.Errorf("EventTaxRate() = %+v, %v, want 16%% VAT", rate, err)
+	}
+
+	if err := service.UpdateOverride(7, &models.EventTaxOverride{Exempt: true}); err != nil {
+		t.Fatalf("UpdateOverride() error = %v", err)
+	}
+	if repo.overrides[7] == nil || repo.overrides[7].EventID != 7 {
+		t.Errorf("override = %+v, want it saved for event 7", repo.overrides[7])
+	}
+	if rate, _ := service.EventTaxRate(7); rate != nil {
+		t.Errorf("EventTaxRate() for an exempt event = %+v, want nil", rate)
+	}
+
+	if err := service.UpdateOverride(7, nil); err != nil {
+		t.Fatalf("UpdateOverride(nil) error = %v", err)
+	}
+	if _, ok := repo.overrides[7]; ok {
+		t.Error("UpdateOverride(nil) should delete the override")
+	}
+	if rate, _ := service.EventTaxRate(7); rate == nil || rate.RateBasisPoints != 1600 {
+		t.Errorf("EventTaxRate() after removing the override = %+v, want the organizer's rate", rate)
+	}
+}
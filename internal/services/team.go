@@ -0,0 +1,220 @@
+package services
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+var (
+	// ErrTeamFull is returned when an organizer's team has as many members as it can
+	ErrTeamFull = fmt.Errorf("a team can have up to %d members", models.MaxTeamMembers)
+	// ErrAlreadyOnTeam is returned when inviting an email that is already on the team
+	ErrAlreadyOnTeam = errors.New("this email has already been invited to your team")
+	// ErrTeamInvitationExpired is returned when accepting an invitation that is too old
+	ErrTeamInvitationExpired = errors.New("this invitation has expired, ask the organizer to invite you again")
+	// ErrTeamInvitationWrongAccount is returned when an invitation is accepted
+	// from an account with a different email address
+	ErrTeamInvitationWrongAccount = errors.New("this invitation was sent to a different email address")
+)
+
+// OrganizerMemberRepository defines the data operations for organizers' teams
+type OrganizerMemberRepository interface {
+	Create(member *models.OrganizerMember) error
+	GetByOrganizer(organizerID int) ([]*models.OrganizerMember, error)
+	GetByUser(userID int) ([]*models.OrganizerMember, error)
+	GetByInvitationHash(hash string) (*models.OrganizerMember, error)
+	GetMembership(organizerID, userID int) (*models.OrganizerMember, error)
+	Accept(id, userID int) error
+	Delete(id, organizerID int) error
+}
+
+// TeamInvitationSender emails invitations to join an organizer's team
+type TeamInvitationSender interface {
+	SendTeamInvitationEmail(email, locale, organizerName string, permissions []models.TeamPermission, link string) error
+}
+
+// TeamAccessChecker reports what team members may do on an organizer's behalf
+type TeamAccessChecker interface {
+	HasAccess(organizerID, userID int, permission models.TeamPermission) (bool, error)
+	GetOrganizersFor(userID int, permission models.TeamPermission) ([]int, error)
+}
+
+// TeamService lets organizers invite team members and grant them access to
+// parts of their account. Members can manage events, view analytics or run
+// check-in, but withdrawals always stay with the organizer.
+type TeamService struct {
+	repo        OrganizerMemberRepository
+	userRepo    UserRepository
+	emailSender TeamInvitationSender
+	baseURL     string
+	now         func() time.Time
+}
+
+// NewTeamService creates a new team service
+func NewTeamService(repo OrganizerMemberRepository, userRepo UserRepository, emailSender TeamInvitationSender, baseURL string) *TeamService {
+	return &TeamService{
+		repo:        repo,
+		userRepo:    userRepo,
+		emailSender: emailSender,
+		baseURL:     baseURL,
+		now:         time.Now,
+	}
+}
+
+// Invite emails an invitation to join the organizer's team with the given
+// permissions, in the given language
+func (s *TeamService) Invite(organizerID int, email string, permissions []models.TeamPermission, locale string) (*models.OrganizerMember, error) {
+	organizer, err := s.userRepo.GetByID(organizerID)
+	if err != nil {
+		return nil, fmt.Errorf("organizer not found: %w", err)
+	}
+	if organizer.Role != models.RoleOrganizer {
+		return nil, fmt.Errorf("only organizers can invite team members")
+	}
+
+	member := &models.OrganizerMember{OrganizerID: organizerID, Email: email}
+	member.SetPermissions(permissions)
+	if err := member.Validate(); err != nil {
+		return nil, err
+	}
+	if member.Email == strings.ToLower(organizer.Email) {
+		return nil, fmt.Errorf("you cannot invite yourself to your own team")
+	}
+
+	team, err := s.repo.GetByOrganizer(organizerID)
+	if err != nil {
+		return nil, err
+	}
+	if len(team) >= models.MaxTeamMembers {
+		return nil, ErrTeamFull
+	}
+	for _, existing := range team {
+		if existing.Email == member.Email {
+			return nil, ErrAlreadyOnTeam
+		}
+	}
+
+	tokenBytes := make([]byte, 32)
+	if _, err := rand.Read(tokenBytes); err != nil {
+		return nil, fmt.Errorf("failed to generate invitation token: %w", err)
+	}
+	token := hex.EncodeToString(tokenBytes)
+	member.InvitationHash = models.HashInvitationToken(token)
+
+	if err := s.repo.Create(member); err != nil {
+		return nil, err
+	}
+
+	link := s.baseURL + "/team/invitations/" + token
+	if err := s.emailSender.SendTeamInvitationEmail(member.Email, locale, organizer.FullName(), member.Permissions(), link); err != nil {
+		// Without the email the invitation can never be accepted
+		if deleteErr := s.repo.Delete(member.ID, organizerID); deleteErr != nil {
+			fmt.Printf("Warning: failed to remove unsent team invitation %d: %v\n", member.ID, deleteErr)
+		}
+		return nil, fmt.Errorf("failed to send team invitation: %w", err)
+	}
+
+	return member, nil
+}
+
+// GetInvitation returns the open invitation for a token
+func (s *TeamService) GetInvitation(token string) (*models.OrganizerMember, error) {
+	member, err := s.repo.GetByInvitationHash(models.HashInvitationToken(token))
+	if err != nil {
+		return nil, err
+	}
+	if member.IsInvitationExpired(s.now()) {
+		return nil, ErrTeamInvitationExpired
+	}
+	return member, nil
+}
+
+// AcceptInvitation joins the user to the team they were invited to. The
+// user's account must have the email address the invitation was sent to.
+// Attendees become organizers so they can reach the organizer pages.
+func (s *TeamService) AcceptInvitation(token string, userID int) (*models.OrganizerMember, error) {
+	member, err := s.GetInvitation(token)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepo.GetByID(userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+	if strings.ToLower(user.Email) != member.Email {
+		return nil, ErrTeamInvitationWrongAccount
+	}
+	if user.ID == member.OrganizerID {
+		return nil, fmt.Errorf("you cannot join your own team")
+	}
+
+	if user.Role == models.RoleAttendee {
+		if _, err := s.userRepo.Update(user.ID, &models.UserUpdateRequest{
+			FirstName: user.FirstName,
+			LastName:  user.LastName,
+			Role:      models.RoleOrganizer,
+		}); err != nil {
+			return nil, fmt.Errorf("failed to give team member organizer access: %w", err)
+		}
+	}
+
+	if err := s.repo.Accept(member.ID, user.ID); err != nil {
+		return nil, err
+	}
+	now := s.now()
+	member.UserID = &user.ID
+	member.AcceptedAt = &now
+	member.InvitationHash = ""
+	return member, nil
+}
+
+// GetMembers returns the organizer's team, including open invitations
+func (s *TeamService) GetMembers(organizerID int) ([]*models.OrganizerMember, error) {
+	return s.repo.GetByOrganizer(organizerID)
+}
+
+// GetTeams returns the teams the user has joined
+func (s *TeamService) GetTeams(userID int) ([]*models.OrganizerMember, error) {
+	return s.repo.GetByUser(userID)
+}
+
+// RemoveMember removes a member or open invitation from the organizer's team
+func (s *TeamService) RemoveMember(organizerID, memberID int) error {
+	return s.repo.Delete(memberID, organizerID)
+}
+
+// HasAccess returns true if the user has joined the organizer's team with
+// the given permission
+func (s *TeamService) HasAccess(organizerID, userID int, permission models.TeamPermission) (bool, error) {
+	member, err := s.repo.GetMembership(organizerID, userID)
+	if err != nil {
+		return false, err
+	}
+	return member != nil && member.Has(permission), nil
+}
+
+// GetOrganizersFor returns the organizers whose teams the user has joined
+// with the given permission
+func (s *TeamService) GetOrganizersFor(userID int, permission models.TeamPermission) ([]int, error) {
+	teams, err := s.repo.GetByUser(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	var organizerIDs []int
+	for _, team := range teams {
+		if team.Has(permission) {
+			organizerIDs = append(organizerIDs, team.OrganizerID)
+		}
+	}
+	sort.Ints(organizerIDs)
+	return organizerIDs, nil
+}
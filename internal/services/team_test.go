@@ -0,0 +1,217 @@
+package services
+
+import (
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/mock"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock OrganizerMemberRepository for testing
+type mockOrganizerMemberRepository struct {
+	members []*models.OrganizerMember
+}
+
+func (m *mockOrganizerMemberRepository) Create(member *models.OrganizerMember) error {
+	member.ID = len(m.members) + 1
+	member.InvitedAt = time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	m.members = append(m.members, member)
+	return nil
+}
+
+func (m *mockOrganizerMemberRepository) GetByOrganizer(organizerID int) ([]*models.OrganizerMember, error) {
+	var members []*models.OrganizerMember
+	for _, member := range m.members {
+		if member.OrganizerID == organizerID {
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+func (m *mockOrganizerMemberRepository) GetByUser(userID int) ([]*models.OrganizerMember, error) {
+	var members []*models.OrganizerMember
+	for _, member := range m.members {
+		if member.IsAccepted() && *member.UserID == userID {
+			members = append(members, member)
+		}
+	}
+	return members, nil
+}
+
+func (m *mockOrganizerMemberRepository) GetByInvitationHash(hash string) (*models.OrganizerMember, error) {
+	for _, member := range m.members {
+		if member.InvitationHash == hash && !member.IsAccepted() {
+			return member, nil
+		}
+	}
+	return nil, errors.New("team invitation not found")
+}
+
+func (m *mockOrganizerMemberRepository) GetMembership(organizerID, userID int) (*models.OrganizerMember, error) {
+	for _, member := range m.members {
+		if member.OrganizerID == organizerID && member.IsAccepted() && *member.UserID == userID {
+			return member, nil
+		}
+	}
+	return nil, nil
+}
+
+func (m *mockOrganizerMemberRepository) Accept(id, userID int) error {
+	for _, member := range m.members {
+		if member.ID == id {
+			acceptedAt := member.InvitedAt.Add(time.Hour)
+			member.UserID = &userID
+			member.AcceptedAt = &acceptedAt
+			member.InvitationHash = ""
+			return nil
+		}
+	}
+	return errors.New("team invitation not found or already accepted")
+}
+
+func (m *mockOrganizerMemberRepository) Delete(id, organizerID int) error {
+	for i, member := range m.members {
+		if member.ID == id && member.OrganizerID == organizerID {
+			m.members = append(m.members[:i], m.members[i+1:]...)
+			return nil
+		}
+	}
+	return errors.New("team member not found")
+}
+
+// Mock TeamInvitationSender for testing
+type mockTeamInvitationSender struct {
+	links []string
+	err   error
+}
+
+func (m *mockTeamInvitationSender) SendTeamInvitationEmail(email, locale, organizerName string, permissions []models.TeamPermission, link string) error {
+	if m.err != nil {
+		return m.err
+	}
+	m.links = append(m.links, link)
+	return nil
+}
+
+func newTestTeamService() (*TeamService, *mockOrganizerMemberRepository, *MockUserRepository, *mockTeamInvitationSender) {
+	repo := &mockOrganizerMemberRepository{}
+	users := &MockUserRepository{}
+	users.On("GetByID", 1).Return(&models.User{ID: 1, Email: "owner@example.com", FirstName: "Ada", LastName: "Owner", Role: models.RoleOrganizer}, nil)
+	users.On("GetByID", 2).Return(&models.User{ID: 2, Email: "Door@Example.com", FirstName: "Dee", LastName: "Door", Role: models.RoleAttendee}, nil)
+	users.On("GetByID", 3).Return(&models.User{ID: 3, Email: "someone@example.com", Role: models.RoleAttendee}, nil)
+	sender := &mockTeamInvitationSender{}
+
+	service := NewTeamService(repo, users, sender, "https://tickets.example.com")
+	service.now = func() time.Time { return time.Date(2026, 3, 2, 12, 0, 0, 0, time.UTC) }
+	return service, repo, users, sender
+}
+
+func invitationToken(t *testing.T, link string) string {
+	t.Helper()
+	const prefix = "https://tickets.example.com/team/invitations/"
+	if !strings.HasPrefix(link, prefix) {
+		t.Fatalf("unexpected invitation link %q", link)
+	}
+	return strings.TrimPrefix(link, prefix)
+}
+
+func TestTeamService_Invite(t *testing.T) {
+	service, repo, _, sender := newTestTeamService()
+
+	member, err := service.Invite(1, "door@example.com", []models.TeamPermission{models.PermissionCheckIn}, "en")
+	if err != nil {
+		t.Fatalf("expected invitation to be sent, got %v", err)
+	}
+	if member.InvitationHash != models.HashInvitationToken(invitationToken(t, sender.links[0])) {
+		t.Error("expected only the hash of the emailed token to be stored")
+	}
+
+	if _, err := service.Invite(1, " DOOR@example.com ", []models.TeamPermission{models.PermissionViewAnalytics}, "en"); err != ErrAlreadyOnTeam {
+		t.Errorf("expected ErrAlreadyOnTeam, got %v", err)
+	}
+	if _, err := service.Invite(1, "owner@example.com", []models.TeamPermission{models.PermissionCheckIn}, "en"); err == nil {
+		t.Error("expected organizers not to be able to invite themselves")
+	}
+	if _, err := service.Invite(2, "friend@example.com", []models.TeamPermission{models.PermissionCheckIn}, "en"); err == nil {
+		t.Error("expected attendees not to be able to build a team")
+	}
+
+	sender.err = errors.New("provider down")
+	if _, err := service.Invite(1, "bar@example.com", []models.TeamPermission{models.PermissionCheckIn}, "en"); err == nil {
+		t.Error("expected email failures to be returned")
+	}
+	if len(repo.members) != 1 {
+		t.Errorf("expected unsent invitations to be removed, have %d members", len(repo.members))
+	}
+}
+
+func TestTeamService_InviteTeamFull(t *testing.T) {
+	service, repo, _, _ := newTestTeamService()
+	for i := 0; i < models.MaxTeamMembers; i++ {
+		repo.members = append(repo.members, &models.OrganizerMember{ID: i + 1, OrganizerID: 1, Email: "member" + string(rune('a'+i)) + "@example.com"})
+	}
+
+	if _, err := service.Invite(1, "door@example.com", []models.TeamPermission{models.PermissionCheckIn}, "en"); err != ErrTeamFull {
+		t.Errorf("expected ErrTeamFull, got %v", err)
+	}
+}
+
+func TestTeamService_AcceptInvitation(t *testing.T) {
+	service, _, users, sender := newTestTeamService()
+	users.On("Update", 2, mock.MatchedBy(func(req *models.UserUpdateRequest) bool {
+		return req.Role == models.RoleOrganizer
+	})).Return(&models.User{ID: 2, Role: models.RoleOrganizer}, nil)
+
+	if _, err := service.Invite(1, "door@example.com", []models.TeamPermission{models.PermissionCheckIn, models.PermissionViewAnalytics}, "en"); err != nil {
+		t.Fatalf("failed to invite: %v", err)
+	}
+	token := invitationToken(t, sender.links[0])
+
+	if _, err := service.AcceptInvitation(token, 3); err != ErrTeamInvitationWrongAccount {
+		t.Errorf("expected ErrTeamInvitationWrongAccount, got %v", err)
+	}
+
+	member, err := service.AcceptInvitation(token, 2)
+	if err != nil {
+		t.Fatalf("expected invitation to be accepted, got %v", err)
+	}
+	if !member.IsAccepted() || *member.UserID != 2 {
+		t.Error("expected the member to have joined the team")
+	}
+	users.AssertCalled(t, "Update", 2, mock.Anything)
+
+	if _, err := service.AcceptInvitation(token, 2); err == nil {
+		t.Error("expected accepted invitations not to be reusable")
+	}
+
+	ok, err := service.HasAccess(1, 2, models.PermissionCheckIn)
+	if err != nil || !ok {
+		t.Errorf("expected check-in access, got %v, %v", ok, err)
+	}
+	ok, _ = service.HasAccess(1, 2, models.PermissionManageEvents)
+	if ok {
+		t.Error("expected no access to manage events")
+	}
+
+	organizers, _ := service.GetOrganizersFor(2, models.PermissionViewAnalytics)
+	if len(organizers) != 1 || organizers[0] != 1 {
+		t.Errorf("expected organizer 1, got %v", organizers)
+	}
+}
+
+func TestTeamService_AcceptExpiredInvitation(t *testing.T) {
+	service, _, _, sender := newTestTeamService()
+	if _, err := service.Invite(1, "door@example.com", []models.TeamPermission{models.PermissionCheckIn}, "en"); err != nil {
+		t.Fatalf("failed to invite: %v", err)
+	}
+
+	service.now = func() time.Time { return time.Date(2026, 3, 9, 13, 0, 0, 0, time.UTC) }
+	if _, err := service.AcceptInvitation(invitationToken(t, sender.links[0]), 2); err != ErrTeamInvitationExpired {
+		t.Errorf("expected ErrTeamInvitationExpired, got %v", err)
+	}
+}
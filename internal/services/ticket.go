@@ -6,6 +6,7 @@ import (
 	"fmt"
 	"time"
 
+	"event-ticketing-platform/internal/cache"
 	"event-ticketing-platform/internal/models"
 	"event-ticketing-platform/internal/repositories"
 )
@@ -19,6 +20,9 @@ type TicketRepository interface {
 	DeleteTicketType(id int) error
 	ReserveTickets(ticketTypeID, quantity, userID int, expirationMinutes int) (*repositories.TicketReservation, error)
 	ReleaseReservation(reservationID string, ticketTypeID, quantity int) error
+	HoldTickets(userID int, holds []repositories.TicketHold, expirationMinutes int) ([]*repositories.TicketReservation, error)
+	ReleaseUserReservations(userID int, ticketTypeIDs []int) error
+	DeleteExpiredReservations() (int64, error)
 	CreateTicket(orderID, ticketTypeID int, qrCode string) (*models.Ticket, error)
 	GetTicketByID(id int) (*models.Ticket, error)
 	GetTicketByQRCode(qrCode string) (*models.Ticket, error)
@@ -87,8 +91,42 @@ type TicketService struct {
 	authService    *AuthService
 	pdfService     *PDFService
 	reservationTTL int // Reservation time-to-live in minutes
+	cache          cache.Cache
+	walletPasses   *WalletPassService
+	arrivalSlots   ArrivalSlotLookup
+	answers        CheckoutAnswerRecorder
+	attendees      TicketAttendeeAssigner
+	tiers          TicketTierRepository
+	priceHistory   PriceChangeRecorder
+	events         *DomainEventBus
+	availability   *AvailabilityBroker
+	taxes          TaxRateLookup
+	limits         OrderLimitSettings
 }
 
+// OrderLimitSettings provides the system settings that limit order sizes
+type OrderLimitSettings interface {
+	GetSettings() (*models.SystemSettings, error)
+}
+
+// defaultMaxTicketsPerReservation is the most tickets reserved at once while
+// the system settings aren't known
+const defaultMaxTicketsPerReservation = 10
+
+// PriceChangeRecorder records ticket type prices as they change. oldPrice is
+// nil for new ticket types.
+type PriceChangeRecorder interface {
+	TicketPriceChanged(ticketType *models.TicketType, oldPrice *int)
+}
+
+// Ticket availability is polled by every open event page, so it is cached
+// briefly and served stale while it reloads
+const (
+	availabilityCachePrefix   = "availability:"
+	availabilityCacheTTL      = 5 * time.Second
+	availabilityCacheStaleTTL = 25 * time.Second
+)
+
 // NewTicketService creates a new ticket service
 func NewTicketService(
 	ticketRepo TicketRepository,
@@ -112,6 +150,110 @@ func NewTicketService(
 	}
 }
 
+// SetEventBus sets the bus completed purchases and refunds are published on
+func (s *TicketService) SetEventBus(events *DomainEventBus) {
+	s.events = events
+}
+
+// SetAvailabilityBroker sets the broker live availability streams are
+// notified through when availability changes
+func (s *TicketService) SetAvailabilityBroker(broker *AvailabilityBroker) {
+	s.availability = broker
+}
+
+// SetArrivalSlots prints the arrival time buyers chose on their tickets
+func (s *TicketService) SetArrivalSlots(arrivalSlots ArrivalSlotLookup) {
+	s.arrivalSlots = arrivalSlots
+}
+
+// SetCheckoutAnswers stores the answers attendees give to events' checkout
+// questions with their tickets
+func (s *TicketService) SetCheckoutAnswers(answers CheckoutAnswerRecorder) {
+	s.answers = answers
+}
+
+// SetAttendees names each ticket with the attendee given for it at checkout
+func (s *TicketService) SetAttendees(attendees TicketAttendeeAssigner) {
+	s.attendees = attendees
+}
+
+// SetTaxRates charges the tax organizers set on the tickets bought
+func (s *TicketService) SetTaxRates(taxes TaxRateLookup) {
+	s.taxes = taxes
+}
+
+// SetOrderLimits limits how many tickets are reserved at once to the
+// maximum the system settings allow
+func (s *TicketService) SetOrderLimits(limits OrderLimitSettings) {
+	s.limits = limits
+}
+
+// maxTicketsPerReservation returns the most tickets a buyer can reserve at once
+func (s *TicketService) maxTicketsPerReservation() int {
+	if s.limits == nil {
+		return defaultMaxTicketsPerReservation
+	}
+	settings, err := s.limits.GetSettings()
+	if err != nil || settings.MaxTicketsPerOrder <= 0 {
+		return defaultMaxTicketsPerReservation
+	}
+	return settings.MaxTicketsPerOrder
+}
+
+// withArrivalSlot attaches the arrival slot the order chose, if any, so it
+// can be printed on the tickets
+func (s *TicketService) withArrivalSlot(order *models.Order) *models.Order {
+	if s.arrivalSlots == nil || order.ArrivalSlotID == nil || order.ArrivalSlot != nil {
+		return order
+	}
+	slot, err := s.arrivalSlots.GetSlot(*order.ArrivalSlotID)
+	if err != nil {
+		fmt.Printf("Warning: failed to get arrival slot for order %s: %v\n", order.OrderNumber, err)
+		return order
+	}
+	withSlot := *order
+	withSlot.ArrivalSlot = slot
+	return &withSlot
+}
+
+// SetPriceHistory records every ticket type's prices as they change
+func (s *TicketService) SetPriceHistory(priceHistory PriceChangeRecorder) {
+	s.priceHistory = priceHistory
+}
+
+// SetCache enables caching of ticket availability
+func (s *TicketService) SetCache(c cache.Cache) {
+	s.cache = c
+}
+
+// GetTicketAvailability returns an event's ticket types for the availability
+// poll. It may be a few seconds stale; purchases invalidate it.
+func (s *TicketService) GetTicketAvailability(eventID int) ([]*models.TicketType, error) {
+	key := cache.Key(availabilityCachePrefix+"event", eventID)
+	return cache.RememberStale(s.cache, key, availabilityCacheTTL, availabilityCacheStaleTTL, func() ([]*models.TicketType, error) {
+		return s.ticketRepo.GetTicketTypesByEvent(eventID)
+	})
+}
+
+// InvalidateAvailability drops the cached ticket availability of an event
+// and tells the live streams watching it
+func (s *TicketService) InvalidateAvailability(eventID int) {
+	if s.cache != nil {
+		if err := s.cache.Delete(cache.Key(availabilityCachePrefix+"event", eventID)); err != nil {
+			fmt.Printf("Warning: failed to invalidate ticket availability for event %d: %v\n", eventID, err)
+		}
+	}
+	s.availability.Notify(eventID)
+}
+
+// OrderCompleted puts the next tier on sale for any tier the order sold out
+// and drops the cached availability of the order's event. It implements
+// OrderCompletionHook.
+func (s *TicketService) OrderCompleted(order *models.Order) {
+	s.AdvanceTiers(order.EventID)
+	s.InvalidateAvailability(order.EventID)
+}
+
 // TicketReservationRequest represents a request to reserve tickets
 type TicketReservationRequest struct {
 	TicketTypeID int `json:"ticket_type_id"`
@@ -127,12 +269,18 @@ type TicketPurchaseRequest struct {
 	BillingInfo     PaymentBillingInfo `json:"billing_info"`
 	PaymentMethod   string             `json:"payment_method"`
 	UserID          int                `json:"user_id"`
+	Locale          string             `json:"locale"` // Email language chosen at checkout
+	ArrivalSlotID   *int               `json:"arrival_slot_id,omitempty"`
+	Answers         []models.AttendeeAnswers `json:"answers,omitempty"` // Checkout answers of each attendee, in cart order
+	Attendees       []models.TicketAttendee  `json:"attendees,omitempty"` // Who each ticket is for, in cart order
+	Donation        int                      `json:"donation"`            // Added to the total, in cents
 }
 
 // TicketSelection represents a selection of tickets to purchase
 type TicketSelection struct {
 	TicketTypeID int `json:"ticket_type_id"`
 	Quantity     int `json:"quantity"`
+	Price        int `json:"price"` // Per ticket; the amount chosen for pay-what-you-want ticket types
 }
 
 // PurchaseResult represents the result of a ticket purchase
@@ -181,7 +329,7 @@ func (s *TicketService) ReserveTickets(req *TicketReservationRequest) (*reposito
 	}
 
 	// Limit maximum tickets per reservation (business rule)
-	maxTicketsPerReservation := 10
+	maxTicketsPerReservation := s.maxTicketsPerReservation()
 	if req.Quantity > maxTicketsPerReservation {
 		return nil, fmt.Errorf("cannot reserve more than %d tickets at once", maxTicketsPerReservation)
 	}
@@ -197,6 +345,9 @@ func (s *TicketService) ReserveTickets(req *TicketReservationRequest) (*reposito
 		return nil, fmt.Errorf("failed to reserve tickets: %w", err)
 	}
 
+	// Reservations are unavailable to others until they expire
+	s.InvalidateAvailability(ticketType.EventID)
+
 	return reservation, nil
 }
 
@@ -210,6 +361,45 @@ func (s *TicketService) ReleaseReservation(reservationID string, ticketTypeID, q
 	return nil
 }
 
+// HoldTickets reserves a checkout's tickets for the user for the reservation
+// TTL, so nobody else can buy them while the user pays. Holding again
+// replaces the user's earlier holds on the same ticket types.
+func (s *TicketService) HoldTickets(eventID, userID int, selections []TicketSelection) error {
+	holds := make([]repositories.TicketHold, 0, len(selections))
+	for _, selection := range selections {
+		holds = append(holds, repositories.TicketHold{TicketTypeID: selection.TicketTypeID, Quantity: selection.Quantity})
+	}
+
+	if _, err := s.ticketRepo.HoldTickets(userID, holds, s.reservationTTL); err != nil {
+		return err
+	}
+
+	s.InvalidateAvailability(eventID)
+	return nil
+}
+
+// ReleaseHolds gives back the tickets held for a checkout that didn't
+// complete, rather than leaving them unavailable until the holds expire
+func (s *TicketService) ReleaseHolds(eventID, userID int, selections []TicketSelection) {
+	ticketTypeIDs := make([]int, 0, len(selections))
+	for _, selection := range selections {
+		ticketTypeIDs = append(ticketTypeIDs, selection.TicketTypeID)
+	}
+
+	if err := s.ticketRepo.ReleaseUserReservations(userID, ticketTypeIDs); err != nil {
+		fmt.Printf("Warning: failed to release held tickets for user %d: %v\n", userID, err)
+		return
+	}
+
+	s.InvalidateAvailability(eventID)
+}
+
+// DeleteExpiredReservations deletes expired holds, returning how many were
+// deleted. It is run periodically in the background.
+func (s *TicketService) DeleteExpiredReservations() (int64, error) {
+	return s.ticketRepo.DeleteExpiredReservations()
+}
+
 // PurchaseTickets processes a ticket purchase with payment
 func (s *TicketService) PurchaseTickets(req *TicketPurchaseRequest) (*PurchaseResult, error) {
 	// Validate user permissions
@@ -223,19 +413,51 @@ func (s *TicketService) PurchaseTickets(req *TicketPurchaseRequest) (*PurchaseRe
 	if err != nil {
 		return nil, fmt.Errorf("invalid ticket selection: %w", err)
 	}
+	if err := models.ValidateDonation(req.Donation); err != nil {
+		return nil, fmt.Errorf("invalid donation: %w", err)
+	}
+	// Donations aren't taxed, so the tax is worked out on the tickets alone
+	var tax *models.TaxLine
+	if s.taxes != nil {
+		rate, err := s.taxes.EventTaxRate(req.EventID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get tax rate: %w", err)
+		}
+		if rate != nil {
+			tax = rate.Apply(totalAmount)
+		}
+	}
+	totalAmount += req.Donation + tax.Added()
+
+	itemPrices := make([]models.OrderItemPrice, 0, len(ticketDetails))
+	for _, detail := range ticketDetails {
+		itemPrices = append(itemPrices, models.OrderItemPrice{TicketTypeID: detail.TicketTypeID, UnitPrice: detail.Price})
+	}
+
+	// Hold the tickets while the payment is processed, so they can't be sold
+	// to someone else in the meantime
+	if err := s.HoldTickets(req.EventID, req.UserID, ticketDetails); err != nil {
+		return nil, fmt.Errorf("tickets could not be reserved: %w", err)
+	}
 
 	// Create pending order
 	orderReq := &models.OrderCreateRequest{
-		UserID:       req.UserID,
-		EventID:      req.EventID,
-		TotalAmount:  totalAmount,
-		BillingEmail: req.BillingInfo.Email,
-		BillingName:  req.BillingInfo.Name,
-		Status:       models.OrderPending,
+		UserID:         req.UserID,
+		EventID:        req.EventID,
+		TotalAmount:    totalAmount,
+		BillingEmail:   req.BillingInfo.Email,
+		BillingName:    req.BillingInfo.Name,
+		Locale:         req.Locale,
+		ArrivalSlotID:  req.ArrivalSlotID,
+		Status:         models.OrderPending,
+		DonationAmount: req.Donation,
+		Tax:            tax,
+		ItemPrices:     itemPrices,
 	}
 
 	order, err := s.orderRepo.Create(orderReq)
 	if err != nil {
+		s.ReleaseHolds(req.EventID, req.UserID, ticketDetails)
 		return nil, fmt.Errorf("failed to create order: %w", err)
 	}
 
@@ -248,12 +470,14 @@ func (s *TicketService) PurchaseTickets(req *TicketPurchaseRequest) (*PurchaseRe
 	if err != nil {
 		// Cancel the order if payment fails
 		s.orderRepo.UpdateStatus(order.ID, models.OrderCancelled)
+		s.ReleaseHolds(req.EventID, req.UserID, ticketDetails)
 		return nil, fmt.Errorf("payment processing failed: %w", err)
 	}
 
 	if paymentResult.Status != "success" {
 		// Cancel the order if payment is not successful
 		s.orderRepo.UpdateStatus(order.ID, models.OrderCancelled)
+		s.ReleaseHolds(req.EventID, req.UserID, ticketDetails)
 		return nil, fmt.Errorf("payment failed: %s", paymentResult.ErrorMessage)
 	}
 
@@ -270,6 +494,7 @@ func (s *TicketService) PurchaseTickets(req *TicketPurchaseRequest) (*PurchaseRe
 				// Refund payment and cancel order
 				s.paymentService.RefundPayment(paymentResult.PaymentID, totalAmount)
 				s.orderRepo.UpdateStatus(order.ID, models.OrderCancelled)
+				s.ReleaseHolds(req.EventID, req.UserID, ticketDetails)
 				return nil, fmt.Errorf("failed to generate QR code: %w", err)
 			}
 
@@ -289,6 +514,7 @@ func (s *TicketService) PurchaseTickets(req *TicketPurchaseRequest) (*PurchaseRe
 		// Refund payment and cancel order
 		s.paymentService.RefundPayment(paymentResult.PaymentID, totalAmount)
 		s.orderRepo.UpdateStatus(order.ID, models.OrderCancelled)
+		s.ReleaseHolds(req.EventID, req.UserID, ticketDetails)
 		return nil, fmt.Errorf("failed to complete order: %w", err)
 	}
 
@@ -298,12 +524,28 @@ func (s *TicketService) PurchaseTickets(req *TicketPurchaseRequest) (*PurchaseRe
 		return nil, fmt.Errorf("failed to get completed order: %w", err)
 	}
 
+	// Name the tickets before loading them, so they are returned with their attendees
+	if s.attendees != nil {
+		if err := s.attendees.AssignAttendees(order.ID, req.Attendees); err != nil {
+			fmt.Printf("Warning: failed to name tickets for order %s: %v\n", order.OrderNumber, err)
+		}
+	}
+
 	// Get the created tickets
 	tickets, err := s.ticketRepo.GetTicketsByOrder(order.ID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get created tickets: %w", err)
 	}
 
+	// The tickets are paid for, so failing to keep the answers does not fail the purchase
+	if s.answers != nil {
+		if err := s.answers.RecordAnswers(order.ID, req.Answers); err != nil {
+			fmt.Printf("Warning: failed to record checkout answers for order %s: %v\n", order.OrderNumber, err)
+		}
+	}
+
+	s.events.PublishOrderCompleted(completedOrder)
+
 	return &PurchaseResult{
 		Order:       completedOrder,
 		Tickets:     tickets,
@@ -347,20 +589,72 @@ func (s *TicketService) RefundTickets(orderID int, requestingUserID int) (*Refun
 		}
 	}
 
-	// Process refund
-	refundResult, err := s.paymentService.RefundPayment(order.PaymentID, order.TotalAmount)
+	// Process refund. Box office sales were paid at the door, so they are
+	// refunded there too.
+	refundResult := &RefundResult{Status: "success", Amount: order.TotalAmount, ProcessedAt: time.Now()}
+	if !models.IsBoxOfficePayment(order.PaymentID) {
+		refundResult, err = s.paymentService.RefundPayment(order.PaymentID, order.TotalAmount)
+		if err != nil {
+			return nil, fmt.Errorf("refund processing failed: %w", err)
+		}
+
+		if refundResult.Status != "success" {
+			return nil, fmt.Errorf("refund failed: %s", refundResult.ErrorMessage)
+		}
+	}
+
+	if err := s.markRefunded(order, tickets); err != nil {
+		return nil, err
+	}
+
+	return refundResult, nil
+}
+
+// RefundCancelledOrder refunds an order in full because its event was
+// cancelled. Unlike RefundTickets it needs no buyer request and also refunds
+// tickets that were already scanned. Free orders and box office sales are
+// voided without calling the payment provider.
+func (s *TicketService) RefundCancelledOrder(orderID int) (*RefundResult, error) {
+	order, err := s.orderRepo.GetByID(orderID)
 	if err != nil {
-		return nil, fmt.Errorf("refund processing failed: %w", err)
+		return nil, fmt.Errorf("order not found: %w", err)
+	}
+
+	if !order.CanBeRefunded() {
+		return nil, fmt.Errorf("order cannot be refunded in current status: %s", order.Status)
+	}
+
+	tickets, err := s.ticketRepo.GetTicketsByOrder(orderID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get order tickets: %w", err)
 	}
 
-	if refundResult.Status != "success" {
-		return nil, fmt.Errorf("refund failed: %s", refundResult.ErrorMessage)
+	refundResult := &RefundResult{Status: "success", ProcessedAt: time.Now()}
+	if order.TotalAmount > 0 && !models.IsBoxOfficePayment(order.PaymentID) {
+		refundResult, err = s.paymentService.RefundPayment(order.PaymentID, order.TotalAmount)
+		if err != nil {
+			return nil, fmt.Errorf("refund processing failed: %w", err)
+		}
+
+		if refundResult.Status != "success" {
+			return nil, fmt.Errorf("refund failed: %s", refundResult.ErrorMessage)
+		}
+	}
+
+	if err := s.markRefunded(order, tickets); err != nil {
+		return nil, err
 	}
 
+	return refundResult, nil
+}
+
+// markRefunded records a refunded order, voids its tickets and publishes the
+// refund
+func (s *TicketService) markRefunded(order *models.Order, tickets []*models.Ticket) error {
 	// Update order status to refunded
-	err = s.orderRepo.UpdateStatus(orderID, models.OrderRefunded)
+	err := s.orderRepo.UpdateStatus(order.ID, models.OrderRefunded)
 	if err != nil {
-		return nil, fmt.Errorf("failed to update order status: %w", err)
+		return fmt.Errorf("failed to update order status: %w", err)
 	}
 
 	// Update all tickets to refunded status
@@ -372,7 +666,9 @@ func (s *TicketService) RefundTickets(orderID int, requestingUserID int) (*Refun
 		}
 	}
 
-	return refundResult, nil
+	s.events.PublishRefundIssued(order)
+
+	return nil
 }
 
 // ValidateTicket validates a ticket by QR code (for event entry)
@@ -478,7 +774,9 @@ func (s *TicketService) GetEventTickets(eventID int, requestingUserID int) ([]*m
 	return tickets, nil
 }
 
-// validateAndCalculateTotal validates ticket selections and calculates total amount
+// validateAndCalculateTotal validates ticket selections and calculates total
+// amount. Selections of a finished tier are moved to the tier now on sale,
+// and each is returned with the price its tickets sell at.
 func (s *TicketService) validateAndCalculateTotal(selections []TicketSelection) (int, []TicketSelection, error) {
 	if len(selections) == 0 {
 		return 0, nil, fmt.Errorf("no tickets selected")
@@ -492,11 +790,12 @@ func (s *TicketService) validateAndCalculateTotal(selections []TicketSelection)
 			continue // Skip invalid quantities
 		}
 
-		// Get ticket type
-		ticketType, err := s.ticketRepo.GetTicketTypeByID(selection.TicketTypeID)
+		// Get ticket type, or the tier now on sale in its place
+		ticketType, err := s.ResolveActiveTier(selection.TicketTypeID)
 		if err != nil {
 			return 0, nil, fmt.Errorf("ticket type %d not found", selection.TicketTypeID)
 		}
+		selection.TicketTypeID = ticketType.ID
 
 		// Check availability
 		if !ticketType.IsAvailable() {
@@ -509,7 +808,12 @@ func (s *TicketService) validateAndCalculateTotal(selections []TicketSelection)
 		}
 
 		// Calculate amount for this selection
-		selectionAmount := ticketType.Price * selection.Quantity
+		price, err := ticketType.ChoosePrice(selection.Price)
+		if err != nil {
+			return 0, nil, err
+		}
+		selection.Price = price
+		selectionAmount := price * selection.Quantity
 		totalAmount += selectionAmount
 
 		validSelections = append(validSelections, selection)
@@ -528,6 +832,11 @@ func (s *TicketService) validateAndCalculateTotal(selections []TicketSelection)
 
 // generateQRCode generates a unique QR code for a ticket
 func (s *TicketService) generateQRCode(orderID, ticketTypeID int) (string, error) {
+	return generateTicketQRCode(orderID, ticketTypeID)
+}
+
+// generateTicketQRCode generates a unique QR code for a ticket
+func generateTicketQRCode(orderID, ticketTypeID int) (string, error) {
 	// Generate random bytes for uniqueness
 	randomBytes := make([]byte, 16)
 	if _, err := rand.Read(randomBytes); err != nil {
@@ -547,7 +856,60 @@ func (s *TicketService) GenerateTicketsPDF(tickets []*models.Ticket, event *mode
 		return nil, fmt.Errorf("PDF service not available")
 	}
 
-	return s.pdfService.GenerateTicketsPDF(tickets, event, order)
+	return s.pdfService.GenerateTicketsPDF(tickets, event, s.withArrivalSlot(order))
+}
+
+// SetWalletPassService enables adding tickets to Apple Wallet and Google Wallet
+func (s *TicketService) SetWalletPassService(walletPasses *WalletPassService) {
+	s.walletPasses = walletPasses
+}
+
+// WalletPassOptions reports which wallets tickets can be added to
+func (s *TicketService) WalletPassOptions() WalletPassOptions {
+	if s.walletPasses == nil {
+		return WalletPassOptions{}
+	}
+	return s.walletPasses.Options()
+}
+
+// GenerateApplePass generates a signed Apple Wallet pass for a ticket
+func (s *TicketService) GenerateApplePass(ticket *models.Ticket, event *models.Event, order *models.Order) ([]byte, error) {
+	if s.walletPasses == nil {
+		return nil, fmt.Errorf("wallet passes not available")
+	}
+	if ticket.Status == models.TicketRefunded {
+		return nil, fmt.Errorf("ticket has been refunded")
+	}
+
+	return s.walletPasses.ApplePass(s.walletPassTicket(ticket, event, order))
+}
+
+// GoogleWalletURL returns an "Add to Google Wallet" link for the given tickets.
+// Refunded tickets are left out.
+func (s *TicketService) GoogleWalletURL(tickets []*models.Ticket, event *models.Event, order *models.Order) (string, error) {
+	if s.walletPasses == nil {
+		return "", fmt.Errorf("wallet passes not available")
+	}
+
+	var passes []*WalletPassTicket
+	for _, ticket := range tickets {
+		if ticket.Status != models.TicketRefunded {
+			passes = append(passes, s.walletPassTicket(ticket, event, order))
+		}
+	}
+	if len(passes) == 0 {
+		return "", fmt.Errorf("ticket has been refunded")
+	}
+
+	return s.walletPasses.GoogleSaveURL(passes)
+}
+
+func (s *TicketService) walletPassTicket(ticket *models.Ticket, event *models.Event, order *models.Order) *WalletPassTicket {
+	pass := &WalletPassTicket{Ticket: ticket, Event: event, Order: s.withArrivalSlot(order)}
+	if ticketType, err := s.ticketRepo.GetTicketTypeByID(ticket.TicketTypeID); err == nil {
+		pass.TicketType = ticketType
+	}
+	return pass
 }
 
 // GetTicketTypesByEventID retrieves ticket types for an event
@@ -564,17 +926,53 @@ func (s *TicketService) GetTicketTypeByID(id int) (*models.TicketType, error) {
 
 // CreateTicketType creates a new ticket type
 func (s *TicketService) CreateTicketType(req *models.TicketTypeCreateRequest) (*models.TicketType, error) {
-	return s.ticketRepo.CreateTicketType(req)
+	ticketType, err := s.ticketRepo.CreateTicketType(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.priceHistory != nil {
+		s.priceHistory.TicketPriceChanged(ticketType, nil)
+	}
+	s.InvalidateAvailability(ticketType.EventID)
+	return ticketType, nil
 }
 
 // UpdateTicketType updates an existing ticket type
 func (s *TicketService) UpdateTicketType(id int, req *models.TicketTypeUpdateRequest) (*models.TicketType, error) {
-	return s.ticketRepo.UpdateTicketType(id, req)
+	var oldPrice *int
+	if s.priceHistory != nil {
+		if previous, err := s.ticketRepo.GetTicketTypeByID(id); err == nil {
+			price := previous.Price
+			oldPrice = &price
+		}
+	}
+
+	ticketType, err := s.ticketRepo.UpdateTicketType(id, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if oldPrice != nil && *oldPrice != ticketType.Price {
+		s.priceHistory.TicketPriceChanged(ticketType, oldPrice)
+	}
+	s.InvalidateAvailability(ticketType.EventID)
+	return ticketType, nil
 }
 
 // DeleteTicketType deletes a ticket type
 func (s *TicketService) DeleteTicketType(id int) error {
-	return s.ticketRepo.DeleteTicketType(id)
+	ticketType, err := s.ticketRepo.GetTicketTypeByID(id)
+	if err != nil {
+		return err
+	}
+
+	if err := s.ticketRepo.DeleteTicketType(id); err != nil {
+		return err
+	}
+
+	s.InvalidateAvailability(ticketType.EventID)
+	return nil
 }
 
 // GetTicketByID retrieves a ticket by ID
@@ -0,0 +1,136 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Named ticket errors shown to buyers
+var (
+	ErrAttendeeEditClosed = errors.New("attendee details can no longer be changed for this event")
+	ErrTicketNotNameable  = errors.New("only active tickets can be named")
+)
+
+// DefaultAttendeeEditCutoff is how long before an event starts buyers stop
+// being able to change who its tickets are for
+const DefaultAttendeeEditCutoff = 24 * time.Hour
+
+// TicketAttendeeRepository defines the data operations for named tickets
+type TicketAttendeeRepository interface {
+	GetTicketByID(id int) (*models.Ticket, error)
+	GetTicketsByOrder(orderID int) ([]*models.Ticket, error)
+	UpdateTicketAttendee(id int, attendee models.TicketAttendee) error
+}
+
+// TicketAttendeeAssigner names an order's tickets with the attendees given
+// at checkout
+type TicketAttendeeAssigner interface {
+	AssignAttendees(orderID int, attendees []models.TicketAttendee) error
+}
+
+// TicketAttendeeService manages who each ticket is for. Buyers name tickets
+// at checkout and can change the names until the cutoff before the event.
+type TicketAttendeeService struct {
+	tickets   TicketAttendeeRepository
+	orderRepo OrderRepository
+	eventRepo EventRepository
+	cutoff    time.Duration
+	now       func() time.Time
+}
+
+// NewTicketAttendeeService creates a new ticket attendee service. A cutoff
+// of zero or less uses DefaultAttendeeEditCutoff.
+func NewTicketAttendeeService(tickets TicketAttendeeRepository, orderRepo OrderRepository, eventRepo EventRepository, cutoff time.Duration) *TicketAttendeeService {
+	if cutoff <= 0 {
+		cutoff = DefaultAttendeeEditCutoff
+	}
+	return &TicketAttendeeService{
+		tickets:   tickets,
+		orderRepo: orderRepo,
+		eventRepo: eventRepo,
+		cutoff:    cutoff,
+		now:       time.Now,
+	}
+}
+
+// EditableUntil returns when buyers stop being able to change who the
+// event's tickets are for
+func (s *TicketAttendeeService) EditableUntil(event *models.Event) time.Time {
+	return event.StartDate.Add(-s.cutoff)
+}
+
+// CanEdit returns true if the event's tickets can still be renamed
+func (s *TicketAttendeeService) CanEdit(event *models.Event) bool {
+	return s.now().Before(s.EditableUntil(event))
+}
+
+// AssignAttendees names each of an order's tickets. Attendees are in the
+// order of the cart, which is the order the tickets were created in.
+func (s *TicketAttendeeService) AssignAttendees(orderID int, attendees []models.TicketAttendee) error {
+	if len(attendees) == 0 {
+		return nil
+	}
+
+	tickets, err := s.tickets.GetTicketsByOrder(orderID)
+	if err != nil {
+		return fmt.Errorf("failed to get order tickets: %w", err)
+	}
+	if len(tickets) != len(attendees) {
+		return errors.New("the number of attendees does not match the order's tickets")
+	}
+	sort.Slice(tickets, func(i, j int) bool { return tickets[i].ID < tickets[j].ID })
+
+	for i, ticket := range tickets {
+		if attendees[i].IsEmpty() {
+			continue
+		}
+		if err := s.tickets.UpdateTicketAttendee(ticket.ID, attendees[i]); err != nil {
+			return fmt.Errorf("failed to name ticket %d: %w", ticket.ID, err)
+		}
+	}
+	return nil
+}
+
+// UpdateAttendee changes who one of the user's tickets is for. Once the
+// ticket is known to be the user's, it is returned with any error.
+func (s *TicketAttendeeService) UpdateAttendee(ticketID, userID int, attendee models.TicketAttendee) (*models.Ticket, error) {
+	ticket, err := s.tickets.GetTicketByID(ticketID)
+	if err != nil {
+		return nil, fmt.Errorf("ticket not found: %w", err)
+	}
+
+	order, err := s.orderRepo.GetByID(ticket.OrderID)
+	if err != nil {
+		return nil, fmt.Errorf("order not found: %w", err)
+	}
+	if order.UserID != userID {
+		return nil, errors.New("ticket does not belong to this user")
+	}
+	if order.Status != models.OrderCompleted || ticket.Status != models.TicketActive {
+		return ticket, ErrTicketNotNameable
+	}
+
+	event, err := s.eventRepo.GetByID(order.EventID)
+	if err != nil {
+		return nil, fmt.Errorf("event not found: %w", err)
+	}
+	if !s.CanEdit(event) {
+		return ticket, ErrAttendeeEditClosed
+	}
+
+	if err := attendee.Validate(); err != nil {
+		return ticket, err
+	}
+
+	if err := s.tickets.UpdateTicketAttendee(ticket.ID, attendee); err != nil {
+		return nil, fmt.Errorf("failed to update attendee: %w", err)
+	}
+
+	ticket.AttendeeName = attendee.Name
+	ticket.AttendeeEmail = attendee.Email
+	return ticket, nil
+}
@@ -0,0 +1,95 @@
+package services
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+func (m *mockTicketRepository) UpdateTicketAttendee(id int, attendee models.TicketAttendee) error {
+	ticket, exists := m.tickets[id]
+	if !exists {
+		return errors.New("ticket not found")
+	}
+
+	ticket.AttendeeName = attendee.Name
+	ticket.AttendeeEmail = attendee.Email
+	return nil
+}
+
+func setupTicketAttendeeService(startsIn time.Duration) (*TicketAttendeeService, *mockTicketRepository, *models.Order) {
+	ticketRepo := newMockTicketRepository()
+	orderRepo := newMockOrderRepository()
+	eventRepo := newMockEventRepository()
+
+	now := time.Date(2026, 6, 1, 12, 0, 0, 0, time.UTC)
+	eventRepo.events[1] = &models.Event{ID: 1, Title: "Jazz Night", OrganizerID: 7, StartDate: now.Add(startsIn)}
+
+	order, _ := orderRepo.Create(&models.OrderCreateRequest{UserID: 3, EventID: 1, Status: models.OrderCompleted})
+	ticketRepo.CreateTicket(order.ID, 1, "QR-1")
+	ticketRepo.CreateTicket(order.ID, 1, "QR-2")
+
+	service := NewTicketAttendeeService(ticketRepo, orderRepo, eventRepo, 0)
+	service.now = func() time.Time { return now }
+	return service, ticketRepo, order
+}
+
+func TestTicketAttendeeService_AssignAttendees(t *testing.T) {
+	service, ticketRepo, order := setupTicketAttendeeService(72 * time.Hour)
+
+	attendees := []models.TicketAttendee{{Name: "Amina Otieno", Email: "amina@example.com"}, {}}
+	if err := service.AssignAttendees(order.ID, attendees); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	first, _ := ticketRepo.GetTicketByQRCode("QR-1")
+	second, _ := ticketRepo.GetTicketByQRCode("QR-2")
+	if first.AttendeeName != "Amina Otieno" || first.AttendeeEmail != "amina@example.com" {
+		t.Errorf("expected the first ticket to be named, got %q %q", first.AttendeeName, first.AttendeeEmail)
+	}
+	if second.AttendeeName != "" {
+		t.Errorf("expected unnamed attendees to leave the ticket unnamed, got %q", second.AttendeeName)
+	}
+
+	if err := service.AssignAttendees(order.ID, attendees[:1]); err == nil {
+		t.Error("expected an error when attendees do not match the order's tickets")
+	}
+}
+
+func TestTicketAttendeeService_UpdateAttendee(t *testing.T) {
+	service, _, _ := setupTicketAttendeeService(72 * time.Hour)
+
+	ticket, err := service.UpdateAttendee(1, 3, models.TicketAttendee{Name: " Brian Mwangi "})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if ticket.AttendeeName != "Brian Mwangi" {
+		t.Errorf("expected the trimmed name, got %q", ticket.AttendeeName)
+	}
+
+	if _, err := service.UpdateAttendee(1, 99, models.TicketAttendee{Name: "Someone"}); err == nil {
+		t.Error("expected other users not to be able to rename the ticket")
+	}
+	if _, err := service.UpdateAttendee(1, 3, models.TicketAttendee{Email: "brian@example.com"}); err == nil {
+		t.Error("expected an email without a name to be refused")
+	}
+}
+
+func TestTicketAttendeeService_UpdateAttendeeAfterCutoff(t *testing.T) {
+	service, ticketRepo, _ := setupTicketAttendeeService(12 * time.Hour)
+
+	ticket, err := service.UpdateAttendee(1, 3, models.TicketAttendee{Name: "Late Change"})
+	if !errors.Is(err, ErrAttendeeEditClosed) {
+		t.Fatalf("expected ErrAttendeeEditClosed, got %v", err)
+	}
+	if ticket == nil || ticket.OrderID == 0 {
+		t.Error("expected the ticket to be returned with the error")
+	}
+
+	stored, _ := ticketRepo.GetTicketByID(1)
+	if stored.AttendeeName != "" {
+		t.Errorf("expected the ticket not to be renamed, got %q", stored.AttendeeName)
+	}
+}
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// icsTimeFormat is the UTC date-time format used in iCalendar files
+const icsTimeFormat = "20060102T150405Z"
+
+// icsProductID identifies the platform as the producer of calendar files
+const icsProductID = "-//Event Ticketing Platform//Tickets//EN"
+
+// TicketCalendarRepository defines the data operations for attendee calendars
+type TicketCalendarRepository interface {
+	GetTicketedEventsForUser(userID int, from time.Time) ([]*models.Event, error)
+}
+
+// TicketCalendarService builds iCalendar files so attendees can add events
+// they hold tickets for to their own calendars
+type TicketCalendarService struct {
+	repo       TicketCalendarRepository
+	baseURL    string
+	feedSecret []byte
+	now        func() time.Time
+}
+
+// NewTicketCalendarService creates a new ticket calendar service. feedSecret
+// signs the personal feed URLs calendar apps subscribe to.
+func NewTicketCalendarService(repo TicketCalendarRepository, baseURL, feedSecret string) *TicketCalendarService {
+	return &TicketCalendarService{
+		repo:       repo,
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		feedSecret: []byte(feedSecret),
+		now:        time.Now,
+	}
+}
+
+// EventURL returns the public URL of an event
+func (s *TicketCalendarService) EventURL(eventID int) string {
+	return fmt.Sprintf("%s/events/%d", s.baseURL, eventID)
+}
+
+// EventICS returns an iCalendar file containing a single event
+func (s *TicketCalendarService) EventICS(event *models.Event) []byte {
+	w := &icsWriter{}
+	w.begin(event.Title)
+	s.writeEvent(w, event)
+	w.end()
+	return w.buf.Bytes()
+}
+
+// GetAttendeeFeed returns an iCalendar feed of every upcoming event the user
+// holds tickets for
+func (s *TicketCalendarService) GetAttendeeFeed(userID int) ([]byte, error) {
+	events, err := s.repo.GetTicketedEventsForUser(userID, s.now())
+	if err != nil {
+		return nil, err
+	}
+
+	w := &icsWriter{}
+	w.begin("My Tickets")
+	// Ask subscribed calendar apps to check for new purchases hourly
+	w.line("REFRESH-INTERVAL;VALUE=DURATION", "PT1H")
+	w.line("X-PUBLISHED-TTL", "PT1H")
+	for _, event := range events {
+		s.writeEvent(w, event)
+	}
+	w.end()
+
+	return w.buf.Bytes(), nil
+}
+
+// FeedURL returns the user's personal calendar feed URL. It is signed so
+// calendar apps can fetch it without a session.
+func (s *TicketCalendarService) FeedURL(userID int) string {
+	values := url.Values{}
+	values.Set("user", fmt.Sprintf("%d", userID))
+	values.Set("token", s.FeedToken(userID))
+	return s.baseURL + "/dashboard/calendar.ics?" + values.Encode()
+}
+
+// FeedToken returns the token that authenticates a user's calendar feed
+func (s *TicketCalendarService) FeedToken(userID int) string {
+	mac := hmac.New(sha256.New, s.feedSecret)
+	fmt.Fprintf(mac, "calendar-feed:%d", userID)
+	return hex.EncodeToString(mac.Sum(nil)[:16])
+}
+
+// ValidFeedToken checks a calendar feed token for a user
+func (s *TicketCalendarService) ValidFeedToken(userID int, token string) bool {
+	return hmac.Equal([]byte(s.FeedToken(userID)), []byte(token))
+}
+
+// writeEvent writes an event as a VEVENT component
+func (s *TicketCalendarService) writeEvent(w *icsWriter, event *models.Event) {
+	host := "localhost"
+	if parsed, err := url.Parse(s.baseURL); err == nil && parsed.Hostname() != "" {
+		host = parsed.Hostname()
+	}
+
+	modified := event.UpdatedAt
+	if modified.IsZero() {
+		modified = s.now()
+	}
+
+	w.line("BEGIN", "VEVENT")
+	w.line("UID", fmt.Sprintf("event-%d@%s", event.ID, host))
+	w.line("DTSTAMP", s.now().UTC().Format(icsTimeFormat))
+	w.line("LAST-MODIFIED", modified.UTC().Format(icsTimeFormat))
+	w.line("DTSTART", event.StartDate.UTC().Format(icsTimeFormat))
+	w.line("DTEND", eventEnd(event).UTC().Format(icsTimeFormat))
+	w.text("SUMMARY", event.Title)
+	if event.Location != "" {
+		w.text("LOCATION", event.Location)
+	}
+	w.text("DESCRIPTION", calendarDescription(event.Description, s.EventURL(event.ID)))
+	w.line("URL", s.EventURL(event.ID))
+	if event.Status == models.StatusCancelled {
+		w.line("STATUS", "CANCELLED")
+	} else {
+		w.line("STATUS", "CONFIRMED")
+	}
+	w.line("END", "VEVENT")
+}
+
+// GoogleCalendarURL returns a link that opens Google Calendar's new event
+// form prefilled with the event. eventURL may be empty.
+func GoogleCalendarURL(event *models.Event, eventURL string) string {
+	values := url.Values{}
+	values.Set("action", "TEMPLATE")
+	values.Set("text", event.Title)
+	values.Set("dates", event.StartDate.UTC().Format(icsTimeFormat)+"/"+eventEnd(event).UTC().Format(icsTimeFormat))
+	values.Set("details", calendarDescription(event.Description, eventURL))
+	if event.Location != "" {
+		values.Set("location", event.Location)
+	}
+	return "https://calendar.google.com/calendar/render?" + values.Encode()
+}
+
+// eventEnd returns the event's end, assuming two hours when none is set
+func eventEnd(event *models.Event) time.Time {
+	if event.EndDate.After(event.StartDate) {
+		return event.EndDate
+	}
+	return event.StartDate.Add(2 * time.Hour)
+}
+
+// calendarDescription returns the event description as plain text with a link back to the event
+func calendarDescription(description, eventURL string) string {
+	text := plainText(description)
+	if runes := []rune(text); len(runes) > 500 {
+		text = strings.TrimSpace(string(runes[:500])) + "..."
+	}
+	if text == "" || eventURL == "" {
+		return text + eventURL
+	}
+	return text + "\n\n" + eventURL
+}
+
+// icsWriter writes iCalendar (RFC 5545) content lines
+type icsWriter struct {
+	buf bytes.Buffer
+}
+
+func (w *icsWriter) begin(name string) {
+	w.line("BEGIN", "VCALENDAR")
+	w.line("VERSION", "2.0")
+	w.line("PRODID", icsProductID)
+	w.line("CALSCALE", "GREGORIAN")
+	w.line("METHOD", "PUBLISH")
+	w.text("X-WR-CALNAME", name)
+}
+
+func (w *icsWriter) end() {
+	w.line("END", "VCALENDAR")
+}
+
+// text writes a line whose value is escaped as iCalendar TEXT
+func (w *icsWriter) text(name, value string) {
+	replacer := strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+	w.line(name, replacer.Replace(value))
+}
+
+// line writes a content line, folding it at 75 octets without splitting characters
+func (w *icsWriter) line(name, value string) {
+	content := name + ":" + value
+
+	width := 0
+	for _, r := range content {
+		size := len(string(r))
+		if width+size > 75 {
+			w.buf.WriteString("\r\n ")
+			width = 1
+		}
+		w.buf.WriteRune(r)
+		width += size
+	}
+	w.buf.WriteString("\r\n")
+}
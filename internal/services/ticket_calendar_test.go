@@ -0,0 +1,147 @@
+package services
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock TicketCalendarRepository for testing
+type mockTicketCalendarRepository struct {
+	events []*models.Event
+	from   time.Time
+}
+
+func (m *mockTicketCalendarRepository) GetTicketedEventsForUser(userID int, from time.Time) ([]*models.Event, error) {
+	m.from = from
+	return m.events, nil
+}
+
+func setupTicketCalendarService() (*TicketCalendarService, *mockTicketCalendarRepository) {
+	repo := &mockTicketCalendarRepository{}
+	service := NewTicketCalendarService(repo, "https://tickets.example.com/", "secret")
+	service.now = func() time.Time { return time.Date(2025, 6, 1, 9, 0, 0, 0, time.UTC) }
+	return service, repo
+}
+
+func TestTicketCalendarService_EventICS(t *testing.T) {
+	service, _ := setupTicketCalendarService()
+
+	event := &models.Event{
+		ID:          5,
+		Title:       "Jazz, Blues; Soul",
+		Description: "<p>Live music</p>\n" + strings.Repeat("Great bands all night. ", 5),
+		Location:    "Uhuru Gardens, Nairobi",
+		StartDate:   time.Date(2025, 7, 1, 18, 0, 0, 0, time.UTC),
+		Status:      models.StatusPublished,
+	}
+
+	ics := string(service.EventICS(event))
+
+	for _, line := range strings.Split(strings.TrimSuffix(ics, "\r\n"), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("expected lines to be folded at 75 octets, got %d: %q", len(line), line)
+		}
+		if strings.Contains(line, "\n") {
+			t.Errorf("expected CRLF line endings, got %q", line)
+		}
+	}
+
+	unfolded := strings.ReplaceAll(ics, "\r\n ", "")
+	for _, expected := range []string{
+		"BEGIN:VCALENDAR\r\n",
+		"UID:event-5@tickets.example.com\r\n",
+		"DTSTART:20250701T180000Z\r\n",
+		"DTEND:20250701T200000Z\r\n",
+		`SUMMARY:Jazz\, Blues\; Soul` + "\r\n",
+		`LOCATION:Uhuru Gardens\, Nairobi` + "\r\n",
+		`\n\nhttps://tickets.example.com/events/5` + "\r\n",
+		"STATUS:CONFIRMED\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(unfolded, expected) {
+			t.Errorf("expected calendar to contain %q, got:\n%s", expected, unfolded)
+		}
+	}
+
+	event.Status = models.StatusCancelled
+	if ics := string(service.EventICS(event)); !strings.Contains(ics, "STATUS:CANCELLED\r\n") {
+		t.Errorf("expected cancelled event to be marked as cancelled, got:\n%s", ics)
+	}
+}
+
+func TestTicketCalendarService_GetAttendeeFeed(t *testing.T) {
+	service, repo := setupTicketCalendarService()
+	repo.events = []*models.Event{
+		{ID: 1, Title: "Jazz Night", StartDate: time.Date(2025, 7, 1, 18, 0, 0, 0, time.UTC)},
+		{ID: 2, Title: "Rock Night", StartDate: time.Date(2025, 8, 1, 18, 0, 0, 0, time.UTC)},
+	}
+
+	feed, err := service.GetAttendeeFeed(3)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !repo.from.Equal(service.now()) {
+		t.Errorf("expected feed to only include upcoming events, got from %v", repo.from)
+	}
+	if count := strings.Count(string(feed), "BEGIN:VEVENT"); count != 2 {
+		t.Errorf("expected 2 events, got %d", count)
+	}
+	if !strings.Contains(string(feed), "REFRESH-INTERVAL;VALUE=DURATION:PT1H\r\n") {
+		t.Error("expected feed to include a refresh interval")
+	}
+}
+
+func TestTicketCalendarService_FeedToken(t *testing.T) {
+	service, _ := setupTicketCalendarService()
+
+	feedURL, err := url.Parse(service.FeedURL(3))
+	if err != nil {
+		t.Fatalf("invalid feed URL: %v", err)
+	}
+	if feedURL.Path != "/dashboard/calendar.ics" || feedURL.Query().Get("user") != "3" {
+		t.Errorf("unexpected feed URL: %s", feedURL)
+	}
+
+	token := feedURL.Query().Get("token")
+	if !service.ValidFeedToken(3, token) {
+		t.Error("expected feed token to be valid for its user")
+	}
+	if service.ValidFeedToken(4, token) {
+		t.Error("expected feed token to be rejected for another user")
+	}
+
+	other := NewTicketCalendarService(nil, "https://tickets.example.com", "other-secret")
+	if other.ValidFeedToken(3, token) {
+		t.Error("expected feed token to be rejected with another secret")
+	}
+}
+
+func TestGoogleCalendarURL(t *testing.T) {
+	event := &models.Event{
+		Title:     "Jazz Night",
+		Location:  "Nairobi",
+		StartDate: time.Date(2025, 7, 1, 18, 0, 0, 0, time.UTC),
+		EndDate:   time.Date(2025, 7, 1, 23, 30, 0, 0, time.UTC),
+	}
+
+	link, err := url.Parse(GoogleCalendarURL(event, "https://tickets.example.com/events/1"))
+	if err != nil {
+		t.Fatalf("invalid URL: %v", err)
+	}
+
+	query := link.Query()
+	if query.Get("action") != "TEMPLATE" || query.Get("text") != "Jazz Night" || query.Get("location") != "Nairobi" {
+		t.Errorf("unexpected query: %v", query)
+	}
+	if query.Get("dates") != "20250701T180000Z/20250701T233000Z" {
+		t.Errorf("unexpected dates: %s", query.Get("dates"))
+	}
+	if query.Get("details") != "https://tickets.example.com/events/1" {
+		t.Errorf("unexpected details: %s", query.Get("details"))
+	}
+}
@@ -0,0 +1,378 @@
+package services
+
+import (
+	"crypto/ed25519"
+	"encoding/csv"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// TicketScanRepository interface for ticket scan audit data operations
+type TicketScanRepository interface {
+	Create(scan *models.TicketScan) error
+	GetByEvent(eventID int) ([]*models.TicketScan, error)
+	GetCheckInStats(eventID int) (*models.CheckInStats, error)
+	GetManifestTickets(eventID int) ([]models.ManifestTicket, error)
+	GetSyncedClientIDs(eventID int, clientIDs []string) (map[string]bool, error)
+}
+
+// ScanRequest represents a ticket scan at an event entrance
+type ScanRequest struct {
+	EventID     int    `json:"event_id"`
+	QRCode      string `json:"qr_code"`
+	Gate        string `json:"gate"`
+	Device      string `json:"device"`
+	StaffUserID int    `json:"staff_user_id"`
+}
+
+// checkInIntervalWindow is how many intervals of the check-in rate are shown
+const checkInIntervalWindow = 16
+
+// TicketScanService handles ticket scanning and keeps an audit trail of every attempt
+type TicketScanService struct {
+	scanRepo     TicketScanRepository
+	ticketRepo   TicketRepository
+	orderRepo    OrderRepository
+	eventRepo    EventRepository
+	arrivalSlots ArrivalSlotLookup
+	events       *DomainEventBus
+	team         TeamAccessChecker
+	manifestKey  ed25519.PrivateKey
+	now          func() time.Time
+}
+
+// NewTicketScanService creates a new ticket scan service
+func NewTicketScanService(scanRepo TicketScanRepository, ticketRepo TicketRepository, orderRepo OrderRepository, eventRepo EventRepository) *TicketScanService {
+	return &TicketScanService{
+		scanRepo:   scanRepo,
+		ticketRepo: ticketRepo,
+		orderRepo:  orderRepo,
+		eventRepo:  eventRepo,
+		now:        time.Now,
+	}
+}
+
+// SetArrivalSlots flags ticket holders who arrive outside the arrival slot
+// they chose. They are still admitted.
+func (s *TicketScanService) SetArrivalSlots(arrivalSlots ArrivalSlotLookup) {
+	s.arrivalSlots = arrivalSlots
+}
+
+// SetEventBus sets the bus admitted ticket holders are published on
+func (s *TicketScanService) SetEventBus(events *DomainEventBus) {
+	s.events = events
+}
+
+// SetTeamAccess lets organizers' team members with check-in access scan
+// tickets at their events
+func (s *TicketScanService) SetTeamAccess(team TeamAccessChecker) {
+	s.team = team
+}
+
+// ScanTicket checks a ticket in at the event and records the attempt, whatever
+// its outcome. Only accepted scans mark the ticket as used.
+func (s *TicketScanService) ScanTicket(req *ScanRequest) (*models.TicketScan, error) {
+	if err := s.checkEventAccess(req.EventID, req.StaffUserID); err != nil {
+		return nil, err
+	}
+
+	qrCode := strings.TrimSpace(req.QRCode)
+	if qrCode == "" {
+		return nil, fmt.Errorf("QR code is required")
+	}
+
+	staffUserID := req.StaffUserID
+	scan := &models.TicketScan{
+		EventID:     req.EventID,
+		QRCode:      qrCode,
+		Gate:        truncateField(strings.TrimSpace(req.Gate), 100),
+		Device:      truncateField(strings.TrimSpace(req.Device), 255),
+		StaffUserID: &staffUserID,
+	}
+
+	if err := s.classifyScan(scan); err != nil {
+		return nil, err
+	}
+
+	if err := s.scanRepo.Create(scan); err != nil {
+		return nil, fmt.Errorf("failed to record scan: %w", err)
+	}
+	if scan.IsAccepted() {
+		s.events.PublishTicketCheckedIn(scan)
+	}
+
+	return scan, nil
+}
+
+// classifyScan looks up the scanned ticket and sets the scan result
+func (s *TicketScanService) classifyScan(scan *models.TicketScan) error {
+	ticket, err := s.ticketRepo.GetTicketByQRCode(scan.QRCode)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			scan.Result = models.ScanInvalid
+			scan.Reason = "ticket not found"
+			return nil
+		}
+		return fmt.Errorf("failed to look up ticket: %w", err)
+	}
+
+	ticketID := ticket.ID
+	scan.TicketID = &ticketID
+	scan.AttendeeName = ticket.AttendeeName
+
+	order, err := s.orderRepo.GetByID(ticket.OrderID)
+	if err != nil {
+		return fmt.Errorf("failed to get ticket order: %w", err)
+	}
+	if order.EventID != scan.EventID {
+		scan.Result = models.ScanInvalid
+		scan.Reason = "ticket is for a different event"
+		return nil
+	}
+
+	switch ticket.Status {
+	case models.TicketActive:
+		if err := s.ticketRepo.UpdateTicketStatus(ticket.ID, models.TicketUsed); err != nil {
+			return fmt.Errorf("failed to mark ticket as used: %w", err)
+		}
+		scan.Result = models.ScanAccepted
+		arrivedAt := scan.ScannedAt // Offline scans carry the time they were made
+		if arrivedAt.IsZero() {
+			arrivedAt = s.now()
+		}
+		scan.Reason = s.checkArrivalSlot(order, arrivedAt)
+	case models.TicketUsed:
+		scan.Result = models.ScanDuplicate
+		scan.Reason = "ticket has already been scanned"
+	case models.TicketRefunded:
+		scan.Result = models.ScanRefunded
+		scan.Reason = "ticket has been refunded"
+	default:
+		scan.Result = models.ScanInvalid
+		scan.Reason = fmt.Sprintf("ticket status is %s", ticket.Status)
+	}
+
+	return nil
+}
+
+// checkArrivalSlot describes how far outside their arrival slot a ticket
+// holder arrived, or returns "" if they are on time or chose no slot
+func (s *TicketScanService) checkArrivalSlot(order *models.Order, arrivedAt time.Time) string {
+	if s.arrivalSlots == nil || order.ArrivalSlotID == nil {
+		return ""
+	}
+
+	slot, err := s.arrivalSlots.GetSlot(*order.ArrivalSlotID)
+	if err != nil {
+		fmt.Printf("Warning: failed to get arrival slot for order %s: %v\n", order.OrderNumber, err)
+		return ""
+	}
+
+	deviation := slot.ArrivalDeviation(arrivedAt)
+	switch {
+	case deviation < 0:
+		return fmt.Sprintf("arrived %s early for the %s arrival slot", formatMinutes(-deviation), slot.Label())
+	case deviation > 0:
+		return fmt.Sprintf("arrived %s late for the %s arrival slot", formatMinutes(deviation), slot.Label())
+	}
+	return ""
+}
+
+// formatMinutes formats a duration in whole minutes, e.g. "45 min" or "1 h 20 min"
+func formatMinutes(d time.Duration) string {
+	minutes := int(d.Round(time.Minute) / time.Minute)
+	if minutes < 60 {
+		return fmt.Sprintf("%d min", minutes)
+	}
+	if minutes%60 == 0 {
+		return fmt.Sprintf("%d h", minutes/60)
+	}
+	return fmt.Sprintf("%d h %d min", minutes/60, minutes%60)
+}
+
+// GetEventScans retrieves every scan attempt for an event owned by the organizer
+func (s *TicketScanService) GetEventScans(eventID, organizerID int) ([]*models.TicketScan, error) {
+	if err := s.checkEventAccess(eventID, organizerID); err != nil {
+		return nil, err
+	}
+
+	scans, err := s.scanRepo.GetByEvent(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get scans: %w", err)
+	}
+
+	return scans, nil
+}
+
+// CheckInDashboard returns an event the user runs check-in for, with its
+// live check-in statistics
+func (s *TicketScanService) CheckInDashboard(eventID, userID int) (*models.Event, *models.CheckInStats, error) {
+	event, err := s.accessibleEvent(eventID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	stats, err := s.loadCheckInStats(eventID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return event, stats, nil
+}
+
+// GetCheckInStats returns the live check-in statistics of an event the user
+// runs check-in for
+func (s *TicketScanService) GetCheckInStats(eventID, userID int) (*models.CheckInStats, error) {
+	if err := s.checkEventAccess(eventID, userID); err != nil {
+		return nil, err
+	}
+	return s.loadCheckInStats(eventID)
+}
+
+// loadCheckInStats loads an event's check-in statistics, filling in the
+// recent intervals nobody was admitted in
+func (s *TicketScanService) loadCheckInStats(eventID int) (*models.CheckInStats, error) {
+	stats, err := s.scanRepo.GetCheckInStats(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get check-in stats: %w", err)
+	}
+	stats.Intervals = fillCheckInIntervals(stats.Intervals, s.now())
+	return stats, nil
+}
+
+// fillCheckInIntervals returns the last checkInIntervalWindow intervals of
+// check-ins, oldest first, including those without any. While check-in is
+// under way the window ends at the current interval; once it has been quiet
+// for longer than the window, it ends at the last check-in instead.
+func fillCheckInIntervals(intervals []models.CheckInInterval, now time.Time) []models.CheckInInterval {
+	if len(intervals) == 0 {
+		return nil
+	}
+
+	counts := make(map[int64]int, len(intervals))
+	for _, interval := range intervals {
+		counts[interval.Start.Truncate(models.CheckInIntervalLength).Unix()] += interval.CheckedIn
+	}
+
+	first := intervals[0].Start.Truncate(models.CheckInIntervalLength)
+	end := intervals[len(intervals)-1].Start.Truncate(models.CheckInIntervalLength)
+	if current := now.Truncate(models.CheckInIntervalLength); current.After(end) && current.Sub(end) < checkInIntervalWindow*models.CheckInIntervalLength {
+		end = current
+	}
+	start := end.Add(-(checkInIntervalWindow - 1) * models.CheckInIntervalLength)
+	if first.After(start) {
+		start = first
+	}
+
+	var series []models.CheckInInterval
+	for t := start; !t.After(end); t = t.Add(models.CheckInIntervalLength) {
+		series = append(series, models.CheckInInterval{Start: t, CheckedIn: counts[t.Unix()]})
+	}
+	return series
+}
+
+// ExportEventScans exports every scan attempt for an event as CSV
+func (s *TicketScanService) ExportEventScans(eventID, organizerID int) ([]byte, error) {
+	scans, err := s.GetEventScans(eventID, organizerID)
+	if err != nil {
+		return nil, err
+	}
+
+	var csvData strings.Builder
+	writer := csv.NewWriter(&csvData)
+
+	header := []string{
+		"Scanned At",
+		"Result",
+		"Reason",
+		"Ticket ID",
+		"Attendee",
+		"QR Code",
+		"Gate",
+		"Device",
+		"Staff Member",
+		"Staff Email",
+		"Synced At",
+	}
+	if err := writer.Write(header); err != nil {
+		return nil, fmt.Errorf("failed to write CSV header: %w", err)
+	}
+
+	for _, scan := range scans {
+		ticketID := ""
+		if scan.TicketID != nil {
+			ticketID = strconv.Itoa(*scan.TicketID)
+		}
+		syncedAt := "" // Only offline scans are synced later
+		if scan.SyncedAt != nil {
+			syncedAt = scan.SyncedAt.UTC().Format(time.RFC3339)
+		}
+
+		row := []string{
+			scan.ScannedAt.UTC().Format(time.RFC3339),
+			string(scan.Result),
+			scan.Reason,
+			ticketID,
+			scan.AttendeeName,
+			scan.QRCode,
+			scan.Gate,
+			scan.Device,
+			strings.TrimSpace(scan.StaffName),
+			scan.StaffEmail,
+			syncedAt,
+		}
+		if err := writer.Write(row); err != nil {
+			return nil, fmt.Errorf("failed to write CSV row: %w", err)
+		}
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return nil, fmt.Errorf("failed to flush CSV writer: %w", err)
+	}
+
+	return []byte(csvData.String()), nil
+}
+
+// checkEventAccess verifies the user organizes the event or runs check-in
+// for its organizer
+func (s *TicketScanService) checkEventAccess(eventID, userID int) error {
+	_, err := s.accessibleEvent(eventID, userID)
+	return err
+}
+
+// accessibleEvent returns the event if the user organizes it or runs
+// check-in for its organizer
+func (s *TicketScanService) accessibleEvent(eventID, userID int) (*models.Event, error) {
+	event, err := s.eventRepo.GetByID(eventID)
+	if err != nil {
+		return nil, fmt.Errorf("event not found: %w", err)
+	}
+
+	if event.OrganizerID == userID {
+		return event, nil
+	}
+	if s.team != nil {
+		ok, err := s.team.HasAccess(event.OrganizerID, userID, models.PermissionCheckIn)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check team access: %w", err)
+		}
+		if ok {
+			return event, nil
+		}
+	}
+
+	return nil, fmt.Errorf("organizer does not have access to this event")
+}
+
+// truncateField shortens s to at most limit bytes
+func truncateField(s string, limit int) string {
+	if len(s) > limit {
+		return s[:limit]
+	}
+	return s
+}
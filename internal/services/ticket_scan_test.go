@@ -0,0 +1,270 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock TicketScanRepository for testing
+type mockTicketScanRepository struct {
+	scans []*models.TicketScan
+}
+
+func (m *mockTicketScanRepository) Create(scan *models.TicketScan) error {
+	scan.ID = len(m.scans) + 1
+	if scan.ScannedAt.IsZero() {
+		scan.ScannedAt = time.Date(2025, 6, 1, 18, 0, scan.ID, 0, time.UTC)
+	}
+	m.scans = append(m.scans, scan)
+	return nil
+}
+
+func (m *mockTicketScanRepository) GetManifestTickets(eventID int) ([]models.ManifestTicket, error) {
+	return []models.ManifestTicket{{TicketID: 1, QRCodeHash: models.HashQRCode("QR-ACTIVE"), TicketType: "General"}}, nil
+}
+
+func (m *mockTicketScanRepository) GetSyncedClientIDs(eventID int, clientIDs []string) (map[string]bool, error) {
+	synced := make(map[string]bool)
+	for _, scan := range m.scans {
+		if scan.EventID == eventID && scan.ClientID != "" {
+			synced[scan.ClientID] = true
+		}
+	}
+	return synced, nil
+}
+
+func (m *mockTicketScanRepository) GetByEvent(eventID int) ([]*models.TicketScan, error) {
+	var result []*models.TicketScan
+	for _, scan := range m.scans {
+		if scan.EventID == eventID {
+			result = append(result, scan)
+		}
+	}
+	return result, nil
+}
+
+func (m *mockTicketScanRepository) GetCheckInStats(eventID int) (*models.CheckInStats, error) {
+	stats := &models.CheckInStats{EventID: eventID}
+	gates := make(map[string]int)
+	for _, scan := range m.scans {
+		if scan.EventID != eventID {
+			continue
+		}
+		if !scan.IsAccepted() {
+			stats.Rejected++
+			continue
+		}
+		stats.CheckedIn++
+		gates[scan.Gate]++
+		start := scan.ScannedAt.Truncate(models.CheckInIntervalLength)
+		if n := len(stats.Intervals); n > 0 && stats.Intervals[n-1].Start.Equal(start) {
+			stats.Intervals[n-1].CheckedIn++
+		} else {
+			stats.Intervals = append(stats.Intervals, models.CheckInInterval{Start: start, CheckedIn: 1})
+		}
+	}
+	for gate, count := range gates {
+		stats.ByGate = append(stats.ByGate, models.CheckInCount{Label: gate, CheckedIn: count})
+	}
+	return stats, nil
+}
+
+func setupTicketScanService() (*TicketScanService, *mockTicketScanRepository, *mockTicketRepository) {
+	scanRepo := &mockTicketScanRepository{}
+	ticketRepo := newMockTicketRepository()
+	orderRepo := newMockOrderRepository()
+	eventRepo := newMockEventRepository()
+
+	eventRepo.events[1] = &models.Event{ID: 1, Title: "Jazz Night", OrganizerID: 7}
+	eventRepo.events[2] = &models.Event{ID: 2, Title: "Rock Night", OrganizerID: 7}
+
+	order, _ := orderRepo.Create(&models.OrderCreateRequest{UserID: 3, EventID: 1, Status: models.OrderCompleted})
+	ticketRepo.CreateTicket(order.ID, 1, "QR-ACTIVE")
+	refunded, _ := ticketRepo.CreateTicket(order.ID, 1, "QR-REFUNDED")
+	refunded.Status = models.TicketRefunded
+
+	return NewTicketScanService(scanRepo, ticketRepo, orderRepo, eventRepo), scanRepo, ticketRepo
+}
+
+func TestTicketScanService_ScanTicket(t *testing.T) {
+	service, scanRepo, ticketRepo := setupTicketScanService()
+
+	tests := []struct {
+		name     string
+		eventID  int
+		qrCode   string
+		expected models.ScanResult
+	}{
+		{name: "first scan is accepted", eventID: 1, qrCode: "QR-ACTIVE", expected: models.ScanAccepted},
+		{name: "second scan is a duplicate", eventID: 1, qrCode: "QR-ACTIVE", expected: models.ScanDuplicate},
+		{name: "refunded ticket", eventID: 1, qrCode: "QR-REFUNDED", expected: models.ScanRefunded},
+		{name: "unknown ticket", eventID: 1, qrCode: "QR-UNKNOWN", expected: models.ScanInvalid},
+		{name: "ticket for another event", eventID: 2, qrCode: "QR-REFUNDED", expected: models.ScanInvalid},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scan, err := service.ScanTicket(&ScanRequest{
+				EventID:     tt.eventID,
+				QRCode:      tt.qrCode,
+				Gate:        "North Gate",
+				Device:      "scanner-1",
+				StaffUserID: 7,
+			})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if scan.Result != tt.expected {
+				t.Errorf("expected result %s, got %s (%s)", tt.expected, scan.Result, scan.Reason)
+			}
+		})
+	}
+
+	if len(scanRepo.scans) != len(tests) {
+		t.Errorf("expected every attempt to be recorded, got %d", len(scanRepo.scans))
+	}
+
+	ticket, _ := ticketRepo.GetTicketByQRCode("QR-ACTIVE")
+	if ticket.Status != models.TicketUsed {
+		t.Errorf("expected accepted ticket to be marked as used, got %s", ticket.Status)
+	}
+}
+
+func TestTicketScanService_ScanTicket_Access(t *testing.T) {
+	service, scanRepo, _ := setupTicketScanService()
+
+	_, err := service.ScanTicket(&ScanRequest{EventID: 1, QRCode: "QR-ACTIVE", StaffUserID: 99})
+	if err == nil || !strings.Contains(err.Error(), "does not have access") {
+		t.Errorf("expected access error, got %v", err)
+	}
+
+	_, err = service.ScanTicket(&ScanRequest{EventID: 1, QRCode: "  ", StaffUserID: 7})
+	if err == nil {
+		t.Error("expected error for empty QR code")
+	}
+
+	if len(scanRepo.scans) != 0 {
+		t.Errorf("expected rejected requests not to be recorded, got %d", len(scanRepo.scans))
+	}
+}
+
+func TestTicketScanService_ScanTicket_TeamAccess(t *testing.T) {
+	service, _, _ := setupTicketScanService()
+	doorStaffID, analystID := 12, 13
+	acceptedAt := time.Now()
+	service.SetTeamAccess(NewTeamService(&mockOrganizerMemberRepository{members: []*models.OrganizerMember{
+		{ID: 1, OrganizerID: 7, UserID: &doorStaffID, CanCheckIn: true, AcceptedAt: &acceptedAt},
+		{ID: 2, OrganizerID: 7, UserID: &analystID, CanViewAnalytics: true, AcceptedAt: &acceptedAt},
+	}}, nil, nil, ""))
+
+	scan, err := service.ScanTicket(&ScanRequest{EventID: 1, QRCode: "QR-ACTIVE", StaffUserID: 12})
+	if err != nil {
+		t.Fatalf("expected check-in staff to scan tickets, got %v", err)
+	}
+	if !scan.IsAccepted() {
+		t.Errorf("expected scan to be accepted, got %s", scan.Result)
+	}
+
+	_, err = service.ScanTicket(&ScanRequest{EventID: 1, QRCode: "QR-ACTIVE", StaffUserID: 13})
+	if err == nil || !strings.Contains(err.Error(), "does not have access") {
+		t.Errorf("expected team members without check-in access to be refused, got %v", err)
+	}
+}
+
+func TestTicketScanService_ExportEventScans(t *testing.T) {
+	service, _, _ := setupTicketScanService()
+
+	service.ScanTicket(&ScanRequest{EventID: 1, QRCode: "QR-ACTIVE", Gate: "North Gate", Device: "scanner-1", StaffUserID: 7})
+	service.ScanTicket(&ScanRequest{EventID: 1, QRCode: "QR-ACTIVE", Gate: "South Gate", Device: "scanner-2", StaffUserID: 7})
+
+	csvData, err := service.ExportEventScans(1, 7)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	lines := strings.Split(strings.TrimSpace(string(csvData)), "\n")
+	if len(lines) != 3 {
+		t.Fatalf("expected header and 2 rows, got %d lines", len(lines))
+	}
+	if !strings.HasPrefix(lines[0], "Scanned At,Result,Reason") {
+		t.Errorf("unexpected header: %s", lines[0])
+	}
+	if !strings.Contains(lines[1], "accepted") || !strings.Contains(lines[1], "North Gate") {
+		t.Errorf("unexpected first row: %s", lines[1])
+	}
+	if !strings.Contains(lines[2], "duplicate") || !strings.Contains(lines[2], "scanner-2") {
+		t.Errorf("unexpected second row: %s", lines[2])
+	}
+
+	if _, err := service.ExportEventScans(1, 99); err == nil {
+		t.Error("expected access error for another organizer")
+	}
+}
+
+func TestTicketScanService_GetCheckInStats(t *testing.T) {
+	service, _, _ := setupTicketScanService()
+	service.now = func() time.Time { return time.Date(2025, 6, 1, 18, 40, 0, 0, time.UTC) }
+
+	service.ScanTicket(&ScanRequest{EventID: 1, QRCode: "QR-ACTIVE", Gate: "North", StaffUserID: 7})
+	service.ScanTicket(&ScanRequest{EventID: 1, QRCode: "QR-ACTIVE", Gate: "North", StaffUserID: 7})
+	service.ScanTicket(&ScanRequest{EventID: 1, QRCode: "QR-REFUNDED", Gate: "South", StaffUserID: 7})
+
+	stats, err := service.GetCheckInStats(1, 7)
+	if err != nil {
+		t.Fatalf("GetCheckInStats() error = %v", err)
+	}
+	if stats.CheckedIn != 1 || stats.Rejected != 2 {
+		t.Errorf("expected 1 check-in and 2 rejected scans, got %d and %d", stats.CheckedIn, stats.Rejected)
+	}
+	if len(stats.ByGate) != 1 || stats.ByGate[0].Label != "North" {
+		t.Errorf("expected check-ins at the North gate only, got %+v", stats.ByGate)
+	}
+
+	// The scan at 18:00 is followed by the quiet intervals up to now
+	if len(stats.Intervals) != 3 {
+		t.Fatalf("expected intervals from 18:00 to 18:30, got %+v", stats.Intervals)
+	}
+	if stats.Intervals[0].CheckedIn != 1 || stats.Intervals[2].CheckedIn != 0 {
+		t.Errorf("unexpected intervals %+v", stats.Intervals)
+	}
+
+	if _, err := service.GetCheckInStats(1, 99); err == nil || !strings.Contains(err.Error(), "does not have access") {
+		t.Errorf("expected access error, got %v", err)
+	}
+}
+
+func TestFillCheckInIntervals(t *testing.T) {
+	at := func(hour, minute int) time.Time { return time.Date(2025, 6, 1, hour, minute, 0, 0, time.UTC) }
+	intervals := []models.CheckInInterval{
+		{Start: at(18, 0), CheckedIn: 4},
+		{Start: at(18, 30), CheckedIn: 2},
+	}
+
+	// While check-in is under way the series runs to the current interval
+	series := fillCheckInIntervals(intervals, at(19, 5))
+	if len(series) != 5 || !series[4].Start.Equal(at(19, 0)) {
+		t.Fatalf("expected intervals from 18:00 to 19:00, got %+v", series)
+	}
+	if series[1].CheckedIn != 0 || series[2].CheckedIn != 2 {
+		t.Errorf("unexpected counts %+v", series)
+	}
+
+	// Long after check-in it ends at the last check-in
+	series = fillCheckInIntervals(intervals, at(23, 0))
+	if len(series) != 3 || !series[2].Start.Equal(at(18, 30)) {
+		t.Errorf("expected intervals from 18:00 to 18:30, got %+v", series)
+	}
+
+	// Only the most recent intervals are kept
+	series = fillCheckInIntervals([]models.CheckInInterval{{Start: at(10, 0), CheckedIn: 1}, {Start: at(18, 0), CheckedIn: 1}}, at(18, 0))
+	if len(series) != checkInIntervalWindow || !series[0].Start.Equal(at(14, 15)) {
+		t.Errorf("expected %d intervals from 14:15, got %d from %v", checkInIntervalWindow, len(series), series[0].Start)
+	}
+
+	if series := fillCheckInIntervals(nil, at(18, 0)); series != nil {
+		t.Errorf("expected no intervals without check-ins, got %+v", series)
+	}
+}
@@ -5,6 +5,7 @@ import (
 	"testing"
 	"time"
 
+	"event-ticketing-platform/internal/cache"
 	"event-ticketing-platform/internal/models"
 	"event-ticketing-platform/internal/repositories"
 )
@@ -168,6 +169,48 @@ func (m *mockTicketRepository) ReleaseReservation(reservationID string, ticketTy
 	return nil
 }
 
+func (m *mockTicketRepository) HoldTickets(userID int, holds []repositories.TicketHold, expirationMinutes int) ([]*repositories.TicketReservation, error) {
+	if m.shouldFailOps["HoldTickets"] {
+		return nil, errors.New("mock error")
+	}
+	
+	for _, hold := range holds {
+		tt, exists := m.ticketTypes[hold.TicketTypeID]
+		if !exists {
+			return nil, errors.New("ticket type not found")
+		}
+		if tt.Available() < hold.Quantity {
+			return nil, repositories.ErrInsufficientTickets
+		}
+	}
+	
+	var reservations []*repositories.TicketReservation
+	for _, hold := range holds {
+		m.ticketTypes[hold.TicketTypeID].Held += hold.Quantity
+		reservations = append(reservations, &repositories.TicketReservation{
+			TicketTypeID: hold.TicketTypeID,
+			Quantity:     hold.Quantity,
+			UserID:       userID,
+			ExpiresAt:    time.Now().Add(time.Duration(expirationMinutes) * time.Minute),
+			CreatedAt:    time.Now(),
+		})
+	}
+	return reservations, nil
+}
+
+func (m *mockTicketRepository) ReleaseUserReservations(userID int, ticketTypeIDs []int) error {
+	for _, id := range ticketTypeIDs {
+		if tt, exists := m.ticketTypes[id]; exists {
+			tt.Held = 0
+		}
+	}
+	return nil
+}
+
+func (m *mockTicketRepository) DeleteExpiredReservations() (int64, error) {
+	return 0, nil
+}
+
 func (m *mockTicketRepository) CreateTicket(orderID, ticketTypeID int, qrCode string) (*models.Ticket, error) {
 	if m.shouldFailOps["CreateTicket"] {
 		return nil, errors.New("mock error")
@@ -455,6 +498,10 @@ func (m *mockOrderRepository) GetTotalRevenue() (float64, error) {
 	return 0.0, nil
 }
 
+func (m *mockOrderRepository) GetItemPrices(orderID int) (map[int]int, error) {
+	return map[int]int{}, nil
+}
+
 type mockPaymentService struct {
 	shouldFailOps map[string]bool
 }
@@ -646,6 +693,35 @@ func TestTicketService_ReserveTickets(t *testing.T) {
 	}
 }
 
+func TestTicketService_PurchaseTickets_HoldsTickets(t *testing.T) {
+	service, ticketRepo, _, paymentService, _ := createTestTicketService()
+	ticketType := createTestTicketType(ticketRepo, 1)
+	
+	req := &TicketPurchaseRequest{
+		EventID:          1,
+		TicketSelections: []TicketSelection{{TicketTypeID: ticketType.ID, Quantity: 2}},
+		BillingInfo:      PaymentBillingInfo{Email: "test@example.com", Name: "Test User"},
+		PaymentMethod:    "card",
+		UserID:           1,
+	}
+	
+	// A failed payment gives the held tickets back
+	paymentService.shouldFailOps["ProcessPayment"] = true
+	if _, err := service.PurchaseTickets(req); err == nil {
+		t.Fatal("expected payment failure")
+	}
+	if ticketType.Held != 0 {
+		t.Errorf("expected held tickets to be released, %d still held", ticketType.Held)
+	}
+	
+	// Tickets held by other buyers can't be bought
+	paymentService.shouldFailOps = make(map[string]bool)
+	ticketType.Held = ticketType.Quantity - ticketType.Sold - 1
+	if _, err := service.PurchaseTickets(req); err == nil {
+		t.Error("expected purchase of held tickets to fail")
+	}
+}
+
 func TestTicketService_PurchaseTickets(t *testing.T) {
 	service, ticketRepo, _, paymentService, _ := createTestTicketService()
 	ticketType := createTestTicketType(ticketRepo, 1)
@@ -982,4 +1058,40 @@ func TestTicketService_RefundTickets(t *testing.T) {
 			}
 		})
 	}
-}
\ No newline at end of file
+}
+
+func TestTicketService_GetTicketAvailability(t *testing.T) {
+	ticketRepo := newMockTicketRepository()
+	service := NewTicketService(ticketRepo, newMockOrderRepository(), nil, nil, nil, 15)
+	service.SetCache(cache.NewMemoryCache())
+
+	ticketType, _ := service.CreateTicketType(&models.TicketTypeCreateRequest{EventID: 1, Name: "General", Price: 1000, Quantity: 100})
+
+	available, err := service.GetTicketAvailability(1)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(available) != 1 || available[0].Sold != 0 {
+		t.Fatalf("unexpected availability: %+v", available)
+	}
+
+	// Sales between polls are served from the cache until a purchase invalidates it
+	ticketRepo.ticketTypes[ticketType.ID].Sold = 5
+	available, _ = service.GetTicketAvailability(1)
+	if available[0].Sold != 0 {
+		t.Errorf("expected cached availability, got %d sold", available[0].Sold)
+	}
+
+	service.OrderCompleted(&models.Order{EventID: 1})
+	available, _ = service.GetTicketAvailability(1)
+	if available[0].Sold != 5 {
+		t.Errorf("expected availability to be reloaded after purchase, got %d sold", available[0].Sold)
+	}
+
+	// Editing ticket types invalidates too
+	service.UpdateTicketType(ticketType.ID, &models.TicketTypeUpdateRequest{Name: "General Admission", Price: 1000, Quantity: 200})
+	available, _ = service.GetTicketAvailability(1)
+	if available[0].Quantity != 200 {
+		t.Errorf("expected availability to be reloaded after edit, got quantity %d", available[0].Quantity)
+	}
+}
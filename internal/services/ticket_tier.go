@@ -0,0 +1,163 @@
+package services
+
+import (
+	"fmt"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// TicketTierRepository defines the data operations for chaining ticket types
+// into pricing tiers
+type TicketTierRepository interface {
+	SetNextTier(ticketTypeID int, nextTierID *int) error
+	AdvanceTier(tierID, nextTierID int) error
+}
+
+// SetTierRepository enables pricing tiers, where a ticket type is replaced
+// by its next tier once it sells out or its sale ends
+func (s *TicketService) SetTierRepository(tiers TicketTierRepository) {
+	s.tiers = tiers
+}
+
+// SetNextTier sets the ticket type that goes on sale once this one sells out
+// or its sale ends, or clears it if nextTierID is nil. The next tier's sales
+// start no earlier than this tier's end.
+func (s *TicketService) SetNextTier(ticketTypeID int, nextTierID *int) error {
+	if s.tiers == nil {
+		return fmt.Errorf("pricing tiers are not enabled")
+	}
+
+	ticketType, err := s.ticketRepo.GetTicketTypeByID(ticketTypeID)
+	if err != nil {
+		return err
+	}
+
+	if nextTierID != nil {
+		if err := s.validateNextTier(ticketType, *nextTierID); err != nil {
+			return err
+		}
+	}
+
+	if err := s.tiers.SetNextTier(ticketTypeID, nextTierID); err != nil {
+		return err
+	}
+
+	s.InvalidateAvailability(ticketType.EventID)
+	return nil
+}
+
+// validateNextTier checks that the next tier belongs to the same event and
+// that the tiers form a single chain
+func (s *TicketService) validateNextTier(ticketType *models.TicketType, nextTierID int) error {
+	if nextTierID == ticketType.ID {
+		return fmt.Errorf("a ticket type cannot be its own next tier")
+	}
+
+	ticketTypes, err := s.ticketRepo.GetTicketTypesByEvent(ticketType.EventID)
+	if err != nil {
+		return fmt.Errorf("failed to get ticket types: %w", err)
+	}
+
+	byID := make(map[int]*models.TicketType, len(ticketTypes))
+	for _, tt := range ticketTypes {
+		byID[tt.ID] = tt
+	}
+
+	next, ok := byID[nextTierID]
+	if !ok {
+		return fmt.Errorf("the next tier must be a ticket type of the same event")
+	}
+
+	for _, tt := range ticketTypes {
+		if tt.ID != ticketType.ID && tt.NextTierID != nil && *tt.NextTierID == nextTierID {
+			return fmt.Errorf("'%s' already follows '%s'", next.Name, tt.Name)
+		}
+	}
+
+	// Following the chain from the next tier must not lead back to this one
+	tier := next
+	for i := 0; i < len(ticketTypes) && tier.NextTierID != nil; i++ {
+		if *tier.NextTierID == ticketType.ID {
+			return fmt.Errorf("'%s' already comes before '%s'", next.Name, ticketType.Name)
+		}
+		if tier, ok = byID[*tier.NextTierID]; !ok {
+			break
+		}
+	}
+
+	if !next.SaleEnd.After(ticketType.SaleEnd) {
+		return fmt.Errorf("'%s' must stay on sale after '%s' ends", next.Name, ticketType.Name)
+	}
+
+	return nil
+}
+
+// ResolveActiveTier returns the ticket type buyers should get when asking for
+// ticketTypeID: the ticket type itself, or the first of its following tiers
+// still selling if it has finished. Finished tiers are advanced on the way.
+func (s *TicketService) ResolveActiveTier(ticketTypeID int) (*models.TicketType, error) {
+	ticketType, err := s.ticketRepo.GetTicketTypeByID(ticketTypeID)
+	if err != nil {
+		return nil, err
+	}
+	if s.tiers == nil {
+		return ticketType, nil
+	}
+
+	// Tiers form a chain, so it can be no longer than the event's ticket types
+	visited := map[int]bool{ticketType.ID: true}
+	for ticketType.NextTierID != nil && ticketType.TierFinished() && !visited[*ticketType.NextTierID] {
+		next, err := s.ticketRepo.GetTicketTypeByID(*ticketType.NextTierID)
+		if err != nil {
+			return nil, err
+		}
+
+		if next.SaleNotStarted() {
+			if err := s.tiers.AdvanceTier(ticketType.ID, next.ID); err != nil {
+				return nil, fmt.Errorf("failed to advance tier: %w", err)
+			}
+			if next, err = s.ticketRepo.GetTicketTypeByID(next.ID); err != nil {
+				return nil, err
+			}
+			s.InvalidateAvailability(next.EventID)
+		}
+
+		visited[next.ID] = true
+		ticketType = next
+	}
+
+	return ticketType, nil
+}
+
+// AdvanceTiers puts the next tier on sale for each of an event's tiers that
+// has sold out, so the event page shows the new price straight away. Errors
+// are logged, as tiers are also advanced when buyers ask for them.
+func (s *TicketService) AdvanceTiers(eventID int) {
+	if s.tiers == nil {
+		return
+	}
+
+	ticketTypes, err := s.ticketRepo.GetTicketTypesByEvent(eventID)
+	if err != nil {
+		fmt.Printf("Warning: failed to get ticket types of event %d: %v\n", eventID, err)
+		return
+	}
+
+	byID := make(map[int]*models.TicketType, len(ticketTypes))
+	for _, tt := range ticketTypes {
+		byID[tt.ID] = tt
+	}
+
+	for _, tt := range ticketTypes {
+		if tt.NextTierID == nil || !tt.TierFinished() {
+			continue
+		}
+		next, ok := byID[*tt.NextTierID]
+		if !ok || !next.SaleNotStarted() {
+			continue
+		}
+		if err := s.tiers.AdvanceTier(tt.ID, next.ID); err != nil {
+			fmt.Printf("Warning: failed to advance tier %d of event %d: %v\n", tt.ID, eventID, err)
+		}
+	}
+}
@@ -0,0 +1,150 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// mockTicketTierRepository links and advances tiers of a mock ticket repository
+type mockTicketTierRepository struct {
+	ticketRepo *mockTicketRepository
+	advanced   int
+}
+
+func (m *mockTicketTierRepository) SetNextTier(ticketTypeID int, nextTierID *int) error {
+	tier := m.ticketRepo.ticketTypes[ticketTypeID]
+	tier.NextTierID = nextTierID
+	if nextTierID != nil {
+		if next := m.ticketRepo.ticketTypes[*nextTierID]; next.SaleStart.Before(tier.SaleEnd) {
+			next.SaleStart = tier.SaleEnd
+		}
+	}
+	return nil
+}
+
+func (m *mockTicketTierRepository) AdvanceTier(tierID, nextTierID int) error {
+	now := time.Now()
+	if tier := m.ticketRepo.ticketTypes[tierID]; tier.SaleEnd.After(now) {
+		tier.SaleEnd = now
+	}
+	if next := m.ticketRepo.ticketTypes[nextTierID]; next.SaleStart.After(now) {
+		next.SaleStart = now.Add(-time.Second)
+	}
+	m.advanced++
+	return nil
+}
+
+// setupTieredTicketService returns a ticket service with an Early Bird tier
+// on sale, followed by a Regular tier
+func setupTieredTicketService() (*TicketService, *mockTicketRepository, *mockTicketTierRepository, *models.TicketType, *models.TicketType) {
+	service, ticketRepo, _, _, _ := createTestTicketService()
+	tiers := &mockTicketTierRepository{ticketRepo: ticketRepo}
+	service.SetTierRepository(tiers)
+
+	now := time.Now()
+	earlyBird, _ := ticketRepo.CreateTicketType(&models.TicketTypeCreateRequest{
+		EventID: 1, Name: "Early Bird", Price: 1500, Quantity: 2,
+		SaleStart: now.Add(-time.Hour), SaleEnd: now.Add(24 * time.Hour),
+	})
+	regular, _ := ticketRepo.CreateTicketType(&models.TicketTypeCreateRequest{
+		EventID: 1, Name: "Regular", Price: 2500, Quantity: 100,
+		SaleStart: now.Add(-time.Hour), SaleEnd: now.Add(48 * time.Hour),
+	})
+	return service, ticketRepo, tiers, earlyBird, regular
+}
+
+func TestTicketService_SetNextTier(t *testing.T) {
+	service, ticketRepo, _, earlyBird, regular := setupTieredTicketService()
+	otherEvent := createTestTicketType(ticketRepo, 2)
+
+	if err := service.SetNextTier(earlyBird.ID, &earlyBird.ID); err == nil {
+		t.Error("expected a ticket type not to follow itself")
+	}
+	if err := service.SetNextTier(earlyBird.ID, &otherEvent.ID); err == nil {
+		t.Error("expected a tier of another event to be rejected")
+	}
+	if err := service.SetNextTier(regular.ID, &earlyBird.ID); err == nil {
+		t.Error("expected a tier that stops selling first to be rejected")
+	}
+
+	if err := service.SetNextTier(earlyBird.ID, &regular.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !regular.SaleStart.Equal(earlyBird.SaleEnd) {
+		t.Errorf("expected Regular to go on sale when Early Bird ends, got %v", regular.SaleStart)
+	}
+
+	late := &models.TicketType{ID: 50, EventID: 1, Name: "Late", Quantity: 50, SaleEnd: regular.SaleEnd.Add(time.Hour)}
+	ticketRepo.ticketTypes[late.ID] = late
+	if err := service.SetNextTier(late.ID, &regular.ID); err == nil {
+		t.Error("expected a tier to follow only one other")
+	}
+	if err := service.SetNextTier(regular.ID, &late.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := service.SetNextTier(late.ID, &earlyBird.ID); err == nil {
+		t.Error("expected tiers not to loop")
+	}
+
+	if err := service.SetNextTier(earlyBird.ID, nil); err != nil || earlyBird.NextTierID != nil {
+		t.Errorf("expected next tier to be cleared, got %v", err)
+	}
+}
+
+func TestTicketService_ResolveActiveTier(t *testing.T) {
+	service, _, tiers, earlyBird, regular := setupTieredTicketService()
+	if err := service.SetNextTier(earlyBird.ID, &regular.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	active, err := service.ResolveActiveTier(regular.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if active.ID != regular.ID {
+		t.Errorf("expected a tier to resolve to itself, got %s", active.Name)
+	}
+	if active, _ := service.ResolveActiveTier(earlyBird.ID); active.ID != earlyBird.ID || regular.IsOnSale() {
+		t.Fatal("expected only Early Bird to be on sale")
+	}
+
+	earlyBird.Sold = earlyBird.Quantity
+	active, err = service.ResolveActiveTier(earlyBird.ID)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if active.ID != regular.ID || !regular.IsOnSale() {
+		t.Errorf("expected Regular to go on sale once Early Bird sold out, got %s", active.Name)
+	}
+	if earlyBird.IsOnSale() {
+		t.Error("expected Early Bird sales to end")
+	}
+
+	if _, err := service.ResolveActiveTier(earlyBird.ID); err != nil || tiers.advanced != 1 {
+		t.Errorf("expected the tier to be advanced once, got %d (%v)", tiers.advanced, err)
+	}
+}
+
+func TestTicketService_PurchaseTickets_ResolvesActiveTier(t *testing.T) {
+	service, _, _, earlyBird, regular := setupTieredTicketService()
+	if err := service.SetNextTier(earlyBird.ID, &regular.ID); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	earlyBird.Sold = earlyBird.Quantity
+
+	result, err := service.PurchaseTickets(&TicketPurchaseRequest{
+		EventID:          1,
+		TicketSelections: []TicketSelection{{TicketTypeID: earlyBird.ID, Quantity: 2}},
+		BillingInfo:      PaymentBillingInfo{Email: "test@example.com", Name: "Test User"},
+		PaymentMethod:    "card",
+		UserID:           1,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Order.TotalAmount != 2*regular.Price {
+		t.Errorf("expected the Regular price to be charged, got %d", result.Order.TotalAmount)
+	}
+}
@@ -0,0 +1,106 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// TrashRepositoryInterface defines the trash data operations
+type TrashRepositoryInterface interface {
+	List(kind models.TrashKind) ([]*models.TrashedItem, error)
+	Restore(kind models.TrashKind, id int) error
+	Purge(kind models.TrashKind, before time.Time) (purged, skipped int, err error)
+}
+
+// trashPurgeOrder purges ticket types and events before users, so an
+// organizer deleted along with their events is purged in the same run
+var trashPurgeOrder = []models.TrashKind{models.TrashTicketTypes, models.TrashEvents, models.TrashUsers}
+
+// TrashService lets admins restore deleted users, events and ticket types,
+// and purges them once they have been in the trash longer than the retention
+type TrashService struct {
+	repo         TrashRepositoryInterface
+	retention    time.Duration
+	auditService *AuditService
+	now          func() time.Time
+}
+
+// NewTrashService creates a new trash service
+func NewTrashService(repo TrashRepositoryInterface, retention time.Duration) *TrashService {
+	return &TrashService{
+		repo:      repo,
+		retention: retention,
+		now:       time.Now,
+	}
+}
+
+// SetAuditService records restores in the audit log
+func (s *TrashService) SetAuditService(auditService *AuditService) {
+	s.auditService = auditService
+}
+
+// Retention returns how long deleted records are kept
+func (s *TrashService) Retention() time.Duration {
+	return s.retention
+}
+
+// List returns the deleted records of a kind
+func (s *TrashService) List(kind models.TrashKind) ([]*models.TrashedItem, error) {
+	return s.repo.List(kind)
+}
+
+// Restore takes a record out of the trash
+func (s *TrashService) Restore(adminID int, kind models.TrashKind, id int, r *http.Request) error {
+	if err := s.repo.Restore(kind, id); err != nil {
+		return err
+	}
+
+	if s.auditService != nil {
+		details := map[string]interface{}{"kind": string(kind)}
+		if err := s.auditService.LogAction(adminID, models.AuditActionTrashRestore, trashAuditTarget(kind), id, details, r); err != nil {
+			fmt.Printf("Warning: failed to log restore of %s %d: %v\n", kind, id, err)
+		}
+	}
+	return nil
+}
+
+// Purge permanently deletes the records that have been in the trash longer
+// than the retention
+func (s *TrashService) Purge(ctx context.Context) (*models.TrashPurgeResult, error) {
+	before := s.now().Add(-s.retention)
+	result := &models.TrashPurgeResult{
+		Purged:  make(map[models.TrashKind]int),
+		Skipped: make(map[models.TrashKind]int),
+	}
+
+	for _, kind := range trashPurgeOrder {
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+
+		purged, skipped, err := s.repo.Purge(kind, before)
+		result.Purged[kind] += purged
+		result.Skipped[kind] += skipped
+		if err != nil {
+			return result, fmt.Errorf("failed to purge %s: %w", kind, err)
+		}
+	}
+
+	return result, nil
+}
+
+// trashAuditTarget returns the audit log target type of a trash kind
+func trashAuditTarget(kind models.TrashKind) string {
+	switch kind {
+	case models.TrashUsers:
+		return models.AuditTargetUser
+	case models.TrashEvents:
+		return models.AuditTargetEvent
+	default:
+		return models.AuditTargetTicketType
+	}
+}
@@ -0,0 +1,105 @@
+package services
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock TrashRepository for testing
+type mockTrashRepository struct {
+	purged   map[models.TrashKind]int
+	skipped  map[models.TrashKind]int
+	failKind models.TrashKind
+	order    []models.TrashKind
+	before   time.Time
+	restored []int
+}
+
+func (m *mockTrashRepository) List(kind models.TrashKind) ([]*models.TrashedItem, error) {
+	return nil, nil
+}
+
+func (m *mockTrashRepository) Restore(kind models.TrashKind, id int) error {
+	m.restored = append(m.restored, id)
+	return nil
+}
+
+func (m *mockTrashRepository) Purge(kind models.TrashKind, before time.Time) (int, int, error) {
+	m.order = append(m.order, kind)
+	m.before = before
+	if kind == m.failKind {
+		return 0, 0, errors.New("connection lost")
+	}
+	return m.purged[kind], m.skipped[kind], nil
+}
+
+func TestTrashService_Purge(t *testing.T) {
+	repo := &mockTrashRepository{
+		purged:  map[models.TrashKind]int{models.TrashUsers: 2, models.TrashEvents: 3},
+		skipped: map[models.TrashKind]int{models.TrashEvents: 1},
+	}
+	service := NewTrashService(repo, 30*24*time.Hour)
+	now := time.Date(2026, 3, 31, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	result, err := service.Purge(context.Background())
+	if err != nil {
+		t.Fatalf("Purge() error = %v", err)
+	}
+
+	if want := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC); !repo.before.Equal(want) {
+		t.Errorf("cutoff = %v, want %v", repo.before, want)
+	}
+	// Users go last, so organizers deleted with their events are purged too
+	wantOrder := []models.TrashKind{models.TrashTicketTypes, models.TrashEvents, models.TrashUsers}
+	if len(repo.order) != len(wantOrder) {
+		t.Fatalf("purged kinds = %v, want %v", repo.order, wantOrder)
+	}
+	for i, kind := range wantOrder {
+		if repo.order[i] != kind {
+			t.Errorf("purged kinds = %v, want %v", repo.order, wantOrder)
+			break
+		}
+	}
+	if result.Total() != 5 {
+		t.Errorf("Total() = %d, want 5", result.Total())
+	}
+	if result.Skipped[models.TrashEvents] != 1 {
+		t.Errorf("skipped events = %d, want 1", result.Skipped[models.TrashEvents])
+	}
+}
+
+func TestTrashService_PurgeStopsOnError(t *testing.T) {
+	repo := &mockTrashRepository{
+		purged:   map[models.TrashKind]int{models.TrashTicketTypes: 4},
+		failKind: models.TrashEvents,
+	}
+	service := NewTrashService(repo, 24*time.Hour)
+
+	result, err := service.Purge(context.Background())
+	if err == nil {
+		t.Fatal("Purge() expected an error")
+	}
+	if len(repo.order) != 2 {
+		t.Errorf("purged kinds = %v, want users left untouched", repo.order)
+	}
+	if result.Purged[models.TrashTicketTypes] != 4 {
+		t.Errorf("purged ticket types = %d, want 4", result.Purged[models.TrashTicketTypes])
+	}
+}
+
+func TestTrashService_Restore(t *testing.T) {
+	repo := &mockTrashRepository{}
+	service := NewTrashService(repo, 24*time.Hour)
+
+	if err := service.Restore(1, models.TrashEvents, 42, nil); err != nil {
+		t.Fatalf("Restore() error = %v", err)
+	}
+	if len(repo.restored) != 1 || repo.restored[0] != 42 {
+		t.Errorf("restored = %v, want [42]", repo.restored)
+	}
+}
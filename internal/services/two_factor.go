@@ -0,0 +1,264 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/utils"
+)
+
+// backupCodeCount is the number of backup codes issued at a time
+const backupCodeCount = 10
+
+// TwoFactorRepository interface for two-factor authentication data operations
+type TwoFactorRepository interface {
+	GetByUser(userID int) (*models.TwoFactorAuth, error)
+	SavePending(userID int, secret string) error
+	Enable(userID int, step int64) error
+	UpdateLastUsedStep(userID int, step int64) (bool, error)
+	Delete(userID int) error
+	ReplaceBackupCodes(userID int, codeHashes []string) error
+	UseBackupCode(userID int, codeHash string) (bool, error)
+	CountUnusedBackupCodes(userID int) (int, error)
+}
+
+// TwoFactorSettings provides the system settings that control 2FA requirements
+type TwoFactorSettings interface {
+	GetSettings() (*models.SystemSettings, error)
+}
+
+// TwoFactorEnrollment holds the details shown to a user while setting up 2FA
+type TwoFactorEnrollment struct {
+	Secret          string
+	ProvisioningURI string
+}
+
+// TwoFactorStatus summarizes a user's 2FA state for the security page
+type TwoFactorStatus struct {
+	Enabled              bool
+	Required             bool
+	BackupCodesRemaining int
+}
+
+// TwoFactorService handles TOTP two-factor authentication
+type TwoFactorService struct {
+	repo     TwoFactorRepository
+	settings TwoFactorSettings
+	issuer   string
+	now      func() time.Time
+}
+
+// NewTwoFactorService creates a new two-factor authentication service
+func NewTwoFactorService(repo TwoFactorRepository, settings TwoFactorSettings, issuer string) *TwoFactorService {
+	return &TwoFactorService{
+		repo:     repo,
+		settings: settings,
+		issuer:   issuer,
+		now:      time.Now,
+	}
+}
+
+// IsEnabled returns true if the user has confirmed 2FA enrollment
+func (s *TwoFactorService) IsEnabled(userID int) (bool, error) {
+	tfa, err := s.repo.GetByUser(userID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return false, nil
+		}
+		return false, err
+	}
+	return tfa.IsEnabled(), nil
+}
+
+// IsRequired returns true if system settings require 2FA for the user's role
+func (s *TwoFactorService) IsRequired(user *models.User) bool {
+	if s.settings == nil || user == nil {
+		return false
+	}
+
+	settings, err := s.settings.GetSettings()
+	if err != nil {
+		fmt.Printf("Warning: failed to load settings for 2FA requirement: %v\n", err)
+		return false
+	}
+
+	switch user.Role {
+	case models.UserRoleAdmin:
+		return settings.RequireTwoFactorAdmins
+	case models.UserRoleOrganizer:
+		return settings.RequireTwoFactorOrganizers
+	default:
+		return false
+	}
+}
+
+// GetStatus returns the user's current 2FA status
+func (s *TwoFactorService) GetStatus(user *models.User) (*TwoFactorStatus, error) {
+	enabled, err := s.IsEnabled(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	status := &TwoFactorStatus{
+		Enabled:  enabled,
+		Required: s.IsRequired(user),
+	}
+
+	if enabled {
+		remaining, err := s.repo.CountUnusedBackupCodes(user.ID)
+		if err != nil {
+			return nil, err
+		}
+		status.BackupCodesRemaining = remaining
+	}
+
+	return status, nil
+}
+
+// BeginEnrollment generates a new secret for the user. 2FA is not enabled
+// until the user confirms it with a code from their authenticator app.
+func (s *TwoFactorService) BeginEnrollment(user *models.User) (*TwoFactorEnrollment, error) {
+	enabled, err := s.IsEnabled(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if enabled {
+		return nil, fmt.Errorf("two-factor authentication is already enabled")
+	}
+
+	secret, err := utils.GenerateTOTPSecret()
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.SavePending(user.ID, secret); err != nil {
+		return nil, err
+	}
+
+	return &TwoFactorEnrollment{
+		Secret:          secret,
+		ProvisioningURI: utils.TOTPProvisioningURI(s.issuer, user.Email, secret),
+	}, nil
+}
+
+// PendingEnrollment returns the enrollment details for a secret that has been
+// generated but not yet confirmed, or nil if there is none.
+func (s *TwoFactorService) PendingEnrollment(user *models.User) (*TwoFactorEnrollment, error) {
+	tfa, err := s.repo.GetByUser(user.ID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if tfa.IsEnabled() {
+		return nil, nil
+	}
+
+	return &TwoFactorEnrollment{
+		Secret:          tfa.Secret,
+		ProvisioningURI: utils.TOTPProvisioningURI(s.issuer, user.Email, tfa.Secret),
+	}, nil
+}
+
+// ConfirmEnrollment enables 2FA once the user proves their authenticator app
+// works. It returns the plain backup codes, which are only shown once.
+func (s *TwoFactorService) ConfirmEnrollment(userID int, code string) ([]string, error) {
+	tfa, err := s.repo.GetByUser(userID)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, fmt.Errorf("two-factor setup has not been started")
+		}
+		return nil, err
+	}
+	if tfa.IsEnabled() {
+		return nil, fmt.Errorf("two-factor authentication is already enabled")
+	}
+
+	step, ok := utils.ValidateTOTP(tfa.Secret, code, s.now())
+	if !ok {
+		return nil, fmt.Errorf("invalid verification code")
+	}
+
+	if err := s.repo.Enable(userID, step); err != nil {
+		return nil, err
+	}
+
+	return s.issueBackupCodes(userID)
+}
+
+// Verify checks a login code, accepting either a TOTP code or an unused backup code
+func (s *TwoFactorService) Verify(userID int, code string) error {
+	tfa, err := s.repo.GetByUser(userID)
+	if err != nil {
+		return err
+	}
+	if !tfa.IsEnabled() {
+		return fmt.Errorf("two-factor authentication is not enabled")
+	}
+
+	if step, ok := utils.ValidateTOTP(tfa.Secret, code, s.now()); ok {
+		updated, err := s.repo.UpdateLastUsedStep(userID, step)
+		if err != nil {
+			return err
+		}
+		if !updated {
+			return fmt.Errorf("verification code has already been used")
+		}
+		return nil
+	}
+
+	used, err := s.repo.UseBackupCode(userID, utils.HashBackupCode(code))
+	if err != nil {
+		return err
+	}
+	if !used {
+		return fmt.Errorf("invalid verification code")
+	}
+
+	return nil
+}
+
+// RegenerateBackupCodes replaces the user's backup codes after verifying a current code
+func (s *TwoFactorService) RegenerateBackupCodes(userID int, code string) ([]string, error) {
+	if err := s.Verify(userID, code); err != nil {
+		return nil, err
+	}
+	return s.issueBackupCodes(userID)
+}
+
+// Disable turns off 2FA after verifying a current code. It is refused when
+// system settings require 2FA for the user's role.
+func (s *TwoFactorService) Disable(user *models.User, code string) error {
+	if s.IsRequired(user) {
+		return fmt.Errorf("two-factor authentication is required for your role")
+	}
+
+	if err := s.Verify(user.ID, code); err != nil {
+		return err
+	}
+
+	return s.repo.Delete(user.ID)
+}
+
+// issueBackupCodes generates a fresh set of backup codes and stores their hashes
+func (s *TwoFactorService) issueBackupCodes(userID int) ([]string, error) {
+	codes := make([]string, 0, backupCodeCount)
+	hashes := make([]string, 0, backupCodeCount)
+	for i := 0; i < backupCodeCount; i++ {
+		code, err := utils.GenerateBackupCode()
+		if err != nil {
+			return nil, err
+		}
+		codes = append(codes, code)
+		hashes = append(hashes, utils.HashBackupCode(code))
+	}
+
+	if err := s.repo.ReplaceBackupCodes(userID, hashes); err != nil {
+		return nil, err
+	}
+
+	return codes, nil
+}
@@ -0,0 +1,234 @@
+package services
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/utils"
+)
+
+// Mock TwoFactorRepository for testing
+type mockTwoFactorRepository struct {
+	enrollments map[int]*models.TwoFactorAuth
+	backupCodes map[int]map[string]bool
+}
+
+func newMockTwoFactorRepository() *mockTwoFactorRepository {
+	return &mockTwoFactorRepository{
+		enrollments: make(map[int]*models.TwoFactorAuth),
+		backupCodes: make(map[int]map[string]bool),
+	}
+}
+
+func (m *mockTwoFactorRepository) GetByUser(userID int) (*models.TwoFactorAuth, error) {
+	tfa, exists := m.enrollments[userID]
+	if !exists {
+		return nil, fmt.Errorf("two-factor authentication not found")
+	}
+	return tfa, nil
+}
+
+func (m *mockTwoFactorRepository) SavePending(userID int, secret string) error {
+	m.enrollments[userID] = &models.TwoFactorAuth{UserID: userID, Secret: secret, CreatedAt: time.Now()}
+	return nil
+}
+
+func (m *mockTwoFactorRepository) Enable(userID int, step int64) error {
+	tfa, exists := m.enrollments[userID]
+	if !exists {
+		return fmt.Errorf("two-factor authentication not found")
+	}
+	now := time.Now()
+	tfa.EnabledAt = &now
+	tfa.LastUsedStep = step
+	return nil
+}
+
+func (m *mockTwoFactorRepository) UpdateLastUsedStep(userID int, step int64) (bool, error) {
+	tfa, exists := m.enrollments[userID]
+	if !exists || tfa.LastUsedStep >= step {
+		return false, nil
+	}
+	tfa.LastUsedStep = step
+	return true, nil
+}
+
+func (m *mockTwoFactorRepository) Delete(userID int) error {
+	delete(m.enrollments, userID)
+	delete(m.backupCodes, userID)
+	return nil
+}
+
+func (m *mockTwoFactorRepository) ReplaceBackupCodes(userID int, codeHashes []string) error {
+	m.backupCodes[userID] = make(map[string]bool)
+	for _, hash := range codeHashes {
+		m.backupCodes[userID][hash] = false
+	}
+	return nil
+}
+
+func (m *mockTwoFactorRepository) UseBackupCode(userID int, codeHash string) (bool, error) {
+	used, exists := m.backupCodes[userID][codeHash]
+	if !exists || used {
+		return false, nil
+	}
+	m.backupCodes[userID][codeHash] = true
+	return true, nil
+}
+
+func (m *mockTwoFactorRepository) CountUnusedBackupCodes(userID int) (int, error) {
+	count := 0
+	for _, used := range m.backupCodes[userID] {
+		if !used {
+			count++
+		}
+	}
+	return count, nil
+}
+
+// Mock TwoFactorSettings for testing
+type mockTwoFactorSettings struct {
+	settings *models.SystemSettings
+}
+
+func (m *mockTwoFactorSettings) GetSettings() (*models.SystemSettings, error) {
+	return m.settings, nil
+}
+
+func setupTwoFactorService() (*TwoFactorService, *mockTwoFactorRepository, *mockTwoFactorSettings, *time.Time) {
+	repo := newMockTwoFactorRepository()
+	settings := &mockTwoFactorSettings{settings: models.DefaultSettings()}
+	service := NewTwoFactorService(repo, settings, "Runtown")
+
+	now := time.Date(2025, 6, 1, 12, 0, 0, 0, time.UTC)
+	service.now = func() time.Time { return now }
+
+	return service, repo, settings, &now
+}
+
+func enrollTwoFactor(t *testing.T, service *TwoFactorService, user *models.User, now time.Time) (string, []string) {
+	t.Helper()
+
+	enrollment, err := service.BeginEnrollment(user)
+	if err != nil {
+		t.Fatalf("unexpected error beginning enrollment: %v", err)
+	}
+
+	code, _ := utils.TOTPCode(enrollment.Secret, utils.TOTPStep(now))
+	backupCodes, err := service.ConfirmEnrollment(user.ID, code)
+	if err != nil {
+		t.Fatalf("unexpected error confirming enrollment: %v", err)
+	}
+
+	return enrollment.Secret, backupCodes
+}
+
+func TestTwoFactorService_Enrollment(t *testing.T) {
+	service, _, _, now := setupTwoFactorService()
+	user := &models.User{ID: 1, Email: "organizer@example.com", Role: models.UserRoleOrganizer}
+
+	enrollment, err := service.BeginEnrollment(user)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(enrollment.ProvisioningURI, "secret="+enrollment.Secret) {
+		t.Errorf("expected provisioning URI to contain the secret: %s", enrollment.ProvisioningURI)
+	}
+
+	if enabled, _ := service.IsEnabled(user.ID); enabled {
+		t.Error("expected 2FA to stay disabled until confirmed")
+	}
+
+	if _, err := service.ConfirmEnrollment(user.ID, "000000"); err == nil {
+		t.Error("expected invalid code to be rejected")
+	}
+
+	code, _ := utils.TOTPCode(enrollment.Secret, utils.TOTPStep(*now))
+	backupCodes, err := service.ConfirmEnrollment(user.ID, code)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(backupCodes) != backupCodeCount {
+		t.Errorf("expected %d backup codes, got %d", backupCodeCount, len(backupCodes))
+	}
+
+	status, _ := service.GetStatus(user)
+	if !status.Enabled || status.BackupCodesRemaining != backupCodeCount {
+		t.Errorf("unexpected status: %+v", status)
+	}
+
+	if _, err := service.BeginEnrollment(user); err == nil {
+		t.Error("expected error when 2FA is already enabled")
+	}
+}
+
+func TestTwoFactorService_Verify(t *testing.T) {
+	service, _, _, now := setupTwoFactorService()
+	user := &models.User{ID: 1, Email: "user@example.com", Role: models.UserRoleUser}
+	secret, backupCodes := enrollTwoFactor(t, service, user, *now)
+
+	// The code used to confirm enrollment cannot be replayed
+	code, _ := utils.TOTPCode(secret, utils.TOTPStep(*now))
+	if err := service.Verify(user.ID, code); err == nil {
+		t.Error("expected reused code to be rejected")
+	}
+
+	*now = now.Add(utils.TOTPPeriod)
+	code, _ = utils.TOTPCode(secret, utils.TOTPStep(*now))
+	if err := service.Verify(user.ID, code); err != nil {
+		t.Errorf("expected fresh code to be accepted: %v", err)
+	}
+
+	if err := service.Verify(user.ID, backupCodes[0]); err != nil {
+		t.Errorf("expected backup code to be accepted: %v", err)
+	}
+	if err := service.Verify(user.ID, backupCodes[0]); err == nil {
+		t.Error("expected used backup code to be rejected")
+	}
+
+	if err := service.Verify(user.ID, "ZZZZZ-ZZZZZ"); err == nil {
+		t.Error("expected unknown backup code to be rejected")
+	}
+
+	status, _ := service.GetStatus(user)
+	if status.BackupCodesRemaining != backupCodeCount-1 {
+		t.Errorf("expected %d backup codes remaining, got %d", backupCodeCount-1, status.BackupCodesRemaining)
+	}
+}
+
+func TestTwoFactorService_RequiredRoles(t *testing.T) {
+	service, _, settings, now := setupTwoFactorService()
+	admin := &models.User{ID: 1, Email: "admin@example.com", Role: models.UserRoleAdmin}
+	organizer := &models.User{ID: 2, Email: "organizer@example.com", Role: models.UserRoleOrganizer}
+	attendee := &models.User{ID: 3, Email: "user@example.com", Role: models.UserRoleUser}
+
+	if service.IsRequired(admin) || service.IsRequired(organizer) {
+		t.Error("expected 2FA to be optional by default")
+	}
+
+	settings.settings.RequireTwoFactorAdmins = true
+	if !service.IsRequired(admin) {
+		t.Error("expected 2FA to be required for admins")
+	}
+	if service.IsRequired(organizer) || service.IsRequired(attendee) {
+		t.Error("expected 2FA to stay optional for other roles")
+	}
+
+	secret, _ := enrollTwoFactor(t, service, admin, *now)
+	*now = now.Add(utils.TOTPPeriod)
+	code, _ := utils.TOTPCode(secret, utils.TOTPStep(*now))
+	if err := service.Disable(admin, code); err == nil || !strings.Contains(err.Error(), "required") {
+		t.Errorf("expected required 2FA not to be disabled, got %v", err)
+	}
+
+	settings.settings.RequireTwoFactorAdmins = false
+	if err := service.Disable(admin, code); err != nil {
+		t.Errorf("unexpected error disabling 2FA: %v", err)
+	}
+	if enabled, _ := service.IsEnabled(admin.ID); enabled {
+		t.Error("expected 2FA to be disabled")
+	}
+}
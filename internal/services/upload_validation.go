@@ -0,0 +1,98 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+)
+
+// ErrInvalidImage is returned when an uploaded image is rejected before
+// anything is stored
+var ErrInvalidImage = errors.New("invalid image")
+
+// ErrMalwareDetected is returned when the upload scanner finds malware
+var ErrMalwareDetected = fmt.Errorf("%w: malware detected", ErrInvalidImage)
+
+// ImageUploadLimits bounds the dimensions of uploaded images. Images are
+// decoded in full to generate variants, so the limits keep small files that
+// expand to huge bitmaps out.
+type ImageUploadLimits struct {
+	MaxWidth      int
+	MaxHeight     int
+	MaxMegapixels int
+}
+
+// DefaultImageUploadLimits allows photos from current phones and cameras
+var DefaultImageUploadLimits = ImageUploadLimits{
+	MaxWidth:      8000,
+	MaxHeight:     8000,
+	MaxMegapixels: 40,
+}
+
+// UploadScanner scans uploaded files for malware. Scan returns an error
+// wrapping ErrMalwareDetected when the file is infected.
+type UploadScanner interface {
+	Scan(ctx context.Context, data []byte) error
+}
+
+// imageSignatures are the magic bytes each accepted image format starts with
+var imageSignatures = []struct {
+	format string
+	match  func(data []byte) bool
+}{
+	{"jpeg", func(data []byte) bool { return bytes.HasPrefix(data, []byte{0xFF, 0xD8, 0xFF}) }},
+	{"png", func(data []byte) bool { return bytes.HasPrefix(data, []byte("\x89PNG\r\n\x1a\n")) }},
+	{"webp", func(data []byte) bool {
+		return len(data) >= 12 && bytes.HasPrefix(data, []byte("RIFF")) && bytes.Equal(data[8:12], []byte("WEBP"))
+	}},
+}
+
+// sniffImageFormat returns the image format the file's magic bytes show,
+// whatever its name or declared content type say
+func sniffImageFormat(data []byte) (string, bool) {
+	for _, signature := range imageSignatures {
+		if signature.match(data) {
+			return signature.format, true
+		}
+	}
+	return "", false
+}
+
+// validateUpload checks an uploaded image before it is decoded or stored: its
+// magic bytes must match an accepted format that its header agrees with, its
+// dimensions must be within the limits and the scanner, when there is one,
+// must find it clean. It returns the image's format.
+func (s *ImageService) validateUpload(ctx context.Context, data []byte) (string, error) {
+	format, ok := sniffImageFormat(data)
+	if !ok {
+		return "", fmt.Errorf("%w: only JPEG, PNG and WebP images are accepted", ErrInvalidImage)
+	}
+
+	config, decodedFormat, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil || decodedFormat != format {
+		return "", fmt.Errorf("%w: the file is not a valid %s image", ErrInvalidImage, format)
+	}
+
+	limits := s.limits
+	if config.Width > limits.MaxWidth || config.Height > limits.MaxHeight {
+		return "", fmt.Errorf("%w: images can be at most %dx%d pixels, this one is %dx%d", ErrInvalidImage, limits.MaxWidth, limits.MaxHeight, config.Width, config.Height)
+	}
+	if int64(config.Width)*int64(config.Height) > int64(limits.MaxMegapixels)*1_000_000 {
+		return "", fmt.Errorf("%w: images can be at most %d megapixels", ErrInvalidImage, limits.MaxMegapixels)
+	}
+
+	if s.scanner != nil {
+		if err := s.scanner.Scan(ctx, data); err != nil {
+			if errors.Is(err, ErrMalwareDetected) {
+				return "", err
+			}
+			// Fail closed, so uploads aren't stored unscanned while the
+			// scanner is down
+			return "", fmt.Errorf("failed to scan upload: %w", err)
+		}
+	}
+
+	return format, nil
+}
@@ -0,0 +1,145 @@
+package services
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Fake UploadScanner for testing
+type fakeUploadScanner struct {
+	err     error
+	scanned int
+}
+
+func (f *fakeUploadScanner) Scan(ctx context.Context, data []byte) error {
+	f.scanned++
+	return f.err
+}
+
+// withEXIFOrientation inserts an EXIF segment with the orientation tag into
+// a JPEG
+func withEXIFOrientation(jpegData []byte, orientation byte) []byte {
+	tiff := []byte("MM\x00\x2a\x00\x00\x00\x08" + // Big endian header, first IFD at 8
+		"\x00\x01" + // One entry
+		"\x01\x12\x00\x03\x00\x00\x00\x01\x00" + string(orientation) + "\x00\x00" + // Orientation, SHORT
+		"\x00\x00\x00\x00") // No next IFD
+	payload := append([]byte("Exif\x00\x00"), tiff...)
+	segment := append([]byte{0xFF, 0xE1, byte((len(payload) + 2) >> 8), byte(len(payload) + 2)}, payload...)
+
+	data := append([]byte{}, jpegData[:2]...)
+	data = append(data, segment...)
+	return append(data, jpegData[2:]...)
+}
+
+func TestSniffImageFormat(t *testing.T) {
+	tests := []struct {
+		name   string
+		data   []byte
+		format string
+		ok     bool
+	}{
+		{"jpeg", createTestJPEG(10, 10), "jpeg", true},
+		{"png", createTestPNG(10, 10), "png", true},
+		{"webp", []byte("RIFF\x24\x00\x00\x00WEBPVP8 "), "webp", true},
+		{"gif", []byte("GIF89a"), "", false},
+		{"html", []byte("<html><script>alert(1)</script>"), "", false},
+		{"empty", nil, "", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, ok := sniffImageFormat(tt.data)
+			assert.Equal(t, tt.format, format)
+			assert.Equal(t, tt.ok, ok)
+		})
+	}
+}
+
+func TestImageService_UploadRejectsBeforeStoring(t *testing.T) {
+	jpegHeaderOnly := append([]byte{0xFF, 0xD8, 0xFF, 0xE0}, []byte("not really a jpeg")...)
+
+	limits := ImageUploadLimits{MaxWidth: 250, MaxHeight: 250, MaxMegapixels: 1}
+	tests := []struct {
+		name   string
+		data   []byte
+		limits ImageUploadLimits
+	}{
+		{"disguised script", []byte("<?php system($_GET['c']); ?>"), limits},
+		{"magic bytes without an image", jpegHeaderOnly, limits},
+		{"png magic with a jpeg body", append([]byte("\x89PNG\r\n\x1a\n"), createTestJPEG(10, 10)...), limits},
+		{"too wide", createTestPNG(300, 10), limits},
+		{"too many megapixels", createTestPNG(200, 200), ImageUploadLimits{MaxWidth: 250, MaxHeight: 250, MaxMegapixels: 0}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			storage := newFakeBlobStorage("https://cdn.example.com")
+			service := NewImageService(storage)
+			service.SetUploadLimits(tt.limits)
+
+			_, err := service.UploadImage(context.Background(), bytes.NewReader(tt.data), "photo.jpg")
+			assert.ErrorIs(t, err, ErrInvalidImage)
+			assert.Equal(t, 0, storage.uploads, "nothing should be stored")
+		})
+	}
+}
+
+func TestImageService_UploadScanning(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("clean", func(t *testing.T) {
+		storage := newFakeBlobStorage("https://cdn.example.com")
+		service := NewImageService(storage)
+		scanner := &fakeUploadScanner{}
+		service.SetUploadScanner(scanner)
+
+		_, err := service.UploadImage(ctx, bytes.NewReader(createTestJPEG(100, 100)), "photo.jpg")
+		require.NoError(t, err)
+		assert.Equal(t, 1, scanner.scanned)
+	})
+
+	t.Run("infected", func(t *testing.T) {
+		storage := newFakeBlobStorage("https://cdn.example.com")
+		service := NewImageService(storage)
+		service.SetUploadScanner(&fakeUploadScanner{err: ErrMalwareDetected})
+
+		_, err := service.UploadImage(ctx, bytes.NewReader(createTestJPEG(100, 100)), "photo.jpg")
+		assert.ErrorIs(t, err, ErrMalwareDetected)
+		assert.Equal(t, 0, storage.uploads)
+	})
+
+	t.Run("scanner unavailable", func(t *testing.T) {
+		storage := newFakeBlobStorage("https://cdn.example.com")
+		service := NewImageService(storage)
+		service.SetUploadScanner(&fakeUploadScanner{err: errors.New("connection refused")})
+
+		_, err := service.UploadImage(ctx, bytes.NewReader(createTestJPEG(100, 100)), "photo.jpg")
+		assert.Error(t, err)
+		assert.False(t, errors.Is(err, ErrInvalidImage), "an unavailable scanner isn't the uploader's fault")
+		assert.Equal(t, 0, storage.uploads)
+	})
+}
+
+func TestImageService_UploadStripsEXIF(t *testing.T) {
+	storage := newFakeBlobStorage("https://cdn.example.com")
+	service := NewImageService(storage)
+
+	// Orientation 6 means the camera was turned; the image is stored upright
+	data := withEXIFOrientation(createTestJPEG(200, 100), 6)
+	result, err := service.UploadImage(context.Background(), bytes.NewReader(data), "photo.jpg")
+	require.NoError(t, err)
+	assert.Equal(t, 100, result.Original.Width)
+	assert.Equal(t, 200, result.Original.Height)
+
+	original := storage.blobs[result.Original.Key]
+	assert.False(t, bytes.Contains(original, []byte("Exif")), "EXIF metadata should be stripped")
+	config, _, err := image.DecodeConfig(bytes.NewReader(original))
+	require.NoError(t, err)
+	assert.Equal(t, 100, config.Width)
+}
@@ -2,6 +2,7 @@ package services
 
 import (
 	"fmt"
+	"net/http"
 
 	"event-ticketing-platform/internal/models"
 )
@@ -17,14 +18,15 @@ type UserServiceInterface interface {
 	GetUsersWithPagination(page, limit int, search, roleFilter string) ([]*models.User, int, error)
 	GetUserCount() (int, error)
 	GetActiveUserCount() (int, error)
-	UpdateUserRole(userID int, role models.UserRole) error
-	SuspendUser(userID int) error
-	ActivateUser(userID int) error
+	UpdateUserRole(adminID, userID int, role models.UserRole, r *http.Request) error
+	SuspendUser(adminID, userID int, r *http.Request) error
+	ActivateUser(adminID, userID int, r *http.Request) error
 }
 
 // UserService handles user-related business logic
 type UserService struct {
-	userRepo UserRepositoryInterface
+	userRepo     UserRepositoryInterface
+	auditService *AuditService
 }
 
 // NewUserService creates a new user service
@@ -34,6 +36,12 @@ func NewUserService(userRepo UserRepositoryInterface) *UserService {
 	}
 }
 
+// SetAuditService records admins' role changes, suspensions and activations
+// in the audit log
+func (s *UserService) SetAuditService(auditService *AuditService) {
+	s.auditService = auditService
+}
+
 // UpdateProfileRequest represents a profile update request
 type UpdateProfileRequest struct {
 	FirstName string `json:"first_name"`
@@ -105,15 +113,9 @@ func (s *UserService) UpdatePreferences(userID int, preferences *UserPreferences
 	return nil
 }
 
-// DeleteAccount deletes a user account and all associated data
+// DeleteAccount moves a user account to the trash, where admins can restore
+// it until the retention job purges it
 func (s *UserService) DeleteAccount(userID int) error {
-	// In a real implementation, you would:
-	// 1. Cancel any active orders
-	// 2. Delete user sessions
-	// 3. Anonymize or delete user data according to privacy policy
-	// 4. Send confirmation email
-	
-	// For now, we'll just delete the user record
 	err := s.userRepo.Delete(userID)
 	if err != nil {
 		return fmt.Errorf("failed to delete user account: %w", err)
@@ -189,17 +191,47 @@ func (s *UserService) GetActiveUserCount() (int, error) {
 	return s.userRepo.GetActiveUserCount()
 }
 
-// UpdateUserRole updates a user's role
-func (s *UserService) UpdateUserRole(userID int, role models.UserRole) error {
-	return s.userRepo.UpdateUserRole(userID, role)
+// UpdateUserRole updates a user's role on behalf of an admin
+func (s *UserService) UpdateUserRole(adminID, userID int, role models.UserRole, r *http.Request) error {
+	details := map[string]interface{}{"new_role": string(role)}
+	if user, err := s.userRepo.GetByID(userID); err == nil {
+		details["previous_role"] = string(user.Role)
+	}
+
+	if err := s.userRepo.UpdateUserRole(userID, role); err != nil {
+		return err
+	}
+
+	s.logUserAction(adminID, models.AuditActionUserRoleChange, userID, details, r)
+	return nil
 }
 
-// SuspendUser suspends a user account
-func (s *UserService) SuspendUser(userID int) error {
-	return s.userRepo.UpdateUserStatus(userID, false)
+// SuspendUser suspends a user account on behalf of an admin
+func (s *UserService) SuspendUser(adminID, userID int, r *http.Request) error {
+	if err := s.userRepo.UpdateUserStatus(userID, false); err != nil {
+		return err
+	}
+
+	s.logUserAction(adminID, models.AuditActionUserSuspend, userID, nil, r)
+	return nil
 }
 
-// ActivateUser activates a user account
-func (s *UserService) ActivateUser(userID int) error {
-	return s.userRepo.UpdateUserStatus(userID, true)
+// ActivateUser activates a user account on behalf of an admin
+func (s *UserService) ActivateUser(adminID, userID int, r *http.Request) error {
+	if err := s.userRepo.UpdateUserStatus(userID, true); err != nil {
+		return err
+	}
+
+	s.logUserAction(adminID, models.AuditActionUserActivate, userID, nil, r)
+	return nil
+}
+
+// logUserAction records an admin's change to a user in the audit log
+func (s *UserService) logUserAction(adminID int, action string, userID int, details interface{}, r *http.Request) {
+	if s.auditService == nil {
+		return
+	}
+	if err := s.auditService.LogAction(adminID, action, models.AuditTargetUser, userID, details, r); err != nil {
+		fmt.Printf("Warning: failed to log %s of user %d: %v\n", action, userID, err)
+	}
 }
\ No newline at end of file
@@ -0,0 +1,299 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// ErrVenueNotFound is returned when a venue doesn't exist or belongs to
+// another organizer
+var ErrVenueNotFound = errors.New("venue not found")
+
+// maxVenueImageSize is the largest venue image organizers can upload
+const maxVenueImageSize = 5 * 1024 * 1024
+
+// VenueRepository defines the data operations for venues
+type VenueRepository interface {
+	Create(venue *models.Venue) error
+	GetByID(id int) (*models.Venue, error)
+	GetBySlug(slug string) (*models.Venue, error)
+	GetByOrganizer(organizerID int) ([]*models.Venue, error)
+	Update(venue *models.Venue) error
+	Delete(id, organizerID int) error
+	GetEventVenue(eventID int) (*models.Venue, error)
+	SetEventVenue(eventID int, venue *models.Venue) error
+	GetEvents(venueID int) ([]*models.Event, error)
+}
+
+// VenueRequest is a venue an organizer has entered or edited
+type VenueRequest struct {
+	Name         string
+	Address      string
+	City         string
+	Capacity     int
+	Coordinates  *models.GeoPoint // Geocoded from the address when nil
+	Description  string
+	Images       []*multipart.FileHeader
+	RemoveImages []string // URLs of current images to remove
+}
+
+// VenueService manages the venues organizers reuse across their events and
+// the public venue pages listing the events held at them
+type VenueService struct {
+	repo       VenueRepository
+	geocoder   Geocoder
+	uploadPath string
+	now        func() time.Time
+}
+
+// NewVenueService creates a new venue service. Venue images are saved under
+// uploadPath.
+func NewVenueService(repo VenueRepository, uploadPath string) *VenueService {
+	return &VenueService{
+		repo:       repo,
+		uploadPath: uploadPath,
+		now:        time.Now,
+	}
+}
+
+// SetGeocoder looks up the coordinates of venues saved without them
+func (s *VenueService) SetGeocoder(geocoder Geocoder) {
+	s.geocoder = geocoder
+}
+
+// GetVenues returns an organizer's venues by name
+func (s *VenueService) GetVenues(organizerID int) ([]*models.Venue, error) {
+	venues, err := s.repo.GetByOrganizer(organizerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get venues: %w", err)
+	}
+	return venues, nil
+}
+
+// GetVenue returns one of an organizer's venues
+func (s *VenueService) GetVenue(id, organizerID int) (*models.Venue, error) {
+	venue, err := s.repo.GetByID(id)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			return nil, ErrVenueNotFound
+		}
+		return nil, err
+	}
+	if venue.OrganizerID != organizerID {
+		return nil, ErrVenueNotFound
+	}
+	return venue, nil
+}
+
+// CreateVenue validates and saves a new venue for an organizer
+func (s *VenueService) CreateVenue(organizerID int, req *VenueRequest) (*models.Venue, error) {
+	venue := &models.Venue{OrganizerID: organizerID}
+	saved, err := s.applyRequest(venue, req)
+	if err != nil {
+		return nil, err
+	}
+	venue.Slug = models.VenueSlug(venue.Name, venue.City)
+
+	if err := s.repo.Create(venue); err != nil {
+		s.removeImages(saved...)
+		return nil, err
+	}
+	return venue, nil
+}
+
+// UpdateVenue saves an organizer's edits to their venue. Upcoming events at
+// the venue move to its new name and city.
+func (s *VenueService) UpdateVenue(id, organizerID int, req *VenueRequest) (*models.Venue, error) {
+	current, err := s.GetVenue(id, organizerID)
+	if err != nil {
+		return nil, err
+	}
+
+	venue := *current
+	venue.Images = append([]string(nil), current.Images...)
+	saved, err := s.applyRequest(&venue, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := s.repo.Update(&venue); err != nil {
+		s.removeImages(saved...)
+		return nil, err
+	}
+
+	kept := map[string]bool{}
+	for _, url := range venue.Images {
+		kept[url] = true
+	}
+	for _, url := range current.Images {
+		if !kept[url] {
+			s.removeImages(url)
+		}
+	}
+	return &venue, nil
+}
+
+// DeleteVenue deletes an organizer's venue and its images. Events held at it
+// keep their location.
+func (s *VenueService) DeleteVenue(id, organizerID int) error {
+	venue, err := s.GetVenue(id, organizerID)
+	if err != nil {
+		return err
+	}
+	if err := s.repo.Delete(id, organizerID); err != nil {
+		return err
+	}
+	s.removeImages(venue.Images...)
+	return nil
+}
+
+// GetVenuePage returns a venue's public page with the published events held
+// at it
+func (s *VenueService) GetVenuePage(slug string) (*models.VenuePage, error) {
+	venue, err := s.repo.GetBySlug(slug)
+	if err != nil {
+		return nil, err
+	}
+
+	events, err := s.repo.GetEvents(venue.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get venue events: %w", err)
+	}
+
+	page := &models.VenuePage{Venue: venue}
+	now := s.now()
+	for _, event := range events {
+		if event.EndDate.After(now) {
+			page.UpcomingEvents = append(page.UpcomingEvents, event)
+		} else {
+			page.PastEvents = append(page.PastEvents, event)
+		}
+	}
+	sortEventsByStart(page.UpcomingEvents, false)
+	sortEventsByStart(page.PastEvents, true)
+	return page, nil
+}
+
+// GetEventVenue returns the venue an event is held at, or nil if it has none
+func (s *VenueService) GetEventVenue(eventID int) (*models.Venue, error) {
+	return s.repo.GetEventVenue(eventID)
+}
+
+// SetEventVenue holds an event at a venue, setting its location from the
+// venue, or detaches it from its venue when venue is nil
+func (s *VenueService) SetEventVenue(eventID int, venue *models.Venue) error {
+	return s.repo.SetEventVenue(eventID, venue)
+}
+
+// applyRequest validates a venue request into venue, storing any new images,
+// and returns the URLs of the images it stored
+func (s *VenueService) applyRequest(venue *models.Venue, req *VenueRequest) ([]string, error) {
+	venue.Name = req.Name
+	venue.Address = req.Address
+	venue.City = req.City
+	venue.Capacity = req.Capacity
+	venue.Coordinates = req.Coordinates
+	venue.Description = req.Description
+
+	removed := map[string]bool{}
+	for _, url := range req.RemoveImages {
+		removed[url] = true
+	}
+	var images []string
+	for _, url := range venue.Images {
+		if !removed[url] {
+			images = append(images, url)
+		}
+	}
+	venue.Images = images
+	if len(venue.Images)+len(req.Images) > models.MaxVenueImages {
+		return nil, fmt.Errorf("venues can have at most %d images", models.MaxVenueImages)
+	}
+	if err := venue.Validate(); err != nil {
+		return nil, err
+	}
+
+	if venue.Coordinates == nil && s.geocoder != nil {
+		point, err := s.geocoder.Geocode(venue.FullAddress())
+		if err != nil {
+			fmt.Printf("Warning: failed to geocode venue %q: %v\n", venue.FullAddress(), err)
+		} else if point != nil && point.Valid() {
+			venue.Coordinates = point
+		}
+	}
+
+	var saved []string
+	for _, fileHeader := range req.Images {
+		url, err := s.saveImage(fileHeader, venue.OrganizerID)
+		if err != nil {
+			s.removeImages(saved...)
+			return nil, err
+		}
+		saved = append(saved, url)
+	}
+	venue.Images = append(venue.Images, saved...)
+	return saved, nil
+}
+
+// saveImage stores an uploaded venue image and returns its URL
+func (s *VenueService) saveImage(fileHeader *multipart.FileHeader, organizerID int) (string, error) {
+	if fileHeader.Size > maxVenueImageSize {
+		return "", fmt.Errorf("venue image too large (max 5MB)")
+	}
+
+	file, err := fileHeader.Open()
+	if err != nil {
+		return "", fmt.Errorf("failed to open uploaded file: %w", err)
+	}
+	defer file.Close()
+
+	head := make([]byte, 512)
+	n, err := io.ReadFull(file, head)
+	if err != nil && err != io.ErrUnexpectedEOF {
+		return "", fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+	ext, ok := uploadImageTypes[http.DetectContentType(head[:n])]
+	if !ok {
+		return "", fmt.Errorf("invalid venue image type (only JPEG, PNG, GIF and WebP allowed)")
+	}
+	if _, err := file.Seek(0, io.SeekStart); err != nil {
+		return "", fmt.Errorf("failed to read uploaded file: %w", err)
+	}
+
+	if err := os.MkdirAll(s.uploadPath, 0755); err != nil {
+		return "", fmt.Errorf("failed to create upload directory: %w", err)
+	}
+
+	filename := fmt.Sprintf("venue_%d_%d%s", organizerID, s.now().UnixNano(), ext)
+	destFile, err := os.Create(filepath.Join(s.uploadPath, filename))
+	if err != nil {
+		return "", fmt.Errorf("failed to create destination file: %w", err)
+	}
+	defer destFile.Close()
+
+	if _, err := io.Copy(destFile, file); err != nil {
+		return "", fmt.Errorf("failed to copy file content: %w", err)
+	}
+
+	return "/uploads/venues/" + filename, nil
+}
+
+// removeImages deletes uploaded venue images that are no longer used
+func (s *VenueService) removeImages(urls ...string) {
+	for _, url := range urls {
+		filename := strings.TrimPrefix(url, "/uploads/venues/")
+		if filename == url || filename == "" || strings.Contains(filename, "/") {
+			continue // Not one of our uploads
+		}
+		os.Remove(filepath.Join(s.uploadPath, filename)) // Ignore errors for cleanup
+	}
+}
@@ -0,0 +1,208 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// Mock VenueRepository for testing
+type mockVenueRepository struct {
+	venues      map[int]*models.Venue
+	eventVenues map[int]int
+	events      map[int][]*models.Event
+	nextID      int
+}
+
+func newMockVenueRepository() *mockVenueRepository {
+	return &mockVenueRepository{
+		venues:      make(map[int]*models.Venue),
+		eventVenues: make(map[int]int),
+		events:      make(map[int][]*models.Event),
+		nextID:      1,
+	}
+}
+
+func (m *mockVenueRepository) Create(venue *models.Venue) error {
+	taken := make(map[string]bool)
+	for _, other := range m.venues {
+		taken[other.Slug] = true
+	}
+	venue.Slug = models.UniqueEventSlug(venue.Slug, taken)
+	venue.ID = m.nextID
+	m.nextID++
+	m.venues[venue.ID] = venue
+	return nil
+}
+
+func (m *mockVenueRepository) GetByID(id int) (*models.Venue, error) {
+	venue, ok := m.venues[id]
+	if !ok {
+		return nil, fmt.Errorf("venue with id %d not found", id)
+	}
+	return venue, nil
+}
+
+func (m *mockVenueRepository) GetBySlug(slug string) (*models.Venue, error) {
+	for _, venue := range m.venues {
+		if venue.Slug == slug {
+			return venue, nil
+		}
+	}
+	return nil, fmt.Errorf("venue with slug %s not found", slug)
+}
+
+func (m *mockVenueRepository) GetByOrganizer(organizerID int) ([]*models.Venue, error) {
+	var venues []*models.Venue
+	for _, venue := range m.venues {
+		if venue.OrganizerID == organizerID {
+			venues = append(venues, venue)
+		}
+	}
+	return venues, nil
+}
+
+func (m *mockVenueRepository) Update(venue *models.Venue) error {
+	if _, ok := m.venues[venue.ID]; !ok {
+		return errors.New("venue not found")
+	}
+	m.venues[venue.ID] = venue
+	return nil
+}
+
+func (m *mockVenueRepository) Delete(id, organizerID int) error {
+	delete(m.venues, id)
+	return nil
+}
+
+func (m *mockVenueRepository) GetEventVenue(eventID int) (*models.Venue, error) {
+	return m.venues[m.eventVenues[eventID]], nil
+}
+
+func (m *mockVenueRepository) SetEventVenue(eventID int, venue *models.Venue) error {
+	if venue == nil {
+		delete(m.eventVenues, eventID)
+		return nil
+	}
+	m.eventVenues[eventID] = venue.ID
+	return nil
+}
+
+func (m *mockVenueRepository) GetEvents(venueID int) ([]*models.Event, error) {
+	return m.events[venueID], nil
+}
+
+func TestVenueService_CreateVenue(t *testing.T) {
+	repo := newMockVenueRepository()
+	geocoder := &mockGeocoder{points: map[string]*models.GeoPoint{
+		"KICC, Harambee Avenue, Nairobi": {Latitude: -1.2884, Longitude: 36.8233},
+	}}
+	service := NewVenueService(repo, t.TempDir())
+	service.SetGeocoder(geocoder)
+
+	if _, err := service.CreateVenue(7, &VenueRequest{Name: "KICC"}); err == nil {
+		t.Error("expected a venue without a city to be refused")
+	}
+
+	venue, err := service.CreateVenue(7, &VenueRequest{Name: " KICC ", Address: "Harambee Avenue", City: "Nairobi", Capacity: 5000})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if venue.Slug != "kicc-nairobi" || venue.OrganizerID != 7 {
+		t.Errorf("expected a venue slugged from its name and city, got %+v", venue)
+	}
+	if venue.Coordinates == nil || venue.Coordinates.Latitude != -1.2884 {
+		t.Errorf("expected the venue to be geocoded from its address, got %v", venue.Coordinates)
+	}
+
+	// Venues entered with coordinates aren't geocoded
+	geocoder.calls = nil
+	other, err := service.CreateVenue(8, &VenueRequest{Name: "KICC", City: "Nairobi", Coordinates: &models.GeoPoint{Latitude: -1.28, Longitude: 36.82}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(geocoder.calls) != 0 {
+		t.Errorf("expected no geocoding, got %v", geocoder.calls)
+	}
+	if other.Slug != "kicc-nairobi-2" {
+		t.Errorf("expected a unique slug, got %q", other.Slug)
+	}
+}
+
+func TestVenueService_GetVenue(t *testing.T) {
+	repo := newMockVenueRepository()
+	service := NewVenueService(repo, t.TempDir())
+
+	venue, err := service.CreateVenue(7, &VenueRequest{Name: "The Alchemist", City: "Westlands"})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, err := service.GetVenue(venue.ID, 7); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+	if _, err := service.GetVenue(venue.ID, 8); !errors.Is(err, ErrVenueNotFound) {
+		t.Errorf("expected another organizer's venue to be hidden, got %v", err)
+	}
+	if _, err := service.GetVenue(99, 7); !errors.Is(err, ErrVenueNotFound) {
+		t.Errorf("expected a missing venue to be reported, got %v", err)
+	}
+	if _, err := service.UpdateVenue(venue.ID, 8, &VenueRequest{Name: "Mine now", City: "Westlands"}); !errors.Is(err, ErrVenueNotFound) {
+		t.Errorf("expected another organizer's venue not to be editable, got %v", err)
+	}
+}
+
+func TestVenueService_UpdateVenue(t *testing.T) {
+	repo := newMockVenueRepository()
+	service := NewVenueService(repo, t.TempDir())
+	repo.venues[1] = &models.Venue{ID: 1, OrganizerID: 7, Slug: "kicc-nairobi", Name: "KICC", City: "Nairobi",
+		Images: []string{"/uploads/venues/venue_7_1.jpg", "/uploads/venues/venue_7_2.jpg"}}
+
+	venue, err := service.UpdateVenue(1, 7, &VenueRequest{Name: "KICC Amphitheatre", City: "Nairobi",
+		RemoveImages: []string{"/uploads/venues/venue_7_1.jpg"}})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if venue.Slug != "kicc-nairobi" {
+		t.Errorf("expected the slug to be kept, got %q", venue.Slug)
+	}
+	if len(venue.Images) != 1 || venue.CoverImage() != "/uploads/venues/venue_7_2.jpg" {
+		t.Errorf("expected the removed image to be dropped, got %v", venue.Images)
+	}
+	if repo.venues[1].Name != "KICC Amphitheatre" {
+		t.Errorf("expected the venue to be saved, got %+v", repo.venues[1])
+	}
+}
+
+func TestVenueService_GetVenuePage(t *testing.T) {
+	now := time.Date(2025, 6, 4, 12, 0, 0, 0, time.UTC)
+	repo := newMockVenueRepository()
+	service := NewVenueService(repo, t.TempDir())
+	service.now = func() time.Time { return now }
+
+	repo.venues[1] = &models.Venue{ID: 1, OrganizerID: 7, Slug: "kicc-nairobi", Name: "KICC", City: "Nairobi"}
+	repo.events[1] = []*models.Event{
+		{ID: 1, StartDate: now.AddDate(0, -2, 0), EndDate: now.AddDate(0, -2, 1)},
+		{ID: 2, StartDate: now.AddDate(0, 0, 9), EndDate: now.AddDate(0, 0, 10)},
+		{ID: 3, StartDate: now.AddDate(0, 0, 2), EndDate: now.AddDate(0, 0, 3)},
+		{ID: 4, StartDate: now.AddDate(0, -1, 0), EndDate: now.AddDate(0, -1, 1)},
+	}
+
+	page, err := service.GetVenuePage("kicc-nairobi")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(page.UpcomingEvents) != 2 || page.UpcomingEvents[0].ID != 3 || page.UpcomingEvents[1].ID != 2 {
+		t.Errorf("expected upcoming events soonest first, got %v", page.UpcomingEvents)
+	}
+	if len(page.PastEvents) != 2 || page.PastEvents[0].ID != 4 || page.PastEvents[1].ID != 1 {
+		t.Errorf("expected past events most recent first, got %v", page.PastEvents)
+	}
+
+	if _, err := service.GetVenuePage("atlantis"); err == nil {
+		t.Error("expected a missing venue to be reported")
+	}
+}
@@ -0,0 +1,307 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/cache"
+	"event-ticketing-platform/internal/models"
+)
+
+const (
+	// waitingRoomTick is how often a waiting room admits its next batch
+	// of visitors. The waiting room page polls more often than this, so
+	// every tick is seen while anyone is queueing.
+	waitingRoomTick = 5 * time.Second
+	// waitingRoomStateTTL is how long a queue's positions and admissions
+	// are kept; on-sales are expected to finish well within it
+	waitingRoomStateTTL = 48 * time.Hour
+	// waitingRoomSettingsTTL is how long settings are cached, since every
+	// queued visitor polls them
+	waitingRoomSettingsTTL = 10 * time.Second
+)
+
+// ErrInvalidWaitingRoomToken is returned for a position token that was not
+// issued by the event's current queue
+var ErrInvalidWaitingRoomToken = errors.New("invalid waiting room token")
+
+// WaitingRoomRepository defines the data operations for waiting room settings
+type WaitingRoomRepository interface {
+	Get(eventID int) (*models.WaitingRoom, error)
+	Save(room *models.WaitingRoom) error
+}
+
+// WaitingRoomService queues visitors for high-demand on-sales and admits
+// them at each event's configured rate. Positions and admissions are
+// counters in the shared cache, so every server agrees on the queue when it
+// is backed by Redis. Visitors hold their position in a signed token.
+//
+// Admission is advanced by whichever request first sees each tick, and only
+// while visitors are waiting, so quiet periods don't build up admissions
+// that a later rush could use at once.
+type WaitingRoomService struct {
+	repo   WaitingRoomRepository
+	store  cache.Cache
+	secret []byte
+	now    func() time.Time
+}
+
+// NewWaitingRoomService creates a new waiting room service. secret signs
+// position tokens.
+func NewWaitingRoomService(repo WaitingRoomRepository, store cache.Cache, secret []byte) *WaitingRoomService {
+	return &WaitingRoomService{
+		repo:   repo,
+		store:  store,
+		secret: secret,
+		now:    time.Now,
+	}
+}
+
+// GetWaitingRoom retrieves an event's waiting room settings
+func (s *WaitingRoomService) GetWaitingRoom(eventID int) (*models.WaitingRoom, error) {
+	room, err := cache.Remember(s.store, cache.Key("waitingroom:settings", eventID), waitingRoomSettingsTTL, func() (*models.WaitingRoom, error) {
+		return s.repo.Get(eventID)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get waiting room: %w", err)
+	}
+	return room, nil
+}
+
+// UpdateWaitingRoom validates and saves an event's waiting room settings.
+// Moving the opening time starts a new queue.
+func (s *WaitingRoomService) UpdateWaitingRoom(room *models.WaitingRoom) error {
+	if err := room.Validate(); err != nil {
+		return err
+	}
+
+	if err := s.repo.Save(room); err != nil {
+		return fmt.Errorf("failed to update waiting room: %w", err)
+	}
+	if err := s.store.Delete(cache.Key("waitingroom:settings", room.EventID)); err != nil {
+		log.Printf("Failed to invalidate waiting room settings for event %d: %v", room.EventID, err)
+	}
+	return nil
+}
+
+// Join returns the visitor's place in an event's queue. A visitor without a
+// valid token is given the next position and a new token, which is returned
+// for the caller to hand back; otherwise the returned token is empty. Events
+// without an enabled waiting room admit everyone.
+func (s *WaitingRoomService) Join(eventID int, token string) (*models.WaitingRoomStatus, string, error) {
+	room, err := s.GetWaitingRoom(eventID)
+	if err != nil {
+		return nil, "", err
+	}
+	if !room.Enabled {
+		return &models.WaitingRoomStatus{EventID: eventID, Admitted: true}, "", nil
+	}
+
+	newToken := ""
+	position, ok := s.parseToken(room, token)
+	if !ok {
+		position, err = s.store.Increment(s.queueKey(room, "joined"), waitingRoomStateTTL)
+		if err != nil {
+			return nil, "", fmt.Errorf("failed to join waiting room: %w", err)
+		}
+		newToken = s.token(room, position)
+	}
+
+	status, err := s.status(room, position)
+	if err != nil {
+		return nil, "", err
+	}
+	return status, newToken, nil
+}
+
+// Status returns the place in an event's queue of the visitor holding
+// token, admitting the next batch of visitors when it is due. It returns
+// ErrInvalidWaitingRoomToken when the visitor has to join again.
+func (s *WaitingRoomService) Status(eventID int, token string) (*models.WaitingRoomStatus, error) {
+	room, err := s.GetWaitingRoom(eventID)
+	if err != nil {
+		return nil, err
+	}
+	if !room.Enabled {
+		return &models.WaitingRoomStatus{EventID: eventID, Admitted: true}, nil
+	}
+
+	position, ok := s.parseToken(room, token)
+	if !ok {
+		return nil, ErrInvalidWaitingRoomToken
+	}
+	return s.status(room, position)
+}
+
+// IsAdmitted reports whether the visitor holding token may shop for an
+// event, which they always may when it has no enabled waiting room
+func (s *WaitingRoomService) IsAdmitted(eventID int, token string) (bool, error) {
+	room, err := s.GetWaitingRoom(eventID)
+	if err != nil {
+		return false, err
+	}
+	if !room.Enabled {
+		return true, nil
+	}
+
+	position, ok := s.parseToken(room, token)
+	if !ok {
+		return false, nil
+	}
+	admitted, err := s.counter(s.queueKey(room, "admitted"))
+	if err != nil {
+		return false, err
+	}
+	return position <= admitted, nil
+}
+
+// GetStats returns how many visitors have joined and been admitted from an
+// event's current queue
+func (s *WaitingRoomService) GetStats(eventID int) (models.WaitingRoomStats, error) {
+	room, err := s.GetWaitingRoom(eventID)
+	if err != nil {
+		return models.WaitingRoomStats{}, err
+	}
+
+	joined, err := s.counter(s.queueKey(room, "joined"))
+	if err != nil {
+		return models.WaitingRoomStats{}, err
+	}
+	admitted, err := s.counter(s.queueKey(room, "admitted"))
+	if err != nil {
+		return models.WaitingRoomStats{}, err
+	}
+	return models.WaitingRoomStats{Joined: joined, Admitted: admitted}, nil
+}
+
+// status advances the queue and returns the visitor's place in it
+func (s *WaitingRoomService) status(room *models.WaitingRoom, position int64) (*models.WaitingRoomStatus, error) {
+	s.advance(room)
+
+	admitted, err := s.counter(s.queueKey(room, "admitted"))
+	if err != nil {
+		return nil, err
+	}
+
+	status := &models.WaitingRoomStatus{
+		EventID:  room.EventID,
+		Position: position,
+		Admitted: position <= admitted,
+		OpensAt:  room.OpensAt,
+	}
+	if !status.Admitted {
+		status.Ahead = position - admitted - 1
+		status.EstimatedWait = time.Duration(status.Ahead+1) * time.Minute / time.Duration(room.AdmitPerMinute)
+	}
+	return status, nil
+}
+
+// advance admits the current tick's batch of visitors, if no other request
+// has yet. Failures are logged; admission resumes on the next tick.
+func (s *WaitingRoomService) advance(room *models.WaitingRoom) {
+	now := s.now()
+	if now.Before(room.OpensAt) {
+		return
+	}
+	tick := int64(now.Sub(room.OpensAt) / waitingRoomTick)
+
+	// Only the first request to see the tick admits its batch
+	claims, err := s.store.Increment(s.queueKey(room, "tick", tick), 2*waitingRoomTick)
+	if err != nil || claims != 1 {
+		return
+	}
+
+	joined, err := s.counter(s.queueKey(room, "joined"))
+	if err != nil {
+		log.Printf("Failed to advance waiting room for event %d: %v", room.EventID, err)
+		return
+	}
+	admittedKey := s.queueKey(room, "admitted")
+	admitted, err := s.counter(admittedKey)
+	if err != nil {
+		log.Printf("Failed to advance waiting room for event %d: %v", room.EventID, err)
+		return
+	}
+
+	for batch := admissionsInTick(room.AdmitPerMinute, tick); batch > 0 && admitted < joined; batch-- {
+		if admitted, err = s.store.Increment(admittedKey, waitingRoomStateTTL); err != nil {
+			log.Printf("Failed to advance waiting room for event %d: %v", room.EventID, err)
+			return
+		}
+	}
+}
+
+// admissionsInTick spreads a per-minute rate over the ticks of each minute,
+// rounding so that each minute admits exactly perMinute visitors
+func admissionsInTick(perMinute int, tick int64) int64 {
+	ticksPerMinute := int64(time.Minute / waitingRoomTick)
+	admittedBy := func(ticks int64) int64 {
+		return (int64(perMinute)*ticks + ticksPerMinute - 1) / ticksPerMinute
+	}
+	return admittedBy(tick+1) - admittedBy(tick)
+}
+
+// queueKey names one of the counters of an event's queue. The opening time
+// is part of the key, so rescheduling an on-sale starts a new queue.
+func (s *WaitingRoomService) queueKey(room *models.WaitingRoom, parts ...interface{}) string {
+	return cache.Key("waitingroom", append([]interface{}{room.EventID, room.OpensAt.Unix()}, parts...)...)
+}
+
+// counter reads a counter, which is zero until first incremented
+func (s *WaitingRoomService) counter(key string) (int64, error) {
+	value, found, err := s.store.Get(key)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read waiting room: %w", err)
+	}
+	if !found {
+		return 0, nil
+	}
+	count, err := strconv.ParseInt(string(value), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to read waiting room: %w", err)
+	}
+	return count, nil
+}
+
+// token returns the signed token for a position in the room's queue, e.g.
+// "42.1735689600.17.<signature>"
+func (s *WaitingRoomService) token(room *models.WaitingRoom, position int64) string {
+	payload := fmt.Sprintf("%d.%d.%d", room.EventID, room.OpensAt.Unix(), position)
+	return payload + "." + s.sign(payload)
+}
+
+// parseToken returns the position a token holds in the room's current queue
+func (s *WaitingRoomService) parseToken(room *models.WaitingRoom, token string) (int64, bool) {
+	dot := strings.LastIndex(token, ".")
+	if dot < 0 {
+		return 0, false
+	}
+	payload, signature := token[:dot], token[dot+1:]
+	if !hmac.Equal([]byte(s.sign(payload)), []byte(signature)) {
+		return 0, false
+	}
+
+	prefix := fmt.Sprintf("%d.%d.", room.EventID, room.OpensAt.Unix())
+	positionPart, ok := strings.CutPrefix(payload, prefix)
+	if !ok {
+		return 0, false
+	}
+	position, err := strconv.ParseInt(positionPart, 10, 64)
+	if err != nil || position <= 0 {
+		return 0, false
+	}
+	return position, true
+}
+
+func (s *WaitingRoomService) sign(payload string) string {
+	mac := hmac.New(sha256.New, s.secret)
+	fmt.Fprintf(mac, "waiting-room:%s", payload)
+	return hex.EncodeToString(mac.Sum(nil)[:16])
+}
@@ -0,0 +1,185 @@
+package services
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/cache"
+	"event-ticketing-platform/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// mockWaitingRoomRepository keeps waiting room settings in memory
+type mockWaitingRoomRepository struct {
+	rooms map[int]*models.WaitingRoom
+}
+
+func (m *mockWaitingRoomRepository) Get(eventID int) (*models.WaitingRoom, error) {
+	if room, ok := m.rooms[eventID]; ok {
+		copied := *room
+		return &copied, nil
+	}
+	return models.DefaultWaitingRoom(eventID), nil
+}
+
+func (m *mockWaitingRoomRepository) Save(room *models.WaitingRoom) error {
+	copied := *room
+	m.rooms[room.EventID] = &copied
+	return nil
+}
+
+// newTestWaitingRoomService returns a service with a waiting room for event
+// 1 opening at opensAt and admitting perMinute visitors, and a clock the
+// test moves
+func newTestWaitingRoomService(t *testing.T, opensAt time.Time, perMinute int) (*WaitingRoomService, *time.Time) {
+	repo := &mockWaitingRoomRepository{rooms: map[int]*models.WaitingRoom{}}
+	service := NewWaitingRoomService(repo, cache.NewMemoryCache(), []byte("test-secret"))
+	now := opensAt.Add(-time.Minute)
+	service.now = func() time.Time { return now }
+
+	require.NoError(t, service.UpdateWaitingRoom(&models.WaitingRoom{
+		EventID:        1,
+		Enabled:        true,
+		OpensAt:        opensAt,
+		AdmitPerMinute: perMinute,
+	}))
+	return service, &now
+}
+
+func TestWaitingRoomService_WithoutWaitingRoomAdmitsEveryone(t *testing.T) {
+	service, _ := newTestWaitingRoomService(t, time.Now(), 10)
+
+	status, token, err := service.Join(2, "")
+	require.NoError(t, err)
+	assert.True(t, status.Admitted)
+	assert.Empty(t, token)
+
+	admitted, err := service.IsAdmitted(2, "")
+	require.NoError(t, err)
+	assert.True(t, admitted)
+}
+
+func TestWaitingRoomService_JoinGivesPositionsInOrder(t *testing.T) {
+	opensAt := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	service, _ := newTestWaitingRoomService(t, opensAt, 60)
+
+	first, firstToken, err := service.Join(1, "")
+	require.NoError(t, err)
+	second, secondToken, err := service.Join(1, "")
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(1), first.Position)
+	assert.Equal(t, int64(2), second.Position)
+	assert.Equal(t, int64(1), second.Ahead)
+	assert.Equal(t, 2*time.Second, second.EstimatedWait)
+	assert.False(t, first.Admitted, "nobody is admitted before the room opens")
+	assert.NotEqual(t, firstToken, secondToken)
+
+	// Coming back keeps the position
+	again, token, err := service.Join(1, firstToken)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), again.Position)
+	assert.Empty(t, token)
+}
+
+func TestWaitingRoomService_AdmitsAtTheConfiguredRate(t *testing.T) {
+	opensAt := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	// 24 a minute is 2 every 5 second tick
+	service, now := newTestWaitingRoomService(t, opensAt, 24)
+
+	var tokens []string
+	for i := 0; i < 5; i++ {
+		_, token, err := service.Join(1, "")
+		require.NoError(t, err)
+		tokens = append(tokens, token)
+	}
+
+	*now = opensAt
+	status, err := service.Status(1, tokens[0])
+	require.NoError(t, err)
+	assert.True(t, status.Admitted)
+
+	// Polling again within the tick admits nobody else
+	status, err = service.Status(1, tokens[2])
+	require.NoError(t, err)
+	assert.False(t, status.Admitted)
+	assert.Equal(t, int64(0), status.Ahead)
+
+	*now = opensAt.Add(waitingRoomTick)
+	status, err = service.Status(1, tokens[3])
+	require.NoError(t, err)
+	assert.True(t, status.Admitted)
+
+	for i, token := range tokens {
+		admitted, err := service.IsAdmitted(1, token)
+		require.NoError(t, err)
+		assert.Equal(t, i < 4, admitted, "position %d", i+1)
+	}
+
+	stats, err := service.GetStats(1)
+	require.NoError(t, err)
+	assert.Equal(t, models.WaitingRoomStats{Joined: 5, Admitted: 4}, stats)
+	assert.Equal(t, int64(1), stats.Waiting())
+}
+
+func TestWaitingRoomService_QuietPeriodsDontBuildUpAdmissions(t *testing.T) {
+	opensAt := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	service, now := newTestWaitingRoomService(t, opensAt, 12) // 1 per tick
+
+	// An hour of polling with an empty queue, then a rush
+	*now = opensAt.Add(time.Hour)
+	_, _, err := service.Join(1, "")
+	require.NoError(t, err)
+
+	var last string
+	for i := 0; i < 3; i++ {
+		_, last, err = service.Join(1, "")
+		require.NoError(t, err)
+	}
+
+	status, err := service.Status(1, last)
+	require.NoError(t, err)
+	assert.False(t, status.Admitted)
+	assert.Equal(t, int64(2), status.Ahead)
+}
+
+func TestWaitingRoomService_RejectsForgedAndStaleTokens(t *testing.T) {
+	opensAt := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+	service, now := newTestWaitingRoomService(t, opensAt, 60)
+
+	_, token, err := service.Join(1, "")
+	require.NoError(t, err)
+	*now = opensAt
+	_, err = service.Status(1, token)
+	require.NoError(t, err)
+
+	forged := strings.Replace(token, ".1.", ".0.", 1)
+	_, err = service.Status(1, forged)
+	assert.ErrorIs(t, err, ErrInvalidWaitingRoomToken)
+	admitted, err := service.IsAdmitted(1, forged)
+	require.NoError(t, err)
+	assert.False(t, admitted)
+
+	// Rescheduling the on-sale starts a new queue
+	require.NoError(t, service.UpdateWaitingRoom(&models.WaitingRoom{
+		EventID:        1,
+		Enabled:        true,
+		OpensAt:        opensAt.Add(time.Hour),
+		AdmitPerMinute: 60,
+	}))
+	_, err = service.Status(1, token)
+	assert.ErrorIs(t, err, ErrInvalidWaitingRoomToken)
+}
+
+func TestAdmissionsInTick(t *testing.T) {
+	for _, perMinute := range []int{1, 7, 100, 1000} {
+		var minute int64
+		for tick := int64(0); tick < 12; tick++ {
+			minute += admissionsInTick(perMinute, tick)
+		}
+		assert.Equal(t, int64(perMinute), minute, "%d a minute", perMinute)
+	}
+}
@@ -0,0 +1,452 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// googleWalletSaveURL is the prefix of Google Wallet "Add to Google Wallet" links
+const googleWalletSaveURL = "https://pay.google.com/gp/v/save/"
+
+// walletBrandColor is the background color of wallet passes
+var walletBrandColor = color.RGBA{R: 37, G: 99, B: 235, A: 255}
+
+// applePassImages are the images bundled in Apple passes, with their sizes in pixels
+var applePassImages = []struct {
+	name string
+	size int
+}{
+	{"icon.png", 29},
+	{"icon@2x.png", 58},
+	{"logo.png", 50},
+	{"logo@2x.png", 100},
+}
+
+// WalletPassConfig holds the signing configuration for wallet passes. Each
+// wallet is enabled when all of its settings are present.
+type WalletPassConfig struct {
+	BaseURL          string
+	OrganizationName string
+
+	ApplePassTypeID      string // e.g. pass.com.example.tickets
+	AppleTeamID          string
+	AppleCertificate     string // PEM encoded pass type certificate
+	ApplePrivateKey      string // PEM encoded RSA key of the pass type certificate
+	AppleWWDRCertificate string // PEM encoded Apple WWDR intermediate certificate
+
+	GoogleIssuerID            string
+	GoogleServiceAccountEmail string
+	GooglePrivateKey          string // PEM encoded RSA key of the service account
+}
+
+// WalletPassOptions reports which wallets tickets can be added to
+type WalletPassOptions struct {
+	Apple  bool
+	Google bool
+}
+
+// Any returns true if at least one wallet is available
+func (o WalletPassOptions) Any() bool {
+	return o.Apple || o.Google
+}
+
+// WalletPassTicket is a ticket with the details shown on its wallet pass
+type WalletPassTicket struct {
+	Ticket     *models.Ticket
+	TicketType *models.TicketType // optional
+	Event      *models.Event
+	Order      *models.Order
+}
+
+// WalletPassService generates Apple Wallet passes and Google Wallet links for tickets
+type WalletPassService struct {
+	config WalletPassConfig
+	now    func() time.Time
+
+	appleCertificate *x509.Certificate
+	appleKey         *rsa.PrivateKey
+	appleWWDR        *x509.Certificate
+
+	googleKey *rsa.PrivateKey
+}
+
+// NewWalletPassService creates a new wallet pass service, parsing the signing
+// keys of each configured wallet
+func NewWalletPassService(config WalletPassConfig) (*WalletPassService, error) {
+	s := &WalletPassService{
+		config: config,
+		now:    time.Now,
+	}
+	if s.config.OrganizationName == "" {
+		s.config.OrganizationName = "Event Ticketing Platform"
+	}
+	s.config.BaseURL = strings.TrimSuffix(s.config.BaseURL, "/")
+
+	if config.ApplePassTypeID != "" && config.AppleTeamID != "" && config.AppleCertificate != "" {
+		var err error
+		if s.appleCertificate, err = parseCertificatePEM(config.AppleCertificate); err != nil {
+			return nil, fmt.Errorf("invalid Apple pass certificate: %w", err)
+		}
+		if s.appleWWDR, err = parseCertificatePEM(config.AppleWWDRCertificate); err != nil {
+			return nil, fmt.Errorf("invalid Apple WWDR certificate: %w", err)
+		}
+		if s.appleKey, err = parseRSAPrivateKeyPEM(config.ApplePrivateKey); err != nil {
+			return nil, fmt.Errorf("invalid Apple pass key: %w", err)
+		}
+	}
+
+	if config.GoogleIssuerID != "" && config.GoogleServiceAccountEmail != "" && config.GooglePrivateKey != "" {
+		var err error
+		if s.googleKey, err = parseRSAPrivateKeyPEM(config.GooglePrivateKey); err != nil {
+			return nil, fmt.Errorf("invalid Google Wallet service account key: %w", err)
+		}
+	}
+
+	return s, nil
+}
+
+// Options reports which wallets are configured
+func (s *WalletPassService) Options() WalletPassOptions {
+	return WalletPassOptions{
+		Apple:  s.appleKey != nil,
+		Google: s.googleKey != nil,
+	}
+}
+
+// ApplePass returns a signed .pkpass file for a ticket
+func (s *WalletPassService) ApplePass(pass *WalletPassTicket) ([]byte, error) {
+	if s.appleKey == nil {
+		return nil, fmt.Errorf("Apple Wallet passes are not configured")
+	}
+
+	passJSON, err := json.MarshalIndent(s.applePassJSON(pass), "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode pass: %w", err)
+	}
+
+	names := []string{"pass.json"}
+	files := map[string][]byte{"pass.json": passJSON}
+	for _, img := range applePassImages {
+		names = append(names, img.name)
+		if files[img.name], err = walletIcon(img.size); err != nil {
+			return nil, err
+		}
+	}
+
+	// The manifest lists the SHA-1 of every file; its signature covers the pass
+	manifest := make(map[string]string, len(files))
+	for name, data := range files {
+		sum := sha1.Sum(data)
+		manifest[name] = hex.EncodeToString(sum[:])
+	}
+	if files["manifest.json"], err = json.Marshal(manifest); err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	if files["signature"], err = signDetachedPKCS7(files["manifest.json"], s.appleCertificate, s.appleKey, []*x509.Certificate{s.appleWWDR}, s.now()); err != nil {
+		return nil, err
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+	for _, name := range append(names, "manifest.json", "signature") {
+		f, err := archive.Create(name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write pass: %w", err)
+		}
+		if _, err := f.Write(files[name]); err != nil {
+			return nil, fmt.Errorf("failed to write pass: %w", err)
+		}
+	}
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("failed to write pass: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+type applePassField struct {
+	Key       string `json:"key"`
+	Label     string `json:"label,omitempty"`
+	Value     string `json:"value"`
+	DateStyle string `json:"dateStyle,omitempty"`
+	TimeStyle string `json:"timeStyle,omitempty"`
+}
+
+type applePassBarcode struct {
+	Format          string `json:"format"`
+	Message         string `json:"message"`
+	MessageEncoding string `json:"messageEncoding"`
+	AltText         string `json:"altText,omitempty"`
+}
+
+// applePassJSON returns the pass.json contents of an event ticket
+func (s *WalletPassService) applePassJSON(pass *WalletPassTicket) map[string]interface{} {
+	event := pass.Event
+	barcode := applePassBarcode{
+		Format:          "PKBarcodeFormatQR",
+		Message:         pass.Ticket.QRCode,
+		MessageEncoding: "iso-8859-1",
+		AltText:         pass.Order.OrderNumber,
+	}
+
+	secondary := []applePassField{{
+		Key:       "date",
+		Label:     "DATE",
+		Value:     event.StartDate.Format(time.RFC3339),
+		DateStyle: "PKDateStyleMedium",
+		TimeStyle: "PKDateStyleShort",
+	}}
+	if event.Location != "" {
+		secondary = append(secondary, applePassField{Key: "venue", Label: "VENUE", Value: event.Location})
+	}
+
+	auxiliary := []applePassField{{Key: "order", Label: "ORDER", Value: pass.Order.OrderNumber}}
+	if pass.TicketType != nil {
+		auxiliary = append([]applePassField{{Key: "ticket_type", Label: "TICKET", Value: pass.TicketType.Name}}, auxiliary...)
+	}
+	if pass.Order.BillingName != "" {
+		auxiliary = append(auxiliary, applePassField{Key: "holder", Label: "NAME", Value: pass.Order.BillingName})
+	}
+	if pass.Order.ArrivalSlot != nil {
+		secondary = append(secondary, applePassField{Key: "arrival", Label: "ARRIVE", Value: pass.Order.ArrivalSlot.Label()})
+	}
+
+	back := []applePassField{{Key: "event_url", Label: "Event details", Value: s.eventURL(event.ID)}}
+	if description := calendarDescription(event.Description, ""); description != "" {
+		back = append(back, applePassField{Key: "description", Label: "About this event", Value: description})
+	}
+
+	return map[string]interface{}{
+		"formatVersion":      1,
+		"passTypeIdentifier": s.config.ApplePassTypeID,
+		"teamIdentifier":     s.config.AppleTeamID,
+		"serialNumber":       walletSerialNumber(pass.Ticket),
+		"organizationName":   s.config.OrganizationName,
+		"description":        "Ticket for " + event.Title,
+		"logoText":           s.config.OrganizationName,
+		"foregroundColor":    "rgb(255, 255, 255)",
+		"labelColor":         "rgb(219, 234, 254)",
+		"backgroundColor":    fmt.Sprintf("rgb(%d, %d, %d)", walletBrandColor.R, walletBrandColor.G, walletBrandColor.B),
+		"relevantDate":       event.StartDate.Format(time.RFC3339),
+		"expirationDate":     eventEnd(event).Add(24 * time.Hour).Format(time.RFC3339),
+		"voided":             pass.Ticket.Status == models.TicketRefunded || event.Status == models.StatusCancelled,
+		"barcodes":           []applePassBarcode{barcode},
+		"barcode":            barcode, // iOS 8 and earlier
+		"eventTicket": map[string]interface{}{
+			"primaryFields":   []applePassField{{Key: "event", Label: "EVENT", Value: event.Title}},
+			"secondaryFields": secondary,
+			"auxiliaryFields": auxiliary,
+			"backFields":      back,
+		},
+	}
+}
+
+// GoogleSaveURL returns an "Add to Google Wallet" link for one or more
+// tickets. The link carries a signed JWT that creates the pass objects.
+func (s *WalletPassService) GoogleSaveURL(passes []*WalletPassTicket) (string, error) {
+	if s.googleKey == nil {
+		return "", fmt.Errorf("Google Wallet passes are not configured")
+	}
+	if len(passes) == 0 {
+		return "", fmt.Errorf("no tickets to add")
+	}
+
+	classes := map[int]bool{}
+	var ticketClasses, ticketObjects []map[string]interface{}
+	for _, pass := range passes {
+		classID := s.googleID(fmt.Sprintf("event-%d", pass.Event.ID))
+		if !classes[pass.Event.ID] {
+			classes[pass.Event.ID] = true
+			ticketClasses = append(ticketClasses, s.googleEventClass(classID, pass.Event))
+		}
+		ticketObjects = append(ticketObjects, s.googleTicketObject(classID, pass))
+	}
+
+	origins := []string{}
+	if s.config.BaseURL != "" {
+		origins = append(origins, s.config.BaseURL)
+	}
+
+	claims := map[string]interface{}{
+		"iss":     s.config.GoogleServiceAccountEmail,
+		"aud":     "google",
+		"typ":     "savetowallet",
+		"iat":     s.now().Unix(),
+		"origins": origins,
+		"payload": map[string]interface{}{
+			"eventTicketClasses": ticketClasses,
+			"eventTicketObjects": ticketObjects,
+		},
+	}
+
+	token, err := signRS256JWT(claims, s.googleKey)
+	if err != nil {
+		return "", err
+	}
+	return googleWalletSaveURL + token, nil
+}
+
+func (s *WalletPassService) googleEventClass(classID string, event *models.Event) map[string]interface{} {
+	class := map[string]interface{}{
+		"id":           classID,
+		"issuerName":   s.config.OrganizationName,
+		"reviewStatus": "UNDER_REVIEW",
+		"eventName":    googleLocalizedString(event.Title),
+		"dateTime": map[string]string{
+			"start": event.StartDate.Format(time.RFC3339),
+			"end":   eventEnd(event).Format(time.RFC3339),
+		},
+		"homepageUri":        map[string]string{"uri": s.eventURL(event.ID), "description": "Event details"},
+		"hexBackgroundColor": fmt.Sprintf("#%02x%02x%02x", walletBrandColor.R, walletBrandColor.G, walletBrandColor.B),
+	}
+	if event.Location != "" {
+		class["venue"] = map[string]interface{}{
+			"name":    googleLocalizedString(event.Location),
+			"address": googleLocalizedString(event.Location),
+		}
+	}
+	return class
+}
+
+func (s *WalletPassService) googleTicketObject(classID string, pass *WalletPassTicket) map[string]interface{} {
+	state := "ACTIVE"
+	if pass.Ticket.Status == models.TicketRefunded || pass.Event.Status == models.StatusCancelled {
+		state = "INACTIVE"
+	}
+
+	object := map[string]interface{}{
+		"id":           s.googleID(walletSerialNumber(pass.Ticket)),
+		"classId":      classID,
+		"state":        state,
+		"ticketNumber": pass.Order.OrderNumber,
+		"barcode": map[string]string{
+			"type":          "QR_CODE",
+			"value":         pass.Ticket.QRCode,
+			"alternateText": pass.Order.OrderNumber,
+		},
+		"validTimeInterval": map[string]interface{}{
+			"end": map[string]string{"date": eventEnd(pass.Event).Add(24 * time.Hour).Format(time.RFC3339)},
+		},
+	}
+	if pass.Order.BillingName != "" {
+		object["ticketHolderName"] = pass.Order.BillingName
+	}
+	if pass.TicketType != nil {
+		object["ticketType"] = googleLocalizedString(pass.TicketType.Name)
+	}
+	if pass.Order.ArrivalSlot != nil {
+		object["textModulesData"] = []map[string]string{
+			{"id": "arrival", "header": "Arrival time", "body": pass.Order.ArrivalSlot.Label()},
+		}
+	}
+	return object
+}
+
+// googleID returns a Google Wallet class or object ID, which must be
+// prefixed with the issuer ID
+func (s *WalletPassService) googleID(suffix string) string {
+	return s.config.GoogleIssuerID + "." + suffix
+}
+
+func (s *WalletPassService) eventURL(eventID int) string {
+	return fmt.Sprintf("%s/events/%d", s.config.BaseURL, eventID)
+}
+
+func googleLocalizedString(value string) map[string]interface{} {
+	return map[string]interface{}{
+		"defaultValue": map[string]string{"language": "en-US", "value": value},
+	}
+}
+
+// walletSerialNumber identifies a ticket's pass. It is stable so re-adding a
+// ticket updates the existing pass instead of creating a second one.
+func walletSerialNumber(ticket *models.Ticket) string {
+	return fmt.Sprintf("ticket-%d", ticket.ID)
+}
+
+// walletIcon renders the square brand-colored icon that every Apple pass requires
+func walletIcon(size int) ([]byte, error) {
+	img := image.NewRGBA(image.Rect(0, 0, size, size))
+	for y := 0; y < size; y++ {
+		for x := 0; x < size; x++ {
+			img.Set(x, y, walletBrandColor)
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return nil, fmt.Errorf("failed to encode pass icon: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// signRS256JWT returns a compact JWT signed with RSA SHA-256
+func signRS256JWT(claims interface{}, key *rsa.PrivateKey) (string, error) {
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode JWT claims: %w", err)
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign JWT: %w", err)
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+func parseCertificatePEM(data string) (*x509.Certificate, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, fmt.Errorf("expected a PEM encoded certificate")
+	}
+	return x509.ParseCertificate(block.Bytes)
+}
+
+// parseRSAPrivateKeyPEM parses PKCS#1 and PKCS#8 RSA keys. Google service
+// account keys are PKCS#8; keys exported from the macOS keychain are PKCS#1.
+func parseRSAPrivateKeyPEM(data string) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode([]byte(data))
+	if block == nil {
+		return nil, fmt.Errorf("expected a PEM encoded private key")
+	}
+
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("expected an RSA private key")
+	}
+	return key, nil
+}
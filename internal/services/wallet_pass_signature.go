@@ -0,0 +1,146 @@
+package services
+
+import (
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+)
+
+// PKCS#7 object identifiers (RFC 2315)
+var (
+	oidData                   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 1}
+	oidSignedData             = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 7, 2}
+	oidAttributeContentType   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 3}
+	oidAttributeMessageDigest = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 4}
+	oidAttributeSigningTime   = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 5}
+	oidDigestSHA256           = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 2, 1}
+	oidEncryptionRSA          = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 1}
+)
+
+type pkcs7ContentInfo struct {
+	ContentType asn1.ObjectIdentifier
+	Content     asn1.RawValue `asn1:"optional"`
+}
+
+type pkcs7SignedData struct {
+	Version          int
+	DigestAlgorithms []pkix.AlgorithmIdentifier `asn1:"set"`
+	ContentInfo      pkcs7ContentInfo
+	Certificates     asn1.RawValue
+	SignerInfos      []pkcs7SignerInfo `asn1:"set"`
+}
+
+type pkcs7IssuerAndSerial struct {
+	Issuer       asn1.RawValue
+	SerialNumber *big.Int
+}
+
+type pkcs7SignerInfo struct {
+	Version                   int
+	IssuerAndSerialNumber     pkcs7IssuerAndSerial
+	DigestAlgorithm           pkix.AlgorithmIdentifier
+	AuthenticatedAttributes   asn1.RawValue
+	DigestEncryptionAlgorithm pkix.AlgorithmIdentifier
+	EncryptedDigest           []byte
+}
+
+type pkcs7Attribute struct {
+	Type   asn1.ObjectIdentifier
+	Values asn1.RawValue
+}
+
+// signDetachedPKCS7 returns a DER encoded, detached PKCS#7 signature of
+// content, as Apple Wallet expects for a pass manifest. The intermediate
+// certificates are embedded so the signature can be verified offline.
+func signDetachedPKCS7(content []byte, cert *x509.Certificate, key *rsa.PrivateKey, intermediates []*x509.Certificate, signingTime time.Time) ([]byte, error) {
+	digest := sha256.Sum256(content)
+
+	attributes, err := pkcs7Attributes(digest[:], signingTime)
+	if err != nil {
+		return nil, err
+	}
+
+	// The signature covers the attributes encoded as a SET, not as the
+	// implicitly tagged field they are stored in
+	attributesDigest := sha256.Sum256(derSet(attributes))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, attributesDigest[:])
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign manifest: %w", err)
+	}
+
+	var certificates bytes.Buffer
+	certificates.Write(cert.Raw)
+	for _, intermediate := range intermediates {
+		certificates.Write(intermediate.Raw)
+	}
+
+	digestAlgorithm := pkix.AlgorithmIdentifier{Algorithm: oidDigestSHA256, Parameters: asn1.NullRawValue}
+	signedData := pkcs7SignedData{
+		Version:          1,
+		DigestAlgorithms: []pkix.AlgorithmIdentifier{digestAlgorithm},
+		ContentInfo:      pkcs7ContentInfo{ContentType: oidData},
+		Certificates:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: certificates.Bytes()},
+		SignerInfos: []pkcs7SignerInfo{{
+			Version:                   1,
+			IssuerAndSerialNumber:     pkcs7IssuerAndSerial{Issuer: asn1.RawValue{FullBytes: cert.RawIssuer}, SerialNumber: cert.SerialNumber},
+			DigestAlgorithm:           digestAlgorithm,
+			AuthenticatedAttributes:   asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: attributes},
+			DigestEncryptionAlgorithm: pkix.AlgorithmIdentifier{Algorithm: oidEncryptionRSA, Parameters: asn1.NullRawValue},
+			EncryptedDigest:           signature,
+		}},
+	}
+
+	signedDataDER, err := asn1.Marshal(signedData)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode signed data: %w", err)
+	}
+
+	return asn1.Marshal(pkcs7ContentInfo{
+		ContentType: oidSignedData,
+		Content:     asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: 0, IsCompound: true, Bytes: signedDataDER},
+	})
+}
+
+// pkcs7Attributes returns the DER encoded authenticated attributes, sorted
+// as DER requires for the members of a SET
+func pkcs7Attributes(digest []byte, signingTime time.Time) ([]byte, error) {
+	values := []struct {
+		oid   asn1.ObjectIdentifier
+		value interface{}
+	}{
+		{oidAttributeContentType, oidData},
+		{oidAttributeSigningTime, signingTime.UTC()},
+		{oidAttributeMessageDigest, digest},
+	}
+
+	var encoded [][]byte
+	for _, v := range values {
+		valueDER, err := asn1.Marshal(v.value)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode signature attribute: %w", err)
+		}
+		attributeDER, err := asn1.Marshal(pkcs7Attribute{Type: v.oid, Values: asn1.RawValue{FullBytes: derSet(valueDER)}})
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode signature attribute: %w", err)
+		}
+		encoded = append(encoded, attributeDER)
+	}
+
+	sort.Slice(encoded, func(i, j int) bool { return bytes.Compare(encoded[i], encoded[j]) < 0 })
+	return bytes.Join(encoded, nil), nil
+}
+
+// derSet wraps DER encoded members in a SET
+func derSet(members []byte) []byte {
+	encoded, _ := asn1.Marshal(asn1.RawValue{Class: asn1.ClassUniversal, Tag: asn1.TagSet, IsCompound: true, Bytes: members})
+	return encoded
+}
@@ -0,0 +1,327 @@
+package services
+
+import (
+	"archive/zip"
+	"bytes"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"io"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/models"
+)
+
+// testWalletCredentials creates a CA standing in for Apple's WWDR certificate
+// and a pass certificate signed by it
+func testWalletCredentials(t *testing.T) (caPEM, certPEM, keyPEM string, key *rsa.PrivateKey) {
+	t.Helper()
+
+	caKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	caTemplate := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test WWDR"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	caDER, err := x509.CreateCertificate(rand.Reader, caTemplate, caTemplate, &caKey.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("failed to create CA: %v", err)
+	}
+	ca, _ := x509.ParseCertificate(caDER)
+
+	key, err = rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("failed to generate key: %v", err)
+	}
+	certTemplate := &x509.Certificate{
+		SerialNumber: big.NewInt(42),
+		Subject:      pkix.Name{CommonName: "Pass Type ID: pass.com.example.tickets"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+	}
+	certDER, err := x509.CreateCertificate(rand.Reader, certTemplate, ca, &key.PublicKey, caKey)
+	if err != nil {
+		t.Fatalf("failed to create certificate: %v", err)
+	}
+
+	caPEM = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: caDER}))
+	certPEM = string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: certDER}))
+	keyPEM = string(pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)}))
+	return caPEM, certPEM, keyPEM, key
+}
+
+func setupWalletPassService(t *testing.T) (*WalletPassService, *rsa.PrivateKey) {
+	t.Helper()
+
+	caPEM, certPEM, keyPEM, key := testWalletCredentials(t)
+	pkcs8, _ := x509.MarshalPKCS8PrivateKey(key)
+
+	service, err := NewWalletPassService(WalletPassConfig{
+		BaseURL:                   "https://tickets.example.com/",
+		OrganizationName:          "Tickets",
+		ApplePassTypeID:           "pass.com.example.tickets",
+		AppleTeamID:               "TEAM123",
+		AppleCertificate:          certPEM,
+		ApplePrivateKey:           keyPEM,
+		AppleWWDRCertificate:      caPEM,
+		GoogleIssuerID:            "3388000000000000000",
+		GoogleServiceAccountEmail: "wallet@example.iam.gserviceaccount.com",
+		GooglePrivateKey:          string(pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8})),
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return service, key
+}
+
+func testWalletPassTicket() *WalletPassTicket {
+	return &WalletPassTicket{
+		Ticket:     &models.Ticket{ID: 9, QRCode: "TKT-1-2-3-abc", Status: models.TicketActive},
+		TicketType: &models.TicketType{Name: "VIP"},
+		Event: &models.Event{
+			ID:        1,
+			Title:     "Jazz Night",
+			Location:  "Nairobi",
+			StartDate: time.Date(2025, 7, 1, 18, 0, 0, 0, time.UTC),
+			Status:    models.StatusPublished,
+		},
+		Order: &models.Order{OrderNumber: "ORD-1001", BillingName: "Jane Doe"},
+	}
+}
+
+func TestNewWalletPassService_Options(t *testing.T) {
+	service, err := NewWalletPassService(WalletPassConfig{})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if service.Options().Any() {
+		t.Errorf("expected no wallets without configuration, got %+v", service.Options())
+	}
+	if _, err := service.ApplePass(testWalletPassTicket()); err == nil {
+		t.Error("expected error generating an Apple pass without configuration")
+	}
+
+	_, err = NewWalletPassService(WalletPassConfig{ApplePassTypeID: "pass.com.example.tickets", AppleTeamID: "TEAM123", AppleCertificate: "not a certificate"})
+	if err == nil {
+		t.Error("expected error for an invalid certificate")
+	}
+
+	configured, _ := setupWalletPassService(t)
+	if options := configured.Options(); !options.Apple || !options.Google {
+		t.Errorf("expected both wallets to be enabled, got %+v", options)
+	}
+}
+
+func TestWalletPassService_ApplePass(t *testing.T) {
+	service, key := setupWalletPassService(t)
+
+	data, err := service.ApplePass(testWalletPassTicket())
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	archive, err := zip.NewReader(bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatalf("expected a zip archive: %v", err)
+	}
+	files := map[string][]byte{}
+	for _, f := range archive.File {
+		r, _ := f.Open()
+		files[f.Name], _ = io.ReadAll(r)
+		r.Close()
+	}
+
+	for _, name := range []string{"pass.json", "icon.png", "manifest.json", "signature"} {
+		if _, ok := files[name]; !ok {
+			t.Fatalf("expected pass to contain %s", name)
+		}
+	}
+
+	var pass map[string]interface{}
+	if err := json.Unmarshal(files["pass.json"], &pass); err != nil {
+		t.Fatalf("invalid pass.json: %v", err)
+	}
+	if pass["passTypeIdentifier"] != "pass.com.example.tickets" || pass["teamIdentifier"] != "TEAM123" || pass["serialNumber"] != "ticket-9" {
+		t.Errorf("unexpected pass identifiers: %v", pass)
+	}
+	barcodes := pass["barcodes"].([]interface{})
+	if barcodes[0].(map[string]interface{})["message"] != "TKT-1-2-3-abc" {
+		t.Errorf("expected QR code in barcode, got %v", barcodes)
+	}
+	if !strings.Contains(string(files["pass.json"]), `"value": "VIP"`) {
+		t.Error("expected ticket type on the pass")
+	}
+
+	var manifest map[string]string
+	if err := json.Unmarshal(files["manifest.json"], &manifest); err != nil {
+		t.Fatalf("invalid manifest.json: %v", err)
+	}
+	for name, content := range files {
+		if name == "manifest.json" || name == "signature" {
+			continue
+		}
+		sum := sha1.Sum(content)
+		if manifest[name] != hex.EncodeToString(sum[:]) {
+			t.Errorf("manifest hash mismatch for %s", name)
+		}
+	}
+
+	verifyDetachedPKCS7(t, files["signature"], files["manifest.json"], &key.PublicKey)
+}
+
+// verifyDetachedPKCS7 checks that signature is a valid signature of content
+func verifyDetachedPKCS7(t *testing.T, signature, content []byte, publicKey *rsa.PublicKey) {
+	t.Helper()
+
+	var contentInfo pkcs7ContentInfo
+	if _, err := asn1.Unmarshal(signature, &contentInfo); err != nil {
+		t.Fatalf("invalid signature: %v", err)
+	}
+	if !contentInfo.ContentType.Equal(oidSignedData) {
+		t.Fatalf("unexpected content type %v", contentInfo.ContentType)
+	}
+
+	var signedData pkcs7SignedData
+	if _, err := asn1.Unmarshal(contentInfo.Content.Bytes, &signedData); err != nil {
+		t.Fatalf("invalid signed data: %v", err)
+	}
+	if len(signedData.SignerInfos) != 1 {
+		t.Fatalf("expected one signer, got %d", len(signedData.SignerInfos))
+	}
+
+	certificates, err := x509.ParseCertificates(signedData.Certificates.Bytes)
+	if err != nil || len(certificates) != 2 {
+		t.Fatalf("expected pass and WWDR certificates, got %d (%v)", len(certificates), err)
+	}
+
+	signer := signedData.SignerInfos[0]
+	var attributes []pkcs7Attribute
+	rest := signer.AuthenticatedAttributes.Bytes
+	for len(rest) > 0 {
+		var attribute pkcs7Attribute
+		if rest, err = asn1.Unmarshal(rest, &attribute); err != nil {
+			t.Fatalf("invalid attribute: %v", err)
+		}
+		attributes = append(attributes, attribute)
+	}
+
+	digest := sha256.Sum256(content)
+	foundDigest := false
+	for _, attribute := range attributes {
+		if attribute.Type.Equal(oidAttributeMessageDigest) {
+			var value []byte
+			asn1.Unmarshal(attribute.Values.Bytes, &value)
+			foundDigest = bytes.Equal(value, digest[:])
+		}
+	}
+	if !foundDigest {
+		t.Error("expected message digest attribute to match the manifest")
+	}
+
+	attributesDigest := sha256.Sum256(derSet(signer.AuthenticatedAttributes.Bytes))
+	if err := rsa.VerifyPKCS1v15(publicKey, crypto.SHA256, attributesDigest[:], signer.EncryptedDigest); err != nil {
+		t.Errorf("signature does not verify: %v", err)
+	}
+}
+
+func TestWalletPassService_GoogleSaveURL(t *testing.T) {
+	service, key := setupWalletPassService(t)
+
+	refunded := testWalletPassTicket()
+	refunded.Ticket = &models.Ticket{ID: 10, QRCode: "TKT-refunded", Status: models.TicketRefunded}
+
+	saveURL, err := service.GoogleSaveURL([]*WalletPassTicket{testWalletPassTicket(), refunded})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(saveURL, googleWalletSaveURL) {
+		t.Fatalf("unexpected save URL: %s", saveURL)
+	}
+
+	parts := strings.Split(strings.TrimPrefix(saveURL, googleWalletSaveURL), ".")
+	if len(parts) != 3 {
+		t.Fatalf("expected a JWT, got %d parts", len(parts))
+	}
+
+	signature, _ := base64.RawURLEncoding.DecodeString(parts[2])
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature); err != nil {
+		t.Errorf("JWT signature does not verify: %v", err)
+	}
+
+	payload, _ := base64.RawURLEncoding.DecodeString(parts[1])
+	var claims struct {
+		Iss     string `json:"iss"`
+		Aud     string `json:"aud"`
+		Typ     string `json:"typ"`
+		Payload struct {
+			EventTicketClasses []map[string]interface{} `json:"eventTicketClasses"`
+			EventTicketObjects []map[string]interface{} `json:"eventTicketObjects"`
+		} `json:"payload"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		t.Fatalf("invalid claims: %v", err)
+	}
+
+	if claims.Iss != "wallet@example.iam.gserviceaccount.com" || claims.Aud != "google" || claims.Typ != "savetowallet" {
+		t.Errorf("unexpected claims: %+v", claims)
+	}
+	if len(claims.Payload.EventTicketClasses) != 1 || claims.Payload.EventTicketClasses[0]["id"] != "3388000000000000000.event-1" {
+		t.Errorf("expected one class per event, got %v", claims.Payload.EventTicketClasses)
+	}
+	objects := claims.Payload.EventTicketObjects
+	if len(objects) != 2 || objects[0]["id"] != "3388000000000000000.ticket-9" {
+		t.Fatalf("unexpected objects: %v", objects)
+	}
+	if objects[0]["state"] != "ACTIVE" || objects[1]["state"] != "INACTIVE" {
+		t.Errorf("expected refunded ticket to be inactive, got %v and %v", objects[0]["state"], objects[1]["state"])
+	}
+}
+
+func TestTicketService_WalletPasses(t *testing.T) {
+	ticketRepo := newMockTicketRepository()
+	service := NewTicketService(ticketRepo, newMockOrderRepository(), nil, nil, nil, 15)
+
+	pass := testWalletPassTicket()
+	if service.WalletPassOptions().Any() {
+		t.Error("expected no wallets without a wallet pass service")
+	}
+	if _, err := service.GenerateApplePass(pass.Ticket, pass.Event, pass.Order); err == nil {
+		t.Error("expected error without a wallet pass service")
+	}
+
+	walletPasses, _ := setupWalletPassService(t)
+	service.SetWalletPassService(walletPasses)
+
+	if _, err := service.GenerateApplePass(pass.Ticket, pass.Event, pass.Order); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	refunded := &models.Ticket{ID: 10, QRCode: "TKT-refunded", Status: models.TicketRefunded}
+	if _, err := service.GenerateApplePass(refunded, pass.Event, pass.Order); err == nil {
+		t.Error("expected error for a refunded ticket")
+	}
+	if _, err := service.GoogleWalletURL([]*models.Ticket{refunded}, pass.Event, pass.Order); err == nil {
+		t.Error("expected error when every ticket is refunded")
+	}
+}
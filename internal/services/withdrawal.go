@@ -1,15 +1,34 @@
 package services
 
 import (
+	"errors"
 	"fmt"
+	"net/http"
 
 	"event-ticketing-platform/internal/models"
 	"event-ticketing-platform/internal/repositories"
 )
 
+// ErrWithdrawalsPausedForRefunds is returned when an organizer requests a
+// withdrawal while ticket holders of a cancelled event are still being refunded
+var ErrWithdrawalsPausedForRefunds = errors.New("withdrawals are paused until ticket holders of your cancelled events have been refunded")
+
+// PayoutDetailsRecorder records the bank details organizers ask to be paid to
+type PayoutDetailsRecorder interface {
+	RecordPayoutDetails(userID int, details string)
+}
+
+// BalanceSource returns organizers' withdrawable balances
+type BalanceSource interface {
+	GetOrganizerBalance(organizerID int) (float64, error)
+}
+
 // WithdrawalService handles withdrawal business logic
 type WithdrawalService struct {
 	withdrawalRepo *repositories.WithdrawalRepository
+	payoutRecorder PayoutDetailsRecorder
+	balances       BalanceSource
+	auditService   *AuditService
 }
 
 // NewWithdrawalService creates a new withdrawal service
@@ -19,6 +38,22 @@ func NewWithdrawalService(withdrawalRepo *repositories.WithdrawalRepository) *Wi
 	}
 }
 
+// SetAuditService records admins' decisions on withdrawals in the audit log
+func (s *WithdrawalService) SetAuditService(auditService *AuditService) {
+	s.auditService = auditService
+}
+
+// SetPayoutRecorder sets where the bank details of new withdrawals are recorded
+func (s *WithdrawalService) SetPayoutRecorder(recorder PayoutDetailsRecorder) {
+	s.payoutRecorder = recorder
+}
+
+// SetBalanceReader sets where organizers' balances are read from, such as
+// their ledgers, instead of working them out from their orders
+func (s *WithdrawalService) SetBalanceReader(balances BalanceSource) {
+	s.balances = balances
+}
+
 // CreateWithdrawal creates a new withdrawal request
 func (s *WithdrawalService) CreateWithdrawal(organizerID int, req *models.WithdrawalCreateRequest) (*models.Withdrawal, error) {
 	// Validate request
@@ -26,8 +61,17 @@ func (s *WithdrawalService) CreateWithdrawal(organizerID int, req *models.Withdr
 		return nil, err
 	}
 
+	// Funds stay with the platform until every buyer of a cancelled event is refunded
+	refunding, err := s.withdrawalRepo.CountRefundingCancellations(organizerID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check cancelled events: %w", err)
+	}
+	if refunding > 0 {
+		return nil, ErrWithdrawalsPausedForRefunds
+	}
+
 	// Check available balance
-	availableBalance, err := s.withdrawalRepo.GetOrganizerBalance(organizerID)
+	availableBalance, err := s.GetOrganizerBalance(organizerID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to get organizer balance: %w", err)
 	}
@@ -37,7 +81,16 @@ func (s *WithdrawalService) CreateWithdrawal(organizerID int, req *models.Withdr
 	}
 
 	// Create withdrawal
-	return s.withdrawalRepo.Create(organizerID, req)
+	withdrawal, err := s.withdrawalRepo.Create(organizerID, req)
+	if err != nil {
+		return nil, err
+	}
+
+	if s.payoutRecorder != nil {
+		s.payoutRecorder.RecordPayoutDetails(organizerID, req.BankDetails)
+	}
+
+	return withdrawal, nil
 }
 
 // GetWithdrawalByID retrieves a withdrawal by ID
@@ -58,15 +111,53 @@ func (s *WithdrawalService) GetAllWithdrawals(page, limit int, status string) ([
 }
 
 // UpdateWithdrawalStatus updates the status of a withdrawal (admin only)
-func (s *WithdrawalService) UpdateWithdrawalStatus(id int, status models.WithdrawalStatus, adminNotes string) error {
-	return s.withdrawalRepo.UpdateStatus(id, status, adminNotes)
+func (s *WithdrawalService) UpdateWithdrawalStatus(adminID, id int, status models.WithdrawalStatus, adminNotes string, r *http.Request) error {
+	if err := s.withdrawalRepo.UpdateStatus(id, status, adminNotes); err != nil {
+		return err
+	}
+
+	if action := withdrawalAuditAction(status); action != "" && s.auditService != nil {
+		details := map[string]interface{}{"admin_notes": adminNotes}
+		if withdrawal, err := s.withdrawalRepo.GetByID(id); err == nil {
+			details["amount"] = withdrawal.Amount
+			details["organizer_id"] = withdrawal.OrganizerID
+		}
+		if err := s.auditService.LogAction(adminID, action, models.AuditTargetWithdrawal, id, details, r); err != nil {
+			fmt.Printf("Warning: failed to log %s of withdrawal %d: %v\n", status, id, err)
+		}
+	}
+	return nil
+}
+
+// withdrawalAuditAction returns the audit action for moving a withdrawal to
+// status, or "" for statuses that aren't decisions
+func withdrawalAuditAction(status models.WithdrawalStatus) string {
+	switch status {
+	case models.WithdrawalStatusApproved:
+		return models.AuditActionWithdrawalApprove
+	case models.WithdrawalStatusRejected:
+		return models.AuditActionWithdrawalReject
+	case models.WithdrawalStatusCompleted:
+		return models.AuditActionWithdrawalComplete
+	default:
+		return ""
+	}
 }
 
 // GetOrganizerBalance gets the available balance for an organizer
 func (s *WithdrawalService) GetOrganizerBalance(organizerID int) (float64, error) {
+	if s.balances != nil {
+		return s.balances.GetOrganizerBalance(organizerID)
+	}
 	return s.withdrawalRepo.GetOrganizerBalance(organizerID)
 }
 
+// CountRefundingCancellations returns how many of an organizer's cancelled
+// events are still refunding ticket holders
+func (s *WithdrawalService) CountRefundingCancellations(organizerID int) (int, error) {
+	return s.withdrawalRepo.CountRefundingCancellations(organizerID)
+}
+
 // CanUserAccessWithdrawal checks if a user can access a specific withdrawal
 func (s *WithdrawalService) CanUserAccessWithdrawal(withdrawalID int, userID int, userRole models.UserRole) (bool, error) {
 	withdrawal, err := s.withdrawalRepo.GetByID(withdrawalID)
@@ -85,4 +176,4 @@ func (s *WithdrawalService) CanUserAccessWithdrawal(withdrawalID int, userID int
 	}
 
 	return false, nil
-}
\ No newline at end of file
+}
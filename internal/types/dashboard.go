@@ -14,4 +14,10 @@ type AttendeeDashboardData struct {
 	PastEvents        []*models.Event
 	RecentOrders      []*repositories.OrderWithDetails
 	RecommendedEvents []*models.Event
+	CalendarFeedURL   string // Personal iCalendar feed of ticketed events; empty when unavailable
+
+	SavedEvents      []*models.Event       // Upcoming events the attendee saved
+	SavedSearches    []*models.SavedSearch // Searches the attendee is emailed about
+	Categories       []*models.Category    // Choices for saving a search
+	SavedSearchError string                // Why the last search could not be saved
 }
@@ -0,0 +1,102 @@
+package utils
+
+import (
+	"strings"
+	"testing"
+	"time"
+)
+
+// rfc6238Secret is the RFC 6238 test key "12345678901234567890" in base32
+const rfc6238Secret = "GEZDGNBVGY3TQOJQGEZDGNBVGY3TQOJQ"
+
+func TestTOTPCode_RFC6238Vectors(t *testing.T) {
+	tests := []struct {
+		unix     int64
+		expected string
+	}{
+		{59, "287082"},
+		{1111111109, "081804"},
+		{1111111111, "050471"},
+		{1234567890, "005924"},
+		{2000000000, "279037"},
+	}
+
+	for _, tt := range tests {
+		code, err := TOTPCode(rfc6238Secret, TOTPStep(time.Unix(tt.unix, 0)))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if code != tt.expected {
+			t.Errorf("at %d: expected %s, got %s", tt.unix, tt.expected, code)
+		}
+	}
+}
+
+func TestValidateTOTP(t *testing.T) {
+	now := time.Unix(1111111111, 0)
+	code, _ := TOTPCode(rfc6238Secret, TOTPStep(now))
+
+	step, ok := ValidateTOTP(rfc6238Secret, code, now)
+	if !ok || step != TOTPStep(now) {
+		t.Fatalf("expected current code to be valid, got step=%d ok=%v", step, ok)
+	}
+
+	// Codes from adjacent periods are accepted to allow for clock skew
+	if _, ok := ValidateTOTP(rfc6238Secret, code, now.Add(TOTPPeriod)); !ok {
+		t.Error("expected code from the previous period to be accepted")
+	}
+
+	if _, ok := ValidateTOTP(rfc6238Secret, code, now.Add(3*TOTPPeriod)); ok {
+		t.Error("expected old code to be rejected")
+	}
+
+	for _, invalid := range []string{"", "12345", "1234567", "abcdef"} {
+		if _, ok := ValidateTOTP(rfc6238Secret, invalid, now); ok {
+			t.Errorf("expected %q to be rejected", invalid)
+		}
+	}
+}
+
+func TestGenerateTOTPSecret(t *testing.T) {
+	secret, err := GenerateTOTPSecret()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(secret) != 32 {
+		t.Errorf("expected a 32 character secret, got %d", len(secret))
+	}
+	if _, err := TOTPCode(secret, 1); err != nil {
+		t.Errorf("expected generated secret to be usable: %v", err)
+	}
+}
+
+func TestTOTPProvisioningURI(t *testing.T) {
+	uri := TOTPProvisioningURI("Runtown", "user@example.com", "SECRET")
+	if !strings.HasPrefix(uri, "otpauth://totp/Runtown:user@example.com?") {
+		t.Errorf("unexpected URI: %s", uri)
+	}
+	if !strings.Contains(uri, "secret=SECRET") || !strings.Contains(uri, "issuer=Runtown") {
+		t.Errorf("expected secret and issuer in URI: %s", uri)
+	}
+}
+
+func TestBackupCodes(t *testing.T) {
+	code, err := GenerateBackupCode()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(code) != 11 || code[5] != '-' {
+		t.Errorf("unexpected backup code format: %s", code)
+	}
+
+	// Hashes ignore case, spacing and the separator
+	spaced := strings.ToLower(strings.Replace(code, "-", " ", 1))
+	if HashBackupCode(code) != HashBackupCode(spaced) {
+		t.Error("expected normalized backup codes to hash the same")
+	}
+
+	other, _ := GenerateBackupCode()
+	if HashBackupCode(code) == HashBackupCode(other) {
+		t.Error("expected different codes to hash differently")
+	}
+}
@@ -299,6 +299,68 @@ document.addEventListener('DOMContentLoaded', function() {
     }
 });
 
+// Live ticket availability
+// Containers with data-availability-stream open a server-sent events stream
+// and keep the [data-ticket-remaining] labels inside them current. The script
+// runs again on boosted navigation, so open streams are kept on window.
+window.availabilityStreams = window.availabilityStreams || new Map();
+
+function initAvailabilityStreams() {
+    if (!window.EventSource) return;
+
+    document.querySelectorAll('[data-availability-stream]').forEach(container => {
+        const url = container.dataset.availabilityStream;
+        const stream = window.availabilityStreams.get(url);
+        if (stream) {
+            // Content was swapped in; label it with the latest update
+            if (stream.update) applyAvailability(container, stream.update);
+            return;
+        }
+
+        const source = new EventSource(url);
+        const entry = { source: source, update: null };
+        source.addEventListener('availability', function(evt) {
+            const current = document.querySelector(`[data-availability-stream="${url}"]`);
+            if (!current) {
+                // The page was navigated away from
+                source.close();
+                window.availabilityStreams.delete(url);
+                return;
+            }
+            entry.update = JSON.parse(evt.data);
+            applyAvailability(current, entry.update);
+        });
+        window.availabilityStreams.set(url, entry);
+    });
+}
+
+function applyAvailability(container, update) {
+    let soldOutChanged = false;
+    update.ticket_types.forEach(ticketType => {
+        container.querySelectorAll(`[data-ticket-remaining="${ticketType.id}"]`).forEach(label => {
+            if (label.dataset.soldOut !== undefined && (label.dataset.soldOut === 'true') !== ticketType.sold_out) {
+                soldOutChanged = true;
+            }
+            label.textContent = availabilityLabel(ticketType, label.dataset.cartQuantity);
+        });
+    });
+
+    // Selling out swaps the add to cart form for a label, so the section is
+    // reloaded rather than relabelled
+    if (soldOutChanged) {
+        htmx.trigger(container, 'availability-changed');
+    }
+}
+
+function availabilityLabel(ticketType, cartQuantity) {
+    if (ticketType.sold_out) return 'Sold out';
+    if (cartQuantity === undefined) return `${ticketType.remaining} left`;
+    if (ticketType.remaining < parseInt(cartQuantity, 10)) return `Only ${ticketType.remaining} left`;
+    return ticketType.remaining <= 10 ? `${ticketType.remaining} left` : '';
+}
+
+htmx.onLoad(initAvailabilityStreams);
+
 // Export functions for global use
 window.EventHub = {
     showToast,
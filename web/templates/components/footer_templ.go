@@ -8,6 +8,8 @@ package components
 import "github.com/a-h/templ"
 import templruntime "github.com/a-h/templ/runtime"
 
+import "event-ticketing-platform/internal/models"
+
 func Footer() templ.Component {
 	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
@@ -29,7 +31,49 @@ func Footer() templ.Component {
 			templ_7745c5c3_Var1 = templ.NopComponent
 		}
 		ctx = templ.ClearChildren(ctx)
-		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<footer class=\"bg-gray-900 text-white\"><div class=\"max-w-7xl mx-auto px-4 sm:px-6 lg:px-8 py-12\"><div class=\"grid grid-cols-1 md:grid-cols-4 gap-8\"><div class=\"col-span-1 md:col-span-2\"><h3 class=\"text-2xl font-bold text-white mb-4\">Runtown</h3><p class=\"text-gray-300 mb-4\">Discover amazing events and create unforgettable experiences.  Connect with your community through events that matter.</p><div class=\"flex space-x-4\"><a href=\"#\" class=\"text-gray-300 hover:text-white transition-colors\"><svg class=\"h-6 w-6\" fill=\"currentColor\" viewBox=\"0 0 24 24\"><path d=\"M24 4.557c-.883.392-1.832.656-2.828.775 1.017-.609 1.798-1.574 2.165-2.724-.951.564-2.005.974-3.127 1.195-.897-.957-2.178-1.555-3.594-1.555-3.179 0-5.515 2.966-4.797 6.045-4.091-.205-7.719-2.165-10.148-5.144-1.29 2.213-.669 5.108 1.523 6.574-.806-.026-1.566-.247-2.229-.616-.054 2.281 1.581 4.415 3.949 4.89-.693.188-1.452.232-2.224.084.626 1.956 2.444 3.379 4.6 3.419-2.07 1.623-4.678 2.348-7.29 2.04 2.179 1.397 4.768 2.212 7.548 2.212 9.142 0 14.307-7.721 13.995-14.646.962-.695 1.797-1.562 2.457-2.549z\"></path></svg></a> <a href=\"#\" class=\"text-gray-300 hover:text-white transition-colors\"><svg class=\"h-6 w-6\" fill=\"currentColor\" viewBox=\"0 0 24 24\"><path d=\"M22.46 6c-.77.35-1.6.58-2.46.69.88-.53 1.56-1.37 1.88-2.38-.83.5-1.75.85-2.72 1.05C18.37 4.5 17.26 4 16 4c-2.35 0-4.27 1.92-4.27 4.29 0 .34.04.67.11.98C8.28 9.09 5.11 7.38 3 4.79c-.37.63-.58 1.37-.58 2.15 0 1.49.75 2.81 1.91 3.56-.71 0-1.37-.2-1.95-.5v.03c0 2.08 1.48 3.82 3.44 4.21a4.22 4.22 0 0 1-1.93.07 4.28 4.28 0 0 0 4 2.98 8.521 8.521 0 0 1-5.33 1.84c-.34 0-.68-.02-1.02-.06C3.44 20.29 5.7 21 8.12 21 16 21 20.33 14.46 20.33 8.79c0-.19 0-.37-.01-.56.84-.6 1.56-1.36 2.14-2.23z\"></path></svg></a> <a href=\"#\" class=\"text-gray-300 hover:text-white transition-colors\"><svg class=\"h-6 w-6\" fill=\"currentColor\" viewBox=\"0 0 24 24\"><path d=\"M12.017 0C5.396 0 .029 5.367.029 11.987c0 5.079 3.158 9.417 7.618 11.174-.105-.949-.199-2.403.041-3.439.219-.937 1.406-5.957 1.406-5.957s-.359-.72-.359-1.781c0-1.663.967-2.911 2.168-2.911 1.024 0 1.518.769 1.518 1.688 0 1.029-.653 2.567-.992 3.992-.285 1.193.6 2.165 1.775 2.165 2.128 0 3.768-2.245 3.768-5.487 0-2.861-2.063-4.869-5.008-4.869-3.41 0-5.409 2.562-5.409 5.199 0 1.033.394 2.143.889 2.741.097.118.112.221.085.345-.09.375-.293 1.199-.334 1.363-.053.225-.172.271-.402.165-1.495-.69-2.433-2.878-2.433-4.646 0-3.776 2.748-7.252 7.92-7.252 4.158 0 7.392 2.967 7.392 6.923 0 4.135-2.607 7.462-6.233 7.462-1.214 0-2.357-.629-2.75-1.378l-.748 2.853c-.271 1.043-1.002 2.35-1.492 3.146C9.57 23.812 10.763 24.009 12.017 24.009c6.624 0 11.99-5.367 11.99-11.988C24.007 5.367 18.641.001.012.001z.017 0z\"></path></svg></a></div></div><div><h4 class=\"text-lg font-semibold mb-4\">For Attendees</h4><ul class=\"space-y-2\"><li><a href=\"/events\" class=\"text-gray-300 hover:text-white transition-colors\">Browse Events</a></li><li><a href=\"/categories\" class=\"text-gray-300 hover:text-white transition-colors\">Categories</a></li><li><a href=\"/help\" class=\"text-gray-300 hover:text-white transition-colors\">Help Center</a></li><li><a href=\"/contact\" class=\"text-gray-300 hover:text-white transition-colors\">Contact Us</a></li></ul></div><div><h4 class=\"text-lg font-semibold mb-4\">For Organizers</h4><ul class=\"space-y-2\"><li><a href=\"/organizer/events/create\" class=\"text-gray-300 hover:text-white transition-colors\">Create Event</a></li><li><a href=\"/organizer/events\" class=\"text-gray-300 hover:text-white transition-colors\">Manage Events</a></li><li><a href=\"/pricing\" class=\"text-gray-300 hover:text-white transition-colors\">Pricing</a></li><li><a href=\"/resources\" class=\"text-gray-300 hover:text-white transition-colors\">Resources</a></li></ul></div></div><div class=\"border-t border-gray-800 mt-8 pt-8 flex flex-col md:flex-row justify-between items-center\"><p class=\"text-gray-300 text-sm\">© 2025 Runtown. All rights reserved.</p><div class=\"flex space-x-6 mt-4 md:mt-0\"><a href=\"/privacy\" class=\"text-gray-300 hover:text-white text-sm transition-colors\">Privacy Policy</a> <a href=\"/terms\" class=\"text-gray-300 hover:text-white text-sm transition-colors\">Terms of Service</a> <a href=\"/cookies\" class=\"text-gray-300 hover:text-white text-sm transition-colors\">Cookie Policy</a></div></div></div></footer>")
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 1, "<footer class=\"bg-gray-900 text-white\"><div class=\"max-w-7xl mx-auto px-4 sm:px-6 lg:px-8 py-12\"><div class=\"grid grid-cols-1 md:grid-cols-4 gap-8\"><div class=\"col-span-1 md:col-span-2\"><h3 class=\"text-2xl font-bold text-white mb-4\">Runtown</h3>")
+		if templ_7745c5c3_Err != nil {
+			return templ_7745c5c3_Err
+		}
+		if footerText := getSnippet(ctx, models.SnippetFooterText); footerText != "" {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 2, "<p class=\"text-gray-300 mb-4 whitespace-pre-line\">")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var2 string
+			templ_7745c5c3_Var2, templ_7745c5c3_Err = templ.JoinStringErrs(footerText)
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `web/templates/components/footer.templ`, Line: 12, Col: 68}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var2))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 3, "</p>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		if supportContact := getSnippet(ctx, models.SnippetSupportContact); supportContact != "" {
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 4, "<p class=\"text-gray-400 text-sm mb-4 whitespace-pre-line\">")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			var templ_7745c5c3_Var3 string
+			templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinStringErrs(supportContact)
+			if templ_7745c5c3_Err != nil {
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `web/templates/components/footer.templ`, Line: 15, Col: 80}
+			}
+			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var3))
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "</p>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+		}
+		templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 6, "<div class=\"flex space-x-4\"><a href=\"#\" class=\"text-gray-300 hover:text-white transition-colors\"><svg class=\"h-6 w-6\" fill=\"currentColor\" viewBox=\"0 0 24 24\"><path d=\"M24 4.557c-.883.392-1.832.656-2.828.775 1.017-.609 1.798-1.574 2.165-2.724-.951.564-2.005.974-3.127 1.195-.897-.957-2.178-1.555-3.594-1.555-3.179 0-5.515 2.966-4.797 6.045-4.091-.205-7.719-2.165-10.148-5.144-1.29 2.213-.669 5.108 1.523 6.574-.806-.026-1.566-.247-2.229-.616-.054 2.281 1.581 4.415 3.949 4.89-.693.188-1.452.232-2.224.084.626 1.956 2.444 3.379 4.6 3.419-2.07 1.623-4.678 2.348-7.29 2.04 2.179 1.397 4.768 2.212 7.548 2.212 9.142 0 14.307-7.721 13.995-14.646.962-.695 1.797-1.562 2.457-2.549z\"></path></svg></a> <a href=\"#\" class=\"text-gray-300 hover:text-white transition-colors\"><svg class=\"h-6 w-6\" fill=\"currentColor\" viewBox=\"0 0 24 24\"><path d=\"M22.46 6c-.77.35-1.6.58-2.46.69.88-.53 1.56-1.37 1.88-2.38-.83.5-1.75.85-2.72 1.05C18.37 4.5 17.26 4 16 4c-2.35 0-4.27 1.92-4.27 4.29 0 .34.04.67.11.98C8.28 9.09 5.11 7.38 3 4.79c-.37.63-.58 1.37-.58 2.15 0 1.49.75 2.81 1.91 3.56-.71 0-1.37-.2-1.95-.5v.03c0 2.08 1.48 3.82 3.44 4.21a4.22 4.22 0 0 1-1.93.07 4.28 4.28 0 0 0 4 2.98 8.521 8.521 0 0 1-5.33 1.84c-.34 0-.68-.02-1.02-.06C3.44 20.29 5.7 21 8.12 21 16 21 20.33 14.46 20.33 8.79c0-.19 0-.37-.01-.56.84-.6 1.56-1.36 2.14-2.23z\"></path></svg></a> <a href=\"#\" class=\"text-gray-300 hover:text-white transition-colors\"><svg class=\"h-6 w-6\" fill=\"currentColor\" viewBox=\"0 0 24 24\"><path d=\"M12.017 0C5.396 0 .029 5.367.029 11.987c0 5.079 3.158 9.417 7.618 11.174-.105-.949-.199-2.403.041-3.439.219-.937 1.406-5.957 1.406-5.957s-.359-.72-.359-1.781c0-1.663.967-2.911 2.168-2.911 1.024 0 1.518.769 1.518 1.688 0 1.029-.653 2.567-.992 3.992-.285 1.193.6 2.165 1.775 2.165 2.128 0 3.768-2.245 3.768-5.487 0-2.861-2.063-4.869-5.008-4.869-3.41 0-5.409 2.562-5.409 5.199 0 1.033.394 2.143.889 2.741.097.118.112.221.085.345-.09.375-.293 1.199-.334 1.363-.053.225-.172.271-.402.165-1.495-.69-2.433-2.878-2.433-4.646 0-3.776 2.748-7.252 7.92-7.252 4.158 0 7.392 2.967 7.392 6.923 0 4.135-2.607 7.462-6.233 7.462-1.214 0-2.357-.629-2.75-1.378l-.748 2.853c-.271 1.043-1.002 2.35-1.492 3.146C9.57 23.812 10.763 24.009 12.017 24.009c6.624 0 11.99-5.367 11.99-11.988C24.007 5.367 18.641.001.012.001z.017 0z\"></path></svg></a></div></div><div><h4 class=\"text-lg font-semibold mb-4\">For Attendees</h4><ul class=\"space-y-2\"><li><a href=\"/events\" class=\"text-gray-300 hover:text-white transition-colors\">Browse Events</a></li><li><a href=\"/categories\" class=\"text-gray-300 hover:text-white transition-colors\">Categories</a></li><li><a href=\"/help\" class=\"text-gray-300 hover:text-white transition-colors\">Help Center</a></li><li><a href=\"/contact\" class=\"text-gray-300 hover:text-white transition-colors\">Contact Us</a></li></ul></div><div><h4 class=\"text-lg font-semibold mb-4\">For Organizers</h4><ul class=\"space-y-2\"><li><a href=\"/organizer/events/create\" class=\"text-gray-300 hover:text-white transition-colors\">Create Event</a></li><li><a href=\"/organizer/events\" class=\"text-gray-300 hover:text-white transition-colors\">Manage Events</a></li><li><a href=\"/pricing\" class=\"text-gray-300 hover:text-white transition-colors\">Pricing</a></li><li><a href=\"/resources\" class=\"text-gray-300 hover:text-white transition-colors\">Resources</a></li></ul></div></div><div class=\"border-t border-gray-800 mt-8 pt-8 flex flex-col md:flex-row justify-between items-center\"><p class=\"text-gray-300 text-sm\">© 2025 Runtown. All rights reserved.</p><div class=\"flex space-x-6 mt-4 md:mt-0\"><a href=\"/privacy\" class=\"text-gray-300 hover:text-white text-sm transition-colors\">Privacy Policy</a> <a href=\"/terms\" class=\"text-gray-300 hover:text-white text-sm transition-colors\">Terms of Service</a> <a href=\"/cookies\" class=\"text-gray-300 hover:text-white text-sm transition-colors\">Cookie Policy</a> <a href=\"/privacy-preferences\" class=\"text-gray-300 hover:text-white text-sm transition-colors\">Privacy Preferences</a></div></div></div></footer>")
 		if templ_7745c5c3_Err != nil {
 			return templ_7745c5c3_Err
 		}
@@ -2,6 +2,8 @@ package components
 
 import (
 	"context"
+
+	"event-ticketing-platform/internal/models"
 )
 
 // getCSRFToken gets the CSRF token from the request context
@@ -10,4 +12,44 @@ func getCSRFToken(ctx context.Context) string {
 		return token
 	}
 	return ""
-}
\ No newline at end of file
+}
+
+// getSnippet gets an admin-editable content snippet from the request context,
+// falling back to its default
+func getSnippet(ctx context.Context, key models.SnippetKey) string {
+	if snippets, ok := ctx.Value("snippets").(map[models.SnippetKey]string); ok {
+		if content, ok := snippets[key]; ok {
+			return content
+		}
+	}
+	definition, _ := models.LookupSnippet(key)
+	return definition.Default
+}
+
+// consentPending reports whether the visitor has yet to choose their privacy
+// preferences. Requests the consent middleware didn't see never ask.
+func consentPending(ctx context.Context) bool {
+	prefs, loaded := ctx.Value("privacy_preferences").(*models.PrivacyPreferences)
+	return loaded && prefs == nil
+}
+
+// getAnnouncements returns the announcement banners shown to the visitor
+func getAnnouncements(ctx context.Context) []*models.Announcement {
+	announcements, _ := ctx.Value("announcements").([]*models.Announcement)
+	return announcements
+}
+
+// getImpersonation returns the impersonation an admin has active, or nil
+func getImpersonation(ctx context.Context) *models.Impersonation {
+	impersonation, _ := ctx.Value("impersonation").(*models.Impersonation)
+	return impersonation
+}
+
+// imageLoading lazy loads event images below the fold. The hero is the
+// largest image on its page, so it loads straight away.
+func imageLoading(variant string) string {
+	if variant == models.ImageVariantHero {
+		return "eager"
+	}
+	return "lazy"
+}
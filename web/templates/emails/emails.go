@@ -0,0 +1,188 @@
+// Package emails renders the platform's transactional emails. Each email has
+// a templ HTML template wrapped in the shared layout and a plain text
+// alternative, and both are rendered in the recipient's language.
+package emails
+
+import (
+	"bytes"
+	"context"
+	"embed"
+	"fmt"
+	"html"
+	"strings"
+	"text/template"
+	"unicode/utf8"
+
+	"event-ticketing-platform/internal/i18n"
+	"event-ticketing-platform/internal/models"
+
+	"github.com/a-h/templ"
+)
+
+//go:embed text/*.txt
+var textFiles embed.FS
+
+// textTemplates are the plain text alternatives, by file name
+var textTemplates = template.Must(template.New("").Funcs(template.FuncMap{
+	"t":            i18n.T,
+	"heading":      TextHeading,
+	"footer":       textFooter,
+	"datetime":     i18n.FormatDateTime,
+	"longdatetime": i18n.FormatLongDateTime,
+	"money":        formatMoney,
+	"orderStatus":  orderStatusName,
+	"taxLine":      taxLineName,
+}).ParseFS(textFiles, "text/*.txt"))
+
+// Header and button colors of each kind of email
+const (
+	accentGreen  = "#059669"
+	accentIndigo = "#4F46E5"
+	accentRed    = "#EF4444"
+	accentPurple = "#7C3AED"
+)
+
+// Email is a rendered email: its subject, HTML body and plain text alternative
+type Email struct {
+	Subject string
+	HTML    string
+	Text    string
+}
+
+// VerifyData is the content of an email address verification email
+type VerifyData struct {
+	Locale string
+	Name   string
+	Link   string
+}
+
+// OrderConfirmationData is the content of an order confirmation email
+type OrderConfirmationData struct {
+	Locale   string
+	Name     string
+	Order    *models.Order
+	Tickets  []*models.Ticket
+	OrderURL string                // Where the buyer can view the order and download tickets
+	Policies *models.EventPolicies // The event's policies, nil if the organizer hasn't set any
+	FAQURL   string                // The event's FAQs, if it has any
+}
+
+// RefundData is the content of an order refund email
+type RefundData struct {
+	Locale string
+	Name   string
+	Order  *models.Order
+}
+
+// ReminderData is the content of an upcoming event reminder email
+type ReminderData struct {
+	Locale  string
+	Name    string
+	Subject string
+	Event   *models.Event
+	Message string // The organizer's custom message, if any
+	Link    string
+	Access  *models.EventAccess // The access link of online events, nil for events held in person
+}
+
+// Verify renders the email asking a new user to verify their email address
+func Verify(data VerifyData) (*Email, error) {
+	return render(i18n.T(data.Locale, "verify.subject"), verifyEmail(data), "verify.txt", data)
+}
+
+// OrderConfirmation renders the email confirming a completed order
+func OrderConfirmation(data OrderConfirmationData) (*Email, error) {
+	return render(i18n.T(data.Locale, "order_confirmation.subject", data.Order.OrderNumber), orderConfirmationEmail(data), "order_confirmation.txt", data)
+}
+
+// Refund renders the email telling a buyer their order was refunded
+func Refund(data RefundData) (*Email, error) {
+	return render(i18n.T(data.Locale, "order_refunded.subject", data.Order.OrderNumber), refundEmail(data), "refund.txt", data)
+}
+
+// Reminder renders the email reminding a ticket holder about an upcoming event
+func Reminder(data ReminderData) (*Email, error) {
+	return render(data.Subject, reminderEmail(data), "reminder.txt", data)
+}
+
+// render renders an email's HTML template and its plain text alternative. Line
+// endings of the text templates are normalized so checkouts that convert
+// them don't change the emails.
+func render(subject string, component templ.Component, textTemplate string, data interface{}) (*Email, error) {
+	var htmlBody bytes.Buffer
+	if err := component.Render(context.Background(), &htmlBody); err != nil {
+		return nil, fmt.Errorf("failed to render %s email: %w", strings.TrimSuffix(textTemplate, ".txt"), err)
+	}
+
+	var textBody bytes.Buffer
+	if err := textTemplates.ExecuteTemplate(&textBody, textTemplate, data); err != nil {
+		return nil, fmt.Errorf("failed to render %s text: %w", strings.TrimSuffix(textTemplate, ".txt"), err)
+	}
+
+	return &Email{
+		Subject: subject,
+		HTML:    htmlBody.String(),
+		Text:    strings.TrimSpace(strings.ReplaceAll(textBody.String(), "\r\n", "\n")),
+	}, nil
+}
+
+// TextHeading formats a heading for a plain text email, underlined
+func TextHeading(heading string) string {
+	heading = strings.ToUpper(heading)
+	return heading + "\n" + strings.Repeat("=", utf8.RuneCountInString(heading))
+}
+
+// textFooter is the plain text equivalent of the layout's footer
+func textFooter(locale, sentTo string) string {
+	footer := i18n.T(locale, "email.team")
+	if sentTo != "" {
+		footer += "\n" + i18n.T(locale, "email.sent_to", sentTo)
+	}
+	return footer
+}
+
+// stylesheet returns the styles shared by every email, with the header and
+// buttons in the given accent color
+func stylesheet(accent string) string {
+	return fmt.Sprintf(`<style>
+        body { font-family: Arial, sans-serif; line-height: 1.6; color: #333; }
+        .container { max-width: 600px; margin: 0 auto; padding: 20px; }
+        .header { background-color: %[1]s; color: white; padding: 20px; text-align: center; border-radius: 8px 8px 0 0; }
+        .content { padding: 20px; background-color: #f9f9f9; }
+        .highlight { background-color: white; padding: 15px; border-left: 4px solid %[1]s; margin: 20px 0; border-radius: 4px; }
+        .notice { background-color: #FEF3C7; color: #92400E; padding: 15px; border-left: 4px solid #F59E0B; margin: 20px 0; border-radius: 4px; }
+        .button { display: inline-block; padding: 12px 24px; background-color: %[1]s; color: white; text-decoration: none; border-radius: 4px; margin: 20px 0; }
+        .link { word-break: break-all; background-color: #f0f0f0; padding: 10px; border-radius: 4px; }
+        .footer { text-align: center; padding: 20px; color: #666; font-size: 12px; }
+    </style>`, accent)
+}
+
+// strong escapes text and marks it up in bold, for use as an argument of a
+// message that is rendered as HTML
+func strong(text string) string {
+	return "<strong>" + html.EscapeString(text) + "</strong>"
+}
+
+// formatMoney formats an amount in shillings
+func formatMoney(amount float64) string {
+	return fmt.Sprintf("KSh %.2f", amount)
+}
+
+// taxLineName returns the name and rate of an order's tax in the given
+// language, like "VAT 16%"
+func taxLineName(locale string, tax *models.TaxLine) string {
+	name := tax.Label + " " + models.FormatTaxRate(tax.RateBasisPoints)
+	if tax.Inclusive {
+		name += " (" + i18n.T(locale, "order.tax_included") + ")"
+	}
+	return name
+}
+
+// orderStatusName returns the order's status in the given language
+func orderStatusName(locale string, order *models.Order) string {
+	switch order.Status {
+	case models.OrderPending, models.OrderCompleted, models.OrderCancelled, models.OrderRefunded:
+		return i18n.T(locale, "order.status."+string(order.Status))
+	}
+	return order.GetStatusDisplayName()
+}
@@ -0,0 +1,133 @@
+package emails
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"event-ticketing-platform/internal/i18n"
+	"event-ticketing-platform/internal/models"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderPreview_AllTemplatesAndLocales(t *testing.T) {
+	for _, preview := range Previews {
+		for _, locale := range i18n.SupportedLocales {
+			email, err := RenderPreview(preview.Name, locale)
+			require.NoError(t, err, "%s in %s", preview.Name, locale)
+
+			assert.NotEmpty(t, email.Subject, "%s in %s", preview.Name, locale)
+			assert.Contains(t, email.HTML, `<html lang="`+locale+`">`, "%s in %s", preview.Name, locale)
+			assert.Contains(t, email.HTML, `<div class="footer">`, "%s in %s", preview.Name, locale)
+			assert.Contains(t, email.Text, i18n.T(locale, "email.team"), "%s in %s", preview.Name, locale)
+			assert.NotContains(t, email.Text, "<no value>", "%s in %s", preview.Name, locale)
+		}
+	}
+}
+
+func TestRenderPreview_UnknownTemplate(t *testing.T) {
+	_, err := RenderPreview("missing", i18n.English)
+	assert.Error(t, err)
+}
+
+func TestVerify(t *testing.T) {
+	email, err := Verify(VerifyData{Locale: i18n.English, Name: "Amina", Link: "https://example.com/auth/verify?token=abc"})
+	require.NoError(t, err)
+
+	assert.Equal(t, "Verify your email address - Runtown", email.Subject)
+	assert.Contains(t, email.HTML, `href="https://example.com/auth/verify?token=abc"`)
+	assert.Contains(t, email.HTML, "Dear Amina,")
+	assert.True(t, strings.HasPrefix(email.Text, "Verify Your Email Address"))
+	assert.Contains(t, email.Text, "https://example.com/auth/verify?token=abc")
+}
+
+func TestRefund_EscapesArguments(t *testing.T) {
+	order := sampleOrder()
+	order.OrderNumber = "<script>"
+
+	email, err := Refund(RefundData{Locale: i18n.French, Name: "Amina", Order: order})
+	require.NoError(t, err)
+
+	assert.Contains(t, email.HTML, "<strong>&lt;script&gt;</strong>")
+	assert.NotContains(t, email.HTML, "<script>")
+	assert.Contains(t, email.Text, "Le montant de KSh 1500.00 sera reversé")
+}
+
+func TestOrderConfirmation_Tax(t *testing.T) {
+	order := sampleOrder()
+	order.Tax = &models.TaxLine{Label: "VAT", RateBasisPoints: 1600, Inclusive: true, TaxID: "P051234567X", Taxable: 150000, Amount: 20690}
+
+	email, err := OrderConfirmation(OrderConfirmationData{Locale: i18n.English, Name: "Amina", Order: order, OrderURL: "https://example.com/o"})
+	require.NoError(t, err)
+	assert.Contains(t, email.HTML, "VAT 16% (included):</strong> KSh 206.90")
+	assert.Contains(t, email.HTML, "P051234567X")
+	assert.Contains(t, email.Text, "VAT 16% (included): KSh 206.90\n")
+	assert.Contains(t, email.Text, "Tax ID: P051234567X\n")
+
+	order.Tax = nil
+	email, err = OrderConfirmation(OrderConfirmationData{Locale: i18n.English, Name: "Amina", Order: order, OrderURL: "https://example.com/o"})
+	require.NoError(t, err)
+	assert.NotContains(t, email.Text, "VAT")
+	assert.NotContains(t, email.Text, "Tax ID")
+}
+
+func TestOrderConfirmation_EventPolicies(t *testing.T) {
+	order := sampleOrder()
+	policies := &models.EventPolicies{RefundPolicy: "Full refunds up to 7 days before", MinimumAge: 18, AccessibilityInfo: "Step-free access at gate B"}
+
+	email, err := OrderConfirmation(OrderConfirmationData{Locale: i18n.English, Name: "Amina", Order: order, OrderURL: "https://example.com/o", Policies: policies, FAQURL: "https://example.com/events/1#faqs"})
+	require.NoError(t, err)
+	assert.Contains(t, email.HTML, "Refund policy: Full refunds up to 7 days before")
+	assert.NotContains(t, email.HTML, "non-refundable")
+	assert.Contains(t, email.HTML, "This event is for ages 18 and over.")
+	assert.Contains(t, email.HTML, `href="https://example.com/events/1#faqs"`)
+	assert.Contains(t, email.Text, "• Refund policy: Full refunds up to 7 days before\n")
+	assert.Contains(t, email.Text, "• Accessibility: Step-free access at gate B\n")
+	assert.Contains(t, email.Text, "common ones at:\nhttps://example.com/events/1#faqs\n")
+
+	email, err = OrderConfirmation(OrderConfirmationData{Locale: i18n.English, Name: "Amina", Order: order, OrderURL: "https://example.com/o"})
+	require.NoError(t, err)
+	assert.Contains(t, email.Text, "• Tickets are non-transferable and non-refundable\n")
+	assert.NotContains(t, email.Text, "ages")
+	assert.NotContains(t, email.Text, "FAQ")
+}
+
+func TestReminder_OrganizerMessage(t *testing.T) {
+	event := sampleEvent()
+
+	email, err := Reminder(ReminderData{Locale: i18n.English, Name: "Amina", Subject: "Reminder", Event: event, Message: "Line <one>\nLine two", Link: "https://example.com/t"})
+	require.NoError(t, err)
+	assert.Contains(t, email.HTML, "Line &lt;one&gt;<br>")
+	assert.Contains(t, email.Text, "A message from the organizer:\nLine <one>\nLine two")
+
+	email, err = Reminder(ReminderData{Locale: i18n.English, Name: "Amina", Subject: "Reminder", Event: event, Link: "https://example.com/t"})
+	require.NoError(t, err)
+	assert.NotContains(t, email.HTML, "A message from the organizer:")
+	assert.NotContains(t, email.Text, "A message from the organizer:")
+}
+
+func TestReminder_OnlineEvent(t *testing.T) {
+	event := sampleEvent()
+	event.Location = models.OnlineEventLocation
+
+	email, err := Reminder(ReminderData{Locale: i18n.English, Name: "Amina", Subject: "Reminder", Event: event, Link: "https://example.com/t",
+		Access: &models.EventAccess{HasTicket: true, URL: "https://meet.example.com/abc"}})
+	require.NoError(t, err)
+	assert.Contains(t, email.HTML, `href="https://meet.example.com/abc"`)
+	assert.Contains(t, email.Text, "Join the event: https://meet.example.com/abc")
+	assert.NotContains(t, email.Text, "Please bring your tickets")
+
+	revealAt := event.StartDate.Add(-time.Hour)
+	email, err = Reminder(ReminderData{Locale: i18n.English, Name: "Amina", Subject: "Reminder", Event: event, Link: "https://example.com/t",
+		Access: &models.EventAccess{HasTicket: true, RevealAt: revealAt}})
+	require.NoError(t, err)
+	assert.NotContains(t, email.Text, "meet.example.com")
+	assert.Contains(t, email.Text, "The link to join will be on your order page from "+i18n.FormatLongDateTime(i18n.English, revealAt))
+	assert.Contains(t, email.HTML, "The link to join will be on your order page")
+}
+
+func TestTextHeading(t *testing.T) {
+	assert.Equal(t, "ÉTAPES\n======", TextHeading("Étapes"))
+}
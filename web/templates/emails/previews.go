@@ -0,0 +1,97 @@
+package emails
+
+import (
+	"fmt"
+	"time"
+
+	"event-ticketing-platform/internal/i18n"
+	"event-ticketing-platform/internal/models"
+)
+
+// Preview names an email that can be previewed with sample data
+type Preview struct {
+	Name  string
+	Label string
+}
+
+// Previews lists the emails that can be previewed, in display order
+var Previews = []Preview{
+	{Name: "verify", Label: "Email verification"},
+	{Name: "order_confirmation", Label: "Order confirmation"},
+	{Name: "refund", Label: "Order refund"},
+	{Name: "reminder", Label: "Event reminder"},
+}
+
+// RenderPreview renders the named email in the given language with sample
+// data, so admins can check the templates without sending anything
+func RenderPreview(name, locale string) (*Email, error) {
+	order := sampleOrder()
+
+	switch name {
+	case "verify":
+		return Verify(VerifyData{
+			Locale: locale,
+			Name:   "Amina Otieno",
+			Link:   "https://runtown.onrender.com/auth/verify?token=preview",
+		})
+	case "order_confirmation":
+		return OrderConfirmation(OrderConfirmationData{
+			Locale:   locale,
+			Name:     "Amina Otieno",
+			Order:    order,
+			Tickets:  sampleTickets(order),
+			OrderURL: fmt.Sprintf("https://runtown.onrender.com/dashboard/orders/%d", order.ID),
+		})
+	case "refund":
+		order.Status = models.OrderRefunded
+		return Refund(RefundData{Locale: locale, Name: "Amina Otieno", Order: order})
+	case "reminder":
+		event := sampleEvent()
+		return Reminder(ReminderData{
+			Locale:  locale,
+			Name:    "Amina Otieno",
+			Subject: i18n.T(locale, "reminder.subject", event.Title, i18n.T(locale, "reminder.lead.24h")),
+			Event:   event,
+			Message: "Gates open at 5pm.\nBring a jacket, it gets cold after sunset.",
+			Link:    "https://runtown.onrender.com/dashboard/tickets",
+		})
+	}
+	return nil, fmt.Errorf("unknown email template %q", name)
+}
+
+func sampleOrder() *models.Order {
+	return &models.Order{
+		ID:           1042,
+		OrderNumber:  "ORD-20250804-1042",
+		UserID:       7,
+		EventID:      12,
+		TotalAmount:  150000,
+		Status:       models.OrderCompleted,
+		BillingEmail: "amina@example.com",
+		BillingName:  "Amina Otieno",
+		CreatedAt:    time.Date(2025, time.August, 4, 18, 30, 0, 0, time.UTC),
+	}
+}
+
+func sampleTickets(order *models.Order) []*models.Ticket {
+	tickets := make([]*models.Ticket, 2)
+	for i := range tickets {
+		tickets[i] = &models.Ticket{
+			ID:           500 + i,
+			OrderID:      order.ID,
+			TicketTypeID: 3,
+			QRCode:       fmt.Sprintf("PREVIEW-%d", i+1),
+			Status:       models.TicketActive,
+		}
+	}
+	return tickets
+}
+
+func sampleEvent() *models.Event {
+	return &models.Event{
+		ID:        12,
+		Title:     "Nairobi Jazz Festival",
+		StartDate: time.Date(2025, time.August, 16, 17, 0, 0, 0, time.UTC),
+		Location:  "Carnivore Grounds, Nairobi",
+	}
+}
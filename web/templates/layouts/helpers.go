@@ -0,0 +1,14 @@
+package layouts
+
+import (
+	"context"
+
+	"event-ticketing-platform/internal/assets"
+)
+
+// assetURL returns the URL of a static file, its content-hashed name when the
+// request has an asset manifest
+func assetURL(ctx context.Context, name string) string {
+	manifest, _ := ctx.Value("assets").(*assets.Manifest)
+	return manifest.URL(name)
+}
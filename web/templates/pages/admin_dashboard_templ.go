@@ -100,14 +100,14 @@ func AdminDashboard(user *models.User, stats map[string]interface{}) templ.Compo
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "</p></div></div></div></div><!-- Quick Actions --><div class=\"grid grid-cols-1 lg:grid-cols-2 gap-6 mb-8\"><!-- User Management --><div class=\"bg-white rounded-lg shadow-sm border border-gray-200 p-6\"><h3 class=\"text-lg font-medium text-gray-900 mb-4\">User Management</h3><p class=\"text-gray-600 mb-4\">Manage user accounts, roles, and permissions</p><a href=\"/admin/users\" class=\"inline-flex items-center px-4 py-2 border border-transparent text-sm font-medium rounded-md text-white bg-blue-600 hover:bg-blue-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-blue-500\">Manage Users <svg class=\"ml-2 -mr-1 w-4 h-4\" fill=\"currentColor\" viewBox=\"0 0 20 20\"><path fill-rule=\"evenodd\" d=\"M10.293 3.293a1 1 0 011.414 0l6 6a1 1 0 010 1.414l-6 6a1 1 0 01-1.414-1.414L14.586 11H3a1 1 0 110-2h11.586l-4.293-4.293a1 1 0 010-1.414z\" clip-rule=\"evenodd\"></path></svg></a></div><!-- Category Management --><div class=\"bg-white rounded-lg shadow-sm border border-gray-200 p-6\"><h3 class=\"text-lg font-medium text-gray-900 mb-4\">Category Management</h3><p class=\"text-gray-600 mb-4\">Manage event categories and classifications</p><a href=\"/admin/categories\" class=\"inline-flex items-center px-4 py-2 border border-transparent text-sm font-medium rounded-md text-white bg-purple-600 hover:bg-purple-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-purple-500\">Manage Categories <svg class=\"ml-2 -mr-1 w-4 h-4\" fill=\"currentColor\" viewBox=\"0 0 20 20\"><path fill-rule=\"evenodd\" d=\"M10.293 3.293a1 1 0 011.414 0l6 6a1 1 0 010 1.414l-6 6a1 1 0 01-1.414-1.414L14.586 11H3a1 1 0 110-2h11.586l-4.293-4.293a1 1 0 010-1.414z\" clip-rule=\"evenodd\"></path></svg></a></div></div><!-- Second Row --><div class=\"grid grid-cols-1 lg:grid-cols-2 gap-6 mb-8\"><!-- Withdrawal Management --><div class=\"bg-white rounded-lg shadow-sm border border-gray-200 p-6\"><h3 class=\"text-lg font-medium text-gray-900 mb-4\">Withdrawal Management</h3><p class=\"text-gray-600 mb-4\">Review and process organizer withdrawal requests</p><a href=\"/admin/withdrawals\" class=\"inline-flex items-center px-4 py-2 border border-transparent text-sm font-medium rounded-md text-white bg-green-600 hover:bg-green-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-green-500\">Manage Withdrawals <svg class=\"ml-2 -mr-1 w-4 h-4\" fill=\"currentColor\" viewBox=\"0 0 20 20\"><path fill-rule=\"evenodd\" d=\"M10.293 3.293a1 1 0 011.414 0l6 6a1 1 0 010 1.414l-6 6a1 1 0 01-1.414-1.414L14.586 11H3a1 1 0 110-2h11.586l-4.293-4.293a1 1 0 010-1.414z\" clip-rule=\"evenodd\"></path></svg></a></div><!-- Event Moderation --><div class=\"bg-white rounded-lg shadow-sm border border-gray-200 p-6\"><h3 class=\"text-lg font-medium text-gray-900 mb-4\">Event Moderation</h3><p class=\"text-gray-600 mb-4\">Review and moderate event submissions</p><a href=\"/admin/events/moderate\" class=\"inline-flex items-center px-4 py-2 border border-transparent text-sm font-medium rounded-md text-white bg-orange-600 hover:bg-orange-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-orange-500\">Moderate Events <svg class=\"ml-2 -mr-1 w-4 h-4\" fill=\"currentColor\" viewBox=\"0 0 20 20\"><path fill-rule=\"evenodd\" d=\"M10.293 3.293a1 1 0 011.414 0l6 6a1 1 0 010 1.414l-6 6a1 1 0 01-1.414-1.414L14.586 11H3a1 1 0 110-2h11.586l-4.293-4.293a1 1 0 010-1.414z\" clip-rule=\"evenodd\"></path></svg></a></div></div><!-- Third Row --><div class=\"grid grid-cols-1 lg:grid-cols-2 gap-6 mb-8\"><!-- System Settings --><div class=\"bg-white rounded-lg shadow-sm border border-gray-200 p-6\"><h3 class=\"text-lg font-medium text-gray-900 mb-4\">System Settings</h3><p class=\"text-gray-600 mb-4\">Configure platform fees, withdrawal limits, and moderation settings</p><a href=\"/admin/settings\" class=\"inline-flex items-center px-4 py-2 border border-transparent text-sm font-medium rounded-md text-white bg-indigo-600 hover:bg-indigo-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-indigo-500\">System Settings <svg class=\"ml-2 -mr-1 w-4 h-4\" fill=\"currentColor\" viewBox=\"0 0 20 20\"><path fill-rule=\"evenodd\" d=\"M10.293 3.293a1 1 0 011.414 0l6 6a1 1 0 010 1.414l-6 6a1 1 0 01-1.414-1.414L14.586 11H3a1 1 0 110-2h11.586l-4.293-4.293a1 1 0 010-1.414z\" clip-rule=\"evenodd\"></path></svg></a></div><!-- Audit Logs --><div class=\"bg-white rounded-lg shadow-sm border border-gray-200 p-6\"><h3 class=\"text-lg font-medium text-gray-900 mb-4\">Audit Logs</h3><p class=\"text-gray-600 mb-4\">View administrative action logs</p><button class=\"inline-flex items-center px-4 py-2 border border-transparent text-sm font-medium rounded-md text-white bg-gray-600 hover:bg-gray-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-gray-500\" disabled>Coming Soon <svg class=\"ml-2 -mr-1 w-4 h-4\" fill=\"currentColor\" viewBox=\"0 0 20 20\"><path fill-rule=\"evenodd\" d=\"M10.293 3.293a1 1 0 011.414 0l6 6a1 1 0 010 1.414l-6 6a1 1 0 01-1.414-1.414L14.586 11H3a1 1 0 110-2h11.586l-4.293-4.293a1 1 0 010-1.414z\" clip-rule=\"evenodd\"></path></svg></button></div></div><!-- Recent Activity --><div class=\"bg-white rounded-lg shadow-sm border border-gray-200\"><div class=\"px-6 py-4 border-b border-gray-200\"><h3 class=\"text-lg font-medium text-gray-900\">System Statistics</h3></div><div class=\"p-6\"><div class=\"grid grid-cols-1 md:grid-cols-3 gap-6\"><div class=\"text-center\"><p class=\"text-2xl font-semibold text-gray-900\">")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 5, "</p></div></div></div></div><!-- Quick Actions --><div class=\"grid grid-cols-1 lg:grid-cols-2 gap-6 mb-8\"><!-- User Management --><div class=\"bg-white rounded-lg shadow-sm border border-gray-200 p-6\"><h3 class=\"text-lg font-medium text-gray-900 mb-4\">User Management</h3><p class=\"text-gray-600 mb-4\">Manage user accounts, roles, and permissions</p><a href=\"/admin/users\" class=\"inline-flex items-center px-4 py-2 border border-transparent text-sm font-medium rounded-md text-white bg-blue-600 hover:bg-blue-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-blue-500\">Manage Users <svg class=\"ml-2 -mr-1 w-4 h-4\" fill=\"currentColor\" viewBox=\"0 0 20 20\"><path fill-rule=\"evenodd\" d=\"M10.293 3.293a1 1 0 011.414 0l6 6a1 1 0 010 1.414l-6 6a1 1 0 01-1.414-1.414L14.586 11H3a1 1 0 110-2h11.586l-4.293-4.293a1 1 0 010-1.414z\" clip-rule=\"evenodd\"></path></svg></a></div><!-- Category Management --><div class=\"bg-white rounded-lg shadow-sm border border-gray-200 p-6\"><h3 class=\"text-lg font-medium text-gray-900 mb-4\">Category Management</h3><p class=\"text-gray-600 mb-4\">Manage event categories and classifications</p><a href=\"/admin/categories\" class=\"inline-flex items-center px-4 py-2 border border-transparent text-sm font-medium rounded-md text-white bg-purple-600 hover:bg-purple-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-purple-500\">Manage Categories <svg class=\"ml-2 -mr-1 w-4 h-4\" fill=\"currentColor\" viewBox=\"0 0 20 20\"><path fill-rule=\"evenodd\" d=\"M10.293 3.293a1 1 0 011.414 0l6 6a1 1 0 010 1.414l-6 6a1 1 0 01-1.414-1.414L14.586 11H3a1 1 0 110-2h11.586l-4.293-4.293a1 1 0 010-1.414z\" clip-rule=\"evenodd\"></path></svg></a></div></div><!-- Second Row --><div class=\"grid grid-cols-1 lg:grid-cols-2 gap-6 mb-8\"><!-- Withdrawal Management --><div class=\"bg-white rounded-lg shadow-sm border border-gray-200 p-6\"><h3 class=\"text-lg font-medium text-gray-900 mb-4\">Withdrawal Management</h3><p class=\"text-gray-600 mb-4\">Review and process organizer withdrawal requests</p><a href=\"/admin/withdrawals\" class=\"inline-flex items-center px-4 py-2 border border-transparent text-sm font-medium rounded-md text-white bg-green-600 hover:bg-green-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-green-500\">Manage Withdrawals <svg class=\"ml-2 -mr-1 w-4 h-4\" fill=\"currentColor\" viewBox=\"0 0 20 20\"><path fill-rule=\"evenodd\" d=\"M10.293 3.293a1 1 0 011.414 0l6 6a1 1 0 010 1.414l-6 6a1 1 0 01-1.414-1.414L14.586 11H3a1 1 0 110-2h11.586l-4.293-4.293a1 1 0 010-1.414z\" clip-rule=\"evenodd\"></path></svg></a></div><!-- Event Moderation --><div class=\"bg-white rounded-lg shadow-sm border border-gray-200 p-6\"><h3 class=\"text-lg font-medium text-gray-900 mb-4\">Event Moderation</h3><p class=\"text-gray-600 mb-4\">Review and moderate event submissions</p><a href=\"/admin/events/moderate\" class=\"inline-flex items-center px-4 py-2 border border-transparent text-sm font-medium rounded-md text-white bg-orange-600 hover:bg-orange-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-orange-500\">Moderate Events <svg class=\"ml-2 -mr-1 w-4 h-4\" fill=\"currentColor\" viewBox=\"0 0 20 20\"><path fill-rule=\"evenodd\" d=\"M10.293 3.293a1 1 0 011.414 0l6 6a1 1 0 010 1.414l-6 6a1 1 0 01-1.414-1.414L14.586 11H3a1 1 0 110-2h11.586l-4.293-4.293a1 1 0 010-1.414z\" clip-rule=\"evenodd\"></path></svg></a></div></div><!-- Third Row --><div class=\"grid grid-cols-1 lg:grid-cols-2 gap-6 mb-8\"><!-- System Settings --><div class=\"bg-white rounded-lg shadow-sm border border-gray-200 p-6\"><h3 class=\"text-lg font-medium text-gray-900 mb-4\">System Settings</h3><p class=\"text-gray-600 mb-4\">Configure platform fees, withdrawal limits, and moderation settings</p><a href=\"/admin/settings\" class=\"inline-flex items-center px-4 py-2 border border-transparent text-sm font-medium rounded-md text-white bg-indigo-600 hover:bg-indigo-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-indigo-500\">System Settings <svg class=\"ml-2 -mr-1 w-4 h-4\" fill=\"currentColor\" viewBox=\"0 0 20 20\"><path fill-rule=\"evenodd\" d=\"M10.293 3.293a1 1 0 011.414 0l6 6a1 1 0 010 1.414l-6 6a1 1 0 01-1.414-1.414L14.586 11H3a1 1 0 110-2h11.586l-4.293-4.293a1 1 0 010-1.414z\" clip-rule=\"evenodd\"></path></svg></a></div><!-- Audit Logs --><div class=\"bg-white rounded-lg shadow-sm border border-gray-200 p-6\"><h3 class=\"text-lg font-medium text-gray-900 mb-4\">Audit Logs</h3><p class=\"text-gray-600 mb-4\">Search and export who changed roles, settings, withdrawals and refunds, and unusual sign-ins</p><a href=\"/admin/audit\" class=\"inline-flex items-center px-4 py-2 border border-transparent text-sm font-medium rounded-md text-white bg-gray-600 hover:bg-gray-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-gray-500\">View Audit Log <svg class=\"ml-2 -mr-1 w-4 h-4\" fill=\"currentColor\" viewBox=\"0 0 20 20\"><path fill-rule=\"evenodd\" d=\"M10.293 3.293a1 1 0 011.414 0l6 6a1 1 0 010 1.414l-6 6a1 1 0 01-1.414-1.414L14.586 11H3a1 1 0 110-2h11.586l-4.293-4.293a1 1 0 010-1.414z\" clip-rule=\"evenodd\"></path></svg></a></div></div><!-- Fourth Row --><div class=\"grid grid-cols-1 lg:grid-cols-2 gap-6 mb-8\"><!-- Data Quality --><div class=\"bg-white rounded-lg shadow-sm border border-gray-200 p-6\"><h3 class=\"text-lg font-medium text-gray-900 mb-4\">Data Quality</h3><p class=\"text-gray-600 mb-4\">Find and repair inconsistent events, orders, tickets and images</p><a href=\"/admin/data-quality\" class=\"inline-flex items-center px-4 py-2 border border-transparent text-sm font-medium rounded-md text-white bg-red-600 hover:bg-red-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-red-500\">Data Quality <svg class=\"ml-2 -mr-1 w-4 h-4\" fill=\"currentColor\" viewBox=\"0 0 20 20\"><path fill-rule=\"evenodd\" d=\"M10.293 3.293a1 1 0 011.414 0l6 6a1 1 0 010 1.414l-6 6a1 1 0 01-1.414-1.414L14.586 11H3a1 1 0 110-2h11.586l-4.293-4.293a1 1 0 010-1.414z\" clip-rule=\"evenodd\"></path></svg></a></div><!-- Linked Accounts --><div class=\"bg-white rounded-lg shadow-sm border border-gray-200 p-6\"><h3 class=\"text-lg font-medium text-gray-900 mb-4\">Linked Accounts</h3><p class=\"text-gray-600 mb-4\">Spot organizers sharing payout details, browsers or IP addresses with suspended accounts</p><a href=\"/admin/fraud/linkage\" class=\"inline-flex items-center px-4 py-2 border border-transparent text-sm font-medium rounded-md text-white bg-red-600 hover:bg-red-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-red-500\">Linked Accounts <svg class=\"ml-2 -mr-1 w-4 h-4\" fill=\"currentColor\" viewBox=\"0 0 20 20\"><path fill-rule=\"evenodd\" d=\"M10.293 3.293a1 1 0 011.414 0l6 6a1 1 0 010 1.414l-6 6a1 1 0 01-1.414-1.414L14.586 11H3a1 1 0 110-2h11.586l-4.293-4.293a1 1 0 010-1.414z\" clip-rule=\"evenodd\"></path></svg></a></div></div><!-- Fifth Row --><div class=\"grid grid-cols-1 lg:grid-cols-2 gap-6 mb-8\"><!-- Platform Reports --><div class=\"bg-white rounded-lg shadow-sm border border-gray-200 p-6\"><h3 class=\"text-lg font-medium text-gray-900 mb-4\">Platform Reports</h3><p class=\"text-gray-600 mb-4\">GMV, fees, refunds, growth and top events over any date range</p><a href=\"/admin/reports\" class=\"inline-flex items-center px-4 py-2 border border-transparent text-sm font-medium rounded-md text-white bg-indigo-600 hover:bg-indigo-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-indigo-500\">View Reports <svg class=\"ml-2 -mr-1 w-4 h-4\" fill=\"currentColor\" viewBox=\"0 0 20 20\"><path fill-rule=\"evenodd\" d=\"M10.293 3.293a1 1 0 011.414 0l6 6a1 1 0 010 1.414l-6 6a1 1 0 01-1.414-1.414L14.586 11H3a1 1 0 110-2h11.586l-4.293-4.293a1 1 0 010-1.414z\" clip-rule=\"evenodd\"></path></svg></a></div><!-- Payment Reconciliation --><div class=\"bg-white rounded-lg shadow-sm border border-gray-200 p-6\"><h3 class=\"text-lg font-medium text-gray-900 mb-4\">Payment Reconciliation</h3><p class=\"text-gray-600 mb-4\">Follow up payments the provider and orders disagree about, like buyers who paid without getting tickets</p><a href=\"/admin/payments/reconciliation\" class=\"inline-flex items-center px-4 py-2 border border-transparent text-sm font-medium rounded-md text-white bg-indigo-600 hover:bg-indigo-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-indigo-500\">Reconcile Payments <svg class=\"ml-2 -mr-1 w-4 h-4\" fill=\"currentColor\" viewBox=\"0 0 20 20\"><path fill-rule=\"evenodd\" d=\"M10.293 3.293a1 1 0 011.414 0l6 6a1 1 0 010 1.414l-6 6a1 1 0 01-1.414-1.414L14.586 11H3a1 1 0 110-2h11.586l-4.293-4.293a1 1 0 010-1.414z\" clip-rule=\"evenodd\"></path></svg></a></div><!-- Disputes --><div class=\"bg-white rounded-lg shadow-sm border border-gray-200 p-6\"><h3 class=\"text-lg font-medium text-gray-900 mb-4\">Disputes</h3><p class=\"text-gray-600 mb-4\">Track chargebacks buyers raised with their card issuers, the evidence organizers submitted and their outcomes</p><a href=\"/admin/disputes\" class=\"inline-flex items-center px-4 py-2 border border-transparent text-sm font-medium rounded-md text-white bg-indigo-600 hover:bg-indigo-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-indigo-500\">View Disputes <svg class=\"ml-2 -mr-1 w-4 h-4\" fill=\"currentColor\" viewBox=\"0 0 20 20\"><path fill-rule=\"evenodd\" d=\"M10.293 3.293a1 1 0 011.414 0l6 6a1 1 0 010 1.414l-6 6a1 1 0 01-1.414-1.414L14.586 11H3a1 1 0 110-2h11.586l-4.293-4.293a1 1 0 010-1.414z\" clip-rule=\"evenodd\"></path></svg></a></div><!-- Events --><div class=\"bg-white rounded-lg shadow-sm border border-gray-200 p-6\"><h3 class=\"text-lg font-medium text-gray-900 mb-4\">Events</h3><p class=\"text-gray-600 mb-4\">Browse every organizer's events and unpublish or recategorize many at once</p><a href=\"/admin/events\" class=\"inline-flex items-center px-4 py-2 border border-transparent text-sm font-medium rounded-md text-white bg-indigo-600 hover:bg-indigo-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-indigo-500\">View Events <svg class=\"ml-2 -mr-1 w-4 h-4\" fill=\"currentColor\" viewBox=\"0 0 20 20\"><path fill-rule=\"evenodd\" d=\"M10.293 3.293a1 1 0 011.414 0l6 6a1 1 0 010 1.414l-6 6a1 1 0 01-1.414-1.414L14.586 11H3a1 1 0 110-2h11.586l-4.293-4.293a1 1 0 010-1.414z\" clip-rule=\"evenodd\"></path></svg></a></div><!-- Announcements --><div class=\"bg-white rounded-lg shadow-sm border border-gray-200 p-6\"><h3 class=\"text-lg font-medium text-gray-900 mb-4\">Announcements</h3><p class=\"text-gray-600 mb-4\">Schedule site-wide banners for everyone or just attendees, organizers or admins</p><a href=\"/admin/announcements\" class=\"inline-flex items-center px-4 py-2 border border-transparent text-sm font-medium rounded-md text-white bg-indigo-600 hover:bg-indigo-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-indigo-500\">Manage Announcements <svg class=\"ml-2 -mr-1 w-4 h-4\" fill=\"currentColor\" viewBox=\"0 0 20 20\"><path fill-rule=\"evenodd\" d=\"M10.293 3.293a1 1 0 011.414 0l6 6a1 1 0 010 1.414l-6 6a1 1 0 01-1.414-1.414L14.586 11H3a1 1 0 110-2h11.586l-4.293-4.293a1 1 0 010-1.414z\" clip-rule=\"evenodd\"></path></svg></a></div><!-- Help Center --><div class=\"bg-white rounded-lg shadow-sm border border-gray-200 p-6\"><h3 class=\"text-lg font-medium text-gray-900 mb-4\">Help Center</h3><p class=\"text-gray-600 mb-4\">Write and publish the help articles buyers and organizers search and dashboards link to</p><a href=\"/admin/help\" class=\"inline-flex items-center px-4 py-2 border border-transparent text-sm font-medium rounded-md text-white bg-indigo-600 hover:bg-indigo-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-indigo-500\">Manage Articles <svg class=\"ml-2 -mr-1 w-4 h-4\" fill=\"currentColor\" viewBox=\"0 0 20 20\"><path fill-rule=\"evenodd\" d=\"M10.293 3.293a1 1 0 011.414 0l6 6a1 1 0 010 1.414l-6 6a1 1 0 01-1.414-1.414L14.586 11H3a1 1 0 110-2h11.586l-4.293-4.293a1 1 0 010-1.414z\" clip-rule=\"evenodd\"></path></svg></a></div><!-- Trash --><div class=\"bg-white rounded-lg shadow-sm border border-gray-200 p-6\"><h3 class=\"text-lg font-medium text-gray-900 mb-4\">Trash</h3><p class=\"text-gray-600 mb-4\">Restore deleted users, events and ticket types before the retention job purges them</p><a href=\"/admin/trash\" class=\"inline-flex items-center px-4 py-2 border border-transparent text-sm font-medium rounded-md text-white bg-gray-600 hover:bg-gray-700 focus:outline-none focus:ring-2 focus:ring-offset-2 focus:ring-gray-500\">View Trash <svg class=\"ml-2 -mr-1 w-4 h-4\" fill=\"currentColor\" viewBox=\"0 0 20 20\"><path fill-rule=\"evenodd\" d=\"M10.293 3.293a1 1 0 011.414 0l6 6a1 1 0 010 1.414l-6 6a1 1 0 01-1.414-1.414L14.586 11H3a1 1 0 110-2h11.586l-4.293-4.293a1 1 0 010-1.414z\" clip-rule=\"evenodd\"></path></svg></a></div></div><!-- Recent Activity --><div class=\"bg-white rounded-lg shadow-sm border border-gray-200\"><div class=\"px-6 py-4 border-b border-gray-200\"><h3 class=\"text-lg font-medium text-gray-900\">System Statistics</h3></div><div class=\"p-6\"><div class=\"grid grid-cols-1 md:grid-cols-3 gap-6\"><div class=\"text-center\"><p class=\"text-2xl font-semibold text-gray-900\">")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			var templ_7745c5c3_Var7 string
 			templ_7745c5c3_Var7, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", stats["PublishedEvents"]))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `web/templates/pages/admin_dashboard.templ`, Line: 172, Col: 101}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `web/templates/pages/admin_dashboard.templ`, Line: 286, Col: 101}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var7))
 			if templ_7745c5c3_Err != nil {
@@ -120,7 +120,7 @@ func AdminDashboard(user *models.User, stats map[string]interface{}) templ.Compo
 			var templ_7745c5c3_Var8 string
 			templ_7745c5c3_Var8, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%d", stats["TotalOrders"]))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `web/templates/pages/admin_dashboard.templ`, Line: 176, Col: 97}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `web/templates/pages/admin_dashboard.templ`, Line: 290, Col: 97}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var8))
 			if templ_7745c5c3_Err != nil {
@@ -133,7 +133,7 @@ func AdminDashboard(user *models.User, stats map[string]interface{}) templ.Compo
 			var templ_7745c5c3_Var9 string
 			templ_7745c5c3_Var9, templ_7745c5c3_Err = templ.JoinStringErrs(fmt.Sprintf("%.1f%%", float64(stats["ActiveUsers"].(int))/float64(stats["TotalUsers"].(int))*100))
 			if templ_7745c5c3_Err != nil {
-				return templ.Error{Err: templ_7745c5c3_Err, FileName: `web/templates/pages/admin_dashboard.templ`, Line: 180, Col: 159}
+				return templ.Error{Err: templ_7745c5c3_Err, FileName: `web/templates/pages/admin_dashboard.templ`, Line: 294, Col: 159}
 			}
 			_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var9))
 			if templ_7745c5c3_Err != nil {
@@ -66,7 +66,7 @@ func EventsListPage(user *models.User, events []*models.Event, categories []*mod
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
-			templ_7745c5c3_Err = EventsList(events, pagination).Render(ctx, templ_7745c5c3_Buffer)
+			templ_7745c5c3_Err = EventResults(events, filters, pagination).Render(ctx, templ_7745c5c3_Buffer)
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
@@ -640,6 +640,8 @@ type EventFilters struct {
 	Location string
 	DateFrom string
 	DateTo   string
+	View     string           // "map" shows results on a map
+	Near     *models.GeoPoint // Set for "near me" searches
 }
 
 var _ = templruntime.GeneratedTemplate
@@ -3,8 +3,12 @@ package pages
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"strconv"
+	"time"
+
 	"event-ticketing-platform/internal/models"
+	"event-ticketing-platform/internal/services"
 )
 
 func getFormValue(formData map[string]string, key, defaultValue string) string {
@@ -29,15 +33,53 @@ func getStringValue(formData map[string]interface{}, key string) string {
 	return ""
 }
 
+// getLocationVenue returns the venue part of the form's location
+func getLocationVenue(formData map[string]interface{}) string {
+	venue, _ := models.SplitLocation(getPlaceLocation(formData))
+	return venue
+}
+
+// getLocationCity returns the city part of the form's location
+func getLocationCity(formData map[string]interface{}) string {
+	_, city := models.SplitLocation(getPlaceLocation(formData))
+	return city
+}
+
+// getPlaceLocation returns the form's location, or nothing for online events
+func getPlaceLocation(formData map[string]interface{}) string {
+	location := getStringValue(formData, "location")
+	if location == models.OnlineEventLocation {
+		return ""
+	}
+	return location
+}
+
+// accessRevealOptions are the choices of when ticket holders see an online
+// event's link
+var accessRevealOptions = []struct{ value, label string }{
+	{"0", "As soon as they buy a ticket"},
+	{"1440", "A day before the event starts"},
+	{"60", "An hour before the event starts"},
+	{"15", "15 minutes before the event starts"},
+}
+
+// isOnlineEventForm returns true if the event form is for an online event
+func isOnlineEventForm(formData map[string]interface{}) bool {
+	return getStringValue(formData, "event_type") == "online"
+}
+
 // getFormDataFromEvent converts an event to form data for editing
 func getFormDataFromEvent(event *models.Event, formData map[string]interface{}) map[string]interface{} {
+	hasImage := strconv.FormatBool(event != nil && event.ImageURL != "")
+
 	// If formData is provided (from form submission), use it (for validation errors)
 	if formData != nil && len(formData) > 0 {
+		formData["has_image"] = hasImage
 		return formData
 	}
 	
 	// Otherwise, populate from event data
-	return map[string]interface{}{
+	data := map[string]interface{}{
 		"title":       event.Title,
 		"description": event.Description,
 		"location":    event.Location,
@@ -45,7 +87,29 @@ func getFormDataFromEvent(event *models.Event, formData map[string]interface{})
 		"end_date":    event.EndDate.Format("2006-01-02T15:04"),
 		"category_id": strconv.Itoa(event.CategoryID),
 		"status":      string(event.Status),
+		"image_alt_text": event.ImageAltText,
+		"has_image":   hasImage,
+	}
+	if event.Venue != nil {
+		data["venue_id"] = strconv.Itoa(event.Venue.ID)
+	}
+	if event.IsOnline() {
+		data["event_type"] = "online"
+		if event.AccessLink != nil {
+			data["access_url"] = event.AccessLink.URL
+			data["reveal_minutes_before"] = strconv.Itoa(event.AccessLink.RevealMinutesBefore)
+		}
 	}
+	return data
+}
+
+// getAccessibilityReport returns the accessibility check results stored in form data
+func getAccessibilityReport(formData map[string]interface{}) *services.AccessibilityReport {
+	if formData == nil {
+		return nil
+	}
+	report, _ := formData["accessibility"].(*services.AccessibilityReport)
+	return report
 }
 
 // getFormDataFromTicketType converts a ticket type to form data for editing
@@ -56,7 +120,7 @@ func getFormDataFromTicketType(ticketType *models.TicketType, formData map[strin
 	}
 	
 	// Otherwise, populate from ticket type data
-	return map[string]interface{}{
+	data := map[string]interface{}{
 		"name":        ticketType.Name,
 		"description": ticketType.Description,
 		"price":       fmt.Sprintf("%.2f", ticketType.PriceInDollars()),
@@ -64,6 +128,10 @@ func getFormDataFromTicketType(ticketType *models.TicketType, formData map[strin
 		"sale_start":  ticketType.SaleStart.Format("2006-01-02T15:04"),
 		"sale_end":    ticketType.SaleEnd.Format("2006-01-02T15:04"),
 	}
+	if ticketType.PayWhatYouWant {
+		data["pay_what_you_want"] = "on"
+	}
+	return data
 }
 
 // getCSRFToken gets the CSRF token from the request context
@@ -72,4 +140,103 @@ func getCSRFToken(ctx context.Context) string {
 		return token
 	}
 	return ""
-}
\ No newline at end of file
+}
+
+// formatBytes formats a byte count for display, e.g. "1.5 MB"
+func formatBytes(bytes int64) string {
+	const unit = 1024
+	if bytes < unit {
+		return fmt.Sprintf("%d B", bytes)
+	}
+	div, exp := int64(unit), 0
+	for n := bytes / unit; n >= unit; n /= unit {
+		div *= unit
+		exp++
+	}
+	return fmt.Sprintf("%.1f %cB", float64(bytes)/float64(div), "KMGTPE"[exp])
+}
+
+// getSnippet gets an admin-editable content snippet from the request context,
+// falling back to its default
+func getSnippet(ctx context.Context, key models.SnippetKey) string {
+	if snippets, ok := ctx.Value("snippets").(map[models.SnippetKey]string); ok {
+		if content, ok := snippets[key]; ok {
+			return content
+		}
+	}
+	definition, _ := models.LookupSnippet(key)
+	return definition.Default
+}
+
+// barHeight returns the style of a chart bar for value on a scale up to max
+func barHeight(value, max float64) string {
+	if max <= 0 || value <= 0 {
+		return "height: 0%"
+	}
+	return fmt.Sprintf("height: %.1f%%", value/max*100)
+}
+
+// maxDailyRevenue returns the highest daily revenue, the scale of a sales chart
+func maxDailyRevenue(series []*services.DailySales) float64 {
+	max := 0.0
+	for _, day := range series {
+		if day.Revenue > max {
+			max = day.Revenue
+		}
+	}
+	return max
+}
+
+// maxMonthlyRevenue returns the highest monthly revenue, the scale of a revenue chart
+func maxMonthlyRevenue(months []*services.MonthlyRevenue) float64 {
+	max := 0.0
+	for _, month := range months {
+		if month.Revenue > max {
+			max = month.Revenue
+		}
+	}
+	return max
+}
+
+// funnelStepLabel returns the display name of a sales funnel step
+func funnelStepLabel(step string) string {
+	switch step {
+	case services.FunnelStepViews:
+		return "Page views"
+	case services.FunnelStepAddToCart:
+		return "Added to cart"
+	case services.FunnelStepCheckout:
+		return "Started checkout"
+	case services.FunnelStepPaid:
+		return "Paid"
+	}
+	return step
+}
+
+// lockoutRemaining describes how long until a locked account can sign in
+// again, e.g. "12 minutes", given the lock's expiry in RFC 3339 format
+func lockoutRemaining(lockedUntil string) string {
+	until, err := time.Parse(time.RFC3339, lockedUntil)
+	if err != nil {
+		return "a few minutes"
+	}
+	minutes := int(time.Until(until).Round(time.Minute).Minutes())
+	switch {
+	case minutes < 1:
+		return "less than a minute"
+	case minutes == 1:
+		return "1 minute"
+	}
+	return fmt.Sprintf("%d minutes", minutes)
+}
+
+// reportExportURL returns the CSV export URL of a platform report's range
+func reportExportURL(r services.ReportRange) string {
+	query := url.Values{}
+	query.Set("range", r.Preset)
+	if r.Preset == services.ReportPresetCustom {
+		query.Set("from", r.From.Format("2006-01-02"))
+		query.Set("to", r.LastDay().Format("2006-01-02"))
+	}
+	return "/admin/reports/export?" + query.Encode()
+}
@@ -8,11 +8,12 @@ package pages
 import "github.com/a-h/templ"
 import templruntime "github.com/a-h/templ/runtime"
 
+import "event-ticketing-platform/internal/i18n"
 import "event-ticketing-platform/internal/models"
 import "event-ticketing-platform/internal/services"
 import "event-ticketing-platform/web/templates/layouts"
 
-func SettingsPage(user *models.User, preferences *services.UserPreferences, errors map[string][]string, success bool) templ.Component {
+func SettingsPage(user *models.User, preferences *services.UserPreferences, locale string, errors map[string][]string, success bool) templ.Component {
 	return templruntime.GeneratedTemplate(func(templ_7745c5c3_Input templruntime.GeneratedComponentInput) (templ_7745c5c3_Err error) {
 		templ_7745c5c3_W, ctx := templ_7745c5c3_Input.Writer, templ_7745c5c3_Input.Context
 		if templ_7745c5c3_CtxErr := ctx.Err(); templ_7745c5c3_CtxErr != nil {
@@ -72,7 +73,7 @@ func SettingsPage(user *models.User, preferences *services.UserPreferences, erro
 					var templ_7745c5c3_Var3 string
 					templ_7745c5c3_Var3, templ_7745c5c3_Err = templ.JoinStringErrs(err)
 					if templ_7745c5c3_Err != nil {
-						return templ.Error{Err: templ_7745c5c3_Err, FileName: `web/templates/pages/settings.templ`, Line: 72, Col: 60}
+						return templ.Error{Err: templ_7745c5c3_Err, FileName: `web/templates/pages/settings.templ`, Line: 73, Col: 60}
 					}
 					_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var3))
 					if templ_7745c5c3_Err != nil {
@@ -88,57 +89,126 @@ func SettingsPage(user *models.User, preferences *services.UserPreferences, erro
 					return templ_7745c5c3_Err
 				}
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, "<div class=\"space-y-6\"><!-- Email Notifications --><div class=\"flex items-center justify-between\"><div class=\"flex-1\"><h3 class=\"text-sm font-medium text-gray-900\">Email Notifications</h3><p class=\"text-sm text-gray-500\">Receive important updates and notifications via email</p></div><label class=\"relative inline-flex items-center cursor-pointer\"><input type=\"checkbox\" name=\"email_notifications\" class=\"sr-only peer\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 8, "<div class=\"space-y-6\"><!-- Email Language --><div class=\"flex items-center justify-between\"><div class=\"flex-1\"><h3 class=\"text-sm font-medium text-gray-900\">Email Language</h3><p class=\"text-sm text-gray-500\">Order confirmations, event reminders and refund emails are sent in this language</p>")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			if localeErrors, hasLocale := errors["locale"]; hasLocale {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 9, "<p class=\"mt-1 text-sm text-red-600\">")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				var templ_7745c5c3_Var4 string
+				templ_7745c5c3_Var4, templ_7745c5c3_Err = templ.JoinStringErrs(localeErrors[0])
+				if templ_7745c5c3_Err != nil {
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `web/templates/pages/settings.templ`, Line: 87, Col: 64}
+				}
+				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var4))
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 10, "</p>")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 11, "</div><select name=\"locale\" class=\"text-sm border border-gray-300 rounded-lg px-3 py-1 focus:outline-none focus:ring-2 focus:ring-primary-500\"><option value=\"\">Same as the event</option> ")
+			if templ_7745c5c3_Err != nil {
+				return templ_7745c5c3_Err
+			}
+			for _, option := range i18n.SupportedLocales {
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 12, "<option value=\"")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				var templ_7745c5c3_Var5 string
+				templ_7745c5c3_Var5, templ_7745c5c3_Err = templ.JoinStringErrs(option)
+				if templ_7745c5c3_Err != nil {
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `web/templates/pages/settings.templ`, Line: 94, Col: 25}
+				}
+				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var5))
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 13, "\"")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				if option == locale {
+					templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 14, " selected")
+					if templ_7745c5c3_Err != nil {
+						return templ_7745c5c3_Err
+					}
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 15, ">")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				var templ_7745c5c3_Var6 string
+				templ_7745c5c3_Var6, templ_7745c5c3_Err = templ.JoinStringErrs(i18n.Name(option))
+				if templ_7745c5c3_Err != nil {
+					return templ.Error{Err: templ_7745c5c3_Err, FileName: `web/templates/pages/settings.templ`, Line: 99, Col: 30}
+				}
+				_, templ_7745c5c3_Err = templ_7745c5c3_Buffer.WriteString(templ.EscapeString(templ_7745c5c3_Var6))
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 16, "</option>")
+				if templ_7745c5c3_Err != nil {
+					return templ_7745c5c3_Err
+				}
+			}
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 17, "</select></div><!-- Email Notifications --><div class=\"flex items-center justify-between\"><div class=\"flex-1\"><h3 class=\"text-sm font-medium text-gray-900\">Email Notifications</h3><p class=\"text-sm text-gray-500\">Receive important updates and notifications via email</p></div><label class=\"relative inline-flex items-center cursor-pointer\"><input type=\"checkbox\" name=\"email_notifications\" class=\"sr-only peer\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			if preferences.EmailNotifications {
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 9, " checked")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 18, " checked")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 10, "><div class=\"w-11 h-6 bg-gray-200 peer-focus:outline-none peer-focus:ring-4 peer-focus:ring-primary-300 rounded-full peer peer-checked:after:translate-x-full peer-checked:after:border-white after:content-[''] after:absolute after:top-[2px] after:left-[2px] after:bg-white after:border-gray-300 after:border after:rounded-full after:h-5 after:w-5 after:transition-all peer-checked:bg-primary-600\"></div></label></div><!-- Event Reminders --><div class=\"flex items-center justify-between\"><div class=\"flex-1\"><h3 class=\"text-sm font-medium text-gray-900\">Event Reminders</h3><p class=\"text-sm text-gray-500\">Get reminded about upcoming events you have tickets for</p></div><label class=\"relative inline-flex items-center cursor-pointer\"><input type=\"checkbox\" name=\"event_reminders\" class=\"sr-only peer\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 19, "><div class=\"w-11 h-6 bg-gray-200 peer-focus:outline-none peer-focus:ring-4 peer-focus:ring-primary-300 rounded-full peer peer-checked:after:translate-x-full peer-checked:after:border-white after:content-[''] after:absolute after:top-[2px] after:left-[2px] after:bg-white after:border-gray-300 after:border after:rounded-full after:h-5 after:w-5 after:transition-all peer-checked:bg-primary-600\"></div></label></div><!-- Event Reminders --><div class=\"flex items-center justify-between\"><div class=\"flex-1\"><h3 class=\"text-sm font-medium text-gray-900\">Event Reminders</h3><p class=\"text-sm text-gray-500\">Get reminded about upcoming events you have tickets for</p></div><label class=\"relative inline-flex items-center cursor-pointer\"><input type=\"checkbox\" name=\"event_reminders\" class=\"sr-only peer\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			if preferences.EventReminders {
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 11, " checked")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 20, " checked")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 12, "><div class=\"w-11 h-6 bg-gray-200 peer-focus:outline-none peer-focus:ring-4 peer-focus:ring-primary-300 rounded-full peer peer-checked:after:translate-x-full peer-checked:after:border-white after:content-[''] after:absolute after:top-[2px] after:left-[2px] after:bg-white after:border-gray-300 after:border after:rounded-full after:h-5 after:w-5 after:transition-all peer-checked:bg-primary-600\"></div></label></div><!-- Marketing Emails --><div class=\"flex items-center justify-between\"><div class=\"flex-1\"><h3 class=\"text-sm font-medium text-gray-900\">Marketing Emails</h3><p class=\"text-sm text-gray-500\">Receive promotional emails about new events and special offers</p></div><label class=\"relative inline-flex items-center cursor-pointer\"><input type=\"checkbox\" name=\"marketing_emails\" class=\"sr-only peer\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 21, "><div class=\"w-11 h-6 bg-gray-200 peer-focus:outline-none peer-focus:ring-4 peer-focus:ring-primary-300 rounded-full peer peer-checked:after:translate-x-full peer-checked:after:border-white after:content-[''] after:absolute after:top-[2px] after:left-[2px] after:bg-white after:border-gray-300 after:border after:rounded-full after:h-5 after:w-5 after:transition-all peer-checked:bg-primary-600\"></div></label></div><!-- Marketing Emails --><div class=\"flex items-center justify-between\"><div class=\"flex-1\"><h3 class=\"text-sm font-medium text-gray-900\">Marketing Emails</h3><p class=\"text-sm text-gray-500\">Receive promotional emails about new events and special offers</p></div><label class=\"relative inline-flex items-center cursor-pointer\"><input type=\"checkbox\" name=\"marketing_emails\" class=\"sr-only peer\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			if preferences.MarketingEmails {
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 13, " checked")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 22, " checked")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 14, "><div class=\"w-11 h-6 bg-gray-200 peer-focus:outline-none peer-focus:ring-4 peer-focus:ring-primary-300 rounded-full peer peer-checked:after:translate-x-full peer-checked:after:border-white after:content-[''] after:absolute after:top-[2px] after:left-[2px] after:bg-white after:border-gray-300 after:border after:rounded-full after:h-5 after:w-5 after:transition-all peer-checked:bg-primary-600\"></div></label></div><!-- SMS Notifications --><div class=\"flex items-center justify-between\"><div class=\"flex-1\"><h3 class=\"text-sm font-medium text-gray-900\">SMS Notifications</h3><p class=\"text-sm text-gray-500\">Receive text messages for urgent updates and reminders</p></div><label class=\"relative inline-flex items-center cursor-pointer\"><input type=\"checkbox\" name=\"sms_notifications\" class=\"sr-only peer\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 23, "><div class=\"w-11 h-6 bg-gray-200 peer-focus:outline-none peer-focus:ring-4 peer-focus:ring-primary-300 rounded-full peer peer-checked:after:translate-x-full peer-checked:after:border-white after:content-[''] after:absolute after:top-[2px] after:left-[2px] after:bg-white after:border-gray-300 after:border after:rounded-full after:h-5 after:w-5 after:transition-all peer-checked:bg-primary-600\"></div></label></div><!-- SMS Notifications --><div class=\"flex items-center justify-between\"><div class=\"flex-1\"><h3 class=\"text-sm font-medium text-gray-900\">SMS Notifications</h3><p class=\"text-sm text-gray-500\">Receive text messages for urgent updates and reminders</p></div><label class=\"relative inline-flex items-center cursor-pointer\"><input type=\"checkbox\" name=\"sms_notifications\" class=\"sr-only peer\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			if preferences.SMSNotifications {
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 15, " checked")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 24, " checked")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 16, "><div class=\"w-11 h-6 bg-gray-200 peer-focus:outline-none peer-focus:ring-4 peer-focus:ring-primary-300 rounded-full peer peer-checked:after:translate-x-full peer-checked:after:border-white after:content-[''] after:absolute after:top-[2px] after:left-[2px] after:bg-white after:border-gray-300 after:border after:rounded-full after:h-5 after:w-5 after:transition-all peer-checked:bg-primary-600\"></div></label></div><!-- Newsletter Subscription --><div class=\"flex items-center justify-between\"><div class=\"flex-1\"><h3 class=\"text-sm font-medium text-gray-900\">Newsletter Subscription</h3><p class=\"text-sm text-gray-500\">Subscribe to our monthly newsletter with event highlights and tips</p></div><label class=\"relative inline-flex items-center cursor-pointer\"><input type=\"checkbox\" name=\"newsletter_subscription\" class=\"sr-only peer\"")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 25, "><div class=\"w-11 h-6 bg-gray-200 peer-focus:outline-none peer-focus:ring-4 peer-focus:ring-primary-300 rounded-full peer peer-checked:after:translate-x-full peer-checked:after:border-white after:content-[''] after:absolute after:top-[2px] after:left-[2px] after:bg-white after:border-gray-300 after:border after:rounded-full after:h-5 after:w-5 after:transition-all peer-checked:bg-primary-600\"></div></label></div><!-- Newsletter Subscription --><div class=\"flex items-center justify-between\"><div class=\"flex-1\"><h3 class=\"text-sm font-medium text-gray-900\">Newsletter Subscription</h3><p class=\"text-sm text-gray-500\">Subscribe to our monthly newsletter with event highlights and tips</p></div><label class=\"relative inline-flex items-center cursor-pointer\"><input type=\"checkbox\" name=\"newsletter_subscription\" class=\"sr-only peer\"")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}
 			if preferences.NewsletterSubscription {
-				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 17, " checked")
+				templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 26, " checked")
 				if templ_7745c5c3_Err != nil {
 					return templ_7745c5c3_Err
 				}
 			}
-			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 18, "><div class=\"w-11 h-6 bg-gray-200 peer-focus:outline-none peer-focus:ring-4 peer-focus:ring-primary-300 rounded-full peer peer-checked:after:translate-x-full peer-checked:after:border-white after:content-[''] after:absolute after:top-[2px] after:left-[2px] after:bg-white after:border-gray-300 after:border after:rounded-full after:h-5 after:w-5 after:transition-all peer-checked:bg-primary-600\"></div></label></div></div><!-- Submit Button --><div class=\"mt-8 flex justify-end space-x-3\"><a href=\"/dashboard\" class=\"px-4 py-2 border border-gray-300 rounded-lg text-sm font-medium text-gray-700 hover:bg-gray-50 transition-colors\">Cancel</a> <button type=\"submit\" class=\"px-4 py-2 bg-primary-600 hover:bg-primary-700 text-white rounded-lg text-sm font-medium transition-colors\">Save Preferences</button></div></form></div><!-- Account Preferences --><div class=\"mt-8 bg-white rounded-lg shadow-sm border border-gray-200\"><div class=\"px-6 py-4 border-b border-gray-200\"><h2 class=\"text-lg font-medium text-gray-900\">Account Preferences</h2></div><div class=\"p-6\"><div class=\"space-y-4\"><div class=\"flex items-center justify-between py-3 border-b border-gray-200\"><div><h3 class=\"text-sm font-medium text-gray-900\">Language</h3><p class=\"text-sm text-gray-500\">Choose your preferred language</p></div><select class=\"text-sm border border-gray-300 rounded-lg px-3 py-1 focus:outline-none focus:ring-2 focus:ring-primary-500\"><option value=\"en\" selected>English</option> <option value=\"es\">Español</option> <option value=\"fr\">Français</option></select></div><div class=\"flex items-center justify-between py-3 border-b border-gray-200\"><div><h3 class=\"text-sm font-medium text-gray-900\">Timezone</h3><p class=\"text-sm text-gray-500\">Events will be displayed in your local time</p></div><select class=\"text-sm border border-gray-300 rounded-lg px-3 py-1 focus:outline-none focus:ring-2 focus:ring-primary-500\"><option value=\"UTC\" selected>UTC</option> <option value=\"America/New_York\">Eastern Time</option> <option value=\"America/Chicago\">Central Time</option> <option value=\"America/Denver\">Mountain Time</option> <option value=\"America/Los_Angeles\">Pacific Time</option></select></div><div class=\"flex items-center justify-between py-3\"><div><h3 class=\"text-sm font-medium text-gray-900\">Currency</h3><p class=\"text-sm text-gray-500\">Default currency for displaying prices</p></div><select class=\"text-sm border border-gray-300 rounded-lg px-3 py-1 focus:outline-none focus:ring-2 focus:ring-primary-500\"><option value=\"KES\" selected>KES (KSh)</option> <option value=\"USD\">USD ($)</option> <option value=\"EUR\">EUR (€)</option> <option value=\"GBP\">GBP (£)</option></select></div></div></div></div><!-- Privacy Settings --><div class=\"mt-8 bg-white rounded-lg shadow-sm border border-gray-200\"><div class=\"px-6 py-4 border-b border-gray-200\"><h2 class=\"text-lg font-medium text-gray-900\">Privacy Settings</h2></div><div class=\"p-6\"><div class=\"space-y-4\"><div class=\"flex items-center justify-between py-3 border-b border-gray-200\"><div><h3 class=\"text-sm font-medium text-gray-900\">Profile Visibility</h3><p class=\"text-sm text-gray-500\">Control who can see your profile information</p></div><select class=\"text-sm border border-gray-300 rounded-lg px-3 py-1 focus:outline-none focus:ring-2 focus:ring-primary-500\"><option value=\"private\" selected>Private</option> <option value=\"public\">Public</option> <option value=\"friends\">Friends Only</option></select></div><div class=\"flex items-center justify-between py-3\"><div><h3 class=\"text-sm font-medium text-gray-900\">Data Export</h3><p class=\"text-sm text-gray-500\">Download a copy of your account data</p></div><button class=\"text-primary-600 hover:text-primary-500 text-sm font-medium\">Request Export</button></div></div></div></div></div></div>")
+			templ_7745c5c3_Err = templruntime.WriteString(templ_7745c5c3_Buffer, 27, "><div class=\"w-11 h-6 bg-gray-200 peer-focus:outline-none peer-focus:ring-4 peer-focus:ring-primary-300 rounded-full peer peer-checked:after:translate-x-full peer-checked:after:border-white after:content-[''] after:absolute after:top-[2px] after:left-[2px] after:bg-white after:border-gray-300 after:border after:rounded-full after:h-5 after:w-5 after:transition-all peer-checked:bg-primary-600\"></div></label></div></div><!-- Submit Button --><div class=\"mt-8 flex justify-end space-x-3\"><a href=\"/dashboard\" class=\"px-4 py-2 border border-gray-300 rounded-lg text-sm font-medium text-gray-700 hover:bg-gray-50 transition-colors\">Cancel</a> <button type=\"submit\" class=\"px-4 py-2 bg-primary-600 hover:bg-primary-700 text-white rounded-lg text-sm font-medium transition-colors\">Save Preferences</button></div></form></div><!-- Account Preferences --><div class=\"mt-8 bg-white rounded-lg shadow-sm border border-gray-200\"><div class=\"px-6 py-4 border-b border-gray-200\"><h2 class=\"text-lg font-medium text-gray-900\">Account Preferences</h2></div><div class=\"p-6\"><div class=\"space-y-4\"><div class=\"flex items-center justify-between py-3 border-b border-gray-200\"><div><h3 class=\"text-sm font-medium text-gray-900\">Timezone</h3><p class=\"text-sm text-gray-500\">Events will be displayed in your local time</p></div><select class=\"text-sm border border-gray-300 rounded-lg px-3 py-1 focus:outline-none focus:ring-2 focus:ring-primary-500\"><option value=\"UTC\" selected>UTC</option> <option value=\"America/New_York\">Eastern Time</option> <option value=\"America/Chicago\">Central Time</option> <option value=\"America/Denver\">Mountain Time</option> <option value=\"America/Los_Angeles\">Pacific Time</option></select></div><div class=\"flex items-center justify-between py-3\"><div><h3 class=\"text-sm font-medium text-gray-900\">Currency</h3><p class=\"text-sm text-gray-500\">Default currency for displaying prices</p></div><select class=\"text-sm border border-gray-300 rounded-lg px-3 py-1 focus:outline-none focus:ring-2 focus:ring-primary-500\"><option value=\"KES\" selected>KES (KSh)</option> <option value=\"USD\">USD ($)</option> <option value=\"EUR\">EUR (€)</option> <option value=\"GBP\">GBP (£)</option></select></div></div></div></div><!-- Privacy Settings --><div class=\"mt-8 bg-white rounded-lg shadow-sm border border-gray-200\"><div class=\"px-6 py-4 border-b border-gray-200\"><h2 class=\"text-lg font-medium text-gray-900\">Privacy Settings</h2></div><div class=\"p-6\"><div class=\"space-y-4\"><div class=\"flex items-center justify-between py-3 border-b border-gray-200\"><div><h3 class=\"text-sm font-medium text-gray-900\">Profile Visibility</h3><p class=\"text-sm text-gray-500\">Control who can see your profile information</p></div><select class=\"text-sm border border-gray-300 rounded-lg px-3 py-1 focus:outline-none focus:ring-2 focus:ring-primary-500\"><option value=\"private\" selected>Private</option> <option value=\"public\">Public</option> <option value=\"friends\">Friends Only</option></select></div><div class=\"flex items-center justify-between py-3\"><div><h3 class=\"text-sm font-medium text-gray-900\">Data Export</h3><p class=\"text-sm text-gray-500\">Download a copy of your account data</p></div><button class=\"text-primary-600 hover:text-primary-500 text-sm font-medium\">Request Export</button></div></div></div></div></div></div>")
 			if templ_7745c5c3_Err != nil {
 				return templ_7745c5c3_Err
 			}